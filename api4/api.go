@@ -50,6 +50,14 @@ type Routes struct {
 	ChannelMembersForUser    *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/teams/{team_id:[A-Za-z0-9]+}/channels/members'
 	ChannelModerations       *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/moderations'
 	ChannelCategories        *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/teams/{team_id:[A-Za-z0-9]+}/channels/categories'
+	ChannelBookmarks         *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/bookmarks'
+	ChannelBookmark          *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/bookmarks/{bookmark_id:[A-Za-z0-9]+}'
+	ChannelEvents            *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/events'
+	ChannelEvent             *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/events/{event_id:[A-Za-z0-9]+}'
+	ChannelJoinRequests      *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/join_requests'
+	ChannelJoinRequest       *mux.Router // 'api/v4/channels/{channel_id:[A-Za-z0-9]+}/join_requests/{join_request_id:[A-Za-z0-9]+}'
+	TeamInviteLinks          *mux.Router // 'api/v4/teams/{team_id:[A-Za-z0-9]+}/invite_links'
+	TeamInviteLink           *mux.Router // 'api/v4/teams/{team_id:[A-Za-z0-9]+}/invite_links/{invite_link_id:[A-Za-z0-9]+}'
 
 	Posts           *mux.Router // 'api/v4/posts'
 	Post            *mux.Router // 'api/v4/posts/{post_id:[A-Za-z0-9]+}'
@@ -81,6 +89,9 @@ type Routes struct {
 	OAuthApps *mux.Router // 'api/v4/oauth/apps'
 	OAuthApp  *mux.Router // 'api/v4/oauth/apps/{app_id:[A-Za-z0-9]+}'
 
+	OutgoingOAuthConnections *mux.Router // 'api/v4/oauth/outgoing_connections'
+	OutgoingOAuthConnection  *mux.Router // 'api/v4/oauth/outgoing_connections/{outgoing_oauth_connection_id:[A-Za-z0-9]+}'
+
 	OpenGraph *mux.Router // 'api/v4/opengraph'
 
 	SAML       *mux.Router // 'api/v4/saml'
@@ -139,6 +150,29 @@ type Routes struct {
 	InsightsForUser *mux.Router // 'api/v4/users/me/top'
 
 	Usage *mux.Router // 'api/v4/usage'
+
+	TeamTemplates *mux.Router // 'api/v4/team_templates'
+	TeamTemplate  *mux.Router // 'api/v4/team_templates/{team_template_id:[A-Za-z0-9]+}'
+
+	Onboarding     *mux.Router // 'api/v4/onboarding'
+	OnboardingTask *mux.Router // 'api/v4/onboarding/tasks/{onboarding_task_id:[a-z0-9_]+}'
+
+	Impersonation *mux.Router // 'api/v4/impersonation/{impersonation_id:[A-Za-z0-9]+}'
+
+	PostReports *mux.Router // 'api/v4/post_reports'
+	PostReport  *mux.Router // 'api/v4/post_reports/{report_id:[A-Za-z0-9]+}'
+
+	Reminders *mux.Router // 'api/v4/reminders'
+	Reminder  *mux.Router // 'api/v4/reminders/{reminder_id:[A-Za-z0-9]+}'
+
+	Turn *mux.Router // 'api/v4/turn'
+
+	PostPropSchemas *mux.Router // 'api/v4/post_prop_schemas'
+	PostPropSchema  *mux.Router // 'api/v4/post_prop_schemas/{namespace:[a-z0-9_.-]+}'
+
+	Markdown *mux.Router // 'api/v4/markdown'
+
+	Scim *mux.Router // 'api/scim/v2'
 }
 
 type API struct {
@@ -187,6 +221,14 @@ func Init(srv *app.Server) (*API, error) {
 	api.BaseRoutes.ChannelMembersForUser = api.BaseRoutes.User.PathPrefix("/teams/{team_id:[A-Za-z0-9]+}/channels/members").Subrouter()
 	api.BaseRoutes.ChannelModerations = api.BaseRoutes.Channel.PathPrefix("/moderations").Subrouter()
 	api.BaseRoutes.ChannelCategories = api.BaseRoutes.User.PathPrefix("/teams/{team_id:[A-Za-z0-9]+}/channels/categories").Subrouter()
+	api.BaseRoutes.ChannelBookmarks = api.BaseRoutes.Channel.PathPrefix("/bookmarks").Subrouter()
+	api.BaseRoutes.ChannelBookmark = api.BaseRoutes.ChannelBookmarks.PathPrefix("/{bookmark_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.ChannelEvents = api.BaseRoutes.Channel.PathPrefix("/events").Subrouter()
+	api.BaseRoutes.ChannelEvent = api.BaseRoutes.ChannelEvents.PathPrefix("/{event_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.ChannelJoinRequests = api.BaseRoutes.Channel.PathPrefix("/join_requests").Subrouter()
+	api.BaseRoutes.ChannelJoinRequest = api.BaseRoutes.ChannelJoinRequests.PathPrefix("/{join_request_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.TeamInviteLinks = api.BaseRoutes.Team.PathPrefix("/invite_links").Subrouter()
+	api.BaseRoutes.TeamInviteLink = api.BaseRoutes.TeamInviteLinks.PathPrefix("/{invite_link_id:[A-Za-z0-9]+}").Subrouter()
 
 	api.BaseRoutes.Posts = api.BaseRoutes.APIRoot.PathPrefix("/posts").Subrouter()
 	api.BaseRoutes.Post = api.BaseRoutes.Posts.PathPrefix("/{post_id:[A-Za-z0-9]+}").Subrouter()
@@ -218,6 +260,8 @@ func Init(srv *app.Server) (*API, error) {
 	api.BaseRoutes.OAuth = api.BaseRoutes.APIRoot.PathPrefix("/oauth").Subrouter()
 	api.BaseRoutes.OAuthApps = api.BaseRoutes.OAuth.PathPrefix("/apps").Subrouter()
 	api.BaseRoutes.OAuthApp = api.BaseRoutes.OAuthApps.PathPrefix("/{app_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.OutgoingOAuthConnections = api.BaseRoutes.OAuth.PathPrefix("/outgoing_connections").Subrouter()
+	api.BaseRoutes.OutgoingOAuthConnection = api.BaseRoutes.OutgoingOAuthConnections.PathPrefix("/{outgoing_oauth_connection_id:[A-Za-z0-9]+}").Subrouter()
 
 	api.BaseRoutes.Compliance = api.BaseRoutes.APIRoot.PathPrefix("/compliance").Subrouter()
 	api.BaseRoutes.Cluster = api.BaseRoutes.APIRoot.PathPrefix("/cluster").Subrouter()
@@ -265,6 +309,29 @@ func Init(srv *app.Server) (*API, error) {
 
 	api.BaseRoutes.Usage = api.BaseRoutes.APIRoot.PathPrefix("/usage").Subrouter()
 
+	api.BaseRoutes.TeamTemplates = api.BaseRoutes.APIRoot.PathPrefix("/team_templates").Subrouter()
+	api.BaseRoutes.TeamTemplate = api.BaseRoutes.TeamTemplates.PathPrefix("/{team_template_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.Onboarding = api.BaseRoutes.APIRoot.PathPrefix("/onboarding").Subrouter()
+	api.BaseRoutes.OnboardingTask = api.BaseRoutes.Onboarding.PathPrefix("/tasks/{onboarding_task_id:[a-z0-9_]+}").Subrouter()
+
+	api.BaseRoutes.Impersonation = api.BaseRoutes.APIRoot.PathPrefix("/impersonation/{impersonation_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.PostReports = api.BaseRoutes.APIRoot.PathPrefix("/post_reports").Subrouter()
+	api.BaseRoutes.PostReport = api.BaseRoutes.PostReports.PathPrefix("/{report_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.Reminders = api.BaseRoutes.APIRoot.PathPrefix("/reminders").Subrouter()
+	api.BaseRoutes.Reminder = api.BaseRoutes.Reminders.PathPrefix("/{reminder_id:[A-Za-z0-9]+}").Subrouter()
+
+	api.BaseRoutes.Turn = api.BaseRoutes.APIRoot.PathPrefix("/turn").Subrouter()
+
+	api.BaseRoutes.PostPropSchemas = api.BaseRoutes.APIRoot.PathPrefix("/post_prop_schemas").Subrouter()
+	api.BaseRoutes.PostPropSchema = api.BaseRoutes.PostPropSchemas.PathPrefix("/{namespace:[a-z0-9_.-]+}").Subrouter()
+
+	api.BaseRoutes.Markdown = api.BaseRoutes.APIRoot.PathPrefix("/markdown").Subrouter()
+
+	api.BaseRoutes.Scim = api.BaseRoutes.Root.PathPrefix(model.ScimURLSuffix).Subrouter()
+
 	api.InitUser()
 	api.InitBot()
 	api.InitTeam()
@@ -273,6 +340,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitFile()
 	api.InitUpload()
 	api.InitSystem()
+	api.InitHealth()
 	api.InitLicense()
 	api.InitConfig()
 	api.InitWebhook()
@@ -308,6 +376,26 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitExport()
 	api.InitInsights()
 	api.InitUsage()
+	api.InitTeamTemplate()
+	api.InitOnboardingTask()
+	api.InitImpersonation()
+	api.InitChannelMemberTimeout()
+	api.InitChannelSlowmode()
+	api.InitDirectMessageRequest()
+	api.InitReminder()
+	api.InitChannelBookmark()
+	api.InitTurn()
+	api.InitPostPropSchema()
+	api.InitMarkdown()
+	api.InitChannelEvent()
+	api.InitScheduledConfigChange()
+	api.InitPostReport()
+	api.InitPostRetentionLabel()
+	api.InitOutgoingOAuthConnection()
+	api.InitChannelJoinRequest()
+	api.InitTeamInviteLink()
+	api.InitDeprecation()
+	api.InitScim()
 	if err := api.InitGraphQL(); err != nil {
 		return nil, err
 	}
@@ -385,6 +473,8 @@ func InitLocal(srv *app.Server) *API {
 
 	api.BaseRoutes.Jobs = api.BaseRoutes.APIRoot.PathPrefix("/jobs").Subrouter()
 
+	api.BaseRoutes.Compliance = api.BaseRoutes.APIRoot.PathPrefix("/compliance").Subrouter()
+
 	api.BaseRoutes.SAML = api.BaseRoutes.APIRoot.PathPrefix("/saml").Subrouter()
 
 	api.InitUserLocal()
@@ -405,6 +495,7 @@ func InitLocal(srv *app.Server) *API {
 	api.InitImportLocal()
 	api.InitExportLocal()
 	api.InitJobLocal()
+	api.InitComplianceLocal()
 	api.InitSamlLocal()
 
 	srv.LocalRouter.Handle("/api/v4/{anything:.*}", http.HandlerFunc(api.Handle404))
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// MaxBulkItems is the maximum number of items of a request to a batch endpoint.
+const MaxBulkItems = 256
+
+// checkBulkItems sets the context error unless the number of items of a batch request is between
+// 1 and MaxBulkItems.
+func checkBulkItems(c *Context, count int) bool {
+	if count == 0 {
+		c.SetInvalidParam("no items in batch")
+		return false
+	}
+
+	if count > MaxBulkItems {
+		c.SetInvalidParam("too many items in batch")
+		return false
+	}
+
+	return true
+}
+
+// writeBulkResponse writes the response of a batch endpoint, whose status depends on the results
+// of its items.
+func writeBulkResponse(c *Context, w http.ResponseWriter, resp *model.BulkResponse) {
+	for _, result := range resp.Results {
+		if result.Error != nil {
+			c.PrepareBulkItemError(result.Error)
+			result.StatusCode = result.Error.StatusCode
+		}
+	}
+
+	w.WriteHeader(resp.StatusCode())
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -6,6 +6,8 @@ package api4
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"hash/crc32"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,6 +16,8 @@ import (
 	"github.com/mattermost/mattermost-server/v6/audit"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/utils"
+	"github.com/mattermost/mattermost-server/v6/web"
 )
 
 func (api *API) InitChannel() {
@@ -54,6 +58,7 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.Channel.Handle("", api.APISessionRequired(deleteChannel)).Methods("DELETE")
 	api.BaseRoutes.Channel.Handle("/stats", api.APISessionRequired(getChannelStats)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/pinned", api.APISessionRequired(getPinnedPosts)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/pins/order", api.APISessionRequired(updatePinnedPostsOrder)).Methods("PUT")
 	api.BaseRoutes.Channel.Handle("/timezones", api.APISessionRequired(getChannelMembersTimezones)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/members_minus_group_members", api.APISessionRequired(channelMembersMinusGroupMembers)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/move", api.APISessionRequired(moveChannel)).Methods("POST")
@@ -67,6 +72,9 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.ChannelMembers.Handle("", api.APISessionRequired(getChannelMembers)).Methods("GET")
 	api.BaseRoutes.ChannelMembers.Handle("/ids", api.APISessionRequired(getChannelMembersByIds)).Methods("POST")
 	api.BaseRoutes.ChannelMembers.Handle("", api.APISessionRequired(addChannelMember)).Methods("POST")
+	api.BaseRoutes.ChannelMembers.Handle("/batch", api.APISessionRequired(addChannelMembers)).Methods("POST")
+	api.BaseRoutes.ChannelMembers.Handle("/bulk", api.APISessionRequired(bulkUpdateChannelMembers)).Methods("POST")
+	api.BaseRoutes.ChannelMembers.Handle("/bulk/{job_id:[A-Za-z0-9]+}", api.APISessionRequired(getBulkChannelMembersReport)).Methods("GET")
 	api.BaseRoutes.ChannelMembersForUser.Handle("", api.APISessionRequired(getChannelMembersForTeamForUser)).Methods("GET")
 	api.BaseRoutes.ChannelMember.Handle("", api.APISessionRequired(getChannelMember)).Methods("GET")
 	api.BaseRoutes.ChannelMember.Handle("", api.APISessionRequired(removeChannelMember)).Methods("DELETE")
@@ -677,7 +685,7 @@ func getPinnedPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if c.HandleEtag(posts.Etag(), "Get Pinned Posts", w, r) {
+	if c.HandleEtag(pinnedPostsEtag(posts), "Get Pinned Posts", w, r) {
 		return
 	}
 
@@ -688,7 +696,56 @@ func getPinnedPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set(model.HeaderEtagServer, clientPostList.Etag())
+	w.Header().Set(model.HeaderEtagServer, pinnedPostsEtag(clientPostList))
+	if err := clientPostList.EncodeJSON(w); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// pinnedPostsEtag returns the etag of the pinned posts of a channel, which changes with their
+// order as well.
+func pinnedPostsEtag(posts *model.PostList) string {
+	return model.Etag(posts.Etag(), crc32.ChecksumIEEE([]byte(strings.Join(posts.Order, ","))))
+}
+
+func updatePinnedPostsOrder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var postIds []string
+	if jsonErr := json.NewDecoder(r.Body).Decode(&postIds); jsonErr != nil {
+		c.SetInvalidParam("post_ids")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updatePinnedPostsOrder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("post_ids", postIds)
+
+	// Like pinning posts, ordering them only requires being able to read the channel.
+	if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannel) {
+		c.SetPermissionError(model.PermissionReadChannel)
+		return
+	}
+
+	posts, err := c.App.SetPinnedPostsOrder(c.Params.ChannelId, postIds)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	clientPostList := c.App.PreparePostListForClient(posts)
+	clientPostList, err = c.App.SanitizePostListMetadataForUser(clientPostList, c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
 	if err := clientPostList.EncodeJSON(w); err != nil {
 		mlog.Warn("Error while writing response", mlog.Err(err))
 	}
@@ -1726,6 +1783,191 @@ func addChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// sessionHasPermissionToManageChannelMembers checks the permission needed to add or remove other
+// users from the channel, setting the context error when it is missing.
+func sessionHasPermissionToManageChannelMembers(c *Context, channel *model.Channel) bool {
+	switch channel.Type {
+	case model.ChannelTypeOpen:
+		if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), channel.Id, model.PermissionManagePublicChannelMembers) {
+			c.SetPermissionError(model.PermissionManagePublicChannelMembers)
+			return false
+		}
+	case model.ChannelTypePrivate:
+		if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), channel.Id, model.PermissionManagePrivateChannelMembers) {
+			c.SetPermissionError(model.PermissionManagePrivateChannelMembers)
+			return false
+		}
+	default:
+		c.Err = model.NewAppError("sessionHasPermissionToManageChannelMembers", "api.channel.add_user_to_channel.type.app_error", nil, "", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// addChannelMembers adds several users to the channel, each independently of the others.
+func addChannelMembers(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var batch struct {
+		UserIds []string `json:"user_ids"`
+	}
+	if jsonErr := json.NewDecoder(r.Body).Decode(&batch); jsonErr != nil {
+		c.SetInvalidParam("user_ids")
+		return
+	}
+
+	if !checkBulkItems(c, len(batch.UserIds)) {
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("addChannelMembers", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel", channel)
+	auditRec.AddMeta("user_ids", batch.UserIds)
+
+	if !sessionHasPermissionToManageChannelMembers(c, channel) {
+		return
+	}
+
+	var nonMembers []string
+	if channel.IsGroupConstrained() {
+		var nErr error
+		nonMembers, nErr = c.App.FilterNonGroupChannelMembers(batch.UserIds, channel)
+		if nErr != nil {
+			if v, ok := nErr.(*model.AppError); ok {
+				c.Err = v
+			} else {
+				c.Err = model.NewAppError("addChannelMembers", "api.channel.add_members.error", nil, nErr.Error(), http.StatusBadRequest)
+			}
+			return
+		}
+	}
+
+	resp := model.NewBulkResponse(len(batch.UserIds))
+	for _, userID := range batch.UserIds {
+		if !model.IsValidId(userID) {
+			resp.AddError(userID, web.NewInvalidParamError("user_id"))
+			continue
+		}
+
+		if utils.StringInSlice(userID, nonMembers) {
+			resp.AddError(userID, model.NewAppError("addChannelMembers", "api.channel.add_members.user_denied", map[string]interface{}{"UserIDs": []string{userID}}, "", http.StatusBadRequest))
+			continue
+		}
+
+		cm, err := c.App.AddChannelMember(c.AppContext, userID, channel, app.ChannelMemberOpts{
+			UserRequestorID: c.AppContext.Session().UserId,
+		})
+		if err != nil {
+			resp.AddError(userID, err)
+			continue
+		}
+
+		resp.AddSuccess(userID, http.StatusCreated, cm)
+	}
+
+	auditRec.AddMeta("succeeded", resp.Succeeded)
+	auditRec.AddMeta("failed", resp.Failed)
+	if resp.Succeeded > 0 {
+		auditRec.Success()
+		c.LogAudit(fmt.Sprintf("name=%s added=%d", channel.Name, resp.Succeeded))
+	}
+
+	writeBulkResponse(c, w, resp)
+}
+
+func bulkUpdateChannelMembers(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var bulkRequest model.ChannelMembersBulkRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&bulkRequest); jsonErr != nil {
+		c.SetInvalidParam("members")
+		return
+	}
+
+	if err := bulkRequest.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("bulkUpdateChannelMembers", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel", channel)
+	auditRec.AddMeta("action", bulkRequest.Action)
+	auditRec.AddMeta("user_count", len(bulkRequest.UserIds))
+
+	if !sessionHasPermissionToManageChannelMembers(c, channel) {
+		return
+	}
+
+	job, err := c.App.CreateBulkChannelMembersJob(channel, c.AppContext.Session().UserId, &bulkRequest)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("job", job)
+	c.LogAudit("name=" + channel.Name + " job_id=" + job.Id)
+
+	report, err := c.App.GetBulkChannelMembersReport(channel.Id, job.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getBulkChannelMembersReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !sessionHasPermissionToManageChannelMembers(c, channel) {
+		return
+	}
+
+	report, err := c.App.GetBulkChannelMembersReport(channel.Id, c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func removeChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId().RequireUserId()
 	if c.Err != nil {
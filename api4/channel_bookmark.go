@@ -0,0 +1,247 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitChannelBookmark() {
+	api.BaseRoutes.ChannelBookmarks.Handle("", api.APISessionRequired(getChannelBookmarks)).Methods("GET")
+	api.BaseRoutes.ChannelBookmarks.Handle("", api.APISessionRequired(createChannelBookmark)).Methods("POST")
+	api.BaseRoutes.ChannelBookmark.Handle("", api.APISessionRequired(patchChannelBookmark)).Methods("PATCH")
+	api.BaseRoutes.ChannelBookmark.Handle("/sort_order", api.APISessionRequired(updateChannelBookmarkSortOrder)).Methods("POST")
+	api.BaseRoutes.ChannelBookmark.Handle("", api.APISessionRequired(deleteChannelBookmark)).Methods("DELETE")
+}
+
+func getChannelBookmarks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannel) {
+		c.SetPermissionError(model.PermissionReadChannel)
+		return
+	}
+
+	bookmarks, appErr := c.App.GetChannelBookmarks(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(bookmarks); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// checkChannelBookmarkPermission sets the error of the context if the user of the session can't
+// manage the bookmarks of the channel of the request. Managing bookmarks requires the permission
+// to manage the properties of the channel, or being a member of a direct or group message.
+func checkChannelBookmarkPermission(c *Context) {
+	checkManageChannelPropertiesPermission(c, "checkChannelBookmarkPermission", "api.channel_bookmark.forbidden.app_error")
+}
+
+// checkManageChannelPropertiesPermission sets the error of the context if the user of the session
+// can't manage the properties of the channel of the request, or isn't a member of it for a direct
+// or group message. Members of other channels are denied with the given error.
+func checkManageChannelPropertiesPermission(c *Context, where, forbiddenErrorID string) {
+	channel, appErr := c.App.GetChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	switch channel.Type {
+	case model.ChannelTypeOpen:
+		if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), channel.Id, model.PermissionManagePublicChannelProperties) {
+			c.SetPermissionError(model.PermissionManagePublicChannelProperties)
+		}
+
+	case model.ChannelTypePrivate:
+		if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), channel.Id, model.PermissionManagePrivateChannelProperties) {
+			c.SetPermissionError(model.PermissionManagePrivateChannelProperties)
+		}
+
+	case model.ChannelTypeGroup, model.ChannelTypeDirect:
+		if _, appErr := c.App.GetChannelMember(context.Background(), channel.Id, c.AppContext.Session().UserId); appErr != nil {
+			c.Err = model.NewAppError(where, forbiddenErrorID, nil, "", http.StatusForbidden)
+		}
+
+	default:
+		c.Err = model.NewAppError(where, forbiddenErrorID, nil, "", http.StatusForbidden)
+	}
+}
+
+// getChannelBookmarkForRequest returns the bookmark of the request, which must belong to the
+// channel of the request.
+func getChannelBookmarkForRequest(c *Context) *model.ChannelBookmark {
+	bookmark, appErr := c.App.GetChannelBookmark(c.Params.BookmarkId, false)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	if bookmark.ChannelId != c.Params.ChannelId {
+		c.Err = model.NewAppError("getChannelBookmarkForRequest", "app.channel_bookmark.get.not_found.app_error", nil, "bookmark_id="+bookmark.Id, http.StatusNotFound)
+		return nil
+	}
+
+	return bookmark
+}
+
+func createChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var bookmark model.ChannelBookmark
+	if jsonErr := json.NewDecoder(r.Body).Decode(&bookmark); jsonErr != nil {
+		c.SetInvalidParam("bookmark")
+		return
+	}
+	bookmark.ChannelId = c.Params.ChannelId
+	bookmark.OwnerId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	checkChannelBookmarkPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	created, appErr := c.App.CreateChannelBookmark(&bookmark)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("bookmark", created)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.ChannelBookmarkPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParam("bookmark")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+
+	checkChannelBookmarkPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	bookmark := getChannelBookmarkForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	patched, appErr := c.App.PatchChannelBookmark(bookmark, &patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(patched); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateChannelBookmarkSortOrder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	var newIndex int64
+	if jsonErr := json.NewDecoder(r.Body).Decode(&newIndex); jsonErr != nil {
+		c.SetInvalidParam("sort_order")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateChannelBookmarkSortOrder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+	auditRec.AddMeta("sort_order", newIndex)
+
+	checkChannelBookmarkPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	changed, appErr := c.App.UpdateChannelBookmarkSortOrder(c.Params.BookmarkId, c.Params.ChannelId, newIndex)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(changed); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteChannelBookmark(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireBookmarkId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteChannelBookmark", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("bookmark_id", c.Params.BookmarkId)
+
+	checkChannelBookmarkPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	bookmark := getChannelBookmarkForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	deleted, appErr := c.App.DeleteChannelBookmark(bookmark)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(deleted); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestChannelBookmarks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channelId := th.BasicChannel.Id
+	newLink := func(displayName string) *model.ChannelBookmark {
+		return &model.ChannelBookmark{
+			ChannelId:   channelId,
+			DisplayName: displayName,
+			Type:        model.ChannelBookmarkLink,
+			LinkUrl:     "https://example.com/" + displayName,
+		}
+	}
+
+	t.Run("create, list, reorder and delete", func(t *testing.T) {
+		webSocketClient, err := th.CreateWebSocketClient()
+		require.NoError(t, err)
+		webSocketClient.Listen()
+		defer webSocketClient.Close()
+
+		first, resp, err := th.Client.CreateChannelBookmark(newLink("first"))
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, first.OwnerId)
+		assert.Equal(t, int64(0), first.SortOrder)
+
+		timeout := time.After(5 * time.Second)
+		waiting := true
+		for waiting {
+			select {
+			case event := <-webSocketClient.EventChannel:
+				if event.EventType() == model.WebsocketEventChannelBookmarkCreated {
+					assert.Equal(t, channelId, event.GetBroadcast().ChannelId)
+					waiting = false
+				}
+			case <-timeout:
+				require.Fail(t, "Should have received the channel bookmark created websocket event")
+				waiting = false
+			}
+		}
+
+		second, _, err := th.Client.CreateChannelBookmark(newLink("second"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), second.SortOrder)
+
+		changed, _, err := th.Client.UpdateChannelBookmarkSortOrder(channelId, second.Id, 0)
+		require.NoError(t, err)
+		assert.Len(t, changed, 2)
+
+		bookmarks, _, err := th.Client.GetChannelBookmarks(channelId)
+		require.NoError(t, err)
+		require.Len(t, bookmarks, 2)
+		assert.Equal(t, second.Id, bookmarks[0].Id)
+		assert.Equal(t, first.Id, bookmarks[1].Id)
+
+		_, resp, err = th.Client.UpdateChannelBookmarkSortOrder(channelId, second.Id, 2)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		patched, _, err := th.Client.PatchChannelBookmark(channelId, first.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("renamed"), Emoji: model.NewString("books")})
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", patched.DisplayName)
+		assert.Equal(t, "books", patched.Emoji)
+
+		for _, bookmark := range []*model.ChannelBookmark{first, second} {
+			deleted, _, err := th.Client.DeleteChannelBookmark(channelId, bookmark.Id)
+			require.NoError(t, err)
+			assert.NotZero(t, deleted.DeleteAt)
+		}
+
+		bookmarks, _, err = th.Client.GetChannelBookmarks(channelId)
+		require.NoError(t, err)
+		assert.Empty(t, bookmarks)
+
+		_, resp, err = th.Client.DeleteChannelBookmark(channelId, first.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid bookmarks", func(t *testing.T) {
+		bookmark := newLink("bad")
+		bookmark.LinkUrl = "javascript:alert(1)"
+		_, resp, err := th.Client.CreateChannelBookmark(bookmark)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.Client.CreateChannelBookmark(&model.ChannelBookmark{ChannelId: channelId, DisplayName: "file", Type: model.ChannelBookmarkFile, FileId: model.NewId()})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("file bookmarks must be uploaded to the channel", func(t *testing.T) {
+		uploaded, _, err := th.Client.UploadFile([]byte("notes"), channelId, "notes.txt")
+		require.NoError(t, err)
+		fileId := uploaded.FileInfos[0].Id
+
+		bookmark, _, err := th.Client.CreateChannelBookmark(&model.ChannelBookmark{ChannelId: channelId, DisplayName: "notes", Type: model.ChannelBookmarkFile, FileId: fileId})
+		require.NoError(t, err)
+		assert.Equal(t, fileId, bookmark.FileId)
+
+		_, _, err = th.Client.DeleteChannelBookmark(channelId, bookmark.Id)
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.CreateChannelBookmark(&model.ChannelBookmark{ChannelId: th.BasicChannel2.Id, DisplayName: "notes", Type: model.ChannelBookmarkFile, FileId: fileId})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		bookmark, _, err := th.Client.CreateChannelBookmark(newLink("handbook"))
+		require.NoError(t, err)
+
+		th.RemovePermissionFromRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+
+		_, resp, err := th.Client.CreateChannelBookmark(newLink("nope"))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.PatchChannelBookmark(channelId, bookmark.Id, &model.ChannelBookmarkPatch{DisplayName: model.NewString("nope")})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.DeleteChannelBookmark(channelId, bookmark.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		bookmarks, _, err := th.Client.GetChannelBookmarks(channelId)
+		require.NoError(t, err)
+		assert.Len(t, bookmarks, 1)
+
+		_, _, err = th.SystemAdminClient.DeleteChannelBookmark(channelId, bookmark.Id)
+		require.NoError(t, err)
+
+		private := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		_, resp, err = th.Client.GetChannelBookmarks(private.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("direct messages members can manage bookmarks", func(t *testing.T) {
+		dm := th.CreateDmChannel(th.BasicUser2)
+		bookmark := newLink("shared")
+		bookmark.ChannelId = dm.Id
+
+		created, _, err := th.Client.CreateChannelBookmark(bookmark)
+		require.NoError(t, err)
+		assert.Equal(t, dm.Id, created.ChannelId)
+	})
+
+	t.Run("bookmarks are limited per channel", func(t *testing.T) {
+		channel := th.CreatePublicChannel()
+		for i := 0; i < model.MaxChannelBookmarksPerChannel; i++ {
+			bookmark := newLink("link")
+			bookmark.ChannelId = channel.Id
+			bookmark.OwnerId = th.BasicUser.Id
+			_, appErr := th.App.CreateChannelBookmark(bookmark)
+			require.Nil(t, appErr)
+		}
+
+		bookmark := newLink("one-too-many")
+		bookmark.ChannelId = channel.Id
+		_, resp, err := th.Client.CreateChannelBookmark(bookmark)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
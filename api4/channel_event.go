@@ -0,0 +1,197 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitChannelEvent() {
+	api.BaseRoutes.ChannelEvents.Handle("", api.APISessionRequired(getChannelEvents)).Methods("GET")
+	api.BaseRoutes.ChannelEvents.Handle("", api.APISessionRequired(createChannelEvent)).Methods("POST")
+	api.BaseRoutes.ChannelEvent.Handle("", api.APISessionRequired(getChannelEvent)).Methods("GET")
+	api.BaseRoutes.ChannelEvent.Handle("", api.APISessionRequired(patchChannelEvent)).Methods("PATCH")
+	api.BaseRoutes.ChannelEvent.Handle("", api.APISessionRequired(deleteChannelEvent)).Methods("DELETE")
+}
+
+func getChannelEvents(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannel) {
+		c.SetPermissionError(model.PermissionReadChannel)
+		return
+	}
+
+	events, appErr := c.App.GetChannelEventsForChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// checkChannelEventPermission sets the error of the context if the user of the session can't
+// manage the events of the channel of the request, which requires the same permissions as
+// managing its bookmarks.
+func checkChannelEventPermission(c *Context) {
+	checkManageChannelPropertiesPermission(c, "checkChannelEventPermission", "api.channel_event.forbidden.app_error")
+}
+
+// getChannelEventForRequest returns the event of the request, which must belong to the channel of
+// the request.
+func getChannelEventForRequest(c *Context) *model.ChannelEvent {
+	event, appErr := c.App.GetChannelEvent(c.Params.EventId)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	if event.ChannelId != c.Params.ChannelId {
+		c.Err = model.NewAppError("getChannelEventForRequest", "app.channel_event.get.not_found.app_error", nil, "event_id="+event.Id, http.StatusNotFound)
+		return nil
+	}
+
+	return event
+}
+
+func getChannelEvent(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireEventId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannel) {
+		c.SetPermissionError(model.PermissionReadChannel)
+		return
+	}
+
+	event := getChannelEventForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(event); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createChannelEvent(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var event model.ChannelEvent
+	if jsonErr := json.NewDecoder(r.Body).Decode(&event); jsonErr != nil {
+		c.SetInvalidParam("event")
+		return
+	}
+	event.ChannelId = c.Params.ChannelId
+	event.CreatorId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createChannelEvent", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	checkChannelEventPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	created, appErr := c.App.CreateChannelEvent(&event)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("event", created)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchChannelEvent(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireEventId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.ChannelEventPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParam("event")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchChannelEvent", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("event_id", c.Params.EventId)
+
+	checkChannelEventPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	event := getChannelEventForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	patched, appErr := c.App.PatchChannelEvent(event, &patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(patched); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteChannelEvent(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireEventId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteChannelEvent", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("event_id", c.Params.EventId)
+
+	checkChannelEventPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	event := getChannelEventForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.DeleteChannelEvent(event.Id); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
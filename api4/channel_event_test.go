@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestChannelEvents(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	tomorrow := time.Now().In(paris).AddDate(0, 0, 1)
+	startAt := model.GetMillisForTime(time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, paris))
+
+	newEvent := func() *model.ChannelEvent {
+		return &model.ChannelEvent{ChannelId: th.BasicChannel.Id, Title: "Standup", StartAt: startAt, Timezone: "Europe/Paris"}
+	}
+
+	t.Run("create, list, patch and delete", func(t *testing.T) {
+		event, resp, err := th.Client.CreateChannelEvent(newEvent())
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, event.CreatorId)
+		assert.Equal(t, startAt, event.NextStartAt)
+		assert.Equal(t, startAt, event.NotifyAt)
+
+		events, _, err := th.Client.GetChannelEvents(th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, event.Id, events[0].Id)
+
+		patched, _, err := th.Client.PatchChannelEvent(th.BasicChannel.Id, event.Id, &model.ChannelEventPatch{RemindBeforeMinutes: model.NewInt(15)})
+		require.NoError(t, err)
+		assert.Equal(t, startAt, patched.NextStartAt)
+		assert.Equal(t, startAt-15*60*1000, patched.NotifyAt)
+
+		_, resp, err = th.Client.GetChannelEvent(th.BasicChannel2.Id, event.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		_, err = th.Client.DeleteChannelEvent(th.BasicChannel.Id, event.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.Client.GetChannelEvent(th.BasicChannel.Id, event.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid events", func(t *testing.T) {
+		event := newEvent()
+		event.Timezone = "Mars/Olympus_Mons"
+		_, resp, err := th.Client.CreateChannelEvent(event)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		event = newEvent()
+		event.StartAt = model.GetMillis() - 1000
+		_, resp, err = th.Client.CreateChannelEvent(event)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		event = newEvent()
+		event.Recurrence = "hourly"
+		_, resp, err = th.Client.CreateChannelEvent(event)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("non-members cannot read or manage the events of a private channel", func(t *testing.T) {
+		channel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		event, _, err := th.SystemAdminClient.CreateChannelEvent(&model.ChannelEvent{ChannelId: channel.Id, Title: "Secret", StartAt: startAt, Timezone: "UTC"})
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.GetChannelEvents(channel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.CreateChannelEvent(&model.ChannelEvent{ChannelId: channel.Id, Title: "Mine", StartAt: startAt, Timezone: "UTC"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeleteChannelEvent(channel.Id, event.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("due events are announced in their timezone and rescheduled", func(t *testing.T) {
+		event := newEvent()
+		event.Recurrence = model.ChannelEventRecurrenceDaily
+		event, _, err := th.Client.CreateChannelEvent(event)
+		require.NoError(t, err)
+
+		announced, appErr := th.App.AnnounceDueChannelEvents(startAt)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, announced)
+
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, PerPage: 10})
+		require.Nil(t, appErr)
+		var announcement *model.Post
+		for _, post := range posts.Posts {
+			if post.GetProp("channel_event_id") == event.Id {
+				announcement = post
+			}
+		}
+		require.NotNil(t, announcement)
+		assert.Contains(t, announcement.Message, "Standup")
+		assert.Contains(t, announcement.Message, "09:00")
+
+		rescheduled, _, err := th.Client.GetChannelEvent(th.BasicChannel.Id, event.Id)
+		require.NoError(t, err)
+		next := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day()+1, 9, 0, 0, 0, paris)
+		assert.Equal(t, model.GetMillisForTime(next), rescheduled.NextStartAt)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableChannelEvents = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableChannelEvents = true })
+
+		_, resp, err := th.Client.CreateChannelEvent(newEvent())
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitChannelJoinRequest() {
+	api.BaseRoutes.ChannelJoinRequests.Handle("", api.APISessionRequired(getChannelJoinRequests)).Methods("GET")
+	api.BaseRoutes.ChannelJoinRequests.Handle("", api.APISessionRequired(requestToJoinChannel)).Methods("POST")
+	api.BaseRoutes.ChannelJoinRequest.Handle("/approve", api.APISessionRequired(approveChannelJoinRequest)).Methods("POST")
+	api.BaseRoutes.ChannelJoinRequest.Handle("/deny", api.APISessionRequired(denyChannelJoinRequest)).Methods("POST")
+	api.BaseRoutes.ChannelJoinRequest.Handle("", api.APISessionRequired(cancelChannelJoinRequest)).Methods("DELETE")
+}
+
+// getChannelJoinRequests returns the pending requests to join the channel to the users allowed to
+// review them, and only their own request to the other users.
+func getChannelJoinRequests(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	requests, appErr := c.App.GetChannelJoinRequests(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), c.Params.ChannelId, model.PermissionManagePrivateChannelMembers) {
+		own := []*model.ChannelJoinRequest{}
+		for _, joinRequest := range requests {
+			if joinRequest.UserId == c.AppContext.Session().UserId {
+				own = append(own, joinRequest)
+			}
+		}
+		requests = own
+	}
+
+	if err := json.NewEncoder(w).Encode(requests); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// Any member of the team of the channel can request to join it, which the app layer checks.
+
+func requestToJoinChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var joinRequest model.ChannelJoinRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&joinRequest); jsonErr != nil {
+		c.SetInvalidParam("join_request")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("requestToJoinChannel", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	created, appErr := c.App.RequestToJoinChannel(c.AppContext, c.Params.ChannelId, c.AppContext.Session().UserId, joinRequest.Message)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("join_request", created)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getChannelJoinRequestForRequest returns the join request of the request, which must be for the
+// channel of the request.
+func getChannelJoinRequestForRequest(c *Context) *model.ChannelJoinRequest {
+	joinRequest, appErr := c.App.GetChannelJoinRequest(c.Params.JoinRequestId)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	if joinRequest.ChannelId != c.Params.ChannelId {
+		c.Err = model.NewAppError("getChannelJoinRequestForRequest", "app.channel_join_request.not_found.app_error", nil, "join_request_id="+joinRequest.Id, http.StatusNotFound)
+		return nil
+	}
+
+	return joinRequest
+}
+
+func approveChannelJoinRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	reviewChannelJoinRequest(c, w, "approveChannelJoinRequest", c.App.ApproveChannelJoinRequest)
+}
+
+func denyChannelJoinRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	reviewChannelJoinRequest(c, w, "denyChannelJoinRequest", c.App.DenyChannelJoinRequest)
+}
+
+// reviewChannelJoinRequest approves or denies the join request of the request with the given app
+// function, which requires the permission to manage the members of the channel.
+func reviewChannelJoinRequest(c *Context, w http.ResponseWriter, event string, review func(*request.Context, *model.ChannelJoinRequest, string) (*model.ChannelJoinRequest, *model.AppError)) {
+	c.RequireChannelId().RequireJoinRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(event, audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("join_request_id", c.Params.JoinRequestId)
+
+	if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), c.Params.ChannelId, model.PermissionManagePrivateChannelMembers) {
+		c.SetPermissionError(model.PermissionManagePrivateChannelMembers)
+		return
+	}
+
+	joinRequest := getChannelJoinRequestForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	reviewed, appErr := review(c.AppContext, joinRequest, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("join_request", reviewed)
+
+	if err := json.NewEncoder(w).Encode(reviewed); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// Only the user of a join request can cancel it, which the app layer checks.
+
+func cancelChannelJoinRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireJoinRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("cancelChannelJoinRequest", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("join_request_id", c.Params.JoinRequestId)
+
+	joinRequest := getChannelJoinRequestForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.CancelChannelJoinRequest(joinRequest, c.AppContext.Session().UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestChannelJoinRequests(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// Only the creator of the channel, its admin, is a member of it.
+	channel := th.BasicPrivateChannel2
+
+	user2Client := th.CreateClient()
+	_, _, err := user2Client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+	require.NoError(t, err)
+
+	other := th.CreateUser()
+	th.LinkUserToTeam(other, th.BasicTeam)
+	otherClient := th.CreateClient()
+	_, _, err = otherClient.Login(other.Email, other.Password)
+	require.NoError(t, err)
+
+	t.Run("only private channels can be requested", func(t *testing.T) {
+		_, resp, err := th.Client.RequestToJoinChannel(th.BasicChannel.Id, "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("only team members can request", func(t *testing.T) {
+		stranger := th.CreateUser()
+		strangerClient := th.CreateClient()
+		_, _, err := strangerClient.Login(stranger.Email, stranger.Password)
+		require.NoError(t, err)
+
+		_, resp, err := strangerClient.RequestToJoinChannel(channel.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	var joinRequest *model.ChannelJoinRequest
+	t.Run("request", func(t *testing.T) {
+		var resp *model.Response
+		joinRequest, resp, err = user2Client.RequestToJoinChannel(channel.Id, "I work on this project")
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser2.Id, joinRequest.UserId)
+		assert.Equal(t, "I work on this project", joinRequest.Message)
+		assert.Equal(t, model.ChannelJoinRequestStatusPending, joinRequest.Status)
+
+		again, _, err := user2Client.RequestToJoinChannel(channel.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, joinRequest.Id, again.Id, "the pending request should be returned")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		requests, _, err := th.Client.GetChannelJoinRequests(channel.Id)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, joinRequest.Id, requests[0].Id)
+
+		requests, _, err = user2Client.GetChannelJoinRequests(channel.Id)
+		require.NoError(t, err)
+		require.Len(t, requests, 1, "the requester should see their own request")
+
+		requests, _, err = otherClient.GetChannelJoinRequests(channel.Id)
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("only channel admins can review", func(t *testing.T) {
+		_, resp, err := user2Client.ApproveChannelJoinRequest(channel.Id, joinRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = otherClient.DenyChannelJoinRequest(channel.Id, joinRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("the request must be for the channel", func(t *testing.T) {
+		_, resp, err := th.Client.ApproveChannelJoinRequest(th.BasicPrivateChannel.Id, joinRequest.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		approved, _, err := th.Client.ApproveChannelJoinRequest(channel.Id, joinRequest.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ChannelJoinRequestStatusApproved, approved.Status)
+		assert.Equal(t, th.BasicUser.Id, approved.ReviewerId)
+
+		_, appErr := th.App.GetChannelMember(context.Background(), channel.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr, "the requester should have been added to the channel")
+
+		_, resp, err := th.Client.ApproveChannelJoinRequest(channel.Id, joinRequest.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = user2Client.RequestToJoinChannel(channel.Id, "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		otherRequest, _, err := otherClient.RequestToJoinChannel(channel.Id, "")
+		require.NoError(t, err)
+
+		denied, _, err := th.Client.DenyChannelJoinRequest(channel.Id, otherRequest.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ChannelJoinRequestStatusDenied, denied.Status)
+
+		_, appErr := th.App.GetChannelMember(context.Background(), channel.Id, other.Id)
+		require.NotNil(t, appErr)
+
+		requests, _, err := th.Client.GetChannelJoinRequests(channel.Id)
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		otherRequest, _, err := otherClient.RequestToJoinChannel(channel.Id, "")
+		require.NoError(t, err, "a user can ask again once denied")
+
+		resp, err := th.Client.CancelChannelJoinRequest(channel.Id, otherRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = otherClient.CancelChannelJoinRequest(channel.Id, otherRequest.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.Client.GetChannelJoinRequests(channel.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		_, resp, err = th.Client.ApproveChannelJoinRequest(channel.Id, otherRequest.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("expired requests can't be reviewed", func(t *testing.T) {
+		now := model.GetMillis()
+		expired, err := th.App.Srv().Store.ChannelJoinRequest().Save(&model.ChannelJoinRequest{
+			ChannelId: channel.Id,
+			UserId:    other.Id,
+			CreateAt:  now - 2000,
+			ExpireAt:  now - 1000,
+		})
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.ApproveChannelJoinRequest(channel.Id, expired.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
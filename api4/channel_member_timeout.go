@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitChannelMemberTimeout() {
+	api.BaseRoutes.Channel.Handle("/timeouts", api.APISessionRequired(getChannelMemberTimeouts)).Methods("GET")
+	api.BaseRoutes.ChannelMember.Handle("/timeout", api.APISessionRequired(timeoutChannelMember)).Methods("PUT")
+	api.BaseRoutes.ChannelMember.Handle("/timeout", api.APISessionRequired(removeChannelMemberTimeout)).Methods("DELETE")
+}
+
+// checkChannelModeratorPermission sets an error unless the user of the session moderates the
+// channel, that is can manage the roles of its members.
+func checkChannelModeratorPermission(c *Context, channel *model.Channel) {
+	if !(channel.Type == model.ChannelTypeOpen || channel.Type == model.ChannelTypePrivate) {
+		c.Err = model.NewAppError("checkChannelModeratorPermission", "api.channel.channel_member_timeout.type.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), channel.Id, model.PermissionManageChannelRoles) {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+	}
+}
+
+func getChannelMemberTimeouts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, appErr := c.App.GetChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	checkChannelModeratorPermission(c, channel)
+	if c.Err != nil {
+		return
+	}
+
+	timeouts, appErr := c.App.GetChannelMemberTimeouts(channel.Id)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(timeouts); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func timeoutChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var timeoutRequest model.ChannelMemberTimeoutRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&timeoutRequest); jsonErr != nil {
+		c.SetInvalidParam("duration_minutes")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("timeoutChannelMember", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("duration_minutes", timeoutRequest.DurationMinutes)
+
+	channel, appErr := c.App.GetChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	checkChannelModeratorPermission(c, channel)
+	if c.Err != nil {
+		return
+	}
+
+	timeout, appErr := c.App.TimeoutChannelMember(c.AppContext, channel.Id, c.Params.UserId, c.AppContext.Session().UserId, timeoutRequest.DurationMinutes)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("expires_at", timeout.ExpiresAt)
+	c.LogAudit("name=" + channel.Name + " user_id=" + c.Params.UserId)
+
+	if err := json.NewEncoder(w).Encode(timeout); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func removeChannelMemberTimeout(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("removeChannelMemberTimeout", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	channel, appErr := c.App.GetChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	checkChannelModeratorPermission(c, channel)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.RemoveChannelMemberTimeout(channel.Id, c.Params.UserId, c.AppContext.Session().UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("name=" + channel.Name + " user_id=" + c.Params.UserId)
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestChannelMemberTimeout(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.BasicChannel
+	user := th.BasicUser2
+
+	t.Run("requires moderator permission", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.TimeoutChannelMember(channel.Id, th.BasicUser.Id, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetChannelMemberTimeouts(channel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("validates the duration", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.TimeoutChannelMember(channel.Id, user.Id, 0)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.TimeoutChannelMember(channel.Id, user.Id, model.ChannelMemberTimeoutMaxMinutes+1)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("prevents the member from posting until removed", func(t *testing.T) {
+		client := th.CreateClient()
+		_, _, err := client.Login(user.Email, user.Password)
+		require.NoError(t, err)
+
+		wsClient, err := th.CreateWebSocketClientWithClient(client)
+		require.NoError(t, err)
+		defer wsClient.Close()
+		wsClient.Listen()
+
+		post, _, err := client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "before the timeout"})
+		require.NoError(t, err)
+
+		timeout, _, err := th.SystemAdminClient.TimeoutChannelMember(channel.Id, user.Id, 10)
+		require.NoError(t, err)
+		assert.Equal(t, th.SystemAdminUser.Id, timeout.CreatorId)
+		assert.Greater(t, timeout.ExpiresAt, model.GetMillis())
+
+		assertExpectedWebsocketEvent(t, wsClient, model.WebsocketEventChannelMemberTimeoutUpdated, func(event *model.WebSocketEvent) {
+			assert.Equal(t, channel.Id, event.GetData()["channel_id"])
+			assert.EqualValues(t, timeout.ExpiresAt, event.GetData()["expires_at"])
+		})
+
+		timeouts, _, err := th.SystemAdminClient.GetChannelMemberTimeouts(channel.Id)
+		require.NoError(t, err)
+		require.Len(t, timeouts, 1)
+		assert.Equal(t, user.Id, timeouts[0].UserId)
+
+		_, resp, err := client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "muted"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.UpdatePost(post.Id, &model.Post{Id: post.Id, ChannelId: channel.Id, Message: "edited while muted"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.PatchPost(post.Id, &model.PostPatch{Message: model.NewString("patched while muted")})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		// Other channels are not affected.
+		_, _, err = client.CreatePost(&model.Post{ChannelId: th.BasicChannel2.Id, Message: "not muted"})
+		require.NoError(t, err)
+
+		_, err = th.SystemAdminClient.RemoveChannelMemberTimeout(channel.Id, user.Id)
+		require.NoError(t, err)
+
+		_, _, err = client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "unmuted"})
+		require.NoError(t, err)
+	})
+
+	t.Run("moderators cannot be timed out", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.TimeoutChannelMember(channel.Id, th.SystemAdminUser.Id, 10)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		th.MakeUserChannelAdmin(th.BasicUser, channel)
+
+		_, resp, err = th.SystemAdminClient.TimeoutChannelMember(channel.Id, th.BasicUser.Id, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("members cannot remove their own timeout", func(t *testing.T) {
+		th.MakeUserChannelAdmin(th.BasicUser, channel)
+
+		resp, err := th.Client.RemoveChannelMemberTimeout(channel.Id, th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitChannelSlowmode() {
+	api.BaseRoutes.ChannelModerations.Handle("/slowmode", api.APISessionRequired(getChannelSlowmode)).Methods("GET")
+	api.BaseRoutes.ChannelModerations.Handle("/slowmode", api.APISessionRequired(updateChannelSlowmode)).Methods("PUT")
+}
+
+func getChannelSlowmode(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, appErr := c.App.GetChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	checkChannelModeratorPermission(c, channel)
+	if c.Err != nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&model.ChannelSlowmode{Seconds: channel.SlowmodeSeconds}); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateChannelSlowmode(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var slowmode model.ChannelSlowmode
+	if jsonErr := json.NewDecoder(r.Body).Decode(&slowmode); jsonErr != nil {
+		c.SetInvalidParam("seconds")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateChannelSlowmode", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+	auditRec.AddMeta("seconds", slowmode.Seconds)
+
+	channel, appErr := c.App.GetChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	checkChannelModeratorPermission(c, channel)
+	if c.Err != nil {
+		return
+	}
+
+	channel, appErr = c.App.SetChannelSlowmode(channel.Id, slowmode.Seconds)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("name=" + channel.Name + " seconds=" + strconv.Itoa(channel.SlowmodeSeconds))
+
+	if err := json.NewEncoder(w).Encode(&model.ChannelSlowmode{Seconds: channel.SlowmodeSeconds}); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
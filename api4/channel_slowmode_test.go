@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestChannelSlowmode(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.BasicChannel
+
+	t.Run("requires moderator permission", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.UpdateChannelSlowmode(channel.Id, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetChannelSlowmode(channel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("validates the interval", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpdateChannelSlowmode(channel.Id, -1)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.UpdateChannelSlowmode(channel.Id, model.ChannelSlowmodeMaxSeconds+1)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("limits the posts of members", func(t *testing.T) {
+		slowmode, _, err := th.SystemAdminClient.UpdateChannelSlowmode(channel.Id, 60)
+		require.NoError(t, err)
+		assert.Equal(t, 60, slowmode.Seconds)
+
+		slowmode, _, err = th.Client.GetChannelSlowmode(channel.Id)
+		require.NoError(t, err)
+		assert.Equal(t, 60, slowmode.Seconds)
+
+		client := th.CreateClient()
+		_, _, err = client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+		require.NoError(t, err)
+
+		_, _, err = client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "first"})
+		require.NoError(t, err)
+
+		_, resp, err := client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "second"})
+		require.Error(t, err)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		CheckErrorID(t, err, "api.post.create_post.slowmode.app_error")
+
+		// Channel admins are exempt
+		_, _, err = th.Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "first"})
+		require.NoError(t, err)
+		_, _, err = th.Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "second"})
+		require.NoError(t, err)
+
+		_, _, err = th.SystemAdminClient.UpdateChannelSlowmode(channel.Id, 0)
+		require.NoError(t, err)
+
+		_, _, err = client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "third"})
+		require.NoError(t, err)
+	})
+}
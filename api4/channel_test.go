@@ -2585,6 +2585,56 @@ func TestGetPinnedPosts(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUpdatePinnedPostsOrder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+	channel := th.BasicChannel
+
+	p1 := th.CreatePinnedPost()
+	time.Sleep(time.Millisecond)
+	p2 := th.CreatePinnedPost()
+	time.Sleep(time.Millisecond)
+	p3 := th.CreatePinnedPost()
+
+	posts, _, err := client.UpdatePinnedPostsOrder(channel.Id, []string{p3.Id, p1.Id})
+	require.NoError(t, err)
+	require.Equal(t, []string{p3.Id, p1.Id, p2.Id}, posts.Order)
+
+	posts, _, err = th.Client.GetPinnedPosts(channel.Id, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{p3.Id, p1.Id, p2.Id}, posts.Order)
+
+	t.Run("reordering changes the etag", func(t *testing.T) {
+		_, resp, err := client.GetPinnedPosts(channel.Id, "")
+		require.NoError(t, err)
+
+		_, _, err = client.UpdatePinnedPostsOrder(channel.Id, []string{p3.Id, p2.Id})
+		require.NoError(t, err)
+
+		posts, _, err := client.GetPinnedPosts(channel.Id, resp.Etag)
+		require.NoError(t, err)
+		require.Equal(t, []string{p3.Id, p2.Id, p1.Id}, posts.Order)
+	})
+
+	t.Run("only pinned posts of the channel can be ordered", func(t *testing.T) {
+		_, resp, err := client.UpdatePinnedPostsOrder(channel.Id, []string{th.BasicPost.Id})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.UpdatePinnedPostsOrder(channel.Id, []string{p1.Id, p1.Id})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires access to the channel", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		_, resp, err := client.UpdatePinnedPostsOrder(privateChannel.Id, []string{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestUpdateChannelRoles(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2840,6 +2890,44 @@ func TestUpdateChannelNotifyProps(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAddChannelMembers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+	outsider := th.CreateUser()
+
+	result, resp, err := th.Client.AddChannelMembers(th.BasicChannel.Id, []string{user.Id, outsider.Id, "junk"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	require.Len(t, result.Results, 3)
+
+	require.True(t, result.Results[0].IsSuccess())
+	assert.Equal(t, http.StatusCreated, result.Results[0].StatusCode)
+	var member model.ChannelMember
+	require.NoError(t, result.Results[0].DecodeData(&member))
+	assert.Equal(t, th.BasicChannel.Id, member.ChannelId)
+	assert.Equal(t, user.Id, member.UserId)
+
+	assert.Equal(t, outsider.Id, result.Results[1].Id)
+	assert.False(t, result.Results[1].IsSuccess())
+	assert.Equal(t, http.StatusBadRequest, result.Results[2].StatusCode)
+
+	t.Run("the permission to manage members is checked for the whole batch", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+		th.Client.Logout()
+		th.Client.Login(user.Email, user.Password)
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.AddChannelMembers(privateChannel.Id, []string{th.BasicUser2.Id})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestAddChannelMember(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -4549,3 +4637,65 @@ func TestViewChannelWithoutCollapsedThreads(t *testing.T) {
 	require.NoError(t, err)
 	require.Zero(t, threads.TotalUnreadMentions)
 }
+
+func TestBulkUpdateChannelMembers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	publicChannel := th.CreatePublicChannel()
+
+	t.Run("schedules a job", func(t *testing.T) {
+		report, resp, err := client.BulkUpdateChannelMembers(publicChannel.Id, &model.ChannelMembersBulkRequest{
+			Action:  model.ChannelMembersBulkActionAdd,
+			UserIds: []string{th.BasicUser2.Id, th.BasicUser2.Id, model.NewId()},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.NotEmpty(t, report.JobId)
+		assert.Equal(t, publicChannel.Id, report.ChannelId)
+		assert.Equal(t, model.ChannelMembersBulkActionAdd, report.Action)
+		assert.Equal(t, model.JobStatusPending, report.Status)
+		assert.Equal(t, 2, report.Total)
+		assert.Empty(t, report.Failures)
+
+		fetched, _, err := client.GetBulkChannelMembersReport(publicChannel.Id, report.JobId)
+		require.NoError(t, err)
+		assert.Equal(t, report.JobId, fetched.JobId)
+
+		_, resp, err = client.GetBulkChannelMembersReport(th.BasicChannel.Id, report.JobId)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, resp, err := client.BulkUpdateChannelMembers(publicChannel.Id, &model.ChannelMembersBulkRequest{Action: "invite", UserIds: []string{th.BasicUser2.Id}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.BulkUpdateChannelMembers(publicChannel.Id, &model.ChannelMembersBulkRequest{Action: model.ChannelMembersBulkActionAdd})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("direct channel", func(t *testing.T) {
+		dm, _, err := th.SystemAdminClient.CreateDirectChannel(th.SystemAdminUser.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+
+		_, resp, err := th.SystemAdminClient.BulkUpdateChannelMembers(dm.Id, &model.ChannelMembersBulkRequest{Action: model.ChannelMembersBulkActionAdd, UserIds: []string{th.BasicUser2.Id}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		adminChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypeOpen)
+		th.AddUserToChannel(th.BasicUser, adminChannel)
+
+		th.RemovePermissionFromRole(model.PermissionManagePublicChannelMembers.Id, model.ChannelUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionManagePublicChannelMembers.Id, model.ChannelUserRoleId)
+
+		_, resp, err := client.BulkUpdateChannelMembers(adminChannel.Id, &model.ChannelMembersBulkRequest{Action: model.ChannelMembersBulkActionAdd, UserIds: []string{th.BasicUser2.Id}})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
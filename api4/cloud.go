@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/audit"
@@ -36,10 +37,12 @@ func (api *API) InitCloud() {
 	api.BaseRoutes.Cloud.Handle("/customer/address", api.APISessionRequired(updateCloudCustomerAddress)).Methods("PUT")
 
 	// GET /api/v4/cloud/subscription
+	// GET /api/v4/cloud/subscription/preview
 	api.BaseRoutes.Cloud.Handle("/subscription", api.APISessionRequired(getSubscription)).Methods("GET")
 	api.BaseRoutes.Cloud.Handle("/subscription/invoices", api.APISessionRequired(getInvoicesForSubscription)).Methods("GET")
 	api.BaseRoutes.Cloud.Handle("/subscription/invoices/{invoice_id:in_[A-Za-z0-9]+}/pdf", api.APISessionRequired(getSubscriptionInvoicePDF)).Methods("GET")
 	api.BaseRoutes.Cloud.Handle("/subscription", api.APISessionRequired(changeSubscription)).Methods("PUT")
+	api.BaseRoutes.Cloud.Handle("/subscription/preview", api.APISessionRequired(previewSubscriptionChange)).Methods("GET")
 
 	// GET /api/v4/cloud/request-trial
 	api.BaseRoutes.Cloud.Handle("/request-trial", api.APISessionRequired(requestCloudTrial)).Methods("PUT")
@@ -124,6 +127,44 @@ func changeSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(json)
 }
 
+func previewSubscriptionChange(c *Context, w http.ResponseWriter, r *http.Request) {
+	if c.App.Channels().License() == nil || !*c.App.Channels().License().Features.Cloud {
+		c.Err = model.NewAppError("Api4.previewSubscriptionChange", "api.cloud.license_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteBilling) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteBilling)
+		return
+	}
+
+	seats, err := strconv.Atoi(r.URL.Query().Get("seats"))
+	if err != nil || seats <= 0 {
+		c.SetInvalidURLParam("seats")
+		return
+	}
+
+	currentSubscription, err := c.App.Cloud().GetSubscription(c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = model.NewAppError("Api4.previewSubscriptionChange", "api.cloud.request_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	preview, err := c.App.Cloud().PreviewSubscriptionChange(c.AppContext.Session().UserId, currentSubscription.ID, seats)
+	if err != nil {
+		c.Err = model.NewAppError("Api4.previewSubscriptionChange", "api.cloud.request_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json, err := json.Marshal(preview)
+	if err != nil {
+		c.Err = model.NewAppError("Api4.previewSubscriptionChange", "api.cloud.request_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(json)
+}
+
 func requestCloudTrial(c *Context, w http.ResponseWriter, r *http.Request) {
 	if c.App.Channels().License() == nil || !*c.App.Channels().License().Features.Cloud {
 		c.Err = model.NewAppError("Api4.requestCloudTrial", "api.cloud.license_error", nil, "", http.StatusForbidden)
@@ -403,9 +444,32 @@ func getInvoicesForSubscription(c *Context, w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	invoices, appErr := c.App.Cloud().GetInvoicesForSubscription(c.AppContext.Session().UserId)
+	// Invoices are only paginated on request, the full list being returned otherwise.
+	opts := &model.InvoiceListOptions{}
+	if r.URL.Query().Get("page") != "" || r.URL.Query().Get("per_page") != "" {
+		opts.Page = c.Params.Page
+		opts.PerPage = c.Params.PerPage
+	}
+
+	if since := r.URL.Query().Get("since"); since != "" {
+		var err error
+		if opts.Since, err = strconv.ParseInt(since, 10, 64); err != nil {
+			c.SetInvalidURLParam("since")
+			return
+		}
+	}
+
+	if until := r.URL.Query().Get("until"); until != "" {
+		var err error
+		if opts.Until, err = strconv.ParseInt(until, 10, 64); err != nil {
+			c.SetInvalidURLParam("until")
+			return
+		}
+	}
+
+	invoices, appErr := c.App.GetInvoicesForSubscription(c.AppContext.Session().UserId, opts)
 	if appErr != nil {
-		c.Err = model.NewAppError("Api4.getInvoicesForSubscription", "api.cloud.request_error", nil, appErr.Error(), http.StatusInternalServerError)
+		c.Err = appErr
 		return
 	}
 
@@ -434,9 +498,9 @@ func getSubscriptionInvoicePDF(c *Context, w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	pdfData, filename, appErr := c.App.Cloud().GetInvoicePDF(c.AppContext.Session().UserId, c.Params.InvoiceId)
+	pdfData, filename, appErr := c.App.GetSubscriptionInvoicePDF(c.AppContext.Session().UserId, c.Params.InvoiceId)
 	if appErr != nil {
-		c.Err = model.NewAppError("Api4.getSubscriptionInvoicePDF", "api.cloud.request_error", nil, appErr.Error(), http.StatusInternalServerError)
+		c.Err = appErr
 		return
 	}
 
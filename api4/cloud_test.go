@@ -5,6 +5,7 @@ package api4
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"testing"
@@ -246,3 +247,183 @@ func Test_requestTrial(t *testing.T) {
 		require.Equal(t, http.StatusOK, r.StatusCode, "Status OK")
 	})
 }
+
+func Test_previewSubscriptionChange(t *testing.T) {
+	subscription := &model.Subscription{
+		ID:    "MySubscriptionID",
+		Seats: 10,
+	}
+
+	preview := &model.SubscriptionChangePreview{
+		SubscriptionID: subscription.ID,
+		CurrentSeats:   10,
+		Seats:          15,
+		ProratedTotal:  2500,
+	}
+
+	setupCloud := func(th *TestHelper) *mocks.CloudInterface {
+		th.App.Srv().SetLicense(model.NewTestLicense("cloud"))
+
+		cloud := &mocks.CloudInterface{}
+		cloud.Mock.On("GetSubscription", mock.Anything).Return(subscription, nil)
+		cloud.Mock.On("PreviewSubscriptionChange", mock.Anything, subscription.ID, 15).Return(preview, nil)
+
+		cloudImpl := th.App.Srv().Cloud
+		th.App.Srv().Cloud = cloud
+		t.Cleanup(func() {
+			th.App.Srv().Cloud = cloudImpl
+		})
+		return cloud
+	}
+
+	t.Run("non admin users can not preview seat changes", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+		setupCloud(th)
+
+		result, r, err := th.Client.GetSubscriptionChangePreview(15)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Equal(t, http.StatusForbidden, r.StatusCode, "403 Forbidden")
+	})
+
+	t.Run("non cloud license returns not implemented", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(model.NewTestLicense())
+
+		result, r, err := th.SystemAdminClient.GetSubscriptionChangePreview(15)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Equal(t, http.StatusNotImplemented, r.StatusCode, "Expected 501 Not Implemented")
+	})
+
+	t.Run("invalid seats are rejected", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+		setupCloud(th)
+
+		result, r, err := th.SystemAdminClient.GetSubscriptionChangePreview(0)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Equal(t, http.StatusBadRequest, r.StatusCode, "400 Bad Request")
+	})
+
+	t.Run("admin gets the prorated cost", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+		cloud := setupCloud(th)
+
+		result, r, err := th.SystemAdminClient.GetSubscriptionChangePreview(15)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, r.StatusCode, "200 OK")
+		require.Equal(t, preview, result)
+		cloud.AssertExpectations(t)
+	})
+}
+
+func Test_getInvoicesForSubscription(t *testing.T) {
+	invoices := []*model.Invoice{
+		{ID: "in_1", CreateAt: 1000},
+		{ID: "in_3", CreateAt: 3000},
+		{ID: "in_2", CreateAt: 2000},
+	}
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("cloud"))
+
+	cloud := &mocks.CloudInterface{}
+	cloud.Mock.On("GetInvoicesForSubscription", mock.Anything).Return(invoices, nil)
+
+	cloudImpl := th.App.Srv().Cloud
+	defer func() {
+		th.App.Srv().Cloud = cloudImpl
+	}()
+	th.App.Srv().Cloud = cloud
+
+	t.Run("non admin users can not list invoices", func(t *testing.T) {
+		_, r, err := th.Client.GetInvoicesForSubscriptionPage(0, 10, 0, 0)
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, r.StatusCode, "403 Forbidden")
+	})
+
+	t.Run("all invoices in the billing backend order without pagination", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.GetInvoicesForSubscription()
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		require.Equal(t, "in_1", result[0].ID)
+		require.Equal(t, "in_3", result[1].ID)
+		require.Equal(t, "in_2", result[2].ID)
+	})
+
+	t.Run("paginated newest first", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.GetInvoicesForSubscriptionPage(0, 2, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, "in_3", result[0].ID)
+		require.Equal(t, "in_2", result[1].ID)
+
+		result, _, err = th.SystemAdminClient.GetInvoicesForSubscriptionPage(1, 2, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "in_1", result[0].ID)
+	})
+
+	t.Run("date filters", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.GetInvoicesForSubscriptionPage(0, 10, 1500, 2500)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "in_2", result[0].ID)
+	})
+
+	t.Run("invalid date filter", func(t *testing.T) {
+		r, err := th.SystemAdminClient.DoAPIGet("/cloud/subscription/invoices?since=yesterday", "")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, r.StatusCode, "400 Bad Request")
+	})
+}
+
+func Test_getSubscriptionInvoicePDF(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("cloud"))
+
+	pdfData := []byte("%PDF-1.4 invoice")
+	cloud := &mocks.CloudInterface{}
+	cloud.Mock.On("GetInvoicesForSubscription", mock.Anything).Return([]*model.Invoice{
+		{ID: "in_cached", Status: model.InvoiceStatusPaid},
+		{ID: "in_open", Status: "open"},
+	}, nil)
+	cloud.Mock.On("GetInvoicePDF", mock.Anything, "in_cached").Return(pdfData, "invoice.pdf", nil).Once()
+	cloud.Mock.On("GetInvoicePDF", mock.Anything, "in_open").Return(pdfData, "open.pdf", nil)
+
+	cloudImpl := th.App.Srv().Cloud
+	defer func() {
+		th.App.Srv().Cloud = cloudImpl
+	}()
+	th.App.Srv().Cloud = cloud
+
+	for i := 0; i < 2; i++ {
+		r, err := th.SystemAdminClient.DoAPIGet("/cloud/subscription/invoices/in_cached/pdf", "")
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, pdfData, body)
+	}
+
+	// the second download must be served from the filestore.
+	cloud.AssertNumberOfCalls(t, "GetInvoicePDF", 1)
+
+	// invoices which aren't final yet may still change, so they aren't cached.
+	for i := 0; i < 2; i++ {
+		r, err := th.SystemAdminClient.DoAPIGet("/cloud/subscription/invoices/in_open/pdf", "")
+		require.NoError(t, err)
+		r.Body.Close()
+	}
+	cloud.AssertNumberOfCalls(t, "GetInvoicePDF", 3)
+}
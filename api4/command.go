@@ -27,6 +27,7 @@ func (api *API) InitCommand() {
 	api.BaseRoutes.Team.Handle("/commands/autocomplete", api.APISessionRequired(listAutocompleteCommands)).Methods("GET")
 	api.BaseRoutes.Team.Handle("/commands/autocomplete_suggestions", api.APISessionRequired(listCommandAutocompleteSuggestions)).Methods("GET")
 	api.BaseRoutes.Command.Handle("/regen_token", api.APISessionRequired(regenCommandToken)).Methods("PUT")
+	api.BaseRoutes.Command.Handle("/regen_signing_secret", api.APISessionRequired(regenCommandSigningSecret)).Methods("PUT")
 }
 
 func createCommand(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -477,3 +478,50 @@ func regenCommandToken(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	w.Write([]byte(model.MapToJSON(resp)))
 }
+
+func regenCommandSigningSecret(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireCommandId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("regenCommandSigningSecret", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	c.LogAudit("attempt")
+
+	cmd, err := c.App.GetCommand(c.Params.CommandId)
+	if err != nil {
+		auditRec.AddMeta("command_id", c.Params.CommandId)
+		c.SetCommandNotFoundError()
+		return
+	}
+	auditRec.AddMeta("command", cmd)
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), cmd.TeamId, model.PermissionManageSlashCommands) {
+		c.LogAudit("fail - inappropriate permissions")
+		// here we return Not_found instead of a permissions error so we don't leak the existence of
+		// a command to someone without permissions for the team it belongs to.
+		c.SetCommandNotFoundError()
+		return
+	}
+
+	if c.AppContext.Session().UserId != cmd.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), cmd.TeamId, model.PermissionManageOthersSlashCommands) {
+		c.LogAudit("fail - inappropriate permissions")
+		c.SetPermissionError(model.PermissionManageOthersSlashCommands)
+		return
+	}
+
+	rcmd, err := c.App.RegenCommandSigningSecret(cmd)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("success")
+
+	resp := make(map[string]string)
+	resp["signing_secret"] = rcmd.SigningSecret
+
+	w.Write([]byte(model.MapToJSON(resp)))
+}
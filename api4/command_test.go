@@ -625,6 +625,42 @@ func TestRegenToken(t *testing.T) {
 	require.Empty(t, token, "should not return the token")
 }
 
+func TestRegenSigningSecret(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCommands = true })
+
+	newCmd := &model.Command{
+		CreatorId:     th.BasicUser.Id,
+		TeamId:        th.BasicTeam.Id,
+		URL:           "http://nowhere.com",
+		Method:        model.CommandMethodPost,
+		Trigger:       "trigger",
+		SigningSecret: "chosen by the client",
+	}
+
+	createdCmd, resp, err := th.SystemAdminClient.CreateCommand(newCmd)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.Len(t, createdCmd.SigningSecret, 26, "should generate the signing secret")
+
+	secret, _, err := th.SystemAdminClient.RegenCommandSigningSecret(createdCmd.Id)
+	require.NoError(t, err)
+	require.Len(t, secret, 26)
+	require.NotEqual(t, createdCmd.SigningSecret, secret, "should update the signing secret")
+
+	createdCmd.SigningSecret = ""
+	updatedCmd, _, err := th.SystemAdminClient.UpdateCommand(createdCmd)
+	require.NoError(t, err)
+	require.Equal(t, secret, updatedCmd.SigningSecret, "should keep the signing secret on update")
+
+	secret, resp, err = th.Client.RegenCommandSigningSecret(createdCmd.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+	require.Empty(t, secret, "should not return the signing secret")
+}
+
 func TestExecuteInvalidCommand(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -35,6 +35,9 @@ func (api *API) InitConfig() {
 	api.BaseRoutes.APIRoot.Handle("/config/reload", api.APISessionRequired(configReload)).Methods("POST")
 	api.BaseRoutes.APIRoot.Handle("/config/client", api.APIHandler(getClientConfig)).Methods("GET")
 	api.BaseRoutes.APIRoot.Handle("/config/environment", api.APISessionRequired(getEnvironmentConfig)).Methods("GET")
+	api.BaseRoutes.APIRoot.Handle("/config/locked", api.APISessionRequired(getLockedConfigPaths)).Methods("GET")
+	api.BaseRoutes.APIRoot.Handle("/config/schema", api.APISessionRequired(getConfigSchema)).Methods("GET")
+	api.BaseRoutes.APIRoot.Handle("/config/feature_flags", api.APISessionRequired(patchFeatureFlagOverrides)).Methods("PATCH")
 }
 
 func init() {
@@ -67,7 +70,14 @@ func getConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	if c.App.Channels().License() != nil && *c.App.Channels().License().Features.Cloud {
+	isCloud := c.App.Channels().License() != nil && *c.App.Channels().License().Features.Cloud
+
+	if r.URL.Query().Get("include_origin") == "true" {
+		writeConfigWithOrigins(c, w, cfg, isCloud)
+		return
+	}
+
+	if isCloud {
 		js, jsonErr := cfg.ToJSONFiltered(model.ConfigAccessTagType, model.ConfigAccessTagCloudRestrictable)
 		if jsonErr != nil {
 			c.Err = model.NewAppError("getConfig", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
@@ -81,6 +91,47 @@ func getConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// writeConfigWithOrigins writes the config along with the origin of each of the values the
+// client is allowed to see.
+func writeConfigWithOrigins(c *Context, w http.ResponseWriter, cfg *model.Config, isCloud bool) {
+	origins := c.App.GetConfigOrigins(func(structField reflect.StructField) bool {
+		if isCloud && isCloudRestrictable(structField) {
+			return false
+		}
+		return readFilter(c, structField)
+	})
+
+	var js []byte
+	var jsonErr error
+	if isCloud {
+		js, jsonErr = cfg.ToJSONFiltered(model.ConfigAccessTagType, model.ConfigAccessTagCloudRestrictable)
+	} else {
+		js, jsonErr = json.Marshal(cfg)
+	}
+	if jsonErr != nil {
+		c.Err = model.NewAppError("getConfig", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The config is written as is, so that cloud restricted settings stay left out.
+	resp := struct {
+		Config  json.RawMessage        `json:"config"`
+		Origins map[string]interface{} `json:"origins"`
+	}{js, origins}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func isCloudRestrictable(structField reflect.StructField) bool {
+	for _, tag := range strings.Split(structField.Tag.Get(model.ConfigAccessTagType), ",") {
+		if strings.TrimSpace(tag) == model.ConfigAccessTagCloudRestrictable {
+			return true
+		}
+	}
+	return false
+}
+
 func configReload(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec := c.MakeAuditRecord("configReload", audit.Fail)
 	defer c.LogAuditRec(auditRec)
@@ -188,7 +239,9 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Err = model.NewAppError("updateConfig", "api.config.update_config.diff.app_error", nil, diffErr.Error(), http.StatusInternalServerError)
 		return
 	}
-	auditRec.AddMeta("diff", diffs.Sanitize())
+	sanitizedDiffs := diffs.Sanitize()
+	auditRec.AddMeta("diff", sanitizedDiffs)
+	auditRec.AddEventNewData(sanitizedDiffs)
 
 	newCfg.Sanitize()
 
@@ -238,6 +291,18 @@ func getClientConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		config = c.App.LimitedClientConfigWithComputed()
 	} else {
 		config = c.App.ClientConfigWithComputed()
+
+		// Feature flags with rollout rules can differ from one user to another.
+		if len(c.App.Config().FeatureFlagRules) > 0 {
+			flags, appErr := c.App.GetFeatureFlagsForUser(c.AppContext.Session().UserId, c.AppContext.Session().GetUserRoles())
+			if appErr != nil {
+				c.Err = appErr
+				return
+			}
+			for key, value := range flags.ToMap() {
+				config["FeatureFlag"+key] = value
+			}
+		}
 	}
 
 	w.Write([]byte(model.MapToJSON(config)))
@@ -254,6 +319,29 @@ func getEnvironmentConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.StringInterfaceToJSON(envConfig)))
 }
 
+func getConfigSchema(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionToAny(*c.AppContext.Session(), model.SysconsoleReadPermissions) {
+		c.SetPermissionError(model.SysconsoleReadPermissions...)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(config.GenerateSchema()); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getLockedConfigPaths(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionToAny(*c.AppContext.Session(), model.SysconsoleReadPermissions) {
+		c.SetPermissionError(model.SysconsoleReadPermissions...)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if err := json.NewEncoder(w).Encode(c.App.GetLockedConfigPaths()); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	cfg := model.ConfigFromJSON(r.Body)
 	if cfg == nil {
@@ -269,10 +357,63 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	updatedCfg := mergeConfigPatch(c, "patchConfig", cfg)
+	if c.Err != nil {
+		return
+	}
+
+	oldCfg, newCfg, err := c.App.SaveConfig(updatedCfg, true)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	diffs, diffErr := config.Diff(oldCfg, newCfg)
+	if diffErr != nil {
+		c.Err = model.NewAppError("patchConfig", "api.config.patch_config.diff.app_error", nil, diffErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	sanitizedDiffs := diffs.Sanitize()
+	auditRec.AddMeta("diff", sanitizedDiffs)
+	auditRec.AddEventNewData(sanitizedDiffs)
+
+	newCfg.Sanitize()
+
+	auditRec.Success()
+
+	cfg, mergeErr := config.Merge(&model.Config{}, newCfg, &utils.MergeConfig{
+		StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
+			return readFilter(c, structField)
+		},
+	})
+	if mergeErr != nil {
+		c.Err = model.NewAppError("patchConfig", "api.config.patch_config.restricted_merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if c.App.Channels().License() != nil && *c.App.Channels().License().Features.Cloud {
+		js, jsonErr := cfg.ToJSONFiltered(model.ConfigAccessTagType, model.ConfigAccessTagCloudRestrictable)
+		if jsonErr != nil {
+			c.Err = model.NewAppError("patchConfig", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(js)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(cfg); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// mergeConfigPatch returns the configuration with the settings of the patch the user of the
+// session may write merged onto it, if the result is valid and allowed. Otherwise it sets the
+// error of the context and returns nil.
+func mergeConfigPatch(c *Context, where string, cfg *model.Config) *model.Config {
 	appCfg := c.App.Config()
 	if *appCfg.ServiceSettings.SiteURL != "" && cfg.ServiceSettings.SiteURL != nil && *cfg.ServiceSettings.SiteURL == "" {
-		c.Err = model.NewAppError("patchConfig", "api.config.update_config.clear_siteurl.app_error", nil, "", http.StatusBadRequest)
-		return
+		c.Err = model.NewAppError(where, "api.config.update_config.clear_siteurl.app_error", nil, "", http.StatusBadRequest)
+		return nil
 	}
 
 	filterFn := func(structField reflect.StructField, base, patch reflect.Value) bool {
@@ -281,22 +422,22 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	// Do not allow plugin uploads to be toggled through the API
 	if cfg.PluginSettings.EnableUploads != nil && *cfg.PluginSettings.EnableUploads != *appCfg.PluginSettings.EnableUploads {
-		c.Err = model.NewAppError("patchConfig", "api.config.update_config.not_allowed_security.app_error", map[string]interface{}{"Name": "PluginSettings.EnableUploads"}, "", http.StatusForbidden)
-		return
+		c.Err = model.NewAppError(where, "api.config.update_config.not_allowed_security.app_error", map[string]interface{}{"Name": "PluginSettings.EnableUploads"}, "", http.StatusForbidden)
+		return nil
 	}
 
 	// Do not allow marketplace URL to be toggled if plugin uploads are disabled.
 	if cfg.PluginSettings.MarketplaceURL != nil && cfg.PluginSettings.EnableUploads != nil {
 		// Breaking it down to 2 conditions to make it simple.
 		if *cfg.PluginSettings.MarketplaceURL != *appCfg.PluginSettings.MarketplaceURL && !*cfg.PluginSettings.EnableUploads {
-			c.Err = model.NewAppError("patchConfig", "api.config.update_config.not_allowed_security.app_error", map[string]interface{}{"Name": "PluginSettings.MarketplaceURL"}, "", http.StatusForbidden)
-			return
+			c.Err = model.NewAppError(where, "api.config.update_config.not_allowed_security.app_error", map[string]interface{}{"Name": "PluginSettings.MarketplaceURL"}, "", http.StatusForbidden)
+			return nil
 		}
 	}
 
 	if err := c.App.CheckFreemiumLimitsForConfigSave(appCfg, cfg); err != nil {
 		c.Err = err
-		return
+		return nil
 	}
 
 	if cfg.MessageExportSettings.EnableExport != nil {
@@ -308,67 +449,83 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	})
 
 	if mergeErr != nil {
-		c.Err = model.NewAppError("patchConfig", "api.config.update_config.restricted_merge.app_error", nil, mergeErr.Error(), http.StatusInternalServerError)
-		return
+		c.Err = model.NewAppError(where, "api.config.update_config.restricted_merge.app_error", nil, mergeErr.Error(), http.StatusInternalServerError)
+		return nil
 	}
 
 	// There are some settings that cannot be changed in a cloud env
 	if c.App.Channels().License() != nil && *c.App.Channels().License().Features.Cloud {
 		diffs, diffErr := config.DiffTags(appCfg, updatedCfg, "access", "cloud_restrictable")
 		if diffErr != nil {
-			c.Err = model.NewAppError("patchConfig", "api.config.update_config.diff.app_error", nil, diffErr.Error(), http.StatusInternalServerError)
-			return
+			c.Err = model.NewAppError(where, "api.config.update_config.diff.app_error", nil, diffErr.Error(), http.StatusInternalServerError)
+			return nil
 		}
 		if len(diffs) > 0 {
-			c.Err = model.NewAppError("patchConfig", "api.config.update_config.not_allowed_security.app_error", map[string]interface{}{"Name": diffs[0].Path}, "", http.StatusForbidden)
-			return
+			c.Err = model.NewAppError(where, "api.config.update_config.not_allowed_security.app_error", map[string]interface{}{"Name": diffs[0].Path}, "", http.StatusForbidden)
+			return nil
 		}
 	}
 
-	err := updatedCfg.IsValid()
-	if err != nil {
+	if err := updatedCfg.IsValid(); err != nil {
 		c.Err = err
-		return
+		return nil
 	}
 
-	oldCfg, newCfg, err := c.App.SaveConfig(updatedCfg, true)
-	if err != nil {
-		c.Err = err
+	return updatedCfg
+}
+
+func patchFeatureFlagOverrides(c *Context, w http.ResponseWriter, r *http.Request) {
+	var patch map[string]*string
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || len(patch) == 0 {
+		c.SetInvalidParam("feature_flags")
 		return
 	}
 
-	diffs, diffErr := config.Diff(oldCfg, newCfg)
-	if diffErr != nil {
-		c.Err = model.NewAppError("patchConfig", "api.config.patch_config.diff.app_error", nil, diffErr.Error(), http.StatusInternalServerError)
+	auditRec := c.MakeAuditRecord("patchFeatureFlagOverrides", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
 		return
 	}
-	auditRec.AddMeta("diff", diffs.Sanitize())
 
-	newCfg.Sanitize()
+	if !c.AppContext.Session().IsUnrestricted() && *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+		c.Err = model.NewAppError("patchFeatureFlagOverrides", "api.restricted_system_admin", nil, "", http.StatusForbidden)
+		return
+	}
 
-	auditRec.Success()
+	// Cloud workspaces get their feature flags from the management service.
+	if license := c.App.Channels().License(); license != nil && *license.Features.Cloud {
+		c.Err = model.NewAppError("patchFeatureFlagOverrides", "api.config.patch_feature_flags.cloud.app_error", nil, "", http.StatusForbidden)
+		return
+	}
 
-	cfg, mergeErr = config.Merge(&model.Config{}, newCfg, &utils.MergeConfig{
-		StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
-			return readFilter(c, structField)
-		},
-	})
-	if mergeErr != nil {
-		c.Err = model.NewAppError("patchConfig", "api.config.patch_config.restricted_merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+	oldOverrides, newOverrides, appErr := c.App.PatchFeatureFlagOverrides(patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
 	}
 
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	if c.App.Channels().License() != nil && *c.App.Channels().License().Features.Cloud {
-		js, jsonErr := cfg.ToJSONFiltered(model.ConfigAccessTagType, model.ConfigAccessTagCloudRestrictable)
-		if jsonErr != nil {
-			c.Err = model.NewAppError("patchConfig", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
-			return
+	// Every changed flag gets its own audit record so each change can be traced.
+	for name := range patch {
+		oldValue, hadOld := oldOverrides[name]
+		newValue, hasNew := newOverrides[name]
+		if hadOld == hasNew && oldValue == newValue {
+			continue
 		}
-		w.Write(js)
-		return
+
+		flagAuditRec := c.MakeAuditRecord("updateFeatureFlagOverride", audit.Success)
+		flagAuditRec.AddMeta("flag", name)
+		flagAuditRec.AddMeta("old_value", oldValue)
+		flagAuditRec.AddMeta("new_value", newValue)
+		flagAuditRec.AddMeta("removed", !hasNew)
+		c.LogAuditRec(flagAuditRec)
 	}
 
-	if err := json.NewEncoder(w).Encode(cfg); err != nil {
+	auditRec.Success()
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if err := json.NewEncoder(w).Encode(newOverrides); err != nil {
 		mlog.Warn("Error while writing response", mlog.Err(err))
 	}
 }
@@ -60,6 +60,113 @@ func TestGetConfig(t *testing.T) {
 			require.FailNow(t, "did not sanitize properly")
 		}
 	})
+
+	t.Run("with origins", func(t *testing.T) {
+		_, resp, err := client.GetConfigWithOrigins()
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+			cfgWithOrigins, _, err := client.GetConfigWithOrigins()
+			require.NoError(t, err)
+
+			require.NotNil(t, cfgWithOrigins.Config)
+			require.Equal(t, model.FakeSetting, *cfgWithOrigins.Config.SqlSettings.DataSource, "did not sanitize properly")
+
+			serviceSettings, ok := cfgWithOrigins.Origins["ServiceSettings"].(map[string]interface{})
+			require.True(t, ok)
+			require.Contains(t, []interface{}{model.ConfigOriginDefault, model.ConfigOriginMemory, model.ConfigOriginFile, model.ConfigOriginDatabase, model.ConfigOriginEnvironment}, serviceSettings["SiteURL"])
+		})
+	})
+}
+
+func TestGetConfigSchema(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	_, resp, err := th.Client.GetConfigSchema()
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	schema, _, err := th.SystemAdminClient.GetConfigSchema()
+	require.NoError(t, err)
+	require.NotEmpty(t, schema)
+
+	var found bool
+	for _, setting := range schema {
+		if setting.Path == "SqlSettings.DataSource" {
+			found = true
+			assert.Equal(t, model.ConfigSchemaTypeString, setting.Type)
+			assert.True(t, setting.Sensitive)
+			assert.True(t, setting.RestartRequired)
+			assert.Nil(t, setting.Default)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestLockedConfigPaths(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	os.Setenv(config.LockedPathsEnvVar, "ServiceSettings.ListenAddress")
+	defer func() {
+		os.Unsetenv(config.LockedPathsEnvVar)
+		require.NoError(t, th.App.ReloadConfig())
+	}()
+	require.NoError(t, th.App.ReloadConfig())
+
+	_, resp, err := th.Client.GetLockedConfigPaths()
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	lockedPaths, _, err := th.SystemAdminClient.GetLockedConfigPaths()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ServiceSettings.ListenAddress"}, lockedPaths)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		cfg := &model.Config{}
+		cfg.ServiceSettings.ListenAddress = model.NewString(":9999")
+		_, resp, err := client.PatchConfig(cfg)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "app.config.locked_path.app_error")
+
+		fullCfg, _, err := client.GetConfig()
+		require.NoError(t, err)
+		*fullCfg.ServiceSettings.ListenAddress = ":9999"
+		_, resp, err = client.UpdateConfig(fullCfg)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		cfg = &model.Config{}
+		cfg.TeamSettings.SiteName = model.NewString("Unlocked")
+		_, _, err = client.PatchConfig(cfg)
+		require.NoError(t, err)
+	})
+
+	require.NotEqual(t, ":9999", *th.App.Config().ServiceSettings.ListenAddress)
+}
+
+func TestLockedConfigPathsCertificateUpload(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	os.Setenv(config.LockedPathsEnvVar, "LdapSettings.PublicCertificateFile")
+	defer func() {
+		os.Unsetenv(config.LockedPathsEnvVar)
+		require.NoError(t, th.App.ReloadConfig())
+	}()
+	require.NoError(t, th.App.ReloadConfig())
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		resp, err := client.UploadLdapPublicCertificate([]byte(spPublicCertificate))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "app.config.locked_path.app_error")
+	})
+
+	require.Empty(t, *th.App.Config().LdapSettings.PublicCertificateFile)
 }
 
 func TestGetConfigWithAccessTag(t *testing.T) {
@@ -576,6 +683,45 @@ func TestUpdateConfigDiffInAuditRecord(t *testing.T) {
 	require.Contains(t, string(data),
 		fmt.Sprintf(`"diff":"[{Path:ServiceSettings.ReadTimeout BaseVal:%d ActualVal:%d}]"`,
 			timeoutVal, timeoutVal+1))
+	require.Contains(t, string(data), `"event_data":`)
+}
+
+func TestPatchFeatureFlagOverrides(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("as regular user", func(t *testing.T) {
+		_, resp, err := th.Client.PatchFeatureFlagOverrides(map[string]*string{"TestFeature": model.NewString("on")})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("set and remove an override", func(t *testing.T) {
+		overrides, _, err := th.SystemAdminClient.PatchFeatureFlagOverrides(map[string]*string{"TestFeature": model.NewString("overridden")})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"TestFeature": "overridden"}, overrides)
+		assert.Equal(t, "overridden", th.App.Config().FeatureFlags.TestFeature)
+
+		overrides, _, err = th.SystemAdminClient.PatchFeatureFlagOverrides(map[string]*string{"TestFeature": nil})
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+		assert.NotEqual(t, "overridden", th.App.Config().FeatureFlags.TestFeature)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.PatchFeatureFlagOverrides(map[string]*string{"NotAFeature": model.NewString("true")})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("cloud license", func(t *testing.T) {
+		th.App.Srv().SetLicense(model.NewTestLicense("cloud"))
+		defer th.App.Srv().RemoveLicense()
+
+		_, resp, err := th.SystemAdminClient.PatchFeatureFlagOverrides(map[string]*string{"TestFeature": model.NewString("on")})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
 }
 
 func TestGetEnvironmentConfig(t *testing.T) {
@@ -643,6 +789,31 @@ func TestGetEnvironmentConfig(t *testing.T) {
 	})
 }
 
+func TestGetOldClientConfigFeatureFlagRules(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.FeatureFlagRules = []*model.FeatureFlagRule{
+			{Flag: "TestFeature", Value: "canary", TeamIds: []string{th.BasicTeam.Id}},
+		}
+	})
+
+	config, _, err := th.Client.GetOldClientConfig("")
+	require.NoError(t, err)
+	require.Equal(t, "canary", config["FeatureFlagTestFeature"])
+
+	// Users outside the team keep the instance-wide value.
+	otherUser := th.CreateUser()
+	otherClient := th.CreateClient()
+	_, _, err = otherClient.Login(otherUser.Email, otherUser.Password)
+	require.NoError(t, err)
+
+	config, _, err = otherClient.GetOldClientConfig("")
+	require.NoError(t, err)
+	require.Equal(t, th.App.Config().FeatureFlags.TestFeature, config["FeatureFlagTestFeature"])
+}
+
 func TestGetOldClientConfig(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
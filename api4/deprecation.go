@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// apiDeprecations lists the endpoints and fields scheduled for removal. An entry is added here when
+// something is deprecated, given a SunsetAt once the release removing it is planned, and removed
+// along with the endpoint or field.
+var apiDeprecations = append([]*model.APIDeprecation{
+	{
+		Method:       "POST",
+		Path:         "/saml/certificate/idp",
+		Field:        "certificate.filename",
+		Description:  "The name of the uploaded file is ignored: the certificate is always saved as saml-idp.crt.",
+		DeprecatedAt: 1642291200000,
+	},
+	{
+		Method:       "POST",
+		Path:         "/saml/certificate/public",
+		Field:        "certificate.filename",
+		Description:  "The name of the uploaded file is ignored: the certificate is always saved as saml-public.crt.",
+		DeprecatedAt: 1642291200000,
+	},
+	{
+		Method:       "POST",
+		Path:         "/saml/certificate/private",
+		Field:        "certificate.filename",
+		Description:  "The name of the uploaded file is ignored: the key is always saved as saml-private.key.",
+		DeprecatedAt: 1642291200000,
+	},
+}, sessionLengthDeprecations()...)
+
+// sessionLengthDeprecations returns the deprecations of the session lengths in days, superseded by
+// the ones in hours, for each endpoint updating the configuration.
+func sessionLengthDeprecations() []*model.APIDeprecation {
+	var deprecations []*model.APIDeprecation
+	for _, path := range []string{"/config", "/config/patch"} {
+		for _, session := range []string{"Web", "Mobile", "SSO"} {
+			deprecations = append(deprecations, &model.APIDeprecation{
+				Method:       "PUT",
+				Path:         path,
+				Field:        "ServiceSettings.SessionLength" + session + "InDays",
+				Description:  "The session length in days is only used when the one in hours isn't set.",
+				Replacement:  "ServiceSettings.SessionLength" + session + "InHours",
+				DeprecatedAt: 1621123200000,
+			})
+		}
+	}
+	return deprecations
+}
+
+func (api *API) InitDeprecation() {
+	api.BaseRoutes.APIRoot.Handle("/deprecations", api.APIHandler(getDeprecations)).Methods("GET")
+
+	api.BaseRoutes.APIRoot.Use(newDeprecationMiddleware(apiDeprecations))
+}
+
+func getDeprecations(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := json.NewEncoder(w).Encode(apiDeprecations); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// newDeprecationMiddleware returns a middleware setting the deprecation headers on the responses
+// of the deprecated endpoints. It relies on the route matched by the router, so it must be used
+// by the router of the API or one of its subrouters.
+func newDeprecationMiddleware(deprecations []*model.APIDeprecation) mux.MiddlewareFunc {
+	endpoints := make(map[string]*model.APIDeprecation)
+	for _, deprecation := range deprecations {
+		if deprecation.IsEndpoint() {
+			endpoints[deprecation.Method+" "+deprecation.Path] = deprecation
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(endpoints) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if route := mux.CurrentRoute(r); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil {
+					if deprecation, ok := endpoints[r.Method+" "+routePath(template)]; ok {
+						setDeprecationHeaders(w, deprecation)
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func setDeprecationHeaders(w http.ResponseWriter, deprecation *model.APIDeprecation) {
+	w.Header().Set(model.HeaderDeprecation, deprecation.DeprecationHeader())
+	if sunset := deprecation.SunsetHeader(); sunset != "" {
+		w.Header().Set(model.HeaderSunset, sunset)
+	}
+	if link := deprecation.LinkHeader(); link != "" {
+		w.Header().Add(model.HeaderLink, link)
+	}
+}
+
+// routePath returns the path of a route template relative to the API root, without the patterns
+// of its variables, e.g. /users/{user_id} for /api/v4/users/{user_id:[A-Za-z0-9]+}.
+func routePath(template string) string {
+	template = strings.TrimPrefix(template, model.APIURLSuffix)
+
+	var sb strings.Builder
+	depth := 0
+	inPattern := false
+	for _, r := range template {
+		switch {
+		case r == '{':
+			depth++
+			if depth > 1 {
+				continue
+			}
+		case r == '}':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			inPattern = false
+		case r == ':' && depth == 1:
+			inPattern = true
+			continue
+		}
+
+		if !inPattern {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestGetDeprecations(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	deprecations, resp, err := th.Client.GetAPIDeprecations()
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	require.Len(t, deprecations, len(apiDeprecations))
+
+	for _, deprecation := range deprecations {
+		assert.NotEmpty(t, deprecation.Method)
+		assert.NotEmpty(t, deprecation.Path)
+		assert.NotEmpty(t, deprecation.Description)
+		assert.NotZero(t, deprecation.DeprecatedAt)
+	}
+}
+
+func TestDeprecationMiddleware(t *testing.T) {
+	deprecations := []*model.APIDeprecation{
+		{
+			Method:       "GET",
+			Path:         "/users/{user_id}/image",
+			DeprecatedAt: 1688169599000,
+			SunsetAt:     1704067200000,
+			Link:         "https://example.com/deprecations",
+		},
+		{
+			Method:       "PUT",
+			Path:         "/users/{user_id}/image",
+			Field:        "image.filename",
+			DeprecatedAt: 1688169599000,
+		},
+	}
+
+	router := mux.NewRouter()
+	apiRoot := router.PathPrefix(model.APIURLSuffix).Subrouter()
+	apiRoot.Use(newDeprecationMiddleware(deprecations))
+	user := apiRoot.PathPrefix("/users/{user_id:[A-Za-z0-9]+}").Subrouter()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	user.Handle("/image", ok).Methods("GET", "PUT")
+	user.Handle("", ok).Methods("GET")
+
+	serve := func(method, path string) http.Header {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Header()
+	}
+
+	t.Run("deprecated endpoint", func(t *testing.T) {
+		header := serve("GET", "/api/v4/users/"+model.NewId()+"/image")
+		assert.Equal(t, "@1688169599", header.Get(model.HeaderDeprecation))
+		assert.Equal(t, "Mon, 01 Jan 2024 00:00:00 GMT", header.Get(model.HeaderSunset))
+		assert.Contains(t, header.Get(model.HeaderLink), `rel="deprecation"`)
+	})
+
+	t.Run("deprecated field", func(t *testing.T) {
+		header := serve("PUT", "/api/v4/users/"+model.NewId()+"/image")
+		assert.Empty(t, header.Get(model.HeaderDeprecation))
+		assert.Empty(t, header.Get(model.HeaderSunset))
+	})
+
+	t.Run("other endpoint", func(t *testing.T) {
+		header := serve("GET", "/api/v4/users/"+model.NewId())
+		assert.Empty(t, header.Get(model.HeaderDeprecation))
+	})
+}
+
+func TestRoutePath(t *testing.T) {
+	assert.Equal(t, "/users/{user_id}/image", routePath("/api/v4/users/{user_id:[A-Za-z0-9]+}/image"))
+	assert.Equal(t, "/system/ping", routePath("/api/v4/system/ping"))
+	assert.Equal(t, "/emoji/name/{emoji_name}", routePath("/api/v4/emoji/name/{emoji_name:[A-Za-z0-9\\_\\-\\+]{1,64}}"))
+	assert.Equal(t, "/healthz", routePath("/healthz"))
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitDirectMessageRequest() {
+	api.BaseRoutes.User.Handle("/message_requests", api.APISessionRequired(getDirectMessageRequestsForUser)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/message_request/accept", api.APISessionRequired(acceptDirectMessageRequest)).Methods("POST")
+	api.BaseRoutes.Channel.Handle("/message_request/decline", api.APISessionRequired(declineDirectMessageRequest)).Methods("POST")
+}
+
+func getDirectMessageRequestsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	requests, appErr := c.App.GetDirectMessageRequestsForUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(requests); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// Only the recipient of a message request can accept or decline it, which the app layer checks.
+
+func acceptDirectMessageRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("acceptDirectMessageRequest", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	if appErr := c.App.AcceptDirectMessageRequest(c.Params.ChannelId, c.AppContext.Session().UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func declineDirectMessageRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("declineDirectMessageRequest", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("channel_id", c.Params.ChannelId)
+
+	if appErr := c.App.DeclineDirectMessageRequest(c.Params.ChannelId, c.AppContext.Session().UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestDirectMessageRequests(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.EnableDirectMessageRequests = true })
+
+	stranger := th.CreateUser()
+	strangerClient := th.CreateClient()
+	_, _, err := strangerClient.Login(stranger.Email, stranger.Password)
+	require.NoError(t, err)
+
+	t.Run("users sharing a channel message each other freely", func(t *testing.T) {
+		_, _, err := th.Client.CreateDirectChannel(th.BasicUser.Id, th.BasicUser2.Id)
+		require.NoError(t, err)
+
+		requests, _, err := th.SystemAdminClient.GetDirectMessageRequests(th.BasicUser2.Id)
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+	})
+
+	channel, _, err := th.Client.CreateDirectChannel(th.BasicUser.Id, stranger.Id)
+	require.NoError(t, err)
+
+	t.Run("lists the pending requests of a user", func(t *testing.T) {
+		requests, _, err := strangerClient.GetDirectMessageRequests(stranger.Id)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Equal(t, channel.Id, requests[0].ChannelId)
+		assert.Equal(t, th.BasicUser.Id, requests[0].RequesterId)
+		assert.Equal(t, model.DirectMessageRequestStatusPending, requests[0].Status)
+
+		_, resp, err := th.Client.GetDirectMessageRequests(stranger.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("only the recipient can accept or decline", func(t *testing.T) {
+		resp, err := th.Client.AcceptDirectMessageRequest(channel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeclineDirectMessageRequest(channel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("the requester can't post once declined", func(t *testing.T) {
+		_, _, err := th.Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "hello"})
+		require.NoError(t, err)
+
+		_, err = strangerClient.DeclineDirectMessageRequest(channel.Id)
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "hello again"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "api.post.create_post.direct_message_request_declined.app_error")
+
+		resp, err = strangerClient.DeclineDirectMessageRequest(channel.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("accepting lets the requester post again", func(t *testing.T) {
+		_, err := strangerClient.AcceptDirectMessageRequest(channel.Id)
+		require.NoError(t, err)
+
+		_, _, err = th.Client.CreatePost(&model.Post{ChannelId: channel.Id, Message: "hello again"})
+		require.NoError(t, err)
+
+		requests, _, err := strangerClient.GetDirectMessageRequests(stranger.Id)
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+
+		resp, err := strangerClient.AcceptDirectMessageRequest(channel.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
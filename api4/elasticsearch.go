@@ -5,6 +5,7 @@ package api4
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v6/audit"
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -43,7 +44,10 @@ func testElasticsearch(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := c.App.TestElasticsearch(cfg); err != nil {
+	start := time.Now()
+	err := c.App.TestElasticsearch(cfg)
+	c.App.RecordConnectivityTest(model.ConnectivityTestServiceElasticsearch, c.AppContext.Session().UserId, time.Since(start), err)
+	if err != nil {
 		c.Err = err
 		return
 	}
@@ -25,6 +25,8 @@ func (api *API) InitEmoji() {
 	api.BaseRoutes.Emojis.Handle("", api.APISessionRequired(getEmojiList)).Methods("GET")
 	api.BaseRoutes.Emojis.Handle("/search", api.APISessionRequired(searchEmojis)).Methods("POST")
 	api.BaseRoutes.Emojis.Handle("/autocomplete", api.APISessionRequired(autocompleteEmojis)).Methods("GET")
+	api.BaseRoutes.Emojis.Handle("/export", api.APISessionRequiredTrustRequester(exportEmojiArchive)).Methods("GET")
+	api.BaseRoutes.Emojis.Handle("/import", api.APISessionRequired(importEmojiArchive)).Methods("POST")
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(deleteEmoji)).Methods("DELETE")
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(getEmoji)).Methods("GET")
 	api.BaseRoutes.EmojiByName.Handle("", api.APISessionRequired(getEmojiByName)).Methods("GET")
@@ -90,6 +92,18 @@ func createEmoji(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	auditRec.AddMeta("emoji", emoji)
 
+	// Team scoped emoji can only be created by members of the team
+	if emoji.TeamId != "" {
+		if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), emoji.TeamId, model.PermissionViewTeam) {
+			c.SetPermissionError(model.PermissionViewTeam)
+			return
+		}
+		if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), emoji.TeamId, model.PermissionCreateEmojis) {
+			c.SetPermissionError(model.PermissionCreateEmojis)
+			return
+		}
+	}
+
 	newEmoji, err := c.App.CreateEmoji(c.AppContext.Session().UserId, &emoji, m)
 	if err != nil {
 		c.Err = err
@@ -114,7 +128,23 @@ func getEmojiList(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	listEmoji, err := c.App.GetEmojiList(c.Params.Page, c.Params.PerPage, sort)
+	var listEmoji []*model.Emoji
+	var err *model.AppError
+	if teamID := r.URL.Query().Get("team_id"); teamID != "" {
+		if !model.IsValidId(teamID) {
+			c.SetInvalidURLParam("team_id")
+			return
+		}
+
+		if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), teamID, model.PermissionViewTeam) {
+			c.SetPermissionError(model.PermissionViewTeam)
+			return
+		}
+
+		listEmoji, err = c.App.GetEmojiListForTeam(teamID, c.Params.Page, c.Params.PerPage, sort)
+	} else {
+		listEmoji, err = c.App.GetEmojiList(c.Params.Page, c.Params.PerPage, sort)
+	}
 	if err != nil {
 		c.Err = err
 		return
@@ -294,3 +324,96 @@ func autocompleteEmojis(c *Context, w http.ResponseWriter, r *http.Request) {
 		mlog.Warn("Error while writing response", mlog.Err(err))
 	}
 }
+
+func exportEmojiArchive(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().ServiceSettings.EnableCustomEmoji {
+		c.Err = model.NewAppError("exportEmojiArchive", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("exportEmojiArchive", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment;filename=\"custom_emoji.zip\"")
+
+	if err := c.App.ExportEmojiArchive(w); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+}
+
+func importEmojiArchive(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer io.Copy(ioutil.Discard, r.Body)
+
+	if !*c.App.Config().ServiceSettings.EnableCustomEmoji {
+		c.Err = model.NewAppError("importEmojiArchive", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("importEmojiArchive", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	maxSize := *c.App.Config().FileSettings.MaxFileSize
+	if r.ContentLength > maxSize {
+		c.Err = model.NewAppError("importEmojiArchive", "api.emoji.import.too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxSize); err != nil {
+		c.Err = model.NewAppError("importEmojiArchive", "api.emoji.create.parse.app_error", nil, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	collisionPolicy := r.MultipartForm.Value["collision_policy"]
+	policy := model.EmojiImportCollisionSkip
+	if len(collisionPolicy) > 0 && collisionPolicy[0] != "" {
+		policy = collisionPolicy[0]
+	}
+	if !model.IsValidEmojiImportCollisionPolicy(policy) {
+		c.SetInvalidParam("collision_policy")
+		return
+	}
+	auditRec.AddMeta("collision_policy", policy)
+
+	fileArray := r.MultipartForm.File["archive"]
+	if len(fileArray) == 0 {
+		c.SetInvalidParam("archive")
+		return
+	}
+
+	fileData := fileArray[0]
+	auditRec.AddMeta("filename", fileData.Filename)
+
+	file, err := fileData.Open()
+	if err != nil {
+		c.Err = model.NewAppError("importEmojiArchive", "api.emoji.import.open.app_error", nil, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	result, appErr := c.App.ImportEmojiArchive(c.AppContext.Session().UserId, file, fileData.Size, policy)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("imported", len(result.Imported))
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
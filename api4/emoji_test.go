@@ -4,7 +4,9 @@
 package api4
 
 import (
+	"archive/zip"
 	"bytes"
+	"encoding/json"
 	"image"
 	_ "image/gif"
 	"os"
@@ -715,3 +717,147 @@ func TestAutocompleteEmoji(t *testing.T) {
 	require.Error(t, err)
 	CheckUnauthorizedStatus(t, resp)
 }
+
+func TestEmojiArchive(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	emoji, _, err := th.SystemAdminClient.CreateEmoji(&model.Emoji{CreatorId: th.SystemAdminUser.Id, Name: "party_" + model.NewId()[:8]}, utils.CreateTestGif(t, 10, 10), "image.gif")
+	require.NoError(t, err)
+
+	t.Run("regular users can't export or import", func(t *testing.T) {
+		_, resp, err := th.Client.ExportEmojiArchive()
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.ImportEmojiArchive([]byte("junk"), "emoji.zip", model.EmojiImportCollisionSkip)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	archive, _, err := th.SystemAdminClient.ExportEmojiArchive()
+	require.NoError(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	var manifest model.EmojiArchiveManifest
+	for _, file := range zipReader.File {
+		if file.Name != model.EmojiArchiveManifestName {
+			continue
+		}
+		reader, err := file.Open()
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(reader).Decode(&manifest))
+		reader.Close()
+	}
+	require.Len(t, manifest.Emojis, 1)
+	assert.Equal(t, emoji.Name, manifest.Emojis[0].Name)
+	assert.Equal(t, "images/"+emoji.Name+".gif", manifest.Emojis[0].File)
+
+	t.Run("skip collisions", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.ImportEmojiArchive(archive, "emoji.zip", model.EmojiImportCollisionSkip)
+		require.NoError(t, err)
+		assert.Equal(t, []string{emoji.Name}, result.Skipped)
+		assert.Empty(t, result.Imported)
+	})
+
+	t.Run("overwrite collisions", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.ImportEmojiArchive(archive, "emoji.zip", model.EmojiImportCollisionOverwrite)
+		require.NoError(t, err)
+		assert.Equal(t, []string{emoji.Name}, result.Overwritten)
+	})
+
+	t.Run("rename collisions", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.ImportEmojiArchive(archive, "emoji.zip", model.EmojiImportCollisionRename)
+		require.NoError(t, err)
+		require.Equal(t, emoji.Name+"_1", result.Renamed[emoji.Name])
+
+		renamed, _, err := th.SystemAdminClient.GetEmojiByName(emoji.Name + "_1")
+		require.NoError(t, err)
+		assert.Equal(t, th.SystemAdminUser.Id, renamed.CreatorId)
+	})
+
+	t.Run("archive without manifest", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		zipWriter := zip.NewWriter(buf)
+		fileWriter, err := zipWriter.Create("emoji/slack_" + model.NewId()[:8] + ".gif")
+		require.NoError(t, err)
+		_, err = fileWriter.Write(utils.CreateTestGif(t, 10, 10))
+		require.NoError(t, err)
+		fileWriter, err = zipWriter.Create("emoji/not_an_image.txt")
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte("text"))
+		require.NoError(t, err)
+		require.NoError(t, zipWriter.Close())
+
+		result, _, err := th.SystemAdminClient.ImportEmojiArchive(buf.Bytes(), "slack.zip", "")
+		require.NoError(t, err)
+		assert.Len(t, result.Imported, 1)
+		assert.Empty(t, result.Failures)
+	})
+
+	t.Run("invalid collision policy", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.ImportEmojiArchive(archive, "emoji.zip", "merge")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestTeamScopedEmoji(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCustomEmoji = true
+		*cfg.ServiceSettings.MaxCustomEmojiPerTeam = 1
+	})
+
+	emoji := &model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId(), TeamId: th.BasicTeam.Id}
+	newEmoji, _, err := th.Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+	require.NoError(t, err)
+	require.Equal(t, th.BasicTeam.Id, newEmoji.TeamId)
+
+	t.Run("quota is enforced", func(t *testing.T) {
+		emoji := &model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId(), TeamId: th.BasicTeam.Id}
+		_, resp, err := th.Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+		CheckErrorID(t, err, "app.emoji.create.team_quota_exceeded.app_error")
+		CheckBadRequestStatus(t, resp)
+
+		// Instance wide emoji aren't subject to team quotas
+		emoji.TeamId = ""
+		_, _, err = th.Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+		require.NoError(t, err)
+	})
+
+	t.Run("list and usage", func(t *testing.T) {
+		list, _, err := th.Client.GetEmojiListForTeam(th.BasicTeam.Id, 0, 60)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, newEmoji.Id, list[0].Id)
+
+		usage, _, err := th.Client.GetTeamEmojiUsage(th.BasicTeam.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage.Count)
+		assert.Equal(t, 1, usage.Limit)
+	})
+
+	t.Run("non members can't see team emoji", func(t *testing.T) {
+		otherTeam := th.CreateTeamWithClient(th.SystemAdminClient)
+
+		_, resp, err := th.Client.GetEmojiListForTeam(otherTeam.Id, 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetTeamEmojiUsage(otherTeam.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		emoji := &model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId(), TeamId: otherTeam.Id}
+		_, resp, err = th.Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
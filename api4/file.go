@@ -62,6 +62,7 @@ func (api *API) InitFile() {
 	api.BaseRoutes.File.Handle("/link", api.APISessionRequired(getFileLink)).Methods("GET")
 	api.BaseRoutes.File.Handle("/preview", api.APISessionRequiredTrustRequester(getFilePreview)).Methods("GET")
 	api.BaseRoutes.File.Handle("/info", api.APISessionRequired(getFileInfo)).Methods("GET")
+	api.BaseRoutes.File.Handle("/alt_text", api.APISessionRequired(setFileAltText)).Methods("PUT")
 
 	api.BaseRoutes.Team.Handle("/files/search", api.APISessionRequiredDisableWhenBusy(searchFilesInTeam)).Methods("POST")
 
@@ -500,6 +501,7 @@ func getFile(c *Context, w http.ResponseWriter, r *http.Request) {
 	defer fileReader.Close()
 
 	auditRec.Success()
+	c.LogAudit("name=" + info.Name)
 
 	writeFileResponse(info.Name, info.MimeType, info.Size, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
 }
@@ -636,6 +638,47 @@ func getFileInfo(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func setFileAltText(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireFileId()
+	if c.Err != nil {
+		return
+	}
+
+	var req model.FileInfoAltTextRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&req); jsonErr != nil {
+		c.SetInvalidParam("alt_text")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("setFileAltText", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("file_id", c.Params.FileId)
+
+	info, appErr := c.App.GetFileInfo(c.Params.FileId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// Only the author of a file describes it.
+	if info.CreatorId != c.AppContext.Session().UserId {
+		c.Err = model.NewAppError("setFileAltText", "api.file.alt_text.forbidden.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		return
+	}
+
+	updated, appErr := c.App.SetFileAltText(info, req.AltText)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireFileId()
 	if c.Err != nil {
@@ -1042,6 +1042,39 @@ func TestGetFileInfo(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSetFileAltText(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	sent, err := testutils.ReadTestFile("test.png")
+	require.NoError(t, err)
+
+	fileResp, _, err := th.Client.UploadFile(sent, th.BasicChannel.Id, "test.png")
+	require.NoError(t, err)
+	fileId := fileResp.FileInfos[0].Id
+
+	info, _, err := th.Client.SetFileAltText(fileId, "a test image")
+	require.NoError(t, err)
+	assert.Equal(t, "a test image", info.AltText)
+	assert.False(t, info.AltTextGenerated)
+
+	_, resp, err := th.Client.SetFileAltText(fileId, strings.Repeat("a", model.FileInfoAltTextMaxRunes+1))
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.SetFileAltText(fileId, "hijacked")
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = th.Client.SetFileAltText(model.NewId(), "missing")
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
+
 func TestGetPublicFile(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -55,6 +55,26 @@ func (api *API) APISessionRequired(h handlerFunc) http.Handler {
 
 }
 
+// ScimSessionRequired provides a handler for the SCIM endpoints, which require the user to be logged in
+// and write their errors as SCIM errors.
+func (api *API) ScimSessionRequired(h handlerFunc) http.Handler {
+	handler := &web.Handler{
+		Srv:            api.srv,
+		HandleFunc:     h,
+		HandlerName:    web.GetHandlerName(h),
+		RequireSession: true,
+		TrustRequester: false,
+		RequireMfa:     true,
+		IsStatic:       false,
+		IsLocal:        false,
+		WriteError:     writeScimError,
+	}
+	if *api.srv.Config().ServiceSettings.WebserverMode == "gzip" {
+		return gziphandler.GzipHandler(handler)
+	}
+	return handler
+}
+
 // CloudAPIKeyRequired provides a handler for webhook endpoints to access Cloud installations from CWS
 func (api *API) CloudAPIKeyRequired(h handlerFunc) http.Handler {
 	handler := &web.Handler{
@@ -0,0 +1,38 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// InitHealth registers the liveness and readiness probes used by orchestrators such as
+// Kubernetes. Unlike the ping endpoint, they don't require the API path.
+func (api *API) InitHealth() {
+	api.BaseRoutes.Root.Handle("/healthz", api.APIHandler(getLiveness)).Methods("GET")
+	api.BaseRoutes.Root.Handle("/readyz", api.APIHandler(getReadiness)).Methods("GET")
+}
+
+// getLiveness reports that the server is alive as long as it can handle requests, so that it
+// isn't restarted because of a dependency being down.
+func getLiveness(c *Context, w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(model.MapToJSON(map[string]string{model.STATUS: model.StatusOk})))
+}
+
+// getReadiness reports whether the server is ready to receive traffic, along with the status of
+// each of its dependencies.
+func getReadiness(c *Context, w http.ResponseWriter, r *http.Request) {
+	readiness := c.App.Srv().DependencyHealth.CheckReadiness()
+	if readiness.Status != model.StatusOk {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(w).Encode(readiness); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
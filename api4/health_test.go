@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestGetLiveness(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	status, _, err := th.CreateClient().GetLiveness()
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusOk, status)
+}
+
+func TestGetReadiness(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+
+		readiness, _, err := th.CreateClient().GetReadiness()
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusOk, readiness.Status)
+
+		dependencies := map[string]*model.DependencyStatus{}
+		for _, dependency := range readiness.Dependencies {
+			dependencies[dependency.Name] = dependency
+		}
+		require.Contains(t, dependencies, model.DependencyDatabase)
+		assert.True(t, dependencies[model.DependencyDatabase].Required)
+		assert.Equal(t, model.StatusOk, dependencies[model.DependencyDatabase].Status)
+		require.Contains(t, dependencies, model.DependencyFileStore)
+		assert.False(t, dependencies[model.DependencyFileStore].Required)
+	})
+
+	t.Run("not ready when a required dependency is unreachable", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadinessRequiredDependencies = "database, smtp"
+			*cfg.EmailSettings.SendEmailNotifications = true
+			*cfg.EmailSettings.SMTPServer = "127.0.0.1"
+			*cfg.EmailSettings.SMTPPort = "1"
+		})
+
+		_, resp, err := th.CreateClient().GetReadiness()
+		require.Error(t, err)
+		CheckServiceUnavailableStatus(t, resp)
+	})
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitImpersonation() {
+	api.BaseRoutes.User.Handle("/impersonation", api.APISessionRequired(requestImpersonation)).Methods("POST")
+	api.BaseRoutes.User.Handle("/impersonation", api.APISessionRequired(getImpersonationRequestsForUser)).Methods("GET")
+
+	api.BaseRoutes.Impersonation.Handle("", api.APISessionRequired(getImpersonationRequest)).Methods("GET")
+	api.BaseRoutes.Impersonation.Handle("", api.APISessionRequired(revokeImpersonation)).Methods("DELETE")
+	api.BaseRoutes.Impersonation.Handle("/consent", api.APISessionRequired(answerImpersonationRequest)).Methods("POST")
+	api.BaseRoutes.Impersonation.Handle("/start", api.APISessionRequired(startImpersonation)).Methods("POST")
+}
+
+func requestImpersonation(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var impersonation model.ImpersonationRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&impersonation); jsonErr != nil {
+		c.SetInvalidParam("impersonation")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("requestImpersonation", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("reason", impersonation.Reason)
+	auditRec.AddMeta("duration_minutes", impersonation.DurationMinutes)
+	auditRec.AddMeta("policy_override", impersonation.PolicyOverride)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	impersonation.UserId = c.Params.UserId
+	impersonation.RequesterId = c.AppContext.Session().UserId
+
+	created, appErr := c.App.RequestImpersonation(c.AppContext, &impersonation)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("impersonation_id", created.Id)
+	auditRec.AddMeta("status", created.Status)
+	c.LogAudit("user_id=" + created.UserId)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getImpersonationRequestsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	impersonations, appErr := c.App.GetImpersonationRequestsForUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(impersonations); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getImpersonationRequestForSession returns a request, if the user of the session is the one
+// impersonated, its requester or a system admin.
+func getImpersonationRequestForSession(c *Context) *model.ImpersonationRequest {
+	c.RequireImpersonationId()
+	if c.Err != nil {
+		return nil
+	}
+
+	impersonation, appErr := c.App.GetImpersonationRequest(c.Params.ImpersonationId)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	userID := c.AppContext.Session().UserId
+	if userID != impersonation.UserId && userID != impersonation.RequesterId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return nil
+	}
+
+	return impersonation
+}
+
+func getImpersonationRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	impersonation := getImpersonationRequestForSession(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(impersonation); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func answerImpersonationRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	var answer struct {
+		Consent bool `json:"consent"`
+	}
+	if jsonErr := json.NewDecoder(r.Body).Decode(&answer); jsonErr != nil {
+		c.SetInvalidParam("consent")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("answerImpersonationRequest", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("impersonation_id", c.Params.ImpersonationId)
+	auditRec.AddMeta("consent", answer.Consent)
+
+	impersonation := getImpersonationRequestForSession(c)
+	if c.Err != nil {
+		return
+	}
+
+	// Only the impersonated user can consent.
+	if c.AppContext.Session().UserId != impersonation.UserId {
+		c.Err = model.NewAppError("answerImpersonationRequest", "api.impersonation.consent.not_user.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
+	impersonation, appErr := c.App.AnswerImpersonationRequest(c.AppContext, impersonation, answer.Consent)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("requester_id", impersonation.RequesterId)
+
+	if err := json.NewEncoder(w).Encode(impersonation); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func startImpersonation(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("startImpersonation", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("impersonation_id", c.Params.ImpersonationId)
+
+	impersonation := getImpersonationRequestForSession(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("user_id", impersonation.UserId)
+
+	// Only the requester can use the request, as long as they are a system admin.
+	if c.AppContext.Session().UserId != impersonation.RequesterId {
+		c.Err = model.NewAppError("startImpersonation", "api.impersonation.start.not_requester.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	session, appErr := c.App.StartImpersonation(c.AppContext, impersonation)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("session_id", session.Request.SessionId)
+	auditRec.AddMeta("expires_at", session.Request.ExpiresAt)
+	c.LogAudit("user_id=" + impersonation.UserId + " session_id=" + session.Request.SessionId)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(session); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func revokeImpersonation(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("revokeImpersonation", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("impersonation_id", c.Params.ImpersonationId)
+
+	impersonation := getImpersonationRequestForSession(c)
+	if c.Err != nil {
+		return
+	}
+	auditRec.AddMeta("user_id", impersonation.UserId)
+	auditRec.AddMeta("session_id", impersonation.SessionId)
+
+	impersonation, appErr := c.App.RevokeImpersonation(c.AppContext, impersonation)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(impersonation); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
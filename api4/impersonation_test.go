@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestImpersonation(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	request := &model.ImpersonationRequest{Reason: "Investigating a support ticket", DurationMinutes: 10}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RequestImpersonation(th.BasicUser.Id, request)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableImpersonation = true })
+
+	t.Run("requires a system admin", func(t *testing.T) {
+		_, resp, err := th.Client.RequestImpersonation(th.BasicUser2.Id, request)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("policy override not allowed", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RequestImpersonation(th.BasicUser.Id, &model.ImpersonationRequest{Reason: "Urgent", DurationMinutes: 10, PolicyOverride: true})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("duration above the maximum", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RequestImpersonation(th.BasicUser.Id, &model.ImpersonationRequest{Reason: "Long", DurationMinutes: 1000})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("with consent", func(t *testing.T) {
+		created, resp, err := th.SystemAdminClient.RequestImpersonation(th.BasicUser.Id, request)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, model.ImpersonationStatusPending, created.Status)
+		assert.Equal(t, th.SystemAdminUser.Id, created.RequesterId)
+
+		_, resp, err = th.SystemAdminClient.StartImpersonation(created.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		pending, _, err := th.Client.GetImpersonationRequestsForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, created.Id, pending[0].Id)
+
+		_, resp, err = th.SystemAdminClient.AnswerImpersonationRequest(created.Id, true)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		answered, _, err := th.Client.AnswerImpersonationRequest(created.Id, true)
+		require.NoError(t, err)
+		assert.Equal(t, model.ImpersonationStatusApproved, answered.Status)
+
+		session, resp, err := th.SystemAdminClient.StartImpersonation(created.Id)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, model.ImpersonationStatusActive, session.Request.Status)
+		assert.LessOrEqual(t, session.Request.ExpiresAt, model.GetMillis()+10*60*1000)
+
+		_, resp, err = th.SystemAdminClient.StartImpersonation(created.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		impersonator := th.CreateClient()
+		impersonator.AuthToken = session.Token
+		impersonator.AuthType = model.HeaderBearer
+
+		me, _, err := impersonator.GetMe("")
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser.Id, me.Id)
+
+		_, resp, err = impersonator.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "not allowed"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = impersonator.AnswerImpersonationRequest(created.Id, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		revoked, _, err := th.Client.RevokeImpersonation(created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ImpersonationStatusRevoked, revoked.Status)
+
+		_, resp, err = impersonator.GetMe("")
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		created, _, err := th.SystemAdminClient.RequestImpersonation(th.BasicUser.Id, request)
+		require.NoError(t, err)
+
+		answered, _, err := th.Client.AnswerImpersonationRequest(created.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, model.ImpersonationStatusDenied, answered.Status)
+
+		_, resp, err := th.SystemAdminClient.StartImpersonation(created.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("with policy override", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ImpersonationAllowPolicyOverride = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ImpersonationAllowPolicyOverride = false })
+
+		created, _, err := th.SystemAdminClient.RequestImpersonation(th.BasicUser2.Id, &model.ImpersonationRequest{Reason: "Urgent", DurationMinutes: 5, PolicyOverride: true})
+		require.NoError(t, err)
+		assert.Equal(t, model.ImpersonationStatusApproved, created.Status)
+
+		session, _, err := th.SystemAdminClient.StartImpersonation(created.Id)
+		require.NoError(t, err)
+
+		_, _, err = th.SystemAdminClient.RevokeImpersonation(created.Id)
+		require.NoError(t, err)
+
+		_, appErr := th.App.GetSession(session.Token)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("other users cannot see a request", func(t *testing.T) {
+		created, _, err := th.SystemAdminClient.RequestImpersonation(th.BasicUser.Id, request)
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.GetImpersonationRequestsForUser(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+		_, resp, err = th.Client.GetImpersonationRequest(created.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -94,7 +94,7 @@ func submitDialog(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if submit.URL == "" {
+	if submit.URL == "" && submit.StateToken == "" {
 		c.SetInvalidParam("url")
 		return
 	}
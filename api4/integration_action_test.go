@@ -273,3 +273,109 @@ func TestSubmitDialog(t *testing.T) {
 	CheckForbiddenStatus(t, resp)
 	assert.Nil(t, submitResp)
 }
+
+func TestSubmitMultiStepDialog(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request model.SubmitDialogRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		require.NoError(t, err)
+
+		var response model.SubmitDialogResponse
+		switch request.Step {
+		case 0:
+			assert.Equal(t, "first", request.CallbackId)
+			response.Next = &model.Dialog{
+				CallbackId: "second",
+				State:      "secondstate",
+				Elements:   []model.DialogElement{{Name: "name", Type: "text", MinLength: 3}},
+			}
+		case 1:
+			assert.Equal(t, "second", request.CallbackId)
+			assert.Equal(t, "secondstate", request.State)
+			require.Len(t, request.Submissions, 1)
+			assert.Equal(t, "firstvalue", request.Submissions[0]["value"])
+			if request.Submission["name"] == "rejected" {
+				response.Errors = map[string]string{"name": "rejected"}
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer ts.Close()
+
+	submit := model.SubmitDialogRequest{
+		URL:        ts.URL,
+		CallbackId: "first",
+		ChannelId:  th.BasicChannel.Id,
+		TeamId:     th.BasicTeam.Id,
+		Submission: map[string]interface{}{"value": "firstvalue"},
+	}
+
+	submitResp, _, err := client.SubmitInteractiveDialog(submit)
+	require.NoError(t, err)
+	require.NotNil(t, submitResp.Next)
+	assert.Empty(t, submitResp.Next.State, "the integration state is held by the server")
+	require.NotEmpty(t, submitResp.StateToken)
+	stateToken := submitResp.StateToken
+
+	t.Run("validated by the server", func(t *testing.T) {
+		submitResp, _, err := client.SubmitInteractiveDialog(model.SubmitDialogRequest{
+			StateToken: stateToken,
+			ChannelId:  th.BasicChannel.Id,
+			TeamId:     th.BasicTeam.Id,
+			Submission: map[string]interface{}{"name": "ab"},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, submitResp.Errors, "name")
+		assert.Equal(t, stateToken, submitResp.StateToken)
+	})
+
+	t.Run("rejected by the integration", func(t *testing.T) {
+		submitResp, _, err := client.SubmitInteractiveDialog(model.SubmitDialogRequest{
+			StateToken: stateToken,
+			ChannelId:  th.BasicChannel.Id,
+			TeamId:     th.BasicTeam.Id,
+			Submission: map[string]interface{}{"name": "rejected"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "rejected", submitResp.Errors["name"])
+		assert.Equal(t, stateToken, submitResp.StateToken)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.SubmitInteractiveDialog(model.SubmitDialogRequest{
+			StateToken: stateToken,
+			ChannelId:  th.BasicChannel.Id,
+			TeamId:     th.BasicTeam.Id,
+			Submission: map[string]interface{}{"name": "accepted"},
+		})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	submitResp, _, err = client.SubmitInteractiveDialog(model.SubmitDialogRequest{
+		StateToken: stateToken,
+		ChannelId:  th.BasicChannel.Id,
+		TeamId:     th.BasicTeam.Id,
+		Submission: map[string]interface{}{"name": "accepted"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, submitResp.Next)
+	assert.Empty(t, submitResp.StateToken)
+
+	_, resp, err := client.SubmitInteractiveDialog(model.SubmitDialogRequest{
+		StateToken: stateToken,
+		ChannelId:  th.BasicChannel.Id,
+		TeamId:     th.BasicTeam.Id,
+		Submission: map[string]interface{}{"name": "accepted"},
+	})
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
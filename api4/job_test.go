@@ -265,6 +265,9 @@ func TestDownloadJob(t *testing.T) {
 	_, _, err = th.SystemAdminClient.DownloadJob(job.Id)
 	require.NoError(t, err)
 
+	_, _, err = th.LocalClient.DownloadJob(job.Id)
+	require.NoError(t, err)
+
 	// Here we are creating a new job which doesn't have type of message export
 	jobName = model.NewId()
 	job = &model.Job{
@@ -308,3 +308,35 @@ func TestRequestRenewalLink(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
+
+func TestGetLicenseStatus(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("regular user", func(t *testing.T) {
+		_, resp, err := th.Client.GetLicenseStatus()
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("no license", func(t *testing.T) {
+		th.App.Srv().SetLicense(nil)
+		_, resp, err := th.SystemAdminClient.GetLicenseStatus()
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("seat overage", func(t *testing.T) {
+		license := model.NewTestLicense()
+		*license.Features.Users = 1
+		th.App.Srv().SetLicense(license)
+
+		status, _, err := th.SystemAdminClient.GetLicenseStatus()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), status.LicensedSeats)
+		require.Greater(t, status.UsedSeats, int64(1))
+		require.Equal(t, model.LicenseSeatStateOverage, status.SeatState)
+		require.Equal(t, status.UsedSeats-1, status.OverageSeats)
+		require.False(t, status.IsExpired)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitMarkdown() {
+	api.BaseRoutes.Markdown.Handle("/render", api.APISessionRequired(renderMarkdown)).Methods("POST")
+}
+
+func renderMarkdown(c *Context, w http.ResponseWriter, r *http.Request) {
+	var req model.MarkdownRenderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParam("text")
+		return
+	}
+
+	if appErr := req.IsValid(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(&model.MarkdownRenderResponse{HTML: c.App.RenderMarkdown(req.Text)}); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
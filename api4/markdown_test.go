@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("renders links, lists and code", func(t *testing.T) {
+		html, _, err := th.Client.RenderMarkdown("Hello [site](https://example.com) and www.example.org\n\n- one\n- two\n\n```go\nx := <b>\n```")
+		require.NoError(t, err)
+		assert.Equal(t, `<p>Hello <a href="https://example.com" rel="nofollow">site</a> and <a href="http://www.example.org" rel="nofollow">www.example.org</a></p>`+
+			`<ul><li>one</li><li>two</li></ul>`+
+			`<pre><code class="language-go">x := &lt;b&gt;`+"\n"+`</code></pre>`, html)
+	})
+
+	t.Run("sanitizes the output", func(t *testing.T) {
+		html, _, err := th.Client.RenderMarkdown("[click](javascript:alert(1)) <script>alert(1)</script>")
+		require.NoError(t, err)
+		assert.NotContains(t, html, "javascript:")
+		assert.NotContains(t, html, "<script>")
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, resp, err := th.Client.RenderMarkdown(strings.Repeat("a", model.MarkdownRenderMaxRunes+1))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires a session", func(t *testing.T) {
+		client := th.CreateClient()
+		_, resp, err := client.RenderMarkdown("text")
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitOnboardingTask() {
+	api.BaseRoutes.Onboarding.Handle("/tasks", api.APISessionRequired(getOnboardingChecklist)).Methods("GET")
+	api.BaseRoutes.Onboarding.Handle("/tasks", api.APISessionRequired(createOnboardingTask)).Methods("POST")
+	api.BaseRoutes.OnboardingTask.Handle("", api.APISessionRequired(updateOnboardingTask)).Methods("PUT")
+	api.BaseRoutes.OnboardingTask.Handle("", api.APISessionRequired(deleteOnboardingTask)).Methods("DELETE")
+	api.BaseRoutes.OnboardingTask.Handle("/complete", api.APISessionRequired(completeOnboardingTask)).Methods("POST")
+	api.BaseRoutes.OnboardingTask.Handle("/complete", api.APISessionRequired(resetOnboardingTask)).Methods("DELETE")
+}
+
+func getOnboardingChecklist(c *Context, w http.ResponseWriter, r *http.Request) {
+	isAdmin := c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem)
+
+	checklist, err := c.App.GetOnboardingChecklist(c.AppContext.Session().UserId, isAdmin)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(checklist); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createOnboardingTask(c *Context, w http.ResponseWriter, r *http.Request) {
+	var task model.OnboardingTask
+	if jsonErr := json.NewDecoder(r.Body).Decode(&task); jsonErr != nil {
+		c.SetInvalidParam("onboarding_task")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createOnboardingTask", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("title", task.Title)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	task.CreatorId = c.AppContext.Session().UserId
+
+	rtask, err := c.App.CreateOnboardingTask(&task)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("onboarding_task_id", rtask.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(rtask); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateOnboardingTask(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOnboardingTaskId()
+	if c.Err != nil {
+		return
+	}
+
+	var task model.OnboardingTask
+	if jsonErr := json.NewDecoder(r.Body).Decode(&task); jsonErr != nil {
+		c.SetInvalidParam("onboarding_task")
+		return
+	}
+
+	// The task id in the URL will override any that may be in the body.
+	task.Id = c.Params.OnboardingTaskId
+
+	auditRec := c.MakeAuditRecord("updateOnboardingTask", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("onboarding_task_id", task.Id)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	rtask, err := c.App.UpdateOnboardingTask(&task)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(rtask); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteOnboardingTask(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOnboardingTaskId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteOnboardingTask", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("onboarding_task_id", c.Params.OnboardingTaskId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	if err := c.App.DeleteOnboardingTask(c.Params.OnboardingTaskId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func completeOnboardingTask(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOnboardingTaskId()
+	if c.Err != nil {
+		return
+	}
+
+	isAdmin := c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem)
+	if err := c.App.CompleteOnboardingTask(c.AppContext.Session().UserId, c.Params.OnboardingTaskId, isAdmin); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func resetOnboardingTask(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOnboardingTaskId()
+	if c.Err != nil {
+		return
+	}
+
+	isAdmin := c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem)
+	if err := c.App.ResetOnboardingTask(c.AppContext.Session().UserId, c.Params.OnboardingTaskId, isAdmin); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
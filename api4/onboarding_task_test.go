@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func findOnboardingTask(checklist []*model.OnboardingTaskStatus, taskID string) *model.OnboardingTaskStatus {
+	for _, task := range checklist {
+		if task.Id == taskID {
+			return task
+		}
+	}
+	return nil
+}
+
+func TestGetOnboardingChecklist(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	checklist, _, err := th.Client.GetOnboardingChecklist()
+	require.NoError(t, err)
+
+	profileTask := findOnboardingTask(checklist, model.OnboardingTaskCompleteProfile)
+	require.NotNil(t, profileTask)
+	assert.Equal(t, "Complete your profile", profileTask.Title)
+	assert.Zero(t, profileTask.CompleteAt)
+	assert.Nil(t, findOnboardingTask(checklist, model.OnboardingTaskVisitSystemConsole), "admin tasks should be hidden from regular users")
+
+	checklist, _, err = th.SystemAdminClient.GetOnboardingChecklist()
+	require.NoError(t, err)
+	assert.NotNil(t, findOnboardingTask(checklist, model.OnboardingTaskVisitSystemConsole))
+}
+
+func TestCompleteOnboardingTask(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	_, err := th.Client.CompleteOnboardingTask(model.OnboardingTaskCompleteProfile)
+	require.NoError(t, err)
+
+	checklist, _, err := th.Client.GetOnboardingChecklist()
+	require.NoError(t, err)
+	assert.NotZero(t, findOnboardingTask(checklist, model.OnboardingTaskCompleteProfile).CompleteAt)
+
+	_, err = th.Client.ResetOnboardingTask(model.OnboardingTaskCompleteProfile)
+	require.NoError(t, err)
+
+	checklist, _, err = th.Client.GetOnboardingChecklist()
+	require.NoError(t, err)
+	assert.Zero(t, findOnboardingTask(checklist, model.OnboardingTaskCompleteProfile).CompleteAt)
+
+	t.Run("unknown task", func(t *testing.T) {
+		resp, err := th.Client.CompleteOnboardingTask("unknown_task")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("admin task as a regular user", func(t *testing.T) {
+		resp, err := th.Client.CompleteOnboardingTask(model.OnboardingTaskVisitSystemConsole)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestCustomOnboardingTasks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newTask := &model.OnboardingTask{Title: "Read the handbook", Link: "https://example.com/handbook", SortOrder: 15}
+
+	_, resp, err := th.Client.CreateOnboardingTask(newTask)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	task, resp, err := th.SystemAdminClient.CreateOnboardingTask(newTask)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	assert.False(t, task.BuiltIn)
+
+	checklist, _, err := th.Client.GetOnboardingChecklist()
+	require.NoError(t, err)
+	require.NotNil(t, findOnboardingTask(checklist, task.Id))
+	assert.Equal(t, model.OnboardingTaskCompleteProfile, checklist[0].Id)
+	assert.Equal(t, task.Id, checklist[1].Id)
+
+	_, err = th.Client.CompleteOnboardingTask(task.Id)
+	require.NoError(t, err)
+
+	task.Audience = model.OnboardingTaskAudienceAdmins
+	_, resp, err = th.Client.UpdateOnboardingTask(task)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, _, err = th.SystemAdminClient.UpdateOnboardingTask(task)
+	require.NoError(t, err)
+
+	checklist, _, err = th.Client.GetOnboardingChecklist()
+	require.NoError(t, err)
+	assert.Nil(t, findOnboardingTask(checklist, task.Id))
+
+	t.Run("built-in tasks can't be modified", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.DeleteOnboardingTask(model.OnboardingTaskCompleteProfile)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	resp, err = th.Client.DeleteOnboardingTask(task.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, err = th.SystemAdminClient.DeleteOnboardingTask(task.Id)
+	require.NoError(t, err)
+
+	checklist, _, err = th.SystemAdminClient.GetOnboardingChecklist()
+	require.NoError(t, err)
+	assert.Nil(t, findOnboardingTask(checklist, task.Id))
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitOutgoingOAuthConnection() {
+	api.BaseRoutes.OutgoingOAuthConnections.Handle("", api.APISessionRequired(createOutgoingOAuthConnection)).Methods("POST")
+	api.BaseRoutes.OutgoingOAuthConnections.Handle("", api.APISessionRequired(getOutgoingOAuthConnections)).Methods("GET")
+	api.BaseRoutes.OutgoingOAuthConnection.Handle("", api.APISessionRequired(getOutgoingOAuthConnection)).Methods("GET")
+	api.BaseRoutes.OutgoingOAuthConnection.Handle("", api.APISessionRequired(updateOutgoingOAuthConnection)).Methods("PUT")
+	api.BaseRoutes.OutgoingOAuthConnection.Handle("", api.APISessionRequired(deleteOutgoingOAuthConnection)).Methods("DELETE")
+}
+
+func createOutgoingOAuthConnection(c *Context, w http.ResponseWriter, r *http.Request) {
+	var connection model.OutgoingOAuthConnection
+	if jsonErr := json.NewDecoder(r.Body).Decode(&connection); jsonErr != nil {
+		c.SetInvalidParam("outgoing_oauth_connection")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createOutgoingOAuthConnection", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("outgoing_oauth_connection_name", connection.Name)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteIntegrationsIntegrationManagement) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteIntegrationsIntegrationManagement)
+		return
+	}
+
+	connection.CreatorId = c.AppContext.Session().UserId
+
+	rconnection, err := c.App.CreateOutgoingOAuthConnection(&connection)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("outgoing_oauth_connection_id", rconnection.Id)
+
+	rconnection.Sanitize()
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(rconnection); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getOutgoingOAuthConnections(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadIntegrationsIntegrationManagement) {
+		c.SetPermissionError(model.PermissionSysconsoleReadIntegrationsIntegrationManagement)
+		return
+	}
+
+	connections, err := c.App.GetOutgoingOAuthConnections(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	for _, connection := range connections {
+		connection.Sanitize()
+	}
+
+	if err := json.NewEncoder(w).Encode(connections); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getOutgoingOAuthConnection(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOutgoingOAuthConnectionId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadIntegrationsIntegrationManagement) {
+		c.SetPermissionError(model.PermissionSysconsoleReadIntegrationsIntegrationManagement)
+		return
+	}
+
+	connection, err := c.App.GetOutgoingOAuthConnection(c.Params.OutgoingOAuthConnectionId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	connection.Sanitize()
+
+	if err := json.NewEncoder(w).Encode(connection); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateOutgoingOAuthConnection(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOutgoingOAuthConnectionId()
+	if c.Err != nil {
+		return
+	}
+
+	var connection model.OutgoingOAuthConnection
+	if jsonErr := json.NewDecoder(r.Body).Decode(&connection); jsonErr != nil {
+		c.SetInvalidParam("outgoing_oauth_connection")
+		return
+	}
+
+	// The connection id in the URL will override any that may be in the body.
+	connection.Id = c.Params.OutgoingOAuthConnectionId
+
+	auditRec := c.MakeAuditRecord("updateOutgoingOAuthConnection", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("outgoing_oauth_connection_id", connection.Id)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteIntegrationsIntegrationManagement) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteIntegrationsIntegrationManagement)
+		return
+	}
+
+	rconnection, err := c.App.UpdateOutgoingOAuthConnection(&connection)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	rconnection.Sanitize()
+
+	if err := json.NewEncoder(w).Encode(rconnection); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteOutgoingOAuthConnection(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOutgoingOAuthConnectionId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteOutgoingOAuthConnection", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("outgoing_oauth_connection_id", c.Params.OutgoingOAuthConnectionId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteIntegrationsIntegrationManagement) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteIntegrationsIntegrationManagement)
+		return
+	}
+
+	if err := c.App.DeleteOutgoingOAuthConnection(c.Params.OutgoingOAuthConnectionId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func newTestOutgoingOAuthConnection() *model.OutgoingOAuthConnection {
+	return &model.OutgoingOAuthConnection{
+		Name:          "Provider",
+		ClientId:      "client",
+		ClientSecret:  "secret",
+		OAuthTokenURL: "https://auth.example.com/token",
+		GrantType:     model.OutgoingOAuthConnectionGrantTypeClientCredentials,
+		Audiences:     model.StringArray{"https://integration.example.com"},
+	}
+}
+
+func TestOutgoingOAuthConnectionCRUD(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("regular users can't manage connections", func(t *testing.T) {
+		_, resp, err := th.Client.CreateOutgoingOAuthConnection(newTestOutgoingOAuthConnection())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetOutgoingOAuthConnections(0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	connection, resp, err := th.SystemAdminClient.CreateOutgoingOAuthConnection(newTestOutgoingOAuthConnection())
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.NotEmpty(t, connection.Id)
+	assert.Equal(t, th.SystemAdminUser.Id, connection.CreatorId)
+	assert.Empty(t, connection.ClientSecret)
+
+	t.Run("invalid connection", func(t *testing.T) {
+		invalid := newTestOutgoingOAuthConnection()
+		invalid.Audiences = nil
+		_, resp, err := th.SystemAdminClient.CreateOutgoingOAuthConnection(invalid)
+		CheckErrorID(t, err, "model.outgoing_oauth_connection.is_valid.audiences.app_error")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		fetched, _, err := th.SystemAdminClient.GetOutgoingOAuthConnection(connection.Id)
+		require.NoError(t, err)
+		assert.Equal(t, connection.Name, fetched.Name)
+		assert.Empty(t, fetched.ClientSecret)
+
+		list, _, err := th.SystemAdminClient.GetOutgoingOAuthConnections(0, 10)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Empty(t, list[0].ClientSecret)
+
+		_, resp, err := th.SystemAdminClient.GetOutgoingOAuthConnection(model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("update keeps the secret", func(t *testing.T) {
+		connection.Name = "Renamed"
+		updated, _, err := th.SystemAdminClient.UpdateOutgoingOAuthConnection(connection)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", updated.Name)
+
+		stored, appErr := th.App.GetOutgoingOAuthConnection(connection.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "secret", stored.ClientSecret)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := th.Client.DeleteOutgoingOAuthConnection(connection.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeleteOutgoingOAuthConnection(connection.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.SystemAdminClient.GetOutgoingOAuthConnection(connection.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
@@ -477,6 +477,43 @@ func TestDisableOnRemove(t *testing.T) {
 	}
 }
 
+func TestGetPluginStatuses(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, _, err := client.GetPluginStatuses()
+		require.NoError(t, err)
+	})
+
+	_, resp, err := th.Client.GetPluginStatuses()
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestGetPluginResourceUsage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := client.GetPluginResourceUsage("com.mattermost.notinstalled")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	_, resp, err := th.Client.GetPluginResourceUsage("com.mattermost.notinstalled")
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
 func TestGetMarketplacePlugins(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -21,6 +21,7 @@ func (api *API) InitPost() {
 	api.BaseRoutes.Post.Handle("", api.APISessionRequired(getPost)).Methods("GET")
 	api.BaseRoutes.Post.Handle("", api.APISessionRequired(deletePost)).Methods("DELETE")
 	api.BaseRoutes.Posts.Handle("/ids", api.APISessionRequired(getPostsByIds)).Methods("POST")
+	api.BaseRoutes.Posts.Handle("/delete/batch", api.APISessionRequired(deletePosts)).Methods("POST")
 	api.BaseRoutes.Posts.Handle("/ephemeral", api.APISessionRequired(createEphemeralPost)).Methods("POST")
 	api.BaseRoutes.Post.Handle("/thread", api.APISessionRequired(getPostThread)).Methods("GET")
 	api.BaseRoutes.Post.Handle("/files/info", api.APISessionRequired(getFileInfosForPost)).Methods("GET")
@@ -30,7 +31,9 @@ func (api *API) InitPost() {
 	api.BaseRoutes.ChannelForUser.Handle("/posts/unread", api.APISessionRequired(getPostsForChannelAroundLastUnread)).Methods("GET")
 
 	api.BaseRoutes.Team.Handle("/posts/search", api.APISessionRequiredDisableWhenBusy(searchPostsInTeam)).Methods("POST")
+	api.BaseRoutes.Team.Handle("/posts/archive/search", api.APISessionRequiredDisableWhenBusy(searchArchivedPostsInTeam)).Methods("POST")
 	api.BaseRoutes.Posts.Handle("/search", api.APISessionRequiredDisableWhenBusy(searchPostsInAllTeams)).Methods("POST")
+	api.BaseRoutes.Posts.Handle("/search/advanced", api.APISessionRequiredDisableWhenBusy(advancedSearchPosts)).Methods("POST")
 	api.BaseRoutes.Post.Handle("", api.APISessionRequired(updatePost)).Methods("PUT")
 	api.BaseRoutes.Post.Handle("/patch", api.APISessionRequired(patchPost)).Methods("PUT")
 	api.BaseRoutes.PostForUser.Handle("/set_unread", api.APISessionRequired(setPostUnread)).Methods("POST")
@@ -499,6 +502,57 @@ func deletePost(c *Context, w http.ResponseWriter, _ *http.Request) {
 	ReturnStatusOK(w)
 }
 
+// deletePosts deletes several posts at once, each independently of the others.
+func deletePosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	postIDs := model.ArrayFromJSON(r.Body)
+	if !checkBulkItems(c, len(postIDs)) {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deletePosts", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	auditRec.AddMeta("post_ids", postIDs)
+
+	resp := model.NewBulkResponse(len(postIDs))
+	for _, postID := range postIDs {
+		if !model.IsValidId(postID) {
+			resp.AddError(postID, web.NewInvalidParamError("post_id"))
+			continue
+		}
+
+		post, err := c.App.GetSinglePost(postID)
+		if err != nil {
+			// The existence of the posts the user can't see isn't revealed.
+			resp.AddError(postID, c.App.MakePermissionError(c.AppContext.Session(), []*model.Permission{model.PermissionDeletePost}))
+			continue
+		}
+
+		permission := model.PermissionDeletePost
+		if c.AppContext.Session().UserId != post.UserId {
+			permission = model.PermissionDeleteOthersPosts
+		}
+		if !c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), post.ChannelId, permission) {
+			resp.AddError(postID, c.App.MakePermissionError(c.AppContext.Session(), []*model.Permission{permission}))
+			continue
+		}
+
+		if _, err := c.App.DeletePost(postID, c.AppContext.Session().UserId); err != nil {
+			resp.AddError(postID, err)
+			continue
+		}
+
+		resp.AddSuccess(postID, http.StatusOK, nil)
+	}
+
+	auditRec.AddMeta("succeeded", resp.Succeeded)
+	auditRec.AddMeta("failed", resp.Failed)
+	if resp.Succeeded > 0 {
+		auditRec.Success()
+	}
+
+	writeBulkResponse(c, w, resp)
+}
+
 func getPostThread(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -682,6 +736,136 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request, teamId stri
 	}
 }
 
+func searchArchivedPostsInTeam(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionViewTeam) {
+		c.SetPermissionError(model.PermissionViewTeam)
+		return
+	}
+
+	var params model.SearchParameter
+	if jsonErr := json.NewDecoder(r.Body).Decode(&params); jsonErr != nil {
+		c.Err = model.NewAppError("searchArchivedPostsInTeam", "api.post.search_posts.invalid_body.app_error", nil, jsonErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if params.Terms == nil || *params.Terms == "" {
+		c.SetInvalidParam("terms")
+		return
+	}
+
+	page := 0
+	if params.Page != nil {
+		page = *params.Page
+	}
+
+	perPage := 60
+	if params.PerPage != nil {
+		perPage = *params.PerPage
+	}
+
+	if page < 0 {
+		c.SetInvalidParam("page")
+		return
+	}
+
+	if perPage <= 0 || perPage > web.PerPageMaximum {
+		c.SetInvalidParam("per_page")
+		return
+	}
+
+	list, err := c.App.SearchArchivedPosts(c.Params.TeamId, c.AppContext.Session().UserId, *params.Terms, page, perPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	clientPostList := c.App.PreparePostListForClient(list)
+	clientPostList, err = c.App.SanitizePostListMetadataForUser(clientPostList, c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if err := clientPostList.EncodeJSON(w); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func advancedSearchPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	var params model.AdvancedSearchParameter
+	if jsonErr := json.NewDecoder(r.Body).Decode(&params); jsonErr != nil {
+		c.Err = model.NewAppError("advancedSearchPosts", "api.post.search_posts.invalid_body.app_error", nil, jsonErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	terms := ""
+	if params.Terms != nil {
+		terms = *params.Terms
+	}
+
+	regex := ""
+	if params.Regex != nil {
+		regex = *params.Regex
+	}
+
+	if terms == "" && regex == "" {
+		c.SetInvalidParam("terms")
+		return
+	}
+
+	teamID := ""
+	if params.TeamId != nil {
+		teamID = *params.TeamId
+	}
+	if teamID != "" && !model.IsValidId(teamID) {
+		c.SetInvalidParam("team_id")
+		return
+	}
+
+	timeZoneOffset := 0
+	if params.TimeZoneOffset != nil {
+		timeZoneOffset = *params.TimeZoneOffset
+	}
+
+	auditRec := c.MakeAuditRecord("advancedSearchPosts", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	auditRec.AddMeta("terms", terms)
+	auditRec.AddMeta("regex", regex)
+	auditRec.AddMeta("team_id", teamID)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	list, err := c.App.AdvancedSearchPosts(teamID, terms, regex, timeZoneOffset)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("results", len(list.Order))
+
+	clientPostList := c.App.PreparePostListForClient(list)
+	clientPostList, err = c.App.SanitizePostListMetadataForUser(clientPostList, c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if err := clientPostList.EncodeJSON(w); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func updatePost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitPostPropSchema() {
+	api.BaseRoutes.PostPropSchemas.Handle("", api.APISessionRequired(getPostPropSchemas)).Methods("GET")
+	api.BaseRoutes.PostPropSchema.Handle("", api.APISessionRequired(getPostPropSchema)).Methods("GET")
+	api.BaseRoutes.PostPropSchema.Handle("", api.APISessionRequired(registerPostPropSchema)).Methods("PUT")
+	api.BaseRoutes.PostPropSchema.Handle("", api.APISessionRequired(deletePostPropSchema)).Methods("DELETE")
+}
+
+func getPostPropSchemas(c *Context, w http.ResponseWriter, r *http.Request) {
+	schemas, appErr := c.App.GetPostPropSchemas()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(schemas); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPostPropSchema(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireNamespace()
+	if c.Err != nil {
+		return
+	}
+
+	schema, appErr := c.App.GetPostPropSchema(c.Params.Namespace)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(schema); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// registerPostPropSchema registers the JSON schema in the body of the request for the namespace,
+// replacing the one registered before.
+func registerPostPropSchema(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireNamespace()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("registerPostPropSchema", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("namespace", c.Params.Namespace)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteIntegrationsIntegrationManagement) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteIntegrationsIntegrationManagement)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, model.PostPropSchemaMaxSize+1))
+	if err != nil || !json.Valid(body) {
+		c.SetInvalidParam("schema")
+		return
+	}
+
+	schema, appErr := c.App.RegisterPostPropSchema(c.Params.Namespace, body, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(schema); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deletePostPropSchema(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireNamespace()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deletePostPropSchema", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("namespace", c.Params.Namespace)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteIntegrationsIntegrationManagement) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteIntegrationsIntegrationManagement)
+		return
+	}
+
+	if appErr := c.App.DeletePostPropSchema(c.Params.Namespace); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
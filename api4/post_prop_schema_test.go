@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestPostPropSchemas(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	const namespace = "com.example.poll"
+	pollSchema := []byte(`{
+		"type": "object",
+		"required": ["question"],
+		"properties": {
+			"question": {"type": "string", "maxLength": 20},
+			"options": {"type": "array", "items": {"type": "string"}}
+		}
+	}`)
+
+	t.Run("registering requires the integration management permission", func(t *testing.T) {
+		_, resp, err := th.Client.RegisterPostPropSchema(namespace, pollSchema)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid namespaces and schemas are rejected", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RegisterPostPropSchema("attachments", pollSchema)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.RegisterPostPropSchema(namespace, []byte(`{"type": "object"`))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.RegisterPostPropSchema(namespace, []byte(`{"anyOf": [{"type": "object"}]}`))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	schema, _, err := th.SystemAdminClient.RegisterPostPropSchema(namespace, pollSchema)
+	require.NoError(t, err)
+	assert.Equal(t, namespace, schema.Namespace)
+	assert.Equal(t, th.SystemAdminUser.Id, schema.CreatorId)
+
+	t.Run("schemas are exposed to users", func(t *testing.T) {
+		schemas, _, err := th.Client.GetPostPropSchemas()
+		require.NoError(t, err)
+		require.Len(t, schemas, 1)
+		assert.JSONEq(t, string(pollSchema), string(schemas[0].Schema))
+
+		got, _, err := th.Client.GetPostPropSchema(namespace)
+		require.NoError(t, err)
+		assert.Equal(t, schema.CreateAt, got.CreateAt)
+
+		_, resp, err := th.Client.GetPostPropSchema("com.example.missing")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("posts are validated on create", func(t *testing.T) {
+		post := &model.Post{ChannelId: th.BasicChannel.Id, Message: "poll"}
+		post.AddProp(namespace, map[string]interface{}{"options": []string{"yes", "no"}})
+		_, resp, err := th.Client.CreatePost(post)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		post.AddProp(namespace, map[string]interface{}{"question": "Lunch?", "options": []string{"yes", "no"}})
+		created, _, err := th.Client.CreatePost(post)
+		require.NoError(t, err)
+
+		t.Run("and on update", func(t *testing.T) {
+			props := created.GetProps()
+			props[namespace] = map[string]interface{}{"question": 42}
+			_, resp, err := th.Client.PatchPost(created.Id, &model.PostPatch{Props: &props})
+			require.Error(t, err)
+			CheckBadRequestStatus(t, resp)
+
+			props[namespace] = map[string]interface{}{"question": "Dinner?"}
+			patched, _, err := th.Client.PatchPost(created.Id, &model.PostPatch{Props: &props})
+			require.NoError(t, err)
+			assert.Equal(t, "Dinner?", patched.GetProp(namespace).(map[string]interface{})["question"])
+		})
+	})
+
+	t.Run("props without a schema are not validated", func(t *testing.T) {
+		post := &model.Post{ChannelId: th.BasicChannel.Id, Message: "other"}
+		post.AddProp("com.example.other", "anything")
+		_, _, err := th.Client.CreatePost(post)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := th.Client.DeletePostPropSchema(namespace)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeletePostPropSchema(namespace)
+		require.NoError(t, err)
+
+		resp, err = th.SystemAdminClient.DeletePostPropSchema(namespace)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		post := &model.Post{ChannelId: th.BasicChannel.Id, Message: "poll"}
+		post.AddProp(namespace, "no longer validated")
+		_, _, err = th.Client.CreatePost(post)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitPostReport() {
+	api.BaseRoutes.Post.Handle("/report", api.APISessionRequired(reportPost)).Methods("POST")
+
+	api.BaseRoutes.PostReports.Handle("", api.APISessionRequired(getPostReports)).Methods("GET")
+	api.BaseRoutes.PostReport.Handle("", api.APISessionRequired(getPostReport)).Methods("GET")
+	api.BaseRoutes.PostReport.Handle("/action", api.APISessionRequired(actOnPostReport)).Methods("POST")
+}
+
+// canModerateChannel returns true if the user of the session is a system admin, or moderates the
+// channel, that is can manage the roles of its members.
+func canModerateChannel(c *Context, channelID string) bool {
+	if c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		return true
+	}
+	return channelID != "" && c.App.SessionHasPermissionToChannel(*c.AppContext.Session(), channelID, model.PermissionManageChannelRoles)
+}
+
+func reportPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	var report model.PostReport
+	if jsonErr := json.NewDecoder(r.Body).Decode(&report); jsonErr != nil {
+		c.SetInvalidParam("report")
+		return
+	}
+
+	// Only the content policy reports posts for this reason.
+	if report.Reason == model.PostReportReasonContentPolicy {
+		c.SetInvalidParam("reason")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("reportPost", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+	auditRec.AddMeta("reason", report.Reason)
+
+	post, appErr := c.App.GetPostIfAuthorized(c.Params.PostId, c.AppContext.Session())
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	created, appErr := c.App.ReportPost(c.AppContext, post, c.AppContext.Session().UserId, report.Reason, report.Comment)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("report_id", created.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPostReports(c *Context, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := model.PostReportGetOptions{
+		Status:    query.Get("status"),
+		ChannelId: query.Get("channel_id"),
+		Page:      c.Params.Page,
+		PerPage:   c.Params.PerPage,
+	}
+
+	if opts.ChannelId != "" && !model.IsValidId(opts.ChannelId) {
+		c.SetInvalidURLParam("channel_id")
+		return
+	}
+
+	// Moderators of a channel can only see the reports of the channel.
+	if !canModerateChannel(c, opts.ChannelId) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	reports, appErr := c.App.GetPostReports(opts)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reports); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPostReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReportId()
+	if c.Err != nil {
+		return
+	}
+
+	report, appErr := c.App.GetPostReport(c.Params.ReportId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !canModerateChannel(c, report.ChannelId) {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func actOnPostReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReportId()
+	if c.Err != nil {
+		return
+	}
+
+	var action model.PostReportAction
+	if jsonErr := json.NewDecoder(r.Body).Decode(&action); jsonErr != nil {
+		c.SetInvalidParam("action")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("actOnPostReport", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("report_id", c.Params.ReportId)
+	auditRec.AddMeta("action", action.Action)
+	auditRec.AddMeta("timeout_minutes", action.TimeoutMinutes)
+
+	report, appErr := c.App.GetPostReport(c.Params.ReportId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddMeta("post_id", report.PostId)
+	auditRec.AddMeta("post_user_id", report.PostUserId)
+
+	if !canModerateChannel(c, report.ChannelId) {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+		return
+	}
+
+	report, appErr = c.App.ActOnPostReport(c.AppContext, report, &action, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("report_id=" + report.Id + " action=" + action.Action)
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
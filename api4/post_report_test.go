@@ -0,0 +1,117 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestPostReports(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	reporter := th.CreateClient()
+	_, _, err := reporter.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+	require.NoError(t, err)
+
+	t.Run("report a post", func(t *testing.T) {
+		_, resp, err := reporter.ReportPost(th.BasicPost.Id, "boring", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.Client.ReportPost(th.BasicPost.Id, model.PostReportReasonSpam, "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		report, resp, err := reporter.ReportPost(th.BasicPost.Id, model.PostReportReasonSpam, "buy now")
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicPost.Id, report.PostId)
+		assert.Equal(t, th.BasicUser.Id, report.PostUserId)
+		assert.Equal(t, model.PostReportStatusOpen, report.Status)
+
+		_, resp, err = reporter.ReportPost(th.BasicPost.Id, model.PostReportReasonHarassment, "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("moderation queue", func(t *testing.T) {
+		_, resp, err := reporter.GetPostReports(model.PostReportStatusOpen, "", 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = reporter.GetPostReports(model.PostReportStatusOpen, th.BasicChannel.Id, 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		reports, _, err := th.SystemAdminClient.GetPostReports(model.PostReportStatusOpen, th.BasicChannel.Id, 0, 60)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, th.BasicPost.Id, reports[0].PostId)
+
+		_, resp, err = reporter.GetPostReport(reports[0].Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = reporter.ActOnPostReport(reports[0].Id, &model.PostReportAction{Action: model.PostReportActionDismiss})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("dismiss", func(t *testing.T) {
+		post := th.CreatePost()
+		report, _, err := reporter.ReportPost(post.Id, model.PostReportReasonOther, "")
+		require.NoError(t, err)
+
+		report, _, err = th.SystemAdminClient.ActOnPostReport(report.Id, &model.PostReportAction{Action: model.PostReportActionDismiss})
+		require.NoError(t, err)
+		assert.Equal(t, model.PostReportStatusDismissed, report.Status)
+		assert.Equal(t, th.SystemAdminUser.Id, report.ResolverId)
+
+		_, _, err = th.SystemAdminClient.GetPost(post.Id, "")
+		require.NoError(t, err)
+
+		_, resp, err := th.SystemAdminClient.ActOnPostReport(report.Id, &model.PostReportAction{Action: model.PostReportActionDismiss})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("delete post", func(t *testing.T) {
+		post := th.CreatePost()
+		report, _, err := reporter.ReportPost(post.Id, model.PostReportReasonInappropriate, "")
+		require.NoError(t, err)
+
+		report, _, err = th.SystemAdminClient.ActOnPostReport(report.Id, &model.PostReportAction{Action: model.PostReportActionDeletePost})
+		require.NoError(t, err)
+		assert.Equal(t, model.PostReportStatusResolved, report.Status)
+		assert.Equal(t, model.PostReportActionDeletePost, report.Action)
+
+		_, resp, err := th.SystemAdminClient.GetPost(post.Id, "")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("timeout author", func(t *testing.T) {
+		post := th.CreatePost()
+		report, _, err := reporter.ReportPost(post.Id, model.PostReportReasonHarassment, "")
+		require.NoError(t, err)
+
+		_, resp, err := th.SystemAdminClient.ActOnPostReport(report.Id, &model.PostReportAction{Action: model.PostReportActionTimeoutAuthor})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		report, _, err = th.SystemAdminClient.ActOnPostReport(report.Id, &model.PostReportAction{Action: model.PostReportActionTimeoutAuthor, TimeoutMinutes: 10})
+		require.NoError(t, err)
+		assert.Equal(t, model.PostReportStatusResolved, report.Status)
+
+		_, resp, err = th.Client.CreatePost(&model.Post{ChannelId: post.ChannelId, Message: "timed out"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitPostRetentionLabel() {
+	api.BaseRoutes.Post.Handle("/retention_label", api.APISessionRequired(getPostRetentionLabel)).Methods("GET")
+	api.BaseRoutes.Post.Handle("/retention_label", api.APISessionRequired(setPostRetentionLabel)).Methods("PUT")
+	api.BaseRoutes.Post.Handle("/retention_label", api.APISessionRequired(removePostRetentionLabel)).Methods("DELETE")
+}
+
+// canLabelPost returns true if the user of the session moderates the channel, or is a bot that
+// can post in it.
+func canLabelPost(c *Context, channelID string) bool {
+	if canModerateChannel(c, channelID) {
+		return true
+	}
+	session := c.AppContext.Session()
+	return session.Props[model.SessionPropIsBot] == model.SessionPropIsBotValue && c.App.SessionHasPermissionToChannel(*session, channelID, model.PermissionCreatePost)
+}
+
+func getPostRetentionLabel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if _, appErr := c.App.GetPostIfAuthorized(c.Params.PostId, c.AppContext.Session()); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	label, appErr := c.App.GetPostRetentionLabel(c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(label); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func setPostRetentionLabel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	var req model.PostRetentionLabelRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&req); jsonErr != nil || req.Label == "" {
+		c.SetInvalidParam("label")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("setPostRetentionLabel", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+	auditRec.AddMeta("label", req.Label)
+
+	post, appErr := c.App.GetSinglePost(c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !canLabelPost(c, post.ChannelId) {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+		return
+	}
+
+	label, appErr := c.App.SetPostRetentionLabel(post, req.Label, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("expire_at", label.ExpireAt)
+
+	if err := json.NewEncoder(w).Encode(label); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func removePostRetentionLabel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("removePostRetentionLabel", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", c.Params.PostId)
+
+	post, appErr := c.App.GetSinglePost(c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !canLabelPost(c, post.ChannelId) {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+		return
+	}
+
+	if appErr := c.App.RemovePostRetentionLabel(post.Id); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestPostRetentionLabel(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.DataRetentionSettings.RetentionLabels = []*model.RetentionLabel{
+			{Name: "ephemeral-24h", RetentionHours: 24},
+			{Name: "retain-7-years", RetentionHours: 7 * 365 * 24},
+		}
+	})
+
+	post := th.BasicPost
+
+	t.Run("members can't label posts", func(t *testing.T) {
+		_, resp, err := th.Client.SetPostRetentionLabel(post.Id, "ephemeral-24h")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.RemovePostRetentionLabel(post.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("unknown label", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.SetPostRetentionLabel(post.Id, "retain-forever")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("label a post", func(t *testing.T) {
+		_, resp, err := th.Client.GetPostRetentionLabel(post.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		label, _, err := th.SystemAdminClient.SetPostRetentionLabel(post.Id, "ephemeral-24h")
+		require.NoError(t, err)
+		assert.Equal(t, "ephemeral-24h", label.Label)
+		assert.Equal(t, post.CreateAt+24*60*60*1000, label.ExpireAt)
+		assert.Equal(t, th.SystemAdminUser.Id, label.CreatorId)
+
+		label, _, err = th.SystemAdminClient.SetPostRetentionLabel(post.Id, "retain-7-years")
+		require.NoError(t, err)
+
+		fetched, _, err := th.Client.GetPostRetentionLabel(post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "retain-7-years", fetched.Label)
+		assert.Equal(t, label.ExpireAt, fetched.ExpireAt)
+
+		_, err = th.SystemAdminClient.RemovePostRetentionLabel(post.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.Client.GetPostRetentionLabel(post.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("non members can't see the label", func(t *testing.T) {
+		privatePost := th.CreatePostWithClient(th.Client, th.BasicPrivateChannel2)
+		_, _, err := th.SystemAdminClient.SetPostRetentionLabel(privatePost.Id, "ephemeral-24h")
+		require.NoError(t, err)
+
+		client := th.CreateClient()
+		_, _, err = client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+		require.NoError(t, err)
+
+		_, resp, err := client.GetPostRetentionLabel(privatePost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
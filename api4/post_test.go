@@ -1017,6 +1017,28 @@ func TestPinPost(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPinPostLimit(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxPinnedPostsPerChannel = 2 })
+
+	th.CreatePinnedPost()
+	th.CreatePinnedPost()
+
+	post := th.CreatePost()
+	resp, err := client.PinPost(post.Id)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+	CheckErrorID(t, err, "api.post.update_post.pinned_posts_limit.app_error")
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaxPinnedPostsPerChannel = 0 })
+
+	_, err = client.PinPost(post.Id)
+	require.NoError(t, err)
+}
+
 func TestUnpinPost(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2052,6 +2074,45 @@ func TestDeletePost(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDeletePosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	_, resp, err := client.DeletePosts([]string{})
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	ownPost := th.CreatePost()
+	otherPost := th.CreatePostWithClient(th.SystemAdminClient, th.BasicChannel)
+
+	result, resp, err := client.DeletePosts([]string{ownPost.Id, otherPost.Id, "junk", model.NewId()})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 3, result.Failed)
+	require.Len(t, result.Results, 4)
+
+	assert.Equal(t, ownPost.Id, result.Results[0].Id)
+	assert.True(t, result.Results[0].IsSuccess())
+	assert.Equal(t, http.StatusForbidden, result.Results[1].StatusCode)
+	assert.Equal(t, "api.context.permissions.app_error", result.Results[1].Error.Id)
+	assert.Equal(t, http.StatusBadRequest, result.Results[2].StatusCode)
+	assert.Equal(t, "api.context.invalid_body_param.app_error", result.Results[2].Error.Id)
+	assert.Equal(t, http.StatusForbidden, result.Results[3].StatusCode, "should not reveal whether a post exists")
+
+	_, appErr := th.App.GetSinglePost(ownPost.Id)
+	require.NotNil(t, appErr, "should have deleted the post")
+	_, appErr = th.App.GetSinglePost(otherPost.Id)
+	require.Nil(t, appErr, "should not have deleted the post of another user")
+
+	result, resp, err = th.SystemAdminClient.DeletePosts([]string{otherPost.Id})
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 0, result.Failed)
+}
+
 func TestDeletePostEvent(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -2384,6 +2445,117 @@ func TestSearchHashtagPosts(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestSearchArchivedPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.LoginBasic()
+	client := th.Client
+
+	// Posts are created far in the past so that archiving them leaves the posts of other tests alone.
+	createAt := int64(-2000000)
+	archived, err := th.App.Srv().Store.Post().Save(&model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "archived roadmap discussion",
+		CreateAt:  createAt,
+	})
+	require.NoError(t, err)
+
+	privateChannel := th.CreateChannelWithClientAndTeam(th.SystemAdminClient, model.ChannelTypePrivate, th.BasicTeam.Id)
+	_, err = th.App.Srv().Store.Post().Save(&model.Post{
+		ChannelId: privateChannel.Id,
+		UserId:    th.SystemAdminUser.Id,
+		Message:   "private roadmap discussion",
+		CreateAt:  createAt,
+	})
+	require.NoError(t, err)
+
+	_, err = th.App.Srv().Store.PostArchive().ArchiveThreadsBefore(createAt+1, 100)
+	require.NoError(t, err)
+
+	posts, _, err := client.SearchArchivedPosts(th.BasicTeam.Id, "roadmap", 0, 60)
+	require.NoError(t, err)
+	require.Equal(t, []string{archived.Id}, posts.Order, "only posts of the user's channels should be found")
+	assert.Equal(t, archived.Message, posts.Posts[archived.Id].Message)
+
+	posts, _, err = client.SearchArchivedPosts(th.BasicTeam.Id, "unrelated", 0, 60)
+	require.NoError(t, err)
+	require.Empty(t, posts.Order)
+
+	_, resp, err := client.SearchArchivedPosts(th.BasicTeam.Id, "", 0, 60)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = client.SearchArchivedPosts(th.BasicTeam.Id, "roadmap", 0, 1000)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	otherTeam := th.CreateTeamWithClient(th.SystemAdminClient)
+	_, resp, err = client.SearchArchivedPosts(otherTeam.Id, "roadmap", 0, 60)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	client.Logout()
+	_, resp, err = client.SearchArchivedPosts(th.BasicTeam.Id, "roadmap", 0, 60)
+	require.Error(t, err)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestAdvancedSearchPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	marker := model.NewId()
+	withLink, err := th.App.Srv().Store.Post().Save(&model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "build " + marker + "-42 at https://example.com",
+	})
+	require.NoError(t, err)
+	withoutLink, err := th.App.Srv().Store.Post().Save(&model.Post{
+		ChannelId: th.BasicPrivateChannel2.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "build " + marker + "-43 failed",
+	})
+	require.NoError(t, err)
+
+	posts, _, err := th.SystemAdminClient.AdvancedSearchPosts(th.BasicTeam.Id, "", marker+"-[0-9]+")
+	require.NoError(t, err)
+	require.Len(t, posts.Order, 2, "channels the admin isn't a member of should be searched too")
+	require.Contains(t, posts.Posts, withLink.Id)
+	require.Contains(t, posts.Posts, withoutLink.Id)
+
+	posts, _, err = th.SystemAdminClient.AdvancedSearchPosts("", "has:link", marker+"-[0-9]+")
+	require.NoError(t, err)
+	require.Equal(t, []string{withLink.Id}, posts.Order)
+
+	posts, _, err = th.SystemAdminClient.AdvancedSearchPosts(th.BasicTeam.Id, "has:file", marker)
+	require.NoError(t, err)
+	require.Empty(t, posts.Order)
+
+	_, resp, err := th.SystemAdminClient.AdvancedSearchPosts(th.BasicTeam.Id, "", "")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.AdvancedSearchPosts(th.BasicTeam.Id, "", "build (")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.AdvancedSearchPosts(th.BasicTeam.Id, "", strings.Repeat("a", 257))
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	th.LoginBasic()
+	_, resp, err = th.Client.AdvancedSearchPosts(th.BasicTeam.Id, "", marker)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	th.Client.Logout()
+	_, resp, err = th.Client.AdvancedSearchPosts(th.BasicTeam.Id, "", marker)
+	require.Error(t, err)
+	CheckUnauthorizedStatus(t, resp)
+}
+
 func TestSearchPostsInChannel(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitReminder() {
+	api.BaseRoutes.Reminders.Handle("", api.APISessionRequired(createReminder)).Methods("POST")
+	api.BaseRoutes.User.Handle("/reminders", api.APISessionRequired(getRemindersForUser)).Methods("GET")
+	api.BaseRoutes.Reminder.Handle("", api.APISessionRequired(getReminder)).Methods("GET")
+	api.BaseRoutes.Reminder.Handle("", api.APISessionRequired(patchReminder)).Methods("PATCH")
+	api.BaseRoutes.Reminder.Handle("", api.APISessionRequired(deleteReminder)).Methods("DELETE")
+}
+
+func createReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	var reminder model.Reminder
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reminder); jsonErr != nil {
+		c.SetInvalidParam("reminder")
+		return
+	}
+	reminder.UserId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createReminder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("post_id", reminder.PostId)
+
+	// Users can only be reminded of the posts they can read.
+	if reminder.PostId != "" {
+		if _, appErr := c.App.GetPostIfAuthorized(reminder.PostId, c.AppContext.Session()); appErr != nil {
+			c.Err = appErr
+			return
+		}
+	}
+
+	created, appErr := c.App.CreateReminder(&reminder)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("reminder_id", created.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getRemindersForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	reminders, appErr := c.App.GetRemindersForUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reminders); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getReminderForSession returns the reminder of the request if it belongs to the user of the
+// session, or the session has the permission to manage the system.
+func getReminderForSession(c *Context) *model.Reminder {
+	reminder, appErr := c.App.GetReminder(c.Params.ReminderId)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	if reminder.UserId != c.AppContext.Session().UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return nil
+	}
+
+	return reminder
+}
+
+func getReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReminderId()
+	if c.Err != nil {
+		return
+	}
+
+	reminder := getReminderForSession(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reminder); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReminderId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.ReminderPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParam("reminder")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchReminder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("reminder_id", c.Params.ReminderId)
+
+	reminder := getReminderForSession(c)
+	if c.Err != nil {
+		return
+	}
+
+	patched, appErr := c.App.PatchReminder(reminder, &patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(patched); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReminderId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteReminder", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("reminder_id", c.Params.ReminderId)
+
+	reminder := getReminderForSession(c)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.DeleteReminder(reminder.Id); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestReminders(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	remindAt := model.GetMillis() + 60*60*1000
+
+	t.Run("create and list", func(t *testing.T) {
+		reminder, resp, err := th.Client.CreateReminder(&model.Reminder{PostId: th.BasicPost.Id, Message: "follow up", RemindAt: remindAt})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, reminder.UserId)
+
+		reminders, _, err := th.Client.GetReminders(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, reminder.Id, reminders[0].Id)
+
+		_, resp, err = th.Client.GetReminders(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		require.NoError(t, th.App.DeleteReminder(reminder.Id))
+	})
+
+	t.Run("invalid reminders", func(t *testing.T) {
+		_, resp, err := th.Client.CreateReminder(&model.Reminder{Message: "too late", RemindAt: model.GetMillis() - 1000})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.Client.CreateReminder(&model.Reminder{RemindAt: remindAt})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.Client.CreateReminder(&model.Reminder{Message: "hi", RemindAt: remindAt, Recurrence: "hourly"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("cannot set a reminder on an unreadable post", func(t *testing.T) {
+		channel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		post := th.CreatePostWithClient(th.SystemAdminClient, channel)
+
+		_, resp, err := th.Client.CreateReminder(&model.Reminder{PostId: post.Id, RemindAt: remindAt})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("only the owner can read, patch or delete a reminder", func(t *testing.T) {
+		reminder, _, err := th.Client.CreateReminder(&model.Reminder{Message: "stand up", RemindAt: remindAt})
+		require.NoError(t, err)
+
+		client2 := th.CreateClient()
+		_, _, err = client2.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+		require.NoError(t, err)
+
+		_, resp, err := client2.GetReminder(reminder.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client2.PatchReminder(reminder.Id, &model.ReminderPatch{Message: model.NewString("hijacked")})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = client2.DeleteReminder(reminder.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		patched, _, err := th.Client.PatchReminder(reminder.Id, &model.ReminderPatch{Recurrence: model.NewString(model.ReminderRecurrenceDaily)})
+		require.NoError(t, err)
+		assert.Equal(t, model.ReminderRecurrenceDaily, patched.Recurrence)
+		assert.Equal(t, "stand up", patched.Message)
+
+		fetched, _, err := th.SystemAdminClient.GetReminder(reminder.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReminderRecurrenceDaily, fetched.Recurrence)
+
+		_, err = th.Client.DeleteReminder(reminder.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.Client.GetReminder(reminder.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("due reminders are sent by the system bot", func(t *testing.T) {
+		once, _, err := th.Client.CreateReminder(&model.Reminder{PostId: th.BasicPost.Id, Message: "review this", RemindAt: remindAt})
+		require.NoError(t, err)
+		daily, _, err := th.Client.CreateReminder(&model.Reminder{Message: "water the plants", RemindAt: remindAt, Recurrence: model.ReminderRecurrenceDaily})
+		require.NoError(t, err)
+
+		sent, appErr := th.App.SendDueReminders(remindAt + 1000)
+		require.Nil(t, appErr)
+		assert.Equal(t, 2, sent)
+
+		bot, appErr := th.App.GetSystemBot()
+		require.Nil(t, appErr)
+		channel, appErr := th.App.GetOrCreateDirectChannel(th.Context, th.BasicUser.Id, bot.UserId)
+		require.Nil(t, appErr)
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: channel.Id, PerPage: 10})
+		require.Nil(t, appErr)
+
+		var messages []string
+		for _, post := range posts.Posts {
+			messages = append(messages, post.Message)
+		}
+		joined := strings.Join(messages, "\n")
+		assert.Contains(t, joined, "review this")
+		assert.Contains(t, joined, th.BasicPost.Id)
+		assert.Contains(t, joined, "water the plants")
+
+		_, resp, err := th.Client.GetReminder(once.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		rescheduled, _, err := th.Client.GetReminder(daily.Id)
+		require.NoError(t, err)
+		assert.Equal(t, remindAt+24*60*60*1000, rescheduled.RemindAt)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReminders = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReminders = true })
+
+		_, resp, err := th.Client.CreateReminder(&model.Reminder{Message: "hi", RemindAt: remindAt})
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
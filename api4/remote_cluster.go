@@ -22,6 +22,8 @@ func (api *API) InitRemoteCluster() {
 	api.BaseRoutes.RemoteCluster.Handle("/confirm_invite", api.RemoteClusterTokenRequired(remoteClusterConfirmInvite)).Methods("POST")
 	api.BaseRoutes.RemoteCluster.Handle("/upload/{upload_id:[A-Za-z0-9]+}", api.RemoteClusterTokenRequired(uploadRemoteData)).Methods("POST")
 	api.BaseRoutes.RemoteCluster.Handle("/{user_id:[A-Za-z0-9]+}/image", api.RemoteClusterTokenRequired(remoteSetProfileImage)).Methods("POST")
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/pinned_certs", api.APISessionRequired(getRemoteClusterPinnedCerts)).Methods("GET")
+	api.BaseRoutes.RemoteCluster.Handle("/{remote_id:[A-Za-z0-9]+}/pinned_certs", api.APISessionRequired(updateRemoteClusterPinnedCerts)).Methods("PUT")
 }
 
 func remoteClusterPing(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -278,3 +280,72 @@ func remoteSetProfileImage(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	ReturnStatusOK(w)
 }
+
+func getRemoteClusterPinnedCerts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireRemoteId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSecureConnections) {
+		c.SetPermissionError(model.PermissionManageSecureConnections)
+		return
+	}
+
+	rc, appErr := c.App.GetRemoteCluster(c.Params.RemoteId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	pinned := model.RemoteClusterPinnedCerts{
+		RemoteId:    rc.RemoteId,
+		PinnedCerts: rc.GetPinnedCerts(),
+	}
+
+	if err := json.NewEncoder(w).Encode(pinned); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateRemoteClusterPinnedCerts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireRemoteId()
+	if c.Err != nil {
+		return
+	}
+
+	var pinned model.RemoteClusterPinnedCerts
+	if jsonErr := json.NewDecoder(r.Body).Decode(&pinned); jsonErr != nil {
+		c.SetInvalidParam("pinned_certs")
+		return
+	}
+
+	if appErr := pinned.IsValid(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateRemoteClusterPinnedCerts", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("remote_id", c.Params.RemoteId)
+	auditRec.AddMeta("pinned_certs", pinned.PinnedCerts)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSecureConnections) {
+		c.SetPermissionError(model.PermissionManageSecureConnections)
+		return
+	}
+
+	rc, appErr := c.App.UpdateRemoteClusterPinnedCerts(c.Params.RemoteId, pinned.PinnedCerts)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	pinned.RemoteId = rc.RemoteId
+	pinned.PinnedCerts = rc.GetPinnedCerts()
+	if err := json.NewEncoder(w).Encode(pinned); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -20,6 +20,7 @@ var notAllowedPermissions = []string{
 
 func (api *API) InitRole() {
 	api.BaseRoutes.Roles.Handle("", api.APISessionRequired(getAllRoles)).Methods("GET")
+	api.BaseRoutes.Roles.Handle("", api.APISessionRequired(createRole)).Methods("POST")
 	api.BaseRoutes.Roles.Handle("/{role_id:[A-Za-z0-9]+}", api.APISessionRequiredTrustRequester(getRole)).Methods("GET")
 	api.BaseRoutes.Roles.Handle("/name/{role_name:[a-z0-9_]+}", api.APISessionRequiredTrustRequester(getRoleByName)).Methods("GET")
 	api.BaseRoutes.Roles.Handle("/names", api.APISessionRequiredTrustRequester(getRolesByNames)).Methods("POST")
@@ -47,6 +48,60 @@ func getAllRoles(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(js)
 }
 
+func createRole(c *Context, w http.ResponseWriter, r *http.Request) {
+	var role model.Role
+	if jsonErr := json.NewDecoder(r.Body).Decode(&role); jsonErr != nil {
+		c.SetInvalidParam("role")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createRole", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("role_name", role.Name)
+	auditRec.AddMeta("scope", role.Scope)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementSystemRoles) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementSystemRoles)
+		return
+	}
+
+	// Custom roles must say where they can be assigned.
+	if role.Scope != model.RoleScopeSystem && role.Scope != model.RoleScopeTeam && role.Scope != model.RoleScopeChannel {
+		c.SetInvalidParam("scope")
+		return
+	}
+
+	for _, permission := range role.Permissions {
+		for _, notAllowedPermission := range notAllowedPermissions {
+			if permission == notAllowedPermission {
+				c.Err = model.NewAppError("Api4.CreateRole", "api.roles.create_role.not_allowed_permission.error", nil, "Cannot add permission: "+permission, http.StatusNotImplemented)
+				return
+			}
+		}
+	}
+	role.Permissions = model.RemoveDuplicateStrings(role.Permissions)
+
+	if existing, _ := c.App.GetRoleByName(r.Context(), role.Name); existing != nil {
+		c.Err = model.NewAppError("Api4.CreateRole", "api.roles.create_role.name_exists.app_error", nil, "name="+role.Name, http.StatusBadRequest)
+		return
+	}
+
+	created, err := c.App.CreateRole(&role)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("role", created)
+	c.LogAudit("name=" + created.Name)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getRole(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireRoleId()
 	if c.Err != nil {
@@ -318,3 +318,92 @@ func TestPatchRole(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateRole(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newRole := func(scope model.RoleScope, permissions ...string) *model.Role {
+		return &model.Role{
+			Name:        model.NewId(),
+			DisplayName: model.NewId(),
+			Permissions: permissions,
+			Scope:       scope,
+		}
+	}
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.CreateRole(newRole(model.RoleScopeSystem, model.PermissionManageSystem.Id))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("validates the role", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateRole(newRole("", model.PermissionCreatePost.Id))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.CreateRole(newRole(model.RoleScopeSystem, model.PermissionManageRoles.Id))
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.CreateRole(newRole(model.RoleScopeChannel, model.PermissionAddUserToTeam.Id))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		existing := newRole(model.RoleScopeTeam, model.PermissionAddUserToTeam.Id)
+		existing.Name = model.TeamAdminRoleId
+		_, resp, err = th.SystemAdminClient.CreateRole(existing)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("system role", func(t *testing.T) {
+		role, resp, err := th.SystemAdminClient.CreateRole(newRole(model.RoleScopeSystem, model.PermissionSysconsoleReadCompliance.Id, model.PermissionSysconsoleReadCompliance.Id))
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.NotEmpty(t, role.Id)
+		assert.False(t, role.BuiltIn)
+		assert.False(t, role.SchemeManaged)
+		assert.Equal(t, []string{model.PermissionSysconsoleReadCompliance.Id}, role.Permissions)
+
+		_, err = th.SystemAdminClient.UpdateUserRoles(th.BasicUser.Id, model.SystemUserRoleId+" "+role.Name)
+		require.NoError(t, err)
+
+		resp, err = th.SystemAdminClient.UpdateTeamMemberRoles(th.BasicTeam.Id, th.BasicUser.Id, model.TeamUserRoleId+" "+role.Name)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("team role", func(t *testing.T) {
+		role, _, err := th.SystemAdminClient.CreateRole(newRole(model.RoleScopeTeam, model.PermissionAddUserToTeam.Id, model.PermissionCreatePost.Id))
+		require.NoError(t, err)
+
+		_, err = th.SystemAdminClient.UpdateTeamMemberRoles(th.BasicTeam.Id, th.BasicUser.Id, model.TeamUserRoleId+" "+role.Name)
+		require.NoError(t, err)
+
+		member, _, err := th.SystemAdminClient.GetTeamMember(th.BasicTeam.Id, th.BasicUser.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, role.Name, member.ExplicitRoles)
+
+		resp, err := th.SystemAdminClient.UpdateUserRoles(th.BasicUser.Id, model.SystemUserRoleId+" "+role.Name)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("channel role", func(t *testing.T) {
+		role, _, err := th.SystemAdminClient.CreateRole(newRole(model.RoleScopeChannel, model.PermissionCreatePost.Id))
+		require.NoError(t, err)
+
+		_, err = th.SystemAdminClient.UpdateChannelRoles(th.BasicChannel.Id, th.BasicUser.Id, model.ChannelUserRoleId+" "+role.Name)
+		require.NoError(t, err)
+
+		member, _, err := th.SystemAdminClient.GetChannelMember(th.BasicChannel.Id, th.BasicUser.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, role.Name, member.ExplicitRoles)
+
+		_, resp, err := th.SystemAdminClient.PatchRole(role.Id, &model.RolePatch{Permissions: &[]string{model.PermissionManageSystem.Id}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/config"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/utils"
+)
+
+func (api *API) InitScheduledConfigChange() {
+	api.BaseRoutes.APIRoot.Handle("/config/scheduled", api.APISessionRequired(getScheduledConfigChanges)).Methods("GET")
+	api.BaseRoutes.APIRoot.Handle("/config/scheduled", api.APISessionRequired(scheduleConfigChange)).Methods("POST")
+	api.BaseRoutes.APIRoot.Handle("/config/scheduled/{config_change_id:[A-Za-z0-9]+}", api.APISessionRequired(cancelScheduledConfigChange)).Methods("DELETE")
+}
+
+func getScheduledConfigChanges(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	includeFinished, _ := strconv.ParseBool(r.URL.Query().Get("include_finished"))
+	changes, appErr := c.App.GetScheduledConfigChanges(includeFinished, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	for _, change := range changes {
+		change.Sanitize()
+	}
+
+	if err := json.NewEncoder(w).Encode(changes); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func scheduleConfigChange(c *Context, w http.ResponseWriter, r *http.Request) {
+	var change model.ScheduledConfigChange
+	if jsonErr := json.NewDecoder(r.Body).Decode(&change); jsonErr != nil || change.Config == nil {
+		c.SetInvalidParam("scheduled_config_change")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scheduleConfigChange", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("apply_at", change.ApplyAt)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	// The patch is checked against the current configuration the same way as when patching it, and
+	// only the settings the user may write are kept.
+	mergeConfigPatch(c, "scheduleConfigChange", change.Config)
+	if c.Err != nil {
+		return
+	}
+
+	patch, mergeErr := config.Merge(&model.Config{}, change.Config, &utils.MergeConfig{
+		StructFieldFilter: func(structField reflect.StructField, base, patch reflect.Value) bool {
+			return writeFilter(c, structField)
+		},
+	})
+	if mergeErr != nil {
+		c.Err = model.NewAppError("scheduleConfigChange", "api.config.update_config.restricted_merge.app_error", nil, mergeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	change.Config = patch
+	change.CreatorId = c.AppContext.Session().UserId
+
+	scheduled, appErr := c.App.ScheduleConfigChange(&change)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("scheduled_config_change_id", scheduled.Id)
+	auditRec.AddMeta("diff", scheduled.Diffs)
+
+	scheduled.Sanitize()
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(scheduled); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func cancelScheduledConfigChange(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireConfigChangeId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("cancelScheduledConfigChange", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("scheduled_config_change_id", c.Params.ConfigChangeId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	canceled, appErr := c.App.CancelScheduledConfigChange(c.Params.ConfigChangeId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	canceled.Sanitize()
+
+	if err := json.NewEncoder(w).Encode(canceled); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestScheduledConfigChanges(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.AnnouncementSettings.EnableBanner = false
+		*cfg.AnnouncementSettings.BannerText = ""
+	})
+
+	newChange := func(applyAt int64) *model.ScheduledConfigChange {
+		cfg := &model.Config{}
+		cfg.AnnouncementSettings.EnableBanner = model.NewBool(true)
+		cfg.AnnouncementSettings.BannerText = model.NewString("Maintenance tonight")
+		return &model.ScheduledConfigChange{ApplyAt: applyAt, Config: cfg}
+	}
+
+	t.Run("requires the manage system permission", func(t *testing.T) {
+		_, resp, err := th.Client.ScheduleConfigChange(newChange(model.GetMillis() + 60*60*1000))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetScheduledConfigChanges(false, 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("schedule and apply", func(t *testing.T) {
+		applyAt := model.GetMillis() + 60*60*1000
+		change, resp, err := th.SystemAdminClient.ScheduleConfigChange(newChange(applyAt))
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, model.ScheduledConfigChangeStatusPending, change.Status)
+		assert.Equal(t, th.SystemAdminUser.Id, change.CreatorId)
+		assert.Nil(t, change.Config, "the partial config must not be returned")
+		assert.Contains(t, string(change.Diffs), "AnnouncementSettings.EnableBanner")
+
+		pending, _, err := th.SystemAdminClient.GetScheduledConfigChanges(false, 0, 60)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, change.Id, pending[0].Id)
+
+		applied, appErr := th.App.ApplyDueScheduledConfigChanges(applyAt - 1)
+		require.Nil(t, appErr)
+		assert.Zero(t, applied)
+		assert.False(t, *th.App.Config().AnnouncementSettings.EnableBanner)
+
+		applied, appErr = th.App.ApplyDueScheduledConfigChanges(applyAt)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, applied)
+		assert.True(t, *th.App.Config().AnnouncementSettings.EnableBanner)
+		assert.Equal(t, "Maintenance tonight", *th.App.Config().AnnouncementSettings.BannerText)
+
+		pending, _, err = th.SystemAdminClient.GetScheduledConfigChanges(false, 0, 60)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		all, _, err := th.SystemAdminClient.GetScheduledConfigChanges(true, 0, 60)
+		require.NoError(t, err)
+		require.NotEmpty(t, all)
+		assert.Equal(t, model.ScheduledConfigChangeStatusApplied, all[0].Status)
+
+		_, resp, err = th.SystemAdminClient.CancelScheduledConfigChange(change.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.AnnouncementSettings.EnableBanner = false })
+
+		applyAt := model.GetMillis() + 60*60*1000
+		change, _, err := th.SystemAdminClient.ScheduleConfigChange(newChange(applyAt))
+		require.NoError(t, err)
+
+		canceled, _, err := th.SystemAdminClient.CancelScheduledConfigChange(change.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledConfigChangeStatusCanceled, canceled.Status)
+
+		applied, appErr := th.App.ApplyDueScheduledConfigChanges(applyAt)
+		require.Nil(t, appErr)
+		assert.Zero(t, applied)
+		assert.False(t, *th.App.Config().AnnouncementSettings.EnableBanner)
+
+		_, resp, err := th.SystemAdminClient.CancelScheduledConfigChange(model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid changes", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.ScheduleConfigChange(newChange(model.GetMillis() - 1000))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		cfg := &model.Config{}
+		cfg.TeamSettings.SiteName = model.NewString(*th.App.Config().TeamSettings.SiteName)
+		_, resp, err = th.SystemAdminClient.ScheduleConfigChange(&model.ScheduledConfigChange{ApplyAt: model.GetMillis() + 60*60*1000, Config: cfg})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		cfg = &model.Config{}
+		cfg.PluginSettings.EnableUploads = model.NewBool(!*th.App.Config().PluginSettings.EnableUploads)
+		_, resp, err = th.SystemAdminClient.ScheduleConfigChange(&model.ScheduledConfigChange{ApplyAt: model.GetMillis() + 60*60*1000, Config: cfg})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -0,0 +1,431 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// InitScim registers the SCIM 2.0 endpoints identity providers provision users and custom
+// groups with, authenticated by the access token of a user allowed to manage them.
+func (api *API) InitScim() {
+	api.BaseRoutes.Scim.Handle("/Users", api.ScimSessionRequired(scimGetUsers)).Methods("GET")
+	api.BaseRoutes.Scim.Handle("/Users", api.ScimSessionRequired(scimCreateUser)).Methods("POST")
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimGetUser)).Methods("GET")
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimReplaceUser)).Methods("PUT")
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimPatchUser)).Methods("PATCH")
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimDeactivateUser)).Methods("DELETE")
+
+	api.BaseRoutes.Scim.Handle("/Groups", api.ScimSessionRequired(scimGetGroups)).Methods("GET")
+	api.BaseRoutes.Scim.Handle("/Groups", api.ScimSessionRequired(scimCreateGroup)).Methods("POST")
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimGetGroup)).Methods("GET")
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimReplaceGroup)).Methods("PUT")
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimPatchGroup)).Methods("PATCH")
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.ScimSessionRequired(scimDeleteGroup)).Methods("DELETE")
+}
+
+// requireScimPermission checks that SCIM is enabled and that the session has a permission, and
+// for groups that custom groups are available.
+func requireScimPermission(c *Context, permission *model.Permission) bool {
+	if !*c.App.Config().ScimSettings.Enable {
+		c.Err = model.NewAppError("requireScimPermission", "api.scim.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return false
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), permission) {
+		c.SetPermissionError(permission)
+		return false
+	}
+
+	if permission == model.PermissionSysconsoleWriteUserManagementGroups || permission == model.PermissionSysconsoleReadUserManagementGroups {
+		if appErr := licensedAndConfiguredForGroupBySource(c.App, model.GroupSourceCustom); appErr != nil {
+			appErr.Where = "Api4.requireScimPermission"
+			c.Err = appErr
+			return false
+		}
+	}
+
+	return true
+}
+
+// requireScimUserWritable checks that the session can provision a user. Only system admins can
+// provision other system admins.
+func requireScimUserWritable(c *Context) bool {
+	if !requireScimPermission(c, model.PermissionSysconsoleWriteUserManagementUsers) {
+		return false
+	}
+
+	user, appErr := c.App.GetUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return false
+	}
+
+	if user.IsSystemAdmin() && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return false
+	}
+
+	return true
+}
+
+func writeScimResponse(c *Context, w http.ResponseWriter, status int, v interface{}) {
+	js, err := json.Marshal(v)
+	if err != nil {
+		c.Err = model.NewAppError("writeScimResponse", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	w.Write(js)
+}
+
+func writeScimError(w http.ResponseWriter, appErr *model.AppError) {
+	js, err := json.Marshal(model.NewScimError(appErr))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(appErr.StatusCode)
+	w.Write(js)
+}
+
+// scimListParams returns the filter, the 1-based start index and the count of a list request.
+func scimListParams(c *Context, r *http.Request) (string, int, int) {
+	query := r.URL.Query()
+
+	startIndex := 1
+	if value := query.Get("startIndex"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.SetInvalidURLParam("startIndex")
+			return "", 0, 0
+		}
+		startIndex = parsed
+	}
+
+	count := model.ScimDefaultCount
+	if value := query.Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.SetInvalidURLParam("count")
+			return "", 0, 0
+		}
+		count = parsed
+	}
+
+	return query.Get("filter"), startIndex, count
+}
+
+func scimGetUsers(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !requireScimPermission(c, model.PermissionSysconsoleReadUserManagementUsers) {
+		return
+	}
+
+	filter, startIndex, count := scimListParams(c, r)
+	if c.Err != nil {
+		return
+	}
+
+	list, appErr := c.App.ScimGetUsers(filter, startIndex, count)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, list)
+}
+
+func scimGetUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !requireScimPermission(c, model.PermissionSysconsoleReadUserManagementUsers) {
+		return
+	}
+
+	scimUser, appErr := c.App.ScimGetUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, scimUser)
+}
+
+func scimCreateUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimUser *model.ScimUser
+	if err := json.NewDecoder(r.Body).Decode(&scimUser); err != nil || scimUser == nil {
+		c.SetInvalidParam("user")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimCreateUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_name", scimUser.UserName)
+
+	if !requireScimPermission(c, model.PermissionSysconsoleWriteUserManagementUsers) {
+		return
+	}
+
+	created, appErr := c.App.ScimCreateUser(c.AppContext, scimUser)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("user_id", created.Id)
+
+	writeScimResponse(c, w, http.StatusCreated, created)
+}
+
+func scimReplaceUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var scimUser *model.ScimUser
+	if err := json.NewDecoder(r.Body).Decode(&scimUser); err != nil || scimUser == nil {
+		c.SetInvalidParam("user")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimReplaceUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !requireScimUserWritable(c) {
+		return
+	}
+
+	updated, appErr := c.App.ScimReplaceUser(c.AppContext, c.Params.UserId, scimUser)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("active", *updated.Active)
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func scimPatchUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch *model.ScimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || patch == nil {
+		c.SetInvalidParam("patch")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimPatchUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !requireScimUserWritable(c) {
+		return
+	}
+
+	updated, appErr := c.App.ScimPatchUser(c.AppContext, c.Params.UserId, patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("active", *updated.Active)
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func scimDeactivateUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimDeactivateUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !requireScimUserWritable(c) {
+		return
+	}
+
+	if appErr := c.App.ScimDeactivateUser(c.AppContext, c.Params.UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func scimGetGroups(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !requireScimPermission(c, model.PermissionSysconsoleReadUserManagementGroups) {
+		return
+	}
+
+	filter, startIndex, count := scimListParams(c, r)
+	if c.Err != nil {
+		return
+	}
+
+	list, appErr := c.App.ScimGetGroups(filter, startIndex, count)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, list)
+}
+
+func scimGetGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	if !requireScimPermission(c, model.PermissionSysconsoleReadUserManagementGroups) {
+		return
+	}
+
+	scimGroup, appErr := c.App.ScimGetGroup(c.Params.GroupId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, scimGroup)
+}
+
+func scimCreateGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimGroup *model.ScimGroup
+	if err := json.NewDecoder(r.Body).Decode(&scimGroup); err != nil || scimGroup == nil {
+		c.SetInvalidParam("group")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimCreateGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("display_name", scimGroup.DisplayName)
+
+	if !requireScimPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	created, appErr := c.App.ScimCreateGroup(scimGroup)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("group_id", created.Id)
+
+	writeScimResponse(c, w, http.StatusCreated, created)
+}
+
+func scimReplaceGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	var scimGroup *model.ScimGroup
+	if err := json.NewDecoder(r.Body).Decode(&scimGroup); err != nil || scimGroup == nil {
+		c.SetInvalidParam("group")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimReplaceGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	if !requireScimPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	updated, appErr := c.App.ScimReplaceGroup(c.Params.GroupId, scimGroup)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("members", len(updated.Members))
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func scimPatchGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch *model.ScimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || patch == nil {
+		c.SetInvalidParam("patch")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimPatchGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	if !requireScimPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	updated, appErr := c.App.ScimPatchGroup(c.Params.GroupId, patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("members", len(updated.Members))
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func scimDeleteGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("scimDeleteGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	if !requireScimPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	if appErr := c.App.ScimDeleteGroup(c.Params.GroupId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func scimRequest(t *testing.T, client *model.Client4, method, path, body string, v interface{}) *http.Response {
+	t.Helper()
+
+	resp, err := client.DoAPIRequestWithHeaders(method, client.URL+model.ScimURLSuffix+path, body, map[string]string{"Content-Type": "application/scim+json"})
+	if resp == nil {
+		require.NoError(t, err)
+	}
+	if err == nil && v != nil {
+		defer resp.Body.Close()
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp
+}
+
+// scimErrorRequest makes a request expected to fail, returning the SCIM error of the response.
+func scimErrorRequest(t *testing.T, client *model.Client4, method, path, body string) *model.ScimError {
+	t.Helper()
+
+	rq, err := http.NewRequest(method, client.URL+model.ScimURLSuffix+path, strings.NewReader(body))
+	require.NoError(t, err)
+	rq.Header.Set("Content-Type", "application/scim+json")
+	rq.Header.Set(model.HeaderAuth, client.AuthType+" "+client.AuthToken)
+
+	resp, err := client.HTTPClient.Do(rq)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.GreaterOrEqual(t, resp.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, "application/scim+json", resp.Header.Get("Content-Type"))
+
+	var scimErr model.ScimError
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&scimErr))
+	assert.Equal(t, []string{model.ScimSchemaError}, scimErr.Schemas)
+	assert.Equal(t, strconv.Itoa(resp.StatusCode), scimErr.Status)
+	return &scimErr
+}
+
+func TestScimUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	resp := scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users", "", nil)
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ScimSettings.Enable = true })
+
+	resp = scimRequest(t, th.Client, http.MethodGet, "/Users", "", nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	var created model.ScimUser
+	resp = scimRequest(t, th.SystemAdminClient, http.MethodPost, "/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "jane.scim@example.com",
+		"name": {"givenName": "Jane", "familyName": "Doe"},
+		"emails": [{"value": "jane.scim@example.com", "primary": true}],
+		"active": true
+	}`, &created)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "jane.scim@example.com", created.UserName)
+	require.NotNil(t, created.Active)
+	assert.True(t, *created.Active)
+
+	user, appErr := th.App.GetUser(created.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, "jane.scim", user.Username)
+	assert.Equal(t, "Jane", user.FirstName)
+	assert.True(t, user.EmailVerified)
+
+	t.Run("a user can't be provisioned twice", func(t *testing.T) {
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodPost, "/Users", `{"userName": "jane.scim@example.com", "emails": [{"value": "jane.scim@example.com"}]}`, nil)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("filter by userName", func(t *testing.T) {
+		var list model.ScimListResponse
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq "jane.scim@example.com"`), "", &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, list.TotalResults)
+
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq "nobody@example.com"`), "", &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 0, list.TotalResults)
+
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName sw "jane"`), "", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("pages skip the bots", func(t *testing.T) {
+		_, appErr := th.App.CreateBot(th.Context, &model.Bot{Username: "scimbot", OwnerId: th.SystemAdminUser.Id})
+		require.Nil(t, appErr)
+
+		var all model.ScimListResponse
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?count="+strconv.Itoa(model.ScimMaxCount), "", &all)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Greater(t, all.TotalResults, 3)
+		require.Len(t, all.Resources, all.TotalResults)
+		for _, resource := range all.Resources {
+			assert.NotEqual(t, "scimbot", resource.(map[string]interface{})["userName"])
+		}
+
+		var page model.ScimListResponse
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?startIndex=2&count=2", "", &page)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, all.TotalResults, page.TotalResults)
+		assert.Equal(t, 2, page.StartIndex)
+		assert.Equal(t, 2, page.ItemsPerPage)
+		assert.Equal(t, all.Resources[1:3], page.Resources)
+
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?startIndex="+strconv.Itoa(all.TotalResults)+"&count=2", "", &page)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, page.ItemsPerPage)
+		assert.Equal(t, all.Resources[all.TotalResults-1:], page.Resources)
+	})
+
+	t.Run("filtered pages", func(t *testing.T) {
+		filter := "filter=" + url.QueryEscape(`userName eq "jane.scim@example.com"`)
+
+		var list model.ScimListResponse
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?"+filter+"&startIndex=2", "", &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, list.TotalResults)
+		assert.Equal(t, 0, list.ItemsPerPage)
+
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?"+filter+"&count=0", "", &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, list.TotalResults)
+		assert.Equal(t, 0, list.ItemsPerPage)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		scimErr := scimErrorRequest(t, th.SystemAdminClient, http.MethodPost, "/Users", `{"userName": "jane.scim@example.com", "emails": [{"value": "jane.scim@example.com"}]}`)
+		assert.Equal(t, "uniqueness", scimErr.ScimType)
+
+		scimErr = scimErrorRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName sw "jane"`), "")
+		assert.Equal(t, "invalidFilter", scimErr.ScimType)
+
+		scimErr = scimErrorRequest(t, th.SystemAdminClient, http.MethodGet, "/Users/"+model.NewId(), "")
+		assert.Equal(t, "404", scimErr.Status)
+		assert.Empty(t, scimErr.ScimType)
+
+		scimErr = scimErrorRequest(t, th.Client, http.MethodGet, "/Users", "")
+		assert.Equal(t, "403", scimErr.Status)
+	})
+
+	t.Run("patch and deactivate", func(t *testing.T) {
+		var patched model.ScimUser
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodPatch, "/Users/"+created.Id, `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [
+				{"op": "replace", "path": "name.familyName", "value": "Smith"},
+				{"op": "replace", "value": {"active": "False"}}
+			]
+		}`, &patched)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "Smith", patched.Name.FamilyName)
+		assert.False(t, *patched.Active)
+
+		user, appErr := th.App.GetUser(created.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, user.DeleteAt)
+
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodPatch, "/Users/"+created.Id, `{"Operations": [{"op": "replace", "path": "active", "value": true}]}`, &patched)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.True(t, *patched.Active)
+
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodDelete, "/Users/"+created.Id, "", nil)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		user, appErr = th.App.GetUser(created.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, user.DeleteAt)
+	})
+
+	t.Run("system admins can only be provisioned by system admins", func(t *testing.T) {
+		th.AddPermissionToRole(model.PermissionSysconsoleWriteUserManagementUsers.Id, model.SystemUserRoleId)
+		defer th.RemovePermissionFromRole(model.PermissionSysconsoleWriteUserManagementUsers.Id, model.SystemUserRoleId)
+
+		resp := scimRequest(t, th.Client, http.MethodDelete, "/Users/"+th.SystemAdminUser.Id, "", nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestScimGroups(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuProfessional))
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ScimSettings.Enable = true
+		cfg.FeatureFlags.CustomGroups = true
+		*cfg.ServiceSettings.EnableCustomGroups = true
+	})
+
+	var created model.ScimGroup
+	resp := scimRequest(t, th.SystemAdminClient, http.MethodPost, "/Groups", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": "SCIM Engineers",
+		"members": [{"value": "`+th.BasicUser.Id+`"}]
+	}`, &created)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Len(t, created.Members, 1)
+
+	group, appErr := th.App.GetGroup(created.Id, nil)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.GroupSourceCustom, group.Source)
+	assert.Equal(t, "scim-engineers", *group.Name)
+
+	t.Run("filter by displayName", func(t *testing.T) {
+		var list model.ScimListResponse
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Groups?filter="+url.QueryEscape(`displayName eq "scim engineers"`), "", &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, list.TotalResults)
+	})
+
+	t.Run("patch the members", func(t *testing.T) {
+		var patched model.ScimGroup
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodPatch, "/Groups/"+created.Id, `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [
+				{"op": "add", "path": "members", "value": [{"value": "`+th.BasicUser2.Id+`"}]},
+				{"op": "remove", "path": "members[value eq \"`+th.BasicUser.Id+`\"]"}
+			]
+		}`, &patched)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Len(t, patched.Members, 1)
+		assert.Equal(t, th.BasicUser2.Id, patched.Members[0].Value)
+
+		members, appErr := th.App.GetGroupMemberUsers(created.Id)
+		require.Nil(t, appErr)
+		require.Len(t, members, 1)
+		assert.Equal(t, th.BasicUser2.Id, members[0].Id)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodDelete, "/Groups/"+created.Id, "", nil)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp = scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Groups/"+created.Id, "", nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("custom groups must be available", func(t *testing.T) {
+		th.App.Srv().RemoveLicense()
+
+		resp := scimRequest(t, th.SystemAdminClient, http.MethodGet, "/Groups", "", nil)
+		assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+}
@@ -22,6 +22,9 @@ func (api *API) InitStatus() {
 	// as DELETE method doesn't support request body in the mobile app.
 	api.BaseRoutes.User.Handle("/status/custom/recent", api.APISessionRequired(removeUserRecentCustomStatus)).Methods("DELETE")
 	api.BaseRoutes.User.Handle("/status/custom/recent/delete", api.APISessionRequired(removeUserRecentCustomStatus)).Methods("POST")
+
+	api.BaseRoutes.User.Handle("/status/dnd/bypass", api.APISessionRequired(getUserDNDBypassList)).Methods("GET")
+	api.BaseRoutes.User.Handle("/status/dnd/bypass", api.APISessionRequired(updateUserDNDBypassList)).Methods("PUT")
 }
 
 func getUserStatus(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -209,3 +212,53 @@ func removeUserRecentCustomStatus(c *Context, w http.ResponseWriter, r *http.Req
 
 	ReturnStatusOK(w)
 }
+
+func getUserDNDBypassList(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	list, err := c.App.GetDNDBypassList(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateUserDNDBypassList(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var list model.DNDBypassList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		c.SetInvalidParam("dnd_bypass")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	updated, err := c.App.UpdateDNDBypassList(c.Params.UserId, &list)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
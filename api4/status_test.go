@@ -242,3 +242,51 @@ func TestUpdateUserStatus(t *testing.T) {
 		CheckUnauthorizedStatus(t, resp)
 	})
 }
+
+func TestUserDNDBypassList(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	t.Run("empty list by default", func(t *testing.T) {
+		list, _, err := client.GetUserDNDBypassList(th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Empty(t, list.UserIds)
+		assert.Empty(t, list.Keywords)
+	})
+
+	t.Run("update own list", func(t *testing.T) {
+		updated, _, err := client.UpdateUserDNDBypassList(th.BasicUser.Id, &model.DNDBypassList{
+			UserIds:  []string{th.BasicUser2.Id},
+			Keywords: []string{" outage ", "Outage"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{th.BasicUser2.Id}, updated.UserIds)
+		assert.Equal(t, []string{"outage"}, updated.Keywords)
+
+		list, _, err := client.GetUserDNDBypassList("me")
+		require.NoError(t, err)
+		assert.Equal(t, updated, list)
+	})
+
+	t.Run("invalid list", func(t *testing.T) {
+		_, resp, err := client.UpdateUserDNDBypassList(th.BasicUser.Id, &model.DNDBypassList{UserIds: []string{"invalid"}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other user's list as regular user", func(t *testing.T) {
+		_, resp, err := client.GetUserDNDBypassList(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.UpdateUserDNDBypassList(th.BasicUser2.Id, &model.DNDBypassList{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("other user's list as admin", func(t *testing.T) {
+		_, _, err := th.SystemAdminClient.GetUserDNDBypassList(th.BasicUser.Id)
+		require.NoError(t, err)
+	})
+}
@@ -37,8 +37,10 @@ var redirectLocationDataCache = cache.NewLRU(cache.LRUOptions{
 
 func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/ping", api.APIHandler(getSystemPing)).Methods("GET")
+	api.BaseRoutes.System.Handle("/dependencies", api.APIHandler(getSystemDependencies)).Methods("GET")
 
 	api.BaseRoutes.System.Handle("/timezones", api.APISessionRequired(getSupportedTimezones)).Methods("GET")
+	api.BaseRoutes.System.Handle("/telemetry/events", api.APISessionRequired(trackClientTelemetryEvent)).Methods("POST")
 
 	api.BaseRoutes.APIRoot.Handle("/audits", api.APISessionRequired(getAudits)).Methods("GET")
 	api.BaseRoutes.APIRoot.Handle("/email/test", api.APISessionRequired(testEmail)).Methods("POST")
@@ -69,6 +71,8 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/notices/{team_id:[A-Za-z0-9]+}", api.APISessionRequired(getProductNotices)).Methods("GET")
 	api.BaseRoutes.System.Handle("/notices/view", api.APISessionRequired(updateViewedProductNotices)).Methods("PUT")
 	api.BaseRoutes.System.Handle("/support_packet", api.APISessionRequired(generateSupportPacket)).Methods("GET")
+	api.BaseRoutes.System.Handle("/checkup", api.APISessionRequired(getSystemCheckup)).Methods("GET")
+	api.BaseRoutes.System.Handle("/connectivity_tests", api.APISessionRequired(getConnectivityTestHistory)).Methods("GET")
 	api.BaseRoutes.System.Handle("/onboarding/complete", api.APISessionRequired(getOnboarding)).Methods("GET")
 	api.BaseRoutes.System.Handle("/onboarding/complete", api.APISessionRequired(completeOnboarding)).Methods("POST")
 	api.BaseRoutes.System.Handle("/schema/version", api.APISessionRequired(getAppliedSchemaMigrations)).Methods("GET")
@@ -95,7 +99,15 @@ func generateSupportPacket(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fileDatas := c.App.GenerateSupportPacket()
+	options := &model.SupportPacketOptions{
+		IncludeDiagnostics: r.URL.Query().Get("include_diagnostics") == "true",
+	}
+
+	auditRec := c.MakeAuditRecord("generateSupportPacket", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("include_diagnostics", options.IncludeDiagnostics)
+
+	fileDatas := c.App.GenerateSupportPacket(options)
 
 	// Constructing the ZIP file name as per spec (mattermost_support_packet_YYYY-MM-DD-HH-MM.zip)
 	now := time.Now()
@@ -124,6 +136,7 @@ func generateSupportPacket(c *Context, w http.ResponseWriter, r *http.Request) {
 	// We are able to pass 0 for content size due to the fact that Golang's serveContent (https://golang.org/src/net/http/fs.go)
 	// already sets that for us
 	writeFileResponse(outputZipFilename, FileMime, 0, now, *c.App.Config().ServiceSettings.WebserverMode, fileBytesReader, true, w, r)
+	auditRec.Success()
 }
 
 func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -195,6 +208,14 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.MapToJSON(s)))
 }
 
+// getSystemDependencies reports the reachability of the dependencies of the server, without
+// requiring a session so that it can be used by orchestrators as the ping endpoint is.
+func getSystemDependencies(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := json.NewEncoder(w).Encode(c.App.Srv().DependencyHealth.Status()); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func testEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 	cfg := model.ConfigFromJSON(r.Body)
 	if cfg == nil {
@@ -216,7 +237,9 @@ func testEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	start := time.Now()
 	err := c.App.TestEmail(c.AppContext.Session().UserId, cfg)
+	c.App.RecordConnectivityTest(model.ConnectivityTestServiceSMTP, c.AppContext.Session().UserId, time.Since(start), err)
 	if err != nil {
 		c.Err = err
 		return
@@ -482,7 +505,9 @@ func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 		cfg.FileSettings.AmazonS3SecretAccessKey = c.App.Config().FileSettings.AmazonS3SecretAccessKey
 	}
 
+	start := time.Now()
 	appErr := c.App.TestFileStoreConnectionWithConfig(&cfg.FileSettings)
+	c.App.RecordConnectivityTest(model.ConnectivityTestServiceS3, c.AppContext.Session().UserId, time.Since(start), appErr)
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -898,6 +923,21 @@ func updateViewedProductNotices(c *Context, w http.ResponseWriter, r *http.Reque
 	ReturnStatusOK(w)
 }
 
+func trackClientTelemetryEvent(c *Context, w http.ResponseWriter, r *http.Request) {
+	var event model.TelemetryEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		c.SetInvalidParam("event")
+		return
+	}
+
+	if appErr := c.App.TrackClientTelemetryEvent(c.AppContext.Session().UserId, &event); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
 func getOnboarding(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec := c.MakeAuditRecord("getOnboarding", audit.Fail)
 	defer c.LogAuditRec(auditRec)
@@ -972,6 +1012,60 @@ func getAppliedSchemaMigrations(c *Context, w http.ResponseWriter, r *http.Reque
 	auditRec.Success()
 }
 
+func getSystemCheckup(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	checkup := c.App.RunSystemCheckup()
+	checkup.Translate(c.AppContext.T)
+
+	js, err := json.Marshal(checkup)
+	if err != nil {
+		c.Err = model.NewAppError("getSystemCheckup", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(js)
+}
+
+// connectivityTestReadPermissions maps each service with a connection test to the permission
+// needed to see the history of its tests.
+var connectivityTestReadPermissions = map[string]*model.Permission{
+	model.ConnectivityTestServiceSMTP:          model.PermissionSysconsoleReadEnvironmentSMTP,
+	model.ConnectivityTestServiceS3:            model.PermissionSysconsoleReadEnvironmentFileStorage,
+	model.ConnectivityTestServiceElasticsearch: model.PermissionSysconsoleReadEnvironmentElasticsearch,
+}
+
+func getConnectivityTestHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	service := r.URL.Query().Get("service")
+	if service != "" && !model.IsValidConnectivityTestService(service) {
+		c.SetInvalidParam("service")
+		return
+	}
+
+	for s, permission := range connectivityTestReadPermissions {
+		if service != "" && s != service {
+			continue
+		}
+		if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), permission) {
+			c.SetPermissionError(permission)
+			return
+		}
+	}
+
+	results, err := c.App.GetConnectivityTestHistory(service, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 // returns true if the data has nil fields
 // this is being used for testS3 and testEmail methods
 func checkHasNilFields(value interface{}) bool {
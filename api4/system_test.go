@@ -95,6 +95,26 @@ func TestGetPing(t *testing.T) {
 	}, "ping and test push notification")
 }
 
+func TestGetSystemDependencies(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.TestForAllClients(t, func(t *testing.T, client *model.Client4) {
+		status, _, err := client.GetSystemDependencies()
+		require.NoError(t, err)
+		assert.Equal(t, model.StartupDependencyModeDisabled, status.Mode)
+		assert.False(t, status.DegradedMode)
+		assert.Empty(t, status.Dependencies)
+	})
+
+	t.Run("without a session", func(t *testing.T) {
+		client := th.CreateClient()
+		status, _, err := client.GetSystemDependencies()
+		require.NoError(t, err)
+		assert.False(t, status.DegradedMode)
+	})
+}
+
 func TestGetAudits(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -206,6 +226,50 @@ func TestEmailTest(t *testing.T) {
 	})
 }
 
+func TestConnectivityTestHistory(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	es := model.EmailSettings{}
+	es.SetDefaults(false)
+	es.SMTPServer = model.NewString("")
+	config := model.Config{EmailSettings: es}
+
+	resp, err := th.SystemAdminClient.TestEmail(&config)
+	CheckErrorID(t, err, "api.admin.test_email.missing_server")
+	CheckBadRequestStatus(t, resp)
+
+	t.Run("as system admin", func(t *testing.T) {
+		results, _, err := th.SystemAdminClient.GetConnectivityTestHistory(model.ConnectivityTestServiceSMTP, 0, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, model.ConnectivityTestServiceSMTP, results[0].Service)
+		assert.Equal(t, th.SystemAdminUser.Id, results[0].UserId)
+		assert.False(t, results[0].Success)
+		assert.NotEmpty(t, results[0].Error)
+
+		results, _, err = th.SystemAdminClient.GetConnectivityTestHistory(model.ConnectivityTestServiceS3, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		results, _, err = th.SystemAdminClient.GetConnectivityTestHistory("", 0, 10)
+		require.NoError(t, err)
+		assert.NotEmpty(t, results)
+	})
+
+	t.Run("invalid service", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetConnectivityTestHistory("ftp", 0, 10)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := th.Client.GetConnectivityTestHistory(model.ConnectivityTestServiceSMTP, 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestGenerateSupportPacket(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -219,6 +283,15 @@ func TestGenerateSupportPacket(t *testing.T) {
 		require.NotZero(t, len(file))
 	})
 
+	t.Run("As a System Administrator including diagnostics", func(t *testing.T) {
+		l := model.NewTestLicense()
+		th.App.Srv().SetLicense(l)
+
+		file, _, err := th.SystemAdminClient.GenerateSupportPacketWithDiagnostics()
+		require.NoError(t, err)
+		require.NotZero(t, len(file))
+	})
+
 	t.Run("As a System Administrator but with RestrictSystemAdmin true", func(t *testing.T) {
 		originalRestrictSystemAdminVal := *th.App.Config().ExperimentalSettings.RestrictSystemAdmin
 		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
@@ -255,6 +328,35 @@ func TestGenerateSupportPacket(t *testing.T) {
 	})
 }
 
+func TestGetSystemCheckup(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("As a System Administrator", func(t *testing.T) {
+		checkup, _, err := th.SystemAdminClient.GetSystemCheckup()
+		require.NoError(t, err)
+		require.NotNil(t, checkup)
+		assert.NotZero(t, checkup.CreateAt)
+		for _, finding := range checkup.Findings {
+			assert.True(t, model.IsValidCheckupSeverity(finding.Severity))
+			assert.NotEmpty(t, finding.MessageId)
+			assert.NotEqual(t, finding.MessageId, finding.Message, "the message should be translated")
+		}
+	})
+
+	t.Run("As a system role, not system admin", func(t *testing.T) {
+		_, resp, err := th.SystemManagerClient.GetSystemCheckup()
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("As a Regular User", func(t *testing.T) {
+		_, resp, err := th.Client.GetSystemCheckup()
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestSiteURLTest(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -1015,3 +1117,53 @@ func TestCheckHasNilFields(t *testing.T) {
 		require.True(t, res)
 	})
 }
+
+func TestTrackClientTelemetryEvent(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	events := make(chan string, 10)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _, _ := r.BasicAuth()
+		events <- key
+	}))
+	defer sink.Close()
+
+	event := &model.TelemetryEvent{Event: "click", Properties: map[string]interface{}{"button": "save"}}
+
+	t.Run("sink not configured", func(t *testing.T) {
+		resp, err := th.Client.TrackTelemetryEvent(event)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.LogSettings.EnableDiagnostics = true
+		*cfg.LogSettings.DiagnosticsSinkURL = sink.URL
+		*cfg.LogSettings.DiagnosticsSinkKey = "sinkkey"
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		resp, err := th.Client.TrackTelemetryEvent(&model.TelemetryEvent{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("forwarded with the key of the sink", func(t *testing.T) {
+		_, err := th.Client.TrackTelemetryEvent(event)
+		require.NoError(t, err)
+
+		clientConfig, _, err := th.Client.GetOldClientConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, "true", clientConfig["DiagnosticsSinkEnabled"])
+		assert.NotContains(t, clientConfig, "DiagnosticsSinkKey")
+
+		require.NoError(t, th.App.Srv().GetTelemetryService().Shutdown())
+		select {
+		case key := <-events:
+			assert.Equal(t, "sinkkey", key)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Did not receive the event")
+		}
+	})
+}
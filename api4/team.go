@@ -45,6 +45,7 @@ func (api *API) InitTeam() {
 	api.BaseRoutes.Team.Handle("", api.APISessionRequired(deleteTeam)).Methods("DELETE")
 	api.BaseRoutes.Team.Handle("/except", api.APISessionRequired(softDeleteTeamsExcept)).Methods("DELETE")
 	api.BaseRoutes.Team.Handle("/patch", api.APISessionRequired(patchTeam)).Methods("PUT")
+	api.BaseRoutes.Team.Handle("/features", api.APISessionRequired(patchTeamFeatures)).Methods("PATCH")
 	api.BaseRoutes.Team.Handle("/restore", api.APISessionRequired(restoreTeam)).Methods("POST")
 	api.BaseRoutes.Team.Handle("/privacy", api.APISessionRequired(updateTeamPrivacy)).Methods("PUT")
 	api.BaseRoutes.Team.Handle("/stats", api.APISessionRequired(getTeamStats)).Methods("GET")
@@ -95,12 +96,32 @@ func createTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var template *model.TeamTemplate
+	if templateID := r.URL.Query().Get("template_id"); templateID != "" {
+		auditRec.AddMeta("template_id", templateID)
+
+		if !model.IsValidId(templateID) {
+			c.SetInvalidURLParam("template_id")
+			return
+		}
+
+		var appErr *model.AppError
+		if template, appErr = c.App.GetTeamTemplate(templateID); appErr != nil {
+			c.Err = appErr
+			return
+		}
+	}
+
 	rteam, err := c.App.CreateTeamWithUser(c.AppContext, &team, c.AppContext.Session().UserId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
+	if template != nil {
+		c.App.ApplyTeamTemplate(c.AppContext, rteam, template, c.AppContext.Session().UserId)
+	}
+
 	// Don't sanitize the team here since the user will be a team admin and their session won't reflect that yet
 
 	auditRec.Success()
@@ -244,6 +265,45 @@ func patchTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func patchTeamFeatures(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.TeamFeaturesPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParam("features")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchTeamFeatures", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("team_id", c.Params.TeamId)
+	auditRec.AddMeta("features", patch)
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	patchedTeam, err := c.App.PatchTeamFeatures(c.Params.TeamId, patch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.App.SanitizeTeam(*c.AppContext.Session(), patchedTeam)
+
+	auditRec.Success()
+	auditRec.AddMeta("patched", patchedTeam)
+	c.LogAudit("")
+
+	if err := json.NewEncoder(w).Encode(patchedTeam); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func restoreTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireTeamId()
 	if c.Err != nil {
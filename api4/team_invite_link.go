@@ -0,0 +1,196 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitTeamInviteLink() {
+	api.BaseRoutes.TeamInviteLinks.Handle("", api.APISessionRequired(getTeamInviteLinks)).Methods("GET")
+	api.BaseRoutes.TeamInviteLinks.Handle("", api.APISessionRequired(createTeamInviteLink)).Methods("POST")
+	api.BaseRoutes.TeamInviteLink.Handle("", api.APISessionRequired(getTeamInviteLink)).Methods("GET")
+	api.BaseRoutes.TeamInviteLink.Handle("/patch", api.APISessionRequired(patchTeamInviteLink)).Methods("PUT")
+	api.BaseRoutes.TeamInviteLink.Handle("", api.APISessionRequired(revokeTeamInviteLink)).Methods("DELETE")
+}
+
+// Managing the invite links of a team, like regenerating its invite id, requires the permission to
+// manage the team.
+
+func getTeamInviteLinks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	links, appErr := c.App.GetTeamInviteLinks(c.Params.TeamId, c.Params.IncludeDeleted)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(links); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	var link model.TeamInviteLink
+	if jsonErr := json.NewDecoder(r.Body).Decode(&link); jsonErr != nil {
+		c.SetInvalidParam("invite_link")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createTeamInviteLink", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("team_id", c.Params.TeamId)
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	link.Id = ""
+	link.TeamId = c.Params.TeamId
+	link.CreatorId = c.AppContext.Session().UserId
+
+	created, appErr := c.App.CreateTeamInviteLink(&link)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("invite_link", created)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getTeamInviteLinkForRequest returns the invite link of the request, which must be for the team
+// of the request.
+func getTeamInviteLinkForRequest(c *Context) *model.TeamInviteLink {
+	link, appErr := c.App.GetTeamInviteLink(c.Params.InviteLinkId)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	if link.TeamId != c.Params.TeamId {
+		c.Err = model.NewAppError("getTeamInviteLinkForRequest", "app.team_invite_link.not_found.app_error", nil, "invite_link_id="+link.Id, http.StatusNotFound)
+		return nil
+	}
+
+	return link
+}
+
+func getTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireInviteLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	link := getTeamInviteLinkForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(link); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireInviteLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.TeamInviteLinkPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParam("invite_link")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchTeamInviteLink", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("team_id", c.Params.TeamId)
+	auditRec.AddMeta("invite_link_id", c.Params.InviteLinkId)
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	link := getTeamInviteLinkForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	patched, appErr := c.App.PatchTeamInviteLink(link, &patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("invite_link", patched)
+
+	if err := json.NewEncoder(w).Encode(patched); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func revokeTeamInviteLink(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId().RequireInviteLinkId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("revokeTeamInviteLink", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("team_id", c.Params.TeamId)
+	auditRec.AddMeta("invite_link_id", c.Params.InviteLinkId)
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	link := getTeamInviteLinkForRequest(c)
+	if c.Err != nil {
+		return
+	}
+
+	if _, appErr := c.App.RevokeTeamInviteLink(link); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
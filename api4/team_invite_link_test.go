@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestTeamInviteLinks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	team := th.BasicTeam
+
+	// joinWithLink logs a new user in and makes them join the team with the link.
+	joinWithLink := func(t *testing.T, linkID string) (*model.Response, error) {
+		user := th.CreateUser()
+		client := th.CreateClient()
+		_, _, err := client.Login(user.Email, user.Password)
+		require.NoError(t, err)
+
+		_, resp, err := client.AddTeamMemberFromInvite("", linkID)
+		return resp, err
+	}
+
+	t.Run("managing links requires the permission to manage the team", func(t *testing.T) {
+		user2Client := th.CreateClient()
+		_, _, err := user2Client.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+		require.NoError(t, err)
+
+		_, resp, err := user2Client.CreateTeamInviteLink(team.Id, &model.TeamInviteLink{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = user2Client.GetTeamInviteLinks(team.Id, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("capped link", func(t *testing.T) {
+		link, resp, err := th.SystemAdminClient.CreateTeamInviteLink(team.Id, &model.TeamInviteLink{Name: "Meetup", MaxUses: 1})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, team.Id, link.TeamId)
+		assert.Equal(t, th.SystemAdminUser.Id, link.CreatorId)
+
+		info, _, err := th.Client.GetTeamInviteInfo(link.Id)
+		require.NoError(t, err)
+		assert.Equal(t, team.Id, info.Id)
+
+		_, err = joinWithLink(t, link.Id)
+		require.NoError(t, err)
+
+		resp, err = joinWithLink(t, link.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		link, _, err = th.SystemAdminClient.GetTeamInviteLink(team.Id, link.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), link.UseCount)
+		assert.Equal(t, int64(1), link.RejectedCount)
+		assert.NotZero(t, link.LastUsedAt)
+
+		maxUses := int64(2)
+		link, _, err = th.SystemAdminClient.PatchTeamInviteLink(team.Id, link.Id, &model.TeamInviteLinkPatch{MaxUses: &maxUses})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), link.MaxUses)
+
+		_, err = joinWithLink(t, link.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		link, _, err := th.SystemAdminClient.CreateTeamInviteLink(team.Id, &model.TeamInviteLink{ExpireAt: model.GetMillis() - 1000})
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.GetTeamInviteInfo(link.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = joinWithLink(t, link.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("link restricted to domains", func(t *testing.T) {
+		link, _, err := th.SystemAdminClient.CreateTeamInviteLink(team.Id, &model.TeamInviteLink{AllowedDomains: "example.com"})
+		require.NoError(t, err)
+
+		resp, err := joinWithLink(t, link.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		// The test users have emails of the simulator domain.
+		domains := "example.com, simulator.amazonses.com"
+		_, _, err = th.SystemAdminClient.PatchTeamInviteLink(team.Id, link.Id, &model.TeamInviteLinkPatch{AllowedDomains: &domains})
+		require.NoError(t, err)
+
+		_, err = joinWithLink(t, link.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("revoked link", func(t *testing.T) {
+		link, _, err := th.SystemAdminClient.CreateTeamInviteLink(team.Id, &model.TeamInviteLink{})
+		require.NoError(t, err)
+
+		_, err = th.SystemAdminClient.RevokeTeamInviteLink(team.Id, link.Id)
+		require.NoError(t, err)
+
+		resp, err := joinWithLink(t, link.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		links, _, err := th.SystemAdminClient.GetTeamInviteLinks(team.Id, false)
+		require.NoError(t, err)
+		for _, l := range links {
+			assert.NotEqual(t, link.Id, l.Id)
+		}
+
+		links, _, err = th.SystemAdminClient.GetTeamInviteLinks(team.Id, true)
+		require.NoError(t, err)
+		require.NotEmpty(t, links)
+		assert.Equal(t, link.Id, links[0].Id, "the newest link comes first")
+		assert.NotZero(t, links[0].DeleteAt)
+	})
+
+	t.Run("links of other teams are not found", func(t *testing.T) {
+		link, _, err := th.SystemAdminClient.CreateTeamInviteLink(team.Id, &model.TeamInviteLink{})
+		require.NoError(t, err)
+
+		other := th.CreateTeamWithClient(th.SystemAdminClient)
+		_, resp, err := th.SystemAdminClient.GetTeamInviteLink(other.Id, link.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("the invite id of the team still works", func(t *testing.T) {
+		_, err := joinWithLink(t, team.InviteId)
+		require.NoError(t, err)
+	})
+}
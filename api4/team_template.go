@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitTeamTemplate() {
+	api.BaseRoutes.TeamTemplates.Handle("", api.APISessionRequired(createTeamTemplate)).Methods("POST")
+	api.BaseRoutes.TeamTemplates.Handle("", api.APISessionRequired(getTeamTemplates)).Methods("GET")
+	api.BaseRoutes.TeamTemplate.Handle("", api.APISessionRequired(getTeamTemplate)).Methods("GET")
+	api.BaseRoutes.TeamTemplate.Handle("", api.APISessionRequired(updateTeamTemplate)).Methods("PUT")
+	api.BaseRoutes.TeamTemplate.Handle("", api.APISessionRequired(deleteTeamTemplate)).Methods("DELETE")
+}
+
+func createTeamTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	var template model.TeamTemplate
+	if jsonErr := json.NewDecoder(r.Body).Decode(&template); jsonErr != nil {
+		c.SetInvalidParam("team_template")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createTeamTemplate", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("team_template_name", template.DisplayName)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementTeams) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementTeams)
+		return
+	}
+
+	template.CreatorId = c.AppContext.Session().UserId
+
+	rtemplate, err := c.App.CreateTeamTemplate(&template)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("team_template_id", rtemplate.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(rtemplate); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getTeamTemplates(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionCreateTeam) {
+		c.SetPermissionError(model.PermissionCreateTeam)
+		return
+	}
+
+	templates, err := c.App.GetTeamTemplates(c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(templates); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getTeamTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamTemplateId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionCreateTeam) {
+		c.SetPermissionError(model.PermissionCreateTeam)
+		return
+	}
+
+	template, err := c.App.GetTeamTemplate(c.Params.TeamTemplateId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(template); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateTeamTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamTemplateId()
+	if c.Err != nil {
+		return
+	}
+
+	var template model.TeamTemplate
+	if jsonErr := json.NewDecoder(r.Body).Decode(&template); jsonErr != nil {
+		c.SetInvalidParam("team_template")
+		return
+	}
+
+	// The template id in the URL will override any that may be in the body.
+	template.Id = c.Params.TeamTemplateId
+
+	auditRec := c.MakeAuditRecord("updateTeamTemplate", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("team_template_id", template.Id)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementTeams) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementTeams)
+		return
+	}
+
+	rtemplate, err := c.App.UpdateTeamTemplate(&template)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(rtemplate); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteTeamTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamTemplateId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteTeamTemplate", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("team_template_id", c.Params.TeamTemplateId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementTeams) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementTeams)
+		return
+	}
+
+	if err := c.App.DeleteTeamTemplate(c.Params.TeamTemplateId); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func newTestTeamTemplate() *model.TeamTemplate {
+	return &model.TeamTemplate{
+		DisplayName: "Project",
+		Description: "Standard project team",
+		Definition: model.TeamTemplateDefinition{
+			Channels: []model.TeamTemplateChannel{
+				{Name: "planning", DisplayName: "Planning", Type: model.ChannelTypeOpen, Purpose: "Plan the work"},
+				{Name: "leads", DisplayName: "Leads", Type: model.ChannelTypePrivate},
+			},
+			Categories: []model.TeamTemplateCategory{
+				{DisplayName: "Project", ChannelNames: []string{"planning", "leads"}},
+			},
+		},
+	}
+}
+
+func TestTeamTemplateCRUD(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("regular users can't manage team templates", func(t *testing.T) {
+		_, resp, err := th.Client.CreateTeamTemplate(newTestTeamTemplate())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	template, resp, err := th.SystemAdminClient.CreateTeamTemplate(newTestTeamTemplate())
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.NotEmpty(t, template.Id)
+	require.Equal(t, th.SystemAdminUser.Id, template.CreatorId)
+	require.Len(t, template.Definition.Channels, 2)
+
+	t.Run("invalid definition", func(t *testing.T) {
+		invalid := newTestTeamTemplate()
+		invalid.Definition.Categories[0].ChannelNames = []string{"missing"}
+		_, resp, err := th.SystemAdminClient.CreateTeamTemplate(invalid)
+		CheckErrorID(t, err, "model.team_template.is_valid.unknown_channel.app_error")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("users who can create teams can read templates", func(t *testing.T) {
+		fetched, _, err := th.Client.GetTeamTemplate(template.Id)
+		require.NoError(t, err)
+		require.Equal(t, template.Id, fetched.Id)
+
+		templates, _, err := th.Client.GetTeamTemplates(0, 60)
+		require.NoError(t, err)
+		require.Len(t, templates, 1)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		template.DisplayName = "Renamed"
+		updated, _, err := th.SystemAdminClient.UpdateTeamTemplate(template)
+		require.NoError(t, err)
+		require.Equal(t, "Renamed", updated.DisplayName)
+
+		_, resp, err := th.Client.UpdateTeamTemplate(template)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := th.Client.DeleteTeamTemplate(template.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeleteTeamTemplate(template.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.SystemAdminClient.GetTeamTemplate(template.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestCreateTeamWithTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	template, _, err := th.SystemAdminClient.CreateTeamTemplate(newTestTeamTemplate())
+	require.NoError(t, err)
+
+	t.Run("unknown template", func(t *testing.T) {
+		team := &model.Team{Name: GenerateTestUsername(), DisplayName: "Some Team", Type: model.TeamOpen}
+		_, resp, err := th.Client.CreateTeamWithTemplate(team, model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	team := &model.Team{Name: GenerateTestUsername(), DisplayName: "Some Team", Type: model.TeamOpen}
+	rteam, resp, err := th.Client.CreateTeamWithTemplate(team, template.Id)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+
+	planning, _, err := th.Client.GetChannelByName("planning", rteam.Id, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Plan the work", planning.Purpose)
+
+	leads, _, err := th.Client.GetChannelByName("leads", rteam.Id, "")
+	require.NoError(t, err)
+	assert.Equal(t, model.ChannelTypePrivate, leads.Type)
+
+	categories, _, err := th.Client.GetSidebarCategoriesForTeamForUser(th.BasicUser.Id, rteam.Id, "")
+	require.NoError(t, err)
+
+	var found *model.SidebarCategoryWithChannels
+	for _, category := range categories.Categories {
+		if category.DisplayName == "Project" {
+			found = category
+		}
+	}
+	require.NotNil(t, found)
+	assert.ElementsMatch(t, []string{planning.Id, leads.Id}, found.Channels)
+}
@@ -498,6 +498,56 @@ func TestPatchTeam(t *testing.T) {
 	})
 }
 
+func TestPatchTeamFeatures(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	townSquare, appErr := th.App.GetChannelByName(model.DefaultChannelName, th.BasicTeam.Id, false)
+	require.Nil(t, appErr)
+
+	t.Run("requires manage team", func(t *testing.T) {
+		_, resp, err := th.Client.PatchTeamFeatures(th.BasicTeam.Id, model.TeamFeaturesPatch{model.TeamFeatureFileUploads: false})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("unknown feature", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.PatchTeamFeatures(th.BasicTeam.Id, model.TeamFeaturesPatch{"unknown": false})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("disabled features are enforced", func(t *testing.T) {
+		team, _, err := th.SystemAdminClient.PatchTeamFeatures(th.BasicTeam.Id, model.TeamFeaturesPatch{
+			model.TeamFeatureFileUploads:       false,
+			model.TeamFeatureTownSquarePosting: false,
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{model.TeamFeatureFileUploads, model.TeamFeatureTownSquarePosting}, team.DisabledFeatures)
+		assert.True(t, team.IsFeatureEnabled(model.TeamFeatureCustomEmoji))
+
+		_, resp, err := th.Client.UploadFile([]byte("data"), th.BasicChannel.Id, "test.txt")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.CreatePost(&model.Post{ChannelId: townSquare.Id, Message: "hello"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, _, err = th.Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "hello"})
+		require.NoError(t, err)
+	})
+
+	t.Run("features can be enabled again", func(t *testing.T) {
+		team, _, err := th.SystemAdminClient.PatchTeamFeatures(th.BasicTeam.Id, model.TeamFeaturesPatch{model.TeamFeatureTownSquarePosting: true})
+		require.NoError(t, err)
+		assert.Equal(t, model.StringArray{model.TeamFeatureFileUploads}, team.DisabledFeatures)
+
+		_, _, err = th.Client.CreatePost(&model.Post{ChannelId: townSquare.Id, Message: "hello"})
+		require.NoError(t, err)
+	})
+}
+
 func TestRestoreTeam(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (api *API) InitTurn() {
+	api.BaseRoutes.Turn.Handle("/credentials", api.APISessionRequired(getTurnCredentials)).Methods("GET")
+}
+
+func getTurnCredentials(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("getTurnCredentials", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	credentials, appErr := c.App.GenerateTurnCredentials(c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("expires_at", credentials.ExpiresAt)
+
+	// The credentials are secret, they must not be cached by intermediaries.
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(credentials); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestGetTurnCredentials(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled", func(t *testing.T) {
+		_, resp, err := th.Client.GetTurnCredentials()
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TurnSettings.Enable = true
+		cfg.TurnSettings.URIs = []string{"turn:turn.example.com:3478", "turns:turn.example.com:5349"}
+		*cfg.TurnSettings.Secret = "north"
+	})
+
+	t.Run("requires a session", func(t *testing.T) {
+		client := th.CreateClient()
+		_, resp, err := client.GetTurnCredentials()
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("credentials of the user", func(t *testing.T) {
+		credentials, resp, err := th.Client.GetTurnCredentials()
+		require.NoError(t, err)
+		assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+		assert.Len(t, credentials.URLs, 2)
+		assert.True(t, strings.HasSuffix(credentials.Username, ":"+th.BasicUser.Id))
+		assert.NotEmpty(t, credentials.Credential)
+		assert.Equal(t, int64(model.TurnSettingsDefaultCredentialTTLSeconds), credentials.TTL)
+	})
+
+	t.Run("the secret is not exposed", func(t *testing.T) {
+		cfg, _, err := th.SystemAdminClient.GetConfig()
+		require.NoError(t, err)
+		assert.Equal(t, model.FakeSetting, *cfg.TurnSettings.Secret)
+	})
+}
@@ -11,11 +11,33 @@ import (
 )
 
 func (api *API) InitUsage() {
+	// GET /api/v4/usage
+	api.BaseRoutes.Usage.Handle("", api.APISessionRequired(getUsage)).Methods("GET")
+
 	// GET /api/v4/usage/posts
 	api.BaseRoutes.Usage.Handle("/posts", api.APISessionRequired(getPostsUsage)).Methods("GET")
 
 	// GET /api/v4/usage/integrations
 	api.BaseRoutes.Usage.Handle("/integrations", api.APISessionRequired(getIntegrationsUsage)).Methods("GET")
+
+	// GET /api/v4/usage/emoji?team_id=
+	api.BaseRoutes.Usage.Handle("/emoji", api.APISessionRequired(getTeamEmojiUsage)).Methods("GET")
+}
+
+func getUsage(c *Context, w http.ResponseWriter, r *http.Request) {
+	usage, appErr := c.App.GetUsage()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	json, err := json.Marshal(usage)
+	if err != nil {
+		c.Err = model.NewAppError("Api4.getUsage", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(json)
 }
 
 func getPostsUsage(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -60,3 +82,30 @@ func getIntegrationsUsage(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	w.Write(json)
 }
+
+func getTeamEmojiUsage(c *Context, w http.ResponseWriter, r *http.Request) {
+	teamID := r.URL.Query().Get("team_id")
+	if !model.IsValidId(teamID) {
+		c.SetInvalidURLParam("team_id")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), teamID, model.PermissionViewTeam) {
+		c.SetPermissionError(model.PermissionViewTeam)
+		return
+	}
+
+	usage, appErr := c.App.GetTeamEmojiUsage(teamID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	json, err := json.Marshal(usage)
+	if err != nil {
+		c.Err = model.NewAppError("Api4.getTeamEmojiUsage", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(json)
+}
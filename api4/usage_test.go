@@ -12,6 +12,38 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestGetUsage(t *testing.T) {
+	t.Run("unauthenticated users can not access", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+
+		th.Client.Logout()
+
+		usage, r, err := th.Client.GetUsage()
+		assert.Error(t, err)
+		assert.Nil(t, usage)
+		assert.Equal(t, http.StatusUnauthorized, r.StatusCode)
+	})
+
+	t.Run("good request returns the usage of all products", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		usage, r, err := th.Client.GetUsage()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+
+		names := []string{}
+		for _, counter := range usage.Counters {
+			assert.Nil(t, counter.Limit)
+			assert.False(t, counter.Exceeded)
+			names = append(names, counter.Name)
+		}
+		assert.Contains(t, names, model.UsageCounterMessagesHistory)
+		assert.Contains(t, names, model.UsageCounterTeamsActive)
+	})
+}
+
 func TestGetPostsUsage(t *testing.T) {
 	t.Run("unauthenticated users can not access", func(t *testing.T) {
 		th := Setup(t)
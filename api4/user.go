@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -44,6 +45,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("", api.APISessionRequired(deleteUser)).Methods("DELETE")
 	api.BaseRoutes.User.Handle("/roles", api.APISessionRequired(updateUserRoles)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/active", api.APISessionRequired(updateUserActive)).Methods("PUT")
+	api.BaseRoutes.Users.Handle("/active/batch", api.APISessionRequired(updateUsersActive)).Methods("POST")
 	api.BaseRoutes.User.Handle("/password", api.APISessionRequired(updatePassword)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/promote", api.APISessionRequired(promoteGuestToUser)).Methods("POST")
 	api.BaseRoutes.User.Handle("/demote", api.APISessionRequired(demoteUserToGuest)).Methods("POST")
@@ -60,6 +62,8 @@ func (api *API) InitUser() {
 
 	api.BaseRoutes.User.Handle("/mfa", api.APISessionRequiredMfa(updateUserMfa)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/mfa/generate", api.APISessionRequiredMfa(generateMfaSecret)).Methods("POST")
+	api.BaseRoutes.User.Handle("/mfa/backup_codes", api.APISessionRequiredMfa(regenerateMfaBackupCodes)).Methods("POST")
+	api.BaseRoutes.User.Handle("/mfa/reset", api.APISessionRequired(resetUserMfa)).Methods("POST")
 
 	api.BaseRoutes.Users.Handle("/login", api.APIHandler(login)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/login/switch", api.APIHandler(switchAccountType)).Methods("POST")
@@ -70,11 +74,16 @@ func (api *API) InitUser() {
 	api.BaseRoutes.UserByEmail.Handle("", api.APISessionRequired(getUserByEmail)).Methods("GET")
 
 	api.BaseRoutes.User.Handle("/sessions", api.APISessionRequired(getSessions)).Methods("GET")
+	api.BaseRoutes.User.Handle("/sessions/devices", api.APISessionRequired(getSessionDevices)).Methods("GET")
+	api.BaseRoutes.User.Handle("/sessions/devices/name", api.APISessionRequired(updateSessionDeviceName)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/sessions/revoke", api.APISessionRequired(revokeSession)).Methods("POST")
 	api.BaseRoutes.User.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsForUser)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsAllUsers)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/sessions/device", api.APISessionRequired(attachDeviceId)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/audits", api.APISessionRequired(getUserAudits)).Methods("GET")
+	api.BaseRoutes.User.Handle("/activity/export", api.APISessionRequired(exportUserActivity)).Methods("GET")
+	api.BaseRoutes.User.Handle("/notifications/diagnostics", api.APISessionRequired(getPushNotificationDiagnostics)).Methods("GET")
+	api.BaseRoutes.User.Handle("/notifications/preview", api.APISessionRequired(previewNotification)).Methods("POST")
 
 	api.BaseRoutes.User.Handle("/tokens", api.APISessionRequired(createUserAccessToken)).Methods("POST")
 	api.BaseRoutes.User.Handle("/tokens", api.APISessionRequired(getUserAccessTokensForUser)).Methods("GET")
@@ -89,10 +98,18 @@ func (api *API) InitUser() {
 
 	api.BaseRoutes.Users.Handle("/migrate_auth/ldap", api.APISessionRequired(migrateAuthToLDAP)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/migrate_auth/saml", api.APISessionRequired(migrateAuthToSaml)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/migrate_auth/jobs", api.APISessionRequired(createAuthMigrationJob)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/migrate_auth/jobs/{job_id:[A-Za-z0-9]+}", api.APISessionRequired(getAuthMigrationReport)).Methods("GET")
+	api.BaseRoutes.Users.Handle("/migrate_auth/jobs/{job_id:[A-Za-z0-9]+}/mismatches", api.APISessionRequired(downloadAuthMigrationMismatches)).Methods("GET")
+	api.BaseRoutes.Users.Handle("/merge", api.APISessionRequired(mergeUsers)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/merge/{job_id:[A-Za-z0-9]+}", api.APISessionRequired(getUserMergeReport)).Methods("GET")
+	api.BaseRoutes.Users.Handle("/deletion/{job_id:[A-Za-z0-9]+}", api.APISessionRequired(getUserDeletionReport)).Methods("GET")
+	api.BaseRoutes.User.Handle("/deletion", api.APISessionRequired(createUserDeletion)).Methods("POST")
 
 	api.BaseRoutes.User.Handle("/uploads", api.APISessionRequired(getUploadsForUser)).Methods("GET")
 	api.BaseRoutes.User.Handle("/channel_members", api.APISessionRequired(getChannelMembersForUser)).Methods("GET")
 	api.BaseRoutes.User.Handle("/recent_searches", api.APISessionRequiredDisableWhenBusy(getRecentSearches)).Methods("GET")
+	api.BaseRoutes.User.Handle("/unreads", api.APISessionRequired(getUnreadSummaryForUser)).Methods("GET")
 
 	api.BaseRoutes.Users.Handle("/invalid_emails", api.APISessionRequired(getUsersWithInvalidEmails)).Methods("GET")
 
@@ -1407,7 +1424,7 @@ func updateUserRoles(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 	auditRec.AddMeta("user", user)
-	c.LogAudit(fmt.Sprintf("user=%s roles=%s", c.Params.UserId, newRoles))
+	c.LogAuditWithUserId(c.Params.UserId, fmt.Sprintf("user=%s roles=%s", c.Params.UserId, newRoles))
 
 	ReturnStatusOK(w)
 }
@@ -1466,7 +1483,7 @@ func updateUserActive(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	auditRec.Success()
-	c.LogAudit(fmt.Sprintf("user_id=%s active=%v", user.Id, active))
+	c.LogAuditWithUserId(user.Id, fmt.Sprintf("user_id=%s active=%v", user.Id, active))
 
 	if isSelfDeactivate {
 		c.App.Srv().Go(func() {
@@ -1482,6 +1499,84 @@ func updateUserActive(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+// updateUsersActive activates or deactivates several users at once, each independently of the
+// others. Unlike updateUserActive, it doesn't let users deactivate themselves.
+func updateUsersActive(c *Context, w http.ResponseWriter, r *http.Request) {
+	var batch struct {
+		UserIds []string `json:"user_ids"`
+		Active  *bool    `json:"active"`
+	}
+	if jsonErr := json.NewDecoder(r.Body).Decode(&batch); jsonErr != nil {
+		c.SetInvalidParam("user_ids")
+		return
+	}
+
+	if batch.Active == nil {
+		c.SetInvalidParam("active")
+		return
+	}
+	active := *batch.Active
+
+	if !checkBulkItems(c, len(batch.UserIds)) {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateUsersActive", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("active", active)
+	auditRec.AddMeta("user_ids", batch.UserIds)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementUsers) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementUsers)
+		return
+	}
+
+	canManageSystem := c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem)
+
+	resp := model.NewBulkResponse(len(batch.UserIds))
+	for _, userID := range batch.UserIds {
+		if !model.IsValidId(userID) {
+			resp.AddError(userID, web.NewInvalidParamError("user_id"))
+			continue
+		}
+
+		user, err := c.App.GetUser(userID)
+		if err != nil {
+			resp.AddError(userID, err)
+			continue
+		}
+
+		if user.IsSystemAdmin() && !canManageSystem {
+			resp.AddError(userID, c.App.MakePermissionError(c.AppContext.Session(), []*model.Permission{model.PermissionManageSystem}))
+			continue
+		}
+
+		if active && user.IsGuest() && !*c.App.Config().GuestAccountsSettings.Enable {
+			resp.AddError(userID, model.NewAppError("updateUsersActive", "api.user.update_active.cannot_enable_guest_when_guest_feature_is_disabled.app_error", nil, "userId="+userID, http.StatusUnauthorized))
+			continue
+		}
+
+		if _, err := c.App.UpdateActive(c.AppContext, user, active); err != nil {
+			resp.AddError(userID, err)
+			continue
+		}
+
+		resp.AddSuccess(userID, http.StatusOK, nil)
+		c.LogAuditWithUserId(user.Id, fmt.Sprintf("user_id=%s active=%v", user.Id, active))
+	}
+
+	auditRec.AddMeta("succeeded", resp.Succeeded)
+	auditRec.AddMeta("failed", resp.Failed)
+	if resp.Succeeded > 0 {
+		auditRec.Success()
+
+		message := model.NewWebSocketEvent(model.WebsocketEventUserActivationStatusChange, "", "", "", nil)
+		c.App.Publish(message)
+	}
+
+	writeBulkResponse(c, w, resp)
+}
+
 func updateUserAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.IsSystemAdmin() {
 		c.SetPermissionError(model.PermissionEditOtherUsers)
@@ -1519,7 +1614,7 @@ func updateUserAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 	auditRec.AddMeta("auth_service", user.AuthService)
-	c.LogAudit(fmt.Sprintf("updated user %s auth to service=%v", c.Params.UserId, user.AuthService))
+	c.LogAuditWithUserId(c.Params.UserId, fmt.Sprintf("updated user %s auth to service=%v", c.Params.UserId, user.AuthService))
 
 	if err := json.NewEncoder(w).Encode(user); err != nil {
 		mlog.Warn("Error while writing response", mlog.Err(err))
@@ -1577,6 +1672,108 @@ func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("activate", activate)
 	c.LogAudit("success - mfa updated")
 
+	if !activate {
+		ReturnStatusOK(w)
+		return
+	}
+
+	// The backup codes are generated at enrollment, and only shown once.
+	backupCodes, err := c.App.GenerateMfaBackupCodes(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":       "OK",
+		"backup_codes": backupCodes.BackupCodes,
+	}); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func regenerateMfaBackupCodes(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("regenerateMfaBackupCodes", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	// Nobody but the user can see their backup codes, administrators included.
+	if c.AppContext.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	props := model.MapFromJSON(r.Body)
+	code := props["code"]
+	if code == "" {
+		c.SetInvalidParam("code")
+		return
+	}
+
+	backupCodes, err := c.App.RegenerateMfaBackupCodes(c.Params.UserId, code)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(backupCodes); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func resetUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("resetUserMfa", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	// Users who still have access to their authenticator app can deactivate it themselves.
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionEditOtherUsers) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	user, err := c.App.GetUser(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddMeta("user", user)
+
+	if user.IsSystemAdmin() && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	if err := c.App.ResetUserMfa(user.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	c.LogAuditWithUserId(c.Params.UserId, "success - mfa reset")
+
 	ReturnStatusOK(w)
 }
 
@@ -1976,6 +2173,71 @@ func getSessions(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(js)
 }
 
+func getSessionDevices(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	devices, err := c.App.GetSessionDevices(c.Params.UserId, c.AppContext.Session().Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	js, jsonErr := json.Marshal(devices)
+	if jsonErr != nil {
+		c.Err = model.NewAppError("getSessionDevices", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(js)
+}
+
+func updateSessionDeviceName(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateSessionDeviceName", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	props := model.MapFromJSON(r.Body)
+	sessionId := props["session_id"]
+	if sessionId == "" {
+		c.SetInvalidParam("session_id")
+		return
+	}
+	auditRec.AddMeta("session_id", sessionId)
+	auditRec.AddMeta("name", props["name"])
+
+	device, err := c.App.UpdateSessionDeviceName(c.Params.UserId, sessionId, props["name"])
+	if err != nil {
+		c.Err = err
+		return
+	}
+	device.Current = device.SessionId == c.AppContext.Session().Id
+
+	auditRec.Success()
+
+	js, jsonErr := json.Marshal(device)
+	if jsonErr != nil {
+		c.Err = model.NewAppError("updateSessionDeviceName", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(js)
+}
+
 func revokeSession(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -2041,7 +2303,7 @@ func revokeAllSessionsForUser(c *Context, w http.ResponseWriter, r *http.Request
 	}
 
 	auditRec.Success()
-	c.LogAudit("")
+	c.LogAuditWithUserId(c.Params.UserId, "")
 
 	ReturnStatusOK(w)
 }
@@ -2155,6 +2417,111 @@ func getUserAudits(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func exportUserActivity(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("exportUserActivity", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDownloadComplianceExportResult) {
+		c.SetPermissionError(model.PermissionDownloadComplianceExportResult)
+		return
+	}
+
+	until := model.GetMillis()
+	if untilString := r.URL.Query().Get("until"); untilString != "" {
+		var parseError error
+		until, parseError = strconv.ParseInt(untilString, 10, 64)
+		if parseError != nil {
+			c.SetInvalidParam("until")
+			return
+		}
+	}
+
+	since := until - model.UserActivityReportDefaultPeriod
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		var parseError error
+		since, parseError = strconv.ParseInt(sinceString, 10, 64)
+		if parseError != nil {
+			c.SetInvalidParam("since")
+			return
+		}
+	}
+
+	report, err := c.App.GetUserActivityReport(c.Params.UserId, since, until)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	report.GeneratedBy = c.AppContext.Session().UserId
+
+	auditRec.Success()
+	auditRec.AddMeta("since", since)
+	auditRec.AddMeta("until", until)
+	c.LogAudit("user_id=" + c.Params.UserId)
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=\"user_activity_%s.json\"", c.Params.UserId))
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPushNotificationDiagnostics(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	diagnostics, err := c.App.GetPushNotificationDiagnostics(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(diagnostics); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// previewNotification renders the notifications the user would be sent for the post in the body
+// of the request, in their locale and timezone.
+func previewNotification(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	var req model.NotificationPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !model.IsValidId(req.PostId) {
+		c.SetInvalidParam("post_id")
+		return
+	}
+
+	preview, appErr := c.App.PreviewNotification(c.Params.UserId, req.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(preview); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func verifyUserEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 	props := model.MapFromJSON(r.Body)
 
@@ -2969,6 +3336,234 @@ func migrateAuthToSaml(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func createAuthMigrationJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	var migrationRequest model.AuthMigrationRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&migrationRequest); jsonErr != nil {
+		c.SetInvalidParam("migration")
+		return
+	}
+
+	if err := migrationRequest.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createAuthMigrationJob", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("from", migrationRequest.From)
+	auditRec.AddMeta("to", migrationRequest.To)
+	auditRec.AddMeta("match_field", migrationRequest.MatchField)
+	auditRec.AddMeta("dry_run", migrationRequest.DryRun)
+	auditRec.AddMeta("partial", migrationRequest.Partial)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	job, err := c.App.CreateAuthMigrationJob(&migrationRequest, c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("job", job)
+	c.LogAudit("from=" + migrationRequest.From + " to=" + migrationRequest.To + " job_id=" + job.Id)
+
+	report, err := c.App.GetAuthMigrationReport(job.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getAuthMigrationReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	report, err := c.App.GetAuthMigrationReport(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func downloadAuthMigrationMismatches(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	data, err := c.App.GetAuthMigrationMismatches(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	writeFileResponse(c.Params.JobId+"_mismatches.csv", "text/csv", int64(len(data)), time.Now(), *c.App.Config().ServiceSettings.WebserverMode, bytes.NewReader(data), true, w, r)
+}
+
+func mergeUsers(c *Context, w http.ResponseWriter, r *http.Request) {
+	var mergeRequest model.UserMergeRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&mergeRequest); jsonErr != nil {
+		c.SetInvalidParam("merge")
+		return
+	}
+
+	if err := mergeRequest.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("mergeUsers", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("source_user_id", mergeRequest.SourceUserId)
+	auditRec.AddMeta("target_user_id", mergeRequest.TargetUserId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	job, err := c.App.CreateUserMergeJob(&mergeRequest, c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("job", job)
+	c.LogAudit("source_user_id=" + mergeRequest.SourceUserId + " target_user_id=" + mergeRequest.TargetUserId + " job_id=" + job.Id)
+
+	report, err := c.App.GetUserMergeReport(job.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getUserMergeReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	report, err := c.App.GetUserMergeReport(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// createUserDeletion schedules the staged deletion of a user, whose personal data is exported
+// before it is anonymized or removed.
+func createUserDeletion(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var deletionRequest model.UserDeletionRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&deletionRequest); jsonErr != nil {
+		c.SetInvalidParam("deletion")
+		return
+	}
+
+	if err := deletionRequest.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createUserDeletion", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+	auditRec.AddMeta("policy", deletionRequest.Policy)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	job, err := c.App.CreateUserDeletionJob(c.Params.UserId, &deletionRequest, c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("job", job)
+	c.LogAuditWithUserId(c.Params.UserId, "policy="+deletionRequest.Policy+" job_id="+job.Id)
+
+	report, err := c.App.GetUserDeletionReport(job.Id)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getUserDeletionReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	report, err := c.App.GetUserDeletionReport(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getThreadForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId().RequireTeamId().RequireThreadId()
 	if c.Err != nil {
@@ -3268,3 +3863,27 @@ func getRecentSearches(c *Context, w http.ResponseWriter, r *http.Request) {
 		mlog.Warn("Error while writing response", mlog.Err(err))
 	}
 }
+
+func getUnreadSummaryForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	includeCollapsedThreads := r.URL.Query().Get("include_collapsed_threads") == "true"
+
+	summary, err := c.App.GetUnreadSummaryForUser(c.Params.UserId, includeCollapsedThreads)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		mlog.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -2182,6 +2183,56 @@ func assertWebsocketEventUserUpdatedWithEmail(t *testing.T, client *model.WebSoc
 	})
 }
 
+func TestUpdateUsersActive(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user1 := th.CreateUser()
+	user2 := th.CreateUser()
+
+	t.Run("regular users can't update other users", func(t *testing.T) {
+		_, resp, err := th.Client.UpdateUsersActive([]string{user1.Id}, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("too many users", func(t *testing.T) {
+		userIDs := make([]string, MaxBulkItems+1)
+		for i := range userIDs {
+			userIDs[i] = model.NewId()
+		}
+		_, resp, err := th.SystemAdminClient.UpdateUsersActive(userIDs, false)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	result, resp, err := th.SystemAdminClient.UpdateUsersActive([]string{user1.Id, user2.Id, "junk", model.NewId()}, false)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	require.Len(t, result.Results, 4)
+	assert.True(t, result.Results[0].IsSuccess())
+	assert.True(t, result.Results[1].IsSuccess())
+	assert.Equal(t, http.StatusBadRequest, result.Results[2].StatusCode)
+	assert.Equal(t, http.StatusNotFound, result.Results[3].StatusCode)
+
+	for _, user := range []*model.User{user1, user2} {
+		ruser, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, ruser.DeleteAt)
+	}
+
+	result, resp, err = th.SystemAdminClient.UpdateUsersActive([]string{user1.Id, user2.Id}, true)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	assert.Equal(t, 2, result.Succeeded)
+
+	ruser, appErr := th.App.GetUser(user1.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, ruser.DeleteAt)
+}
+
 func TestUpdateUserActive(t *testing.T) {
 	t.Run("basic tests", func(t *testing.T) {
 		th := Setup(t).InitBasic()
@@ -2834,6 +2885,79 @@ func TestUserLoginMFAFlow(t *testing.T) {
 	})
 }
 
+func TestMfaBackupCodes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("mfa"))
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	secret, _, err := th.Client.GenerateMfaSecret(th.BasicUser.Id)
+	require.NoError(t, err)
+	currentCode := func() string {
+		return fmt.Sprintf("%06d", dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30))
+	}
+
+	backupCodes, _, err := th.Client.ActivateUserMfa(th.BasicUser.Id, currentCode())
+	require.NoError(t, err)
+	require.Len(t, backupCodes.BackupCodes, 10)
+
+	t.Run("log in with a backup code", func(t *testing.T) {
+		client := th.CreateClient()
+		user, _, err := client.LoginWithMFA(th.BasicUser.Email, th.BasicUser.Password, backupCodes.BackupCodes[0])
+		require.NoError(t, err)
+		require.Equal(t, th.BasicUser.Id, user.Id)
+
+		_, _, err = client.LoginWithMFA(th.BasicUser.Email, th.BasicUser.Password, backupCodes.BackupCodes[0])
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+	})
+
+	t.Run("regenerate the backup codes", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.RegenerateMfaBackupCodes(th.BasicUser.Id, currentCode())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.RegenerateMfaBackupCodes(th.BasicUser.Id, "000000")
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+
+		regenerated, _, err := th.Client.RegenerateMfaBackupCodes(th.BasicUser.Id, backupCodes.BackupCodes[1])
+		require.NoError(t, err)
+		require.Len(t, regenerated.BackupCodes, 10)
+
+		_, _, err = th.CreateClient().LoginWithMFA(th.BasicUser.Email, th.BasicUser.Password, backupCodes.BackupCodes[2])
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+
+		_, _, err = th.CreateClient().LoginWithMFA(th.BasicUser.Email, th.BasicUser.Password, regenerated.BackupCodes[0])
+		require.NoError(t, err)
+	})
+
+	t.Run("reset the mfa of a user", func(t *testing.T) {
+		resp, err := th.Client.ResetUserMfa(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.SystemAdminClient.ResetUserMfa(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, err = th.SystemAdminClient.ResetUserMfa(th.BasicUser.Id)
+		require.NoError(t, err)
+
+		user, appErr := th.App.GetUser(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.False(t, user.MfaActive)
+
+		sessions, appErr := th.App.GetSessions(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Empty(t, sessions, "the sessions of the user are revoked")
+
+		count, err := th.App.Srv().Store.MfaBackupCode().CountUnusedForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
+
 func TestGenerateMfaSecret(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -3084,6 +3208,73 @@ func TestGetSessions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetSessionDevices(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	geoIPPath := filepath.Join(t.TempDir(), "geoip.csv")
+	err := os.WriteFile(geoIPPath, []byte("127.0.0.0,127.255.255.255,EU,FR,Ile-de-France,Paris\n::1,::1,EU,FR,Ile-de-France,Paris\n"), 0600)
+	require.NoError(t, err)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.GeoIPDatabasePath = geoIPPath })
+
+	user := th.BasicUser
+	th.Client.Login(user.Email, user.Password)
+
+	devices, _, err := th.Client.GetSessionDevices(model.Me)
+	require.NoError(t, err)
+	var current *model.SessionDevice
+	for _, device := range devices {
+		if device.Current {
+			current = device
+		}
+	}
+	require.NotNil(t, current, "the device of the current session is listed")
+	require.NotEmpty(t, current.Fingerprint)
+	require.NotEmpty(t, current.IPAddress)
+	require.NotNil(t, current.Location)
+	require.Equal(t, "FR", current.Location.Country)
+	require.Equal(t, "Paris", current.Location.City)
+
+	t.Run("name a device", func(t *testing.T) {
+		device, _, err := th.Client.UpdateSessionDeviceName(model.Me, current.SessionId, "Work laptop")
+		require.NoError(t, err)
+		require.Equal(t, "Work laptop", device.Name)
+		require.True(t, device.Current)
+
+		devices, _, err := th.Client.GetSessionDevices(user.Id)
+		require.NoError(t, err)
+		for _, device := range devices {
+			if device.SessionId == current.SessionId {
+				require.Equal(t, "Work laptop", device.Name)
+			}
+		}
+
+		_, resp, err := th.Client.UpdateSessionDeviceName(model.Me, current.SessionId, strings.Repeat("a", model.SessionDeviceNameMaxRunes+1))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("can't name the devices of other users", func(t *testing.T) {
+		_, resp, err := th.Client.UpdateSessionDeviceName(th.BasicUser2.Id, current.SessionId, "Work laptop")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.UpdateSessionDeviceName(th.SystemAdminUser.Id, current.SessionId, "Work laptop")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("can't list the devices of other users", func(t *testing.T) {
+		_, resp, err := th.Client.GetSessionDevices(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		devices, _, err := th.SystemAdminClient.GetSessionDevices(user.Id)
+		require.NoError(t, err)
+		require.NotEmpty(t, devices)
+	})
+}
+
 func TestRevokeSessions(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -3298,6 +3489,212 @@ func TestGetUserAudits(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestExportUserActivity(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	user := th.BasicUser
+
+	var fileId string
+	if *th.App.Config().FileSettings.DriverName != "" {
+		sent, err := testutils.ReadTestFile("test.png")
+		require.NoError(t, err)
+		fileResp, _, err := th.Client.UploadFile(sent, th.BasicChannel.Id, "test.png")
+		require.NoError(t, err)
+		fileId = fileResp.FileInfos[0].Id
+		_, _, err = th.Client.GetFile(fileId)
+		require.NoError(t, err)
+	}
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.ExportUserActivity(user.Id, 0, 0)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		now := model.GetMillis()
+		_, resp, err := th.SystemAdminClient.ExportUserActivity(user.Id, now, now-1000)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.ExportUserActivity(user.Id, now-model.UserActivityReportMaxPeriod-1, now)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.ExportUserActivity(model.NewId(), 0, 0)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		report, resp, err := th.SystemAdminClient.ExportUserActivity(user.Id, 0, 0)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), "user_activity_"+user.Id+".json")
+
+		assert.Equal(t, user.Id, report.UserId)
+		assert.Equal(t, user.Username, report.Username)
+		assert.Equal(t, th.SystemAdminUser.Id, report.GeneratedBy)
+		assert.Equal(t, model.UserActivityReportDefaultPeriod, report.Until-report.Since)
+		assert.NotEmpty(t, report.Sessions)
+		for _, session := range report.Sessions {
+			assert.Empty(t, session.Token)
+		}
+
+		var channel *model.UserActivityChannel
+		for _, c := range report.Channels {
+			if c.ChannelId == th.BasicChannel.Id {
+				channel = c
+			}
+		}
+		require.NotNil(t, channel, "the report should list the channels of the user")
+		assert.Equal(t, th.BasicChannel.Name, channel.Name)
+		assert.Equal(t, th.BasicTeam.Id, channel.TeamId)
+		assert.Nil(t, channel.LeaveTime)
+
+		if fileId != "" {
+			require.Len(t, report.FileDownloads, 1)
+			assert.Equal(t, fileId, report.FileDownloads[0].FileId)
+			assert.Equal(t, "test.png", report.FileDownloads[0].Name)
+			assert.Equal(t, th.BasicChannel.Id, report.FileDownloads[0].ChannelId)
+		}
+	})
+
+	t.Run("admin actions", func(t *testing.T) {
+		_, err := th.SystemAdminClient.UpdateUserActive(user.Id, true)
+		require.NoError(t, err)
+
+		report, _, err := th.SystemAdminClient.ExportUserActivity(user.Id, 0, 0)
+		require.NoError(t, err)
+		require.NotEmpty(t, report.AdminActions)
+		for _, action := range report.AdminActions {
+			assert.Contains(t, action.ExtraInfo, "session_user="+th.SystemAdminUser.Id)
+		}
+		for _, action := range report.Actions {
+			assert.NotContains(t, action.ExtraInfo, "session_user="+th.SystemAdminUser.Id)
+		}
+	})
+}
+
+func TestGetPushNotificationDiagnostics(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	receipt := &model.PushNotificationReceipt{
+		AckId:    model.NewId(),
+		UserId:   th.BasicUser.Id,
+		PostId:   th.BasicPost.Id,
+		Platform: model.PushNotifyApple,
+		Type:     model.PushTypeMessage,
+		Status:   model.PushReceiptStatusSent,
+	}
+	_, err := th.App.Srv().Store.PushNotificationReceipt().Save(receipt)
+	require.NoError(t, err)
+
+	t.Run("own diagnostics", func(t *testing.T) {
+		diagnostics, _, err := th.Client.GetPushNotificationDiagnostics("me")
+		require.NoError(t, err)
+		require.Len(t, diagnostics.Receipts, 1)
+		assert.Equal(t, receipt.AckId, diagnostics.Receipts[0].AckId)
+		assert.Equal(t, 1, diagnostics.Sent)
+		assert.Equal(t, 0, diagnostics.Received)
+	})
+
+	t.Run("receipt is marked received on ack", func(t *testing.T) {
+		// the push proxy is not reachable in tests, the receipt is updated regardless.
+		_ = th.App.SendAckToPushProxy(&model.PushNotificationAck{
+			Id:               receipt.AckId,
+			ClientReceivedAt: model.GetMillis(),
+			NotificationType: model.PushTypeMessage,
+		})
+
+		diagnostics, _, err := th.Client.GetPushNotificationDiagnostics(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, diagnostics.Receipts, 1)
+		assert.Equal(t, model.PushReceiptStatusReceived, diagnostics.Receipts[0].Status)
+		assert.Equal(t, 1, diagnostics.Received)
+	})
+
+	t.Run("other user's diagnostics", func(t *testing.T) {
+		_, resp, err := th.Client.GetPushNotificationDiagnostics(th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, _, err = th.SystemAdminClient.GetPushNotificationDiagnostics(th.BasicUser.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		th.Client.Logout()
+		_, resp, err := th.Client.GetPushNotificationDiagnostics(th.BasicUser.Id)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
+func TestPreviewNotification(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.BasicUser2
+	user.Locale = "es"
+	user.Timezone = map[string]string{
+		"useAutomaticTimezone": "false",
+		"manualTimezone":       "Asia/Tokyo",
+	}
+	_, appErr := th.App.UpdateUser(user, false)
+	require.Nil(t, appErr)
+
+	t.Run("requires the manage system permission", func(t *testing.T) {
+		_, resp, err := th.Client.PreviewNotification(user.Id, th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid and missing posts", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.PreviewNotification(user.Id, "junk")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.PreviewNotification(user.Id, model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("rendered in the user's locale and timezone", func(t *testing.T) {
+		preview, _, err := th.SystemAdminClient.PreviewNotification(user.Id, th.BasicPost.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "es", preview.Locale)
+		assert.Equal(t, "Asia/Tokyo", preview.Timezone)
+		assert.Contains(t, preview.EmailBody, "JST")
+		assert.NotEmpty(t, preview.EmailSubject)
+		assert.NotEmpty(t, preview.PushMessage)
+	})
+
+	t.Run("template overrides apply to their locale", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.LocalizationSettings.NotificationTemplateOverrides = []*model.NotificationTemplateOverride{{
+				Locale:        "es",
+				TranslationId: "app.notification.subject.notification.full",
+				Translation:   map[string]string{"other": "[{{.SiteName}}] Mención en {{.TeamName}}"},
+			}}
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.LocalizationSettings.NotificationTemplateOverrides = []*model.NotificationTemplateOverride{}
+		})
+
+		preview, _, err := th.SystemAdminClient.PreviewNotification(user.Id, th.BasicPost.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "["+*th.App.Config().TeamSettings.SiteName+"] Mención en "+th.BasicTeam.DisplayName, preview.EmailSubject)
+
+		preview, _, err = th.SystemAdminClient.PreviewNotification(th.BasicUser.Id, th.BasicPost.Id)
+		require.NoError(t, err)
+		assert.NotContains(t, preview.EmailSubject, "Mención")
+	})
+}
+
 func TestVerifyUserEmail(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -6893,3 +7290,205 @@ func TestUserUpdateEvents(t *testing.T) {
 		})
 	})
 }
+
+func TestMergeUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	duplicate := th.CreateUser()
+
+	t.Run("requires manage system", func(t *testing.T) {
+		_, resp, err := th.Client.MergeUsers(&model.UserMergeRequest{SourceUserId: duplicate.Id, TargetUserId: th.BasicUser.Id})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.MergeUsers(&model.UserMergeRequest{SourceUserId: duplicate.Id, TargetUserId: duplicate.Id})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.MergeUsers(&model.UserMergeRequest{SourceUserId: duplicate.Id, TargetUserId: model.NewId()})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("schedules a job", func(t *testing.T) {
+		report, resp, err := th.SystemAdminClient.MergeUsers(&model.UserMergeRequest{SourceUserId: duplicate.Id, TargetUserId: th.BasicUser.Id})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.NotEmpty(t, report.JobId)
+		assert.Equal(t, duplicate.Id, report.SourceUserId)
+		assert.Equal(t, th.BasicUser.Id, report.TargetUserId)
+		assert.Equal(t, model.JobStatusPending, report.Status)
+
+		fetched, _, err := th.SystemAdminClient.GetUserMergeReport(report.JobId)
+		require.NoError(t, err)
+		assert.Equal(t, report.JobId, fetched.JobId)
+
+		_, resp, err = th.Client.GetUserMergeReport(report.JobId)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestCreateAuthMigrationJob(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	migrationRequest := &model.AuthMigrationRequest{
+		From:    model.UserAuthServiceEmail,
+		To:      model.UserAuthServiceSaml,
+		Matches: map[string]string{th.BasicUser.Email: "saml-basic"},
+		DryRun:  true,
+	}
+
+	t.Run("requires manage system", func(t *testing.T) {
+		_, resp, err := th.Client.CreateAuthMigrationJob(migrationRequest)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateAuthMigrationJob(&model.AuthMigrationRequest{From: model.UserAuthServiceEmail, To: model.ServiceGitlab})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires a license", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateAuthMigrationJob(migrationRequest)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("schedules a job", func(t *testing.T) {
+		th.App.Srv().SetLicense(model.NewTestLicense("saml"))
+		defer th.App.Srv().RemoveLicense()
+
+		report, resp, err := th.SystemAdminClient.CreateAuthMigrationJob(migrationRequest)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.NotEmpty(t, report.JobId)
+		assert.Equal(t, model.UserAuthServiceSaml, report.To)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, model.JobStatusPending, report.Status)
+
+		fetched, _, err := th.SystemAdminClient.GetAuthMigrationReport(report.JobId)
+		require.NoError(t, err)
+		assert.Equal(t, report.JobId, fetched.JobId)
+
+		_, resp, err = th.SystemAdminClient.DownloadAuthMigrationMismatches(report.JobId)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestGetUnreadSummary(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.LoginBasic2()
+	dm, _, err := th.Client.CreateDirectChannel(th.BasicUser2.Id, th.BasicUser.Id)
+	require.NoError(t, err)
+	_, _, err = th.Client.CreatePost(&model.Post{ChannelId: dm.Id, Message: "hello"})
+	require.NoError(t, err)
+	_, _, err = th.Client.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "@" + th.BasicUser.Username})
+	require.NoError(t, err)
+
+	th.LoginBasic()
+
+	t.Run("includes direct messages", func(t *testing.T) {
+		summary, _, err := th.Client.GetUnreadSummary("me", false)
+		require.NoError(t, err)
+
+		unreads := map[string]*model.ChannelUnread{}
+		for _, cu := range summary.Channels {
+			unreads[cu.ChannelId] = cu
+		}
+		require.Contains(t, unreads, dm.Id)
+		assert.Equal(t, "", unreads[dm.Id].TeamId)
+		assert.EqualValues(t, 1, unreads[dm.Id].MsgCount)
+		require.Contains(t, unreads, th.BasicChannel.Id)
+		assert.EqualValues(t, 1, unreads[th.BasicChannel.Id].MentionCount)
+		assert.Empty(t, summary.Threads)
+	})
+
+	t.Run("leaves out read channels", func(t *testing.T) {
+		_, _, err := th.Client.ViewChannel(th.BasicUser.Id, &model.ChannelView{ChannelId: dm.Id})
+		require.NoError(t, err)
+
+		summary, _, err := th.Client.GetUnreadSummary(th.BasicUser.Id, true)
+		require.NoError(t, err)
+		for _, cu := range summary.Channels {
+			assert.NotEqual(t, dm.Id, cu.ChannelId)
+		}
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp, err := th.Client.GetUnreadSummary(th.BasicUser2.Id, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, _, err = th.SystemAdminClient.GetUnreadSummary(th.BasicUser.Id, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("logged out", func(t *testing.T) {
+		th.Client.Logout()
+		_, resp, err := th.Client.GetUnreadSummary(th.BasicUser.Id, false)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
+func TestUserDeletion(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+
+	t.Run("requires manage system", func(t *testing.T) {
+		_, resp, err := th.Client.DeleteUserInStages(user.Id, &model.UserDeletionRequest{Policy: model.UserDeletionPolicyAnonymize})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.DeleteUserInStages(user.Id, &model.UserDeletionRequest{Policy: "archive"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.DeleteUserInStages(model.NewId(), &model.UserDeletionRequest{Policy: model.UserDeletionPolicyAnonymize})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("removing requires the API user deletion", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableAPIUserDeletion = false })
+		_, resp, err := th.SystemAdminClient.DeleteUserInStages(user.Id, &model.UserDeletionRequest{Policy: model.UserDeletionPolicyRemove})
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("schedules a job", func(t *testing.T) {
+		report, resp, err := th.SystemAdminClient.DeleteUserInStages(user.Id, &model.UserDeletionRequest{Policy: model.UserDeletionPolicyAnonymize})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.NotEmpty(t, report.JobId)
+		assert.Equal(t, user.Id, report.UserId)
+		assert.Equal(t, model.UserDeletionPolicyAnonymize, report.Policy)
+		assert.Equal(t, model.JobStatusPending, report.Status)
+
+		_, resp, err = th.SystemAdminClient.DeleteUserInStages(user.Id, &model.UserDeletionRequest{Policy: model.UserDeletionPolicyAnonymize})
+		require.Error(t, err, "the deletion of a user is only scheduled once")
+		CheckBadRequestStatus(t, resp)
+
+		fetched, _, err := th.SystemAdminClient.GetUserDeletionReport(report.JobId)
+		require.NoError(t, err)
+		assert.Equal(t, report.JobId, fetched.JobId)
+
+		_, resp, err = th.Client.GetUserDeletionReport(report.JobId)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
@@ -993,6 +993,39 @@ func TestRegenOutgoingHookToken(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestRegenOutgoingHookSigningSecret(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}, SigningSecret: "chosen by the client"}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(hook)
+	require.NoError(t, err)
+	require.Len(t, rhook.SigningSecret, 26, "should generate the signing secret")
+
+	_, resp, err := th.SystemAdminClient.RegenOutgoingHookSigningSecret("junk")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	regenHook, _, err := th.SystemAdminClient.RegenOutgoingHookSigningSecret(rhook.Id)
+	require.NoError(t, err)
+	require.Len(t, regenHook.SigningSecret, 26)
+	require.NotEqual(t, rhook.SigningSecret, regenHook.SigningSecret, "regen didn't work properly")
+	require.Equal(t, rhook.Token, regenHook.Token, "the token shouldn't change")
+
+	secret := regenHook.SigningSecret
+	regenHook.SigningSecret = ""
+	updatedHook, _, err := th.SystemAdminClient.UpdateOutgoingWebhook(regenHook)
+	require.NoError(t, err)
+	require.Equal(t, secret, updatedHook.SigningSecret, "should keep the signing secret on update")
+
+	_, resp, err = client.RegenOutgoingHookSigningSecret(rhook.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
 func TestUpdateOutgoingHook(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -5,6 +5,7 @@ package api4
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/websocket"
 
@@ -14,8 +15,9 @@ import (
 )
 
 const (
-	connectionIDParam   = "connection_id"
-	sequenceNumberParam = "sequence_number"
+	connectionIDParam      = "connection_id"
+	sequenceNumberParam    = "sequence_number"
+	pluginEventsSinceParam = "plugin_events_since"
 )
 
 func (api *API) InitWebSocket() {
@@ -64,6 +66,14 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 	wc := c.App.NewWebConn(cfg)
 	if c.AppContext.Session().UserId != "" {
 		c.App.HubRegister(wc)
+
+		// The client passes the creation time of the last persistent event it received to get
+		// the ones it missed while disconnected.
+		if sinceVal := r.URL.Query().Get(pluginEventsSinceParam); sinceVal != "" {
+			if since, err := strconv.ParseInt(sinceVal, 10, 64); err == nil {
+				c.App.ReplayPersistentWebSocketEvents(wc, since)
+			}
+		}
 	}
 
 	wc.Pump()
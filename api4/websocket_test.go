@@ -417,3 +417,57 @@ func TestWebSocketStatuses(t *testing.T) {
 
 	WebSocketClient.Close()
 }
+
+func TestWebSocketReauthenticate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	oldToken := th.Client.AuthToken
+	WebSocketClient, err := th.CreateWebSocketClient()
+	require.NoError(t, err)
+	defer WebSocketClient.Close()
+	WebSocketClient.Listen()
+
+	resp := <-WebSocketClient.ResponseChannel
+	require.Equal(t, resp.Status, model.StatusOk, "should have responded OK to authentication challenge")
+
+	t.Run("invalid token", func(t *testing.T) {
+		WebSocketClient.Reauthenticate(model.NewId())
+		resp := <-WebSocketClient.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, http.StatusUnauthorized, resp.Error.StatusCode)
+	})
+
+	t.Run("token of another user", func(t *testing.T) {
+		client := th.CreateClient()
+		th.LoginBasic2WithClient(client)
+
+		WebSocketClient.Reauthenticate(client.AuthToken)
+		resp := <-WebSocketClient.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.Error.StatusCode)
+	})
+
+	t.Run("fresh token of the same user", func(t *testing.T) {
+		client := th.CreateClient()
+		th.LoginBasicWithClient(client)
+
+		WebSocketClient.Reauthenticate(client.AuthToken)
+		resp := <-WebSocketClient.ResponseChannel
+		require.Nil(t, resp.Error, resp.Error)
+		require.Equal(t, model.StatusOk, resp.Status)
+		require.Equal(t, resp.SeqReply, WebSocketClient.Sequence-1, "bad sequence number")
+		require.NotNil(t, resp.Data["expires_at"])
+
+		// Revoking the session the connection was opened with keeps it open.
+		oldSession, appErr := th.App.GetSession(oldToken)
+		require.Nil(t, appErr)
+		require.Nil(t, th.App.RevokeSession(oldSession))
+		time.Sleep(300 * time.Millisecond)
+
+		WebSocketClient.GetStatuses()
+		resp = <-WebSocketClient.ResponseChannel
+		require.Nil(t, resp.Error, resp.Error)
+		require.Equal(t, resp.SeqReply, WebSocketClient.Sequence-1, "bad sequence number")
+	})
+}
@@ -121,6 +121,12 @@ func (a *App) Metrics() einterfaces.MetricsInterface {
 func (a *App) Notification() einterfaces.NotificationInterface {
 	return a.ch.Notification
 }
+func (a *App) Transcription() einterfaces.TranscriptionInterface {
+	return a.ch.Transcription
+}
+func (a *App) ImageCaption() einterfaces.ImageCaptionInterface {
+	return a.ch.ImageCaption
+}
 func (a *App) Saml() einterfaces.SamlInterface {
 	return a.ch.Saml
 }
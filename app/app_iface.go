@@ -11,6 +11,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/tls"
+	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -43,6 +45,12 @@ type AppIface interface {
 	ListAutocompleteCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError)
 	// @openTracingParams teamID, skipSlackParsing
 	CreateCommandPost(c *request.Context, post *model.Post, teamID string, response *model.CommandResponse, skipSlackParsing bool) (*model.Post, *model.AppError)
+	// AcceptDirectMessageRequest lets the requester of the message request of a direct channel message
+	// its recipient freely, including after the recipient declined it.
+	AcceptDirectMessageRequest(channelID, userID string) *model.AppError
+	// ActOnPostReport applies the action of a moderator to a reported post, and closes every open
+	// report of the post.
+	ActOnPostReport(c *request.Context, report *model.PostReport, action *model.PostReportAction, moderatorID string) (*model.PostReport, *model.AppError)
 	// AddChannelMember adds a user to a channel. It is a wrapper over AddUserToChannel.
 	AddChannelMember(c *request.Context, userID string, channel *model.Channel, opts ChannelMemberOpts) (*model.ChannelMember, *model.AppError)
 	// AddCursorIdsForPostList adds NextPostId and PrevPostId as cursor to the PostList.
@@ -53,8 +61,43 @@ type AppIface interface {
 	AddPublicKey(name string, key io.Reader) *model.AppError
 	// AddUserToChannel adds a user to a given channel.
 	AddUserToChannel(user *model.User, channel *model.Channel, skipTeamMemberIntegrityCheck bool) (*model.ChannelMember, *model.AppError)
+	// AdvancedSearchPosts searches the posts of teamID, or of every team when teamID is empty,
+	// regardless of channel membership. Besides the regular search syntax, terms accept the has:link and
+	// has:file operators, and regex, when set, must match the message. Search engines can't evaluate
+	// these filters, so the search always runs against the database.
+	AdvancedSearchPosts(teamID, terms, regex string, timeZoneOffset int) (*model.PostList, *model.AppError)
+	// AnnounceDueChannelEvents announces the events due by now in their channels, returning how many
+	// were announced. Each event is scheduled again for its next occurrence, if it has one. An event
+	// that can't be announced isn't retried.
+	AnnounceDueChannelEvents(now int64) (int, *model.AppError)
+	// AnonymizeUser deactivates a user and strips the account of its profile, credentials and
+	// settings, keeping its posts and files where they are.
+	AnonymizeUser(c *request.Context, user *model.User) *model.AppError
+	// AnswerImpersonationRequest records the consent, or refusal, of the user to be impersonated.
+	AnswerImpersonationRequest(c *request.Context, impersonation *model.ImpersonationRequest, consent bool) (*model.ImpersonationRequest, *model.AppError)
+	// ApplyAuthMigration switches a verified user to the target provider and revokes their sessions.
+	ApplyAuthMigration(match *model.AuthMigrationMatch, to string) *model.AppError
+	// ApplyBulkChannelMemberAction adds the user to or removes them from the channel on behalf of the
+	// user that requested the bulk operation.
+	ApplyBulkChannelMemberAction(c *request.Context, channel *model.Channel, action, userID, requesterID string) *model.AppError
+	// ApplyDueScheduledConfigChanges applies the changes due by now, the first due first, returning
+	// how many were applied. A change that can't be applied, e.g. because the configuration changed
+	// since it was scheduled and merging it would make it invalid, is marked as failed.
+	ApplyDueScheduledConfigChanges(now int64) (int, *model.AppError)
+	// ApplyTeamTemplate provisions the channels, bot members, incoming webhooks and sidebar categories
+	// of the template in a newly created team on behalf of userID. Provisioning is best effort: an item
+	// that cannot be created is logged and skipped so the team is still usable.
+	ApplyTeamTemplate(c *request.Context, team *model.Team, template *model.TeamTemplate, userID string)
+	// ApproveChannelJoinRequest adds the user of a pending request to the channel, on behalf of the
+	// reviewer.
+	ApproveChannelJoinRequest(c *request.Context, joinRequest *model.ChannelJoinRequest, reviewerID string) (*model.ChannelJoinRequest, *model.AppError)
 	// Caller must close the first return value
 	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
+	// CancelChannelJoinRequest removes a pending request to join a channel, which only its user can
+	// cancel.
+	CancelChannelJoinRequest(joinRequest *model.ChannelJoinRequest, userID string) *model.AppError
+	// CancelScheduledConfigChange cancels a change that is still pending.
+	CancelScheduledConfigChange(changeID string) (*model.ScheduledConfigChange, *model.AppError)
 	// ChannelMembersMinusGroupMembers returns the set of users in the given channel minus the set of users in the given
 	// groups.
 	//
@@ -70,18 +113,44 @@ type AppIface interface {
 	ChannelMembersToAdd(since int64, channelID *string, includeRemovedMembers bool) ([]*model.UserChannelIDPair, *model.AppError)
 	// CheckFreemiumLimitsForConfigSave returns an error if the configuration being saved violates the Cloud Freemium limits
 	CheckFreemiumLimitsForConfigSave(oldConfig, newConfig *model.Config) *model.AppError
+	// CheckLoginNotificationToken returns an error if the token of the link of a login notification
+	// can't be used to revoke its session, without using it.
+	CheckLoginNotificationToken(tokenString string) *model.AppError
+	// CheckOutgoingOAuthConnectionURLs checks that the connection exists and that its tokens may be
+	// sent to the URLs of an integration referencing it.
+	CheckOutgoingOAuthConnectionURLs(connectionID string, urls []string) *model.AppError
 	// CheckProviderAttributes returns the empty string if the patch can be applied without
 	// overriding attributes set by the user's login provider; otherwise, the name of the offending
 	// field is returned.
 	CheckProviderAttributes(user *model.User, patch *model.UserPatch) string
+	// CleanupOrphanedFiles deletes the files created before createdBefore which are no longer
+	// used: the FileInfos of posts which were purged, by data retention or dropped partitions, with
+	// their stored files, and the stored attachments which no FileInfo refers to. A dry run only
+	// reports what would be deleted. Failing to delete a file is counted in the report rather than
+	// failing the cleanup.
+	CleanupOrphanedFiles(dryRun bool, createdBefore time.Time) (*model.OrphanedFilesReport, *model.AppError)
 	// ClientConfigWithComputed gets the configuration in a format suitable for sending to the client.
 	ClientConfigWithComputed() map[string]string
+	// CompleteOnboardingTask marks the task as done for the user.
+	CompleteOnboardingTask(userID, taskID string, isAdmin bool) *model.AppError
 	// ConvertBotToUser converts a bot to user.
 	ConvertBotToUser(bot *model.Bot, userPatch *model.UserPatch, sysadmin bool) (*model.User, *model.AppError)
 	// ConvertUserToBot converts a user to bot.
 	ConvertUserToBot(user *model.User) (*model.Bot, *model.AppError)
+	// CreateAuthMigrationJob stores the request in the file store and schedules a job verifying the
+	// users against the target provider and migrating them to it.
+	CreateAuthMigrationJob(req *model.AuthMigrationRequest, requesterID string) (*model.Job, *model.AppError)
 	// CreateBot creates the given bot and corresponding user.
 	CreateBot(c *request.Context, bot *model.Bot) (*model.Bot, *model.AppError)
+	// CreateBulkChannelMembersJob stores the users of the request in the file store and schedules a
+	// job adding them to or removing them from the channel.
+	CreateBulkChannelMembersJob(channel *model.Channel, requesterID string, req *model.ChannelMembersBulkRequest) (*model.Job, *model.AppError)
+	// CreateChannelBookmark adds a bookmark at the end of the bookmarks of a channel. The file of a
+	// file bookmark must have been uploaded to the channel.
+	CreateChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError)
+	// CreateChannelEvent schedules an event in a channel. The event must have an occurrence in the
+	// future.
+	CreateChannelEvent(event *model.ChannelEvent) (*model.ChannelEvent, *model.AppError)
 	// CreateChannelScheme creates a new Scheme of scope channel and assigns it to the channel.
 	CreateChannelScheme(channel *model.Channel) (*model.Scheme, *model.AppError)
 	// CreateDefaultMemberships adds users to teams and channels based on their group memberships and how those groups
@@ -92,11 +161,26 @@ type AppIface interface {
 	// CreateGuest creates a guest and sets several fields of the returned User struct to
 	// their zero values.
 	CreateGuest(c *request.Context, user *model.User) (*model.User, *model.AppError)
+	// CreatePluginSearchIndex creates a search index of a plugin if it doesn't exist yet.
+	CreatePluginSearchIndex(pluginID, index string) *model.AppError
+	// CreateReminder schedules a reminder, which must be due in the future.
+	CreateReminder(reminder *model.Reminder) (*model.Reminder, *model.AppError)
+	// CreateTeamInviteLink creates a link letting users join the team of the link.
+	CreateTeamInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, *model.AppError)
 	// CreateUser creates a user and sets several fields of the returned User struct to
 	// their zero values.
 	CreateUser(c *request.Context, user *model.User) (*model.User, *model.AppError)
+	// CreateUserDeletionJob schedules a job exporting the personal data of a user then deleting the
+	// user according to the policy of the request.
+	CreateUserDeletionJob(userID string, req *model.UserDeletionRequest, requesterID string) (*model.Job, *model.AppError)
+	// CreateUserMergeJob checks that the source user of the request can be merged into its target
+	// user and schedules a job doing so.
+	CreateUserMergeJob(req *model.UserMergeRequest, requesterID string) (*model.Job, *model.AppError)
 	// Creates and stores FileInfos for a post created before the FileInfos table existed.
 	MigrateFilenamesToFileInfos(post *model.Post) []*model.FileInfo
+	// DeclineDirectMessageRequest prevents the requester of the pending message request of a direct
+	// channel from messaging its recipient any further.
+	DeclineDirectMessageRequest(channelID, userID string) *model.AppError
 	// DefaultChannelNames returns the list of system-wide default channel names.
 	//
 	// By default the list will be (not necessarily in this order):
@@ -106,16 +190,31 @@ type AppIface interface {
 	//	['town-square', 'game-of-thrones', 'wow']
 	//
 	DefaultChannelNames() []string
+	// DeleteChannelBookmark deletes a bookmark and returns it.
+	DeleteChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError)
 	// DeleteChannelScheme deletes a channels scheme and sets its SchemeId to nil.
 	DeleteChannelScheme(channel *model.Channel) (*model.Channel, *model.AppError)
+	// DeleteExpiredPolicyFiles deletes the file attachments of the posts affected by a retention
+	// policy whose file duration has passed at now, along with their stored files. The posts are
+	// kept, the names of their deleted files being recorded in their props. Failing to delete a
+	// file is counted in the report rather than failing the deletion.
+	DeleteExpiredPolicyFiles(now int64) (*model.FileRetentionReport, *model.AppError)
 	// DeleteGroupConstrainedMemberships deletes team and channel memberships of users who aren't members of the allowed
 	// groups of all group-constrained teams and channels.
 	DeleteGroupConstrainedMemberships(c *request.Context) error
+	// DeleteOutgoingOAuthConnection deletes the connection. The requests of the integrations still
+	// referencing it fail until they reference another connection or none.
+	DeleteOutgoingOAuthConnection(connectionID string) *model.AppError
 	// DeletePublicKey will delete plugin public key from the config.
 	DeletePublicKey(name string) *model.AppError
+	// DeleteUserWithPolicy anonymizes or permanently deletes a user, the last stage of a user deletion
+	// job.
+	DeleteUserWithPolicy(c *request.Context, userID, policy string) *model.AppError
 	// DemoteUserToGuest Convert user's roles and all his membership's roles from
 	// regular user roles to guest roles.
 	DemoteUserToGuest(user *model.User) *model.AppError
+	// DenyChannelJoinRequest turns down a pending request to join a channel. The user can ask again.
+	DenyChannelJoinRequest(c *request.Context, joinRequest *model.ChannelJoinRequest, reviewerID string) (*model.ChannelJoinRequest, *model.AppError)
 	// DisablePlugin will set the config for an installed plugin to disabled, triggering deactivation if active.
 	// Notifies cluster peers through config change.
 	DisablePlugin(id string) *model.AppError
@@ -130,6 +229,12 @@ type AppIface interface {
 	// attributes of the attachment structure. The Slack attachment structure is
 	// documented here: https://api.slack.com/docs/attachments
 	ProcessSlackAttachments(attachments []*model.SlackAttachment) []*model.SlackAttachment
+	// ExportEmojiArchive writes every custom emoji to w as a zip archive holding the emoji images and a
+	// manifest mapping emoji names to them.
+	ExportEmojiArchive(w io.Writer) *model.AppError
+	// ExportUserPersonalData writes a zip archive holding the profile of a user, the posts they
+	// authored and the files they uploaded.
+	ExportUserPersonalData(userID string, w io.Writer) *model.AppError
 	// ExtendSessionExpiryIfNeeded extends Session.ExpiresAt based on session lengths in config.
 	// A new ExpiresAt is only written if enough time has elapsed since last update.
 	// Returns true only if the session was extended.
@@ -145,42 +250,96 @@ type AppIface interface {
 	// FilterNonGroupTeamMembers returns the subset of the given user IDs of the users who are not members of groups
 	// associated to the team excluding bots.
 	FilterNonGroupTeamMembers(userIDs []string, team *model.Team) ([]string, error)
+	// GenerateMfaBackupCodes replaces the backup codes of a user with new ones. Only their hashes are
+	// stored, so the codes returned can't be retrieved later.
+	GenerateMfaBackupCodes(userID string) (*model.MfaBackupCodes, *model.AppError)
+	// GenerateTurnCredentials returns credentials of the configured TURN servers for a user, valid
+	// for TurnSettings.CredentialTTLSeconds.
+	GenerateTurnCredentials(userID string) (*model.TurnCredentials, *model.AppError)
 	// GetAllLdapGroupsPage retrieves all LDAP groups under the configured base DN using the default or configured group
 	// filter.
 	GetAllLdapGroupsPage(page int, perPage int, opts model.LdapGroupSearchOpts) ([]*model.Group, int, *model.AppError)
+	// GetAuthMigrationMismatches returns the CSV report of the users an auth migration job could not
+	// verify or migrate.
+	GetAuthMigrationMismatches(jobID string) ([]byte, *model.AppError)
+	// GetAuthMigrationReport returns the progress of an auth migration job.
+	GetAuthMigrationReport(jobID string) (*model.AuthMigrationReport, *model.AppError)
 	// GetBot returns the given bot.
 	GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.AppError)
 	// GetBots returns the requested page of bots.
 	GetBots(options *model.BotGetOptions) (model.BotList, *model.AppError)
+	// GetBulkChannelMembersReport returns the progress of a bulk channel membership job and the users
+	// it failed for.
+	GetBulkChannelMembersReport(channelID, jobID string) (*model.ChannelMembersBulkReport, *model.AppError)
+	// GetChannelBookmarks returns the bookmarks of a channel, in their order.
+	GetChannelBookmarks(channelID string) ([]*model.ChannelBookmark, *model.AppError)
+	// GetChannelEventsForChannel returns the events of a channel, ordered by start.
+	GetChannelEventsForChannel(channelID string) ([]*model.ChannelEvent, *model.AppError)
 	// GetChannelGroupUsers returns the users who are associated to the channel via GroupChannels and GroupMembers.
 	GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError)
+	// GetChannelJoinRequest returns a request to join a channel, whatever its status.
+	GetChannelJoinRequest(requestID string) (*model.ChannelJoinRequest, *model.AppError)
+	// GetChannelJoinRequests returns the pending requests to join a channel, the oldest first.
+	GetChannelJoinRequests(channelID string) ([]*model.ChannelJoinRequest, *model.AppError)
+	// GetChannelMemberTimeouts returns the timeouts of the members of a channel that have not expired.
+	GetChannelMemberTimeouts(channelID string) ([]*model.ChannelMemberTimeout, *model.AppError)
 	// GetChannelModerationsForChannel Gets a channels ChannelModerations from either the higherScoped roles or from the channel scheme roles.
 	GetChannelModerationsForChannel(channel *model.Channel) ([]*model.ChannelModeration, *model.AppError)
 	// GetClusterPluginStatuses returns the status for plugins installed anywhere in the cluster.
 	GetClusterPluginStatuses() (model.PluginStatuses, *model.AppError)
 	// GetConfigFile proxies access to the given configuration file to the underlying config store.
 	GetConfigFile(name string) ([]byte, error)
+	// GetConfigOrigins returns a map of configuration keys to the origin of their value: an
+	// environment variable, the backing store or the defaults.
+	// If filter is not nil and returns false for a struct field, that field will be omitted.
+	GetConfigOrigins(filter func(reflect.StructField) bool) map[string]interface{}
+	// GetDNDBypassList returns the people and keywords allowed to notify the user while they are in
+	// do not disturb. Users that never saved a list get an empty one.
+	GetDNDBypassList(userID string) (*model.DNDBypassList, *model.AppError)
+	// GetDirectMessageRequestsForUser returns the pending message requests sent to a user.
+	GetDirectMessageRequestsForUser(userID string) ([]*model.DirectMessageRequest, *model.AppError)
 	// GetEmojiStaticURL returns a relative static URL for system default emojis,
 	// and the API route for custom ones. Errors if not found or if custom and deleted.
 	GetEmojiStaticURL(emojiName string) (string, *model.AppError)
 	// GetEnvironmentConfig returns a map of configuration keys whose values have been overridden by an environment variable.
 	// If filter is not nil and returns false for a struct field, that field will be omitted.
 	GetEnvironmentConfig(filter func(reflect.StructField) bool) map[string]interface{}
+	// GetFeatureFlagsForUser returns the feature flags with the rollout rules evaluated for the
+	// given user and system roles.
+	GetFeatureFlagsForUser(userID string, roles []string) (*model.FeatureFlags, *model.AppError)
 	// GetFilteredUsersStats is used to get a count of users based on the set of filters supported by UserCountOptions.
 	GetFilteredUsersStats(options *model.UserCountOptions) (*model.UsersStats, *model.AppError)
 	// GetGroupsByTeam returns the paged list and the total count of group associated to the given team.
 	GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError)
+	// GetImpersonationRequest returns a request which has not expired.
+	GetImpersonationRequest(id string) (*model.ImpersonationRequest, *model.AppError)
+	// GetImpersonationRequestsForUser returns the requests to impersonate a user which have not
+	// expired, so that the user can review them.
+	GetImpersonationRequestsForUser(userID string) ([]*model.ImpersonationRequest, *model.AppError)
 	// GetIntegrationsUsage returns usage information on enabled integrations
 	GetIntegrationsUsage() (*model.IntegrationsUsage, *model.AppError)
+	// GetInvoicesForSubscription returns the invoices of the workspace subscription matching the given options.
+	GetInvoicesForSubscription(userID string, opts *model.InvoiceListOptions) ([]*model.Invoice, *model.AppError)
 	// GetKnownUsers returns the list of user ids of users with any direct
 	// relationship with a user. That means any user sharing any channel, including
 	// direct and group channels.
 	GetKnownUsers(userID string) ([]string, *model.AppError)
 	// GetLdapGroup retrieves a single LDAP group by the given LDAP group id.
 	GetLdapGroup(ldapGroupID string) (*model.Group, *model.AppError)
+	// GetLockedConfigPaths returns the configuration paths locked by the deployment, which can't be
+	// changed through the API.
+	GetLockedConfigPaths() []string
 	// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
 	// and plugins that are installed locally.
 	GetMarketplacePlugins(filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError)
+	// GetOnboardingChecklist returns the onboarding tasks of the user along with when they completed
+	// each of them.
+	GetOnboardingChecklist(userID string, isAdmin bool) ([]*model.OnboardingTaskStatus, *model.AppError)
+	// GetPluginResourceUsage returns the resource usage of the server process of a plugin running on
+	// this server.
+	GetPluginResourceUsage(id string) (*model.PluginResourceUsage, *model.AppError)
+	// GetPluginScheduledTasks returns the tasks registered by the plugins running on this server.
+	GetPluginScheduledTasks() []*model.PluginScheduledTask
 	// GetPluginStatus returns the status for a plugin installed on this server.
 	GetPluginStatus(id string) (*model.PluginStatus, *model.AppError)
 	// GetPluginStatuses returns the status for plugins installed on this server.
@@ -191,33 +350,83 @@ type AppIface interface {
 	// To get the plugins environment when the plugins are disabled, manually acquire the plugins
 	// lock instead.
 	GetPluginsEnvironment() *plugin.Environment
+	// GetPostPropSchemas returns the schemas registered for post props, ordered by namespace.
+	GetPostPropSchemas() ([]*model.PostPropSchema, *model.AppError)
+	// GetPostReports returns the moderation queue, oldest reports first.
+	GetPostReports(opts model.PostReportGetOptions) ([]*model.PostReport, *model.AppError)
 	// GetPostsUsage returns "rounded off" total posts count like returns 900 instead of 987
 	GetPostsUsage() (int64, *model.AppError)
 	// GetProductNotices is called from the frontend to fetch the product notices that are relevant to the caller
 	GetProductNotices(c *request.Context, userID, teamID string, client model.NoticeClientType, clientVersion string, locale string) (model.NoticeMessages, *model.AppError)
 	// GetPublicKey will return the actual public key saved in the `name` file.
 	GetPublicKey(name string) ([]byte, *model.AppError)
+	// GetPushNotificationDiagnostics returns the recent push notification delivery receipts for the
+	// user along with what is needed to tell why notifications might not be reaching their devices.
+	GetPushNotificationDiagnostics(userID string) (*model.PushNotificationDiagnostics, *model.AppError)
+	// GetRemindersForUser returns the reminders of a user, the first due first.
+	GetRemindersForUser(userID string) ([]*model.Reminder, *model.AppError)
 	// GetSanitizedConfig gets the configuration for a system admin without any secrets.
 	GetSanitizedConfig() *model.Config
+	// GetScheduledConfigChanges returns a page of the scheduled changes, the last to apply first. The
+	// changes that were applied, failed or were canceled are only returned if includeFinished is true.
+	GetScheduledConfigChanges(includeFinished bool, page, perPage int) ([]*model.ScheduledConfigChange, *model.AppError)
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
 	GetSchemeRolesForChannel(channelID string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
+	// GetSessionDevices returns the devices of the sessions of a user, located from their IP
+	// address when a GeoIP database is configured.
+	GetSessionDevices(userID, currentSessionID string) ([]*model.SessionDevice, *model.AppError)
 	// GetSessionLengthInMillis returns the session length, in milliseconds,
 	// based on the type of session (Mobile, SSO, Web/LDAP).
 	GetSessionLengthInMillis(session *model.Session) int64
+	// GetSubscriptionInvoicePDF returns the PDF of an invoice and its file name. Invoices can't change
+	// once issued, so the PDF is cached in the filestore and later downloads don't reach the billing backend.
+	GetSubscriptionInvoicePDF(userID, invoiceID string) ([]byte, string, *model.AppError)
 	// GetSuggestions returns suggestions for user input.
 	GetSuggestions(c *request.Context, commandArgs *model.CommandArgs, commands []*model.Command, roleID string) []model.AutocompleteSuggestion
+	// GetTeamByInviteId returns the team an invite id lets users join, failing if the id is the one
+	// of an invite link which can no longer be used.
+	GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError)
+	// GetTeamEmojiUsage returns the number of custom emoji scoped to the team and the team's quota
+	GetTeamEmojiUsage(teamID string) (*model.EmojiUsage, *model.AppError)
 	// GetTeamGroupUsers returns the users who are associated to the team via GroupTeams and GroupMembers.
 	GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError)
+	// GetTeamInviteLink returns an invite link, revoked or not.
+	GetTeamInviteLink(linkID string) (*model.TeamInviteLink, *model.AppError)
+	// GetTeamInviteLinks returns the invite links of a team, the newest first.
+	GetTeamInviteLinks(teamID string, includeRevoked bool) ([]*model.TeamInviteLink, *model.AppError)
 	// GetTeamSchemeChannelRoles Checks if a team has an override scheme and returns the scheme channel role names or default channel role names.
 	GetTeamSchemeChannelRoles(teamID string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
 	// GetTotalUsersStats is used for the DM list total
 	GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError)
+	// GetUnreadSummaryForUser returns the unread messages and mentions of a user in all of their
+	// channels, direct and group messages included, and, when collapsed threads are enabled, in the
+	// threads they follow. Channels and threads that are fully read are left out.
+	GetUnreadSummaryForUser(userID string, includeCollapsedThreads bool) (*model.UnreadSummary, *model.AppError)
+	// GetUsage returns the usage counters reported by all the products, evaluated against the
+	// limits of the Cloud workspace if any.
+	GetUsage() (*model.Usage, *model.AppError)
+	// GetUserActivityReport builds the access report of the user over the period between since and
+	// until: their sessions, the channels they were a member of, the files they downloaded, and the
+	// audited actions made by them or on their account.
+	GetUserActivityReport(userID string, since, until int64) (*model.UserActivityReport, *model.AppError)
+	// GetUserDeletionReport returns the progress of a user deletion job.
+	GetUserDeletionReport(jobID string) (*model.UserDeletionReport, *model.AppError)
+	// GetUserMergeReport returns the progress of a user merge job and, once it is done, what it
+	// merged.
+	GetUserMergeReport(jobID string) (*model.UserMergeReport, *model.AppError)
 	// HasRemote returns whether a given channelID is present in the channel remotes or not.
 	HasRemote(channelID string, remoteID string) (bool, error)
 	// HubRegister registers a connection to a hub.
 	HubRegister(webConn *WebConn)
 	// HubUnregister unregisters a connection from a hub.
 	HubUnregister(webConn *WebConn)
+	// ImportEmojiArchive creates custom emoji, owned by userID, from a zip archive. Emoji whose name is
+	// already taken are handled according to collisionPolicy. Emoji that can't be imported are reported
+	// in the result rather than failing the whole import.
+	ImportEmojiArchive(userID string, archive io.ReaderAt, size int64, collisionPolicy string) (*model.EmojiImportResult, *model.AppError)
+	// IndexPluginSearchDocument adds a document to a search index of a plugin, replacing the
+	// document with the same id.
+	IndexPluginSearchDocument(pluginID, index string, doc *model.PluginSearchDocument) *model.AppError
 	// InstallPlugin unpacks and installs a plugin but does not enable or activate it.
 	InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Manifest, *model.AppError)
 	// LimitedClientConfigWithComputed gets the configuration in a format suitable for sending to the client.
@@ -226,6 +435,10 @@ type AppIface interface {
 	LogAuditRec(rec *audit.Record, err error)
 	// LogAuditRecWithLevel logs an audit record using specified Level.
 	LogAuditRecWithLevel(rec *audit.Record, level mlog.Level, err error)
+	// MSTeamsImport imports the users, teams, channels, messages and files of a Microsoft Teams export
+	// package through the bulk import. With dryRun set, the package is only converted and validated.
+	// The returned report is filled in as far as the import got, even when it fails.
+	MSTeamsImport(c *request.Context, zipReader *zip.Reader, dryRun bool, workers int) (*model.MSTeamsImportReport, *model.AppError)
 	// MakeAuditRecord creates a audit record pre-populated with defaults.
 	MakeAuditRecord(event string, initialStatus string) *audit.Record
 	// MarkChanelAsUnreadFromPost will take a post and set the channel as unread from that one.
@@ -236,6 +449,11 @@ type AppIface interface {
 	// MentionsToTeamMembers returns all the @ mentions found in message that
 	// belong to users in the specified team, linking them to their users
 	MentionsToTeamMembers(message, teamID string) model.UserMentionMap
+	// MergeUsers re-attributes the posts, files, reactions, memberships and preferences of the
+	// source user to the target user, then revokes the sessions of the source user and deactivates
+	// it, recording which user it was merged into. It returns what was merged and the number of
+	// sessions revoked.
+	MergeUsers(c *request.Context, sourceUserID, targetUserID string) (*model.UserMergeCounts, int, *model.AppError)
 	// MoveChannel method is prone to data races if someone joins to channel during the move process. However this
 	// function is only exposed to sysadmins and the possibility of this edge case is relatively small.
 	MoveChannel(c *request.Context, team *model.Team, channel *model.Channel, user *model.User) *model.AppError
@@ -248,8 +466,24 @@ type AppIface interface {
 	OverrideIconURLIfEmoji(post *model.Post)
 	// PatchBot applies the given patch to the bot and corresponding user.
 	PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
+	// PatchChannelBookmark changes the name, target or emoji of a bookmark.
+	PatchChannelBookmark(bookmark *model.ChannelBookmark, patch *model.ChannelBookmarkPatch) (*model.ChannelBookmark, *model.AppError)
+	// PatchChannelEvent changes an event. An event whose time, timezone, recurrence or reminder is
+	// changed is scheduled again, and must then have an occurrence in the future.
+	PatchChannelEvent(event *model.ChannelEvent, patch *model.ChannelEventPatch) (*model.ChannelEvent, *model.AppError)
 	// PatchChannelModerationsForChannel Updates a channels scheme roles based on a given ChannelModerationPatch, if the permissions match the higher scoped role the scheme is deleted.
 	PatchChannelModerationsForChannel(channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
+	// PatchFeatureFlagOverrides sets the given feature flag overrides, removing those with a nil
+	// value, and persists them in the config store. It returns the overrides before and after
+	// the change.
+	PatchFeatureFlagOverrides(patch map[string]*string) (map[string]string, map[string]string, *model.AppError)
+	// PatchReminder changes the message, time or recurrence of a reminder. A new time must be in the
+	// future.
+	PatchReminder(reminder *model.Reminder, patch *model.ReminderPatch) (*model.Reminder, *model.AppError)
+	// PatchTeamFeatures enables or disables the features of a team set in the patch.
+	PatchTeamFeatures(teamID string, patch model.TeamFeaturesPatch) (*model.Team, *model.AppError)
+	// PatchTeamInviteLink changes the restrictions of an invite link, which applies to its next uses.
+	PatchTeamInviteLink(link *model.TeamInviteLink, patch *model.TeamInviteLinkPatch) (*model.TeamInviteLink, *model.AppError)
 	// Perform an HTTP POST request to an integration's action endpoint.
 	// Caller must consume and close returned http.Response as necessary.
 	// For internal requests, requests are routed directly to a plugin ServerHTTP hook
@@ -259,22 +493,150 @@ type AppIface interface {
 	// PopulateWebConnConfig checks if the connection id already exists in the hub,
 	// and if so, accordingly populates the other fields of the webconn.
 	PopulateWebConnConfig(s *model.Session, cfg *WebConnConfig, seqVal string) (*WebConnConfig, error)
+	// PreviewNotification renders the email and push notifications the user would be sent for the
+	// post, without sending them.
+	PreviewNotification(userID, postID string) (*model.NotificationPreview, *model.AppError)
 	// PromoteGuestToUser Convert user's roles and all his membership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(c *request.Context, user *model.User, requestorId string) *model.AppError
+	// PublishChangeEvents publishes the unpublished change events to the configured broker in
+	// batches, the first recorded first, returning how many were published. The events published
+	// more than RetentionHours ago are then deleted. A batch that fails to publish is retried on the
+	// next call, so the consumers may receive an event more than once.
+	PublishChangeEvents() (int, *model.AppError)
+	// PublishPersistentWebSocketEvent publishes a websocket event on behalf of a plugin and keeps it
+	// for model.PersistentWebSocketEventRetentionMinutes, so that it is replayed to the clients that
+	// were disconnected when it was published.
+	PublishPersistentWebSocketEvent(pluginID string, ev *model.WebSocketEvent) *model.AppError
+	// PublishUserTyping publishes a typing event for the user in the channel. Typing events of a
+	// user in a channel are coalesced, only one of them being published per typing update interval,
+	// and they aren't published at all in channels with more members than configured.
+	PublishUserTyping(userID, channelID, parentId string) *model.AppError
+	// PurgeSoftDeleted permanently deletes the teams and commands deleted before the given time,
+	// returning how many were deleted. The teams are permanently deleted along with everything in
+	// them, as by PermanentDeleteTeam.
+	PurgeSoftDeleted(before int64) (int64, *model.AppError)
+	// RecordConnectivityTest keeps the outcome of a connection test against an external service so
+	// admins can look back at it later. Failing to store the result is only logged.
+	RecordConnectivityTest(service, userID string, latency time.Duration, testErr *model.AppError)
+	// RegenCommandSigningSecret generates a new signing secret for the command, which also signs
+	// the requests of the commands created before signing secrets were introduced.
+	RegenCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError)
+	// RegenOutgoingWebhookSigningSecret generates a new signing secret for the webhook, which also
+	// signs the requests of the webhooks created before signing secrets were introduced.
+	RegenOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
+	// RegenerateMfaBackupCodes replaces the backup codes of a user with multi-factor authentication
+	// active, given a token of their authenticator app or one of their current backup codes.
+	RegenerateMfaBackupCodes(userID, token string) (*model.MfaBackupCodes, *model.AppError)
+	// RegisterPostPropSchema registers the schema of a namespace, replacing the one registered
+	// before. Posts already setting the prop are left as they are, but are validated against the new
+	// schema when the prop is changed.
+	RegisterPostPropSchema(namespace string, schema json.RawMessage, creatorID string) (*model.PostPropSchema, *model.AppError)
+	// RemoveChannelMemberTimeout lets a member post in a channel again before their timeout expires.
+	// Members can't remove their own timeout.
+	RemoveChannelMemberTimeout(channelID, userID, removerID string) *model.AppError
+	// RemovePostRetentionLabel removes the retention label of a post, which falls back under the
+	// retention policies of its channel and team.
+	RemovePostRetentionLabel(postID string) *model.AppError
 	// RenameChannel is used to rename the channel Name and the DisplayName fields
 	RenameChannel(channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// RenderMarkdown renders markdown to sanitized HTML with the parser the server uses to read posts,
+	// so that the output only depends on the version of the server.
+	RenderMarkdown(text string) string
+	// ReplayPersistentWebSocketEvents sends to a reconnecting client the persistent events it is
+	// allowed to receive that were created after since. It must be called once the connection is
+	// registered with its hub.
+	ReplayPersistentWebSocketEvents(wc *WebConn, since int64)
+	// ReportPost reports a post to the moderators. A user can only report a post once, and cannot
+	// report their own posts.
+	ReportPost(c *request.Context, post *model.Post, reporterID, reason, comment string) (*model.PostReport, *model.AppError)
+	// RequestImpersonation creates a request to impersonate a user. The request is approved right
+	// away if the requester overrides the consent of the user and the policy allows it, otherwise
+	// the user is asked for consent.
+	RequestImpersonation(c *request.Context, impersonation *model.ImpersonationRequest) (*model.ImpersonationRequest, *model.AppError)
+	// RequestToJoinChannel asks the members allowed to manage the members of a private channel to add
+	// the user to it. The pending request of the user is returned if they already asked.
+	RequestToJoinChannel(c *request.Context, channelID, userID, message string) (*model.ChannelJoinRequest, *model.AppError)
+	// ResetOnboardingTask marks the task as not done for the user.
+	ResetOnboardingTask(userID, taskID string, isAdmin bool) *model.AppError
+	// ResetUserMfa deactivates the multi-factor authentication of a user who lost their
+	// authenticator app and backup codes, on behalf of an administrator. The sessions of the user
+	// are revoked, so that they have to log in, and enroll again if it is enforced.
+	ResetUserMfa(userID string) *model.AppError
+	// RestoreTeam restores the team, along with the channels, members and commands deleted with it.
+	RestoreTeam(teamID string) *model.AppError
+	// RevokeImpersonation ends a request, and its session if it was started.
+	RevokeImpersonation(c *request.Context, impersonation *model.ImpersonationRequest) (*model.ImpersonationRequest, *model.AppError)
+	// RevokeSessionFromLoginNotification revokes the session of a login notified to a user, given
+	// the token of the link of the notification. The token can only be used once.
+	RevokeSessionFromLoginNotification(tokenString string) *model.AppError
 	// RevokeSessionsFromAllUsers will go through all the sessions active
 	// in the server and revoke them
 	RevokeSessionsFromAllUsers() *model.AppError
+	// RevokeTeamInviteLink stops an invite link from letting users join its team. The link is kept
+	// with its counters.
+	RevokeTeamInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, *model.AppError)
+	// RunPluginScheduledTask invokes the OnScheduledTask hook of a plugin. It is called by the job
+	// created when the task is due, which can run on a different server than the one that created it.
+	RunPluginScheduledTask(pluginID, callback string) *model.AppError
+	// RunSystemCheckup evaluates the running configuration and environment against a set of
+	// rules and returns everything that looks misconfigured, so admins don't have to work
+	// through the usual troubleshooting checklist by hand.
+	RunSystemCheckup() *model.SystemCheckup
 	// SaveConfig replaces the active configuration, optionally notifying cluster peers.
 	SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError)
+	// ScheduleConfigChange schedules the partial configuration of the change to be merged onto the
+	// configuration at ApplyAt, which must be in the future. The differences it makes to the current
+	// configuration are saved along with the change, which must make at least one.
+	ScheduleConfigChange(change *model.ScheduledConfigChange) (*model.ScheduledConfigChange, *model.AppError)
+	// ScimCreateGroup provisions a custom group and its members. Its name is cleaned from its
+	// display name, and its externalId is ignored since custom groups have no remote id.
+	ScimCreateGroup(scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError)
+	// ScimCreateUser provisions a user, whose email address is verified. Users log in with
+	// ScimSettings.AuthService, or have to reset their random password.
+	ScimCreateUser(c *request.Context, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError)
+	// ScimDeactivateUser deprovisions a user. Users are deactivated rather than deleted, so that
+	// their posts are kept.
+	ScimDeactivateUser(c *request.Context, userID string) *model.AppError
+	// ScimDeleteGroup deletes a custom group.
+	ScimDeleteGroup(groupID string) *model.AppError
+	// ScimGetGroup returns the SCIM representation of a custom group.
+	ScimGetGroup(groupID string) (*model.ScimGroup, *model.AppError)
+	// ScimGetGroups returns a page of the custom groups, starting at startIndex which is 1-based,
+	// matching a filter on their displayName.
+	ScimGetGroups(filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError)
+	// ScimGetUser returns the SCIM representation of a user.
+	ScimGetUser(userID string) (*model.ScimUser, *model.AppError)
+	// ScimGetUsers returns a page of the users, starting at startIndex which is 1-based, matching a
+	// filter on their userName or email address.
+	ScimGetUsers(filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError)
+	// ScimPatchGroup applies patch operations to a custom group, such as adding or removing
+	// members.
+	ScimPatchGroup(groupID string, patch *model.ScimPatchRequest) (*model.ScimGroup, *model.AppError)
+	// ScimPatchUser applies patch operations to a user.
+	ScimPatchUser(c *request.Context, userID string, patch *model.ScimPatchRequest) (*model.ScimUser, *model.AppError)
+	// ScimReplaceGroup replaces the display name and the members of a custom group.
+	ScimReplaceGroup(groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError)
+	// ScimReplaceUser replaces the attributes of a user, deactivating or reactivating it as
+	// described by its active attribute.
+	ScimReplaceUser(c *request.Context, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError)
 	// SearchAllChannels returns a list of channels, the total count of the results of the search (if the paginate search option is true), and an error.
 	SearchAllChannels(term string, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, *model.AppError)
 	// SearchAllTeams returns a team list and the total count of the results
 	SearchAllTeams(searchOpts *model.TeamSearch) ([]*model.Team, int64, *model.AppError)
+	// SearchArchivedPosts searches the posts moved to the archive by the post archive job in the
+	// channels of teamID that userID is a member of. Archived posts aren't indexed, so this is much
+	// slower than the regular search and only matches messages containing every term.
+	SearchArchivedPosts(teamID, userID, terms string, page, perPage int) (*model.PostList, *model.AppError)
+	// SearchPluginSearchIndex queries a search index of a plugin, only matching the documents of the
+	// tenants of the params.
+	SearchPluginSearchIndex(pluginID, index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError)
+	// SendDueReminders sends the users the reminders due by now as direct messages from the system
+	// bot, returning how many were sent. Recurring reminders are rescheduled, the others deleted.
+	// A reminder that can't be sent isn't retried.
+	SendDueReminders(now int64) (int, *model.AppError)
 	// SendNoCardPaymentFailedEmail
 	SendNoCardPaymentFailedEmail() *model.AppError
 	// SessionHasPermissionToManageBot returns nil if the session has access to manage the given bot.
@@ -283,6 +645,22 @@ type AppIface interface {
 	SessionHasPermissionToManageBot(session model.Session, botUserId string) *model.AppError
 	// SessionIsRegistered determines if a specific session has been registered
 	SessionIsRegistered(session model.Session) bool
+	// SetChannelSlowmode sets the minimum number of seconds between two posts of a member in a
+	// channel, zero disabling slowmode.
+	SetChannelSlowmode(channelID string, seconds int) (*model.Channel, *model.AppError)
+	// SetFileAltText sets the alt text of an image, replacing any generated one. An empty alt text
+	// removes it.
+	SetFileAltText(fileInfo *model.FileInfo, altText string) (*model.FileInfo, *model.AppError)
+	// SetPinnedPostsOrder puts the given pinned posts of a channel first in its pinned posts, in that
+	// order. The other pinned posts follow from the oldest to the newest.
+	SetPinnedPostsOrder(channelID string, postIDs []string) (*model.PostList, *model.AppError)
+	// SetPluginKeysWithOptions applies the operations of a plugin atomically: either every
+	// operation is applied, or none of them is if the comparison of an atomic operation fails.
+	SetPluginKeysWithOptions(pluginID string, operations []*model.PluginKVSetOperation) (bool, *model.AppError)
+	// SetPostRetentionLabel applies one of the configured retention labels to a post, replacing the
+	// label it had. The post is kept until the retention period of the label has passed since its
+	// creation, regardless of the retention policies of its channel and team.
+	SetPostRetentionLabel(post *model.Post, labelName, creatorID string) (*model.PostRetentionLabel, *model.AppError)
 	// SetSessionExpireInHours sets the session's expiry the specified number of hours
 	// relative to either the session creation date or the current time, depending
 	// on the `ExtendSessionOnActivity` config setting.
@@ -294,6 +672,16 @@ type AppIface interface {
 	// status to away if needed. Used by the WS to set status to away if an 'online' device disconnects
 	// while an 'away' device is still connected
 	SetStatusLastActivityAt(userID string, activityAt int64)
+	// SlackExport writes the public and private channels of a team, along with their members and
+	// messages, as a zip archive laid out like a Slack workspace export. Direct and group messages
+	// aren't part of a Slack workspace export and are left out.
+	SlackExport(writer io.Writer, teamID string, opts model.BulkExportOpts) *model.AppError
+	// SoftDeleteTeam marks the team as deleted, along with its channels, members and commands.
+	SoftDeleteTeam(teamID string) *model.AppError
+	// StartImpersonation creates the read-only session of an approved request. A request can only
+	// be used for a single session, which expires after the duration of the request and is never
+	// extended.
+	StartImpersonation(c *request.Context, impersonation *model.ImpersonationRequest) (*model.ImpersonationSession, *model.AppError)
 	// SyncLdap starts an LDAP sync job.
 	// If includeRemovedMembers is true, then members who left or were removed from a team/channel will
 	// be re-added; otherwise, they will not be re-added.
@@ -328,19 +716,37 @@ type AppIface interface {
 	CreateZipFileAndAddFiles(fileBackend filestore.FileBackend, fileDatas []model.FileData, zipFileName, directory string) error
 	// This to be used for places we check the users password when they are already logged in
 	DoubleCheckPassword(user *model.User, password string) *model.AppError
+	// TimeoutChannelMember prevents a member from posting in a channel for the given number of
+	// minutes, replacing any timeout they already had. Members who can moderate the channel, including
+	// team and system admins, can't be timed out.
+	TimeoutChannelMember(c *request.Context, channelID, userID, creatorID string, minutes int) (*model.ChannelMemberTimeout, *model.AppError)
+	// TrackClientTelemetryEvent forwards an event tracked by the client of a user to the self-hosted
+	// diagnostics sink, whose key is kept on the server.
+	TrackClientTelemetryEvent(userID string, event *model.TelemetryEvent) *model.AppError
 	// UpdateBotActive marks a bot as active or inactive, along with its corresponding user.
 	UpdateBotActive(c *request.Context, botUserId string, active bool) (*model.Bot, *model.AppError)
 	// UpdateBotOwner changes a bot's owner to the given value.
 	UpdateBotOwner(botUserId, newOwnerId string) (*model.Bot, *model.AppError)
 	// UpdateChannel updates a given channel by its Id. It also publishes the CHANNEL_UPDATED event.
 	UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError)
+	// UpdateChannelBookmarkSortOrder moves a bookmark of a channel to the given index and returns the
+	// bookmarks of the channel whose order changed.
+	UpdateChannelBookmarkSortOrder(bookmarkID, channelID string, newIndex int64) ([]*model.ChannelBookmark, *model.AppError)
 	// UpdateChannelScheme saves the new SchemeId of the channel passed.
 	UpdateChannelScheme(channel *model.Channel) (*model.Channel, *model.AppError)
 	// UpdateDNDStatusOfUsers is a recurring task which is started when server starts
 	// which unsets dnd status of users if needed and saves and broadcasts it
 	UpdateDNDStatusOfUsers()
+	// UpdateOutgoingOAuthConnection updates the connection. The secrets left empty keep their current
+	// value, so that they don't have to be sent again with every update.
+	UpdateOutgoingOAuthConnection(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, *model.AppError)
 	// UpdateProductNotices is called periodically from a scheduled worker to fetch new notices and update the cache
 	UpdateProductNotices() *model.AppError
+	// UpdateRemoteClusterPinnedCerts replaces the certificates pinned for a remote cluster. Adding the
+	// new fingerprint before removing the old one allows certificates to be rotated without downtime.
+	UpdateRemoteClusterPinnedCerts(remoteClusterId string, fingerprints []string) (*model.RemoteCluster, *model.AppError)
+	// UpdateSessionDeviceName names the device of a session of a user, or clears its name.
+	UpdateSessionDeviceName(userID, sessionID, name string) (*model.SessionDevice, *model.AppError)
 	// UpdateViewedProductNotices is called from the frontend to mark a set of notices as 'viewed' by user
 	UpdateViewedProductNotices(userID string, noticeIds []string) *model.AppError
 	// UpdateViewedProductNoticesForNewUser is called when new user is created to mark all current notices for this
@@ -363,8 +769,21 @@ type AppIface interface {
 	// UserIsInAdminRoleGroup returns true at least one of the user's groups are configured to set the members as
 	// admins in the given syncable.
 	UserIsInAdminRoleGroup(userID, syncableID string, syncableType model.GroupSyncableType) (bool, *model.AppError)
+	// VerifyAuthMigration matches the users of the source provider of the request with their account
+	// in the target provider, returning the users that were matched and the ones that were not.
+	VerifyAuthMigration(req *model.AuthMigrationRequest) ([]*model.AuthMigrationMatch, []*model.AuthMigrationMismatch, *model.AppError)
 	// VerifyPlugin checks that the given signature corresponds to the given plugin and matches a trusted certificate.
 	VerifyPlugin(plugin, signature io.ReadSeeker) *model.AppError
+	// VerifyRemoteClusterCertificate checks the certificate presented by a remote cluster over mutual TLS
+	// against the certificates pinned for it. With mutual TLS enabled, remotes without pinned certificates
+	// or without a client certificate are rejected.
+	VerifyRemoteClusterCertificate(remoteClusterId string, state *tls.ConnectionState) *model.AppError
+	// WriteAuthMigrationMismatches writes the CSV report of the mismatches of an auth migration job
+	// to the file store, returning its path.
+	WriteAuthMigrationMismatches(jobID string, mismatches []*model.AuthMigrationMismatch) (string, *model.AppError)
+	// WriteUserPersonalDataExport writes the export of the personal data of a user to the exports
+	// directory, returning the name of the export.
+	WriteUserPersonalDataExport(userID, jobID string) (string, *model.AppError)
 	//GetUserStatusesByIds used by apiV4
 	GetUserStatusesByIds(userIDs []string) ([]*model.Status, *model.AppError)
 	AccountMigration() einterfaces.AccountMigrationInterface
@@ -459,6 +878,8 @@ type AppIface interface {
 	CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	CreateOAuthStateToken(extra string) (*model.Token, *model.AppError)
 	CreateOAuthUser(c *request.Context, service string, userData io.Reader, teamID string, tokenUser *model.User) (*model.User, *model.AppError)
+	CreateOnboardingTask(task *model.OnboardingTask) (*model.OnboardingTask, *model.AppError)
+	CreateOutgoingOAuthConnection(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, *model.AppError)
 	CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
 	CreatePasswordRecoveryToken(userID, email string) (*model.Token, *model.AppError)
 	CreatePost(c *request.Context, post *model.Post, channel *model.Channel, triggerWebhooks, setOnline bool) (savedPost *model.Post, err *model.AppError)
@@ -470,6 +891,7 @@ type AppIface interface {
 	CreateSession(session *model.Session) (*model.Session, *model.AppError)
 	CreateSidebarCategory(userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError)
 	CreateTeam(c *request.Context, team *model.Team) (*model.Team, *model.AppError)
+	CreateTeamTemplate(template *model.TeamTemplate) (*model.TeamTemplate, *model.AppError)
 	CreateTeamWithUser(c *request.Context, team *model.Team, userID string) (*model.Team, *model.AppError)
 	CreateTermsOfService(text, userID string) (*model.TermsOfService, *model.AppError)
 	CreateUploadSession(us *model.UploadSession) (*model.UploadSession, *model.AppError)
@@ -489,6 +911,7 @@ type AppIface interface {
 	DeleteAllKeysForPlugin(pluginID string) *model.AppError
 	DeleteBrandImage() *model.AppError
 	DeleteChannel(c *request.Context, channel *model.Channel, userID string) *model.AppError
+	DeleteChannelEvent(eventID string) *model.AppError
 	DeleteCommand(commandID string) *model.AppError
 	DeleteEmoji(emoji *model.Emoji) *model.AppError
 	DeleteEphemeralPost(userID, postID string)
@@ -499,17 +922,23 @@ type AppIface interface {
 	DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError)
 	DeleteIncomingWebhook(hookID string) *model.AppError
 	DeleteOAuthApp(appID string) *model.AppError
+	DeleteOnboardingTask(taskID string) *model.AppError
 	DeleteOutgoingWebhook(hookID string) *model.AppError
 	DeletePluginKey(pluginID string, key string) *model.AppError
+	DeletePluginSearchDocument(pluginID, index, documentID string) *model.AppError
+	DeletePluginSearchIndex(pluginID, index string) *model.AppError
 	DeletePost(postID, deleteByID string) (*model.Post, *model.AppError)
+	DeletePostPropSchema(namespace string) *model.AppError
 	DeletePreferences(userID string, preferences model.Preferences) *model.AppError
 	DeleteReactionForPost(c *request.Context, reaction *model.Reaction) *model.AppError
+	DeleteReminder(reminderID string) *model.AppError
 	DeleteRemoteCluster(remoteClusterId string) (bool, *model.AppError)
 	DeleteRetentionPolicy(policyID string) *model.AppError
 	DeleteScheme(schemeId string) (*model.Scheme, *model.AppError)
 	DeleteSharedChannel(channelID string) (bool, error)
 	DeleteSharedChannelRemote(id string) (bool, error)
 	DeleteSidebarCategory(userID, teamID, categoryId string) *model.AppError
+	DeleteTeamTemplate(templateID string) *model.AppError
 	DeleteToken(token *model.Token) *model.AppError
 	DisableAutoResponder(userID string, asAdmin bool) *model.AppError
 	DisableUserAccessToken(token *model.UserAccessToken) *model.AppError
@@ -540,7 +969,7 @@ type AppIface interface {
 	FindTeamByName(name string) bool
 	GenerateMfaSecret(userID string) (*model.MfaSecret, *model.AppError)
 	GeneratePublicLink(siteURL string, info *model.FileInfo) string
-	GenerateSupportPacket() []model.FileData
+	GenerateSupportPacket(options *model.SupportPacketOptions) []model.FileData
 	GetActivePluginManifests() ([]*model.Manifest, *model.AppError)
 	GetAllChannels(page, perPage int, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, *model.AppError)
 	GetAllChannelsCount(opts model.ChannelSearchOpts) (int64, *model.AppError)
@@ -561,9 +990,11 @@ type AppIface interface {
 	GetBrandImage() ([]byte, *model.AppError)
 	GetBulkReactionsForPosts(postIDs []string) (map[string][]*model.Reaction, *model.AppError)
 	GetChannel(channelID string) (*model.Channel, *model.AppError)
+	GetChannelBookmark(bookmarkID string, includeDeleted bool) (*model.ChannelBookmark, *model.AppError)
 	GetChannelByName(channelName, teamID string, includeDeleted bool) (*model.Channel, *model.AppError)
 	GetChannelByNameForTeamName(channelName, teamName string, includeDeleted bool) (*model.Channel, *model.AppError)
 	GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, *model.AppError)
+	GetChannelEvent(eventID string) (*model.ChannelEvent, *model.AppError)
 	GetChannelFileCount(channelID string) (int64, *model.AppError)
 	GetChannelGuestCount(channelID string) (int64, *model.AppError)
 	GetChannelMember(ctx context.Context, channelID string, userID string) (*model.ChannelMember, *model.AppError)
@@ -594,6 +1025,7 @@ type AppIface interface {
 	GetComplianceFile(job *model.Compliance) ([]byte, *model.AppError)
 	GetComplianceReport(reportId string) (*model.Compliance, *model.AppError)
 	GetComplianceReports(page, perPage int) (model.Compliances, *model.AppError)
+	GetConnectivityTestHistory(service string, page, perPage int) ([]*model.ConnectivityTestResult, *model.AppError)
 	GetCookieDomain() string
 	GetCustomStatus(userID string) (*model.CustomStatus, *model.AppError)
 	GetDefaultProfileImage(user *model.User) ([]byte, *model.AppError)
@@ -602,6 +1034,7 @@ type AppIface interface {
 	GetEmojiByName(emojiName string) (*model.Emoji, *model.AppError)
 	GetEmojiImage(emojiId string) ([]byte, string, *model.AppError)
 	GetEmojiList(page, perPage int, sort string) ([]*model.Emoji, *model.AppError)
+	GetEmojiListForTeam(teamID string, page, perPage int, sort string) ([]*model.Emoji, *model.AppError)
 	GetFile(fileID string) ([]byte, *model.AppError)
 	GetFileInfo(fileID string) (*model.FileInfo, *model.AppError)
 	GetFileInfos(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError)
@@ -661,8 +1094,11 @@ type AppIface interface {
 	GetOAuthSignupEndpoint(w http.ResponseWriter, r *http.Request, service, teamID string) (string, *model.AppError)
 	GetOAuthStateToken(token string) (*model.Token, *model.AppError)
 	GetOnboarding() (*model.System, *model.AppError)
+	GetOnboardingTask(taskID string) (*model.OnboardingTask, *model.AppError)
 	GetOpenGraphMetadata(requestURL string) ([]byte, error)
 	GetOrCreateDirectChannel(c *request.Context, userID, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError)
+	GetOutgoingOAuthConnection(connectionID string) (*model.OutgoingOAuthConnection, *model.AppError)
+	GetOutgoingOAuthConnections(page, perPage int) ([]*model.OutgoingOAuthConnection, *model.AppError)
 	GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForChannelPageByUser(channelID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPage(teamID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
@@ -678,6 +1114,9 @@ type AppIface interface {
 	GetPostIdAfterTime(channelID string, time int64, collapsedThreads bool) (string, *model.AppError)
 	GetPostIdBeforeTime(channelID string, time int64, collapsedThreads bool) (string, *model.AppError)
 	GetPostIfAuthorized(postID string, session *model.Session) (*model.Post, *model.AppError)
+	GetPostPropSchema(namespace string) (*model.PostPropSchema, *model.AppError)
+	GetPostReport(reportID string) (*model.PostReport, *model.AppError)
+	GetPostRetentionLabel(postID string) (*model.PostRetentionLabel, *model.AppError)
 	GetPostThread(postID string, opts model.GetPostsOptions, userID string) (*model.PostList, *model.AppError)
 	GetPosts(channelID string, offset int, limit int) (*model.PostList, *model.AppError)
 	GetPostsAfterPost(options model.GetPostsOptions) (*model.PostList, *model.AppError)
@@ -700,6 +1139,7 @@ type AppIface interface {
 	GetRecentSearchesForUser(userID string) ([]*model.SearchParams, *model.AppError)
 	GetRecentlyActiveUsersForTeam(teamID string) (map[string]*model.User, *model.AppError)
 	GetRecentlyActiveUsersForTeamPage(teamID string, page, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetReminder(reminderID string) (*model.Reminder, *model.AppError)
 	GetRemoteCluster(remoteClusterId string) (*model.RemoteCluster, *model.AppError)
 	GetRemoteClusterForUser(remoteID string, userID string) (*model.RemoteCluster, *model.AppError)
 	GetRemoteClusterService() (remotecluster.RemoteClusterServiceIFace, *model.AppError)
@@ -714,6 +1154,7 @@ type AppIface interface {
 	GetSamlMetadata() (string, *model.AppError)
 	GetSamlMetadataFromIdp(idpMetadataURL string) (*model.SamlMetadataResponse, *model.AppError)
 	GetSanitizeOptions(asAdmin bool) map[string]bool
+	GetScheduledConfigChange(changeID string) (*model.ScheduledConfigChange, *model.AppError)
 	GetScheme(id string) (*model.Scheme, *model.AppError)
 	GetSchemeByName(name string) (*model.Scheme, *model.AppError)
 	GetSchemeRolesForTeam(teamID string) (string, string, string, *model.AppError)
@@ -739,7 +1180,6 @@ type AppIface interface {
 	GetStatusesByIds(userIDs []string) (map[string]interface{}, *model.AppError)
 	GetSystemBot() (*model.Bot, *model.AppError)
 	GetTeam(teamID string) (*model.Team, *model.AppError)
-	GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError)
 	GetTeamByName(name string) (*model.Team, *model.AppError)
 	GetTeamIcon(team *model.Team) ([]byte, *model.AppError)
 	GetTeamIdFromQuery(query url.Values) (string, *model.AppError)
@@ -750,6 +1190,8 @@ type AppIface interface {
 	GetTeamMembersForUserWithPagination(userID string, page, perPage int) ([]*model.TeamMember, *model.AppError)
 	GetTeamPoliciesForUser(userID string, offset, limit int) (*model.RetentionPolicyForTeamList, *model.AppError)
 	GetTeamStats(teamID string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, *model.AppError)
+	GetTeamTemplate(templateID string) (*model.TeamTemplate, *model.AppError)
+	GetTeamTemplates(page, perPage int) ([]*model.TeamTemplate, *model.AppError)
 	GetTeamUnread(teamID, userID string) (*model.TeamUnread, *model.AppError)
 	GetTeams(teamIDs []string) ([]*model.Team, *model.AppError)
 	GetTeamsForRetentionPolicy(policyID string, offset, limit int) (*model.TeamsWithCount, *model.AppError)
@@ -822,6 +1264,7 @@ type AppIface interface {
 	HasPermissionToTeam(askingUserId string, teamID string, permission *model.Permission) bool
 	HasPermissionToUser(askingUserId string, userID string) bool
 	HasSharedChannel(channelID string) (bool, error)
+	ImageCaption() einterfaces.ImageCaptionInterface
 	ImageProxy() *imageproxy.ImageProxy
 	ImageProxyAdder() func(string) string
 	ImageProxyRemover() (f func(string) string)
@@ -900,7 +1343,6 @@ type AppIface interface {
 	PreparePostListForClient(originalList *model.PostList) *model.PostList
 	ProcessSlackText(text string) string
 	Publish(message *model.WebSocketEvent)
-	PublishUserTyping(userID, channelID, parentId string) *model.AppError
 	PurgeBleveIndexes() *model.AppError
 	PurgeElasticsearchIndexes() *model.AppError
 	ReadFile(path string) ([]byte, *model.AppError)
@@ -910,6 +1352,7 @@ type AppIface interface {
 	RegenerateOAuthAppSecret(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	RegenerateTeamInviteId(teamID string) (*model.Team, *model.AppError)
 	RegisterPluginCommand(pluginID string, command *model.Command) error
+	RegisterPluginScheduledTask(pluginID, cron, callback string) error
 	ReloadConfig() error
 	RemoveAllDeactivatedMembersFromChannel(channel *model.Channel) *model.AppError
 	RemoveChannelsFromRetentionPolicy(policyID string, channelIDs []string) *model.AppError
@@ -933,7 +1376,6 @@ type AppIface interface {
 	ResetPermissionsSystem() *model.AppError
 	ResetSamlAuthDataToEmail(includeDeleted bool, dryRun bool, userIDs []string) (numAffected int, appErr *model.AppError)
 	RestoreChannel(c *request.Context, channel *model.Channel, userID string) (*model.Channel, *model.AppError)
-	RestoreTeam(teamID string) *model.AppError
 	RestrictUsersGetByPermissions(userID string, options *model.UserGetOptions) (*model.UserGetOptions, *model.AppError)
 	RestrictUsersSearchByPermissions(userID string, options *model.UserSearchOptions) (*model.UserSearchOptions, *model.AppError)
 	ReturnSessionToPool(session *model.Session)
@@ -1029,7 +1471,6 @@ type AppIface interface {
 	SetTeamIconFromMultiPartFile(teamID string, file multipart.File) *model.AppError
 	SlackImport(c *request.Context, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer)
 	SoftDeleteAllTeamsExcept(teamID string) *model.AppError
-	SoftDeleteTeam(teamID string) *model.AppError
 	Srv() *Server
 	SubmitInteractiveDialog(c *request.Context, request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError)
 	SwitchEmailToLdap(email, password, code, ldapLoginId, ldapPassword string) (string, *model.AppError)
@@ -1047,8 +1488,10 @@ type AppIface interface {
 	Timezones() *timezones.Timezones
 	ToggleMuteChannel(channelID, userID string) (*model.ChannelMember, *model.AppError)
 	TotalWebsocketConnections() int
+	Transcription() einterfaces.TranscriptionInterface
 	TriggerWebhook(c *request.Context, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel)
 	UnregisterPluginCommand(pluginID, teamID, trigger string)
+	UnregisterPluginScheduledTask(pluginID, callback string)
 	UpdateActive(c *request.Context, user *model.User, active bool) (*model.User, *model.AppError)
 	UpdateChannelMemberNotifyProps(data map[string]string, channelID string, userID string) (*model.ChannelMember, *model.AppError)
 	UpdateChannelMemberRoles(channelID string, userID string, newRoles string) (*model.ChannelMember, *model.AppError)
@@ -1056,6 +1499,7 @@ type AppIface interface {
 	UpdateChannelPrivacy(c *request.Context, oldChannel *model.Channel, user *model.User) (*model.Channel, *model.AppError)
 	UpdateCommand(oldCmd, updatedCmd *model.Command) (*model.Command, *model.AppError)
 	UpdateConfig(f func(*model.Config))
+	UpdateDNDBypassList(userID string, list *model.DNDBypassList) (*model.DNDBypassList, *model.AppError)
 	UpdateEphemeralPost(userID string, post *model.Post) *model.Post
 	UpdateExpiredDNDStatuses() ([]*model.Status, error)
 	UpdateGroup(group *model.Group) (*model.Group, *model.AppError)
@@ -1068,6 +1512,7 @@ type AppIface interface {
 	UpdateMobileAppBadge(userID string)
 	UpdateOAuthApp(oldApp, updatedApp *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	UpdateOAuthUserAttrs(userData io.Reader, user *model.User, provider einterfaces.OAuthProvider, service string, tokenUser *model.User) *model.AppError
+	UpdateOnboardingTask(task *model.OnboardingTask) (*model.OnboardingTask, *model.AppError)
 	UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
 	UpdatePassword(user *model.User, newPassword string) *model.AppError
 	UpdatePasswordAsUser(userID, currentPassword, newPassword string) *model.AppError
@@ -1088,6 +1533,7 @@ type AppIface interface {
 	UpdateTeamMemberSchemeRoles(teamID string, userID string, isSchemeGuest bool, isSchemeUser bool, isSchemeAdmin bool) (*model.TeamMember, *model.AppError)
 	UpdateTeamPrivacy(teamID string, teamType string, allowOpenInvite bool) *model.AppError
 	UpdateTeamScheme(team *model.Team) (*model.Team, *model.AppError)
+	UpdateTeamTemplate(template *model.TeamTemplate) (*model.TeamTemplate, *model.AppError)
 	UpdateThreadFollowForUser(userID, teamID, threadID string, state bool) *model.AppError
 	UpdateThreadFollowForUserFromChannelAdd(userID, teamID, threadID string) *model.AppError
 	UpdateThreadReadForUser(currentSessionId, userID, teamID, threadID string, timestamp int64) (*model.ThreadResponse, *model.AppError)
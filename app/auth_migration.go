@@ -0,0 +1,268 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const authMigrationDir = "auth_migration"
+
+// CreateAuthMigrationJob stores the request in the file store and schedules a job verifying the
+// users against the target provider and migrating them to it.
+func (a *App) CreateAuthMigrationJob(req *model.AuthMigrationRequest, requesterID string) (*model.Job, *model.AppError) {
+	license := a.Srv().License()
+	switch req.To {
+	case model.UserAuthServiceLdap:
+		if license == nil || !*license.Features.LDAP || a.Ldap() == nil {
+			return nil, model.NewAppError("CreateAuthMigrationJob", "api.admin.ldap.not_available.app_error", nil, "", http.StatusNotImplemented)
+		}
+	case model.UserAuthServiceSaml:
+		if license == nil || !*license.Features.SAML {
+			return nil, model.NewAppError("CreateAuthMigrationJob", "api.admin.saml.not_available.app_error", nil, "", http.StatusNotImplemented)
+		}
+	}
+
+	requestJSON, jsonErr := json.Marshal(req)
+	if jsonErr != nil {
+		return nil, model.NewAppError("CreateAuthMigrationJob", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+
+	requestFile := path.Join(authMigrationDir, model.NewId()+".json")
+	if _, err := a.WriteFile(bytes.NewReader(requestJSON), requestFile); err != nil {
+		return nil, err
+	}
+
+	job, err := a.Srv().Jobs.CreateJob(model.JobTypeAuthMigration, map[string]string{
+		"from":         req.From,
+		"to":           req.To,
+		"dry_run":      strconv.FormatBool(req.DryRun),
+		"partial":      strconv.FormatBool(req.Partial),
+		"request_file": requestFile,
+		"requester_id": requesterID,
+	})
+	if err != nil {
+		if rmErr := a.RemoveFile(requestFile); rmErr != nil {
+			a.Log().Warn("Failed to remove auth migration request file", mlog.String("path", requestFile), mlog.Err(rmErr))
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetAuthMigrationReport returns the progress of an auth migration job.
+func (a *App) GetAuthMigrationReport(jobID string) (*model.AuthMigrationReport, *model.AppError) {
+	job, err := a.getAuthMigrationJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.AuthMigrationReport{
+		JobId:    job.Id,
+		From:     job.Data["from"],
+		To:       job.Data["to"],
+		DryRun:   job.Data["dry_run"] == "true",
+		Partial:  job.Data["partial"] == "true",
+		Status:   job.Status,
+		Progress: job.Progress,
+		Applied:  job.Data["applied"] == "true",
+		Error:    job.Data["error"],
+	}
+	report.Total, _ = strconv.Atoi(job.Data["total"])
+	report.Matched, _ = strconv.Atoi(job.Data["matched"])
+	report.Mismatched, _ = strconv.Atoi(job.Data["mismatched"])
+	report.Migrated, _ = strconv.Atoi(job.Data["migrated"])
+
+	return report, nil
+}
+
+// GetAuthMigrationMismatches returns the CSV report of the users an auth migration job could not
+// verify or migrate.
+func (a *App) GetAuthMigrationMismatches(jobID string) ([]byte, *model.AppError) {
+	job, err := a.getAuthMigrationJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	mismatchesFile := job.Data["mismatches_file"]
+	if mismatchesFile == "" {
+		return nil, model.NewAppError("GetAuthMigrationMismatches", "app.auth_migration.mismatches.not_found.app_error", nil, "job_id="+jobID, http.StatusNotFound)
+	}
+
+	return a.ReadFile(mismatchesFile)
+}
+
+func (a *App) getAuthMigrationJob(jobID string) (*model.Job, *model.AppError) {
+	job, err := a.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JobTypeAuthMigration {
+		return nil, model.NewAppError("getAuthMigrationJob", "app.auth_migration.report.not_found.app_error", nil, "job_id="+jobID, http.StatusNotFound)
+	}
+
+	return job, nil
+}
+
+// VerifyAuthMigration matches the users of the source provider of the request with their account
+// in the target provider, returning the users that were matched and the ones that were not.
+func (a *App) VerifyAuthMigration(req *model.AuthMigrationRequest) ([]*model.AuthMigrationMatch, []*model.AuthMigrationMismatch, *model.AppError) {
+	from := req.From
+	// Email auth in Mattermost system is represented by ""
+	if from == model.UserAuthServiceEmail {
+		from = ""
+	}
+
+	users, err := a.Srv().Store.User().GetAllUsingAuthService(from)
+	if err != nil {
+		return nil, nil, model.NewAppError("VerifyAuthMigration", "app.user.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	lookup, appErr := a.authMigrationLookup(req)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	matches := []*model.AuthMigrationMatch{}
+	mismatches := []*model.AuthMigrationMismatch{}
+	matchedBy := map[string]*model.User{}
+	duplicated := map[string]bool{}
+	for _, user := range users {
+		if user.IsBot {
+			continue
+		}
+
+		mismatch := &model.AuthMigrationMismatch{UserId: user.Id, Username: user.Username, Email: user.Email}
+
+		authData, ok := lookup(user)
+		if !ok || authData == "" {
+			mismatch.Reason = model.AuthMigrationMismatchNotFound
+			mismatches = append(mismatches, mismatch)
+			continue
+		}
+
+		if other, ok := matchedBy[authData]; ok {
+			mismatch.Reason = model.AuthMigrationMismatchDuplicate
+			mismatch.Detail = other.Username
+			mismatches = append(mismatches, mismatch)
+			duplicated[authData] = true
+			continue
+		}
+		matchedBy[authData] = user
+
+		if existing, appErr := a.GetUserByAuth(&authData, req.To); appErr == nil && existing.Id != user.Id {
+			mismatch.Reason = model.AuthMigrationMismatchTaken
+			mismatch.Detail = existing.Username
+			mismatches = append(mismatches, mismatch)
+			continue
+		}
+
+		matches = append(matches, &model.AuthMigrationMatch{UserId: user.Id, AuthData: authData})
+	}
+
+	// The user matched first for an account that turned out to match others can't be migrated
+	// either.
+	verified := make([]*model.AuthMigrationMatch, 0, len(matches))
+	for _, match := range matches {
+		if duplicated[match.AuthData] {
+			user := matchedBy[match.AuthData]
+			mismatches = append(mismatches, &model.AuthMigrationMismatch{UserId: user.Id, Username: user.Username, Email: user.Email, Reason: model.AuthMigrationMismatchDuplicate})
+			continue
+		}
+		verified = append(verified, match)
+	}
+
+	return verified, mismatches, nil
+}
+
+// authMigrationLookup returns a function finding the id of a user in the target provider of the
+// request.
+func (a *App) authMigrationLookup(req *model.AuthMigrationRequest) (func(user *model.User) (string, bool), *model.AppError) {
+	if req.To == model.UserAuthServiceSaml {
+		matches := make(map[string]string, len(req.Matches))
+		for email, samlID := range req.Matches {
+			matches[strings.ToLower(email)] = samlID
+		}
+
+		return func(user *model.User) (string, bool) {
+			if samlID, ok := matches[strings.ToLower(user.Email)]; ok {
+				return samlID, true
+			}
+			return user.Email, req.Auto
+		}, nil
+	}
+
+	ldapInterface := a.Ldap()
+	if ldapInterface == nil {
+		return nil, model.NewAppError("VerifyAuthMigration", "api.admin.ldap.not_available.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	ldapUsers, appErr := ldapInterface.GetAllLdapUsers()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	key := func(user *model.User) string {
+		if req.MatchField == model.AuthMigrationMatchFieldUsername {
+			return strings.ToLower(user.Username)
+		}
+		return strings.ToLower(user.Email)
+	}
+
+	ldapIDs := make(map[string]string, len(ldapUsers))
+	for _, ldapUser := range ldapUsers {
+		if ldapUser.AuthData != nil {
+			ldapIDs[key(ldapUser)] = *ldapUser.AuthData
+		}
+	}
+
+	return func(user *model.User) (string, bool) {
+		ldapID, ok := ldapIDs[key(user)]
+		return ldapID, ok
+	}, nil
+}
+
+// ApplyAuthMigration switches a verified user to the target provider and revokes their sessions.
+func (a *App) ApplyAuthMigration(match *model.AuthMigrationMatch, to string) *model.AppError {
+	if _, err := a.UpdateUserAuth(match.UserId, &model.UserAuth{AuthService: to, AuthData: model.NewString(match.AuthData)}); err != nil {
+		return err
+	}
+
+	a.InvalidateCacheForUser(match.UserId)
+
+	return a.RevokeAllSessions(match.UserId)
+}
+
+// WriteAuthMigrationMismatches writes the CSV report of the mismatches of an auth migration job
+// to the file store, returning its path.
+func (a *App) WriteAuthMigrationMismatches(jobID string, mismatches []*model.AuthMigrationMismatch) (string, *model.AppError) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"user_id", "username", "email", "reason", "detail"})
+	for _, mismatch := range mismatches {
+		w.Write([]string{mismatch.UserId, mismatch.Username, mismatch.Email, mismatch.Reason, mismatch.Detail})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", model.NewAppError("WriteAuthMigrationMismatches", "app.auth_migration.mismatches.write.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	mismatchesFile := path.Join(authMigrationDir, jobID+"_mismatches.csv")
+	if _, err := a.WriteFile(&buf, mismatchesFile); err != nil {
+		return "", err
+	}
+
+	return mismatchesFile, nil
+}
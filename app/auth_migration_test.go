@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestVerifyAuthMigration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	matched := th.CreateUser()
+	duplicate1 := th.CreateUser()
+	duplicate2 := th.CreateUser()
+	taken := th.CreateUser()
+	samlUser := th.CreateUser()
+	_, appErr := th.App.UpdateUserAuth(samlUser.Id, &model.UserAuth{AuthService: model.UserAuthServiceSaml, AuthData: model.NewString("saml-taken")})
+	require.Nil(t, appErr)
+
+	req := &model.AuthMigrationRequest{
+		From: model.UserAuthServiceEmail,
+		To:   model.UserAuthServiceSaml,
+		Matches: map[string]string{
+			strings.ToUpper(matched.Email): "saml-matched",
+			duplicate1.Email:               "saml-duplicate",
+			duplicate2.Email:               "saml-duplicate",
+			taken.Email:                    "saml-taken",
+		},
+	}
+
+	matches, mismatches, appErr := th.App.VerifyAuthMigration(req)
+	require.Nil(t, appErr)
+	require.Len(t, matches, 1)
+	assert.Equal(t, &model.AuthMigrationMatch{UserId: matched.Id, AuthData: "saml-matched"}, matches[0])
+
+	reasons := map[string]string{}
+	for _, mismatch := range mismatches {
+		reasons[mismatch.UserId] = mismatch.Reason
+	}
+	assert.Equal(t, model.AuthMigrationMismatchDuplicate, reasons[duplicate1.Id])
+	assert.Equal(t, model.AuthMigrationMismatchDuplicate, reasons[duplicate2.Id])
+	assert.Equal(t, model.AuthMigrationMismatchTaken, reasons[taken.Id])
+	assert.Equal(t, model.AuthMigrationMismatchNotFound, reasons[th.BasicUser.Id])
+	assert.NotContains(t, reasons, samlUser.Id)
+
+	t.Run("apply a match", func(t *testing.T) {
+		require.Nil(t, th.App.ApplyAuthMigration(matches[0], model.UserAuthServiceSaml))
+
+		user, appErr := th.App.GetUser(matched.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.UserAuthServiceSaml, user.AuthService)
+		assert.Equal(t, "saml-matched", *user.AuthData)
+	})
+
+	t.Run("mismatch report", func(t *testing.T) {
+		job, appErr := th.App.CreateUserMergeJob(&model.UserMergeRequest{SourceUserId: th.BasicUser2.Id, TargetUserId: th.BasicUser.Id}, th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+		_, appErr = th.App.GetAuthMigrationMismatches(job.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		mismatchesFile, appErr := th.App.WriteAuthMigrationMismatches(model.NewId(), []*model.AuthMigrationMismatch{
+			{UserId: taken.Id, Username: taken.Username, Email: taken.Email, Reason: model.AuthMigrationMismatchTaken, Detail: samlUser.Username},
+		})
+		require.Nil(t, appErr)
+
+		data, appErr := th.App.ReadFile(mismatchesFile)
+		require.Nil(t, appErr)
+		assert.Equal(t, "user_id,username,email,reason,detail\n"+taken.Id+","+taken.Username+","+taken.Email+",taken,"+samlUser.Username+"\n", string(data))
+	})
+}
@@ -211,6 +211,11 @@ func (a *App) CheckUserMfa(user *model.User, token string) *model.AppError {
 		return model.NewAppError("CheckUserMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
+	// Backup codes can't be mistaken for the tokens of authenticator apps, which are numeric.
+	if code, ok := mfa.NormalizeBackupCode(token); ok {
+		return a.useMfaBackupCode(user, code)
+	}
+
 	ok, err := mfa.New(a.Srv().Store.User()).ValidateToken(user.MfaSecret, token)
 	if err != nil {
 		return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, err.Error(), http.StatusBadRequest)
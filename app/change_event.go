@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/changecapture"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const publishedChangeEventsDeleteBatchSize = 1000
+
+// PublishChangeEvents publishes the unpublished change events to the configured broker in
+// batches, the first recorded first, returning how many were published. The events published
+// more than RetentionHours ago are then deleted. A batch that fails to publish is retried on the
+// next call, so the consumers may receive an event more than once.
+func (a *App) PublishChangeEvents() (int, *model.AppError) {
+	settings := a.Config().ChangeDataCaptureSettings
+
+	publisher, err := a.Srv().getChangeEventPublisher(settings)
+	if err != nil {
+		return 0, model.NewAppError("PublishChangeEvents", "app.change_event.publisher.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	published := 0
+	for {
+		events, err := a.Srv().Store.ChangeEvent().GetUnpublished(*settings.BatchSize)
+		if err != nil {
+			return published, model.NewAppError("PublishChangeEvents", "app.change_event.get_unpublished.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		if err := publisher.Publish(events); err != nil {
+			return published, model.NewAppError("PublishChangeEvents", "app.change_event.publish.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		ids := make([]string, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.Id)
+		}
+		if err := a.Srv().Store.ChangeEvent().MarkPublished(ids, model.GetMillis()); err != nil {
+			return published, model.NewAppError("PublishChangeEvents", "app.change_event.mark_published.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		published += len(events)
+
+		if len(events) < *settings.BatchSize {
+			break
+		}
+	}
+
+	endTime := model.GetMillis() - int64(*settings.RetentionHours)*int64(time.Hour/time.Millisecond)
+	for {
+		deleted, err := a.Srv().Store.ChangeEvent().PermanentDeletePublishedBatch(endTime, publishedChangeEventsDeleteBatchSize)
+		if err != nil {
+			return published, model.NewAppError("PublishChangeEvents", "app.change_event.permanent_delete_published.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if deleted < publishedChangeEventsDeleteBatchSize {
+			break
+		}
+	}
+
+	return published, nil
+}
+
+// getChangeEventPublisher returns the publisher of the change events for the settings, which is
+// kept open until the settings change.
+func (s *Server) getChangeEventPublisher(settings model.ChangeDataCaptureSettings) (changecapture.Publisher, error) {
+	s.changeEventPublisherMut.Lock()
+	defer s.changeEventPublisherMut.Unlock()
+
+	if s.changeEventPublisher != nil && reflect.DeepEqual(s.changeEventPublisherSettings, settings) {
+		return s.changeEventPublisher, nil
+	}
+	s.closeChangeEventPublisherLocked()
+
+	publisher, err := changecapture.NewPublisher(&settings)
+	if err != nil {
+		return nil, err
+	}
+	s.changeEventPublisher = publisher
+	s.changeEventPublisherSettings = settings
+
+	return publisher, nil
+}
+
+// closeChangeEventPublisher closes the publisher of the change events, if open.
+func (s *Server) closeChangeEventPublisher() {
+	s.changeEventPublisherMut.Lock()
+	defer s.changeEventPublisherMut.Unlock()
+
+	s.closeChangeEventPublisherLocked()
+}
+
+func (s *Server) closeChangeEventPublisherLocked() {
+	if s.changeEventPublisher == nil {
+		return
+	}
+
+	if err := s.changeEventPublisher.Close(); err != nil {
+		mlog.Warn("Failed to close the change event publisher", mlog.Err(err))
+	}
+	s.changeEventPublisher = nil
+	s.changeEventPublisherSettings = model.ChangeDataCaptureSettings{}
+}
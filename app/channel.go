@@ -364,6 +364,10 @@ func (a *App) GetOrCreateDirectChannel(c *request.Context, userID, otherUserID s
 		return nil, err
 	}
 
+	if err := a.createDirectMessageRequestIfNeeded(c, userID, otherUserID, channel); err != nil {
+		return nil, err
+	}
+
 	a.handleCreationEvent(c, userID, otherUserID, channel)
 	return channel, nil
 }
@@ -1168,6 +1172,10 @@ func (a *App) UpdateChannelMemberRoles(channelID string, userID string, newRoles
 			return nil, err
 		}
 
+		if !role.IsAssignableAt(model.RoleScopeChannel) {
+			return nil, model.NewAppError("UpdateChannelMemberRoles", "app.role.check_roles_assignable.invalid_scope.app_error", map[string]interface{}{"Scope": role.Scope}, "role_name="+roleName, http.StatusBadRequest)
+		}
+
 		if !role.SchemeManaged {
 			// The role is not scheme-managed, so it's OK to apply it to the explicit roles field.
 			newExplicitRoles = append(newExplicitRoles, roleName)
@@ -2041,6 +2049,10 @@ func (a *App) GetChannelMembersWithTeamDataForUserWithPagination(userID string,
 }
 
 func (a *App) GetChannelMemberCount(channelID string) (int64, *model.AppError) {
+	if counts := a.getMaintainedChannelMemberCounts(channelID); counts != nil {
+		return counts.MemberCount, nil
+	}
+
 	count, err := a.Srv().Store.Channel().GetMemberCount(channelID, true)
 	if err != nil {
 		return 0, model.NewAppError("GetChannelMemberCount", "app.channel.get_member_count.app_error", nil, err.Error(), http.StatusInternalServerError)
@@ -2049,6 +2061,26 @@ func (a *App) GetChannelMemberCount(channelID string) (int64, *model.AppError) {
 	return count, nil
 }
 
+// getMaintainedChannelMemberCounts returns the maintained member counts of the channel when they
+// are to be used instead of counting the members, and nil otherwise. Channels whose counts aren't
+// maintained yet are counted until they are reconciled.
+func (a *App) getMaintainedChannelMemberCounts(channelID string) *model.ChannelMemberCounts {
+	if !*a.Config().ServiceSettings.UseMaintainedChannelMemberCounts {
+		return nil
+	}
+
+	counts, err := a.Srv().Store.Channel().GetMemberCounts(channelID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			mlog.Warn("Failed to get the maintained member counts of a channel", mlog.String("channel_id", channelID), mlog.Err(err))
+		}
+		return nil
+	}
+
+	return counts
+}
+
 func (a *App) GetChannelFileCount(channelID string) (int64, *model.AppError) {
 	count, err := a.Srv().Store.Channel().GetFileCount(channelID)
 	if err != nil {
@@ -2059,6 +2091,10 @@ func (a *App) GetChannelFileCount(channelID string) (int64, *model.AppError) {
 }
 
 func (a *App) GetChannelGuestCount(channelID string) (int64, *model.AppError) {
+	if counts := a.getMaintainedChannelMemberCounts(channelID); counts != nil {
+		return counts.GuestCount, nil
+	}
+
 	count, err := a.Srv().Store.Channel().GetGuestCount(channelID, true)
 	if err != nil {
 		return 0, model.NewAppError("SqlChannelStore.GetGuestCount", "app.channel.get_member_count.app_error", nil, err.Error(), http.StatusInternalServerError)
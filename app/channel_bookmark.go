@@ -0,0 +1,216 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// GetChannelBookmarks returns the bookmarks of a channel, in their order.
+func (a *App) GetChannelBookmarks(channelID string) ([]*model.ChannelBookmark, *model.AppError) {
+	bookmarks, err := a.Srv().Store.ChannelBookmark().GetForChannel(channelID)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelBookmarks", "app.channel_bookmark.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return bookmarks, nil
+}
+
+func (a *App) GetChannelBookmark(bookmarkID string, includeDeleted bool) (*model.ChannelBookmark, *model.AppError) {
+	bookmark, err := a.Srv().Store.ChannelBookmark().Get(bookmarkID, includeDeleted)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetChannelBookmark", "app.channel_bookmark.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetChannelBookmark", "app.channel_bookmark.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return bookmark, nil
+}
+
+// CreateChannelBookmark adds a bookmark at the end of the bookmarks of a channel. The file of a
+// file bookmark must have been uploaded to the channel.
+func (a *App) CreateChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError) {
+	if appErr := a.checkChannelBookmarkChannel(bookmark.ChannelId); appErr != nil {
+		return nil, appErr
+	}
+
+	bookmarks, appErr := a.GetChannelBookmarks(bookmark.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(bookmarks) >= model.MaxChannelBookmarksPerChannel {
+		return nil, model.NewAppError("CreateChannelBookmark", "app.channel_bookmark.limit_reached.app_error", map[string]interface{}{"Max": model.MaxChannelBookmarksPerChannel}, "", http.StatusBadRequest)
+	}
+
+	if appErr := a.checkChannelBookmarkFile(bookmark); appErr != nil {
+		return nil, appErr
+	}
+
+	bookmark.Id = ""
+	bookmark.CreateAt = 0
+	bookmark.SortOrder = 0
+	if len(bookmarks) > 0 {
+		bookmark.SortOrder = bookmarks[len(bookmarks)-1].SortOrder + 1
+	}
+
+	saved, err := a.Srv().Store.ChannelBookmark().Save(bookmark)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateChannelBookmark", "app.channel_bookmark.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkCreated, saved.ChannelId, "bookmark", saved)
+
+	return saved, nil
+}
+
+// PatchChannelBookmark changes the name, target or emoji of a bookmark.
+func (a *App) PatchChannelBookmark(bookmark *model.ChannelBookmark, patch *model.ChannelBookmarkPatch) (*model.ChannelBookmark, *model.AppError) {
+	if appErr := a.checkChannelBookmarkChannel(bookmark.ChannelId); appErr != nil {
+		return nil, appErr
+	}
+
+	fileID := bookmark.FileId
+	bookmark.Patch(patch)
+	if bookmark.FileId != fileID {
+		if appErr := a.checkChannelBookmarkFile(bookmark); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	updated, err := a.Srv().Store.ChannelBookmark().Update(bookmark)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("PatchChannelBookmark", "app.channel_bookmark.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("PatchChannelBookmark", "app.channel_bookmark.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkUpdated, updated.ChannelId, "bookmark", updated)
+
+	return updated, nil
+}
+
+// UpdateChannelBookmarkSortOrder moves a bookmark of a channel to the given index and returns the
+// bookmarks of the channel whose order changed.
+func (a *App) UpdateChannelBookmarkSortOrder(bookmarkID, channelID string, newIndex int64) ([]*model.ChannelBookmark, *model.AppError) {
+	if appErr := a.checkChannelBookmarkChannel(channelID); appErr != nil {
+		return nil, appErr
+	}
+
+	changed, err := a.Srv().Store.ChannelBookmark().UpdateSortOrder(bookmarkID, channelID, newIndex)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.update_sort_order.invalid_index.app_error", nil, invErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("UpdateChannelBookmarkSortOrder", "app.channel_bookmark.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkSorted, channelID, "bookmarks", changed)
+
+	return changed, nil
+}
+
+// DeleteChannelBookmark deletes a bookmark and returns it.
+func (a *App) DeleteChannelBookmark(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, *model.AppError) {
+	if appErr := a.checkChannelBookmarkChannel(bookmark.ChannelId); appErr != nil {
+		return nil, appErr
+	}
+
+	deleteAt := model.GetMillis()
+	if err := a.Srv().Store.ChannelBookmark().Delete(bookmark.Id, deleteAt); err != nil {
+		return nil, model.NewAppError("DeleteChannelBookmark", "app.channel_bookmark.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	bookmark.DeleteAt = deleteAt
+	bookmark.UpdateAt = deleteAt
+
+	a.publishChannelBookmarkEvent(model.WebsocketEventChannelBookmarkDeleted, bookmark.ChannelId, "bookmark", bookmark)
+
+	return bookmark, nil
+}
+
+// checkChannelBookmarkChannel returns an error if the bookmarks of the channel can't be changed,
+// which they can't once it is archived.
+func (a *App) checkChannelBookmarkChannel(channelID string) *model.AppError {
+	channel, appErr := a.GetChannel(channelID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if channel.DeleteAt != 0 {
+		return model.NewAppError("checkChannelBookmarkChannel", "app.channel_bookmark.archived_channel.app_error", nil, "channel_id="+channelID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// checkChannelBookmarkFile returns an error if the file of a file bookmark wasn't uploaded to its
+// channel, so that bookmarks can't expose files of other channels.
+func (a *App) checkChannelBookmarkFile(bookmark *model.ChannelBookmark) *model.AppError {
+	if bookmark.Type != model.ChannelBookmarkFile || bookmark.FileId == "" {
+		return nil
+	}
+
+	fileInfo, appErr := a.GetFileInfo(bookmark.FileId)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return model.NewAppError("checkChannelBookmarkFile", "app.channel_bookmark.invalid_file.app_error", nil, "file_id="+bookmark.FileId, http.StatusBadRequest)
+		}
+		return appErr
+	}
+
+	// Files uploaded for a bookmark aren't attached to a post, the channel they were uploaded to is
+	// part of their path.
+	inChannel := fileInfo.ChannelId == bookmark.ChannelId
+	if fileInfo.PostId == "" {
+		inChannel = strings.Contains(fileInfo.Path, "/channels/"+bookmark.ChannelId+"/")
+	}
+
+	if !inChannel || fileInfo.DeleteAt != 0 {
+		return model.NewAppError("checkChannelBookmarkFile", "app.channel_bookmark.invalid_file.app_error", nil, "file_id="+bookmark.FileId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (a *App) publishChannelBookmarkEvent(event, channelID, key string, data interface{}) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		mlog.Warn("Failed to encode channel bookmark to JSON", mlog.String("channel_id", channelID), mlog.Err(err))
+		return
+	}
+
+	message := model.NewWebSocketEvent(event, "", channelID, "", nil)
+	message.Add(key, string(dataJSON))
+	a.Publish(message)
+}
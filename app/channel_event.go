@@ -0,0 +1,243 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const dueChannelEventsBatchSize = 100
+
+func (a *App) checkChannelEventsEnabled(where string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableChannelEvents {
+		return model.NewAppError(where, "app.channel_event.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+// CreateChannelEvent schedules an event in a channel. The event must have an occurrence in the
+// future.
+func (a *App) CreateChannelEvent(event *model.ChannelEvent) (*model.ChannelEvent, *model.AppError) {
+	if appErr := a.checkChannelEventsEnabled("CreateChannelEvent"); appErr != nil {
+		return nil, appErr
+	}
+
+	event.Id = ""
+	event.CreateAt = 0
+	if appErr := a.scheduleChannelEvent("CreateChannelEvent", event); appErr != nil {
+		return nil, appErr
+	}
+
+	saved, err := a.Srv().Store.ChannelEvent().Save(event)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateChannelEvent", "app.channel_event.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetChannelEvent(eventID string) (*model.ChannelEvent, *model.AppError) {
+	event, err := a.Srv().Store.ChannelEvent().Get(eventID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetChannelEvent", "app.channel_event.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetChannelEvent", "app.channel_event.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return event, nil
+}
+
+// GetChannelEventsForChannel returns the events of a channel, ordered by start.
+func (a *App) GetChannelEventsForChannel(channelID string) ([]*model.ChannelEvent, *model.AppError) {
+	events, err := a.Srv().Store.ChannelEvent().GetForChannel(channelID)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelEventsForChannel", "app.channel_event.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return events, nil
+}
+
+// PatchChannelEvent changes an event. An event whose time, timezone, recurrence or reminder is
+// changed is scheduled again, and must then have an occurrence in the future.
+func (a *App) PatchChannelEvent(event *model.ChannelEvent, patch *model.ChannelEventPatch) (*model.ChannelEvent, *model.AppError) {
+	if appErr := a.checkChannelEventsEnabled("PatchChannelEvent"); appErr != nil {
+		return nil, appErr
+	}
+
+	event.Patch(patch)
+
+	if patch.StartAt != nil || patch.Timezone != nil || patch.Recurrence != nil || patch.RemindBeforeMinutes != nil {
+		if appErr := a.scheduleChannelEvent("PatchChannelEvent", event); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	updated, err := a.Srv().Store.ChannelEvent().Update(event)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("PatchChannelEvent", "app.channel_event.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updated, nil
+}
+
+func (a *App) DeleteChannelEvent(eventID string) *model.AppError {
+	if err := a.Srv().Store.ChannelEvent().Delete(eventID); err != nil {
+		return model.NewAppError("DeleteChannelEvent", "app.channel_event.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (a *App) scheduleChannelEvent(where string, event *model.ChannelEvent) *model.AppError {
+	if !model.IsValidChannelEventTimezone(event.Timezone) {
+		return model.NewAppError(where, "model.channel_event.is_valid.timezone.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	event.Schedule(model.GetMillis())
+	if event.NextStartAt == 0 {
+		return model.NewAppError(where, "app.channel_event.no_occurrence.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AnnounceDueChannelEvents announces the events due by now in their channels, returning how many
+// were announced. Each event is scheduled again for its next occurrence, if it has one. An event
+// that can't be announced isn't retried.
+func (a *App) AnnounceDueChannelEvents(now int64) (int, *model.AppError) {
+	bot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	c := request.EmptyContext()
+	announced := 0
+	for {
+		events, err := a.Srv().Store.ChannelEvent().GetDue(now, dueChannelEventsBatchSize)
+		if err != nil {
+			return announced, model.NewAppError("AnnounceDueChannelEvents", "app.channel_event.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, event := range events {
+			if appErr := a.announceChannelEvent(c, bot, event); appErr != nil {
+				mlog.Warn("Failed to announce a channel event", mlog.String("channel_event_id", event.Id), mlog.String("channel_id", event.ChannelId), mlog.Err(appErr))
+			} else {
+				announced++
+			}
+
+			event.Schedule(now)
+			if _, err := a.Srv().Store.ChannelEvent().Update(event); err != nil {
+				return announced, model.NewAppError("AnnounceDueChannelEvents", "app.channel_event.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+		}
+
+		if len(events) < dueChannelEventsBatchSize {
+			return announced, nil
+		}
+	}
+}
+
+// announceChannelEvent posts the next occurrence of the event in its channel as the system bot,
+// at its time in the timezone of the event. Each member of the channel is also sent the time in
+// their own timezone and locale, unless the channel has too many members to notify.
+func (a *App) announceChannelEvent(c *request.Context, bot *model.Bot, event *model.ChannelEvent) *model.AppError {
+	channel, appErr := a.GetChannel(event.ChannelId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if channel.DeleteAt != 0 {
+		return nil
+	}
+
+	loc, err := event.Location()
+	if err != nil {
+		return model.NewAppError("announceChannelEvent", "model.channel_event.is_valid.timezone.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	T := i18n.GetNotificationTranslations(*a.Config().LocalizationSettings.DefaultServerLocale)
+	post := &model.Post{
+		UserId:    bot.UserId,
+		ChannelId: channel.Id,
+		Message:   channelEventMessage(T, "app.channel_event.announcement", event, getFormattedTime(model.GetTimeForMillis(event.NextStartAt).In(loc), true, T)),
+	}
+	post.AddProp("channel_event_id", event.Id)
+	post.AddProp("start_at", event.NextStartAt)
+
+	if _, appErr := a.CreatePost(c, post, channel, false, true); appErr != nil {
+		return appErr
+	}
+
+	memberCount, appErr := a.GetChannelMemberCount(channel.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	if *a.Config().TeamSettings.MaxNotificationsPerChannel > 0 && memberCount > *a.Config().TeamSettings.MaxNotificationsPerChannel {
+		return nil
+	}
+
+	profiles, err := a.Srv().Store.User().GetAllProfilesInChannel(context.Background(), channel.Id, true)
+	if err != nil {
+		return model.NewAppError("announceChannelEvent", "app.channel_event.announce.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, user := range profiles {
+		if user.DeleteAt != 0 || user.IsBot {
+			continue
+		}
+
+		userT := i18n.GetNotificationTranslations(user.Locale)
+		startAt := getFormattedTimeForUser(user, event.NextStartAt, a.useMilitaryTime(user.Id), userT)
+		a.SendEphemeralPost(user.Id, &model.Post{
+			UserId:    bot.UserId,
+			ChannelId: channel.Id,
+			Message:   channelEventMessage(userT, "app.channel_event.local_time", event, startAt),
+			CreateAt:  model.GetMillis(),
+		})
+	}
+
+	return nil
+}
+
+func channelEventMessage(T i18n.TranslateFunc, translationID string, event *model.ChannelEvent, startAt formattedPostTime) string {
+	message := T(translationID, map[string]interface{}{
+		"Title":    event.Title,
+		"Year":     startAt.Year,
+		"Month":    startAt.Month,
+		"Day":      startAt.Day,
+		"Hour":     startAt.Hour,
+		"Minute":   startAt.Minute,
+		"TimeZone": startAt.TimeZone,
+	})
+	if event.Description != "" {
+		message += "\n\n" + event.Description
+	}
+
+	return message
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// channelAdminsPerPage is the number of channel members read at once to find its admins.
+const channelAdminsPerPage = 200
+
+// RequestToJoinChannel asks the members allowed to manage the members of a private channel to add
+// the user to it. The pending request of the user is returned if they already asked.
+func (a *App) RequestToJoinChannel(c *request.Context, channelID, userID, message string) (*model.ChannelJoinRequest, *model.AppError) {
+	channel, appErr := a.GetChannel(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if channel.Type != model.ChannelTypePrivate || channel.DeleteAt != 0 || channel.IsGroupConstrained() {
+		return nil, model.NewAppError("RequestToJoinChannel", "app.channel_join_request.invalid_channel.app_error", nil, "channel_id="+channelID, http.StatusBadRequest)
+	}
+
+	if teamMember, appErr := a.GetTeamMember(channel.TeamId, userID); appErr != nil || teamMember.DeleteAt != 0 {
+		return nil, model.NewAppError("RequestToJoinChannel", "app.channel_join_request.not_team_member.app_error", nil, "channel_id="+channelID, http.StatusForbidden)
+	}
+
+	_, err := a.Srv().Store.Channel().GetMember(context.Background(), channelID, userID)
+	if err == nil {
+		return nil, model.NewAppError("RequestToJoinChannel", "app.channel_join_request.already_member.app_error", nil, "channel_id="+channelID, http.StatusBadRequest)
+	}
+	var nfErr *store.ErrNotFound
+	if !errors.As(err, &nfErr) {
+		return nil, model.NewAppError("RequestToJoinChannel", "app.channel.get_member.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if joinRequest, appErr := a.getPendingChannelJoinRequest(channelID, userID); appErr == nil || appErr.StatusCode != http.StatusNotFound {
+		return joinRequest, appErr
+	}
+
+	joinRequest, err := a.Srv().Store.ChannelJoinRequest().Save(&model.ChannelJoinRequest{
+		ChannelId: channelID,
+		UserId:    userID,
+		Message:   message,
+	})
+	if err != nil {
+		var invErr *model.AppError
+		var uniqueErr *store.ErrUniqueConstraint
+		switch {
+		case errors.As(err, &invErr):
+			return nil, invErr
+		case errors.As(err, &uniqueErr):
+			// The user asked concurrently, their other request is the pending one.
+			return a.getPendingChannelJoinRequest(channelID, userID)
+		default:
+			return nil, model.NewAppError("RequestToJoinChannel", "app.channel_join_request.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	adminIDs, appErr := a.getChannelAdminIDs(channelID)
+	if appErr != nil {
+		mlog.Warn("Failed to get the admins of a channel to notify of a join request", mlog.String("channel_id", channelID), mlog.Err(appErr))
+	}
+	a.publishChannelJoinRequest(model.WebsocketEventChannelJoinRequestCreated, joinRequest, adminIDs...)
+
+	if requester, appErr := a.GetUser(userID); appErr == nil {
+		for _, adminID := range adminIDs {
+			a.sendChannelJoinRequestNotification(c, adminID, "app.channel_join_request.requested_message", map[string]interface{}{
+				"Username":    requester.Username,
+				"ChannelName": channel.DisplayName,
+				"Message":     joinRequest.Message,
+			})
+		}
+	}
+
+	return joinRequest, nil
+}
+
+// GetChannelJoinRequests returns the pending requests to join a channel, the oldest first.
+func (a *App) GetChannelJoinRequests(channelID string) ([]*model.ChannelJoinRequest, *model.AppError) {
+	requests, err := a.Srv().Store.ChannelJoinRequest().GetPendingForChannel(channelID, model.GetMillis())
+	if err != nil {
+		return nil, model.NewAppError("GetChannelJoinRequests", "app.channel_join_request.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return requests, nil
+}
+
+// getPendingChannelJoinRequest returns the pending request of a user to join a channel.
+func (a *App) getPendingChannelJoinRequest(channelID, userID string) (*model.ChannelJoinRequest, *model.AppError) {
+	requests, appErr := a.GetChannelJoinRequests(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	for _, joinRequest := range requests {
+		if joinRequest.UserId == userID {
+			return joinRequest, nil
+		}
+	}
+
+	return nil, model.NewAppError("getPendingChannelJoinRequest", "app.channel_join_request.not_found.app_error", nil, "channel_id="+channelID+", user_id="+userID, http.StatusNotFound)
+}
+
+// GetChannelJoinRequest returns a request to join a channel, whatever its status.
+func (a *App) GetChannelJoinRequest(requestID string) (*model.ChannelJoinRequest, *model.AppError) {
+	joinRequest, err := a.Srv().Store.ChannelJoinRequest().Get(requestID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetChannelJoinRequest", "app.channel_join_request.not_found.app_error", nil, "id="+requestID, http.StatusNotFound)
+		}
+		return nil, model.NewAppError("GetChannelJoinRequest", "app.channel_join_request.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return joinRequest, nil
+}
+
+// ApproveChannelJoinRequest adds the user of a pending request to the channel, on behalf of the
+// reviewer. The request is marked as approved first so that it is only acted on once.
+func (a *App) ApproveChannelJoinRequest(c *request.Context, joinRequest *model.ChannelJoinRequest, reviewerID string) (*model.ChannelJoinRequest, *model.AppError) {
+	if !joinRequest.IsPending(model.GetMillis()) {
+		return nil, model.NewAppError("ApproveChannelJoinRequest", "app.channel_join_request.not_pending.app_error", nil, "id="+joinRequest.Id, http.StatusBadRequest)
+	}
+
+	channel, appErr := a.GetChannel(joinRequest.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	updated, appErr := a.updateChannelJoinRequestStatus(joinRequest, model.ChannelJoinRequestStatusApproved, reviewerID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if _, appErr := a.AddChannelMember(c, updated.UserId, channel, ChannelMemberOpts{UserRequestorID: reviewerID}); appErr != nil {
+		return nil, appErr
+	}
+
+	a.notifyChannelJoinRequestReviewed(c, updated, channel)
+	return updated, nil
+}
+
+// DenyChannelJoinRequest turns down a pending request to join a channel. The user can ask again.
+func (a *App) DenyChannelJoinRequest(c *request.Context, joinRequest *model.ChannelJoinRequest, reviewerID string) (*model.ChannelJoinRequest, *model.AppError) {
+	if !joinRequest.IsPending(model.GetMillis()) {
+		return nil, model.NewAppError("DenyChannelJoinRequest", "app.channel_join_request.not_pending.app_error", nil, "id="+joinRequest.Id, http.StatusBadRequest)
+	}
+
+	channel, appErr := a.GetChannel(joinRequest.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	updated, appErr := a.updateChannelJoinRequestStatus(joinRequest, model.ChannelJoinRequestStatusDenied, reviewerID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	a.notifyChannelJoinRequestReviewed(c, updated, channel)
+	return updated, nil
+}
+
+// CancelChannelJoinRequest removes a pending request to join a channel, which only its user can
+// cancel.
+func (a *App) CancelChannelJoinRequest(joinRequest *model.ChannelJoinRequest, userID string) *model.AppError {
+	if joinRequest.UserId != userID {
+		return model.NewAppError("CancelChannelJoinRequest", "app.channel_join_request.not_requester.app_error", nil, "id="+joinRequest.Id, http.StatusForbidden)
+	}
+
+	if !joinRequest.IsPending(model.GetMillis()) {
+		return model.NewAppError("CancelChannelJoinRequest", "app.channel_join_request.not_pending.app_error", nil, "id="+joinRequest.Id, http.StatusBadRequest)
+	}
+
+	if err := a.Srv().Store.ChannelJoinRequest().Delete(joinRequest.Id); err != nil {
+		return model.NewAppError("CancelChannelJoinRequest", "app.channel_join_request.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	adminIDs, appErr := a.getChannelAdminIDs(joinRequest.ChannelId)
+	if appErr != nil {
+		mlog.Warn("Failed to get the admins of a channel to notify of a canceled join request", mlog.String("channel_id", joinRequest.ChannelId), mlog.Err(appErr))
+	}
+	joinRequest.Status = model.ChannelJoinRequestStatusCanceled
+	a.publishChannelJoinRequest(model.WebsocketEventChannelJoinRequestUpdated, joinRequest, adminIDs...)
+
+	return nil
+}
+
+// updateChannelJoinRequestStatus records the review of a request, failing if it is no longer
+// pending, for instance because another admin reviewed it concurrently.
+func (a *App) updateChannelJoinRequestStatus(joinRequest *model.ChannelJoinRequest, status, reviewerID string) (*model.ChannelJoinRequest, *model.AppError) {
+	reviewed := *joinRequest
+	reviewed.Status = status
+	reviewed.ReviewerId = reviewerID
+	updated, err := a.Srv().Store.ChannelJoinRequest().Update(&reviewed)
+	if err != nil {
+		var invErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &invErr):
+			return nil, invErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("updateChannelJoinRequestStatus", "app.channel_join_request.not_pending.app_error", nil, "id="+joinRequest.Id, http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("updateChannelJoinRequestStatus", "app.channel_join_request.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updated, nil
+}
+
+// notifyChannelJoinRequestReviewed informs the admins of the channel and the user of the review
+// of their request.
+func (a *App) notifyChannelJoinRequestReviewed(c *request.Context, joinRequest *model.ChannelJoinRequest, channel *model.Channel) {
+	adminIDs, appErr := a.getChannelAdminIDs(channel.Id)
+	if appErr != nil {
+		mlog.Warn("Failed to get the admins of a channel to notify of a reviewed join request", mlog.String("channel_id", channel.Id), mlog.Err(appErr))
+	}
+	a.publishChannelJoinRequest(model.WebsocketEventChannelJoinRequestUpdated, joinRequest, append(adminIDs, joinRequest.UserId)...)
+
+	translationID := "app.channel_join_request.denied_message"
+	if joinRequest.Status == model.ChannelJoinRequestStatusApproved {
+		translationID = "app.channel_join_request.approved_message"
+	}
+	a.sendChannelJoinRequestNotification(c, joinRequest.UserId, translationID, map[string]interface{}{
+		"ChannelName": channel.DisplayName,
+	})
+}
+
+// getChannelAdminIDs returns the ids of the admins of a channel, who are notified of the requests
+// to join it.
+func (a *App) getChannelAdminIDs(channelID string) ([]string, *model.AppError) {
+	var adminIDs []string
+	for offset := 0; ; offset += channelAdminsPerPage {
+		members, err := a.Srv().Store.Channel().GetMembers(channelID, offset, channelAdminsPerPage)
+		if err != nil {
+			return adminIDs, model.NewAppError("getChannelAdminIDs", "app.channel.get_members.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, member := range members {
+			if member.SchemeAdmin {
+				adminIDs = append(adminIDs, member.UserId)
+			}
+		}
+
+		if len(members) < channelAdminsPerPage {
+			return adminIDs, nil
+		}
+	}
+}
+
+// sendChannelJoinRequestNotification sends a direct message from the system bot to a user about a
+// request to join a channel, in their locale.
+func (a *App) sendChannelJoinRequestNotification(c *request.Context, userID, translationID string, args map[string]interface{}) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		mlog.Warn("Failed to get the user to notify of a channel join request", mlog.String("user_id", userID), mlog.Err(appErr))
+		return
+	}
+
+	bot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		mlog.Warn("Failed to get the system bot to notify of a channel join request", mlog.String("user_id", userID), mlog.Err(appErr))
+		return
+	}
+
+	channel, appErr := a.GetOrCreateDirectChannel(c, user.Id, bot.UserId)
+	if appErr != nil {
+		mlog.Warn("Failed to get the direct channel to notify of a channel join request", mlog.String("user_id", userID), mlog.Err(appErr))
+		return
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	post := &model.Post{
+		UserId:    bot.UserId,
+		ChannelId: channel.Id,
+		Message:   T(translationID, args),
+	}
+	if _, appErr := a.CreatePost(c, post, channel, false, true); appErr != nil {
+		mlog.Warn("Failed to notify of a channel join request", mlog.String("user_id", userID), mlog.Err(appErr))
+	}
+}
+
+// publishChannelJoinRequest informs the given users that a request to join a channel was created
+// or its status changed.
+func (a *App) publishChannelJoinRequest(event string, joinRequest *model.ChannelJoinRequest, userIDs ...string) {
+	for _, userID := range userIDs {
+		message := model.NewWebSocketEvent(event, "", "", userID, nil)
+		message.Add("request_id", joinRequest.Id)
+		message.Add("channel_id", joinRequest.ChannelId)
+		message.Add("user_id", joinRequest.UserId)
+		message.Add("status", joinRequest.Status)
+		a.Publish(message)
+	}
+}
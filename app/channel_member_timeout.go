@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// TimeoutChannelMember prevents a member from posting in a channel for the given number of
+// minutes, replacing any timeout they already had. Members who can moderate the channel, including
+// team and system admins, can't be timed out.
+func (a *App) TimeoutChannelMember(c *request.Context, channelID, userID, creatorID string, minutes int) (*model.ChannelMemberTimeout, *model.AppError) {
+	if minutes <= 0 || minutes > model.ChannelMemberTimeoutMaxMinutes {
+		return nil, model.NewAppError("TimeoutChannelMember", "app.channel_member_timeout.invalid_duration.app_error", map[string]interface{}{"Max": model.ChannelMemberTimeoutMaxMinutes}, "", http.StatusBadRequest)
+	}
+
+	if userID == creatorID {
+		return nil, model.NewAppError("TimeoutChannelMember", "app.channel_member_timeout.self.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, appErr := a.GetChannelMember(c.Context(), channelID, userID); appErr != nil {
+		return nil, appErr
+	}
+
+	if a.HasPermissionToChannel(userID, channelID, model.PermissionManageChannelRoles) {
+		return nil, model.NewAppError("TimeoutChannelMember", "app.channel_member_timeout.moderator.app_error", nil, "", http.StatusForbidden)
+	}
+
+	now := model.GetMillis()
+	timeout := &model.ChannelMemberTimeout{
+		ChannelId: channelID,
+		UserId:    userID,
+		CreatorId: creatorID,
+		CreateAt:  now,
+		ExpiresAt: now + int64(minutes)*60*1000,
+	}
+
+	timeout, err := a.Srv().Store.ChannelMemberTimeout().Save(timeout)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("TimeoutChannelMember", "app.channel_member_timeout.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.publishChannelMemberTimeout(channelID, userID, timeout.ExpiresAt)
+
+	return timeout, nil
+}
+
+// RemoveChannelMemberTimeout lets a member post in a channel again before their timeout expires.
+// Members can't remove their own timeout.
+func (a *App) RemoveChannelMemberTimeout(channelID, userID, removerID string) *model.AppError {
+	if userID == removerID {
+		return model.NewAppError("RemoveChannelMemberTimeout", "app.channel_member_timeout.remove_self.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if err := a.Srv().Store.ChannelMemberTimeout().Delete(channelID, userID); err != nil {
+		return model.NewAppError("RemoveChannelMemberTimeout", "app.channel_member_timeout.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.publishChannelMemberTimeout(channelID, userID, 0)
+
+	return nil
+}
+
+// GetChannelMemberTimeouts returns the timeouts of the members of a channel that have not expired.
+func (a *App) GetChannelMemberTimeouts(channelID string) ([]*model.ChannelMemberTimeout, *model.AppError) {
+	timeouts, err := a.Srv().Store.ChannelMemberTimeout().GetForChannel(channelID, model.GetMillis())
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMemberTimeouts", "app.channel_member_timeout.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return timeouts, nil
+}
+
+// checkChannelMemberTimeout returns an error if the user is timed out in the channel.
+func (a *App) checkChannelMemberTimeout(channelID, userID string) *model.AppError {
+	timeout, err := a.Srv().Store.ChannelMemberTimeout().Get(channelID, userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil
+		}
+		return model.NewAppError("checkChannelMemberTimeout", "app.channel_member_timeout.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if timeout.IsExpired() {
+		return nil
+	}
+
+	return model.NewAppError("checkChannelMemberTimeout", "api.post.create_post.channel_member_timeout.app_error", map[string]interface{}{"ExpiresAt": timeout.ExpiresAt}, "", http.StatusForbidden)
+}
+
+// publishChannelMemberTimeout informs the user that they are timed out in a channel until
+// expiresAt, or no longer timed out if it is zero.
+func (a *App) publishChannelMemberTimeout(channelID, userID string, expiresAt int64) {
+	message := model.NewWebSocketEvent(model.WebsocketEventChannelMemberTimeoutUpdated, "", "", userID, nil)
+	message.Add("channel_id", channelID)
+	message.Add("expires_at", expiresAt)
+	a.Publish(message)
+}
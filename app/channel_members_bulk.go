@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const bulkChannelMembersDir = "bulk_channel_members"
+
+// CreateBulkChannelMembersJob stores the users of the request in the file store and schedules a
+// job adding them to or removing them from the channel.
+func (a *App) CreateBulkChannelMembersJob(channel *model.Channel, requesterID string, req *model.ChannelMembersBulkRequest) (*model.Job, *model.AppError) {
+	userIDs := model.RemoveDuplicateStrings(req.UserIds)
+
+	usersJSON, jsonErr := json.Marshal(userIDs)
+	if jsonErr != nil {
+		return nil, model.NewAppError("CreateBulkChannelMembersJob", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+
+	usersFile := path.Join(bulkChannelMembersDir, model.NewId()+".json")
+	if _, err := a.WriteFile(bytes.NewReader(usersJSON), usersFile); err != nil {
+		return nil, err
+	}
+
+	job, err := a.Srv().Jobs.CreateJob(model.JobTypeBulkChannelMembers, map[string]string{
+		"channel_id":   channel.Id,
+		"requester_id": requesterID,
+		"action":       req.Action,
+		"users_file":   usersFile,
+		"total":        strconv.Itoa(len(userIDs)),
+	})
+	if err != nil {
+		if rmErr := a.RemoveFile(usersFile); rmErr != nil {
+			a.Log().Warn("Failed to remove bulk channel members file", mlog.String("path", usersFile), mlog.Err(rmErr))
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetBulkChannelMembersReport returns the progress of a bulk channel membership job and the users
+// it failed for.
+func (a *App) GetBulkChannelMembersReport(channelID, jobID string) (*model.ChannelMembersBulkReport, *model.AppError) {
+	job, err := a.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JobTypeBulkChannelMembers || job.Data["channel_id"] != channelID {
+		return nil, model.NewAppError("GetBulkChannelMembersReport", "app.channel.bulk_members.report.not_found.app_error", nil, "job_id="+jobID, http.StatusNotFound)
+	}
+
+	report := &model.ChannelMembersBulkReport{
+		JobId:     job.Id,
+		ChannelId: channelID,
+		Action:    job.Data["action"],
+		Status:    job.Status,
+		Progress:  job.Progress,
+		Failures:  []model.ChannelMembersBulkFailure{},
+	}
+	report.Total, _ = strconv.Atoi(job.Data["total"])
+	report.Processed, _ = strconv.Atoi(job.Data["processed"])
+	report.Succeeded, _ = strconv.Atoi(job.Data["succeeded"])
+
+	if failuresFile := job.Data["failures_file"]; failuresFile != "" {
+		data, err := a.ReadFile(failuresFile)
+		if err != nil {
+			return nil, err
+		}
+		if jsonErr := json.Unmarshal(data, &report.Failures); jsonErr != nil {
+			return nil, model.NewAppError("GetBulkChannelMembersReport", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return report, nil
+}
+
+// ApplyBulkChannelMemberAction adds the user to or removes them from the channel on behalf of the
+// user that requested the bulk operation.
+func (a *App) ApplyBulkChannelMemberAction(c *request.Context, channel *model.Channel, action, userID, requesterID string) *model.AppError {
+	switch action {
+	case model.ChannelMembersBulkActionAdd:
+		if channel.IsGroupConstrained() {
+			nonMembers, err := a.FilterNonGroupChannelMembers([]string{userID}, channel)
+			if err != nil {
+				return model.NewAppError("ApplyBulkChannelMemberAction", "api.channel.add_members.error", nil, err.Error(), http.StatusBadRequest)
+			}
+			if len(nonMembers) > 0 {
+				return model.NewAppError("ApplyBulkChannelMemberAction", "api.channel.add_members.user_denied", map[string]interface{}{"UserIDs": nonMembers}, "", http.StatusBadRequest)
+			}
+		}
+
+		_, err := a.AddChannelMember(c, userID, channel, ChannelMemberOpts{UserRequestorID: requesterID})
+		return err
+	case model.ChannelMembersBulkActionRemove:
+		if channel.IsGroupConstrained() && userID != requesterID {
+			user, err := a.GetUser(userID)
+			if err != nil {
+				return err
+			}
+			if !user.IsBot {
+				return model.NewAppError("ApplyBulkChannelMemberAction", "api.channel.remove_member.group_constrained.app_error", nil, "", http.StatusBadRequest)
+			}
+		}
+
+		return a.RemoveUserFromChannel(c, userID, requesterID, channel)
+	}
+
+	return model.NewAppError("ApplyBulkChannelMemberAction", "model.channel_members_bulk.is_valid.action.app_error", nil, "action="+action, http.StatusBadRequest)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestApplyBulkChannelMemberAction(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+
+	appErr := th.App.ApplyBulkChannelMemberAction(th.Context, channel, model.ChannelMembersBulkActionAdd, user.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+	_, appErr = th.App.GetChannelMember(context.Background(), channel.Id, user.Id)
+	require.Nil(t, appErr)
+
+	appErr = th.App.ApplyBulkChannelMemberAction(th.Context, channel, model.ChannelMembersBulkActionRemove, user.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+	_, appErr = th.App.GetChannelMember(context.Background(), channel.Id, user.Id)
+	require.NotNil(t, appErr)
+
+	outsider := th.CreateUser()
+	appErr = th.App.ApplyBulkChannelMemberAction(th.Context, channel, model.ChannelMembersBulkActionAdd, outsider.Id, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+}
+
+func TestGetBulkChannelMembersReport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	job, appErr := th.App.CreateBulkChannelMembersJob(th.BasicChannel, th.BasicUser.Id, &model.ChannelMembersBulkRequest{
+		Action:  model.ChannelMembersBulkActionAdd,
+		UserIds: []string{th.BasicUser2.Id, th.BasicUser2.Id},
+	})
+	require.Nil(t, appErr)
+
+	usersData, appErr := th.App.ReadFile(job.Data["users_file"])
+	require.Nil(t, appErr)
+	var userIDs []string
+	require.NoError(t, json.Unmarshal(usersData, &userIDs))
+	assert.Equal(t, []string{th.BasicUser2.Id}, userIDs)
+
+	report, appErr := th.App.GetBulkChannelMembersReport(th.BasicChannel.Id, job.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, 1, report.Total)
+	assert.Equal(t, model.JobStatusPending, report.Status)
+	assert.Empty(t, report.Failures)
+
+	t.Run("with failures", func(t *testing.T) {
+		failures := []model.ChannelMembersBulkFailure{{UserId: th.BasicUser2.Id, Error: "failed"}}
+		failuresJSON, err := json.Marshal(failures)
+		require.NoError(t, err)
+		_, appErr := th.App.WriteFile(bytes.NewReader(failuresJSON), "bulk_channel_members/"+job.Id+"_failures.json")
+		require.Nil(t, appErr)
+
+		job.Data["failures_file"] = "bulk_channel_members/" + job.Id + "_failures.json"
+		job.Data["processed"] = "1"
+		_, err = th.App.Srv().Store.Job().UpdateOptimistically(job, model.JobStatusPending)
+		require.NoError(t, err)
+
+		report, appErr := th.App.GetBulkChannelMembersReport(th.BasicChannel.Id, job.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, report.Processed)
+		assert.Equal(t, failures, report.Failures)
+	})
+
+	t.Run("job of another channel", func(t *testing.T) {
+		_, appErr := th.App.GetBulkChannelMembersReport(model.NewId(), job.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
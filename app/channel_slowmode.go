@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// SetChannelSlowmode sets the minimum number of seconds between two posts of a member in a
+// channel, zero disabling slowmode.
+func (a *App) SetChannelSlowmode(channelID string, seconds int) (*model.Channel, *model.AppError) {
+	if seconds < 0 || seconds > model.ChannelSlowmodeMaxSeconds {
+		return nil, model.NewAppError("SetChannelSlowmode", "model.channel.is_valid.slowmode.app_error", map[string]interface{}{"Max": model.ChannelSlowmodeMaxSeconds}, "", http.StatusBadRequest)
+	}
+
+	channel, appErr := a.GetChannel(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channel.SlowmodeSeconds = seconds
+
+	return a.UpdateChannel(channel)
+}
+
+// checkChannelSlowmode returns an error if the user posted in the channel too recently for its
+// slowmode. Members that can manage the roles of the channel members are exempt from slowmode.
+func (a *App) checkChannelSlowmode(channel *model.Channel, userID string) *model.AppError {
+	if channel.SlowmodeSeconds == 0 {
+		return nil
+	}
+
+	if a.HasPermissionToChannel(userID, channel.Id, model.PermissionManageChannelRoles) {
+		return nil
+	}
+
+	interval := int64(channel.SlowmodeSeconds) * 1000
+	now := model.GetMillis()
+	lastPostAt, err := a.Srv().Store.Post().GetLastPostAtByUserSince(model.GetPostsSinceOptions{ChannelId: channel.Id, Time: now - interval}, userID)
+	if err != nil {
+		return model.NewAppError("checkChannelSlowmode", "app.post.get_last_post_at.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if lastPostAt == 0 {
+		return nil
+	}
+
+	retryAfter := (lastPostAt + interval - now + 999) / 1000
+	return model.NewAppError("checkChannelSlowmode", "api.post.create_post.slowmode.app_error", map[string]interface{}{"Seconds": channel.SlowmodeSeconds, "RetryAfter": retryAfter}, "", http.StatusTooManyRequests)
+}
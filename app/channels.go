@@ -60,6 +60,12 @@ type Channels struct {
 	pluginsEnvironment     *plugin.Environment
 	pluginConfigListenerID string
 
+	pluginScheduledTasksLock sync.RWMutex
+	pluginScheduledTasks     []*model.PluginScheduledTask
+
+	usageReportersLock sync.RWMutex
+	usageReporters     map[string]func() (map[string]int64, error)
+
 	imageProxy *imageproxy.ImageProxy
 
 	asymmetricSigningKey atomic.Value
@@ -81,6 +87,8 @@ type Channels struct {
 	Saml             einterfaces.SamlInterface
 	Notification     einterfaces.NotificationInterface
 	Ldap             einterfaces.LdapInterface
+	Transcription    einterfaces.TranscriptionInterface
+	ImageCaption     einterfaces.ImageCaptionInterface
 
 	// These are used to prevent concurrent upload requests
 	// for a given upload session which could cause inconsistencies
@@ -114,6 +122,9 @@ func NewChannels(s *Server, services map[ServiceKey]interface{}) (*Channels, err
 		imageProxy:    imageproxy.MakeImageProxy(s, s.httpService, s.Log),
 		uploadLockMap: map[string]bool{},
 	}
+	ch.usageReporters = map[string]func() (map[string]int64, error){
+		"channels": ch.getChannelsUsage,
+	}
 
 	// To get another service:
 	// 1. Prepare the service interface
@@ -182,6 +193,12 @@ func NewChannels(s *Server, services map[ServiceKey]interface{}) (*Channels, err
 	if notificationInterface != nil {
 		ch.Notification = notificationInterface(New(ServerConnector(ch)))
 	}
+	if transcriptionInterface != nil {
+		ch.Transcription = transcriptionInterface(New(ServerConnector(ch)))
+	}
+	if imageCaptionInterface != nil {
+		ch.ImageCaption = imageCaptionInterface(New(ServerConnector(ch)))
+	}
 	if samlInterfaceNew != nil {
 		ch.Saml = samlInterfaceNew(New(ServerConnector(ch)))
 		if err := ch.Saml.ConfigureSP(); err != nil {
@@ -223,6 +240,10 @@ func NewChannels(s *Server, services map[ServiceKey]interface{}) (*Channels, err
 		app: &App{ch: ch},
 	}
 
+	services[UsageKey] = &usageServiceWrapper{
+		ch: ch,
+	}
+
 	return ch, nil
 }
 
@@ -4,14 +4,20 @@
 package app
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
+	"path"
+	"path/filepath"
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+// invoicePDFCacheDir is where invoice PDFs fetched from the billing backend are kept in the filestore.
+const invoicePDFCacheDir = "cloud/invoices"
+
 func (a *App) getSysAdminsEmailRecipients() ([]*model.User, *model.AppError) {
 	userOptions := &model.UserGetOptions{
 		Page:     0,
@@ -167,3 +173,59 @@ func (a *App) SendCloudTrialEndedEmail() *model.AppError {
 
 	return nil
 }
+
+// GetInvoicesForSubscription returns the invoices of the workspace subscription matching the given options.
+func (a *App) GetInvoicesForSubscription(userID string, opts *model.InvoiceListOptions) ([]*model.Invoice, *model.AppError) {
+	invoices, err := a.Cloud().GetInvoicesForSubscription(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetInvoicesForSubscription", "api.cloud.request_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return model.FilterInvoices(invoices, opts), nil
+}
+
+// GetSubscriptionInvoicePDF returns the PDF of an invoice and its file name. The PDF of a final
+// invoice can't change anymore, so it is cached in the filestore and later downloads don't reach the
+// billing backend.
+func (a *App) GetSubscriptionInvoicePDF(userID, invoiceID string) ([]byte, string, *model.AppError) {
+	cacheDir := path.Join(invoicePDFCacheDir, invoiceID)
+
+	if cached, appErr := a.ListDirectory(cacheDir); appErr == nil && len(cached) > 0 {
+		pdfData, appErr := a.ReadFile(cached[0])
+		if appErr == nil {
+			return pdfData, filepath.Base(cached[0]), nil
+		}
+		a.Log().Warn("Unable to read cached invoice PDF", mlog.String("invoice_id", invoiceID), mlog.Err(appErr))
+	}
+
+	pdfData, filename, err := a.Cloud().GetInvoicePDF(userID, invoiceID)
+	if err != nil {
+		return nil, "", model.NewAppError("GetSubscriptionInvoicePDF", "api.cloud.request_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !a.isInvoiceFinal(userID, invoiceID) {
+		return pdfData, filename, nil
+	}
+
+	if _, appErr := a.WriteFile(bytes.NewReader(pdfData), path.Join(cacheDir, filepath.Base(filename))); appErr != nil {
+		a.Log().Warn("Unable to cache invoice PDF", mlog.String("invoice_id", invoiceID), mlog.Err(appErr))
+	}
+
+	return pdfData, filename, nil
+}
+
+// isInvoiceFinal returns true if the invoice of the subscription is known to be final.
+func (a *App) isInvoiceFinal(userID, invoiceID string) bool {
+	invoices, err := a.Cloud().GetInvoicesForSubscription(userID)
+	if err != nil {
+		a.Log().Warn("Unable to get the status of an invoice", mlog.String("invoice_id", invoiceID), mlog.Err(err))
+		return false
+	}
+
+	for _, invoice := range invoices {
+		if invoice.ID == invoiceID {
+			return invoice.IsFinal()
+		}
+	}
+	return false
+}
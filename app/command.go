@@ -11,8 +11,10 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/mattermost/mattermost-server/v6/app/request"
@@ -462,6 +464,19 @@ func (a *App) tryExecuteCustomCommand(args *model.CommandArgs, trigger string, m
 
 	p.Set("trigger_id", args.TriggerId)
 
+	hook, appErr := a.CreateCommandWebhook(cmd.Id, args)
+	if appErr != nil {
+		return cmd, nil, model.NewAppError("command", "api.command.execute_command.failed.app_error", map[string]interface{}{"Trigger": trigger}, appErr.Error(), http.StatusInternalServerError)
+	}
+	p.Set("response_url", args.SiteURL+"/hooks/commands/"+hook.Id)
+
+	if cmd.PayloadFormat == model.PayloadFormatSlack {
+		// Slack sends the id of the app owning the command, the command itself being the app here.
+		// The mentions resolved below have no Slack equivalent.
+		p.Set("api_app_id", cmd.Id)
+		return a.DoCommandRequest(cmd, p)
+	}
+
 	userMentionMap := a.MentionsToTeamMembers(message, team.Id)
 	for key, values := range userMentionMap.ToURLValues() {
 		p[key] = values
@@ -472,20 +487,20 @@ func (a *App) tryExecuteCustomCommand(args *model.CommandArgs, trigger string, m
 		p[key] = values
 	}
 
-	hook, appErr := a.CreateCommandWebhook(cmd.Id, args)
-	if appErr != nil {
-		return cmd, nil, model.NewAppError("command", "api.command.execute_command.failed.app_error", map[string]interface{}{"Trigger": trigger}, appErr.Error(), http.StatusInternalServerError)
-	}
-	p.Set("response_url", args.SiteURL+"/hooks/commands/"+hook.Id)
-
 	return a.DoCommandRequest(cmd, p)
 }
 
 func (a *App) DoCommandRequest(cmd *model.Command, p url.Values) (*model.Command, *model.CommandResponse, *model.AppError) {
+	slackFormat := cmd.PayloadFormat == model.PayloadFormatSlack
+	method := cmd.Method
+	if slackFormat {
+		method = model.CommandMethodPost
+	}
+
 	// Prepare the request
 	var req *http.Request
 	var err error
-	if cmd.Method == model.CommandMethodGet {
+	if method == model.CommandMethodGet {
 		req, err = http.NewRequest(http.MethodGet, cmd.URL, nil)
 	} else {
 		req, err = http.NewRequest(http.MethodPost, cmd.URL, strings.NewReader(p.Encode()))
@@ -495,7 +510,7 @@ func (a *App) DoCommandRequest(cmd *model.Command, p url.Values) (*model.Command
 		return cmd, nil, model.NewAppError("command", "api.command.execute_command.failed.app_error", map[string]interface{}{"Trigger": cmd.Trigger}, err.Error(), http.StatusInternalServerError)
 	}
 
-	if cmd.Method == model.CommandMethodGet {
+	if method == model.CommandMethodGet {
 		if req.URL.RawQuery != "" {
 			req.URL.RawQuery += "&"
 		}
@@ -504,9 +519,28 @@ func (a *App) DoCommandRequest(cmd *model.Command, p url.Values) (*model.Command
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", "Token "+cmd.Token)
-	if cmd.Method == model.CommandMethodPost {
+	if method == model.CommandMethodPost {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
+	if slackFormat {
+		// Slack integrations verify requests with their signing secret, which is the token here.
+		timestamp := time.Now().Unix()
+		req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(timestamp, 10))
+		req.Header.Set("X-Slack-Signature", model.SlackRequestSignature(cmd.Token, timestamp, []byte(p.Encode())))
+	}
+	if cmd.SigningSecret != "" {
+		payload := p.Encode()
+		if method == model.CommandMethodGet {
+			payload = req.URL.RawQuery
+		}
+		signIntegrationRequest(req, cmd.SigningSecret, []byte(payload))
+	}
+	if cmd.OutgoingOAuthConnectionId != "" {
+		// The command token is still sent with the other parameters.
+		if appErr := a.authorizeOutgoingRequest(req, cmd.OutgoingOAuthConnectionId); appErr != nil {
+			return cmd, nil, model.NewAppError("command", "api.command.execute_command.failed.app_error", map[string]interface{}{"Trigger": cmd.Trigger}, appErr.Error(), appErr.StatusCode)
+		}
+	}
 
 	// Send the request
 	resp, err := a.HTTPService().MakeClient(false).Do(req)
@@ -533,6 +567,10 @@ func (a *App) DoCommandRequest(cmd *model.Command, p url.Values) (*model.Command
 		return cmd, nil, model.NewAppError("command", "api.command.execute_command.failed_empty.app_error", map[string]interface{}{"Trigger": cmd.Trigger}, "", http.StatusInternalServerError)
 	}
 
+	if slackFormat {
+		response.TranslateSlackFormat()
+	}
+
 	return cmd, response, nil
 }
 
@@ -638,6 +676,14 @@ func (a *App) CreateCommand(cmd *model.Command) (*model.Command, *model.AppError
 
 func (a *App) createCommand(cmd *model.Command) (*model.Command, *model.AppError) {
 	cmd.Trigger = strings.ToLower(cmd.Trigger)
+	// The signing secret is always generated by the server.
+	cmd.SigningSecret = ""
+
+	if cmd.OutgoingOAuthConnectionId != "" {
+		if appErr := a.CheckOutgoingOAuthConnectionURLs(cmd.OutgoingOAuthConnectionId, []string{cmd.URL}); appErr != nil {
+			return nil, appErr
+		}
+	}
 
 	teamCmds, err := a.Srv().Store.Command().GetByTeam(cmd.TeamId)
 	if err != nil {
@@ -697,6 +743,7 @@ func (a *App) UpdateCommand(oldCmd, updatedCmd *model.Command) (*model.Command,
 	updatedCmd.Trigger = strings.ToLower(updatedCmd.Trigger)
 	updatedCmd.Id = oldCmd.Id
 	updatedCmd.Token = oldCmd.Token
+	updatedCmd.SigningSecret = oldCmd.SigningSecret
 	updatedCmd.CreateAt = oldCmd.CreateAt
 	updatedCmd.UpdateAt = model.GetMillis()
 	updatedCmd.DeleteAt = oldCmd.DeleteAt
@@ -704,6 +751,12 @@ func (a *App) UpdateCommand(oldCmd, updatedCmd *model.Command) (*model.Command,
 	updatedCmd.PluginId = oldCmd.PluginId
 	updatedCmd.TeamId = oldCmd.TeamId
 
+	if updatedCmd.OutgoingOAuthConnectionId != "" {
+		if appErr := a.CheckOutgoingOAuthConnectionURLs(updatedCmd.OutgoingOAuthConnectionId, []string{updatedCmd.URL}); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	command, err := a.Srv().Store.Command().Update(updatedCmd)
 	if err != nil {
 		var nfErr *store.ErrNotFound
@@ -765,6 +818,32 @@ func (a *App) RegenCommandToken(cmd *model.Command) (*model.Command, *model.AppE
 	return command, nil
 }
 
+// RegenCommandSigningSecret generates a new signing secret for the command, which also signs
+// the requests of the commands created before signing secrets were introduced.
+func (a *App) RegenCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCommands {
+		return nil, model.NewAppError("RegenCommandSigningSecret", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	cmd.SigningSecret = model.NewId()
+
+	command, err := a.Srv().Store.Command().Update(cmd)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("SqlCommandStore.Update", "store.sql_command.update.missing.app_error", map[string]interface{}{"command_id": cmd.Id}, "", http.StatusNotFound)
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("RegenCommandSigningSecret", "app.command.regencommandtoken.internal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return command, nil
+}
+
 func (a *App) DeleteCommand(commandID string) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableCommands {
 		return model.NewAppError("DeleteCommand", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -4,9 +4,17 @@
 package app
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
 )
 
 func TestPossibleAtMentions(t *testing.T) {
@@ -82,3 +90,36 @@ func TestTrimUsernameSpecialChar(t *testing.T) {
 		}
 	}
 }
+
+func TestDoCommandRequestSignature(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCommands = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "127.0.0.1"
+	})
+
+	var signed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := []byte(r.URL.RawQuery)
+		if r.Method == http.MethodPost {
+			var err error
+			payload, err = ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+		}
+		signed = model.VerifyIntegrationRequestSignature("secret", r.Header.Get(model.HeaderIntegrationRequestTimestamp), r.Header.Get(model.HeaderIntegrationSignature), payload, time.Now())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"text": "ok"}`))
+	}))
+	defer server.Close()
+
+	for _, method := range []string{model.CommandMethodPost, model.CommandMethodGet} {
+		signed = false
+		cmd := &model.Command{Trigger: "signed", URL: server.URL + "/command?static=1", Method: method, Token: model.NewId(), SigningSecret: "secret"}
+		_, _, appErr := th.App.DoCommandRequest(cmd, url.Values{"text": []string{"hello"}})
+		require.Nil(t, appErr)
+		assert.True(t, signed, "method %s", method)
+	}
+}
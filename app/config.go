@@ -68,8 +68,11 @@ func (w *configWrapper) UpdateConfig(f func(*model.Config)) {
 
 func (w *configWrapper) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError) {
 	oldCfg, newCfg, err := w.Store.Set(newCfg)
+	var lockedErr *config.LockedPathError
 	if errors.Cause(err) == config.ErrReadOnlyConfiguration {
 		return nil, nil, model.NewAppError("saveConfig", "ent.cluster.save_config.error", nil, err.Error(), http.StatusForbidden)
+	} else if errors.As(err, &lockedErr) {
+		return nil, nil, model.NewAppError("saveConfig", "app.config.locked_path.app_error", map[string]interface{}{"Name": lockedErr.Path}, "", http.StatusForbidden)
 	} else if err != nil {
 		return nil, nil, model.NewAppError("saveConfig", "app.save_config.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -467,6 +470,19 @@ func (a *App) GetEnvironmentConfig(filter func(reflect.StructField) bool) map[st
 	return a.EnvironmentConfig(filter)
 }
 
+// GetConfigOrigins returns a map of configuration keys to the origin of their value: an
+// environment variable, the backing store or the defaults.
+// If filter is not nil and returns false for a struct field, that field will be omitted.
+func (a *App) GetConfigOrigins(filter func(reflect.StructField) bool) map[string]interface{} {
+	return a.Srv().configStore.GetOrigins(filter)
+}
+
+// GetLockedConfigPaths returns the configuration paths locked by the deployment, which can't be
+// changed through the API.
+func (a *App) GetLockedConfigPaths() []string {
+	return a.Srv().configStore.GetLockedPaths()
+}
+
 // SaveConfig replaces the active configuration, optionally notifying cluster peers.
 // It returns both the previous and current configs.
 func (s *Server) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError) {
@@ -478,6 +494,45 @@ func (a *App) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bo
 	return a.Srv().SaveConfig(newCfg, sendConfigChangeClusterMessage)
 }
 
+// PatchFeatureFlagOverrides sets the given feature flag overrides, removing those with a nil
+// value, and persists them in the config store. It returns the overrides before and after
+// the change.
+func (a *App) PatchFeatureFlagOverrides(patch map[string]*string) (map[string]string, map[string]string, *model.AppError) {
+	cfg := a.Config().Clone()
+
+	oldOverrides := make(map[string]string, len(cfg.FeatureFlagOverrides))
+	for name, value := range cfg.FeatureFlagOverrides {
+		oldOverrides[name] = value
+	}
+
+	if cfg.FeatureFlagOverrides == nil {
+		cfg.FeatureFlagOverrides = make(map[string]string)
+	}
+	for name, value := range patch {
+		if value == nil {
+			delete(cfg.FeatureFlagOverrides, name)
+			continue
+		}
+		cfg.FeatureFlagOverrides[name] = *value
+	}
+
+	if appErr := cfg.IsValid(); appErr != nil {
+		return nil, nil, appErr
+	}
+
+	_, newCfg, appErr := a.SaveConfig(cfg, true)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	newOverrides := newCfg.FeatureFlagOverrides
+	if newOverrides == nil {
+		newOverrides = map[string]string{}
+	}
+
+	return oldOverrides, newOverrides, nil
+}
+
 func (a *App) HandleMessageExportConfig(cfg *model.Config, appCfg *model.Config) {
 	// If the Message Export feature has been toggled in the System Console, rewrite the ExportFromTimestamp field to an
 	// appropriate value. The rewriting occurs here to ensure it doesn't affect values written to the config file
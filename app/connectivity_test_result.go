@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// RecordConnectivityTest keeps the outcome of a connection test against an external service so
+// admins can look back at it later. Failing to store the result is only logged.
+func (a *App) RecordConnectivityTest(service, userID string, latency time.Duration, testErr *model.AppError) {
+	result := &model.ConnectivityTestResult{
+		Service:   service,
+		UserId:    userID,
+		LatencyMs: latency.Milliseconds(),
+		Success:   testErr == nil,
+	}
+	if testErr != nil {
+		// Translate a copy since the error is still returned to the client in its own locale.
+		translated := *testErr
+		translated.Translate(i18n.T)
+		result.Error = translated.Error()
+	}
+
+	if _, err := a.Srv().Store.ConnectivityTestResult().Save(result); err != nil {
+		mlog.Warn("Failed to save connectivity test result", mlog.String("service", service), mlog.Err(err))
+	}
+}
+
+func (a *App) GetConnectivityTestHistory(service string, page, perPage int) ([]*model.ConnectivityTestResult, *model.AppError) {
+	results, err := a.Srv().Store.ConnectivityTestResult().GetHistory(service, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetConnectivityTestHistory", "app.connectivity_test_result.get_history.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return results, nil
+}
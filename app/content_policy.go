@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// contentPolicyRegexps caches the compiled expressions of the content policy rules, keyed by
+// expression, since rules are only compiled to be validated when the config is loaded.
+var contentPolicyRegexps sync.Map
+
+func compileContentPolicyRule(rule *model.ContentPolicyRule) (*regexp.Regexp, error) {
+	expression := rule.Expression()
+	if re, ok := contentPolicyRegexps.Load(expression); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	contentPolicyRegexps.Store(expression, re)
+
+	return re, nil
+}
+
+// isExemptFromContentPolicy returns true if the posts of the user in the channel are not
+// checked against the content policy. Users are exempted by their system or channel roles.
+func (a *App) isExemptFromContentPolicy(user *model.User, channel *model.Channel) bool {
+	settings := a.Config().ContentPolicySettings
+
+	for _, channelID := range settings.ExemptChannelIds {
+		if channelID == channel.Id {
+			return true
+		}
+	}
+
+	if len(settings.ExemptRoles) == 0 {
+		return false
+	}
+
+	roles := user.GetRoles()
+	if member, appErr := a.GetChannelMember(context.Background(), channel.Id, user.Id); appErr == nil {
+		roles = append(roles, member.GetRoles()...)
+	}
+
+	for _, role := range roles {
+		for _, exemptRole := range settings.ExemptRoles {
+			if role == exemptRole {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// checkContentPolicy checks the message of a post against the rules of the content policy
+// which apply to its channel. It returns an error if a rule blocks the post, and otherwise the
+// ids of the rules which flagged it, if any.
+func (a *App) checkContentPolicy(c *request.Context, post *model.Post, user *model.User, channel *model.Channel) ([]string, *model.AppError) {
+	settings := a.Config().ContentPolicySettings
+	if !*settings.Enable || len(settings.Rules) == 0 {
+		return nil, nil
+	}
+
+	if post.IsSystemMessage() || post.Message == "" || a.isExemptFromContentPolicy(user, channel) {
+		return nil, nil
+	}
+
+	var flagged []string
+	for _, rule := range settings.Rules {
+		if !rule.AppliesToTeam(channel.TeamId) {
+			continue
+		}
+
+		re, err := compileContentPolicyRule(rule)
+		if err != nil {
+			mlog.Warn("Skipping invalid content policy rule", mlog.String("rule_id", rule.Id), mlog.Err(err))
+			continue
+		}
+
+		if !re.MatchString(post.Message) {
+			continue
+		}
+
+		if rule.Action == model.ContentPolicyActionBlock {
+			a.auditContentPolicyBlock(c, post, channel, rule)
+			return nil, model.NewAppError("checkContentPolicy", "app.content_policy.blocked.app_error", map[string]interface{}{"Description": rule.Description}, "rule_id="+rule.Id, http.StatusBadRequest)
+		}
+
+		flagged = append(flagged, rule.Id)
+	}
+
+	return flagged, nil
+}
+
+func (a *App) auditContentPolicyBlock(c *request.Context, post *model.Post, channel *model.Channel, rule *model.ContentPolicyRule) {
+	auditRec := a.MakeAuditRecord("contentPolicyBlockPost", audit.Success)
+	auditRec.UserID = post.UserId
+	auditRec.SessionID = c.Session().Id
+	auditRec.IPAddress = c.IPAddress()
+	auditRec.APIPath = c.Path()
+	auditRec.AddMeta("rule_id", rule.Id)
+	auditRec.AddMeta("channel_id", channel.Id)
+	auditRec.AddMeta("team_id", channel.TeamId)
+	a.LogAuditRec(auditRec, nil)
+}
+
+// reportContentPolicyFlags reports a post flagged by the content policy to the moderators, on
+// behalf of the system bot.
+func (a *App) reportContentPolicyFlags(c *request.Context, post *model.Post, ruleIDs []string) {
+	bot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		mlog.Warn("Failed to get the system bot to report a flagged post", mlog.String("post_id", post.Id), mlog.Err(appErr))
+		return
+	}
+
+	comment := strings.Join(ruleIDs, ", ")
+	if _, appErr := a.ReportPost(c, post, bot.UserId, model.PostReportReasonContentPolicy, comment); appErr != nil {
+		// Edits of a post which was already flagged are not reported again.
+		if appErr.Id == "app.post_report.already_reported.app_error" {
+			return
+		}
+		mlog.Warn("Failed to report a post flagged by the content policy", mlog.String("post_id", post.Id), mlog.Err(appErr))
+	}
+}
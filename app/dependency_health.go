@@ -0,0 +1,325 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mail"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// readinessCacheDuration is how long the readiness of the server is reused, so that frequent
+// probes don't overload its dependencies.
+const readinessCacheDuration = 5 * time.Second
+
+// dependencyCheck tests the reachability of a dependency of the server. A dependency is only
+// checked if enabled is nil or returns true.
+type dependencyCheck struct {
+	name     string
+	critical bool
+	enabled  func() bool
+	check    func() error
+}
+
+// DependencyHealth checks the reachability of the database, file store, SMTP server and search
+// engine when the server starts, as configured by ServiceSettings.StartupDependencyMode. When a
+// non-critical dependency is unreachable, the server can be started in a read-only degraded
+// mode, which is left once the dependency is reachable again, rather than failing to start.
+// The dependencies are also checked to report the readiness of the server to orchestrators.
+type DependencyHealth struct {
+	degraded int32 // protected via atomic for fast IsDegraded calls
+
+	configFn func() *model.Config
+	checks   []*dependencyCheck
+
+	mut    sync.RWMutex
+	status *model.DependenciesStatus
+
+	readinessMut sync.Mutex
+	readiness    *model.ReadinessStatus
+	readinessAt  time.Time
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewDependencyHealth creates a new DependencyHealth. CheckAtStartup must be called for the
+// dependencies to be checked.
+func NewDependencyHealth(configFn func() *model.Config, checks []*dependencyCheck) *DependencyHealth {
+	return &DependencyHealth{
+		configFn: configFn,
+		checks:   checks,
+		status: &model.DependenciesStatus{
+			Mode:         *configFn().ServiceSettings.StartupDependencyMode,
+			Dependencies: []*model.DependencyStatus{},
+		},
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// CheckAtStartup checks the dependencies and returns an error if the server should not start.
+// In degraded mode, the unreachable dependencies are checked again until they are all reachable.
+func (h *DependencyHealth) CheckAtStartup() error {
+	mode := *h.configFn().ServiceSettings.StartupDependencyMode
+	if mode == model.StartupDependencyModeDisabled {
+		close(h.stopped)
+		return nil
+	}
+
+	statuses := h.runChecks()
+	h.setStatus(mode, statuses)
+
+	var degraded bool
+	for _, status := range statuses {
+		if status.Status != model.StatusUnhealthy {
+			continue
+		}
+		if mode == model.StartupDependencyModeStrict || status.Critical {
+			close(h.stopped)
+			return errors.Errorf("dependency %s is unreachable", status.Name)
+		}
+		degraded = true
+	}
+
+	if !degraded {
+		close(h.stopped)
+		return nil
+	}
+
+	mlog.Warn("Some dependencies are unreachable, starting in read-only degraded mode")
+	h.setDegraded(true)
+	go h.recheck()
+
+	return nil
+}
+
+// Stop stops checking the dependencies of a server in degraded mode.
+func (h *DependencyHealth) Stop() {
+	if h == nil {
+		return
+	}
+
+	h.stopOnce.Do(func() {
+		close(h.stop)
+		<-h.stopped
+	})
+}
+
+// IsDegraded returns true if the server runs in degraded mode.
+func (h *DependencyHealth) IsDegraded() bool {
+	if h == nil {
+		return false
+	}
+	return atomic.LoadInt32(&h.degraded) != 0
+}
+
+// Status returns the result of the last check of the dependencies.
+func (h *DependencyHealth) Status() *model.DependenciesStatus {
+	if h == nil {
+		return &model.DependenciesStatus{Mode: model.StartupDependencyModeDisabled, Dependencies: []*model.DependencyStatus{}}
+	}
+
+	h.mut.RLock()
+	defer h.mut.RUnlock()
+
+	status := *h.status
+	status.Dependencies = make([]*model.DependencyStatus, 0, len(h.status.Dependencies))
+	for _, dependency := range h.status.Dependencies {
+		dependencyCopy := *dependency
+		status.Dependencies = append(status.Dependencies, &dependencyCopy)
+	}
+	return &status
+}
+
+// CheckReadiness checks the dependencies, whatever ServiceSettings.StartupDependencyMode is, and
+// reports the server as ready if those required by ServiceSettings.ReadinessRequiredDependencies
+// are reachable. A server which hasn't started is never ready.
+func (h *DependencyHealth) CheckReadiness() *model.ReadinessStatus {
+	if h == nil {
+		return &model.ReadinessStatus{Status: model.StatusUnhealthy, Dependencies: []*model.DependencyStatus{}}
+	}
+
+	h.readinessMut.Lock()
+	defer h.readinessMut.Unlock()
+
+	if h.readiness != nil && time.Since(h.readinessAt) < readinessCacheDuration {
+		return h.readiness
+	}
+
+	required := make(map[string]bool)
+	for _, dependency := range h.configFn().ServiceSettings.GetReadinessRequiredDependencies() {
+		required[dependency] = true
+	}
+
+	readiness := &model.ReadinessStatus{
+		Status:       model.StatusOk,
+		Dependencies: h.runChecks(),
+	}
+	for _, status := range readiness.Dependencies {
+		status.Required = required[status.Name]
+		if status.Required && status.Status == model.StatusUnhealthy {
+			readiness.Status = model.StatusUnhealthy
+		}
+	}
+
+	h.readiness = readiness
+	h.readinessAt = time.Now()
+
+	return readiness
+}
+
+func (h *DependencyHealth) recheck() {
+	defer close(h.stopped)
+
+	for {
+		select {
+		case <-time.After(time.Duration(*h.configFn().ServiceSettings.DegradedModeRecheckSeconds) * time.Second):
+		case <-h.stop:
+			return
+		}
+
+		statuses := h.runChecks()
+		h.setStatus(model.StartupDependencyModeDegraded, statuses)
+
+		recovered := true
+		for _, status := range statuses {
+			if status.Status == model.StatusUnhealthy {
+				recovered = false
+				break
+			}
+		}
+
+		if recovered {
+			mlog.Info("All dependencies are reachable, leaving degraded mode")
+			h.setDegraded(false)
+			return
+		}
+	}
+}
+
+// runChecks checks the enabled dependencies concurrently. A dependency which can't be checked
+// within ServiceSettings.StartupDependencyTimeoutSeconds is unhealthy.
+func (h *DependencyHealth) runChecks() []*model.DependencyStatus {
+	timeout := time.After(time.Duration(*h.configFn().ServiceSettings.StartupDependencyTimeoutSeconds) * time.Second)
+
+	statuses := make([]*model.DependencyStatus, len(h.checks))
+	results := make([]chan error, len(h.checks))
+	for i, check := range h.checks {
+		statuses[i] = &model.DependencyStatus{
+			Name:     check.name,
+			Critical: check.critical,
+			Status:   model.DependencyStatusDisabled,
+		}
+		if check.enabled != nil && !check.enabled() {
+			continue
+		}
+
+		results[i] = make(chan error, 1)
+		go func(check *dependencyCheck, result chan<- error) {
+			result <- check.check()
+		}(check, results[i])
+	}
+
+	timedOut := false
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+
+		var err error
+		if timedOut {
+			select {
+			case err = <-result:
+			default:
+				err = errors.New("timed out")
+			}
+		} else {
+			select {
+			case err = <-result:
+			case <-timeout:
+				timedOut = true
+				err = errors.New("timed out")
+			}
+		}
+
+		statuses[i].CheckedAt = model.GetMillis()
+		statuses[i].Status = model.StatusOk
+		if err != nil {
+			mlog.Error("Dependency is unreachable", mlog.String("dependency", statuses[i].Name), mlog.Err(err))
+			statuses[i].Status = model.StatusUnhealthy
+		}
+	}
+
+	return statuses
+}
+
+func (h *DependencyHealth) setStatus(mode string, statuses []*model.DependencyStatus) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	h.status.Mode = mode
+	h.status.Dependencies = statuses
+}
+
+func (h *DependencyHealth) setDegraded(degraded bool) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	h.status.DegradedMode = degraded
+	h.status.DegradedAt = 0
+	if degraded {
+		h.status.DegradedAt = model.GetMillis()
+	}
+	atomic.StoreInt32(&h.degraded, boolToInt32(degraded))
+}
+
+// dependencyChecks returns the checks of the dependencies of the server. Only the database is
+// critical, the server being unusable without it.
+func (s *Server) dependencyChecks() []*dependencyCheck {
+	return []*dependencyCheck{
+		{
+			name:     model.DependencyDatabase,
+			critical: true,
+			check: func() error {
+				_, err := s.Store.GetDbVersion(false)
+				return err
+			},
+		},
+		{
+			name: model.DependencyFileStore,
+			check: func() error {
+				return s.FileBackend().TestConnection()
+			},
+		},
+		{
+			name: model.DependencySMTP,
+			enabled: func() bool {
+				return s.MailServiceConfig().SendEmailNotifications
+			},
+			check: func() error {
+				return mail.TestConnection(s.MailServiceConfig())
+			},
+		},
+		{
+			name: model.DependencySearch,
+			enabled: func() bool {
+				return s.SearchEngine != nil && s.SearchEngine.ElasticsearchEngine != nil && *s.Config().ElasticsearchSettings.EnableIndexing
+			},
+			check: func() error {
+				if appErr := s.SearchEngine.ElasticsearchEngine.TestConfig(s.Config()); appErr != nil {
+					return appErr
+				}
+				return nil
+			},
+		},
+	}
+}
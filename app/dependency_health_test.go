@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func newTestDependencyHealth(mode string, checks ...*dependencyCheck) *DependencyHealth {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.ServiceSettings.StartupDependencyMode = mode
+	*cfg.ServiceSettings.StartupDependencyTimeoutSeconds = 1
+	*cfg.ServiceSettings.DegradedModeRecheckSeconds = 1
+
+	return NewDependencyHealth(func() *model.Config { return cfg }, checks)
+}
+
+func newTestDependencyCheck(name string, critical bool, failing *int32) *dependencyCheck {
+	return &dependencyCheck{
+		name:     name,
+		critical: critical,
+		check: func() error {
+			if atomic.LoadInt32(failing) != 0 {
+				return errors.New("unreachable")
+			}
+			return nil
+		},
+	}
+}
+
+func TestDependencyHealthCheckAtStartup(t *testing.T) {
+	failing := int32(1)
+	healthy := int32(0)
+
+	t.Run("disabled", func(t *testing.T) {
+		health := newTestDependencyHealth(model.StartupDependencyModeDisabled, newTestDependencyCheck(model.DependencyDatabase, true, &failing))
+		defer health.Stop()
+
+		require.NoError(t, health.CheckAtStartup())
+		require.False(t, health.IsDegraded())
+		require.Empty(t, health.Status().Dependencies, "dependencies are not checked")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		health := newTestDependencyHealth(model.StartupDependencyModeStrict,
+			newTestDependencyCheck(model.DependencyDatabase, true, &healthy),
+			newTestDependencyCheck(model.DependencySMTP, false, &failing),
+		)
+		defer health.Stop()
+
+		require.Error(t, health.CheckAtStartup())
+		require.False(t, health.IsDegraded())
+	})
+
+	t.Run("degraded with a critical dependency unreachable", func(t *testing.T) {
+		health := newTestDependencyHealth(model.StartupDependencyModeDegraded,
+			newTestDependencyCheck(model.DependencyDatabase, true, &failing),
+			newTestDependencyCheck(model.DependencySMTP, false, &healthy),
+		)
+		defer health.Stop()
+
+		require.Error(t, health.CheckAtStartup())
+		require.False(t, health.IsDegraded())
+	})
+
+	t.Run("degraded with all dependencies reachable", func(t *testing.T) {
+		health := newTestDependencyHealth(model.StartupDependencyModeDegraded,
+			newTestDependencyCheck(model.DependencyDatabase, true, &healthy),
+			newTestDependencyCheck(model.DependencySMTP, false, &healthy),
+		)
+		defer health.Stop()
+
+		require.NoError(t, health.CheckAtStartup())
+		require.False(t, health.IsDegraded())
+
+		status := health.Status()
+		require.Len(t, status.Dependencies, 2)
+		for _, dependency := range status.Dependencies {
+			require.Equal(t, model.StatusOk, dependency.Status)
+		}
+	})
+
+	t.Run("disabled dependencies are not checked", func(t *testing.T) {
+		check := newTestDependencyCheck(model.DependencySearch, false, &failing)
+		check.enabled = func() bool { return false }
+		health := newTestDependencyHealth(model.StartupDependencyModeStrict, check)
+		defer health.Stop()
+
+		require.NoError(t, health.CheckAtStartup())
+		require.Equal(t, model.DependencyStatusDisabled, health.Status().Dependencies[0].Status)
+	})
+
+	t.Run("dependencies which can't be checked in time are unreachable", func(t *testing.T) {
+		blocked := make(chan struct{})
+		defer close(blocked)
+		health := newTestDependencyHealth(model.StartupDependencyModeStrict, &dependencyCheck{
+			name: model.DependencyFileStore,
+			check: func() error {
+				<-blocked
+				return nil
+			},
+		})
+		defer health.Stop()
+
+		require.Error(t, health.CheckAtStartup())
+		require.Equal(t, model.StatusUnhealthy, health.Status().Dependencies[0].Status)
+	})
+}
+
+func TestDependencyHealthDegradedMode(t *testing.T) {
+	healthy := int32(0)
+	failing := int32(1)
+	health := newTestDependencyHealth(model.StartupDependencyModeDegraded,
+		newTestDependencyCheck(model.DependencyDatabase, true, &healthy),
+		newTestDependencyCheck(model.DependencyFileStore, false, &failing),
+	)
+	defer health.Stop()
+
+	require.NoError(t, health.CheckAtStartup())
+	require.True(t, health.IsDegraded())
+
+	status := health.Status()
+	require.True(t, status.DegradedMode)
+	require.NotZero(t, status.DegradedAt)
+	require.Equal(t, model.StatusOk, status.Dependencies[0].Status)
+	require.Equal(t, model.StatusUnhealthy, status.Dependencies[1].Status)
+
+	atomic.StoreInt32(&failing, 0)
+	require.Eventually(t, func() bool {
+		return !health.IsDegraded()
+	}, 5*time.Second, 100*time.Millisecond, "degraded mode is left once the dependencies are reachable")
+
+	status = health.Status()
+	require.False(t, status.DegradedMode)
+	require.Equal(t, model.StatusOk, status.Dependencies[1].Status)
+
+	var nilHealth *DependencyHealth
+	require.False(t, nilHealth.IsDegraded())
+	require.Equal(t, model.StartupDependencyModeDisabled, nilHealth.Status().Mode)
+}
+
+func TestDependencyHealthCheckReadiness(t *testing.T) {
+	healthy := int32(0)
+	failing := int32(1)
+	health := newTestDependencyHealth(model.StartupDependencyModeDisabled,
+		newTestDependencyCheck(model.DependencyDatabase, true, &healthy),
+		newTestDependencyCheck(model.DependencySMTP, false, &failing),
+	)
+
+	readiness := health.CheckReadiness()
+	require.Equal(t, model.StatusOk, readiness.Status, "only the database is required by default")
+	require.True(t, readiness.Dependencies[0].Required)
+	require.False(t, readiness.Dependencies[1].Required)
+	require.Equal(t, model.StatusUnhealthy, readiness.Dependencies[1].Status)
+
+	*health.configFn().ServiceSettings.ReadinessRequiredDependencies = "database,smtp"
+	require.Equal(t, model.StatusOk, health.CheckReadiness().Status, "the readiness is cached")
+
+	health.readinessAt = time.Time{}
+	readiness = health.CheckReadiness()
+	require.Equal(t, model.StatusUnhealthy, readiness.Status)
+	require.True(t, readiness.Dependencies[1].Required)
+
+	var nilHealth *DependencyHealth
+	require.Equal(t, model.StatusUnhealthy, nilHealth.CheckReadiness().Status)
+}
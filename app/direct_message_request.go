@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// GetDirectMessageRequestsForUser returns the pending message requests sent to a user.
+func (a *App) GetDirectMessageRequestsForUser(userID string) ([]*model.DirectMessageRequest, *model.AppError) {
+	requests, err := a.Srv().Store.DirectMessageRequest().GetPendingForRecipient(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetDirectMessageRequestsForUser", "app.direct_message_request.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return requests, nil
+}
+
+// AcceptDirectMessageRequest lets the requester of the message request of a direct channel message
+// its recipient freely, including after the recipient declined it.
+func (a *App) AcceptDirectMessageRequest(channelID, userID string) *model.AppError {
+	dmRequest, appErr := a.getDirectMessageRequestForRecipient(channelID, userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.acceptDirectMessageRequest(dmRequest)
+}
+
+// DeclineDirectMessageRequest prevents the requester of the pending message request of a direct
+// channel from messaging its recipient any further.
+func (a *App) DeclineDirectMessageRequest(channelID, userID string) *model.AppError {
+	dmRequest, appErr := a.getDirectMessageRequestForRecipient(channelID, userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !dmRequest.IsPending() {
+		return model.NewAppError("DeclineDirectMessageRequest", "app.direct_message_request.not_pending.app_error", nil, "channel_id="+channelID, http.StatusBadRequest)
+	}
+
+	if err := a.Srv().Store.DirectMessageRequest().UpdateStatus(channelID, model.DirectMessageRequestStatusDeclined, model.GetMillis()); err != nil {
+		return model.NewAppError("DeclineDirectMessageRequest", "app.direct_message_request.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	dmRequest.Status = model.DirectMessageRequestStatusDeclined
+	a.publishDirectMessageRequest(model.WebsocketEventDirectMessageRequestUpdated, dmRequest, dmRequest.RequesterId, dmRequest.RecipientId)
+
+	return nil
+}
+
+func (a *App) getDirectMessageRequestForRecipient(channelID, userID string) (*model.DirectMessageRequest, *model.AppError) {
+	dmRequest, appErr := a.getDirectMessageRequest(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if dmRequest == nil {
+		return nil, model.NewAppError("getDirectMessageRequestForRecipient", "app.direct_message_request.not_found.app_error", nil, "channel_id="+channelID, http.StatusNotFound)
+	}
+
+	if dmRequest.RecipientId != userID {
+		return nil, model.NewAppError("getDirectMessageRequestForRecipient", "app.direct_message_request.not_recipient.app_error", nil, "channel_id="+channelID, http.StatusForbidden)
+	}
+
+	return dmRequest, nil
+}
+
+// getDirectMessageRequest returns the message request of a direct channel, or nil if it has none.
+func (a *App) getDirectMessageRequest(channelID string) (*model.DirectMessageRequest, *model.AppError) {
+	dmRequest, err := a.Srv().Store.DirectMessageRequest().Get(channelID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil
+		}
+		return nil, model.NewAppError("getDirectMessageRequest", "app.direct_message_request.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return dmRequest, nil
+}
+
+func (a *App) acceptDirectMessageRequest(dmRequest *model.DirectMessageRequest) *model.AppError {
+	if err := a.Srv().Store.DirectMessageRequest().Delete(dmRequest.ChannelId); err != nil {
+		return model.NewAppError("acceptDirectMessageRequest", "app.direct_message_request.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	dmRequest.Status = model.DirectMessageRequestStatusAccepted
+	a.publishDirectMessageRequest(model.WebsocketEventDirectMessageRequestUpdated, dmRequest, dmRequest.RequesterId, dmRequest.RecipientId)
+
+	return nil
+}
+
+// createDirectMessageRequestIfNeeded holds a direct channel the session user just created with a
+// user they share no channel with as a message request to that user. Direct channels with bots or
+// created on behalf of other users, such as by system admins, are never held.
+func (a *App) createDirectMessageRequestIfNeeded(c *request.Context, userID, otherUserID string, channel *model.Channel) *model.AppError {
+	if !*a.Config().TeamSettings.EnableDirectMessageRequests || userID == otherUserID {
+		return nil
+	}
+
+	var recipientID string
+	switch c.Session().UserId {
+	case userID:
+		recipientID = otherUserID
+	case otherUserID:
+		recipientID = userID
+	default:
+		return nil
+	}
+	requesterID := c.Session().UserId
+
+	if a.SessionHasPermissionTo(*c.Session(), model.PermissionManageSystem) {
+		return nil
+	}
+
+	users, appErr := a.GetUsersByIds([]string{userID, otherUserID}, &store.UserGetByIdsOpts{})
+	if appErr != nil {
+		return appErr
+	}
+	for _, user := range users {
+		if user.IsBot {
+			return nil
+		}
+	}
+
+	shared, err := a.Srv().Store.Channel().UsersShareChannel(requesterID, recipientID)
+	if err != nil {
+		return model.NewAppError("createDirectMessageRequestIfNeeded", "app.channel.users_share_channel.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if shared {
+		return nil
+	}
+
+	dmRequest, err := a.Srv().Store.DirectMessageRequest().Save(&model.DirectMessageRequest{
+		ChannelId:   channel.Id,
+		RequesterId: requesterID,
+		RecipientId: recipientID,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return appErr
+		case errors.As(err, &cErr):
+			return nil
+		default:
+			return model.NewAppError("createDirectMessageRequestIfNeeded", "app.direct_message_request.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.publishDirectMessageRequest(model.WebsocketEventDirectMessageRequestCreated, dmRequest, recipientID)
+
+	return nil
+}
+
+// checkDirectMessageRequest returns an error if the user can't post in a direct channel because
+// its recipient declined their message request. The recipient posting in the channel accepts the
+// request.
+func (a *App) checkDirectMessageRequest(channel *model.Channel, userID string) *model.AppError {
+	if channel.Type != model.ChannelTypeDirect || !*a.Config().TeamSettings.EnableDirectMessageRequests {
+		return nil
+	}
+
+	dmRequest, appErr := a.getDirectMessageRequest(channel.Id)
+	if appErr != nil || dmRequest == nil {
+		return appErr
+	}
+
+	if userID == dmRequest.RecipientId {
+		return a.acceptDirectMessageRequest(dmRequest)
+	}
+
+	if !dmRequest.IsPending() {
+		return model.NewAppError("checkDirectMessageRequest", "api.post.create_post.direct_message_request_declined.app_error", nil, "channel_id="+channel.Id, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// isPendingDirectMessageRequestRecipient returns whether the user is the recipient of a message
+// request of a direct channel they didn't accept yet, in which case they aren't notified of the
+// messages posted in it.
+func (a *App) isPendingDirectMessageRequestRecipient(channel *model.Channel, userID string) (bool, *model.AppError) {
+	if channel.Type != model.ChannelTypeDirect || !*a.Config().TeamSettings.EnableDirectMessageRequests {
+		return false, nil
+	}
+
+	dmRequest, appErr := a.getDirectMessageRequest(channel.Id)
+	if appErr != nil || dmRequest == nil {
+		return false, appErr
+	}
+
+	return dmRequest.RecipientId == userID, nil
+}
+
+// publishDirectMessageRequest informs the given users that a message request was created or its
+// status changed.
+func (a *App) publishDirectMessageRequest(event string, dmRequest *model.DirectMessageRequest, userIDs ...string) {
+	for _, userID := range userIDs {
+		message := model.NewWebSocketEvent(event, "", "", userID, nil)
+		message.Add("channel_id", dmRequest.ChannelId)
+		message.Add("requester_id", dmRequest.RequesterId)
+		message.Add("recipient_id", dmRequest.RecipientId)
+		message.Add("status", dmRequest.Status)
+		a.Publish(message)
+	}
+}
@@ -430,6 +430,34 @@ func (es *Service) SendPasswordResetEmail(email string, token *model.Token, loca
 	return true, nil
 }
 
+// SendLoginNotificationEmail warns a user of a login from a new device or country, with a link
+// revoking the session of the login.
+func (es *Service) SendLoginNotificationEmail(email string, token *model.Token, device, location, locale, siteURL string) error {
+	T := i18n.GetUserTranslations(locale)
+
+	link := fmt.Sprintf("%s/login/revoke_session?token=%s", siteURL, url.QueryEscape(token.Token))
+
+	subject := T("api.templates.login_notification_subject",
+		map[string]interface{}{"SiteName": es.config().TeamSettings.SiteName})
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.login_notification_body.title")
+	data.Props["SubTitle"] = T("api.templates.login_notification_body.subTitle", map[string]interface{}{"Device": device, "Location": location})
+	data.Props["Info"] = T("api.templates.login_notification_body.info")
+	data.Props["ButtonURL"] = link
+	data.Props["Button"] = T("api.templates.login_notification_body.button")
+	data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
+	data.Props["QuestionInfo"] = T("api.templates.questions_footer.info")
+
+	body, err := es.templatesContainer.RenderToString("reset_body", data)
+	if err != nil {
+		return err
+	}
+
+	return es.sendMail(email, subject, body)
+}
+
 func (es *Service) SendMfaChangeEmail(email string, activated bool, locale, siteURL string) error {
 	T := i18n.GetUserTranslations(locale)
 
@@ -997,6 +1025,37 @@ func (es *Service) SendLicenseUpForRenewalEmail(email, name, locale, siteURL, re
 	return nil
 }
 
+func (es *Service) SendLicenseSeatLimitEmail(email, name, locale, siteURL string, status *model.LicenseStatus) error {
+	T := i18n.GetUserTranslations(locale)
+
+	seats := map[string]interface{}{"UserName": name, "UsedSeats": status.UsedSeats, "LicensedSeats": status.LicensedSeats, "OverageSeats": status.OverageSeats}
+
+	subject := T("api.templates.license_seat_limit.warning.subject")
+	subTitle := T("api.templates.license_seat_limit.warning.subtitle", seats)
+	if status.SeatState == model.LicenseSeatStateOverage {
+		subject = T("api.templates.license_seat_limit.overage.subject")
+		subTitle = T("api.templates.license_seat_limit.overage.subtitle", seats)
+	}
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = subject
+	data.Props["SubTitle"] = subTitle
+	data.Props["SubTitleTwo"] = T("api.templates.license_seat_limit.subtitle_two")
+	data.Props["Button"] = T("api.templates.license_seat_limit.button")
+	data.Props["ButtonURL"] = siteURL + "/admin_console/about/license"
+	data.Props["QuestionTitle"] = T("api.templates.questions_footer.title")
+	data.Props["SupportEmail"] = "feedback@mattermost.com"
+	data.Props["QuestionInfo"] = T("api.templates.questions_footer.info")
+
+	body, err := es.templatesContainer.RenderToString("license_seat_limit", data)
+	if err != nil {
+		return err
+	}
+
+	return es.sendMail(email, subject, body)
+}
+
 func (es *Service) SendPaymentFailedEmail(email string, locale string, failedPayment *model.FailedPayment, siteURL string) (bool, error) {
 	T := i18n.GetUserTranslations(locale)
 
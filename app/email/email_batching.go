@@ -228,7 +228,9 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 		return
 	}
 
-	translateFunc := i18n.GetUserTranslations(user.Locale)
+	translateFunc := i18n.GetNotificationTranslations(user.Locale)
+	location := es.getUserLocation(user)
+	useMilitaryTime := es.useMilitaryTime(user.Id)
 	displayNameFormat := *es.config().TeamSettings.TeammateNameDisplay
 	siteURL := *es.config().ServiceSettings.SiteURL
 
@@ -273,12 +275,19 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 				embeddedFiles[senderPhoto] = bytes.NewReader(senderProfileImage)
 			}
 
-			tm := time.Unix(notification.post.CreateAt/1000, 0)
+			tm := time.Unix(notification.post.CreateAt/1000, 0).In(location)
 			timezone, _ := tm.Zone()
 
+			hour := tm.Format("15")
+			minute := fmt.Sprintf("%02d", tm.Minute())
+			if !useMilitaryTime {
+				hour = tm.Format("3")
+				minute += " " + tm.Format("PM")
+			}
+
 			t := translateFunc("api.email_batching.send_batched_email_notification.time", map[string]interface{}{
-				"Hour":     tm.Hour(),
-				"Minute":   fmt.Sprintf("%02d", tm.Minute()),
+				"Hour":     hour,
+				"Minute":   minute,
 				"Month":    translateFunc(tm.Month().String()),
 				"Day":      tm.Day(),
 				"Year":     tm.Year(),
@@ -318,7 +327,7 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 		}
 	}
 
-	tm := time.Unix(notifications[0].post.CreateAt/1000, 0)
+	tm := time.Unix(notifications[0].post.CreateAt/1000, 0).In(location)
 
 	subject := translateFunc("api.email_batching.send_batched_email_notification.subject", len(notifications), map[string]interface{}{
 		"SiteName": es.config().TeamSettings.SiteName,
@@ -329,7 +338,7 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 
 	data := es.NewEmailTemplateData(user.Locale)
 	data.Props["SiteURL"] = siteURL
-	data.Props["Title"] = translateFunc("api.email_batching.send_batched_email_notification.title", len(notifications)-1)
+	data.Props["Title"] = translateFunc("api.email_batching.send_batched_email_notification.title", len(notifications))
 	data.Props["SubTitle"] = translateFunc("api.email_batching.send_batched_email_notification.subTitle")
 	data.Props["Button"] = translateFunc("api.email_batching.send_batched_email_notification.button")
 	data.Props["ButtonURL"] = siteURL
@@ -348,3 +357,24 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 		mlog.Warn("Unable to send batched email notification", mlog.String("email", user.Email), mlog.Err(nErr))
 	}
 }
+
+// getUserLocation returns the location of the user's preferred timezone, or the server's location
+// if the user hasn't set a valid one.
+func (es *Service) getUserLocation(user *model.User) *time.Location {
+	if timezone := user.GetPreferredTimezone(); timezone != "" {
+		if location, err := time.LoadLocation(timezone); err == nil {
+			return location
+		}
+	}
+
+	return time.Local
+}
+
+func (es *Service) useMilitaryTime(userID string) bool {
+	data, err := es.store.Preference().Get(userID, model.PreferenceCategoryDisplaySettings, model.PreferenceNameUseMilitaryTime)
+	if err != nil {
+		return true
+	}
+
+	return data.Value == "true"
+}
@@ -358,3 +358,44 @@ func TestMailServiceConfig(t *testing.T) {
 		require.Equal(t, configuredReplyTo, mailConfig.ReplyToAddress)
 	})
 }
+
+func TestSendLicenseSeatLimitEmail(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.ConfigureInbucketMail()
+
+	emailTo := "testlicenseuser@example.com"
+	emailToUsername := strings.Split(emailTo, "@")[0]
+
+	t.Run("SendLicenseSeatLimitEmail", func(t *testing.T) {
+		verifyMailbox := func(t *testing.T) {
+			t.Helper()
+
+			var resultsMailbox mail.JSONMessageHeaderInbucket
+			err2 := mail.RetryInbucket(5, func() error {
+				var err error
+				resultsMailbox, err = mail.GetMailBox(emailTo)
+				return err
+			})
+			if err2 != nil {
+				t.Skipf("No email was received, maybe due load on the server: %v", err2)
+			}
+
+			require.Len(t, resultsMailbox, 1)
+			require.Contains(t, resultsMailbox[0].To[0], emailTo, "Wrong To: recipient")
+			resultsEmail, err := mail.GetMessageFromMailbox(emailTo, resultsMailbox[0].ID)
+			require.NoError(t, err, "Could not get message from mailbox")
+			require.Contains(t, resultsEmail.Body.Text, "Your Mattermost license seat limit has been exceeded", "Wrong received message %s", resultsEmail.Body.Text)
+			require.Contains(t, resultsEmail.Body.Text, "View license", "Wrong received message %s", resultsEmail.Body.Text)
+			require.Contains(t, resultsEmail.Body.HTML, "http://testserver/admin_console/about/license", "Wrong received message %s", resultsEmail.Body.Text)
+			require.NotContains(t, resultsEmail.Body.HTML, "ucarecdn.com", "the renewal illustration shouldn't be included")
+		}
+		mail.DeleteMailBox(emailTo)
+
+		status := &model.LicenseStatus{LicensedSeats: 10, UsedSeats: 12, OverageSeats: 2, SeatState: model.LicenseSeatStateOverage}
+		err := th.service.SendLicenseSeatLimitEmail(emailTo, emailToUsername, th.BasicUser.Locale, "http://testserver", status)
+		require.NoError(t, err)
+
+		verifyMailbox(t)
+	})
+}
@@ -288,6 +288,20 @@ func (_m *ServiceInterface) SendLicenseInactivityEmail(_a0 string, name string,
 	return r0
 }
 
+// SendLicenseSeatLimitEmail provides a mock function with given fields: _a0, name, locale, siteURL, status
+func (_m *ServiceInterface) SendLicenseSeatLimitEmail(_a0 string, name string, locale string, siteURL string, status *model.LicenseStatus) error {
+	ret := _m.Called(_a0, name, locale, siteURL, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string, *model.LicenseStatus) error); ok {
+		r0 = rf(_a0, name, locale, siteURL, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendLicenseUpForRenewalEmail provides a mock function with given fields: _a0, name, locale, siteURL, renewalLink, daysToExpiration
 func (_m *ServiceInterface) SendLicenseUpForRenewalEmail(_a0 string, name string, locale string, siteURL string, renewalLink string, daysToExpiration int) error {
 	ret := _m.Called(_a0, name, locale, siteURL, renewalLink, daysToExpiration)
@@ -316,6 +330,20 @@ func (_m *ServiceInterface) SendMailWithEmbeddedFiles(to string, subject string,
 	return r0
 }
 
+// SendLoginNotificationEmail provides a mock function with given fields: _a0, token, device, location, locale, siteURL
+func (_m *ServiceInterface) SendLoginNotificationEmail(_a0 string, token *model.Token, device string, location string, locale string, siteURL string) error {
+	ret := _m.Called(_a0, token, device, location, locale, siteURL)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *model.Token, string, string, string, string) error); ok {
+		r0 = rf(_a0, token, device, location, locale, siteURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendMfaChangeEmail provides a mock function with given fields: _a0, activated, locale, siteURL
 func (_m *ServiceInterface) SendMfaChangeEmail(_a0 string, activated bool, locale string, siteURL string) error {
 	ret := _m.Called(_a0, activated, locale, siteURL)
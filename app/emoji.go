@@ -63,6 +63,16 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 		return nil, model.NewAppError("createEmoji", "api.emoji.create.duplicate.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if emoji.TeamId != "" {
+		if err := a.checkTeamFeature(emoji.TeamId, model.TeamFeatureCustomEmoji); err != nil {
+			return nil, err
+		}
+
+		if appErr := a.checkTeamEmojiQuota(emoji.TeamId); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	imageData := multiPartImageData.File["image"]
 	if len(imageData) == 0 {
 		err := model.NewAppError("Context", "api.context.invalid_body_param.app_error", map[string]interface{}{"Name": "createEmoji"}, "", http.StatusBadRequest)
@@ -73,11 +83,17 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 		return nil, err
 	}
 
-	emoji, err := a.Srv().Store.Emoji().Save(emoji)
+	emoji, err := a.saveEmoji(emoji)
 	if err != nil {
-		return nil, model.NewAppError("CreateEmoji", "app.emoji.create.internal_error", nil, err.Error(), http.StatusInternalServerError)
+		a.deleteEmojiImage(emoji.Id)
+		return nil, err
 	}
 
+	a.publishEmojiAdded(emoji)
+	return emoji, nil
+}
+
+func (a *App) publishEmojiAdded(emoji *model.Emoji) {
 	message := model.NewWebSocketEvent(model.WebsocketEventEmojiAdded, "", "", "", nil)
 	emojiJSON, jsonErr := json.Marshal(emoji)
 	if jsonErr != nil {
@@ -85,7 +101,65 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 	}
 	message.Add("emoji", string(emojiJSON))
 	a.Publish(message)
-	return emoji, nil
+}
+
+// saveEmoji stores the emoji, enforcing the custom emoji quota of its team if it is team scoped.
+func (a *App) saveEmoji(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
+	if emoji.TeamId == "" {
+		saved, err := a.Srv().Store.Emoji().Save(emoji)
+		if err != nil {
+			return emoji, model.NewAppError("CreateEmoji", "app.emoji.create.internal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		return saved, nil
+	}
+
+	maxEmojiPerTeam := *a.Config().ServiceSettings.MaxCustomEmojiPerTeam
+	limit := int64(maxEmojiPerTeam)
+	if maxEmojiPerTeam == 0 {
+		limit = -1
+	}
+
+	saved, err := a.Srv().Store.Emoji().SaveForTeam(emoji, limit)
+	if err != nil {
+		var ltErr *store.ErrLimitExceeded
+		switch {
+		case errors.As(err, &ltErr):
+			return emoji, model.NewAppError("CreateEmoji", "app.emoji.create.team_quota_exceeded.app_error", map[string]interface{}{"Max": maxEmojiPerTeam}, ltErr.Error(), http.StatusBadRequest)
+		default:
+			return emoji, model.NewAppError("CreateEmoji", "app.emoji.create.internal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+// checkTeamEmojiQuota returns an error if the team can't have any more custom emoji. The quota is
+// checked again when saving the emoji, this only avoids uploading images that would be rejected.
+func (a *App) checkTeamEmojiQuota(teamID string) *model.AppError {
+	maxEmojiPerTeam := *a.Config().ServiceSettings.MaxCustomEmojiPerTeam
+	if maxEmojiPerTeam == 0 {
+		return nil
+	}
+
+	count, err := a.Srv().Store.Emoji().CountByTeam(teamID)
+	if err != nil {
+		return model.NewAppError("checkTeamEmojiQuota", "app.emoji.count_by_team.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if count >= int64(maxEmojiPerTeam) {
+		return model.NewAppError("checkTeamEmojiQuota", "app.emoji.create.team_quota_exceeded.app_error", map[string]interface{}{"Max": maxEmojiPerTeam}, "team_id="+teamID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (a *App) GetEmojiListForTeam(teamID string, page, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
+	list, err := a.Srv().Store.Emoji().GetListForTeam(teamID, page*perPage, perPage, sort)
+	if err != nil {
+		return nil, model.NewAppError("GetEmojiListForTeam", "app.emoji.get_list.internal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return list, nil
 }
 
 func (a *App) GetEmojiList(page, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
@@ -115,6 +189,14 @@ func (a *App) UploadEmojiImage(id string, imageData *multipart.FileHeader) *mode
 	buf := bytes.NewBuffer(nil)
 	io.Copy(buf, file)
 
+	return a.uploadEmojiImageBytes(id, imageData.Filename, buf.Bytes())
+}
+
+// uploadEmojiImageBytes validates the image, shrinks it to the emoji dimensions if needed and stores
+// it as the image of the emoji with the given id.
+func (a *App) uploadEmojiImageBytes(id, filename string, imageBytes []byte) *model.AppError {
+	buf := bytes.NewBuffer(imageBytes)
+
 	// make sure the file is an image and is within the required dimensions
 	config, _, err := image.DecodeConfig(bytes.NewReader(buf.Bytes()))
 	if err != nil {
@@ -131,7 +213,7 @@ func (a *App) UploadEmojiImage(id string, imageData *multipart.FileHeader) *mode
 	if config.Width > MaxEmojiWidth || config.Height > MaxEmojiHeight {
 		data := buf.Bytes()
 		newbuf := bytes.NewBuffer(nil)
-		info, err := model.GetInfoForBytes(imageData.Filename, bytes.NewReader(data), len(data))
+		info, err := model.GetInfoForBytes(filename, bytes.NewReader(data), len(data))
 		if err != nil {
 			return err
 		}
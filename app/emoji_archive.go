@@ -0,0 +1,290 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const (
+	MaxEmojiArchiveEntries = 10000
+
+	emojiExportPageSize = 200
+	// emojiRenameAttempts is how many numeric suffixes are tried when looking for a free emoji name.
+	emojiRenameAttempts = 100
+)
+
+var emojiArchiveImageExtensions = map[string]bool{
+	".png":  true,
+	".gif":  true,
+	".jpg":  true,
+	".jpeg": true,
+}
+
+// ExportEmojiArchive writes every custom emoji to w as a zip archive holding the emoji images and a
+// manifest mapping emoji names to them.
+func (a *App) ExportEmojiArchive(w io.Writer) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return model.NewAppError("ExportEmojiArchive", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if *a.Config().FileSettings.DriverName == "" {
+		return model.NewAppError("ExportEmojiArchive", "api.emoji.storage.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	zipWriter := zip.NewWriter(w)
+	manifest := model.EmojiArchiveManifest{
+		Version: model.EmojiArchiveVersion,
+		Emojis:  []model.EmojiArchiveEntry{},
+	}
+
+	for page := 0; ; page++ {
+		emojis, appErr := a.GetEmojiList(page, emojiExportPageSize, model.EmojiSortByName)
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, emoji := range emojis {
+			img, appErr := a.ReadFile(getEmojiImagePath(emoji.Id))
+			if appErr != nil {
+				mlog.Warn("Skipping emoji without an image during export", mlog.String("emoji_id", emoji.Id), mlog.Err(appErr))
+				continue
+			}
+
+			_, imageType, err := image.DecodeConfig(bytes.NewReader(img))
+			if err != nil {
+				mlog.Warn("Skipping emoji with an unreadable image during export", mlog.String("emoji_id", emoji.Id), mlog.Err(err))
+				continue
+			}
+
+			file := path.Join(model.EmojiArchiveImagesDir, emoji.Name+"."+imageType)
+			fileWriter, err := zipWriter.Create(file)
+			if err != nil {
+				return model.NewAppError("ExportEmojiArchive", "api.emoji.export.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+			if _, err := fileWriter.Write(img); err != nil {
+				return model.NewAppError("ExportEmojiArchive", "api.emoji.export.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+
+			manifest.Emojis = append(manifest.Emojis, model.EmojiArchiveEntry{Name: emoji.Name, File: file})
+		}
+
+		if len(emojis) < emojiExportPageSize {
+			break
+		}
+	}
+
+	manifestWriter, err := zipWriter.Create(model.EmojiArchiveManifestName)
+	if err != nil {
+		return model.NewAppError("ExportEmojiArchive", "api.emoji.export.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if err := json.NewEncoder(manifestWriter).Encode(manifest); err != nil {
+		return model.NewAppError("ExportEmojiArchive", "api.emoji.export.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return model.NewAppError("ExportEmojiArchive", "api.emoji.export.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ImportEmojiArchive creates custom emoji, owned by userID, from a zip archive. Emoji whose name is
+// already taken are handled according to collisionPolicy. Emoji that can't be imported are reported
+// in the result rather than failing the whole import.
+func (a *App) ImportEmojiArchive(userID string, archive io.ReaderAt, size int64, collisionPolicy string) (*model.EmojiImportResult, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCustomEmoji {
+		return nil, model.NewAppError("ImportEmojiArchive", "api.emoji.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if *a.Config().FileSettings.DriverName == "" {
+		return nil, model.NewAppError("ImportEmojiArchive", "api.emoji.storage.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	zipReader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, model.NewAppError("ImportEmojiArchive", "api.emoji.import.open.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	files := make(map[string]*zip.File, len(zipReader.File))
+	for _, file := range zipReader.File {
+		if !file.FileInfo().IsDir() {
+			files[file.Name] = file
+		}
+	}
+
+	entries, appErr := readEmojiArchiveEntries(files)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(entries) > MaxEmojiArchiveEntries {
+		return nil, model.NewAppError("ImportEmojiArchive", "api.emoji.import.too_many.app_error", map[string]interface{}{"Max": MaxEmojiArchiveEntries}, "", http.StatusBadRequest)
+	}
+
+	result := &model.EmojiImportResult{
+		Imported:    []string{},
+		Overwritten: []string{},
+		Renamed:     map[string]string{},
+		Skipped:     []string{},
+		Failures:    []model.EmojiImportFailure{},
+	}
+
+	for _, entry := range entries {
+		if appErr := a.importArchivedEmoji(userID, entry, files[entry.File], collisionPolicy, result); appErr != nil {
+			appErr.Translate(i18n.T)
+			result.Failures = append(result.Failures, model.EmojiImportFailure{Name: entry.Name, Error: appErr.Message})
+		}
+	}
+
+	return result, nil
+}
+
+// readEmojiArchiveEntries returns the emoji listed in the manifest of the archive or, when there is
+// none, one emoji per image named after the image file.
+func readEmojiArchiveEntries(files map[string]*zip.File) ([]model.EmojiArchiveEntry, *model.AppError) {
+	if manifestFile, ok := files[model.EmojiArchiveManifestName]; ok {
+		reader, err := manifestFile.Open()
+		if err != nil {
+			return nil, model.NewAppError("readEmojiArchiveEntries", "api.emoji.import.manifest.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+		defer reader.Close()
+
+		var manifest model.EmojiArchiveManifest
+		if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+			return nil, model.NewAppError("readEmojiArchiveEntries", "api.emoji.import.manifest.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+
+		return manifest.Emojis, nil
+	}
+
+	entries := []model.EmojiArchiveEntry{}
+	for name := range files {
+		ext := strings.ToLower(path.Ext(name))
+		if !emojiArchiveImageExtensions[ext] {
+			continue
+		}
+
+		entries = append(entries, model.EmojiArchiveEntry{
+			Name: strings.TrimSuffix(path.Base(name), path.Ext(name)),
+			File: name,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].File < entries[j].File
+	})
+
+	return entries, nil
+}
+
+func (a *App) importArchivedEmoji(userID string, entry model.EmojiArchiveEntry, file *zip.File, collisionPolicy string, result *model.EmojiImportResult) *model.AppError {
+	if file == nil {
+		return model.NewAppError("importArchivedEmoji", "api.emoji.import.missing_file.app_error", map[string]interface{}{"File": entry.File}, "", http.StatusBadRequest)
+	}
+
+	if file.UncompressedSize64 > MaxEmojiFileSize {
+		return model.NewAppError("importArchivedEmoji", "api.emoji.create.too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return model.NewAppError("importArchivedEmoji", "api.emoji.import.read.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(reader, MaxEmojiFileSize))
+	if err != nil {
+		return model.NewAppError("importArchivedEmoji", "api.emoji.import.read.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	name := entry.Name
+	existing, err := a.Srv().Store.Emoji().GetByName(context.Background(), name, false)
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return model.NewAppError("importArchivedEmoji", "app.emoji.get_by_name.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if existing != nil && err == nil {
+		switch collisionPolicy {
+		case model.EmojiImportCollisionOverwrite:
+			if appErr := a.uploadEmojiImageBytes(existing.Id, file.Name, data); appErr != nil {
+				return appErr
+			}
+			result.Overwritten = append(result.Overwritten, name)
+			return nil
+		case model.EmojiImportCollisionRename:
+			newName, appErr := a.findFreeEmojiName(name)
+			if appErr != nil {
+				return appErr
+			}
+			result.Renamed[name] = newName
+			name = newName
+		default:
+			result.Skipped = append(result.Skipped, name)
+			return nil
+		}
+	}
+
+	emoji := &model.Emoji{
+		CreatorId: userID,
+		Name:      name,
+	}
+	emoji.PreSave()
+	if appErr := emoji.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.uploadEmojiImageBytes(emoji.Id, file.Name, data); appErr != nil {
+		return appErr
+	}
+
+	emoji, err = a.Srv().Store.Emoji().Save(emoji)
+	if err != nil {
+		return model.NewAppError("importArchivedEmoji", "app.emoji.create.internal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.publishEmojiAdded(emoji)
+	result.Imported = append(result.Imported, emoji.Name)
+
+	return nil
+}
+
+// findFreeEmojiName returns the first name made of the given one and a numeric suffix that isn't
+// used by another custom emoji.
+func (a *App) findFreeEmojiName(name string) (string, *model.AppError) {
+	for i := 1; i <= emojiRenameAttempts; i++ {
+		candidate := fmt.Sprintf("%s_%d", name, i)
+		if model.IsValidEmojiName(candidate) != nil {
+			break
+		}
+
+		_, err := a.Srv().Store.Emoji().GetByName(context.Background(), candidate, false)
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return candidate, nil
+		} else if err != nil {
+			return "", model.NewAppError("findFreeEmojiName", "app.emoji.get_by_name.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return "", model.NewAppError("findFreeEmojiName", "api.emoji.import.rename.app_error", nil, "name="+name, http.StatusBadRequest)
+}
@@ -105,6 +105,18 @@ func RegisterNotificationInterface(f func(*App) einterfaces.NotificationInterfac
 	notificationInterface = f
 }
 
+var transcriptionInterface func(*App) einterfaces.TranscriptionInterface
+
+func RegisterTranscriptionInterface(f func(*App) einterfaces.TranscriptionInterface) {
+	transcriptionInterface = f
+}
+
+var imageCaptionInterface func(*App) einterfaces.ImageCaptionInterface
+
+func RegisterImageCaptionInterface(f func(*App) einterfaces.ImageCaptionInterface) {
+	imageCaptionInterface = f
+}
+
 var licenseInterface func(*Server) einterfaces.LicenseInterface
 
 func RegisterLicenseInterface(f func(*Server) einterfaces.LicenseInterface) {
@@ -0,0 +1,399 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	slackExportUsersPageSize = 200
+	slackExportPostsPageSize = 1000
+	slackExportUploadsDir    = "__uploads"
+	slackExportDayFormat     = "2006-01-02"
+)
+
+var (
+	slackExportUserMentionRegexp    = regexp.MustCompile(`(^|[^\w])@([a-z0-9.\-_]+)`)
+	slackExportChannelMentionRegexp = regexp.MustCompile(`(^|[^\w])~([a-z0-9\-_]+)`)
+)
+
+type slackExportProfile struct {
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	RealName    string `json:"real_name"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Title       string `json:"title"`
+}
+
+type slackExportUser struct {
+	Id       string             `json:"id"`
+	TeamId   string             `json:"team_id"`
+	Name     string             `json:"name"`
+	Deleted  bool               `json:"deleted"`
+	RealName string             `json:"real_name"`
+	IsBot    bool               `json:"is_bot"`
+	Profile  slackExportProfile `json:"profile"`
+}
+
+type slackExportChannelSub struct {
+	Value   string `json:"value"`
+	Creator string `json:"creator"`
+	LastSet int64  `json:"last_set"`
+}
+
+type slackExportChannel struct {
+	Id         string                `json:"id"`
+	Name       string                `json:"name"`
+	Created    int64                 `json:"created"`
+	Creator    string                `json:"creator"`
+	IsArchived bool                  `json:"is_archived"`
+	IsGeneral  bool                  `json:"is_general"`
+	Members    []string              `json:"members"`
+	Topic      slackExportChannelSub `json:"topic"`
+	Purpose    slackExportChannelSub `json:"purpose"`
+}
+
+type slackExportFile struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Title    string `json:"title"`
+	Mimetype string `json:"mimetype"`
+	Size     int64  `json:"size"`
+}
+
+type slackExportMessage struct {
+	Type         string            `json:"type"`
+	User         string            `json:"user"`
+	Text         string            `json:"text"`
+	Ts           string            `json:"ts"`
+	ThreadTs     string            `json:"thread_ts,omitempty"`
+	ReplyCount   int64             `json:"reply_count,omitempty"`
+	ParentUserId string            `json:"parent_user_id,omitempty"`
+	Upload       bool              `json:"upload,omitempty"`
+	Files        []slackExportFile `json:"files,omitempty"`
+}
+
+// slackExportAttachment is a file to copy into the uploads directory of the export once all the
+// messages have been written.
+type slackExportAttachment struct {
+	fileID string
+	name   string
+	path   string
+}
+
+// slackTimestamp formats milliseconds since the epoch the way Slack formats message timestamps.
+func slackTimestamp(millis int64) string {
+	return fmt.Sprintf("%d.%06d", millis/1000, (millis%1000)*1000)
+}
+
+// SlackExport writes the public and private channels of a team, along with their members and
+// messages, as a zip archive laid out like a Slack workspace export. Direct and group messages
+// aren't part of a Slack workspace export and are left out.
+func (a *App) SlackExport(writer io.Writer, teamID string, opts model.BulkExportOpts) *model.AppError {
+	team, appErr := a.GetTeam(teamID)
+	if appErr != nil {
+		return appErr
+	}
+
+	zipWr := zip.NewWriter(writer)
+	defer zipWr.Close()
+
+	mlog.Info("Slack export: exporting users", mlog.String("team_id", team.Id))
+	users, appErr := a.slackExportUsers(zipWr, team.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	channels, nErr := a.Srv().Store.Channel().GetTeamChannels(team.Id)
+	if nErr != nil {
+		return model.NewAppError("SlackExport", "app.channel.get_channels.get.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Slack export: exporting channels", mlog.String("team_id", team.Id))
+	if appErr = a.slackExportChannels(zipWr, channels); appErr != nil {
+		return appErr
+	}
+
+	channelIDs := make(map[string]string, len(channels))
+	for _, channel := range channels {
+		channelIDs[channel.Name] = channel.Id
+	}
+
+	mlog.Info("Slack export: exporting posts", mlog.String("team_id", team.Id))
+	var attachments []slackExportAttachment
+	for _, channel := range channels {
+		if channel.Type != model.ChannelTypeOpen && channel.Type != model.ChannelTypePrivate {
+			continue
+		}
+
+		channelAttachments, appErr := a.slackExportChannelPosts(zipWr, channel, users, channelIDs, opts.IncludeAttachments)
+		if appErr != nil {
+			return appErr
+		}
+		attachments = append(attachments, channelAttachments...)
+	}
+
+	if opts.IncludeAttachments {
+		mlog.Info("Slack export: exporting file attachments", mlog.String("team_id", team.Id))
+		for _, attachment := range attachments {
+			if appErr := a.slackExportAttachment(zipWr, attachment); appErr != nil {
+				return appErr
+			}
+		}
+	}
+
+	return nil
+}
+
+func slackExportWriteJSON(zipWr *zip.Writer, name string, v interface{}) *model.AppError {
+	wr, err := zipWr.Create(name)
+	if err != nil {
+		return model.NewAppError("SlackExport", "app.export.zip_create.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	encoder := json.NewEncoder(wr)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(v); err != nil {
+		return model.NewAppError("SlackExport", "app.export.export_write_line.io_writer.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// slackExportUsers writes users.json and returns the usernames of the team members by id.
+func (a *App) slackExportUsers(zipWr *zip.Writer, teamID string) (map[string]string, *model.AppError) {
+	users := map[string]string{}
+	exported := []slackExportUser{}
+
+	for page := 0; ; page++ {
+		profiles, err := a.Srv().Store.User().GetProfiles(&model.UserGetOptions{
+			InTeamId: teamID,
+			Page:     page,
+			PerPage:  slackExportUsersPageSize,
+		})
+		if err != nil {
+			return nil, model.NewAppError("slackExportUsers", "app.user.get_profiles.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, user := range profiles {
+			users[user.Id] = user.Username
+			exported = append(exported, slackExportUser{
+				Id:       user.Id,
+				TeamId:   teamID,
+				Name:     user.Username,
+				Deleted:  user.DeleteAt != 0,
+				RealName: user.GetFullName(),
+				IsBot:    user.IsBot,
+				Profile: slackExportProfile{
+					FirstName:   user.FirstName,
+					LastName:    user.LastName,
+					RealName:    user.GetFullName(),
+					DisplayName: user.Nickname,
+					Email:       user.Email,
+					Title:       user.Position,
+				},
+			})
+		}
+
+		if len(profiles) < slackExportUsersPageSize {
+			break
+		}
+	}
+
+	return users, slackExportWriteJSON(zipWr, "users.json", exported)
+}
+
+// slackExportChannels writes the public channels to channels.json and the private ones to
+// groups.json.
+func (a *App) slackExportChannels(zipWr *zip.Writer, channels model.ChannelList) *model.AppError {
+	public := []slackExportChannel{}
+	private := []slackExportChannel{}
+
+	for _, channel := range channels {
+		members, err := a.Srv().Store.Channel().GetAllChannelMembersById(channel.Id)
+		if err != nil {
+			return model.NewAppError("slackExportChannels", "app.channel.get_members.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		exported := slackExportChannel{
+			Id:         channel.Id,
+			Name:       channel.Name,
+			Created:    channel.CreateAt / 1000,
+			Creator:    channel.CreatorId,
+			IsArchived: channel.DeleteAt != 0,
+			IsGeneral:  channel.Name == model.DefaultChannelName,
+			Members:    members,
+			Topic:      slackExportChannelSub{Value: channel.Header},
+			Purpose:    slackExportChannelSub{Value: channel.Purpose},
+		}
+
+		switch channel.Type {
+		case model.ChannelTypeOpen:
+			public = append(public, exported)
+		case model.ChannelTypePrivate:
+			private = append(private, exported)
+		}
+	}
+
+	if appErr := slackExportWriteJSON(zipWr, "channels.json", public); appErr != nil {
+		return appErr
+	}
+
+	return slackExportWriteJSON(zipWr, "groups.json", private)
+}
+
+// slackExportText rewrites user and channel mentions to Slack's <@id> and <#id|name> markup.
+func slackExportText(text string, userIDs, channelIDs map[string]string) string {
+	text = slackExportUserMentionRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		parts := slackExportUserMentionRegexp.FindStringSubmatch(match)
+		username := strings.TrimRight(parts[2], ".-_")
+		if id, ok := userIDs[username]; ok {
+			return parts[1] + "<@" + id + ">" + strings.TrimPrefix(parts[2], username)
+		}
+		return match
+	})
+
+	return slackExportChannelMentionRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		parts := slackExportChannelMentionRegexp.FindStringSubmatch(match)
+		if id, ok := channelIDs[parts[2]]; ok {
+			return parts[1] + "<#" + id + "|" + parts[2] + ">"
+		}
+		return match
+	})
+}
+
+// slackExportChannelPosts writes the messages of a channel to one file per day, named after the
+// channel, and returns the attachments those messages reference.
+func (a *App) slackExportChannelPosts(zipWr *zip.Writer, channel *model.Channel, users map[string]string, channelIDs map[string]string, withAttachments bool) ([]slackExportAttachment, *model.AppError) {
+	userIDs := make(map[string]string, len(users))
+	for id, username := range users {
+		userIDs[username] = id
+	}
+
+	var attachments []slackExportAttachment
+	rootTimestamps := map[string]string{}
+	rootUsers := map[string]string{}
+
+	day := ""
+	messages := []slackExportMessage{}
+	flush := func() *model.AppError {
+		if day == "" || len(messages) == 0 {
+			return nil
+		}
+		appErr := slackExportWriteJSON(zipWr, path.Join(channel.Name, day+".json"), messages)
+		messages = []slackExportMessage{}
+		return appErr
+	}
+
+	var afterCreateAt int64
+	afterID := ""
+	for {
+		posts, err := a.Srv().Store.Post().GetChannelPostsForExportAfter(channel.Id, afterCreateAt, afterID, slackExportPostsPageSize)
+		if err != nil {
+			return nil, model.NewAppError("slackExportChannelPosts", "app.post.get_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, post := range posts {
+			afterCreateAt = post.CreateAt
+			afterID = post.Id
+
+			if post.IsSystemMessage() {
+				continue
+			}
+
+			postDay := time.Unix(0, post.CreateAt*int64(time.Millisecond)).UTC().Format(slackExportDayFormat)
+			if postDay != day {
+				if appErr := flush(); appErr != nil {
+					return nil, appErr
+				}
+				day = postDay
+			}
+
+			message := slackExportMessage{
+				Type: "message",
+				User: post.UserId,
+				Text: slackExportText(post.Message, userIDs, channelIDs),
+				Ts:   slackTimestamp(post.CreateAt),
+			}
+
+			if post.RootId == "" && post.ReplyCount > 0 {
+				message.ThreadTs = message.Ts
+				message.ReplyCount = post.ReplyCount
+				rootTimestamps[post.Id] = message.Ts
+				rootUsers[post.Id] = post.UserId
+			} else if ts, ok := rootTimestamps[post.RootId]; ok {
+				message.ThreadTs = ts
+				message.ParentUserId = rootUsers[post.RootId]
+			}
+
+			if len(post.FileIds) > 0 {
+				infos, err := a.Srv().Store.FileInfo().GetForPost(post.Id, false, false, true)
+				if err != nil {
+					return nil, model.NewAppError("slackExportChannelPosts", "app.file_info.get_for_post.app_error", nil, err.Error(), http.StatusInternalServerError)
+				}
+
+				for _, info := range infos {
+					message.Files = append(message.Files, slackExportFile{
+						Id:       info.Id,
+						Name:     info.Name,
+						Title:    info.Name,
+						Mimetype: info.MimeType,
+						Size:     info.Size,
+					})
+					if withAttachments {
+						attachments = append(attachments, slackExportAttachment{fileID: info.Id, name: info.Name, path: info.Path})
+					}
+				}
+				message.Upload = len(message.Files) > 0
+			}
+
+			messages = append(messages, message)
+		}
+
+		if len(posts) < slackExportPostsPageSize {
+			break
+		}
+	}
+
+	if appErr := flush(); appErr != nil {
+		return nil, appErr
+	}
+
+	return attachments, nil
+}
+
+func (a *App) slackExportAttachment(zipWr *zip.Writer, attachment slackExportAttachment) *model.AppError {
+	rd, appErr := a.FileReader(attachment.path)
+	if appErr != nil {
+		return appErr
+	}
+	defer rd.Close()
+
+	wr, err := zipWr.CreateHeader(&zip.FileHeader{
+		Name:   path.Join(slackExportUploadsDir, attachment.fileID, attachment.name),
+		Method: zip.Store,
+	})
+	if err != nil {
+		return model.NewAppError("slackExportAttachment", "app.export.export_attachment.zip_create_header.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, err := io.Copy(wr, rd); err != nil {
+		return model.NewAppError("slackExportAttachment", "app.export.export_attachment.copy_file.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
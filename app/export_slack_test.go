@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestSlackTimestamp(t *testing.T) {
+	assert.Equal(t, "1600000000.123000", slackTimestamp(1600000000123))
+	assert.Equal(t, "1600000000.000000", slackTimestamp(1600000000000))
+}
+
+func TestSlackExportText(t *testing.T) {
+	userIDs := map[string]string{"alice": "u1", "bob.smith": "u2"}
+	channelIDs := map[string]string{"town-square": "c1"}
+
+	for name, tc := range map[string]struct {
+		in       string
+		expected string
+	}{
+		"user mention":             {"hi @alice", "hi <@u1>"},
+		"user mention with period": {"thanks @bob.smith.", "thanks <@u2>."},
+		"unknown user":             {"hi @carol", "hi @carol"},
+		"email address":            {"mail alice@alice.com", "mail alice@alice.com"},
+		"channel mention":          {"see ~town-square", "see <#c1|town-square>"},
+		"unknown channel":          {"see ~off-topic", "see ~off-topic"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, slackExportText(tc.in, userIDs, channelIDs))
+		})
+	}
+}
+
+func TestSlackExport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	privateChannel := th.CreatePrivateChannel(th.BasicTeam)
+
+	root, appErr := th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "hello @" + th.BasicUser2.Username,
+		CreateAt:  1600000000000,
+	}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		RootId:    root.Id,
+		Message:   "reply",
+		CreateAt:  1600000001000,
+	}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	var buf bytes.Buffer
+	appErr = th.App.SlackExport(&buf, th.BasicTeam.Id, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string]*zip.File{}
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	decode := func(name string, v interface{}) {
+		file, ok := files[name]
+		require.True(t, ok, "missing %s", name)
+		reader, err := file.Open()
+		require.NoError(t, err)
+		defer reader.Close()
+		require.NoError(t, json.NewDecoder(reader).Decode(v))
+	}
+
+	var users []slackExportUser
+	decode("users.json", &users)
+	usernames := []string{}
+	for _, user := range users {
+		usernames = append(usernames, user.Name)
+	}
+	assert.Contains(t, usernames, th.BasicUser.Username)
+	assert.Contains(t, usernames, th.BasicUser2.Username)
+
+	var channels []slackExportChannel
+	decode("channels.json", &channels)
+	var exportedChannel *slackExportChannel
+	for i := range channels {
+		if channels[i].Id == th.BasicChannel.Id {
+			exportedChannel = &channels[i]
+		}
+	}
+	require.NotNil(t, exportedChannel)
+	assert.Equal(t, th.BasicChannel.Name, exportedChannel.Name)
+	assert.Contains(t, exportedChannel.Members, th.BasicUser.Id)
+
+	var private []slackExportChannel
+	decode("groups.json", &private)
+	privateIDs := []string{}
+	for _, channel := range private {
+		privateIDs = append(privateIDs, channel.Id)
+	}
+	assert.Contains(t, privateIDs, privateChannel.Id)
+
+	var messages []slackExportMessage
+	decode(th.BasicChannel.Name+"/2020-09-13.json", &messages)
+	require.Len(t, messages, 2)
+	assert.Equal(t, th.BasicUser.Id, messages[0].User)
+	assert.Equal(t, "hello <@"+th.BasicUser2.Id+">", messages[0].Text)
+	assert.Equal(t, "1600000000.000000", messages[0].Ts)
+	assert.Equal(t, messages[0].Ts, messages[0].ThreadTs)
+	assert.Equal(t, int64(1), messages[0].ReplyCount)
+	assert.Equal(t, th.BasicUser2.Id, messages[1].User)
+	assert.Equal(t, messages[0].Ts, messages[1].ThreadTs)
+	assert.Equal(t, th.BasicUser.Id, messages[1].ParentUserId)
+}
@@ -5,10 +5,12 @@ package app
 
 import (
 	"encoding/json"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/app/featureflag"
+	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -116,3 +118,26 @@ func (s *Server) stopFeatureFlagUpdateJob() {
 		s.featureFlagSynchronizer = nil
 	}
 }
+
+// GetFeatureFlagsForUser returns the feature flags with the rollout rules evaluated for the
+// given user and system roles.
+func (a *App) GetFeatureFlagsForUser(userID string, roles []string) (*model.FeatureFlags, *model.AppError) {
+	cfg := a.Config()
+	flags := *cfg.FeatureFlags
+	if len(cfg.FeatureFlagRules) == 0 {
+		return &flags, nil
+	}
+
+	teamIDs, nErr := a.Srv().Store.Team().GetUserTeamIds(userID, true)
+	if nErr != nil {
+		return nil, model.NewAppError("GetFeatureFlagsForUser", "app.team.get_user_team_ids.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	flags.ApplyRules(cfg.FeatureFlagRules, &model.FeatureFlagTarget{
+		UserId:  userID,
+		TeamIds: teamIDs,
+		Roles:   roles,
+	})
+
+	return &flags, nil
+}
@@ -472,6 +472,12 @@ func (a *App) UploadFiles(c *request.Context, teamID string, channelID string, u
 		return nil, model.NewAppError("UploadFiles", "api.file.upload_file.incorrect_number_of_files.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if channelID != "" {
+		if err := a.checkTeamUploadFeatures(channelID, filenames...); err != nil {
+			return nil, err
+		}
+	}
+
 	resStruct := &model.FileUploadResponse{
 		FileInfos: []*model.FileInfo{},
 		ClientIds: []string{},
@@ -509,6 +515,27 @@ func (a *App) UploadFiles(c *request.Context, teamID string, channelID string, u
 	return resStruct, nil
 }
 
+// checkTeamUploadFeatures returns an error if file uploads, or GIFs when uploading a GIF file, are
+// disabled in the team of the channel.
+func (a *App) checkTeamUploadFeatures(channelID string, filenames ...string) *model.AppError {
+	channel, err := a.GetChannel(channelID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.checkTeamFeature(channel.TeamId, model.TeamFeatureFileUploads); err != nil {
+		return err
+	}
+
+	for _, filename := range filenames {
+		if strings.EqualFold(filepath.Ext(filename), ".gif") {
+			return a.checkTeamFeature(channel.TeamId, model.TeamFeatureGifs)
+		}
+	}
+
+	return nil
+}
+
 // UploadFile uploads a single file in form of a completely constructed byte array for a channel.
 func (a *App) UploadFile(c *request.Context, data []byte, channelID string, filename string) (*model.FileInfo, *model.AppError) {
 	_, err := a.GetChannel(channelID)
@@ -682,6 +709,9 @@ func (a *App) UploadFileX(c *request.Context, channelID, name string, input io.R
 	if t.ContentLength > t.maxFileSize {
 		return nil, t.newAppError("api.file.upload_file.too_large_detailed.app_error", http.StatusRequestEntityTooLarge, "Length", t.ContentLength, "Limit", t.maxFileSize)
 	}
+	if err := a.checkTeamUploadFeatures(t.ChannelId, t.Name); err != nil {
+		return nil, err
+	}
 
 	t.init(a)
 
@@ -747,6 +777,8 @@ func (a *App) UploadFileX(c *request.Context, channelID, name string, input io.R
 		})
 	}
 
+	a.generateImageAltTextAsync(t.fileinfo)
+
 	return t.fileinfo, nil
 }
 
@@ -997,6 +1029,8 @@ func (a *App) DoUploadFileExpectModification(c *request.Context, now time.Time,
 		})
 	}
 
+	a.generateImageAltTextAsync(info)
+
 	return info, data, nil
 }
 
@@ -1124,6 +1158,23 @@ func (a *App) generateMiniPreviewForInfos(fileInfos []*model.FileInfo) {
 	wg.Wait()
 }
 
+// isSharedFile reports whether the stored file of info is also used by other FileInfos, through
+// import deduplication, and so must be kept when info goes away. When that can't be determined,
+// the file is assumed to be shared.
+func (a *App) isSharedFile(info *model.FileInfo) bool {
+	if info.DedupOf != "" {
+		return true
+	}
+
+	count, err := a.Srv().Store.FileInfo().CountDedupReferences(info.Id)
+	if err != nil {
+		mlog.Warn("Unable to count the references to a stored file", mlog.String("file_id", info.Id), mlog.Err(err))
+		return true
+	}
+
+	return count > 0
+}
+
 func (a *App) GetFileInfo(fileID string) (*model.FileInfo, *model.AppError) {
 	fileInfo, err := a.Srv().Store.FileInfo().Get(fileID)
 	if err != nil {
@@ -1307,13 +1358,23 @@ func (a *App) SearchFilesInTeamForUser(c *request.Context, terms string, userId
 }
 
 func (a *App) ExtractContentFromFileInfo(fileInfo *model.FileInfo) error {
+	settings := a.Config().FileSettings
+	// Large files are skipped, their extraction is too costly for what search gains from it.
+	if fileInfo.Size > *settings.ExtractContentMaxSize {
+		mlog.Debug("Skipping content extraction of a file above the size limit", mlog.String("file_info_id", fileInfo.Id), mlog.Int64("size", fileInfo.Size))
+		return nil
+	}
+
 	file, aerr := a.FileReader(fileInfo.Path)
 	if aerr != nil {
 		return errors.Wrap(aerr, "failed to open file for extract file content")
 	}
 	defer file.Close()
 	text, err := docextractor.Extract(fileInfo.Name, file, docextractor.ExtractSettings{
-		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
+		ArchiveRecursion:       *settings.ArchiveRecursion,
+		TikaURL:                *settings.ExtractContentTikaURL,
+		DisablePDF:             !*settings.ExtractPDFContent,
+		DisableOfficeDocuments: !*settings.ExtractDocumentContent,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to extract file content")
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// maxCaptionedImageSize is the size of the largest image sent to the image captioning provider.
+const maxCaptionedImageSize = 20 * 1024 * 1024 // 20MB (IEC)
+
+// generateImageAltTextAsync generates the alt text of an uploaded image in the background, when
+// an image captioning provider is registered and enabled.
+func (a *App) generateImageAltTextAsync(fileInfo *model.FileInfo) {
+	if a.ImageCaption() == nil || !*a.Config().FileSettings.GenerateImageAltText {
+		return
+	}
+
+	if !fileInfo.IsImage() || fileInfo.Size > maxCaptionedImageSize {
+		return
+	}
+
+	infoCopy := *fileInfo
+	a.Srv().Go(func() {
+		if appErr := a.generateImageAltText(&infoCopy); appErr != nil {
+			mlog.Warn("Failed to generate the alt text of an image", mlog.String("file_id", infoCopy.Id), mlog.Err(appErr))
+		}
+	})
+}
+
+// generateImageAltText describes the image with the registered image captioning provider, and
+// saves the description as the alt text of the file unless its author has set one.
+func (a *App) generateImageAltText(fileInfo *model.FileInfo) *model.AppError {
+	provider := a.ImageCaption()
+	if provider == nil {
+		return nil
+	}
+
+	file, appErr := a.FileReader(fileInfo.Path)
+	if appErr != nil {
+		return appErr
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return model.NewAppError("generateImageAltText", "api.file.read_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	caption, appErr := provider.Caption(fileInfo, data)
+	if appErr != nil {
+		return appErr
+	}
+
+	caption = truncateAltText(strings.TrimSpace(caption))
+	if caption == "" {
+		return nil
+	}
+
+	return a.saveFileAltText(fileInfo.Id, caption, true)
+}
+
+// SetFileAltText sets the alt text of an image, replacing any generated one. An empty alt text
+// removes it.
+func (a *App) SetFileAltText(fileInfo *model.FileInfo, altText string) (*model.FileInfo, *model.AppError) {
+	if !fileInfo.IsImage() {
+		return nil, model.NewAppError("SetFileAltText", "app.file_info.alt_text.not_image.app_error", nil, "file_id="+fileInfo.Id, http.StatusBadRequest)
+	}
+
+	altText = strings.TrimSpace(altText)
+	if utf8.RuneCountInString(altText) > model.FileInfoAltTextMaxRunes {
+		return nil, model.NewAppError("SetFileAltText", "model.file_info.is_valid.alt_text.app_error", map[string]interface{}{"Max": model.FileInfoAltTextMaxRunes}, "file_id="+fileInfo.Id, http.StatusBadRequest)
+	}
+
+	if appErr := a.saveFileAltText(fileInfo.Id, altText, false); appErr != nil {
+		return nil, appErr
+	}
+
+	updated, err := a.Srv().Store.FileInfo().GetFromMaster(fileInfo.Id)
+	if err != nil {
+		return nil, model.NewAppError("SetFileAltText", "app.file_info.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return updated, nil
+}
+
+// saveFileAltText saves the alt text of a file and, if the file is attached to a post, sends the
+// post again to the clients so that they show it.
+func (a *App) saveFileAltText(fileID, altText string, generated bool) *model.AppError {
+	if err := a.Srv().Store.FileInfo().SetAltText(fileID, altText, generated); err != nil {
+		return model.NewAppError("saveFileAltText", "app.file_info.set_alt_text.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// The file is read again, it may have been attached to a post while it was captioned.
+	fileInfo, err := a.Srv().Store.FileInfo().GetFromMaster(fileID)
+	if err != nil {
+		return model.NewAppError("saveFileAltText", "app.file_info.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if fileInfo.PostId == "" {
+		return nil
+	}
+
+	a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(fileInfo.PostId, false)
+
+	post, err := a.Srv().Store.Post().GetSingle(fileInfo.PostId, false)
+	if err != nil {
+		return model.NewAppError("saveFileAltText", "app.post.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.invalidateCacheForChannelPosts(post.ChannelId)
+
+	message := model.NewWebSocketEvent(model.WebsocketEventPostEdited, "", post.ChannelId, "", nil)
+	postJSON, jsonErr := a.PreparePostForClient(post, false, true).ToJSON()
+	if jsonErr != nil {
+		return model.NewAppError("saveFileAltText", "app.post.marshal.app_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	message.Add("post", postJSON)
+	a.Publish(message)
+
+	return nil
+}
+
+// truncateAltText shortens a generated alt text to the longest one that can be saved.
+func truncateAltText(altText string) string {
+	if utf8.RuneCountInString(altText) <= model.FileInfoAltTextMaxRunes {
+		return altText
+	}
+
+	return string([]rune(altText)[:model.FileInfoAltTextMaxRunes])
+}
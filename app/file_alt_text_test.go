@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/einterfaces/mocks"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestFileAltText(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	imageData := buf.Bytes()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.GenerateImageAltText = true })
+	defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.GenerateImageAltText = false })
+
+	imageCaption := &mocks.ImageCaptionInterface{}
+	imageCaption.On("Caption", mock.AnythingOfType("*model.FileInfo"), mock.AnythingOfType("[]uint8")).Return("  a black square  ", nil)
+	th.App.Channels().ImageCaption = imageCaption
+	defer func() { th.App.Channels().ImageCaption = nil }()
+
+	t.Run("uploaded images are captioned", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "square.png", imageData)
+		require.Nil(t, appErr)
+
+		require.Eventually(t, func() bool {
+			saved, err := th.App.Srv().Store.FileInfo().Get(info.Id)
+			return err == nil && saved.AltText == "a black square" && saved.AltTextGenerated
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("the author's alt text replaces the generated one", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "square.png", imageData)
+		require.Nil(t, appErr)
+
+		updated, appErr := th.App.SetFileAltText(info, "the logo of the project")
+		require.Nil(t, appErr)
+		assert.Equal(t, "the logo of the project", updated.AltText)
+		assert.False(t, updated.AltTextGenerated)
+
+		require.Nil(t, th.App.generateImageAltText(info))
+		saved, err := th.App.Srv().Store.FileInfo().Get(info.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "the logo of the project", saved.AltText)
+	})
+
+	t.Run("only images have an alt text", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "notes.txt", []byte("notes"))
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.SetFileAltText(info, "notes")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("generated alt text is truncated", func(t *testing.T) {
+		assert.Equal(t, model.FileInfoAltTextMaxRunes, len([]rune(truncateAltText(strings.Repeat("é", model.FileInfoAltTextMaxRunes+10)))))
+		assert.Equal(t, "short", truncateAltText("short"))
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// fileRetentionBatchSize is how many expired FileInfos are read at once.
+const fileRetentionBatchSize = 1000
+
+// DeleteExpiredPolicyFiles deletes the file attachments of the posts affected by a retention
+// policy whose file duration has passed at now, along with their stored files. The posts are
+// kept, the names of their deleted files being recorded in their props. Failing to delete a
+// file is counted in the report rather than failing the deletion.
+func (a *App) DeleteExpiredPolicyFiles(now int64) (*model.FileRetentionReport, *model.AppError) {
+	report := &model.FileRetentionReport{}
+	updatedPosts := map[string]bool{}
+
+	afterID := ""
+	for {
+		infos, err := a.Srv().Store.FileInfo().GetExpiredForRetentionPolicies(now, afterID, fileRetentionBatchSize)
+		if err != nil {
+			return report, model.NewAppError("DeleteExpiredPolicyFiles", "app.file_info.get_expired.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(infos) == 0 {
+			report.Posts = len(updatedPosts)
+			return report, nil
+		}
+
+		deletedByPost := map[string][]*model.FileInfo{}
+		for _, info := range infos {
+			afterID = info.Id
+
+			// The FileInfo is kept as long as its stored file is reused by other FileInfos.
+			ownsFile := info.DedupOf == ""
+			if ownsFile && a.isSharedFile(info) {
+				report.SharedFileInfos++
+				continue
+			}
+
+			if ownsFile {
+				if failed := a.removeStoredFiles(info.Path, info.ThumbnailPath, info.PreviewPath); failed > 0 {
+					report.Errors += failed
+					continue
+				}
+				report.ReclaimedBytes += info.Size
+			}
+
+			if err := a.Srv().Store.FileInfo().PermanentDelete(info.Id); err != nil {
+				mlog.Warn("Failed to delete expired FileInfo", mlog.String("file_id", info.Id), mlog.Err(err))
+				report.Errors++
+				continue
+			}
+			report.FileInfos++
+			deletedByPost[info.PostId] = append(deletedByPost[info.PostId], info)
+		}
+
+		for postID, deleted := range deletedByPost {
+			if appErr := a.detachDeletedFiles(postID, deleted); appErr != nil {
+				mlog.Warn("Failed to detach the expired files of a post", mlog.String("post_id", postID), mlog.Err(appErr))
+				report.Errors++
+				continue
+			}
+			updatedPosts[postID] = true
+		}
+	}
+}
+
+// detachDeletedFiles removes the deleted files from the file ids of a post, recording their
+// names in its props in their place.
+func (a *App) detachDeletedFiles(postID string, deleted []*model.FileInfo) *model.AppError {
+	post, err := a.Srv().Store.Post().GetSingle(postID, true)
+	if err != nil {
+		return model.NewAppError("detachDeletedFiles", "app.post.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	deletedIDs := make(map[string]bool, len(deleted))
+	for _, info := range deleted {
+		deletedIDs[info.Id] = true
+	}
+
+	fileIDs := model.StringArray{}
+	for _, fileID := range post.FileIds {
+		if !deletedIDs[fileID] {
+			fileIDs = append(fileIDs, fileID)
+		}
+	}
+
+	names := []string{}
+	if previous, ok := post.GetProp(model.PostPropsRetentionDeletedFiles).([]interface{}); ok {
+		for _, name := range previous {
+			if s, ok := name.(string); ok {
+				names = append(names, s)
+			}
+		}
+	} else if previous, ok := post.GetProp(model.PostPropsRetentionDeletedFiles).([]string); ok {
+		names = append(names, previous...)
+	}
+	for _, info := range deleted {
+		names = append(names, info.Name)
+	}
+
+	post.FileIds = fileIDs
+	post.AddProp(model.PostPropsRetentionDeletedFiles, names)
+
+	updated, err := a.Srv().Store.Post().Overwrite(post)
+	if err != nil {
+		return model.NewAppError("detachDeletedFiles", "app.post.overwrite.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(postID, false)
+	a.invalidateCacheForChannelPosts(updated.ChannelId)
+
+	if updated.DeleteAt == 0 {
+		message := model.NewWebSocketEvent(model.WebsocketEventPostEdited, "", updated.ChannelId, "", nil)
+		postJSON, jsonErr := a.PreparePostForClient(updated, false, true).ToJSON()
+		if jsonErr != nil {
+			return model.NewAppError("detachDeletedFiles", "app.post.marshal.app_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+		}
+		message.Add("post", postJSON)
+		a.Publish(message)
+	}
+
+	return nil
+}
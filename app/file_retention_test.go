@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestDeleteExpiredPolicyFiles(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	policy, err := th.App.Srv().Store.RetentionPolicy().Save(&model.RetentionPolicyWithTeamAndChannelIDs{
+		RetentionPolicy: model.RetentionPolicy{
+			DisplayName:      "Files only",
+			PostDurationDays: model.NewInt64(-1),
+			FileDurationDays: model.NewInt64(1),
+		},
+		ChannelIDs: []string{th.BasicChannel.Id},
+	})
+	require.NoError(t, err)
+	defer th.App.Srv().Store.RetentionPolicy().Delete(policy.ID)
+
+	fileID := model.NewId()
+	path := "20000101/teams/" + th.BasicTeam.Id + "/channels/" + th.BasicChannel.Id + "/users/" + th.BasicUser.Id + "/" + fileID + "/file.txt"
+	defer th.App.FileBackend().RemoveDirectory("20000101")
+	_, appErr := th.App.WriteFile(strings.NewReader("abc"), path)
+	require.Nil(t, appErr)
+
+	info, err := th.App.Srv().Store.FileInfo().Save(&model.FileInfo{
+		Id:        fileID,
+		CreatorId: th.BasicUser.Id,
+		Path:      path,
+		Name:      "file.txt",
+		Size:      3,
+	})
+	require.NoError(t, err)
+
+	post, appErr := th.App.CreatePost(th.Context, &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "message with a file",
+		FileIds:   []string{info.Id},
+	}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	t.Run("files within the file duration are kept", func(t *testing.T) {
+		report, appErr := th.App.DeleteExpiredPolicyFiles(model.GetMillis())
+		require.Nil(t, appErr)
+		assert.Equal(t, 0, report.FileInfos)
+
+		exists, appErr := th.App.FileExists(path)
+		require.Nil(t, appErr)
+		assert.True(t, exists)
+	})
+
+	t.Run("expired files are deleted and the message kept", func(t *testing.T) {
+		report, appErr := th.App.DeleteExpiredPolicyFiles(model.GetMillis() + 2*model.DayInMilliseconds)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, report.FileInfos)
+		assert.Equal(t, 1, report.Posts)
+		assert.Equal(t, int64(3), report.ReclaimedBytes)
+
+		exists, appErr := th.App.FileExists(path)
+		require.Nil(t, appErr)
+		assert.False(t, exists)
+
+		_, err := th.App.Srv().Store.FileInfo().Get(info.Id)
+		require.Error(t, err)
+
+		updated, appErr := th.App.GetSinglePost(post.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "message with a file", updated.Message)
+		assert.Empty(t, updated.FileIds)
+		assert.Equal(t, []interface{}{"file.txt"}, updated.GetProp(model.PostPropsRetentionDeletedFiles))
+	})
+}
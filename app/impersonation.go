@@ -0,0 +1,261 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// Impersonation requests are kept in the Tokens store, keyed by their id, until they expire.
+// The audit trail of the requests and of the sessions is kept by the audit logs.
+
+func (a *App) checkImpersonationEnabled(where string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableImpersonation {
+		return model.NewAppError(where, "app.impersonation.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+// RequestImpersonation creates a request to impersonate a user. The request is approved right
+// away if the requester overrides the consent of the user and the policy allows it, otherwise
+// the user is asked for consent.
+func (a *App) RequestImpersonation(c *request.Context, impersonation *model.ImpersonationRequest) (*model.ImpersonationRequest, *model.AppError) {
+	if appErr := a.checkImpersonationEnabled("RequestImpersonation"); appErr != nil {
+		return nil, appErr
+	}
+
+	if impersonation.PolicyOverride && !*a.Config().ServiceSettings.ImpersonationAllowPolicyOverride {
+		return nil, model.NewAppError("RequestImpersonation", "app.impersonation.policy_override_not_allowed.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if appErr := impersonation.IsValid(*a.Config().ServiceSettings.ImpersonationMaxSessionMinutes); appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(impersonation.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.DeleteAt != 0 || user.IsBot {
+		return nil, model.NewAppError("RequestImpersonation", "app.impersonation.invalid_user.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	impersonation.Id = model.NewRandomString(model.TokenSize)
+	impersonation.CreateAt = model.GetMillis()
+	impersonation.ConsentAt = 0
+	impersonation.SessionId = ""
+	impersonation.ExpiresAt = 0
+	impersonation.Status = model.ImpersonationStatusPending
+	if impersonation.PolicyOverride {
+		impersonation.Status = model.ImpersonationStatusApproved
+	}
+
+	if appErr := a.saveImpersonationRequest(impersonation); appErr != nil {
+		return nil, appErr
+	}
+
+	a.publishImpersonationRequest(impersonation)
+
+	return impersonation, nil
+}
+
+// GetImpersonationRequest returns a request which has not expired.
+func (a *App) GetImpersonationRequest(id string) (*model.ImpersonationRequest, *model.AppError) {
+	token, err := a.Srv().Store.Token().GetByToken(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetImpersonationRequest", "app.impersonation.not_found.app_error", nil, err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("GetImpersonationRequest", "app.impersonation.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if token.Type != TokenTypeImpersonation {
+		return nil, model.NewAppError("GetImpersonationRequest", "app.impersonation.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	impersonation, appErr := impersonationRequestFromToken(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if impersonation.IsExpired() {
+		return nil, model.NewAppError("GetImpersonationRequest", "app.impersonation.not_found.app_error", nil, "expired", http.StatusNotFound)
+	}
+
+	return impersonation, nil
+}
+
+// GetImpersonationRequestsForUser returns the requests to impersonate a user which have not
+// expired, so that the user can review them.
+func (a *App) GetImpersonationRequestsForUser(userID string) ([]*model.ImpersonationRequest, *model.AppError) {
+	tokens, err := a.Srv().Store.Token().GetAllTokensByType(TokenTypeImpersonation)
+	if err != nil {
+		return nil, model.NewAppError("GetImpersonationRequestsForUser", "app.impersonation.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	impersonations := []*model.ImpersonationRequest{}
+	for _, token := range tokens {
+		impersonation, appErr := impersonationRequestFromToken(token)
+		if appErr != nil {
+			mlog.Warn("Skipping invalid impersonation request", mlog.Err(appErr))
+			continue
+		}
+		if impersonation.UserId == userID && !impersonation.IsExpired() {
+			impersonations = append(impersonations, impersonation)
+		}
+	}
+
+	return impersonations, nil
+}
+
+// AnswerImpersonationRequest records the consent, or refusal, of the user to be impersonated.
+func (a *App) AnswerImpersonationRequest(c *request.Context, impersonation *model.ImpersonationRequest, consent bool) (*model.ImpersonationRequest, *model.AppError) {
+	if impersonation.Status != model.ImpersonationStatusPending {
+		return nil, model.NewAppError("AnswerImpersonationRequest", "app.impersonation.not_pending.app_error", nil, "status="+impersonation.Status, http.StatusBadRequest)
+	}
+
+	impersonation.ConsentAt = model.GetMillis()
+	impersonation.Status = model.ImpersonationStatusDenied
+	if consent {
+		impersonation.Status = model.ImpersonationStatusApproved
+	}
+
+	if appErr := a.updateImpersonationRequest(impersonation); appErr != nil {
+		return nil, appErr
+	}
+
+	a.publishImpersonationRequest(impersonation)
+
+	return impersonation, nil
+}
+
+// StartImpersonation creates the read-only session of an approved request. A request can only
+// be used for a single session, which expires after the duration of the request and is never
+// extended.
+func (a *App) StartImpersonation(c *request.Context, impersonation *model.ImpersonationRequest) (*model.ImpersonationSession, *model.AppError) {
+	if appErr := a.checkImpersonationEnabled("StartImpersonation"); appErr != nil {
+		return nil, appErr
+	}
+
+	if impersonation.Status != model.ImpersonationStatusApproved {
+		return nil, model.NewAppError("StartImpersonation", "app.impersonation.not_approved.app_error", nil, "status="+impersonation.Status, http.StatusForbidden)
+	}
+
+	user, appErr := a.GetUser(impersonation.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.DeleteAt != 0 {
+		return nil, model.NewAppError("StartImpersonation", "app.impersonation.invalid_user.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	session := &model.Session{
+		UserId:    user.Id,
+		Roles:     user.GetRawRoles(),
+		ExpiresAt: model.GetMillis() + int64(impersonation.DurationMinutes)*60*1000,
+	}
+	session.AddProp(model.SessionPropType, model.SessionTypeImpersonation)
+	session.AddProp(model.SessionPropImpersonatorId, impersonation.RequesterId)
+	session.AddProp(model.SessionPropImpersonationId, impersonation.Id)
+	session.AddProp(model.SessionPropIsGuest, "false")
+	if user.IsGuest() {
+		session.AddProp(model.SessionPropIsGuest, "true")
+	}
+
+	session, appErr = a.CreateSession(session)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	impersonation.Status = model.ImpersonationStatusActive
+	impersonation.SessionId = session.Id
+	impersonation.ExpiresAt = session.ExpiresAt
+	if appErr := a.updateImpersonationRequest(impersonation); appErr != nil {
+		if err := a.RevokeSession(session); err != nil {
+			mlog.Warn("Failed to revoke impersonation session", mlog.String("session_id", session.Id), mlog.Err(err))
+		}
+		return nil, appErr
+	}
+
+	a.publishImpersonationRequest(impersonation)
+
+	return &model.ImpersonationSession{Request: impersonation, Token: session.Token}, nil
+}
+
+// RevokeImpersonation ends a request, and its session if it was started.
+func (a *App) RevokeImpersonation(c *request.Context, impersonation *model.ImpersonationRequest) (*model.ImpersonationRequest, *model.AppError) {
+	if impersonation.SessionId != "" {
+		if appErr := a.RevokeSessionById(impersonation.SessionId); appErr != nil && appErr.StatusCode != http.StatusBadRequest {
+			return nil, appErr
+		}
+	}
+
+	impersonation.Status = model.ImpersonationStatusRevoked
+	if appErr := a.updateImpersonationRequest(impersonation); appErr != nil {
+		return nil, appErr
+	}
+
+	a.publishImpersonationRequest(impersonation)
+
+	return impersonation, nil
+}
+
+func impersonationRequestFromToken(token *model.Token) (*model.ImpersonationRequest, *model.AppError) {
+	var impersonation model.ImpersonationRequest
+	if err := json.Unmarshal([]byte(token.Extra), &impersonation); err != nil {
+		return nil, model.NewAppError("impersonationRequestFromToken", "app.impersonation.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &impersonation, nil
+}
+
+func (a *App) saveImpersonationRequest(impersonation *model.ImpersonationRequest) *model.AppError {
+	extra, err := json.Marshal(impersonation)
+	if err != nil {
+		return model.NewAppError("saveImpersonationRequest", "app.impersonation.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	token := &model.Token{
+		Token:    impersonation.Id,
+		CreateAt: impersonation.CreateAt,
+		Type:     TokenTypeImpersonation,
+		Extra:    string(extra),
+	}
+	if err := a.Srv().Store.Token().Save(token); err != nil {
+		return model.NewAppError("saveImpersonationRequest", "app.impersonation.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// updateImpersonationRequest replaces the stored request, tokens having no update.
+func (a *App) updateImpersonationRequest(impersonation *model.ImpersonationRequest) *model.AppError {
+	if err := a.Srv().Store.Token().Delete(impersonation.Id); err != nil {
+		return model.NewAppError("updateImpersonationRequest", "app.impersonation.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return a.saveImpersonationRequest(impersonation)
+}
+
+// publishImpersonationRequest notifies the user and the requester of the state of a request.
+func (a *App) publishImpersonationRequest(impersonation *model.ImpersonationRequest) {
+	impersonationJSON, err := json.Marshal(impersonation)
+	if err != nil {
+		mlog.Warn("Failed to encode impersonation request to JSON", mlog.Err(err))
+		return
+	}
+
+	for _, userID := range []string{impersonation.UserId, impersonation.RequesterId} {
+		message := model.NewWebSocketEvent(model.WebsocketEventImpersonationRequestUpdated, "", "", userID, nil)
+		message.Add("impersonation", string(impersonationJSON))
+		a.Publish(message)
+	}
+}
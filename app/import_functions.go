@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha1"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v6/app/request"
 	"github.com/mattermost/mattermost-server/v6/app/teams"
@@ -1204,6 +1206,8 @@ func (a *App) importAttachment(c *request.Context, data *AttachmentImportData, p
 		return nil, model.NewAppError("BulkImport", "app.import.attachment.read_file_data.error", map[string]interface{}{"FilePath": *data.Path}, "", http.StatusBadRequest)
 	}
 
+	contentHash := fmt.Sprintf("%x", sha256.Sum256(fileData))
+
 	// Go over existing files in the post and see if there already exists a file with the same name, size and hash. If so - skip it
 	if post.Id != "" {
 		oldFiles, err := a.GetFileInfosForPost(post.Id, true)
@@ -1214,6 +1218,13 @@ func (a *App) importAttachment(c *request.Context, data *AttachmentImportData, p
 			if oldFile.Name != path.Base(name) || oldFile.Size != int64(len(fileData)) {
 				continue
 			}
+			if oldFile.ContentHash != "" {
+				if oldFile.ContentHash == contentHash {
+					mlog.Info("Skipping uploading of file because name already exists", mlog.Any("file_name", name))
+					return oldFile, nil
+				}
+				continue
+			}
 			// check md5
 			newHash := sha1.Sum(fileData)
 			oldFileData, err := a.GetFile(oldFile.Id)
@@ -1229,6 +1240,14 @@ func (a *App) importAttachment(c *request.Context, data *AttachmentImportData, p
 		}
 	}
 
+	// Files with the same content as one imported earlier in the channel, in this import or a previous one, reuse its stored copy.
+	if fileInfo, appErr := a.reuseImportedFile(name, contentHash, int64(len(fileData)), post, timestamp); appErr != nil {
+		return nil, appErr
+	} else if fileInfo != nil {
+		mlog.Info("Reusing stored file with the same content", mlog.String("file_name", name), mlog.String("dedup_of", fileInfo.DedupOf))
+		return fileInfo, nil
+	}
+
 	mlog.Info("Uploading file with name", mlog.String("file_name", name))
 
 	fileInfo, appErr := a.DoUploadFile(c, timestamp, teamID, post.ChannelId, post.UserId, name, fileData)
@@ -1241,9 +1260,63 @@ func (a *App) importAttachment(c *request.Context, data *AttachmentImportData, p
 		a.HandleImages([]string{fileInfo.PreviewPath}, []string{fileInfo.ThumbnailPath}, [][]byte{fileData})
 	}
 
+	// Without the hash the file is still imported, it just can't be reused by later imports.
+	fileInfo.ContentHash = contentHash
+	if _, err := a.Srv().Store.FileInfo().Upsert(fileInfo); err != nil {
+		mlog.Warn("Failed to record the content hash of an imported file", mlog.String("file_id", fileInfo.Id), mlog.Err(err))
+	}
+
 	return fileInfo, nil
 }
 
+// reuseImportedFile returns a new FileInfo for an attachment pointing at the stored file of an
+// earlier imported file with the same content in the post's channel, or nil if there is no such
+// file. Files aren't shared across channels so that their stored path keeps matching where they
+// were posted.
+func (a *App) reuseImportedFile(name, contentHash string, size int64, post *model.Post, timestamp time.Time) (*model.FileInfo, *model.AppError) {
+	source, err := a.Srv().Store.FileInfo().GetByContentHash(contentHash, post.ChannelId)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		return nil, nil
+	} else if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.file_info.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if source.Size != size {
+		return nil, nil
+	}
+
+	// The stored file may have been removed from under the FileInfo, in which case it's uploaded again.
+	if exists, appErr := a.FileExists(source.Path); appErr != nil || !exists {
+		return nil, nil
+	}
+
+	named := model.NewInfo(path.Base(name))
+	info := *source
+	info.Id = model.NewId()
+	info.CreatorId = post.UserId
+	info.PostId = ""
+	info.CreateAt = timestamp.UnixNano() / int64(time.Millisecond)
+	info.UpdateAt = 0
+	info.DeleteAt = 0
+	info.Name = named.Name
+	info.Extension = named.Extension
+	info.RemoteId = nil
+	info.DedupOf = source.Id
+
+	if _, err := a.Srv().Store.FileInfo().Save(&info); err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("BulkImport", "app.file_info.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return &info, nil
+}
+
 type postAndData struct {
 	post           *model.Post
 	postData       *PostImportData
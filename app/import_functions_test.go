@@ -4358,6 +4358,55 @@ func TestImportPostAndRepliesWithAttachments(t *testing.T) {
 	})
 }
 
+func TestImportAttachmentDeduplication(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	testsDir, _ := fileutils.FindDir("tests")
+	testImage := filepath.Join(testsDir, "test.png")
+
+	importPost := func(t *testing.T, username string, createAt int64) {
+		data := LineImportWorkerData{
+			LineImportData{
+				Post: &PostImportData{
+					Team:        &th.BasicTeam.Name,
+					Channel:     &th.BasicChannel.Name,
+					User:        &username,
+					Message:     ptrStr("Message with attachment"),
+					CreateAt:    &createAt,
+					Attachments: &[]AttachmentImportData{{Path: &testImage}},
+				},
+			},
+			1,
+		}
+		errLine, err := th.App.importMultiplePostLines(th.Context, []LineImportWorkerData{data}, false)
+		require.Nil(t, err)
+		require.Equal(t, 0, errLine)
+	}
+
+	importPost(t, th.BasicUser.Username, model.GetMillis())
+	attachments := GetAttachments(th.BasicUser.Id, th, t)
+	require.Len(t, attachments, 1)
+	source := attachments[0]
+	assert.NotEmpty(t, source.ContentHash)
+	assert.Empty(t, source.DedupOf)
+
+	t.Run("same content in the same channel reuses the stored file", func(t *testing.T) {
+		importPost(t, th.BasicUser2.Username, model.GetMillis()+1)
+
+		attachments := GetAttachments(th.BasicUser2.Id, th, t)
+		require.Len(t, attachments, 1)
+		assert.NotEqual(t, source.Id, attachments[0].Id)
+		assert.Equal(t, source.Id, attachments[0].DedupOf)
+		assert.Equal(t, source.Path, attachments[0].Path)
+		assert.Equal(t, source.ContentHash, attachments[0].ContentHash)
+		AssertFileIdsInPost(attachments, th, t)
+
+		assert.True(t, th.App.isSharedFile(source))
+		assert.True(t, th.App.isSharedFile(attachments[0]))
+	})
+}
+
 func TestImportDirectPostWithAttachments(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
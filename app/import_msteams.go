@@ -0,0 +1,568 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jaytaylor/html2text"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// A Microsoft Teams export package is a zip archive holding the Microsoft Graph representation of
+// the exported data:
+//
+//	users.json
+//	teams/<team id>/team.json
+//	teams/<team id>/members.json
+//	teams/<team id>/channels/<channel id>/channel.json
+//	teams/<team id>/channels/<channel id>/members.json (private channels only)
+//	teams/<team id>/channels/<channel id>/messages.json
+//	files/<attachment id>/<file name>
+const (
+	msTeamsUsersFile    = "users.json"
+	msTeamsTeamsDir     = "teams"
+	msTeamsChannelsDir  = "channels"
+	msTeamsFilesDir     = "files"
+	msTeamsTeamFile     = "team.json"
+	msTeamsChannelFile  = "channel.json"
+	msTeamsMembersFile  = "members.json"
+	msTeamsMessagesFile = "messages.json"
+
+	msTeamsGeneralChannel    = "General"
+	msTeamsVisibilityPublic  = "public"
+	msTeamsMembershipPrivate = "private"
+	msTeamsRoleOwner         = "owner"
+	msTeamsMessageTypeText   = "message"
+	msTeamsContentTypeHTML   = "html"
+)
+
+var msTeamsMentionRegexp = regexp.MustCompile(`<at id="(\d+)">.*?</at>`)
+
+type msTeamsUser struct {
+	Id                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	GivenName         string `json:"givenName"`
+	Surname           string `json:"surname"`
+	Mail              string `json:"mail"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	JobTitle          string `json:"jobTitle"`
+	AccountEnabled    *bool  `json:"accountEnabled"`
+}
+
+type msTeamsTeam struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+}
+
+type msTeamsMember struct {
+	UserId string   `json:"userId"`
+	Roles  []string `json:"roles"`
+}
+
+type msTeamsChannel struct {
+	Id             string `json:"id"`
+	DisplayName    string `json:"displayName"`
+	Description    string `json:"description"`
+	MembershipType string `json:"membershipType"`
+}
+
+type msTeamsIdentity struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type msTeamsMessage struct {
+	Id                 string     `json:"id"`
+	ReplyToId          string     `json:"replyToId"`
+	MessageType        string     `json:"messageType"`
+	CreatedDateTime    time.Time  `json:"createdDateTime"`
+	LastEditedDateTime *time.Time `json:"lastEditedDateTime"`
+	DeletedDateTime    *time.Time `json:"deletedDateTime"`
+	From               *struct {
+		User *msTeamsIdentity `json:"user"`
+	} `json:"from"`
+	Body struct {
+		ContentType string `json:"contentType"`
+		Content     string `json:"content"`
+	} `json:"body"`
+	Attachments []struct {
+		Id          string `json:"id"`
+		ContentType string `json:"contentType"`
+		Name        string `json:"name"`
+	} `json:"attachments"`
+	Mentions []struct {
+		Id        int `json:"id"`
+		Mentioned struct {
+			User *msTeamsIdentity `json:"user"`
+		} `json:"mentioned"`
+	} `json:"mentions"`
+}
+
+// msTeamsConverter turns the content of a Microsoft Teams export package into bulk import lines,
+// recording what it had to leave out in the report.
+type msTeamsConverter struct {
+	files  map[string]*zip.File
+	report *model.MSTeamsImportReport
+
+	// usernames maps Microsoft Teams user ids to the username they are imported with.
+	usernames map[string]string
+	users     map[string]*UserImportData
+}
+
+func newMSTeamsConverter(files map[string]*zip.File, report *model.MSTeamsImportReport) *msTeamsConverter {
+	return &msTeamsConverter{
+		files:     files,
+		report:    report,
+		usernames: map[string]string{},
+		users:     map[string]*UserImportData{},
+	}
+}
+
+func (mc *msTeamsConverter) warn(translationID string, params map[string]interface{}) {
+	mc.report.Warnings = append(mc.report.Warnings, i18n.T(translationID, params))
+}
+
+func (mc *msTeamsConverter) decode(name string, v interface{}) *model.AppError {
+	file, ok := mc.files[name]
+	if !ok {
+		return model.NewAppError("MSTeamsImport", "app.import.msteams.missing_file.error", map[string]interface{}{"Filename": name}, "", http.StatusBadRequest)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return model.NewAppError("MSTeamsImport", "app.import.msteams.open_file.error", map[string]interface{}{"Filename": name}, err.Error(), http.StatusBadRequest)
+	}
+	defer reader.Close()
+
+	if err := json.NewDecoder(reader).Decode(v); err != nil {
+		return model.NewAppError("MSTeamsImport", "app.import.msteams.decode_file.error", map[string]interface{}{"Filename": name}, err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// msTeamsUniqueName returns name, or name with the first numeric suffix not already in taken, and
+// records it as taken.
+func msTeamsUniqueName(name string, taken map[string]bool) string {
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		suffix := fmt.Sprintf("-%d", i)
+		if len(name)+len(suffix) > model.ChannelNameMaxLength {
+			name = name[:model.ChannelNameMaxLength-len(suffix)]
+		}
+		candidate = name + suffix
+	}
+	taken[candidate] = true
+	return candidate
+}
+
+// dirsIn returns the names of the directories directly under dir in the package.
+func (mc *msTeamsConverter) dirsIn(dir string) []string {
+	seen := map[string]bool{}
+	dirs := []string{}
+	for name := range mc.files {
+		if !strings.HasPrefix(name, dir+"/") {
+			continue
+		}
+		sub := strings.SplitN(strings.TrimPrefix(name, dir+"/"), "/", 2)
+		if len(sub) == 2 && !seen[sub[0]] {
+			seen[sub[0]] = true
+			dirs = append(dirs, sub[0])
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+func (mc *msTeamsConverter) convertUsers() *model.AppError {
+	var users []msTeamsUser
+	if appErr := mc.decode(msTeamsUsersFile, &users); appErr != nil {
+		return appErr
+	}
+
+	takenUsernames := map[string]bool{}
+	for _, user := range users {
+		email := user.Mail
+		if email == "" && strings.Contains(user.UserPrincipalName, "@") {
+			email = user.UserPrincipalName
+		}
+		if email == "" {
+			mc.warn("app.import.msteams.warning.user_without_email", map[string]interface{}{"Name": user.DisplayName})
+			continue
+		}
+
+		localPart := strings.SplitN(email, "@", 2)[0]
+		if strings.Contains(user.UserPrincipalName, "@") {
+			localPart = strings.SplitN(user.UserPrincipalName, "@", 2)[0]
+		}
+		username := msTeamsUniqueName(model.CleanUsername(localPart), takenUsernames)
+
+		userData := &UserImportData{
+			Username:  model.NewString(username),
+			Email:     model.NewString(strings.ToLower(email)),
+			FirstName: model.NewString(user.GivenName),
+			LastName:  model.NewString(user.Surname),
+			Position:  model.NewString(user.JobTitle),
+			Teams:     &[]UserTeamImportData{},
+		}
+		if user.AccountEnabled != nil && !*user.AccountEnabled {
+			userData.DeleteAt = model.NewInt64(model.GetMillis())
+		}
+
+		mc.usernames[user.Id] = username
+		mc.users[user.Id] = userData
+	}
+
+	return nil
+}
+
+// readMembers returns the roles of the members of a team or private channel, by user id, leaving
+// out users that weren't exported.
+func (mc *msTeamsConverter) readMembers(name string) (map[string]string, *model.AppError) {
+	var members []msTeamsMember
+	if appErr := mc.decode(name, &members); appErr != nil {
+		return nil, appErr
+	}
+
+	roles := map[string]string{}
+	for _, member := range members {
+		if _, ok := mc.users[member.UserId]; !ok {
+			continue
+		}
+		roles[member.UserId] = ""
+		for _, role := range member.Roles {
+			if role == msTeamsRoleOwner {
+				roles[member.UserId] = msTeamsRoleOwner
+			}
+		}
+	}
+
+	return roles, nil
+}
+
+// convertMessageText turns the body of a message into Mattermost markdown, replacing user
+// mentions with the username of the mentioned user.
+func (mc *msTeamsConverter) convertMessageText(message *msTeamsMessage) string {
+	content := message.Body.Content
+	if message.Body.ContentType != msTeamsContentTypeHTML {
+		return strings.TrimSpace(content)
+	}
+
+	mentioned := map[string]string{}
+	for _, mention := range message.Mentions {
+		if mention.Mentioned.User == nil {
+			continue
+		}
+		if username, ok := mc.usernames[mention.Mentioned.User.Id]; ok {
+			mentioned[fmt.Sprint(mention.Id)] = username
+		}
+	}
+	content = msTeamsMentionRegexp.ReplaceAllStringFunc(content, func(match string) string {
+		id := msTeamsMentionRegexp.FindStringSubmatch(match)[1]
+		if username, ok := mentioned[id]; ok {
+			return "@" + username
+		}
+		return match
+	})
+
+	text, err := html2text.FromString(content)
+	if err != nil {
+		mlog.Warn("Unable to convert Microsoft Teams message to text", mlog.String("message_id", message.Id), mlog.Err(err))
+		return strings.TrimSpace(content)
+	}
+	return strings.TrimSpace(text)
+}
+
+func (mc *msTeamsConverter) convertAttachments(message *msTeamsMessage) *[]AttachmentImportData {
+	attachments := []AttachmentImportData{}
+	for _, attachment := range message.Attachments {
+		if attachment.Name == "" {
+			continue
+		}
+
+		filePath := path.Join(msTeamsFilesDir, attachment.Id, attachment.Name)
+		if _, ok := mc.files[filePath]; !ok {
+			mc.warn("app.import.msteams.warning.missing_attachment", map[string]interface{}{"Filename": attachment.Name, "MessageId": message.Id})
+			continue
+		}
+
+		attachments = append(attachments, AttachmentImportData{Path: model.NewString(filePath)})
+		mc.report.Attachments++
+	}
+	return &attachments
+}
+
+func (mc *msTeamsConverter) convertMessages(name, teamName, channelName string) ([]*LineImportData, *model.AppError) {
+	var messages []msTeamsMessage
+	if appErr := mc.decode(name, &messages); appErr != nil {
+		return nil, appErr
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedDateTime.Before(messages[j].CreatedDateTime)
+	})
+
+	lines := []*LineImportData{}
+	roots := map[string]*PostImportData{}
+	for i := range messages {
+		message := &messages[i]
+		if message.MessageType != msTeamsMessageTypeText || message.DeletedDateTime != nil {
+			continue
+		}
+
+		if message.From == nil || message.From.User == nil {
+			mc.warn("app.import.msteams.warning.message_without_user", map[string]interface{}{"MessageId": message.Id})
+			continue
+		}
+
+		username, ok := mc.usernames[message.From.User.Id]
+		if !ok {
+			mc.warn("app.import.msteams.warning.message_unknown_user", map[string]interface{}{"MessageId": message.Id, "Name": message.From.User.DisplayName})
+			continue
+		}
+
+		text := mc.convertMessageText(message)
+		attachments := mc.convertAttachments(message)
+		if text == "" && len(*attachments) == 0 {
+			continue
+		}
+
+		createAt := model.GetMillisForTime(message.CreatedDateTime)
+		var editAt *int64
+		if message.LastEditedDateTime != nil {
+			editAt = model.NewInt64(model.GetMillisForTime(*message.LastEditedDateTime))
+		}
+
+		if message.ReplyToId != "" {
+			root, ok := roots[message.ReplyToId]
+			if !ok {
+				mc.warn("app.import.msteams.warning.reply_without_root", map[string]interface{}{"MessageId": message.Id})
+				continue
+			}
+			*root.Replies = append(*root.Replies, ReplyImportData{
+				User:        model.NewString(username),
+				Message:     model.NewString(text),
+				CreateAt:    model.NewInt64(createAt),
+				EditAt:      editAt,
+				Attachments: attachments,
+			})
+			mc.report.Replies++
+			continue
+		}
+
+		post := &PostImportData{
+			Team:        model.NewString(teamName),
+			Channel:     model.NewString(channelName),
+			User:        model.NewString(username),
+			Message:     model.NewString(text),
+			CreateAt:    model.NewInt64(createAt),
+			EditAt:      editAt,
+			Replies:     &[]ReplyImportData{},
+			Attachments: attachments,
+		}
+		roots[message.Id] = post
+		lines = append(lines, &LineImportData{Type: "post", Post: post})
+		mc.report.Posts++
+	}
+
+	return lines, nil
+}
+
+// convert returns the bulk import lines for the whole package, in the order the bulk import
+// expects them.
+func (mc *msTeamsConverter) convert() ([]*LineImportData, *model.AppError) {
+	if appErr := mc.convertUsers(); appErr != nil {
+		return nil, appErr
+	}
+
+	var teamLines, channelLines, postLines []*LineImportData
+	takenTeamNames := map[string]bool{}
+	for _, teamDir := range mc.dirsIn(msTeamsTeamsDir) {
+		teamPath := path.Join(msTeamsTeamsDir, teamDir)
+
+		var team msTeamsTeam
+		if appErr := mc.decode(path.Join(teamPath, msTeamsTeamFile), &team); appErr != nil {
+			return nil, appErr
+		}
+
+		teamMembers, appErr := mc.readMembers(path.Join(teamPath, msTeamsMembersFile))
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		teamName := msTeamsUniqueName(model.CleanTeamName(team.DisplayName), takenTeamNames)
+		teamType := model.TeamInvite
+		if team.Visibility == msTeamsVisibilityPublic {
+			teamType = model.TeamOpen
+		}
+		teamLines = append(teamLines, &LineImportData{
+			Type: "team",
+			Team: &TeamImportData{
+				Name:            model.NewString(teamName),
+				DisplayName:     model.NewString(team.DisplayName),
+				Type:            model.NewString(teamType),
+				Description:     model.NewString(team.Description),
+				AllowOpenInvite: model.NewBool(teamType == model.TeamOpen),
+			},
+		})
+		mc.report.Teams++
+
+		userChannels := map[string]*[]UserChannelImportData{}
+		for userID, role := range teamMembers {
+			roles := model.TeamUserRoleId
+			if role == msTeamsRoleOwner {
+				roles += " " + model.TeamAdminRoleId
+			}
+			channels := &[]UserChannelImportData{}
+			userChannels[userID] = channels
+			*mc.users[userID].Teams = append(*mc.users[userID].Teams, UserTeamImportData{
+				Name:     model.NewString(teamName),
+				Roles:    model.NewString(roles),
+				Channels: channels,
+			})
+		}
+
+		takenChannelNames := map[string]bool{}
+		for _, channelDir := range mc.dirsIn(path.Join(teamPath, msTeamsChannelsDir)) {
+			channelPath := path.Join(teamPath, msTeamsChannelsDir, channelDir)
+
+			var channel msTeamsChannel
+			if appErr := mc.decode(path.Join(channelPath, msTeamsChannelFile), &channel); appErr != nil {
+				return nil, appErr
+			}
+
+			// Every Microsoft Teams team has a General channel, which plays the part of Town Square.
+			channelName := model.CleanTeamName(channel.DisplayName)
+			if channel.DisplayName == msTeamsGeneralChannel {
+				channelName = model.DefaultChannelName
+			}
+			channelName = msTeamsUniqueName(channelName, takenChannelNames)
+
+			channelType := model.ChannelTypeOpen
+			channelMembers := teamMembers
+			if channel.MembershipType == msTeamsMembershipPrivate {
+				channelType = model.ChannelTypePrivate
+				if channelMembers, appErr = mc.readMembers(path.Join(channelPath, msTeamsMembersFile)); appErr != nil {
+					return nil, appErr
+				}
+			}
+
+			channelLines = append(channelLines, &LineImportData{
+				Type: "channel",
+				Channel: &ChannelImportData{
+					Team:        model.NewString(teamName),
+					Name:        model.NewString(channelName),
+					DisplayName: model.NewString(channel.DisplayName),
+					Type:        &channelType,
+					Purpose:     model.NewString(channel.Description),
+				},
+			})
+			mc.report.Channels++
+
+			for userID, role := range channelMembers {
+				channels, ok := userChannels[userID]
+				if !ok {
+					continue
+				}
+				roles := model.ChannelUserRoleId
+				if role == msTeamsRoleOwner {
+					roles += " " + model.ChannelAdminRoleId
+				}
+				*channels = append(*channels, UserChannelImportData{
+					Name:  model.NewString(channelName),
+					Roles: model.NewString(roles),
+				})
+			}
+
+			if _, ok := mc.files[path.Join(channelPath, msTeamsMessagesFile)]; !ok {
+				continue
+			}
+			lines, appErr := mc.convertMessages(path.Join(channelPath, msTeamsMessagesFile), teamName, channelName)
+			if appErr != nil {
+				return nil, appErr
+			}
+			postLines = append(postLines, lines...)
+		}
+	}
+
+	userIDs := make([]string, 0, len(mc.users))
+	for userID := range mc.users {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool {
+		return *mc.users[userIDs[i]].Username < *mc.users[userIDs[j]].Username
+	})
+
+	userLines := make([]*LineImportData, 0, len(userIDs))
+	for _, userID := range userIDs {
+		userLines = append(userLines, &LineImportData{Type: "user", User: mc.users[userID]})
+	}
+	mc.report.Users = len(userLines)
+
+	lines := []*LineImportData{{Type: "version", Version: model.NewInt(1)}}
+	lines = append(lines, teamLines...)
+	lines = append(lines, channelLines...)
+	lines = append(lines, userLines...)
+	lines = append(lines, postLines...)
+
+	return lines, nil
+}
+
+// MSTeamsImport imports the users, teams, channels, messages and files of a Microsoft Teams export
+// package through the bulk import. With dryRun set, the package is only converted and validated.
+// The returned report is filled in as far as the import got, even when it fails.
+func (a *App) MSTeamsImport(c *request.Context, zipReader *zip.Reader, dryRun bool, workers int) (*model.MSTeamsImportReport, *model.AppError) {
+	report := &model.MSTeamsImportReport{
+		DryRun:   dryRun,
+		Warnings: []string{},
+	}
+
+	files := make(map[string]*zip.File, len(zipReader.File))
+	for _, file := range zipReader.File {
+		// avoid "zip slip"
+		if strings.Contains(file.Name, "..") {
+			return report, model.NewAppError("MSTeamsImport", "app.import.msteams.open_file.error", map[string]interface{}{"Filename": file.Name}, "file path contains path traversal", http.StatusBadRequest)
+		}
+		files[file.Name] = file
+	}
+
+	lines, appErr := newMSTeamsConverter(files, report).convert()
+	if appErr != nil {
+		return report, appErr
+	}
+
+	var jsonl bytes.Buffer
+	encoder := json.NewEncoder(&jsonl)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return report, model.NewAppError("MSTeamsImport", "app.export.export_write_line.json_marshall.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if appErr, lineNumber := a.BulkImport(c, &jsonl, zipReader, dryRun, workers); appErr != nil {
+		translated := *appErr
+		translated.Translate(i18n.T)
+		report.Error = translated.Error()
+		report.ErrorLine = lineNumber
+		return report, appErr
+	}
+
+	return report, nil
+}
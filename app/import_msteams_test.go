@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func makeMSTeamsPackage(t *testing.T, files map[string]string) *zip.Reader {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return zipReader
+}
+
+var msTeamsTestPackage = map[string]string{
+	"users.json": `[
+		{"id": "u1", "displayName": "Alice Smith", "givenName": "Alice", "surname": "Smith", "mail": "Alice@example.com", "userPrincipalName": "alice@example.com", "accountEnabled": true},
+		{"id": "u2", "displayName": "Bob Jones", "givenName": "Bob", "surname": "Jones", "userPrincipalName": "bob@example.com", "accountEnabled": false},
+		{"id": "u3", "displayName": "No Mail"}
+	]`,
+	"teams/t1/team.json":    `{"id": "t1", "displayName": "Engineering", "description": "Builders", "visibility": "public"}`,
+	"teams/t1/members.json": `[{"userId": "u1", "roles": ["owner"]}, {"userId": "u2", "roles": []}, {"userId": "u3", "roles": []}]`,
+
+	"teams/t1/channels/c1/channel.json": `{"id": "c1", "displayName": "General", "membershipType": "standard"}`,
+	"teams/t1/channels/c1/messages.json": `[
+		{"id": "m2", "replyToId": "m1", "messageType": "message", "createdDateTime": "2021-01-01T10:05:00Z", "from": {"user": {"id": "u2", "displayName": "Bob Jones"}}, "body": {"contentType": "text", "content": "hi!"}},
+		{"id": "m1", "messageType": "message", "createdDateTime": "2021-01-01T10:00:00Z", "from": {"user": {"id": "u1", "displayName": "Alice Smith"}}, "body": {"contentType": "html", "content": "<p>Hello <at id=\"0\">Bob Jones</at></p>"}, "mentions": [{"id": 0, "mentioned": {"user": {"id": "u2"}}}], "attachments": [{"id": "a1", "contentType": "reference", "name": "notes.txt"}, {"id": "a2", "contentType": "reference", "name": "gone.txt"}]},
+		{"id": "m3", "messageType": "systemEventMessage", "createdDateTime": "2021-01-01T10:10:00Z", "body": {"contentType": "html", "content": ""}},
+		{"id": "m4", "messageType": "message", "createdDateTime": "2021-01-01T10:15:00Z", "deletedDateTime": "2021-01-01T10:16:00Z", "from": {"user": {"id": "u1"}}, "body": {"contentType": "text", "content": "oops"}},
+		{"id": "m5", "messageType": "message", "createdDateTime": "2021-01-01T10:20:00Z", "from": {"user": {"id": "u9", "displayName": "Stranger"}}, "body": {"contentType": "text", "content": "who am I"}}
+	]`,
+
+	"teams/t1/channels/c2/channel.json":  `{"id": "c2", "displayName": "Secret Plans", "membershipType": "private"}`,
+	"teams/t1/channels/c2/members.json":  `[{"userId": "u1", "roles": ["owner"]}]`,
+	"teams/t1/channels/c2/messages.json": `[]`,
+
+	"files/a1/notes.txt": "some notes",
+}
+
+func TestMSTeamsConverter(t *testing.T) {
+	zipReader := makeMSTeamsPackage(t, msTeamsTestPackage)
+	files := map[string]*zip.File{}
+	for _, file := range zipReader.File {
+		files[file.Name] = file
+	}
+
+	report := &model.MSTeamsImportReport{Warnings: []string{}}
+	lines, appErr := newMSTeamsConverter(files, report).convert()
+	require.Nil(t, appErr)
+
+	types := []string{}
+	for _, line := range lines {
+		types = append(types, line.Type)
+	}
+	assert.Equal(t, []string{"version", "team", "channel", "channel", "user", "user", "post"}, types)
+
+	team := lines[1].Team
+	assert.Equal(t, "engineering", *team.Name)
+	assert.Equal(t, model.TeamOpen, *team.Type)
+
+	assert.Equal(t, model.DefaultChannelName, *lines[2].Channel.Name)
+	assert.Equal(t, model.ChannelTypeOpen, *lines[2].Channel.Type)
+	assert.Equal(t, "secret-plans", *lines[3].Channel.Name)
+	assert.Equal(t, model.ChannelTypePrivate, *lines[3].Channel.Type)
+
+	alice := lines[4].User
+	assert.Equal(t, "alice", *alice.Username)
+	assert.Equal(t, "alice@example.com", *alice.Email)
+	assert.Nil(t, alice.DeleteAt)
+	require.Len(t, *alice.Teams, 1)
+	assert.Equal(t, model.TeamUserRoleId+" "+model.TeamAdminRoleId, *(*alice.Teams)[0].Roles)
+	assert.Len(t, *(*alice.Teams)[0].Channels, 2)
+
+	bob := lines[5].User
+	assert.Equal(t, "bob", *bob.Username)
+	assert.Equal(t, "bob@example.com", *bob.Email)
+	assert.NotNil(t, bob.DeleteAt)
+	require.Len(t, *bob.Teams, 1)
+	require.Len(t, *(*bob.Teams)[0].Channels, 1)
+	assert.Equal(t, model.DefaultChannelName, *(*(*bob.Teams)[0].Channels)[0].Name)
+
+	post := lines[6].Post
+	assert.Equal(t, "alice", *post.User)
+	assert.Equal(t, "Hello @bob", *post.Message)
+	require.Len(t, *post.Attachments, 1)
+	assert.Equal(t, "files/a1/notes.txt", *(*post.Attachments)[0].Path)
+	require.Len(t, *post.Replies, 1)
+	assert.Equal(t, "bob", *(*post.Replies)[0].User)
+	assert.Equal(t, "hi!", *(*post.Replies)[0].Message)
+
+	assert.Equal(t, 2, report.Users)
+	assert.Equal(t, 1, report.Teams)
+	assert.Equal(t, 2, report.Channels)
+	assert.Equal(t, 1, report.Posts)
+	assert.Equal(t, 1, report.Replies)
+	assert.Equal(t, 1, report.Attachments)
+	// the user without an email, the missing attachment and the message from an unknown user.
+	assert.Len(t, report.Warnings, 3)
+}
+
+func TestMSTeamsImport(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("missing users file", func(t *testing.T) {
+		zipReader := makeMSTeamsPackage(t, map[string]string{})
+		report, appErr := th.App.MSTeamsImport(th.Context, zipReader, true, 1)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.msteams.missing_file.error", appErr.Id)
+		assert.NotNil(t, report)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		zipReader := makeMSTeamsPackage(t, msTeamsTestPackage)
+		report, appErr := th.App.MSTeamsImport(th.Context, zipReader, true, 1)
+		require.Nil(t, appErr)
+		assert.True(t, report.DryRun)
+		assert.Empty(t, report.Error)
+		assert.Equal(t, 1, report.Posts)
+
+		_, appErr = th.App.GetTeamByName("engineering")
+		assert.NotNil(t, appErr)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		zipReader := makeMSTeamsPackage(t, msTeamsTestPackage)
+		_, appErr := th.App.MSTeamsImport(th.Context, zipReader, false, 1)
+		require.Nil(t, appErr)
+
+		team, appErr := th.App.GetTeamByName("engineering")
+		require.Nil(t, appErr)
+
+		channel, appErr := th.App.GetChannelByName("secret-plans", team.Id, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.ChannelTypePrivate, channel.Type)
+
+		user, appErr := th.App.GetUserByUsername("alice")
+		require.Nil(t, appErr)
+		_, appErr = th.App.GetChannelMember(context.Background(), channel.Id, user.Id)
+		assert.Nil(t, appErr)
+	})
+}
@@ -14,6 +14,8 @@
 // for the relevant user, telling them to display the dialog.
 // 7. The user fills in the dialog and submits it, where SubmitInteractiveDialog will submit it back to the
 // integration for handling.
+// 8. The integration can respond with the next step of a multi-step dialog. Its state and the values submitted
+// so far are then held by the server, and the client submits the next step with a state token referencing them.
 
 package app
 
@@ -602,8 +604,39 @@ func (a *App) OpenInteractiveDialog(request model.OpenDialogRequest) *model.AppE
 }
 
 func (a *App) SubmitInteractiveDialog(c *request.Context, request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError) {
+	// The steps following the first one of a multi-step dialog are submitted with a state token.
+	request.Step = 0
+	request.Submissions = nil
+	stateToken := request.StateToken
+	var wizard *model.DialogWizardState
+	if stateToken != "" {
+		var appErr *model.AppError
+		wizard, appErr = a.getDialogWizardState(stateToken, request.UserId)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		if !request.Cancelled {
+			if errs := wizard.Dialog.ValidateSubmission(request.Submission); len(errs) > 0 {
+				response := &model.SubmitDialogResponse{Errors: map[string]string{}, StateToken: stateToken}
+				for name, err := range errs {
+					err.Translate(c.T)
+					response.Errors[name] = err.Message
+				}
+				return response, nil
+			}
+		}
+
+		request.URL = wizard.URL
+		request.CallbackId = wizard.CallbackId
+		request.State = wizard.State
+		request.Step = wizard.Step
+		request.Submissions = wizard.Submissions
+	}
+
 	url := request.URL
 	request.URL = ""
+	request.StateToken = ""
 	request.Type = "dialog_submission"
 
 	b, jsonErr := json.Marshal(request)
@@ -621,8 +654,106 @@ func (a *App) SubmitInteractiveDialog(c *request.Context, request model.SubmitDi
 	var response model.SubmitDialogResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 		// Don't fail, an empty response is acceptable
+		response = model.SubmitDialogResponse{}
+	}
+	response.StateToken = ""
+
+	// A rejected step can be submitted again with the same state.
+	if !request.Cancelled && (response.Error != "" || len(response.Errors) > 0) {
+		response.Next = nil
+		response.StateToken = stateToken
 		return &response, nil
 	}
 
+	if stateToken != "" {
+		a.removeDialogWizardState(stateToken)
+	}
+
+	if request.Cancelled || response.Next == nil {
+		response.Next = nil
+		return &response, nil
+	}
+
+	submissions := append(request.Submissions, request.Submission)
+	next := &model.DialogWizardState{
+		UserId:      request.UserId,
+		URL:         url,
+		CallbackId:  request.CallbackId,
+		State:       response.Next.State,
+		Step:        request.Step + 1,
+		Dialog:      *response.Next,
+		Submissions: submissions,
+	}
+	if response.Next.CallbackId != "" {
+		next.CallbackId = response.Next.CallbackId
+	}
+
+	token, appErr := a.saveDialogWizardState(next)
+	if appErr != nil {
+		return nil, appErr
+	}
+	response.StateToken = token.Token
+
+	// The integration state is held by the server.
+	response.Next.State = ""
+
 	return &response, nil
 }
+
+func (a *App) saveDialogWizardState(state *model.DialogWizardState) (*model.Token, *model.AppError) {
+	if appErr := state.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	extra, err := json.Marshal(state)
+	if err != nil {
+		return nil, model.NewAppError("saveDialogWizardState", "app.submit_interactive_dialog.json_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(extra) > model.DialogWizardStateMaxSize {
+		return nil, model.NewAppError("saveDialogWizardState", "app.submit_interactive_dialog.wizard_state_too_large.app_error", map[string]interface{}{"MaxSize": model.DialogWizardStateMaxSize}, "", http.StatusBadRequest)
+	}
+
+	token := model.NewToken(TokenTypeDialogWizard, string(extra))
+	if err := a.Srv().Store.Token().Save(token); err != nil {
+		return nil, model.NewAppError("saveDialogWizardState", "app.submit_interactive_dialog.save_wizard_state.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return token, nil
+}
+
+func (a *App) getDialogWizardState(stateToken, userID string) (*model.DialogWizardState, *model.AppError) {
+	token, err := a.Srv().Store.Token().GetByToken(stateToken)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("getDialogWizardState", "app.submit_interactive_dialog.wizard_state_not_found.app_error", nil, err.Error(), http.StatusNotFound)
+		}
+		return nil, model.NewAppError("getDialogWizardState", "app.submit_interactive_dialog.get_wizard_state.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if token.Type != TokenTypeDialogWizard {
+		return nil, model.NewAppError("getDialogWizardState", "app.submit_interactive_dialog.wizard_state_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if model.GetMillis()-token.CreateAt > model.DialogWizardExpiryTime {
+		a.removeDialogWizardState(stateToken)
+		return nil, model.NewAppError("getDialogWizardState", "app.submit_interactive_dialog.wizard_state_expired.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	var state model.DialogWizardState
+	if err := json.Unmarshal([]byte(token.Extra), &state); err != nil {
+		return nil, model.NewAppError("getDialogWizardState", "app.submit_interactive_dialog.get_wizard_state.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if state.UserId != userID {
+		return nil, model.NewAppError("getDialogWizardState", "app.submit_interactive_dialog.wizard_state_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	return &state, nil
+}
+
+func (a *App) removeDialogWizardState(stateToken string) {
+	if err := a.Srv().Store.Token().Delete(stateToken); err != nil {
+		mlog.Warn("Failed to remove the state of a multi-step dialog", mlog.Err(err))
+	}
+}
@@ -97,8 +97,24 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeBulkChannelMembers,
+		model.JobTypeMSTeamsImport,
+		model.JobTypePartitionMaintenance,
+		model.JobTypePostArchive,
+		model.JobTypePluginScheduledTasks,
+		model.JobTypeOrphanedFilesCleanup,
+		model.JobTypeUserMerge,
+		model.JobTypeAuthMigration,
+		model.JobTypeFileRetention,
+		model.JobTypeChannelMemberCounts,
+		model.JobTypeReminders,
+		model.JobTypeChannelEvents,
+		model.JobTypeScheduledConfigChanges,
+		model.JobTypePurgeSoftDeleted:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
+	case model.JobTypeUserDeletion:
+		return a.SessionHasPermissionTo(session, model.PermissionManageSystem), model.PermissionManageSystem
 	}
 
 	return false, nil
@@ -128,7 +144,22 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeBulkChannelMembers,
+		model.JobTypeMSTeamsImport,
+		model.JobTypePartitionMaintenance,
+		model.JobTypePostArchive,
+		model.JobTypePluginScheduledTasks,
+		model.JobTypeOrphanedFilesCleanup,
+		model.JobTypeUserMerge,
+		model.JobTypeAuthMigration,
+		model.JobTypeFileRetention,
+		model.JobTypeChannelMemberCounts,
+		model.JobTypeReminders,
+		model.JobTypeChannelEvents,
+		model.JobTypeScheduledConfigChanges,
+		model.JobTypePurgeSoftDeleted,
+		model.JobTypeUserDeletion:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
 	}
 
@@ -211,7 +211,9 @@ func (a *App) AddLdapPublicCertificate(fileData *multipart.FileHeader) *model.Ap
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -228,7 +230,9 @@ func (a *App) AddLdapPrivateCertificate(fileData *multipart.FileHeader) *model.A
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -252,7 +256,9 @@ func (a *App) RemoveLdapPublicCertificate() *model.AppError {
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -269,7 +275,9 @@ func (a *App) RemoveLdapPrivateCertificate() *model.AppError {
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -410,3 +410,18 @@ func (s *Server) GenerateLicenseRenewalLink() (string, string, *model.AppError)
 	renewalLink := LicenseRenewalURL + "?token=" + renewalToken
 	return renewalLink, renewalToken, nil
 }
+
+// GetLicenseStatus reports seat usage and the expiry countdown of the current license.
+func (s *Server) GetLicenseStatus() (*model.LicenseStatus, *model.AppError) {
+	license := s.License()
+	if license == nil {
+		return nil, model.NewAppError("GetLicenseStatus", "app.license.get_status.no_license.app_error", nil, "", http.StatusNotFound)
+	}
+
+	usedSeats, err := s.Store.User().Count(model.UserCountOptions{})
+	if err != nil {
+		return nil, model.NewAppError("GetLicenseStatus", "app.user.get_total_users_count.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return model.NewLicenseStatus(license, usedSeats), nil
+}
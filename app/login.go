@@ -203,6 +203,8 @@ func (a *App) DoLogin(c *request.Context, w http.ResponseWriter, r *http.Request
 	session.AddProp(model.SessionPropPlatform, plat)
 	session.AddProp(model.SessionPropOs, os)
 	session.AddProp(model.SessionPropBrowser, fmt.Sprintf("%v/%v", bname, bversion))
+	session.AddProp(model.SessionPropIPAddress, c.IPAddress())
+	session.AddProp(model.SessionPropDeviceFingerprint, model.SessionDeviceFingerprint(r.UserAgent(), deviceID))
 	if user.IsGuest() {
 		session.AddProp(model.SessionPropIsGuest, "true")
 	} else {
@@ -226,6 +228,14 @@ func (a *App) DoLogin(c *request.Context, w http.ResponseWriter, r *http.Request
 		})
 	}
 
+	if *a.Config().ServiceSettings.EnableLoginNotifications {
+		userVal := *user
+		sessionVal := *session
+		a.Srv().Go(func() {
+			a.checkLoginDevice(c, &userVal, &sessionVal)
+		})
+	}
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		a.Srv().Go(func() {
 			pluginContext := pluginContext(c)
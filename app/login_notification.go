@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type revokeSessionTokenData struct {
+	UserId    string `json:"user_id"`
+	SessionId string `json:"session_id"`
+}
+
+// checkLoginDevice records the device and country of a login, as located from its IP address,
+// and notifies the user when either wasn't seen before, as configured by
+// ServiceSettings.EnableLoginNotifications. The first login of a user is never notified.
+func (a *App) checkLoginDevice(c *request.Context, user *model.User, session *model.Session) {
+	location := a.Srv().getGeoIPDatabase().Lookup(net.ParseIP(session.Props[model.SessionPropIPAddress]))
+	country := ""
+	if location != nil {
+		country = location.Country
+	}
+	fingerprint := session.Props[model.SessionPropDeviceFingerprint]
+
+	devices, err := a.Srv().Store.UserDevice().GetForUser(user.Id)
+	if err != nil {
+		mlog.Warn("Failed to get the devices of a user", mlog.String("user_id", user.Id), mlog.Err(err))
+		return
+	}
+
+	newDevice := true
+	// A login which can't be located doesn't come from a new country.
+	newCountry := country != ""
+	for _, device := range devices {
+		if device.Fingerprint == fingerprint {
+			newDevice = false
+		}
+		if device.Country == country {
+			newCountry = false
+		}
+	}
+
+	now := model.GetMillis()
+	if err := a.Srv().Store.UserDevice().Save(&model.UserDevice{
+		UserId:      user.Id,
+		Fingerprint: fingerprint,
+		Country:     country,
+		CreateAt:    now,
+		LastSeenAt:  now,
+	}); err != nil {
+		mlog.Warn("Failed to save the device of a user", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
+	if len(devices) == 0 || (!newDevice && !newCountry) {
+		return
+	}
+
+	a.sendLoginNotification(c, user, session, location)
+}
+
+// sendLoginNotification emails a user about a login, and sends them a direct message from the
+// system bot if ServiceSettings.LoginNotificationsDirectMessage is enabled. Both link to a page
+// revoking the session of the login.
+func (a *App) sendLoginNotification(c *request.Context, user *model.User, session *model.Session, location *model.GeoLocation) {
+	extra, err := json.Marshal(revokeSessionTokenData{UserId: user.Id, SessionId: session.Id})
+	if err != nil {
+		mlog.Warn("Failed to encode a login notification token", mlog.String("user_id", user.Id), mlog.Err(err))
+		return
+	}
+
+	token := model.NewToken(TokenTypeRevokeSession, string(extra))
+	if err := a.Srv().Store.Token().Save(token); err != nil {
+		mlog.Warn("Failed to save a login notification token", mlog.String("user_id", user.Id), mlog.Err(err))
+		return
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	device := T("app.login_notification.device", map[string]interface{}{
+		"Browser": session.Props[model.SessionPropBrowser],
+		"Os":      session.Props[model.SessionPropOs],
+	})
+	place := T("app.login_notification.unknown_location")
+	if location != nil {
+		var parts []string
+		for _, part := range []string{location.City, location.Region, location.Country} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		place = strings.Join(parts, ", ")
+	}
+
+	siteURL := a.GetSiteURL()
+	if err := a.Srv().EmailService.SendLoginNotificationEmail(user.Email, token, device, place, user.Locale, siteURL); err != nil {
+		mlog.Warn("Failed to send a login notification email", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+
+	if !*a.Config().ServiceSettings.LoginNotificationsDirectMessage {
+		return
+	}
+
+	bot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		mlog.Warn("Failed to get the system bot to send a login notification", mlog.String("user_id", user.Id), mlog.Err(appErr))
+		return
+	}
+
+	channel, appErr := a.GetOrCreateDirectChannel(c, user.Id, bot.UserId)
+	if appErr != nil {
+		mlog.Warn("Failed to get the direct channel to send a login notification", mlog.String("user_id", user.Id), mlog.Err(appErr))
+		return
+	}
+
+	post := &model.Post{
+		UserId:    bot.UserId,
+		ChannelId: channel.Id,
+		Message: T("app.login_notification.direct_message", map[string]interface{}{
+			"Device":   device,
+			"Location": place,
+			"Link":     fmt.Sprintf("%s/login/revoke_session?token=%s", siteURL, url.QueryEscape(token.Token)),
+		}),
+	}
+	if _, appErr := a.CreatePost(c, post, channel, false, true); appErr != nil {
+		mlog.Warn("Failed to send a login notification direct message", mlog.String("user_id", user.Id), mlog.Err(appErr))
+	}
+}
+
+// CheckLoginNotificationToken returns an error if the token of the link of a login notification
+// can't be used to revoke its session, without using it.
+func (a *App) CheckLoginNotificationToken(tokenString string) *model.AppError {
+	_, _, appErr := a.getLoginNotificationToken(tokenString)
+	return appErr
+}
+
+// RevokeSessionFromLoginNotification revokes the session of a login notified to a user, given
+// the token of the link of the notification. The token can only be used once.
+func (a *App) RevokeSessionFromLoginNotification(tokenString string) *model.AppError {
+	token, data, appErr := a.getLoginNotificationToken(tokenString)
+	if appErr != nil {
+		return appErr
+	}
+
+	// A session which no longer exists, for example after logging out, needs no revoking.
+	if session, appErr := a.GetSessionById(data.SessionId); appErr == nil && session.UserId == data.UserId {
+		if appErr := a.RevokeSession(session); appErr != nil {
+			return appErr
+		}
+	}
+
+	return a.DeleteToken(token)
+}
+
+func (a *App) getLoginNotificationToken(tokenString string) (*model.Token, *revokeSessionTokenData, *model.AppError) {
+	token, err := a.Srv().Store.Token().GetByToken(tokenString)
+	if err != nil || token.Type != TokenTypeRevokeSession {
+		return nil, nil, model.NewAppError("getLoginNotificationToken", "app.login_notification.invalid_link.app_error", nil, "", http.StatusBadRequest)
+	}
+	if model.GetMillis()-token.CreateAt >= model.MaxTokenExipryTime {
+		return nil, nil, model.NewAppError("getLoginNotificationToken", "app.login_notification.invalid_link.app_error", nil, "expired", http.StatusBadRequest)
+	}
+
+	var data revokeSessionTokenData
+	if err := json.Unmarshal([]byte(token.Extra), &data); err != nil {
+		return nil, nil, model.NewAppError("getLoginNotificationToken", "app.login_notification.invalid_link.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	return token, &data, nil
+}
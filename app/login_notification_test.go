@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	emailmocks "github.com/mattermost/mattermost-server/v6/app/email/mocks"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestLoginNotification(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	emailServiceMock := emailmocks.ServiceInterface{}
+	th.App.Srv().EmailService = &emailServiceMock
+
+	login := func(fingerprint string) *model.Session {
+		session, appErr := th.App.CreateSession(&model.Session{
+			UserId: th.BasicUser.Id,
+			Props: model.StringMap{
+				model.SessionPropDeviceFingerprint: fingerprint,
+				model.SessionPropBrowser:           "Firefox/100.0",
+				model.SessionPropOs:                "Linux",
+			},
+		})
+		require.Nil(t, appErr)
+		th.App.checkLoginDevice(th.Context, th.BasicUser, session)
+		return session
+	}
+
+	// The first login of a user and logins from known devices are not notified.
+	login("known")
+	login("known")
+	emailServiceMock.AssertNotCalled(t, "SendLoginNotificationEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	var token *model.Token
+	emailServiceMock.On("SendLoginNotificationEmail", th.BasicUser.Email, mock.AnythingOfType("*model.Token"), "Firefox/100.0 on Linux", "an unknown location", th.BasicUser.Locale, mock.Anything).
+		Run(func(args mock.Arguments) { token = args.Get(1).(*model.Token) }).
+		Once().
+		Return(nil)
+	session := login("new")
+	emailServiceMock.AssertExpectations(t)
+	require.NotNil(t, token)
+
+	devices, err := th.App.Srv().Store.UserDevice().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	t.Run("revoke the session", func(t *testing.T) {
+		appErr := th.App.RevokeSessionFromLoginNotification(model.NewId())
+		require.NotNil(t, appErr)
+		require.Equal(t, "app.login_notification.invalid_link.app_error", appErr.Id)
+
+		require.Nil(t, th.App.RevokeSessionFromLoginNotification(token.Token))
+		_, appErr = th.App.GetSessionById(session.Id)
+		require.NotNil(t, appErr)
+
+		appErr = th.App.RevokeSessionFromLoginNotification(token.Token)
+		require.NotNil(t, appErr, "the link can only be used once")
+	})
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+
+	"github.com/mattermost/mattermost-server/v6/shared/markdown"
+)
+
+// markdownPolicy strips anything from the rendered HTML that could run scripts, such as links to
+// javascript: URLs, while keeping the language of code blocks for syntax highlighting.
+var markdownPolicy = func() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w.+-]+$`)).OnElements("code")
+	return policy
+}()
+
+// RenderMarkdown renders markdown to sanitized HTML with the parser the server uses to read posts,
+// so that the output only depends on the version of the server.
+func (a *App) RenderMarkdown(text string) string {
+	return markdownPolicy.Sanitize(markdown.RenderHTML(text))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mfa"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GenerateMfaBackupCodes replaces the backup codes of a user with new ones. Only their hashes are
+// stored, so the codes returned can't be retrieved later.
+func (a *App) GenerateMfaBackupCodes(userID string) (*model.MfaBackupCodes, *model.AppError) {
+	codes, hashes := mfa.GenerateBackupCodes()
+	if err := a.Srv().Store.MfaBackupCode().ReplaceForUser(userID, hashes, model.GetMillis()); err != nil {
+		return nil, model.NewAppError("GenerateMfaBackupCodes", "app.mfa_backup_code.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return &model.MfaBackupCodes{BackupCodes: codes}, nil
+}
+
+// RegenerateMfaBackupCodes replaces the backup codes of a user with multi-factor authentication
+// active, given a token of their authenticator app or one of their current backup codes.
+func (a *App) RegenerateMfaBackupCodes(userID, token string) (*model.MfaBackupCodes, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError("RegenerateMfaBackupCodes", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !user.MfaActive {
+		return nil, model.NewAppError("RegenerateMfaBackupCodes", "app.mfa_backup_code.mfa_inactive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if appErr := a.CheckUserMfa(user, token); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.GenerateMfaBackupCodes(userID)
+}
+
+// useMfaBackupCode consumes a normalized backup code of a user, which can't be used again.
+func (a *App) useMfaBackupCode(user *model.User, code string) *model.AppError {
+	used, err := a.Srv().Store.MfaBackupCode().Use(user.Id, mfa.HashBackupCode(code), model.GetMillis())
+	if err != nil {
+		return model.NewAppError("useMfaBackupCode", "app.mfa_backup_code.use.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if !used {
+		return model.NewAppError("useMfaBackupCode", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	mlog.Info("Used an MFA backup code", mlog.String("user_id", user.Id))
+
+	return nil
+}
+
+// ResetUserMfa deactivates the multi-factor authentication of a user who lost their
+// authenticator app and backup codes, on behalf of an administrator. The sessions of the user
+// are revoked, so that they have to log in, and enroll again if it is enforced.
+func (a *App) ResetUserMfa(userID string) *model.AppError {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !user.MfaActive {
+		return model.NewAppError("ResetUserMfa", "app.mfa_backup_code.mfa_inactive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if appErr := a.DeactivateMfa(userID); appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.RevokeAllSessions(userID); appErr != nil {
+		return appErr
+	}
+
+	a.Srv().Go(func() {
+		if err := a.Srv().EmailService.SendMfaChangeEmail(user.Email, false, user.Locale, a.GetSiteURL()); err != nil {
+			mlog.Error("Failed to send mfa change email", mlog.Err(err))
+		}
+	})
+
+	return nil
+}
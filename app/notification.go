@@ -125,8 +125,14 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	if channel.Type == model.ChannelTypeDirect {
 		otherUserId := channel.GetOtherUserIdForDM(post.UserId)
 
+		// The recipient of a message request isn't notified until they accept it.
+		pendingRequest, appErr := a.isPendingDirectMessageRequestRecipient(channel, otherUserId)
+		if appErr != nil {
+			return nil, appErr
+		}
+
 		_, ok := profileMap[otherUserId]
-		if ok {
+		if ok && !pendingRequest {
 			mentions.addMention(otherUserId, DMMention)
 		}
 
@@ -366,6 +372,10 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 			}
 
 			if a.userAllowsEmail(profileMap[id], channelMemberNotifyPropsMap[id], post) {
+				if isAggregatableMention(mentions, channel, id) && a.aggregateMention(mentionNotificationEmail, notification, profileMap[id], team) {
+					continue
+				}
+
 				senderProfileImage, _, err := a.GetProfileImage(sender)
 				if err != nil {
 					a.Log().Warn("Unable to get the sender user profile image.", mlog.String("user_id", sender.Id), mlog.Err(err))
@@ -426,8 +436,13 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 			if status, err = a.GetStatus(id); err != nil {
 				status = &model.Status{UserId: id, Status: model.StatusOffline, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 			}
+			status = a.statusForNotification(status, post)
 
 			if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], true, status, post) {
+				if isAggregatableMention(mentions, channel, id) && a.aggregateMention(mentionNotificationPush, notification, profileMap[id], team) {
+					continue
+				}
+
 				mentionType := mentions.Mentions[id]
 
 				replyToThreadType := ""
@@ -467,6 +482,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 				if status, err = a.GetStatus(id); err != nil {
 					status = &model.Status{UserId: id, Status: model.StatusOffline, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 				}
+				status = a.statusForNotification(status, post)
 
 				if ShouldSendPushNotification(profileMap[id], channelMemberNotifyPropsMap[id], false, status, post) {
 					a.sendPushNotification(
@@ -499,6 +515,7 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 			if status, err = a.GetStatus(id); err != nil {
 				status = &model.Status{UserId: id, Status: model.StatusOffline, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
 			}
+			status = a.statusForNotification(status, post)
 
 			if DoesStatusAllowPushNotification(profileMap[id].NotifyProps, status, post.ChannelId) {
 				a.sendPushNotification(
@@ -689,6 +706,7 @@ func (a *App) userAllowsEmail(user *model.User, channelMemberNotificationProps m
 			ActiveChannel:  "",
 		}
 	}
+	status = a.statusForNotification(status, post)
 
 	autoResponderRelated := status.Status == model.StatusOutOfOffice || post.Type == model.PostTypeAutoResponder
 	emailNotificationsAllowedForStatus := status.Status != model.StatusOnline && status.Status != model.StatusDnd
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"html"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type mentionNotificationKind string
+
+const (
+	mentionNotificationPush  mentionNotificationKind = "push"
+	mentionNotificationEmail mentionNotificationKind = "email"
+)
+
+// mentionAggregator coalesces the mention notifications a user receives for a channel. The
+// first mention opens a window and is delivered straight away, the following ones are only
+// counted, and a single summary is delivered when the window closes.
+type mentionAggregator struct {
+	mut     sync.Mutex
+	windows map[string]*mentionWindow
+	stopped bool
+}
+
+type mentionWindow struct {
+	kind         mentionNotificationKind
+	user         *model.User
+	team         *model.Team
+	notification *PostNotification
+	count        int
+	timer        *time.Timer
+}
+
+func newMentionAggregator() *mentionAggregator {
+	return &mentionAggregator{
+		windows: make(map[string]*mentionWindow),
+	}
+}
+
+func (agg *mentionAggregator) stop() {
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	agg.stopped = true
+	for key, window := range agg.windows {
+		window.timer.Stop()
+		delete(agg.windows, key)
+	}
+}
+
+// isAggregatableMention returns whether the notification sent to the user for this post can be
+// coalesced. Only keyword and channel-wide mentions outside of direct and group messages are.
+func isAggregatableMention(mentions *ExplicitMentions, channel *model.Channel, userID string) bool {
+	if channel.IsGroupOrDirect() {
+		return false
+	}
+
+	mentionType, ok := mentions.Mentions[userID]
+	return ok && (mentionType == KeywordMention || mentionType == ChannelMention)
+}
+
+// aggregateMention records a mention notification for the user. It returns true when the
+// notification falls within an open window and must not be delivered now.
+func (a *App) aggregateMention(kind mentionNotificationKind, notification *PostNotification, user *model.User, team *model.Team) bool {
+	window := time.Duration(*a.Config().NotificationSettings.MentionAggregationWindowSeconds) * time.Second
+	agg := a.Srv().mentionAggregator
+	if window <= 0 || agg == nil {
+		return false
+	}
+
+	key := string(kind) + user.Id + notification.Channel.Id
+
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	if agg.stopped {
+		return false
+	}
+
+	if w, ok := agg.windows[key]; ok {
+		w.count++
+		w.notification = notification
+		return true
+	}
+
+	agg.windows[key] = &mentionWindow{
+		kind:         kind,
+		user:         user,
+		team:         team,
+		notification: notification,
+		timer: time.AfterFunc(window, func() {
+			a.flushMentionWindow(key)
+		}),
+	}
+
+	return false
+}
+
+func (a *App) flushMentionWindow(key string) {
+	agg := a.Srv().mentionAggregator
+
+	agg.mut.Lock()
+	w, ok := agg.windows[key]
+	delete(agg.windows, key)
+	agg.mut.Unlock()
+
+	if !ok || w.count == 0 {
+		return
+	}
+
+	var appErr *model.AppError
+	switch w.kind {
+	case mentionNotificationPush:
+		appErr = a.sendAggregatedMentionPush(w)
+	case mentionNotificationEmail:
+		appErr = a.sendAggregatedMentionEmail(w)
+	}
+
+	if appErr != nil {
+		mlog.Warn("Unable to send aggregated mention notification", mlog.String("kind", string(w.kind)), mlog.String("user_id", w.user.Id), mlog.Err(appErr))
+	}
+}
+
+func (a *App) sendAggregatedMentionPush(w *mentionWindow) *model.AppError {
+	channel := w.notification.Channel
+
+	// the user may have come back online and read the channel while the window was open.
+	if status, err := a.GetStatus(w.user.Id); err == nil && !DoesStatusAllowPushNotification(w.user.NotifyProps, a.statusForNotification(status, w.notification.Post), channel.Id) {
+		return nil
+	}
+
+	cfg := a.Config()
+	nameFormat := a.GetNotificationNameFormat(w.user)
+	channelName := w.notification.GetChannelName(nameFormat, w.user.Id)
+	senderName := w.notification.GetSenderName(nameFormat, *cfg.ServiceSettings.EnablePostUsernameOverride)
+
+	msg, appErr := a.BuildPushNotificationMessage(
+		*cfg.EmailSettings.PushNotificationContents,
+		w.notification.Post,
+		w.user,
+		channel,
+		channelName,
+		senderName,
+		true,
+		false,
+		"",
+	)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := i18n.GetNotificationTranslations(w.user.Locale)
+	msg.Message = T("app.notification.mention_aggregation.push_message", map[string]interface{}{
+		"Count":       w.count,
+		"ChannelName": channelName,
+	})
+
+	return a.sendPushNotificationToAllSessions(msg, w.user.Id, "")
+}
+
+func (a *App) sendAggregatedMentionEmail(w *mentionWindow) *model.AppError {
+	T := i18n.GetNotificationTranslations(w.user.Locale)
+	channelName := w.notification.GetChannelName(a.GetNotificationNameFormat(w.user), "")
+
+	subject := T("app.notification.mention_aggregation.email_subject", map[string]interface{}{
+		"SiteName":    *a.Config().TeamSettings.SiteName,
+		"ChannelName": channelName,
+		"Count":       w.count,
+	})
+	body := T("app.notification.mention_aggregation.email_body", map[string]interface{}{
+		"Count":       w.count,
+		"ChannelName": html.EscapeString(channelName),
+		"ChannelURL":  a.GetSiteURL() + "/" + w.team.Name + "/channels/" + w.notification.Channel.Name,
+	})
+
+	if err := a.Srv().EmailService.SendNotificationMail(w.user.Email, subject, body); err != nil {
+		return model.NewAppError("sendAggregatedMentionEmail", "app.notification.mention_aggregation.send_email.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if a.Metrics() != nil {
+		a.Metrics().IncrementPostSentEmail()
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestAggregateMention(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	notification := &PostNotification{
+		Post:    th.CreatePost(th.BasicChannel),
+		Channel: th.BasicChannel,
+		Sender:  th.BasicUser,
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.False(t, th.App.aggregateMention(mentionNotificationPush, notification, th.BasicUser2, th.BasicTeam))
+		assert.False(t, th.App.aggregateMention(mentionNotificationPush, notification, th.BasicUser2, th.BasicTeam))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.NotificationSettings.MentionAggregationWindowSeconds = 600
+	})
+
+	t.Run("mentions within the window are coalesced", func(t *testing.T) {
+		require.False(t, th.App.aggregateMention(mentionNotificationPush, notification, th.BasicUser2, th.BasicTeam), "first mention should be delivered")
+		assert.True(t, th.App.aggregateMention(mentionNotificationPush, notification, th.BasicUser2, th.BasicTeam))
+		assert.True(t, th.App.aggregateMention(mentionNotificationPush, notification, th.BasicUser2, th.BasicTeam))
+
+		agg := th.App.Srv().mentionAggregator
+		agg.mut.Lock()
+		window := agg.windows[string(mentionNotificationPush)+th.BasicUser2.Id+th.BasicChannel.Id]
+		agg.mut.Unlock()
+		require.NotNil(t, window)
+		assert.Equal(t, 2, window.count)
+	})
+
+	t.Run("windows are tracked per kind and channel", func(t *testing.T) {
+		assert.False(t, th.App.aggregateMention(mentionNotificationEmail, notification, th.BasicUser2, th.BasicTeam))
+
+		other := &PostNotification{
+			Post:    notification.Post,
+			Channel: th.CreateChannel(th.BasicTeam),
+			Sender:  th.BasicUser,
+		}
+		assert.False(t, th.App.aggregateMention(mentionNotificationPush, other, th.BasicUser2, th.BasicTeam))
+	})
+
+	t.Run("nothing is coalesced once stopped", func(t *testing.T) {
+		th.App.Srv().mentionAggregator.stop()
+		assert.False(t, th.App.aggregateMention(mentionNotificationPush, notification, th.BasicUser2, th.BasicTeam))
+	})
+}
+
+func TestIsAggregatableMention(t *testing.T) {
+	mentions := &ExplicitMentions{
+		Mentions: map[string]MentionType{
+			"keyword": KeywordMention,
+			"channel": ChannelMention,
+			"thread":  ThreadMention,
+		},
+	}
+	open := &model.Channel{Type: model.ChannelTypeOpen}
+
+	assert.True(t, isAggregatableMention(mentions, open, "keyword"))
+	assert.True(t, isAggregatableMention(mentions, open, "channel"))
+	assert.False(t, isAggregatableMention(mentions, open, "thread"))
+	assert.False(t, isAggregatableMention(mentions, open, "unknown"))
+	assert.False(t, isAggregatableMention(mentions, &model.Channel{Type: model.ChannelTypeDirect}, "keyword"))
+}
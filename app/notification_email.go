@@ -20,31 +20,9 @@ import (
 )
 
 func (a *App) sendNotificationEmail(notification *PostNotification, user *model.User, team *model.Team, senderProfileImage []byte) error {
-	channel := notification.Channel
-	post := notification.Post
-
-	if channel.IsGroupOrDirect() {
-		teams, err := a.Srv().Store.Team().GetTeamsByUserId(user.Id)
-		if err != nil {
-			return errors.Wrap(err, "unable to get user teams")
-		}
-
-		// if the recipient isn't in the current user's team, just pick one
-		found := false
-
-		for i := range teams {
-			if teams[i].Id == team.Id {
-				found = true
-				break
-			}
-		}
-
-		if !found && len(teams) > 0 {
-			team = teams[0]
-		} else {
-			// in case the user hasn't joined any teams we send them to the select_team page
-			team = &model.Team{Name: "select_team", DisplayName: *a.Config().TeamSettings.SiteName}
-		}
+	team, err := a.getNotificationEmailTeam(notification.Channel, user, team)
+	if err != nil {
+		return err
 	}
 
 	if *a.Config().EmailSettings.EnableEmailBatching {
@@ -58,7 +36,7 @@ func (a *App) sendNotificationEmail(notification *PostNotification, user *model.
 		}
 
 		if sendBatched {
-			if err := a.Srv().EmailService.AddNotificationEmailToBatch(user, post, team); err == nil {
+			if err := a.Srv().EmailService.AddNotificationEmailToBatch(user, notification.Post, team); err == nil {
 				return nil
 			}
 		}
@@ -66,24 +44,78 @@ func (a *App) sendNotificationEmail(notification *PostNotification, user *model.
 		// fall back to sending a single email if we can't batch it for some reason
 	}
 
-	translateFunc := i18n.GetUserTranslations(user.Locale)
+	senderPhoto := ""
+	embeddedFiles := make(map[string]io.Reader)
+	if a.getEmailNotificationContentsType() == model.EmailNotificationContentsFull && senderProfileImage != nil {
+		senderPhoto = "user-avatar.png"
+		embeddedFiles = map[string]io.Reader{
+			senderPhoto: bytes.NewReader(senderProfileImage),
+		}
+	}
+
+	subjectText, bodyText, err := a.renderNotificationEmail(notification, user, team, senderPhoto)
+	if err != nil {
+		return err
+	}
 
-	var useMilitaryTime bool
-	if data, err := a.Srv().Store.Preference().Get(user.Id, model.PreferenceCategoryDisplaySettings, model.PreferenceNameUseMilitaryTime); err != nil {
-		useMilitaryTime = true
-	} else {
-		useMilitaryTime = data.Value == "true"
+	a.Srv().Go(func() {
+		if nErr := a.Srv().EmailService.SendMailWithEmbeddedFiles(user.Email, subjectText, bodyText, embeddedFiles); nErr != nil {
+			mlog.Error("Error while sending the email", mlog.String("user_email", user.Email), mlog.Err(nErr))
+		}
+	})
+
+	if a.Metrics() != nil {
+		a.Metrics().IncrementPostSentEmail()
+	}
+
+	return nil
+}
+
+// getNotificationEmailTeam returns the team the links of a notification email sent to the user
+// lead to. Direct and group messages don't belong to a team, so one of the user's is picked.
+func (a *App) getNotificationEmailTeam(channel *model.Channel, user *model.User, team *model.Team) (*model.Team, error) {
+	if !channel.IsGroupOrDirect() {
+		return team, nil
+	}
+
+	teams, err := a.Srv().Store.Team().GetTeamsByUserId(user.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get user teams")
+	}
+
+	// if the recipient isn't in the current user's team, just pick one
+	found := false
+
+	for i := range teams {
+		if team != nil && teams[i].Id == team.Id {
+			found = true
+			break
+		}
+	}
+
+	if !found && len(teams) > 0 {
+		return teams[0], nil
 	}
 
+	// in case the user hasn't joined any teams we send them to the select_team page
+	return &model.Team{Name: "select_team", DisplayName: *a.Config().TeamSettings.SiteName}, nil
+}
+
+// renderNotificationEmail renders the subject and body of the email notifying the user of a post,
+// in the user's locale and timezone.
+func (a *App) renderNotificationEmail(notification *PostNotification, user *model.User, team *model.Team, senderPhoto string) (string, string, error) {
+	channel := notification.Channel
+	post := notification.Post
+
+	translateFunc := i18n.GetNotificationTranslations(user.Locale)
+	useMilitaryTime := a.useMilitaryTime(user.Id)
+
 	nameFormat := a.GetNotificationNameFormat(user)
 
 	channelName := notification.GetChannelName(nameFormat, "")
 	senderName := notification.GetSenderName(nameFormat, *a.Config().ServiceSettings.EnablePostUsernameOverride)
 
-	emailNotificationContentsType := model.EmailNotificationContentsFull
-	if license := a.Srv().License(); license != nil && *license.Features.EmailNotificationContents {
-		emailNotificationContentsType = *a.Config().EmailSettings.EmailNotificationContentsType
-	}
+	emailNotificationContentsType := a.getEmailNotificationContentsType()
 
 	var subjectText string
 	if channel.Type == model.ChannelTypeDirect {
@@ -96,33 +128,33 @@ func (a *App) sendNotificationEmail(notification *PostNotification, user *model.
 		subjectText = getNotificationEmailSubject(user, post, translateFunc, *a.Config().TeamSettings.SiteName, team.DisplayName, useMilitaryTime)
 	}
 
-	senderPhoto := ""
-	embeddedFiles := make(map[string]io.Reader)
-	if emailNotificationContentsType == model.EmailNotificationContentsFull && senderProfileImage != nil {
-		senderPhoto = "user-avatar.png"
-		embeddedFiles = map[string]io.Reader{
-			senderPhoto: bytes.NewReader(senderProfileImage),
-		}
-	}
-
 	landingURL := a.GetSiteURL() + "/landing#/" + team.Name
 
-	var bodyText, err = a.getNotificationEmailBody(user, post, channel, channelName, senderName, team.Name, landingURL, emailNotificationContentsType, useMilitaryTime, translateFunc, senderPhoto)
+	bodyText, err := a.getNotificationEmailBody(user, post, channel, channelName, senderName, team.Name, landingURL, emailNotificationContentsType, useMilitaryTime, translateFunc, senderPhoto)
 	if err != nil {
-		return errors.Wrap(err, "unable to render the email notification template")
+		return "", "", errors.Wrap(err, "unable to render the email notification template")
 	}
 
-	a.Srv().Go(func() {
-		if nErr := a.Srv().EmailService.SendMailWithEmbeddedFiles(user.Email, html.UnescapeString(subjectText), bodyText, embeddedFiles); nErr != nil {
-			mlog.Error("Error while sending the email", mlog.String("user_email", user.Email), mlog.Err(nErr))
-		}
-	})
+	return html.UnescapeString(subjectText), bodyText, nil
+}
 
-	if a.Metrics() != nil {
-		a.Metrics().IncrementPostSentEmail()
+func (a *App) getEmailNotificationContentsType() string {
+	if license := a.Srv().License(); license != nil && *license.Features.EmailNotificationContents {
+		return *a.Config().EmailSettings.EmailNotificationContentsType
 	}
 
-	return nil
+	return model.EmailNotificationContentsFull
+}
+
+// useMilitaryTime returns whether the user displays times with the 24-hour clock, which is the
+// default for users who haven't chosen.
+func (a *App) useMilitaryTime(userID string) bool {
+	data, err := a.Srv().Store.Preference().Get(userID, model.PreferenceCategoryDisplaySettings, model.PreferenceNameUseMilitaryTime)
+	if err != nil {
+		return true
+	}
+
+	return data.Value == "true"
 }
 
 /**
@@ -301,19 +333,27 @@ type formattedPostTime struct {
 }
 
 func getFormattedPostTime(user *model.User, post *model.Post, useMilitaryTime bool, translateFunc i18n.TranslateFunc) formattedPostTime {
+	return getFormattedTimeForUser(user, post.CreateAt, useMilitaryTime, translateFunc)
+}
+
+// getFormattedTimeForUser formats the time in the preferred timezone of the user.
+func getFormattedTimeForUser(user *model.User, millis int64, useMilitaryTime bool, translateFunc i18n.TranslateFunc) formattedPostTime {
 	preferredTimezone := user.GetPreferredTimezone()
-	postTime := time.Unix(post.CreateAt/1000, 0)
-	zone, _ := postTime.Zone()
+	localTime := time.Unix(millis/1000, 0)
 
-	localTime := postTime
 	if preferredTimezone != "" {
 		loc, _ := time.LoadLocation(preferredTimezone)
 		if loc != nil {
-			localTime = postTime.In(loc)
-			zone, _ = localTime.Zone()
+			localTime = localTime.In(loc)
 		}
 	}
 
+	return getFormattedTime(localTime, useMilitaryTime, translateFunc)
+}
+
+func getFormattedTime(localTime time.Time, useMilitaryTime bool, translateFunc i18n.TranslateFunc) formattedPostTime {
+	zone, _ := localTime.Zone()
+
 	hour := localTime.Format("15")
 	period := ""
 	if !useMilitaryTime {
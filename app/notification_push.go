@@ -18,6 +18,7 @@ import (
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/i18n"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
 	"github.com/mattermost/mattermost-server/v6/utils"
 )
 
@@ -105,6 +106,7 @@ func (a *App) sendPushNotificationToAllSessions(msg *model.PushNotification, use
 		tmpMessage.AckId = model.NewId()
 
 		err := a.sendToPushProxy(tmpMessage, session)
+		a.savePushNotificationReceipt(tmpMessage, session.UserId, err)
 		if err != nil {
 			a.NotificationsLog().Error("Notification error",
 				mlog.String("ackId", tmpMessage.AckId),
@@ -136,6 +138,32 @@ func (a *App) sendPushNotificationToAllSessions(msg *model.PushNotification, use
 	return nil
 }
 
+// savePushNotificationReceipt records the outcome of sending a push notification to a device
+// so it shows up in the user's notification diagnostics. Clear and badge updates are not kept.
+func (a *App) savePushNotificationReceipt(msg *model.PushNotification, userID string, sendErr error) {
+	if msg.Type == model.PushTypeClear || msg.Type == model.PushTypeUpdateBadge {
+		return
+	}
+
+	receipt := &model.PushNotificationReceipt{
+		AckId:     msg.AckId,
+		UserId:    userID,
+		PostId:    msg.PostId,
+		ChannelId: msg.ChannelId,
+		Platform:  msg.Platform,
+		Type:      msg.Type,
+		Status:    model.PushReceiptStatusSent,
+	}
+	if sendErr != nil {
+		receipt.Status = model.PushReceiptStatusFailed
+		receipt.Error = sendErr.Error()
+	}
+
+	if _, err := a.Srv().Store.PushNotificationReceipt().Save(receipt); err != nil {
+		mlog.Warn("Failed to save push notification receipt", mlog.String("ackId", msg.AckId), mlog.Err(err))
+	}
+}
+
 func (a *App) sendPushNotification(notification *PostNotification, user *model.User, explicitMention, channelWideMention bool, replyToThreadType string) {
 	cfg := a.Config()
 	channel := notification.Channel
@@ -432,6 +460,44 @@ func (a *App) sendToPushProxy(msg *model.PushNotification, session *model.Sessio
 	return nil
 }
 
+func (a *App) markPushNotificationReceived(ack *model.PushNotificationAck) {
+	receivedAt := ack.ClientReceivedAt
+	if receivedAt == 0 {
+		receivedAt = model.GetMillis()
+	}
+
+	if err := a.Srv().Store.PushNotificationReceipt().MarkReceived(ack.Id, receivedAt); err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			mlog.Warn("Failed to mark push notification receipt as received", mlog.String("ackId", ack.Id), mlog.Err(err))
+		}
+	}
+}
+
+// GetPushNotificationDiagnostics returns the recent push notification delivery receipts for the
+// user along with what is needed to tell why notifications might not be reaching their devices.
+func (a *App) GetPushNotificationDiagnostics(userID string) (*model.PushNotificationDiagnostics, *model.AppError) {
+	receipts, err := a.Srv().Store.PushNotificationReceipt().GetForUser(userID, model.PushReceiptDiagnosticsLimit)
+	if err != nil {
+		return nil, model.NewAppError("GetPushNotificationDiagnostics", "app.push_notification_receipt.get_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	sessions, appErr := a.getMobileAppSessions(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	diagnostics := model.NewPushNotificationDiagnostics(receipts)
+	diagnostics.PushNotificationsEnabled = *a.Config().EmailSettings.SendPushNotifications
+	for _, session := range sessions {
+		if !session.IsExpired() {
+			diagnostics.MobileSessions++
+		}
+	}
+
+	return diagnostics, nil
+}
+
 func (a *App) SendAckToPushProxy(ack *model.PushNotificationAck) error {
 	if ack == nil {
 		return nil
@@ -445,6 +511,8 @@ func (a *App) SendAckToPushProxy(ack *model.PushNotificationAck) error {
 		mlog.String("status", model.PushReceived),
 	)
 
+	a.markPushNotificationReceived(ack)
+
 	ackJSON, jsonErr := json.Marshal(ack)
 	if jsonErr != nil {
 		return errors.Wrap(jsonErr, "failed to encode to JSON")
@@ -616,7 +684,7 @@ func (a *App) SendTestPushNotification(deviceID string) string {
 }
 
 func (a *App) buildIdLoadedPushNotificationMessage(channel *model.Channel, post *model.Post, user *model.User) *model.PushNotification {
-	userLocale := i18n.GetUserTranslations(user.Locale)
+	userLocale := i18n.GetNotificationTranslations(user.Locale)
 	msg := &model.PushNotification{
 		PostId:       post.Id,
 		ChannelId:    post.ChannelId,
@@ -650,7 +718,7 @@ func (a *App) buildFullPushNotificationMessage(contentsConfig string, post *mode
 		IsIdLoaded:   false,
 	}
 
-	userLocale := i18n.GetUserTranslations(user.Locale)
+	userLocale := i18n.GetNotificationTranslations(user.Locale)
 	cfg := a.Config()
 	if contentsConfig != model.GenericNoChannelNotification || channel.Type == model.ChannelTypeDirect {
 		msg.ChannelName = channelName
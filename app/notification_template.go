@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// initNotificationTemplateOverrides applies the notification template overrides of the config,
+// and applies them again whenever they are changed.
+func (s *Server) initNotificationTemplateOverrides() {
+	s.setNotificationTemplateOverrides(s.Config())
+
+	s.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if !reflect.DeepEqual(oldCfg.LocalizationSettings.NotificationTemplateOverrides, newCfg.LocalizationSettings.NotificationTemplateOverrides) {
+			s.setNotificationTemplateOverrides(newCfg)
+		}
+	})
+}
+
+func (s *Server) setNotificationTemplateOverrides(cfg *model.Config) {
+	overrides := make([]i18n.TemplateOverride, 0, len(cfg.LocalizationSettings.NotificationTemplateOverrides))
+	for _, override := range cfg.LocalizationSettings.NotificationTemplateOverrides {
+		overrides = append(overrides, i18n.TemplateOverride{
+			Locale:        override.Locale,
+			TranslationID: override.TranslationId,
+			Translation:   override.Translation,
+		})
+	}
+
+	if err := i18n.SetNotificationOverrides(overrides); err != nil {
+		mlog.Error("Unable to apply the notification template overrides", mlog.Err(err))
+	}
+}
+
+// PreviewNotification renders the email and push notifications the user would be sent for the
+// post, without sending them.
+func (a *App) PreviewNotification(userID, postID string) (*model.NotificationPreview, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	post, appErr := a.GetSinglePost(postID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channel, appErr := a.GetChannel(post.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	sender, appErr := a.GetUser(post.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	profileMap, err := a.Srv().Store.User().GetAllProfilesInChannel(context.Background(), channel.Id, true)
+	if err != nil {
+		return nil, model.NewAppError("PreviewNotification", "app.notification.preview.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var team *model.Team
+	if channel.TeamId != "" {
+		if team, appErr = a.GetTeam(channel.TeamId); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	team, err = a.getNotificationEmailTeam(channel, user, team)
+	if err != nil {
+		return nil, model.NewAppError("PreviewNotification", "app.notification.preview.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	notification := &PostNotification{
+		Channel:    channel,
+		Post:       post,
+		ProfileMap: profileMap,
+		Sender:     sender,
+	}
+
+	subject, body, err := a.renderNotificationEmail(notification, user, team, "")
+	if err != nil {
+		return nil, model.NewAppError("PreviewNotification", "app.notification.preview.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	cfg := a.Config()
+	nameFormat := a.GetNotificationNameFormat(user)
+	msg, appErr := a.BuildPushNotificationMessage(
+		*cfg.EmailSettings.PushNotificationContents,
+		post,
+		user,
+		channel,
+		notification.GetChannelName(nameFormat, user.Id),
+		notification.GetSenderName(nameFormat, *cfg.ServiceSettings.EnablePostUsernameOverride),
+		true,
+		false,
+		"",
+	)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &model.NotificationPreview{
+		Locale:       i18n.GetUserLocale(user.Locale),
+		Timezone:     user.GetPreferredTimezone(),
+		EmailSubject: subject,
+		EmailBody:    body,
+		PushMessage:  msg.Message,
+	}, nil
+}
@@ -556,6 +556,10 @@ func (a *App) CompleteOAuth(c *request.Context, service string, body io.ReadClos
 }
 
 func (a *App) getSSOProvider(service string) (einterfaces.OAuthProvider, *model.AppError) {
+	if model.IsOpenIdConnectService(service) {
+		return a.getOpenIdConnectProvider(service)
+	}
+
 	sso := a.Config().GetSSOService(service)
 	if sso == nil || !*sso.Enable {
 		return nil, model.NewAppError("getSSOProvider", "api.user.authorize_oauth_user.unsupported.app_error", nil, "service="+service, http.StatusNotImplemented)
@@ -622,6 +626,10 @@ func (a *App) LoginByOAuth(c *request.Context, service string, userData io.Reade
 		return nil, err
 	}
 
+	if model.IsOpenIdConnectService(service) {
+		a.syncOpenIdConnectGroups(service, buf.Bytes(), user)
+	}
+
 	return user, nil
 }
 
@@ -0,0 +1,217 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func isBuiltInOnboardingTask(taskID string) bool {
+	for _, task := range model.BuiltInOnboardingTasks() {
+		if task.Id == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) CreateOnboardingTask(task *model.OnboardingTask) (*model.OnboardingTask, *model.AppError) {
+	task.Id = ""
+	task.DeleteAt = 0
+
+	saved, err := a.Srv().Store.OnboardingTask().Save(task)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateOnboardingTask", "app.onboarding_task.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetOnboardingTask(taskID string) (*model.OnboardingTask, *model.AppError) {
+	task, err := a.Srv().Store.OnboardingTask().Get(taskID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOnboardingTask", "app.onboarding_task.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetOnboardingTask", "app.onboarding_task.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return task, nil
+}
+
+func (a *App) UpdateOnboardingTask(task *model.OnboardingTask) (*model.OnboardingTask, *model.AppError) {
+	if isBuiltInOnboardingTask(task.Id) {
+		return nil, model.NewAppError("UpdateOnboardingTask", "app.onboarding_task.built_in.app_error", nil, "id="+task.Id, http.StatusBadRequest)
+	}
+
+	oldTask, appErr := a.GetOnboardingTask(task.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	oldTask.Title = task.Title
+	oldTask.Description = task.Description
+	oldTask.Link = task.Link
+	oldTask.Audience = task.Audience
+	oldTask.SortOrder = task.SortOrder
+
+	updated, err := a.Srv().Store.OnboardingTask().Update(oldTask)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateOnboardingTask", "app.onboarding_task.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("UpdateOnboardingTask", "app.onboarding_task.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updated, nil
+}
+
+func (a *App) DeleteOnboardingTask(taskID string) *model.AppError {
+	if isBuiltInOnboardingTask(taskID) {
+		return model.NewAppError("DeleteOnboardingTask", "app.onboarding_task.built_in.app_error", nil, "id="+taskID, http.StatusBadRequest)
+	}
+
+	if err := a.Srv().Store.OnboardingTask().Delete(taskID, model.GetMillis()); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteOnboardingTask", "app.onboarding_task.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteOnboardingTask", "app.onboarding_task.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// getOnboardingTasksForUser returns the built-in and custom tasks the user should see, in
+// checklist order. Built-in tasks are translated to the user's locale.
+func (a *App) getOnboardingTasksForUser(user *model.User, isAdmin bool) ([]*model.OnboardingTask, *model.AppError) {
+	customTasks, err := a.Srv().Store.OnboardingTask().GetAll()
+	if err != nil {
+		return nil, model.NewAppError("getOnboardingTasksForUser", "app.onboarding_task.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	builtInTasks := model.BuiltInOnboardingTasks()
+	for _, task := range builtInTasks {
+		task.Title = T(task.Title)
+		task.Description = T(task.Description)
+	}
+
+	tasks := []*model.OnboardingTask{}
+	for _, task := range append(builtInTasks, customTasks...) {
+		if task.Audience == model.OnboardingTaskAudienceAdmins && !isAdmin {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].SortOrder < tasks[j].SortOrder
+	})
+
+	return tasks, nil
+}
+
+// GetOnboardingChecklist returns the onboarding tasks of the user along with when they completed
+// each of them.
+func (a *App) GetOnboardingChecklist(userID string, isAdmin bool) ([]*model.OnboardingTaskStatus, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	tasks, appErr := a.getOnboardingTasksForUser(user, isAdmin)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	preferences, err := a.Srv().Store.Preference().GetCategory(userID, model.PreferenceCategoryOnboardingTasks)
+	if err != nil {
+		return nil, model.NewAppError("GetOnboardingChecklist", "app.preference.get_category.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	completeAt := make(map[string]int64, len(preferences))
+	for _, preference := range preferences {
+		completeAt[preference.Name], _ = strconv.ParseInt(preference.Value, 10, 64)
+	}
+
+	checklist := make([]*model.OnboardingTaskStatus, 0, len(tasks))
+	for _, task := range tasks {
+		checklist = append(checklist, &model.OnboardingTaskStatus{
+			OnboardingTask: *task,
+			CompleteAt:     completeAt[task.Id],
+		})
+	}
+
+	return checklist, nil
+}
+
+func (a *App) onboardingTaskPreference(userID, taskID string, isAdmin bool) (*model.Preference, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	tasks, appErr := a.getOnboardingTasksForUser(user, isAdmin)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for _, task := range tasks {
+		if task.Id == taskID {
+			return &model.Preference{
+				UserId:   userID,
+				Category: model.PreferenceCategoryOnboardingTasks,
+				Name:     taskID,
+				Value:    strconv.FormatInt(model.GetMillis(), 10),
+			}, nil
+		}
+	}
+
+	return nil, model.NewAppError("onboardingTaskPreference", "app.onboarding_task.get.app_error", nil, "id="+taskID, http.StatusNotFound)
+}
+
+// CompleteOnboardingTask marks the task as done for the user.
+func (a *App) CompleteOnboardingTask(userID, taskID string, isAdmin bool) *model.AppError {
+	preference, appErr := a.onboardingTaskPreference(userID, taskID, isAdmin)
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.UpdatePreferences(userID, model.Preferences{*preference})
+}
+
+// ResetOnboardingTask marks the task as not done for the user.
+func (a *App) ResetOnboardingTask(userID, taskID string, isAdmin bool) *model.AppError {
+	preference, appErr := a.onboardingTaskPreference(userID, taskID, isAdmin)
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.DeletePreferences(userID, model.Preferences{*preference})
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/einterfaces"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// openIdConnectDiscoveryCacheDuration is how long the endpoints discovered for a provider are
+// used before being discovered again.
+const openIdConnectDiscoveryCacheDuration = time.Hour
+
+type openIdConnectDiscovery struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+
+	fetchedAt time.Time
+}
+
+// openIdConnectProvider is the OAuthProvider of a generic OpenID Connect provider, mapping the
+// claims returned by its userinfo endpoint as configured.
+type openIdConnectProvider struct {
+	settings *model.OpenIdConnectProvider
+	sso      *model.SSOSettings
+}
+
+var _ einterfaces.OAuthProvider = (*openIdConnectProvider)(nil)
+
+func (p *openIdConnectProvider) GetUserFromJSON(data io.Reader, tokenUser *model.User) (*model.User, error) {
+	var claims map[string]interface{}
+	if err := json.NewDecoder(data).Decode(&claims); err != nil {
+		return nil, err
+	}
+
+	return p.settings.UserFromClaims(claims)
+}
+
+func (p *openIdConnectProvider) GetSSOSettings(config *model.Config, service string) (*model.SSOSettings, error) {
+	return p.sso, nil
+}
+
+// GetUserFromIdToken returns no user, since the claims are read from the userinfo endpoint.
+func (p *openIdConnectProvider) GetUserFromIdToken(idToken string) (*model.User, error) {
+	return nil, nil
+}
+
+func (p *openIdConnectProvider) IsSameUser(dbUser, oAuthUser *model.User) bool {
+	return dbUser.AuthData != nil && oAuthUser.AuthData != nil && *dbUser.AuthData == *oAuthUser.AuthData
+}
+
+// getOpenIdConnectProvider returns the provider of an OpenID Connect service, with the endpoints
+// which aren't configured read from its discovery endpoint.
+func (a *App) getOpenIdConnectProvider(service string) (*openIdConnectProvider, *model.AppError) {
+	settings := a.Config().OpenIdConnectSettings.GetProvider(service)
+	if settings == nil || !settings.Enable {
+		return nil, model.NewAppError("getOpenIdConnectProvider", "api.user.authorize_oauth_user.unsupported.app_error", nil, "service="+service, http.StatusNotImplemented)
+	}
+
+	sso := settings.SSOSettings()
+	if settings.DiscoveryEndpoint != "" && (settings.AuthEndpoint == "" || settings.TokenEndpoint == "" || settings.UserAPIEndpoint == "") {
+		discovery, err := a.Srv().discoverOpenIdConnectEndpoints(settings.DiscoveryEndpoint)
+		if err != nil {
+			return nil, model.NewAppError("getOpenIdConnectProvider", "app.openid_connect.discovery.app_error", map[string]interface{}{"Service": settings.DisplayName}, err.Error(), http.StatusInternalServerError)
+		}
+
+		if settings.AuthEndpoint == "" {
+			sso.AuthEndpoint = model.NewString(discovery.AuthorizationEndpoint)
+		}
+		if settings.TokenEndpoint == "" {
+			sso.TokenEndpoint = model.NewString(discovery.TokenEndpoint)
+		}
+		if settings.UserAPIEndpoint == "" {
+			sso.UserAPIEndpoint = model.NewString(discovery.UserinfoEndpoint)
+		}
+	}
+
+	return &openIdConnectProvider{settings: settings, sso: sso}, nil
+}
+
+// discoverOpenIdConnectEndpoints returns the endpoints read from a discovery endpoint, cached for
+// openIdConnectDiscoveryCacheDuration.
+func (s *Server) discoverOpenIdConnectEndpoints(endpoint string) (*openIdConnectDiscovery, error) {
+	s.openIdConnectDiscoveryMut.Lock()
+	defer s.openIdConnectDiscoveryMut.Unlock()
+
+	if discovery, ok := s.openIdConnectDiscovery[endpoint]; ok && time.Since(discovery.fetchedAt) < openIdConnectDiscoveryCacheDuration {
+		return discovery, nil
+	}
+
+	resp, err := s.HTTPService().MakeClient(true).Get(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the discovery document")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("the discovery endpoint responded with status code %d", resp.StatusCode)
+	}
+
+	var discovery openIdConnectDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the discovery document")
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.UserinfoEndpoint == "" {
+		return nil, errors.New("the discovery document is missing endpoints")
+	}
+	discovery.fetchedAt = time.Now()
+
+	if s.openIdConnectDiscovery == nil {
+		s.openIdConnectDiscovery = make(map[string]*openIdConnectDiscovery)
+	}
+	s.openIdConnectDiscovery[endpoint] = &discovery
+
+	return &discovery, nil
+}
+
+// syncOpenIdConnectGroups adds a user to the custom groups named by the GroupsClaim of the
+// provider of an OpenID Connect service. Users are never removed from groups, so that groups
+// can also be managed in Mattermost.
+func (a *App) syncOpenIdConnectGroups(service string, userData []byte, user *model.User) {
+	settings := a.Config().OpenIdConnectSettings.GetProvider(service)
+	if settings == nil || settings.GroupsClaim == "" {
+		return
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(userData, &claims); err != nil {
+		mlog.Warn("Failed to decode the claims of an OpenID Connect user", mlog.String("service", service), mlog.Err(err))
+		return
+	}
+
+	for _, name := range settings.GroupsFromClaims(claims) {
+		group, appErr := a.GetGroupByName(name, model.GroupSearchOpts{})
+		if appErr != nil {
+			if appErr.StatusCode != http.StatusNotFound {
+				mlog.Warn("Failed to get a group named by an OpenID Connect claim", mlog.String("group_name", name), mlog.Err(appErr))
+			}
+			continue
+		}
+		if group.Source != model.GroupSourceCustom || group.DeleteAt != 0 {
+			continue
+		}
+
+		if _, appErr := a.UpsertGroupMember(group.Id, user.Id); appErr != nil {
+			mlog.Warn("Failed to add a user to a group named by an OpenID Connect claim", mlog.String("group_id", group.Id), mlog.String("user_id", user.Id), mlog.Err(appErr))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestOpenIdConnectProvider(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	discoveries := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		discoveries++
+		json.NewEncoder(w).Encode(map[string]string{
+			"authorization_endpoint": "https://sso.example.com/auth",
+			"token_endpoint":         "https://sso.example.com/token",
+			"userinfo_endpoint":      "https://sso.example.com/userinfo",
+		})
+	}))
+	defer ts.Close()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+		cfg.OpenIdConnectSettings.Providers = []*model.OpenIdConnectProvider{
+			{
+				Id:                "keycloak",
+				DisplayName:       "Keycloak",
+				Enable:            true,
+				ClientId:          "mattermost",
+				DiscoveryEndpoint: ts.URL,
+				TokenEndpoint:     "https://sso.example.com/custom_token",
+				GroupsClaim:       "groups",
+			},
+			{Id: "disabled", DisplayName: "Disabled"},
+		}
+	})
+
+	t.Run("the endpoints which are not set are discovered", func(t *testing.T) {
+		provider, appErr := th.App.getSSOProvider("oidckeycloak")
+		require.Nil(t, appErr)
+
+		sso, err := provider.GetSSOSettings(th.App.Config(), "oidckeycloak")
+		require.NoError(t, err)
+		assert.Equal(t, "https://sso.example.com/auth", *sso.AuthEndpoint)
+		assert.Equal(t, "https://sso.example.com/custom_token", *sso.TokenEndpoint)
+		assert.Equal(t, "https://sso.example.com/userinfo", *sso.UserAPIEndpoint)
+		assert.Equal(t, model.OpenIdConnectDefaultScope, *sso.Scope)
+
+		_, appErr = th.App.getSSOProvider("oidckeycloak")
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, discoveries, "the discovered endpoints are cached")
+	})
+
+	t.Run("disabled and unknown providers", func(t *testing.T) {
+		_, appErr := th.App.getSSOProvider("oidcdisabled")
+		require.NotNil(t, appErr)
+		_, appErr = th.App.getSSOProvider("oidcunknown")
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("the users are added to the groups of their claims", func(t *testing.T) {
+		group, appErr := th.App.CreateGroup(&model.Group{
+			DisplayName: "Developers",
+			Name:        model.NewString("developers" + model.NewId()[:8]),
+			Source:      model.GroupSourceCustom,
+		})
+		require.Nil(t, appErr)
+		ldapGroup := th.CreateGroup()
+
+		claims, err := json.Marshal(map[string]interface{}{
+			"sub":    model.NewId(),
+			"email":  "oidc" + model.NewId() + "@example.com",
+			"groups": []string{*group.Name, *ldapGroup.Name, "unknown"},
+		})
+		require.NoError(t, err)
+
+		user, appErr := th.App.LoginByOAuth(th.Context, "oidckeycloak", bytes.NewReader(claims), "", nil)
+		require.Nil(t, appErr)
+		assert.Equal(t, "oidckeycloak", user.AuthService)
+
+		groups, appErr := th.App.GetGroupsByUserId(user.Id)
+		require.Nil(t, appErr)
+		require.Len(t, groups, 1)
+		assert.Equal(t, group.Id, groups[0].Id)
+	})
+}
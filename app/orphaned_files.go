@@ -0,0 +1,197 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// orphanedFilesBatchSize is how many FileInfos are read, or stored files are looked up, at once.
+const orphanedFilesBatchSize = 1000
+
+// CleanupOrphanedFiles deletes the files created before createdBefore which are no longer
+// used: the FileInfos of posts which were purged, by data retention or dropped partitions, with
+// their stored files, and the stored attachments which no FileInfo refers to. A dry run only
+// reports what would be deleted. Failing to delete a file is counted in the report rather than
+// failing the cleanup.
+func (a *App) CleanupOrphanedFiles(dryRun bool, createdBefore time.Time) (*model.OrphanedFilesReport, *model.AppError) {
+	report := &model.OrphanedFilesReport{
+		DryRun:        dryRun,
+		CreatedBefore: model.GetMillisForTime(createdBefore),
+		Paths:         []string{},
+	}
+
+	if appErr := a.cleanupOrphanedFileInfos(report); appErr != nil {
+		return report, appErr
+	}
+
+	if appErr := a.cleanupUnreferencedStoredFiles(report, createdBefore); appErr != nil {
+		return report, appErr
+	}
+
+	return report, nil
+}
+
+func (a *App) cleanupOrphanedFileInfos(report *model.OrphanedFilesReport) *model.AppError {
+	afterID := ""
+	for {
+		infos, err := a.Srv().Store.FileInfo().GetOrphaned(report.CreatedBefore, afterID, orphanedFilesBatchSize)
+		if err != nil {
+			return model.NewAppError("CleanupOrphanedFiles", "app.file_info.get_orphaned.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		if len(infos) == 0 {
+			return nil
+		}
+
+		for _, info := range infos {
+			afterID = info.Id
+
+			// The FileInfo is kept as long as its stored file is reused by other FileInfos.
+			ownsFile := info.DedupOf == ""
+			if ownsFile && a.isSharedFile(info) {
+				report.SharedFileInfos++
+				continue
+			}
+
+			report.FileInfos++
+			if ownsFile {
+				report.ReclaimedBytes += info.Size
+				report.AddPath(info.Path)
+			}
+
+			if report.DryRun {
+				continue
+			}
+
+			if ownsFile {
+				if failed := a.removeStoredFiles(info.Path, info.ThumbnailPath, info.PreviewPath); failed > 0 {
+					report.Errors += failed
+					continue
+				}
+			}
+
+			if err := a.Srv().Store.FileInfo().PermanentDelete(info.Id); err != nil {
+				mlog.Warn("Failed to delete orphaned FileInfo", mlog.String("file_id", info.Id), mlog.Err(err))
+				report.Errors++
+			}
+		}
+	}
+}
+
+// removeStoredFiles removes the given paths from the file store, returning how many of them
+// could not be removed.
+func (a *App) removeStoredFiles(paths ...string) int {
+	failed := 0
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if appErr := a.RemoveFile(path); appErr != nil {
+			mlog.Warn("Failed to remove stored file", mlog.String("path", path), mlog.Err(appErr))
+			failed++
+		}
+	}
+	return failed
+}
+
+// cleanupUnreferencedStoredFiles looks for the attachments stored under the daily directories,
+// as YYYYMMDD/teams/{team}/channels/{channel}/users/{user}/{file id}/{name}, whose file id is
+// not referred to by any FileInfo.
+func (a *App) cleanupUnreferencedStoredFiles(report *model.OrphanedFilesReport, createdBefore time.Time) *model.AppError {
+	dirs, appErr := a.ListDirectory("")
+	if appErr != nil {
+		return appErr
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		day, err := time.Parse("20060102", filepath.Base(dir))
+		if err != nil || !day.AddDate(0, 0, 1).Before(createdBefore) {
+			continue
+		}
+
+		paths, appErr := a.ListDirectoryRecursively(dir)
+		if appErr != nil {
+			mlog.Warn("Failed to list stored files", mlog.String("path", dir), mlog.Err(appErr))
+			report.Errors++
+			continue
+		}
+
+		pathsByFileID := map[string][]string{}
+		for _, path := range paths {
+			parts := strings.Split(filepath.ToSlash(path), "/")
+			if len(parts) != 8 || parts[1] != "teams" || !model.IsValidId(parts[6]) {
+				continue
+			}
+			pathsByFileID[parts[6]] = append(pathsByFileID[parts[6]], path)
+		}
+
+		fileIDs := make([]string, 0, len(pathsByFileID))
+		for fileID := range pathsByFileID {
+			fileIDs = append(fileIDs, fileID)
+		}
+		sort.Strings(fileIDs)
+
+		for start := 0; start < len(fileIDs); start += orphanedFilesBatchSize {
+			end := start + orphanedFilesBatchSize
+			if end > len(fileIDs) {
+				end = len(fileIDs)
+			}
+
+			referenced, err := a.Srv().Store.FileInfo().GetReferencedIds(fileIDs[start:end])
+			if err != nil {
+				return model.NewAppError("CleanupOrphanedFiles", "app.file_info.get_referenced.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+			isReferenced := make(map[string]bool, len(referenced))
+			for _, fileID := range referenced {
+				isReferenced[fileID] = true
+			}
+
+			for _, fileID := range fileIDs[start:end] {
+				if !isReferenced[fileID] {
+					a.cleanupUnreferencedStoredFile(report, createdBefore, pathsByFileID[fileID])
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *App) cleanupUnreferencedStoredFile(report *model.OrphanedFilesReport, createdBefore time.Time, paths []string) {
+	for _, path := range paths {
+		// The FileInfo of a file being uploaded is saved after the file is stored.
+		modTime, appErr := a.FileModTime(path)
+		if appErr != nil {
+			mlog.Warn("Failed to get the modification time of a stored file", mlog.String("path", path), mlog.Err(appErr))
+			report.Errors++
+			continue
+		}
+		if !modTime.Before(createdBefore) {
+			continue
+		}
+
+		size, appErr := a.FileSize(path)
+		if appErr != nil {
+			mlog.Warn("Failed to get the size of a stored file", mlog.String("path", path), mlog.Err(appErr))
+			report.Errors++
+			continue
+		}
+
+		report.StoredFiles++
+		report.ReclaimedBytes += size
+		report.AddPath(path)
+
+		if !report.DryRun {
+			report.Errors += a.removeStoredFiles(path)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestCleanupOrphanedFiles(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	const day = "20000101"
+	defer th.App.FileBackend().RemoveDirectory(day)
+
+	writeFile := func(fileID string) string {
+		path := day + "/teams/" + th.BasicTeam.Id + "/channels/" + th.BasicChannel.Id + "/users/" + th.BasicUser.Id + "/" + fileID + "/file.txt"
+		_, appErr := th.App.WriteFile(strings.NewReader("abc"), path)
+		require.Nil(t, appErr)
+		return path
+	}
+
+	saveFileInfo := func(postID string) *model.FileInfo {
+		id := model.NewId()
+		info, err := th.App.Srv().Store.FileInfo().Save(&model.FileInfo{
+			Id:        id,
+			CreatorId: th.BasicUser.Id,
+			PostId:    postID,
+			Path:      writeFile(id),
+			Size:      3,
+		})
+		require.NoError(t, err)
+		return info
+	}
+
+	fileExists := func(path string) bool {
+		exists, appErr := th.App.FileExists(path)
+		require.Nil(t, appErr)
+		return exists
+	}
+
+	attached := saveFileInfo(th.BasicPost.Id)
+	defer th.App.Srv().Store.FileInfo().PermanentDelete(attached.Id)
+	orphaned := saveFileInfo(model.NewId())
+	unreferenced := writeFile(model.NewId())
+
+	// Files are only deleted once they are older than createdBefore.
+	createdBefore := time.Now().Add(time.Hour)
+
+	t.Run("dry run", func(t *testing.T) {
+		report, appErr := th.App.CleanupOrphanedFiles(true, createdBefore)
+		require.Nil(t, appErr)
+		assert.True(t, report.DryRun)
+		assert.Contains(t, report.Paths, orphaned.Path)
+		assert.Contains(t, report.Paths, unreferenced)
+		assert.NotContains(t, report.Paths, attached.Path)
+		assert.GreaterOrEqual(t, report.ReclaimedBytes, int64(6))
+
+		assert.True(t, fileExists(orphaned.Path))
+		assert.True(t, fileExists(unreferenced))
+		_, err := th.App.Srv().Store.FileInfo().Get(orphaned.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("files within the safety window are kept", func(t *testing.T) {
+		report, appErr := th.App.CleanupOrphanedFiles(false, time.Now().Add(-time.Hour))
+		require.Nil(t, appErr)
+		assert.NotContains(t, report.Paths, orphaned.Path)
+		assert.NotContains(t, report.Paths, unreferenced)
+		assert.True(t, fileExists(orphaned.Path))
+		assert.True(t, fileExists(unreferenced))
+	})
+
+	t.Run("cleanup", func(t *testing.T) {
+		report, appErr := th.App.CleanupOrphanedFiles(false, createdBefore)
+		require.Nil(t, appErr)
+		assert.Contains(t, report.Paths, orphaned.Path)
+		assert.Contains(t, report.Paths, unreferenced)
+
+		assert.False(t, fileExists(orphaned.Path))
+		assert.False(t, fileExists(unreferenced))
+		assert.True(t, fileExists(attached.Path))
+
+		_, err := th.App.Srv().Store.FileInfo().Get(orphaned.Id)
+		require.Error(t, err)
+		_, err = th.App.Srv().Store.FileInfo().Get(attached.Id)
+		require.NoError(t, err)
+	})
+}
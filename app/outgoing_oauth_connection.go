@@ -0,0 +1,251 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const (
+	// outgoingOAuthTokenExpiryMargin is how long before their expiry the tokens are renewed, so
+	// that they don't expire on their way to the integration.
+	outgoingOAuthTokenExpiryMargin    = 30 * time.Second
+	maxOutgoingOAuthTokenResponseSize = 64 * 1024
+)
+
+// cachedOutgoingOAuthToken is an access token cached until it expires, for the version of the
+// connection it was obtained with.
+type cachedOutgoingOAuthToken struct {
+	token              *model.OutgoingOAuthToken
+	connectionUpdateAt int64
+	expiresAt          time.Time
+}
+
+func (a *App) CreateOutgoingOAuthConnection(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, *model.AppError) {
+	connection.Id = ""
+
+	saved, err := a.Srv().Store.OutgoingOAuthConnection().Save(connection)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateOutgoingOAuthConnection", "app.outgoing_oauth_connection.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetOutgoingOAuthConnection(connectionID string) (*model.OutgoingOAuthConnection, *model.AppError) {
+	connection, err := a.Srv().Store.OutgoingOAuthConnection().Get(connectionID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOutgoingOAuthConnection", "app.outgoing_oauth_connection.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetOutgoingOAuthConnection", "app.outgoing_oauth_connection.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return connection, nil
+}
+
+func (a *App) GetOutgoingOAuthConnections(page, perPage int) ([]*model.OutgoingOAuthConnection, *model.AppError) {
+	connections, err := a.Srv().Store.OutgoingOAuthConnection().GetAll(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingOAuthConnections", "app.outgoing_oauth_connection.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return connections, nil
+}
+
+// UpdateOutgoingOAuthConnection updates the connection. The secrets left empty keep their current
+// value, so that they don't have to be sent again with every update.
+func (a *App) UpdateOutgoingOAuthConnection(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, *model.AppError) {
+	oldConnection, appErr := a.GetOutgoingOAuthConnection(connection.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	oldConnection.Name = connection.Name
+	oldConnection.ClientId = connection.ClientId
+	oldConnection.OAuthTokenURL = connection.OAuthTokenURL
+	oldConnection.GrantType = connection.GrantType
+	oldConnection.Scopes = connection.Scopes
+	oldConnection.CredentialsUsername = connection.CredentialsUsername
+	oldConnection.Audiences = connection.Audiences
+	if connection.ClientSecret != "" {
+		oldConnection.ClientSecret = connection.ClientSecret
+	}
+	if connection.CredentialsPassword != "" {
+		oldConnection.CredentialsPassword = connection.CredentialsPassword
+	}
+
+	updated, err := a.Srv().Store.OutgoingOAuthConnection().Update(oldConnection)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateOutgoingOAuthConnection", "app.outgoing_oauth_connection.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("UpdateOutgoingOAuthConnection", "app.outgoing_oauth_connection.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.Srv().outgoingOAuthTokens.Delete(updated.Id)
+
+	return updated, nil
+}
+
+// DeleteOutgoingOAuthConnection deletes the connection. The requests of the integrations still
+// referencing it fail until they reference another connection or none.
+func (a *App) DeleteOutgoingOAuthConnection(connectionID string) *model.AppError {
+	if err := a.Srv().Store.OutgoingOAuthConnection().Delete(connectionID); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteOutgoingOAuthConnection", "app.outgoing_oauth_connection.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteOutgoingOAuthConnection", "app.outgoing_oauth_connection.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.Srv().outgoingOAuthTokens.Delete(connectionID)
+
+	return nil
+}
+
+// CheckOutgoingOAuthConnectionURLs checks that the connection exists and that its tokens may be
+// sent to the URLs of an integration referencing it.
+func (a *App) CheckOutgoingOAuthConnectionURLs(connectionID string, urls []string) *model.AppError {
+	connection, appErr := a.GetOutgoingOAuthConnection(connectionID)
+	if appErr != nil {
+		appErr.StatusCode = http.StatusBadRequest
+		return appErr
+	}
+
+	for _, u := range urls {
+		if !connection.MatchesAudience(u) {
+			return model.NewAppError("CheckOutgoingOAuthConnectionURLs", "app.outgoing_oauth_connection.audience.app_error", map[string]interface{}{"URL": u}, "connection_id="+connectionID, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// authorizeOutgoingRequest sets the Authorization header of the request of an integration to a
+// bearer access token of the connection, if the URL of the request is one of its audiences.
+func (a *App) authorizeOutgoingRequest(req *http.Request, connectionID string) *model.AppError {
+	connection, appErr := a.GetOutgoingOAuthConnection(connectionID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if !connection.MatchesAudience(req.URL.String()) {
+		return model.NewAppError("authorizeOutgoingRequest", "app.outgoing_oauth_connection.audience.app_error", map[string]interface{}{"URL": req.URL.String()}, "connection_id="+connectionID, http.StatusBadRequest)
+	}
+
+	token, err := a.getOutgoingOAuthToken(connection)
+	if err != nil {
+		return model.NewAppError("authorizeOutgoingRequest", "app.outgoing_oauth_connection.token.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	return nil
+}
+
+// getOutgoingOAuthToken returns an access token of the connection, from the cache as long as it
+// is valid and the connection didn't change.
+func (a *App) getOutgoingOAuthToken(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthToken, error) {
+	if value, ok := a.Srv().outgoingOAuthTokens.Load(connection.Id); ok {
+		cached := value.(*cachedOutgoingOAuthToken)
+		if cached.connectionUpdateAt == connection.UpdateAt && time.Now().Before(cached.expiresAt) {
+			return cached.token, nil
+		}
+	}
+
+	token, err := a.requestOutgoingOAuthToken(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tokens whose lifetime isn't known aren't cached.
+	if lifetime := time.Duration(token.ExpiresIn)*time.Second - outgoingOAuthTokenExpiryMargin; lifetime > 0 {
+		a.Srv().outgoingOAuthTokens.Store(connection.Id, &cachedOutgoingOAuthToken{
+			token:              token,
+			connectionUpdateAt: connection.UpdateAt,
+			expiresAt:          time.Now().Add(lifetime),
+		})
+	}
+
+	return token, nil
+}
+
+// requestOutgoingOAuthToken requests an access token from the token endpoint of the connection,
+// authenticating with the client credentials as described by RFC 6749.
+func (a *App) requestOutgoingOAuthToken(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", connection.GrantType)
+	if connection.Scopes != "" {
+		form.Set("scope", connection.Scopes)
+	}
+	if connection.GrantType == model.OutgoingOAuthConnectionGrantTypePassword {
+		form.Set("username", connection.CredentialsUsername)
+		form.Set("password", connection.CredentialsPassword)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, connection.OAuthTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(connection.ClientId), url.QueryEscape(connection.ClientSecret))
+
+	resp, err := a.HTTPService().MakeClient(false).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxOutgoingOAuthTokenResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	var token model.OutgoingOAuthToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return nil, fmt.Errorf("unsupported token type %s", token.TokenType)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestAuthorizeOutgoingRequest(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	var requests int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || clientSecret != "secret" || r.FormValue("grant_type") != model.OutgoingOAuthConnectionGrantTypeClientCredentials {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(model.OutgoingOAuthToken{AccessToken: "access-token", TokenType: "Bearer", ExpiresIn: 3600})
+	}))
+	defer tokenServer.Close()
+
+	connection, appErr := th.App.CreateOutgoingOAuthConnection(&model.OutgoingOAuthConnection{
+		Name:          "Provider",
+		ClientId:      "client",
+		ClientSecret:  "secret",
+		OAuthTokenURL: tokenServer.URL,
+		GrantType:     model.OutgoingOAuthConnectionGrantTypeClientCredentials,
+		Audiences:     model.StringArray{"https://integration.example.com/hooks"},
+	})
+	require.Nil(t, appErr)
+
+	t.Run("the token is requested once and cached", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			req, err := http.NewRequest(http.MethodPost, "https://integration.example.com/hooks/build", nil)
+			require.NoError(t, err)
+			require.Nil(t, th.App.authorizeOutgoingRequest(req, connection.Id))
+			assert.Equal(t, "Bearer access-token", req.Header.Get("Authorization"))
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("updating the connection renews the token", func(t *testing.T) {
+		_, appErr := th.App.UpdateOutgoingOAuthConnection(&model.OutgoingOAuthConnection{
+			Id:            connection.Id,
+			Name:          "Renamed",
+			ClientId:      "client",
+			OAuthTokenURL: tokenServer.URL,
+			GrantType:     model.OutgoingOAuthConnectionGrantTypeClientCredentials,
+			Audiences:     connection.Audiences,
+		})
+		require.Nil(t, appErr)
+
+		req, err := http.NewRequest(http.MethodPost, "https://integration.example.com/hooks/build", nil)
+		require.NoError(t, err)
+		require.Nil(t, th.App.authorizeOutgoingRequest(req, connection.Id))
+		assert.Equal(t, "Bearer access-token", req.Header.Get("Authorization"))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("the token isn't sent outside the audiences", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "https://evil.example.com/hooks/build", nil)
+		require.NoError(t, err)
+		appErr := th.App.authorizeOutgoingRequest(req, connection.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.outgoing_oauth_connection.audience.app_error", appErr.Id)
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("integrations are checked against the audiences", func(t *testing.T) {
+		require.Nil(t, th.App.CheckOutgoingOAuthConnectionURLs(connection.Id, []string{"https://integration.example.com/hooks/a"}))
+
+		appErr := th.App.CheckOutgoingOAuthConnectionURLs(connection.Id, []string{"https://integration.example.com/hooks/a", "https://evil.example.com"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		appErr = th.App.CheckOutgoingOAuthConnectionURLs(model.NewId(), []string{"https://integration.example.com/hooks/a"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
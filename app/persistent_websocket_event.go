@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// PublishPersistentWebSocketEvent publishes a websocket event on behalf of a plugin and keeps it
+// for model.PersistentWebSocketEventRetentionMinutes, so that it is replayed to the clients that
+// were disconnected when it was published.
+func (a *App) PublishPersistentWebSocketEvent(pluginID string, ev *model.WebSocketEvent) *model.AppError {
+	event, err := model.NewPersistentWebSocketEvent(pluginID, ev)
+	if err != nil {
+		return model.NewAppError("PublishPersistentWebSocketEvent", "app.persistent_websocket_event.marshal.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	event, err = a.Srv().Store.PersistentWebSocketEvent().Save(event)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return appErr
+		default:
+			return model.NewAppError("PublishPersistentWebSocketEvent", "app.persistent_websocket_event.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	data := make(map[string]interface{}, len(ev.GetData())+2)
+	for key, value := range ev.GetData() {
+		data[key] = value
+	}
+	data[model.PersistentWebSocketEventIdKey] = event.Id
+	data[model.PersistentWebSocketEventCreateAtKey] = event.CreateAt
+
+	a.Publish(ev.SetData(data))
+	return nil
+}
+
+// ReplayPersistentWebSocketEvents sends to a reconnecting client the persistent events it is
+// allowed to receive that were created after since. It must be called once the connection is
+// registered with its hub.
+func (a *App) ReplayPersistentWebSocketEvents(wc *WebConn, since int64) {
+	hub := a.GetHubForUserId(wc.UserId)
+	if hub == nil {
+		return
+	}
+
+	events, err := a.Srv().Store.PersistentWebSocketEvent().GetForUserSince(wc.UserId, since, model.PersistentWebSocketEventReplayLimit)
+	if err != nil {
+		mlog.Warn("Failed to get persistent websocket events to replay", mlog.String("user_id", wc.UserId), mlog.Err(err))
+		return
+	}
+
+	for _, event := range events {
+		if event.IsOmitted(wc.UserId) {
+			continue
+		}
+
+		ev, err := event.ToWebSocketEvent()
+		if err != nil {
+			mlog.Warn("Failed to replay persistent websocket event", mlog.String("id", event.Id), mlog.Err(err))
+			continue
+		}
+		hub.SendMessage(wc, ev)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// SetPinnedPostsOrder puts the given pinned posts of a channel first in its pinned posts, in that
+// order. The other pinned posts follow from the oldest to the newest.
+func (a *App) SetPinnedPostsOrder(channelID string, postIDs []string) (*model.PostList, *model.AppError) {
+	pinned, appErr := a.GetPinnedPosts(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	seen := make(map[string]bool, len(postIDs))
+	for _, postID := range postIDs {
+		if _, ok := pinned.Posts[postID]; !ok || seen[postID] {
+			return nil, model.NewAppError("SetPinnedPostsOrder", "app.channel.pinned_posts_order.invalid_post.app_error", nil, "post_id="+postID, http.StatusBadRequest)
+		}
+		seen[postID] = true
+	}
+
+	if err := a.Srv().Store.Channel().SetPinnedPostsOrder(channelID, postIDs); err != nil {
+		return nil, model.NewAppError("SetPinnedPostsOrder", "app.channel.pinned_posts_order.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	pinned, appErr = a.GetPinnedPosts(channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	message := model.NewWebSocketEvent(model.WebsocketEventPinnedPostsOrderUpdated, "", channelID, "", nil)
+	message.Add("order", pinned.Order)
+	a.Publish(message)
+
+	return pinned, nil
+}
+
+// checkPinnedPostsLimit returns an error if no more posts can be pinned in the channel.
+func (a *App) checkPinnedPostsLimit(channelID string) *model.AppError {
+	limit := *a.Config().ServiceSettings.MaxPinnedPostsPerChannel
+	if limit == 0 {
+		return nil
+	}
+
+	count, err := a.Srv().Store.Channel().GetPinnedPostCount(channelID, false)
+	if err != nil {
+		return model.NewAppError("checkPinnedPostsLimit", "app.channel.get_pinnedpost_count.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if count >= int64(limit) {
+		return model.NewAppError("checkPinnedPostsLimit", "api.post.update_post.pinned_posts_limit.app_error", map[string]interface{}{"Max": limit}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
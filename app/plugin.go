@@ -179,6 +179,7 @@ func (ch *Channels) initPlugins(c *request.Context, pluginDir, webappPluginDir s
 		ch.syncPluginsActiveState()
 		if pluginsEnvironment != nil {
 			pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
+			ch.configurePluginResourceLimits(pluginsEnvironment)
 		}
 		return
 	}
@@ -209,6 +210,7 @@ func (ch *Channels) initPlugins(c *request.Context, pluginDir, webappPluginDir s
 	ch.pluginsLock.Unlock()
 
 	ch.pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
+	ch.configurePluginResourceLimits(ch.pluginsEnvironment)
 
 	if err := ch.syncPlugins(); err != nil {
 		mlog.Error("Failed to sync plugins from the file store", mlog.Err(err))
@@ -407,14 +409,16 @@ func (ch *Channels) enablePlugin(id string) *model.AppError {
 		return model.NewAppError("EnablePlugin", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
 	}
 
-	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
-		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: true}
-	})
+	cfg := ch.cfgSvc.Config().Clone()
+	cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: true}
 
 	// This call will implicitly invoke SyncPluginsActiveState which will activate enabled plugins.
-	if _, _, err := ch.cfgSvc.SaveConfig(ch.cfgSvc.Config(), true); err != nil {
-		if err.Id == "ent.cluster.save_config.error" {
+	if _, _, err := ch.cfgSvc.SaveConfig(cfg, true); err != nil {
+		switch err.Id {
+		case "ent.cluster.save_config.error":
 			return model.NewAppError("EnablePlugin", "app.plugin.cluster.save_config.app_error", nil, "", http.StatusInternalServerError)
+		case "app.config.locked_path.app_error":
+			return err
 		}
 		return model.NewAppError("EnablePlugin", "app.plugin.config.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
@@ -458,15 +462,18 @@ func (ch *Channels) disablePlugin(id string) *model.AppError {
 		return model.NewAppError("DisablePlugin", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
 	}
 
-	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
-		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: false}
-	})
-	ch.unregisterPluginCommands(id)
+	cfg := ch.cfgSvc.Config().Clone()
+	cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: false}
 
 	// This call will implicitly invoke SyncPluginsActiveState which will deactivate disabled plugins.
-	if _, _, err := ch.cfgSvc.SaveConfig(ch.cfgSvc.Config(), true); err != nil {
+	if _, _, err := ch.cfgSvc.SaveConfig(cfg, true); err != nil {
+		if err.Id == "app.config.locked_path.app_error" {
+			return err
+		}
 		return model.NewAppError("DisablePlugin", "app.plugin.config.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
+	ch.unregisterPluginCommands(id)
+	ch.unregisterPluginScheduledTasks(id)
 
 	return nil
 }
@@ -888,6 +888,10 @@ func (api *PluginAPI) KVCompareAndDelete(key string, oldValue []byte) (bool, *mo
 	return api.app.CompareAndDeletePluginKey(api.id, key, oldValue)
 }
 
+func (api *PluginAPI) KVSetMultiple(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	return api.app.SetPluginKeysWithOptions(api.id, operations)
+}
+
 func (api *PluginAPI) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
 	return api.app.SetPluginKeyWithExpiry(api.id, key, value, expireInSeconds)
 }
@@ -914,6 +918,41 @@ func (api *PluginAPI) PublishWebSocketEvent(event string, payload map[string]int
 	api.app.Publish(ev)
 }
 
+func (api *PluginAPI) RegisterScheduledTask(cron, callback string) error {
+	return api.app.RegisterPluginScheduledTask(api.id, cron, callback)
+}
+
+func (api *PluginAPI) UnregisterScheduledTask(callback string) error {
+	api.app.UnregisterPluginScheduledTask(api.id, callback)
+	return nil
+}
+
+func (api *PluginAPI) CreateSearchIndex(index string) *model.AppError {
+	return api.app.CreatePluginSearchIndex(api.id, index)
+}
+
+func (api *PluginAPI) DeleteSearchIndex(index string) *model.AppError {
+	return api.app.DeletePluginSearchIndex(api.id, index)
+}
+
+func (api *PluginAPI) IndexSearchDocument(index string, document *model.PluginSearchDocument) *model.AppError {
+	return api.app.IndexPluginSearchDocument(api.id, index, document)
+}
+
+func (api *PluginAPI) DeleteSearchDocument(index, documentID string) *model.AppError {
+	return api.app.DeletePluginSearchDocument(api.id, index, documentID)
+}
+
+func (api *PluginAPI) SearchIndex(index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError) {
+	return api.app.SearchPluginSearchIndex(api.id, index, params)
+}
+
+func (api *PluginAPI) PublishPersistentWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) *model.AppError {
+	ev := model.NewWebSocketEvent(fmt.Sprintf("custom_%v_%v", api.id, event), "", "", "", nil)
+	ev = ev.SetBroadcast(broadcast).SetData(payload)
+	return api.app.PublishPersistentWebSocketEvent(api.id, ev)
+}
+
 func (api *PluginAPI) HasPermissionTo(userID string, permission *model.Permission) bool {
 	return api.app.HasPermissionTo(userID, permission)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/config"
 	"github.com/mattermost/mattermost-server/v6/einterfaces/mocks"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin"
@@ -573,6 +574,36 @@ func TestPluginAPIGetFile(t *testing.T) {
 	require.Nil(t, data)
 }
 
+func TestPluginAPICreatePostDirectMessageRequest(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	api := th.SetupPluginAPI()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.TeamSettings.EnableDirectMessageRequests = true })
+
+	stranger := th.CreateUser()
+	channel := th.CreateDmChannel(stranger)
+	_, err := th.App.Srv().Store.DirectMessageRequest().Save(&model.DirectMessageRequest{
+		ChannelId:   channel.Id,
+		RequesterId: th.BasicUser.Id,
+		RecipientId: stranger.Id,
+		Status:      model.DirectMessageRequestStatusDeclined,
+	})
+	require.NoError(t, err)
+
+	_, appErr := api.CreatePost(&model.Post{ChannelId: channel.Id, UserId: th.BasicUser.Id, Message: "hello"})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "api.post.create_post.direct_message_request_declined.app_error", appErr.Id)
+	assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+
+	// The recipient posting accepts the request.
+	_, appErr = api.CreatePost(&model.Post{ChannelId: channel.Id, UserId: stranger.Id, Message: "hello"})
+	require.Nil(t, appErr)
+
+	_, appErr = api.CreatePost(&model.Post{ChannelId: channel.Id, UserId: th.BasicUser.Id, Message: "hello again"})
+	require.Nil(t, appErr)
+}
+
 func TestPluginAPIGetFileInfos(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -704,6 +735,38 @@ func TestPluginAPISavePluginConfig(t *testing.T) {
 	assert.Equal(t, expectedConfiguration, savedConfiguration)
 }
 
+func TestPluginAPISaveConfigLockedPaths(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	os.Setenv(config.LockedPathsEnvVar, "ServiceSettings.SiteURL,PluginSettings.Plugins")
+	defer func() {
+		os.Unsetenv(config.LockedPathsEnvVar)
+		require.NoError(t, th.App.ReloadConfig())
+	}()
+	require.NoError(t, th.App.ReloadConfig())
+
+	api := NewPluginAPI(th.App, th.Context, &model.Manifest{Id: "pluginid"})
+	siteURL := *th.App.Config().ServiceSettings.SiteURL
+
+	cfg := api.GetUnsanitizedConfig()
+	*cfg.ServiceSettings.SiteURL = "http://locked.example.com"
+	appErr := api.SaveConfig(cfg)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.config.locked_path.app_error", appErr.Id)
+	assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	assert.Equal(t, siteURL, *th.App.Config().ServiceSettings.SiteURL)
+
+	appErr = api.SavePluginConfig(map[string]interface{}{"mystringsetting": "str"})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.config.locked_path.app_error", appErr.Id)
+	assert.NotContains(t, th.App.Config().PluginSettings.Plugins, "pluginid")
+
+	cfg = api.GetUnsanitizedConfig()
+	*cfg.TeamSettings.SiteName = "Unlocked"
+	require.Nil(t, api.SaveConfig(cfg))
+}
+
 func TestPluginAPIGetPluginConfig(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -1635,6 +1698,7 @@ func TestAPIMetrics(t *testing.T) {
 
 		// Don't care about these mocks
 		metricsMock.On("ObservePluginHookDuration", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+		metricsMock.On("IncrementPluginHookCounter", mock.Anything, mock.Anything).Return()
 		metricsMock.On("ObservePluginMultiHookIterationDuration", mock.Anything, mock.Anything, mock.Anything).Return()
 		metricsMock.On("ObservePluginMultiHookDuration", mock.Anything).Return()
 
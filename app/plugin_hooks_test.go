@@ -1091,6 +1091,11 @@ func TestHookMetrics(t *testing.T) {
 		metricsMock.On("ObservePluginHookDuration", pluginID, "OnDeactivate", true, mock.Anything).Return()
 		metricsMock.On("ObservePluginHookDuration", pluginID, "OnConfigurationChange", true, mock.Anything).Return()
 		metricsMock.On("ObservePluginHookDuration", pluginID, "UserHasBeenCreated", true, mock.Anything).Return()
+		metricsMock.On("IncrementPluginHookCounter", pluginID, "Implemented").Return()
+		metricsMock.On("IncrementPluginHookCounter", pluginID, "OnActivate").Return()
+		metricsMock.On("IncrementPluginHookCounter", pluginID, "OnDeactivate").Return()
+		metricsMock.On("IncrementPluginHookCounter", pluginID, "OnConfigurationChange").Return()
+		metricsMock.On("IncrementPluginHookCounter", pluginID, "UserHasBeenCreated").Return()
 
 		// Don't care about these calls.
 		metricsMock.On("ObservePluginAPIDuration", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
@@ -1128,6 +1133,59 @@ func TestHookMetrics(t *testing.T) {
 
 		metricsMock.AssertExpectations(t)
 	})
+
+	t.Run("should count the errors returned by hooks", func(t *testing.T) {
+		metricsMock := &mocks.MetricsInterface{}
+
+		pluginDir, err := ioutil.TempDir("", "")
+		require.NoError(t, err)
+		webappPluginDir, err := ioutil.TempDir("", "")
+		require.NoError(t, err)
+		defer os.RemoveAll(pluginDir)
+		defer os.RemoveAll(webappPluginDir)
+
+		env, err := plugin.NewEnvironment(th.NewPluginAPI, NewDriverImpl(th.Server), pluginDir, webappPluginDir, th.App.Log(), metricsMock)
+		require.NoError(t, err)
+
+		pluginID := model.NewId()
+		backend := filepath.Join(pluginDir, pluginID, "backend.exe")
+		code :=
+			`
+	package main
+
+	import (
+		"errors"
+
+		"github.com/mattermost/mattermost-server/v6/plugin"
+	)
+
+	type MyPlugin struct {
+		plugin.MattermostPlugin
+	}
+
+	func (p *MyPlugin) OnActivate() error {
+		return errors.New("activation failed")
+	}
+
+	func main() {
+		plugin.ClientMain(&MyPlugin{})
+	}
+`
+		utils.CompileGo(t, code, backend)
+		ioutil.WriteFile(filepath.Join(pluginDir, pluginID, "plugin.json"), []byte(`{"id": "`+pluginID+`", "server": {"executable": "backend.exe"}}`), 0600)
+
+		metricsMock.On("ObservePluginHookDuration", pluginID, "Implemented", true, mock.Anything).Return()
+		metricsMock.On("IncrementPluginHookCounter", pluginID, "Implemented").Return()
+		metricsMock.On("ObservePluginHookDuration", pluginID, "OnActivate", false, mock.Anything).Return()
+		metricsMock.On("IncrementPluginHookCounter", pluginID, "OnActivate").Return()
+		metricsMock.On("IncrementPluginHookErrorCounter", pluginID, "OnActivate", plugin.HookErrorTypeReturned).Return()
+
+		_, _, activationErr := env.Activate(pluginID)
+		require.Error(t, activationErr)
+		require.False(t, env.IsActive(pluginID))
+
+		metricsMock.AssertExpectations(t)
+	})
 }
 
 func TestHookReactionHasBeenAdded(t *testing.T) {
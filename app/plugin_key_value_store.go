@@ -70,6 +70,38 @@ func (a *App) SetPluginKeyWithOptions(pluginID string, key string, value []byte,
 	return a.Srv().setPluginKeyWithOptions(pluginID, key, value, options)
 }
 
+// SetPluginKeysWithOptions applies the operations of a plugin atomically: either every
+// operation is applied, or none of them is if the comparison of an atomic operation fails.
+func (a *App) SetPluginKeysWithOptions(pluginID string, operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	if err := model.IsValidPluginKVSetOperations(pluginID, operations); err != nil {
+		mlog.Debug("Failed to set plugin key values", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		return false, err
+	}
+
+	updated, err := a.Srv().Store.Plugin().SetMultiple(pluginID, operations)
+	if err != nil {
+		mlog.Error("Failed to set plugin key values", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return false, appErr
+		default:
+			return false, model.NewAppError("SetPluginKeysWithOptions", "app.plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if updated {
+		// Clean up the previous entries using the hashed keys, if they exist.
+		for _, op := range operations {
+			if err := a.Srv().Store.Plugin().Delete(pluginID, getKeyHash(op.Key)); err != nil {
+				mlog.Warn("Failed to clean up previously hashed plugin key value", mlog.String("plugin_id", pluginID), mlog.String("key", op.Key), mlog.Err(err))
+			}
+		}
+	}
+
+	return updated, nil
+}
+
 func (a *App) CompareAndDeletePluginKey(pluginID string, key string, oldValue []byte) (bool, *model.AppError) {
 	kv := &model.PluginKeyValue{
 		PluginId: pluginID,
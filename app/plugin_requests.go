@@ -144,6 +144,15 @@ func (ch *Channels) servePluginRequest(w http.ResponseWriter, r *http.Request, h
 		session, err := New(ServerConnector(ch)).GetSession(token)
 		defer ch.srv.userService.ReturnSessionToPool(session)
 
+		// Impersonation sessions are read-only, and plugins can't tell them apart.
+		if session != nil && err == nil && session.IsImpersonation() && r.Method != http.MethodGet && r.Method != http.MethodHead {
+			appErr := model.NewAppError("servePluginRequest", "api.context.impersonation_read_only.app_error", nil, "", http.StatusForbidden)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(appErr.StatusCode)
+			w.Write([]byte(appErr.ToJSON()))
+			return
+		}
+
 		csrfCheckPassed := false
 
 		if session != nil && err == nil && cookieAuth && r.Method != "GET" {
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
 )
 
 func TestServePluginPublicRequest(t *testing.T) {
@@ -30,3 +31,46 @@ func TestServePluginPublicRequest(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 }
+
+func TestServePluginRequestImpersonation(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	session := &model.Session{UserId: th.BasicUser.Id}
+	session.AddProp(model.SessionPropType, model.SessionTypeImpersonation)
+	session, appErr := th.App.CreateSession(session)
+	require.Nil(t, appErr)
+
+	serve := func(method string) (*httptest.ResponseRecorder, string, bool) {
+		req, err := http.NewRequest(method, "/plugins/myplugin/action", nil)
+		require.NoError(t, err)
+		req.Header.Set(model.HeaderAuth, model.HeaderBearer+" "+session.Token)
+
+		var userID string
+		called := false
+		rr := httptest.NewRecorder()
+		th.App.ch.servePluginRequest(rr, req, func(_ *plugin.Context, w http.ResponseWriter, r *http.Request) {
+			called = true
+			userID = r.Header.Get("Mattermost-User-Id")
+		})
+		return rr, userID, called
+	}
+
+	t.Run("POST is denied", func(t *testing.T) {
+		rr, _, called := serve(http.MethodPost)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.False(t, called, "the plugin should not have served the request")
+	})
+
+	t.Run("DELETE is denied", func(t *testing.T) {
+		rr, _, called := serve(http.MethodDelete)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.False(t, called, "the plugin should not have served the request")
+	})
+
+	t.Run("GET is served", func(t *testing.T) {
+		_, userID, called := serve(http.MethodGet)
+		assert.True(t, called)
+		assert.Equal(t, th.BasicUser.Id, userID)
+	})
+}
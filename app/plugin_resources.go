@@ -0,0 +1,38 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
+)
+
+// configurePluginResourceLimits passes the configured plugin resource limits to the plugins
+// environment, and monitors them if there are any.
+func (ch *Channels) configurePluginResourceLimits(env *plugin.Environment) {
+	settings := ch.cfgSvc.Config().PluginSettings
+	env.SetResourceLimits(settings.ResourceLimits, *settings.ResourceCgroupParent)
+	env.TogglePluginResourceMonitorJob(len(settings.ResourceLimits) > 0)
+}
+
+// GetPluginResourceUsage returns the resource usage of the server process of a plugin running on
+// this server.
+func (a *App) GetPluginResourceUsage(id string) (*model.PluginResourceUsage, *model.AppError) {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError("GetPluginResourceUsage", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	usage, err := pluginsEnvironment.GetPluginResourceUsage(id)
+	if err != nil {
+		return nil, model.NewAppError("GetPluginResourceUsage", "app.plugin.resource_usage.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if usage == nil {
+		return nil, model.NewAppError("GetPluginResourceUsage", "app.plugin.resource_usage.not_running.app_error", nil, "plugin_id="+id, http.StatusNotFound)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
+)
+
+func (a *App) RegisterPluginScheduledTask(pluginID, cron, callback string) error {
+	if callback == "" || len(callback) > model.PluginScheduledTaskCallbackMaxLength {
+		return errors.New("invalid callback")
+	}
+
+	schedule, err := model.ParseCronSchedule(cron)
+	if err != nil {
+		return errors.Wrap(err, "invalid cron expression")
+	}
+
+	task := &model.PluginScheduledTask{
+		PluginId: pluginID,
+		Callback: callback,
+		Cron:     cron,
+		Schedule: schedule,
+	}
+
+	a.ch.pluginScheduledTasksLock.Lock()
+	defer a.ch.pluginScheduledTasksLock.Unlock()
+
+	for i, t := range a.ch.pluginScheduledTasks {
+		if t.PluginId == pluginID && t.Callback == callback {
+			a.ch.pluginScheduledTasks[i] = task
+			return nil
+		}
+	}
+
+	a.ch.pluginScheduledTasks = append(a.ch.pluginScheduledTasks, task)
+	return nil
+}
+
+func (a *App) UnregisterPluginScheduledTask(pluginID, callback string) {
+	a.ch.pluginScheduledTasksLock.Lock()
+	defer a.ch.pluginScheduledTasksLock.Unlock()
+
+	var remaining []*model.PluginScheduledTask
+	for _, t := range a.ch.pluginScheduledTasks {
+		if t.PluginId != pluginID || t.Callback != callback {
+			remaining = append(remaining, t)
+		}
+	}
+	a.ch.pluginScheduledTasks = remaining
+}
+
+func (ch *Channels) unregisterPluginScheduledTasks(pluginID string) {
+	ch.pluginScheduledTasksLock.Lock()
+	defer ch.pluginScheduledTasksLock.Unlock()
+
+	var remaining []*model.PluginScheduledTask
+	for _, t := range ch.pluginScheduledTasks {
+		if t.PluginId != pluginID {
+			remaining = append(remaining, t)
+		}
+	}
+	ch.pluginScheduledTasks = remaining
+}
+
+// GetPluginScheduledTasks returns the tasks registered by the plugins running on this server.
+func (a *App) GetPluginScheduledTasks() []*model.PluginScheduledTask {
+	a.ch.pluginScheduledTasksLock.RLock()
+	defer a.ch.pluginScheduledTasksLock.RUnlock()
+
+	tasks := make([]*model.PluginScheduledTask, len(a.ch.pluginScheduledTasks))
+	copy(tasks, a.ch.pluginScheduledTasks)
+	return tasks
+}
+
+// RunPluginScheduledTask invokes the OnScheduledTask hook of a plugin. It is called by the job
+// created when the task is due, which can run on a different server than the one that created it.
+func (a *App) RunPluginScheduledTask(pluginID, callback string) *model.AppError {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return model.NewAppError("RunPluginScheduledTask", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(pluginID)
+	if err != nil {
+		return model.NewAppError("RunPluginScheduledTask", "app.plugin.scheduled_task.not_active.app_error", map[string]interface{}{"PluginId": pluginID}, err.Error(), http.StatusNotFound)
+	}
+
+	if err := hooks.OnScheduledTask(&plugin.Context{}, callback); err != nil {
+		return model.NewAppError("RunPluginScheduledTask", "app.plugin.scheduled_task.failed.app_error", map[string]interface{}{"PluginId": pluginID, "Callback": callback}, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
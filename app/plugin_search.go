@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/searchengine"
+)
+
+func (a *App) getPluginIndexEngine(where, index string) (searchengine.PluginIndexEngine, *model.AppError) {
+	if !model.IsValidPluginSearchIndexName(index) {
+		return nil, model.NewAppError(where, "app.plugin_search.invalid_index.app_error", nil, "index="+index, http.StatusBadRequest)
+	}
+
+	engine := a.SearchEngine().GetPluginIndexEngine()
+	if engine == nil {
+		return nil, model.NewAppError(where, "app.plugin_search.no_engine.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return engine, nil
+}
+
+// CreatePluginSearchIndex creates a search index of a plugin if it doesn't exist yet.
+func (a *App) CreatePluginSearchIndex(pluginID, index string) *model.AppError {
+	engine, appErr := a.getPluginIndexEngine("CreatePluginSearchIndex", index)
+	if appErr != nil {
+		return appErr
+	}
+
+	return engine.CreatePluginIndex(pluginID, index)
+}
+
+func (a *App) DeletePluginSearchIndex(pluginID, index string) *model.AppError {
+	engine, appErr := a.getPluginIndexEngine("DeletePluginSearchIndex", index)
+	if appErr != nil {
+		return appErr
+	}
+
+	return engine.DeletePluginIndex(pluginID, index)
+}
+
+// IndexPluginSearchDocument adds a document to a search index of a plugin, replacing the
+// document with the same id.
+func (a *App) IndexPluginSearchDocument(pluginID, index string, doc *model.PluginSearchDocument) *model.AppError {
+	engine, appErr := a.getPluginIndexEngine("IndexPluginSearchDocument", index)
+	if appErr != nil {
+		return appErr
+	}
+
+	if appErr := doc.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	return engine.IndexPluginDocument(pluginID, index, doc)
+}
+
+func (a *App) DeletePluginSearchDocument(pluginID, index, documentID string) *model.AppError {
+	engine, appErr := a.getPluginIndexEngine("DeletePluginSearchDocument", index)
+	if appErr != nil {
+		return appErr
+	}
+
+	return engine.DeletePluginDocument(pluginID, index, documentID)
+}
+
+// SearchPluginSearchIndex queries a search index of a plugin, only matching the documents of the
+// tenants of the params.
+func (a *App) SearchPluginSearchIndex(pluginID, index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError) {
+	engine, appErr := a.getPluginIndexEngine("SearchPluginSearchIndex", index)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := params.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	return engine.SearchPluginIndex(pluginID, index, params)
+}
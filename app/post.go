@@ -28,6 +28,8 @@ const (
 	PendingPostIDsCacheSize = 25000
 	PendingPostIDsCacheTTL  = 30 * time.Second
 	PageDefault             = 0
+
+	advancedSearchMaxRegexLength = 256
 )
 
 var atMentionPattern = regexp.MustCompile(`\B@`)
@@ -58,6 +60,21 @@ func (a *App) CreatePostAsUser(c *request.Context, post *model.Post, currentSess
 		return nil, err
 	}
 
+	if err := a.checkChannelMemberTimeout(channel.Id, post.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkChannelSlowmode(channel, post.UserId); err != nil {
+		return nil, err
+	}
+
+	// Team admins can still post in town square when posting in it is disabled for the team.
+	if channel.Name == model.DefaultChannelName {
+		if err := a.checkTeamFeature(channel.TeamId, model.TeamFeatureTownSquarePosting); err != nil && !a.HasPermissionToTeam(post.UserId, channel.TeamId, model.PermissionManageTeam) {
+			return nil, err
+		}
+	}
+
 	rp, err := a.CreatePost(c, post, channel, true, setOnline)
 	if err != nil {
 		if err.Id == "api.post.create_post.root_id.app_error" ||
@@ -172,6 +189,14 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 
 	post.SanitizeProps()
 
+	// Checked here rather than in CreatePostAsUser so that the posts of plugins, slash commands
+	// and webhooks can't reach a recipient who declined the message request either.
+	if !post.IsSystemMessage() {
+		if err = a.checkDirectMessageRequest(channel, post.UserId); err != nil {
+			return nil, err
+		}
+	}
+
 	var pchan chan store.StoreResult
 	if post.RootId != "" {
 		pchan = make(chan store.StoreResult, 1)
@@ -274,6 +299,29 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 		}
 	}
 
+	if err = a.validatePostPropsAgainstSchemas(post, nil); err != nil {
+		return nil, err
+	}
+
+	flaggedRuleIDs, err := a.checkContentPolicy(c, post, user, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	secretKinds, err := a.scanPostForSecrets(c, post, user, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	// The voice message props are only set by the server.
+	post.DelProp(model.PostPropsVoiceWaveform)
+	post.DelProp(model.PostPropsVoiceDuration)
+	post.DelProp(model.PostPropsVoiceTranscript)
+	voiceFile := a.getVoiceMessageFile(post)
+	if voiceFile != nil {
+		a.addVoiceMessageProps(post, voiceFile)
+	}
+
 	// Pre-fill the CreateAt field for link previews to get the correct timestamp.
 	if post.CreateAt == 0 {
 		post.CreateAt = model.GetMillis()
@@ -323,6 +371,12 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 		})
 	}
 
+	if len(flaggedRuleIDs) > 0 {
+		a.Srv().Go(func() {
+			a.reportContentPolicyFlags(c, rPostCopy, flaggedRuleIDs)
+		})
+	}
+
 	if a.Metrics() != nil {
 		a.Metrics().IncrementPostCreate()
 	}
@@ -337,6 +391,15 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 		}
 	}
 
+	if voiceFile != nil {
+		postID := rpost.Id
+		a.Srv().Go(func() {
+			if appErr := a.transcribeVoiceMessage(postID, voiceFile); appErr != nil {
+				mlog.Warn("Failed to transcribe a voice message", mlog.String("post_id", postID), mlog.Err(appErr))
+			}
+		})
+	}
+
 	// Normally, we would let the API layer call PreparePostForClient, but we do it here since it also needs
 	// to be done when we send the post over the websocket in handlePostEvents
 	rpost = a.PreparePostForClient(rpost, true, false)
@@ -361,6 +424,10 @@ func (a *App) CreatePost(c *request.Context, post *model.Post, channel *model.Ch
 		a.SendEphemeralPost(post.UserId, ephemeralPost)
 	}
 
+	if len(secretKinds) > 0 {
+		a.sendSecretScanningWarning(rpost, user)
+	}
+
 	rpost, err = a.SanitizePostMetadataForUser(rpost, c.Session().UserId)
 	if err != nil {
 		return nil, err
@@ -612,6 +679,19 @@ func (a *App) UpdatePost(c *request.Context, post *model.Post, safeUpdate bool)
 		return nil, model.NewAppError("UpdatePost", "api.post.update_post.can_not_update_post_in_deleted.error", nil, "", http.StatusBadRequest)
 	}
 
+	// Timed out members can't edit what they posted in the channel either.
+	if post.Message != oldPost.Message {
+		if err := a.checkChannelMemberTimeout(channel.Id, oldPost.UserId); err != nil {
+			return nil, err
+		}
+	}
+
+	if !safeUpdate && post.IsPinned && !oldPost.IsPinned {
+		if err := a.checkPinnedPostsLimit(channel.Id); err != nil {
+			return nil, err
+		}
+	}
+
 	newPost := oldPost.Clone()
 
 	if newPost.Message != post.Message {
@@ -652,6 +732,29 @@ func (a *App) UpdatePost(c *request.Context, post *model.Post, safeUpdate bool)
 		}
 	}
 
+	if err = a.validatePostPropsAgainstSchemas(newPost, oldPost); err != nil {
+		return nil, err
+	}
+
+	var flaggedRuleIDs, secretKinds []string
+	var user *model.User
+	if newPost.Message != oldPost.Message {
+		user, err = a.GetUser(newPost.UserId)
+		if err != nil {
+			return nil, err
+		}
+
+		flaggedRuleIDs, err = a.checkContentPolicy(c, newPost, user, channel)
+		if err != nil {
+			return nil, err
+		}
+
+		secretKinds, err = a.scanPostForSecrets(c, newPost, user, channel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	rpost, nErr := a.Srv().Store.Post().Update(newPost, oldPost)
 	if nErr != nil {
 		var appErr *model.AppError
@@ -673,6 +776,17 @@ func (a *App) UpdatePost(c *request.Context, post *model.Post, safeUpdate bool)
 		})
 	}
 
+	if len(flaggedRuleIDs) > 0 {
+		reportedPost := rpost.Clone()
+		a.Srv().Go(func() {
+			a.reportContentPolicyFlags(c, reportedPost, flaggedRuleIDs)
+		})
+	}
+
+	if len(secretKinds) > 0 {
+		a.sendSecretScanningWarning(rpost, user)
+	}
+
 	rpost = a.PreparePostForClientWithEmbedsAndImages(rpost, false, true)
 
 	// Ensure IsFollowing is nil since this updated post will be broadcast to all users
@@ -1305,6 +1419,35 @@ func (a *App) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams)
 	})
 }
 
+// AdvancedSearchPosts searches the posts of teamID, or of every team when teamID is empty,
+// regardless of channel membership. Besides the regular search syntax, terms accept the has:link and
+// has:file operators, and regex, when set, must match the message. Search engines can't evaluate
+// these filters, so the search always runs against the database.
+func (a *App) AdvancedSearchPosts(teamID, terms, regex string, timeZoneOffset int) (*model.PostList, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnablePostSearch {
+		return nil, model.NewAppError("AdvancedSearchPosts", "store.sql_post.search.disabled", nil, fmt.Sprintf("teamId=%v", teamID), http.StatusNotImplemented)
+	}
+
+	if regex != "" {
+		if len(regex) > advancedSearchMaxRegexLength {
+			return nil, model.NewAppError("AdvancedSearchPosts", "app.post.advanced_search.invalid_regex.app_error", nil, "regex is too long", http.StatusBadRequest)
+		}
+		if _, err := regexp.Compile(regex); err != nil {
+			return nil, model.NewAppError("AdvancedSearchPosts", "app.post.advanced_search.invalid_regex.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	paramsList := model.ParseAdvancedSearchParams(strings.TrimSpace(terms), regex, timeZoneOffset)
+	if appErr := model.IsSearchParamsListValid(paramsList); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.searchPostsInTeam(teamID, "", paramsList, func(params *model.SearchParams) {
+		params.SearchWithoutUserId = true
+		params.IncludeDeletedChannels = true
+	})
+}
+
 func (a *App) SearchPostsForUser(c *request.Context, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int, modifier string) (*model.PostSearchResults, *model.AppError) {
 	var postSearchResults *model.PostSearchResults
 	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
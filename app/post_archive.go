@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// SearchArchivedPosts searches the posts moved to the archive by the post archive job in the
+// channels of teamID that userID is a member of. Archived posts aren't indexed, so this is much
+// slower than the regular search and only matches messages containing every term.
+func (a *App) SearchArchivedPosts(teamID, userID, terms string, page, perPage int) (*model.PostList, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnablePostSearch {
+		return nil, model.NewAppError("SearchArchivedPosts", "store.sql_post.search.disabled", nil, "teamId="+teamID, http.StatusNotImplemented)
+	}
+
+	postList := model.NewPostList()
+	if strings.TrimSpace(terms) == "" {
+		return postList, nil
+	}
+
+	channels, appErr := a.GetChannelsForTeamForUser(teamID, userID, &model.ChannelSearchOpts{IncludeDeleted: true})
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return postList, nil
+		}
+		return nil, appErr
+	}
+
+	channelIDs := make([]string, len(channels))
+	for i, channel := range channels {
+		channelIDs[i] = channel.Id
+	}
+
+	posts, err := a.Srv().Store.PostArchive().Search(channelIDs, terms, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("SearchArchivedPosts", "app.post.search.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, post := range posts {
+		postList.AddPost(post)
+		postList.AddOrder(post.Id)
+	}
+
+	return postList, nil
+}
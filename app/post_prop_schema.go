@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// GetPostPropSchemas returns the schemas registered for post props, ordered by namespace.
+func (a *App) GetPostPropSchemas() ([]*model.PostPropSchema, *model.AppError) {
+	schemas, err := a.Srv().Store.PostPropSchema().GetAll()
+	if err != nil {
+		return nil, model.NewAppError("GetPostPropSchemas", "app.post_prop_schema.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return schemas, nil
+}
+
+func (a *App) GetPostPropSchema(namespace string) (*model.PostPropSchema, *model.AppError) {
+	schema, err := a.Srv().Store.PostPropSchema().Get(namespace)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPostPropSchema", "app.post_prop_schema.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetPostPropSchema", "app.post_prop_schema.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return schema, nil
+}
+
+// RegisterPostPropSchema registers the schema of a namespace, replacing the one registered
+// before. Posts already setting the prop are left as they are, but are validated against the new
+// schema when the prop is changed.
+func (a *App) RegisterPostPropSchema(namespace string, schema json.RawMessage, creatorID string) (*model.PostPropSchema, *model.AppError) {
+	existing, appErr := a.GetPostPropSchema(namespace)
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	var saved *model.PostPropSchema
+	var err error
+	if existing == nil {
+		saved, err = a.Srv().Store.PostPropSchema().Save(&model.PostPropSchema{
+			Namespace: namespace,
+			Schema:    schema,
+			CreatorId: creatorID,
+		})
+	} else {
+		existing.Schema = schema
+		existing.CreatorId = creatorID
+		saved, err = a.Srv().Store.PostPropSchema().Update(existing)
+	}
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("RegisterPostPropSchema", "app.post_prop_schema.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) DeletePostPropSchema(namespace string) *model.AppError {
+	if err := a.Srv().Store.PostPropSchema().Delete(namespace); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeletePostPropSchema", "app.post_prop_schema.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeletePostPropSchema", "app.post_prop_schema.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// validatePostPropsAgainstSchemas checks the props of a post that have a registered schema. On
+// update, the props left as they were in the old post aren't checked again, so that a schema
+// registered after a post was created doesn't prevent editing its message.
+func (a *App) validatePostPropsAgainstSchemas(post, oldPost *model.Post) *model.AppError {
+	props := post.GetProps()
+	if len(props) == 0 {
+		return nil
+	}
+
+	schemas, err := a.Srv().Store.PostPropSchema().GetAll()
+	if err != nil {
+		return model.NewAppError("validatePostPropsAgainstSchemas", "app.post_prop_schema.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	for _, schema := range schemas {
+		value, ok := props[schema.Namespace]
+		if !ok {
+			continue
+		}
+
+		if oldPost != nil && reflect.DeepEqual(value, oldPost.GetProp(schema.Namespace)) {
+			continue
+		}
+
+		compiled, compileErr := schema.Compile()
+		if compileErr != nil {
+			// Schemas are compiled before being saved, so this is only reached if one was
+			// edited in the database.
+			mlog.Warn("Skipping an invalid post prop schema", mlog.String("namespace", schema.Namespace), mlog.Err(compileErr))
+			continue
+		}
+
+		if validationErr := compiled.Validate(value); validationErr != nil {
+			return model.NewAppError("validatePostPropsAgainstSchemas", "app.post_prop_schema.invalid_prop.app_error", map[string]interface{}{"Namespace": schema.Namespace, "Error": validationErr.Error()}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
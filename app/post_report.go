@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// ReportPost reports a post to the moderators. A user can only report a post once, and cannot
+// report their own posts.
+func (a *App) ReportPost(c *request.Context, post *model.Post, reporterID, reason, comment string) (*model.PostReport, *model.AppError) {
+	if post.UserId == reporterID {
+		return nil, model.NewAppError("ReportPost", "app.post_report.own_post.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	report := &model.PostReport{
+		PostId:     post.Id,
+		ChannelId:  post.ChannelId,
+		PostUserId: post.UserId,
+		ReporterId: reporterID,
+		Reason:     reason,
+		Comment:    comment,
+	}
+
+	report, err := a.Srv().Store.PostReport().Save(report)
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("ReportPost", "app.post_report.already_reported.app_error", nil, cErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("ReportPost", "app.post_report.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if a.Metrics() != nil {
+		a.Metrics().IncrementPostReportCounter(report.Reason)
+	}
+
+	return report, nil
+}
+
+func (a *App) GetPostReport(reportID string) (*model.PostReport, *model.AppError) {
+	report, err := a.Srv().Store.PostReport().Get(reportID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPostReport", "app.post_report.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetPostReport", "app.post_report.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return report, nil
+}
+
+// GetPostReports returns the moderation queue, oldest reports first.
+func (a *App) GetPostReports(opts model.PostReportGetOptions) ([]*model.PostReport, *model.AppError) {
+	if opts.Status != "" && !model.IsValidPostReportStatus(opts.Status) {
+		return nil, model.NewAppError("GetPostReports", "app.post_report.invalid_status.app_error", nil, "status="+opts.Status, http.StatusBadRequest)
+	}
+
+	if opts.PerPage <= 0 {
+		opts.PerPage = model.PostReportPerPageDefault
+	} else if opts.PerPage > model.PostReportPerPageMax {
+		opts.PerPage = model.PostReportPerPageMax
+	}
+
+	reports, err := a.Srv().Store.PostReport().GetReports(opts)
+	if err != nil {
+		return nil, model.NewAppError("GetPostReports", "app.post_report.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return reports, nil
+}
+
+// ActOnPostReport applies the action of a moderator to a reported post, and closes every open
+// report of the post.
+func (a *App) ActOnPostReport(c *request.Context, report *model.PostReport, action *model.PostReportAction, moderatorID string) (*model.PostReport, *model.AppError) {
+	if appErr := action.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if report.Status != model.PostReportStatusOpen {
+		return nil, model.NewAppError("ActOnPostReport", "app.post_report.not_open.app_error", nil, "status="+report.Status, http.StatusBadRequest)
+	}
+
+	status := model.PostReportStatusResolved
+	switch action.Action {
+	case model.PostReportActionDismiss:
+		status = model.PostReportStatusDismissed
+	case model.PostReportActionDeletePost:
+		// The post may have been deleted since it was reported.
+		if _, appErr := a.GetSinglePost(report.PostId); appErr == nil {
+			if _, appErr := a.DeletePost(report.PostId, moderatorID); appErr != nil {
+				return nil, appErr
+			}
+		} else if appErr.StatusCode != http.StatusNotFound {
+			return nil, appErr
+		}
+	case model.PostReportActionTimeoutAuthor:
+		if _, appErr := a.TimeoutChannelMember(c, report.ChannelId, report.PostUserId, moderatorID, action.TimeoutMinutes); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if _, err := a.Srv().Store.PostReport().ResolveForPost(report.PostId, status, action.Action, moderatorID, model.GetMillis()); err != nil {
+		return nil, model.NewAppError("ActOnPostReport", "app.post_report.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if a.Metrics() != nil {
+		a.Metrics().IncrementPostReportActionCounter(action.Action)
+	}
+
+	return a.GetPostReport(report.Id)
+}
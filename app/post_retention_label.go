@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// SetPostRetentionLabel applies one of the configured retention labels to a post, replacing the
+// label it had. The post is kept until the retention period of the label has passed since its
+// creation, regardless of the retention policies of its channel and team.
+func (a *App) SetPostRetentionLabel(post *model.Post, labelName, creatorID string) (*model.PostRetentionLabel, *model.AppError) {
+	var retentionLabel *model.RetentionLabel
+	for _, label := range a.Config().DataRetentionSettings.RetentionLabels {
+		if label.Name == labelName {
+			retentionLabel = label
+			break
+		}
+	}
+	if retentionLabel == nil {
+		return nil, model.NewAppError("SetPostRetentionLabel", "app.post_retention_label.unknown_label.app_error", map[string]interface{}{"Label": labelName}, "", http.StatusBadRequest)
+	}
+
+	label, err := a.Srv().Store.PostRetentionLabel().Save(&model.PostRetentionLabel{
+		PostId:    post.Id,
+		Label:     retentionLabel.Name,
+		ExpireAt:  post.CreateAt + retentionLabel.RetentionHours*60*60*1000,
+		CreatorId: creatorID,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SetPostRetentionLabel", "app.post_retention_label.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return label, nil
+}
+
+func (a *App) GetPostRetentionLabel(postID string) (*model.PostRetentionLabel, *model.AppError) {
+	label, err := a.Srv().Store.PostRetentionLabel().Get(postID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPostRetentionLabel", "app.post_retention_label.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetPostRetentionLabel", "app.post_retention_label.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return label, nil
+}
+
+// RemovePostRetentionLabel removes the retention label of a post, which falls back under the
+// retention policies of its channel and team.
+func (a *App) RemovePostRetentionLabel(postID string) *model.AppError {
+	if err := a.Srv().Store.PostRetentionLabel().Delete(postID); err != nil {
+		return model.NewAppError("RemovePostRetentionLabel", "app.post_retention_label.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
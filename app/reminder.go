@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const dueRemindersBatchSize = 100
+
+// CreateReminder schedules a reminder, which must be due in the future.
+func (a *App) CreateReminder(reminder *model.Reminder) (*model.Reminder, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableReminders {
+		return nil, model.NewAppError("CreateReminder", "app.reminder.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if reminder.RemindAt <= model.GetMillis() {
+		return nil, model.NewAppError("CreateReminder", "app.reminder.remind_at_past.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	reminder.Id = ""
+	reminder.CreateAt = 0
+	saved, err := a.Srv().Store.Reminder().Save(reminder)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateReminder", "app.reminder.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetReminder(reminderID string) (*model.Reminder, *model.AppError) {
+	reminder, err := a.Srv().Store.Reminder().Get(reminderID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetReminder", "app.reminder.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetReminder", "app.reminder.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return reminder, nil
+}
+
+// GetRemindersForUser returns the reminders of a user, the first due first.
+func (a *App) GetRemindersForUser(userID string) ([]*model.Reminder, *model.AppError) {
+	reminders, err := a.Srv().Store.Reminder().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetRemindersForUser", "app.reminder.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return reminders, nil
+}
+
+// PatchReminder changes the message, time or recurrence of a reminder. A new time must be in the
+// future.
+func (a *App) PatchReminder(reminder *model.Reminder, patch *model.ReminderPatch) (*model.Reminder, *model.AppError) {
+	if patch.RemindAt != nil && *patch.RemindAt != reminder.RemindAt && *patch.RemindAt <= model.GetMillis() {
+		return nil, model.NewAppError("PatchReminder", "app.reminder.remind_at_past.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	reminder.Patch(patch)
+
+	updated, err := a.Srv().Store.Reminder().Update(reminder)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("PatchReminder", "app.reminder.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updated, nil
+}
+
+func (a *App) DeleteReminder(reminderID string) *model.AppError {
+	if err := a.Srv().Store.Reminder().Delete(reminderID); err != nil {
+		return model.NewAppError("DeleteReminder", "app.reminder.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// SendDueReminders sends the users the reminders due by now as direct messages from the system
+// bot, returning how many were sent. Recurring reminders are rescheduled, the others deleted.
+// A reminder that can't be sent isn't retried.
+func (a *App) SendDueReminders(now int64) (int, *model.AppError) {
+	bot, appErr := a.GetSystemBot()
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	c := request.EmptyContext()
+	sent := 0
+	for {
+		reminders, err := a.Srv().Store.Reminder().GetDue(now, dueRemindersBatchSize)
+		if err != nil {
+			return sent, model.NewAppError("SendDueReminders", "app.reminder.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, reminder := range reminders {
+			if ok, appErr := a.sendReminder(c, bot, reminder); appErr != nil {
+				mlog.Warn("Failed to send a reminder", mlog.String("reminder_id", reminder.Id), mlog.String("user_id", reminder.UserId), mlog.Err(appErr))
+			} else if ok {
+				sent++
+			}
+
+			if next := reminder.NextRemindAt(now); next != 0 {
+				reminder.RemindAt = next
+				if _, err := a.Srv().Store.Reminder().Update(reminder); err != nil {
+					return sent, model.NewAppError("SendDueReminders", "app.reminder.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+				}
+			} else if err := a.Srv().Store.Reminder().Delete(reminder.Id); err != nil {
+				return sent, model.NewAppError("SendDueReminders", "app.reminder.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+		}
+
+		if len(reminders) < dueRemindersBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// sendReminder sends a reminder to its user, returning whether it was sent. Reminders of
+// deactivated users are dropped.
+func (a *App) sendReminder(c *request.Context, bot *model.Bot, reminder *model.Reminder) (bool, *model.AppError) {
+	user, appErr := a.GetUser(reminder.UserId)
+	if appErr != nil {
+		return false, appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return false, nil
+	}
+
+	channel, appErr := a.GetOrCreateDirectChannel(c, user.Id, bot.UserId)
+	if appErr != nil {
+		return false, appErr
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	message := T("app.reminder.direct_message", map[string]interface{}{"Message": reminder.Message})
+	if reminder.PostId != "" {
+		message = T("app.reminder.direct_message_post", map[string]interface{}{
+			"Message": reminder.Message,
+			"Link":    fmt.Sprintf("%s/_redirect/pl/%s", a.GetSiteURL(), reminder.PostId),
+		})
+	}
+
+	post := &model.Post{
+		UserId:    bot.UserId,
+		ChannelId: channel.Id,
+		Message:   message,
+	}
+	if _, appErr := a.CreatePost(c, post, channel, false, true); appErr != nil {
+		return false, appErr
+	}
+
+	return true, nil
+}
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"crypto/tls"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -85,3 +86,31 @@ func (a *App) GetRemoteClusterService() (remotecluster.RemoteClusterServiceIFace
 	}
 	return service, nil
 }
+
+// UpdateRemoteClusterPinnedCerts replaces the certificates pinned for a remote cluster. Adding the
+// new fingerprint before removing the old one allows certificates to be rotated without downtime.
+func (a *App) UpdateRemoteClusterPinnedCerts(remoteClusterId string, fingerprints []string) (*model.RemoteCluster, *model.AppError) {
+	rc, appErr := a.GetRemoteCluster(remoteClusterId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rc.SetPinnedCerts(fingerprints)
+	return a.UpdateRemoteCluster(rc)
+}
+
+// VerifyRemoteClusterCertificate checks the certificate presented by a remote cluster over mutual TLS
+// against the certificates pinned for it. With mutual TLS enabled, remotes without pinned certificates
+// or without a client certificate are rejected.
+func (a *App) VerifyRemoteClusterCertificate(remoteClusterId string, state *tls.ConnectionState) *model.AppError {
+	rc, appErr := a.GetRemoteCluster(remoteClusterId)
+	if appErr != nil {
+		return appErr
+	}
+
+	mutualTLS := *a.Config().ExperimentalSettings.RemoteClusterEnableMutualTLS
+	if err := remotecluster.VerifyClientCert(rc, state, mutualTLS); err != nil {
+		return model.NewAppError("VerifyRemoteClusterCertificate", "api.remote_cluster.cert_not_pinned.app_error", nil, err.Error(), http.StatusUnauthorized)
+	}
+	return nil
+}
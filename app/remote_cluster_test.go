@@ -4,6 +4,9 @@
 package app
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -145,3 +148,59 @@ func TestUpdateRemoteCluster(t *testing.T) {
 		assert.Nil(t, err, "Updating remote cluster should not error")
 	})
 }
+
+func TestVerifyRemoteClusterCertificate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	cert := &x509.Certificate{Raw: []byte("remote certificate")}
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	unpinned, appErr := th.App.AddRemoteCluster(&model.RemoteCluster{
+		RemoteTeamId: model.NewId(),
+		Name:         "unpinned",
+		SiteURL:      "http://unpinned.example.com:8065",
+		Token:        model.NewId(),
+		CreatorId:    th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	pinned := &model.RemoteCluster{
+		RemoteTeamId: model.NewId(),
+		Name:         "pinned",
+		SiteURL:      "http://pinned.example.com:8065",
+		Token:        model.NewId(),
+		CreatorId:    th.BasicUser.Id,
+	}
+	pinned.SetPinnedCerts([]string{model.CertFingerprint(cert)})
+	pinned, appErr = th.App.AddRemoteCluster(pinned)
+	require.Nil(t, appErr)
+
+	t.Run("mutual TLS disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RemoteClusterEnableMutualTLS = false })
+
+		assert.Nil(t, th.App.VerifyRemoteClusterCertificate(unpinned.RemoteId, nil))
+		assert.Nil(t, th.App.VerifyRemoteClusterCertificate(pinned.RemoteId, state))
+	})
+
+	t.Run("mutual TLS enabled rejects unpinned remote", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RemoteClusterEnableMutualTLS = true })
+
+		appErr := th.App.VerifyRemoteClusterCertificate(unpinned.RemoteId, state)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+
+		appErr = th.App.VerifyRemoteClusterCertificate(unpinned.RemoteId, nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+	})
+
+	t.Run("mutual TLS enabled requires a client certificate", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RemoteClusterEnableMutualTLS = true })
+
+		assert.Nil(t, th.App.VerifyRemoteClusterCertificate(pinned.RemoteId, state))
+		appErr := th.App.VerifyRemoteClusterCertificate(pinned.RemoteId, &tls.ConnectionState{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/einterfaces"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	resourceGuardPollInterval = 5 * time.Second
+
+	memoryTotalMetric    = "/memory/classes/total:bytes"
+	memoryReleasedMetric = "/memory/classes/heap/released:bytes"
+	heapAllocsMetric     = "/gc/heap/allocs:bytes"
+)
+
+// ResourceGuard protects the server from running out of memory. It periodically reads the memory
+// used by the process and, once it nears ServiceSettings.ResourceGuardMemoryLimitMB, sheds the
+// requests made to the handlers listed in ServiceSettings.ResourceGuardShedHandlers. It also
+// samples the allocations and goroutines of requests so that expensive handlers can be found.
+type ResourceGuard struct {
+	memoryBytes uint64 // protected via atomic for fast ShouldShed calls
+	requests    uint64 // protected via atomic, used for sampling
+	shedding    int32  // protected via atomic, only used to log transitions
+
+	configFn func() *model.Config
+	metrics  einterfaces.MetricsInterface
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewResourceGuard creates a new ResourceGuard. Start must be called for memory usage to be
+// tracked.
+func NewResourceGuard(configFn func() *model.Config, metricsInterface einterfaces.MetricsInterface) *ResourceGuard {
+	return &ResourceGuard{
+		configFn: configFn,
+		metrics:  metricsInterface,
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Start begins polling the memory used by the process.
+func (g *ResourceGuard) Start() {
+	go func() {
+		defer close(g.stopped)
+
+		ticker := time.NewTicker(resourceGuardPollInterval)
+		defer ticker.Stop()
+
+		for {
+			g.poll()
+
+			select {
+			case <-ticker.C:
+			case <-g.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling the memory used by the process.
+func (g *ResourceGuard) Stop() {
+	if g == nil {
+		return
+	}
+
+	g.stopOnce.Do(func() {
+		close(g.stop)
+		<-g.stopped
+	})
+}
+
+func (g *ResourceGuard) poll() {
+	if !*g.configFn().ServiceSettings.EnableResourceGuard {
+		atomic.StoreUint64(&g.memoryBytes, 0)
+		return
+	}
+
+	samples := []metrics.Sample{{Name: memoryTotalMetric}, {Name: memoryReleasedMetric}}
+	metrics.Read(samples)
+
+	var memoryBytes uint64
+	if samples[0].Value.Kind() == metrics.KindUint64 && samples[1].Value.Kind() == metrics.KindUint64 {
+		memoryBytes = samples[0].Value.Uint64() - samples[1].Value.Uint64()
+	}
+	atomic.StoreUint64(&g.memoryBytes, memoryBytes)
+
+	if g.metrics != nil {
+		g.metrics.SetResourceGuardMemoryBytes(float64(memoryBytes))
+	}
+
+	shedding := g.isUnderMemoryPressure()
+	wasShedding := atomic.SwapInt32(&g.shedding, boolToInt32(shedding)) != 0
+	if shedding && !wasShedding {
+		mlog.Warn("Memory usage is nearing its limit, shedding requests to heavy endpoints", mlog.Uint64("memory_bytes", memoryBytes), mlog.Int("limit_mb", *g.configFn().ServiceSettings.ResourceGuardMemoryLimitMB))
+	} else if !shedding && wasShedding {
+		mlog.Info("Memory usage is back under its limit, no longer shedding requests", mlog.Uint64("memory_bytes", memoryBytes))
+	}
+}
+
+func (g *ResourceGuard) isUnderMemoryPressure() bool {
+	settings := g.configFn().ServiceSettings
+	if !*settings.EnableResourceGuard {
+		return false
+	}
+
+	threshold := uint64(*settings.ResourceGuardMemoryLimitMB) * 1024 * 1024 * uint64(*settings.ResourceGuardShedThresholdPercent) / 100
+	return atomic.LoadUint64(&g.memoryBytes) >= threshold
+}
+
+// ShouldShed returns true if requests to handlerName must be rejected because the process is
+// nearing its memory limit.
+func (g *ResourceGuard) ShouldShed(handlerName string) bool {
+	if g == nil || !g.isUnderMemoryPressure() {
+		return false
+	}
+
+	for _, name := range strings.Split(*g.configFn().ServiceSettings.ResourceGuardShedHandlers, ",") {
+		if strings.TrimSpace(name) == handlerName {
+			if g.metrics != nil {
+				g.metrics.IncrementAPIEndpointShedCounter(handlerName)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// SampleRequest starts measuring one in every ServiceSettings.ResourceGuardSampleRate requests.
+// The returned function must be called once the request is handled to record the bytes allocated
+// and the goroutines started meanwhile. Both are measured for the whole process, so they include
+// the work of concurrent requests and are only meaningful when aggregated.
+func (g *ResourceGuard) SampleRequest(handlerName string) func() {
+	if g == nil || g.metrics == nil || !*g.configFn().ServiceSettings.EnableResourceGuard {
+		return func() {}
+	}
+
+	if atomic.AddUint64(&g.requests, 1)%uint64(*g.configFn().ServiceSettings.ResourceGuardSampleRate) != 0 {
+		return func() {}
+	}
+
+	allocsBefore := readHeapAllocs()
+	goroutinesBefore := runtime.NumGoroutine()
+
+	return func() {
+		if allocs := readHeapAllocs(); allocs > allocsBefore {
+			g.metrics.ObserveAPIEndpointAllocatedBytes(handlerName, float64(allocs-allocsBefore))
+		}
+		goroutines := runtime.NumGoroutine() - goroutinesBefore
+		if goroutines < 0 {
+			goroutines = 0
+		}
+		g.metrics.ObserveAPIEndpointGoroutines(handlerName, float64(goroutines))
+	}
+}
+
+func readHeapAllocs() uint64 {
+	samples := []metrics.Sample{{Name: heapAllocsMetric}}
+	metrics.Read(samples)
+	if samples[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return samples[0].Value.Uint64()
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
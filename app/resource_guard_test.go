@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/einterfaces/mocks"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func newTestResourceGuard(metrics *mocks.MetricsInterface) (*ResourceGuard, *model.Config) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.ServiceSettings.EnableResourceGuard = true
+	*cfg.ServiceSettings.ResourceGuardMemoryLimitMB = 100
+	*cfg.ServiceSettings.ResourceGuardShedThresholdPercent = 50
+	*cfg.ServiceSettings.ResourceGuardSampleRate = 2
+	*cfg.ServiceSettings.ResourceGuardShedHandlers = "downloadExport, downloadJob"
+
+	return NewResourceGuard(func() *model.Config { return cfg }, metrics), cfg
+}
+
+func TestResourceGuardShouldShed(t *testing.T) {
+	metrics := &mocks.MetricsInterface{}
+	metrics.On("IncrementAPIEndpointShedCounter", "downloadJob").Once()
+	guard, cfg := newTestResourceGuard(metrics)
+
+	atomic.StoreUint64(&guard.memoryBytes, 49*1024*1024)
+	require.False(t, guard.ShouldShed("downloadJob"), "memory is under the threshold")
+
+	atomic.StoreUint64(&guard.memoryBytes, 50*1024*1024)
+	require.True(t, guard.ShouldShed("downloadJob"))
+	require.False(t, guard.ShouldShed("getPost"), "only the configured handlers are shed")
+
+	*cfg.ServiceSettings.EnableResourceGuard = false
+	require.False(t, guard.ShouldShed("downloadJob"), "nothing is shed when the guard is disabled")
+
+	var nilGuard *ResourceGuard
+	require.False(t, nilGuard.ShouldShed("downloadJob"))
+
+	metrics.AssertExpectations(t)
+}
+
+func TestResourceGuardSampleRequest(t *testing.T) {
+	metrics := &mocks.MetricsInterface{}
+	metrics.On("ObserveAPIEndpointAllocatedBytes", "getPost", mock.AnythingOfType("float64")).Maybe()
+	metrics.On("ObserveAPIEndpointGoroutines", "getPost", mock.AnythingOfType("float64")).Once()
+	guard, _ := newTestResourceGuard(metrics)
+
+	// One in two requests is sampled.
+	guard.SampleRequest("getPost")()
+	guard.SampleRequest("getPost")()
+
+	metrics.AssertExpectations(t)
+}
+
+func TestResourceGuardPoll(t *testing.T) {
+	metrics := &mocks.MetricsInterface{}
+	metrics.On("SetResourceGuardMemoryBytes", mock.AnythingOfType("float64")).Once()
+	guard, cfg := newTestResourceGuard(metrics)
+	*cfg.ServiceSettings.ResourceGuardMemoryLimitMB = 1024 * 1024
+
+	guard.poll()
+	require.NotZero(t, atomic.LoadUint64(&guard.memoryBytes))
+
+	*cfg.ServiceSettings.EnableResourceGuard = false
+	guard.poll()
+	require.Zero(t, atomic.LoadUint64(&guard.memoryBytes))
+
+	metrics.AssertExpectations(t)
+}
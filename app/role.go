@@ -254,6 +254,22 @@ func (a *App) CheckRolesExist(roleNames []string) *model.AppError {
 	return nil
 }
 
+// checkRolesAssignableAt returns an error if one of the roles is a custom role of another scope.
+func (a *App) checkRolesAssignableAt(roleNames []string, scope model.RoleScope) *model.AppError {
+	roles, err := a.GetRolesByNames(roleNames)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		if !role.IsAssignableAt(scope) {
+			return model.NewAppError("checkRolesAssignableAt", "app.role.check_roles_assignable.invalid_scope.app_error", map[string]interface{}{"Scope": role.Scope}, "role="+role.Name, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) sendUpdatedRoleEvent(role *model.Role) {
 	message := model.NewWebSocketEvent(model.WebsocketEventRoleUpdated, "", "", "", nil)
 	roleJSON, jsonErr := json.Marshal(role)
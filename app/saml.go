@@ -67,7 +67,9 @@ func (a *App) AddSamlPublicCertificate(fileData *multipart.FileHeader) *model.Ap
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -84,7 +86,9 @@ func (a *App) AddSamlPrivateCertificate(fileData *multipart.FileHeader) *model.A
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -101,7 +105,9 @@ func (a *App) AddSamlIdpCertificate(fileData *multipart.FileHeader) *model.AppEr
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -127,7 +133,9 @@ func (a *App) RemoveSamlPublicCertificate() *model.AppError {
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -145,7 +153,9 @@ func (a *App) RemoveSamlPrivateCertificate() *model.AppError {
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -163,7 +173,9 @@ func (a *App) RemoveSamlIdpCertificate() *model.AppError {
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -278,7 +290,9 @@ func (a *App) SetSamlIdpCertificateFromMetadata(data []byte) *model.AppError {
 		return err
 	}
 
-	a.UpdateConfig(func(dest *model.Config) { *dest = *cfg })
+	if _, _, err := a.SaveConfig(cfg, false); err != nil {
+		return err
+	}
 
 	return nil
 }
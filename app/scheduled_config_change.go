@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/config"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const dueScheduledConfigChangesBatchSize = 100
+
+// ScheduleConfigChange schedules the partial configuration of the change to be merged onto the
+// configuration at ApplyAt, which must be in the future. The differences it makes to the current
+// configuration are saved along with the change, which must make at least one.
+func (a *App) ScheduleConfigChange(change *model.ScheduledConfigChange) (*model.ScheduledConfigChange, *model.AppError) {
+	if change.ApplyAt <= model.GetMillis() {
+		return nil, model.NewAppError("ScheduleConfigChange", "app.scheduled_config_change.apply_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if change.Config == nil {
+		return nil, model.NewAppError("ScheduleConfigChange", "model.scheduled_config_change.is_valid.config.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	oldCfg := a.Config()
+	newCfg, appErr := mergeScheduledConfigChange(oldCfg, change)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	diffs, err := config.Diff(oldCfg, newCfg)
+	if err != nil {
+		return nil, model.NewAppError("ScheduleConfigChange", "api.config.patch_config.diff.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(diffs) == 0 {
+		return nil, model.NewAppError("ScheduleConfigChange", "app.scheduled_config_change.no_changes.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	diffsJSON, err := json.Marshal(diffs.Sanitize())
+	if err != nil {
+		return nil, model.NewAppError("ScheduleConfigChange", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	change.Id = ""
+	change.Status = ""
+	change.Error = ""
+	change.CreateAt = 0
+	change.Diffs = diffsJSON
+
+	saved, err := a.Srv().Store.ScheduledConfigChange().Save(change)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("ScheduleConfigChange", "app.scheduled_config_change.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetScheduledConfigChange(changeID string) (*model.ScheduledConfigChange, *model.AppError) {
+	change, err := a.Srv().Store.ScheduledConfigChange().Get(changeID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetScheduledConfigChange", "app.scheduled_config_change.get.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetScheduledConfigChange", "app.scheduled_config_change.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return change, nil
+}
+
+// GetScheduledConfigChanges returns a page of the scheduled changes, the last to apply first. The
+// changes that were applied, failed or were canceled are only returned if includeFinished is true.
+func (a *App) GetScheduledConfigChanges(includeFinished bool, page, perPage int) ([]*model.ScheduledConfigChange, *model.AppError) {
+	changes, err := a.Srv().Store.ScheduledConfigChange().GetAll(includeFinished, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetScheduledConfigChanges", "app.scheduled_config_change.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return changes, nil
+}
+
+// CancelScheduledConfigChange cancels a change that is still pending.
+func (a *App) CancelScheduledConfigChange(changeID string) (*model.ScheduledConfigChange, *model.AppError) {
+	if _, appErr := a.GetScheduledConfigChange(changeID); appErr != nil {
+		return nil, appErr
+	}
+
+	canceled, err := a.Srv().Store.ScheduledConfigChange().UpdateStatus(changeID, model.ScheduledConfigChangeStatusPending, model.ScheduledConfigChangeStatusCanceled, "")
+	if err != nil {
+		return nil, model.NewAppError("CancelScheduledConfigChange", "app.scheduled_config_change.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if !canceled {
+		return nil, model.NewAppError("CancelScheduledConfigChange", "app.scheduled_config_change.not_pending.app_error", nil, "id="+changeID, http.StatusBadRequest)
+	}
+
+	return a.GetScheduledConfigChange(changeID)
+}
+
+// ApplyDueScheduledConfigChanges applies the changes due by now, the first due first, returning
+// how many were applied. A change that can't be applied, e.g. because the configuration changed
+// since it was scheduled and merging it would make it invalid, is marked as failed.
+func (a *App) ApplyDueScheduledConfigChanges(now int64) (int, *model.AppError) {
+	applied := 0
+	for {
+		changes, err := a.Srv().Store.ScheduledConfigChange().GetDue(now, dueScheduledConfigChangesBatchSize)
+		if err != nil {
+			return applied, model.NewAppError("ApplyDueScheduledConfigChanges", "app.scheduled_config_change.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, change := range changes {
+			// The change is marked as applied first, so that it can no longer be canceled.
+			claimed, err := a.Srv().Store.ScheduledConfigChange().UpdateStatus(change.Id, model.ScheduledConfigChangeStatusPending, model.ScheduledConfigChangeStatusApplied, "")
+			if err != nil {
+				return applied, model.NewAppError("ApplyDueScheduledConfigChanges", "app.scheduled_config_change.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+			if !claimed {
+				continue
+			}
+
+			if appErr := a.applyScheduledConfigChange(change); appErr != nil {
+				mlog.Warn("Failed to apply a scheduled config change", mlog.String("scheduled_config_change_id", change.Id), mlog.Err(appErr))
+				if _, err := a.Srv().Store.ScheduledConfigChange().UpdateStatus(change.Id, model.ScheduledConfigChangeStatusApplied, model.ScheduledConfigChangeStatusFailed, appErr.Error()); err != nil {
+					return applied, model.NewAppError("ApplyDueScheduledConfigChanges", "app.scheduled_config_change.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+				}
+				continue
+			}
+
+			mlog.Info("Applied a scheduled config change", mlog.String("scheduled_config_change_id", change.Id), mlog.String("creator_id", change.CreatorId))
+			applied++
+		}
+
+		if len(changes) < dueScheduledConfigChangesBatchSize {
+			return applied, nil
+		}
+	}
+}
+
+func (a *App) applyScheduledConfigChange(change *model.ScheduledConfigChange) *model.AppError {
+	newCfg, appErr := mergeScheduledConfigChange(a.Config(), change)
+	if appErr != nil {
+		return appErr
+	}
+
+	// The paths changed by the change may have been locked since it was scheduled, in which case
+	// saving it fails.
+	_, _, appErr = a.SaveConfig(newCfg, true)
+	return appErr
+}
+
+// mergeScheduledConfigChange returns the configuration with the partial configuration of the
+// change merged onto it, if the result is valid.
+func mergeScheduledConfigChange(cfg *model.Config, change *model.ScheduledConfigChange) (*model.Config, *model.AppError) {
+	newCfg, err := config.Merge(cfg, change.Config, nil)
+	if err != nil {
+		return nil, model.NewAppError("mergeScheduledConfigChange", "app.scheduled_config_change.merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if appErr := newCfg.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	return newCfg, nil
+}
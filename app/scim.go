@@ -0,0 +1,394 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func (a *App) scimSiteURL() string {
+	return strings.TrimRight(a.GetSiteURL(), "/")
+}
+
+func scimPage(startIndex, count int) (int, int) {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = 0
+	} else if count > model.ScimMaxCount {
+		count = model.ScimMaxCount
+	}
+	return startIndex, count
+}
+
+// getScimUser returns a user which can be provisioned, that is which isn't a bot.
+func (a *App) getScimUser(userID string) (*model.User, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.IsBot {
+		return nil, model.NewAppError("getScimUser", MissingAccountError, nil, "user_id="+userID, http.StatusNotFound)
+	}
+	return user, nil
+}
+
+// ScimGetUser returns the SCIM representation of a user.
+func (a *App) ScimGetUser(userID string) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getScimUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return model.NewScimUser(user, a.scimSiteURL()), nil
+}
+
+// ScimGetUsers returns a page of the users, starting at startIndex which is 1-based, matching a
+// filter on their userName or email address.
+func (a *App) ScimGetUsers(filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError) {
+	scimFilter, appErr := model.ParseScimFilter(filter)
+	if appErr != nil {
+		return nil, appErr
+	}
+	startIndex, count = scimPage(startIndex, count)
+	siteURL := a.scimSiteURL()
+
+	if scimFilter != nil {
+		var matching []*model.User
+		if user := a.findScimUser(scimFilter); user != nil {
+			matching = append(matching, user)
+		}
+
+		resources := []interface{}{}
+		for i := startIndex - 1; i < len(matching) && len(resources) < count; i++ {
+			resources = append(resources, model.NewScimUser(matching[i], siteURL))
+		}
+		return model.NewScimListResponse(resources, len(matching), startIndex), nil
+	}
+
+	total, err := a.Srv().Store.User().Count(model.UserCountOptions{IncludeDeleted: true})
+	if err != nil {
+		return nil, model.NewAppError("ScimGetUsers", "app.user.get_total_users_count.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	resources := []interface{}{}
+	if count > 0 {
+		users, err := a.Srv().Store.User().GetNonBotUsers(startIndex-1, count)
+		if err != nil {
+			return nil, model.NewAppError("ScimGetUsers", "app.user.get_profiles.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, user := range users {
+			resources = append(resources, model.NewScimUser(user, siteURL))
+		}
+	}
+
+	return model.NewScimListResponse(resources, int(total), startIndex), nil
+}
+
+// findScimUser returns the user matching a filter, or nil if there is none. A userName is
+// looked up as an email address or as the username it was cleaned into.
+func (a *App) findScimUser(filter *model.ScimFilter) *model.User {
+	var user *model.User
+	switch {
+	case strings.EqualFold(filter.Attribute, "userName"):
+		if strings.Contains(filter.Value, "@") {
+			user, _ = a.GetUserByEmail(filter.Value)
+		}
+		if user == nil {
+			user, _ = a.GetUserByUsername((&model.ScimUser{UserName: filter.Value}).Username())
+		}
+		if user != nil && !strings.EqualFold(model.NewScimUser(user, "").UserName, filter.Value) {
+			user = nil
+		}
+	case strings.EqualFold(filter.Attribute, "emails"), strings.EqualFold(filter.Attribute, "emails.value"):
+		user, _ = a.GetUserByEmail(filter.Value)
+	}
+
+	if user == nil || user.IsBot {
+		return nil
+	}
+	return user
+}
+
+// ScimCreateUser provisions a user, whose email address is verified. Users log in with
+// ScimSettings.AuthService, or have to reset their random password.
+func (a *App) ScimCreateUser(c *request.Context, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	if appErr := scimUser.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if a.findScimUser(&model.ScimFilter{Attribute: "userName", Value: scimUser.UserName}) != nil {
+		return nil, model.NewAppError("ScimCreateUser", "app.scim.user_exists.app_error", nil, "user_name="+scimUser.UserName, http.StatusConflict)
+	}
+	if existing, _ := a.GetUserByEmail(scimUser.PrimaryEmail()); existing != nil {
+		return nil, model.NewAppError("ScimCreateUser", "app.scim.user_exists.app_error", nil, "email="+scimUser.PrimaryEmail(), http.StatusConflict)
+	}
+
+	user := &model.User{EmailVerified: true}
+	scimUser.ApplyTo(user)
+	if authService := *a.Config().ScimSettings.AuthService; authService != "" {
+		user.AuthService = authService
+		user.AuthData = model.NewString(scimUser.UserName)
+	} else {
+		user.Password = model.NewId() + model.NewRandomString(16) + "!Aa1"
+	}
+
+	ruser, appErr := a.CreateUser(c, user)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if scimUser.Active != nil && !*scimUser.Active {
+		if ruser, appErr = a.UpdateActive(c, ruser, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return model.NewScimUser(ruser, a.scimSiteURL()), nil
+}
+
+// ScimReplaceUser replaces the attributes of a user, deactivating or reactivating it as
+// described by its active attribute.
+func (a *App) ScimReplaceUser(c *request.Context, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	if appErr := scimUser.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.getScimUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// The AuthData of a user is kept, as set when provisioned.
+	scimUser.ApplyTo(user)
+	user, appErr = a.UpdateUser(user, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if scimUser.Active != nil && *scimUser.Active != (user.DeleteAt == 0) {
+		if user, appErr = a.UpdateActive(c, user, *scimUser.Active); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return model.NewScimUser(user, a.scimSiteURL()), nil
+}
+
+// ScimPatchUser applies patch operations to a user.
+func (a *App) ScimPatchUser(c *request.Context, userID string, patch *model.ScimPatchRequest) (*model.ScimUser, *model.AppError) {
+	scimUser, appErr := a.ScimGetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := scimUser.ApplyPatch(patch.Operations); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.ScimReplaceUser(c, userID, scimUser)
+}
+
+// ScimDeactivateUser deprovisions a user. Users are deactivated rather than deleted, so that
+// their posts are kept.
+func (a *App) ScimDeactivateUser(c *request.Context, userID string) *model.AppError {
+	user, appErr := a.getScimUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	_, appErr = a.UpdateActive(c, user, false)
+	return appErr
+}
+
+// getScimGroup returns a group which can be provisioned, that is a custom group.
+func (a *App) getScimGroup(groupID string) (*model.Group, *model.AppError) {
+	group, appErr := a.GetGroup(groupID, nil)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if group.Source != model.GroupSourceCustom || group.DeleteAt != 0 {
+		return nil, model.NewAppError("getScimGroup", "app.group.no_rows", nil, "group_id="+groupID, http.StatusNotFound)
+	}
+	return group, nil
+}
+
+func (a *App) newScimGroup(group *model.Group) (*model.ScimGroup, *model.AppError) {
+	members, appErr := a.GetGroupMemberUsers(group.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return model.NewScimGroup(group, members, a.scimSiteURL()), nil
+}
+
+// ScimGetGroup returns the SCIM representation of a custom group.
+func (a *App) ScimGetGroup(groupID string) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.newScimGroup(group)
+}
+
+// ScimGetGroups returns a page of the custom groups, starting at startIndex which is 1-based,
+// matching a filter on their displayName.
+func (a *App) ScimGetGroups(filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError) {
+	scimFilter, appErr := model.ParseScimFilter(filter)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if scimFilter != nil && !strings.EqualFold(scimFilter.Attribute, "displayName") {
+		return nil, model.NewAppError("ScimGetGroups", "model.scim.filter.app_error", nil, "filter="+filter, http.StatusBadRequest)
+	}
+	startIndex, count = scimPage(startIndex, count)
+
+	groups, appErr := a.GetGroupsBySource(model.GroupSourceCustom)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	matching := make([]*model.Group, 0, len(groups))
+	for _, group := range groups {
+		if group.DeleteAt != 0 {
+			continue
+		}
+		if scimFilter != nil && !strings.EqualFold(group.DisplayName, scimFilter.Value) {
+			continue
+		}
+		matching = append(matching, group)
+	}
+
+	resources := []interface{}{}
+	for i := startIndex - 1; i < len(matching) && len(resources) < count; i++ {
+		scimGroup, appErr := a.newScimGroup(matching[i])
+		if appErr != nil {
+			return nil, appErr
+		}
+		resources = append(resources, scimGroup)
+	}
+
+	return model.NewScimListResponse(resources, len(matching), startIndex), nil
+}
+
+// ScimCreateGroup provisions a custom group and its members. Its name is cleaned from its
+// display name, and its externalId is ignored since custom groups have no remote id.
+func (a *App) ScimCreateGroup(scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	if appErr := scimGroup.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if existing, _ := a.GetGroupByName(scimGroup.GroupName(), model.GroupSearchOpts{}); existing != nil {
+		return nil, model.NewAppError("ScimCreateGroup", "app.scim.group_exists.app_error", nil, "display_name="+scimGroup.DisplayName, http.StatusConflict)
+	}
+
+	group := &model.Group{
+		Name:           model.NewString(scimGroup.GroupName()),
+		DisplayName:    scimGroup.DisplayName,
+		Source:         model.GroupSourceCustom,
+		AllowReference: true,
+	}
+	group, appErr := a.CreateGroup(group)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(scimGroup.Members) > 0 {
+		if _, appErr := a.UpsertGroupMembers(group.Id, scimGroup.MemberIds()); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.newScimGroup(group)
+}
+
+// ScimReplaceGroup replaces the display name and the members of a custom group.
+func (a *App) ScimReplaceGroup(groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	if appErr := scimGroup.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	group, appErr := a.getScimGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if group.DisplayName != scimGroup.DisplayName {
+		group.DisplayName = scimGroup.DisplayName
+		if group, appErr = a.UpdateGroup(group); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	members, appErr := a.GetGroupMemberUsers(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	wanted := make(map[string]bool, len(scimGroup.Members))
+	for _, id := range scimGroup.MemberIds() {
+		wanted[id] = true
+	}
+
+	var removed []string
+	for _, member := range members {
+		if !wanted[member.Id] {
+			removed = append(removed, member.Id)
+		}
+		delete(wanted, member.Id)
+	}
+
+	added := make([]string, 0, len(wanted))
+	for id := range wanted {
+		added = append(added, id)
+	}
+
+	if len(added) > 0 {
+		if _, appErr := a.UpsertGroupMembers(groupID, added); appErr != nil {
+			return nil, appErr
+		}
+	}
+	if len(removed) > 0 {
+		if _, appErr := a.DeleteGroupMembers(groupID, removed); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.newScimGroup(group)
+}
+
+// ScimPatchGroup applies patch operations to a custom group, such as adding or removing
+// members.
+func (a *App) ScimPatchGroup(groupID string, patch *model.ScimPatchRequest) (*model.ScimGroup, *model.AppError) {
+	scimGroup, appErr := a.ScimGetGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := scimGroup.ApplyPatch(patch.Operations); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.ScimReplaceGroup(groupID, scimGroup)
+}
+
+// ScimDeleteGroup deletes a custom group.
+func (a *App) ScimDeleteGroup(groupID string) *model.AppError {
+	if _, appErr := a.getScimGroup(groupID); appErr != nil {
+		return appErr
+	}
+
+	_, appErr := a.DeleteGroup(groupID)
+	return appErr
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+)
+
+// secretScanningAction returns the action taken on the posts containing secrets in the
+// channels of the team, or in direct and group messages if teamID is empty.
+func (a *App) secretScanningAction(teamID string) string {
+	settings := a.Config().ContentPolicySettings
+	if action, ok := settings.SecretScanningTeamActions[teamID]; ok && teamID != "" {
+		return action
+	}
+	return *settings.SecretScanningAction
+}
+
+// scanPostForSecrets looks for secrets in the message of a post. Depending on the action set
+// for the team of the channel, it masks the secrets in the post, returns an error to block it,
+// or returns the kinds of the secrets found so that the author can be warned.
+func (a *App) scanPostForSecrets(c *request.Context, post *model.Post, user *model.User, channel *model.Channel) ([]string, *model.AppError) {
+	if !*a.Config().ContentPolicySettings.EnableSecretScanning {
+		return nil, nil
+	}
+
+	if post.IsSystemMessage() || post.Message == "" {
+		return nil, nil
+	}
+
+	kinds := model.ScanSecrets(post.Message)
+	if len(kinds) == 0 || a.isExemptFromContentPolicy(user, channel) {
+		return nil, nil
+	}
+
+	switch action := a.secretScanningAction(channel.TeamId); action {
+	case model.SecretScanningActionBlock:
+		a.auditSecretScanningBlock(c, post, channel, kinds)
+		return nil, model.NewAppError("scanPostForSecrets", "app.secret_scanning.blocked.app_error", nil, "kinds="+strings.Join(kinds, ","), http.StatusBadRequest)
+	case model.SecretScanningActionMask:
+		post.Message = model.MaskSecrets(post.Message)
+		post.AddProp(model.PostPropsSecretsMasked, true)
+		return nil, nil
+	}
+
+	return kinds, nil
+}
+
+func (a *App) auditSecretScanningBlock(c *request.Context, post *model.Post, channel *model.Channel, kinds []string) {
+	auditRec := a.MakeAuditRecord("secretScanningBlockPost", audit.Success)
+	auditRec.UserID = post.UserId
+	auditRec.SessionID = c.Session().Id
+	auditRec.IPAddress = c.IPAddress()
+	auditRec.APIPath = c.Path()
+	auditRec.AddMeta("kinds", kinds)
+	auditRec.AddMeta("channel_id", channel.Id)
+	auditRec.AddMeta("team_id", channel.TeamId)
+	a.LogAuditRec(auditRec, nil)
+}
+
+// sendSecretScanningWarning warns the author of a post that it seems to contain secrets.
+func (a *App) sendSecretScanningWarning(post *model.Post, user *model.User) {
+	T := i18n.GetUserTranslations(user.Locale)
+	a.SendEphemeralPost(user.Id, &model.Post{
+		UserId:    user.Id,
+		RootId:    post.RootId,
+		ChannelId: post.ChannelId,
+		Message:   T("app.secret_scanning.warning.message"),
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"os/user"
 	"path"
 	"runtime"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 	sentryhttp "github.com/getsentry/sentry-go/http"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/lucas-clemente/quic-go/http3"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
 	"golang.org/x/crypto/acme/autocert"
@@ -43,19 +45,37 @@ import (
 	"github.com/mattermost/mattermost-server/v6/einterfaces"
 	"github.com/mattermost/mattermost-server/v6/jobs"
 	"github.com/mattermost/mattermost-server/v6/jobs/active_users"
+	"github.com/mattermost/mattermost-server/v6/jobs/auth_migration"
+	"github.com/mattermost/mattermost-server/v6/jobs/bulk_channel_members"
+	"github.com/mattermost/mattermost-server/v6/jobs/channel_events"
+	"github.com/mattermost/mattermost-server/v6/jobs/channel_member_counts"
 	"github.com/mattermost/mattermost-server/v6/jobs/expirynotify"
 	"github.com/mattermost/mattermost-server/v6/jobs/export_delete"
 	"github.com/mattermost/mattermost-server/v6/jobs/export_process"
 	"github.com/mattermost/mattermost-server/v6/jobs/extract_content"
+	"github.com/mattermost/mattermost-server/v6/jobs/file_retention"
 	"github.com/mattermost/mattermost-server/v6/jobs/import_delete"
 	"github.com/mattermost/mattermost-server/v6/jobs/import_process"
 	"github.com/mattermost/mattermost-server/v6/jobs/migrations"
+	"github.com/mattermost/mattermost-server/v6/jobs/msteams_import"
+	"github.com/mattermost/mattermost-server/v6/jobs/orphaned_files_cleanup"
+	"github.com/mattermost/mattermost-server/v6/jobs/partition_maintenance"
+	"github.com/mattermost/mattermost-server/v6/jobs/plugin_scheduled_tasks"
+	"github.com/mattermost/mattermost-server/v6/jobs/post_archive"
 	"github.com/mattermost/mattermost-server/v6/jobs/product_notices"
+	"github.com/mattermost/mattermost-server/v6/jobs/purge_soft_deleted"
+	"github.com/mattermost/mattermost-server/v6/jobs/reminders"
 	"github.com/mattermost/mattermost-server/v6/jobs/resend_invitation_email"
+	"github.com/mattermost/mattermost-server/v6/jobs/scheduled_config_changes"
+	"github.com/mattermost/mattermost-server/v6/jobs/user_deletion"
+	"github.com/mattermost/mattermost-server/v6/jobs/user_merge"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin/scheduler"
 	"github.com/mattermost/mattermost-server/v6/services/awsmeter"
 	"github.com/mattermost/mattermost-server/v6/services/cache"
+	"github.com/mattermost/mattermost-server/v6/services/changecapture"
+	"github.com/mattermost/mattermost-server/v6/services/eventbus"
+	"github.com/mattermost/mattermost-server/v6/services/geoip"
 	"github.com/mattermost/mattermost-server/v6/services/httpservice"
 	"github.com/mattermost/mattermost-server/v6/services/remotecluster"
 	"github.com/mattermost/mattermost-server/v6/services/searchengine"
@@ -72,6 +92,7 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 	"github.com/mattermost/mattermost-server/v6/shared/templates"
 	"github.com/mattermost/mattermost-server/v6/store"
+	"github.com/mattermost/mattermost-server/v6/store/changecapturelayer"
 	"github.com/mattermost/mattermost-server/v6/store/localcachelayer"
 	"github.com/mattermost/mattermost-server/v6/store/retrylayer"
 	"github.com/mattermost/mattermost-server/v6/store/searchlayer"
@@ -95,6 +116,7 @@ const (
 	TeamKey        ServiceKey = "team"
 	UserKey        ServiceKey = "user"
 	PermissionsKey ServiceKey = "permissions"
+	UsageKey       ServiceKey = "usage"
 )
 
 type Server struct {
@@ -113,13 +135,28 @@ type Server struct {
 	// from RootRouter only if the SiteURL contains a /subpath.
 	Router *mux.Router
 
-	Server      *http.Server
-	ListenAddr  *net.TCPAddr
-	RateLimiter *RateLimiter
-	Busy        *Busy
+	Server           *http.Server
+	ListenAddr       *net.TCPAddr
+	RateLimiter      *RateLimiter
+	Busy             *Busy
+	ResourceGuard    *ResourceGuard
+	DependencyHealth *DependencyHealth
 
 	localModeServer *http.Server
 
+	http3Server *http3.Server
+	http3Conn   net.PacketConn
+
+	// geoIPMut protects the GeoIP database, loaded from geoIPPath when first needed.
+	geoIPMut      sync.Mutex
+	geoIPPath     string
+	geoIPDatabase *geoip.Database
+
+	// openIdConnectDiscoveryMut protects the documents fetched from the discovery endpoints of
+	// the OpenID Connect providers, keyed by endpoint.
+	openIdConnectDiscoveryMut sync.Mutex
+	openIdConnectDiscovery    map[string]*openIdConnectDiscovery
+
 	metricsServer *http.Server
 	metricsRouter *mux.Router
 	metricsLock   sync.Mutex
@@ -137,6 +174,19 @@ type Server struct {
 	httpService            httpservice.HTTPService
 	PushNotificationsHub   PushNotificationsHub
 	pushNotificationClient *http.Client // TODO: move this to it's own package
+	mentionAggregator      *mentionAggregator
+	statusBatcher          *statusBatcher
+	eventBus               *eventbus.Publisher
+
+	// changeEventPublisher is kept open between the publications of the change events, along
+	// with the settings it was made from.
+	changeEventPublisherMut      sync.Mutex
+	changeEventPublisher         changecapture.Publisher
+	changeEventPublisherSettings model.ChangeDataCaptureSettings
+
+	// outgoingOAuthTokens caches the access tokens of the outgoing OAuth connections by
+	// connection id.
+	outgoingOAuthTokens sync.Map
 
 	runEssentialJobs bool
 	Jobs             *jobs.JobServer
@@ -155,6 +205,7 @@ type Server struct {
 
 	htmlTemplateWatcher     *templates.Container
 	seenPendingPostIdsCache cache.Cache
+	typingEventsCache       cache.Cache
 	statusCache             cache.Cache
 	openGraphDataCache      cache.Cache
 	configListenerId        string
@@ -303,19 +354,26 @@ func NewServer(options ...Option) (*Server, error) {
 				lcl,
 				s.SearchEngine,
 				s.Config(),
+				s.Metrics,
 			)
 
 			s.AddConfigListener(func(prevCfg, cfg *model.Config) {
 				searchStore.UpdateConfig(cfg)
 			})
 
+			changeCaptureStore := changecapturelayer.NewChangeCaptureLayer(searchStore, s.Config())
+
+			s.AddConfigListener(func(prevCfg, cfg *model.Config) {
+				changeCaptureStore.UpdateConfig(cfg)
+			})
+
 			s.sqlStore.UpdateLicense(s.License())
 			s.AddLicenseListener(func(oldLicense, newLicense *model.License) {
 				s.sqlStore.UpdateLicense(newLicense)
 			})
 
 			return timerlayer.New(
-				searchStore,
+				changeCaptureStore,
 				s.Metrics,
 			), nil
 		}
@@ -462,6 +520,11 @@ func NewServer(options ...Option) (*Server, error) {
 	}); err != nil {
 		return nil, errors.Wrap(err, "Unable to create pending post ids cache")
 	}
+	if s.typingEventsCache, err = s.CacheProvider.NewCache(&cache.CacheOptions{
+		Size: typingEventsCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create typing events cache")
+	}
 	if s.openGraphDataCache, err = s.CacheProvider.NewCache(&cache.CacheOptions{
 		Size: openGraphMetadataCacheSize,
 	}); err != nil {
@@ -469,10 +532,17 @@ func NewServer(options ...Option) (*Server, error) {
 	}
 
 	s.createPushNotificationsHub()
+	s.mentionAggregator = newMentionAggregator()
+	s.statusBatcher = newStatusBatcher()
+	s.eventBus = eventbus.NewPublisher(s.Config().EventBusSettings)
+	s.AddConfigListener(func(_, newCfg *model.Config) {
+		s.eventBus.UpdateSettings(newCfg.EventBusSettings)
+	})
 
 	if err2 := i18n.InitTranslations(*s.Config().LocalizationSettings.DefaultServerLocale, *s.Config().LocalizationSettings.DefaultClientLocale); err2 != nil {
 		return nil, errors.Wrapf(err2, "unable to load Mattermost translation files")
 	}
+	s.initNotificationTemplateOverrides()
 
 	templatesDir, ok := templates.GetTemplateDirectory()
 	if !ok {
@@ -661,6 +731,7 @@ func NewServer(options ...Option) (*Server, error) {
 			s.runLicenseExpirationCheckJob()
 			s.runInactivityCheckJob()
 			runDNDStatusExpireJob(appInstance)
+			runChangeEventPublisherJob(appInstance)
 		})
 		s.runJobs()
 	}
@@ -735,6 +806,18 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		runConfigCleanupJob(s)
 	})
+	s.Go(func() {
+		runPushNotificationReceiptCleanupJob(s)
+	})
+	s.Go(func() {
+		runConnectivityTestResultCleanupJob(s)
+	})
+	s.Go(func() {
+		runPersistentWebSocketEventCleanupJob(s)
+	})
+	s.Go(func() {
+		runChannelJoinRequestExpiryJob(s)
+	})
 
 	if complianceI := s.Channels().Compliance; complianceI != nil {
 		complianceI.StartComplianceDailyJob()
@@ -950,6 +1033,8 @@ func (s *Server) enableLoggingMetrics() {
 const TimeToWaitForConnectionsToCloseOnServerShutdown = time.Second
 
 func (s *Server) StopHTTPServer() {
+	s.stopHTTP3Server()
+
 	if s.Server != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), TimeToWaitForConnectionsToCloseOnServerShutdown)
 		defer cancel()
@@ -1006,10 +1091,16 @@ func (s *Server) Shutdown() {
 	s.serviceMux.RUnlock()
 
 	s.StopHTTPServer()
+	s.ResourceGuard.Stop()
+	s.DependencyHealth.Stop()
 	s.stopLocalModeServer()
 	// Push notification hub needs to be shutdown after HTTP server
 	// to prevent stray requests from generating a push notification after it's shut down.
 	s.StopPushNotificationsHubWorkers()
+	s.mentionAggregator.stop()
+	s.statusBatcher.stop()
+	s.eventBus.Stop()
+	s.closeChangeEventPublisher()
 	s.htmlTemplateWatcher.Close()
 
 	s.WaitForGoroutines()
@@ -1214,6 +1305,11 @@ func (s *Server) Start() error {
 
 	s.checkPushNotificationServerURL()
 
+	s.DependencyHealth = NewDependencyHealth(s.Config, s.dependencyChecks())
+	if err := s.DependencyHealth.CheckAtStartup(); err != nil {
+		return errors.Wrap(err, "unable to start with unreachable dependencies")
+	}
+
 	s.ReloadConfig()
 
 	mlog.Info("Starting Server...")
@@ -1261,6 +1357,8 @@ func (s *Server) Start() error {
 		handler = rateLimiter.RateLimitHandler(handler)
 	}
 	s.Busy = NewBusy(s.Cluster)
+	s.ResourceGuard = NewResourceGuard(s.Config, s.Metrics)
+	s.ResourceGuard.Start()
 
 	// Creating a logger for logging errors from http.Server at error level
 	errStdLog := s.Log.With(mlog.String("source", "httpserver")).StdLogger(mlog.LvlError)
@@ -1296,6 +1394,19 @@ func (s *Server) Start() error {
 		Prompt: autocert.AcceptTOS,
 	}
 
+	var tlsConfig *tls.Config
+	var certFile, keyFile string
+	if *s.Config().ServiceSettings.ConnectionSecurity == model.ConnSecurityTLS {
+		tlsConfig, certFile, keyFile = s.newServerTLSConfig(m)
+	}
+
+	if *s.Config().ServiceSettings.EnableHTTP3 {
+		if err = s.startHTTP3Server(addr, handler, tlsConfig, certFile, keyFile); err != nil {
+			listener.Close()
+			return errors.Wrapf(err, i18n.T("api.server.start_server.starting.critical"), err)
+		}
+	}
+
 	if *s.Config().ServiceSettings.Forward80To443 {
 		if host, port, err := net.SplitHostPort(addr); err != nil {
 			mlog.Error("Unable to setup forwarding", mlog.Err(err))
@@ -1336,64 +1447,6 @@ func (s *Server) Start() error {
 	go func() {
 		var err error
 		if *s.Config().ServiceSettings.ConnectionSecurity == model.ConnSecurityTLS {
-
-			tlsConfig := &tls.Config{
-				PreferServerCipherSuites: true,
-				CurvePreferences:         []tls.CurveID{tls.CurveP521, tls.CurveP384, tls.CurveP256},
-			}
-
-			switch *s.Config().ServiceSettings.TLSMinVer {
-			case "1.0":
-				tlsConfig.MinVersion = tls.VersionTLS10
-			case "1.1":
-				tlsConfig.MinVersion = tls.VersionTLS11
-			default:
-				tlsConfig.MinVersion = tls.VersionTLS12
-			}
-
-			defaultCiphers := []uint16{
-				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
-				tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
-				tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
-			}
-
-			if len(s.Config().ServiceSettings.TLSOverwriteCiphers) == 0 {
-				tlsConfig.CipherSuites = defaultCiphers
-			} else {
-				var cipherSuites []uint16
-				for _, cipher := range s.Config().ServiceSettings.TLSOverwriteCiphers {
-					value, ok := model.ServerTLSSupportedCiphers[cipher]
-
-					if !ok {
-						mlog.Warn("Unsupported cipher passed", mlog.String("cipher", cipher))
-						continue
-					}
-
-					cipherSuites = append(cipherSuites, value)
-				}
-
-				if len(cipherSuites) == 0 {
-					mlog.Warn("No supported ciphers passed, fallback to default cipher suite")
-					cipherSuites = defaultCiphers
-				}
-
-				tlsConfig.CipherSuites = cipherSuites
-			}
-
-			certFile := ""
-			keyFile := ""
-
-			if *s.Config().ServiceSettings.UseLetsEncrypt {
-				tlsConfig.GetCertificate = m.GetCertificate
-				tlsConfig.NextProtos = append(tlsConfig.NextProtos, "h2")
-			} else {
-				certFile = *s.Config().ServiceSettings.TLSCertFile
-				keyFile = *s.Config().ServiceSettings.TLSKeyFile
-			}
-
 			s.Server.TLSConfig = tlsConfig
 			err = s.Server.ServeTLS(listener, certFile, keyFile)
 		} else {
@@ -1421,13 +1474,89 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// newServerTLSConfig returns the TLS configuration of the main server, with the files of its
+// certificate unless it is provided by Let's Encrypt.
+func (s *Server) newServerTLSConfig(m *autocert.Manager) (*tls.Config, string, string) {
+	tlsConfig := &tls.Config{
+		PreferServerCipherSuites: true,
+		CurvePreferences:         []tls.CurveID{tls.CurveP521, tls.CurveP384, tls.CurveP256},
+	}
+
+	switch *s.Config().ServiceSettings.TLSMinVer {
+	case "1.0":
+		tlsConfig.MinVersion = tls.VersionTLS10
+	case "1.1":
+		tlsConfig.MinVersion = tls.VersionTLS11
+	default:
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+
+	defaultCiphers := []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+	}
+
+	if len(s.Config().ServiceSettings.TLSOverwriteCiphers) == 0 {
+		tlsConfig.CipherSuites = defaultCiphers
+	} else {
+		var cipherSuites []uint16
+		for _, cipher := range s.Config().ServiceSettings.TLSOverwriteCiphers {
+			value, ok := model.ServerTLSSupportedCiphers[cipher]
+
+			if !ok {
+				mlog.Warn("Unsupported cipher passed", mlog.String("cipher", cipher))
+				continue
+			}
+
+			cipherSuites = append(cipherSuites, value)
+		}
+
+		if len(cipherSuites) == 0 {
+			mlog.Warn("No supported ciphers passed, fallback to default cipher suite")
+			cipherSuites = defaultCiphers
+		}
+
+		tlsConfig.CipherSuites = cipherSuites
+	}
+
+	// Remote clusters authenticate with client certificates when mutual TLS is enabled. The
+	// certificate is only requested at the TLS layer since browsers and other clients share this
+	// listener; remote cluster requests without a pinned certificate are rejected by the handler.
+	if *s.Config().ExperimentalSettings.RemoteClusterEnableMutualTLS {
+		tlsConfig.ClientAuth = tls.RequestClientCert
+	}
+
+	certFile := ""
+	keyFile := ""
+
+	if *s.Config().ServiceSettings.UseLetsEncrypt {
+		tlsConfig.GetCertificate = m.GetCertificate
+		tlsConfig.NextProtos = append(tlsConfig.NextProtos, "h2")
+	} else {
+		certFile = *s.Config().ServiceSettings.TLSCertFile
+		keyFile = *s.Config().ServiceSettings.TLSKeyFile
+	}
+
+	return tlsConfig, certFile, keyFile
+}
+
 func (s *Server) startLocalModeServer() error {
 	s.localModeServer = &http.Server{
 		Handler: s.LocalRouter,
 	}
 
-	socket := *s.configStore.Get().ServiceSettings.LocalModeSocketLocation
-	if err := os.RemoveAll(socket); err != nil {
+	settings := s.configStore.Get().ServiceSettings
+	socket := *settings.LocalModeSocketLocation
+	perm, err := settings.GetLocalModeSocketPermissions()
+	if err != nil {
+		return errors.Wrapf(err, i18n.T("api.server.start_server.starting.critical"), err)
+	}
+
+	if err = os.RemoveAll(socket); err != nil {
 		return errors.Wrapf(err, i18n.T("api.server.start_server.starting.critical"), err)
 	}
 
@@ -1435,9 +1564,18 @@ func (s *Server) startLocalModeServer() error {
 	if err != nil {
 		return errors.Wrapf(err, i18n.T("api.server.start_server.starting.critical"), err)
 	}
-	if err = os.Chmod(socket, 0600); err != nil {
+	if err = os.Chmod(socket, perm); err != nil {
+		unixListener.Close()
 		return errors.Wrapf(err, i18n.T("api.server.start_server.starting.critical"), err)
 	}
+	// Access to the socket is granted through its file permissions, which may be shared with the
+	// members of a group so that they can use mmctl --local without running as the server's user.
+	if group := *settings.LocalModeSocketGroup; group != "" {
+		if err = chownToGroup(socket, group); err != nil {
+			unixListener.Close()
+			return errors.Wrapf(err, i18n.T("api.server.start_server.starting.critical"), err)
+		}
+	}
 
 	go func() {
 		err = s.localModeServer.Serve(unixListener)
@@ -1448,6 +1586,20 @@ func (s *Server) startLocalModeServer() error {
 	return nil
 }
 
+func chownToGroup(path, groupName string) error {
+	group, err := user.LookupGroup(groupName)
+	if err != nil {
+		return err
+	}
+
+	gid, err := strconv.Atoi(group.Gid)
+	if err != nil {
+		return errors.Wrapf(err, "unsupported gid %s for group %s", group.Gid, groupName)
+	}
+
+	return os.Chown(path, -1, gid)
+}
+
 func (s *Server) stopLocalModeServer() {
 	if s.localModeServer != nil {
 		s.localModeServer.Close()
@@ -1511,6 +1663,34 @@ func runJobsCleanupJob(s *Server) {
 	}, time.Hour*24)
 }
 
+func runPushNotificationReceiptCleanupJob(s *Server) {
+	doPushNotificationReceiptCleanup(s)
+	model.CreateRecurringTask("Push Notification Receipt Cleanup", func() {
+		doPushNotificationReceiptCleanup(s)
+	}, time.Hour*24)
+}
+
+func runConnectivityTestResultCleanupJob(s *Server) {
+	doConnectivityTestResultCleanup(s)
+	model.CreateRecurringTask("Connectivity Test Result Cleanup", func() {
+		doConnectivityTestResultCleanup(s)
+	}, time.Hour*24)
+}
+
+func runPersistentWebSocketEventCleanupJob(s *Server) {
+	doPersistentWebSocketEventCleanup(s)
+	model.CreateRecurringTask("Persistent WebSocket Event Cleanup", func() {
+		doPersistentWebSocketEventCleanup(s)
+	}, time.Minute*10)
+}
+
+func runChannelJoinRequestExpiryJob(s *Server) {
+	doChannelJoinRequestExpiry(s)
+	model.CreateRecurringTask("Channel Join Request Expiry", func() {
+		doChannelJoinRequestExpiry(s)
+	}, time.Hour)
+}
+
 func runConfigCleanupJob(s *Server) {
 	doConfigCleanup(s)
 	model.CreateRecurringTask("Configuration Cleanup", func() {
@@ -1566,8 +1746,12 @@ func doCommandWebhookCleanup(s *Server) {
 }
 
 const (
-	sessionsCleanupBatchSize = 1000
-	jobsCleanupBatchSize     = 1000
+	sessionsCleanupBatchSize           = 1000
+	jobsCleanupBatchSize               = 1000
+	pushReceiptCleanupBatchSize        = 1000
+	connectivityTestCleanupBatchSize   = 1000
+	persistentEventCleanupBatchSize    = 1000
+	channelJoinRequestCleanupBatchSize = 1000
 )
 
 func doSessionCleanup(s *Server) {
@@ -1578,6 +1762,44 @@ func doSessionCleanup(s *Server) {
 	}
 }
 
+func doPushNotificationReceiptCleanup(s *Server) {
+	mlog.Debug("Cleaning up push notification receipt store.")
+	expiry := model.GetMillisForTime(time.Now().AddDate(0, 0, -model.PushReceiptRetentionDays))
+	if err := s.Store.PushNotificationReceipt().Cleanup(expiry, pushReceiptCleanupBatchSize); err != nil {
+		mlog.Warn("Error while cleaning up push notification receipts", mlog.Err(err))
+	}
+}
+
+func doConnectivityTestResultCleanup(s *Server) {
+	mlog.Debug("Cleaning up connectivity test result store.")
+	expiry := model.GetMillisForTime(time.Now().AddDate(0, 0, -model.ConnectivityTestRetentionDays))
+	if err := s.Store.ConnectivityTestResult().Cleanup(expiry, connectivityTestCleanupBatchSize); err != nil {
+		mlog.Warn("Error while cleaning up connectivity test results", mlog.Err(err))
+	}
+}
+
+func doPersistentWebSocketEventCleanup(s *Server) {
+	mlog.Debug("Cleaning up persistent websocket event store.")
+	expiry := model.GetMillisForTime(time.Now().Add(-model.PersistentWebSocketEventRetentionMinutes * time.Minute))
+	if err := s.Store.PersistentWebSocketEvent().Cleanup(expiry, persistentEventCleanupBatchSize); err != nil {
+		mlog.Warn("Error while cleaning up persistent websocket events", mlog.Err(err))
+	}
+}
+
+// doChannelJoinRequestExpiry marks the requests to join a channel which expired as such, and
+// removes the old requests no longer pending.
+func doChannelJoinRequestExpiry(s *Server) {
+	mlog.Debug("Expiring channel join requests.")
+	if _, err := s.Store.ChannelJoinRequest().Expire(model.GetMillis()); err != nil {
+		mlog.Warn("Error while expiring channel join requests", mlog.Err(err))
+	}
+
+	expiry := model.GetMillisForTime(time.Now().AddDate(0, 0, -model.ChannelJoinRequestRetentionDays))
+	if err := s.Store.ChannelJoinRequest().Cleanup(expiry, channelJoinRequestCleanupBatchSize); err != nil {
+		mlog.Warn("Error while cleaning up channel join requests", mlog.Err(err))
+	}
+}
+
 func doJobsCleanup(s *Server) {
 	if *s.Config().JobSettings.CleanupJobsThresholdDays < 0 {
 		return
@@ -1748,6 +1970,52 @@ func (s *Server) sendLicenseUpForRenewalEmail(users map[string]*model.User, lice
 	return nil
 }
 
+// sendLicenseSeatLimitEmail warns admins once per license and seat state when the number of
+// active users nears or exceeds the licensed seats.
+func (s *Server) sendLicenseSeatLimitEmail(users map[string]*model.User, license *model.License) *model.AppError {
+	status, appErr := s.GetLicenseStatus()
+	if appErr != nil {
+		return appErr
+	}
+
+	if status.SeatState == model.LicenseSeatStateOk {
+		return nil
+	}
+
+	key := model.LicenseSeatLimitEmailSent + license.Id + status.SeatState
+	if _, err := s.Store.System().GetByName(key); err == nil {
+		return nil
+	}
+
+	countNotOks := 0
+
+	for _, user := range users {
+		name := user.FirstName
+		if name == "" {
+			name = user.Username
+		}
+		if err := s.EmailService.SendLicenseSeatLimitEmail(user.Email, name, user.Locale, *s.Config().ServiceSettings.SiteURL, status); err != nil {
+			mlog.Error("Error sending license seat limit email to", mlog.String("user_email", user.Email), mlog.Err(err))
+			countNotOks++
+		}
+	}
+
+	if countNotOks == len(users) {
+		return model.NewAppError("s.sendLicenseSeatLimitEmail", "api.server.license_seat_limit.error_sending_email", nil, "", http.StatusInternalServerError)
+	}
+
+	system := model.System{
+		Name:  key,
+		Value: "true",
+	}
+
+	if err := s.Store.System().Save(&system); err != nil {
+		mlog.Debug("Failed to mark license seat limit email sending as completed.", mlog.Err(err))
+	}
+
+	return nil
+}
+
 func (s *Server) doLicenseExpirationCheck() {
 	s.LoadLicense()
 
@@ -1777,6 +2045,10 @@ func (s *Server) doLicenseExpirationCheck() {
 		return
 	}
 
+	if appErr := s.sendLicenseSeatLimitEmail(users, license); appErr != nil {
+		mlog.Debug(appErr.Error())
+	}
+
 	if license.IsWithinExpirationPeriod() {
 		appErr := s.sendLicenseUpForRenewalEmail(users, license)
 		if appErr != nil {
@@ -1832,7 +2104,9 @@ func (s *Server) StartSearchEngine() (string, string) {
 		s.Go(func() {
 			if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
 				s.Log.Error(err.Error())
+				return
 			}
+			s.applyIndexLifecyclePolicy(&s.Config().ElasticsearchSettings)
 		})
 	}
 
@@ -1846,7 +2120,9 @@ func (s *Server) StartSearchEngine() (string, string) {
 			s.Go(func() {
 				if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
 					mlog.Error(err.Error())
+					return
 				}
+				s.applyIndexLifecyclePolicy(&newConfig.ElasticsearchSettings)
 			})
 		} else if s.SearchEngine.ElasticsearchEngine != nil && *oldConfig.ElasticsearchSettings.EnableIndexing && !*newConfig.ElasticsearchSettings.EnableIndexing {
 			s.Go(func() {
@@ -1862,9 +2138,15 @@ func (s *Server) StartSearchEngine() (string, string) {
 					}
 					if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
 						mlog.Error(err.Error())
+						return
 					}
+					s.applyIndexLifecyclePolicy(&newConfig.ElasticsearchSettings)
 				}
 			})
+		} else if s.SearchEngine.ElasticsearchEngine != nil && indexLifecycleSettingsChanged(&oldConfig.ElasticsearchSettings, &newConfig.ElasticsearchSettings) {
+			s.Go(func() {
+				s.applyIndexLifecyclePolicy(&newConfig.ElasticsearchSettings)
+			})
 		}
 	})
 
@@ -1877,7 +2159,9 @@ func (s *Server) StartSearchEngine() (string, string) {
 				s.Go(func() {
 					if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
 						mlog.Error(err.Error())
+						return
 					}
+					s.applyIndexLifecyclePolicy(&s.Config().ElasticsearchSettings)
 				})
 			}
 		} else if oldLicense != nil && newLicense == nil {
@@ -1894,6 +2178,38 @@ func (s *Server) StartSearchEngine() (string, string) {
 	return configListenerId, licenseListenerId
 }
 
+// applyIndexLifecyclePolicy has the Elasticsearch engine write the posts through the rollover
+// alias of the lifecycle policy built from the settings, and migrates the daily post indexes to
+// it, if the index lifecycle management is enabled.
+func (s *Server) applyIndexLifecyclePolicy(settings *model.ElasticsearchSettings) {
+	policy := searchengine.NewIndexLifecyclePolicy(settings)
+	if policy == nil {
+		return
+	}
+
+	engine := s.SearchEngine.GetIndexLifecycleEngine()
+	if engine == nil {
+		s.Log.Warn("The index lifecycle management is enabled, but not supported by the search engine.")
+		return
+	}
+
+	if appErr := engine.ApplyIndexLifecyclePolicy(policy); appErr != nil {
+		s.Log.Error("Failed to apply the index lifecycle policy.", mlog.String("policy", policy.Name), mlog.Err(appErr))
+		return
+	}
+	if appErr := engine.MigrateIndexesToLifecyclePolicy(policy); appErr != nil {
+		s.Log.Error("Failed to migrate the indexes to the index lifecycle policy.", mlog.String("policy", policy.Name), mlog.Err(appErr))
+	}
+}
+
+func indexLifecycleSettingsChanged(oldSettings, newSettings *model.ElasticsearchSettings) bool {
+	return *newSettings.EnableIndexLifecycleManagement && (!*oldSettings.EnableIndexLifecycleManagement ||
+		*oldSettings.RolloverMaxAgeDays != *newSettings.RolloverMaxAgeDays ||
+		*oldSettings.RolloverMaxSizeGB != *newSettings.RolloverMaxSizeGB ||
+		*oldSettings.WarmPhaseAfterDays != *newSettings.WarmPhaseAfterDays ||
+		*oldSettings.ColdPhaseAfterDays != *newSettings.ColdPhaseAfterDays)
+}
+
 func (s *Server) stopSearchEngine() {
 	s.RemoveConfigListener(s.searchConfigListenerId)
 	s.RemoveLicenseListener(s.searchLicenseListenerId)
@@ -2027,6 +2343,96 @@ func (s *Server) initJobs() {
 		extract_content.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store),
 		nil,
 	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeBulkChannelMembers,
+		bulk_channel_members.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeMSTeamsImport,
+		msteams_import.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypePartitionMaintenance,
+		partition_maintenance.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store),
+		partition_maintenance.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypePostArchive,
+		post_archive.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store),
+		post_archive.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypePluginScheduledTasks,
+		plugin_scheduled_tasks.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		plugin_scheduled_tasks.MakeScheduler(s.Jobs, New(ServerConnector(s.Channels()))),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeOrphanedFilesCleanup,
+		orphaned_files_cleanup.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		orphaned_files_cleanup.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeUserMerge,
+		user_merge.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeAuthMigration,
+		auth_migration.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileRetention,
+		file_retention.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		file_retention.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeChannelMemberCounts,
+		channel_member_counts.MakeWorker(s.Jobs, s.Store),
+		channel_member_counts.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeReminders,
+		reminders.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		reminders.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeChannelEvents,
+		channel_events.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		channel_events.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeScheduledConfigChanges,
+		scheduled_config_changes.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		scheduled_config_changes.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypePurgeSoftDeleted,
+		purge_soft_deleted.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		purge_soft_deleted.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeUserDeletion,
+		user_deletion.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
 }
 
 func (s *Server) TelemetryId() string {
@@ -2174,6 +2580,20 @@ func runDNDStatusExpireJob(a *App) {
 	})
 }
 
+// runChangeEventPublisherJob publishes the recorded change events every few seconds, from the
+// leader only so that the events are published in order.
+func runChangeEventPublisherJob(a *App) {
+	model.CreateRecurringTask("Publish Change Events", func() {
+		if !*a.Config().ChangeDataCaptureSettings.Enable || !a.IsLeader() {
+			a.Srv().closeChangeEventPublisher()
+			return
+		}
+		if _, appErr := a.PublishChangeEvents(); appErr != nil {
+			mlog.Warn("Failed to publish change events", mlog.Err(appErr))
+		}
+	}, 5*time.Second)
+}
+
 func (a *App) GetAppliedSchemaMigrations() ([]model.AppliedMigration, *model.AppError) {
 	table, err := a.Srv().Store.GetAppliedMigrations()
 	if err != nil {
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/lucas-clemente/quic-go/http3"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// startHTTP3Server serves HTTP/3 over UDP, at the address of the main server unless
+// HTTP3ListenAddress is set, with the TLS configuration and the certificates of the main server:
+// either the ones loaded from certFile and keyFile, or the ones provided by
+// tlsConfig.GetCertificate. The main server only advertises HTTP/3 to its clients, through the
+// Alt-Svc header, once the certificates are loaded and the UDP socket is open.
+func (s *Server) startHTTP3Server(addr string, handler http.Handler, tlsConfig *tls.Config, certFile, keyFile string) error {
+	if tlsConfig == nil {
+		return errors.New("HTTP/3 requires TLS")
+	}
+
+	tlsConfig = tlsConfig.Clone()
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to load the certificate of the HTTP/3 server")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if http3Addr := *s.Config().ServiceSettings.HTTP3ListenAddress; http3Addr != "" {
+		addr = http3Addr
+	}
+
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen for HTTP/3 on %s", addr)
+	}
+
+	server := &http3.Server{
+		Server: &http.Server{
+			Handler:     handler,
+			TLSConfig:   tlsConfig,
+			IdleTimeout: time.Duration(*s.Config().ServiceSettings.IdleTimeout) * time.Second,
+			ErrorLog:    s.Log.With(mlog.String("source", "http3server")).StdLogger(mlog.LvlError),
+		},
+	}
+	s.http3Conn = conn
+	s.http3Server = server
+
+	go func() {
+		if err := server.Serve(conn); err != nil && err != http.ErrServerClosed {
+			mlog.Error("Error starting HTTP/3 server", mlog.Err(err))
+		}
+	}()
+
+	altSvc := fmt.Sprintf(`h3=":%d"; ma=%d`, conn.LocalAddr().(*net.UDPAddr).Port, *s.Config().ServiceSettings.HTTP3AltSvcMaxAge)
+	next := s.Server.Handler
+	s.Server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Alt-Svc", altSvc)
+		next.ServeHTTP(w, r)
+	})
+
+	mlog.Info("HTTP/3 server is listening", mlog.String("address", conn.LocalAddr().String()))
+	return nil
+}
+
+func (s *Server) stopHTTP3Server() {
+	if s.http3Server == nil {
+		return
+	}
+
+	if err := s.http3Server.Close(); err != nil {
+		mlog.Warn("Unable to shutdown HTTP/3 server", mlog.Err(err))
+	}
+	s.http3Conn.Close()
+
+	s.http3Server = nil
+	s.http3Conn = nil
+}
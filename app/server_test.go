@@ -21,10 +21,13 @@ import (
 
 	"github.com/getsentry/sentry-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/config"
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/searchengine"
+	"github.com/mattermost/mattermost-server/v6/services/searchengine/mocks"
 	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 	"github.com/mattermost/mattermost-server/v6/store/storetest"
@@ -218,6 +221,69 @@ func TestStartServerTLSSuccess(t *testing.T) {
 	require.NoError(t, serverErr)
 }
 
+func TestStartServerHTTP3(t *testing.T) {
+	testDir, _ := fileutils.FindDir("tests")
+
+	t.Run("advertised once listening", func(t *testing.T) {
+		s, err := newServerWithConfig(t, func(cfg *model.Config) {
+			*cfg.ServiceSettings.ListenAddress = ":0"
+			*cfg.ServiceSettings.ConnectionSecurity = "TLS"
+			*cfg.ServiceSettings.TLSKeyFile = path.Join(testDir, "tls_test_key.pem")
+			*cfg.ServiceSettings.TLSCertFile = path.Join(testDir, "tls_test_cert.pem")
+			*cfg.ServiceSettings.EnableHTTP3 = true
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, s.Start())
+		defer s.Shutdown()
+		require.NotNil(t, s.http3Conn)
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+		resp, err := client.Get("https://localhost:" + strconv.Itoa(s.ListenAddr.Port) + "/")
+		require.NoError(t, err)
+		resp.Body.Close()
+		expected := fmt.Sprintf(`h3=":%d"; ma=%d`, s.http3Conn.LocalAddr().(*net.UDPAddr).Port, model.ServiceSettingsDefaultHTTP3AltSvcMaxAge)
+		assert.Equal(t, expected, resp.Header.Get("Alt-Svc"))
+	})
+
+	t.Run("failing to load the certificate", func(t *testing.T) {
+		s, err := newServerWithConfig(t, func(cfg *model.Config) {
+			*cfg.ServiceSettings.ListenAddress = ":0"
+			*cfg.ServiceSettings.ConnectionSecurity = "TLS"
+			*cfg.ServiceSettings.TLSKeyFile = path.Join(testDir, "missing_key.pem")
+			*cfg.ServiceSettings.TLSCertFile = path.Join(testDir, "tls_test_cert.pem")
+			*cfg.ServiceSettings.EnableHTTP3 = true
+		})
+		require.NoError(t, err)
+
+		serverErr := s.Start()
+		s.Shutdown()
+		require.Error(t, serverErr)
+		assert.Nil(t, s.http3Conn)
+	})
+
+	t.Run("failing to listen", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		s, err := newServerWithConfig(t, func(cfg *model.Config) {
+			*cfg.ServiceSettings.ListenAddress = ":0"
+			*cfg.ServiceSettings.ConnectionSecurity = "TLS"
+			*cfg.ServiceSettings.TLSKeyFile = path.Join(testDir, "tls_test_key.pem")
+			*cfg.ServiceSettings.TLSCertFile = path.Join(testDir, "tls_test_cert.pem")
+			*cfg.ServiceSettings.EnableHTTP3 = true
+			*cfg.ServiceSettings.HTTP3ListenAddress = conn.LocalAddr().String()
+		})
+		require.NoError(t, err)
+
+		serverErr := s.Start()
+		s.Shutdown()
+		require.Error(t, serverErr)
+		assert.Nil(t, s.http3Conn)
+	})
+}
+
 func TestDatabaseTypeAndMattermostVersion(t *testing.T) {
 	sqlDrivernameEnvironment := os.Getenv("MM_SQLSETTINGS_DRIVERNAME")
 
@@ -544,3 +610,64 @@ func TestSentry(t *testing.T) {
 		}
 	})
 }
+
+func TestApplyIndexLifecyclePolicy(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	es := &mocks.SearchEngineInterface{}
+	es.On("IsActive").Return(true)
+	engine := &mocks.IndexLifecycleEngine{}
+	th.App.Srv().SearchEngine.ElasticsearchEngine = &struct {
+		*mocks.SearchEngineInterface
+		*mocks.IndexLifecycleEngine
+	}{es, engine}
+	defer func() {
+		th.App.Srv().SearchEngine.ElasticsearchEngine = nil
+	}()
+
+	settings := &model.ElasticsearchSettings{}
+	settings.SetDefaults()
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.Srv().applyIndexLifecyclePolicy(settings)
+		engine.AssertNotCalled(t, "ApplyIndexLifecyclePolicy", mock.Anything)
+		engine.AssertNotCalled(t, "MigrateIndexesToLifecyclePolicy", mock.Anything)
+	})
+
+	settings.EnableIndexLifecycleManagement = model.NewBool(true)
+	policy := searchengine.NewIndexLifecyclePolicy(settings)
+
+	t.Run("enabled", func(t *testing.T) {
+		engine.On("ApplyIndexLifecyclePolicy", policy).Return(nil).Once()
+		engine.On("MigrateIndexesToLifecyclePolicy", policy).Return(nil).Once()
+
+		th.App.Srv().applyIndexLifecyclePolicy(settings)
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("failing to apply the policy", func(t *testing.T) {
+		engine.On("ApplyIndexLifecyclePolicy", policy).Return(model.NewAppError("test", "test", nil, "", http.StatusInternalServerError)).Once()
+
+		th.App.Srv().applyIndexLifecyclePolicy(settings)
+		engine.AssertExpectations(t)
+		engine.AssertNumberOfCalls(t, "MigrateIndexesToLifecyclePolicy", 1)
+	})
+
+	t.Run("settings changed", func(t *testing.T) {
+		oldSettings := &model.ElasticsearchSettings{}
+		oldSettings.SetDefaults()
+		newSettings := &model.ElasticsearchSettings{}
+		newSettings.SetDefaults()
+		assert.False(t, indexLifecycleSettingsChanged(oldSettings, newSettings))
+
+		newSettings.EnableIndexLifecycleManagement = model.NewBool(true)
+		assert.True(t, indexLifecycleSettingsChanged(oldSettings, newSettings))
+
+		oldSettings.EnableIndexLifecycleManagement = model.NewBool(true)
+		assert.False(t, indexLifecycleSettingsChanged(oldSettings, newSettings))
+
+		newSettings.WarmPhaseAfterDays = model.NewInt(7)
+		assert.True(t, indexLifecycleSettingsChanged(oldSettings, newSettings))
+	})
+}
@@ -259,7 +259,8 @@ func (a *App) ExtendSessionExpiryIfNeeded(session *model.Session) bool {
 		return false
 	}
 
-	if session == nil || session.IsExpired() {
+	// Impersonation sessions are time-boxed.
+	if session == nil || session.IsExpired() || session.IsImpersonation() {
 		return false
 	}
 
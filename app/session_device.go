@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/geoip"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// getGeoIPDatabase returns the database configured by ServiceSettings.GeoIPDatabasePath, loaded
+// the first time it is needed and again when the path changes, or nil if there is none.
+func (s *Server) getGeoIPDatabase() *geoip.Database {
+	path := *s.Config().ServiceSettings.GeoIPDatabasePath
+
+	s.geoIPMut.Lock()
+	defer s.geoIPMut.Unlock()
+
+	if path == s.geoIPPath {
+		return s.geoIPDatabase
+	}
+
+	// A database failing to load isn't retried until the path changes.
+	s.geoIPPath = path
+	s.geoIPDatabase = nil
+	if path == "" {
+		return nil
+	}
+
+	db, err := geoip.Open(path)
+	if err != nil {
+		mlog.Error("Failed to load the GeoIP database", mlog.String("path", path), mlog.Err(err))
+		return nil
+	}
+	mlog.Info("Loaded the GeoIP database", mlog.String("path", path), mlog.Int("ranges", db.Len()))
+	s.geoIPDatabase = db
+
+	return db
+}
+
+// GetSessionDevices returns the devices of the sessions of a user, located from their IP
+// address when a GeoIP database is configured.
+func (a *App) GetSessionDevices(userID, currentSessionID string) ([]*model.SessionDevice, *model.AppError) {
+	sessions, appErr := a.GetSessions(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	db := a.Srv().getGeoIPDatabase()
+
+	devices := make([]*model.SessionDevice, 0, len(sessions))
+	for _, session := range sessions {
+		if session.IsExpired() {
+			continue
+		}
+
+		device := model.NewSessionDevice(session)
+		device.Current = session.Id == currentSessionID
+		if device.IPAddress != "" {
+			device.Location = db.Lookup(net.ParseIP(device.IPAddress))
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+// UpdateSessionDeviceName names the device of a session of a user, or clears its name.
+func (a *App) UpdateSessionDeviceName(userID, sessionID, name string) (*model.SessionDevice, *model.AppError) {
+	if appErr := model.IsValidSessionDeviceName(name); appErr != nil {
+		return nil, appErr
+	}
+
+	session, appErr := a.GetSessionById(sessionID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if session.UserId != userID {
+		return nil, model.NewAppError("UpdateSessionDeviceName", "app.session.get.app_error", nil, "session_id="+sessionID, http.StatusBadRequest)
+	}
+
+	session.AddProp(model.SessionPropDeviceName, name)
+	if err := a.Srv().Store.Session().UpdateProps(session); err != nil {
+		return nil, model.NewAppError("UpdateSessionDeviceName", "app.session.update_props.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	a.ClearSessionCacheForUser(userID)
+
+	device := model.NewSessionDevice(session)
+	if device.IPAddress != "" {
+		device.Location = a.Srv().getGeoIPDatabase().Lookup(net.ParseIP(device.IPAddress))
+	}
+
+	return device, nil
+}
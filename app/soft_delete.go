@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const purgeSoftDeletedBatchSize = 100
+
+// PurgeSoftDeleted permanently deletes the teams and commands deleted before the given time,
+// returning how many were deleted. The teams are permanently deleted along with everything in
+// them, as by PermanentDeleteTeam.
+func (a *App) PurgeSoftDeleted(before int64) (int64, *model.AppError) {
+	var purged int64
+	for {
+		teamIDs, err := a.Srv().Store.Team().GetSoftDeletedIds(before, purgeSoftDeletedBatchSize)
+		if err != nil {
+			return purged, model.NewAppError("PurgeSoftDeleted", "app.team.get_soft_deleted.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, teamID := range teamIDs {
+			if appErr := a.PermanentDeleteTeamId(teamID); appErr != nil {
+				return purged, appErr
+			}
+			purged++
+		}
+
+		if len(teamIDs) < purgeSoftDeletedBatchSize {
+			break
+		}
+	}
+
+	for {
+		deleted, err := a.Srv().Store.Command().PermanentDeleteSoftDeletedBatch(before, purgeSoftDeletedBatchSize)
+		if err != nil {
+			return purged, model.NewAppError("PurgeSoftDeleted", "app.command.permanent_delete_soft_deleted.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		purged += deleted
+
+		if deleted < purgeSoftDeletedBatchSize {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestSoftDeleteTeamCascade(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	team := th.CreateTeam()
+	channel := th.CreateChannel(team)
+	th.LinkUserToTeam(th.BasicUser2, team)
+
+	require.Nil(t, th.App.SoftDeleteTeam(team.Id))
+
+	deletedChannel, appErr := th.App.GetChannel(channel.Id)
+	require.Nil(t, appErr)
+	assert.NotZero(t, deletedChannel.DeleteAt)
+
+	member, appErr := th.App.GetTeamMember(team.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+	assert.NotZero(t, member.DeleteAt)
+
+	require.Nil(t, th.App.RestoreTeam(team.Id))
+
+	restoredChannel, appErr := th.App.GetChannel(channel.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, restoredChannel.DeleteAt)
+
+	member, appErr = th.App.GetTeamMember(team.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, member.DeleteAt)
+}
+
+func TestPurgeSoftDeleted(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	team := th.CreateTeam()
+	th.CreateChannel(team)
+	require.Nil(t, th.App.SoftDeleteTeam(team.Id))
+
+	_, appErr := th.App.PurgeSoftDeleted(1)
+	require.Nil(t, appErr)
+	_, appErr = th.App.GetTeam(team.Id)
+	require.Nil(t, appErr, "teams deleted after the given time should be kept")
+
+	purged, appErr := th.App.PurgeSoftDeleted(model.GetMillis() + 1)
+	require.Nil(t, appErr)
+	assert.GreaterOrEqual(t, purged, int64(1))
+
+	_, appErr = th.App.GetTeam(team.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	_, appErr = th.App.GetTeam(th.BasicTeam.Id)
+	require.Nil(t, appErr)
+}
@@ -233,6 +233,9 @@ func (a *App) BroadcastStatus(status *model.Status) {
 		// this is considered a non-critical service and will be disabled when server busy.
 		return
 	}
+	if a.batchStatus(status) {
+		return
+	}
 	event := model.NewWebSocketEvent(model.WebsocketEventStatusChange, "", "", status.UserId, nil)
 	event.Add("status", status.Status)
 	event.Add("user_id", status.UserId)
@@ -518,3 +521,70 @@ func (a *App) RemoveRecentCustomStatus(userID string, status *model.CustomStatus
 
 	return nil
 }
+
+// GetDNDBypassList returns the people and keywords allowed to notify the user while they are in
+// do not disturb. Users that never saved a list get an empty one.
+func (a *App) GetDNDBypassList(userID string) (*model.DNDBypassList, *model.AppError) {
+	list := &model.DNDBypassList{
+		UserIds:  []string{},
+		Keywords: []string{},
+	}
+
+	pref, err := a.GetPreferenceByCategoryAndNameForUser(userID, model.PreferenceCategoryDoNotDisturb, model.PreferenceNameDNDBypass)
+	if err != nil || pref.Value == "" {
+		return list, nil
+	}
+
+	if jsonErr := json.Unmarshal([]byte(pref.Value), list); jsonErr != nil {
+		return nil, model.NewAppError("GetDNDBypassList", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	list.CompileKeywords()
+
+	return list, nil
+}
+
+func (a *App) UpdateDNDBypassList(userID string, list *model.DNDBypassList) (*model.DNDBypassList, *model.AppError) {
+	list.PreSave()
+	if err := list.IsValid(); err != nil {
+		return nil, err
+	}
+
+	listJSON, jsonErr := json.Marshal(list)
+	if jsonErr != nil {
+		return nil, model.NewAppError("UpdateDNDBypassList", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+
+	pref := model.Preference{
+		UserId:   userID,
+		Category: model.PreferenceCategoryDoNotDisturb,
+		Name:     model.PreferenceNameDNDBypass,
+		Value:    string(listJSON),
+	}
+	if err := a.UpdatePreferences(userID, model.Preferences{pref}); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// statusForNotification returns the status used to decide whether the user is notified of the
+// post. Posts matching the user's bypass list are delivered as if do not disturb was off.
+func (a *App) statusForNotification(status *model.Status, post *model.Post) *model.Status {
+	if status.Status != model.StatusDnd {
+		return status
+	}
+
+	list, err := a.GetDNDBypassList(status.UserId)
+	if err != nil {
+		mlog.Warn("Unable to get the do not disturb bypass list", mlog.String("user_id", status.UserId), mlog.Err(err))
+		return status
+	}
+
+	if !list.Matches(post) {
+		return status
+	}
+
+	bypassed := *status
+	bypassed.Status = model.StatusOffline
+	return &bypassed
+}
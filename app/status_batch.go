@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// statusBatcher collects the status changes of the users over an interval, for them to be
+// published as a single digest per team rather than one event per change.
+type statusBatcher struct {
+	mut     sync.Mutex
+	pending map[string]*model.Status
+	timer   *time.Timer
+	stopped bool
+}
+
+func newStatusBatcher() *statusBatcher {
+	return &statusBatcher{
+		pending: make(map[string]*model.Status),
+	}
+}
+
+func (b *statusBatcher) stop() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	b.stopped = true
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.pending = make(map[string]*model.Status)
+}
+
+// batchStatus records the status change of a user, to be published with the next digest. It
+// returns false when status changes aren't batched and the change must be published now.
+func (a *App) batchStatus(status *model.Status) bool {
+	interval := time.Duration(*a.Config().ServiceSettings.StatusBatchIntervalMilliseconds) * time.Millisecond
+	b := a.Srv().statusBatcher
+	if interval <= 0 || b == nil {
+		return false
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if b.stopped {
+		return false
+	}
+
+	// Only the latest status of a user within the interval is published.
+	b.pending[status.UserId] = status
+	if b.timer == nil {
+		b.timer = time.AfterFunc(interval, a.flushStatusBatch)
+	}
+
+	return true
+}
+
+// flushStatusBatch publishes the status changes collected since the last digest. Each team gets
+// the statuses of its members, and users who aren't member of any team get their own.
+func (a *App) flushStatusBatch() {
+	b := a.Srv().statusBatcher
+
+	b.mut.Lock()
+	pending := b.pending
+	b.pending = make(map[string]*model.Status)
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mut.Unlock()
+
+	if len(pending) == 0 || a.Srv().Busy.IsBusy() {
+		return
+	}
+
+	byTeam := map[string]map[string]string{}
+	for userID, status := range pending {
+		teamIDs, err := a.Srv().Store.Team().GetUserTeamIds(userID, true)
+		if err != nil {
+			mlog.Warn("Failed to get the teams of a user to publish their status", mlog.String("user_id", userID), mlog.Err(err))
+			continue
+		}
+
+		if len(teamIDs) == 0 {
+			a.publishStatusDigest("", userID, map[string]string{userID: status.Status})
+			continue
+		}
+
+		for _, teamID := range teamIDs {
+			if byTeam[teamID] == nil {
+				byTeam[teamID] = map[string]string{}
+			}
+			byTeam[teamID][userID] = status.Status
+		}
+	}
+
+	for teamID, statuses := range byTeam {
+		a.publishStatusDigest(teamID, "", statuses)
+	}
+}
+
+func (a *App) publishStatusDigest(teamID, userID string, statuses map[string]string) {
+	event := model.NewWebSocketEvent(model.WebsocketEventStatusesChanged, teamID, "", userID, nil)
+	event.Add("statuses", statuses)
+	a.Publish(event)
+}
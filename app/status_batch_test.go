@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestBatchStatus(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	status := &model.Status{UserId: th.BasicUser.Id, Status: model.StatusOnline}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.False(t, th.App.batchStatus(status))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.StatusBatchIntervalMilliseconds = 600000
+	})
+
+	t.Run("only the latest status of a user is kept", func(t *testing.T) {
+		require.True(t, th.App.batchStatus(status))
+		require.True(t, th.App.batchStatus(&model.Status{UserId: th.BasicUser.Id, Status: model.StatusAway}))
+		require.True(t, th.App.batchStatus(&model.Status{UserId: th.BasicUser2.Id, Status: model.StatusDnd}))
+
+		b := th.App.Srv().statusBatcher
+		b.mut.Lock()
+		defer b.mut.Unlock()
+		require.Len(t, b.pending, 2)
+		assert.Equal(t, model.StatusAway, b.pending[th.BasicUser.Id].Status)
+		assert.NotNil(t, b.timer)
+	})
+
+	t.Run("flushing empties the batch", func(t *testing.T) {
+		th.App.flushStatusBatch()
+
+		b := th.App.Srv().statusBatcher
+		b.mut.Lock()
+		defer b.mut.Unlock()
+		assert.Empty(t, b.pending)
+		assert.Nil(t, b.timer)
+	})
+
+	t.Run("nothing is batched once stopped", func(t *testing.T) {
+		th.App.Srv().statusBatcher.stop()
+		assert.False(t, th.App.batchStatus(status))
+	})
+}
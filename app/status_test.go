@@ -127,3 +127,38 @@ func TestCustomStatusErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusForNotification(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.BasicUser
+	_, err := th.App.UpdateDNDBypassList(user.Id, &model.DNDBypassList{
+		UserIds:  []string{th.BasicUser2.Id},
+		Keywords: []string{"outage"},
+	})
+	require.Nil(t, err)
+
+	dnd := &model.Status{UserId: user.Id, Status: model.StatusDnd}
+
+	t.Run("bypassed by sender", func(t *testing.T) {
+		status := th.App.statusForNotification(dnd, &model.Post{UserId: th.BasicUser2.Id, Message: "hello"})
+		require.Equal(t, model.StatusOffline, status.Status)
+		require.Equal(t, model.StatusDnd, dnd.Status)
+	})
+
+	t.Run("bypassed by keyword", func(t *testing.T) {
+		status := th.App.statusForNotification(dnd, &model.Post{UserId: model.NewId(), Message: "there is an outage"})
+		require.Equal(t, model.StatusOffline, status.Status)
+	})
+
+	t.Run("not bypassed", func(t *testing.T) {
+		status := th.App.statusForNotification(dnd, &model.Post{UserId: model.NewId(), Message: "hello"})
+		require.Equal(t, model.StatusDnd, status.Status)
+	})
+
+	t.Run("not in dnd", func(t *testing.T) {
+		away := &model.Status{UserId: user.Id, Status: model.StatusAway}
+		require.Equal(t, away, th.App.statusForNotification(away, &model.Post{UserId: th.BasicUser2.Id}))
+	})
+}
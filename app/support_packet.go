@@ -4,10 +4,12 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"runtime"
+	"runtime/pprof"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/v6/config"
@@ -16,7 +18,10 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-func (a *App) GenerateSupportPacket() []model.FileData {
+// Number of queries included in slow_queries.json when diagnostics are requested.
+const supportPacketSlowQueriesLimit = 50
+
+func (a *App) GenerateSupportPacket(options *model.SupportPacketOptions) []model.FileData {
 	// If any errors we come across within this function, we will log it in a warning.txt file so that we know why certain files did not get produced if any
 	var warnings []string
 
@@ -32,6 +37,18 @@ func (a *App) GenerateSupportPacket() []model.FileData {
 		a.getNotificationsLog,
 	}
 
+	// Diagnostics can be large and include runtime profiles, so they are only added when
+	// the admin explicitly asks for them.
+	if options != nil && options.IncludeDiagnostics {
+		functions = append(functions,
+			a.createTableRowCountsFile,
+			a.createCacheStatsFile,
+			a.createSlowQueriesFile,
+			a.createGoroutineProfile,
+			a.createHeapProfile,
+		)
+	}
+
 	for _, fn := range functions {
 		fileData, warning := fn()
 
@@ -204,3 +221,76 @@ func (a *App) createSanitizedConfigFile() (*model.FileData, string) {
 	warning := fmt.Sprintf("json.MarshalIndent(c.App.GetSanitizedConfig()) Error: %s", err.Error())
 	return nil, warning
 }
+
+func (a *App) createTableRowCountsFile() (*model.FileData, string) {
+	// Only table names and row counts are included, no row data.
+	counts, err := a.Srv().Store.GetTableRowCounts()
+	if err != nil {
+		return nil, fmt.Sprintf("a.Srv().Store.GetTableRowCounts() Error: %s", err.Error())
+	}
+
+	countsPrettyJSON, err := json.MarshalIndent(counts, "", "    ")
+	if err != nil {
+		return nil, fmt.Sprintf("json.MarshalIndent(counts) Error: %s", err.Error())
+	}
+
+	return &model.FileData{
+		Filename: "table_row_counts.json",
+		Body:     countsPrettyJSON,
+	}, ""
+}
+
+func (a *App) createCacheStatsFile() (*model.FileData, string) {
+	statsPrettyJSON, err := json.MarshalIndent(a.Srv().CacheProvider.Stats(), "", "    ")
+	if err != nil {
+		return nil, fmt.Sprintf("json.MarshalIndent(cacheStats) Error: %s", err.Error())
+	}
+
+	return &model.FileData{
+		Filename: "cache_stats.json",
+		Body:     statsPrettyJSON,
+	}, ""
+}
+
+func (a *App) createSlowQueriesFile() (*model.FileData, string) {
+	// The database reports normalized queries, with literal values replaced by placeholders.
+	queries, err := a.Srv().Store.GetSlowQueries(supportPacketSlowQueriesLimit)
+	if err != nil {
+		return nil, fmt.Sprintf("a.Srv().Store.GetSlowQueries() Error: %s", err.Error())
+	}
+
+	queriesPrettyJSON, err := json.MarshalIndent(queries, "", "    ")
+	if err != nil {
+		return nil, fmt.Sprintf("json.MarshalIndent(queries) Error: %s", err.Error())
+	}
+
+	return &model.FileData{
+		Filename: "slow_queries.json",
+		Body:     queriesPrettyJSON,
+	}, ""
+}
+
+func (a *App) createGoroutineProfile() (*model.FileData, string) {
+	return createProfile("goroutine", "goroutines.pprof")
+}
+
+func (a *App) createHeapProfile() (*model.FileData, string) {
+	return createProfile("heap", "heap.pprof")
+}
+
+func createProfile(name, filename string) (*model.FileData, string) {
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return nil, fmt.Sprintf("pprof.Lookup(%q) returned no profile", name)
+	}
+
+	var buf bytes.Buffer
+	if err := profile.WriteTo(&buf, 0); err != nil {
+		return nil, fmt.Sprintf("pprof.Lookup(%q).WriteTo() Error: %s", name, err.Error())
+	}
+
+	return &model.FileData{
+		Filename: filename,
+		Body:     buf.Bytes(),
+	}, ""
+}
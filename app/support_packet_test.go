@@ -66,7 +66,7 @@ func TestGenerateSupportPacket(t *testing.T) {
 	err = ioutil.WriteFile("notifications.log", d1, 0777)
 	require.NoError(t, err)
 
-	fileDatas := th.App.GenerateSupportPacket()
+	fileDatas := th.App.GenerateSupportPacket(nil)
 	testFiles := []string{"support_packet.yaml", "plugins.json", "sanitized_config.json", "mattermost.log", "notifications.log"}
 	for i, fileData := range fileDatas {
 		require.NotNil(t, fileData)
@@ -79,7 +79,7 @@ func TestGenerateSupportPacket(t *testing.T) {
 	require.NoError(t, err)
 	err = os.Remove("mattermost.log")
 	require.NoError(t, err)
-	fileDatas = th.App.GenerateSupportPacket()
+	fileDatas = th.App.GenerateSupportPacket(nil)
 	testFiles = []string{"support_packet.yaml", "plugins.json", "sanitized_config.json", "warning.txt"}
 	for i, fileData := range fileDatas {
 		require.NotNil(t, fileData)
@@ -88,6 +88,29 @@ func TestGenerateSupportPacket(t *testing.T) {
 	}
 }
 
+func TestGenerateSupportPacketWithDiagnostics(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	fileDatas := th.App.GenerateSupportPacket(&model.SupportPacketOptions{IncludeDiagnostics: true})
+
+	filenames := map[string]bool{}
+	for _, fileData := range fileDatas {
+		filenames[fileData.Filename] = true
+		assert.Positive(t, len(fileData.Body))
+	}
+
+	for _, filename := range []string{"table_row_counts.json", "cache_stats.json", "goroutines.pprof", "heap.pprof"} {
+		assert.True(t, filenames[filename], "missing %s", filename)
+	}
+
+	// Diagnostics are left out unless requested.
+	for _, fileData := range th.App.GenerateSupportPacket(&model.SupportPacketOptions{}) {
+		assert.NotEqual(t, "goroutines.pprof", fileData.Filename)
+		assert.NotEqual(t, "table_row_counts.json", fileData.Filename)
+	}
+}
+
 func TestGetNotificationsLog(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mail"
+)
+
+const (
+	// Postgres 10 is still supported but has reached end of life upstream.
+	recommendedPostgresVersion   = 110000
+	recommendedMySQLMajorVersion = 8
+
+	// Matches the LimitNOFILE value shipped in the sample systemd unit.
+	recommendedOpenFileLimit = 49152
+)
+
+// RunSystemCheckup evaluates the running configuration and environment against a set of
+// rules and returns everything that looks misconfigured, so admins don't have to work
+// through the usual troubleshooting checklist by hand.
+func (a *App) RunSystemCheckup() *model.SystemCheckup {
+	// All rules share the same signature so new ones only need adding here.
+	rules := []func() []*model.CheckupFinding{
+		a.checkupSiteURL,
+		a.checkupProxyHeaders,
+		a.checkupDatabaseVersion,
+		a.checkupOpenFileLimit,
+		a.checkupFileStorage,
+		a.checkupSMTP,
+	}
+
+	checkup := &model.SystemCheckup{
+		CreateAt: model.GetMillis(),
+		Findings: []*model.CheckupFinding{},
+	}
+	for _, rule := range rules {
+		checkup.Findings = append(checkup.Findings, rule()...)
+	}
+
+	return checkup
+}
+
+func (a *App) checkupSiteURL() []*model.CheckupFinding {
+	if *a.Config().ServiceSettings.SiteURL != "" {
+		return nil
+	}
+
+	return []*model.CheckupFinding{{
+		Id:        "site_url_not_set",
+		Category:  model.CheckupCategoryConfig,
+		Severity:  model.CheckupSeverityCritical,
+		MessageId: "app.system_checkup.site_url_not_set",
+	}}
+}
+
+func (a *App) checkupProxyHeaders() []*model.CheckupFinding {
+	headers := a.Config().ServiceSettings.TrustedProxyIPHeader
+	if len(headers) == 0 {
+		return []*model.CheckupFinding{{
+			Id:        "trusted_proxy_ip_header_not_set",
+			Category:  model.CheckupCategoryConfig,
+			Severity:  model.CheckupSeverityInfo,
+			MessageId: "app.system_checkup.trusted_proxy_ip_header_not_set",
+		}}
+	}
+
+	for _, header := range headers {
+		if strings.TrimSpace(header) == "" {
+			return []*model.CheckupFinding{{
+				Id:        "trusted_proxy_ip_header_empty",
+				Category:  model.CheckupCategoryConfig,
+				Severity:  model.CheckupSeverityWarning,
+				MessageId: "app.system_checkup.trusted_proxy_ip_header_empty",
+			}}
+		}
+	}
+
+	if *a.Config().RateLimitSettings.Enable && !*a.Config().RateLimitSettings.VaryByRemoteAddr && a.Config().RateLimitSettings.VaryByHeader == "" {
+		return []*model.CheckupFinding{{
+			Id:        "rate_limit_not_varied",
+			Category:  model.CheckupCategoryConfig,
+			Severity:  model.CheckupSeverityWarning,
+			MessageId: "app.system_checkup.rate_limit_not_varied",
+		}}
+	}
+
+	return nil
+}
+
+func (a *App) checkupDatabaseVersion() []*model.CheckupFinding {
+	driver := *a.Config().SqlSettings.DriverName
+	numerical := driver == model.DatabaseDriverPostgres
+
+	version, err := a.Srv().Store.GetDbVersion(numerical)
+	if err != nil {
+		return []*model.CheckupFinding{{
+			Id:        "database_version_unknown",
+			Category:  model.CheckupCategoryDatabase,
+			Severity:  model.CheckupSeverityWarning,
+			MessageId: "app.system_checkup.database_version_unknown",
+			Detail:    err.Error(),
+		}}
+	}
+
+	outdated := false
+	switch driver {
+	case model.DatabaseDriverPostgres:
+		intVer, err := strconv.Atoi(version)
+		outdated = err == nil && intVer < recommendedPostgresVersion
+	case model.DatabaseDriverMysql:
+		// MariaDB versions don't line up with MySQL ones, so they aren't checked.
+		if strings.Contains(strings.ToLower(version), "maria") {
+			return nil
+		}
+		majorVer, err := strconv.Atoi(strings.Split(version, ".")[0])
+		outdated = err == nil && majorVer < recommendedMySQLMajorVersion
+	}
+
+	if !outdated {
+		return nil
+	}
+
+	return []*model.CheckupFinding{{
+		Id:        "database_version_outdated",
+		Category:  model.CheckupCategoryDatabase,
+		Severity:  model.CheckupSeverityWarning,
+		MessageId: "app.system_checkup.database_version_outdated",
+		Detail:    fmt.Sprintf("%s %s", driver, version),
+	}}
+}
+
+func (a *App) checkupOpenFileLimit() []*model.CheckupFinding {
+	limit, err := getOpenFileLimit()
+	if err != nil {
+		return []*model.CheckupFinding{{
+			Id:        "open_file_limit_unknown",
+			Category:  model.CheckupCategoryEnvironment,
+			Severity:  model.CheckupSeverityInfo,
+			MessageId: "app.system_checkup.open_file_limit_unknown",
+			Detail:    err.Error(),
+		}}
+	}
+
+	if limit >= recommendedOpenFileLimit {
+		return nil
+	}
+
+	return []*model.CheckupFinding{{
+		Id:            "open_file_limit_low",
+		Category:      model.CheckupCategoryEnvironment,
+		Severity:      model.CheckupSeverityWarning,
+		MessageId:     "app.system_checkup.open_file_limit_low",
+		MessageParams: map[string]interface{}{"Recommended": recommendedOpenFileLimit},
+		Detail:        strconv.FormatUint(limit, 10),
+	}}
+}
+
+func (a *App) checkupFileStorage() []*model.CheckupFinding {
+	if appErr := a.TestFileStoreConnection(); appErr != nil {
+		return []*model.CheckupFinding{{
+			Id:            "file_storage_unreachable",
+			Category:      model.CheckupCategoryFileStorage,
+			Severity:      model.CheckupSeverityCritical,
+			MessageId:     "app.system_checkup.file_storage_unreachable",
+			MessageParams: map[string]interface{}{"Driver": *a.Config().FileSettings.DriverName},
+			Detail:        appErr.Error(),
+		}}
+	}
+
+	return nil
+}
+
+func (a *App) checkupSMTP() []*model.CheckupFinding {
+	if !*a.Config().EmailSettings.SendEmailNotifications {
+		return []*model.CheckupFinding{{
+			Id:        "email_notifications_disabled",
+			Category:  model.CheckupCategoryEmail,
+			Severity:  model.CheckupSeverityInfo,
+			MessageId: "app.system_checkup.email_notifications_disabled",
+		}}
+	}
+
+	if err := mail.TestConnection(a.Srv().MailServiceConfig()); err != nil {
+		return []*model.CheckupFinding{{
+			Id:        "smtp_unreachable",
+			Category:  model.CheckupCategoryEmail,
+			Severity:  model.CheckupSeverityCritical,
+			MessageId: "app.system_checkup.smtp_unreachable",
+			Detail:    err.Error(),
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+//go:build !linux
+// +build !linux
+
+package app
+
+import "errors"
+
+func getOpenFileLimit() (uint64, error) {
+	return 0, errors.New("open file limit is only available on linux")
+}
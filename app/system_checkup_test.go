@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func findCheckupFinding(checkup *model.SystemCheckup, id string) *model.CheckupFinding {
+	for _, f := range checkup.Findings {
+		if f.Id == id {
+			return f
+		}
+	}
+	return nil
+}
+
+func TestRunSystemCheckup(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("site url not set", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SiteURL = "" })
+
+		finding := findCheckupFinding(th.App.RunSystemCheckup(), "site_url_not_set")
+		require.NotNil(t, finding)
+		assert.Equal(t, model.CheckupSeverityCritical, finding.Severity)
+		assert.Equal(t, "app.system_checkup.site_url_not_set", finding.MessageId)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SiteURL = "http://localhost:8065" })
+		assert.Nil(t, findCheckupFinding(th.App.RunSystemCheckup(), "site_url_not_set"))
+	})
+
+	t.Run("proxy headers", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.TrustedProxyIPHeader = []string{} })
+		assert.NotNil(t, findCheckupFinding(th.App.RunSystemCheckup(), "trusted_proxy_ip_header_not_set"))
+
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.TrustedProxyIPHeader = []string{"X-Forwarded-For", " "} })
+		assert.NotNil(t, findCheckupFinding(th.App.RunSystemCheckup(), "trusted_proxy_ip_header_empty"))
+
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.TrustedProxyIPHeader = []string{"X-Forwarded-For"} })
+		checkup := th.App.RunSystemCheckup()
+		assert.Nil(t, findCheckupFinding(checkup, "trusted_proxy_ip_header_not_set"))
+		assert.Nil(t, findCheckupFinding(checkup, "trusted_proxy_ip_header_empty"))
+	})
+
+	t.Run("email notifications disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SendEmailNotifications = false })
+
+		checkup := th.App.RunSystemCheckup()
+		assert.NotNil(t, findCheckupFinding(checkup, "email_notifications_disabled"))
+		assert.Nil(t, findCheckupFinding(checkup, "smtp_unreachable"))
+	})
+
+	t.Run("file storage reachable", func(t *testing.T) {
+		assert.Nil(t, findCheckupFinding(th.App.RunSystemCheckup(), "file_storage_unreachable"))
+	})
+}
@@ -350,6 +350,57 @@ func (a *App) PatchTeam(teamID string, patch *model.TeamPatch) (*model.Team, *mo
 	return team, nil
 }
 
+// PatchTeamFeatures enables or disables the features of a team set in the patch.
+func (a *App) PatchTeamFeatures(teamID string, patch model.TeamFeaturesPatch) (*model.Team, *model.AppError) {
+	if err := patch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	team, err := a.GetTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	team.PatchFeatures(patch)
+
+	updatedTeam, nErr := a.Srv().Store.Team().Update(team)
+	if nErr != nil {
+		var invErr *store.ErrInvalidInput
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &invErr):
+			return nil, model.NewAppError("PatchTeamFeatures", "app.team.update.find.app_error", nil, invErr.Error(), http.StatusBadRequest)
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("PatchTeamFeatures", "app.team.update.updating.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.sendTeamEvent(updatedTeam, model.WebsocketEventUpdateTeam)
+
+	return updatedTeam, nil
+}
+
+// checkTeamFeature returns an error if the feature is disabled in the team. Every feature is
+// enabled outside of teams, in direct and group messages.
+func (a *App) checkTeamFeature(teamID, feature string) *model.AppError {
+	if teamID == "" {
+		return nil
+	}
+
+	team, err := a.GetTeam(teamID)
+	if err != nil {
+		return err
+	}
+
+	if !team.IsFeatureEnabled(feature) {
+		return model.NewAppError("checkTeamFeature", "app.team.feature_disabled.app_error", map[string]interface{}{"Feature": feature}, "team_id="+teamID, http.StatusForbidden)
+	}
+
+	return nil
+}
+
 func (a *App) RegenerateTeamInviteId(teamID string) (*model.Team, *model.AppError) {
 	team, err := a.GetTeam(teamID)
 	if err != nil {
@@ -448,6 +499,9 @@ func (a *App) UpdateTeamMemberRoles(teamID string, userID string, newRoles strin
 			err.StatusCode = http.StatusBadRequest
 			return nil, err
 		}
+		if !role.IsAssignableAt(model.RoleScopeTeam) {
+			return nil, model.NewAppError("UpdateTeamMemberRoles", "app.role.check_roles_assignable.invalid_scope.app_error", map[string]interface{}{"Scope": role.Scope}, "role_name="+roleName, http.StatusBadRequest)
+		}
 		if !role.SchemeManaged {
 			// The role is not scheme-managed, so it's OK to apply it to the explicit roles field.
 			newExplicitRoles = append(newExplicitRoles, roleName)
@@ -700,13 +754,6 @@ func (a *App) AddUserToTeamByToken(c *request.Context, userID string, tokenID st
 }
 
 func (a *App) AddUserToTeamByInviteId(c *request.Context, inviteId string, userID string) (*model.Team, *model.TeamMember, *model.AppError) {
-	tchan := make(chan store.StoreResult, 1)
-	go func() {
-		team, err := a.Srv().Store.Team().GetByInviteId(inviteId)
-		tchan <- store.StoreResult{Data: team, NErr: err}
-		close(tchan)
-	}()
-
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
 		user, err := a.Srv().Store.User().Get(context.Background(), userID)
@@ -714,19 +761,12 @@ func (a *App) AddUserToTeamByInviteId(c *request.Context, inviteId string, userI
 		close(uchan)
 	}()
 
-	result := <-tchan
-	if result.NErr != nil {
-		var nfErr *store.ErrNotFound
-		switch {
-		case errors.As(result.NErr, &nfErr):
-			return nil, nil, model.NewAppError("AddUserToTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, nfErr.Error(), http.StatusNotFound)
-		default:
-			return nil, nil, model.NewAppError("AddUserToTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, result.NErr.Error(), http.StatusInternalServerError)
-		}
+	team, link, appErr := a.getTeamByInviteId("AddUserToTeamByInviteId", inviteId)
+	if appErr != nil {
+		return nil, nil, appErr
 	}
-	team := result.Data.(*model.Team)
 
-	result = <-uchan
+	result := <-uchan
 	if result.NErr != nil {
 		var nfErr *store.ErrNotFound
 		switch {
@@ -738,8 +778,24 @@ func (a *App) AddUserToTeamByInviteId(c *request.Context, inviteId string, userI
 	}
 	user := result.Data.(*model.User)
 
+	// Members following the link again don't use it up.
+	if link != nil {
+		if teamMember, appErr := a.GetTeamMember(team.Id, user.Id); appErr == nil && teamMember.DeleteAt == 0 {
+			link = nil
+		}
+	}
+
+	if link != nil {
+		if appErr := a.useTeamInviteLink(link, user.Email); appErr != nil {
+			return nil, nil, appErr
+		}
+	}
+
 	teamMember, err := a.JoinUserToTeam(c, team, user, "")
 	if err != nil {
+		if link != nil {
+			a.releaseTeamInviteLink(link)
+		}
 		return nil, nil, err
 	}
 
@@ -872,15 +928,17 @@ func (a *App) GetTeamByName(name string) (*model.Team, *model.AppError) {
 	return team, nil
 }
 
+// GetTeamByInviteId returns the team an invite id lets users join, failing if the id is the one
+// of an invite link which can no longer be used.
 func (a *App) GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError) {
-	team, err := a.Srv().Store.Team().GetByInviteId(inviteId)
-	if err != nil {
-		var nfErr *store.ErrNotFound
-		switch {
-		case errors.As(err, &nfErr):
-			return nil, model.NewAppError("GetTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, nfErr.Error(), http.StatusNotFound)
-		default:
-			return nil, model.NewAppError("GetTeamByInviteId", "app.team.get_by_invite_id.finding.app_error", nil, err.Error(), http.StatusInternalServerError)
+	team, link, appErr := a.getTeamByInviteId("GetTeamByInviteId", inviteId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if link != nil {
+		if appErr := checkTeamInviteLink(link, model.GetMillis()); appErr != nil {
+			return nil, appErr
 		}
 	}
 
@@ -1781,52 +1839,45 @@ func (a *App) PermanentDeleteTeam(team *model.Team) *model.AppError {
 	return nil
 }
 
+// SoftDeleteTeam marks the team as deleted, along with its channels, members and commands.
 func (a *App) SoftDeleteTeam(teamID string) *model.AppError {
+	if _, err := a.GetTeam(teamID); err != nil {
+		return err
+	}
+
+	if err := a.Srv().Store.Team().SoftDelete(teamID, model.GetMillis()); err != nil {
+		return model.NewAppError("SoftDeleteTeam", "app.team.soft_delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	team, err := a.GetTeam(teamID)
 	if err != nil {
 		return err
 	}
 
-	team.DeleteAt = model.GetMillis()
-	team, nErr := a.Srv().Store.Team().Update(team)
-	if nErr != nil {
-		var invErr *store.ErrInvalidInput
-		var appErr *model.AppError
-		switch {
-		case errors.As(nErr, &invErr):
-			return model.NewAppError("SoftDeleteTeam", "app.team.update.find.app_error", nil, invErr.Error(), http.StatusBadRequest)
-		case errors.As(nErr, &appErr):
-			return appErr
-		default:
-			return model.NewAppError("SoftDeleteTeam", "app.team.update.updating.app_error", nil, nErr.Error(), http.StatusInternalServerError)
-		}
-	}
+	// The sessions hold the team memberships of their users, which were deleted with the team.
+	a.ClearSessionCacheForAllUsers()
 
 	a.sendTeamEvent(team, model.WebsocketEventDeleteTeam)
 
 	return nil
 }
 
+// RestoreTeam restores the team, along with the channels, members and commands deleted with it.
 func (a *App) RestoreTeam(teamID string) *model.AppError {
+	if _, err := a.GetTeam(teamID); err != nil {
+		return err
+	}
+
+	if err := a.Srv().Store.Team().Restore(teamID); err != nil {
+		return model.NewAppError("RestoreTeam", "app.team.restore.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	team, err := a.GetTeam(teamID)
 	if err != nil {
 		return err
 	}
 
-	team.DeleteAt = 0
-	team, nErr := a.Srv().Store.Team().Update(team)
-	if nErr != nil {
-		var invErr *store.ErrInvalidInput
-		var appErr *model.AppError
-		switch {
-		case errors.As(nErr, &invErr):
-			return model.NewAppError("RestoreTeam", "app.team.update.find.app_error", nil, invErr.Error(), http.StatusBadRequest)
-		case errors.As(nErr, &appErr):
-			return appErr
-		default:
-			return model.NewAppError("RestoreTeam", "app.team.update.updating.app_error", nil, nErr.Error(), http.StatusInternalServerError)
-		}
-	}
+	a.ClearSessionCacheForAllUsers()
 
 	a.sendTeamEvent(team, model.WebsocketEventRestoreTeam)
 	return nil
@@ -1888,12 +1939,17 @@ func (a *App) GetTeamIdFromQuery(query url.Values) (string, *model.AppError) {
 		return tokenData["teamId"], nil
 	}
 	if inviteId != "" {
-		team, err := a.Srv().Store.Team().GetByInviteId(inviteId)
-		if err == nil {
+		team, link, appErr := a.getTeamByInviteId("GetTeamIdFromQuery", inviteId)
+		if appErr == nil && link != nil {
+			// The email of the user is not known yet, so links restricted to some domains are
+			// refused, and the use is counted before the user is created.
+			appErr = a.useTeamInviteLink(link, "")
+		}
+		if appErr == nil {
 			return team.Id, nil
 		}
 		// soft fail, so we still create user but don't auto-join team
-		mlog.Warn("Error getting team by inviteId.", mlog.String("invite_id", inviteId), mlog.Err(err))
+		mlog.Warn("Error getting team by inviteId.", mlog.String("invite_id", inviteId), mlog.Err(appErr))
 	}
 
 	return "", nil
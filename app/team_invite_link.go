@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/users"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// CreateTeamInviteLink creates a link letting users join the team of the link.
+func (a *App) CreateTeamInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, *model.AppError) {
+	saved, err := a.Srv().Store.TeamInviteLink().Save(link)
+	if err != nil {
+		var invErr *model.AppError
+		if errors.As(err, &invErr) {
+			return nil, invErr
+		}
+		return nil, model.NewAppError("CreateTeamInviteLink", "app.team_invite_link.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return saved, nil
+}
+
+// GetTeamInviteLinks returns the invite links of a team, the newest first.
+func (a *App) GetTeamInviteLinks(teamID string, includeRevoked bool) ([]*model.TeamInviteLink, *model.AppError) {
+	links, err := a.Srv().Store.TeamInviteLink().GetForTeam(teamID, includeRevoked)
+	if err != nil {
+		return nil, model.NewAppError("GetTeamInviteLinks", "app.team_invite_link.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return links, nil
+}
+
+// GetTeamInviteLink returns an invite link, revoked or not.
+func (a *App) GetTeamInviteLink(linkID string) (*model.TeamInviteLink, *model.AppError) {
+	link, err := a.Srv().Store.TeamInviteLink().Get(linkID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetTeamInviteLink", "app.team_invite_link.not_found.app_error", nil, "id="+linkID, http.StatusNotFound)
+		}
+		return nil, model.NewAppError("GetTeamInviteLink", "app.team_invite_link.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return link, nil
+}
+
+// PatchTeamInviteLink changes the restrictions of an invite link, which applies to its next uses.
+func (a *App) PatchTeamInviteLink(link *model.TeamInviteLink, patch *model.TeamInviteLinkPatch) (*model.TeamInviteLink, *model.AppError) {
+	if link.DeleteAt != 0 {
+		return nil, model.NewAppError("PatchTeamInviteLink", "app.team_invite_link.revoked.app_error", nil, "id="+link.Id, http.StatusBadRequest)
+	}
+
+	link.Patch(patch)
+
+	return a.updateTeamInviteLink(link)
+}
+
+// RevokeTeamInviteLink stops an invite link from letting users join its team. The link is kept
+// with its counters.
+func (a *App) RevokeTeamInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, *model.AppError) {
+	if link.DeleteAt != 0 {
+		return link, nil
+	}
+
+	link.DeleteAt = model.GetMillis()
+
+	return a.updateTeamInviteLink(link)
+}
+
+func (a *App) updateTeamInviteLink(link *model.TeamInviteLink) (*model.TeamInviteLink, *model.AppError) {
+	updated, err := a.Srv().Store.TeamInviteLink().Update(link)
+	if err != nil {
+		var invErr *model.AppError
+		if errors.As(err, &invErr) {
+			return nil, invErr
+		}
+		return nil, model.NewAppError("updateTeamInviteLink", "app.team_invite_link.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return updated, nil
+}
+
+// getTeamByInviteId returns the team an invite id lets users join, along with the invite link of
+// that id. The link is nil when the id is the invite id of the team itself, which is still
+// accepted for the links shared before invite links existed.
+func (a *App) getTeamByInviteId(where, inviteId string) (*model.Team, *model.TeamInviteLink, *model.AppError) {
+	link, err := a.Srv().Store.TeamInviteLink().Get(inviteId)
+	if err == nil {
+		team, appErr := a.GetTeam(link.TeamId)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		return team, link, nil
+	}
+
+	var nfErr *store.ErrNotFound
+	if !errors.As(err, &nfErr) {
+		return nil, nil, model.NewAppError(where, "app.team_invite_link.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	team, err := a.Srv().Store.Team().GetByInviteId(inviteId)
+	if err != nil {
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, nil, model.NewAppError(where, "app.team.get_by_invite_id.finding.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, nil, model.NewAppError(where, "app.team.get_by_invite_id.finding.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return team, nil, nil
+}
+
+// checkTeamInviteLink returns why an invite link can't be used at the given time, if it can't.
+func checkTeamInviteLink(link *model.TeamInviteLink, now int64) *model.AppError {
+	switch {
+	case link.DeleteAt != 0:
+		return model.NewAppError("checkTeamInviteLink", "app.team_invite_link.revoked.app_error", nil, "id="+link.Id, http.StatusNotFound)
+	case link.IsExpired(now):
+		return model.NewAppError("checkTeamInviteLink", "app.team_invite_link.expired.app_error", nil, "id="+link.Id, http.StatusForbidden)
+	case link.IsExhausted():
+		return model.NewAppError("checkTeamInviteLink", "app.team_invite_link.exhausted.app_error", nil, "id="+link.Id, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// useTeamInviteLink counts a use of an invite link by the user with the given email, provided the
+// link can be used and allows the domain of the email. Refused uses are counted as well.
+func (a *App) useTeamInviteLink(link *model.TeamInviteLink, email string) *model.AppError {
+	now := model.GetMillis()
+	appErr := checkTeamInviteLink(link, now)
+	if appErr == nil && !users.CheckEmailDomain(email, link.AllowedDomains) {
+		appErr = model.NewAppError("useTeamInviteLink", "api.team.invite_members.invalid_email.app_error", map[string]interface{}{"Addresses": link.AllowedDomains}, "id="+link.Id, http.StatusForbidden)
+	}
+
+	if appErr == nil {
+		if err := a.Srv().Store.TeamInviteLink().Use(link.Id, now); err != nil {
+			var nfErr *store.ErrNotFound
+			if !errors.As(err, &nfErr) {
+				return model.NewAppError("useTeamInviteLink", "app.team_invite_link.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+			// The link was used up, or revoked, since it was read.
+			appErr = model.NewAppError("useTeamInviteLink", "app.team_invite_link.exhausted.app_error", nil, "id="+link.Id, http.StatusForbidden)
+		}
+	}
+
+	if appErr != nil {
+		if err := a.Srv().Store.TeamInviteLink().Reject(link.Id); err != nil {
+			mlog.Warn("Failed to count a refused use of a team invite link", mlog.String("invite_link_id", link.Id), mlog.Err(err))
+		}
+	}
+
+	return appErr
+}
+
+// releaseTeamInviteLink gives back a use of an invite link which did not let the user join the
+// team after all.
+func (a *App) releaseTeamInviteLink(link *model.TeamInviteLink) {
+	if err := a.Srv().Store.TeamInviteLink().Release(link.Id); err != nil {
+		mlog.Warn("Failed to release a use of a team invite link", mlog.String("invite_link_id", link.Id), mlog.Err(err))
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func (a *App) CreateTeamTemplate(template *model.TeamTemplate) (*model.TeamTemplate, *model.AppError) {
+	template.Id = ""
+	template.DeleteAt = 0
+
+	saved, err := a.Srv().Store.TeamTemplate().Save(template)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateTeamTemplate", "app.team_template.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetTeamTemplate(templateID string) (*model.TeamTemplate, *model.AppError) {
+	template, err := a.Srv().Store.TeamTemplate().Get(templateID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetTeamTemplate", "app.team_template.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("GetTeamTemplate", "app.team_template.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return template, nil
+}
+
+func (a *App) GetTeamTemplates(page, perPage int) ([]*model.TeamTemplate, *model.AppError) {
+	templates, err := a.Srv().Store.TeamTemplate().GetAll(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetTeamTemplates", "app.team_template.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return templates, nil
+}
+
+func (a *App) UpdateTeamTemplate(template *model.TeamTemplate) (*model.TeamTemplate, *model.AppError) {
+	oldTemplate, appErr := a.GetTeamTemplate(template.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	oldTemplate.DisplayName = template.DisplayName
+	oldTemplate.Description = template.Description
+	oldTemplate.Definition = template.Definition
+
+	updated, err := a.Srv().Store.TeamTemplate().Update(oldTemplate)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateTeamTemplate", "app.team_template.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("UpdateTeamTemplate", "app.team_template.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return updated, nil
+}
+
+func (a *App) DeleteTeamTemplate(templateID string) *model.AppError {
+	if err := a.Srv().Store.TeamTemplate().Delete(templateID, model.GetMillis()); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteTeamTemplate", "app.team_template.get.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return model.NewAppError("DeleteTeamTemplate", "app.team_template.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// ApplyTeamTemplate provisions the channels, bot members, incoming webhooks and sidebar categories
+// of the template in a newly created team on behalf of userID. Provisioning is best effort: an item
+// that cannot be created is logged and skipped so the team is still usable.
+func (a *App) ApplyTeamTemplate(c *request.Context, team *model.Team, template *model.TeamTemplate, userID string) {
+	logFailure := func(msg string, err *model.AppError, fields ...mlog.Field) {
+		fields = append(fields, mlog.String("team_id", team.Id), mlog.String("template_id", template.Id), mlog.Err(err))
+		mlog.Warn(msg, fields...)
+	}
+
+	channels := make(map[string]*model.Channel, len(template.Definition.Channels))
+	for _, tc := range template.Definition.Channels {
+		if existing, err := a.GetChannelByName(tc.Name, team.Id, false); err == nil {
+			channels[tc.Name] = existing
+			continue
+		}
+
+		channel, err := a.CreateChannel(c, &model.Channel{
+			TeamId:      team.Id,
+			Name:        tc.Name,
+			DisplayName: tc.DisplayName,
+			Type:        tc.Type,
+			Purpose:     tc.Purpose,
+			Header:      tc.Header,
+			CreatorId:   userID,
+		}, true)
+		if err != nil {
+			logFailure("Failed to create team template channel", err, mlog.String("channel_name", tc.Name))
+			continue
+		}
+		channels[tc.Name] = channel
+	}
+
+	for _, botUserID := range template.Definition.BotUserIds {
+		if _, err := a.GetBot(botUserID, false); err != nil {
+			logFailure("Failed to find team template bot", err, mlog.String("bot_user_id", botUserID))
+			continue
+		}
+
+		if _, err := a.AddTeamMember(c, team.Id, botUserID); err != nil {
+			logFailure("Failed to add team template bot to the team", err, mlog.String("bot_user_id", botUserID))
+			continue
+		}
+
+		for _, channel := range channels {
+			if _, err := a.AddChannelMember(c, botUserID, channel, ChannelMemberOpts{UserRequestorID: userID}); err != nil {
+				logFailure("Failed to add team template bot to a channel", err, mlog.String("bot_user_id", botUserID), mlog.String("channel_id", channel.Id))
+			}
+		}
+	}
+
+	if *a.Config().ServiceSettings.EnableIncomingWebhooks {
+		for _, hookDef := range template.Definition.IncomingWebhooks {
+			channel, ok := channels[hookDef.ChannelName]
+			if !ok {
+				continue
+			}
+
+			hook := &model.IncomingWebhook{
+				ChannelId:   channel.Id,
+				DisplayName: hookDef.DisplayName,
+				Description: hookDef.Description,
+				Username:    hookDef.Username,
+			}
+			if _, err := a.CreateIncomingWebhookForChannel(userID, channel, hook); err != nil {
+				logFailure("Failed to create team template incoming webhook", err, mlog.String("channel_id", channel.Id))
+			}
+		}
+	}
+
+	for _, tc := range template.Definition.Categories {
+		category := &model.SidebarCategoryWithChannels{
+			SidebarCategory: model.SidebarCategory{
+				UserId:      userID,
+				TeamId:      team.Id,
+				DisplayName: tc.DisplayName,
+				Type:        model.SidebarCategoryCustom,
+			},
+			Channels: []string{},
+		}
+		for _, name := range tc.ChannelNames {
+			if channel, ok := channels[name]; ok {
+				category.Channels = append(category.Channels, channel.Id)
+			}
+		}
+
+		if _, err := a.CreateSidebarCategory(userID, team.Id, category); err != nil {
+			logFailure("Failed to create team template sidebar category", err, mlog.String("category", tc.DisplayName))
+		}
+	}
+}
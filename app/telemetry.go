@@ -3,8 +3,31 @@
 
 package app
 
-import "github.com/mattermost/mattermost-server/v6/services/telemetry"
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/telemetry"
+)
 
 func (s *Server) GetTelemetryService() *telemetry.TelemetryService {
 	return s.telemetryService
 }
+
+// TrackClientTelemetryEvent forwards an event tracked by the client of a user to the self-hosted
+// diagnostics sink, whose key is kept on the server.
+func (a *App) TrackClientTelemetryEvent(userID string, event *model.TelemetryEvent) *model.AppError {
+	if appErr := event.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	if !*a.Config().LogSettings.EnableDiagnostics || *a.Config().LogSettings.DiagnosticsSinkURL == "" {
+		return model.NewAppError("TrackClientTelemetryEvent", "app.telemetry.sink_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if err := a.Srv().GetTelemetryService().TrackClientEvent(userID, event); err != nil {
+		return model.NewAppError("TrackClientTelemetryEvent", "app.telemetry.track_client_event.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// GenerateTurnCredentials returns credentials of the configured TURN servers for a user, valid
+// for TurnSettings.CredentialTTLSeconds.
+func (a *App) GenerateTurnCredentials(userID string) (*model.TurnCredentials, *model.AppError) {
+	settings := a.Config().TurnSettings
+	if !*settings.Enable {
+		return nil, model.NewAppError("GenerateTurnCredentials", "app.turn.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	ttl := int64(*settings.CredentialTTLSeconds)
+	expiresAt := model.GetMillis()/1000 + ttl
+	username := strconv.FormatInt(expiresAt, 10) + ":" + userID
+
+	return &model.TurnCredentials{
+		URLs:       settings.URIs,
+		Username:   username,
+		Credential: turnCredential(*settings.Secret, username),
+		TTL:        ttl,
+		ExpiresAt:  expiresAt * 1000,
+	}, nil
+}
+
+// turnCredential returns the password TURN servers expect for a username of the TURN REST API.
+func turnCredential(secret, username string) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(username))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestTurnCredential(t *testing.T) {
+	// base64(HMAC-SHA1("north", "1433906985:bob"))
+	assert.Equal(t, "wohZAhDefHvUj5W4+kiUrWUGHZk=", turnCredential("north", "1433906985:bob"))
+}
+
+func TestGenerateTurnCredentials(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	userID := model.NewId()
+
+	_, appErr := th.App.GenerateTurnCredentials(userID)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.turn.disabled.app_error", appErr.Id)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TurnSettings.Enable = true
+		cfg.TurnSettings.URIs = []string{"turn:turn.example.com:3478"}
+		*cfg.TurnSettings.Secret = "north"
+		*cfg.TurnSettings.CredentialTTLSeconds = 600
+	})
+
+	now := model.GetMillis() / 1000
+	credentials, appErr := th.App.GenerateTurnCredentials(userID)
+	require.Nil(t, appErr)
+	assert.Equal(t, []string{"turn:turn.example.com:3478"}, credentials.URLs)
+	assert.Equal(t, int64(600), credentials.TTL)
+
+	parts := strings.SplitN(credentials.Username, ":", 2)
+	require.Len(t, parts, 2)
+	assert.Equal(t, userID, parts[1])
+	expiresAt, err := strconv.ParseInt(parts[0], 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, now+600, expiresAt, 2)
+	assert.Equal(t, expiresAt*1000, credentials.ExpiresAt)
+	assert.Equal(t, turnCredential("north", credentials.Username), credentials.Credential)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// GetUnreadSummaryForUser returns the unread messages and mentions of a user in all of their
+// channels, direct and group messages included, and, when collapsed threads are enabled, in the
+// threads they follow. Channels and threads that are fully read are left out.
+func (a *App) GetUnreadSummaryForUser(userID string, includeCollapsedThreads bool) (*model.UnreadSummary, *model.AppError) {
+	channelUnreads, err := a.Srv().Store.Channel().GetChannelUnreadsForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetUnreadSummaryForUser", "app.channel.get_unread.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	summary := &model.UnreadSummary{
+		Channels: make([]*model.ChannelUnread, 0, len(channelUnreads)),
+		Threads:  []*model.ThreadUnread{},
+	}
+
+	for _, cu := range channelUnreads {
+		// Channels marked unread on mentions only don't count their messages as unread.
+		if cu.NotifyProps[model.MarkUnreadNotifyProp] == model.ChannelMarkUnreadMention {
+			if cu.MentionCount == 0 {
+				continue
+			}
+			cu.MsgCount = 0
+			cu.MsgCountRoot = 0
+		}
+		summary.Channels = append(summary.Channels, cu)
+	}
+
+	if includeCollapsedThreads && *a.Config().ServiceSettings.CollapsedThreads != model.CollapsedThreadsDisabled {
+		threadUnreads, err := a.Srv().Store.Thread().GetUnreadThreadsForUser(userID)
+		if err != nil {
+			return nil, model.NewAppError("GetUnreadSummaryForUser", "app.user.get_threads_for_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		summary.Threads = threadUnreads
+	}
+
+	return summary, nil
+}
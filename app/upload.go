@@ -127,6 +127,9 @@ func (a *App) CreateUploadSession(us *model.UploadSession) (*model.UploadSession
 			return nil, model.NewAppError("CreateUploadSession", "app.upload.create.cannot_upload_to_deleted_channel.app_error",
 				map[string]interface{}{"channelId": us.ChannelId}, "", http.StatusBadRequest)
 		}
+		if err := a.checkTeamUploadFeatures(channel.Id, us.Filename); err != nil {
+			return nil, err
+		}
 	}
 
 	us, storeErr := a.Srv().Store.UploadSession().Save(us)
@@ -306,6 +309,8 @@ func (a *App) UploadData(c *request.Context, us *model.UploadSession, rd io.Read
 		})
 	}
 
+	a.generateImageAltTextAsync(info)
+
 	// delete upload session
 	if storeErr := a.Srv().Store.UploadSession().Delete(us.Id); storeErr != nil {
 		mlog.Warn("Failed to delete UploadSession", mlog.Err(storeErr))
@@ -5,11 +5,111 @@ package app
 
 import (
 	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/utils"
 )
 
+// usageServiceWrapper lets embedded products report their usage counters, e.g.
+// model.UsageCounterBoardsCards, so that they are evaluated against the product limits
+// along with the ones of the other products.
+type usageServiceWrapper struct {
+	ch *Channels
+}
+
+// RegisterUsageReporter registers the function returning the usage counters of a product,
+// by counter name.
+func (s *usageServiceWrapper) RegisterUsageReporter(product string, getUsage func() (map[string]int64, error)) error {
+	return s.ch.registerUsageReporter(product, getUsage)
+}
+
+func (ch *Channels) registerUsageReporter(product string, getUsage func() (map[string]int64, error)) error {
+	ch.usageReportersLock.Lock()
+	defer ch.usageReportersLock.Unlock()
+
+	if _, ok := ch.usageReporters[product]; ok {
+		return errors.Errorf("a usage reporter is already registered for product %q", product)
+	}
+	ch.usageReporters[product] = getUsage
+
+	return nil
+}
+
+// getChannelsUsage returns the usage counters of channels.
+func (ch *Channels) getChannelsUsage() (map[string]int64, error) {
+	posts, err := ch.srv.Store.Post().AnalyticsPostCount(&model.PostCountOptions{ExcludeDeleted: true, UsersPostsOnly: true, AllowFromCache: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count posts")
+	}
+
+	integrations, appErr := ch.getIntegrationsUsage()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	teams, err := ch.srv.Store.Team().AnalyticsTeamCount(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count active teams")
+	}
+
+	return map[string]int64{
+		model.UsageCounterMessagesHistory:     posts,
+		model.UsageCounterIntegrationsEnabled: int64(integrations.Enabled),
+		model.UsageCounterTeamsActive:         teams,
+	}, nil
+}
+
+// GetUsage returns the usage counters reported by all the products, evaluated against the
+// limits of the Cloud workspace if any.
+func (a *App) GetUsage() (*model.Usage, *model.AppError) {
+	var limits *model.ProductLimits
+	if a.Cloud() != nil && a.Config().FeatureFlags.CloudFree {
+		var err error
+		limits, err = a.Cloud().GetCloudLimits("")
+		if err != nil {
+			return nil, model.NewAppError("GetUsage", "api.cloud.request_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	a.ch.usageReportersLock.RLock()
+	reporters := make(map[string]func() (map[string]int64, error), len(a.ch.usageReporters))
+	for product, getUsage := range a.ch.usageReporters {
+		reporters[product] = getUsage
+	}
+	a.ch.usageReportersLock.RUnlock()
+
+	usage := &model.Usage{Counters: []*model.UsageCounter{}}
+	for product, getUsage := range reporters {
+		counters, err := getUsage()
+		if err != nil {
+			return nil, model.NewAppError("GetUsage", "app.usage.get_product_usage.app_error", map[string]interface{}{"Product": product}, err.Error(), http.StatusInternalServerError)
+		}
+
+		for name, count := range counters {
+			counter := &model.UsageCounter{
+				Name:    name,
+				Product: product,
+				Count:   count,
+				Limit:   limits.Limit(name),
+			}
+			counter.Exceeded = counter.Limit != nil && count > *counter.Limit
+			usage.Counters = append(usage.Counters, counter)
+		}
+	}
+
+	sort.Slice(usage.Counters, func(i, j int) bool {
+		if usage.Counters[i].Name != usage.Counters[j].Name {
+			return usage.Counters[i].Name < usage.Counters[j].Name
+		}
+		return usage.Counters[i].Product < usage.Counters[j].Product
+	})
+
+	return usage, nil
+}
+
 // CheckFreemiumLimitsForConfigSave returns an error if the configuration being saved violates the Cloud Freemium limits
 func (a *App) CheckFreemiumLimitsForConfigSave(oldConfig, newConfig *model.Config) *model.AppError {
 	if !a.Config().FeatureFlags.CloudFree {
@@ -54,3 +154,17 @@ func (a *App) GetPostsUsage() (int64, *model.AppError) {
 
 	return utils.RoundOffToZeroes(float64(count)), nil
 }
+
+// GetTeamEmojiUsage returns the number of custom emoji scoped to the team and the team's quota
+func (a *App) GetTeamEmojiUsage(teamID string) (*model.EmojiUsage, *model.AppError) {
+	count, err := a.Srv().Store.Emoji().CountByTeam(teamID)
+	if err != nil {
+		return nil, model.NewAppError("GetTeamEmojiUsage", "app.emoji.count_by_team.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return &model.EmojiUsage{
+		TeamId: teamID,
+		Count:  count,
+		Limit:  *a.Config().ServiceSettings.MaxCustomEmojiPerTeam,
+	}, nil
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/store/storetest/mocks"
 )
 
@@ -47,3 +49,48 @@ func TestGetPostsUsage(t *testing.T) {
 		assert.Equal(t, expected, count)
 	})
 }
+
+func TestGetUsage(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	mockStore := th.App.Srv().Store.(*mocks.Store)
+	mockPostStore := mocks.PostStore{}
+	mockPostStore.On("AnalyticsPostCount", mock.Anything).Return(int64(4321), nil)
+	mockStore.On("Post").Return(&mockPostStore)
+	mockTeamStore := mocks.TeamStore{}
+	mockTeamStore.On("AnalyticsTeamCount", mock.Anything).Return(int64(2), nil)
+	mockStore.On("Team").Return(&mockTeamStore)
+
+	require.NoError(t, th.App.Channels().registerUsageReporter("boards", func() (map[string]int64, error) {
+		return map[string]int64{model.UsageCounterBoardsCards: 12}, nil
+	}))
+	require.Error(t, th.App.Channels().registerUsageReporter("boards", func() (map[string]int64, error) {
+		return nil, nil
+	}), "a product registers a single reporter")
+
+	usage, appErr := th.App.GetUsage()
+	require.Nil(t, appErr)
+
+	counts := map[string]int64{}
+	for _, counter := range usage.Counters {
+		counts[counter.Product+"/"+counter.Name] = counter.Count
+	}
+	assert.Equal(t, map[string]int64{
+		"boards/" + model.UsageCounterBoardsCards:           12,
+		"channels/" + model.UsageCounterIntegrationsEnabled: 0,
+		"channels/" + model.UsageCounterMessagesHistory:     4321,
+		"channels/" + model.UsageCounterTeamsActive:         2,
+	}, counts)
+	assert.Equal(t, model.UsageCounterBoardsCards, usage.Counters[0].Name)
+
+	t.Run("failing reporter", func(t *testing.T) {
+		require.NoError(t, th.App.Channels().registerUsageReporter("playbooks", func() (map[string]int64, error) {
+			return nil, errors.New("unavailable")
+		}))
+
+		_, appErr := th.App.GetUsage()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.usage.get_product_usage.app_error", appErr.Id)
+	})
+}
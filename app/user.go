@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -37,9 +38,16 @@ const (
 	TokenTypeTeamInvitation    = "team_invitation"
 	TokenTypeGuestInvitation   = "guest_invitation"
 	TokenTypeCWSAccess         = "cws_access_token"
+	TokenTypeDialogWizard      = "dialog_wizard"
+	TokenTypeImpersonation     = "impersonation_request"
+	TokenTypeRevokeSession     = "revoke_session"
 	PasswordRecoverExpiryTime  = 1000 * 60 * 60 * 24 // 24 hours
 	InvitationExpiryTime       = 1000 * 60 * 60 * 48 // 48 hours
 	ImageProfilePixelDimension = 128
+
+	// typingEventsCacheSize is the number of users typing in a channel tracked to coalesce their
+	// typing events.
+	typingEventsCacheSize = 50000
 )
 
 func (a *App) CreateUserWithToken(c *request.Context, user *model.User, token *model.Token) (*model.User, *model.AppError) {
@@ -120,15 +128,9 @@ func (a *App) CreateUserWithInviteId(c *request.Context, user *model.User, invit
 		return nil, err
 	}
 
-	team, nErr := a.Srv().Store.Team().GetByInviteId(inviteId)
-	if nErr != nil {
-		var nfErr *store.ErrNotFound
-		switch {
-		case errors.As(nErr, &nfErr):
-			return nil, model.NewAppError("CreateUserWithInviteId", "app.team.get_by_invite_id.finding.app_error", nil, nfErr.Error(), http.StatusNotFound)
-		default:
-			return nil, model.NewAppError("CreateUserWithInviteId", "app.team.get_by_invite_id.finding.app_error", nil, nErr.Error(), http.StatusInternalServerError)
-		}
+	team, link, appErr := a.getTeamByInviteId("CreateUserWithInviteId", inviteId)
+	if appErr != nil {
+		return nil, appErr
 	}
 
 	if team.IsGroupConstrained() {
@@ -139,14 +141,26 @@ func (a *App) CreateUserWithInviteId(c *request.Context, user *model.User, invit
 		return nil, model.NewAppError("CreateUserWithInviteId", "api.team.invite_members.invalid_email.app_error", map[string]interface{}{"Addresses": team.AllowedDomains}, "", http.StatusForbidden)
 	}
 
+	if link != nil {
+		if appErr := a.useTeamInviteLink(link, user.Email); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	user.EmailVerified = false
 
 	ruser, err := a.CreateUser(c, user)
 	if err != nil {
+		if link != nil {
+			a.releaseTeamInviteLink(link)
+		}
 		return nil, err
 	}
 
 	if _, err := a.JoinUserToTeam(c, team, ruser, ""); err != nil {
+		if link != nil {
+			a.releaseTeamInviteLink(link)
+		}
 		return nil, err
 	}
 
@@ -717,6 +731,10 @@ func (a *App) DeactivateMfa(userID string) *model.AppError {
 		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	if err := a.Srv().Store.MfaBackupCode().PermanentDeleteByUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	// Make sure old MFA status is not cached locally or in cluster nodes.
 	a.InvalidateCacheForUser(userID)
 
@@ -1462,6 +1480,10 @@ func (a *App) UpdateUserRolesWithUser(user *model.User, newRoles string, sendWeb
 		return nil, err
 	}
 
+	if err := a.checkRolesAssignableAt(strings.Fields(newRoles), model.RoleScopeSystem); err != nil {
+		return nil, err
+	}
+
 	user.Roles = newRoles
 	uchan := make(chan store.StoreResult, 1)
 	go func() {
@@ -1591,6 +1613,10 @@ func (a *App) PermanentDeleteUser(c *request.Context, user *model.User) *model.A
 			continue
 		}
 
+		if a.isSharedFile(info) {
+			continue
+		}
+
 		err = a.RemoveFile(info.Path)
 
 		if err != nil {
@@ -1618,6 +1644,14 @@ func (a *App) PermanentDeleteUser(c *request.Context, user *model.User) *model.A
 		return model.NewAppError("PermanentDeleteUser", "app.team.remove_member.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	if err := a.Srv().Store.UserDevice().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.user_device.permanent_delete_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.Srv().Store.MfaBackupCode().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.mfa_backup_code.permanent_delete_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
 	mlog.Warn("Permanently deleted account", mlog.String("user_email", user.Email), mlog.String("user_id", user.Id))
 
 	return nil
@@ -2220,7 +2254,28 @@ func (a *App) DemoteUserToGuest(user *model.User) *model.AppError {
 	return nil
 }
 
+// PublishUserTyping publishes a typing event for the user in the channel. Typing events of a
+// user in a channel are coalesced, only one of them being published per typing update interval,
+// and they aren't published at all in channels with more members than configured.
 func (a *App) PublishUserTyping(userID, channelID, parentId string) *model.AppError {
+	if maxMembers := *a.Config().ServiceSettings.TypingMessagesMaxChannelMembers; maxMembers > 0 {
+		count, appErr := a.GetChannelMemberCount(channelID)
+		if appErr != nil {
+			return appErr
+		}
+		if count > maxMembers {
+			return nil
+		}
+	}
+
+	key := userID + ":" + channelID + ":" + parentId
+	var lastTypingAt int64
+	if err := a.Srv().typingEventsCache.Get(key, &lastTypingAt); err == nil {
+		return nil
+	}
+	interval := time.Duration(*a.Config().ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds) * time.Millisecond
+	a.Srv().typingEventsCache.SetWithExpiry(key, model.GetMillis(), interval)
+
 	omitUsers := make(map[string]bool, 1)
 	omitUsers[userID] = true
 
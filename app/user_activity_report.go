@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// userActivityAuditBatchSize is the number of audit records read at once for a report.
+const userActivityAuditBatchSize = 1000
+
+// fileDownloadAuditActionRegexp matches the action of the audit records of the file downloads.
+var fileDownloadAuditActionRegexp = regexp.MustCompile(`/api/v4/files/([a-z0-9]{26})$`)
+
+// GetUserActivityReport builds the access report of the user over the period between since and
+// until: their sessions, the channels they were a member of, the files they downloaded, and the
+// audited actions made by them or on their account.
+func (a *App) GetUserActivityReport(userID string, since, until int64) (*model.UserActivityReport, *model.AppError) {
+	if since > until || until-since > model.UserActivityReportMaxPeriod {
+		return nil, model.NewAppError("GetUserActivityReport", "app.user_activity_report.invalid_period.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	report := &model.UserActivityReport{
+		UserId:        user.Id,
+		Username:      user.Username,
+		Email:         user.Email,
+		Since:         since,
+		Until:         until,
+		GeneratedAt:   model.GetMillis(),
+		Sessions:      []*model.Session{},
+		Channels:      []*model.UserActivityChannel{},
+		FileDownloads: []*model.UserActivityFileDownload{},
+		Actions:       model.Audits{},
+		AdminActions:  model.Audits{},
+	}
+
+	sessions, err := a.Srv().Store.Session().GetSessions(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetUserActivityReport", "app.session.get_sessions.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, session := range sessions {
+		if session.CreateAt <= until && session.LastActivityAt >= since {
+			session.Sanitize()
+			report.Sessions = append(report.Sessions, session)
+		}
+	}
+
+	if appErr := a.addUserActivityChannels(report); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.addUserActivityAudits(report); appErr != nil {
+		return nil, appErr
+	}
+
+	return report, nil
+}
+
+func (a *App) addUserActivityChannels(report *model.UserActivityReport) *model.AppError {
+	histories, err := a.Srv().Store.ChannelMemberHistory().GetChannelsForUserDuring(report.UserId, report.Since, report.Until)
+	if err != nil {
+		return model.NewAppError("GetUserActivityReport", "app.user_activity_report.get_channels.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if len(histories) == 0 {
+		return nil
+	}
+
+	channelIDs := make([]string, 0, len(histories))
+	for _, history := range histories {
+		channelIDs = append(channelIDs, history.ChannelId)
+	}
+
+	channels := map[string]*model.Channel{}
+	channelList, err := a.Srv().Store.Channel().GetMany(channelIDs, true)
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return model.NewAppError("GetUserActivityReport", "app.user_activity_report.get_channels.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, channel := range channelList {
+		channels[channel.Id] = channel
+	}
+
+	lastViewedAt := map[string]int64{}
+	members, err := a.Srv().Store.Channel().GetMembersByChannelIds(channelIDs, report.UserId)
+	if err != nil {
+		return model.NewAppError("GetUserActivityReport", "app.user_activity_report.get_channels.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, member := range members {
+		lastViewedAt[member.ChannelId] = member.LastViewedAt
+	}
+
+	for _, history := range histories {
+		activity := &model.UserActivityChannel{
+			ChannelId: history.ChannelId,
+			JoinTime:  history.JoinTime,
+			LeaveTime: history.LeaveTime,
+		}
+		if history.LeaveTime == nil {
+			activity.LastViewedAt = lastViewedAt[history.ChannelId]
+		}
+		if channel, ok := channels[history.ChannelId]; ok {
+			activity.TeamId = channel.TeamId
+			activity.Name = channel.Name
+			activity.DisplayName = channel.DisplayName
+			activity.Type = channel.Type
+		}
+		report.Channels = append(report.Channels, activity)
+	}
+
+	return nil
+}
+
+func (a *App) addUserActivityAudits(report *model.UserActivityReport) *model.AppError {
+	var downloads model.Audits
+
+	count := 0
+	for offset := 0; ; offset += userActivityAuditBatchSize {
+		audits, err := a.Srv().Store.Audit().Get(report.UserId, offset, userActivityAuditBatchSize)
+		if err != nil {
+			return model.NewAppError("GetUserActivityReport", "app.audit.get.finding.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		// The audit records are sorted from the newest to the oldest.
+		for _, audit := range audits {
+			if audit.CreateAt > report.Until {
+				continue
+			}
+			if audit.CreateAt < report.Since {
+				return a.addUserActivityFileDownloads(report, downloads)
+			}
+
+			if count == model.UserActivityReportMaxAudits {
+				report.Truncated = true
+				return a.addUserActivityFileDownloads(report, downloads)
+			}
+			count++
+
+			switch {
+			case isAuditByOtherUser(audit, report.UserId):
+				report.AdminActions = append(report.AdminActions, audit)
+			case fileDownloadAuditActionRegexp.MatchString(audit.Action):
+				downloads = append(downloads, audit)
+			default:
+				report.Actions = append(report.Actions, audit)
+			}
+		}
+
+		if len(audits) < userActivityAuditBatchSize {
+			return a.addUserActivityFileDownloads(report, downloads)
+		}
+	}
+}
+
+func (a *App) addUserActivityFileDownloads(report *model.UserActivityReport, downloads model.Audits) *model.AppError {
+	if len(downloads) == 0 {
+		return nil
+	}
+
+	fileIDs := make([]string, 0, len(downloads))
+	for _, audit := range downloads {
+		fileIDs = append(fileIDs, fileDownloadAuditActionRegexp.FindStringSubmatch(audit.Action)[1])
+	}
+
+	files := map[string]*model.FileInfo{}
+	infos, err := a.Srv().Store.FileInfo().GetByIds(fileIDs)
+	if err != nil {
+		return model.NewAppError("GetUserActivityReport", "app.file_info.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	for _, info := range infos {
+		files[info.Id] = info
+	}
+
+	for i, audit := range downloads {
+		download := &model.UserActivityFileDownload{
+			FileId:     fileIDs[i],
+			DownloadAt: audit.CreateAt,
+			IpAddress:  audit.IpAddress,
+			SessionId:  audit.SessionId,
+		}
+		if info, ok := files[download.FileId]; ok {
+			download.Name = info.Name
+			download.ChannelId = info.ChannelId
+			download.PostId = info.PostId
+		}
+		report.FileDownloads = append(report.FileDownloads, download)
+	}
+
+	return nil
+}
+
+// isAuditByOtherUser returns whether the audit record of the user was made by another user, such
+// as a system admin updating their account.
+func isAuditByOtherUser(audit model.Audit, userID string) bool {
+	for _, field := range strings.Fields(audit.ExtraInfo) {
+		if sessionUserID := strings.TrimPrefix(field, "session_user="); sessionUserID != field {
+			return sessionUserID != userID
+		}
+	}
+	return false
+}
@@ -0,0 +1,319 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// userDataExportPostsPageSize is the number of posts read at once when exporting the personal
+// data of a user.
+const userDataExportPostsPageSize = 1000
+
+// CreateUserDeletionJob schedules a job exporting the personal data of a user then deleting the
+// user according to the policy of the request.
+func (a *App) CreateUserDeletionJob(userID string, req *model.UserDeletionRequest, requesterID string) (*model.Job, *model.AppError) {
+	if appErr := a.checkUserDeletionPolicy(req.Policy); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, appErr := a.GetUser(userID); appErr != nil {
+		return nil, appErr
+	}
+
+	for _, status := range []string{model.JobStatusPending, model.JobStatusInProgress} {
+		jobs, appErr := a.Srv().Jobs.GetJobsByTypeAndStatus(model.JobTypeUserDeletion, status)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, job := range jobs {
+			if job.Data["user_id"] == userID {
+				return nil, model.NewAppError("CreateUserDeletionJob", "app.user_deletion.already_scheduled.app_error", nil, "user_id="+userID+" job_id="+job.Id, http.StatusBadRequest)
+			}
+		}
+	}
+
+	return a.Srv().Jobs.CreateJob(model.JobTypeUserDeletion, map[string]string{
+		"user_id":      userID,
+		"policy":       req.Policy,
+		"requester_id": requesterID,
+	})
+}
+
+// GetUserDeletionReport returns the progress of a user deletion job.
+func (a *App) GetUserDeletionReport(jobID string) (*model.UserDeletionReport, *model.AppError) {
+	job, err := a.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JobTypeUserDeletion {
+		return nil, model.NewAppError("GetUserDeletionReport", "app.user_deletion.report.not_found.app_error", nil, "job_id="+jobID, http.StatusNotFound)
+	}
+
+	return &model.UserDeletionReport{
+		JobId:      job.Id,
+		UserId:     job.Data["user_id"],
+		Policy:     job.Data["policy"],
+		Status:     job.Status,
+		Stage:      job.Data["stage"],
+		Progress:   job.Progress,
+		ExportName: job.Data["export_name"],
+		Error:      job.Data["error"],
+	}, nil
+}
+
+// checkUserDeletionPolicy fails if the policy removes the data of the user while permanently
+// deleting users through the API is disabled.
+func (a *App) checkUserDeletionPolicy(policy string) *model.AppError {
+	if policy == model.UserDeletionPolicyRemove && !*a.Config().ServiceSettings.EnableAPIUserDeletion {
+		return model.NewAppError("checkUserDeletionPolicy", "api.user.delete_user.not_enabled.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	return nil
+}
+
+// DeleteUserWithPolicy anonymizes or permanently deletes a user, the last stage of a user deletion
+// job.
+func (a *App) DeleteUserWithPolicy(c *request.Context, userID, policy string) *model.AppError {
+	if appErr := a.checkUserDeletionPolicy(policy); appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	switch policy {
+	case model.UserDeletionPolicyAnonymize:
+		return a.AnonymizeUser(c, user)
+	case model.UserDeletionPolicyRemove:
+		return a.PermanentDeleteUser(c, user)
+	}
+
+	return model.NewAppError("DeleteUserWithPolicy", "model.user_deletion.is_valid.policy.app_error", nil, "policy="+policy, http.StatusBadRequest)
+}
+
+// AnonymizeUser deactivates a user and strips the account of its profile, credentials and
+// settings, keeping its posts and files where they are.
+func (a *App) AnonymizeUser(c *request.Context, user *model.User) *model.AppError {
+	if user.DeleteAt == 0 {
+		if _, appErr := a.UpdateActive(c, user, false); appErr != nil {
+			return appErr
+		}
+	}
+
+	if err := a.Srv().Store.Session().PermanentDeleteSessionsByUser(user.Id); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.session.permanent_delete_sessions_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.Srv().Store.UserAccessToken().DeleteAllForUser(user.Id); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.user_access_token.delete.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.Srv().Store.OAuth().PermanentDeleteAuthDataByUser(user.Id); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.oauth.permanent_delete_auth_data_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.Srv().Store.Preference().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.preference.permanent_delete_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.Srv().Store.UserDevice().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.user_device.permanent_delete_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if err := a.Srv().Store.MfaBackupCode().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.mfa_backup_code.permanent_delete_by_user.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// Clears the password, the external authentication and MFA along with the email.
+	email := user.Id + "@deleted.invalid"
+	if _, err := a.Srv().Store.User().UpdateAuthData(user.Id, "", nil, email, true); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.user_deletion.anonymize.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	anonymized, err := a.Srv().Store.User().Get(context.Background(), user.Id)
+	if err != nil {
+		return model.NewAppError("AnonymizeUser", "app.user.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	anonymized.Username = "deleted-" + user.Id
+	anonymized.Nickname = ""
+	anonymized.FirstName = ""
+	anonymized.LastName = ""
+	anonymized.Position = ""
+	anonymized.Roles = model.SystemUserRoleId
+	anonymized.AllowMarketing = false
+	anonymized.Props = model.StringMap{}
+	anonymized.SetDefaultNotifications()
+	if _, err := a.Srv().Store.User().Update(anonymized, true); err != nil {
+		return model.NewAppError("AnonymizeUser", "app.user_deletion.anonymize.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.InvalidateCacheForUser(user.Id)
+
+	if appErr := a.SetDefaultProfileImage(anonymized); appErr != nil {
+		mlog.Warn("Failed to reset the profile image of an anonymized user", mlog.String("user_id", user.Id), mlog.Err(appErr))
+	}
+
+	mlog.Warn("Anonymized account", mlog.String("user_id", user.Id))
+
+	return nil
+}
+
+// WriteUserPersonalDataExport writes the export of the personal data of a user to the exports
+// directory, returning the name of the export.
+func (a *App) WriteUserPersonalDataExport(userID, jobID string) (string, *model.AppError) {
+	name := fmt.Sprintf("%s_%s_personal_data.zip", userID, jobID)
+
+	rd, wr := io.Pipe()
+
+	errCh := make(chan *model.AppError, 1)
+	go func() {
+		defer close(errCh)
+		_, appErr := a.WriteFile(rd, filepath.Join(*a.Config().ExportSettings.Directory, name))
+		errCh <- appErr
+	}()
+
+	appErr := a.ExportUserPersonalData(userID, wr)
+	if appErr != nil {
+		wr.CloseWithError(appErr)
+		<-errCh
+		return "", appErr
+	}
+	if err := wr.Close(); err != nil {
+		mlog.Warn("Error closing the writer of a personal data export", mlog.Err(err))
+	}
+
+	if appErr := <-errCh; appErr != nil {
+		return "", appErr
+	}
+
+	return name, nil
+}
+
+// ExportUserPersonalData writes a zip archive holding the profile of a user, the posts they
+// authored and the files they uploaded.
+func (a *App) ExportUserPersonalData(userID string, w io.Writer) *model.AppError {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	zipWr := zip.NewWriter(w)
+
+	user.Sanitize(map[string]bool{"email": true, "fullname": true, "passwordupdate": true, "authservice": true})
+	if appErr := userDataExportWriteJSON(zipWr, "profile.json", user); appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.exportUserPosts(zipWr, userID); appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.exportUserFiles(zipWr, userID); appErr != nil {
+		return appErr
+	}
+
+	if err := zipWr.Close(); err != nil {
+		return model.NewAppError("ExportUserPersonalData", "app.export.zip_create.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// exportUserPosts writes posts.jsonl, holding the posts of the user one per line, the oldest
+// first.
+func (a *App) exportUserPosts(zipWr *zip.Writer, userID string) *model.AppError {
+	wr, err := zipWr.Create("posts.jsonl")
+	if err != nil {
+		return model.NewAppError("exportUserPosts", "app.export.zip_create.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+	encoder := json.NewEncoder(wr)
+
+	var afterCreateAt int64
+	afterID := ""
+	for {
+		posts, err := a.Srv().Store.Post().GetUserPostsForExportAfter(userID, afterCreateAt, afterID, userDataExportPostsPageSize)
+		if err != nil {
+			return model.NewAppError("exportUserPosts", "app.post.get_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		for _, post := range posts {
+			afterCreateAt = post.CreateAt
+			afterID = post.Id
+
+			if err := encoder.Encode(post); err != nil {
+				return model.NewAppError("exportUserPosts", "app.export.export_write_line.io_writer.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+			}
+		}
+
+		if len(posts) < userDataExportPostsPageSize {
+			return nil
+		}
+	}
+}
+
+// exportUserFiles writes files.json, holding the information of the files of the user, and the
+// files themselves under files/. Files missing from the file store are skipped.
+func (a *App) exportUserFiles(zipWr *zip.Writer, userID string) *model.AppError {
+	infos, err := a.Srv().Store.FileInfo().GetForUser(userID)
+	if err != nil {
+		return model.NewAppError("exportUserFiles", "app.file_info.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if appErr := userDataExportWriteJSON(zipWr, "files.json", infos); appErr != nil {
+		return appErr
+	}
+
+	for _, info := range infos {
+		rd, appErr := a.FileReader(info.Path)
+		if appErr != nil {
+			mlog.Warn("Failed to read a file of a personal data export", mlog.String("path", info.Path), mlog.Err(appErr))
+			continue
+		}
+
+		wr, err := zipWr.CreateHeader(&zip.FileHeader{
+			Name:   path.Join("files", info.Id, info.Name),
+			Method: zip.Store,
+		})
+		if err == nil {
+			_, err = io.Copy(wr, rd)
+		}
+		rd.Close()
+		if err != nil {
+			return model.NewAppError("exportUserFiles", "app.export.export_attachment.copy_file.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+func userDataExportWriteJSON(zipWr *zip.Writer, name string, v interface{}) *model.AppError {
+	wr, err := zipWr.Create(name)
+	if err != nil {
+		return model.NewAppError("ExportUserPersonalData", "app.export.zip_create.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	encoder := json.NewEncoder(wr)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(v); err != nil {
+		return model.NewAppError("ExportUserPersonalData", "app.export.export_write_line.io_writer.error", nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestExportUserPersonalData(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+	th.AddUserToChannel(user, th.BasicChannel)
+
+	post, appErr := th.App.CreatePost(th.Context, &model.Post{UserId: user.Id, ChannelId: th.BasicChannel.Id, Message: "mine"}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+	_, appErr = th.App.CreatePost(th.Context, &model.Post{UserId: th.BasicUser.Id, ChannelId: th.BasicChannel.Id, Message: "not mine"}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	info, appErr := th.App.UploadFile(th.Context, []byte("file contents"), th.BasicChannel.Id, "notes.txt")
+	require.Nil(t, appErr)
+	info.CreatorId = user.Id
+	_, err := th.App.Srv().Store.FileInfo().Upsert(info)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.Nil(t, th.App.ExportUserPersonalData(user.Id, &buf))
+
+	zipRd, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	contents := map[string]string{}
+	for _, file := range zipRd.File {
+		rd, err := file.Open()
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(rd)
+		require.NoError(t, err)
+		rd.Close()
+		contents[file.Name] = string(data)
+	}
+
+	var profile model.User
+	require.NoError(t, json.Unmarshal([]byte(contents["profile.json"]), &profile))
+	assert.Equal(t, user.Id, profile.Id)
+	assert.Equal(t, user.Email, profile.Email)
+	assert.Empty(t, profile.Password)
+
+	lines := strings.Split(strings.TrimSpace(contents["posts.jsonl"]), "\n")
+	require.Len(t, lines, 1)
+	var exported model.Post
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &exported))
+	assert.Equal(t, post.Id, exported.Id)
+
+	assert.Contains(t, contents["files.json"], info.Id)
+	assert.Equal(t, "file contents", contents["files/"+info.Id+"/notes.txt"])
+}
+
+func TestAnonymizeUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+	th.AddUserToChannel(user, th.BasicChannel)
+
+	post, appErr := th.App.CreatePost(th.Context, &model.Post{UserId: user.Id, ChannelId: th.BasicChannel.Id, Message: "kept"}, th.BasicChannel, false, true)
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.CreateSession(&model.Session{UserId: user.Id})
+	require.Nil(t, appErr)
+
+	require.Nil(t, th.App.DeleteUserWithPolicy(th.Context, user.Id, model.UserDeletionPolicyAnonymize))
+
+	anonymized, appErr := th.App.GetUser(user.Id)
+	require.Nil(t, appErr)
+	assert.NotZero(t, anonymized.DeleteAt)
+	assert.Equal(t, "deleted-"+user.Id, anonymized.Username)
+	assert.Equal(t, user.Id+"@deleted.invalid", anonymized.Email)
+	assert.Empty(t, anonymized.FirstName)
+	assert.Empty(t, anonymized.LastName)
+	assert.Empty(t, anonymized.Nickname)
+
+	sessions, appErr := th.App.GetSessions(user.Id)
+	require.Nil(t, appErr)
+	assert.Empty(t, sessions)
+
+	kept, appErr := th.App.GetSinglePost(post.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, user.Id, kept.UserId)
+}
+
+func TestDeleteUserWithPolicyRemove(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableAPIUserDeletion = false })
+	appErr := th.App.DeleteUserWithPolicy(th.Context, user.Id, model.UserDeletionPolicyRemove)
+	require.NotNil(t, appErr, "removing users requires the API user deletion to be enabled")
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableAPIUserDeletion = true })
+	require.Nil(t, th.App.DeleteUserWithPolicy(th.Context, user.Id, model.UserDeletionPolicyRemove))
+
+	_, appErr = th.App.GetUser(user.Id)
+	require.NotNil(t, appErr)
+}
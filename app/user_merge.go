@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// CreateUserMergeJob checks that the source user of the request can be merged into its target
+// user and schedules a job doing so.
+func (a *App) CreateUserMergeJob(req *model.UserMergeRequest, requesterID string) (*model.Job, *model.AppError) {
+	if _, _, err := a.getUsersToMerge(req.SourceUserId, req.TargetUserId); err != nil {
+		return nil, err
+	}
+
+	return a.Srv().Jobs.CreateJob(model.JobTypeUserMerge, map[string]string{
+		"source_user_id": req.SourceUserId,
+		"target_user_id": req.TargetUserId,
+		"requester_id":   requesterID,
+	})
+}
+
+// GetUserMergeReport returns the progress of a user merge job and, once it is done, what it
+// merged.
+func (a *App) GetUserMergeReport(jobID string) (*model.UserMergeReport, *model.AppError) {
+	job, err := a.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JobTypeUserMerge {
+		return nil, model.NewAppError("GetUserMergeReport", "app.user_merge.report.not_found.app_error", nil, "job_id="+jobID, http.StatusNotFound)
+	}
+
+	report := &model.UserMergeReport{
+		JobId:        job.Id,
+		SourceUserId: job.Data["source_user_id"],
+		TargetUserId: job.Data["target_user_id"],
+		Status:       job.Status,
+		Progress:     job.Progress,
+		Error:        job.Data["error"],
+	}
+	report.RevokedSessions, _ = strconv.Atoi(job.Data["revoked_sessions"])
+
+	if counts := job.Data["counts"]; counts != "" {
+		if jsonErr := json.Unmarshal([]byte(counts), &report.Counts); jsonErr != nil {
+			return nil, model.NewAppError("GetUserMergeReport", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return report, nil
+}
+
+// MergeUsers re-attributes the posts, files, reactions, memberships and preferences of the
+// source user to the target user, then revokes the sessions of the source user and deactivates
+// it, recording which user it was merged into. It returns what was merged and the number of
+// sessions revoked.
+func (a *App) MergeUsers(c *request.Context, sourceUserID, targetUserID string) (*model.UserMergeCounts, int, *model.AppError) {
+	source, target, appErr := a.getUsersToMerge(sourceUserID, targetUserID)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	sessions, appErr := a.GetSessions(source.Id)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	counts, err := a.Srv().Store.User().MergeInto(source.Id, target.Id)
+	if err != nil {
+		return nil, 0, model.NewAppError("MergeUsers", "app.user_merge.merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.Srv().Store.Channel().ClearCaches()
+	a.Srv().Store.Team().ClearCaches()
+	a.Srv().Store.Post().ClearCaches()
+	a.Srv().Store.FileInfo().ClearCaches()
+	a.invalidateCacheForUserTeams(source.Id)
+	a.invalidateCacheForUserTeams(target.Id)
+	a.InvalidateCacheForUser(target.Id)
+
+	source.SetProp(model.UserPropMergedInto, target.Id)
+	if _, appErr := a.UpdateActive(c, source, false); appErr != nil {
+		return nil, 0, appErr
+	}
+
+	return counts, len(sessions), nil
+}
+
+func (a *App) getUsersToMerge(sourceUserID, targetUserID string) (*model.User, *model.User, *model.AppError) {
+	if sourceUserID == targetUserID {
+		return nil, nil, model.NewAppError("getUsersToMerge", "model.user_merge.is_valid.same_user.app_error", nil, "user_id="+sourceUserID, http.StatusBadRequest)
+	}
+
+	source, appErr := a.GetUser(sourceUserID)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	target, appErr := a.GetUser(targetUserID)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	if source.IsBot || target.IsBot {
+		return nil, nil, model.NewAppError("getUsersToMerge", "app.user_merge.bot.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, merged := source.GetProp(model.UserPropMergedInto); merged {
+		return nil, nil, model.NewAppError("getUsersToMerge", "app.user_merge.already_merged.app_error", nil, "user_id="+source.Id, http.StatusBadRequest)
+	}
+
+	if target.DeleteAt != 0 {
+		return nil, nil, model.NewAppError("getUsersToMerge", "app.user_merge.target_inactive.app_error", nil, "user_id="+target.Id, http.StatusBadRequest)
+	}
+
+	return source, target, nil
+}
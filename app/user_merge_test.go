@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestMergeUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	source := th.CreateUser()
+	th.LinkUserToTeam(source, th.BasicTeam)
+	th.AddUserToChannel(source, th.BasicChannel)
+	channel := th.CreateChannel(th.BasicTeam)
+	th.AddUserToChannel(source, channel)
+
+	target := th.CreateUser()
+
+	post, appErr := th.App.CreatePost(th.Context, &model.Post{UserId: source.Id, ChannelId: channel.Id, Message: "duplicate"}, channel, false, true)
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.CreateSession(&model.Session{UserId: source.Id})
+	require.Nil(t, appErr)
+
+	counts, revokedSessions, appErr := th.App.MergeUsers(th.Context, source.Id, target.Id)
+	require.Nil(t, appErr)
+	assert.NotZero(t, counts.Posts)
+	assert.NotZero(t, counts.ChannelMemberships)
+	assert.Equal(t, int64(1), counts.TeamMemberships)
+	assert.Equal(t, 1, revokedSessions)
+
+	merged, appErr := th.App.GetSinglePost(post.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, target.Id, merged.UserId)
+
+	_, appErr = th.App.GetChannelMember(context.Background(), channel.Id, target.Id)
+	require.Nil(t, appErr)
+	_, appErr = th.App.GetTeamMember(th.BasicTeam.Id, target.Id)
+	require.Nil(t, appErr)
+
+	sessions, appErr := th.App.GetSessions(source.Id)
+	require.Nil(t, appErr)
+	assert.Empty(t, sessions)
+
+	tombstone, appErr := th.App.GetUser(source.Id)
+	require.Nil(t, appErr)
+	assert.NotZero(t, tombstone.DeleteAt)
+	mergedInto, _ := tombstone.GetProp(model.UserPropMergedInto)
+	assert.Equal(t, target.Id, mergedInto)
+
+	t.Run("a user can only be merged once", func(t *testing.T) {
+		_, _, appErr := th.App.MergeUsers(th.Context, source.Id, th.BasicUser2.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user_merge.already_merged.app_error", appErr.Id)
+	})
+
+	t.Run("bots can't be merged", func(t *testing.T) {
+		bot := th.CreateBot()
+
+		_, appErr := th.App.CreateUserMergeJob(&model.UserMergeRequest{SourceUserId: bot.UserId, TargetUserId: th.BasicUser.Id}, th.SystemAdminUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
+
+func TestGetUserMergeReport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	job, appErr := th.App.CreateUserMergeJob(&model.UserMergeRequest{SourceUserId: th.BasicUser2.Id, TargetUserId: th.BasicUser.Id}, th.SystemAdminUser.Id)
+	require.Nil(t, appErr)
+
+	report, appErr := th.App.GetUserMergeReport(job.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, th.BasicUser2.Id, report.SourceUserId)
+	assert.Equal(t, th.BasicUser.Id, report.TargetUserId)
+	assert.Nil(t, report.Counts)
+
+	otherJob, appErr := th.App.CreateBulkChannelMembersJob(th.BasicChannel, th.BasicUser.Id, &model.ChannelMembersBulkRequest{
+		Action:  model.ChannelMembersBulkActionAdd,
+		UserIds: []string{th.BasicUser2.Id},
+	})
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.GetUserMergeReport(otherJob.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
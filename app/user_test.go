@@ -1762,3 +1762,45 @@ func TestCreateUserWithInitialPreferences(t *testing.T) {
 		assert.Equal(t, "0", preferences[1].Value)
 	})
 }
+
+func TestPublishUserTyping(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	typingKey := func(userID, channelID, parentID string) string {
+		return userID + ":" + channelID + ":" + parentID
+	}
+
+	t.Run("typing events are coalesced", func(t *testing.T) {
+		th.Server.typingEventsCache.Purge()
+
+		appErr := th.App.PublishUserTyping(th.BasicUser.Id, th.BasicChannel.Id, "")
+		require.Nil(t, appErr)
+
+		var firstTypingAt int64
+		require.NoError(t, th.Server.typingEventsCache.Get(typingKey(th.BasicUser.Id, th.BasicChannel.Id, ""), &firstTypingAt))
+
+		appErr = th.App.PublishUserTyping(th.BasicUser.Id, th.BasicChannel.Id, "")
+		require.Nil(t, appErr)
+
+		var lastTypingAt int64
+		require.NoError(t, th.Server.typingEventsCache.Get(typingKey(th.BasicUser.Id, th.BasicChannel.Id, ""), &lastTypingAt))
+		assert.Equal(t, firstTypingAt, lastTypingAt)
+	})
+
+	t.Run("typing events aren't published in channels above the member threshold", func(t *testing.T) {
+		th.Server.typingEventsCache.Purge()
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.TypingMessagesMaxChannelMembers = 1
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.TypingMessagesMaxChannelMembers = 0
+		})
+
+		appErr := th.App.PublishUserTyping(th.BasicUser.Id, th.BasicChannel.Id, "")
+		require.Nil(t, appErr)
+
+		var typingAt int64
+		require.Error(t, th.Server.typingEventsCache.Get(typingKey(th.BasicUser.Id, th.BasicChannel.Id, ""), &typingAt))
+	})
+}
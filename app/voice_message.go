@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/waveform"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// voiceMessageWaveformBuckets is the number of bars of the waveform preview of a voice message.
+	voiceMessageWaveformBuckets = 64
+	// maxVoiceMessageSize is the size of the largest audio file processed as a voice message.
+	maxVoiceMessageSize = 25 * 1024 * 1024 // 25MB (IEC)
+)
+
+// getVoiceMessageFile returns the audio file of a voice message, a post with a single audio
+// attachment uploaded by its author, or nil if the post isn't one.
+func (a *App) getVoiceMessageFile(post *model.Post) *model.FileInfo {
+	if len(post.FileIds) != 1 {
+		return nil
+	}
+
+	fileInfo, appErr := a.GetFileInfo(post.FileIds[0])
+	if appErr != nil {
+		mlog.Debug("Failed to get the file of a post", mlog.String("file_id", post.FileIds[0]), mlog.Err(appErr))
+		return nil
+	}
+
+	if fileInfo.CreatorId != post.UserId || fileInfo.PostId != "" || !fileInfo.IsAudio() || fileInfo.Size > maxVoiceMessageSize {
+		return nil
+	}
+
+	return fileInfo
+}
+
+// addVoiceMessageProps sets the waveform preview and the duration of the audio file of a voice
+// message on its post. Audio that can't be decoded gets no preview.
+func (a *App) addVoiceMessageProps(post *model.Post, fileInfo *model.FileInfo) {
+	file, appErr := a.FileReader(fileInfo.Path)
+	if appErr != nil {
+		mlog.Warn("Failed to open the file of a voice message", mlog.String("file_id", fileInfo.Id), mlog.Err(appErr))
+		return
+	}
+	defer file.Close()
+
+	preview, err := waveform.Generate(file, voiceMessageWaveformBuckets)
+	if err != nil {
+		if !errors.Is(err, waveform.ErrUnsupportedFormat) {
+			mlog.Warn("Failed to generate the waveform of a voice message", mlog.String("file_id", fileInfo.Id), mlog.Err(err))
+		}
+		return
+	}
+
+	post.AddProp(model.PostPropsVoiceWaveform, preview.Peaks)
+	post.AddProp(model.PostPropsVoiceDuration, preview.Duration)
+}
+
+// transcribeVoiceMessage transcribes the audio file of a voice message with the registered
+// transcription provider. The transcript is attached to the post for accessibility, and saved as
+// the content of the file so that file search finds it.
+func (a *App) transcribeVoiceMessage(postID string, fileInfo *model.FileInfo) *model.AppError {
+	provider := a.Transcription()
+	if provider == nil || !*a.Config().FileSettings.TranscribeVoiceMessages {
+		return nil
+	}
+
+	file, appErr := a.FileReader(fileInfo.Path)
+	if appErr != nil {
+		return appErr
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return model.NewAppError("transcribeVoiceMessage", "api.file.read_file.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	transcript, appErr := provider.Transcribe(fileInfo, data)
+	if appErr != nil {
+		return appErr
+	}
+
+	transcript = strings.TrimSpace(transcript)
+	if transcript == "" {
+		return nil
+	}
+	if len(transcript) > maxContentExtractionSize {
+		transcript = transcript[:maxContentExtractionSize]
+	}
+
+	if err := a.Srv().Store.FileInfo().SetContent(fileInfo.Id, transcript); err != nil {
+		return model.NewAppError("transcribeVoiceMessage", "app.file_info.set_content.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// The post is read again, it may have been edited while its file was transcribed.
+	post, err := a.Srv().Store.Post().GetSingle(postID, false)
+	if err != nil {
+		return model.NewAppError("transcribeVoiceMessage", "app.post.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	post.AddProp(model.PostPropsVoiceTranscript, transcript)
+	updated, err := a.Srv().Store.Post().Overwrite(post)
+	if err != nil {
+		return model.NewAppError("transcribeVoiceMessage", "app.post.overwrite.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	a.Srv().Store.FileInfo().InvalidateFileInfosForPostCache(postID, false)
+	a.invalidateCacheForChannelPosts(updated.ChannelId)
+
+	message := model.NewWebSocketEvent(model.WebsocketEventPostEdited, "", updated.ChannelId, "", nil)
+	postJSON, jsonErr := a.PreparePostForClient(updated, false, true).ToJSON()
+	if jsonErr != nil {
+		return model.NewAppError("transcribeVoiceMessage", "app.post.marshal.app_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	message.Add("post", postJSON)
+	a.Publish(message)
+
+	return nil
+}
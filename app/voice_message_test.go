@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/einterfaces/mocks"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// voiceMessageWav returns one second of 16 bit mono PCM audio as a WAV file.
+func voiceMessageWav() []byte {
+	samples := make([]int16, 8000)
+	for i := range samples {
+		samples[i] = int16(i % 16000)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+2*len(samples)))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, []uint32{16})
+	binary.Write(&buf, binary.LittleEndian, []uint16{1, 1})
+	binary.Write(&buf, binary.LittleEndian, []uint32{8000, 16000})
+	binary.Write(&buf, binary.LittleEndian, []uint16{2, 16})
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(2*len(samples)))
+	binary.Write(&buf, binary.LittleEndian, samples)
+	return buf.Bytes()
+}
+
+func TestVoiceMessages(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	uploadVoiceMessage := func(t *testing.T) *model.FileInfo {
+		info, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "voice.wav", voiceMessageWav())
+		require.Nil(t, appErr)
+		return info
+	}
+
+	t.Run("waveform of a voice message", func(t *testing.T) {
+		info := uploadVoiceMessage(t)
+
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			FileIds:   []string{info.Id},
+			Props:     model.StringInterface{model.PostPropsVoiceTranscript: "forged"},
+		}, th.BasicChannel, false, true)
+		require.Nil(t, appErr)
+
+		peaks, ok := post.GetProp(model.PostPropsVoiceWaveform).([]int)
+		require.True(t, ok)
+		assert.Len(t, peaks, voiceMessageWaveformBuckets)
+		assert.Equal(t, int64(1000), post.GetProp(model.PostPropsVoiceDuration))
+		assert.Nil(t, post.GetProp(model.PostPropsVoiceTranscript))
+	})
+
+	t.Run("posts with other files aren't voice messages", func(t *testing.T) {
+		info, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "notes.txt", []byte("notes"))
+		require.Nil(t, appErr)
+
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			FileIds:   []string{info.Id},
+		}, th.BasicChannel, false, true)
+		require.Nil(t, appErr)
+		assert.Nil(t, post.GetProp(model.PostPropsVoiceWaveform))
+	})
+
+	t.Run("transcript of a voice message", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.TranscribeVoiceMessages = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.TranscribeVoiceMessages = false })
+
+		transcription := &mocks.TranscriptionInterface{}
+		transcription.On("Transcribe", mock.AnythingOfType("*model.FileInfo"), mock.AnythingOfType("[]uint8")).Return("remember the milk", nil)
+		th.App.Channels().Transcription = transcription
+		defer func() { th.App.Channels().Transcription = nil }()
+
+		info := uploadVoiceMessage(t)
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			FileIds:   []string{info.Id},
+		}, th.BasicChannel, false, true)
+		require.Nil(t, appErr)
+
+		require.Eventually(t, func() bool {
+			saved, err := th.App.Srv().Store.Post().GetSingle(post.Id, false)
+			return err == nil && saved.GetProp(model.PostPropsVoiceTranscript) == "remember the milk"
+		}, 5*time.Second, 50*time.Millisecond)
+
+		saved, err := th.App.Srv().Store.FileInfo().Get(info.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "remember the milk", saved.Content)
+		transcription.AssertExpectations(t)
+	})
+}
@@ -456,6 +456,7 @@ func (wc *WebConn) writePump() {
 				switch msg.EventType() {
 				case model.WebsocketEventTyping,
 					model.WebsocketEventStatusChange,
+					model.WebsocketEventStatusesChanged,
 					model.WebsocketEventChannelViewed:
 					mlog.Warn(
 						"websocket.slow: dropping message",
@@ -4,10 +4,12 @@
 package app
 
 import (
+	"encoding/json"
 	"hash/maphash"
 	"runtime"
 	"runtime/debug"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -18,6 +20,16 @@ import (
 const (
 	broadcastQueueSize         = 4096
 	inactiveConnReaperInterval = 5 * time.Minute
+
+	// ephemeralPostTTL is how long the ephemeral posts sent to a user are also sent to the
+	// connections the user opens afterwards.
+	ephemeralPostTTL = 30 * time.Minute
+	// maxEphemeralPostsPerUser is the maximum number of ephemeral posts tracked for a user.
+	maxEphemeralPostsPerUser = 50
+
+	// minConnectionsPerShard is the minimum number of connections a broadcast is fanned out to
+	// by a single worker of a hub. Smaller broadcasts are sent by the hub itself.
+	minConnectionsPerShard = 256
 )
 
 type webConnActivityMessage struct {
@@ -43,6 +55,37 @@ type webConnCheckMessage struct {
 	result       chan *CheckConnResult
 }
 
+// hubFanoutTask is a shard of the connections of a hub a broadcast is sent to by one of the
+// workers of the hub. The connections whose send queue is full are collected in dead, for the
+// hub to close them once all the shards are done.
+type hubFanoutTask struct {
+	msg   *model.WebSocketEvent
+	conns []*WebConn
+	dead  []*WebConn
+	wg    *sync.WaitGroup
+}
+
+func (t *hubFanoutTask) run() {
+	defer t.wg.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			mlog.Error("Recovering from websocket broadcast panic.", mlog.Any("panic", r))
+			mlog.Error(string(debug.Stack()))
+		}
+	}()
+
+	for _, webConn := range t.conns {
+		if !webConn.shouldSendEvent(t.msg) {
+			continue
+		}
+		select {
+		case webConn.send <- t.msg:
+		default:
+			t.dead = append(t.dead, webConn)
+		}
+	}
+}
+
 // Hub is the central place to manage all websocket connections in the server.
 // It handles different websocket events and sending messages to individual
 // user connections.
@@ -63,11 +106,17 @@ type Hub struct {
 	explicitStop    bool
 	checkRegistered chan *webConnSessionMessage
 	checkConn       chan *webConnCheckMessage
+	// workers is the number of goroutines the broadcasts to many connections of the hub are
+	// fanned out to, the hub itself being one of them.
+	workers int
+	fanout  chan *hubFanoutTask
 }
 
 // newWebHub creates a new Hub.
 func newWebHub(s *Server) *Hub {
 	return &Hub{
+		workers:         1,
+		fanout:          make(chan *hubFanoutTask),
 		srv:             s,
 		register:        make(chan *WebConn),
 		unregister:      make(chan *WebConn),
@@ -86,17 +135,34 @@ func (a *App) TotalWebsocketConnections() int {
 	return a.Srv().TotalWebsocketConnections()
 }
 
+// hubSizing returns the number of hubs and the number of workers of each hub. Unless configured,
+// there are twice as many hubs as CPUs, and the CPUs are spread over the workers of the hubs.
+func hubSizing(shards, workers int) (int, int) {
+	if shards <= 0 {
+		shards = runtime.NumCPU() * 2
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0) / shards
+		if workers < 1 {
+			workers = 1
+		}
+	}
+
+	return shards, workers
+}
+
 // HubStart starts all the hubs.
 func (s *Server) HubStart() {
-	// Total number of hubs is twice the number of CPUs.
-	numberOfHubs := runtime.NumCPU() * 2
-	s.Log.Info("Starting websocket hubs", mlog.Int("number_of_hubs", numberOfHubs))
+	numberOfHubs, workersPerHub := hubSizing(*s.Config().ServiceSettings.WebsocketHubShards, *s.Config().ServiceSettings.WebsocketHubWorkers)
+	s.Log.Info("Starting websocket hubs", mlog.Int("number_of_hubs", numberOfHubs), mlog.Int("workers_per_hub", workersPerHub))
 
 	hubs := make([]*Hub, numberOfHubs)
 
 	for i := 0; i < numberOfHubs; i++ {
 		hubs[i] = newWebHub(s)
 		hubs[i].connectionIndex = i
+		hubs[i].workers = workersPerHub
 		hubs[i].Start()
 	}
 	// Assigning to the hubs slice without any mutex is fine because it is only assigned once
@@ -163,6 +229,11 @@ func (s *Server) Publish(message *model.WebSocketEvent) {
 
 	s.PublishSkipClusterSend(message)
 
+	// Only the node the event originates from publishes it to the event bus.
+	if s.eventBus != nil {
+		s.eventBus.Publish(message)
+	}
+
 	if s.Cluster != nil {
 		data, err := message.ToJSON()
 		if err != nil {
@@ -405,8 +476,81 @@ func (h *Hub) Stop() {
 	<-h.didStop
 }
 
+// fanoutWorker sends the broadcasts handed over by the hub to a shard of its connections.
+func (h *Hub) fanoutWorker() {
+	for {
+		select {
+		case task := <-h.fanout:
+			task.run()
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+// broadcastToAll sends the message to all the connections of the hub. When there are enough
+// of them, the connections are sharded and the shards are sent to by the workers of the hub in
+// parallel, the hub itself taking the first shard.
+func (h *Hub) broadcastToAll(connIndex *hubConnectionIndex, msg *model.WebSocketEvent) {
+	shards := shardConnections(connIndex.All(), h.workers)
+
+	var wg sync.WaitGroup
+	tasks := make([]*hubFanoutTask, len(shards))
+	for i, conns := range shards {
+		tasks[i] = &hubFanoutTask{msg: msg, conns: conns, wg: &wg}
+	}
+
+	wg.Add(len(tasks))
+	for _, task := range tasks[1:] {
+		select {
+		case h.fanout <- task:
+		case <-h.stop:
+			task.run()
+		}
+	}
+	tasks[0].run()
+	wg.Wait()
+
+	for _, task := range tasks {
+		for _, webConn := range task.dead {
+			mlog.Error("webhub.broadcast: cannot send, closing websocket for user", mlog.String("user_id", webConn.UserId))
+			close(webConn.send)
+			connIndex.Remove(webConn)
+		}
+	}
+}
+
+// shardConnections splits the connections in at most the given number of shards, each of them
+// holding at least minConnectionsPerShard connections.
+func shardConnections(conns map[*WebConn]int, maxShards int) [][]*WebConn {
+	count := len(conns) / minConnectionsPerShard
+	if count > maxShards {
+		count = maxShards
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	shards := make([][]*WebConn, count)
+	size := (len(conns) + count - 1) / count
+	i := 0
+	for webConn := range conns {
+		if shards[i/size] == nil {
+			shards[i/size] = make([]*WebConn, 0, size)
+		}
+		shards[i/size] = append(shards[i/size], webConn)
+		i++
+	}
+
+	return shards
+}
+
 // Start starts the hub.
 func (h *Hub) Start() {
+	for i := 1; i < h.workers; i++ {
+		go h.fanoutWorker()
+	}
+
 	var doStart func()
 	var doRecoverableStart func()
 	var doRecover func()
@@ -420,6 +564,7 @@ func (h *Hub) Start() {
 		appInstance := New(ServerConnector(h.srv.Channels()))
 
 		connIndex := newHubConnectionIndex(inactiveConnReaperInterval)
+		ephemeralPosts := newEphemeralPostIndex(ephemeralPostTTL, maxEphemeralPostsPerUser)
 
 		for {
 			select {
@@ -451,6 +596,7 @@ func (h *Hub) Start() {
 				req.result <- res
 			case <-ticker.C:
 				connIndex.RemoveInactiveConnections()
+				ephemeralPosts.RemoveExpired()
 			case webConn := <-h.register:
 				// Mark the current one as active.
 				// There is no need to check if it was inactive or not,
@@ -466,6 +612,19 @@ func (h *Hub) Start() {
 					// In case of seq number not found in dead queue, it is handled by
 					// the webconn write pump.
 					webConn.send <- webConn.createHelloMessage()
+
+					// The ephemeral posts sent before the connection was opened are sent too,
+					// with their latest updates.
+					for _, ev := range ephemeralPosts.ForUser(webConn.UserId) {
+						if !webConn.shouldSendEvent(ev) {
+							continue
+						}
+						select {
+						case webConn.send <- ev:
+						default:
+							mlog.Warn("webhub.register: cannot send ephemeral post, send queue is full", mlog.String("user_id", webConn.UserId))
+						}
+					}
 				}
 			case webConn := <-h.unregister:
 				// If already removed (via queue full), then removing again becomes a noop.
@@ -550,6 +709,7 @@ func (h *Hub) Start() {
 						continue
 					}
 				} else if msg.GetBroadcast().UserId != "" {
+					ephemeralPosts.Track(msg)
 					candidates := connIndex.ForUser(msg.GetBroadcast().UserId)
 					for _, webConn := range candidates {
 						broadcast(webConn)
@@ -557,6 +717,11 @@ func (h *Hub) Start() {
 					continue
 				}
 
+				if h.workers > 1 && len(connIndex.All()) >= 2*minConnectionsPerShard {
+					h.broadcastToAll(connIndex, msg)
+					continue
+				}
+
 				candidates := connIndex.All()
 				for webConn := range candidates {
 					broadcast(webConn)
@@ -704,3 +869,103 @@ func (i *hubConnectionIndex) AllActive() int {
 	}
 	return cnt
 }
+
+type trackedEphemeralPost struct {
+	postID   string
+	event    *model.WebSocketEvent
+	expireAt int64
+}
+
+// ephemeralPostIndex tracks the ephemeral posts sent to the users of a hub, so that they are
+// sent to the connections the users open afterwards with their latest version. This lets
+// integrations update or delete ephemeral posts on all the clients of a user.
+type ephemeralPostIndex struct {
+	byUserId map[string][]*trackedEphemeralPost
+	ttl      time.Duration
+	maxCount int
+}
+
+func newEphemeralPostIndex(ttl time.Duration, maxCount int) *ephemeralPostIndex {
+	return &ephemeralPostIndex{
+		byUserId: make(map[string][]*trackedEphemeralPost),
+		ttl:      ttl,
+		maxCount: maxCount,
+	}
+}
+
+// Track updates the index with an event sent to a user, if it creates, updates or deletes an
+// ephemeral post.
+func (i *ephemeralPostIndex) Track(msg *model.WebSocketEvent) {
+	eventType := msg.EventType()
+	if eventType != model.WebsocketEventEphemeralMessage && eventType != model.WebsocketEventPostEdited && eventType != model.WebsocketEventPostDeleted {
+		return
+	}
+
+	postJSON, ok := msg.GetData()["post"].(string)
+	if !ok {
+		return
+	}
+	var post model.Post
+	if err := json.Unmarshal([]byte(postJSON), &post); err != nil || post.Type != model.PostTypeEphemeral {
+		return
+	}
+
+	userID := msg.GetBroadcast().UserId
+	posts := i.byUserId[userID]
+	for index, tracked := range posts {
+		if tracked.postID == post.Id {
+			posts = append(posts[:index], posts[index+1:]...)
+			break
+		}
+	}
+
+	if eventType != model.WebsocketEventPostDeleted {
+		// Updates are sent as the ephemeral post itself, since new connections do not have it.
+		ev := model.NewWebSocketEvent(model.WebsocketEventEphemeralMessage, "", post.ChannelId, userID, nil)
+		ev.Add("post", postJSON)
+		posts = append(posts, &trackedEphemeralPost{
+			postID:   post.Id,
+			event:    ev.PrecomputeJSON(),
+			expireAt: model.GetMillis() + i.ttl.Milliseconds(),
+		})
+		if len(posts) > i.maxCount {
+			posts = posts[len(posts)-i.maxCount:]
+		}
+	}
+
+	if len(posts) == 0 {
+		delete(i.byUserId, userID)
+		return
+	}
+	i.byUserId[userID] = posts
+}
+
+// ForUser returns the events to send to a new connection of the user, oldest first.
+func (i *ephemeralPostIndex) ForUser(userID string) []*model.WebSocketEvent {
+	now := model.GetMillis()
+	var events []*model.WebSocketEvent
+	for _, tracked := range i.byUserId[userID] {
+		if tracked.expireAt > now {
+			events = append(events, tracked.event)
+		}
+	}
+	return events
+}
+
+// RemoveExpired removes the ephemeral posts tracked for longer than the TTL.
+func (i *ephemeralPostIndex) RemoveExpired() {
+	now := model.GetMillis()
+	for userID, posts := range i.byUserId {
+		kept := posts[:0]
+		for _, tracked := range posts {
+			if tracked.expireAt > now {
+				kept = append(kept, tracked)
+			}
+		}
+		if len(kept) == 0 {
+			delete(i.byUserId, userID)
+			continue
+		}
+		i.byUserId[userID] = kept
+	}
+}
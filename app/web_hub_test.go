@@ -4,9 +4,11 @@
 package app
 
 import (
+	"encoding/json"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
 	"time"
 
@@ -398,6 +400,65 @@ func TestHubConnIndexInactive(t *testing.T) {
 	assert.Len(t, connIndex.All(), 2)
 }
 
+func TestEphemeralPostIndex(t *testing.T) {
+	userID := model.NewId()
+	event := func(eventType string, post *model.Post) *model.WebSocketEvent {
+		postJSON, err := post.ToJSON()
+		require.NoError(t, err)
+		ev := model.NewWebSocketEvent(eventType, "", post.ChannelId, userID, nil)
+		ev.Add("post", postJSON)
+		return ev
+	}
+	postIDs := func(events []*model.WebSocketEvent) []string {
+		var ids []string
+		for _, ev := range events {
+			assert.Equal(t, model.WebsocketEventEphemeralMessage, ev.EventType())
+			var post model.Post
+			require.NoError(t, json.Unmarshal([]byte(ev.GetData()["post"].(string)), &post))
+			ids = append(ids, post.Id+":"+post.Message)
+		}
+		return ids
+	}
+
+	index := newEphemeralPostIndex(time.Minute, 2)
+	post1 := &model.Post{Id: model.NewId(), Type: model.PostTypeEphemeral, Message: "one"}
+	post2 := &model.Post{Id: model.NewId(), Type: model.PostTypeEphemeral, Message: "two"}
+	post3 := &model.Post{Id: model.NewId(), Type: model.PostTypeEphemeral, Message: "three"}
+
+	index.Track(event(model.WebsocketEventEphemeralMessage, post1))
+	index.Track(event(model.WebsocketEventEphemeralMessage, post2))
+	assert.Equal(t, []string{post1.Id + ":one", post2.Id + ":two"}, postIDs(index.ForUser(userID)))
+	assert.Empty(t, index.ForUser(model.NewId()))
+
+	t.Run("updated", func(t *testing.T) {
+		post1.Message = "updated"
+		index.Track(event(model.WebsocketEventPostEdited, post1))
+		assert.Equal(t, []string{post2.Id + ":two", post1.Id + ":updated"}, postIDs(index.ForUser(userID)))
+	})
+
+	t.Run("regular posts are ignored", func(t *testing.T) {
+		index.Track(event(model.WebsocketEventPostEdited, &model.Post{Id: model.NewId(), Message: "regular"}))
+		assert.Len(t, index.ForUser(userID), 2)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		index.Track(event(model.WebsocketEventEphemeralMessage, post3))
+		assert.Equal(t, []string{post1.Id + ":updated", post3.Id + ":three"}, postIDs(index.ForUser(userID)))
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		index.Track(event(model.WebsocketEventPostDeleted, &model.Post{Id: post1.Id, Type: model.PostTypeEphemeral}))
+		assert.Equal(t, []string{post3.Id + ":three"}, postIDs(index.ForUser(userID)))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		index.byUserId[userID][0].expireAt = model.GetMillis() - 1
+		assert.Empty(t, index.ForUser(userID))
+		index.RemoveExpired()
+		assert.NotContains(t, index.byUserId, userID)
+	})
+}
+
 func TestReliableWebSocketSend(t *testing.T) {
 	testCluster := &testlib.FakeClusterInterface{}
 
@@ -460,6 +521,65 @@ func TestHubIsRegistered(t *testing.T) {
 	assert.False(t, th.App.SessionIsRegistered(*session4))
 }
 
+func TestHubSizing(t *testing.T) {
+	shards, workers := hubSizing(4, 3)
+	assert.Equal(t, 4, shards)
+	assert.Equal(t, 3, workers)
+
+	shards, workers = hubSizing(0, 0)
+	assert.Equal(t, runtime.NumCPU()*2, shards)
+	assert.GreaterOrEqual(t, workers, 1)
+
+	shards, workers = hubSizing(1, 0)
+	assert.Equal(t, 1, shards)
+	assert.Equal(t, runtime.GOMAXPROCS(0), workers)
+}
+
+func TestShardConnections(t *testing.T) {
+	newConns := func(n int) map[*WebConn]int {
+		conns := make(map[*WebConn]int, n)
+		for i := 0; i < n; i++ {
+			conns[&WebConn{}] = 0
+		}
+		return conns
+	}
+
+	countConns := func(shards [][]*WebConn) int {
+		count := 0
+		for _, shard := range shards {
+			count += len(shard)
+		}
+		return count
+	}
+
+	t.Run("few connections are kept in a single shard", func(t *testing.T) {
+		shards := shardConnections(newConns(minConnectionsPerShard+1), 8)
+		require.Len(t, shards, 1)
+		assert.Len(t, shards[0], minConnectionsPerShard+1)
+	})
+
+	t.Run("connections are spread over the shards", func(t *testing.T) {
+		shards := shardConnections(newConns(4*minConnectionsPerShard), 8)
+		require.Len(t, shards, 4)
+		assert.Equal(t, 4*minConnectionsPerShard, countConns(shards))
+		for _, shard := range shards {
+			assert.Len(t, shard, minConnectionsPerShard)
+		}
+	})
+
+	t.Run("shards are capped", func(t *testing.T) {
+		shards := shardConnections(newConns(10*minConnectionsPerShard+3), 3)
+		require.Len(t, shards, 3)
+		assert.Equal(t, 10*minConnectionsPerShard+3, countConns(shards))
+	})
+
+	t.Run("no connections", func(t *testing.T) {
+		shards := shardConnections(newConns(0), 3)
+		require.Len(t, shards, 1)
+		assert.Empty(t, shards[0])
+	})
+}
+
 // Always run this with -benchtime=0.1s
 // See: https://github.com/golang/go/issues/27217.
 func BenchmarkHubConnIndex(b *testing.B) {
@@ -11,7 +11,9 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mattermost/mattermost-server/v6/app/request"
@@ -95,17 +97,21 @@ func (a *App) handleWebhookEvents(c *request.Context, post *model.Post, team *mo
 }
 
 func (a *App) TriggerWebhook(c *request.Context, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
-	var body io.Reader
+	var body []byte
 	var contentType string
-	if hook.ContentType == "application/json" {
+	if hook.PayloadFormat == model.PayloadFormatSlack {
+		// Slack only sends form encoded outgoing webhooks.
+		body = []byte(payload.ToSlackFormValues(hook.Id))
+		contentType = "application/x-www-form-urlencoded"
+	} else if hook.ContentType == "application/json" {
 		js, jsonErr := json.Marshal(payload)
 		if jsonErr != nil {
 			mlog.Warn("Failed to encode to JSON", mlog.Err(jsonErr))
 		}
-		body = bytes.NewReader(js)
+		body = js
 		contentType = "application/json"
 	} else {
-		body = strings.NewReader(payload.ToFormValues())
+		body = []byte(payload.ToFormValues())
 		contentType = "application/x-www-form-urlencoded"
 	}
 
@@ -114,12 +120,16 @@ func (a *App) TriggerWebhook(c *request.Context, payload *model.OutgoingWebhookP
 		url := hook.CallbackURLs[i]
 
 		a.Srv().Go(func() {
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType)
+			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, hook)
 			if err != nil {
 				mlog.Error("Event POST failed.", mlog.Err(err))
 				return
 			}
 
+			if webhookResp != nil && hook.PayloadFormat == model.PayloadFormatSlack {
+				webhookResp.TranslateSlackFormat()
+			}
+
 			if webhookResp != nil && (webhookResp.Text != nil || len(webhookResp.Attachments) > 0) {
 				postRootId := ""
 				if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
@@ -154,14 +164,22 @@ func (a *App) TriggerWebhook(c *request.Context, payload *model.OutgoingWebhookP
 	}
 }
 
-func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string) (*model.OutgoingWebhookResponse, error) {
-	req, err := http.NewRequest("POST", url, body)
+func (a *App) doOutgoingWebhookRequest(url string, body []byte, contentType string, hook *model.OutgoingWebhook) (*model.OutgoingWebhookResponse, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
+	if hook.SigningSecret != "" {
+		signIntegrationRequest(req, hook.SigningSecret, body)
+	}
+	if hook.OutgoingOAuthConnectionId != "" {
+		if appErr := a.authorizeOutgoingRequest(req, hook.OutgoingOAuthConnectionId); appErr != nil {
+			return nil, appErr
+		}
+	}
 
 	resp, err := a.HTTPService().MakeClient(false).Do(req)
 	if err != nil {
@@ -181,6 +199,14 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 	return &hookResp, nil
 }
 
+// signIntegrationRequest sets the headers letting the integration receiving the request verify
+// that it was sent by the server with the payload, as described by model.IntegrationRequestSignature.
+func signIntegrationRequest(req *http.Request, secret string, payload []byte) {
+	timestamp := time.Now().Unix()
+	req.Header.Set(model.HeaderIntegrationRequestTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(model.HeaderIntegrationSignature, model.IntegrationRequestSignature(secret, timestamp, payload))
+}
+
 func SplitWebhookPost(post *model.Post, maxPostSize int) ([]*model.Post, *model.AppError) {
 	splits := make([]*model.Post, 0)
 	remainingText := post.Message
@@ -484,6 +510,12 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if hook.OutgoingOAuthConnectionId != "" {
+		if appErr := a.CheckOutgoingOAuthConnectionURLs(hook.OutgoingOAuthConnectionId, hook.CallbackURLs); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	allHooks, err := a.Srv().Store.Webhook().GetOutgoingByTeam(hook.TeamId, -1, -1)
 	if err != nil {
 		return nil, model.NewAppError("CreateOutgoingWebhook", "app.webhooks.get_outgoing_by_team.app_error", nil, err.Error(), http.StatusInternalServerError)
@@ -497,6 +529,9 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		}
 	}
 
+	// The signing secret is always generated by the server.
+	hook.SigningSecret = ""
+
 	webhook, err := a.Srv().Store.Webhook().SaveOutgoing(hook)
 	if err != nil {
 		var appErr *model.AppError
@@ -536,6 +571,12 @@ func (a *App) UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook)
 		return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusInternalServerError)
 	}
 
+	if updatedHook.OutgoingOAuthConnectionId != "" {
+		if appErr := a.CheckOutgoingOAuthConnectionURLs(updatedHook.OutgoingOAuthConnectionId, updatedHook.CallbackURLs); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	allHooks, err := a.Srv().Store.Webhook().GetOutgoingByTeam(oldHook.TeamId, -1, -1)
 	if err != nil {
 		return nil, model.NewAppError("UpdateOutgoingWebhook", "app.webhooks.get_outgoing_by_team.app_error", nil, err.Error(), http.StatusInternalServerError)
@@ -550,6 +591,7 @@ func (a *App) UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook)
 		}
 	}
 
+	updatedHook.SigningSecret = oldHook.SigningSecret
 	updatedHook.CreatorId = oldHook.CreatorId
 	updatedHook.CreateAt = oldHook.CreateAt
 	updatedHook.DeleteAt = oldHook.DeleteAt
@@ -657,6 +699,23 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	return webhook, nil
 }
 
+// RegenOutgoingWebhookSigningSecret generates a new signing secret for the webhook, which also
+// signs the requests of the webhooks created before signing secrets were introduced.
+func (a *App) RegenOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("RegenOutgoingWebhookSigningSecret", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hook.SigningSecret = model.NewId()
+
+	webhook, err := a.Srv().Store.Webhook().UpdateOutgoing(hook)
+	if err != nil {
+		return nil, model.NewAppError("RegenOutgoingWebhookSigningSecret", "app.webhooks.update_outgoing.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return webhook, nil
+}
+
 func (a *App) HandleIncomingWebhook(c *request.Context, hookID string, req *model.IncomingWebhookRequest) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -862,6 +921,10 @@ func (a *App) HandleCommandWebhook(c *request.Context, hookID string, response *
 		}
 	}
 
+	if cmd.PayloadFormat == model.PayloadFormatSlack {
+		response.TranslateSlackFormat()
+	}
+
 	_, err := a.HandleCommandResponse(c, cmd, args, response, false)
 	return err
 }
@@ -6,6 +6,7 @@ package app
 import (
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -713,6 +714,74 @@ func TestTriggerOutGoingWebhookWithUsernameAndIconURL(t *testing.T) {
 
 }
 
+func TestTriggerOutgoingWebhookSlackPayloadFormat(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	requests := make(chan *http.Request, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		requests <- r
+		w.Write([]byte(`{"text": "fallback", "blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "*Deployed* to prod"}}]}`))
+	}))
+	defer ts.Close()
+
+	channel := th.CreateChannel(th.BasicTeam)
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:     channel.Id,
+		TeamId:        channel.TeamId,
+		CallbackURLs:  []string{ts.URL},
+		CreatorId:     th.BasicUser.Id,
+		TriggerWords:  []string{"deploy"},
+		ContentType:   "application/json",
+		PayloadFormat: model.PayloadFormatSlack,
+	})
+	require.Nil(t, appErr)
+
+	payload := &model.OutgoingWebhookPayload{
+		Token:       hook.Token,
+		TeamId:      hook.TeamId,
+		TeamDomain:  th.BasicTeam.Name,
+		ChannelId:   channel.Id,
+		ChannelName: channel.Name,
+		Timestamp:   1355517523005,
+		UserId:      th.BasicUser.Id,
+		UserName:    th.BasicUser.Username,
+		PostId:      th.BasicPost.Id,
+		Text:        "deploy prod",
+		TriggerWord: "deploy",
+	}
+	th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, channel)
+
+	select {
+	case r := <-requests:
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		assert.Equal(t, hook.Token, r.PostForm.Get("token"))
+		assert.Equal(t, hook.Id, r.PostForm.Get("service_id"))
+		assert.Equal(t, "1355517523.005000", r.PostForm.Get("timestamp"))
+		assert.Equal(t, "deploy", r.PostForm.Get("trigger_word"))
+		assert.NotContains(t, r.PostForm, "post_id")
+		assert.NotContains(t, r.PostForm, "file_ids")
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "Timeout, webhook request not received")
+	}
+
+	require.Eventually(t, func() bool {
+		posts, _ := th.App.GetPosts(channel.Id, 0, 5)
+		for _, post := range posts.Posts {
+			if post.Message == "**Deployed** to prod" {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 type InfiniteReader struct {
 	Prefix string
 }
@@ -740,7 +809,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		resp, err := th.App.doOutgoingWebhookRequest(server.URL, nil, "application/json", &model.OutgoingWebhook{})
 		require.NoError(t, err)
 
 		assert.NotNil(t, resp)
@@ -754,7 +823,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, nil, "application/json", &model.OutgoingWebhook{})
 		require.Error(t, err)
 		require.Equal(t, "api.unmarshal_error", err.(*model.AppError).Id)
 	})
@@ -765,7 +834,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, nil, "application/json", &model.OutgoingWebhook{})
 		require.Error(t, err)
 		require.Equal(t, "api.unmarshal_error", err.(*model.AppError).Id)
 	})
@@ -776,7 +845,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, nil, "application/json", &model.OutgoingWebhook{})
 		require.Error(t, err)
 		require.Equal(t, "api.unmarshal_error", err.(*model.AppError).Id)
 	})
@@ -796,7 +865,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 			th.App.HTTPService().(*httpservice.HTTPServiceImpl).RequestTimeout = httpservice.RequestTimeout
 		}()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, nil, "application/json", &model.OutgoingWebhook{})
 		require.Error(t, err)
 		require.IsType(t, &url.Error{}, err)
 	})
@@ -806,8 +875,31 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json")
+		resp, err := th.App.doOutgoingWebhookRequest(server.URL, nil, "application/json", &model.OutgoingWebhook{})
 		require.NoError(t, err)
 		require.Nil(t, resp)
 	})
+
+	t.Run("signed with the signing secret", func(t *testing.T) {
+		body := []byte(`{"text": "hello"}`)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.True(t, model.VerifyIntegrationRequestSignature("secret", r.Header.Get(model.HeaderIntegrationRequestTimestamp), r.Header.Get(model.HeaderIntegrationSignature), received, time.Now()))
+		}))
+		defer server.Close()
+
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, body, "application/json", &model.OutgoingWebhook{SigningSecret: "secret"})
+		require.NoError(t, err)
+	})
+
+	t.Run("unsigned without a signing secret", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get(model.HeaderIntegrationSignature))
+		}))
+		defer server.Close()
+
+		_, err := th.App.doOutgoingWebhookRequest(server.URL, nil, "application/json", &model.OutgoingWebhook{})
+		require.NoError(t, err)
+	})
 }
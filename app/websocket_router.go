@@ -11,6 +11,13 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+// readOnlyWebSocketActions are the actions allowed to the read-only sessions of impersonators.
+var readOnlyWebSocketActions = map[string]bool{
+	"ping":                true,
+	"get_statuses":        true,
+	"get_statuses_by_ids": true,
+}
+
 type webSocketHandler interface {
 	ServeWebSocket(*WebConn, *model.WebSocketRequest)
 }
@@ -73,12 +80,23 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 		return
 	}
 
+	if r.Action == model.WebsocketReauthenticate {
+		reauthenticateWebConn(conn, r)
+		return
+	}
+
 	if !conn.IsAuthenticated() {
 		err := model.NewAppError("ServeWebSocket", "api.web_socket_router.not_authenticated.app_error", nil, "", http.StatusUnauthorized)
 		returnWebSocketError(conn.App, conn, r, err)
 		return
 	}
 
+	if conn.GetSession().IsImpersonation() && !readOnlyWebSocketActions[r.Action] {
+		err := model.NewAppError("ServeWebSocket", "api.web_socket_router.impersonation_read_only.app_error", nil, "", http.StatusForbidden)
+		returnWebSocketError(conn.App, conn, r, err)
+		return
+	}
+
 	handler, ok := wr.handlers[r.Action]
 	if !ok {
 		err := model.NewAppError("ServeWebSocket", "api.web_socket_router.bad_action.app_error", nil, "", http.StatusInternalServerError)
@@ -111,3 +129,44 @@ func returnWebSocketError(app *App, conn *WebConn, r *model.WebSocketRequest, er
 	errorResp := model.NewWebSocketError(r.Seq, err)
 	hub.SendMessage(conn, errorResp)
 }
+
+// reauthenticateWebConn replaces the session of an authenticated connection with a session of the
+// same user, so that long-lived connections can outlive the session they were opened with.
+func reauthenticateWebConn(conn *WebConn, r *model.WebSocketRequest) {
+	if conn.UserId == "" {
+		err := model.NewAppError("reauthenticateWebConn", "api.web_socket_router.not_authenticated.app_error", nil, "", http.StatusUnauthorized)
+		returnWebSocketError(conn.App, conn, r, err)
+		return
+	}
+
+	token, ok := r.Data["token"].(string)
+	if !ok || token == "" {
+		err := model.NewAppError("reauthenticateWebConn", "api.web_socket_router.reauthenticate.invalid_token.app_error", nil, "", http.StatusBadRequest)
+		returnWebSocketError(conn.App, conn, r, err)
+		return
+	}
+
+	session, appErr := conn.App.GetSession(token)
+	if appErr != nil {
+		err := model.NewAppError("reauthenticateWebConn", "api.web_socket_router.reauthenticate.invalid_token.app_error", nil, appErr.Error(), http.StatusUnauthorized)
+		returnWebSocketError(conn.App, conn, r, err)
+		return
+	}
+
+	if session.UserId != conn.UserId {
+		err := model.NewAppError("reauthenticateWebConn", "api.web_socket_router.reauthenticate.user_mismatch.app_error", nil, "session_user_id="+session.UserId, http.StatusForbidden)
+		returnWebSocketError(conn.App, conn, r, err)
+		return
+	}
+
+	conn.SetSession(session)
+	conn.SetSessionToken(session.Token)
+	conn.SetSessionExpiresAt(session.ExpiresAt)
+
+	resp := model.NewWebSocketResponse(model.StatusOk, r.Seq, map[string]interface{}{"expires_at": session.ExpiresAt})
+	hub := conn.App.GetHubForUserId(conn.UserId)
+	if hub == nil {
+		return
+	}
+	hub.SendMessage(conn, resp)
+}
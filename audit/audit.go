@@ -41,6 +41,10 @@ func (a *Audit) LogRecord(level mlog.Level, rec Record) {
 		mlog.String(KeyIPAddress, rec.IPAddress),
 	}
 
+	if rec.EventData.NewData != nil {
+		flds = append(flds, mlog.Any(KeyEventData, rec.EventData))
+	}
+
 	for k, v := range rec.Meta {
 		flds = append(flds, mlog.Any(k, v))
 	}
@@ -14,6 +14,7 @@ const (
 	KeyClient    = "client"
 	KeyIPAddress = "ip_address"
 	KeyClusterID = "cluster_id"
+	KeyEventData = "event_data"
 
 	Success = "success"
 	Attempt = "attempt"
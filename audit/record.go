@@ -20,9 +20,16 @@ type Record struct {
 	Client    string
 	IPAddress string
 	Meta      Meta
+	EventData EventData
 	metaConv  []FuncMetaTypeConv
 }
 
+// EventData holds the structured data describing the effect of the audited event.
+type EventData struct {
+	// NewData is the state resulting from the event, or what it changed.
+	NewData interface{} `json:"new_data,omitempty"`
+}
+
 // Success marks the audit record status as successful.
 func (rec *Record) Success() {
 	rec.Status = Success
@@ -52,6 +59,11 @@ func (rec *Record) AddMeta(name string, val interface{}) {
 	rec.Meta[name] = val
 }
 
+// AddEventNewData sets the state resulting from the audited event, or what it changed.
+func (rec *Record) AddEventNewData(val interface{}) {
+	rec.EventData.NewData = val
+}
+
 // AddMetaTypeConverter adds a function capable of converting meta field types
 // into something more suitable for serialization.
 func (rec *Record) AddMetaTypeConverter(f FuncMetaTypeConv) {
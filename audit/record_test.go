@@ -74,3 +74,13 @@ func TestRecord_AddMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestRecord_AddEventNewData(t *testing.T) {
+	rec := &Record{}
+	require.Nil(t, rec.EventData.NewData)
+
+	diff := []string{"ServiceSettings.ReadTimeout"}
+	rec.AddEventNewData(diff)
+	require.Equal(t, diff, rec.EventData.NewData)
+	require.Empty(t, rec.Meta)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost-server/v6/config"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Commands related to the configuration",
+}
+
+var ConfigExportEnvCmd = &cobra.Command{
+	Use:   "export-env",
+	Short: "Export the configuration as environment variables",
+	Long: `Print the effective configuration, environment overrides included, as environment variables.
+
+Each line has the form MM_SECTION_SETTING=value and can be used as an environment file. Maps and values spanning several lines can't be set through the environment and are left out.`,
+	Example: `  # export the settings changed from their defaults
+  $ mattermost config export-env --changed-only > mattermost.env`,
+	Args: cobra.NoArgs,
+	RunE: configExportEnvCmdF,
+}
+
+var ConfigSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the schema of the configuration",
+	Long:  "Print the type, default value and constraints of each setting of the configuration as JSON.",
+	Args:  cobra.NoArgs,
+	RunE:  configSchemaCmdF,
+}
+
+func init() {
+	ConfigExportEnvCmd.Flags().Bool("changed-only", false, "Only export the settings whose value differs from the default.")
+
+	ConfigCmd.AddCommand(
+		ConfigExportEnvCmd,
+		ConfigSchemaCmd,
+	)
+
+	RootCmd.AddCommand(
+		ConfigCmd,
+	)
+}
+
+func configExportEnvCmdF(command *cobra.Command, args []string) error {
+	cfgDSN := getConfigDSN(command, config.GetEnvironment())
+	cfgStore, err := config.NewStoreFromDSN(cfgDSN, true, nil, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to load configuration")
+	}
+	defer cfgStore.Close()
+
+	var defaults *model.Config
+	if changedOnly, _ := command.Flags().GetBool("changed-only"); changedOnly {
+		defaults = &model.Config{}
+		defaults.SetDefaults()
+	}
+
+	for _, line := range config.ExportEnvironment(cfgStore.Get(), defaults) {
+		CommandPrintln(line)
+	}
+
+	return nil
+}
+
+func configSchemaCmdF(command *cobra.Command, args []string) error {
+	schema, err := json.MarshalIndent(config.GenerateSchema(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the configuration schema")
+	}
+
+	CommandPrettyPrintln(string(schema))
+
+	return nil
+}
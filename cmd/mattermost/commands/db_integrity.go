@@ -0,0 +1,220 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store/sqlstore"
+)
+
+var DbIntegrityCmd = &cobra.Command{
+	Use:   "integrity",
+	Short: "Check and repair the integrity of the database",
+	Long: `Check the relational integrity of the database, reporting the records that reference a record that no longer exists.
+
+With --repair, the orphaned records of every relation can be deleted, skipped or, for the relations that allow it, have their reference cleared. The strategy is asked for each relation unless --auto is set, in which case the default strategy and the per relation overrides are applied.`,
+	Example: `  # print the report as JSON
+  $ mattermost db integrity --json
+
+  # choose how to repair each relation
+  $ mattermost db integrity --repair
+
+  # delete every orphaned record, except replies to deleted threads which become root posts
+  $ mattermost db integrity --repair --auto --strategy delete --relation-strategy Posts.RootId=clear`,
+	Args: cobra.NoArgs,
+	RunE: dbIntegrityCmdF,
+}
+
+func init() {
+	DbIntegrityCmd.Flags().Bool("repair", false, "Repair the orphaned records.")
+	DbIntegrityCmd.Flags().Bool("auto", false, "Repair without asking for a strategy for each relation.")
+	DbIntegrityCmd.Flags().Bool("confirm", false, "Confirm a DB backup has been performed.")
+	DbIntegrityCmd.Flags().String("strategy", model.IntegrityRepairStrategySkip, "Default repair strategy used with --auto: skip, delete or clear.")
+	DbIntegrityCmd.Flags().StringToString("relation-strategy", nil, "Repair strategy of a relation used with --auto, in the form Child.ParentIdAttr=strategy.")
+	DbIntegrityCmd.Flags().Bool("json", false, "Print the report as JSON.")
+
+	DbCmd.AddCommand(
+		DbIntegrityCmd,
+	)
+}
+
+type integrityReportEntry struct {
+	Relation     string                 `json:"relation"`
+	ParentName   string                 `json:"parent_name"`
+	ChildName    string                 `json:"child_name"`
+	ParentIdAttr string                 `json:"parent_id_attr"`
+	ChildIdAttr  string                 `json:"child_id_attr"`
+	Records      []model.OrphanedRecord `json:"records"`
+	Error        string                 `json:"error,omitempty"`
+	Strategy     string                 `json:"strategy,omitempty"`
+	Repaired     int64                  `json:"repaired"`
+	RepairError  string                 `json:"repair_error,omitempty"`
+}
+
+func isValidRepairStrategy(strategy string) bool {
+	switch strategy {
+	case model.IntegrityRepairStrategySkip, model.IntegrityRepairStrategyDelete, model.IntegrityRepairStrategyClear:
+		return true
+	}
+	return false
+}
+
+func dbIntegrityCmdF(command *cobra.Command, args []string) error {
+	repairFlag, _ := command.Flags().GetBool("repair")
+	autoFlag, _ := command.Flags().GetBool("auto")
+	confirmFlag, _ := command.Flags().GetBool("confirm")
+	jsonFlag, _ := command.Flags().GetBool("json")
+	strategy, _ := command.Flags().GetString("strategy")
+	relationStrategies, _ := command.Flags().GetStringToString("relation-strategy")
+
+	if !isValidRepairStrategy(strategy) {
+		return errors.Errorf("invalid strategy %q", strategy)
+	}
+	for relation, relationStrategy := range relationStrategies {
+		if !isValidRepairStrategy(relationStrategy) {
+			return errors.Errorf("invalid strategy %q for relation %s", relationStrategy, relation)
+		}
+	}
+	if repairFlag && jsonFlag && (!autoFlag || !confirmFlag) {
+		return errors.New("--auto and --confirm are required to repair with --json")
+	}
+
+	store, err := initMaintenanceStore(command)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var report []*integrityReportEntry
+	for result := range store.CheckIntegrity() {
+		data, ok := result.Data.(model.RelationalIntegrityCheckData)
+		if !ok && result.Err == nil {
+			continue
+		}
+
+		entry := &integrityReportEntry{
+			ParentName:   data.ParentName,
+			ChildName:    data.ChildName,
+			ParentIdAttr: data.ParentIdAttr,
+			ChildIdAttr:  data.ChildIdAttr,
+			Records:      data.Records,
+		}
+		if ok {
+			entry.Relation = data.Relation()
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		report = append(report, entry)
+
+		if !jsonFlag {
+			printIntegrityReportEntry(entry)
+		}
+	}
+
+	if repairFlag {
+		if !confirmFlag {
+			var confirm string
+			CommandPrettyPrintln("Have you performed a database backup? (YES/NO): ")
+			fmt.Scanln(&confirm)
+
+			if confirm != "YES" {
+				return errors.New("ABORTED: You did not answer YES exactly, in all capitals.")
+			}
+		}
+
+		for _, entry := range report {
+			if len(entry.Records) == 0 {
+				continue
+			}
+
+			entry.Strategy = strategy
+			if relationStrategy, ok := relationStrategies[entry.Relation]; ok {
+				entry.Strategy = relationStrategy
+			}
+			if !autoFlag {
+				entry.Strategy = askRepairStrategy(entry)
+			}
+
+			data := model.RelationalIntegrityCheckData{
+				ParentName:   entry.ParentName,
+				ChildName:    entry.ChildName,
+				ParentIdAttr: entry.ParentIdAttr,
+				ChildIdAttr:  entry.ChildIdAttr,
+				Records:      entry.Records,
+			}
+			entry.Repaired, err = sqlstore.RepairRelationalIntegrity(store, data, entry.Strategy)
+			if err != nil {
+				entry.RepairError = err.Error()
+			}
+
+			if !jsonFlag {
+				if entry.RepairError != "" {
+					CommandPrintErrorln(fmt.Sprintf("%s: %s", entry.Relation, entry.RepairError))
+				} else if entry.Strategy != model.IntegrityRepairStrategySkip {
+					CommandPrettyPrintln(fmt.Sprintf("%s: repaired %d records (%s)", entry.Relation, entry.Repaired, entry.Strategy))
+				}
+			}
+		}
+	}
+
+	if jsonFlag {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the report")
+		}
+		CommandPrintln(string(b))
+	}
+
+	return nil
+}
+
+func printIntegrityReportEntry(entry *integrityReportEntry) {
+	if entry.Error != "" {
+		CommandPrintErrorln("Integrity check failed: " + entry.Error)
+		return
+	}
+	if len(entry.Records) == 0 {
+		return
+	}
+
+	CommandPrettyPrintln(fmt.Sprintf("%s: %d records reference a missing %s record", entry.Relation, len(entry.Records), entry.ParentName))
+	for _, record := range entry.Records {
+		var parentId, childId string
+		if record.ParentId != nil {
+			parentId = *record.ParentId
+		}
+		if record.ChildId != nil {
+			childId = *record.ChildId
+		}
+		if childId != "" {
+			CommandPrettyPrintln(fmt.Sprintf("  %s %s -> %s %s", entry.ChildIdAttr, childId, entry.ParentIdAttr, parentId))
+		} else {
+			CommandPrettyPrintln(fmt.Sprintf("  %s %s", entry.ParentIdAttr, parentId))
+		}
+	}
+}
+
+func askRepairStrategy(entry *integrityReportEntry) string {
+	for {
+		var answer string
+		CommandPrettyPrintln(fmt.Sprintf("Repair the %d orphaned records of %s? (skip/delete/clear) [%s]: ", len(entry.Records), entry.Relation, entry.Strategy))
+		fmt.Scanln(&answer)
+
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer == "" {
+			return entry.Strategy
+		}
+		if isValidRepairStrategy(answer) {
+			return answer
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost-server/v6/config"
+	"github.com/mattermost/mattermost-server/v6/store/sqlstore"
+)
+
+var DbMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Database maintenance operations",
+}
+
+var DbOrphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "Find and optionally delete orphaned rows",
+	Long: `Count the rows that reference a record that no longer exists: files whose post was deleted
+and channel memberships of permanently deleted users. With --delete, the rows are removed in batches.`,
+	Example: `  # report the orphaned rows
+  $ mattermost db maintenance orphans
+
+  # delete them, 500 rows at a time
+  $ mattermost db maintenance orphans --delete --batch-size 500`,
+	Args: cobra.NoArgs,
+	RunE: dbOrphansCmdF,
+}
+
+var DbBloatCmd = &cobra.Command{
+	Use:   "bloat",
+	Short: "Report the storage used by every table",
+	Long: `List the tables with their estimated live rows, their total size and the space that can be
+reclaimed: dead rows for Postgres and free bytes for MySQL.`,
+	Args: cobra.NoArgs,
+	RunE: dbBloatCmdF,
+}
+
+var DbReindexCmd = &cobra.Command{
+	Use:   "reindex [tables...]",
+	Short: "Rebuild the indexes of tables without locking them",
+	Long: `Rebuild the indexes of the given tables, or of every table if none is given. Postgres 12 or
+later is required, and the operation fails rather than locking a table if it cannot be done online.`,
+	Example: `  $ mattermost db maintenance reindex Posts FileInfo`,
+	RunE:    dbReindexCmdF,
+}
+
+func init() {
+	DbOrphansCmd.Flags().Bool("delete", false, "Delete the orphaned rows.")
+	DbOrphansCmd.Flags().Bool("confirm", false, "Confirm you really want to delete the orphaned rows and a DB backup has been performed.")
+	DbOrphansCmd.Flags().Int("batch-size", 1000, "Number of rows deleted at a time.")
+
+	DbMaintenanceCmd.AddCommand(
+		DbOrphansCmd,
+		DbBloatCmd,
+		DbReindexCmd,
+	)
+
+	DbCmd.AddCommand(
+		DbMaintenanceCmd,
+	)
+}
+
+func initMaintenanceStore(command *cobra.Command) (*sqlstore.SqlStore, error) {
+	cfgDSN := getConfigDSN(command, config.GetEnvironment())
+	cfgStore, err := config.NewStoreFromDSN(cfgDSN, true, nil, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load configuration")
+	}
+	defer cfgStore.Close()
+
+	return sqlstore.New(cfgStore.Get().SqlSettings, nil), nil
+}
+
+func dbOrphansCmdF(command *cobra.Command, args []string) error {
+	deleteFlag, _ := command.Flags().GetBool("delete")
+	confirmFlag, _ := command.Flags().GetBool("confirm")
+	batchSize, _ := command.Flags().GetInt("batch-size")
+	if batchSize <= 0 {
+		return errors.New("batch-size must be positive")
+	}
+
+	store, err := initMaintenanceStore(command)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	orphans, err := store.CountOrphanedRows()
+	if err != nil {
+		return errors.Wrap(err, "failed to count orphaned rows")
+	}
+
+	var total int64
+	for _, orphan := range orphans {
+		CommandPrettyPrintln(fmt.Sprintf("%s: %d orphaned rows in %s", orphan.Name, orphan.Count, orphan.Table))
+		total += orphan.Count
+	}
+
+	if !deleteFlag || total == 0 {
+		return nil
+	}
+
+	if !confirmFlag {
+		var confirm string
+		CommandPrettyPrintln("Have you performed a database backup? (YES/NO): ")
+		fmt.Scanln(&confirm)
+
+		if confirm != "YES" {
+			return errors.New("ABORTED: You did not answer YES exactly, in all capitals.")
+		}
+	}
+
+	for _, orphan := range orphans {
+		if orphan.Count == 0 {
+			continue
+		}
+
+		count := orphan.Count
+		deleted, err := store.DeleteOrphanedRows(orphan.Name, batchSize, func(deleted int64) {
+			CommandPrettyPrintln(fmt.Sprintf("%s: deleted %d/%d rows", orphan.Name, deleted, count))
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete orphaned rows after deleting %d rows", deleted)
+		}
+	}
+
+	CommandPrettyPrintln("Orphaned rows successfully deleted")
+
+	return nil
+}
+
+func dbBloatCmdF(command *cobra.Command, args []string) error {
+	store, err := initMaintenanceStore(command)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	tables, err := store.GetTableBloat()
+	if err != nil {
+		return errors.Wrap(err, "failed to get table bloat")
+	}
+
+	CommandPrettyPrintln(fmt.Sprintf("%-40s %12s %12s %14s %14s", "TABLE", "LIVE ROWS", "DEAD ROWS", "TOTAL BYTES", "FREE BYTES"))
+	for _, table := range tables {
+		CommandPrettyPrintln(fmt.Sprintf("%-40s %12d %12d %14d %14d", table.Name, table.LiveRows, table.DeadRows, table.TotalBytes, table.FreeBytes))
+	}
+
+	return nil
+}
+
+func dbReindexCmdF(command *cobra.Command, args []string) error {
+	store, err := initMaintenanceStore(command)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	tableNames := args
+	if len(tableNames) == 0 {
+		tables, err := store.GetTableBloat()
+		if err != nil {
+			return errors.Wrap(err, "failed to list tables")
+		}
+		for _, table := range tables {
+			tableNames = append(tableNames, table.Name)
+		}
+	}
+
+	var failed []string
+	for i, table := range tableNames {
+		CommandPrettyPrintln(fmt.Sprintf("[%d/%d] Reindexing %s", i+1, len(tableNames), table))
+		if err := store.ReindexTable(table); err != nil {
+			CommandPrintErrorln(err.Error())
+			failed = append(failed, table)
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("failed to reindex %s", strings.Join(failed, ", "))
+	}
+
+	CommandPrettyPrintln("Tables successfully reindexed")
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -15,9 +16,14 @@ import (
 func GenerateClientConfig(c *model.Config, telemetryID string, license *model.License) map[string]string {
 	props := GenerateLimitedClientConfig(c, telemetryID, license)
 
+	// The events of the clients are sent to the diagnostics sink through the server, which keeps
+	// its URL and key.
+	props["DiagnosticsSinkEnabled"] = strconv.FormatBool(*c.LogSettings.DiagnosticsSinkURL != "")
+
 	props["EnableCustomUserStatuses"] = strconv.FormatBool(*c.TeamSettings.EnableCustomUserStatuses)
 	props["EnableUserDeactivation"] = strconv.FormatBool(*c.TeamSettings.EnableUserDeactivation)
 	props["RestrictDirectMessage"] = *c.TeamSettings.RestrictDirectMessage
+	props["EnableDirectMessageRequests"] = strconv.FormatBool(*c.TeamSettings.EnableDirectMessageRequests)
 	props["TeammateNameDisplay"] = *c.TeamSettings.TeammateNameDisplay
 	props["LockTeammateNameDisplay"] = strconv.FormatBool(*c.TeamSettings.LockTeammateNameDisplay)
 	props["ExperimentalPrimaryTeam"] = *c.TeamSettings.ExperimentalPrimaryTeam
@@ -78,6 +84,10 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 	props["SQLDriverName"] = *c.SqlSettings.DriverName
 
 	props["EnableEmojiPicker"] = strconv.FormatBool(*c.ServiceSettings.EnableEmojiPicker)
+	props["MaxCustomEmojiPerTeam"] = strconv.FormatInt(int64(*c.ServiceSettings.MaxCustomEmojiPerTeam), 10)
+	props["MaxPinnedPostsPerChannel"] = strconv.FormatInt(int64(*c.ServiceSettings.MaxPinnedPostsPerChannel), 10)
+	props["EnableReminders"] = strconv.FormatBool(*c.ServiceSettings.EnableReminders)
+	props["EnableChannelEvents"] = strconv.FormatBool(*c.ServiceSettings.EnableChannelEvents)
 	props["EnableGifPicker"] = strconv.FormatBool(*c.ServiceSettings.EnableGifPicker)
 	props["GfycatApiKey"] = *c.ServiceSettings.GfycatAPIKey
 	props["GfycatApiSecret"] = *c.ServiceSettings.GfycatAPISecret
@@ -87,6 +97,7 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 	props["EnableConfirmNotificationsToChannel"] = strconv.FormatBool(*c.TeamSettings.EnableConfirmNotificationsToChannel)
 	props["TimeBetweenUserTypingUpdatesMilliseconds"] = strconv.FormatInt(*c.ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds, 10)
 	props["EnableUserTypingMessages"] = strconv.FormatBool(*c.ServiceSettings.EnableUserTypingMessages)
+	props["TypingMessagesMaxChannelMembers"] = strconv.FormatInt(*c.ServiceSettings.TypingMessagesMaxChannelMembers, 10)
 	props["EnableChannelViewedMessages"] = strconv.FormatBool(*c.ServiceSettings.EnableChannelViewedMessages)
 
 	props["RunJobs"] = strconv.FormatBool(*c.JobSettings.RunJobs)
@@ -308,6 +319,19 @@ func GenerateLimitedClientConfig(c *model.Config, telemetryID string, license *m
 	props["EnableGuestAccounts"] = strconv.FormatBool(*c.GuestAccountsSettings.Enable)
 	props["GuestAccountsEnforceMultifactorAuthentication"] = strconv.FormatBool(*c.GuestAccountsSettings.EnforceMultifactorAuthentication)
 
+	openIdConnectProviders := []*model.OpenIdConnectProviderButton{}
+	for _, provider := range c.OpenIdConnectSettings.Providers {
+		if provider.Enable {
+			openIdConnectProviders = append(openIdConnectProviders, &model.OpenIdConnectProviderButton{
+				Service:     provider.Service(),
+				DisplayName: provider.DisplayName,
+				ButtonColor: provider.ButtonColor,
+			})
+		}
+	}
+	openIdConnectProvidersJSON, _ := json.Marshal(openIdConnectProviders)
+	props["OpenIdConnectProviders"] = string(openIdConnectProvidersJSON)
+
 	if license != nil {
 		if *license.Features.LDAP {
 			props["EnableLdap"] = strconv.FormatBool(*c.LdapSettings.Enable)
@@ -264,3 +264,28 @@ func TestGetLimitedClientConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestClientConfigDiagnosticsSink(t *testing.T) {
+	t.Parallel()
+
+	cfg := &model.Config{
+		LogSettings: model.LogSettings{
+			DiagnosticsSinkURL: model.NewString("https://analytics.example.com"),
+			DiagnosticsSinkKey: model.NewString("sinkkey"),
+		},
+	}
+	cfg.SetDefaults()
+
+	for name, configMap := range map[string]map[string]string{
+		"limited": GenerateLimitedClientConfig(cfg, "", nil),
+		"full":    GenerateClientConfig(cfg, "", nil),
+	} {
+		for key, value := range configMap {
+			assert.NotContains(t, value, "sinkkey", "%s config exposes the key of the sink as %s", name, key)
+			assert.NotContains(t, value, "analytics.example.com", "%s config exposes the URL of the sink as %s", name, key)
+		}
+	}
+
+	assert.NotContains(t, GenerateLimitedClientConfig(cfg, "", nil), "DiagnosticsSinkEnabled")
+	assert.Equal(t, "true", GenerateClientConfig(cfg, "", nil)["DiagnosticsSinkEnabled"])
+}
@@ -32,6 +32,12 @@ var configSensitivePaths = map[string]bool{
 	"GoogleSettings.Secret":                                  true,
 	"Office365Settings.Secret":                               true,
 	"OpenIdSettings.Secret":                                  true,
+	"OpenIdConnectSettings.Providers":                        true,
+	"TurnSettings.Secret":                                    true,
+	"ChangeDataCaptureSettings.URL":                          true,
+	"ChangeDataCaptureSettings.Password":                     true,
+	"EventBusSettings.URL":                                   true,
+	"LogSettings.DiagnosticsSinkKey":                         true,
 	"ElasticsearchSettings.Password":                         true,
 	"MessageExportSettings.GlobalRelaySettings.SMTPUsername": true,
 	"MessageExportSettings.GlobalRelaySettings.SMTPPassword": true,
@@ -6,6 +6,7 @@ package config
 import (
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -87,6 +88,22 @@ func applyEnvironmentMap(inputConfig *model.Config, env map[string]string) *mode
 	return appliedConfig
 }
 
+// applyFeatureFlagOverrides applies the feature flag overrides set by a system admin on top of
+// the configured feature flags. Flags also set through environment variables keep the value
+// from the environment.
+func applyFeatureFlagOverrides(cfg *model.Config, env map[string]string) {
+	if cfg.FeatureFlags == nil {
+		return
+	}
+
+	for name, value := range cfg.FeatureFlagOverrides {
+		if _, ok := env["MM_FEATUREFLAGS_"+strings.ToUpper(name)]; ok {
+			continue
+		}
+		cfg.FeatureFlags.SetValue(name, value)
+	}
+}
+
 // generateEnvironmentMap creates a map[string]interface{} containing true at the leaves mirroring the
 // configuration structure so the client can know which env variables are overridden
 func generateEnvironmentMap(env map[string]string, filter func(reflect.StructField) bool) map[string]interface{} {
@@ -186,3 +203,79 @@ func getVal(src interface{}, path []string) reflect.Value {
 	}
 	return val
 }
+
+// ExportEnvironment returns the environment variables setting the configuration to the given
+// one, as sorted KEY=value lines. Only the values that can be set through the environment are
+// exported, which excludes maps and values spanning several lines. If defaults is not nil, the
+// values equal to their default are left out.
+func ExportEnvironment(cfg, defaults *model.Config) []string {
+	var defaultsValue reflect.Value
+	if defaults != nil {
+		defaultsValue = reflect.ValueOf(defaults).Elem()
+	}
+
+	lines := exportEnvironmentWithBaseKey(reflect.ValueOf(cfg).Elem(), defaultsValue, "MM")
+	sort.Strings(lines)
+	return lines
+}
+
+func exportEnvironmentWithBaseKey(cfg, defaults reflect.Value, base string) []string {
+	var lines []string
+	rType := cfg.Type()
+	for i := 0; i < rType.NumField(); i++ {
+		rField := rType.Field(i)
+		if rField.PkgPath != "" {
+			continue
+		}
+
+		key := strings.ToUpper(base + "_" + rField.Name)
+		rFieldValue := cfg.Field(i)
+		var rDefaultValue reflect.Value
+		if defaults.IsValid() {
+			rDefaultValue = defaults.Field(i)
+		}
+
+		if rFieldValue.Kind() == reflect.Struct {
+			lines = append(lines, exportEnvironmentWithBaseKey(rFieldValue, rDefaultValue, key)...)
+			continue
+		}
+
+		if rDefaultValue.IsValid() && reflect.DeepEqual(rFieldValue.Interface(), rDefaultValue.Interface()) {
+			continue
+		}
+
+		if rFieldValue.Kind() == reflect.Ptr {
+			if rFieldValue.IsNil() {
+				continue
+			}
+			rFieldValue = rFieldValue.Elem()
+		}
+
+		// The values are formatted the way applyEnvKey parses them back.
+		var value string
+		switch rFieldValue.Kind() {
+		case reflect.String:
+			value = rFieldValue.String()
+		case reflect.Bool:
+			value = strconv.FormatBool(rFieldValue.Bool())
+		case reflect.Int, reflect.Int64:
+			value = strconv.FormatInt(rFieldValue.Int(), 10)
+		case reflect.Slice:
+			values, ok := rFieldValue.Interface().([]string)
+			if !ok {
+				continue
+			}
+			value = strings.Join(values, " ")
+		default:
+			continue
+		}
+
+		if strings.Contains(value, "\n") {
+			continue
+		}
+
+		lines = append(lines, key+"="+value)
+	}
+
+	return lines
+}
@@ -4,8 +4,11 @@
 package config
 
 import (
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -126,3 +129,37 @@ func TestRemoveEnvOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestExportEnvironment(t *testing.T) {
+	// The defaults include randomly generated keys, so both configs start from the same ones.
+	defaults := defaultConfig()
+	cfg := defaults.Clone()
+	*cfg.ServiceSettings.SiteURL = "http://exported"
+	*cfg.ServiceSettings.EnableDeveloper = true
+	*cfg.ClusterSettings.GossipPort = 500
+	cfg.SqlSettings.DataSourceReplicas = []string{"replica1", "replica2"}
+
+	t.Run("round trip", func(t *testing.T) {
+		env := make(map[string]string)
+		for _, line := range ExportEnvironment(cfg, nil) {
+			kv := strings.SplitN(line, "=", 2)
+			require.Len(t, kv, 2, line)
+			env[kv[0]] = kv[1]
+		}
+
+		imported := applyEnvironmentMap(defaultConfig(), env)
+		assert.Equal(t, "http://exported", *imported.ServiceSettings.SiteURL)
+		assert.True(t, *imported.ServiceSettings.EnableDeveloper)
+		assert.Equal(t, 500, *imported.ClusterSettings.GossipPort)
+		assert.Equal(t, []string{"replica1", "replica2"}, imported.SqlSettings.DataSourceReplicas)
+	})
+
+	t.Run("changed only", func(t *testing.T) {
+		lines := ExportEnvironment(cfg, defaults)
+		assert.Contains(t, lines, "MM_SERVICESETTINGS_SITEURL=http://exported")
+		assert.Contains(t, lines, "MM_CLUSTERSETTINGS_GOSSIPPORT=500")
+		assert.Contains(t, lines, "MM_SQLSETTINGS_DATASOURCEREPLICAS=replica1 replica2")
+		assert.Len(t, lines, 4)
+		assert.True(t, sort.StringsAreSorted(lines))
+	})
+}
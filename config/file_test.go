@@ -1310,6 +1310,57 @@ func TestFileStoreSetReadOnlyFF(t *testing.T) {
 	})
 }
 
+func TestFileStoreFeatureFlagOverrides(t *testing.T) {
+	store, tearDown := setupConfigFileStore(t, minimalConfig)
+	defer tearDown()
+
+	newCfg := store.Get().Clone()
+	newCfg.FeatureFlagOverrides = map[string]string{"TestFeature": "overridden", "TestBoolFeature": "on"}
+
+	// store has read-only FF by default, overrides still apply.
+	_, _, err := store.Set(newCfg)
+	require.NoError(t, err)
+
+	config := store.Get()
+	require.Equal(t, "overridden", config.FeatureFlags.TestFeature)
+	require.True(t, config.FeatureFlags.TestBoolFeature)
+
+	t.Run("overrides survive a reload", func(t *testing.T) {
+		require.NoError(t, store.Load())
+		config := store.Get()
+		require.Equal(t, "overridden", config.FeatureFlags.TestFeature)
+		require.True(t, config.FeatureFlags.TestBoolFeature)
+	})
+
+	t.Run("removing an override restores the flag", func(t *testing.T) {
+		newCfg := store.Get().Clone()
+		delete(newCfg.FeatureFlagOverrides, "TestFeature")
+
+		_, _, err := store.Set(newCfg)
+		require.NoError(t, err)
+
+		config := store.Get()
+		require.Equal(t, minimalConfig.FeatureFlags.TestFeature, config.FeatureFlags.TestFeature)
+		require.True(t, config.FeatureFlags.TestBoolFeature)
+	})
+
+	t.Run("environment takes precedence", func(t *testing.T) {
+		os.Setenv("MM_FEATUREFLAGS_TESTBOOLFEATURE", "false")
+		defer os.Unsetenv("MM_FEATUREFLAGS_TESTBOOLFEATURE")
+
+		require.NoError(t, store.Load())
+		require.False(t, store.Get().FeatureFlags.TestBoolFeature)
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		newCfg := store.Get().Clone()
+		newCfg.FeatureFlagOverrides = map[string]string{"NotAFeature": "true"}
+
+		_, _, err := store.Set(newCfg)
+		require.Error(t, err)
+	})
+}
+
 func TestResolveConfigPath(t *testing.T) {
 	t.Run("should be able to resolve an absolute path", func(t *testing.T) {
 		cf, err := ioutil.TempFile("", "config-test.json")
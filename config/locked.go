@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const (
+	// LockedPathsEnvVar lists the locked configuration paths, separated by commas, e.g.
+	// "SqlSettings,ServiceSettings.ListenAddress".
+	LockedPathsEnvVar = "MM_LOCKED_CONFIG_PATHS"
+	// LockedPathsFileEnvVar is the name of a file listing the locked configuration paths, one
+	// per line. Empty lines and lines starting with # are ignored.
+	LockedPathsFileEnvVar = "MM_LOCKED_CONFIG_PATHS_FILE"
+)
+
+// LockedPathError is returned when a change to the configuration touches a locked path.
+type LockedPathError struct {
+	Path string
+}
+
+func (e *LockedPathError) Error() string {
+	return fmt.Sprintf("the configuration path %s is locked", e.Path)
+}
+
+// readLockedPaths returns the locked configuration paths declared by the deployment through the
+// environment, with the names of the settings as written in the configuration.
+func readLockedPaths() ([]string, error) {
+	declared := strings.Split(os.Getenv(LockedPathsEnvVar), ",")
+
+	if fileName := os.Getenv(LockedPathsFileEnvVar); fileName != "" {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", fileName)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); !strings.HasPrefix(line, "#") {
+				declared = append(declared, line)
+			}
+		}
+	}
+
+	var lockedPaths []string
+	for _, path := range declared {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		canonical, ok := canonicalConfigPath(path)
+		if !ok {
+			return nil, errors.Errorf("unknown locked configuration path %s", path)
+		}
+		lockedPaths = append(lockedPaths, canonical)
+	}
+
+	return lockedPaths, nil
+}
+
+// canonicalConfigPath returns the path with the names of the settings as written in the
+// configuration, matching the names case-insensitively. It returns false if the path doesn't
+// lead to a section or a setting of the configuration.
+func canonicalConfigPath(path string) (string, bool) {
+	rType := reflect.TypeOf(model.Config{})
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		if rType.Kind() == reflect.Ptr {
+			rType = rType.Elem()
+		}
+		if rType.Kind() != reflect.Struct {
+			return "", false
+		}
+
+		field, ok := rType.FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, part)
+		})
+		if !ok {
+			return "", false
+		}
+
+		parts[i] = field.Name
+		rType = field.Type
+	}
+
+	return strings.Join(parts, "."), true
+}
+
+// isLockedPath returns whether the path is locked, either itself or by a locked section it is in.
+func isLockedPath(lockedPaths []string, path string) bool {
+	for _, lockedPath := range lockedPaths {
+		if path == lockedPath || strings.HasPrefix(path, lockedPath+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLockedPaths returns the configuration paths locked by the deployment.
+func (s *Store) GetLockedPaths() []string {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
+	return append([]string{}, s.lockedPaths...)
+}
+
+// CheckLockedPaths returns a *LockedPathError if the given configuration changes a locked path
+// of the current configuration. Sanitized settings are not considered changed.
+func (s *Store) CheckLockedPaths(newCfg *model.Config) error {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
+	newCfg = newCfg.Clone()
+	newCfg.SetDefaults()
+	desanitize(s.config, newCfg)
+
+	return s.checkLockedPaths(s.config, newCfg)
+}
+
+// checkLockedPaths returns a *LockedPathError if the new configuration, with its defaults set and
+// desanitized, changes a locked path of the old one. The caller must hold the config lock.
+func (s *Store) checkLockedPaths(oldCfg, newCfg *model.Config) error {
+	if len(s.lockedPaths) == 0 {
+		return nil
+	}
+
+	diffs, err := Diff(oldCfg, newCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to diff configs")
+	}
+
+	for _, diff := range diffs {
+		if isLockedPath(s.lockedPaths, diff.Path) {
+			return &LockedPathError{Path: diff.Path}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLockedPaths(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		lockedPaths, err := readLockedPaths()
+		require.NoError(t, err)
+		assert.Empty(t, lockedPaths)
+	})
+
+	t.Run("from the environment and a file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "locked")
+		require.NoError(t, os.WriteFile(fileName, []byte("# Managed by the infrastructure\nsqlsettings\n\nFileSettings.DriverName\n"), 0600))
+
+		os.Setenv(LockedPathsEnvVar, "ServiceSettings.ListenAddress, servicesettings.siteurl")
+		defer os.Unsetenv(LockedPathsEnvVar)
+		os.Setenv(LockedPathsFileEnvVar, fileName)
+		defer os.Unsetenv(LockedPathsFileEnvVar)
+
+		lockedPaths, err := readLockedPaths()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ServiceSettings.ListenAddress", "ServiceSettings.SiteURL", "SqlSettings", "FileSettings.DriverName"}, lockedPaths)
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		os.Setenv(LockedPathsEnvVar, "ServiceSettings.Unknown")
+		defer os.Unsetenv(LockedPathsEnvVar)
+
+		_, err := readLockedPaths()
+		require.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		os.Setenv(LockedPathsFileEnvVar, filepath.Join(t.TempDir(), "missing"))
+		defer os.Unsetenv(LockedPathsFileEnvVar)
+
+		_, err := readLockedPaths()
+		require.Error(t, err)
+	})
+}
+
+func TestCheckLockedPaths(t *testing.T) {
+	os.Setenv(LockedPathsEnvVar, "SqlSettings,ServiceSettings.SiteURL")
+	defer os.Unsetenv(LockedPathsEnvVar)
+
+	store := NewTestMemoryStore()
+	defer store.Close()
+	assert.Equal(t, []string{"SqlSettings", "ServiceSettings.SiteURL"}, store.GetLockedPaths())
+
+	cfg := store.Get()
+	require.NoError(t, store.CheckLockedPaths(cfg))
+
+	unlocked := cfg.Clone()
+	*unlocked.TeamSettings.SiteName = "Unlocked"
+	require.NoError(t, store.CheckLockedPaths(unlocked))
+
+	sanitized := cfg.Clone()
+	sanitized.Sanitize()
+	require.NoError(t, store.CheckLockedPaths(sanitized))
+
+	locked := cfg.Clone()
+	*locked.ServiceSettings.SiteURL = "http://changed"
+	err := store.CheckLockedPaths(locked)
+	var lockedErr *LockedPathError
+	require.True(t, errors.As(err, &lockedErr))
+	assert.Equal(t, "ServiceSettings.SiteURL", lockedErr.Path)
+
+	lockedSection := cfg.Clone()
+	*lockedSection.SqlSettings.MaxIdleConns = *cfg.SqlSettings.MaxIdleConns + 1
+	err = store.CheckLockedPaths(lockedSection)
+	require.True(t, errors.As(err, &lockedErr))
+	assert.Equal(t, "SqlSettings.MaxIdleConns", lockedErr.Path)
+}
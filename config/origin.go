@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// GetOrigins returns where each value of the current configuration comes from: an environment
+// variable, the backing store or the defaults. The map mirrors the configuration structure, with
+// the origin at the leaves. If filter is not nil and returns false for a struct field, that field
+// will be omitted.
+func (s *Store) GetOrigins(filter func(reflect.StructField) bool) map[string]interface{} {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
+	defaults := &model.Config{}
+	defaults.SetDefaults()
+
+	return generateOriginMap(
+		reflect.ValueOf(s.configNoEnv).Elem(),
+		reflect.ValueOf(defaults).Elem(),
+		GetEnvironment(),
+		"MM",
+		backingStoreOrigin(s.backingStore),
+		filter,
+	)
+}
+
+// backingStoreOrigin returns the origin of the values read from the backing store.
+func backingStoreOrigin(backingStore BackingStore) string {
+	switch backingStore.(type) {
+	case *DatabaseStore:
+		return model.ConfigOriginDatabase
+	case *MemoryStore:
+		return model.ConfigOriginMemory
+	default:
+		return model.ConfigOriginFile
+	}
+}
+
+// generateOriginMap walks the configuration the same way as generateEnvironmentMap, so that both
+// agree on which values are overridden by an environment variable. A stored value equal to its
+// default is reported as a default.
+func generateOriginMap(cfg, defaults reflect.Value, env map[string]string, base, storeOrigin string, filter func(reflect.StructField) bool) map[string]interface{} {
+	rType := cfg.Type()
+	origins := make(map[string]interface{})
+	for i := 0; i < rType.NumField(); i++ {
+		rField := rType.Field(i)
+		if rField.PkgPath != "" || (filter != nil && !filter(rField)) {
+			continue
+		}
+
+		key := base + "_" + rField.Name
+		if rField.Type.Kind() == reflect.Struct {
+			if val := generateOriginMap(cfg.Field(i), defaults.Field(i), env, key, storeOrigin, filter); len(val) > 0 {
+				origins[rField.Name] = val
+			}
+			continue
+		}
+
+		switch {
+		case hasEnvKey(env, key):
+			origins[rField.Name] = model.ConfigOriginEnvironment
+		case reflect.DeepEqual(cfg.Field(i).Interface(), defaults.Field(i).Interface()):
+			origins[rField.Name] = model.ConfigOriginDefault
+		default:
+			origins[rField.Name] = storeOrigin
+		}
+	}
+
+	return origins
+}
+
+func hasEnvKey(env map[string]string, key string) bool {
+	_, ok := env[strings.ToUpper(key)]
+	return ok
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestGetOrigins(t *testing.T) {
+	os.Setenv("MM_SERVICESETTINGS_SITEURL", "http://override")
+	defer os.Unsetenv("MM_SERVICESETTINGS_SITEURL")
+
+	store := NewTestMemoryStore()
+	defer store.Close()
+
+	cfg := store.Get().Clone()
+	*cfg.TeamSettings.SiteName = "Origins"
+	_, _, err := store.Set(cfg)
+	require.NoError(t, err)
+
+	t.Run("all values", func(t *testing.T) {
+		origins := store.GetOrigins(nil)
+
+		serviceSettings := origins["ServiceSettings"].(map[string]interface{})
+		assert.Equal(t, model.ConfigOriginEnvironment, serviceSettings["SiteURL"])
+		assert.Equal(t, model.ConfigOriginDefault, serviceSettings["ListenAddress"])
+
+		teamSettings := origins["TeamSettings"].(map[string]interface{})
+		assert.Equal(t, model.ConfigOriginMemory, teamSettings["SiteName"])
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		origins := store.GetOrigins(func(structField reflect.StructField) bool {
+			return structField.Name != "ServiceSettings"
+		})
+
+		assert.NotContains(t, origins, "ServiceSettings")
+		assert.Contains(t, origins, "TeamSettings")
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// GenerateSchema returns the schema of the configuration: the type, default value and
+// constraints of each of its settings, in the order of the Config struct. It is generated from
+// the struct and its tags, so that tooling and the System Console can rely on it.
+func GenerateSchema() []*model.ConfigSchemaSetting {
+	defaults := &model.Config{}
+	defaults.SetDefaults()
+
+	return generateSchema(reflect.TypeOf(model.Config{}), reflect.ValueOf(defaults).Elem(), "")
+}
+
+func generateSchema(rType reflect.Type, defaults reflect.Value, base string) []*model.ConfigSchemaSetting {
+	var settings []*model.ConfigSchemaSetting
+	for i := 0; i < rType.NumField(); i++ {
+		rField := rType.Field(i)
+		if rField.PkgPath != "" {
+			continue
+		}
+
+		path := rField.Name
+		if base != "" {
+			path = base + "." + rField.Name
+		}
+
+		fieldType := rField.Type
+		fieldDefault := defaults.Field(i)
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+			if fieldDefault.IsNil() {
+				fieldDefault = reflect.Zero(fieldType)
+			} else {
+				fieldDefault = fieldDefault.Elem()
+			}
+		}
+
+		if fieldType.Kind() == reflect.Struct {
+			settings = append(settings, generateSchema(fieldType, fieldDefault, path)...)
+			continue
+		}
+
+		setting := &model.ConfigSchemaSetting{
+			Path:            path,
+			Type:            configSchemaType(fieldType),
+			Sensitive:       configSensitivePaths[path],
+			RestartRequired: rField.Tag.Get(model.ConfigRestartTagType) == "true",
+		}
+		if fieldType.Kind() == reflect.Slice {
+			setting.ItemType = configSchemaType(fieldType.Elem())
+		}
+		if enum, ok := rField.Tag.Lookup(model.ConfigEnumTagType); ok {
+			setting.EnumValues = strings.Split(enum, ",")
+		}
+		if access := rField.Tag.Get(model.ConfigAccessTagType); access != "" {
+			setting.Access = strings.Split(access, ",")
+		}
+
+		hasDefault := rField.Type.Kind() != reflect.Ptr || !defaults.Field(i).IsNil()
+		if (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Map) && fieldDefault.IsNil() {
+			hasDefault = false
+		}
+		if hasDefault && !setting.Sensitive {
+			setting.Default = fieldDefault.Interface()
+		}
+
+		settings = append(settings, setting)
+	}
+
+	return settings
+}
+
+func configSchemaType(rType reflect.Type) string {
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	switch rType.Kind() {
+	case reflect.String:
+		return model.ConfigSchemaTypeString
+	case reflect.Bool:
+		return model.ConfigSchemaTypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return model.ConfigSchemaTypeInteger
+	case reflect.Float32, reflect.Float64:
+		return model.ConfigSchemaTypeNumber
+	case reflect.Slice, reflect.Array:
+		return model.ConfigSchemaTypeArray
+	default:
+		return model.ConfigSchemaTypeObject
+	}
+}
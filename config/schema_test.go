@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	schema := GenerateSchema()
+	settings := make(map[string]*model.ConfigSchemaSetting, len(schema))
+	for _, setting := range schema {
+		settings[setting.Path] = setting
+	}
+
+	t.Run("types and defaults", func(t *testing.T) {
+		siteName := settings["TeamSettings.SiteName"]
+		require.NotNil(t, siteName)
+		assert.Equal(t, model.ConfigSchemaTypeString, siteName.Type)
+		assert.Equal(t, model.TeamSettingsDefaultSiteName, siteName.Default)
+		assert.Equal(t, []string{"site_customization"}, siteName.Access)
+
+		gossipPort := settings["ClusterSettings.GossipPort"]
+		require.NotNil(t, gossipPort)
+		assert.Equal(t, model.ConfigSchemaTypeInteger, gossipPort.Type)
+		assert.Equal(t, 8074, gossipPort.Default)
+		assert.True(t, gossipPort.RestartRequired)
+
+		replicas := settings["SqlSettings.DataSourceReplicas"]
+		require.NotNil(t, replicas)
+		assert.Equal(t, model.ConfigSchemaTypeArray, replicas.Type)
+		assert.Equal(t, model.ConfigSchemaTypeString, replicas.ItemType)
+
+		assert.Equal(t, model.ConfigSchemaTypeObject, settings["PluginSettings.Plugins"].Type)
+		assert.Contains(t, settings, "MessageExportSettings.GlobalRelaySettings.CustomerType")
+		assert.NotContains(t, settings, "ServiceSettings")
+	})
+
+	t.Run("sensitive settings have no default", func(t *testing.T) {
+		salt := settings["FileSettings.PublicLinkSalt"]
+		require.NotNil(t, salt)
+		assert.True(t, salt.Sensitive)
+		assert.Nil(t, salt.Default)
+	})
+
+	t.Run("defaults are within the enum values", func(t *testing.T) {
+		for _, setting := range schema {
+			if len(setting.EnumValues) == 0 {
+				continue
+			}
+			assert.Contains(t, setting.EnumValues, setting.Default, setting.Path)
+		}
+		assert.Equal(t, []string{model.DatabaseDriverMysql, model.DatabaseDriverPostgres}, settings["SqlSettings.DriverName"].EnumValues)
+	})
+}
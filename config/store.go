@@ -34,6 +34,9 @@ type Store struct {
 
 	readOnly   bool
 	readOnlyFF bool
+
+	// lockedPaths are the configuration paths that can't be changed once loaded.
+	lockedPaths []string
 }
 
 // BackingStore defines the behaviour exposed by the underlying store
@@ -183,6 +186,11 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 	// data from the existing config as necessary.
 	desanitize(oldCfg, newCfg)
 
+	// Every writer of the configuration is denied changing the paths locked by the deployment.
+	if err := s.checkLockedPaths(oldCfg, newCfg); err != nil {
+		return nil, nil, err
+	}
+
 	if err := newCfg.IsValid(); err != nil {
 		return nil, nil, errors.Wrap(err, "new configuration is invalid")
 	}
@@ -210,7 +218,9 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 
 	// We apply back environment overrides since the input config may or
 	// may not have them applied.
-	newCfg = applyEnvironmentMap(newCfgNoEnv, GetEnvironment())
+	env := GetEnvironment()
+	newCfg = applyEnvironmentMap(newCfgNoEnv, env)
+	applyFeatureFlagOverrides(newCfg, env)
 	fixConfig(newCfg)
 	if err := newCfg.IsValid(); err != nil {
 		return nil, nil, errors.Wrap(err, "new configuration is invalid")
@@ -224,8 +234,11 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 	// We restore the previously cleared feature flags sections back.
 	if s.readOnlyFF {
 		oldCfg.FeatureFlags = oldCfgFF
-		newCfg.FeatureFlags = oldCfgFF
 		newCfgNoEnv.FeatureFlags = oldCfgNoEnvFF
+		// The flags are rebuilt from the persisted values so that overrides
+		// removed since the last load no longer apply.
+		newCfg.FeatureFlags = applyEnvironmentMap(newCfgNoEnv, env).FeatureFlags
+		applyFeatureFlagOverrides(newCfg, env)
 	}
 
 	s.configNoEnv = newCfgNoEnv
@@ -252,6 +265,12 @@ func (s *Store) Load() error {
 		oldCfg = s.config.Clone()
 	}
 
+	lockedPaths, err := readLockedPaths()
+	if err != nil {
+		return errors.Wrap(err, "failed to read the locked configuration paths")
+	}
+	s.lockedPaths = lockedPaths
+
 	configBytes, err := s.backingStore.Load()
 	if err != nil {
 		return err
@@ -291,7 +310,9 @@ func (s *Store) Load() error {
 	loadedCfgNoEnv := loadedCfg
 	fixConfig(loadedCfgNoEnv)
 
-	loadedCfg = applyEnvironmentMap(loadedCfg, GetEnvironment())
+	env := GetEnvironment()
+	loadedCfg = applyEnvironmentMap(loadedCfg, env)
+	applyFeatureFlagOverrides(loadedCfg, env)
 	fixConfig(loadedCfg)
 	if err := loadedCfg.IsValid(); err != nil {
 		return errors.Wrap(err, "invalid config")
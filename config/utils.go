@@ -54,6 +54,35 @@ func desanitize(actual, target *model.Config) {
 		target.OpenIdSettings.Secret = actual.OpenIdSettings.Secret
 	}
 
+	for _, provider := range target.OpenIdConnectSettings.Providers {
+		if provider == nil || provider.ClientSecret != model.FakeSetting {
+			continue
+		}
+		if actualProvider := actual.OpenIdConnectSettings.GetProvider(provider.Service()); actualProvider != nil {
+			provider.ClientSecret = actualProvider.ClientSecret
+		}
+	}
+
+	if target.TurnSettings.Secret != nil && *target.TurnSettings.Secret == model.FakeSetting {
+		target.TurnSettings.Secret = actual.TurnSettings.Secret
+	}
+
+	if target.ChangeDataCaptureSettings.URL != nil && *target.ChangeDataCaptureSettings.URL == model.FakeSetting {
+		target.ChangeDataCaptureSettings.URL = actual.ChangeDataCaptureSettings.URL
+	}
+
+	if target.ChangeDataCaptureSettings.Password != nil && *target.ChangeDataCaptureSettings.Password == model.FakeSetting {
+		target.ChangeDataCaptureSettings.Password = actual.ChangeDataCaptureSettings.Password
+	}
+
+	if target.EventBusSettings.URL != nil && *target.EventBusSettings.URL == model.FakeSetting {
+		target.EventBusSettings.URL = actual.EventBusSettings.URL
+	}
+
+	if target.LogSettings.DiagnosticsSinkKey != nil && *target.LogSettings.DiagnosticsSinkKey == model.FakeSetting {
+		target.LogSettings.DiagnosticsSinkKey = actual.LogSettings.DiagnosticsSinkKey
+	}
+
 	if *target.SqlSettings.DataSource == model.FakeSetting {
 		*target.SqlSettings.DataSource = *actual.SqlSettings.DataSource
 	}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS PushNotificationReceipts (
+    AckId varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    PostId varchar(26),
+    ChannelId varchar(26),
+    Platform varchar(32),
+    Type varchar(32),
+    Status varchar(32),
+    Error varchar(1024),
+    CreateAt bigint NOT NULL,
+    ReceivedAt bigint,
+    PRIMARY KEY (AckId),
+    KEY idx_pushnotificationreceipts_user_id_create_at (UserId, CreateAt),
+    KEY idx_pushnotificationreceipts_create_at (CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
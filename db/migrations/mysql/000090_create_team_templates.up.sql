@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS TeamTemplates (
+    Id varchar(26) NOT NULL,
+    DisplayName varchar(64),
+    Description varchar(1024),
+    CreatorId varchar(26),
+    CreateAt bigint,
+    UpdateAt bigint,
+    DeleteAt bigint,
+    Definition text,
+    PRIMARY KEY (Id),
+    KEY idx_teamtemplates_delete_at (DeleteAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS OnboardingTasks (
+    Id varchar(26) NOT NULL,
+    Title varchar(128),
+    Description varchar(1024),
+    Link varchar(512),
+    Audience varchar(32),
+    SortOrder bigint,
+    CreatorId varchar(26),
+    CreateAt bigint,
+    UpdateAt bigint,
+    DeleteAt bigint,
+    PRIMARY KEY (Id),
+    KEY idx_onboardingtasks_delete_at (DeleteAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
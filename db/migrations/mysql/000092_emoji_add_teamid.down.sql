@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+        WHERE table_name = 'Emoji'
+        AND table_schema = DATABASE()
+        AND index_name = 'idx_emoji_teamid_deleteat'
+    ) > 0,
+    'DROP INDEX idx_emoji_teamid_deleteat on Emoji;',
+    'SELECT 1;'
+));
+
+PREPARE removeIndexIfExists FROM @preparedStatement;
+EXECUTE removeIndexIfExists;
+DEALLOCATE PREPARE removeIndexIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Emoji'
+        AND table_schema = DATABASE()
+        AND column_name = 'TeamId'
+    ) > 0,
+    'ALTER TABLE Emoji DROP COLUMN TeamId;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
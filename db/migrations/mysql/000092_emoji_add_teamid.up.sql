@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Emoji'
+        AND table_schema = DATABASE()
+        AND column_name = 'TeamId'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE Emoji ADD COLUMN TeamId varchar(26) NOT NULL DEFAULT \'\';'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+        WHERE table_name = 'Emoji'
+        AND table_schema = DATABASE()
+        AND index_name = 'idx_emoji_teamid_deleteat'
+    ) > 0,
+    'SELECT 1;',
+    'CREATE INDEX idx_emoji_teamid_deleteat on Emoji(TeamId, DeleteAt) LOCK=NONE;'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ConnectivityTestResults (
+    Id varchar(26) NOT NULL,
+    Service varchar(32) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    CreateAt bigint NOT NULL,
+    LatencyMs bigint,
+    Success tinyint(1),
+    Error varchar(1024),
+    PRIMARY KEY (Id),
+    KEY idx_connectivitytestresults_service_create_at (Service, CreateAt),
+    KEY idx_connectivitytestresults_create_at (CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
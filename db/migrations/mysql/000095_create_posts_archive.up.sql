@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS PostsArchive (
+    Id varchar(26) NOT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    UpdateAt bigint(20) DEFAULT NULL,
+    EditAt bigint(20) DEFAULT NULL,
+    DeleteAt bigint(20) DEFAULT NULL,
+    IsPinned tinyint(1) DEFAULT NULL,
+    UserId varchar(26) DEFAULT NULL,
+    ChannelId varchar(26) DEFAULT NULL,
+    RootId varchar(26) DEFAULT NULL,
+    OriginalId varchar(26) DEFAULT NULL,
+    Message text,
+    Type varchar(26) DEFAULT NULL,
+    Props JSON,
+    Hashtags text,
+    Filenames text,
+    FileIds text,
+    HasReactions tinyint(1) DEFAULT NULL,
+    RemoteId varchar(26) DEFAULT NULL,
+    ArchivedAt bigint(20) NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_postsarchive_channel_id_create_at (ChannelId, CreateAt),
+    KEY idx_postsarchive_root_id (RootId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
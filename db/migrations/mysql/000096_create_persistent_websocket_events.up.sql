@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS PersistentWebSocketEvents (
+    Id varchar(26) NOT NULL,
+    PluginId varchar(190) NOT NULL,
+    Event varchar(256) NOT NULL,
+    Data mediumtext,
+    UserId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    TeamId varchar(26) NOT NULL,
+    OmitUsers text,
+    CreateAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_persistentwebsocketevents_create_at (CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
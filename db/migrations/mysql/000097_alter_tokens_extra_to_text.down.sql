@@ -0,0 +1 @@
+DELETE FROM Tokens WHERE Type = 'dialog_wizard';
@@ -0,0 +1,15 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Tokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'Extra'
+        AND data_type != 'text'
+    ) > 0,
+    'ALTER TABLE Tokens MODIFY Extra text;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'OutgoingWebhooks'
+        AND table_schema = DATABASE()
+        AND column_name = 'PayloadFormat'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE OutgoingWebhooks ADD COLUMN PayloadFormat varchar(32) NOT NULL DEFAULT \'\';'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Commands'
+        AND table_schema = DATABASE()
+        AND column_name = 'PayloadFormat'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE Commands ADD COLUMN PayloadFormat varchar(32) NOT NULL DEFAULT \'\';'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS ChannelMemberTimeouts (
+    ChannelId varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    CreatorId varchar(26) NOT NULL,
+    CreateAt bigint NOT NULL,
+    ExpiresAt bigint NOT NULL,
+    PRIMARY KEY (ChannelId, UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
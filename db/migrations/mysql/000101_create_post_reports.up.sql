@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS PostReports (
+    Id varchar(26) NOT NULL,
+    PostId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    PostUserId varchar(26) NOT NULL,
+    ReporterId varchar(26) NOT NULL,
+    Reason varchar(32) NOT NULL,
+    Comment text,
+    Status varchar(32) NOT NULL,
+    Action varchar(32) NOT NULL DEFAULT '',
+    ResolverId varchar(26) NOT NULL DEFAULT '',
+    CreateAt bigint NOT NULL,
+    ResolveAt bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (Id),
+    UNIQUE KEY idx_postreports_post_id_reporter_id (PostId, ReporterId),
+    KEY idx_postreports_status_create_at (Status, CreateAt),
+    KEY idx_postreports_channel_id (ChannelId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS UserDevices (
+    UserId varchar(26) NOT NULL,
+    Fingerprint varchar(64) NOT NULL,
+    Country varchar(8) NOT NULL DEFAULT '',
+    CreateAt bigint NOT NULL,
+    LastSeenAt bigint NOT NULL,
+    PRIMARY KEY (UserId, Fingerprint, Country)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
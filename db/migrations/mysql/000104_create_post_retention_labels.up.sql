@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS PostRetentionLabels (
+    PostId varchar(26) NOT NULL,
+    Label varchar(64) NOT NULL,
+    ExpireAt bigint NOT NULL,
+    CreatorId varchar(26) NOT NULL,
+    CreateAt bigint NOT NULL,
+    PRIMARY KEY (PostId),
+    KEY idx_postretentionlabels_expire_at (ExpireAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
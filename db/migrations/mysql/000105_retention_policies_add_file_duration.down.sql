@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'RetentionPolicies'
+        AND table_schema = DATABASE()
+        AND column_name = 'FileDuration'
+    ) > 0,
+    'ALTER TABLE RetentionPolicies DROP COLUMN FileDuration;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
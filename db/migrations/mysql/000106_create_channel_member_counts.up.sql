@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS ChannelMemberCounts (
+    ChannelId varchar(26) NOT NULL,
+    MemberCount bigint NOT NULL DEFAULT 0,
+    GuestCount bigint NOT NULL DEFAULT 0,
+    UpdateAt bigint NOT NULL,
+    PRIMARY KEY (ChannelId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS DirectMessageRequests (
+    ChannelId varchar(26) NOT NULL,
+    RequesterId varchar(26) NOT NULL,
+    RecipientId varchar(26) NOT NULL,
+    Status varchar(16) NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    PRIMARY KEY (ChannelId),
+    KEY idx_directmessagerequests_recipient_id_status (RecipientId, Status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS PinnedPostOrders (
+    ChannelId varchar(26) NOT NULL,
+    PostId varchar(26) NOT NULL,
+    SortOrder int NOT NULL,
+    PRIMARY KEY (ChannelId, PostId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS Reminders (
+    Id varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    PostId varchar(26) NOT NULL,
+    Message text NOT NULL,
+    RemindAt bigint NOT NULL,
+    Recurrence varchar(16) NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_reminders_user_id (UserId),
+    KEY idx_reminders_remind_at (RemindAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
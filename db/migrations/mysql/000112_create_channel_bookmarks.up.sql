@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS ChannelBookmarks (
+    Id varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    OwnerId varchar(26) NOT NULL,
+    DisplayName varchar(64) NOT NULL,
+    Type varchar(16) NOT NULL,
+    LinkUrl text NOT NULL,
+    FileId varchar(26) NOT NULL,
+    Emoji varchar(64) NOT NULL,
+    SortOrder bigint NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    DeleteAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_channelbookmarks_channel_id (ChannelId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS PostPropSchemas (
+    Namespace varchar(64) NOT NULL,
+    Definition text NOT NULL,
+    CreatorId varchar(26) NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    PRIMARY KEY (Namespace)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
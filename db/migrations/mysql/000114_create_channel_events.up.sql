@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS ChannelEvents (
+    Id varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    CreatorId varchar(26) NOT NULL,
+    Title varchar(512) NOT NULL,
+    Description text NOT NULL,
+    StartAt bigint NOT NULL,
+    Timezone varchar(64) NOT NULL,
+    Recurrence varchar(16) NOT NULL,
+    RemindBeforeMinutes int NOT NULL,
+    NextStartAt bigint NOT NULL,
+    NotifyAt bigint NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_channelevents_channel_id (ChannelId),
+    KEY idx_channelevents_notify_at (NotifyAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS ScheduledConfigChanges (
+    Id varchar(26) NOT NULL,
+    CreatorId varchar(26) NOT NULL,
+    ApplyAt bigint NOT NULL,
+    Config longtext NOT NULL,
+    Diffs longtext NOT NULL,
+    Status varchar(16) NOT NULL,
+    Error text NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_scheduledconfigchanges_status_apply_at (Status, ApplyAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS ChangeEvents (
+    Id varchar(26) NOT NULL,
+    EntityType varchar(32) NOT NULL,
+    EntityId varchar(26) NOT NULL,
+    Operation varchar(16) NOT NULL,
+    Data longtext NOT NULL,
+    CreateAt bigint NOT NULL,
+    PublishedAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_changeevents_published_at_create_at (PublishedAt, CreateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS OutgoingOAuthConnections (
+    Id varchar(26) NOT NULL,
+    CreatorId varchar(26),
+    CreateAt bigint,
+    UpdateAt bigint,
+    Name varchar(64),
+    ClientId varchar(255),
+    ClientSecret varchar(255),
+    OAuthTokenURL text,
+    GrantType varchar(32),
+    Scopes varchar(1024),
+    CredentialsUsername varchar(255),
+    CredentialsPassword varchar(255),
+    Audiences text,
+    PRIMARY KEY (Id),
+    KEY idx_outgoingoauthconnections_name (Name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
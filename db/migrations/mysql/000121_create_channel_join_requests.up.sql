@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS ChannelJoinRequests (
+    Id varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    Message text NOT NULL,
+    Status varchar(16) NOT NULL,
+    ReviewerId varchar(26) NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    ExpireAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_channeljoinrequests_channel_id_status (ChannelId, Status),
+    KEY idx_channeljoinrequests_user_id (UserId),
+    KEY idx_channeljoinrequests_status_expire_at (Status, ExpireAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
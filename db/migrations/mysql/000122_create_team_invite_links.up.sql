@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS TeamInviteLinks (
+    Id varchar(26) NOT NULL,
+    TeamId varchar(26) NOT NULL,
+    CreatorId varchar(26) NOT NULL,
+    Name varchar(64) NOT NULL,
+    MaxUses bigint NOT NULL,
+    UseCount bigint NOT NULL,
+    RejectedCount bigint NOT NULL,
+    LastUsedAt bigint NOT NULL,
+    ExpireAt bigint NOT NULL,
+    AllowedDomains text NOT NULL,
+    CreateAt bigint NOT NULL,
+    UpdateAt bigint NOT NULL,
+    DeleteAt bigint NOT NULL,
+    PRIMARY KEY (Id),
+    KEY idx_teaminvitelinks_team_id_delete_at (TeamId, DeleteAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'ChannelJoinRequests'
+        AND table_schema = DATABASE()
+        AND column_name = 'PendingChannelId'
+    ) > 0,
+    'ALTER TABLE ChannelJoinRequests DROP INDEX idx_channeljoinrequests_pending_channel_id_user_id, DROP COLUMN PendingChannelId;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
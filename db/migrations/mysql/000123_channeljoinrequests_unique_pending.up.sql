@@ -0,0 +1,26 @@
+-- Only the latest pending request of a user to join a channel is kept before enforcing that there
+-- is at most one.
+UPDATE ChannelJoinRequests r
+JOIN ChannelJoinRequests newer ON newer.ChannelId = r.ChannelId
+    AND newer.UserId = r.UserId
+    AND newer.Status = 'pending'
+    AND (newer.CreateAt > r.CreateAt OR (newer.CreateAt = r.CreateAt AND newer.Id > r.Id))
+SET r.Status = 'expired'
+WHERE r.Status = 'pending';
+
+-- MySQL has no partial indexes: PendingChannelId is only set for pending requests, and NULLs are
+-- not considered equal by unique indexes.
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'ChannelJoinRequests'
+        AND table_schema = DATABASE()
+        AND column_name = 'PendingChannelId'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE ChannelJoinRequests ADD COLUMN PendingChannelId varchar(26) GENERATED ALWAYS AS (IF(Status = \'pending\', ChannelId, NULL)) VIRTUAL, ADD UNIQUE KEY idx_channeljoinrequests_pending_channel_id_user_id (PendingChannelId, UserId);'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
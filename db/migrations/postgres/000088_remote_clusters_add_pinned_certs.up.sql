@@ -0,0 +1 @@
+ALTER TABLE remoteclusters ADD COLUMN IF NOT EXISTS pinnedcerts VARCHAR(1024) DEFAULT '';
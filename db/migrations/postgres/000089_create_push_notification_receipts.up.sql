@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS pushnotificationreceipts (
+    ackid VARCHAR(26) PRIMARY KEY,
+    userid VARCHAR(26) NOT NULL,
+    postid VARCHAR(26),
+    channelid VARCHAR(26),
+    platform VARCHAR(32),
+    type VARCHAR(32),
+    status VARCHAR(32),
+    error VARCHAR(1024),
+    createat bigint NOT NULL,
+    receivedat bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_pushnotificationreceipts_user_id_create_at ON pushnotificationreceipts (userid, createat);
+CREATE INDEX IF NOT EXISTS idx_pushnotificationreceipts_create_at ON pushnotificationreceipts (createat);
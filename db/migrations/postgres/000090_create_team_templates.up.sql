@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS teamtemplates (
+    id VARCHAR(26) PRIMARY KEY,
+    displayname VARCHAR(64),
+    description VARCHAR(1024),
+    creatorid VARCHAR(26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    definition text
+);
+
+CREATE INDEX IF NOT EXISTS idx_teamtemplates_delete_at ON teamtemplates (deleteat);
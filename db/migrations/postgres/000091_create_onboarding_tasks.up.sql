@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS onboardingtasks (
+    id VARCHAR(26) PRIMARY KEY,
+    title VARCHAR(128),
+    description VARCHAR(1024),
+    link VARCHAR(512),
+    audience VARCHAR(32),
+    sortorder bigint,
+    creatorid VARCHAR(26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_onboardingtasks_delete_at ON onboardingtasks (deleteat);
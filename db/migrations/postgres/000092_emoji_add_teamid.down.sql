@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_emoji_teamid_deleteat;
+
+ALTER TABLE emoji DROP COLUMN IF EXISTS teamid;
@@ -0,0 +1,3 @@
+ALTER TABLE emoji ADD COLUMN IF NOT EXISTS teamid varchar(26) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_emoji_teamid_deleteat ON emoji (teamid, deleteat);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS connectivitytestresults (
+    id VARCHAR(26) PRIMARY KEY,
+    service VARCHAR(32) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    createat bigint NOT NULL,
+    latencyms bigint,
+    success boolean,
+    error VARCHAR(1024)
+);
+
+CREATE INDEX IF NOT EXISTS idx_connectivitytestresults_service_create_at ON connectivitytestresults (service, createat);
+CREATE INDEX IF NOT EXISTS idx_connectivitytestresults_create_at ON connectivitytestresults (createat);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_fileinfo_dedupof;
+DROP INDEX IF EXISTS idx_fileinfo_contenthash;
+
+ALTER TABLE fileinfo DROP COLUMN IF EXISTS dedupof;
+ALTER TABLE fileinfo DROP COLUMN IF EXISTS contenthash;
@@ -0,0 +1,5 @@
+ALTER TABLE fileinfo ADD COLUMN IF NOT EXISTS contenthash varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE fileinfo ADD COLUMN IF NOT EXISTS dedupof varchar(26) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_fileinfo_contenthash ON fileinfo (contenthash);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_dedupof ON fileinfo (dedupof);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS postsarchive (
+    id VARCHAR(26) PRIMARY KEY,
+    createat bigint,
+    updateat bigint,
+    editat bigint,
+    deleteat bigint,
+    ispinned boolean,
+    userid VARCHAR(26),
+    channelid VARCHAR(26),
+    rootid VARCHAR(26),
+    originalid VARCHAR(26),
+    message VARCHAR(65535),
+    type VARCHAR(26),
+    props jsonb,
+    hashtags VARCHAR(1000),
+    filenames VARCHAR(4000),
+    fileids VARCHAR(300),
+    hasreactions boolean,
+    remoteid VARCHAR(26),
+    archivedat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_postsarchive_channel_id_create_at ON postsarchive (channelid, createat);
+CREATE INDEX IF NOT EXISTS idx_postsarchive_root_id ON postsarchive (rootid);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS persistentwebsocketevents (
+    id VARCHAR(26) PRIMARY KEY,
+    pluginid VARCHAR(190) NOT NULL,
+    event VARCHAR(256) NOT NULL,
+    data text,
+    userid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    teamid VARCHAR(26) NOT NULL,
+    omitusers text,
+    createat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_persistentwebsocketevents_create_at ON persistentwebsocketevents (createat);
@@ -0,0 +1,3 @@
+DELETE FROM tokens WHERE type = 'dialog_wizard';
+
+ALTER TABLE tokens ALTER COLUMN extra TYPE VARCHAR(2048);
@@ -0,0 +1 @@
+ALTER TABLE tokens ALTER COLUMN extra TYPE text;
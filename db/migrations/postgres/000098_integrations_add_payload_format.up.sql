@@ -0,0 +1,2 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS payloadformat varchar(32) NOT NULL DEFAULT '';
+ALTER TABLE commands ADD COLUMN IF NOT EXISTS payloadformat varchar(32) NOT NULL DEFAULT '';
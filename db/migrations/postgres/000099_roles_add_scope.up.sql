@@ -0,0 +1 @@
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS scope varchar(32) NOT NULL DEFAULT '';
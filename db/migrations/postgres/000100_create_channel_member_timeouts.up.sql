@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS channelmembertimeouts (
+    channelid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    creatorid VARCHAR(26) NOT NULL,
+    createat bigint NOT NULL,
+    expiresat bigint NOT NULL,
+    PRIMARY KEY (channelid, userid)
+);
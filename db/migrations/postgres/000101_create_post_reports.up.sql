@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS postreports (
+    id VARCHAR(26) PRIMARY KEY,
+    postid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    postuserid VARCHAR(26) NOT NULL,
+    reporterid VARCHAR(26) NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    comment text,
+    status VARCHAR(32) NOT NULL,
+    action VARCHAR(32) NOT NULL DEFAULT '',
+    resolverid VARCHAR(26) NOT NULL DEFAULT '',
+    createat bigint NOT NULL,
+    resolveat bigint NOT NULL DEFAULT 0,
+    CONSTRAINT idx_postreports_post_id_reporter_id UNIQUE (postid, reporterid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_postreports_status_create_at ON postreports (status, createat);
+CREATE INDEX IF NOT EXISTS idx_postreports_channel_id ON postreports (channelid);
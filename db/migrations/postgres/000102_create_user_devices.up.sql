@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS userdevices (
+    userid VARCHAR(26) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    country VARCHAR(8) NOT NULL DEFAULT '',
+    createat bigint NOT NULL,
+    lastseenat bigint NOT NULL,
+    PRIMARY KEY (userid, fingerprint, country)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS postretentionlabels (
+    postid VARCHAR(26) PRIMARY KEY,
+    label VARCHAR(64) NOT NULL,
+    expireat bigint NOT NULL,
+    creatorid VARCHAR(26) NOT NULL,
+    createat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_postretentionlabels_expire_at ON postretentionlabels (expireat);
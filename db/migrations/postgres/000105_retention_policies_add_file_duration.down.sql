@@ -0,0 +1 @@
+ALTER TABLE retentionpolicies DROP COLUMN IF EXISTS fileduration;
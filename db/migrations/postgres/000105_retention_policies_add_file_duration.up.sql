@@ -0,0 +1 @@
+ALTER TABLE retentionpolicies ADD COLUMN IF NOT EXISTS fileduration bigint NOT NULL DEFAULT -1;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS channelmembercounts (
+    channelid VARCHAR(26) PRIMARY KEY,
+    membercount bigint NOT NULL DEFAULT 0,
+    guestcount bigint NOT NULL DEFAULT 0,
+    updateat bigint NOT NULL
+);
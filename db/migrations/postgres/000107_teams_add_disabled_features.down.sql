@@ -0,0 +1 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS disabledfeatures;
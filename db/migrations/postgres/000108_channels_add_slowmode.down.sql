@@ -0,0 +1 @@
+ALTER TABLE channels DROP COLUMN IF EXISTS slowmodeseconds;
@@ -0,0 +1 @@
+ALTER TABLE channels ADD COLUMN IF NOT EXISTS slowmodeseconds integer NOT NULL DEFAULT 0;
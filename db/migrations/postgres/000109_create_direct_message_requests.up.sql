@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS directmessagerequests (
+    channelid VARCHAR(26) PRIMARY KEY,
+    requesterid VARCHAR(26) NOT NULL,
+    recipientid VARCHAR(26) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_directmessagerequests_recipient_id_status ON directmessagerequests (recipientid, status);
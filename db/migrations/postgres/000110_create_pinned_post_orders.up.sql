@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS pinnedpostorders (
+    channelid VARCHAR(26) NOT NULL,
+    postid VARCHAR(26) NOT NULL,
+    sortorder integer NOT NULL,
+    PRIMARY KEY (channelid, postid)
+);
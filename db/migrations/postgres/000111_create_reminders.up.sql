@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS reminders (
+    id VARCHAR(26) PRIMARY KEY,
+    userid VARCHAR(26) NOT NULL,
+    postid VARCHAR(26) NOT NULL,
+    message text NOT NULL,
+    remindat bigint NOT NULL,
+    recurrence VARCHAR(16) NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_user_id ON reminders (userid);
+CREATE INDEX IF NOT EXISTS idx_reminders_remind_at ON reminders (remindat);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS channelbookmarks (
+    id VARCHAR(26) PRIMARY KEY,
+    channelid VARCHAR(26) NOT NULL,
+    ownerid VARCHAR(26) NOT NULL,
+    displayname VARCHAR(64) NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    linkurl text NOT NULL,
+    fileid VARCHAR(26) NOT NULL,
+    emoji VARCHAR(64) NOT NULL,
+    sortorder bigint NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_channelbookmarks_channel_id ON channelbookmarks (channelid);
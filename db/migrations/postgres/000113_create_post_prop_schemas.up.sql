@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS postpropschemas (
+    namespace VARCHAR(64) PRIMARY KEY,
+    definition text NOT NULL,
+    creatorid VARCHAR(26) NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
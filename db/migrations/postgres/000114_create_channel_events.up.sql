@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS channelevents (
+    id VARCHAR(26) PRIMARY KEY,
+    channelid VARCHAR(26) NOT NULL,
+    creatorid VARCHAR(26) NOT NULL,
+    title VARCHAR(512) NOT NULL,
+    description text NOT NULL,
+    startat bigint NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    recurrence VARCHAR(16) NOT NULL,
+    remindbeforeminutes integer NOT NULL,
+    nextstartat bigint NOT NULL,
+    notifyat bigint NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_channelevents_channel_id ON channelevents (channelid);
+CREATE INDEX IF NOT EXISTS idx_channelevents_notify_at ON channelevents (notifyat);
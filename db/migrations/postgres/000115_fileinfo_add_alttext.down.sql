@@ -0,0 +1,2 @@
+ALTER TABLE fileinfo DROP COLUMN IF EXISTS alttextgenerated;
+ALTER TABLE fileinfo DROP COLUMN IF EXISTS alttext;
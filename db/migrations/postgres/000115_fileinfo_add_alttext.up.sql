@@ -0,0 +1,2 @@
+ALTER TABLE fileinfo ADD COLUMN IF NOT EXISTS alttext varchar(512) NOT NULL DEFAULT '';
+ALTER TABLE fileinfo ADD COLUMN IF NOT EXISTS alttextgenerated boolean NOT NULL DEFAULT false;
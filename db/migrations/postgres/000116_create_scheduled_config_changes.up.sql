@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS scheduledconfigchanges (
+    id VARCHAR(26) PRIMARY KEY,
+    creatorid VARCHAR(26) NOT NULL,
+    applyat bigint NOT NULL,
+    config text NOT NULL,
+    diffs text NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    error text NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduledconfigchanges_status_apply_at ON scheduledconfigchanges (status, applyat);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS changeevents (
+    id VARCHAR(26) PRIMARY KEY,
+    entitytype VARCHAR(32) NOT NULL,
+    entityid VARCHAR(26) NOT NULL,
+    operation VARCHAR(16) NOT NULL,
+    data text NOT NULL,
+    createat bigint NOT NULL,
+    publishedat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_changeevents_published_at_create_at ON changeevents (publishedat, createat);
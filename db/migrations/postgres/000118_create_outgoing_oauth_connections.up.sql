@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS outgoingoauthconnections (
+    id VARCHAR(26) PRIMARY KEY,
+    creatorid VARCHAR(26),
+    createat bigint,
+    updateat bigint,
+    name VARCHAR(64),
+    clientid VARCHAR(255),
+    clientsecret VARCHAR(255),
+    oauthtokenurl text,
+    granttype VARCHAR(32),
+    scopes VARCHAR(1024),
+    credentialsusername VARCHAR(255),
+    credentialspassword VARCHAR(255),
+    audiences text
+);
+
+CREATE INDEX IF NOT EXISTS idx_outgoingoauthconnections_name ON outgoingoauthconnections (name);
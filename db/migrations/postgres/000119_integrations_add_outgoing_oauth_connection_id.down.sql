@@ -0,0 +1,2 @@
+ALTER TABLE commands DROP COLUMN IF EXISTS outgoingoauthconnectionid;
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS outgoingoauthconnectionid;
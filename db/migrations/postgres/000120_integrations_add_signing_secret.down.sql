@@ -0,0 +1,2 @@
+ALTER TABLE commands DROP COLUMN IF EXISTS signingsecret;
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS signingsecret;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS channeljoinrequests (
+    id VARCHAR(26) PRIMARY KEY,
+    channelid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    message text NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    reviewerid VARCHAR(26) NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    expireat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_channeljoinrequests_channel_id_status ON channeljoinrequests (channelid, status);
+CREATE INDEX IF NOT EXISTS idx_channeljoinrequests_user_id ON channeljoinrequests (userid);
+CREATE INDEX IF NOT EXISTS idx_channeljoinrequests_status_expire_at ON channeljoinrequests (status, expireat);
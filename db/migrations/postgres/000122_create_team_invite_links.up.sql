@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS teaminvitelinks (
+    id VARCHAR(26) PRIMARY KEY,
+    teamid VARCHAR(26) NOT NULL,
+    creatorid VARCHAR(26) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    maxuses bigint NOT NULL,
+    usecount bigint NOT NULL,
+    rejectedcount bigint NOT NULL,
+    lastusedat bigint NOT NULL,
+    expireat bigint NOT NULL,
+    alloweddomains text NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_teaminvitelinks_team_id_delete_at ON teaminvitelinks (teamid, deleteat);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_channeljoinrequests_pending_channel_id_user_id;
@@ -0,0 +1,10 @@
+-- Only the latest pending request of a user to join a channel is kept before enforcing that there
+-- is at most one.
+UPDATE channeljoinrequests SET status = 'expired'
+WHERE status = 'pending' AND id NOT IN (
+    SELECT DISTINCT ON (channelid, userid) id FROM channeljoinrequests
+    WHERE status = 'pending'
+    ORDER BY channelid, userid, createat DESC, id DESC
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_channeljoinrequests_pending_channel_id_user_id ON channeljoinrequests (channelid, userid) WHERE status = 'pending';
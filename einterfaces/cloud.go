@@ -24,6 +24,8 @@ type CloudInterface interface {
 	GetInvoicePDF(userID, invoiceID string) ([]byte, string, error)
 
 	ChangeSubscription(userID, subscriptionID string, subscriptionChange *model.SubscriptionChange) (*model.Subscription, error)
+	// PreviewSubscriptionChange returns the prorated cost of changing the subscription to the given number of seats
+	PreviewSubscriptionChange(userID, subscriptionID string, seats int) (*model.SubscriptionChangePreview, error)
 
 	RequestCloudTrial(userID, subscriptionID string) (*model.Subscription, error)
 
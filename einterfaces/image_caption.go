@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package einterfaces
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// ImageCaptionInterface is implemented by the providers describing uploaded images.
+type ImageCaptionInterface interface {
+	// Caption returns a short description of the content of an image, used as its alt text.
+	Caption(fileInfo *model.FileInfo, data []byte) (string, *model.AppError)
+}
@@ -59,8 +59,21 @@ type MetricsInterface interface {
 	IncrementFileIndexCounter()
 	IncrementUserIndexCounter()
 	IncrementChannelIndexCounter()
+	SetSearchIndexingQueueDepth(depth float64)
+	ObserveSearchIndexingLag(elapsed float64)
+	IncrementSearchIndexingDeadLetterCounter(engine string)
+
+	SetResourceGuardMemoryBytes(bytes float64)
+	ObserveAPIEndpointAllocatedBytes(endpoint string, bytes float64)
+	ObserveAPIEndpointGoroutines(endpoint string, count float64)
+	IncrementAPIEndpointShedCounter(endpoint string)
+
+	IncrementPostReportCounter(reason string)
+	IncrementPostReportActionCounter(action string)
 
 	ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64)
+	IncrementPluginHookCounter(pluginID, hookName string)
+	IncrementPluginHookErrorCounter(pluginID, hookName, errorType string)
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
@@ -247,6 +247,29 @@ func (_m *CloudInterface) InvalidateCaches() error {
 	return r0
 }
 
+// PreviewSubscriptionChange provides a mock function with given fields: userID, subscriptionID, seats
+func (_m *CloudInterface) PreviewSubscriptionChange(userID string, subscriptionID string, seats int) (*model.SubscriptionChangePreview, error) {
+	ret := _m.Called(userID, subscriptionID, seats)
+
+	var r0 *model.SubscriptionChangePreview
+	if rf, ok := ret.Get(0).(func(string, string, int) *model.SubscriptionChangePreview); ok {
+		r0 = rf(userID, subscriptionID, seats)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SubscriptionChangePreview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(userID, subscriptionID, seats)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RequestCloudTrial provides a mock function with given fields: userID, subscriptionID
 func (_m *CloudInterface) RequestCloudTrial(userID string, subscriptionID string) (*model.Subscription, error) {
 	ret := _m.Called(userID, subscriptionID)
@@ -57,6 +57,11 @@ func (_m *MetricsInterface) GetLoggerMetricsCollector() logr.MetricsCollector {
 	return r0
 }
 
+// IncrementAPIEndpointShedCounter provides a mock function with given fields: endpoint
+func (_m *MetricsInterface) IncrementAPIEndpointShedCounter(endpoint string) {
+	_m.Called(endpoint)
+}
+
 // IncrementChannelIndexCounter provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementChannelIndexCounter() {
 	_m.Called()
@@ -147,6 +152,16 @@ func (_m *MetricsInterface) IncrementMemCacheMissCounterSession() {
 	_m.Called()
 }
 
+// IncrementPluginHookCounter provides a mock function with given fields: pluginID, hookName
+func (_m *MetricsInterface) IncrementPluginHookCounter(pluginID string, hookName string) {
+	_m.Called(pluginID, hookName)
+}
+
+// IncrementPluginHookErrorCounter provides a mock function with given fields: pluginID, hookName, errorType
+func (_m *MetricsInterface) IncrementPluginHookErrorCounter(pluginID string, hookName string, errorType string) {
+	_m.Called(pluginID, hookName, errorType)
+}
+
 // IncrementPostBroadcast provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementPostBroadcast() {
 	_m.Called()
@@ -167,6 +182,16 @@ func (_m *MetricsInterface) IncrementPostIndexCounter() {
 	_m.Called()
 }
 
+// IncrementPostReportActionCounter provides a mock function with given fields: action
+func (_m *MetricsInterface) IncrementPostReportActionCounter(action string) {
+	_m.Called(action)
+}
+
+// IncrementPostReportCounter provides a mock function with given fields: reason
+func (_m *MetricsInterface) IncrementPostReportCounter(reason string) {
+	_m.Called(reason)
+}
+
 // IncrementPostSentEmail provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementPostSentEmail() {
 	_m.Called()
@@ -202,6 +227,11 @@ func (_m *MetricsInterface) IncrementRemoteClusterMsgSentCounter(remoteID string
 	_m.Called(remoteID)
 }
 
+// IncrementSearchIndexingDeadLetterCounter provides a mock function with given fields: engine
+func (_m *MetricsInterface) IncrementSearchIndexingDeadLetterCounter(engine string) {
+	_m.Called(engine)
+}
+
 // IncrementUserIndexCounter provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementUserIndexCounter() {
 	_m.Called()
@@ -237,11 +267,21 @@ func (_m *MetricsInterface) IncrementWebsocketReconnectEvent(eventType string) {
 	_m.Called(eventType)
 }
 
+// ObserveAPIEndpointAllocatedBytes provides a mock function with given fields: endpoint, bytes
+func (_m *MetricsInterface) ObserveAPIEndpointAllocatedBytes(endpoint string, bytes float64) {
+	_m.Called(endpoint, bytes)
+}
+
 // ObserveAPIEndpointDuration provides a mock function with given fields: endpoint, method, statusCode, elapsed
 func (_m *MetricsInterface) ObserveAPIEndpointDuration(endpoint string, method string, statusCode string, elapsed float64) {
 	_m.Called(endpoint, method, statusCode, elapsed)
 }
 
+// ObserveAPIEndpointGoroutines provides a mock function with given fields: endpoint, count
+func (_m *MetricsInterface) ObserveAPIEndpointGoroutines(endpoint string, count float64) {
+	_m.Called(endpoint, count)
+}
+
 // ObserveClusterRequestDuration provides a mock function with given fields: elapsed
 func (_m *MetricsInterface) ObserveClusterRequestDuration(elapsed float64) {
 	_m.Called(elapsed)
@@ -292,6 +332,11 @@ func (_m *MetricsInterface) ObserveRemoteClusterPingDuration(remoteID string, el
 	_m.Called(remoteID, elapsed)
 }
 
+// ObserveSearchIndexingLag provides a mock function with given fields: elapsed
+func (_m *MetricsInterface) ObserveSearchIndexingLag(elapsed float64) {
+	_m.Called(elapsed)
+}
+
 // ObserveStoreMethodDuration provides a mock function with given fields: method, success, elapsed
 func (_m *MetricsInterface) ObserveStoreMethodDuration(method string, success string, elapsed float64) {
 	_m.Called(method, success, elapsed)
@@ -311,3 +356,13 @@ func (_m *MetricsInterface) SetReplicaLagAbsolute(node string, value float64) {
 func (_m *MetricsInterface) SetReplicaLagTime(node string, value float64) {
 	_m.Called(node, value)
 }
+
+// SetResourceGuardMemoryBytes provides a mock function with given fields: bytes
+func (_m *MetricsInterface) SetResourceGuardMemoryBytes(bytes float64) {
+	_m.Called(bytes)
+}
+
+// SetSearchIndexingQueueDepth provides a mock function with given fields: depth
+func (_m *MetricsInterface) SetSearchIndexingQueueDepth(depth float64) {
+	_m.Called(depth)
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package einterfaces
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// TranscriptionInterface is implemented by the providers transcribing voice messages.
+type TranscriptionInterface interface {
+	// Transcribe returns the text spoken in the audio file of a voice message.
+	Transcribe(fileInfo *model.FileInfo, data []byte) (string, *model.AppError)
+}
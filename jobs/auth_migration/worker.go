@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package auth_migration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	jobName = "AuthMigration"
+
+	// progressBatchSize is how many users are migrated between two progress updates.
+	progressBatchSize = 100
+)
+
+type AppIface interface {
+	ReadFile(path string) ([]byte, *model.AppError)
+	RemoveFile(path string) *model.AppError
+	VerifyAuthMigration(req *model.AuthMigrationRequest) ([]*model.AuthMigrationMatch, []*model.AuthMigrationMismatch, *model.AppError)
+	ApplyAuthMigration(match *model.AuthMigrationMatch, to string) *model.AppError
+	WriteAuthMigrationMismatches(jobID string, mismatches []*model.AuthMigrationMismatch) (string, *model.AppError)
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(job *model.Job) error {
+		requestFile, ok := job.Data["request_file"]
+		if !ok {
+			return model.NewAppError("AuthMigrationWorker", "auth_migration.worker.do_job.missing_file", nil, "", http.StatusBadRequest)
+		}
+
+		data, appErr := app.ReadFile(requestFile)
+		if appErr != nil {
+			return appErr
+		}
+
+		var req model.AuthMigrationRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return model.NewAppError("AuthMigrationWorker", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		matches, mismatches, appErr := app.VerifyAuthMigration(&req)
+		if appErr != nil {
+			return appErr
+		}
+
+		job.Data["total"] = strconv.Itoa(len(matches) + len(mismatches))
+		job.Data["matched"] = strconv.Itoa(len(matches))
+		job.Data["mismatched"] = strconv.Itoa(len(mismatches))
+
+		// Without partial application, a single user that could not be verified prevents any
+		// user from being migrated.
+		apply := !req.DryRun && (req.Partial || len(mismatches) == 0)
+		job.Data["applied"] = strconv.FormatBool(apply)
+
+		if apply {
+			var migrated int
+			for i, match := range matches {
+				if appErr := app.ApplyAuthMigration(match, req.To); appErr != nil {
+					appErr.Translate(i18n.T)
+					mismatches = append(mismatches, &model.AuthMigrationMismatch{UserId: match.UserId, Reason: model.AuthMigrationMismatchFailed, Detail: appErr.Message})
+				} else {
+					migrated++
+				}
+
+				processed := i + 1
+				if processed%progressBatchSize != 0 && processed != len(matches) {
+					continue
+				}
+
+				job.Data["migrated"] = strconv.Itoa(migrated)
+				job.Data["mismatched"] = strconv.Itoa(len(mismatches))
+				if appErr := jobServer.SetJobProgress(job, int64(processed*100/len(matches))); appErr != nil {
+					mlog.Warn("Worker: Failed to update job progress", mlog.String("worker", model.JobTypeAuthMigration), mlog.String("job_id", job.Id), mlog.Err(appErr))
+				}
+			}
+		}
+
+		if len(mismatches) > 0 {
+			mismatchesFile, appErr := app.WriteAuthMigrationMismatches(job.Id, mismatches)
+			if appErr != nil {
+				return appErr
+			}
+			job.Data["mismatches_file"] = mismatchesFile
+		}
+
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeAuthMigration), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		if appErr := app.RemoveFile(requestFile); appErr != nil {
+			mlog.Warn("Worker: Failed to remove auth migration request file", mlog.String("path", requestFile), mlog.Err(appErr))
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bulk_channel_members
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	jobName = "BulkChannelMembers"
+
+	// progressBatchSize is how many users are processed between two progress updates.
+	progressBatchSize = 100
+)
+
+type AppIface interface {
+	ReadFile(path string) ([]byte, *model.AppError)
+	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
+	RemoveFile(path string) *model.AppError
+	GetChannel(channelID string) (*model.Channel, *model.AppError)
+	ApplyBulkChannelMemberAction(c *request.Context, channel *model.Channel, action, userID, requesterID string) *model.AppError
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	appContext := &request.Context{}
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(job *model.Job) error {
+		usersFile, ok := job.Data["users_file"]
+		if !ok {
+			return model.NewAppError("BulkChannelMembersWorker", "bulk_channel_members.worker.do_job.missing_file", nil, "", http.StatusBadRequest)
+		}
+
+		data, appErr := app.ReadFile(usersFile)
+		if appErr != nil {
+			return appErr
+		}
+
+		var userIDs []string
+		if err := json.Unmarshal(data, &userIDs); err != nil {
+			return model.NewAppError("BulkChannelMembersWorker", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		channel, appErr := app.GetChannel(job.Data["channel_id"])
+		if appErr != nil {
+			return appErr
+		}
+
+		action := job.Data["action"]
+		requesterID := job.Data["requester_id"]
+
+		var succeeded int
+		failures := []model.ChannelMembersBulkFailure{}
+		for i, userID := range userIDs {
+			if appErr := app.ApplyBulkChannelMemberAction(appContext, channel, action, userID, requesterID); appErr != nil {
+				appErr.Translate(i18n.T)
+				failures = append(failures, model.ChannelMembersBulkFailure{UserId: userID, Error: appErr.Message})
+			} else {
+				succeeded++
+			}
+
+			processed := i + 1
+			if processed%progressBatchSize != 0 && processed != len(userIDs) {
+				continue
+			}
+
+			job.Data["processed"] = strconv.Itoa(processed)
+			job.Data["succeeded"] = strconv.Itoa(succeeded)
+			job.Data["failed"] = strconv.Itoa(len(failures))
+			if appErr := jobServer.SetJobProgress(job, int64(processed*100/len(userIDs))); appErr != nil {
+				mlog.Warn("Worker: Failed to update job progress", mlog.String("worker", model.JobTypeBulkChannelMembers), mlog.String("job_id", job.Id), mlog.Err(appErr))
+			}
+		}
+
+		if len(failures) > 0 {
+			failuresJSON, err := json.Marshal(failures)
+			if err != nil {
+				return model.NewAppError("BulkChannelMembersWorker", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+
+			failuresFile := path.Join(path.Dir(usersFile), job.Id+"_failures.json")
+			if _, appErr := app.WriteFile(bytes.NewReader(failuresJSON), failuresFile); appErr != nil {
+				return appErr
+			}
+			job.Data["failures_file"] = failuresFile
+		}
+
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeBulkChannelMembers), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		if appErr := app.RemoveFile(usersFile); appErr != nil {
+			mlog.Warn("Worker: Failed to remove bulk channel members file", mlog.String("path", usersFile), mlog.Err(appErr))
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
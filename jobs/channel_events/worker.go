@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package channel_events
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "ChannelEvents"
+
+type AppIface interface {
+	AnnounceDueChannelEvents(now int64) (int, *model.AppError)
+}
+
+// MakeWorker returns the worker of the channel events job, which announces the events that are
+// due in their channels.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.ServiceSettings.EnableChannelEvents
+	}
+	execute := func(job *model.Job) error {
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		announced, appErr := app.AnnounceDueChannelEvents(model.GetMillis())
+
+		job.Data["announced"] = strconv.Itoa(announced)
+		if err := jobServer.UpdateInProgressJobData(job); err != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeChannelEvents), mlog.String("job_id", job.Id), mlog.Err(err))
+		}
+
+		if appErr != nil {
+			return appErr
+		}
+
+		mlog.Debug("Worker: Announced due channel events", mlog.String("worker", jobName), mlog.Int("announced", announced))
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
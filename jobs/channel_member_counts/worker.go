@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package channel_member_counts
+
+import (
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const (
+	jobName   = "ChannelMemberCounts"
+	batchSize = 1000
+)
+
+// MakeWorker returns the worker of the channel member counts job, which recounts the members of
+// all the channels to reconcile their maintained counts with the users deactivated, reactivated,
+// promoted or demoted since.
+func MakeWorker(jobServer *jobs.JobServer, s store.Store) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.ServiceSettings.UseMaintainedChannelMemberCounts
+	}
+	execute := func(job *model.Job) error {
+		var batches int
+		afterChannelID := ""
+		for {
+			lastChannelID, err := s.Channel().ReconcileMemberCounts(afterChannelID, batchSize)
+			if err != nil {
+				return err
+			}
+			if lastChannelID == "" {
+				break
+			}
+
+			batches++
+			afterChannelID = lastChannelID
+		}
+
+		mlog.Debug("Worker: Reconciled channel member counts", mlog.String("worker", jobName), mlog.Int("batches", batches))
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
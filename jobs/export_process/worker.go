@@ -5,6 +5,7 @@ package export_process
 
 import (
 	"io"
+	"net/http"
 	"path/filepath"
 
 	"github.com/mattermost/mattermost-server/v6/jobs"
@@ -19,6 +20,7 @@ type AppIface interface {
 	configservice.ConfigService
 	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
 	BulkExport(writer io.Writer, outPath string, opts model.BulkExportOpts) *model.AppError
+	SlackExport(writer io.Writer, teamID string, opts model.BulkExportOpts) *model.AppError
 }
 
 func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
@@ -33,8 +35,27 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
 			opts.IncludeAttachments = true
 		}
 
+		format := job.Data["format"]
+		if format == "" {
+			format = model.BulkExportFormatMattermost
+		}
+
+		teamID := job.Data["team_id"]
+		switch format {
+		case model.BulkExportFormatMattermost:
+		case model.BulkExportFormatSlack:
+			if !model.IsValidId(teamID) {
+				return model.NewAppError("ExportProcessWorker", "export_process.worker.do_job.team_id", nil, "", http.StatusBadRequest)
+			}
+		default:
+			return model.NewAppError("ExportProcessWorker", "export_process.worker.do_job.format", map[string]interface{}{"Format": format}, "", http.StatusBadRequest)
+		}
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := model.NewId() + "_export.zip"
+		if format == model.BulkExportFormatSlack {
+			exportFilename = model.NewId() + "_slack_export.zip"
+		}
 
 		rd, wr := io.Pipe()
 
@@ -45,7 +66,12 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
 			errCh <- appErr
 		}()
 
-		appErr := app.BulkExport(wr, outPath, opts)
+		var appErr *model.AppError
+		if format == model.BulkExportFormatSlack {
+			appErr = app.SlackExport(wr, teamID, opts)
+		} else {
+			appErr = app.BulkExport(wr, outPath, opts)
+		}
 		if err := wr.Close(); err != nil {
 			mlog.Warn("Worker: error closing writer")
 		}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_retention
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "FileRetention"
+
+type AppIface interface {
+	DeleteExpiredPolicyFiles(now int64) (*model.FileRetentionReport, *model.AppError)
+}
+
+// MakeWorker returns the worker of the file retention job, which deletes the file attachments
+// of the posts affected by a retention policy with a file duration while keeping the posts.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.DataRetentionSettings.EnablePolicyFileDeletion
+	}
+	execute := func(job *model.Job) error {
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		report, deleteErr := app.DeleteExpiredPolicyFiles(model.GetMillis())
+
+		job.Data["posts"] = strconv.Itoa(report.Posts)
+		job.Data["file_infos"] = strconv.Itoa(report.FileInfos)
+		job.Data["reclaimed_bytes"] = strconv.FormatInt(report.ReclaimedBytes, 10)
+		job.Data["shared_file_infos"] = strconv.Itoa(report.SharedFileInfos)
+		job.Data["errors"] = strconv.Itoa(report.Errors)
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeFileRetention), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		if deleteErr != nil {
+			return deleteErr
+		}
+
+		mlog.Info("Worker: Deleted expired files", mlog.String("worker", jobName),
+			mlog.Int("posts", report.Posts), mlog.Int("file_infos", report.FileInfos), mlog.Int64("reclaimed_bytes", report.ReclaimedBytes))
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msteams_import
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "MSTeamsImport"
+
+type AppIface interface {
+	configservice.ConfigService
+	RemoveFile(path string) *model.AppError
+	FileExists(path string) (bool, *model.AppError)
+	FileSize(path string) (int64, *model.AppError)
+	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
+	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
+	MSTeamsImport(c *request.Context, zipReader *zip.Reader, dryRun bool, workers int) (*model.MSTeamsImportReport, *model.AppError)
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	appContext := &request.Context{}
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(job *model.Job) error {
+		importFileName, ok := job.Data["import_file"]
+		if !ok {
+			return model.NewAppError("MSTeamsImportWorker", "msteams_import.worker.do_job.missing_file", nil, "", http.StatusBadRequest)
+		}
+		dryRun := job.Data["dry_run"] == "true"
+
+		importFilePath := filepath.Join(*app.Config().ImportSettings.Directory, importFileName)
+		if ok, err := app.FileExists(importFilePath); err != nil {
+			return err
+		} else if !ok {
+			return model.NewAppError("MSTeamsImportWorker", "msteams_import.worker.do_job.file_exists", nil, "", http.StatusBadRequest)
+		}
+
+		importFileSize, appErr := app.FileSize(importFilePath)
+		if appErr != nil {
+			return appErr
+		}
+
+		importFile, appErr := app.FileReader(importFilePath)
+		if appErr != nil {
+			return appErr
+		}
+		defer importFile.Close()
+
+		importZipReader, err := zip.NewReader(importFile.(io.ReaderAt), importFileSize)
+		if err != nil {
+			return model.NewAppError("MSTeamsImportWorker", "msteams_import.worker.do_job.open_file", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		report, importErr := app.MSTeamsImport(appContext, importZipReader, dryRun, runtime.NumCPU())
+
+		// The report is kept whether or not the import went through, as it's what tells the
+		// administrator what to fix in the package.
+		reportJSON, err := json.Marshal(report)
+		if err != nil {
+			return model.NewAppError("MSTeamsImportWorker", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		reportFile := filepath.Join(filepath.Dir(importFilePath), job.Id+"_report.json")
+		if _, appErr := app.WriteFile(bytes.NewReader(reportJSON), reportFile); appErr != nil {
+			return appErr
+		}
+		job.Data["report_file"] = reportFile
+		job.Data["users"] = strconv.Itoa(report.Users)
+		job.Data["teams"] = strconv.Itoa(report.Teams)
+		job.Data["channels"] = strconv.Itoa(report.Channels)
+		job.Data["posts"] = strconv.Itoa(report.Posts)
+		job.Data["replies"] = strconv.Itoa(report.Replies)
+		job.Data["warnings"] = strconv.Itoa(len(report.Warnings))
+		if report.ErrorLine != 0 {
+			job.Data["line_number"] = strconv.Itoa(report.ErrorLine)
+		}
+
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeMSTeamsImport), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		if importErr != nil {
+			return importErr
+		}
+
+		// a dry run leaves the package in place so it can be imported for real afterwards.
+		if dryRun {
+			return nil
+		}
+
+		if appErr := app.RemoveFile(importFilePath); appErr != nil {
+			return appErr
+		}
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
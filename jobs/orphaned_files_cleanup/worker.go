@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package orphaned_files_cleanup
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "OrphanedFilesCleanup"
+
+type AppIface interface {
+	configservice.ConfigService
+	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
+	CleanupOrphanedFiles(dryRun bool, createdBefore time.Time) (*model.OrphanedFilesReport, *model.AppError)
+}
+
+// MakeWorker returns the worker of the orphaned files cleanup job. Jobs created with the
+// dry_run data set to true only write the report of the files which would be deleted.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(job *model.Job) error {
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+		dryRun := job.Data["dry_run"] == "true"
+
+		safetyWindow := time.Duration(*app.Config().FileSettings.OrphanedFilesSafetyWindowHours) * time.Hour
+		report, cleanupErr := app.CleanupOrphanedFiles(dryRun, time.Now().Add(-safetyWindow))
+
+		reportJSON, err := json.Marshal(report)
+		if err != nil {
+			return model.NewAppError("OrphanedFilesCleanupWorker", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		reportFile := filepath.Join(*app.Config().ExportSettings.Directory, job.Id+"_orphaned_files_report.json")
+		if _, appErr := app.WriteFile(bytes.NewReader(reportJSON), reportFile); appErr != nil {
+			return appErr
+		}
+		job.Data["report_file"] = reportFile
+		job.Data["file_infos"] = strconv.Itoa(report.FileInfos)
+		job.Data["stored_files"] = strconv.Itoa(report.StoredFiles)
+		job.Data["reclaimed_bytes"] = strconv.FormatInt(report.ReclaimedBytes, 10)
+		job.Data["errors"] = strconv.Itoa(report.Errors)
+
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeOrphanedFilesCleanup), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		if cleanupErr != nil {
+			return cleanupErr
+		}
+
+		mlog.Info("Worker: Cleaned up orphaned files", mlog.String("worker", jobName), mlog.Bool("dry_run", dryRun),
+			mlog.Int("file_infos", report.FileInfos), mlog.Int("stored_files", report.StoredFiles), mlog.Int64("reclaimed_bytes", report.ReclaimedBytes))
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
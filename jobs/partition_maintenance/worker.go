@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package partition_maintenance
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const jobName = "PartitionMaintenance"
+
+type AppIface interface {
+	configservice.ConfigService
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, s store.Store) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.SqlSettings.EnableTablePartitioning
+	}
+	execute := func(job *model.Job) error {
+		cfg := app.Config().SqlSettings
+		now := time.Now()
+		nowMillis := model.GetMillisForTime(now)
+
+		// Partitions of posts are only dropped if the data retention deletes all of their posts
+		// by now.
+		var globalPolicyEndTime int64
+		if dataRetention := app.Config().DataRetentionSettings; *dataRetention.EnableMessageDeletion {
+			globalPolicyEndTime = model.GetMillisForTime(now.AddDate(0, 0, -*dataRetention.MessageRetentionDays))
+		}
+
+		var ensured, dropped int
+		for _, table := range model.PartitionedTables {
+			partitioned, err := s.TablePartition().IsPartitioned(table)
+			if err != nil {
+				return err
+			}
+
+			// The first run converts the table. Every row it already holds goes to the legacy
+			// partition, which is never dropped. The legacy partition extends to the end of the
+			// next month, leaving the rows written while the table is converted within its bounds.
+			if !partitioned {
+				mlog.Info("Worker: Partitioning table", mlog.String("worker", jobName), mlog.String("table", table))
+				boundary := model.NewMonthlyTablePartition(table, now.AddDate(0, 1, 0)).End
+				if err := s.TablePartition().Partition(table, boundary); err != nil {
+					return err
+				}
+			}
+
+			for i := 0; i <= *cfg.PartitionsAheadMonths; i++ {
+				if err := s.TablePartition().CreatePartition(model.NewMonthlyTablePartition(table, now.AddDate(0, i, 0))); err != nil {
+					return err
+				}
+				ensured++
+			}
+
+			if *cfg.PartitionRetentionMonths == 0 {
+				continue
+			}
+
+			// Partitions are only dropped once every row they hold is past the retention period.
+			oldest := model.NewMonthlyTablePartition(table, now.AddDate(0, -*cfg.PartitionRetentionMonths, 0))
+			partitions, err := s.TablePartition().GetPartitions(table)
+			if err != nil {
+				return err
+			}
+			for _, partition := range partitions {
+				if partition.End > oldest.Start {
+					break
+				}
+
+				if table == "Posts" {
+					retained, err := s.TablePartition().HasRetainedRows(partition, nowMillis, globalPolicyEndTime)
+					if err != nil {
+						return err
+					}
+					if retained {
+						mlog.Warn("Worker: Keeping partition holding posts kept by the data retention", mlog.String("worker", jobName), mlog.String("partition", partition.Name))
+						continue
+					}
+				}
+
+				mlog.Info("Worker: Dropping partition", mlog.String("worker", jobName), mlog.String("partition", partition.Name))
+				if err := s.TablePartition().DropPartition(partition); err != nil {
+					return err
+				}
+				dropped++
+			}
+		}
+
+		job.Data["partitions_ensured"] = strconv.Itoa(ensured)
+		job.Data["partitions_dropped"] = strconv.Itoa(dropped)
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypePartitionMaintenance), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
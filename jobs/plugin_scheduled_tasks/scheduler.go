@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin_scheduled_tasks
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type Scheduler struct {
+	jobServer *jobs.JobServer
+	app       AppIface
+
+	// lastScheduleTime is when the due tasks were last looked for. Schedulers only run on the
+	// cluster leader, from a single goroutine.
+	lastScheduleTime time.Time
+}
+
+func MakeScheduler(jobServer *jobs.JobServer, app AppIface) model.Scheduler {
+	return &Scheduler{
+		jobServer: jobServer,
+		app:       app,
+	}
+}
+
+func (scheduler *Scheduler) Enabled(cfg *model.Config) bool {
+	return *cfg.PluginSettings.Enable
+}
+
+// NextScheduleTime returns the next minute, when the tasks might be due. The scheduler is not
+// asked again until then, so the tasks registered in the meantime are taken into account.
+func (scheduler *Scheduler) NextScheduleTime(_ *model.Config, now time.Time, _ bool, _ *model.Job) *time.Time {
+	// The tasks due while this server was not the leader are not caught up on.
+	if time.Since(scheduler.lastScheduleTime) > 2*time.Minute {
+		scheduler.lastScheduleTime = time.Now()
+	}
+
+	nextTime := now.Truncate(time.Minute).Add(time.Minute)
+	return &nextTime
+}
+
+// ScheduleJob creates a job for every task that became due since the last call. A task due
+// several times in between only runs once.
+func (scheduler *Scheduler) ScheduleJob(_ *model.Config, _ bool, _ *model.Job) (*model.Job, *model.AppError) {
+	now := time.Now()
+	since := scheduler.lastScheduleTime
+	scheduler.lastScheduleTime = now
+
+	var lastJob *model.Job
+	for _, task := range scheduler.app.GetPluginScheduledTasks() {
+		if !task.IsDue(since, now) {
+			continue
+		}
+
+		job, err := scheduler.jobServer.CreateJob(model.JobTypePluginScheduledTasks, map[string]string{
+			model.PluginScheduledTaskJobDataPluginId: task.PluginId,
+			model.PluginScheduledTaskJobDataCallback: task.Callback,
+		})
+		if err != nil {
+			// The other tasks are still scheduled, and this one is not retried until it is due again.
+			mlog.Error("Failed to schedule plugin task", mlog.String("plugin_id", task.PluginId), mlog.String("callback", task.Callback), mlog.Err(err))
+			continue
+		}
+		lastJob = job
+	}
+
+	return lastJob, nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin_scheduled_tasks
+
+import (
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const jobName = "PluginScheduledTasks"
+
+type AppIface interface {
+	GetPluginScheduledTasks() []*model.PluginScheduledTask
+	RunPluginScheduledTask(pluginID, callback string) *model.AppError
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.PluginSettings.Enable
+	}
+	execute := func(job *model.Job) error {
+		pluginID := job.Data[model.PluginScheduledTaskJobDataPluginId]
+		callback := job.Data[model.PluginScheduledTaskJobDataCallback]
+		if appErr := app.RunPluginScheduledTask(pluginID, callback); appErr != nil {
+			return appErr
+		}
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package post_archive
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/configservice"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const (
+	jobName = "PostArchive"
+
+	// threadsBatchSize is how many threads are moved to the archive per transaction.
+	threadsBatchSize = 500
+)
+
+type AppIface interface {
+	configservice.ConfigService
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, s store.Store) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.SqlSettings.EnablePostArchiving
+	}
+	execute := func(job *model.Job) error {
+		months := *app.Config().SqlSettings.PostArchiveAfterMonths
+		before := model.GetMillisForTime(time.Now().AddDate(0, -months, 0))
+
+		var archived int64
+		for {
+			count, err := s.PostArchive().ArchiveThreadsBefore(before, threadsBatchSize)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				break
+			}
+			archived += count
+		}
+
+		mlog.Info("Worker: Archived old posts", mlog.String("worker", jobName), mlog.Int64("archived_posts", archived))
+
+		job.Data["archived_posts"] = strconv.FormatInt(archived, 10)
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypePostArchive), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package purge_soft_deleted
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "PurgeSoftDeleted"
+
+type AppIface interface {
+	PurgeSoftDeleted(before int64) (int64, *model.AppError)
+}
+
+// MakeWorker returns the worker of the purge soft deleted job, which permanently deletes the
+// teams and commands deleted for longer than JobSettings.PurgeSoftDeletedThresholdDays.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.JobSettings.PurgeSoftDeletedThresholdDays >= 0
+	}
+	execute := func(job *model.Job) error {
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		thresholdDays := *jobServer.Config().JobSettings.PurgeSoftDeletedThresholdDays
+		before := model.GetMillisForTime(time.Now().AddDate(0, 0, -thresholdDays))
+
+		purged, appErr := app.PurgeSoftDeleted(before)
+
+		job.Data["purged"] = strconv.FormatInt(purged, 10)
+		if err := jobServer.UpdateInProgressJobData(job); err != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypePurgeSoftDeleted), mlog.String("job_id", job.Id), mlog.Err(err))
+		}
+
+		if appErr != nil {
+			return appErr
+		}
+
+		mlog.Debug("Worker: Purged soft deleted entities", mlog.String("worker", jobName), mlog.Int64("purged", purged))
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package reminders
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "Reminders"
+
+type AppIface interface {
+	SendDueReminders(now int64) (int, *model.AppError)
+}
+
+// MakeWorker returns the worker of the reminders job, which sends the users the reminders that
+// are due.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.ServiceSettings.EnableReminders
+	}
+	execute := func(job *model.Job) error {
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		sent, appErr := app.SendDueReminders(model.GetMillis())
+
+		job.Data["sent"] = strconv.Itoa(sent)
+		if err := jobServer.UpdateInProgressJobData(job); err != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeReminders), mlog.String("job_id", job.Id), mlog.Err(err))
+		}
+
+		if appErr != nil {
+			return appErr
+		}
+
+		mlog.Debug("Worker: Sent due reminders", mlog.String("worker", jobName), mlog.Int("sent", sent))
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
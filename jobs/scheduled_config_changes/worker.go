@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package scheduled_config_changes
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "ScheduledConfigChanges"
+
+type AppIface interface {
+	ApplyDueScheduledConfigChanges(now int64) (int, *model.AppError)
+}
+
+// MakeWorker returns the worker of the scheduled config changes job, which applies the changes
+// to the configuration that are due.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	isEnabled := func(_ *model.Config) bool {
+		return true
+	}
+	execute := func(job *model.Job) error {
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		applied, appErr := app.ApplyDueScheduledConfigChanges(model.GetMillis())
+
+		job.Data["applied"] = strconv.Itoa(applied)
+		if err := jobServer.UpdateInProgressJobData(job); err != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeScheduledConfigChanges), mlog.String("job_id", job.Id), mlog.Err(err))
+		}
+
+		if appErr != nil {
+			return appErr
+		}
+
+		mlog.Debug("Worker: Applied due scheduled config changes", mlog.String("worker", jobName), mlog.Int("applied", applied))
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package user_deletion
+
+import (
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "UserDeletion"
+
+type AppIface interface {
+	GetUser(userID string) (*model.User, *model.AppError)
+	UpdateActive(c *request.Context, user *model.User, active bool) (*model.User, *model.AppError)
+	WriteUserPersonalDataExport(userID, jobID string) (string, *model.AppError)
+	DeleteUserWithPolicy(c *request.Context, userID, policy string) *model.AppError
+}
+
+// MakeWorker returns the worker deleting a user in stages: the user is deactivated, their personal
+// data is exported for the admins, then the user is anonymized or removed according to the policy
+// of the job. The stage reached is recorded in the data of the job.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	appContext := &request.Context{}
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(job *model.Job) error {
+		userID := job.Data["user_id"]
+		policy := job.Data["policy"]
+
+		mlog.Info("Worker: Deleting user", mlog.String("worker", model.JobTypeUserDeletion), mlog.String("job_id", job.Id), mlog.String("user_id", userID), mlog.String("policy", policy), mlog.String("requester_id", job.Data["requester_id"]))
+
+		setStage := func(stage string, progress int64) {
+			job.Data["stage"] = stage
+			if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+				mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeUserDeletion), mlog.String("job_id", job.Id), mlog.Err(appErr))
+			}
+			if appErr := jobServer.SetJobProgress(job, progress); appErr != nil {
+				mlog.Error("Worker: Failed to set job progress", mlog.String("worker", model.JobTypeUserDeletion), mlog.String("job_id", job.Id), mlog.Err(appErr))
+			}
+		}
+
+		// The user is deactivated first so the export holds all their data.
+		setStage(model.UserDeletionStageDeactivating, 0)
+		user, appErr := app.GetUser(userID)
+		if appErr != nil {
+			return appErr
+		}
+		if user.DeleteAt == 0 {
+			if _, appErr := app.UpdateActive(appContext, user, false); appErr != nil {
+				return appErr
+			}
+		}
+
+		setStage(model.UserDeletionStageExporting, 10)
+		exportName, appErr := app.WriteUserPersonalDataExport(userID, job.Id)
+		if appErr != nil {
+			return appErr
+		}
+		job.Data["export_name"] = exportName
+
+		setStage(model.UserDeletionStageDeleting, 70)
+		if appErr := app.DeleteUserWithPolicy(appContext, userID, policy); appErr != nil {
+			return appErr
+		}
+
+		setStage(model.UserDeletionStageDone, 100)
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
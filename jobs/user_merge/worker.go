@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package user_merge
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/v6/app/request"
+	"github.com/mattermost/mattermost-server/v6/jobs"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const jobName = "UserMerge"
+
+type AppIface interface {
+	MergeUsers(c *request.Context, sourceUserID, targetUserID string) (*model.UserMergeCounts, int, *model.AppError)
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) model.Worker {
+	appContext := &request.Context{}
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(job *model.Job) error {
+		sourceUserID := job.Data["source_user_id"]
+		targetUserID := job.Data["target_user_id"]
+
+		mlog.Info("Worker: Merging users", mlog.String("worker", model.JobTypeUserMerge), mlog.String("job_id", job.Id), mlog.String("source_user_id", sourceUserID), mlog.String("target_user_id", targetUserID), mlog.String("requester_id", job.Data["requester_id"]))
+
+		counts, revokedSessions, appErr := app.MergeUsers(appContext, sourceUserID, targetUserID)
+		if appErr != nil {
+			return appErr
+		}
+
+		countsJSON, err := json.Marshal(counts)
+		if err != nil {
+			return model.NewAppError("UserMergeWorker", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		job.Data["counts"] = string(countsJSON)
+		job.Data["revoked_sessions"] = strconv.Itoa(revokedSessions)
+
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			mlog.Error("Worker: Failed to update job data", mlog.String("worker", model.JobTypeUserMerge), mlog.String("job_id", job.Id), mlog.Err(appErr))
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// APIDeprecation describes an endpoint of the API, or a field of one of its requests or responses,
+// that is scheduled for removal. The responses of a deprecated endpoint carry the Deprecation
+// header (RFC 9745), the Sunset header (RFC 8594) once a removal date is known, and a Link header
+// to the documentation of the deprecation. The deprecated fields are only listed by the
+// /api/v4/deprecations endpoint since the server can't tell whether a client relies on them.
+type APIDeprecation struct {
+	Method string `json:"method"`
+	// Path is the route of the endpoint relative to /api/v4, with its parameters but without their
+	// patterns, e.g. /users/{user_id}/image.
+	Path string `json:"path"`
+	// Field is the deprecated field, or empty when the whole endpoint is deprecated.
+	Field       string `json:"field,omitempty"`
+	Description string `json:"description"`
+	Replacement string `json:"replacement,omitempty"`
+	// DeprecatedAt and SunsetAt are in milliseconds since the epoch. SunsetAt is 0 until the
+	// removal is scheduled.
+	DeprecatedAt int64  `json:"deprecated_at"`
+	SunsetAt     int64  `json:"sunset_at,omitempty"`
+	Link         string `json:"link,omitempty"`
+}
+
+// IsEndpoint returns whether the whole endpoint is deprecated rather than one of its fields.
+func (d *APIDeprecation) IsEndpoint() bool {
+	return d.Field == ""
+}
+
+// DeprecationHeader returns the value of the Deprecation header, the time of the deprecation in
+// seconds since the epoch.
+func (d *APIDeprecation) DeprecationHeader() string {
+	return "@" + strconv.FormatInt(d.DeprecatedAt/1000, 10)
+}
+
+// SunsetHeader returns the value of the Sunset header, the date of the removal, or an empty string
+// if the removal isn't scheduled yet.
+func (d *APIDeprecation) SunsetHeader() string {
+	if d.SunsetAt == 0 {
+		return ""
+	}
+	return GetTimeForMillis(d.SunsetAt).UTC().Format(http.TimeFormat)
+}
+
+// LinkHeader returns the value of the Link header pointing to the documentation of the
+// deprecation, or an empty string if there is none.
+func (d *APIDeprecation) LinkHeader() string {
+	if d.Link == "" {
+		return ""
+	}
+	header := "<" + d.Link + `>; rel="deprecation"; type="text/html"`
+	if d.SunsetAt != 0 {
+		header += ", <" + d.Link + `>; rel="sunset"; type="text/html"`
+	}
+	return header
+}
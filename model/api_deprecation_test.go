@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIDeprecationHeaders(t *testing.T) {
+	t.Run("not scheduled for removal", func(t *testing.T) {
+		deprecation := &APIDeprecation{
+			Method:       "GET",
+			Path:         "/users/{user_id}/image",
+			DeprecatedAt: 1688169599000,
+		}
+
+		assert.True(t, deprecation.IsEndpoint())
+		assert.Equal(t, "@1688169599", deprecation.DeprecationHeader())
+		assert.Empty(t, deprecation.SunsetHeader())
+		assert.Empty(t, deprecation.LinkHeader())
+	})
+
+	t.Run("scheduled for removal", func(t *testing.T) {
+		deprecation := &APIDeprecation{
+			Method:       "GET",
+			Path:         "/users/{user_id}/image",
+			DeprecatedAt: 1688169599000,
+			SunsetAt:     1704067200000,
+			Link:         "https://example.com/deprecations",
+		}
+
+		assert.Equal(t, "Mon, 01 Jan 2024 00:00:00 GMT", deprecation.SunsetHeader())
+		assert.Equal(t, `<https://example.com/deprecations>; rel="deprecation"; type="text/html", <https://example.com/deprecations>; rel="sunset"; type="text/html"`, deprecation.LinkHeader())
+	})
+
+	t.Run("field", func(t *testing.T) {
+		deprecation := &APIDeprecation{
+			Method: "PUT",
+			Path:   "/config",
+			Field:  "ServiceSettings.SessionLengthWebInDays",
+		}
+
+		assert.False(t, deprecation.IsEndpoint())
+	})
+}
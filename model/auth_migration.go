@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	AuthMigrationMatchFieldEmail    = "email"
+	AuthMigrationMatchFieldUsername = "username"
+
+	// AuthMigrationMismatchNotFound is the reason of a user that has no account in the target
+	// provider.
+	AuthMigrationMismatchNotFound = "not_found"
+	// AuthMigrationMismatchDuplicate is the reason of a user whose account in the target provider
+	// matched another user as well.
+	AuthMigrationMismatchDuplicate = "duplicate"
+	// AuthMigrationMismatchTaken is the reason of a user whose account in the target provider is
+	// already used by another user.
+	AuthMigrationMismatchTaken = "taken"
+	// AuthMigrationMismatchFailed is the reason of a verified user that could not be migrated.
+	AuthMigrationMismatchFailed = "failed"
+)
+
+// AuthMigrationRequest is the body of a request migrating the users of an auth provider to
+// another one.
+type AuthMigrationRequest struct {
+	// From is the auth service the users are migrated from, "email" for users logging in with a
+	// password.
+	From string `json:"from"`
+	// To is the auth service the users are migrated to, either "ldap" or "saml".
+	To string `json:"to"`
+	// MatchField is the field a user is matched on with the AD/LDAP users, "email" or "username".
+	MatchField string `json:"match_field,omitempty"`
+	// Matches maps the email of a user to their SAML id. With Auto, users without a match use their
+	// email as SAML id.
+	Matches map[string]string `json:"matches,omitempty"`
+	Auto    bool              `json:"auto,omitempty"`
+	// DryRun only verifies the users against the target provider, without migrating them.
+	DryRun bool `json:"dry_run"`
+	// Partial migrates the verified users even though others could not be verified. Otherwise, a
+	// single mismatch prevents any user from being migrated.
+	Partial bool `json:"partial"`
+}
+
+func (r *AuthMigrationRequest) IsValid() *AppError {
+	switch r.From {
+	case UserAuthServiceEmail, UserAuthServiceLdap, UserAuthServiceSaml, ServiceGitlab, ServiceGoogle, ServiceOffice365, ServiceOpenid:
+	default:
+		if !IsOpenIdConnectService(r.From) {
+			return NewAppError("AuthMigrationRequest.IsValid", "model.auth_migration.is_valid.from.app_error", nil, "from="+r.From, http.StatusBadRequest)
+		}
+	}
+
+	if (r.To != UserAuthServiceLdap && r.To != UserAuthServiceSaml) || r.To == r.From {
+		return NewAppError("AuthMigrationRequest.IsValid", "model.auth_migration.is_valid.to.app_error", nil, "to="+r.To, http.StatusBadRequest)
+	}
+
+	if r.To == UserAuthServiceLdap && r.MatchField != AuthMigrationMatchFieldEmail && r.MatchField != AuthMigrationMatchFieldUsername {
+		return NewAppError("AuthMigrationRequest.IsValid", "model.auth_migration.is_valid.match_field.app_error", nil, "match_field="+r.MatchField, http.StatusBadRequest)
+	}
+
+	if r.To == UserAuthServiceSaml && len(r.Matches) == 0 && !r.Auto {
+		return NewAppError("AuthMigrationRequest.IsValid", "model.auth_migration.is_valid.matches.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AuthMigrationMatch is a user verified against the target provider, along with their id in it.
+type AuthMigrationMatch struct {
+	UserId   string `json:"user_id"`
+	AuthData string `json:"auth_data"`
+}
+
+// AuthMigrationMismatch is a user that could not be verified against the target provider, or
+// migrated to it.
+type AuthMigrationMismatch struct {
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Reason   string `json:"reason"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// AuthMigrationReport is the progress of an auth migration job. Its mismatches can be downloaded
+// once the job is done.
+type AuthMigrationReport struct {
+	JobId      string `json:"job_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	DryRun     bool   `json:"dry_run"`
+	Partial    bool   `json:"partial"`
+	Status     string `json:"status"`
+	Progress   int64  `json:"progress"`
+	Total      int    `json:"total"`
+	Matched    int    `json:"matched"`
+	Mismatched int    `json:"mismatched"`
+	Migrated   int    `json:"migrated"`
+	// Applied is whether the verified users were migrated, which they aren't on a dry run or when
+	// some users could not be verified without Partial.
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMigrationRequestIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req   AuthMigrationRequest
+		valid bool
+	}{
+		"email to ldap":        {AuthMigrationRequest{From: UserAuthServiceEmail, To: UserAuthServiceLdap, MatchField: AuthMigrationMatchFieldEmail}, true},
+		"oidc to ldap":         {AuthMigrationRequest{From: OpenIdConnectService("okta"), To: UserAuthServiceLdap, MatchField: AuthMigrationMatchFieldUsername}, true},
+		"saml to ldap":         {AuthMigrationRequest{From: UserAuthServiceSaml, To: UserAuthServiceLdap, MatchField: AuthMigrationMatchFieldEmail}, true},
+		"ldap to saml":         {AuthMigrationRequest{From: UserAuthServiceLdap, To: UserAuthServiceSaml, Auto: true}, true},
+		"unknown source":       {AuthMigrationRequest{From: "github", To: UserAuthServiceLdap, MatchField: AuthMigrationMatchFieldEmail}, false},
+		"to gitlab":            {AuthMigrationRequest{From: UserAuthServiceEmail, To: ServiceGitlab}, false},
+		"to the same provider": {AuthMigrationRequest{From: UserAuthServiceLdap, To: UserAuthServiceLdap, MatchField: AuthMigrationMatchFieldEmail}, false},
+		"invalid match field":  {AuthMigrationRequest{From: UserAuthServiceEmail, To: UserAuthServiceLdap, MatchField: "id"}, false},
+		"saml without matches": {AuthMigrationRequest{From: UserAuthServiceEmail, To: UserAuthServiceSaml}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.valid, tc.req.IsValid() == nil)
+		})
+	}
+}
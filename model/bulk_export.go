@@ -7,6 +7,14 @@ package model
 // included with the export (e.g. file attachments).
 const ExportDataDir = "data"
 
+// Formats an export job can write its output in.
+const (
+	BulkExportFormatMattermost = "mattermost"
+	// BulkExportFormatSlack lays out the export like a Slack workspace export. It covers a single
+	// team, given by the team_id of the job data.
+	BulkExportFormatSlack = "slack"
+)
+
 type BulkExportOpts struct {
 	IncludeAttachments bool
 	CreateArchive      bool
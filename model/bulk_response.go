@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// BulkItemResult is the outcome of one item of a batch request: the status code it would have
+// had on its own, and either the resulting object or the error.
+type BulkItemResult struct {
+	// Id identifies the item in the request, e.g. the id of the user to add.
+	Id         string          `json:"id"`
+	StatusCode int             `json:"status_code"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	Error      *AppError       `json:"error,omitempty"`
+}
+
+// IsSuccess returns whether the item succeeded.
+func (r *BulkItemResult) IsSuccess() bool {
+	return r.Error == nil
+}
+
+// DecodeData decodes the resulting object of a successful item into v.
+func (r *BulkItemResult) DecodeData(v interface{}) error {
+	return json.Unmarshal(r.Data, v)
+}
+
+// BulkResponse is the response of the batch endpoints processing each item independently, so
+// that the failure of some items doesn't prevent the others from succeeding. The results are in
+// the order of the items of the request. The response has the status 200 OK when every item
+// succeeded, and 207 Multi-Status otherwise.
+type BulkResponse struct {
+	Results   []*BulkItemResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+func NewBulkResponse(size int) *BulkResponse {
+	return &BulkResponse{
+		Results: make([]*BulkItemResult, 0, size),
+	}
+}
+
+// AddSuccess records the success of the item with the given id, along with its resulting object
+// if it has one.
+func (r *BulkResponse) AddSuccess(id string, statusCode int, data interface{}) {
+	result := &BulkItemResult{
+		Id:         id,
+		StatusCode: statusCode,
+	}
+
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			r.AddError(id, NewAppError("BulkResponse.AddSuccess", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError))
+			return
+		}
+		result.Data = b
+	}
+
+	r.Results = append(r.Results, result)
+	r.Succeeded++
+}
+
+// AddError records the failure of the item with the given id.
+func (r *BulkResponse) AddError(id string, appErr *AppError) {
+	r.Results = append(r.Results, &BulkItemResult{
+		Id:         id,
+		StatusCode: appErr.StatusCode,
+		Error:      appErr,
+	})
+	r.Failed++
+}
+
+// StatusCode returns the status of the whole response.
+func (r *BulkResponse) StatusCode() int {
+	if r.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+	return http.StatusOK
+}
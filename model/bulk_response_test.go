@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkResponse(t *testing.T) {
+	resp := NewBulkResponse(2)
+	resp.AddSuccess("a", http.StatusCreated, &ChannelMember{ChannelId: "channel", UserId: "a"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode(), "should be OK when every item succeeded")
+
+	resp.AddError("b", NewAppError("Test", "test.app_error", nil, "", http.StatusForbidden))
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode())
+	assert.Equal(t, 1, resp.Succeeded)
+	assert.Equal(t, 1, resp.Failed)
+
+	b, err := json.Marshal(resp)
+	require.NoError(t, err)
+
+	var decoded BulkResponse
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Len(t, decoded.Results, 2)
+
+	assert.Equal(t, "a", decoded.Results[0].Id)
+	assert.True(t, decoded.Results[0].IsSuccess())
+	assert.Equal(t, http.StatusCreated, decoded.Results[0].StatusCode)
+	var member ChannelMember
+	require.NoError(t, decoded.Results[0].DecodeData(&member))
+	assert.Equal(t, "channel", member.ChannelId)
+
+	assert.Equal(t, "b", decoded.Results[1].Id)
+	assert.False(t, decoded.Results[1].IsSuccess())
+	assert.Equal(t, http.StatusForbidden, decoded.Results[1].StatusCode)
+	assert.Equal(t, "test.app_error", decoded.Results[1].Error.Id)
+	assert.Empty(t, decoded.Results[1].Data)
+}
+
+func TestBulkResponseAddSuccessWithoutData(t *testing.T) {
+	resp := NewBulkResponse(1)
+	resp.AddSuccess("a", http.StatusOK, nil)
+	require.Len(t, resp.Results, 1)
+	assert.Nil(t, resp.Results[0].Data)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+}
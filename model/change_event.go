@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	ChangeEventEntityPost    = "post"
+	ChangeEventEntityUser    = "user"
+	ChangeEventEntityChannel = "channel"
+
+	ChangeEventOperationCreate = "create"
+	ChangeEventOperationUpdate = "update"
+	ChangeEventOperationDelete = "delete"
+)
+
+// ChangeEvent is a change to a row of a captured entity, recorded in the outbox until it is
+// published. Data is the entity after the change, and is empty for the permanent deletions.
+type ChangeEvent struct {
+	Id          string          `json:"id"`
+	EntityType  string          `json:"entity_type"`
+	EntityId    string          `json:"entity_id"`
+	Operation   string          `json:"operation"`
+	Data        json.RawMessage `json:"data,omitempty"`
+	CreateAt    int64           `json:"create_at"`
+	PublishedAt int64           `json:"published_at"`
+}
+
+// NewChangeEvent returns the event of the given change to an entity, whose data is marshalled to
+// JSON unless it is nil.
+func NewChangeEvent(entityType, entityID, operation string, data interface{}) (*ChangeEvent, error) {
+	event := &ChangeEvent{
+		EntityType: entityType,
+		EntityId:   entityID,
+		Operation:  operation,
+	}
+
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		event.Data = b
+	}
+
+	return event, nil
+}
+
+func (e *ChangeEvent) PreSave() {
+	if e.Id == "" {
+		e.Id = NewId()
+	}
+
+	if e.CreateAt == 0 {
+		e.CreateAt = GetMillis()
+	}
+}
+
+func (e *ChangeEvent) IsValid() *AppError {
+	if !IsValidId(e.Id) {
+		return NewAppError("ChangeEvent.IsValid", "model.change_event.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	switch e.EntityType {
+	case ChangeEventEntityPost, ChangeEventEntityUser, ChangeEventEntityChannel:
+	default:
+		return NewAppError("ChangeEvent.IsValid", "model.change_event.is_valid.entity_type.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(e.EntityId) {
+		return NewAppError("ChangeEvent.IsValid", "model.change_event.is_valid.entity_id.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	switch e.Operation {
+	case ChangeEventOperationCreate, ChangeEventOperationUpdate, ChangeEventOperationDelete:
+	default:
+		return NewAppError("ChangeEvent.IsValid", "model.change_event.is_valid.operation.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.CreateAt == 0 {
+		return NewAppError("ChangeEvent.IsValid", "model.change_event.is_valid.create_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
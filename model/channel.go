@@ -32,6 +32,7 @@ const (
 	ChannelHeaderMaxRunes      = 1024
 	ChannelPurposeMaxRunes     = 250
 	ChannelCacheSize           = 25000
+	ChannelSlowmodeMaxSeconds  = 6 * 60 * 60
 
 	ChannelSortByUsername = "username"
 	ChannelSortByStatus   = "status"
@@ -59,6 +60,9 @@ type Channel struct {
 	TotalMsgCountRoot int64                  `json:"total_msg_count_root"`
 	PolicyID          *string                `json:"policy_id"`
 	LastRootPostAt    int64                  `json:"last_root_post_at"`
+	// SlowmodeSeconds is the minimum number of seconds between two posts of a member in the
+	// channel, zero if slowmode is disabled.
+	SlowmodeSeconds int `json:"slowmode_seconds,omitempty"`
 }
 
 type ChannelWithTeamData struct {
@@ -107,6 +111,13 @@ type ChannelModeratedRole struct {
 	Enabled bool `json:"enabled"`
 }
 
+// ChannelSlowmode is the slowmode setting of a channel.
+type ChannelSlowmode struct {
+	// Seconds is the minimum number of seconds between two posts of a member in the channel, zero
+	// to disable slowmode.
+	Seconds int `json:"seconds"`
+}
+
 type ChannelModerationPatch struct {
 	Name  *string                     `json:"name"`
 	Roles *ChannelModeratedRolesPatch `json:"roles"`
@@ -153,6 +164,17 @@ type ChannelMemberCountByGroup struct {
 	ChannelMemberTimezonesCount int64  `json:"channel_member_timezones_count"`
 }
 
+// ChannelMemberCounts holds the maintained counts of the active members and guests of a channel.
+// They are updated as members join and leave, and reconciled periodically with the actual
+// memberships to account for users being deactivated or demoted. The number of messages of a
+// channel is maintained in its TotalMsgCount.
+type ChannelMemberCounts struct {
+	ChannelId   string `json:"channel_id"`
+	MemberCount int64  `json:"member_count"`
+	GuestCount  int64  `json:"guest_count"`
+	UpdateAt    int64  `json:"update_at"`
+}
+
 type ChannelOption func(channel *Channel)
 
 func WithID(ID string) ChannelOption {
@@ -235,6 +257,10 @@ func (o *Channel) IsValid() *AppError {
 		return NewAppError("Channel.IsValid", "model.channel.is_valid.creator_id.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if o.SlowmodeSeconds < 0 || o.SlowmodeSeconds > ChannelSlowmodeMaxSeconds {
+		return NewAppError("Channel.IsValid", "model.channel.is_valid.slowmode.app_error", map[string]interface{}{"Max": ChannelSlowmodeMaxSeconds}, "id="+o.Id, http.StatusBadRequest)
+	}
+
 	userIds := strings.Split(o.Name, "__")
 	if o.Type != ChannelTypeDirect && len(userIds) == 2 && IsValidId(userIds[0]) && IsValidId(userIds[1]) {
 		return NewAppError("Channel.IsValid", "model.channel.is_valid.name.app_error", nil, "", http.StatusBadRequest)
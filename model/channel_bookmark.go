@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	ChannelBookmarkLink = "link"
+	ChannelBookmarkFile = "file"
+
+	ChannelBookmarkDisplayNameMaxRunes = 64
+	ChannelBookmarkLinkURLMaxRunes     = 1024
+	ChannelBookmarkEmojiMaxRunes       = 64
+
+	// MaxChannelBookmarksPerChannel is the number of bookmarks a channel can have at most.
+	MaxChannelBookmarksPerChannel = 50
+)
+
+// ChannelBookmark is an entry of the bookmark bar of a channel, linking either to a URL or to a
+// file uploaded to the channel. Bookmarks are shown in the order of their SortOrder.
+type ChannelBookmark struct {
+	Id          string `json:"id"`
+	ChannelId   string `json:"channel_id"`
+	OwnerId     string `json:"owner_id"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+	LinkUrl     string `json:"link_url,omitempty"`
+	FileId      string `json:"file_id,omitempty"`
+	Emoji       string `json:"emoji,omitempty"`
+	SortOrder   int64  `json:"sort_order"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+	DeleteAt    int64  `json:"delete_at"`
+}
+
+// ChannelBookmarkPatch holds the fields of a bookmark that can be changed. Its type can't.
+type ChannelBookmarkPatch struct {
+	DisplayName *string `json:"display_name"`
+	LinkUrl     *string `json:"link_url"`
+	FileId      *string `json:"file_id"`
+	Emoji       *string `json:"emoji"`
+}
+
+func (b *ChannelBookmark) PreSave() {
+	if b.Id == "" {
+		b.Id = NewId()
+	}
+
+	if b.CreateAt == 0 {
+		b.CreateAt = GetMillis()
+	}
+	b.UpdateAt = b.CreateAt
+	b.DeleteAt = 0
+}
+
+func (b *ChannelBookmark) PreUpdate() {
+	b.UpdateAt = GetMillis()
+}
+
+func (b *ChannelBookmark) IsValid() *AppError {
+	if !IsValidId(b.Id) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(b.ChannelId) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.channel_id.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(b.OwnerId) {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.owner_id.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+	}
+
+	if b.DisplayName == "" || utf8.RuneCountInString(b.DisplayName) > ChannelBookmarkDisplayNameMaxRunes {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.display_name.app_error", map[string]interface{}{"Max": ChannelBookmarkDisplayNameMaxRunes}, "id="+b.Id, http.StatusBadRequest)
+	}
+
+	switch b.Type {
+	case ChannelBookmarkLink:
+		if b.FileId != "" || utf8.RuneCountInString(b.LinkUrl) > ChannelBookmarkLinkURLMaxRunes || !IsValidHTTPURL(b.LinkUrl) {
+			return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.link_url.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+		}
+	case ChannelBookmarkFile:
+		if b.LinkUrl != "" || !IsValidId(b.FileId) {
+			return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.file_id.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.type.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(b.Emoji) > ChannelBookmarkEmojiMaxRunes {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.emoji.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+	}
+
+	if b.CreateAt == 0 {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.create_at.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+	}
+
+	if b.UpdateAt == 0 {
+		return NewAppError("ChannelBookmark.IsValid", "model.channel_bookmark.is_valid.update_at.app_error", nil, "id="+b.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (b *ChannelBookmark) Patch(patch *ChannelBookmarkPatch) {
+	if patch.DisplayName != nil {
+		b.DisplayName = *patch.DisplayName
+	}
+
+	if patch.LinkUrl != nil {
+		b.LinkUrl = *patch.LinkUrl
+	}
+
+	if patch.FileId != nil {
+		b.FileId = *patch.FileId
+	}
+
+	if patch.Emoji != nil {
+		b.Emoji = *patch.Emoji
+	}
+}
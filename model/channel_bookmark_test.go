@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelBookmarkIsValid(t *testing.T) {
+	b := ChannelBookmark{
+		ChannelId:   NewId(),
+		OwnerId:     NewId(),
+		DisplayName: "Handbook",
+		Type:        ChannelBookmarkLink,
+		LinkUrl:     "https://example.com/handbook",
+	}
+	b.PreSave()
+	require.Nil(t, b.IsValid())
+
+	b.DisplayName = ""
+	require.NotNil(t, b.IsValid())
+
+	b.DisplayName = strings.Repeat("a", ChannelBookmarkDisplayNameMaxRunes+1)
+	require.NotNil(t, b.IsValid())
+
+	b.DisplayName = "Handbook"
+	b.LinkUrl = "javascript:alert(1)"
+	require.NotNil(t, b.IsValid())
+
+	b.LinkUrl = "https://example.com/handbook"
+	b.FileId = NewId()
+	require.NotNil(t, b.IsValid())
+
+	b.Type = ChannelBookmarkFile
+	require.NotNil(t, b.IsValid())
+
+	b.LinkUrl = ""
+	require.Nil(t, b.IsValid())
+
+	b.FileId = ""
+	require.NotNil(t, b.IsValid())
+
+	b.Type = "folder"
+	require.NotNil(t, b.IsValid())
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ChannelEventRecurrenceDaily    = "daily"
+	ChannelEventRecurrenceWeekdays = "weekdays"
+	ChannelEventRecurrenceWeekly   = "weekly"
+
+	ChannelEventTitleMaxRunes       = 128
+	ChannelEventDescriptionMaxRunes = 1024
+	ChannelEventTimezoneMaxLength   = 64
+	ChannelEventRemindBeforeMax     = 7 * 24 * 60 // a week, in minutes
+)
+
+// ChannelEvent is an event scheduled in a channel, announced there by the system bot when it
+// starts, or RemindBeforeMinutes before. A recurring event repeats at the same time of day in its
+// timezone, so that it doesn't shift with daylight saving time.
+type ChannelEvent struct {
+	Id                  string `json:"id"`
+	ChannelId           string `json:"channel_id"`
+	CreatorId           string `json:"creator_id"`
+	Title               string `json:"title"`
+	Description         string `json:"description"`
+	StartAt             int64  `json:"start_at"`
+	Timezone            string `json:"timezone"`
+	Recurrence          string `json:"recurrence,omitempty"`
+	RemindBeforeMinutes int    `json:"remind_before_minutes"`
+	// NextStartAt is the start of the next occurrence to be announced, and NotifyAt the time it
+	// is announced at. Both are zero once an event is over.
+	NextStartAt int64 `json:"next_start_at"`
+	NotifyAt    int64 `json:"notify_at"`
+	CreateAt    int64 `json:"create_at"`
+	UpdateAt    int64 `json:"update_at"`
+}
+
+type ChannelEventPatch struct {
+	Title               *string `json:"title"`
+	Description         *string `json:"description"`
+	StartAt             *int64  `json:"start_at"`
+	Timezone            *string `json:"timezone"`
+	Recurrence          *string `json:"recurrence"`
+	RemindBeforeMinutes *int    `json:"remind_before_minutes"`
+}
+
+func IsValidChannelEventRecurrence(recurrence string) bool {
+	switch recurrence {
+	case "", ChannelEventRecurrenceDaily, ChannelEventRecurrenceWeekdays, ChannelEventRecurrenceWeekly:
+		return true
+	}
+	return false
+}
+
+// IsValidChannelEventTimezone returns whether the timezone is the name of a timezone of the IANA
+// database, e.g. Europe/Paris.
+func IsValidChannelEventTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" || len(timezone) > ChannelEventTimezoneMaxLength {
+		return false
+	}
+
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
+
+func (e *ChannelEvent) PreSave() {
+	if e.Id == "" {
+		e.Id = NewId()
+	}
+
+	if e.CreateAt == 0 {
+		e.CreateAt = GetMillis()
+	}
+	e.UpdateAt = e.CreateAt
+}
+
+func (e *ChannelEvent) PreUpdate() {
+	e.UpdateAt = GetMillis()
+}
+
+func (e *ChannelEvent) IsValid() *AppError {
+	if !IsValidId(e.Id) {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(e.ChannelId) {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.channel_id.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(e.CreatorId) {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.creator_id.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.Title == "" || utf8.RuneCountInString(e.Title) > ChannelEventTitleMaxRunes {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.title.app_error", map[string]interface{}{"Max": ChannelEventTitleMaxRunes}, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(e.Description) > ChannelEventDescriptionMaxRunes {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.description.app_error", map[string]interface{}{"Max": ChannelEventDescriptionMaxRunes}, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.StartAt <= 0 {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.start_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidChannelEventTimezone(e.Timezone) {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.timezone.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidChannelEventRecurrence(e.Recurrence) {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.recurrence.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.RemindBeforeMinutes < 0 || e.RemindBeforeMinutes > ChannelEventRemindBeforeMax {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.remind_before.app_error", map[string]interface{}{"Max": ChannelEventRemindBeforeMax}, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.CreateAt == 0 {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.create_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.UpdateAt == 0 {
+		return NewAppError("ChannelEvent.IsValid", "model.channel_event.is_valid.update_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (e *ChannelEvent) Patch(patch *ChannelEventPatch) {
+	if patch.Title != nil {
+		e.Title = *patch.Title
+	}
+
+	if patch.Description != nil {
+		e.Description = *patch.Description
+	}
+
+	if patch.StartAt != nil {
+		e.StartAt = *patch.StartAt
+	}
+
+	if patch.Timezone != nil {
+		e.Timezone = *patch.Timezone
+	}
+
+	if patch.Recurrence != nil {
+		e.Recurrence = *patch.Recurrence
+	}
+
+	if patch.RemindBeforeMinutes != nil {
+		e.RemindBeforeMinutes = *patch.RemindBeforeMinutes
+	}
+}
+
+// Location returns the location of the timezone of the event.
+func (e *ChannelEvent) Location() (*time.Location, error) {
+	return time.LoadLocation(e.Timezone)
+}
+
+// OccurrenceAfter returns the start of the first occurrence of the event strictly after the given
+// time, or zero if there is none.
+func (e *ChannelEvent) OccurrenceAfter(after int64) int64 {
+	if e.StartAt > after {
+		return e.StartAt
+	}
+
+	var days int
+	switch e.Recurrence {
+	case ChannelEventRecurrenceDaily, ChannelEventRecurrenceWeekdays:
+		days = 1
+	case ChannelEventRecurrenceWeekly:
+		days = 7
+	default:
+		return 0
+	}
+
+	loc, err := e.Location()
+	if err != nil {
+		loc = time.UTC
+	}
+	start := GetTimeForMillis(e.StartAt).In(loc)
+
+	// Occurrences are computed from the wall clock of the first one, which is why they can't
+	// just be a multiple of 24 hours apart. Skip ahead to about the right one first.
+	interval := int64(days) * 24 * 60 * 60 * 1000
+	n := int((after - e.StartAt) / interval)
+	for {
+		next := start.AddDate(0, 0, n*days)
+		if GetMillisForTime(next) > after && (e.Recurrence != ChannelEventRecurrenceWeekdays || isWeekday(next)) {
+			return GetMillisForTime(next)
+		}
+		n++
+	}
+}
+
+// Schedule sets the next occurrence of the event to be announced, the first one that isn't due
+// to be announced by now. Occurrences missed while the server was down are skipped.
+func (e *ChannelEvent) Schedule(now int64) {
+	remindBefore := int64(e.RemindBeforeMinutes) * 60 * 1000
+
+	e.NextStartAt = e.OccurrenceAfter(now + remindBefore)
+	e.NotifyAt = 0
+	if e.NextStartAt != 0 {
+		e.NotifyAt = e.NextStartAt - remindBefore
+	}
+}
+
+func isWeekday(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelEventIsValid(t *testing.T) {
+	e := ChannelEvent{ChannelId: NewId(), CreatorId: NewId(), Title: "Standup", StartAt: GetMillis(), Timezone: "Europe/Paris"}
+	e.PreSave()
+	require.Nil(t, e.IsValid())
+
+	e.Title = ""
+	require.NotNil(t, e.IsValid())
+	e.Title = "Standup"
+
+	for _, timezone := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		e.Timezone = timezone
+		require.NotNil(t, e.IsValid(), timezone)
+	}
+	e.Timezone = "America/New_York"
+	require.Nil(t, e.IsValid())
+
+	e.Recurrence = "hourly"
+	require.NotNil(t, e.IsValid())
+	e.Recurrence = ChannelEventRecurrenceWeekdays
+	require.Nil(t, e.IsValid())
+
+	e.RemindBeforeMinutes = -1
+	require.NotNil(t, e.IsValid())
+	e.RemindBeforeMinutes = ChannelEventRemindBeforeMax + 1
+	require.NotNil(t, e.IsValid())
+}
+
+func TestChannelEventOccurrenceAfter(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	at := func(year int, month time.Month, day, hour int) int64 {
+		return GetMillisForTime(time.Date(year, month, day, hour, 0, 0, 0, paris))
+	}
+
+	// Friday the 24th of March 2023, two days before the switch to summer time.
+	e := ChannelEvent{StartAt: at(2023, time.March, 24, 9), Timezone: "Europe/Paris"}
+
+	t.Run("one-off", func(t *testing.T) {
+		assert.Equal(t, e.StartAt, e.OccurrenceAfter(e.StartAt-1))
+		assert.Zero(t, e.OccurrenceAfter(e.StartAt))
+	})
+
+	t.Run("daily events keep their time of day across daylight saving time", func(t *testing.T) {
+		e.Recurrence = ChannelEventRecurrenceDaily
+		assert.Equal(t, at(2023, time.March, 25, 9), e.OccurrenceAfter(e.StartAt))
+		assert.Equal(t, at(2023, time.March, 27, 9), e.OccurrenceAfter(at(2023, time.March, 26, 10)))
+	})
+
+	t.Run("weekdays", func(t *testing.T) {
+		e.Recurrence = ChannelEventRecurrenceWeekdays
+		assert.Equal(t, at(2023, time.March, 27, 9), e.OccurrenceAfter(e.StartAt))
+	})
+
+	t.Run("weekly", func(t *testing.T) {
+		e.Recurrence = ChannelEventRecurrenceWeekly
+		assert.Equal(t, at(2023, time.March, 31, 9), e.OccurrenceAfter(e.StartAt))
+		assert.Equal(t, at(2023, time.April, 7, 9), e.OccurrenceAfter(at(2023, time.March, 31, 9)))
+	})
+}
+
+func TestChannelEventSchedule(t *testing.T) {
+	start := GetMillis() + 60*60*1000
+	e := ChannelEvent{StartAt: start, Timezone: "UTC", RemindBeforeMinutes: 15}
+
+	e.Schedule(GetMillis())
+	assert.Equal(t, start, e.NextStartAt)
+	assert.Equal(t, start-15*60*1000, e.NotifyAt)
+
+	e.Schedule(start - 10*60*1000)
+	assert.Zero(t, e.NextStartAt)
+	assert.Zero(t, e.NotifyAt)
+
+	e.Recurrence = ChannelEventRecurrenceDaily
+	e.Schedule(start - 10*60*1000)
+	assert.Equal(t, start+24*60*60*1000, e.NextStartAt)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	ChannelJoinRequestStatusPending  = "pending"
+	ChannelJoinRequestStatusApproved = "approved"
+	ChannelJoinRequestStatusDenied   = "denied"
+	ChannelJoinRequestStatusExpired  = "expired"
+	// ChannelJoinRequestStatusCanceled is only used in websocket events, canceled requests being
+	// removed.
+	ChannelJoinRequestStatusCanceled = "canceled"
+
+	ChannelJoinRequestMessageMaxRunes = 1024
+	ChannelJoinRequestExpiryDays      = 14
+	// ChannelJoinRequestRetentionDays is how long the requests are kept once reviewed or expired.
+	ChannelJoinRequestRetentionDays = 90
+)
+
+// ChannelJoinRequest is the request of a user to join a private channel, reviewed by the members
+// allowed to manage its members. A request is no longer pending once it expires.
+type ChannelJoinRequest struct {
+	Id         string `json:"id"`
+	ChannelId  string `json:"channel_id"`
+	UserId     string `json:"user_id"`
+	Message    string `json:"message"`
+	Status     string `json:"status"`
+	ReviewerId string `json:"reviewer_id"`
+	CreateAt   int64  `json:"create_at"`
+	UpdateAt   int64  `json:"update_at"`
+	ExpireAt   int64  `json:"expire_at"`
+}
+
+func (o *ChannelJoinRequest) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = o.CreateAt
+
+	if o.ExpireAt == 0 {
+		o.ExpireAt = o.CreateAt + ChannelJoinRequestExpiryDays*24*60*60*1000
+	}
+
+	if o.Status == "" {
+		o.Status = ChannelJoinRequestStatusPending
+	}
+}
+
+func (o *ChannelJoinRequest) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *ChannelJoinRequest) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Message) > ChannelJoinRequestMessageMaxRunes {
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.message.app_error", map[string]interface{}{"Max": ChannelJoinRequestMessageMaxRunes}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case ChannelJoinRequestStatusPending, ChannelJoinRequestStatusExpired:
+	case ChannelJoinRequestStatusApproved, ChannelJoinRequestStatusDenied:
+		if !IsValidId(o.ReviewerId) {
+			return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.reviewer_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.status.app_error", nil, "id="+o.Id+" status="+o.Status, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ExpireAt <= o.CreateAt {
+		return NewAppError("ChannelJoinRequest.IsValid", "model.channel_join_request.is_valid.expire_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsPending returns whether the request still awaits a review at the given time.
+func (o *ChannelJoinRequest) IsPending(now int64) bool {
+	return o.Status == ChannelJoinRequestStatusPending && o.ExpireAt > now
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelJoinRequestIsValid(t *testing.T) {
+	request := &ChannelJoinRequest{
+		ChannelId: NewId(),
+		UserId:    NewId(),
+	}
+	request.PreSave()
+	require.Nil(t, request.IsValid())
+	assert.Equal(t, ChannelJoinRequestStatusPending, request.Status)
+	assert.Equal(t, request.CreateAt+ChannelJoinRequestExpiryDays*24*60*60*1000, request.ExpireAt)
+
+	request.Message = strings.Repeat("a", ChannelJoinRequestMessageMaxRunes+1)
+	require.NotNil(t, request.IsValid())
+	request.Message = ""
+
+	request.Status = ChannelJoinRequestStatusApproved
+	require.NotNil(t, request.IsValid(), "a reviewed request needs a reviewer")
+	request.ReviewerId = NewId()
+	require.Nil(t, request.IsValid())
+
+	request.Status = ChannelJoinRequestStatusCanceled
+	require.NotNil(t, request.IsValid(), "canceled requests are never stored")
+
+	request.Status = ChannelJoinRequestStatusPending
+	request.ExpireAt = request.CreateAt
+	require.NotNil(t, request.IsValid())
+}
+
+func TestChannelJoinRequestIsPending(t *testing.T) {
+	request := &ChannelJoinRequest{Status: ChannelJoinRequestStatusPending, ExpireAt: 2000}
+	assert.True(t, request.IsPending(1000))
+	assert.False(t, request.IsPending(2000))
+
+	request.Status = ChannelJoinRequestStatusDenied
+	assert.False(t, request.IsPending(1000))
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	// ChannelMemberTimeoutMaxMinutes is the longest a member can be prevented from posting in a
+	// channel, a week.
+	ChannelMemberTimeoutMaxMinutes = 60 * 24 * 7
+)
+
+// ChannelMemberTimeout prevents a member from posting in a channel until it expires.
+type ChannelMemberTimeout struct {
+	ChannelId string `json:"channel_id"`
+	UserId    string `json:"user_id"`
+	CreatorId string `json:"creator_id"`
+	CreateAt  int64  `json:"create_at"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// ChannelMemberTimeoutRequest is the body of a request to time a channel member out.
+type ChannelMemberTimeoutRequest struct {
+	DurationMinutes int `json:"duration_minutes"`
+}
+
+func (o *ChannelMemberTimeout) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *ChannelMemberTimeout) IsValid() *AppError {
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("ChannelMemberTimeout.IsValid", "model.channel_member_timeout.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("ChannelMemberTimeout.IsValid", "model.channel_member_timeout.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("ChannelMemberTimeout.IsValid", "model.channel_member_timeout.is_valid.creator_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelMemberTimeout.IsValid", "model.channel_member_timeout.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.ExpiresAt <= o.CreateAt || o.ExpiresAt-o.CreateAt > ChannelMemberTimeoutMaxMinutes*60*1000 {
+		return NewAppError("ChannelMemberTimeout.IsValid", "model.channel_member_timeout.is_valid.expires_at.app_error", map[string]interface{}{"Max": ChannelMemberTimeoutMaxMinutes}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ChannelMemberTimeout) IsExpired() bool {
+	return GetMillis() >= o.ExpiresAt
+}
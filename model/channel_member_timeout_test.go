@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelMemberTimeoutIsValid(t *testing.T) {
+	now := GetMillis()
+	timeout := &ChannelMemberTimeout{
+		ChannelId: NewId(),
+		UserId:    NewId(),
+		CreatorId: NewId(),
+		CreateAt:  now,
+		ExpiresAt: now + 60*1000,
+	}
+	require.Nil(t, timeout.IsValid())
+	assert.False(t, timeout.IsExpired())
+
+	timeout.ExpiresAt = now
+	require.NotNil(t, timeout.IsValid())
+
+	timeout.ExpiresAt = now + (ChannelMemberTimeoutMaxMinutes+1)*60*1000
+	require.NotNil(t, timeout.IsValid())
+
+	timeout.ExpiresAt = now + 60*1000
+	timeout.CreatorId = ""
+	require.NotNil(t, timeout.IsValid())
+
+	timeout = &ChannelMemberTimeout{ExpiresAt: now - 1}
+	assert.True(t, timeout.IsExpired())
+}
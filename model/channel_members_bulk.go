@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	ChannelMembersBulkActionAdd    = "add"
+	ChannelMembersBulkActionRemove = "remove"
+
+	// ChannelMembersBulkMaxUsers is the maximum number of users a single bulk request can hold.
+	ChannelMembersBulkMaxUsers = 50000
+)
+
+// ChannelMembersBulkRequest is the body of a request adding or removing many users from a channel.
+type ChannelMembersBulkRequest struct {
+	Action  string   `json:"action"`
+	UserIds []string `json:"user_ids"`
+}
+
+func (r *ChannelMembersBulkRequest) IsValid() *AppError {
+	if r.Action != ChannelMembersBulkActionAdd && r.Action != ChannelMembersBulkActionRemove {
+		return NewAppError("ChannelMembersBulkRequest.IsValid", "model.channel_members_bulk.is_valid.action.app_error", nil, "action="+r.Action, http.StatusBadRequest)
+	}
+
+	if len(r.UserIds) == 0 || len(r.UserIds) > ChannelMembersBulkMaxUsers {
+		return NewAppError("ChannelMembersBulkRequest.IsValid", "model.channel_members_bulk.is_valid.user_ids.app_error", map[string]interface{}{"Max": ChannelMembersBulkMaxUsers}, "", http.StatusBadRequest)
+	}
+
+	for _, userID := range r.UserIds {
+		if !IsValidId(userID) {
+			return NewAppError("ChannelMembersBulkRequest.IsValid", "model.channel_members_bulk.is_valid.user_id.app_error", nil, "user_id="+userID, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// ChannelMembersBulkFailure describes why a user could not be added to or removed from the channel.
+type ChannelMembersBulkFailure struct {
+	UserId string `json:"user_id"`
+	Error  string `json:"error"`
+}
+
+// ChannelMembersBulkReport is the progress of a bulk channel membership job, along with the users
+// it failed for so far.
+type ChannelMembersBulkReport struct {
+	JobId     string                      `json:"job_id"`
+	ChannelId string                      `json:"channel_id"`
+	Action    string                      `json:"action"`
+	Status    string                      `json:"status"`
+	Progress  int64                       `json:"progress"`
+	Total     int                         `json:"total"`
+	Processed int                         `json:"processed"`
+	Succeeded int                         `json:"succeeded"`
+	Failures  []ChannelMembersBulkFailure `json:"failures"`
+}
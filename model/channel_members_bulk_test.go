@@ -0,0 +1,20 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelMembersBulkRequestIsValid(t *testing.T) {
+	require.Nil(t, (&ChannelMembersBulkRequest{Action: ChannelMembersBulkActionAdd, UserIds: []string{NewId()}}).IsValid())
+	require.Nil(t, (&ChannelMembersBulkRequest{Action: ChannelMembersBulkActionRemove, UserIds: []string{NewId()}}).IsValid())
+
+	require.NotNil(t, (&ChannelMembersBulkRequest{Action: "invite", UserIds: []string{NewId()}}).IsValid())
+	require.NotNil(t, (&ChannelMembersBulkRequest{Action: ChannelMembersBulkActionAdd}).IsValid())
+	require.NotNil(t, (&ChannelMembersBulkRequest{Action: ChannelMembersBulkActionAdd, UserIds: []string{"invalid"}}).IsValid())
+	require.NotNil(t, (&ChannelMembersBulkRequest{Action: ChannelMembersBulkActionAdd, UserIds: make([]string, ChannelMembersBulkMaxUsers+1)}).IsValid())
+}
@@ -5,6 +5,7 @@ package model
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -36,6 +37,9 @@ const (
 	HeaderRequestedWith      = "X-Requested-With"
 	HeaderRequestedWithXML   = "XMLHttpRequest"
 	HeaderRange              = "Range"
+	HeaderDeprecation        = "Deprecation"
+	HeaderSunset             = "Sunset"
+	HeaderLink               = "Link"
 	STATUS                   = "status"
 	StatusOk                 = "OK"
 	StatusFail               = "FAIL"
@@ -71,6 +75,9 @@ type Client4 struct {
 
 	// FalseString is the string value sent to the server for false boolean query parameters.
 	falseString string
+
+	// ctx is the context of the requests, set with WithContext.
+	ctx context.Context
 }
 
 // SetBoolString is a helper method for overriding how true and false query string parameters are
@@ -100,6 +107,22 @@ func (c *Client4) boolString(value bool) string {
 	return "false"
 }
 
+// WithContext returns a copy of the client whose requests are made with the given context, so that
+// they can be cancelled or given a deadline. The copy shares the HTTP client and the headers of
+// the original.
+func (c *Client4) WithContext(ctx context.Context) *Client4 {
+	client := *c
+	client.ctx = ctx
+	return &client
+}
+
+func (c *Client4) requestContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 func closeBody(r *http.Response) {
 	if r.Body != nil {
 		_, _ = io.Copy(ioutil.Discard, r.Body)
@@ -109,7 +132,7 @@ func closeBody(r *http.Response) {
 
 func NewAPIv4Client(url string) *Client4 {
 	url = strings.TrimRight(url, "/")
-	return &Client4{url, url + APIURLSuffix, &http.Client{}, "", "", map[string]string{}, "", ""}
+	return &Client4{url, url + APIURLSuffix, &http.Client{}, "", "", map[string]string{}, "", "", nil}
 }
 
 func NewAPIv4SocketClient(socketPath string) *Client4 {
@@ -247,6 +270,38 @@ func (c *Client4) channelRoute(channelId string) string {
 	return fmt.Sprintf(c.channelsRoute()+"/%v", channelId)
 }
 
+func (c *Client4) channelBookmarksRoute(channelId string) string {
+	return c.channelRoute(channelId) + "/bookmarks"
+}
+
+func (c *Client4) channelBookmarkRoute(channelId, bookmarkId string) string {
+	return fmt.Sprintf(c.channelBookmarksRoute(channelId)+"/%v", bookmarkId)
+}
+
+func (c *Client4) channelEventsRoute(channelId string) string {
+	return c.channelRoute(channelId) + "/events"
+}
+
+func (c *Client4) channelEventRoute(channelId, eventId string) string {
+	return fmt.Sprintf(c.channelEventsRoute(channelId)+"/%v", eventId)
+}
+
+func (c *Client4) channelJoinRequestsRoute(channelId string) string {
+	return c.channelRoute(channelId) + "/join_requests"
+}
+
+func (c *Client4) channelJoinRequestRoute(channelId, requestId string) string {
+	return fmt.Sprintf(c.channelJoinRequestsRoute(channelId)+"/%v", requestId)
+}
+
+func (c *Client4) teamInviteLinksRoute(teamId string) string {
+	return c.teamRoute(teamId) + "/invite_links"
+}
+
+func (c *Client4) teamInviteLinkRoute(teamId, linkId string) string {
+	return fmt.Sprintf(c.teamInviteLinksRoute(teamId)+"/%v", linkId)
+}
+
 func (c *Client4) channelByNameRoute(channelName, teamId string) string {
 	return fmt.Sprintf(c.teamRoute(teamId)+"/channels/name/%v", channelName)
 }
@@ -292,6 +347,30 @@ func (c *Client4) postRoute(postId string) string {
 	return fmt.Sprintf(c.postsRoute()+"/%v", postId)
 }
 
+func (c *Client4) postReportsRoute() string {
+	return "/post_reports"
+}
+
+func (c *Client4) postReportRoute(reportId string) string {
+	return fmt.Sprintf(c.postReportsRoute()+"/%v", reportId)
+}
+
+func (c *Client4) remindersRoute() string {
+	return "/reminders"
+}
+
+func (c *Client4) reminderRoute(reminderId string) string {
+	return fmt.Sprintf(c.remindersRoute()+"/%v", reminderId)
+}
+
+func (c *Client4) postPropSchemasRoute() string {
+	return "/post_prop_schemas"
+}
+
+func (c *Client4) postPropSchemaRoute(namespace string) string {
+	return fmt.Sprintf(c.postPropSchemasRoute()+"/%v", namespace)
+}
+
 func (c *Client4) filesRoute() string {
 	return "/files"
 }
@@ -332,6 +411,10 @@ func (c *Client4) usageRoute() string {
 	return "/usage"
 }
 
+func (c *Client4) impersonationRoute(impersonationId string) string {
+	return "/impersonation/" + impersonationId
+}
+
 func (c *Client4) testSiteURLRoute() string {
 	return "/site_url/test"
 }
@@ -456,6 +539,14 @@ func (c *Client4) oAuthAppRoute(appId string) string {
 	return fmt.Sprintf("/oauth/apps/%v", appId)
 }
 
+func (c *Client4) outgoingOAuthConnectionsRoute() string {
+	return "/oauth/outgoing_connections"
+}
+
+func (c *Client4) outgoingOAuthConnectionRoute(connectionId string) string {
+	return fmt.Sprintf(c.outgoingOAuthConnectionsRoute()+"/%v", connectionId)
+}
+
 func (c *Client4) openGraphRoute() string {
 	return "/opengraph"
 }
@@ -464,6 +555,22 @@ func (c *Client4) jobsRoute() string {
 	return "/jobs"
 }
 
+func (c *Client4) teamTemplatesRoute() string {
+	return "/team_templates"
+}
+
+func (c *Client4) teamTemplateRoute(templateId string) string {
+	return fmt.Sprintf(c.teamTemplatesRoute()+"/%v", templateId)
+}
+
+func (c *Client4) onboardingTasksRoute() string {
+	return "/onboarding/tasks"
+}
+
+func (c *Client4) onboardingTaskRoute(taskId string) string {
+	return fmt.Sprintf(c.onboardingTasksRoute()+"/%v", taskId)
+}
+
 func (c *Client4) rolesRoute() string {
 	return "/roles"
 }
@@ -548,6 +655,10 @@ func (c *Client4) sharedChannelsRoute() string {
 	return "/sharedchannels"
 }
 
+func (c *Client4) remoteClusterRoute(remoteID string) string {
+	return fmt.Sprintf("/remotecluster/%v", remoteID)
+}
+
 func (c *Client4) permissionsRoute() string {
 	return "/permissions"
 }
@@ -597,7 +708,7 @@ func (c *Client4) DoAPIRequestBytes(method, url string, data []byte, etag string
 }
 
 func (c *Client4) DoAPIRequestReader(method, url string, data io.Reader, headers map[string]string) (*http.Response, error) {
-	rq, err := http.NewRequest(method, url, data)
+	rq, err := http.NewRequestWithContext(c.requestContext(), method, url, data)
 	if err != nil {
 		return nil, err
 	}
@@ -638,7 +749,7 @@ func (c *Client4) DoUploadFile(url string, data []byte, contentType string) (*Fi
 }
 
 func (c *Client4) doUploadFile(url string, body io.Reader, contentType string, contentLength int64) (*FileUploadResponse, *Response, error) {
-	rq, err := http.NewRequest("POST", c.APIURL+url, body)
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+url, body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -669,7 +780,7 @@ func (c *Client4) doUploadFile(url string, body io.Reader, contentType string, c
 }
 
 func (c *Client4) DoEmojiUploadFile(url string, data []byte, contentType string) (*Emoji, *Response, error) {
-	rq, err := http.NewRequest("POST", c.APIURL+url, bytes.NewReader(data))
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+url, bytes.NewReader(data))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -697,7 +808,7 @@ func (c *Client4) DoEmojiUploadFile(url string, data []byte, contentType string)
 }
 
 func (c *Client4) DoUploadImportTeam(url string, data []byte, contentType string) (map[string]string, *Response, error) {
-	rq, err := http.NewRequest("POST", c.APIURL+url, bytes.NewReader(data))
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+url, bytes.NewReader(data))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1384,6 +1495,48 @@ func (c *Client4) UpdateUserMfa(userId, code string, activate bool) (*Response,
 	return BuildResponse(r), nil
 }
 
+// ActivateUserMfa activates multi-factor authentication for a user given a valid code, and
+// returns the backup codes generated for the user.
+func (c *Client4) ActivateUserMfa(userId, code string) (*MfaBackupCodes, *Response, error) {
+	requestBody := map[string]interface{}{"activate": true, "code": code}
+	r, err := c.DoAPIPut(c.userRoute(userId)+"/mfa", StringInterfaceToJSON(requestBody))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var backupCodes MfaBackupCodes
+	if jsonErr := json.NewDecoder(r.Body).Decode(&backupCodes); jsonErr != nil {
+		return nil, nil, NewAppError("ActivateUserMfa", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &backupCodes, BuildResponse(r), nil
+}
+
+// RegenerateMfaBackupCodes replaces the backup codes of a user given a valid code, either from
+// their authenticator app or one of their current backup codes. Must be logged in as the user.
+func (c *Client4) RegenerateMfaBackupCodes(userId, code string) (*MfaBackupCodes, *Response, error) {
+	r, err := c.DoAPIPost(c.userRoute(userId)+"/mfa/backup_codes", MapToJSON(map[string]string{"code": code}))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var backupCodes MfaBackupCodes
+	if jsonErr := json.NewDecoder(r.Body).Decode(&backupCodes); jsonErr != nil {
+		return nil, nil, NewAppError("RegenerateMfaBackupCodes", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &backupCodes, BuildResponse(r), nil
+}
+
+// ResetUserMfa deactivates multi-factor authentication for a user who lost access to it and
+// revokes their sessions. Must have the edit_other_users permission.
+func (c *Client4) ResetUserMfa(userId string) (*Response, error) {
+	r, err := c.DoAPIPost(c.userRoute(userId)+"/mfa/reset", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GenerateMfaSecret will generate a new MFA secret for a user and return it as a string and
 // as a base64 encoded image QR code.
 func (c *Client4) GenerateMfaSecret(userId string) (*MfaSecret, *Response, error) {
@@ -1465,6 +1618,25 @@ func (c *Client4) UpdateUserActive(userId string, active bool) (*Response, error
 	return BuildResponse(r), nil
 }
 
+// UpdateUsersActive activates or deactivates several users at once. The result of each user is
+// in the returned BulkResponse, in the order of the ids.
+func (c *Client4) UpdateUsersActive(userIds []string, active bool) (*BulkResponse, *Response, error) {
+	buf, err := json.Marshal(map[string]interface{}{"user_ids": userIds, "active": active})
+	if err != nil {
+		return nil, nil, NewAppError("UpdateUsersActive", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.usersRoute()+"/active/batch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var br BulkResponse
+	if jsonErr := json.NewDecoder(r.Body).Decode(&br); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateUsersActive", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &br, BuildResponse(r), nil
+}
+
 // DeleteUser deactivates a user in the system based on the provided user id string.
 func (c *Client4) DeleteUser(userId string) (*Response, error) {
 	r, err := c.DoAPIDelete(c.userRoute(userId))
@@ -1580,6 +1752,38 @@ func (c *Client4) RevokeSession(userId, sessionId string) (*Response, error) {
 	return BuildResponse(r), nil
 }
 
+// GetSessionDevices returns the devices of the sessions of a user.
+func (c *Client4) GetSessionDevices(userId string) ([]*SessionDevice, *Response, error) {
+	r, err := c.DoAPIGet(c.userRoute(userId)+"/sessions/devices", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var devices []*SessionDevice
+	if jsonErr := json.NewDecoder(r.Body).Decode(&devices); jsonErr != nil {
+		return nil, nil, NewAppError("GetSessionDevices", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return devices, BuildResponse(r), nil
+}
+
+// UpdateSessionDeviceName names the device of a session of a user, or clears its name if name
+// is empty.
+func (c *Client4) UpdateSessionDeviceName(userId, sessionId, name string) (*SessionDevice, *Response, error) {
+	requestBody := map[string]string{"session_id": sessionId, "name": name}
+	r, err := c.DoAPIPut(c.userRoute(userId)+"/sessions/devices/name", MapToJSON(requestBody))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var device SessionDevice
+	if jsonErr := json.NewDecoder(r.Body).Decode(&device); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateSessionDeviceName", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &device, BuildResponse(r), nil
+}
+
 // RevokeAllSessions revokes all sessions for the provided user id string.
 func (c *Client4) RevokeAllSessions(userId string) (*Response, error) {
 	r, err := c.DoAPIPost(c.userRoute(userId)+"/sessions/revoke/all", "")
@@ -1639,6 +1843,29 @@ func (c *Client4) GetTeamsUnreadForUser(userId, teamIdToExclude string, includeC
 	return list, BuildResponse(r), nil
 }
 
+// GetUnreadSummary will return the unread messages and mentions a user has in each of their
+// channels, direct and group messages included, leaving out the channels they have fully read.
+// An optional boolean can be set to include the unread threads they follow. Must be authenticated.
+func (c *Client4) GetUnreadSummary(userId string, includeCollapsedThreads bool) (*UnreadSummary, *Response, error) {
+	query := url.Values{}
+
+	if includeCollapsedThreads {
+		query.Set("include_collapsed_threads", "true")
+	}
+
+	r, err := c.DoAPIGet(c.userRoute(userId)+"/unreads?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var summary *UnreadSummary
+	if jsonErr := json.NewDecoder(r.Body).Decode(&summary); jsonErr != nil {
+		return nil, nil, NewAppError("GetUnreadSummary", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return summary, BuildResponse(r), nil
+}
+
 // GetUserAudits returns a list of audit based on the provided user id string.
 func (c *Client4) GetUserAudits(userId string, page int, perPage int, etag string) (Audits, *Response, error) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -1656,6 +1883,69 @@ func (c *Client4) GetUserAudits(userId string, page int, perPage int, etag strin
 	return audits, BuildResponse(r), nil
 }
 
+// ExportUserActivity returns the access report of a user over the period between since and until,
+// in milliseconds. The server defaults until to now and since to 30 days before until when they
+// are 0.
+func (c *Client4) ExportUserActivity(userId string, since, until int64) (*UserActivityReport, *Response, error) {
+	values := url.Values{}
+	if since != 0 {
+		values.Set("since", strconv.FormatInt(since, 10))
+	}
+	if until != 0 {
+		values.Set("until", strconv.FormatInt(until, 10))
+	}
+	route := c.userRoute(userId) + "/activity/export"
+	if len(values) > 0 {
+		route += "?" + values.Encode()
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report UserActivityReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("ExportUserActivity", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// GetPushNotificationDiagnostics returns the recent push notification delivery receipts for a user.
+func (c *Client4) GetPushNotificationDiagnostics(userId string) (*PushNotificationDiagnostics, *Response, error) {
+	r, err := c.DoAPIGet(c.userRoute(userId)+"/notifications/diagnostics", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var diagnostics PushNotificationDiagnostics
+	if jsonErr := json.NewDecoder(r.Body).Decode(&diagnostics); jsonErr != nil {
+		return nil, BuildResponse(r), NewAppError("GetPushNotificationDiagnostics", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &diagnostics, BuildResponse(r), nil
+}
+
+// PreviewNotification renders the notifications a user would be sent for a post, without sending
+// them.
+func (c *Client4) PreviewNotification(userId, postId string) (*NotificationPreview, *Response, error) {
+	buf, err := json.Marshal(NotificationPreviewRequest{PostId: postId})
+	if err != nil {
+		return nil, nil, NewAppError("PreviewNotification", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.userRoute(userId)+"/notifications/preview", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var preview NotificationPreview
+	if jsonErr := json.NewDecoder(r.Body).Decode(&preview); jsonErr != nil {
+		return nil, BuildResponse(r), NewAppError("PreviewNotification", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &preview, BuildResponse(r), nil
+}
+
 // VerifyUserEmail will verify a user's email using the supplied token.
 func (c *Client4) VerifyUserEmail(token string) (*Response, error) {
 	requestBody := map[string]string{"token": token}
@@ -1721,7 +2011,7 @@ func (c *Client4) SetProfileImage(userId string, data []byte) (*Response, error)
 		return nil, NewAppError("SetProfileImage", "model.client.set_profile_user.writer.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	rq, err := http.NewRequest("POST", c.APIURL+c.userRoute(userId)+"/image", bytes.NewReader(body.Bytes()))
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+c.userRoute(userId)+"/image", bytes.NewReader(body.Bytes()))
 	if err != nil {
 		return nil, err
 	}
@@ -2071,6 +2361,25 @@ func (c *Client4) CreateTeam(team *Team) (*Team, *Response, error) {
 	return &t, BuildResponse(r), nil
 }
 
+// CreateTeamWithTemplate creates a team in the system based on the provided team struct and
+// provisions it from the team template with the provided id.
+func (c *Client4) CreateTeamWithTemplate(team *Team, templateId string) (*Team, *Response, error) {
+	buf, err := json.Marshal(team)
+	if err != nil {
+		return nil, nil, NewAppError("CreateTeamWithTemplate", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.teamsRoute()+"?template_id="+url.QueryEscape(templateId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var t Team
+	if jsonErr := json.NewDecoder(r.Body).Decode(&t); jsonErr != nil {
+		return nil, nil, NewAppError("CreateTeamWithTemplate", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &t, BuildResponse(r), nil
+}
+
 // GetTeam returns a team based on the provided team id string.
 func (c *Client4) GetTeam(teamId, etag string) (*Team, *Response, error) {
 	r, err := c.DoAPIGet(c.teamRoute(teamId), etag)
@@ -2290,6 +2599,24 @@ func (c *Client4) PatchTeam(teamId string, patch *TeamPatch) (*Team, *Response,
 	return &t, BuildResponse(r), nil
 }
 
+// PatchTeamFeatures enables or disables features of a team, keyed by their name in TeamFeatures.
+func (c *Client4) PatchTeamFeatures(teamId string, patch TeamFeaturesPatch) (*Team, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchTeamFeatures", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPatchBytes(c.teamRoute(teamId)+"/features", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var t Team
+	if jsonErr := json.NewDecoder(r.Body).Decode(&t); jsonErr != nil {
+		return nil, nil, NewAppError("PatchTeamFeatures", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &t, BuildResponse(r), nil
+}
+
 // RestoreTeam restores a previously deleted team.
 func (c *Client4) RestoreTeam(teamId string) (*Team, *Response, error) {
 	r, err := c.DoAPIPost(c.teamRoute(teamId)+"/restore", "")
@@ -2742,7 +3069,7 @@ func (c *Client4) SetTeamIcon(teamId string, data []byte) (*Response, error) {
 		return nil, NewAppError("SetTeamIcon", "model.client.set_team_icon.writer.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	rq, err := http.NewRequest("POST", c.APIURL+c.teamRoute(teamId)+"/image", bytes.NewReader(body.Bytes()))
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+c.teamRoute(teamId)+"/image", bytes.NewReader(body.Bytes()))
 	if err != nil {
 		return nil, err
 	}
@@ -2790,110 +3117,269 @@ func (c *Client4) RemoveTeamIcon(teamId string) (*Response, error) {
 	return BuildResponse(r), nil
 }
 
-// Channel Section
-
-// GetAllChannels get all the channels. Must be a system administrator.
-func (c *Client4) GetAllChannels(page int, perPage int, etag string) (ChannelListWithTeamData, *Response, error) {
-	return c.getAllChannels(page, perPage, etag, ChannelSearchOpts{})
-}
-
-// GetAllChannelsIncludeDeleted get all the channels. Must be a system administrator.
-func (c *Client4) GetAllChannelsIncludeDeleted(page int, perPage int, etag string) (ChannelListWithTeamData, *Response, error) {
-	return c.getAllChannels(page, perPage, etag, ChannelSearchOpts{IncludeDeleted: true})
-}
-
-// GetAllChannelsExcludePolicyConstrained gets all channels which are not part of a data retention policy.
-// Must be a system administrator.
-func (c *Client4) GetAllChannelsExcludePolicyConstrained(page, perPage int, etag string) (ChannelListWithTeamData, *Response, error) {
-	return c.getAllChannels(page, perPage, etag, ChannelSearchOpts{ExcludePolicyConstrained: true})
-}
+// Team Templates Section
 
-func (c *Client4) getAllChannels(page int, perPage int, etag string, opts ChannelSearchOpts) (ChannelListWithTeamData, *Response, error) {
-	query := fmt.Sprintf("?page=%v&per_page=%v&include_deleted=%v&exclude_policy_constrained=%v",
-		page, perPage, opts.IncludeDeleted, opts.ExcludePolicyConstrained)
-	r, err := c.DoAPIGet(c.channelsRoute()+query, etag)
+// CreateTeamTemplate creates a team template based on the provided struct.
+func (c *Client4) CreateTeamTemplate(template *TeamTemplate) (*TeamTemplate, *Response, error) {
+	buf, err := json.Marshal(template)
+	if err != nil {
+		return nil, nil, NewAppError("CreateTeamTemplate", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.teamTemplatesRoute(), buf)
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	var ch ChannelListWithTeamData
-	err = json.NewDecoder(r.Body).Decode(&ch)
-	if err != nil {
-		return nil, BuildResponse(r), NewAppError("getAllChannels", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	var t TeamTemplate
+	if jsonErr := json.NewDecoder(r.Body).Decode(&t); jsonErr != nil {
+		return nil, nil, NewAppError("CreateTeamTemplate", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return ch, BuildResponse(r), nil
+	return &t, BuildResponse(r), nil
 }
 
-// GetAllChannelsWithCount get all the channels including the total count. Must be a system administrator.
-func (c *Client4) GetAllChannelsWithCount(page int, perPage int, etag string) (ChannelListWithTeamData, int64, *Response, error) {
-	query := fmt.Sprintf("?page=%v&per_page=%v&include_total_count="+c.boolString(true), page, perPage)
-	r, err := c.DoAPIGet(c.channelsRoute()+query, etag)
+// GetTeamTemplates returns a page of team templates.
+func (c *Client4) GetTeamTemplates(page, perPage int) ([]*TeamTemplate, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(c.teamTemplatesRoute()+query, "")
 	if err != nil {
-		return nil, 0, BuildResponse(r), err
+		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	var cwc *ChannelsWithCount
-	err = json.NewDecoder(r.Body).Decode(&cwc)
-	if err != nil {
-		return nil, 0, BuildResponse(r), NewAppError("GetAllChannelsWithCount", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	var list []*TeamTemplate
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetTeamTemplates", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return cwc.Channels, cwc.TotalCount, BuildResponse(r), nil
+	return list, BuildResponse(r), nil
 }
 
-// CreateChannel creates a channel based on the provided channel struct.
-func (c *Client4) CreateChannel(channel *Channel) (*Channel, *Response, error) {
-	channelJSON, jsonErr := json.Marshal(channel)
-	if jsonErr != nil {
-		return nil, nil, NewAppError("CreateChannel", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
-	}
-	r, err := c.DoAPIPost(c.channelsRoute(), string(channelJSON))
+// GetTeamTemplate returns a team template based on the provided id string.
+func (c *Client4) GetTeamTemplate(templateId string) (*TeamTemplate, *Response, error) {
+	r, err := c.DoAPIGet(c.teamTemplateRoute(templateId), "")
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	var ch *Channel
-	err = json.NewDecoder(r.Body).Decode(&ch)
-	if err != nil {
-		return nil, BuildResponse(r), NewAppError("CreateChannel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	var t TeamTemplate
+	if jsonErr := json.NewDecoder(r.Body).Decode(&t); jsonErr != nil {
+		return nil, nil, NewAppError("GetTeamTemplate", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return ch, BuildResponse(r), nil
+	return &t, BuildResponse(r), nil
 }
 
-// UpdateChannel updates a channel based on the provided channel struct.
-func (c *Client4) UpdateChannel(channel *Channel) (*Channel, *Response, error) {
-	channelJSON, jsonErr := json.Marshal(channel)
-	if jsonErr != nil {
-		return nil, nil, NewAppError("UpdateChannel", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+// UpdateTeamTemplate updates the display name, description and definition of a team template.
+func (c *Client4) UpdateTeamTemplate(template *TeamTemplate) (*TeamTemplate, *Response, error) {
+	buf, err := json.Marshal(template)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateTeamTemplate", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
 	}
-	r, err := c.DoAPIPut(c.channelRoute(channel.Id), string(channelJSON))
+	r, err := c.DoAPIPutBytes(c.teamTemplateRoute(template.Id), buf)
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	var ch *Channel
-	err = json.NewDecoder(r.Body).Decode(&ch)
-	if err != nil {
-		return nil, BuildResponse(r), NewAppError("UpdateChannel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	var t TeamTemplate
+	if jsonErr := json.NewDecoder(r.Body).Decode(&t); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateTeamTemplate", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return ch, BuildResponse(r), nil
+	return &t, BuildResponse(r), nil
 }
 
-// PatchChannel partially updates a channel. Any missing fields are not updated.
-func (c *Client4) PatchChannel(channelId string, patch *ChannelPatch) (*Channel, *Response, error) {
-	buf, err := json.Marshal(patch)
+// DeleteTeamTemplate deletes the team template with the provided id string.
+func (c *Client4) DeleteTeamTemplate(templateId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.teamTemplateRoute(templateId))
 	if err != nil {
-		return nil, nil, NewAppError("PatchChannel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+		return BuildResponse(r), err
 	}
-	r, err := c.DoAPIPutBytes(c.channelRoute(channelId)+"/patch", buf)
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// Onboarding Tasks Section
+
+// GetOnboardingChecklist returns the onboarding tasks of the current user along with their completion state.
+func (c *Client4) GetOnboardingChecklist() ([]*OnboardingTaskStatus, *Response, error) {
+	r, err := c.DoAPIGet(c.onboardingTasksRoute(), "")
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
+	var list []*OnboardingTaskStatus
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetOnboardingChecklist", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return list, BuildResponse(r), nil
+}
+
+// CreateOnboardingTask creates a custom onboarding task.
+func (c *Client4) CreateOnboardingTask(task *OnboardingTask) (*OnboardingTask, *Response, error) {
+	buf, err := json.Marshal(task)
+	if err != nil {
+		return nil, nil, NewAppError("CreateOnboardingTask", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.onboardingTasksRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var t OnboardingTask
+	if jsonErr := json.NewDecoder(r.Body).Decode(&t); jsonErr != nil {
+		return nil, nil, NewAppError("CreateOnboardingTask", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &t, BuildResponse(r), nil
+}
+
+// UpdateOnboardingTask updates a custom onboarding task.
+func (c *Client4) UpdateOnboardingTask(task *OnboardingTask) (*OnboardingTask, *Response, error) {
+	buf, err := json.Marshal(task)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateOnboardingTask", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.onboardingTaskRoute(task.Id), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var t OnboardingTask
+	if jsonErr := json.NewDecoder(r.Body).Decode(&t); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateOnboardingTask", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &t, BuildResponse(r), nil
+}
+
+// DeleteOnboardingTask deletes a custom onboarding task.
+func (c *Client4) DeleteOnboardingTask(taskId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.onboardingTaskRoute(taskId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// CompleteOnboardingTask marks an onboarding task as done for the current user.
+func (c *Client4) CompleteOnboardingTask(taskId string) (*Response, error) {
+	r, err := c.DoAPIPost(c.onboardingTaskRoute(taskId)+"/complete", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// ResetOnboardingTask marks an onboarding task as not done for the current user.
+func (c *Client4) ResetOnboardingTask(taskId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.onboardingTaskRoute(taskId) + "/complete")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// Channel Section
+
+// GetAllChannels get all the channels. Must be a system administrator.
+func (c *Client4) GetAllChannels(page int, perPage int, etag string) (ChannelListWithTeamData, *Response, error) {
+	return c.getAllChannels(page, perPage, etag, ChannelSearchOpts{})
+}
+
+// GetAllChannelsIncludeDeleted get all the channels. Must be a system administrator.
+func (c *Client4) GetAllChannelsIncludeDeleted(page int, perPage int, etag string) (ChannelListWithTeamData, *Response, error) {
+	return c.getAllChannels(page, perPage, etag, ChannelSearchOpts{IncludeDeleted: true})
+}
+
+// GetAllChannelsExcludePolicyConstrained gets all channels which are not part of a data retention policy.
+// Must be a system administrator.
+func (c *Client4) GetAllChannelsExcludePolicyConstrained(page, perPage int, etag string) (ChannelListWithTeamData, *Response, error) {
+	return c.getAllChannels(page, perPage, etag, ChannelSearchOpts{ExcludePolicyConstrained: true})
+}
+
+func (c *Client4) getAllChannels(page int, perPage int, etag string, opts ChannelSearchOpts) (ChannelListWithTeamData, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v&include_deleted=%v&exclude_policy_constrained=%v",
+		page, perPage, opts.IncludeDeleted, opts.ExcludePolicyConstrained)
+	r, err := c.DoAPIGet(c.channelsRoute()+query, etag)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var ch ChannelListWithTeamData
+	err = json.NewDecoder(r.Body).Decode(&ch)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("getAllChannels", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return ch, BuildResponse(r), nil
+}
+
+// GetAllChannelsWithCount get all the channels including the total count. Must be a system administrator.
+func (c *Client4) GetAllChannelsWithCount(page int, perPage int, etag string) (ChannelListWithTeamData, int64, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v&include_total_count="+c.boolString(true), page, perPage)
+	r, err := c.DoAPIGet(c.channelsRoute()+query, etag)
+	if err != nil {
+		return nil, 0, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var cwc *ChannelsWithCount
+	err = json.NewDecoder(r.Body).Decode(&cwc)
+	if err != nil {
+		return nil, 0, BuildResponse(r), NewAppError("GetAllChannelsWithCount", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return cwc.Channels, cwc.TotalCount, BuildResponse(r), nil
+}
+
+// CreateChannel creates a channel based on the provided channel struct.
+func (c *Client4) CreateChannel(channel *Channel) (*Channel, *Response, error) {
+	channelJSON, jsonErr := json.Marshal(channel)
+	if jsonErr != nil {
+		return nil, nil, NewAppError("CreateChannel", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPost(c.channelsRoute(), string(channelJSON))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var ch *Channel
+	err = json.NewDecoder(r.Body).Decode(&ch)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("CreateChannel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return ch, BuildResponse(r), nil
+}
+
+// UpdateChannel updates a channel based on the provided channel struct.
+func (c *Client4) UpdateChannel(channel *Channel) (*Channel, *Response, error) {
+	channelJSON, jsonErr := json.Marshal(channel)
+	if jsonErr != nil {
+		return nil, nil, NewAppError("UpdateChannel", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPut(c.channelRoute(channel.Id), string(channelJSON))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var ch *Channel
+	err = json.NewDecoder(r.Body).Decode(&ch)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("UpdateChannel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return ch, BuildResponse(r), nil
+}
+
+// PatchChannel partially updates a channel. Any missing fields are not updated.
+func (c *Client4) PatchChannel(channelId string, patch *ChannelPatch) (*Channel, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchChannel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.channelRoute(channelId)+"/patch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
 	var ch *Channel
 	err = json.NewDecoder(r.Body).Decode(&ch)
 	if err != nil {
@@ -3028,6 +3514,26 @@ func (c *Client4) GetPinnedPosts(channelId string, etag string) (*PostList, *Res
 	return &list, BuildResponse(r), nil
 }
 
+// UpdatePinnedPostsOrder puts the given pinned posts of a channel first in its pinned posts, in
+// that order, and returns its pinned posts.
+func (c *Client4) UpdatePinnedPostsOrder(channelId string, postIds []string) (*PostList, *Response, error) {
+	buf, err := json.Marshal(postIds)
+	if err != nil {
+		return nil, nil, NewAppError("UpdatePinnedPostsOrder", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.channelRoute(channelId)+"/pins/order", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var list PostList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("UpdatePinnedPostsOrder", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &list, BuildResponse(r), nil
+}
+
 // GetPrivateChannelsForTeam returns a list of private channels based on the provided team id string.
 func (c *Client4) GetPrivateChannelsForTeam(teamId string, page int, perPage int, etag string) ([]*Channel, *Response, error) {
 	query := fmt.Sprintf("/private?page=%v&per_page=%v", page, perPage)
@@ -3546,6 +4052,25 @@ func (c *Client4) AddChannelMember(channelId, userId string) (*ChannelMember, *R
 	return ch, BuildResponse(r), nil
 }
 
+// AddChannelMembers adds several users to a channel. The result of each user, with the channel
+// member on success, is in the returned BulkResponse, in the order of the ids.
+func (c *Client4) AddChannelMembers(channelId string, userIds []string) (*BulkResponse, *Response, error) {
+	buf, err := json.Marshal(map[string]interface{}{"user_ids": userIds})
+	if err != nil {
+		return nil, nil, NewAppError("AddChannelMembers", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.channelMembersRoute(channelId)+"/batch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var br BulkResponse
+	if jsonErr := json.NewDecoder(r.Body).Decode(&br); jsonErr != nil {
+		return nil, nil, NewAppError("AddChannelMembers", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &br, BuildResponse(r), nil
+}
+
 // AddChannelMemberWithRootId adds user to channel and return a channel member. Post add to channel message has the postRootId.
 func (c *Client4) AddChannelMemberWithRootId(channelId, userId, postRootId string) (*ChannelMember, *Response, error) {
 	requestBody := map[string]string{"user_id": userId, "post_root_id": postRootId}
@@ -3563,6 +4088,40 @@ func (c *Client4) AddChannelMemberWithRootId(channelId, userId, postRootId strin
 	return ch, BuildResponse(r), nil
 }
 
+// BulkUpdateChannelMembers starts a job adding or removing many users from a channel and returns its initial report.
+func (c *Client4) BulkUpdateChannelMembers(channelId string, bulkRequest *ChannelMembersBulkRequest) (*ChannelMembersBulkReport, *Response, error) {
+	buf, err := json.Marshal(bulkRequest)
+	if err != nil {
+		return nil, nil, NewAppError("BulkUpdateChannelMembers", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.channelMembersRoute(channelId)+"/bulk", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report ChannelMembersBulkReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("BulkUpdateChannelMembers", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// GetBulkChannelMembersReport returns the progress and failures of a bulk channel membership job.
+func (c *Client4) GetBulkChannelMembersReport(channelId, jobId string) (*ChannelMembersBulkReport, *Response, error) {
+	r, err := c.DoAPIGet(c.channelMembersRoute(channelId)+"/bulk/"+jobId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report ChannelMembersBulkReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetBulkChannelMembersReport", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
 // RemoveUserFromChannel will delete the channel member object for a user, effectively removing the user from a channel.
 func (c *Client4) RemoveUserFromChannel(channelId, userId string) (*Response, error) {
 	r, err := c.DoAPIDelete(c.channelMemberRoute(channelId, userId))
@@ -3573,6 +4132,117 @@ func (c *Client4) RemoveUserFromChannel(channelId, userId string) (*Response, er
 	return BuildResponse(r), nil
 }
 
+// TimeoutChannelMember prevents a member from posting in a channel for the given number of minutes.
+func (c *Client4) TimeoutChannelMember(channelId, userId string, minutes int) (*ChannelMemberTimeout, *Response, error) {
+	buf, err := json.Marshal(&ChannelMemberTimeoutRequest{DurationMinutes: minutes})
+	if err != nil {
+		return nil, nil, NewAppError("TimeoutChannelMember", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.channelMemberRoute(channelId, userId)+"/timeout", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var timeout ChannelMemberTimeout
+	if jsonErr := json.NewDecoder(r.Body).Decode(&timeout); jsonErr != nil {
+		return nil, nil, NewAppError("TimeoutChannelMember", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &timeout, BuildResponse(r), nil
+}
+
+// RemoveChannelMemberTimeout lets a timed out member post in a channel again.
+func (c *Client4) RemoveChannelMemberTimeout(channelId, userId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.channelMemberRoute(channelId, userId) + "/timeout")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetChannelMemberTimeouts returns the timeouts of the members of a channel that have not expired.
+func (c *Client4) GetChannelMemberTimeouts(channelId string) ([]*ChannelMemberTimeout, *Response, error) {
+	r, err := c.DoAPIGet(c.channelRoute(channelId)+"/timeouts", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var timeouts []*ChannelMemberTimeout
+	if jsonErr := json.NewDecoder(r.Body).Decode(&timeouts); jsonErr != nil {
+		return nil, nil, NewAppError("GetChannelMemberTimeouts", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return timeouts, BuildResponse(r), nil
+}
+
+// GetChannelSlowmode returns the slowmode setting of a channel.
+func (c *Client4) GetChannelSlowmode(channelId string) (*ChannelSlowmode, *Response, error) {
+	r, err := c.DoAPIGet(c.channelRoute(channelId)+"/moderations/slowmode", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var slowmode ChannelSlowmode
+	if jsonErr := json.NewDecoder(r.Body).Decode(&slowmode); jsonErr != nil {
+		return nil, nil, NewAppError("GetChannelSlowmode", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &slowmode, BuildResponse(r), nil
+}
+
+// UpdateChannelSlowmode sets the minimum number of seconds between two posts of a member in a
+// channel, zero disabling slowmode.
+func (c *Client4) UpdateChannelSlowmode(channelId string, seconds int) (*ChannelSlowmode, *Response, error) {
+	buf, err := json.Marshal(&ChannelSlowmode{Seconds: seconds})
+	if err != nil {
+		return nil, nil, NewAppError("UpdateChannelSlowmode", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.channelRoute(channelId)+"/moderations/slowmode", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var slowmode ChannelSlowmode
+	if jsonErr := json.NewDecoder(r.Body).Decode(&slowmode); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateChannelSlowmode", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &slowmode, BuildResponse(r), nil
+}
+
+// GetDirectMessageRequests returns the pending message requests sent to a user, newest first.
+func (c *Client4) GetDirectMessageRequests(userId string) ([]*DirectMessageRequest, *Response, error) {
+	r, err := c.DoAPIGet(c.userRoute(userId)+"/message_requests", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var requests []*DirectMessageRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&requests); jsonErr != nil {
+		return nil, nil, NewAppError("GetDirectMessageRequests", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return requests, BuildResponse(r), nil
+}
+
+// AcceptDirectMessageRequest accepts the message request of a direct channel sent to the current
+// user.
+func (c *Client4) AcceptDirectMessageRequest(channelId string) (*Response, error) {
+	r, err := c.DoAPIPost(c.channelRoute(channelId)+"/message_request/accept", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// DeclineDirectMessageRequest declines the message request of a direct channel sent to the
+// current user.
+func (c *Client4) DeclineDirectMessageRequest(channelId string) (*Response, error) {
+	r, err := c.DoAPIPost(c.channelRoute(channelId)+"/message_request/decline", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // AutocompleteChannelsForTeam will return an ordered list of channels autocomplete suggestions.
 func (c *Client4) AutocompleteChannelsForTeam(teamId, name string) (ChannelList, *Response, error) {
 	query := fmt.Sprintf("?name=%v", name)
@@ -3642,11 +4312,432 @@ func (c *Client4) GetTopChannelsForUserSince(teamId string, timeRange string, pa
 	return topChannels, BuildResponse(r), nil
 }
 
-// Post Section
+// Channel Bookmarks Section
 
-// CreatePost creates a post based on the provided post struct.
-func (c *Client4) CreatePost(post *Post) (*Post, *Response, error) {
-	postJSON, jsonErr := json.Marshal(post)
+// GetChannelBookmarks returns the bookmarks of a channel, in their order.
+func (c *Client4) GetChannelBookmarks(channelId string) ([]*ChannelBookmark, *Response, error) {
+	r, err := c.DoAPIGet(c.channelBookmarksRoute(channelId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var bookmarks []*ChannelBookmark
+	if jsonErr := json.NewDecoder(r.Body).Decode(&bookmarks); jsonErr != nil {
+		return nil, nil, NewAppError("GetChannelBookmarks", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return bookmarks, BuildResponse(r), nil
+}
+
+// CreateChannelBookmark adds a bookmark at the end of the bookmarks of its channel.
+func (c *Client4) CreateChannelBookmark(bookmark *ChannelBookmark) (*ChannelBookmark, *Response, error) {
+	buf, err := json.Marshal(bookmark)
+	if err != nil {
+		return nil, nil, NewAppError("CreateChannelBookmark", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.channelBookmarksRoute(bookmark.ChannelId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var created ChannelBookmark
+	if jsonErr := json.NewDecoder(r.Body).Decode(&created); jsonErr != nil {
+		return nil, nil, NewAppError("CreateChannelBookmark", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &created, BuildResponse(r), nil
+}
+
+// PatchChannelBookmark changes the name, target or emoji of a bookmark.
+func (c *Client4) PatchChannelBookmark(channelId, bookmarkId string, patch *ChannelBookmarkPatch) (*ChannelBookmark, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchChannelBookmark", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPatchBytes(c.channelBookmarkRoute(channelId, bookmarkId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var bookmark ChannelBookmark
+	if jsonErr := json.NewDecoder(r.Body).Decode(&bookmark); jsonErr != nil {
+		return nil, nil, NewAppError("PatchChannelBookmark", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &bookmark, BuildResponse(r), nil
+}
+
+// UpdateChannelBookmarkSortOrder moves a bookmark to the given index in the bookmarks of its
+// channel and returns the bookmarks whose order changed.
+func (c *Client4) UpdateChannelBookmarkSortOrder(channelId, bookmarkId string, newIndex int64) ([]*ChannelBookmark, *Response, error) {
+	buf, err := json.Marshal(newIndex)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateChannelBookmarkSortOrder", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.channelBookmarkRoute(channelId, bookmarkId)+"/sort_order", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var bookmarks []*ChannelBookmark
+	if jsonErr := json.NewDecoder(r.Body).Decode(&bookmarks); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateChannelBookmarkSortOrder", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return bookmarks, BuildResponse(r), nil
+}
+
+// DeleteChannelBookmark deletes a bookmark and returns it.
+func (c *Client4) DeleteChannelBookmark(channelId, bookmarkId string) (*ChannelBookmark, *Response, error) {
+	r, err := c.DoAPIDelete(c.channelBookmarkRoute(channelId, bookmarkId))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var bookmark ChannelBookmark
+	if jsonErr := json.NewDecoder(r.Body).Decode(&bookmark); jsonErr != nil {
+		return nil, nil, NewAppError("DeleteChannelBookmark", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &bookmark, BuildResponse(r), nil
+}
+
+// Channel Events Section
+
+// GetChannelEvents returns the events of a channel, ordered by start.
+func (c *Client4) GetChannelEvents(channelId string) ([]*ChannelEvent, *Response, error) {
+	r, err := c.DoAPIGet(c.channelEventsRoute(channelId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var events []*ChannelEvent
+	if jsonErr := json.NewDecoder(r.Body).Decode(&events); jsonErr != nil {
+		return nil, nil, NewAppError("GetChannelEvents", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return events, BuildResponse(r), nil
+}
+
+// GetChannelEvent returns an event of a channel.
+func (c *Client4) GetChannelEvent(channelId, eventId string) (*ChannelEvent, *Response, error) {
+	r, err := c.DoAPIGet(c.channelEventRoute(channelId, eventId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var event ChannelEvent
+	if jsonErr := json.NewDecoder(r.Body).Decode(&event); jsonErr != nil {
+		return nil, nil, NewAppError("GetChannelEvent", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &event, BuildResponse(r), nil
+}
+
+// CreateChannelEvent schedules an event in its channel.
+func (c *Client4) CreateChannelEvent(event *ChannelEvent) (*ChannelEvent, *Response, error) {
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, NewAppError("CreateChannelEvent", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.channelEventsRoute(event.ChannelId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var created ChannelEvent
+	if jsonErr := json.NewDecoder(r.Body).Decode(&created); jsonErr != nil {
+		return nil, nil, NewAppError("CreateChannelEvent", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &created, BuildResponse(r), nil
+}
+
+// PatchChannelEvent changes an event, which is scheduled again if its time changes.
+func (c *Client4) PatchChannelEvent(channelId, eventId string, patch *ChannelEventPatch) (*ChannelEvent, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchChannelEvent", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPatchBytes(c.channelEventRoute(channelId, eventId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var event ChannelEvent
+	if jsonErr := json.NewDecoder(r.Body).Decode(&event); jsonErr != nil {
+		return nil, nil, NewAppError("PatchChannelEvent", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &event, BuildResponse(r), nil
+}
+
+// DeleteChannelEvent deletes an event.
+func (c *Client4) DeleteChannelEvent(channelId, eventId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.channelEventRoute(channelId, eventId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// Channel Join Requests Section
+
+// RequestToJoinChannel asks the admins of a private channel to add the current user to it, with an
+// optional message.
+func (c *Client4) RequestToJoinChannel(channelId, message string) (*ChannelJoinRequest, *Response, error) {
+	buf, err := json.Marshal(&ChannelJoinRequest{Message: message})
+	if err != nil {
+		return nil, nil, NewAppError("RequestToJoinChannel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.channelJoinRequestsRoute(channelId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var joinRequest ChannelJoinRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&joinRequest); jsonErr != nil {
+		return nil, nil, NewAppError("RequestToJoinChannel", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &joinRequest, BuildResponse(r), nil
+}
+
+// GetChannelJoinRequests returns the pending requests to join a channel, or only the request of
+// the current user if they can't review them.
+func (c *Client4) GetChannelJoinRequests(channelId string) ([]*ChannelJoinRequest, *Response, error) {
+	r, err := c.DoAPIGet(c.channelJoinRequestsRoute(channelId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var requests []*ChannelJoinRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&requests); jsonErr != nil {
+		return nil, nil, NewAppError("GetChannelJoinRequests", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return requests, BuildResponse(r), nil
+}
+
+// ApproveChannelJoinRequest adds the user of a pending request to the channel.
+func (c *Client4) ApproveChannelJoinRequest(channelId, requestId string) (*ChannelJoinRequest, *Response, error) {
+	return c.reviewChannelJoinRequest("ApproveChannelJoinRequest", channelId, requestId, "approve")
+}
+
+// DenyChannelJoinRequest turns down a pending request to join a channel.
+func (c *Client4) DenyChannelJoinRequest(channelId, requestId string) (*ChannelJoinRequest, *Response, error) {
+	return c.reviewChannelJoinRequest("DenyChannelJoinRequest", channelId, requestId, "deny")
+}
+
+func (c *Client4) reviewChannelJoinRequest(where, channelId, requestId, action string) (*ChannelJoinRequest, *Response, error) {
+	r, err := c.DoAPIPost(c.channelJoinRequestRoute(channelId, requestId)+"/"+action, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var joinRequest ChannelJoinRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&joinRequest); jsonErr != nil {
+		return nil, nil, NewAppError(where, "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &joinRequest, BuildResponse(r), nil
+}
+
+// CancelChannelJoinRequest removes a pending request of the current user to join a channel.
+func (c *Client4) CancelChannelJoinRequest(channelId, requestId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.channelJoinRequestRoute(channelId, requestId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// Team Invite Links Section
+
+// CreateTeamInviteLink creates a link letting users join a team, with the restrictions of the given
+// link.
+func (c *Client4) CreateTeamInviteLink(teamId string, link *TeamInviteLink) (*TeamInviteLink, *Response, error) {
+	buf, err := json.Marshal(link)
+	if err != nil {
+		return nil, nil, NewAppError("CreateTeamInviteLink", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.teamInviteLinksRoute(teamId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var created TeamInviteLink
+	if jsonErr := json.NewDecoder(r.Body).Decode(&created); jsonErr != nil {
+		return nil, nil, NewAppError("CreateTeamInviteLink", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &created, BuildResponse(r), nil
+}
+
+// GetTeamInviteLinks returns the invite links of a team, including the revoked ones if asked.
+func (c *Client4) GetTeamInviteLinks(teamId string, includeRevoked bool) ([]*TeamInviteLink, *Response, error) {
+	query := fmt.Sprintf("?include_deleted=%v", includeRevoked)
+	r, err := c.DoAPIGet(c.teamInviteLinksRoute(teamId)+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var links []*TeamInviteLink
+	if jsonErr := json.NewDecoder(r.Body).Decode(&links); jsonErr != nil {
+		return nil, nil, NewAppError("GetTeamInviteLinks", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return links, BuildResponse(r), nil
+}
+
+// GetTeamInviteLink returns an invite link of a team, with how many times it was used.
+func (c *Client4) GetTeamInviteLink(teamId, linkId string) (*TeamInviteLink, *Response, error) {
+	r, err := c.DoAPIGet(c.teamInviteLinkRoute(teamId, linkId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var link TeamInviteLink
+	if jsonErr := json.NewDecoder(r.Body).Decode(&link); jsonErr != nil {
+		return nil, nil, NewAppError("GetTeamInviteLink", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &link, BuildResponse(r), nil
+}
+
+// PatchTeamInviteLink changes the restrictions of an invite link of a team.
+func (c *Client4) PatchTeamInviteLink(teamId, linkId string, patch *TeamInviteLinkPatch) (*TeamInviteLink, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchTeamInviteLink", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.teamInviteLinkRoute(teamId, linkId)+"/patch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var link TeamInviteLink
+	if jsonErr := json.NewDecoder(r.Body).Decode(&link); jsonErr != nil {
+		return nil, nil, NewAppError("PatchTeamInviteLink", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &link, BuildResponse(r), nil
+}
+
+// RevokeTeamInviteLink stops an invite link from letting users join its team.
+func (c *Client4) RevokeTeamInviteLink(teamId, linkId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.teamInviteLinkRoute(teamId, linkId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// Reminders Section
+
+// CreateReminder schedules a reminder for the current user.
+func (c *Client4) CreateReminder(reminder *Reminder) (*Reminder, *Response, error) {
+	buf, err := json.Marshal(reminder)
+	if err != nil {
+		return nil, nil, NewAppError("CreateReminder", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.remindersRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var created Reminder
+	if jsonErr := json.NewDecoder(r.Body).Decode(&created); jsonErr != nil {
+		return nil, nil, NewAppError("CreateReminder", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &created, BuildResponse(r), nil
+}
+
+// GetReminder returns a reminder.
+func (c *Client4) GetReminder(reminderId string) (*Reminder, *Response, error) {
+	r, err := c.DoAPIGet(c.reminderRoute(reminderId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reminder Reminder
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reminder); jsonErr != nil {
+		return nil, nil, NewAppError("GetReminder", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &reminder, BuildResponse(r), nil
+}
+
+// GetReminders returns the pending reminders of a user, the next one first.
+func (c *Client4) GetReminders(userId string) ([]*Reminder, *Response, error) {
+	r, err := c.DoAPIGet(c.userRoute(userId)+"/reminders", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reminders []*Reminder
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reminders); jsonErr != nil {
+		return nil, nil, NewAppError("GetReminders", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return reminders, BuildResponse(r), nil
+}
+
+// PatchReminder updates the message, time or recurrence of a reminder.
+func (c *Client4) PatchReminder(reminderId string, patch *ReminderPatch) (*Reminder, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchReminder", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPatchBytes(c.reminderRoute(reminderId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reminder Reminder
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reminder); jsonErr != nil {
+		return nil, nil, NewAppError("PatchReminder", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &reminder, BuildResponse(r), nil
+}
+
+// DeleteReminder cancels a reminder.
+func (c *Client4) DeleteReminder(reminderId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.reminderRoute(reminderId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// TURN Section
+
+// GetTurnCredentials returns time-limited credentials of the TURN servers configured for RTC
+// integrations.
+func (c *Client4) GetTurnCredentials() (*TurnCredentials, *Response, error) {
+	r, err := c.DoAPIGet("/turn/credentials", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var credentials TurnCredentials
+	if jsonErr := json.NewDecoder(r.Body).Decode(&credentials); jsonErr != nil {
+		return nil, nil, NewAppError("GetTurnCredentials", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &credentials, BuildResponse(r), nil
+}
+
+// Markdown Section
+
+// RenderMarkdown renders markdown to sanitized HTML the way the server does.
+func (c *Client4) RenderMarkdown(text string) (string, *Response, error) {
+	buf, err := json.Marshal(&MarkdownRenderRequest{Text: text})
+	if err != nil {
+		return "", nil, NewAppError("RenderMarkdown", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes("/markdown/render", buf)
+	if err != nil {
+		return "", BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var rendered MarkdownRenderResponse
+	if jsonErr := json.NewDecoder(r.Body).Decode(&rendered); jsonErr != nil {
+		return "", nil, NewAppError("RenderMarkdown", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return rendered.HTML, BuildResponse(r), nil
+}
+
+// Post Section
+
+// CreatePost creates a post based on the provided post struct.
+func (c *Client4) CreatePost(post *Post) (*Post, *Response, error) {
+	postJSON, jsonErr := json.Marshal(post)
 	if jsonErr != nil {
 		return nil, nil, NewAppError("CreatePost", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
@@ -3790,6 +4881,25 @@ func (c *Client4) DeletePost(postId string) (*Response, error) {
 	return BuildResponse(r), nil
 }
 
+// DeletePosts deletes several posts at once. The result of each post is in the returned
+// BulkResponse, in the order of the ids.
+func (c *Client4) DeletePosts(postIds []string) (*BulkResponse, *Response, error) {
+	buf, err := json.Marshal(postIds)
+	if err != nil {
+		return nil, nil, NewAppError("DeletePosts", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.postsRoute()+"/delete/batch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var br BulkResponse
+	if jsonErr := json.NewDecoder(r.Body).Decode(&br); jsonErr != nil {
+		return nil, nil, NewAppError("DeletePosts", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &br, BuildResponse(r), nil
+}
+
 // GetPostThread gets a post with all the other posts in the same thread.
 func (c *Client4) GetPostThread(postId string, etag string, collapsedThreads bool) (*PostList, *Response, error) {
 	url := c.postRoute(postId) + "/thread"
@@ -4106,6 +5216,55 @@ func (c *Client4) SearchPostsWithParams(teamId string, params *SearchParameter)
 	return &list, BuildResponse(r), nil
 }
 
+// SearchArchivedPosts returns any archived posts of the given team matching the terms, newest
+// first.
+func (c *Client4) SearchArchivedPosts(teamId, terms string, page, perPage int) (*PostList, *Response, error) {
+	params := SearchParameter{
+		Terms:   &terms,
+		Page:    &page,
+		PerPage: &perPage,
+	}
+	js, jsonErr := json.Marshal(params)
+	if jsonErr != nil {
+		return nil, nil, NewAppError("SearchArchivedPosts", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPost(c.teamRoute(teamId)+"/posts/archive/search", string(js))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list PostList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("SearchArchivedPosts", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &list, BuildResponse(r), nil
+}
+
+// AdvancedSearchPosts returns the posts matching the terms and regex in the given team, or in
+// every team when teamId is empty. Terms accept the has:link and has:file operators. Must have
+// the manage_system permission.
+func (c *Client4) AdvancedSearchPosts(teamId, terms, regex string) (*PostList, *Response, error) {
+	params := AdvancedSearchParameter{
+		Terms:  &terms,
+		Regex:  &regex,
+		TeamId: &teamId,
+	}
+	js, jsonErr := json.Marshal(params)
+	if jsonErr != nil {
+		return nil, nil, NewAppError("AdvancedSearchPosts", "api.marshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPost(c.postsRoute()+"/search/advanced", string(js))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list PostList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("AdvancedSearchPosts", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &list, BuildResponse(r), nil
+}
+
 // SearchPostsWithMatches returns any posts with matching terms string, including.
 func (c *Client4) SearchPostsWithMatches(teamId string, terms string, isOrSearch bool) (*PostSearchResults, *Response, error) {
 	requestBody := map[string]interface{}{"terms": terms, "is_or_search": isOrSearch}
@@ -4264,102 +5423,296 @@ func (c *Client4) GetFileThumbnail(fileId string) ([]byte, *Response, error) {
 	if err != nil {
 		return nil, BuildResponse(r), NewAppError("GetFileThumbnail", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
 	}
-	return data, BuildResponse(r), nil
+	return data, BuildResponse(r), nil
+}
+
+// DownloadFileThumbnail gets the bytes for a file by id, optionally adding headers to force the browser to download it.
+func (c *Client4) DownloadFileThumbnail(fileId string, download bool) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(c.fileRoute(fileId)+fmt.Sprintf("/thumbnail?download=%v", download), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("DownloadFileThumbnail", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+	}
+	return data, BuildResponse(r), nil
+}
+
+// GetFileLink gets the public link of a file by id.
+func (c *Client4) GetFileLink(fileId string) (string, *Response, error) {
+	r, err := c.DoAPIGet(c.fileRoute(fileId)+"/link", "")
+	if err != nil {
+		return "", BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return MapFromJSON(r.Body)["link"], BuildResponse(r), nil
+}
+
+// GetFilePreview gets the bytes for a file by id.
+func (c *Client4) GetFilePreview(fileId string) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(c.fileRoute(fileId)+"/preview", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("GetFilePreview", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+	}
+	return data, BuildResponse(r), nil
+}
+
+// DownloadFilePreview gets the bytes for a file by id.
+func (c *Client4) DownloadFilePreview(fileId string, download bool) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(c.fileRoute(fileId)+fmt.Sprintf("/preview?download=%v", download), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("DownloadFilePreview", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+	}
+	return data, BuildResponse(r), nil
+}
+
+// GetFileInfo gets all the file info objects.
+func (c *Client4) GetFileInfo(fileId string) (*FileInfo, *Response, error) {
+	r, err := c.DoAPIGet(c.fileRoute(fileId)+"/info", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var fi FileInfo
+	if jsonErr := json.NewDecoder(r.Body).Decode(&fi); jsonErr != nil {
+		return nil, nil, NewAppError("GetFileInfo", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &fi, BuildResponse(r), nil
+}
+
+// SetFileAltText sets the alt text of an image uploaded by the current user. An empty alt text
+// removes it.
+func (c *Client4) SetFileAltText(fileId, altText string) (*FileInfo, *Response, error) {
+	buf, err := json.Marshal(FileInfoAltTextRequest{AltText: altText})
+	if err != nil {
+		return nil, nil, NewAppError("SetFileAltText", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.fileRoute(fileId)+"/alt_text", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var fi FileInfo
+	if jsonErr := json.NewDecoder(r.Body).Decode(&fi); jsonErr != nil {
+		return nil, nil, NewAppError("SetFileAltText", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &fi, BuildResponse(r), nil
+}
+
+// GetFileInfosForPost gets all the file info objects attached to a post.
+func (c *Client4) GetFileInfosForPost(postId string, etag string) ([]*FileInfo, *Response, error) {
+	r, err := c.DoAPIGet(c.postRoute(postId)+"/files/info", etag)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var list []*FileInfo
+	if r.StatusCode == http.StatusNotModified {
+		return list, BuildResponse(r), nil
+	}
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetFileInfosForPost", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return list, BuildResponse(r), nil
+}
+
+// ReportPost reports a post to the moderators for the given reason.
+func (c *Client4) ReportPost(postId, reason, comment string) (*PostReport, *Response, error) {
+	buf, err := json.Marshal(&PostReport{Reason: reason, Comment: comment})
+	if err != nil {
+		return nil, nil, NewAppError("ReportPost", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.postRoute(postId)+"/report", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var report PostReport
+	if jsonErr := json.NewDecoder(r.Body).Decode(&report); jsonErr != nil {
+		return nil, nil, NewAppError("ReportPost", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// GetPostReports returns a page of the moderation queue, optionally filtered by status and
+// channel. Moderators of a channel must filter by their channel.
+func (c *Client4) GetPostReports(status, channelId string, page, perPage int) ([]*PostReport, *Response, error) {
+	query := fmt.Sprintf("?status=%v&channel_id=%v&page=%v&per_page=%v", url.QueryEscape(status), channelId, page, perPage)
+	r, err := c.DoAPIGet(c.postReportsRoute()+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var reports []*PostReport
+	if jsonErr := json.NewDecoder(r.Body).Decode(&reports); jsonErr != nil {
+		return nil, nil, NewAppError("GetPostReports", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return reports, BuildResponse(r), nil
+}
+
+// GetPostReport returns a report of a post.
+func (c *Client4) GetPostReport(reportId string) (*PostReport, *Response, error) {
+	r, err := c.DoAPIGet(c.postReportRoute(reportId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var report PostReport
+	if jsonErr := json.NewDecoder(r.Body).Decode(&report); jsonErr != nil {
+		return nil, nil, NewAppError("GetPostReport", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
 }
 
-// DownloadFileThumbnail gets the bytes for a file by id, optionally adding headers to force the browser to download it.
-func (c *Client4) DownloadFileThumbnail(fileId string, download bool) ([]byte, *Response, error) {
-	r, err := c.DoAPIGet(c.fileRoute(fileId)+fmt.Sprintf("/thumbnail?download=%v", download), "")
+// ActOnPostReport dismisses a report, deletes the reported post or times its author out.
+func (c *Client4) ActOnPostReport(reportId string, action *PostReportAction) (*PostReport, *Response, error) {
+	buf, err := json.Marshal(action)
+	if err != nil {
+		return nil, nil, NewAppError("ActOnPostReport", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.postReportRoute(reportId)+"/action", buf)
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	data, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		return nil, BuildResponse(r), NewAppError("DownloadFileThumbnail", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+	var report PostReport
+	if jsonErr := json.NewDecoder(r.Body).Decode(&report); jsonErr != nil {
+		return nil, nil, NewAppError("ActOnPostReport", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return data, BuildResponse(r), nil
+	return &report, BuildResponse(r), nil
 }
 
-// GetFileLink gets the public link of a file by id.
-func (c *Client4) GetFileLink(fileId string) (string, *Response, error) {
-	r, err := c.DoAPIGet(c.fileRoute(fileId)+"/link", "")
+// GetPostRetentionLabel returns the retention label applied to a post.
+func (c *Client4) GetPostRetentionLabel(postId string) (*PostRetentionLabel, *Response, error) {
+	r, err := c.DoAPIGet(c.postRoute(postId)+"/retention_label", "")
 	if err != nil {
-		return "", BuildResponse(r), err
+		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-	return MapFromJSON(r.Body)["link"], BuildResponse(r), nil
+	var label PostRetentionLabel
+	if jsonErr := json.NewDecoder(r.Body).Decode(&label); jsonErr != nil {
+		return nil, nil, NewAppError("GetPostRetentionLabel", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &label, BuildResponse(r), nil
 }
 
-// GetFilePreview gets the bytes for a file by id.
-func (c *Client4) GetFilePreview(fileId string) ([]byte, *Response, error) {
-	r, err := c.DoAPIGet(c.fileRoute(fileId)+"/preview", "")
+// SetPostRetentionLabel applies one of the configured retention labels to a post, overriding the
+// retention policies of its channel and team.
+func (c *Client4) SetPostRetentionLabel(postId, label string) (*PostRetentionLabel, *Response, error) {
+	buf, err := json.Marshal(&PostRetentionLabelRequest{Label: label})
+	if err != nil {
+		return nil, nil, NewAppError("SetPostRetentionLabel", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.postRoute(postId)+"/retention_label", buf)
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
+	var retentionLabel PostRetentionLabel
+	if jsonErr := json.NewDecoder(r.Body).Decode(&retentionLabel); jsonErr != nil {
+		return nil, nil, NewAppError("SetPostRetentionLabel", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &retentionLabel, BuildResponse(r), nil
+}
 
-	data, err := ioutil.ReadAll(r.Body)
+// RemovePostRetentionLabel removes the retention label of a post.
+func (c *Client4) RemovePostRetentionLabel(postId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.postRoute(postId) + "/retention_label")
 	if err != nil {
-		return nil, BuildResponse(r), NewAppError("GetFilePreview", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+		return BuildResponse(r), err
 	}
-	return data, BuildResponse(r), nil
+	defer closeBody(r)
+	return BuildResponse(r), nil
 }
 
-// DownloadFilePreview gets the bytes for a file by id.
-func (c *Client4) DownloadFilePreview(fileId string, download bool) ([]byte, *Response, error) {
-	r, err := c.DoAPIGet(c.fileRoute(fileId)+fmt.Sprintf("/preview?download=%v", download), "")
+// Post Prop Schemas Section
+
+// RegisterPostPropSchema registers the JSON schema the values of the post prop named after the
+// namespace must be valid against, replacing the one registered before.
+func (c *Client4) RegisterPostPropSchema(namespace string, schema []byte) (*PostPropSchema, *Response, error) {
+	r, err := c.DoAPIPutBytes(c.postPropSchemaRoute(namespace), schema)
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	data, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		return nil, BuildResponse(r), NewAppError("DownloadFilePreview", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+	var registered PostPropSchema
+	if jsonErr := json.NewDecoder(r.Body).Decode(&registered); jsonErr != nil {
+		return nil, nil, NewAppError("RegisterPostPropSchema", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return data, BuildResponse(r), nil
+	return &registered, BuildResponse(r), nil
 }
 
-// GetFileInfo gets all the file info objects.
-func (c *Client4) GetFileInfo(fileId string) (*FileInfo, *Response, error) {
-	r, err := c.DoAPIGet(c.fileRoute(fileId)+"/info", "")
+// GetPostPropSchema returns the schema registered for a namespace.
+func (c *Client4) GetPostPropSchema(namespace string) (*PostPropSchema, *Response, error) {
+	r, err := c.DoAPIGet(c.postPropSchemaRoute(namespace), "")
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	var fi FileInfo
-	if jsonErr := json.NewDecoder(r.Body).Decode(&fi); jsonErr != nil {
-		return nil, nil, NewAppError("GetFileInfo", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	var schema PostPropSchema
+	if jsonErr := json.NewDecoder(r.Body).Decode(&schema); jsonErr != nil {
+		return nil, nil, NewAppError("GetPostPropSchema", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return &fi, BuildResponse(r), nil
+	return &schema, BuildResponse(r), nil
 }
 
-// GetFileInfosForPost gets all the file info objects attached to a post.
-func (c *Client4) GetFileInfosForPost(postId string, etag string) ([]*FileInfo, *Response, error) {
-	r, err := c.DoAPIGet(c.postRoute(postId)+"/files/info", etag)
+// GetPostPropSchemas returns every registered schema, ordered by namespace.
+func (c *Client4) GetPostPropSchemas() ([]*PostPropSchema, *Response, error) {
+	r, err := c.DoAPIGet(c.postPropSchemasRoute(), "")
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
 	defer closeBody(r)
-
-	var list []*FileInfo
-	if r.StatusCode == http.StatusNotModified {
-		return list, BuildResponse(r), nil
+	var schemas []*PostPropSchema
+	if jsonErr := json.NewDecoder(r.Body).Decode(&schemas); jsonErr != nil {
+		return nil, nil, NewAppError("GetPostPropSchemas", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
-		return nil, nil, NewAppError("GetFileInfosForPost", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	return schemas, BuildResponse(r), nil
+}
+
+// DeletePostPropSchema unregisters the schema of a namespace.
+func (c *Client4) DeletePostPropSchema(namespace string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.postPropSchemaRoute(namespace))
+	if err != nil {
+		return BuildResponse(r), err
 	}
-	return list, BuildResponse(r), nil
+	defer closeBody(r)
+	return BuildResponse(r), nil
 }
 
 // General/System Section
 
 // GenerateSupportPacket downloads the generated support packet
 func (c *Client4) GenerateSupportPacket() ([]byte, *Response, error) {
-	r, err := c.DoAPIGet(c.systemRoute()+"/support_packet", "")
+	return c.generateSupportPacket("")
+}
+
+// GenerateSupportPacketWithDiagnostics generates a support packet that also includes
+// store statistics and runtime profiles.
+func (c *Client4) GenerateSupportPacketWithDiagnostics() ([]byte, *Response, error) {
+	return c.generateSupportPacket("?include_diagnostics=true")
+}
+
+func (c *Client4) generateSupportPacket(query string) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(c.systemRoute()+"/support_packet"+query, "")
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
@@ -4372,6 +5725,21 @@ func (c *Client4) GenerateSupportPacket() ([]byte, *Response, error) {
 	return data, BuildResponse(r), nil
 }
 
+// GetSystemCheckup evaluates the server configuration and environment and returns any findings.
+func (c *Client4) GetSystemCheckup() (*SystemCheckup, *Response, error) {
+	r, err := c.DoAPIGet(c.systemRoute()+"/checkup", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var checkup SystemCheckup
+	if jsonErr := json.NewDecoder(r.Body).Decode(&checkup); jsonErr != nil {
+		return nil, nil, NewAppError("GetSystemCheckup", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &checkup, BuildResponse(r), nil
+}
+
 // GetPing will return ok if the running goRoutines are below the threshold and unhealthy for above.
 func (c *Client4) GetPing() (string, *Response, error) {
 	r, err := c.DoAPIGet(c.systemRoute()+"/ping", "")
@@ -4386,6 +5754,20 @@ func (c *Client4) GetPing() (string, *Response, error) {
 	return MapFromJSON(r.Body)["status"], BuildResponse(r), nil
 }
 
+// TrackTelemetryEvent sends an event to the self-hosted diagnostics sink, through the server.
+func (c *Client4) TrackTelemetryEvent(event *TelemetryEvent) (*Response, error) {
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return nil, NewAppError("TrackTelemetryEvent", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.systemRoute()+"/telemetry/events", buf)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetPingWithServerStatus will return ok if several basic server health checks
 // all pass successfully.
 func (c *Client4) GetPingWithServerStatus() (string, *Response, error) {
@@ -4416,6 +5798,48 @@ func (c *Client4) GetPingWithFullServerStatus() (map[string]string, *Response, e
 	return MapFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// GetLiveness will return ok if the server is alive.
+func (c *Client4) GetLiveness() (string, *Response, error) {
+	r, err := c.DoAPIGet(c.URL+"/healthz", "")
+	if err != nil {
+		return "", BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return MapFromJSON(r.Body)[STATUS], BuildResponse(r), nil
+}
+
+// GetReadiness returns the status of the dependencies of the server if it is ready to receive
+// traffic, and an error with a 503 response otherwise.
+func (c *Client4) GetReadiness() (*ReadinessStatus, *Response, error) {
+	r, err := c.DoAPIGet(c.URL+"/readyz", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var readiness ReadinessStatus
+	if jsonErr := json.NewDecoder(r.Body).Decode(&readiness); jsonErr != nil {
+		return nil, nil, NewAppError("GetReadiness", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &readiness, BuildResponse(r), nil
+}
+
+// GetSystemDependencies returns the reachability of the dependencies of the server, as last
+// checked, and whether it runs in degraded mode because of them.
+func (c *Client4) GetSystemDependencies() (*DependenciesStatus, *Response, error) {
+	r, err := c.DoAPIGet(c.systemRoute()+"/dependencies", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var status DependenciesStatus
+	if jsonErr := json.NewDecoder(r.Body).Decode(&status); jsonErr != nil {
+		return nil, nil, NewAppError("GetSystemDependencies", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &status, BuildResponse(r), nil
+}
+
 // TestEmail will attempt to connect to the configured SMTP server.
 func (c *Client4) TestEmail(config *Config) (*Response, error) {
 	buf, err := json.Marshal(config)
@@ -4456,6 +5880,23 @@ func (c *Client4) TestS3Connection(config *Config) (*Response, error) {
 	return BuildResponse(r), nil
 }
 
+// GetConnectivityTestHistory returns the results of past SMTP, S3 and Elasticsearch connection
+// tests, newest first. An empty service returns the results of every service.
+func (c *Client4) GetConnectivityTestHistory(service string, page, perPage int) ([]*ConnectivityTestResult, *Response, error) {
+	query := fmt.Sprintf("?service=%v&page=%v&per_page=%v", url.QueryEscape(service), page, perPage)
+	r, err := c.DoAPIGet(c.systemRoute()+"/connectivity_tests"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var results []*ConnectivityTestResult
+	if jsonErr := json.NewDecoder(r.Body).Decode(&results); jsonErr != nil {
+		return nil, nil, NewAppError("GetConnectivityTestHistory", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return results, BuildResponse(r), nil
+}
+
 // GetConfig will retrieve the server config with some sanitized items.
 func (c *Client4) GetConfig() (*Config, *Response, error) {
 	r, err := c.DoAPIGet(c.configRoute(), "")
@@ -4466,6 +5907,22 @@ func (c *Client4) GetConfig() (*Config, *Response, error) {
 	return ConfigFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// GetConfigWithOrigins will retrieve the server config along with the origin of each of its
+// values: an environment variable, the backing store or the defaults.
+func (c *Client4) GetConfigWithOrigins() (*ConfigWithOrigins, *Response, error) {
+	r, err := c.DoAPIGet(c.configRoute()+"?include_origin=true", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var cfg ConfigWithOrigins
+	if jsonErr := json.NewDecoder(r.Body).Decode(&cfg); jsonErr != nil {
+		return nil, nil, NewAppError("GetConfigWithOrigins", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &cfg, BuildResponse(r), nil
+}
+
 // ReloadConfig will reload the server configuration.
 func (c *Client4) ReloadConfig() (*Response, error) {
 	r, err := c.DoAPIPost(c.configRoute()+"/reload", "")
@@ -4499,6 +5956,26 @@ func (c *Client4) GetEnvironmentConfig() (map[string]interface{}, *Response, err
 	return StringInterfaceFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// PatchFeatureFlagOverrides sets feature flag overrides persisted in the config store. Flags
+// with a nil value have their override removed. It returns all the overrides after the change.
+func (c *Client4) PatchFeatureFlagOverrides(patch map[string]*string) (map[string]string, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchFeatureFlagOverrides", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPatchBytes(c.configRoute()+"/feature_flags", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var overrides map[string]string
+	if jsonErr := json.NewDecoder(r.Body).Decode(&overrides); jsonErr != nil {
+		return nil, nil, NewAppError("PatchFeatureFlagOverrides", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return overrides, BuildResponse(r), nil
+}
+
 // GetOldClientLicense will retrieve the parts of the server license needed by the
 // client, formatted in the old format.
 func (c *Client4) GetOldClientLicense(etag string) (map[string]string, *Response, error) {
@@ -4510,6 +5987,20 @@ func (c *Client4) GetOldClientLicense(etag string) (map[string]string, *Response
 	return MapFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// GetLicenseStatus retrieves seat usage and expiry information for the current license.
+func (c *Client4) GetLicenseStatus() (*LicenseStatus, *Response, error) {
+	r, err := c.DoAPIGet(c.licenseRoute()+"/status", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var status LicenseStatus
+	if jsonErr := json.NewDecoder(r.Body).Decode(&status); jsonErr != nil {
+		return nil, nil, NewAppError("GetLicenseStatus", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &status, BuildResponse(r), nil
+}
+
 // DatabaseRecycle will recycle the connections. Discard current connection and get new one.
 func (c *Client4) DatabaseRecycle() (*Response, error) {
 	r, err := c.DoAPIPost(c.databaseRoute()+"/recycle", "")
@@ -4578,7 +6069,7 @@ func (c *Client4) UploadLicenseFile(data []byte) (*Response, error) {
 		return nil, NewAppError("UploadLicenseFile", "model.client.set_profile_user.writer.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	rq, err := http.NewRequest("POST", c.APIURL+c.licenseRoute(), bytes.NewReader(body.Bytes()))
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+c.licenseRoute(), bytes.NewReader(body.Bytes()))
 	if err != nil {
 		return nil, err
 	}
@@ -4853,6 +6344,20 @@ func (c *Client4) RegenOutgoingHookToken(hookId string) (*OutgoingWebhook, *Resp
 	return &ow, BuildResponse(r), nil
 }
 
+// RegenOutgoingHookSigningSecret regenerates the secret the requests of the outgoing webhook are signed with.
+func (c *Client4) RegenOutgoingHookSigningSecret(hookId string) (*OutgoingWebhook, *Response, error) {
+	r, err := c.DoAPIPost(c.outgoingWebhookRoute(hookId)+"/regen_signing_secret", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var ow OutgoingWebhook
+	if jsonErr := json.NewDecoder(r.Body).Decode(&ow); jsonErr != nil {
+		return nil, nil, NewAppError("RegenOutgoingHookSigningSecret", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &ow, BuildResponse(r), nil
+}
+
 // DeleteOutgoingWebhook delete the outgoing webhook on the system requested by Hook Id.
 func (c *Client4) DeleteOutgoingWebhook(hookId string) (*Response, error) {
 	r, err := c.DoAPIDelete(c.outgoingWebhookRoute(hookId))
@@ -5147,7 +6652,7 @@ func (c *Client4) GetComplianceReport(reportId string) (*Compliance, *Response,
 
 // DownloadComplianceReport returns a full compliance report as a file.
 func (c *Client4) DownloadComplianceReport(reportId string) ([]byte, *Response, error) {
-	rq, err := http.NewRequest("GET", c.APIURL+c.complianceReportDownloadRoute(reportId), nil)
+	rq, err := http.NewRequestWithContext(c.requestContext(), "GET", c.APIURL+c.complianceReportDownloadRoute(reportId), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5544,7 +7049,7 @@ func (c *Client4) UploadBrandImage(data []byte) (*Response, error) {
 		return nil, NewAppError("UploadBrandImage", "model.client.set_profile_user.writer.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
-	rq, err := http.NewRequest("POST", c.APIURL+c.brandRoute()+"/image", bytes.NewReader(body.Bytes()))
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+c.brandRoute()+"/image", bytes.NewReader(body.Bytes()))
 	if err != nil {
 		return nil, err
 	}
@@ -5741,7 +7246,7 @@ func (c *Client4) DeauthorizeOAuthApp(appId string) (*Response, error) {
 // GetOAuthAccessToken is a test helper function for the OAuth access token endpoint.
 func (c *Client4) GetOAuthAccessToken(data url.Values) (*AccessResponse, *Response, error) {
 	url := c.URL + "/oauth/access_token"
-	rq, err := http.NewRequest(http.MethodPost, url, strings.NewReader(data.Encode()))
+	rq, err := http.NewRequestWithContext(c.requestContext(), http.MethodPost, url, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -5770,6 +7275,100 @@ func (c *Client4) GetOAuthAccessToken(data url.Values) (*AccessResponse, *Respon
 	return ar, BuildResponse(rp), nil
 }
 
+// API Deprecations Section
+
+// GetAPIDeprecations returns the endpoints and fields of the API scheduled for removal.
+func (c *Client4) GetAPIDeprecations() ([]*APIDeprecation, *Response, error) {
+	r, err := c.DoAPIGet("/deprecations", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var deprecations []*APIDeprecation
+	if err := json.NewDecoder(r.Body).Decode(&deprecations); err != nil {
+		return nil, nil, NewAppError("GetAPIDeprecations", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return deprecations, BuildResponse(r), nil
+}
+
+// Outgoing OAuth Connections Section
+
+// CreateOutgoingOAuthConnection creates an outgoing OAuth connection based on the provided struct.
+func (c *Client4) CreateOutgoingOAuthConnection(connection *OutgoingOAuthConnection) (*OutgoingOAuthConnection, *Response, error) {
+	buf, err := json.Marshal(connection)
+	if err != nil {
+		return nil, nil, NewAppError("CreateOutgoingOAuthConnection", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.outgoingOAuthConnectionsRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var oc OutgoingOAuthConnection
+	if jsonErr := json.NewDecoder(r.Body).Decode(&oc); jsonErr != nil {
+		return nil, nil, NewAppError("CreateOutgoingOAuthConnection", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &oc, BuildResponse(r), nil
+}
+
+// GetOutgoingOAuthConnections returns a page of outgoing OAuth connections, without their secrets.
+func (c *Client4) GetOutgoingOAuthConnections(page, perPage int) ([]*OutgoingOAuthConnection, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(c.outgoingOAuthConnectionsRoute()+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*OutgoingOAuthConnection
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetOutgoingOAuthConnections", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return list, BuildResponse(r), nil
+}
+
+// GetOutgoingOAuthConnection returns an outgoing OAuth connection, without its secrets, based on the provided id string.
+func (c *Client4) GetOutgoingOAuthConnection(connectionId string) (*OutgoingOAuthConnection, *Response, error) {
+	r, err := c.DoAPIGet(c.outgoingOAuthConnectionRoute(connectionId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var oc OutgoingOAuthConnection
+	if jsonErr := json.NewDecoder(r.Body).Decode(&oc); jsonErr != nil {
+		return nil, nil, NewAppError("GetOutgoingOAuthConnection", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &oc, BuildResponse(r), nil
+}
+
+// UpdateOutgoingOAuthConnection updates an outgoing OAuth connection. The secrets left empty keep their current value.
+func (c *Client4) UpdateOutgoingOAuthConnection(connection *OutgoingOAuthConnection) (*OutgoingOAuthConnection, *Response, error) {
+	buf, err := json.Marshal(connection)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateOutgoingOAuthConnection", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.outgoingOAuthConnectionRoute(connection.Id), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var oc OutgoingOAuthConnection
+	if jsonErr := json.NewDecoder(r.Body).Decode(&oc); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateOutgoingOAuthConnection", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &oc, BuildResponse(r), nil
+}
+
+// DeleteOutgoingOAuthConnection deletes the outgoing OAuth connection with the provided id string.
+func (c *Client4) DeleteOutgoingOAuthConnection(connectionId string) (*Response, error) {
+	r, err := c.DoAPIDelete(c.outgoingOAuthConnectionRoute(connectionId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // Elasticsearch Section
 
 // TestElasticsearch will attempt to connect to the configured Elasticsearch server and return OK if configured.
@@ -6235,6 +7834,16 @@ func (c *Client4) RegenCommandToken(commandId string) (string, *Response, error)
 	return MapFromJSON(r.Body)["token"], BuildResponse(r), nil
 }
 
+// RegenCommandSigningSecret will create a new secret to sign the requests of the command with if the user have the right permissions.
+func (c *Client4) RegenCommandSigningSecret(commandId string) (string, *Response, error) {
+	r, err := c.DoAPIPut(c.commandRoute(commandId)+"/regen_signing_secret", "")
+	if err != nil {
+		return "", BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return MapFromJSON(r.Body)["signing_secret"], BuildResponse(r), nil
+}
+
 // Status Section
 
 // GetUserStatus returns a user based on the provided user id string.
@@ -6324,6 +7933,38 @@ func (c *Client4) RemoveRecentUserCustomStatus(userId string) (*Response, error)
 	return BuildResponse(r), nil
 }
 
+// GetUserDNDBypassList returns the people and keywords allowed to notify a user while in do not disturb.
+func (c *Client4) GetUserDNDBypassList(userId string) (*DNDBypassList, *Response, error) {
+	r, err := c.DoAPIGet(c.userStatusRoute(userId)+"/dnd/bypass", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list DNDBypassList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetUserDNDBypassList", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &list, BuildResponse(r), nil
+}
+
+// UpdateUserDNDBypassList replaces the people and keywords allowed to notify a user while in do not disturb.
+func (c *Client4) UpdateUserDNDBypassList(userId string, list *DNDBypassList) (*DNDBypassList, *Response, error) {
+	buf, err := json.Marshal(list)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateUserDNDBypassList", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.userStatusRoute(userId)+"/dnd/bypass", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var updated DNDBypassList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&updated); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateUserDNDBypassList", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &updated, BuildResponse(r), nil
+}
+
 // Emoji Section
 
 // CreateEmoji will save an emoji to the server if the current user has permission
@@ -6390,6 +8031,21 @@ func (c *Client4) GetSortedEmojiList(page, perPage int, sort string) ([]*Emoji,
 	return list, BuildResponse(r), nil
 }
 
+// GetEmojiListForTeam returns a page of the custom emoji scoped to the team.
+func (c *Client4) GetEmojiListForTeam(teamId string, page, perPage int) ([]*Emoji, *Response, error) {
+	query := fmt.Sprintf("?team_id=%v&page=%v&per_page=%v", teamId, page, perPage)
+	r, err := c.DoAPIGet(c.emojisRoute()+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*Emoji
+	if jsonErr := json.NewDecoder(r.Body).Decode(&list); jsonErr != nil {
+		return nil, nil, NewAppError("GetEmojiListForTeam", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return list, BuildResponse(r), nil
+}
+
 // DeleteEmoji delete an custom emoji on the provided emoji id string.
 func (c *Client4) DeleteEmoji(emojiId string) (*Response, error) {
 	r, err := c.DoAPIDelete(c.emojiRoute(emojiId))
@@ -6425,12 +8081,28 @@ func (c *Client4) GetEmojiByName(name string) (*Emoji, *Response, error) {
 	if jsonErr := json.NewDecoder(r.Body).Decode(&e); jsonErr != nil {
 		return nil, nil, NewAppError("GetEmojiByName", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
 	}
-	return &e, BuildResponse(r), nil
+	return &e, BuildResponse(r), nil
+}
+
+// GetEmojiImage returns the emoji image.
+func (c *Client4) GetEmojiImage(emojiId string) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(c.emojiRoute(emojiId)+"/image", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("GetEmojiImage", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+	}
+
+	return data, BuildResponse(r), nil
 }
 
-// GetEmojiImage returns the emoji image.
-func (c *Client4) GetEmojiImage(emojiId string) ([]byte, *Response, error) {
-	r, err := c.DoAPIGet(c.emojiRoute(emojiId)+"/image", "")
+// ExportEmojiArchive returns a zip archive of all the custom emoji on the system.
+func (c *Client4) ExportEmojiArchive() ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(c.emojisRoute()+"/export", "")
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
@@ -6438,12 +8110,48 @@ func (c *Client4) GetEmojiImage(emojiId string) ([]byte, *Response, error) {
 
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return nil, BuildResponse(r), NewAppError("GetEmojiImage", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
+		return nil, BuildResponse(r), NewAppError("ExportEmojiArchive", "model.client.read_file.app_error", nil, err.Error(), r.StatusCode)
 	}
 
 	return data, BuildResponse(r), nil
 }
 
+// ImportEmojiArchive creates custom emoji from a zip archive, resolving name collisions with the
+// given policy.
+func (c *Client4) ImportEmojiArchive(archive []byte, filename, collisionPolicy string) (*EmojiImportResult, *Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("archive", filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = io.Copy(part, bytes.NewBuffer(archive)); err != nil {
+		return nil, nil, err
+	}
+
+	if err = writer.WriteField("collision_policy", collisionPolicy); err != nil {
+		return nil, nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	r, err := c.DoAPIRequestReader("POST", c.APIURL+c.emojisRoute()+"/import", body, map[string]string{"Content-Type": writer.FormDataContentType()})
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var result EmojiImportResult
+	if jsonErr := json.NewDecoder(r.Body).Decode(&result); jsonErr != nil {
+		return nil, nil, NewAppError("ImportEmojiArchive", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &result, BuildResponse(r), nil
+}
+
 // SearchEmoji returns a list of emoji matching some search criteria.
 func (c *Client4) SearchEmoji(search *EmojiSearch) ([]*Emoji, *Response, error) {
 	buf, err := json.Marshal(search)
@@ -6742,6 +8450,24 @@ func (c *Client4) GetRolesByNames(roleNames []string) ([]*Role, *Response, error
 	return list, BuildResponse(r), nil
 }
 
+// CreateRole creates a custom role, which can be assigned at the scope of the role.
+func (c *Client4) CreateRole(role *Role) (*Role, *Response, error) {
+	buf, err := json.Marshal(role)
+	if err != nil {
+		return nil, nil, NewAppError("CreateRole", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.rolesRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var created Role
+	if jsonErr := json.NewDecoder(r.Body).Decode(&created); jsonErr != nil {
+		return nil, nil, NewAppError("CreateRole", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &created, BuildResponse(r), nil
+}
+
 // PatchRole partially updates a role in the system. Any missing fields are not updated.
 func (c *Client4) PatchRole(roleId string, patch *RolePatch) (*Role, *Response, error) {
 	buf, err := json.Marshal(patch)
@@ -6901,7 +8627,7 @@ func (c *Client4) uploadPlugin(file io.Reader, force bool) (*Manifest, *Response
 		return nil, nil, err
 	}
 
-	rq, err := http.NewRequest("POST", c.APIURL+c.pluginsRoute(), body)
+	rq, err := http.NewRequestWithContext(c.requestContext(), "POST", c.APIURL+c.pluginsRoute(), body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -6994,6 +8720,21 @@ func (c *Client4) GetPluginStatuses() (PluginStatuses, *Response, error) {
 	return list, BuildResponse(r), nil
 }
 
+// GetPluginResourceUsage returns the resource usage of the server process of a plugin, on the
+// server handling the request.
+func (c *Client4) GetPluginResourceUsage(id string) (*PluginResourceUsage, *Response, error) {
+	r, err := c.DoAPIGet(c.pluginRoute(id)+"/resources", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var usage PluginResourceUsage
+	if jsonErr := json.NewDecoder(r.Body).Decode(&usage); jsonErr != nil {
+		return nil, nil, NewAppError("GetPluginResourceUsage", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &usage, BuildResponse(r), nil
+}
+
 // RemovePlugin will disable and delete a plugin.
 func (c *Client4) RemovePlugin(id string) (*Response, error) {
 	r, err := c.DoAPIDelete(c.pluginRoute(id))
@@ -7399,6 +9140,85 @@ func (c *Client4) PatchConfig(config *Config) (*Config, *Response, error) {
 	return ConfigFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// GetConfigSchema returns the schema of the server configuration: the type, default value and
+// constraints of each of its settings.
+func (c *Client4) GetConfigSchema() ([]*ConfigSchemaSetting, *Response, error) {
+	r, err := c.DoAPIGet(c.configRoute()+"/schema", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var schema []*ConfigSchemaSetting
+	if jsonErr := json.NewDecoder(r.Body).Decode(&schema); jsonErr != nil {
+		return nil, nil, NewAppError("GetConfigSchema", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return schema, BuildResponse(r), nil
+}
+
+// GetLockedConfigPaths returns the configuration paths locked by the deployment, which can't be
+// changed through the API.
+func (c *Client4) GetLockedConfigPaths() ([]string, *Response, error) {
+	r, err := c.DoAPIGet(c.configRoute()+"/locked", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var paths []string
+	if jsonErr := json.NewDecoder(r.Body).Decode(&paths); jsonErr != nil {
+		return nil, nil, NewAppError("GetLockedConfigPaths", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return paths, BuildResponse(r), nil
+}
+
+// ScheduleConfigChange schedules the partial configuration of the change to be merged onto the
+// server configuration at its apply time.
+func (c *Client4) ScheduleConfigChange(change *ScheduledConfigChange) (*ScheduledConfigChange, *Response, error) {
+	buf, err := json.Marshal(change)
+	if err != nil {
+		return nil, nil, NewAppError("ScheduleConfigChange", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.configRoute()+"/scheduled", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var scheduled ScheduledConfigChange
+	if jsonErr := json.NewDecoder(r.Body).Decode(&scheduled); jsonErr != nil {
+		return nil, nil, NewAppError("ScheduleConfigChange", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &scheduled, BuildResponse(r), nil
+}
+
+// GetScheduledConfigChanges returns a page of the scheduled config changes, the last to apply
+// first. The changes that are no longer pending are only returned if includeFinished is true.
+func (c *Client4) GetScheduledConfigChanges(includeFinished bool, page, perPage int) ([]*ScheduledConfigChange, *Response, error) {
+	query := fmt.Sprintf("?include_finished=%v&page=%v&per_page=%v", includeFinished, page, perPage)
+	r, err := c.DoAPIGet(c.configRoute()+"/scheduled"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var changes []*ScheduledConfigChange
+	if jsonErr := json.NewDecoder(r.Body).Decode(&changes); jsonErr != nil {
+		return nil, nil, NewAppError("GetScheduledConfigChanges", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return changes, BuildResponse(r), nil
+}
+
+// CancelScheduledConfigChange cancels a scheduled config change that is still pending.
+func (c *Client4) CancelScheduledConfigChange(changeId string) (*ScheduledConfigChange, *Response, error) {
+	r, err := c.DoAPIDelete(c.configRoute() + "/scheduled/" + changeId)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var canceled ScheduledConfigChange
+	if jsonErr := json.NewDecoder(r.Body).Decode(&canceled); jsonErr != nil {
+		return nil, nil, NewAppError("CancelScheduledConfigChange", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &canceled, BuildResponse(r), nil
+}
+
 func (c *Client4) GetChannelModerations(channelID string, etag string) ([]*ChannelModeration, *Response, error) {
 	r, err := c.DoAPIGet(c.channelRoute(channelID)+"/moderations", etag)
 	if err != nil {
@@ -7823,6 +9643,19 @@ func (c *Client4) GetSubscription() (*Subscription, *Response, error) {
 	return subscription, BuildResponse(r), nil
 }
 
+func (c *Client4) GetSubscriptionChangePreview(seats int) (*SubscriptionChangePreview, *Response, error) {
+	r, err := c.DoAPIGet(c.cloudRoute()+"/subscription/preview?seats="+strconv.Itoa(seats), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var preview *SubscriptionChangePreview
+	json.NewDecoder(r.Body).Decode(&preview)
+
+	return preview, BuildResponse(r), nil
+}
+
 func (c *Client4) GetInvoicesForSubscription() ([]*Invoice, *Response, error) {
 	r, err := c.DoAPIGet(c.cloudRoute()+"/subscription/invoices", "")
 	if err != nil {
@@ -7836,6 +9669,29 @@ func (c *Client4) GetInvoicesForSubscription() ([]*Invoice, *Response, error) {
 	return invoices, BuildResponse(r), nil
 }
 
+// GetInvoicesForSubscriptionPage returns a page of invoices created between since and until,
+// given as milliseconds since the epoch. Zero values leave that side of the range open.
+func (c *Client4) GetInvoicesForSubscriptionPage(page, perPage int, since, until int64) ([]*Invoice, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	if since > 0 {
+		query += fmt.Sprintf("&since=%v", since)
+	}
+	if until > 0 {
+		query += fmt.Sprintf("&until=%v", until)
+	}
+
+	r, err := c.DoAPIGet(c.cloudRoute()+"/subscription/invoices"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var invoices []*Invoice
+	json.NewDecoder(r.Body).Decode(&invoices)
+
+	return invoices, BuildResponse(r), nil
+}
+
 func (c *Client4) UpdateCloudCustomer(customerInfo *CloudCustomerInfo) (*CloudCustomer, *Response, error) {
 	customerBytes, _ := json.Marshal(customerInfo)
 
@@ -8053,6 +9909,40 @@ func (c *Client4) GetRemoteClusterInfo(remoteID string) (RemoteClusterInfo, *Res
 	return rci, BuildResponse(r), nil
 }
 
+// GetRemoteClusterPinnedCerts returns the certificate fingerprints pinned for a remote cluster.
+func (c *Client4) GetRemoteClusterPinnedCerts(remoteID string) (*RemoteClusterPinnedCerts, *Response, error) {
+	r, err := c.DoAPIGet(c.remoteClusterRoute(remoteID)+"/pinned_certs", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var pinned RemoteClusterPinnedCerts
+	if jsonErr := json.NewDecoder(r.Body).Decode(&pinned); jsonErr != nil {
+		return nil, nil, NewAppError("GetRemoteClusterPinnedCerts", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &pinned, BuildResponse(r), nil
+}
+
+// UpdateRemoteClusterPinnedCerts replaces the certificate fingerprints pinned for a remote cluster.
+func (c *Client4) UpdateRemoteClusterPinnedCerts(remoteID string, fingerprints []string) (*RemoteClusterPinnedCerts, *Response, error) {
+	buf, err := json.Marshal(RemoteClusterPinnedCerts{RemoteId: remoteID, PinnedCerts: fingerprints})
+	if err != nil {
+		return nil, nil, NewAppError("UpdateRemoteClusterPinnedCerts", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPutBytes(c.remoteClusterRoute(remoteID)+"/pinned_certs", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var pinned RemoteClusterPinnedCerts
+	if jsonErr := json.NewDecoder(r.Body).Decode(&pinned); jsonErr != nil {
+		return nil, nil, NewAppError("UpdateRemoteClusterPinnedCerts", "api.unmarshal_error", nil, jsonErr.Error(), http.StatusInternalServerError)
+	}
+	return &pinned, BuildResponse(r), nil
+}
+
 func (c *Client4) GetAncillaryPermissions(subsectionPermissions []string) ([]string, *Response, error) {
 	var returnedPermissions []string
 	url := fmt.Sprintf("%s/ancillary?subsection_permissions=%s", c.permissionsRoute(), strings.Join(subsectionPermissions, ","))
@@ -8066,6 +9956,127 @@ func (c *Client4) GetAncillaryPermissions(subsectionPermissions []string) ([]str
 	return returnedPermissions, BuildResponse(r), nil
 }
 
+// CreateAuthMigrationJob starts a job verifying users against another auth provider and migrating
+// them to it, and returns its initial report.
+func (c *Client4) CreateAuthMigrationJob(migrationRequest *AuthMigrationRequest) (*AuthMigrationReport, *Response, error) {
+	buf, err := json.Marshal(migrationRequest)
+	if err != nil {
+		return nil, nil, NewAppError("CreateAuthMigrationJob", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.usersRoute()+"/migrate_auth/jobs", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report AuthMigrationReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("CreateAuthMigrationJob", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// GetAuthMigrationReport returns the progress of an auth migration job.
+func (c *Client4) GetAuthMigrationReport(jobId string) (*AuthMigrationReport, *Response, error) {
+	r, err := c.DoAPIGet(c.usersRoute()+"/migrate_auth/jobs/"+jobId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report AuthMigrationReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetAuthMigrationReport", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// DownloadAuthMigrationMismatches returns the CSV report of the users an auth migration job could
+// not verify or migrate.
+func (c *Client4) DownloadAuthMigrationMismatches(jobId string) ([]byte, *Response, error) {
+	r, err := c.DoAPIGet(c.usersRoute()+"/migrate_auth/jobs/"+jobId+"/mismatches", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("DownloadAuthMigrationMismatches", "model.client.read_job_result_file.app_error", nil, err.Error(), r.StatusCode)
+	}
+	return data, BuildResponse(r), nil
+}
+
+// MergeUsers starts a job merging a duplicate user into a primary one and returns its initial report.
+func (c *Client4) MergeUsers(mergeRequest *UserMergeRequest) (*UserMergeReport, *Response, error) {
+	buf, err := json.Marshal(mergeRequest)
+	if err != nil {
+		return nil, nil, NewAppError("MergeUsers", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.usersRoute()+"/merge", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report UserMergeReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("MergeUsers", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// GetUserMergeReport returns the progress of a user merge job and what it merged.
+func (c *Client4) GetUserMergeReport(jobId string) (*UserMergeReport, *Response, error) {
+	r, err := c.DoAPIGet(c.usersRoute()+"/merge/"+jobId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report UserMergeReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetUserMergeReport", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// DeleteUserInStages starts a job exporting the personal data of a user then anonymizing or
+// removing the user according to the policy of the request, and returns its initial report.
+func (c *Client4) DeleteUserInStages(userId string, deletionRequest *UserDeletionRequest) (*UserDeletionReport, *Response, error) {
+	buf, err := json.Marshal(deletionRequest)
+	if err != nil {
+		return nil, nil, NewAppError("DeleteUserInStages", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.userRoute(userId)+"/deletion", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report UserDeletionReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("DeleteUserInStages", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
+// GetUserDeletionReport returns the progress of a user deletion job and, once the personal data
+// of the user is exported, the name of the export.
+func (c *Client4) GetUserDeletionReport(jobId string) (*UserDeletionReport, *Response, error) {
+	r, err := c.DoAPIGet(c.usersRoute()+"/deletion/"+jobId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var report UserDeletionReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetUserDeletionReport", "api.unmarshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return &report, BuildResponse(r), nil
+}
+
 func (c *Client4) GetUsersWithInvalidEmails(page, perPage int) ([]*User, *Response, error) {
 	query := fmt.Sprintf("/invalid_emails?page=%v&per_page=%v", page, perPage)
 	r, err := c.DoAPIGet(c.usersRoute()+query, "")
@@ -8111,6 +10122,19 @@ func (c *Client4) GetPostsUsage() (*PostsUsage, *Response, error) {
 	return usage, BuildResponse(r), err
 }
 
+// GetUsage returns the usage counters reported by all the products, evaluated against the product limits
+func (c *Client4) GetUsage() (*Usage, *Response, error) {
+	r, err := c.DoAPIGet(c.usageRoute(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var usage *Usage
+	err = json.NewDecoder(r.Body).Decode(&usage)
+	return usage, BuildResponse(r), err
+}
+
 // GetIntegrationsUsage returns usage information on integrations, including the count of enabled integrations
 func (c *Client4) GetIntegrationsUsage() (*IntegrationsUsage, *Response, error) {
 	r, err := c.DoAPIGet(c.usageRoute()+"/integrations", "")
@@ -8123,3 +10147,100 @@ func (c *Client4) GetIntegrationsUsage() (*IntegrationsUsage, *Response, error)
 	err = json.NewDecoder(r.Body).Decode(&usage)
 	return usage, BuildResponse(r), err
 }
+
+// GetTeamEmojiUsage returns the number of custom emoji scoped to the team along with the team's quota
+func (c *Client4) GetTeamEmojiUsage(teamId string) (*EmojiUsage, *Response, error) {
+	r, err := c.DoAPIGet(c.usageRoute()+"/emoji?team_id="+teamId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var usage *EmojiUsage
+	err = json.NewDecoder(r.Body).Decode(&usage)
+	return usage, BuildResponse(r), err
+}
+
+// RequestImpersonation asks for the consent of a user to be impersonated by the current user,
+// a system admin, unless the request overrides it as allowed by policy.
+func (c *Client4) RequestImpersonation(userId string, impersonation *ImpersonationRequest) (*ImpersonationRequest, *Response, error) {
+	buf, err := json.Marshal(impersonation)
+	if err != nil {
+		return nil, nil, NewAppError("RequestImpersonation", "api.marshal_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	r, err := c.DoAPIPostBytes(c.userRoute(userId)+"/impersonation", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var created *ImpersonationRequest
+	err = json.NewDecoder(r.Body).Decode(&created)
+	return created, BuildResponse(r), err
+}
+
+// GetImpersonationRequestsForUser returns the requests to impersonate a user which have not expired.
+func (c *Client4) GetImpersonationRequestsForUser(userId string) ([]*ImpersonationRequest, *Response, error) {
+	r, err := c.DoAPIGet(c.userRoute(userId)+"/impersonation", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var impersonations []*ImpersonationRequest
+	err = json.NewDecoder(r.Body).Decode(&impersonations)
+	return impersonations, BuildResponse(r), err
+}
+
+// GetImpersonationRequest returns an impersonation request.
+func (c *Client4) GetImpersonationRequest(impersonationId string) (*ImpersonationRequest, *Response, error) {
+	r, err := c.DoAPIGet(c.impersonationRoute(impersonationId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var impersonation *ImpersonationRequest
+	err = json.NewDecoder(r.Body).Decode(&impersonation)
+	return impersonation, BuildResponse(r), err
+}
+
+// AnswerImpersonationRequest records whether the current user consents to be impersonated.
+func (c *Client4) AnswerImpersonationRequest(impersonationId string, consent bool) (*ImpersonationRequest, *Response, error) {
+	r, err := c.DoAPIPost(c.impersonationRoute(impersonationId)+"/consent", fmt.Sprintf(`{"consent": %t}`, consent))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var impersonation *ImpersonationRequest
+	err = json.NewDecoder(r.Body).Decode(&impersonation)
+	return impersonation, BuildResponse(r), err
+}
+
+// StartImpersonation starts the read-only session of an approved impersonation request, and
+// returns its token.
+func (c *Client4) StartImpersonation(impersonationId string) (*ImpersonationSession, *Response, error) {
+	r, err := c.DoAPIPost(c.impersonationRoute(impersonationId)+"/start", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var session *ImpersonationSession
+	err = json.NewDecoder(r.Body).Decode(&session)
+	return session, BuildResponse(r), err
+}
+
+// RevokeImpersonation ends an impersonation request, and its session if it was started.
+func (c *Client4) RevokeImpersonation(impersonationId string) (*ImpersonationRequest, *Response, error) {
+	r, err := c.DoAPIDelete(c.impersonationRoute(impersonationId))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var impersonation *ImpersonationRequest
+	err = json.NewDecoder(r.Body).Decode(&impersonation)
+	return impersonation, BuildResponse(r), err
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"net/http"
+)
+
+// IteratePerPage is the number of items fetched by each request of the iterators of Client4.
+const IteratePerPage = 200
+
+// ErrStopIterating can be returned by the callback of an iterator of Client4 to stop the iteration
+// early. The iterator then returns nil.
+var ErrStopIterating = errors.New("stop iterating")
+
+// IterateUsers calls fn for each user of the system, fetching them page by page. The iteration
+// stops at the first error, returned by fn or by the server, and returns it.
+//
+// The pages are fetched by offset, so the users created or deleted during the iteration may be
+// skipped or visited twice.
+func (c *Client4) IterateUsers(fn func(user *User) error) error {
+	return c.iteratePages(func(page int) (int, *Response, error) {
+		users, resp, err := c.GetUsers(page, IteratePerPage, "")
+		if err != nil {
+			return 0, resp, err
+		}
+
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return 0, resp, err
+			}
+		}
+		return len(users), resp, nil
+	})
+}
+
+// IterateUsersInTeam calls fn for each user of the team, as IterateUsers does for the system.
+func (c *Client4) IterateUsersInTeam(teamId string, fn func(user *User) error) error {
+	return c.iteratePages(func(page int) (int, *Response, error) {
+		users, resp, err := c.GetUsersInTeam(teamId, page, IteratePerPage, "")
+		if err != nil {
+			return 0, resp, err
+		}
+
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return 0, resp, err
+			}
+		}
+		return len(users), resp, nil
+	})
+}
+
+// IterateAllTeams calls fn for each team the user can see, as IterateUsers does for the users.
+func (c *Client4) IterateAllTeams(fn func(team *Team) error) error {
+	return c.iteratePages(func(page int) (int, *Response, error) {
+		teams, resp, err := c.GetAllTeams("", page, IteratePerPage)
+		if err != nil {
+			return 0, resp, err
+		}
+
+		for _, team := range teams {
+			if err := fn(team); err != nil {
+				return 0, resp, err
+			}
+		}
+		return len(teams), resp, nil
+	})
+}
+
+// IteratePostsForChannel calls fn for each post of the channel, from the newest to the oldest.
+// Each page is fetched before the oldest post of the previous one, so the posts created during
+// the iteration don't shift the pages. The replies are visited along with the other posts unless
+// collapsedThreads is set.
+func (c *Client4) IteratePostsForChannel(channelId string, collapsedThreads bool, fn func(post *Post) error) error {
+	before := ""
+	return c.iteratePages(func(page int) (int, *Response, error) {
+		var list *PostList
+		var resp *Response
+		var err error
+		if before == "" {
+			list, resp, err = c.GetPostsForChannel(channelId, 0, IteratePerPage, "", collapsedThreads)
+		} else {
+			list, resp, err = c.GetPostsBefore(channelId, before, 0, IteratePerPage, "", collapsedThreads)
+		}
+		if err != nil {
+			return 0, resp, err
+		}
+
+		for _, id := range list.Order {
+			if post, ok := list.Posts[id]; ok {
+				if err := fn(post); err != nil {
+					return 0, resp, err
+				}
+			}
+		}
+
+		if len(list.Order) > 0 {
+			before = list.Order[len(list.Order)-1]
+		}
+		return len(list.Order), resp, nil
+	})
+}
+
+// iteratePages calls fetch with increasing page numbers until it returns a partial page or an
+// error. The pages failing because the server is rate limiting the client or is temporarily
+// unavailable are fetched again after a backoff, unless the client already retries its requests.
+func (c *Client4) iteratePages(fetch func(page int) (int, *Response, error)) error {
+	policy := NewClientRetryPolicy()
+	if c.HTTPClient != nil {
+		if _, ok := c.HTTPClient.Transport.(*retryTransport); ok {
+			policy.MaxRetries = 0
+		}
+	}
+
+	for page, attempt := 0, 0; ; {
+		count, resp, err := fetch(page)
+		if err != nil {
+			if errors.Is(err, ErrStopIterating) {
+				return nil
+			}
+
+			if attempt < policy.MaxRetries && c.requestContext().Err() == nil && isRetryablePageError(resp) {
+				delay, ok := serverRetryDelay(resp.Header)
+				if !ok {
+					delay = policy.backoff(attempt)
+				}
+				if delay <= policy.MaxDelay {
+					if waitErr := waitRetry(c.requestContext(), delay); waitErr != nil {
+						return waitErr
+					}
+					attempt++
+					continue
+				}
+			}
+
+			return err
+		}
+
+		if count < IteratePerPage {
+			return nil
+		}
+
+		page++
+		attempt = 0
+	}
+}
+
+// isRetryablePageError returns whether the request of a page failed because the server is rate
+// limiting the client or is temporarily unavailable.
+func isRetryablePageError(resp *Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
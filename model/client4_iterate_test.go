@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient4IterateUsers(t *testing.T) {
+	users := make([]*User, 2*IteratePerPage+5)
+	for i := range users {
+		users[i] = &User{Id: NewId()}
+	}
+
+	var unavailable int32 = 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		assert.Equal(t, IteratePerPage, perPage)
+
+		// The second page fails once.
+		if page == 1 && atomic.CompareAndSwapInt32(&unavailable, 1, 0) {
+			w.Header().Set(HeaderRetryAfter, "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		start := page * perPage
+		end := start + perPage
+		if start > len(users) {
+			start = len(users)
+		}
+		if end > len(users) {
+			end = len(users)
+		}
+		json.NewEncoder(w).Encode(users[start:end])
+	}))
+	defer server.Close()
+
+	client := NewAPIv4Client(server.URL)
+
+	t.Run("visits every user", func(t *testing.T) {
+		var ids []string
+		err := client.IterateUsers(func(user *User) error {
+			ids = append(ids, user.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, ids, len(users))
+		for i, user := range users {
+			assert.Equal(t, user.Id, ids[i])
+		}
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		count := 0
+		err := client.IterateUsers(func(user *User) error {
+			count++
+			if count == 3 {
+				return ErrStopIterating
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("returns the error of the callback", func(t *testing.T) {
+		callbackErr := NewAppError("test", "test", nil, "", http.StatusBadRequest)
+		err := client.IterateUsers(func(user *User) error {
+			return callbackErr
+		})
+		assert.Equal(t, callbackErr, err)
+	})
+}
+
+func TestClient4IteratePostsForChannel(t *testing.T) {
+	channelId := NewId()
+	// The posts are ordered from the newest to the oldest.
+	posts := make([]*Post, IteratePerPage+10)
+	for i := range posts {
+		posts[i] = &Post{Id: NewId(), ChannelId: channelId}
+	}
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "/api/v4/channels/"+channelId+"/posts", r.URL.Path)
+		assert.Equal(t, "0", r.URL.Query().Get("page"))
+
+		start := 0
+		if before := r.URL.Query().Get("before"); before != "" {
+			for i, post := range posts {
+				if post.Id == before {
+					start = i + 1
+				}
+			}
+		}
+		end := start + IteratePerPage
+		if end > len(posts) {
+			end = len(posts)
+		}
+
+		list := NewPostList()
+		for _, post := range posts[start:end] {
+			list.AddPost(post)
+			list.AddOrder(post.Id)
+		}
+		json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	client := NewAPIv4Client(server.URL)
+
+	var ids []string
+	err := client.IteratePostsForChannel(channelId, false, func(post *Post) error {
+		ids = append(ids, post.Id)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, len(posts))
+	for i, post := range posts {
+		assert.Equal(t, post.Id, ids[i])
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestClient4IterateFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"id": "api.context.permissions.app_error"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIv4Client(server.URL)
+
+	err := client.IterateAllTeams(func(team *Team) error {
+		return nil
+	})
+	var appErr *AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "api.context.permissions.app_error", appErr.Id)
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderRetryAfter         = "Retry-After"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// ClientRetryPolicy configures how a Client4 retries the requests rejected by the rate limiter of
+// the server, or failing because the server is temporarily unavailable.
+type ClientRetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// The delay before each retry grows exponentially from MinDelay, with random jitter, up to
+	// MaxDelay. The delay asked by the server through the Retry-After and X-RateLimit headers is
+	// honored instead, unless it is beyond MaxDelay, in which case the response is returned.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// RetryNonIdempotent allows retrying the POST and PATCH requests after a network error or a
+	// 502, 503 or 504 response, when the server may already have processed them. The requests
+	// rejected by the rate limiter are always retried since the server didn't process them.
+	RetryNonIdempotent bool
+}
+
+// NewClientRetryPolicy returns the default retry policy: up to 3 retries waiting at most 30
+// seconds each, and no retry of the non-idempotent requests the server may have processed.
+func NewClientRetryPolicy() *ClientRetryPolicy {
+	return &ClientRetryPolicy{
+		MaxRetries: 3,
+		MinDelay:   250 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// SetRetryPolicy makes the client retry its requests according to the given policy, or stop
+// retrying them if the policy is nil. Once the server reports that the rate limit is reached, the
+// following requests also wait for it to reset. The waits are cancelled along with the context of
+// the requests, see WithContext.
+//
+// The requests whose body can't be read again, such as the uploads of streams, aren't retried.
+func (c *Client4) SetRetryPolicy(policy *ClientRetryPolicy) {
+	var client http.Client
+	if c.HTTPClient != nil {
+		client = *c.HTTPClient
+	}
+
+	next := client.Transport
+	if transport, ok := next.(*retryTransport); ok {
+		next = transport.next
+	}
+
+	if policy == nil {
+		client.Transport = next
+	} else {
+		client.Transport = &retryTransport{
+			next:   next,
+			policy: *policy,
+		}
+	}
+
+	c.HTTPClient = &client
+}
+
+// retryTransport is the http.RoundTripper retrying the requests of a client according to its
+// retry policy.
+type retryTransport struct {
+	next   http.RoundTripper
+	policy ClientRetryPolicy
+
+	mutex sync.Mutex
+	// rateLimitedUntil is when the rate limit reached by the client resets.
+	rateLimitedUntil time.Time
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if delay := t.rateLimitDelay(); delay > 0 && delay <= t.policy.MaxDelay {
+		if err := waitRetry(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := next.RoundTrip(attemptReq)
+		if resp != nil {
+			t.updateRateLimit(resp.Header)
+		}
+
+		if attempt >= t.policy.MaxRetries || !canReplayRequest(req) {
+			return resp, err
+		}
+
+		delay, retry := t.policy.retryDelay(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			closeBody(resp)
+		}
+
+		if err := waitRetry(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		// The request passed to a RoundTripper must not be modified, so each retry sends a copy
+		// with a new reader of the body.
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+// rateLimitDelay returns how long to wait for the rate limit reached by the client to reset.
+func (t *retryTransport) rateLimitDelay() time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return time.Until(t.rateLimitedUntil)
+}
+
+// updateRateLimit records when the rate limit resets if the response reports that the client
+// reached it.
+func (t *retryTransport) updateRateLimit(header http.Header) {
+	if header.Get(HeaderRateLimitRemaining) != "0" {
+		return
+	}
+
+	reset, err := strconv.Atoi(header.Get(HeaderRateLimitReset))
+	if err != nil || reset <= 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.rateLimitedUntil = time.Now().Add(time.Duration(reset) * time.Second)
+}
+
+// retryDelay returns whether the request should be retried given the outcome of its last attempt,
+// and how long to wait before doing so.
+func (p *ClientRetryPolicy) retryDelay(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		if req.Context().Err() != nil || !p.canRetryUnprocessed(req.Method) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !p.canRetryUnprocessed(req.Method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	delay, ok := serverRetryDelay(resp.Header)
+	if !ok {
+		return p.backoff(attempt), true
+	}
+	if delay > p.MaxDelay {
+		return 0, false
+	}
+	return delay, true
+}
+
+// canRetryUnprocessed returns whether a request that the server may have processed can be retried.
+func (p *ClientRetryPolicy) canRetryUnprocessed(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch:
+		return p.RetryNonIdempotent
+	default:
+		return true
+	}
+}
+
+// backoff returns the delay before the given retry when the server didn't ask for one: it doubles
+// with each retry, and is picked at random in its upper half so that clients failing together
+// don't retry together.
+func (p *ClientRetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MaxDelay
+	if attempt < 32 {
+		if d := p.MinDelay << uint(attempt); d > 0 && d < p.MaxDelay {
+			delay = d
+		}
+	}
+
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+// serverRetryDelay returns the delay asked by the server before retrying, from the Retry-After
+// header in seconds or as a date, or from the X-RateLimit headers.
+func serverRetryDelay(header http.Header) (time.Duration, bool) {
+	if retryAfter := header.Get(HeaderRetryAfter); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			if delay := time.Until(date); delay > 0 {
+				return delay, true
+			}
+			return 0, true
+		}
+	}
+
+	if header.Get(HeaderRateLimitRemaining) == "0" {
+		if reset, err := strconv.Atoi(header.Get(HeaderRateLimitReset)); err == nil && reset >= 0 {
+			return time.Duration(reset) * time.Second, true
+		}
+	}
+
+	return 0, false
+}
+
+// canReplayRequest returns whether the body of the request, if any, can be sent again.
+func canReplayRequest(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// waitRetry waits for the given delay, unless the context is done first.
+func waitRetry(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryTestClient(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, attempt int32)) (*Client4, *int32) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, atomic.AddInt32(&attempts, 1))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewAPIv4Client(server.URL)
+	client.SetRetryPolicy(&ClientRetryPolicy{
+		MaxRetries: 3,
+		MinDelay:   time.Millisecond,
+		MaxDelay:   time.Second,
+	})
+
+	return client, &attempts
+}
+
+func TestClient4Retry(t *testing.T) {
+	t.Run("retries until the server is available", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			if attempt < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"status": "OK"}`))
+		})
+
+		status, resp, err := client.GetPing()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "OK", status)
+		assert.Equal(t, int32(3), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("gives up after the maximum number of retries", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, resp, err := client.GetPing()
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, int32(4), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("doesn't retry client errors", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		_, resp, err := client.GetPing()
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("doesn't retry a non-idempotent request the server may have processed", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		_, err := client.DoAPIPost("/posts", `{"message": "hello"}`)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("retries a rate limited non-idempotent request with its body", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"message": "hello"}`, string(body))
+
+			if attempt == 1 {
+				w.Header().Set(HeaderRetryAfter, "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		resp, err := client.DoAPIPost("/posts", `{"message": "hello"}`)
+		require.NoError(t, err)
+		closeBody(resp)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("returns the response when the server asks to wait beyond the maximum delay", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			w.Header().Set(HeaderRateLimitRemaining, "0")
+			w.Header().Set(HeaderRateLimitReset, "60")
+			w.Header().Set(HeaderRetryAfter, "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		_, resp, err := client.GetPing()
+		require.Error(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "60", resp.Header.Get(HeaderRetryAfter))
+		assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			w.Header().Set(HeaderRetryAfter, "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, _, err := client.WithContext(ctx).GetPing()
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		client, attempts := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request, attempt int32) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		client.SetRetryPolicy(nil)
+
+		_, _, err := client.GetPing()
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+}
+
+func TestServerRetryDelay(t *testing.T) {
+	header := http.Header{}
+	_, ok := serverRetryDelay(header)
+	assert.False(t, ok)
+
+	header.Set(HeaderRateLimitRemaining, "0")
+	header.Set(HeaderRateLimitReset, "3")
+	delay, ok := serverRetryDelay(header)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	header.Set(HeaderRetryAfter, "5")
+	delay, ok = serverRetryDelay(header)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	header.Set(HeaderRetryAfter, time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	delay, ok = serverRetryDelay(header)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+}
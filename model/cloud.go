@@ -3,7 +3,10 @@
 
 package model
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 const (
 	EventTypeFailedPayment                = "failed-payment"
@@ -152,6 +155,59 @@ type Invoice struct {
 	CurrentProductName string             `json:"current_product_name"`
 }
 
+const (
+	InvoiceStatusPaid = "paid"
+	InvoiceStatusVoid = "void"
+)
+
+// IsFinal returns true if the invoice can no longer change, that is once it was paid or voided.
+func (i *Invoice) IsFinal() bool {
+	return i.Status == InvoiceStatusPaid || i.Status == InvoiceStatusVoid
+}
+
+// InvoiceListOptions filters and paginates the invoices of a subscription. Since and Until
+// are inclusive bounds on the invoice creation time and are ignored when zero. Invoices are only
+// paginated when PerPage is set.
+type InvoiceListOptions struct {
+	Page    int
+	PerPage int
+	Since   int64
+	Until   int64
+}
+
+// FilterInvoices returns the invoices matching the options. When paginated, the page is taken
+// from the invoices sorted newest first; otherwise they are all returned in their original order.
+func FilterInvoices(invoices []*Invoice, opts *InvoiceListOptions) []*Invoice {
+	filtered := []*Invoice{}
+	for _, invoice := range invoices {
+		if opts.Since > 0 && invoice.CreateAt < opts.Since {
+			continue
+		}
+		if opts.Until > 0 && invoice.CreateAt > opts.Until {
+			continue
+		}
+		filtered = append(filtered, invoice)
+	}
+
+	if opts.PerPage <= 0 {
+		return filtered
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].CreateAt > filtered[j].CreateAt
+	})
+
+	start := opts.Page * opts.PerPage
+	if start >= len(filtered) {
+		return []*Invoice{}
+	}
+	end := start + opts.PerPage
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+	return filtered[start:end]
+}
+
 // InvoiceLineItem model represents a cloud invoice lineitem tied to an invoice.
 type InvoiceLineItem struct {
 	PriceID      string                 `json:"price_id"`
@@ -186,6 +242,18 @@ type SubscriptionChange struct {
 	ProductID string `json:"product_id"`
 }
 
+// SubscriptionChangePreview is the prorated cost of changing the number of seats of a
+// subscription, as calculated by the billing backend before the change is confirmed.
+type SubscriptionChangePreview struct {
+	SubscriptionID string             `json:"subscription_id"`
+	CurrentSeats   int                `json:"current_seats"`
+	Seats          int                `json:"seats"`
+	ProratedTotal  int64              `json:"prorated_total"`
+	EffectiveAt    int64              `json:"effective_at"`
+	PeriodEnd      int64              `json:"period_end"`
+	Items          []*InvoiceLineItem `json:"line_items"`
+}
+
 type BoardsLimits struct {
 	Cards *int `json:"cards"`
 	Views *int `json:"views"`
@@ -214,3 +282,48 @@ type ProductLimits struct {
 	Messages     *MessagesLimits     `json:"messages,omitempty"`
 	Teams        *TeamsLimits        `json:"teams,omitempty"`
 }
+
+// Limit returns the limit of a usage counter, such as UsageCounterBoardsCards, or nil if the
+// counter is not limited.
+func (l *ProductLimits) Limit(counter string) *int64 {
+	if l == nil {
+		return nil
+	}
+
+	fromInt := func(limit *int) *int64 {
+		if limit == nil {
+			return nil
+		}
+		value := int64(*limit)
+		return &value
+	}
+
+	switch counter {
+	case UsageCounterBoardsCards:
+		if l.Boards != nil {
+			return fromInt(l.Boards.Cards)
+		}
+	case UsageCounterBoardsViews:
+		if l.Boards != nil {
+			return fromInt(l.Boards.Views)
+		}
+	case UsageCounterFilesTotalStorage:
+		if l.Files != nil {
+			return l.Files.TotalStorage
+		}
+	case UsageCounterIntegrationsEnabled:
+		if l.Integrations != nil {
+			return fromInt(l.Integrations.Enabled)
+		}
+	case UsageCounterMessagesHistory:
+		if l.Messages != nil {
+			return fromInt(l.Messages.History)
+		}
+	case UsageCounterTeamsActive:
+		if l.Teams != nil {
+			return fromInt(l.Teams.Active)
+		}
+	}
+
+	return nil
+}
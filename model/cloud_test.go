@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterInvoices(t *testing.T) {
+	invoices := []*Invoice{
+		{ID: "in_1", CreateAt: 1000},
+		{ID: "in_3", CreateAt: 3000},
+		{ID: "in_2", CreateAt: 2000},
+		{ID: "in_4", CreateAt: 4000},
+	}
+
+	ids := func(invoices []*Invoice) []string {
+		result := []string{}
+		for _, invoice := range invoices {
+			result = append(result, invoice.ID)
+		}
+		return result
+	}
+
+	t.Run("no options returns all invoices in their original order", func(t *testing.T) {
+		assert.Equal(t, []string{"in_1", "in_3", "in_2", "in_4"}, ids(FilterInvoices(invoices, &InvoiceListOptions{})))
+	})
+
+	t.Run("date range is inclusive", func(t *testing.T) {
+		assert.Equal(t, []string{"in_3", "in_2"}, ids(FilterInvoices(invoices, &InvoiceListOptions{Since: 2000, Until: 3000})))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		assert.Equal(t, []string{"in_4", "in_3"}, ids(FilterInvoices(invoices, &InvoiceListOptions{Page: 0, PerPage: 2})))
+		assert.Equal(t, []string{"in_2", "in_1"}, ids(FilterInvoices(invoices, &InvoiceListOptions{Page: 1, PerPage: 2})))
+		assert.Empty(t, FilterInvoices(invoices, &InvoiceListOptions{Page: 2, PerPage: 2}))
+	})
+
+	t.Run("pagination after filtering", func(t *testing.T) {
+		assert.Equal(t, []string{"in_3"}, ids(FilterInvoices(invoices, &InvoiceListOptions{Page: 1, PerPage: 1, Until: 3000})))
+	})
+}
+
+func TestInvoiceIsFinal(t *testing.T) {
+	assert.True(t, (&Invoice{Status: InvoiceStatusPaid}).IsFinal())
+	assert.True(t, (&Invoice{Status: InvoiceStatusVoid}).IsFinal())
+	assert.False(t, (&Invoice{Status: "open"}).IsFinal())
+	assert.False(t, (&Invoice{Status: "draft"}).IsFinal())
+}
+
+func TestProductLimitsLimit(t *testing.T) {
+	var noLimits *ProductLimits
+	assert.Nil(t, noLimits.Limit(UsageCounterBoardsCards))
+
+	limits := &ProductLimits{
+		Boards: &BoardsLimits{Cards: NewInt(500)},
+		Files:  &FilesLimits{TotalStorage: NewInt64(10)},
+		Teams:  &TeamsLimits{},
+	}
+	assert.Equal(t, int64(500), *limits.Limit(UsageCounterBoardsCards))
+	assert.Nil(t, limits.Limit(UsageCounterBoardsViews))
+	assert.Equal(t, int64(10), *limits.Limit(UsageCounterFilesTotalStorage))
+	assert.Nil(t, limits.Limit(UsageCounterTeamsActive))
+	assert.Nil(t, limits.Limit(UsageCounterMessagesHistory))
+	assert.Nil(t, limits.Limit("unknown"))
+}
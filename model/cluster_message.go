@@ -38,6 +38,7 @@ const (
 	ClusterEventRemovePlugin                                ClusterEvent = "remove_plugin"
 	ClusterEventPluginEvent                                 ClusterEvent = "plugin_event"
 	ClusterEventInvalidateCacheForTermsOfService            ClusterEvent = "inv_terms_of_service"
+	ClusterEventInvalidateCacheForPostPropSchemas           ClusterEvent = "inv_post_prop_schemas"
 	ClusterEventBusyStateChanged                            ClusterEvent = "busy_state_change"
 
 	// Gossip communication
@@ -39,6 +39,17 @@ type Command struct {
 	AutocompleteData *AutocompleteData `db:"-" json:"autocomplete_data,omitempty"`
 	// AutocompleteIconData is a base64 encoded svg
 	AutocompleteIconData string `db:"-" json:"autocomplete_icon_data,omitempty"`
+	// PayloadFormat is the format of the requests sent to the URL and of their responses, either
+	// PayloadFormatMattermost or PayloadFormatSlack. Requests in the Slack format are always POSTs.
+	PayloadFormat string `json:"payload_format"`
+	// OutgoingOAuthConnectionId is the outgoing OAuth connection whose access tokens are sent to
+	// the URL as bearer tokens, in place of the command token, if any.
+	OutgoingOAuthConnectionId string `json:"outgoing_oauth_connection_id"`
+
+	// SigningSecret is the secret the requests sent to the URL are signed with, as described by
+	// IntegrationRequestSignature. The commands created before it was introduced have none until
+	// it is regenerated, and their requests aren't signed.
+	SigningSecret string `json:"signing_secret"`
 }
 
 func (o *Command) IsValid() *AppError {
@@ -100,6 +111,18 @@ func (o *Command) IsValid() *AppError {
 		return NewAppError("Command.IsValid", "model.command.is_valid.description.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if !IsValidPayloadFormat(o.PayloadFormat) {
+		return NewAppError("Command.IsValid", "model.command.is_valid.payload_format.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.OutgoingOAuthConnectionId != "" && !IsValidId(o.OutgoingOAuthConnectionId) {
+		return NewAppError("Command.IsValid", "model.command.is_valid.outgoing_oauth_connection_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.SigningSecret != "" && len(o.SigningSecret) != 26 {
+		return NewAppError("Command.IsValid", "model.command.is_valid.signing_secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if o.AutocompleteData != nil {
 		if err := o.AutocompleteData.IsValid(); err != nil {
 			return NewAppError("Command.IsValid", "model.command.is_valid.autocomplete_data.app_error", nil, err.Error(), http.StatusBadRequest)
@@ -118,6 +141,10 @@ func (o *Command) PreSave() {
 		o.Token = NewId()
 	}
 
+	if o.SigningSecret == "" {
+		o.SigningSecret = NewId()
+	}
+
 	o.CreateAt = GetMillis()
 	o.UpdateAt = o.CreateAt
 }
@@ -128,6 +155,7 @@ func (o *Command) PreUpdate() {
 
 func (o *Command) Sanitize() {
 	o.Token = ""
+	o.SigningSecret = ""
 	o.CreatorId = ""
 	o.Method = ""
 	o.URL = ""
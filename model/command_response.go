@@ -30,6 +30,9 @@ type CommandResponse struct {
 	SkipSlackParsing bool               `json:"skip_slack_parsing"` // Set to `true` to skip the Slack-compatibility handling of Text.
 	Attachments      []*SlackAttachment `json:"attachments"`
 	ExtraResponses   []*CommandResponse `json:"extra_responses"`
+
+	// Blocks are the Slack Block Kit blocks of a response to a command using the Slack payload format.
+	Blocks []*SlackBlock `json:"blocks,omitempty"`
 }
 
 func CommandResponseFromHTTPBody(contentType string, body io.Reader) (*CommandResponse, error) {
@@ -42,6 +45,13 @@ func CommandResponseFromHTTPBody(contentType string, body io.Reader) (*CommandRe
 	return nil, nil
 }
 
+// TranslateSlackFormat translates a response to a command using the Slack payload format: the
+// blocks, if any, replace the text, and Slack mrkdwn is converted to Markdown.
+func (o *CommandResponse) TranslateSlackFormat() {
+	o.Text = slackMessageText(o.Text, o.Blocks)
+	o.Blocks = nil
+}
+
 func CommandResponseFromPlainText(text string) *CommandResponse {
 	return &CommandResponse{
 		Text: text,
@@ -0,0 +1,25 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// The origins of the values of the configuration.
+const (
+	// ConfigOriginDefault is the origin of the values left to their default.
+	ConfigOriginDefault = "default"
+	// ConfigOriginEnvironment is the origin of the values set by an environment variable, which
+	// overrides the stored value.
+	ConfigOriginEnvironment = "environment"
+	// ConfigOriginFile, ConfigOriginDatabase and ConfigOriginMemory are the origins of the values
+	// stored in the backing store of the configuration.
+	ConfigOriginFile     = "file"
+	ConfigOriginDatabase = "database"
+	ConfigOriginMemory   = "memory"
+)
+
+// ConfigWithOrigins is the configuration along with the origin of each of its values. Origins
+// mirrors the structure of the configuration, with the origin of a value at each leaf.
+type ConfigWithOrigins struct {
+	Config  *Config                `json:"config"`
+	Origins map[string]interface{} `json:"origins"`
+}
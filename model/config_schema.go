@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// The types of the settings in the configuration schema.
+const (
+	ConfigSchemaTypeString  = "string"
+	ConfigSchemaTypeBoolean = "boolean"
+	ConfigSchemaTypeInteger = "integer"
+	ConfigSchemaTypeNumber  = "number"
+	ConfigSchemaTypeArray   = "array"
+	ConfigSchemaTypeObject  = "object"
+)
+
+// Besides the access tag, the settings of the configuration may be tagged with:
+//   - restart:"true" if a change to the setting only takes effect once the server restarts.
+//   - enum:"a,b" if the setting only accepts the listed values.
+const (
+	ConfigRestartTagType = "restart"
+	ConfigEnumTagType    = "enum"
+)
+
+// ConfigSchemaSetting describes a setting of the configuration, generated from the Config
+// struct and its tags.
+type ConfigSchemaSetting struct {
+	// Path is the path to the setting, e.g. ServiceSettings.SiteURL.
+	Path string `json:"path"`
+	Type string `json:"type"`
+	// ItemType is the type of the items of an array.
+	ItemType string `json:"item_type,omitempty"`
+	// Default is the default value of the setting. It is left out for sensitive settings, whose
+	// default may be generated.
+	Default         interface{} `json:"default,omitempty"`
+	EnumValues      []string    `json:"enum_values,omitempty"`
+	Sensitive       bool        `json:"sensitive"`
+	RestartRequired bool        `json:"restart_required"`
+	// Access lists the values of the access tag of the setting.
+	Access []string `json:"access,omitempty"`
+}
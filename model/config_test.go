@@ -121,6 +121,38 @@ func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	require.False(t, *c1.FileSettings.AmazonS3SSE)
 }
 
+func TestLogSettingsIsValidDiagnosticsSink(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Nil(t, c1.LogSettings.isValid())
+
+	c1.LogSettings.DiagnosticsSinkURL = NewString("https://analytics.example.com")
+	require.Nil(t, c1.LogSettings.isValid())
+
+	c1.LogSettings.DiagnosticsSinkURL = NewString("analytics.example.com")
+	appErr := c1.LogSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.diagnostics_sink_url.app_error", appErr.Id)
+}
+
+func TestFileSettingsIsValidContentExtraction(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	c1.FileSettings.ExtractContentTikaURL = NewString("http://localhost:9998")
+	require.Nil(t, c1.FileSettings.isValid())
+
+	c1.FileSettings.ExtractContentTikaURL = NewString("localhost:9998")
+	appErr := c1.FileSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.extract_content_tika_url.app_error", appErr.Id)
+
+	c1.FileSettings.ExtractContentTikaURL = NewString("")
+	c1.FileSettings.ExtractContentMaxSize = NewInt64(0)
+	appErr = c1.FileSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.extract_content_max_size.app_error", appErr.Id)
+}
+
 func TestConfigDefaultSignatureAlgorithm(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -419,6 +451,83 @@ func TestTeamSettingsIsValidSiteNameEmpty(t *testing.T) {
 	require.Nil(t, c1.TeamSettings.isValid())
 }
 
+func TestSqlSettingsIsValidTablePartitioning(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	c1.SqlSettings.EnableTablePartitioning = NewBool(true)
+	require.Nil(t, c1.SqlSettings.isValid())
+
+	c1.SqlSettings.DriverName = NewString(DatabaseDriverMysql)
+	appErr := c1.SqlSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.sql_partitioning_driver.app_error", appErr.Id)
+
+	c1.SqlSettings.DriverName = NewString(DatabaseDriverPostgres)
+	c1.SqlSettings.PartitionsAheadMonths = NewInt(0)
+	appErr = c1.SqlSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.sql_partitions_ahead_months.app_error", appErr.Id)
+
+	c1.SqlSettings.PartitionsAheadMonths = NewInt(SqlSettingsDefaultPartitionsAheadMonths)
+	c1.SqlSettings.PartitionRetentionMonths = NewInt(-1)
+	appErr = c1.SqlSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.sql_partition_retention_months.app_error", appErr.Id)
+}
+
+func TestSqlSettingsIsValidPostArchiving(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	c1.SqlSettings.EnablePostArchiving = NewBool(true)
+	require.Nil(t, c1.SqlSettings.isValid())
+
+	c1.SqlSettings.PostArchiveAfterMonths = NewInt(0)
+	appErr := c1.SqlSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.sql_post_archive_after_months.app_error", appErr.Id)
+}
+
+func TestElasticsearchSettingsIsValidIndexLifecycle(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	c1.ElasticsearchSettings.EnableIndexLifecycleManagement = NewBool(true)
+	require.Nil(t, c1.ElasticsearchSettings.isValid())
+
+	for name, tc := range map[string]struct {
+		update   func(s *ElasticsearchSettings)
+		expected string
+	}{
+		"rollover max age": {
+			func(s *ElasticsearchSettings) { s.RolloverMaxAgeDays = NewInt(0) },
+			"model.config.is_valid.elastic_search.rollover_max_age_days.app_error",
+		},
+		"rollover max size": {
+			func(s *ElasticsearchSettings) { s.RolloverMaxSizeGB = NewInt(0) },
+			"model.config.is_valid.elastic_search.rollover_max_size_gb.app_error",
+		},
+		"warm phase": {
+			func(s *ElasticsearchSettings) { s.WarmPhaseAfterDays = NewInt(0) },
+			"model.config.is_valid.elastic_search.warm_phase_after_days.app_error",
+		},
+		"cold phase before warm phase": {
+			func(s *ElasticsearchSettings) { s.ColdPhaseAfterDays = NewInt(*s.WarmPhaseAfterDays) },
+			"model.config.is_valid.elastic_search.cold_phase_after_days.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+			tc.update(&c.ElasticsearchSettings)
+			require.Nil(t, c.ElasticsearchSettings.isValid(), "the settings are only validated when the index lifecycle management is enabled")
+
+			c.ElasticsearchSettings.EnableIndexLifecycleManagement = NewBool(true)
+			appErr := c.ElasticsearchSettings.isValid()
+			require.NotNil(t, appErr)
+			require.Equal(t, tc.expected, appErr.Id)
+		})
+	}
+}
+
 func TestMessageExportSettingsIsValidEnableExportNotSet(t *testing.T) {
 	mes := &MessageExportSettings{}
 
@@ -1296,6 +1405,7 @@ func TestConfigSanitize(t *testing.T) {
 	*c.EmailSettings.SMTPPassword = "baz"
 	*c.GitLabSettings.Secret = "bingo"
 	*c.OpenIdSettings.Secret = "secret"
+	*c.LogSettings.DiagnosticsSinkKey = "sinkkey"
 	c.SqlSettings.DataSourceReplicas = []string{"stuff"}
 	c.SqlSettings.DataSourceSearchReplicas = []string{"stuff"}
 
@@ -1310,6 +1420,7 @@ func TestConfigSanitize(t *testing.T) {
 	assert.Equal(t, FakeSetting, *c.SqlSettings.DataSource)
 	assert.Equal(t, FakeSetting, *c.SqlSettings.AtRestEncryptKey)
 	assert.Equal(t, FakeSetting, *c.ElasticsearchSettings.Password)
+	assert.Equal(t, FakeSetting, *c.LogSettings.DiagnosticsSinkKey)
 	assert.Equal(t, FakeSetting, c.SqlSettings.DataSourceReplicas[0])
 	assert.Equal(t, FakeSetting, c.SqlSettings.DataSourceSearchReplicas[0])
 }
@@ -1504,6 +1615,153 @@ func TestConfigServiceSettingsIsValid(t *testing.T) {
 	require.Equal(t, "model.config.is_valid.collapsed_threads.app_error", err.Id)
 }
 
+func TestConfigServiceSettingsIsValidLocalModeSocketPermissions(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+
+	perm, err := cfg.ServiceSettings.GetLocalModeSocketPermissions()
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), perm)
+
+	for _, valid := range []string{"0600", "0660", "0770", "700"} {
+		*cfg.ServiceSettings.LocalModeSocketPermissions = valid
+		require.Nil(t, cfg.ServiceSettings.isValid(), valid)
+	}
+
+	for _, invalid := range []string{"", "rw-------", "0666", "0604", "0060", "0400", "1600"} {
+		*cfg.ServiceSettings.LocalModeSocketPermissions = invalid
+		appErr := cfg.ServiceSettings.isValid()
+		require.NotNil(t, appErr, invalid)
+		require.Equal(t, "model.config.is_valid.local_mode_socket_permissions.app_error", appErr.Id)
+	}
+}
+
+func TestConfigServiceSettingsIsValidHTTP3(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	*cfg.ServiceSettings.EnableHTTP3 = true
+
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.http3_requires_tls.app_error", err.Id)
+
+	*cfg.ServiceSettings.ConnectionSecurity = ConnSecurityTLS
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.HTTP3ListenAddress = ":8443"
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.HTTP3ListenAddress = "8443"
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.http3_listen_address.app_error", err.Id)
+
+	*cfg.ServiceSettings.HTTP3ListenAddress = ""
+	*cfg.ServiceSettings.HTTP3AltSvcMaxAge = 0
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.http3_alt_svc_max_age.app_error", err.Id)
+}
+
+func TestConfigServiceSettingsIsValidResourceGuard(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	*cfg.ServiceSettings.EnableResourceGuard = true
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.ResourceGuardMemoryLimitMB = 0
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.resource_guard_memory_limit.app_error", err.Id)
+
+	*cfg.ServiceSettings.ResourceGuardMemoryLimitMB = ServiceSettingsDefaultResourceGuardMemoryLimitMB
+	*cfg.ServiceSettings.ResourceGuardShedThresholdPercent = 101
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.resource_guard_shed_threshold.app_error", err.Id)
+
+	*cfg.ServiceSettings.ResourceGuardShedThresholdPercent = ServiceSettingsDefaultResourceGuardShedThresholdPercent
+	*cfg.ServiceSettings.ResourceGuardSampleRate = 0
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.resource_guard_sample_rate.app_error", err.Id)
+}
+
+func TestConfigServiceSettingsIsValidStartupDependencies(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.Equal(t, StartupDependencyModeDisabled, *cfg.ServiceSettings.StartupDependencyMode)
+	*cfg.ServiceSettings.StartupDependencyMode = StartupDependencyModeDegraded
+	require.Nil(t, cfg.ServiceSettings.isValid())
+
+	*cfg.ServiceSettings.StartupDependencyMode = "lenient"
+	err := cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.startup_dependency_mode.app_error", err.Id)
+
+	*cfg.ServiceSettings.StartupDependencyMode = StartupDependencyModeStrict
+	*cfg.ServiceSettings.StartupDependencyTimeoutSeconds = 0
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.startup_dependency_timeout.app_error", err.Id)
+
+	*cfg.ServiceSettings.StartupDependencyTimeoutSeconds = ServiceSettingsDefaultStartupDependencyTimeoutSeconds
+	*cfg.ServiceSettings.DegradedModeRecheckSeconds = 0
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.degraded_mode_recheck.app_error", err.Id)
+
+	*cfg.ServiceSettings.DegradedModeRecheckSeconds = ServiceSettingsDefaultDegradedModeRecheckSeconds
+	*cfg.ServiceSettings.ReadinessRequiredDependencies = "database, filestore"
+	require.Nil(t, cfg.ServiceSettings.isValid())
+	require.Equal(t, []string{DependencyDatabase, DependencyFileStore}, cfg.ServiceSettings.GetReadinessRequiredDependencies())
+
+	*cfg.ServiceSettings.ReadinessRequiredDependencies = "database,cache"
+	err = cfg.ServiceSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.readiness_required_dependencies.app_error", err.Id)
+}
+
+func TestConfigExperimentalAuditSettingsIsValid(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.Equal(t, GetDefaultSensitiveRoutePatterns(), cfg.ExperimentalAuditSettings.SensitiveRoutePatterns)
+	require.Nil(t, cfg.ExperimentalAuditSettings.isValid())
+
+	cfg.ExperimentalAuditSettings.SensitiveRoutePatterns = []string{"config", "plugins/[a-z"}
+	err := cfg.ExperimentalAuditSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.audit.sensitive_route_patterns.app_error", err.Id)
+
+	cfg.ExperimentalAuditSettings.SensitiveRoutePatterns = []string{}
+	require.Nil(t, cfg.ExperimentalAuditSettings.isValid())
+}
+
+func TestConfigLocalizationSettingsIsValid(t *testing.T) {
+	cfg := Config{}
+	cfg.SetDefaults()
+	require.Empty(t, cfg.LocalizationSettings.NotificationTemplateOverrides)
+	require.Nil(t, cfg.LocalizationSettings.isValid())
+
+	override := &NotificationTemplateOverride{
+		Locale:        "en",
+		TranslationId: "app.notification.subject.direct.full",
+		Translation:   map[string]string{"other": "[{{.SiteName}}] New message from {{.SenderDisplayName}}"},
+	}
+	cfg.LocalizationSettings.NotificationTemplateOverrides = []*NotificationTemplateOverride{override}
+	require.Nil(t, cfg.LocalizationSettings.isValid())
+
+	cfg.LocalizationSettings.NotificationTemplateOverrides = []*NotificationTemplateOverride{override, override}
+	err := cfg.LocalizationSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.localization.duplicate_override.app_error", err.Id)
+
+	cfg.LocalizationSettings.NotificationTemplateOverrides = []*NotificationTemplateOverride{{Locale: "en", TranslationId: "app.notification.subject.direct.full"}}
+	err = cfg.LocalizationSettings.isValid()
+	require.NotNil(t, err)
+	require.Equal(t, "model.config.is_valid.localization.notification_template_override.app_error", err.Id)
+}
+
 func TestConfigDefaultCallsPluginState(t *testing.T) {
 	t.Run("should not enable Calls plugin by default when not in Cloud", func(t *testing.T) {
 		c1 := Config{}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	ConnectivityTestServiceSMTP          = "smtp"
+	ConnectivityTestServiceS3            = "s3"
+	ConnectivityTestServiceElasticsearch = "elasticsearch"
+
+	// ConnectivityTestRetentionDays is how long connection test results are kept for diagnostics.
+	ConnectivityTestRetentionDays = 90
+
+	connectivityTestErrorMaxLength = 1024
+)
+
+// ConnectivityTestResult records the outcome of a system console "test connection" request made
+// against an external service.
+type ConnectivityTestResult struct {
+	Id        string `json:"id"`
+	Service   string `json:"service"`
+	UserId    string `json:"user_id"`
+	CreateAt  int64  `json:"create_at"`
+	LatencyMs int64  `json:"latency_ms"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+func IsValidConnectivityTestService(service string) bool {
+	switch service {
+	case ConnectivityTestServiceSMTP, ConnectivityTestServiceS3, ConnectivityTestServiceElasticsearch:
+		return true
+	}
+	return false
+}
+
+func (r *ConnectivityTestResult) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+
+	if len(r.Error) > connectivityTestErrorMaxLength {
+		r.Error = r.Error[:connectivityTestErrorMaxLength]
+	}
+}
+
+func (r *ConnectivityTestResult) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("ConnectivityTestResult.IsValid", "model.connectivity_test_result.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidConnectivityTestService(r.Service) {
+		return NewAppError("ConnectivityTestResult.IsValid", "model.connectivity_test_result.is_valid.service.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("ConnectivityTestResult.IsValid", "model.connectivity_test_result.is_valid.user_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 {
+		return NewAppError("ConnectivityTestResult.IsValid", "model.connectivity_test_result.is_valid.create_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.LatencyMs < 0 {
+		return NewAppError("ConnectivityTestResult.IsValid", "model.connectivity_test_result.is_valid.latency.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
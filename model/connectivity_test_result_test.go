@@ -0,0 +1,44 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectivityTestResultIsValid(t *testing.T) {
+	r := &ConnectivityTestResult{
+		Service: ConnectivityTestServiceSMTP,
+		UserId:  NewId(),
+	}
+	r.PreSave()
+	require.Nil(t, r.IsValid())
+
+	r.Service = "ftp"
+	require.NotNil(t, r.IsValid())
+
+	r.Service = ConnectivityTestServiceS3
+	r.UserId = "junk"
+	require.NotNil(t, r.IsValid())
+
+	r.UserId = NewId()
+	r.LatencyMs = -1
+	require.NotNil(t, r.IsValid())
+
+	r.LatencyMs = 10
+	r.Id = ""
+	require.NotNil(t, r.IsValid())
+}
+
+func TestConnectivityTestResultPreSave(t *testing.T) {
+	r := &ConnectivityTestResult{Error: strings.Repeat("a", connectivityTestErrorMaxLength+10)}
+	r.PreSave()
+	assert.NotEmpty(t, r.Id)
+	assert.NotZero(t, r.CreateAt)
+	assert.Len(t, r.Error, connectivityTestErrorMaxLength)
+}
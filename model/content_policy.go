@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	// Posts matching a rule with the block action are rejected, those matching a rule with the
+	// flag action are posted and reported to the moderators.
+	ContentPolicyActionBlock = "block"
+	ContentPolicyActionFlag  = "flag"
+
+	ContentPolicyPresetAWSAccessKey = "aws_access_key"
+	ContentPolicyPresetPrivateKey   = "private_key"
+	ContentPolicyPresetGitHubToken  = "github_token"
+	ContentPolicyPresetSlackToken   = "slack_token"
+	ContentPolicyPresetGoogleAPIKey = "google_api_key"
+)
+
+// ContentPolicyPresets are the built-in patterns rules can use, mostly for secrets which should
+// not be shared in posts.
+var ContentPolicyPresets = map[string]string{
+	ContentPolicyPresetAWSAccessKey: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	ContentPolicyPresetPrivateKey:   `-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----`,
+	ContentPolicyPresetGitHubToken:  `\bgh[pousr]_[A-Za-z0-9]{36}\b`,
+	ContentPolicyPresetSlackToken:   `\bxox[abprs]-[0-9A-Za-z-]{10,}`,
+	ContentPolicyPresetGoogleAPIKey: `\bAIza[0-9A-Za-z_-]{35}\b`,
+}
+
+// ContentPolicyRule is a rule of the content policy. A post matches the rule if it contains one
+// of its keywords, compared case-insensitively as whole words, or matches its pattern or preset.
+type ContentPolicyRule struct {
+	Id          string   `json:"id"`
+	Description string   `json:"description"`
+	Keywords    []string `json:"keywords"`
+	Pattern     string   `json:"pattern"`
+	Preset      string   `json:"preset"`
+	Action      string   `json:"action"`
+	// TeamIds restricts the rule to the channels of these teams. Rules without teams apply to
+	// every channel, including direct and group messages.
+	TeamIds []string `json:"team_ids"`
+}
+
+// Expression returns the regular expression matching the posts the rule applies to.
+func (r *ContentPolicyRule) Expression() string {
+	var parts []string
+
+	var keywords []string
+	for _, keyword := range r.Keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, regexp.QuoteMeta(keyword))
+		}
+	}
+	if len(keywords) > 0 {
+		parts = append(parts, `(?i:\b(?:`+strings.Join(keywords, "|")+`)\b)`)
+	}
+
+	if r.Pattern != "" {
+		parts = append(parts, "(?:"+r.Pattern+")")
+	}
+
+	if preset, ok := ContentPolicyPresets[r.Preset]; ok {
+		parts = append(parts, "(?:"+preset+")")
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// AppliesToTeam returns true if the rule applies to the channels of the team, or to direct and
+// group messages if teamID is empty.
+func (r *ContentPolicyRule) AppliesToTeam(teamID string) bool {
+	if len(r.TeamIds) == 0 {
+		return true
+	}
+
+	for _, id := range r.TeamIds {
+		if id == teamID {
+			return teamID != ""
+		}
+	}
+
+	return false
+}
+
+func (r *ContentPolicyRule) IsValid() *AppError {
+	if r.Id == "" {
+		return NewAppError("ContentPolicyRule.IsValid", "model.content_policy_rule.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.Action != ContentPolicyActionBlock && r.Action != ContentPolicyActionFlag {
+		return NewAppError("ContentPolicyRule.IsValid", "model.content_policy_rule.is_valid.action.app_error", map[string]interface{}{"Id": r.Id}, "", http.StatusBadRequest)
+	}
+
+	if _, ok := ContentPolicyPresets[r.Preset]; r.Preset != "" && !ok {
+		return NewAppError("ContentPolicyRule.IsValid", "model.content_policy_rule.is_valid.preset.app_error", map[string]interface{}{"Id": r.Id}, "preset="+r.Preset, http.StatusBadRequest)
+	}
+
+	expression := r.Expression()
+	if expression == "" {
+		return NewAppError("ContentPolicyRule.IsValid", "model.content_policy_rule.is_valid.empty.app_error", map[string]interface{}{"Id": r.Id}, "", http.StatusBadRequest)
+	}
+
+	if _, err := regexp.Compile(expression); err != nil {
+		return NewAppError("ContentPolicyRule.IsValid", "model.content_policy_rule.is_valid.pattern.app_error", map[string]interface{}{"Id": r.Id}, err.Error(), http.StatusBadRequest)
+	}
+
+	for _, teamID := range r.TeamIds {
+		if !IsValidId(teamID) {
+			return NewAppError("ContentPolicyRule.IsValid", "model.content_policy_rule.is_valid.team_id.app_error", map[string]interface{}{"Id": r.Id}, "team_id="+teamID, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, spec := range []string{
+		"* * * * *",
+		"*/15 9-17 * * 1-5",
+		"0 0 1,15 * *",
+		"5/10 * * * 7",
+		"30 2 * 2 0",
+	} {
+		_, err := ParseCronSchedule(spec)
+		assert.NoError(t, err, spec)
+	}
+
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		_, err := ParseCronSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2022, time.June, 15, 10, 7, 30, 0, time.UTC)
+
+	for spec, expected := range map[string]time.Time{
+		"* * * * *":         time.Date(2022, time.June, 15, 10, 8, 0, 0, time.UTC),
+		"*/15 * * * *":      time.Date(2022, time.June, 15, 10, 15, 0, 0, time.UTC),
+		"0 9 * * *":         time.Date(2022, time.June, 16, 9, 0, 0, 0, time.UTC),
+		"0 0 1 * *":         time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC),
+		"30 8 * * 1":        time.Date(2022, time.June, 20, 8, 30, 0, 0, time.UTC),
+		"0 0 * * 7":         time.Date(2022, time.June, 19, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":        time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 12 20 * 5":       time.Date(2022, time.June, 17, 12, 0, 0, 0, time.UTC),
+		"*/15 9-17 * * 1-5": time.Date(2022, time.June, 15, 10, 15, 0, 0, time.UTC),
+	} {
+		schedule, err := ParseCronSchedule(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, expected, schedule.Next(now), spec)
+	}
+
+	t.Run("never matching", func(t *testing.T) {
+		schedule, err := ParseCronSchedule("0 0 30 2 *")
+		require.NoError(t, err)
+		assert.True(t, schedule.Next(now).IsZero())
+	})
+
+	t.Run("strictly after", func(t *testing.T) {
+		schedule, err := ParseCronSchedule("0 * * * *")
+		require.NoError(t, err)
+		onTheHour := time.Date(2022, time.June, 15, 10, 0, 0, 0, time.UTC)
+		assert.Equal(t, onTheHour.Add(time.Hour), schedule.Next(onTheHour))
+	})
+}
+
+func TestPluginScheduledTaskIsDue(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 * * * *")
+	require.NoError(t, err)
+	task := &PluginScheduledTask{PluginId: "plugin", Callback: "hourly", Cron: "0 * * * *", Schedule: schedule}
+
+	since := time.Date(2022, time.June, 15, 9, 59, 10, 0, time.UTC)
+	assert.False(t, task.IsDue(since, since.Add(30*time.Second)))
+	assert.True(t, task.IsDue(since, since.Add(time.Minute)))
+	assert.False(t, task.IsDue(since.Add(time.Minute), since.Add(2*time.Minute)))
+}
@@ -16,6 +16,9 @@ type RetentionPolicy struct {
 	ID               string `db:"Id" json:"id"`
 	DisplayName      string `json:"display_name"`
 	PostDurationDays *int64 `db:"PostDuration" json:"post_duration"`
+	// FileDurationDays is how long the file attachments of the posts are kept for, -1 to keep them
+	// as long as the posts. The message of a post whose files were deleted is preserved.
+	FileDurationDays *int64 `db:"FileDuration" json:"file_duration"`
 }
 
 type RetentionPolicyWithTeamAndChannelIDs struct {
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	DependencyDatabase  = "database"
+	DependencyFileStore = "filestore"
+	DependencySMTP      = "smtp"
+	DependencySearch    = "search"
+
+	// DependencyStatusDisabled is the status of a dependency which isn't configured, and so
+	// isn't checked.
+	DependencyStatusDisabled = "DISABLED"
+
+	// StartupDependencyModeDisabled only logs the dependencies found unreachable at startup.
+	StartupDependencyModeDisabled = "disabled"
+	// StartupDependencyModeStrict fails the startup if any dependency is unreachable.
+	StartupDependencyModeStrict = "strict"
+	// StartupDependencyModeDegraded fails the startup if a critical dependency is unreachable,
+	// and starts in a read-only degraded mode if another one is.
+	StartupDependencyModeDegraded = "degraded"
+)
+
+// DependencyStatus is the result of the last reachability check of a dependency of the server.
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Critical  bool   `json:"critical"`
+	Required  bool   `json:"required,omitempty"`
+	Status    string `json:"status"`
+	CheckedAt int64  `json:"checked_at"`
+}
+
+// DependenciesStatus reports the reachability of the dependencies of the server and whether
+// it runs in degraded mode because of them.
+type DependenciesStatus struct {
+	Mode         string              `json:"mode"`
+	DegradedMode bool                `json:"degraded_mode"`
+	DegradedAt   int64               `json:"degraded_at,omitempty"`
+	Dependencies []*DependencyStatus `json:"dependencies"`
+}
+
+// ReadinessStatus reports whether the server is ready to receive traffic, which it is when all
+// the dependencies required by ServiceSettings.ReadinessRequiredDependencies are reachable.
+type ReadinessStatus struct {
+	Status       string              `json:"status"`
+	Dependencies []*DependencyStatus `json:"dependencies"`
+}
+
+// IsValidDependency returns true if name is one of the Dependency values.
+func IsValidDependency(name string) bool {
+	switch name {
+	case DependencyDatabase, DependencyFileStore, DependencySMTP, DependencySearch:
+		return true
+	}
+	return false
+}
+
+// IsValidStartupDependencyMode returns true if mode is one of the StartupDependencyMode values.
+func IsValidStartupDependencyMode(mode string) bool {
+	switch mode {
+	case StartupDependencyModeDisabled, StartupDependencyModeStrict, StartupDependencyModeDegraded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	// DialogWizardMaxSteps is the maximum number of steps of a multi-step dialog.
+	DialogWizardMaxSteps = 20
+	// DialogWizardStateMaxSize is the maximum size of the state kept by the server between the
+	// steps of a multi-step dialog, in bytes.
+	DialogWizardStateMaxSize = 64 * 1024
+	// DialogWizardExpiryTime is how long users have to submit the next step of a dialog.
+	DialogWizardExpiryTime = 1000 * 60 * 60 // 1 hour
+)
+
+// DialogWizardState is the state kept by the server between the steps of a multi-step dialog.
+// Clients only hold a token referencing it, so that the values submitted for the previous
+// steps are not lost when a step is rejected by the integration.
+type DialogWizardState struct {
+	UserId      string                   `json:"user_id"`
+	URL         string                   `json:"url"`
+	CallbackId  string                   `json:"callback_id"`
+	State       string                   `json:"state"`
+	Step        int                      `json:"step"`
+	Dialog      Dialog                   `json:"dialog"`
+	Submissions []map[string]interface{} `json:"submissions"`
+}
+
+func (s *DialogWizardState) IsValid() *AppError {
+	if s.Step > DialogWizardMaxSteps {
+		return NewAppError("DialogWizardState.IsValid", "model.dialog_wizard_state.is_valid.step.app_error", map[string]interface{}{"MaxSteps": DialogWizardMaxSteps}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ValidateSubmission checks the values submitted for the elements of the dialog against their
+// constraints, as clients do before submitting them. It returns the errors by element name.
+func (d *Dialog) ValidateSubmission(submission map[string]interface{}) map[string]*AppError {
+	errs := map[string]*AppError{}
+	for _, element := range d.Elements {
+		raw := submission[element.Name]
+		value, isString := raw.(string)
+
+		if raw == nil || (isString && value == "") {
+			if !element.Optional && element.Type != "bool" {
+				errs[element.Name] = NewAppError("Dialog.ValidateSubmission", "model.dialog.validate_submission.required.app_error", nil, "", http.StatusBadRequest)
+			}
+			continue
+		}
+
+		if !isString || (element.Type != "text" && element.Type != "textarea") {
+			continue
+		}
+
+		length := utf8.RuneCountInString(value)
+		if element.MinLength > 0 && length < element.MinLength {
+			errs[element.Name] = NewAppError("Dialog.ValidateSubmission", "model.dialog.validate_submission.min_length.app_error", map[string]interface{}{"MinLength": element.MinLength}, "", http.StatusBadRequest)
+		} else if element.MaxLength > 0 && length > element.MaxLength {
+			errs[element.Name] = NewAppError("Dialog.ValidateSubmission", "model.dialog.validate_submission.max_length.app_error", map[string]interface{}{"MaxLength": element.MaxLength}, "", http.StatusBadRequest)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialogValidateSubmission(t *testing.T) {
+	dialog := &Dialog{
+		Elements: []DialogElement{
+			{Name: "required", Type: "text"},
+			{Name: "optional", Type: "text", Optional: true},
+			{Name: "short", Type: "textarea", MaxLength: 3, Optional: true},
+			{Name: "long", Type: "text", MinLength: 3, Optional: true},
+			{Name: "number", Type: "text", SubType: "number"},
+			{Name: "select", Type: "select"},
+			{Name: "bool", Type: "bool"},
+		},
+	}
+
+	errs := dialog.ValidateSubmission(map[string]interface{}{
+		"required": "value",
+		"number":   float64(3),
+		"select":   "option",
+	})
+	assert.Empty(t, errs)
+
+	errs = dialog.ValidateSubmission(map[string]interface{}{
+		"required": "",
+		"short":    "abcd",
+		"long":     "ab",
+		"number":   float64(3),
+	})
+	assert.Len(t, errs, 4)
+	assert.Equal(t, "model.dialog.validate_submission.required.app_error", errs["required"].Id)
+	assert.Equal(t, "model.dialog.validate_submission.max_length.app_error", errs["short"].Id)
+	assert.Equal(t, "model.dialog.validate_submission.min_length.app_error", errs["long"].Id)
+	assert.Equal(t, "model.dialog.validate_submission.required.app_error", errs["select"].Id)
+}
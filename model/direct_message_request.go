@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	DirectMessageRequestStatusPending  = "pending"
+	DirectMessageRequestStatusDeclined = "declined"
+	// DirectMessageRequestStatusAccepted is only used in websocket events, accepted requests
+	// being removed.
+	DirectMessageRequestStatusAccepted = "accepted"
+)
+
+// DirectMessageRequest holds the direct messages from a user to another user they don't share a
+// channel with until the recipient accepts them. Declined requests are kept so that the requester
+// can no longer message the recipient.
+type DirectMessageRequest struct {
+	ChannelId   string `json:"channel_id"`
+	RequesterId string `json:"requester_id"`
+	RecipientId string `json:"recipient_id"`
+	Status      string `json:"status"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+}
+
+func (o *DirectMessageRequest) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = o.CreateAt
+
+	if o.Status == "" {
+		o.Status = DirectMessageRequestStatusPending
+	}
+}
+
+func (o *DirectMessageRequest) IsValid() *AppError {
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("DirectMessageRequest.IsValid", "model.direct_message_request.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.RequesterId) {
+		return NewAppError("DirectMessageRequest.IsValid", "model.direct_message_request.is_valid.requester_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.RecipientId) || o.RecipientId == o.RequesterId {
+		return NewAppError("DirectMessageRequest.IsValid", "model.direct_message_request.is_valid.recipient_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.Status != DirectMessageRequestStatusPending && o.Status != DirectMessageRequestStatusDeclined {
+		return NewAppError("DirectMessageRequest.IsValid", "model.direct_message_request.is_valid.status.app_error", nil, "status="+o.Status, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("DirectMessageRequest.IsValid", "model.direct_message_request.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *DirectMessageRequest) IsPending() bool {
+	return o.Status == DirectMessageRequestStatusPending
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	PreferenceCategoryDoNotDisturb = "do_not_disturb"
+	PreferenceNameDNDBypass        = "bypass"
+
+	DNDBypassMaxUsers           = 25
+	DNDBypassMaxKeywords        = 20
+	DNDBypassKeywordMaxRunes    = 50
+	dndBypassKeywordBoundaryFmt = `(?i)(^|[^\pL\pN_])(%s)($|[^\pL\pN_])`
+)
+
+// DNDBypassList holds the people and keywords whose messages still notify a user while their
+// status is set to do not disturb. It is stored as a JSON preference so clients can apply the
+// same rules to desktop notifications.
+type DNDBypassList struct {
+	UserIds  []string `json:"user_ids"`
+	Keywords []string `json:"keywords"`
+
+	// keywordPattern matches any of the keywords as a whole word. It is compiled by
+	// CompileKeywords, or on the first match otherwise.
+	keywordPattern *regexp.Regexp
+}
+
+// PreSave trims the keywords and removes empty and duplicate entries.
+func (l *DNDBypassList) PreSave() {
+	l.UserIds = RemoveDuplicateStrings(l.UserIds)
+
+	keywords := make([]string, 0, len(l.Keywords))
+	seen := make(map[string]bool, len(l.Keywords))
+	for _, keyword := range l.Keywords {
+		keyword = strings.TrimSpace(keyword)
+		lower := strings.ToLower(keyword)
+		if keyword == "" || seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		keywords = append(keywords, keyword)
+	}
+	l.Keywords = keywords
+	l.keywordPattern = nil
+}
+
+// CompileKeywords compiles the pattern matching the keywords, so that it is done once when the
+// list is loaded rather than for every post matched against it.
+func (l *DNDBypassList) CompileKeywords() {
+	if len(l.Keywords) == 0 {
+		l.keywordPattern = nil
+		return
+	}
+
+	quoted := make([]string, len(l.Keywords))
+	for i, keyword := range l.Keywords {
+		quoted[i] = regexp.QuoteMeta(keyword)
+	}
+	l.keywordPattern = regexp.MustCompile(fmt.Sprintf(dndBypassKeywordBoundaryFmt, strings.Join(quoted, "|")))
+}
+
+func (l *DNDBypassList) IsValid() *AppError {
+	if len(l.UserIds) > DNDBypassMaxUsers {
+		return NewAppError("DNDBypassList.IsValid", "model.dnd_bypass.is_valid.user_ids.app_error", map[string]interface{}{"Max": DNDBypassMaxUsers}, "", http.StatusBadRequest)
+	}
+
+	for _, userID := range l.UserIds {
+		if !IsValidId(userID) {
+			return NewAppError("DNDBypassList.IsValid", "model.dnd_bypass.is_valid.user_id.app_error", nil, "user_id="+userID, http.StatusBadRequest)
+		}
+	}
+
+	if len(l.Keywords) > DNDBypassMaxKeywords {
+		return NewAppError("DNDBypassList.IsValid", "model.dnd_bypass.is_valid.keywords.app_error", map[string]interface{}{"Max": DNDBypassMaxKeywords}, "", http.StatusBadRequest)
+	}
+
+	for _, keyword := range l.Keywords {
+		if keyword == "" || utf8.RuneCountInString(keyword) > DNDBypassKeywordMaxRunes {
+			return NewAppError("DNDBypassList.IsValid", "model.dnd_bypass.is_valid.keyword.app_error", map[string]interface{}{"Max": DNDBypassKeywordMaxRunes}, "keyword="+keyword, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns whether the post should notify the user despite do not disturb, either
+// because it was sent by one of the listed users or because it contains one of the keywords.
+func (l *DNDBypassList) Matches(post *Post) bool {
+	for _, userID := range l.UserIds {
+		if post.UserId == userID {
+			return true
+		}
+	}
+
+	if len(l.Keywords) == 0 {
+		return false
+	}
+
+	if l.keywordPattern == nil {
+		l.CompileKeywords()
+	}
+	return l.keywordPattern.MatchString(post.Message)
+}
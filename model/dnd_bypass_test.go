@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNDBypassListPreSave(t *testing.T) {
+	userID := NewId()
+	list := &DNDBypassList{
+		UserIds:  []string{userID, userID},
+		Keywords: []string{" outage ", "Outage", "", "deploy"},
+	}
+	list.PreSave()
+
+	assert.Equal(t, []string{userID}, list.UserIds)
+	assert.Equal(t, []string{"outage", "deploy"}, list.Keywords)
+}
+
+func TestDNDBypassListIsValid(t *testing.T) {
+	require.Nil(t, (&DNDBypassList{}).IsValid())
+	require.Nil(t, (&DNDBypassList{UserIds: []string{NewId()}, Keywords: []string{"outage"}}).IsValid())
+
+	require.NotNil(t, (&DNDBypassList{UserIds: []string{"invalid"}}).IsValid())
+	require.NotNil(t, (&DNDBypassList{Keywords: []string{strings.Repeat("a", DNDBypassKeywordMaxRunes+1)}}).IsValid())
+
+	tooManyUsers := make([]string, DNDBypassMaxUsers+1)
+	for i := range tooManyUsers {
+		tooManyUsers[i] = NewId()
+	}
+	require.NotNil(t, (&DNDBypassList{UserIds: tooManyUsers}).IsValid())
+
+	tooManyKeywords := make([]string, DNDBypassMaxKeywords+1)
+	for i := range tooManyKeywords {
+		tooManyKeywords[i] = NewId()
+	}
+	require.NotNil(t, (&DNDBypassList{Keywords: tooManyKeywords}).IsValid())
+}
+
+func TestDNDBypassListMatches(t *testing.T) {
+	bossID := NewId()
+	list := &DNDBypassList{
+		UserIds:  []string{bossID},
+		Keywords: []string{"outage", "p0"},
+	}
+
+	assert.True(t, list.Matches(&Post{UserId: bossID, Message: "hello"}))
+	assert.True(t, list.Matches(&Post{UserId: NewId(), Message: "We have an OUTAGE in prod"}))
+	assert.True(t, list.Matches(&Post{UserId: NewId(), Message: "p0: database down"}))
+	assert.False(t, list.Matches(&Post{UserId: NewId(), Message: "no outages today"}))
+	assert.False(t, list.Matches(&Post{UserId: NewId(), Message: "hello"}))
+	assert.False(t, (&DNDBypassList{}).Matches(&Post{UserId: bossID, Message: "outage"}))
+
+	t.Run("compiled keywords", func(t *testing.T) {
+		list := &DNDBypassList{Keywords: []string{"c++", "on-call"}}
+		list.CompileKeywords()
+
+		assert.True(t, list.Matches(&Post{UserId: NewId(), Message: "ping the On-Call engineer"}))
+		assert.True(t, list.Matches(&Post{UserId: NewId(), Message: "c++ build broken"}))
+		assert.False(t, list.Matches(&Post{UserId: NewId(), Message: "cpp build broken"}))
+	})
+}
@@ -23,6 +23,7 @@ type Emoji struct {
 	DeleteAt  int64  `json:"delete_at"`
 	CreatorId string `json:"creator_id"`
 	Name      string `json:"name"`
+	TeamId    string `json:"team_id"`
 }
 
 func inSystemEmoji(emojiName string) bool {
@@ -74,6 +75,10 @@ func (emoji *Emoji) IsValid() *AppError {
 		return NewAppError("Emoji.IsValid", "model.emoji.user_id.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if emoji.TeamId != "" && !IsValidId(emoji.TeamId) {
+		return NewAppError("Emoji.IsValid", "model.emoji.team_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return IsValidEmojiName(emoji.Name)
 }
 
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	EmojiArchiveManifestName = "manifest.json"
+	EmojiArchiveImagesDir    = "images"
+	EmojiArchiveVersion      = 1
+
+	// EmojiImportCollisionSkip keeps the existing emoji when the archive contains one with the same name.
+	EmojiImportCollisionSkip = "skip"
+	// EmojiImportCollisionOverwrite replaces the image of the existing emoji, preserving reactions.
+	EmojiImportCollisionOverwrite = "overwrite"
+	// EmojiImportCollisionRename imports the emoji under the first free name with a numeric suffix.
+	EmojiImportCollisionRename = "rename"
+)
+
+// EmojiArchiveManifest describes the content of a custom emoji archive. Archives without a
+// manifest, such as a folder of images exported from Slack, are imported using the file names
+// of the images as emoji names.
+type EmojiArchiveManifest struct {
+	Version int                 `json:"version"`
+	Emojis  []EmojiArchiveEntry `json:"emojis"`
+}
+
+type EmojiArchiveEntry struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+type EmojiImportFailure struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+type EmojiImportResult struct {
+	Imported    []string             `json:"imported"`
+	Overwritten []string             `json:"overwritten"`
+	Renamed     map[string]string    `json:"renamed"`
+	Skipped     []string             `json:"skipped"`
+	Failures    []EmojiImportFailure `json:"failures"`
+}
+
+func IsValidEmojiImportCollisionPolicy(policy string) bool {
+	switch policy {
+	case EmojiImportCollisionSkip, EmojiImportCollisionOverwrite, EmojiImportCollisionRename:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// EventBusSchemaVersion is the version of the EventBusEvent schema. It changes whenever the schema
+// changes in a way the consumers may not expect, e.g. when a field is removed or its meaning
+// changes. Adding a field doesn't change it.
+const EventBusSchemaVersion = 1
+
+// EventBusEvent is a server event as published to the event bus. Its data is the data of the
+// equivalent websocket event.
+type EventBusEvent struct {
+	SchemaVersion int    `json:"schema_version"`
+	Id            string `json:"id"`
+	Type          string `json:"type"`
+	CreateAt      int64  `json:"create_at"`
+	// TeamId, ChannelId and UserId are the team, channel and user the event is about, as far as
+	// the websocket event is only sent to their members, or to the user.
+	TeamId    string                 `json:"team_id,omitempty"`
+	ChannelId string                 `json:"channel_id,omitempty"`
+	UserId    string                 `json:"user_id,omitempty"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// NewEventBusEvent returns the event bus equivalent of the websocket event.
+func NewEventBusEvent(event *WebSocketEvent) *EventBusEvent {
+	busEvent := &EventBusEvent{
+		SchemaVersion: EventBusSchemaVersion,
+		Id:            NewId(),
+		Type:          event.EventType(),
+		CreateAt:      GetMillis(),
+		Data:          event.GetData(),
+	}
+
+	if broadcast := event.GetBroadcast(); broadcast != nil {
+		busEvent.TeamId = broadcast.TeamId
+		busEvent.ChannelId = broadcast.ChannelId
+		busEvent.UserId = broadcast.UserId
+	}
+
+	return busEvent
+}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"hash/fnv"
+	"net/http"
 	"reflect"
 	"strconv"
 )
@@ -139,3 +141,119 @@ func (f *FeatureFlags) ToMap() map[string]string {
 
 	return ret
 }
+
+// SetValue sets the flag with the given name from its string representation, as returned
+// by ToMap. Boolean flags accept "on" in addition to the values understood by
+// strconv.ParseBool. It returns false if there is no such flag or the value is invalid.
+func (f *FeatureFlags) SetValue(name, value string) bool {
+	rFieldVal := reflect.ValueOf(f).Elem().FieldByName(name)
+	if !rFieldVal.IsValid() || !rFieldVal.CanSet() {
+		return false
+	}
+
+	switch rFieldVal.Kind() {
+	case reflect.Bool:
+		if value == "on" || value == "off" {
+			rFieldVal.SetBool(value == "on")
+			return true
+		}
+		boolVal, err := strconv.ParseBool(value)
+		if err != nil {
+			return false
+		}
+		rFieldVal.SetBool(boolVal)
+	case reflect.String:
+		rFieldVal.SetString(value)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// IsValidFeatureFlagOverride returns true if name is a known feature flag and value can be
+// assigned to it.
+func IsValidFeatureFlagOverride(name, value string) bool {
+	var f FeatureFlags
+	return f.SetValue(name, value)
+}
+
+// FeatureFlagRule gives a feature flag a different value for a subset of users, so a feature
+// can be rolled out to some teams, roles or a percentage of users before being enabled for
+// the whole instance. A user gets Value if they match any of the criteria.
+type FeatureFlagRule struct {
+	Flag  string `json:"flag"`
+	Value string `json:"value"`
+	// Percentage of users, between 0 and 100, that get the value. Users are assigned a stable
+	// bucket per flag so the same users stay enabled as the percentage grows.
+	Percentage int      `json:"percentage"`
+	TeamIds    []string `json:"team_ids"`
+	Roles      []string `json:"roles"`
+}
+
+// FeatureFlagTarget describes the user a feature flag rule is evaluated for.
+type FeatureFlagTarget struct {
+	UserId  string
+	TeamIds []string
+	Roles   []string
+}
+
+func (r *FeatureFlagRule) IsValid() *AppError {
+	if !IsValidFeatureFlagOverride(r.Flag, r.Value) {
+		return NewAppError("FeatureFlagRule.IsValid", "model.feature_flag_rule.is_valid.flag.app_error", map[string]interface{}{"Name": r.Flag}, "", http.StatusBadRequest)
+	}
+
+	if r.Percentage < 0 || r.Percentage > 100 {
+		return NewAppError("FeatureFlagRule.IsValid", "model.feature_flag_rule.is_valid.percentage.app_error", map[string]interface{}{"Name": r.Flag}, "", http.StatusBadRequest)
+	}
+
+	for _, teamID := range r.TeamIds {
+		if !IsValidId(teamID) {
+			return NewAppError("FeatureFlagRule.IsValid", "model.feature_flag_rule.is_valid.team_id.app_error", map[string]interface{}{"Name": r.Flag}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the rule applies to the target.
+func (r *FeatureFlagRule) Matches(target *FeatureFlagTarget) bool {
+	if r.Percentage > 0 && target.UserId != "" && featureFlagBucket(r.Flag, target.UserId) < r.Percentage {
+		return true
+	}
+
+	for _, teamID := range r.TeamIds {
+		if !stringNotInSlice(teamID, target.TeamIds) {
+			return true
+		}
+	}
+
+	for _, role := range r.Roles {
+		if !stringNotInSlice(role, target.Roles) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApplyRules sets the value of every flag with a rule matching the target. When several rules
+// match the same flag, the first one wins.
+func (f *FeatureFlags) ApplyRules(rules []*FeatureFlagRule, target *FeatureFlagTarget) {
+	applied := make(map[string]bool)
+	for _, rule := range rules {
+		if applied[rule.Flag] || !rule.Matches(target) {
+			continue
+		}
+		if f.SetValue(rule.Flag, rule.Value) {
+			applied[rule.Flag] = true
+		}
+	}
+}
+
+// featureFlagBucket maps a user to a number between 0 and 99 for the given flag.
+func featureFlagBucket(flag, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag + ":" + userID))
+	return int(h.Sum32() % 100)
+}
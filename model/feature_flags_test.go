@@ -52,3 +52,100 @@ func TestFeatureFlagsToMapBool(t *testing.T) {
 		})
 	}
 }
+
+func TestFeatureFlagsSetValue(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Name     string
+		Value    string
+		Expected bool
+		Check    func(t *testing.T, f FeatureFlags)
+	}{
+		"string flag": {
+			Name:     "TestFeature",
+			Value:    "somevalue",
+			Expected: true,
+			Check:    func(t *testing.T, f FeatureFlags) { require.Equal(t, "somevalue", f.TestFeature) },
+		},
+		"bool flag true": {
+			Name:     "TestBoolFeature",
+			Value:    "true",
+			Expected: true,
+			Check:    func(t *testing.T, f FeatureFlags) { require.True(t, f.TestBoolFeature) },
+		},
+		"bool flag on": {
+			Name:     "TestBoolFeature",
+			Value:    "on",
+			Expected: true,
+			Check:    func(t *testing.T, f FeatureFlags) { require.True(t, f.TestBoolFeature) },
+		},
+		"bool flag invalid": {
+			Name:     "TestBoolFeature",
+			Value:    "maybe",
+			Expected: false,
+			Check:    func(t *testing.T, f FeatureFlags) { require.False(t, f.TestBoolFeature) },
+		},
+		"unknown flag": {
+			Name:     "NotAFeature",
+			Value:    "true",
+			Expected: false,
+			Check:    func(t *testing.T, f FeatureFlags) { require.Equal(t, FeatureFlags{}, f) },
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var f FeatureFlags
+			require.Equal(t, tc.Expected, f.SetValue(tc.Name, tc.Value))
+			require.Equal(t, tc.Expected, IsValidFeatureFlagOverride(tc.Name, tc.Value))
+			tc.Check(t, f)
+		})
+	}
+}
+
+func TestFeatureFlagRule(t *testing.T) {
+	teamID := NewId()
+	userID := NewId()
+
+	t.Run("IsValid", func(t *testing.T) {
+		require.Nil(t, (&FeatureFlagRule{Flag: "TestBoolFeature", Value: "true", Percentage: 50, TeamIds: []string{teamID}}).IsValid())
+		require.NotNil(t, (&FeatureFlagRule{Flag: "NotAFeature", Value: "true"}).IsValid())
+		require.NotNil(t, (&FeatureFlagRule{Flag: "TestBoolFeature", Value: "maybe"}).IsValid())
+		require.NotNil(t, (&FeatureFlagRule{Flag: "TestBoolFeature", Value: "true", Percentage: 101}).IsValid())
+		require.NotNil(t, (&FeatureFlagRule{Flag: "TestBoolFeature", Value: "true", TeamIds: []string{"invalid"}}).IsValid())
+	})
+
+	t.Run("Matches", func(t *testing.T) {
+		target := &FeatureFlagTarget{UserId: userID, TeamIds: []string{teamID}, Roles: []string{SystemUserRoleId}}
+
+		require.False(t, (&FeatureFlagRule{Flag: "TestBoolFeature"}).Matches(target))
+		require.True(t, (&FeatureFlagRule{Flag: "TestBoolFeature", TeamIds: []string{NewId(), teamID}}).Matches(target))
+		require.False(t, (&FeatureFlagRule{Flag: "TestBoolFeature", TeamIds: []string{NewId()}}).Matches(target))
+		require.True(t, (&FeatureFlagRule{Flag: "TestBoolFeature", Roles: []string{SystemUserRoleId}}).Matches(target))
+		require.False(t, (&FeatureFlagRule{Flag: "TestBoolFeature", Roles: []string{SystemAdminRoleId}}).Matches(target))
+		require.True(t, (&FeatureFlagRule{Flag: "TestBoolFeature", Percentage: 100}).Matches(target))
+	})
+
+	t.Run("percentage is stable and roughly proportional", func(t *testing.T) {
+		rule := &FeatureFlagRule{Flag: "TestBoolFeature", Percentage: 30}
+
+		matched := 0
+		for i := 0; i < 1000; i++ {
+			target := &FeatureFlagTarget{UserId: NewId()}
+			if rule.Matches(target) {
+				matched++
+				require.True(t, rule.Matches(target))
+			}
+		}
+		require.InDelta(t, 300, matched, 60)
+	})
+
+	t.Run("ApplyRules", func(t *testing.T) {
+		flags := FeatureFlags{TestFeature: "off"}
+		flags.ApplyRules([]*FeatureFlagRule{
+			{Flag: "TestFeature", Value: "first", TeamIds: []string{teamID}},
+			{Flag: "TestFeature", Value: "second", TeamIds: []string{teamID}},
+			{Flag: "TestBoolFeature", Value: "true", TeamIds: []string{NewId()}},
+		}, &FeatureFlagTarget{UserId: userID, TeamIds: []string{teamID}})
+
+		require.Equal(t, "first", flags.TestFeature)
+		require.False(t, flags.TestBoolFeature)
+	})
+}
@@ -11,11 +11,14 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
 	FileinfoSortByCreated = "CreateAt"
 	FileinfoSortBySize    = "Size"
+
+	FileInfoAltTextMaxRunes = 512
 )
 
 // GetFileInfosOptions contains options for getting FileInfos
@@ -56,6 +59,20 @@ type FileInfo struct {
 	Content         string  `json:"-"`
 	RemoteId        *string `json:"remote_id"`
 	Archived        bool    `json:"archived"`
+	// ContentHash is the hex encoded SHA-256 hash of the file content. It's only recorded for
+	// imported files, which are deduplicated by content.
+	ContentHash string `json:"-"`
+	// DedupOf is the id of the FileInfo whose stored file this one reuses, if any.
+	DedupOf string `json:"-"`
+	// AltText describes an image for screen readers. It's set by the author of the file, or
+	// generated by the image captioning provider when AltTextGenerated.
+	AltText          string `json:"alt_text,omitempty"`
+	AltTextGenerated bool   `json:"alt_text_generated,omitempty"`
+}
+
+// FileInfoAltTextRequest is the alt text an author sets on a file. An empty alt text removes it.
+type FileInfoAltTextRequest struct {
+	AltText string `json:"alt_text"`
 }
 
 func (fi *FileInfo) PreSave() {
@@ -101,6 +118,14 @@ func (fi *FileInfo) IsValid() *AppError {
 		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.path.app_error", nil, "id="+fi.Id, http.StatusBadRequest)
 	}
 
+	if fi.DedupOf != "" && !IsValidId(fi.DedupOf) {
+		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.dedup_of.app_error", nil, "id="+fi.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(fi.AltText) > FileInfoAltTextMaxRunes {
+		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.alt_text.app_error", map[string]interface{}{"Max": FileInfoAltTextMaxRunes}, "id="+fi.Id, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -108,6 +133,22 @@ func (fi *FileInfo) IsImage() bool {
 	return strings.HasPrefix(fi.MimeType, "image")
 }
 
+// audioExtensions are the extensions of audio files, whose MIME type isn't known on every system.
+var audioExtensions = map[string]bool{
+	"aac":  true,
+	"flac": true,
+	"m4a":  true,
+	"mp3":  true,
+	"oga":  true,
+	"ogg":  true,
+	"opus": true,
+	"wav":  true,
+}
+
+func (fi *FileInfo) IsAudio() bool {
+	return strings.HasPrefix(fi.MimeType, "audio/") || audioExtensions[strings.ToLower(fi.Extension)]
+}
+
 func (fi *FileInfo) IsSvg() bool {
 	return fi.MimeType == "image/svg+xml"
 }
@@ -58,6 +58,22 @@ func TestFileInfoIsValid(t *testing.T) {
 		assert.NotNil(t, info.IsValid(), "empty Path isn't valid")
 		info.Path = "fake/path.png"
 	})
+
+	t.Run("Invalid DedupOf is not valid", func(t *testing.T) {
+		info.DedupOf = "invalid"
+		assert.NotNil(t, info.IsValid(), "invalid DedupOf isn't valid")
+		info.DedupOf = NewId()
+		assert.Nil(t, info.IsValid())
+		info.DedupOf = ""
+	})
+
+	t.Run("Alt text longer than the maximum is not valid", func(t *testing.T) {
+		info.AltText = strings.Repeat("é", FileInfoAltTextMaxRunes)
+		assert.Nil(t, info.IsValid())
+		info.AltText += "é"
+		assert.NotNil(t, info.IsValid(), "too long AltText isn't valid")
+		info.AltText = ""
+	})
 }
 
 func TestFileInfoIsImage(t *testing.T) {
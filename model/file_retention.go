@@ -0,0 +1,16 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// FileRetentionReport summarises what the file retention job deleted: the file attachments of
+// the posts affected by a retention policy with a file duration.
+type FileRetentionReport struct {
+	Posts          int   `json:"posts"`
+	FileInfos      int   `json:"file_infos"`
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+	// SharedFileInfos are the expired FileInfos whose stored file is reused by other FileInfos,
+	// which are kept until the file is no longer reused.
+	SharedFileInfos int `json:"shared_file_infos"`
+	Errors          int `json:"errors"`
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	ImpersonationStatusPending  = "pending"
+	ImpersonationStatusApproved = "approved"
+	ImpersonationStatusDenied   = "denied"
+	ImpersonationStatusActive   = "active"
+	ImpersonationStatusRevoked  = "revoked"
+
+	// ImpersonationRequestExpiryTime is how long a request can wait for the consent of the user
+	// and, once approved, for its session to be started.
+	ImpersonationRequestExpiryTime = 1000 * 60 * 60 * 24 // 24 hours
+	// ImpersonationMaxSessionMinutesLimit is the upper bound of ImpersonationMaxSessionMinutes.
+	ImpersonationMaxSessionMinutesLimit = 240
+	ImpersonationReasonMaxRunes         = 512
+
+	SessionPropImpersonatorId  = "impersonator_id"
+	SessionPropImpersonationId = "impersonation_id"
+	SessionTypeImpersonation   = "Impersonation"
+)
+
+// ImpersonationRequest is the request of a system admin to use the account of a user, for
+// support purposes, through a time-boxed and read-only session. Unless the admin overrides
+// it, as allowed by policy, the session can only be started once the user has consented.
+type ImpersonationRequest struct {
+	Id              string `json:"id"`
+	RequesterId     string `json:"requester_id"`
+	UserId          string `json:"user_id"`
+	Reason          string `json:"reason"`
+	DurationMinutes int    `json:"duration_minutes"`
+	PolicyOverride  bool   `json:"policy_override"`
+	Status          string `json:"status"`
+	CreateAt        int64  `json:"create_at"`
+	ConsentAt       int64  `json:"consent_at,omitempty"`
+	// SessionId and ExpiresAt are those of the session, once started.
+	SessionId string `json:"session_id,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+}
+
+// ImpersonationSession is returned to the requester when an impersonation session is started.
+type ImpersonationSession struct {
+	Request *ImpersonationRequest `json:"request"`
+	Token   string                `json:"token"`
+}
+
+func (r *ImpersonationRequest) IsValid(maxSessionMinutes int) *AppError {
+	if !IsValidId(r.RequesterId) {
+		return NewAppError("ImpersonationRequest.IsValid", "model.impersonation_request.is_valid.requester_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) || r.UserId == r.RequesterId {
+		return NewAppError("ImpersonationRequest.IsValid", "model.impersonation_request.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.Reason == "" || utf8.RuneCountInString(r.Reason) > ImpersonationReasonMaxRunes {
+		return NewAppError("ImpersonationRequest.IsValid", "model.impersonation_request.is_valid.reason.app_error", map[string]interface{}{"Max": ImpersonationReasonMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	if r.DurationMinutes <= 0 || r.DurationMinutes > maxSessionMinutes {
+		return NewAppError("ImpersonationRequest.IsValid", "model.impersonation_request.is_valid.duration.app_error", map[string]interface{}{"Max": maxSessionMinutes}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the request is no longer usable: pending and approved requests
+// expire after ImpersonationRequestExpiryTime, active ones when their session does.
+func (r *ImpersonationRequest) IsExpired() bool {
+	switch r.Status {
+	case ImpersonationStatusPending, ImpersonationStatusApproved:
+		return GetMillis()-r.CreateAt > ImpersonationRequestExpiryTime
+	case ImpersonationStatusActive:
+		return GetMillis() > r.ExpiresAt
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpersonationRequestIsValid(t *testing.T) {
+	valid := func() *ImpersonationRequest {
+		return &ImpersonationRequest{RequesterId: NewId(), UserId: NewId(), Reason: "Support ticket", DurationMinutes: 15}
+	}
+
+	assert.Nil(t, valid().IsValid(30))
+
+	r := valid()
+	r.UserId = r.RequesterId
+	assert.NotNil(t, r.IsValid(30), "admins cannot impersonate themselves")
+
+	r = valid()
+	r.Reason = ""
+	assert.NotNil(t, r.IsValid(30))
+
+	r = valid()
+	r.Reason = strings.Repeat("a", ImpersonationReasonMaxRunes+1)
+	assert.NotNil(t, r.IsValid(30))
+
+	r = valid()
+	r.DurationMinutes = 0
+	assert.NotNil(t, r.IsValid(30))
+
+	r = valid()
+	r.DurationMinutes = 31
+	assert.NotNil(t, r.IsValid(30))
+}
+
+func TestImpersonationRequestIsExpired(t *testing.T) {
+	now := GetMillis()
+
+	assert.False(t, (&ImpersonationRequest{Status: ImpersonationStatusPending, CreateAt: now}).IsExpired())
+	assert.True(t, (&ImpersonationRequest{Status: ImpersonationStatusPending, CreateAt: now - ImpersonationRequestExpiryTime - 1}).IsExpired())
+	assert.False(t, (&ImpersonationRequest{Status: ImpersonationStatusApproved, CreateAt: now}).IsExpired())
+	assert.False(t, (&ImpersonationRequest{Status: ImpersonationStatusActive, CreateAt: now, ExpiresAt: now + 60000}).IsExpired())
+	assert.True(t, (&ImpersonationRequest{Status: ImpersonationStatusActive, CreateAt: now, ExpiresAt: now - 1}).IsExpired())
+	assert.True(t, (&ImpersonationRequest{Status: ImpersonationStatusDenied, CreateAt: now}).IsExpired())
+	assert.True(t, (&ImpersonationRequest{Status: ImpersonationStatusRevoked, CreateAt: now}).IsExpired())
+}
@@ -245,11 +245,23 @@ type SubmitDialogRequest struct {
 	TeamId     string                 `json:"team_id"`
 	Submission map[string]interface{} `json:"submission"`
 	Cancelled  bool                   `json:"cancelled"`
+
+	// StateToken is set by clients submitting a step of a multi-step dialog, in place of the URL.
+	StateToken string `json:"state_token,omitempty"`
+	// Step and Submissions are set by the server for multi-step dialogs: the index of the
+	// submitted step and the values submitted for the previous ones.
+	Step        int                      `json:"step,omitempty"`
+	Submissions []map[string]interface{} `json:"submissions,omitempty"`
 }
 
 type SubmitDialogResponse struct {
 	Error  string            `json:"error,omitempty"`
 	Errors map[string]string `json:"errors,omitempty"`
+
+	// Next is the next step of a multi-step dialog, returned by the integration. The server
+	// replaces its state with a StateToken for clients to submit it.
+	Next       *Dialog `json:"next,omitempty"`
+	StateToken string  `json:"state_token,omitempty"`
 }
 
 func GenerateTriggerId(userId string, s crypto.Signer) (string, string, *AppError) {
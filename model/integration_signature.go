@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// The requests of the outgoing webhooks and slash commands that have a signing secret are signed,
+// so that the integrations can authenticate them without relying on the token sent along with the
+// payload. Each request has two headers:
+//
+//	X-Mattermost-Request-Timestamp: the time the request was signed, in seconds since the epoch
+//	X-Mattermost-Signature: v1=<signature>
+//
+// where the signature is the hex encoded HMAC-SHA256, keyed with the signing secret, of
+//
+//	v1:<timestamp>:<payload>
+//
+// The payload is the body of the request, or its URL query for the slash commands using GET.
+// To verify a request, an integration computes the signature from the headers and the payload as
+// received, compares it to the one of the header in constant time, and rejects the requests whose
+// timestamp is more than IntegrationSignatureMaxAge away from its clock to prevent replays.
+// VerifyIntegrationRequestSignature does all of that.
+const (
+	HeaderIntegrationRequestTimestamp = "X-Mattermost-Request-Timestamp"
+	HeaderIntegrationSignature        = "X-Mattermost-Signature"
+
+	IntegrationSignatureVersion = "v1"
+	IntegrationSignatureMaxAge  = 5 * time.Minute
+)
+
+// IntegrationRequestSignature computes the X-Mattermost-Signature header of a request whose payload
+// is signed at the given time, in seconds since the epoch.
+func IntegrationRequestSignature(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(IntegrationSignatureVersion + ":" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(payload)
+	return IntegrationSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyIntegrationRequestSignature returns whether the timestamp and signature headers of a
+// request received by an integration match its payload and signing secret, and whether the
+// request was signed less than IntegrationSignatureMaxAge from now.
+func VerifyIntegrationRequestSignature(secret, timestamp, signature string, payload []byte, now time.Time) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	age := now.Sub(time.Unix(ts, 0))
+	if age > IntegrationSignatureMaxAge || age < -IntegrationSignatureMaxAge {
+		return false
+	}
+
+	expected := IntegrationRequestSignature(secret, ts, payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrationRequestSignature(t *testing.T) {
+	// The signature is the HMAC-SHA256 of "v1:1531420618:token=abc", keyed with "secret".
+	assert.Equal(t,
+		"v1=6d21b5e788e284879a9b2a0c36586162fc81bac4d740276e7e33fc723f8a1e9f",
+		IntegrationRequestSignature("secret", 1531420618, []byte("token=abc")),
+	)
+}
+
+func TestVerifyIntegrationRequestSignature(t *testing.T) {
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	payload := []byte(`{"text":"hello"}`)
+	signature := IntegrationRequestSignature("secret", now.Unix(), payload)
+
+	assert.True(t, VerifyIntegrationRequestSignature("secret", timestamp, signature, payload, now))
+	assert.True(t, VerifyIntegrationRequestSignature("secret", timestamp, signature, payload, now.Add(IntegrationSignatureMaxAge-time.Second)))
+
+	assert.False(t, VerifyIntegrationRequestSignature("other", timestamp, signature, payload, now), "wrong secret")
+	assert.False(t, VerifyIntegrationRequestSignature("secret", timestamp, signature, []byte(`{"text":"bye"}`), now), "tampered payload")
+	assert.False(t, VerifyIntegrationRequestSignature("secret", strconv.FormatInt(now.Unix()+1, 10), signature, payload, now), "tampered timestamp")
+	assert.False(t, VerifyIntegrationRequestSignature("secret", timestamp, signature, payload, now.Add(IntegrationSignatureMaxAge+time.Second)), "replayed")
+	assert.False(t, VerifyIntegrationRequestSignature("secret", timestamp, signature, payload, now.Add(-IntegrationSignatureMaxAge-time.Second)), "from the future")
+	assert.False(t, VerifyIntegrationRequestSignature("secret", "junk", signature, payload, now), "invalid timestamp")
+	assert.False(t, VerifyIntegrationRequestSignature("secret", timestamp, "", payload, now), "missing signature")
+}
@@ -56,3 +56,14 @@ func (r *IntegrityCheckResult) UnmarshalJSON(b []byte) error {
 	}
 	return nil
 }
+
+const (
+	IntegrityRepairStrategySkip   = "skip"
+	IntegrityRepairStrategyDelete = "delete"
+	IntegrityRepairStrategyClear  = "clear"
+)
+
+// Relation returns the name of the checked relation, in the form ChildName.ParentIdAttr.
+func (d RelationalIntegrityCheckData) Relation() string {
+	return d.ChildName + "." + d.ParentIdAttr
+}
@@ -27,6 +27,21 @@ const (
 	JobTypeCloud                        = "cloud"
 	JobTypeResendInvitationEmail        = "resend_invitation_email"
 	JobTypeExtractContent               = "extract_content"
+	JobTypeBulkChannelMembers           = "bulk_channel_members"
+	JobTypeMSTeamsImport                = "ms_teams_import"
+	JobTypePartitionMaintenance         = "partition_maintenance"
+	JobTypePostArchive                  = "post_archive"
+	JobTypePluginScheduledTasks         = "plugin_scheduled_tasks"
+	JobTypeOrphanedFilesCleanup         = "orphaned_files_cleanup"
+	JobTypeUserMerge                    = "user_merge"
+	JobTypeAuthMigration                = "auth_migration"
+	JobTypeFileRetention                = "file_retention"
+	JobTypeChannelMemberCounts          = "channel_member_counts"
+	JobTypeReminders                    = "reminders"
+	JobTypeChannelEvents                = "channel_events"
+	JobTypeScheduledConfigChanges       = "scheduled_config_changes"
+	JobTypePurgeSoftDeleted             = "purge_soft_deleted"
+	JobTypeUserDeletion                 = "user_deletion"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -55,6 +70,21 @@ var AllJobTypes = [...]string{
 	JobTypeExportDelete,
 	JobTypeCloud,
 	JobTypeExtractContent,
+	JobTypeBulkChannelMembers,
+	JobTypeMSTeamsImport,
+	JobTypePartitionMaintenance,
+	JobTypePostArchive,
+	JobTypePluginScheduledTasks,
+	JobTypeOrphanedFilesCleanup,
+	JobTypeUserMerge,
+	JobTypeAuthMigration,
+	JobTypeFileRetention,
+	JobTypeChannelMemberCounts,
+	JobTypeReminders,
+	JobTypeChannelEvents,
+	JobTypeScheduledConfigChanges,
+	JobTypePurgeSoftDeleted,
+	JobTypeUserDeletion,
 }
 
 type Job struct {
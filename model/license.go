@@ -27,6 +27,7 @@ const (
 
 const (
 	LicenseUpForRenewalEmailSent = "LicenseUpForRenewalEmailSent"
+	LicenseSeatLimitEmailSent    = "LicenseSeatLimitEmailSent"
 )
 
 var (
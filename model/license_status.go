@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	// LicenseSeatWarningPercentage is the share of licensed seats in use at which
+	// admins start being warned about the seat limit.
+	LicenseSeatWarningPercentage = 90
+
+	LicenseSeatStateOk      = "ok"
+	LicenseSeatStateWarning = "warning"
+	LicenseSeatStateOverage = "overage"
+)
+
+// LicenseStatus summarises how the installed license is being used, so admins can
+// see seat overages and the expiry countdown before either is enforced.
+type LicenseStatus struct {
+	LicensedSeats        int64  `json:"licensed_seats"`
+	UsedSeats            int64  `json:"used_seats"`
+	OverageSeats         int64  `json:"overage_seats"`
+	SeatState            string `json:"seat_state"`
+	ExpiresAt            int64  `json:"expires_at"`
+	DaysToExpiration     int    `json:"days_to_expiration"`
+	IsExpired            bool   `json:"is_expired"`
+	IsInGracePeriod      bool   `json:"is_in_grace_period"`
+	GracePeriodEndsAt    int64  `json:"grace_period_ends_at"`
+	IsTrial              bool   `json:"is_trial"`
+	IsSeatLimitUnlimited bool   `json:"is_seat_limit_unlimited"`
+}
+
+// NewLicenseStatus builds the status of the given license with usedSeats active users.
+// Licenses without a user limit never report an overage.
+func NewLicenseStatus(license *License, usedSeats int64) *LicenseStatus {
+	status := &LicenseStatus{
+		UsedSeats:         usedSeats,
+		SeatState:         LicenseSeatStateOk,
+		ExpiresAt:         license.ExpiresAt,
+		DaysToExpiration:  license.DaysToExpiration(),
+		IsExpired:         license.IsExpired(),
+		IsInGracePeriod:   license.IsExpired() && !license.IsPastGracePeriod(),
+		GracePeriodEndsAt: license.ExpiresAt + LicenseGracePeriod,
+		IsTrial:           license.IsTrialLicense(),
+	}
+
+	if license.Features == nil || license.Features.Users == nil || *license.Features.Users <= 0 {
+		status.IsSeatLimitUnlimited = true
+		return status
+	}
+
+	status.LicensedSeats = int64(*license.Features.Users)
+	switch {
+	case usedSeats > status.LicensedSeats:
+		status.SeatState = LicenseSeatStateOverage
+		status.OverageSeats = usedSeats - status.LicensedSeats
+	case usedSeats*100 >= status.LicensedSeats*LicenseSeatWarningPercentage:
+		status.SeatState = LicenseSeatStateWarning
+	}
+
+	return status
+}
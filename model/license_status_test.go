@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLicenseStatus(t *testing.T) {
+	newLicense := func(users int, expiresAt int64) *License {
+		l := &License{ExpiresAt: expiresAt, Features: &Features{}}
+		l.Features.SetDefaults()
+		*l.Features.Users = users
+		return l
+	}
+
+	t.Run("seats under the warning threshold", func(t *testing.T) {
+		status := NewLicenseStatus(newLicense(100, GetMillis()+30*DayInMilliseconds), 50)
+		assert.Equal(t, LicenseSeatStateOk, status.SeatState)
+		assert.Equal(t, int64(100), status.LicensedSeats)
+		assert.Equal(t, int64(0), status.OverageSeats)
+		assert.False(t, status.IsExpired)
+		assert.False(t, status.IsInGracePeriod)
+		assert.GreaterOrEqual(t, status.DaysToExpiration, 29)
+	})
+
+	t.Run("seats nearing the limit", func(t *testing.T) {
+		status := NewLicenseStatus(newLicense(100, GetMillis()+30*DayInMilliseconds), 90)
+		assert.Equal(t, LicenseSeatStateWarning, status.SeatState)
+		assert.Equal(t, int64(0), status.OverageSeats)
+	})
+
+	t.Run("seats over the limit", func(t *testing.T) {
+		status := NewLicenseStatus(newLicense(100, GetMillis()+30*DayInMilliseconds), 105)
+		assert.Equal(t, LicenseSeatStateOverage, status.SeatState)
+		assert.Equal(t, int64(5), status.OverageSeats)
+	})
+
+	t.Run("unlimited seats", func(t *testing.T) {
+		status := NewLicenseStatus(newLicense(0, GetMillis()+30*DayInMilliseconds), 1000)
+		assert.True(t, status.IsSeatLimitUnlimited)
+		assert.Equal(t, LicenseSeatStateOk, status.SeatState)
+	})
+
+	t.Run("expired within grace period", func(t *testing.T) {
+		expiresAt := GetMillis() - DayInMilliseconds
+		status := NewLicenseStatus(newLicense(100, expiresAt), 10)
+		assert.True(t, status.IsExpired)
+		assert.True(t, status.IsInGracePeriod)
+		assert.Equal(t, expiresAt+LicenseGracePeriod, status.GracePeriodEndsAt)
+	})
+
+	t.Run("expired past grace period", func(t *testing.T) {
+		status := NewLicenseStatus(newLicense(100, GetMillis()-LicenseGracePeriod-DayInMilliseconds), 10)
+		assert.True(t, status.IsExpired)
+		assert.False(t, status.IsInGracePeriod)
+	})
+}
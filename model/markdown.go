@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+// MarkdownRenderMaxRunes is the longest text that can be rendered, the longest message of a post.
+const MarkdownRenderMaxRunes = PostMessageMaxRunesV2
+
+// MarkdownRenderRequest is the body of a request rendering markdown to HTML.
+type MarkdownRenderRequest struct {
+	Text string `json:"text"`
+}
+
+func (r *MarkdownRenderRequest) IsValid() *AppError {
+	if utf8.RuneCountInString(r.Text) > MarkdownRenderMaxRunes {
+		return NewAppError("MarkdownRenderRequest.IsValid", "model.markdown_render.is_valid.text.app_error", map[string]interface{}{"Max": MarkdownRenderMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// MarkdownRenderResponse holds the sanitized HTML a text rendered to.
+type MarkdownRenderResponse struct {
+	HTML string `json:"html"`
+}
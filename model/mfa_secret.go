@@ -7,3 +7,9 @@ type MfaSecret struct {
 	Secret string `json:"secret"`
 	QRCode string `json:"qr_code"`
 }
+
+// MfaBackupCodes are one-time codes to log in without the authenticator app of a user. They are
+// only returned when generated, and can't be retrieved later.
+type MfaBackupCodes struct {
+	BackupCodes []string `json:"backup_codes"`
+}
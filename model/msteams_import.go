@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// MSTeamsImportReport summarises what a Microsoft Teams import job found in the export package,
+// what it had to leave out and, for failed imports, which bulk import line was rejected.
+type MSTeamsImportReport struct {
+	DryRun      bool     `json:"dry_run"`
+	Users       int      `json:"users"`
+	Teams       int      `json:"teams"`
+	Channels    int      `json:"channels"`
+	Posts       int      `json:"posts"`
+	Replies     int      `json:"replies"`
+	Attachments int      `json:"attachments"`
+	Warnings    []string `json:"warnings"`
+	Error       string   `json:"error,omitempty"`
+	ErrorLine   int      `json:"error_line,omitempty"`
+}
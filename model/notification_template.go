@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"text/template"
+)
+
+// notificationPluralCategories are the CLDR plural categories a template override can be
+// translated for. Every override is translated for the other category, which is used when no
+// other category applies to the count.
+var notificationPluralCategories = map[string]bool{
+	"zero":  true,
+	"one":   true,
+	"two":   true,
+	"few":   true,
+	"many":  true,
+	"other": true,
+}
+
+// NotificationTemplateOverride replaces the translation of a notification template, e.g.
+// app.notification.subject.direct.full, for the users with the given locale.
+type NotificationTemplateOverride struct {
+	Locale        string `json:"locale"`
+	TranslationId string `json:"translation_id"`
+	// Translation is keyed by plural category, and uses the same arguments as the translation
+	// it replaces.
+	Translation map[string]string `json:"translation"`
+}
+
+func (o *NotificationTemplateOverride) IsValid() *AppError {
+	if o.Locale == "" || len(o.Locale) > UserLocaleMaxLength {
+		return NewAppError("NotificationTemplateOverride.IsValid", "model.notification_template_override.is_valid.locale.app_error", nil, "translation_id="+o.TranslationId, http.StatusBadRequest)
+	}
+
+	if o.TranslationId == "" {
+		return NewAppError("NotificationTemplateOverride.IsValid", "model.notification_template_override.is_valid.translation_id.app_error", nil, "locale="+o.Locale, http.StatusBadRequest)
+	}
+
+	if _, ok := o.Translation["other"]; !ok {
+		return NewAppError("NotificationTemplateOverride.IsValid", "model.notification_template_override.is_valid.other.app_error", nil, "translation_id="+o.TranslationId, http.StatusBadRequest)
+	}
+
+	for category, text := range o.Translation {
+		if !notificationPluralCategories[category] {
+			return NewAppError("NotificationTemplateOverride.IsValid", "model.notification_template_override.is_valid.category.app_error", map[string]interface{}{"Category": category}, "translation_id="+o.TranslationId, http.StatusBadRequest)
+		}
+
+		if _, err := template.New(o.TranslationId).Parse(text); err != nil {
+			return NewAppError("NotificationTemplateOverride.IsValid", "model.notification_template_override.is_valid.template.app_error", map[string]interface{}{"Category": category}, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// NotificationPreview is a notification rendered for a user as it would be sent to them, in their
+// locale and timezone.
+type NotificationPreview struct {
+	Locale       string `json:"locale"`
+	Timezone     string `json:"timezone"`
+	EmailSubject string `json:"email_subject"`
+	EmailBody    string `json:"email_body"`
+	PushMessage  string `json:"push_message"`
+}
+
+// NotificationPreviewRequest is the post a notification preview is rendered for.
+type NotificationPreviewRequest struct {
+	PostId string `json:"post_id"`
+}
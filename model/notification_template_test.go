@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationTemplateOverrideIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Override *NotificationTemplateOverride
+		Valid    bool
+	}{
+		"valid": {
+			Override: &NotificationTemplateOverride{Locale: "en", TranslationId: "app.notification.subject.direct.full", Translation: map[string]string{"other": "[{{.SiteName}}] {{.SenderDisplayName}}"}},
+			Valid:    true,
+		},
+		"plural": {
+			Override: &NotificationTemplateOverride{Locale: "pt-BR", TranslationId: "api.email_batching.send_batched_email_notification.title", Translation: map[string]string{"one": "Uma mensagem", "other": "{{.Count}} mensagens"}},
+			Valid:    true,
+		},
+		"missing locale": {
+			Override: &NotificationTemplateOverride{TranslationId: "app.notification.subject.direct.full", Translation: map[string]string{"other": "subject"}},
+		},
+		"missing translation id": {
+			Override: &NotificationTemplateOverride{Locale: "en", Translation: map[string]string{"other": "subject"}},
+		},
+		"missing other category": {
+			Override: &NotificationTemplateOverride{Locale: "en", TranslationId: "app.notification.subject.direct.full", Translation: map[string]string{"one": "subject"}},
+		},
+		"unknown category": {
+			Override: &NotificationTemplateOverride{Locale: "en", TranslationId: "app.notification.subject.direct.full", Translation: map[string]string{"other": "subject", "several": "subjects"}},
+		},
+		"invalid template": {
+			Override: &NotificationTemplateOverride{Locale: "en", TranslationId: "app.notification.subject.direct.full", Translation: map[string]string{"other": "{{.SiteName"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.Valid {
+				assert.Nil(t, tc.Override.IsValid())
+			} else {
+				assert.NotNil(t, tc.Override.IsValid())
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+	"unicode/utf8"
+)
+
+const (
+	PreferenceCategoryOnboardingTasks = "onboarding_task"
+
+	OnboardingTaskAudienceAll    = "all"
+	OnboardingTaskAudienceAdmins = "admins"
+
+	OnboardingTaskTitleMaxRunes       = 128
+	OnboardingTaskDescriptionMaxRunes = 1024
+	OnboardingTaskLinkMaxLength       = 512
+
+	OnboardingTaskCompleteProfile    = "complete_profile"
+	OnboardingTaskExploreChannels    = "explore_channels"
+	OnboardingTaskDownloadApps       = "download_apps"
+	OnboardingTaskInvitePeople       = "invite_people"
+	OnboardingTaskVisitSystemConsole = "visit_system_console"
+)
+
+var validOnboardingTaskID = regexp.MustCompile(`^[a-z0-9_]{1,26}$`)
+
+// OnboardingTask is a step of the first-run checklist. Built-in tasks ship with the server and
+// have translatable titles, custom tasks are authored by system admins.
+type OnboardingTask struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Link        string `json:"link"`
+	Audience    string `json:"audience"`
+	SortOrder   int64  `json:"sort_order"`
+	BuiltIn     bool   `json:"built_in"`
+	CreatorId   string `json:"creator_id,omitempty"`
+	CreateAt    int64  `json:"create_at,omitempty"`
+	UpdateAt    int64  `json:"update_at,omitempty"`
+	DeleteAt    int64  `json:"delete_at,omitempty"`
+}
+
+// OnboardingTaskStatus is an onboarding task along with the time the user completed it, or zero
+// if they haven't yet.
+type OnboardingTaskStatus struct {
+	OnboardingTask
+	CompleteAt int64 `json:"complete_at"`
+}
+
+// BuiltInOnboardingTasks returns the tasks every server offers. Their title and description are
+// translation ids.
+func BuiltInOnboardingTasks() []*OnboardingTask {
+	return []*OnboardingTask{
+		{
+			Id:          OnboardingTaskCompleteProfile,
+			Title:       "onboarding.task.complete_profile.title",
+			Description: "onboarding.task.complete_profile.description",
+			Link:        "/settings/profile",
+			Audience:    OnboardingTaskAudienceAll,
+			SortOrder:   10,
+			BuiltIn:     true,
+		},
+		{
+			Id:          OnboardingTaskExploreChannels,
+			Title:       "onboarding.task.explore_channels.title",
+			Description: "onboarding.task.explore_channels.description",
+			Link:        "/channels",
+			Audience:    OnboardingTaskAudienceAll,
+			SortOrder:   20,
+			BuiltIn:     true,
+		},
+		{
+			Id:          OnboardingTaskDownloadApps,
+			Title:       "onboarding.task.download_apps.title",
+			Description: "onboarding.task.download_apps.description",
+			Link:        "https://mattermost.com/download",
+			Audience:    OnboardingTaskAudienceAll,
+			SortOrder:   30,
+			BuiltIn:     true,
+		},
+		{
+			Id:          OnboardingTaskInvitePeople,
+			Title:       "onboarding.task.invite_people.title",
+			Description: "onboarding.task.invite_people.description",
+			Audience:    OnboardingTaskAudienceAll,
+			SortOrder:   40,
+			BuiltIn:     true,
+		},
+		{
+			Id:          OnboardingTaskVisitSystemConsole,
+			Title:       "onboarding.task.visit_system_console.title",
+			Description: "onboarding.task.visit_system_console.description",
+			Link:        "/admin_console",
+			Audience:    OnboardingTaskAudienceAdmins,
+			SortOrder:   50,
+			BuiltIn:     true,
+		},
+	}
+}
+
+func (t *OnboardingTask) PreSave() {
+	if t.Id == "" {
+		t.Id = NewId()
+	}
+
+	if t.Audience == "" {
+		t.Audience = OnboardingTaskAudienceAll
+	}
+
+	t.BuiltIn = false
+	t.CreateAt = GetMillis()
+	t.UpdateAt = t.CreateAt
+}
+
+func (t *OnboardingTask) PreUpdate() {
+	t.UpdateAt = GetMillis()
+}
+
+// IsValid validates a custom onboarding task.
+func (t *OnboardingTask) IsValid() *AppError {
+	if !IsValidId(t.Id) {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if t.CreateAt == 0 {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.create_at.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.UpdateAt == 0 {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.update_at.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.Title == "" || utf8.RuneCountInString(t.Title) > OnboardingTaskTitleMaxRunes {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.title.app_error", map[string]interface{}{"Max": OnboardingTaskTitleMaxRunes}, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(t.Description) > OnboardingTaskDescriptionMaxRunes {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.description.app_error", map[string]interface{}{"Max": OnboardingTaskDescriptionMaxRunes}, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if len(t.Link) > OnboardingTaskLinkMaxLength || (t.Link != "" && t.Link[0] != '/' && !IsValidHTTPURL(t.Link)) {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.link.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.Audience != OnboardingTaskAudienceAll && t.Audience != OnboardingTaskAudienceAdmins {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.audience.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.CreatorId != "" && !IsValidId(t.CreatorId) {
+		return NewAppError("OnboardingTask.IsValid", "model.onboarding_task.is_valid.creator_id.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsValidOnboardingTaskId reports whether id could identify a built-in or custom onboarding task.
+func IsValidOnboardingTaskId(id string) bool {
+	return validOnboardingTaskID.MatchString(id)
+}
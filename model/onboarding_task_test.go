@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingTaskIsValid(t *testing.T) {
+	task := &OnboardingTask{Title: "Read the handbook", Link: "/channels/handbook"}
+	task.PreSave()
+	require.Nil(t, task.IsValid())
+	assert.Equal(t, OnboardingTaskAudienceAll, task.Audience)
+
+	task.Link = "https://example.com/handbook"
+	require.Nil(t, task.IsValid())
+
+	task.Link = "javascript:alert(1)"
+	require.NotNil(t, task.IsValid())
+	task.Link = ""
+
+	task.Audience = "everyone"
+	require.NotNil(t, task.IsValid())
+	task.Audience = OnboardingTaskAudienceAdmins
+
+	task.Title = ""
+	require.NotNil(t, task.IsValid())
+}
+
+func TestBuiltInOnboardingTasks(t *testing.T) {
+	for _, task := range BuiltInOnboardingTasks() {
+		assert.True(t, IsValidOnboardingTaskId(task.Id), task.Id)
+		assert.True(t, task.BuiltIn)
+	}
+
+	assert.True(t, IsValidOnboardingTaskId(NewId()))
+	assert.False(t, IsValidOnboardingTaskId("Invalid-Id"))
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// OpenIdConnectServicePrefix prefixes the id of a generic OpenID Connect provider to name its
+	// service, which is the AuthService of its users.
+	OpenIdConnectServicePrefix = "oidc"
+
+	OpenIdConnectDefaultScope          = "openid profile email"
+	OpenIdConnectDefaultIdClaim        = "sub"
+	OpenIdConnectDefaultEmailClaim     = "email"
+	OpenIdConnectDefaultUsernameClaim  = "preferred_username"
+	OpenIdConnectDefaultFirstNameClaim = "given_name"
+	OpenIdConnectDefaultLastNameClaim  = "family_name"
+	OpenIdConnectDefaultButtonColor    = "#145DBF"
+)
+
+var validOpenIdConnectProviderId = regexp.MustCompile(`^[a-z0-9]{1,24}$`)
+
+// OpenIdConnectProvider configures a generic OpenID Connect provider. Its endpoints are read from
+// DiscoveryEndpoint unless they are set, and the claims of its users are mapped to their
+// attributes. Claims can be nested, such as "realm_access.roles".
+type OpenIdConnectProvider struct {
+	Id                string `json:"id"`
+	DisplayName       string `json:"display_name"`
+	ButtonColor       string `json:"button_color"`
+	Enable            bool   `json:"enable"`
+	ClientId          string `json:"client_id"`
+	ClientSecret      string `json:"client_secret"`
+	Scope             string `json:"scope"`
+	DiscoveryEndpoint string `json:"discovery_endpoint"`
+	AuthEndpoint      string `json:"auth_endpoint"`
+	TokenEndpoint     string `json:"token_endpoint"`
+	UserAPIEndpoint   string `json:"user_api_endpoint"`
+	IdClaim           string `json:"id_claim"`
+	EmailClaim        string `json:"email_claim"`
+	UsernameClaim     string `json:"username_claim"`
+	FirstNameClaim    string `json:"first_name_claim"`
+	LastNameClaim     string `json:"last_name_claim"`
+	// GroupsClaim, if set, lists the names of the custom groups the users are added to.
+	GroupsClaim string `json:"groups_claim"`
+}
+
+// OpenIdConnectProviderButton is what the login page shows of a provider.
+type OpenIdConnectProviderButton struct {
+	Service     string `json:"service"`
+	DisplayName string `json:"display_name"`
+	ButtonColor string `json:"button_color"`
+}
+
+// OpenIdConnectService returns the name of the service of the provider with the id given.
+func OpenIdConnectService(id string) string {
+	return OpenIdConnectServicePrefix + id
+}
+
+// IsOpenIdConnectService returns true if service is the service of a generic OpenID Connect
+// provider.
+func IsOpenIdConnectService(service string) bool {
+	return strings.HasPrefix(service, OpenIdConnectServicePrefix) && len(service) > len(OpenIdConnectServicePrefix)
+}
+
+// Service returns the name of the service of the provider.
+func (p *OpenIdConnectProvider) Service() string {
+	return OpenIdConnectService(p.Id)
+}
+
+// SetDefaults maps the standard claims, and sets the standard scope, when they are not set.
+func (p *OpenIdConnectProvider) SetDefaults() {
+	if p.Scope == "" {
+		p.Scope = OpenIdConnectDefaultScope
+	}
+
+	if p.ButtonColor == "" {
+		p.ButtonColor = OpenIdConnectDefaultButtonColor
+	}
+
+	if p.IdClaim == "" {
+		p.IdClaim = OpenIdConnectDefaultIdClaim
+	}
+
+	if p.EmailClaim == "" {
+		p.EmailClaim = OpenIdConnectDefaultEmailClaim
+	}
+
+	if p.UsernameClaim == "" {
+		p.UsernameClaim = OpenIdConnectDefaultUsernameClaim
+	}
+
+	if p.FirstNameClaim == "" {
+		p.FirstNameClaim = OpenIdConnectDefaultFirstNameClaim
+	}
+
+	if p.LastNameClaim == "" {
+		p.LastNameClaim = OpenIdConnectDefaultLastNameClaim
+	}
+}
+
+func (p *OpenIdConnectProvider) IsValid() *AppError {
+	if !validOpenIdConnectProviderId.MatchString(p.Id) {
+		return NewAppError("OpenIdConnectProvider.IsValid", "model.openid_connect_provider.is_valid.id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.DisplayName == "" {
+		return NewAppError("OpenIdConnectProvider.IsValid", "model.openid_connect_provider.is_valid.display_name.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.IdClaim == "" || p.EmailClaim == "" {
+		return NewAppError("OpenIdConnectProvider.IsValid", "model.openid_connect_provider.is_valid.claims.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if !p.Enable {
+		return nil
+	}
+
+	if p.ClientId == "" {
+		return NewAppError("OpenIdConnectProvider.IsValid", "model.openid_connect_provider.is_valid.client_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	// The endpoints which are not set are discovered.
+	endpoints := []string{p.AuthEndpoint, p.TokenEndpoint, p.UserAPIEndpoint}
+	if p.DiscoveryEndpoint != "" {
+		endpoints = append(endpoints, p.DiscoveryEndpoint)
+	}
+	for _, endpoint := range endpoints {
+		if endpoint == "" && p.DiscoveryEndpoint != "" {
+			continue
+		}
+		if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return NewAppError("OpenIdConnectProvider.IsValid", "model.openid_connect_provider.is_valid.endpoint.app_error", map[string]interface{}{"Endpoint": endpoint}, "id="+p.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// SSOSettings returns the settings of the provider in the form of those of the other OAuth
+// services. The endpoints which are not set must still be discovered.
+func (p *OpenIdConnectProvider) SSOSettings() *SSOSettings {
+	return &SSOSettings{
+		Enable:            NewBool(p.Enable),
+		Secret:            NewString(p.ClientSecret),
+		Id:                NewString(p.ClientId),
+		Scope:             NewString(p.Scope),
+		AuthEndpoint:      NewString(p.AuthEndpoint),
+		TokenEndpoint:     NewString(p.TokenEndpoint),
+		UserAPIEndpoint:   NewString(p.UserAPIEndpoint),
+		DiscoveryEndpoint: NewString(p.DiscoveryEndpoint),
+		ButtonText:        NewString(p.DisplayName),
+		ButtonColor:       NewString(p.ButtonColor),
+	}
+}
+
+// UserFromClaims returns the user described by the claims of the provider, which must include
+// an id and an email address. The username defaults to the local part of the email address.
+func (p *OpenIdConnectProvider) UserFromClaims(claims map[string]interface{}) (*User, error) {
+	id := claimString(claims, p.IdClaim)
+	if id == "" {
+		return nil, errors.Errorf("the %s claim is missing", p.IdClaim)
+	}
+
+	email := strings.ToLower(claimString(claims, p.EmailClaim))
+	if email == "" {
+		return nil, errors.Errorf("the %s claim is missing", p.EmailClaim)
+	}
+
+	username := claimString(claims, p.UsernameClaim)
+	if username == "" {
+		username = strings.Split(email, "@")[0]
+	}
+
+	return &User{
+		Username:    CleanUsername(username),
+		Email:       email,
+		FirstName:   claimString(claims, p.FirstNameClaim),
+		LastName:    claimString(claims, p.LastNameClaim),
+		AuthData:    NewString(id),
+		AuthService: p.Service(),
+	}, nil
+}
+
+// GroupsFromClaims returns the names of the groups listed by the GroupsClaim of the provider,
+// either as an array or as a single string.
+func (p *OpenIdConnectProvider) GroupsFromClaims(claims map[string]interface{}) []string {
+	if p.GroupsClaim == "" {
+		return nil
+	}
+
+	switch value := claimValue(claims, p.GroupsClaim).(type) {
+	case string:
+		if value == "" {
+			return nil
+		}
+		return []string{value}
+	case []interface{}:
+		groups := make([]string, 0, len(value))
+		for _, group := range value {
+			if name, ok := group.(string); ok && name != "" {
+				groups = append(groups, name)
+			}
+		}
+		return groups
+	}
+
+	return nil
+}
+
+// claimValue returns the value of a claim, following the dots of its name into nested objects.
+func claimValue(claims map[string]interface{}, name string) interface{} {
+	var value interface{} = claims
+	for _, part := range strings.Split(name, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+	return value
+}
+
+func claimString(claims map[string]interface{}, name string) string {
+	if name == "" {
+		return ""
+	}
+
+	switch value := claimValue(claims, name).(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenIdConnectProviderIsValid(t *testing.T) {
+	provider := &OpenIdConnectProvider{Id: "keycloak", DisplayName: "Keycloak"}
+	provider.SetDefaults()
+	require.Nil(t, provider.IsValid())
+
+	provider.Id = "Key-Cloak"
+	assert.NotNil(t, provider.IsValid())
+	provider.Id = "keycloak"
+
+	provider.Enable = true
+	assert.NotNil(t, provider.IsValid(), "an enabled provider needs a client id")
+	provider.ClientId = "mattermost"
+	assert.NotNil(t, provider.IsValid(), "an enabled provider needs endpoints")
+
+	provider.DiscoveryEndpoint = "https://sso.example.com/.well-known/openid-configuration"
+	require.Nil(t, provider.IsValid())
+
+	provider.TokenEndpoint = "not a url"
+	assert.NotNil(t, provider.IsValid())
+	provider.TokenEndpoint = ""
+
+	provider.EmailClaim = ""
+	assert.NotNil(t, provider.IsValid())
+}
+
+func TestOpenIdConnectSettingsIsValid(t *testing.T) {
+	s := &OpenIdConnectSettings{Providers: []*OpenIdConnectProvider{
+		{Id: "keycloak", DisplayName: "Keycloak"},
+		{Id: "keycloak", DisplayName: "Other Keycloak"},
+	}}
+	s.SetDefaults()
+
+	appErr := s.isValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.config.is_valid.openid_connect.duplicate_id.app_error", appErr.Id)
+
+	s.Providers[1].Id = "okta"
+	require.Nil(t, s.isValid())
+	assert.Equal(t, "Other Keycloak", s.GetProvider("oidcokta").DisplayName)
+	assert.Nil(t, s.GetProvider("okta"))
+}
+
+func TestOpenIdConnectProviderClaims(t *testing.T) {
+	provider := &OpenIdConnectProvider{Id: "keycloak", GroupsClaim: "realm_access.roles"}
+	provider.SetDefaults()
+
+	claims := map[string]interface{}{
+		"sub":                "f1b2c3",
+		"email":              "Jane@Example.com",
+		"preferred_username": "jane.doe",
+		"given_name":         "Jane",
+		"family_name":        "Doe",
+		"realm_access": map[string]interface{}{
+			"roles": []interface{}{"developers", "", 42, "admins"},
+		},
+	}
+
+	user, err := provider.UserFromClaims(claims)
+	require.NoError(t, err)
+	assert.Equal(t, "f1b2c3", *user.AuthData)
+	assert.Equal(t, "oidckeycloak", user.AuthService)
+	assert.Equal(t, "jane@example.com", user.Email)
+	assert.Equal(t, "jane.doe", user.Username)
+	assert.Equal(t, "Jane Doe", user.GetFullName())
+	assert.True(t, user.IsOAuthUser())
+	assert.Equal(t, []string{"developers", "admins"}, provider.GroupsFromClaims(claims))
+
+	t.Run("the username defaults to the email address", func(t *testing.T) {
+		delete(claims, "preferred_username")
+		user, err := provider.UserFromClaims(claims)
+		require.NoError(t, err)
+		assert.Equal(t, "jane", user.Username)
+	})
+
+	t.Run("custom claims", func(t *testing.T) {
+		custom := &OpenIdConnectProvider{Id: "custom", IdClaim: "uid", EmailClaim: "mail", GroupsClaim: "group"}
+		custom.SetDefaults()
+
+		user, err := custom.UserFromClaims(map[string]interface{}{"uid": float64(1234), "mail": "john@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "1234", *user.AuthData)
+		assert.Equal(t, []string{"developers"}, custom.GroupsFromClaims(map[string]interface{}{"group": "developers"}))
+	})
+
+	t.Run("missing claims", func(t *testing.T) {
+		_, err := provider.UserFromClaims(map[string]interface{}{"email": "jane@example.com"})
+		assert.Error(t, err)
+		_, err = provider.UserFromClaims(map[string]interface{}{"sub": "f1b2c3"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// OrphanedFilesReportMaxPaths is how many paths of the files found are listed in the report.
+const OrphanedFilesReportMaxPaths = 1000
+
+// OrphanedFilesReport summarises what the orphaned files cleanup job found, and deleted unless
+// it was a dry run: the FileInfos of posts which were purged, with their stored files, and the
+// stored files which no FileInfo refers to.
+type OrphanedFilesReport struct {
+	DryRun         bool  `json:"dry_run"`
+	CreatedBefore  int64 `json:"created_before"`
+	FileInfos      int   `json:"file_infos"`
+	StoredFiles    int   `json:"stored_files"`
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+	// SharedFileInfos are the orphaned FileInfos whose stored file is reused by other FileInfos,
+	// which are kept.
+	SharedFileInfos int      `json:"shared_file_infos"`
+	Errors          int      `json:"errors"`
+	Paths           []string `json:"paths"`
+}
+
+// AddPath records the path of a file found, while the report lists fewer than
+// OrphanedFilesReportMaxPaths.
+func (r *OrphanedFilesReport) AddPath(path string) {
+	if len(r.Paths) < OrphanedFilesReportMaxPaths {
+		r.Paths = append(r.Paths, path)
+	}
+}
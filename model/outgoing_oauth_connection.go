@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	OutgoingOAuthConnectionGrantTypeClientCredentials = "client_credentials"
+	OutgoingOAuthConnectionGrantTypePassword          = "password"
+
+	OutgoingOAuthConnectionNameMaxRunes = 64
+	OutgoingOAuthConnectionMaxAudiences = 20
+)
+
+// OutgoingOAuthConnection is an external OAuth 2.0 provider the server requests access tokens
+// from, to attach them to the requests of the outgoing webhooks and slash commands referencing the
+// connection. The tokens are only sent to the URLs starting with one of the audiences, so that an
+// integration can't send them anywhere else.
+type OutgoingOAuthConnection struct {
+	Id            string `json:"id"`
+	CreatorId     string `json:"creator_id"`
+	CreateAt      int64  `json:"create_at"`
+	UpdateAt      int64  `json:"update_at"`
+	Name          string `json:"name"`
+	ClientId      string `json:"client_id,omitempty"`
+	ClientSecret  string `json:"client_secret,omitempty"`
+	OAuthTokenURL string `json:"oauth_token_url,omitempty"`
+	GrantType     string `json:"grant_type"`
+	// Scopes are the space separated scopes requested with the tokens, if any.
+	Scopes string `json:"scopes,omitempty"`
+	// CredentialsUsername and CredentialsPassword are the resource owner credentials of the
+	// password grant type.
+	CredentialsUsername string      `json:"credentials_username,omitempty"`
+	CredentialsPassword string      `json:"credentials_password,omitempty"`
+	Audiences           StringArray `json:"audiences"`
+}
+
+func (c *OutgoingOAuthConnection) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	c.CreateAt = GetMillis()
+	c.UpdateAt = c.CreateAt
+}
+
+func (c *OutgoingOAuthConnection) PreUpdate() {
+	c.UpdateAt = GetMillis()
+}
+
+// Sanitize removes the secrets of the connection.
+func (c *OutgoingOAuthConnection) Sanitize() {
+	c.ClientSecret = ""
+	c.CredentialsPassword = ""
+}
+
+func (c *OutgoingOAuthConnection) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.UpdateAt == 0 {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.update_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreatorId != "" && !IsValidId(c.CreatorId) {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.creator_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.Name == "" || utf8.RuneCountInString(c.Name) > OutgoingOAuthConnectionNameMaxRunes {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.name.app_error", map[string]interface{}{"Max": OutgoingOAuthConnectionNameMaxRunes}, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.ClientId == "" || c.ClientSecret == "" {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.client_credentials.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidHTTPURL(c.OAuthTokenURL) {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.oauth_token_url.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	switch c.GrantType {
+	case OutgoingOAuthConnectionGrantTypeClientCredentials:
+	case OutgoingOAuthConnectionGrantTypePassword:
+		if c.CredentialsUsername == "" || c.CredentialsPassword == "" {
+			return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.password_credentials.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.grant_type.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if len(c.Audiences) == 0 || len(c.Audiences) > OutgoingOAuthConnectionMaxAudiences {
+		return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.audiences.app_error", map[string]interface{}{"Max": OutgoingOAuthConnectionMaxAudiences}, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	for _, audience := range c.Audiences {
+		if !IsValidHTTPURL(audience) {
+			return NewAppError("OutgoingOAuthConnection.IsValid", "model.outgoing_oauth_connection.is_valid.audience.app_error", map[string]interface{}{"Audience": audience}, "id="+c.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// MatchesAudience returns whether the tokens of the connection may be sent to the URL, which must
+// start with one of the audiences. An audience ending with a path only matches the URLs under that
+// path, e.g. https://example.com/api matches https://example.com/api/hook but not
+// https://example.com/apikeys.
+func (c *OutgoingOAuthConnection) MatchesAudience(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	for _, audience := range c.Audiences {
+		a, err := url.Parse(audience)
+		if err != nil {
+			continue
+		}
+
+		if !strings.EqualFold(a.Scheme, u.Scheme) || !strings.EqualFold(a.Host, u.Host) {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(a.Path, "/")
+		if u.Path == prefix || strings.HasPrefix(u.Path, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OutgoingOAuthToken is an access token obtained through an outgoing OAuth connection.
+type OutgoingOAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is the lifetime of the token in seconds, if the provider told it.
+	ExpiresIn int64 `json:"expires_in"`
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidOutgoingOAuthConnection() *OutgoingOAuthConnection {
+	c := &OutgoingOAuthConnection{
+		CreatorId:     NewId(),
+		Name:          "Provider",
+		ClientId:      "client",
+		ClientSecret:  "secret",
+		OAuthTokenURL: "https://auth.example.com/token",
+		GrantType:     OutgoingOAuthConnectionGrantTypeClientCredentials,
+		Audiences:     StringArray{"https://api.example.com/hooks"},
+	}
+	c.PreSave()
+	return c
+}
+
+func TestOutgoingOAuthConnectionIsValid(t *testing.T) {
+	require.Nil(t, newValidOutgoingOAuthConnection().IsValid())
+
+	for name, tc := range map[string]struct {
+		change func(c *OutgoingOAuthConnection)
+		errID  string
+	}{
+		"invalid id":           {func(c *OutgoingOAuthConnection) { c.Id = "junk" }, "model.outgoing_oauth_connection.is_valid.id.app_error"},
+		"invalid creator":      {func(c *OutgoingOAuthConnection) { c.CreatorId = "junk" }, "model.outgoing_oauth_connection.is_valid.creator_id.app_error"},
+		"empty name":           {func(c *OutgoingOAuthConnection) { c.Name = "" }, "model.outgoing_oauth_connection.is_valid.name.app_error"},
+		"long name":            {func(c *OutgoingOAuthConnection) { c.Name = strings.Repeat("a", OutgoingOAuthConnectionNameMaxRunes+1) }, "model.outgoing_oauth_connection.is_valid.name.app_error"},
+		"missing secret":       {func(c *OutgoingOAuthConnection) { c.ClientSecret = "" }, "model.outgoing_oauth_connection.is_valid.client_credentials.app_error"},
+		"invalid token url":    {func(c *OutgoingOAuthConnection) { c.OAuthTokenURL = "ftp://auth.example.com" }, "model.outgoing_oauth_connection.is_valid.oauth_token_url.app_error"},
+		"unknown grant type":   {func(c *OutgoingOAuthConnection) { c.GrantType = "implicit" }, "model.outgoing_oauth_connection.is_valid.grant_type.app_error"},
+		"password grant type":  {func(c *OutgoingOAuthConnection) { c.GrantType = OutgoingOAuthConnectionGrantTypePassword }, "model.outgoing_oauth_connection.is_valid.password_credentials.app_error"},
+		"no audiences":         {func(c *OutgoingOAuthConnection) { c.Audiences = nil }, "model.outgoing_oauth_connection.is_valid.audiences.app_error"},
+		"invalid audience url": {func(c *OutgoingOAuthConnection) { c.Audiences = StringArray{"api.example.com"} }, "model.outgoing_oauth_connection.is_valid.audience.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newValidOutgoingOAuthConnection()
+			tc.change(c)
+			appErr := c.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errID, appErr.Id)
+		})
+	}
+
+	t.Run("password grant type with credentials", func(t *testing.T) {
+		c := newValidOutgoingOAuthConnection()
+		c.GrantType = OutgoingOAuthConnectionGrantTypePassword
+		c.CredentialsUsername = "user"
+		c.CredentialsPassword = "password"
+		require.Nil(t, c.IsValid())
+	})
+}
+
+func TestOutgoingOAuthConnectionMatchesAudience(t *testing.T) {
+	c := &OutgoingOAuthConnection{
+		Audiences: StringArray{"https://api.example.com/hooks/", "https://other.example.com"},
+	}
+
+	assert.True(t, c.MatchesAudience("https://api.example.com/hooks"))
+	assert.True(t, c.MatchesAudience("https://api.example.com/hooks/build?x=1"))
+	assert.True(t, c.MatchesAudience("https://API.example.com/hooks/build"))
+	assert.True(t, c.MatchesAudience("https://other.example.com/anything"))
+
+	assert.False(t, c.MatchesAudience("https://api.example.com/hookshot"))
+	assert.False(t, c.MatchesAudience("https://api.example.com/"))
+	assert.False(t, c.MatchesAudience("http://api.example.com/hooks"))
+	assert.False(t, c.MatchesAudience("https://api.example.com:8443/hooks"))
+	assert.False(t, c.MatchesAudience("https://evil.com/hooks"))
+	assert.False(t, c.MatchesAudience("://"))
+}
+
+func TestOutgoingOAuthConnectionSanitize(t *testing.T) {
+	c := newValidOutgoingOAuthConnection()
+	c.CredentialsPassword = "password"
+	c.Sanitize()
+	assert.Empty(t, c.ClientSecret)
+	assert.Empty(t, c.CredentialsPassword)
+	assert.Equal(t, "client", c.ClientId)
+}
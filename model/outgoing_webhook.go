@@ -28,6 +28,19 @@ type OutgoingWebhook struct {
 	ContentType  string      `json:"content_type"`
 	Username     string      `json:"username"`
 	IconURL      string      `json:"icon_url"`
+
+	// PayloadFormat is the format of the requests sent to the callback URLs and of their
+	// responses, either PayloadFormatMattermost or PayloadFormatSlack.
+	PayloadFormat string `json:"payload_format"`
+
+	// OutgoingOAuthConnectionId is the outgoing OAuth connection whose access tokens are sent to
+	// the callback URLs as bearer tokens, if any.
+	OutgoingOAuthConnectionId string `json:"outgoing_oauth_connection_id"`
+
+	// SigningSecret is the secret the requests sent to the callback URLs are signed with, as
+	// described by IntegrationRequestSignature. The webhooks created before it was introduced have
+	// none until it is regenerated, and their requests aren't signed.
+	SigningSecret string `json:"signing_secret"`
 }
 
 type OutgoingWebhookPayload struct {
@@ -53,6 +66,9 @@ type OutgoingWebhookResponse struct {
 	Attachments  []*SlackAttachment `json:"attachments"`
 	Type         string             `json:"type"`
 	ResponseType string             `json:"response_type"`
+
+	// Blocks are the Slack Block Kit blocks of a response to a webhook using the Slack payload format.
+	Blocks []*SlackBlock `json:"blocks,omitempty"`
 }
 
 const OutgoingHookResponseTypeComment = "comment"
@@ -75,6 +91,41 @@ func (o *OutgoingWebhookPayload) ToFormValues() string {
 	return v.Encode()
 }
 
+// ToSlackFormValues encodes the payload exactly as Slack does for its outgoing webhooks.
+// Slack has no equivalent of the post and file ids, which are left out.
+func (o *OutgoingWebhookPayload) ToSlackFormValues(hookID string) string {
+	v := url.Values{}
+	v.Set("token", o.Token)
+	v.Set("team_id", o.TeamId)
+	v.Set("team_domain", o.TeamDomain)
+	v.Set("service_id", hookID)
+	v.Set("channel_id", o.ChannelId)
+	v.Set("channel_name", o.ChannelName)
+	v.Set("timestamp", SlackTimestamp(o.Timestamp))
+	v.Set("user_id", o.UserId)
+	v.Set("user_name", o.UserName)
+	v.Set("text", o.Text)
+	v.Set("trigger_word", o.TriggerWord)
+
+	return v.Encode()
+}
+
+// TranslateSlackFormat translates a response to a webhook using the Slack payload format: the
+// blocks, if any, replace the text, and Slack mrkdwn is converted to Markdown.
+func (o *OutgoingWebhookResponse) TranslateSlackFormat() {
+	if o.Text == nil && len(o.Blocks) == 0 {
+		return
+	}
+
+	text := ""
+	if o.Text != nil {
+		text = *o.Text
+	}
+	text = slackMessageText(text, o.Blocks)
+	o.Text = &text
+	o.Blocks = nil
+}
+
 func (o *OutgoingWebhook) IsValid() *AppError {
 
 	if !IsValidId(o.Id) {
@@ -151,6 +202,18 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if !IsValidPayloadFormat(o.PayloadFormat) {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.payload_format.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.OutgoingOAuthConnectionId != "" && !IsValidId(o.OutgoingOAuthConnectionId) {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.outgoing_oauth_connection_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.SigningSecret != "" && len(o.SigningSecret) != 26 {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.signing_secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -163,6 +226,10 @@ func (o *OutgoingWebhook) PreSave() {
 		o.Token = NewId()
 	}
 
+	if o.SigningSecret == "" {
+		o.SigningSecret = NewId()
+	}
+
 	o.CreateAt = GetMillis()
 	o.UpdateAt = o.CreateAt
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	// PersistentWebSocketEventRetentionMinutes is how long persistent events can be replayed to
+	// reconnecting clients.
+	PersistentWebSocketEventRetentionMinutes = 60
+	// PersistentWebSocketEventReplayLimit is the maximum number of events replayed to a
+	// reconnecting client. It is below the size of a connection send queue.
+	PersistentWebSocketEventReplayLimit = 200
+
+	// The keys added to the data of persistent events. A reconnecting client passes the
+	// creation time of the last persistent event it received so that the ones it missed are
+	// replayed, and uses the id to ignore the events it receives twice.
+	PersistentWebSocketEventIdKey       = "persistent_event_id"
+	PersistentWebSocketEventCreateAtKey = "persistent_event_create_at"
+
+	persistentWebSocketEventMaxEventLength = 256
+	persistentWebSocketEventMaxDataSize    = 64 * 1024
+)
+
+// PersistentWebSocketEvent is a websocket event published by a plugin that is kept for a while
+// so that it can be replayed to the clients that were disconnected when it was published.
+type PersistentWebSocketEvent struct {
+	Id        string `json:"id"`
+	PluginId  string `json:"plugin_id"`
+	Event     string `json:"event"`
+	Data      string `json:"data"`
+	UserId    string `json:"user_id"`
+	ChannelId string `json:"channel_id"`
+	TeamId    string `json:"team_id"`
+	OmitUsers string `json:"omit_users"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+// NewPersistentWebSocketEvent creates the persistent version of a websocket event published by a
+// plugin. The connection of the broadcast is ignored since it does not outlive a disconnection.
+func NewPersistentWebSocketEvent(pluginID string, ev *WebSocketEvent) (*PersistentWebSocketEvent, error) {
+	data, err := json.Marshal(ev.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	event := &PersistentWebSocketEvent{
+		PluginId: pluginID,
+		Event:    ev.EventType(),
+		Data:     string(data),
+	}
+
+	if broadcast := ev.GetBroadcast(); broadcast != nil {
+		event.UserId = broadcast.UserId
+		event.ChannelId = broadcast.ChannelId
+		event.TeamId = broadcast.TeamId
+		if len(broadcast.OmitUsers) > 0 {
+			omitUsers, err := json.Marshal(broadcast.OmitUsers)
+			if err != nil {
+				return nil, err
+			}
+			event.OmitUsers = string(omitUsers)
+		}
+	}
+
+	return event, nil
+}
+
+func (e *PersistentWebSocketEvent) PreSave() {
+	if e.Id == "" {
+		e.Id = NewId()
+	}
+
+	if e.CreateAt == 0 {
+		e.CreateAt = GetMillis()
+	}
+}
+
+func (e *PersistentWebSocketEvent) IsValid() *AppError {
+	if !IsValidId(e.Id) {
+		return NewAppError("PersistentWebSocketEvent.IsValid", "model.persistent_websocket_event.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if e.PluginId == "" {
+		return NewAppError("PersistentWebSocketEvent.IsValid", "model.persistent_websocket_event.is_valid.plugin_id.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.Event == "" || len(e.Event) > persistentWebSocketEventMaxEventLength {
+		return NewAppError("PersistentWebSocketEvent.IsValid", "model.persistent_websocket_event.is_valid.event.app_error", map[string]interface{}{"MaxLength": persistentWebSocketEventMaxEventLength}, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if len(e.Data) > persistentWebSocketEventMaxDataSize {
+		return NewAppError("PersistentWebSocketEvent.IsValid", "model.persistent_websocket_event.is_valid.data.app_error", map[string]interface{}{"MaxSize": persistentWebSocketEventMaxDataSize}, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.CreateAt == 0 {
+		return NewAppError("PersistentWebSocketEvent.IsValid", "model.persistent_websocket_event.is_valid.create_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsOmitted returns true if the event must not be sent to the user.
+func (e *PersistentWebSocketEvent) IsOmitted(userID string) bool {
+	if e.OmitUsers == "" {
+		return false
+	}
+
+	var omitUsers map[string]bool
+	if err := json.Unmarshal([]byte(e.OmitUsers), &omitUsers); err != nil {
+		return false
+	}
+	return omitUsers[userID]
+}
+
+// ToWebSocketEvent returns the websocket event to send, with the id and creation time of the
+// persistent event added to its data.
+func (e *PersistentWebSocketEvent) ToWebSocketEvent() (*WebSocketEvent, error) {
+	data := map[string]interface{}{}
+	if e.Data != "" {
+		if err := json.Unmarshal([]byte(e.Data), &data); err != nil {
+			return nil, err
+		}
+	}
+	data[PersistentWebSocketEventIdKey] = e.Id
+	data[PersistentWebSocketEventCreateAtKey] = e.CreateAt
+
+	ev := NewWebSocketEvent(e.Event, e.TeamId, e.ChannelId, e.UserId, nil)
+	return ev.SetData(data), nil
+}
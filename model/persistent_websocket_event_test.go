@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentWebSocketEventIsValid(t *testing.T) {
+	event := &PersistentWebSocketEvent{
+		PluginId: "com.mattermost.demo",
+		Event:    "custom_com.mattermost.demo_event",
+	}
+	event.PreSave()
+	require.Nil(t, event.IsValid())
+
+	event.Event = ""
+	require.NotNil(t, event.IsValid())
+
+	event.Event = strings.Repeat("a", persistentWebSocketEventMaxEventLength+1)
+	require.NotNil(t, event.IsValid())
+
+	event.Event = "custom_com.mattermost.demo_event"
+	event.Data = strings.Repeat("a", persistentWebSocketEventMaxDataSize+1)
+	require.NotNil(t, event.IsValid())
+
+	event.Data = ""
+	event.PluginId = ""
+	require.NotNil(t, event.IsValid())
+}
+
+func TestPersistentWebSocketEventRoundTrip(t *testing.T) {
+	ev := NewWebSocketEvent("custom_plugin_event", "team", "channel", "", map[string]bool{"omitted": true})
+	ev = ev.SetData(map[string]interface{}{"key": "value"})
+
+	event, err := NewPersistentWebSocketEvent("plugin", ev)
+	require.NoError(t, err)
+	event.PreSave()
+
+	assert.Equal(t, "plugin", event.PluginId)
+	assert.Equal(t, "team", event.TeamId)
+	assert.Equal(t, "channel", event.ChannelId)
+	assert.True(t, event.IsOmitted("omitted"))
+	assert.False(t, event.IsOmitted("other"))
+
+	replayed, err := event.ToWebSocketEvent()
+	require.NoError(t, err)
+	assert.Equal(t, "custom_plugin_event", replayed.EventType())
+	assert.Equal(t, "channel", replayed.GetBroadcast().ChannelId)
+	assert.Equal(t, "value", replayed.GetData()["key"])
+	assert.Equal(t, event.Id, replayed.GetData()[PersistentWebSocketEventIdKey])
+	assert.Equal(t, event.CreateAt, replayed.GetData()[PersistentWebSocketEventCreateAtKey])
+}
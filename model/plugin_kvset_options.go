@@ -45,3 +45,42 @@ func NewPluginKeyValueFromOptions(pluginId, key string, value []byte, opt Plugin
 
 	return kv, nil
 }
+
+// PluginKVSetMultipleMaxOperations is the maximum number of operations applied at once.
+const PluginKVSetMultipleMaxOperations = 100
+
+// PluginKVSetOperation is one of the writes applied together to the plugin KV store. A nil
+// Value deletes the key. With Options.Atomic, the write requires the current value to match
+// Options.OldValue, or the key not to exist if OldValue is nil.
+type PluginKVSetOperation struct {
+	Key     string
+	Value   []byte
+	Options PluginKVSetOptions
+}
+
+// IsValidPluginKVSetOperations returns nil if the operations can be applied together: there are
+// at most PluginKVSetMultipleMaxOperations of them and no key is written twice.
+func IsValidPluginKVSetOperations(pluginId string, operations []*PluginKVSetOperation) *AppError {
+	if len(operations) == 0 || len(operations) > PluginKVSetMultipleMaxOperations {
+		return NewAppError("IsValidPluginKVSetOperations", "model.plugin_kvset_operations.is_valid.count.app_error", map[string]interface{}{"Max": PluginKVSetMultipleMaxOperations}, "", http.StatusBadRequest)
+	}
+
+	keys := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		if err := op.Options.IsValid(); err != nil {
+			return err
+		}
+
+		kv := &PluginKeyValue{PluginId: pluginId, Key: op.Key}
+		if err := kv.IsValid(); err != nil {
+			return err
+		}
+
+		if keys[op.Key] {
+			return NewAppError("IsValidPluginKVSetOperations", "model.plugin_kvset_operations.is_valid.duplicate_key.app_error", nil, "key="+op.Key, http.StatusBadRequest)
+		}
+		keys[op.Key] = true
+	}
+
+	return nil
+}
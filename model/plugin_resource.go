@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	// PluginResourceLimitPolicyRestart restarts a plugin exceeding its limits. A plugin
+	// restarted too often is deactivated, as it is when failing health checks.
+	PluginResourceLimitPolicyRestart = "restart"
+	// PluginResourceLimitPolicyDeactivate deactivates a plugin exceeding its limits.
+	PluginResourceLimitPolicyDeactivate = "deactivate"
+	// PluginResourceLimitPolicyLog only logs a warning when a plugin exceeds its limits.
+	PluginResourceLimitPolicyLog = "log"
+)
+
+// PluginResourceLimits are the limits of the server process of a plugin. A zero limit means
+// no limit.
+type PluginResourceLimits struct {
+	// MaxMemoryMB is the maximum resident memory of the plugin process, in megabytes.
+	MaxMemoryMB int
+	// MaxCPUPercent is the maximum CPU usage of the plugin process, in percent of one core.
+	// A plugin using several cores can go above 100.
+	MaxCPUPercent int
+	// Policy is what happens to a plugin found above its limits. It defaults to restarting it.
+	Policy string
+}
+
+func (l *PluginResourceLimits) GetPolicy() string {
+	if l.Policy == "" {
+		return PluginResourceLimitPolicyRestart
+	}
+	return l.Policy
+}
+
+func (l *PluginResourceLimits) IsValid(pluginID string) *AppError {
+	if l.MaxMemoryMB < 0 {
+		return NewAppError("PluginResourceLimits.IsValid", "model.plugin_resource_limits.is_valid.max_memory.app_error", map[string]interface{}{"PluginId": pluginID}, "", http.StatusBadRequest)
+	}
+
+	if l.MaxCPUPercent < 0 {
+		return NewAppError("PluginResourceLimits.IsValid", "model.plugin_resource_limits.is_valid.max_cpu.app_error", map[string]interface{}{"PluginId": pluginID}, "", http.StatusBadRequest)
+	}
+
+	switch l.GetPolicy() {
+	case PluginResourceLimitPolicyRestart, PluginResourceLimitPolicyDeactivate, PluginResourceLimitPolicyLog:
+	default:
+		return NewAppError("PluginResourceLimits.IsValid", "model.plugin_resource_limits.is_valid.policy.app_error", map[string]interface{}{"PluginId": pluginID}, "policy="+l.Policy, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PluginResourceUsage is the resource usage of the server process of a plugin.
+type PluginResourceUsage struct {
+	PluginId string `json:"plugin_id"`
+	Pid      int    `json:"pid"`
+	// MemoryBytes is the resident memory of the process.
+	MemoryBytes uint64 `json:"memory_bytes"`
+	// CPUTimeMillis is the CPU time used by the process since it started.
+	CPUTimeMillis int64 `json:"cpu_time_ms"`
+	// CPUPercent is the CPU usage of the process since the previous sample, in percent of one
+	// core. It is zero for the first sample of a process.
+	CPUPercent float64 `json:"cpu_percent"`
+	// Cgroup is the cgroup enforcing the limits of the plugin, if any.
+	Cgroup string                `json:"cgroup,omitempty"`
+	Limits *PluginResourceLimits `json:"limits,omitempty"`
+	// LimitExceeded is true if the sample is above the limits of the plugin.
+	LimitExceeded bool  `json:"limit_exceeded"`
+	SampledAt     int64 `json:"sampled_at"`
+}
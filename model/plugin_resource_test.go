@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginResourceLimitsIsValid(t *testing.T) {
+	limits := &PluginResourceLimits{MaxMemoryMB: 512, MaxCPUPercent: 150}
+	require.Nil(t, limits.IsValid("plugin"))
+	assert.Equal(t, PluginResourceLimitPolicyRestart, limits.GetPolicy())
+
+	limits.Policy = PluginResourceLimitPolicyLog
+	require.Nil(t, limits.IsValid("plugin"))
+
+	limits.Policy = "kill"
+	require.NotNil(t, limits.IsValid("plugin"))
+
+	limits.Policy = PluginResourceLimitPolicyDeactivate
+	limits.MaxMemoryMB = -1
+	require.NotNil(t, limits.IsValid("plugin"))
+
+	limits.MaxMemoryMB = 0
+	limits.MaxCPUPercent = -1
+	require.NotNil(t, limits.IsValid("plugin"))
+}
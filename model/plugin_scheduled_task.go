@@ -0,0 +1,32 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"time"
+)
+
+const (
+	PluginScheduledTaskCallbackMaxLength = 128
+
+	// The data of the jobs running scheduled tasks.
+	PluginScheduledTaskJobDataPluginId = "plugin_id"
+	PluginScheduledTaskJobDataCallback = "callback"
+)
+
+// PluginScheduledTask is a recurring task registered by a plugin. When it is due, a job is
+// created so that a single server of the cluster invokes the OnScheduledTask hook of the plugin
+// with the callback.
+type PluginScheduledTask struct {
+	PluginId string        `json:"plugin_id"`
+	Callback string        `json:"callback"`
+	Cron     string        `json:"cron"`
+	Schedule *CronSchedule `json:"-"`
+}
+
+// IsDue returns true if the task was scheduled to run after since and no later than now.
+func (t *PluginScheduledTask) IsDue(since, now time.Time) bool {
+	next := t.Schedule.Next(since)
+	return !next.IsZero() && !next.After(now)
+}
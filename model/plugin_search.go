@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+)
+
+const (
+	PluginSearchDocumentIdMaxLength     = 128
+	PluginSearchDocumentMaxFields       = 32
+	PluginSearchDocumentFieldMaxSize    = 64 * 1024
+	PluginSearchParamsMaxTenants        = 1000
+	PluginSearchParamsDefaultPerPage    = 20
+	PluginSearchParamsMaxPerPage        = 200
+	pluginSearchTenantIdMaxLength       = 128
+	pluginSearchTermsMaxLength          = 1024
+	pluginSearchIndexOrFieldNamePattern = `^[a-z0-9][a-z0-9_-]{0,63}$`
+)
+
+var pluginSearchNameRegexp = regexp.MustCompile(pluginSearchIndexOrFieldNamePattern)
+
+// IsValidPluginSearchIndexName returns true if the name can be used for the index of a plugin:
+// up to 64 lowercase letters, digits, underscores and dashes.
+func IsValidPluginSearchIndexName(name string) bool {
+	return pluginSearchNameRegexp.MatchString(name)
+}
+
+// PluginSearchDocument is a document of a plugin search index. Its fields are indexed for
+// full-text search, and it can only be found by the queries restricted to its tenant, e.g. the
+// team or board it belongs to.
+type PluginSearchDocument struct {
+	Id       string            `json:"id"`
+	TenantId string            `json:"tenant_id"`
+	Fields   map[string]string `json:"fields"`
+}
+
+func (d *PluginSearchDocument) IsValid() *AppError {
+	if d.Id == "" || len(d.Id) > PluginSearchDocumentIdMaxLength {
+		return NewAppError("PluginSearchDocument.IsValid", "model.plugin_search_document.is_valid.id.app_error", map[string]interface{}{"MaxLength": PluginSearchDocumentIdMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if d.TenantId == "" || len(d.TenantId) > pluginSearchTenantIdMaxLength {
+		return NewAppError("PluginSearchDocument.IsValid", "model.plugin_search_document.is_valid.tenant_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if len(d.Fields) > PluginSearchDocumentMaxFields {
+		return NewAppError("PluginSearchDocument.IsValid", "model.plugin_search_document.is_valid.fields.app_error", map[string]interface{}{"Max": PluginSearchDocumentMaxFields}, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	for name, value := range d.Fields {
+		if !pluginSearchNameRegexp.MatchString(name) {
+			return NewAppError("PluginSearchDocument.IsValid", "model.plugin_search_document.is_valid.field_name.app_error", map[string]interface{}{"Name": name}, "id="+d.Id, http.StatusBadRequest)
+		}
+		if len(value) > PluginSearchDocumentFieldMaxSize {
+			return NewAppError("PluginSearchDocument.IsValid", "model.plugin_search_document.is_valid.field_size.app_error", map[string]interface{}{"Name": name, "MaxSize": PluginSearchDocumentFieldMaxSize}, "id="+d.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// PluginSearchParams is a full-text query of a plugin search index. The query only matches the
+// documents of the given tenants, which the plugin is responsible for restricting to the ones
+// the user can access.
+type PluginSearchParams struct {
+	Terms     string   `json:"terms"`
+	TenantIds []string `json:"tenant_ids"`
+	// Fields restricts the query to some fields of the documents. Every field is searched if empty.
+	Fields  []string `json:"fields"`
+	Page    int      `json:"page"`
+	PerPage int      `json:"per_page"`
+}
+
+func (p *PluginSearchParams) IsValid() *AppError {
+	if p.Terms == "" || len(p.Terms) > pluginSearchTermsMaxLength {
+		return NewAppError("PluginSearchParams.IsValid", "model.plugin_search_params.is_valid.terms.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(p.TenantIds) == 0 || len(p.TenantIds) > PluginSearchParamsMaxTenants {
+		return NewAppError("PluginSearchParams.IsValid", "model.plugin_search_params.is_valid.tenant_ids.app_error", map[string]interface{}{"Max": PluginSearchParamsMaxTenants}, "", http.StatusBadRequest)
+	}
+
+	for _, tenantId := range p.TenantIds {
+		if tenantId == "" || len(tenantId) > pluginSearchTenantIdMaxLength {
+			return NewAppError("PluginSearchParams.IsValid", "model.plugin_search_document.is_valid.tenant_id.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, field := range p.Fields {
+		if !pluginSearchNameRegexp.MatchString(field) {
+			return NewAppError("PluginSearchParams.IsValid", "model.plugin_search_document.is_valid.field_name.app_error", map[string]interface{}{"Name": field}, "", http.StatusBadRequest)
+		}
+	}
+
+	if p.Page < 0 || p.PerPage < 0 || p.PerPage > PluginSearchParamsMaxPerPage {
+		return NewAppError("PluginSearchParams.IsValid", "model.plugin_search_params.is_valid.paging.app_error", map[string]interface{}{"MaxPerPage": PluginSearchParamsMaxPerPage}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// GetPerPage returns the number of results per page, PluginSearchParamsDefaultPerPage if unset.
+func (p *PluginSearchParams) GetPerPage() int {
+	if p.PerPage == 0 {
+		return PluginSearchParamsDefaultPerPage
+	}
+	return p.PerPage
+}
+
+type PluginSearchHit struct {
+	Id    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+type PluginSearchResults struct {
+	Hits  []*PluginSearchHit `json:"hits"`
+	Total int64              `json:"total"`
+}
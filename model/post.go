@@ -69,6 +69,19 @@ const (
 	PostPropsGroupHighlightDisabled   = "disable_group_highlight"
 
 	PostPropsPreviewedPost = "previewed_post"
+
+	PostPropsSecretsMasked = "secrets_masked"
+
+	// PostPropsRetentionDeletedFiles holds the names of the files of a post deleted by a file
+	// retention policy, so that clients can show a tombstone in their place.
+	PostPropsRetentionDeletedFiles = "retention_deleted_files"
+
+	// PostPropsVoiceWaveform and PostPropsVoiceDuration hold the waveform preview and the
+	// duration in milliseconds of the audio file of a voice message, set by the server.
+	PostPropsVoiceWaveform = "voice_waveform"
+	PostPropsVoiceDuration = "voice_duration"
+	// PostPropsVoiceTranscript holds the transcript of a voice message, once transcribed.
+	PostPropsVoiceTranscript = "voice_transcript"
 )
 
 const (
@@ -135,6 +148,15 @@ type SearchParameter struct {
 	Modifier               *string `json:"modifier"` // whether it's messages or file
 }
 
+// AdvancedSearchParameter is the body of an advanced post search. Its terms accept the has:link and
+// has:file operators besides the regular search syntax, and Regex, when set, must match the message.
+type AdvancedSearchParameter struct {
+	Terms          *string `json:"terms"`
+	Regex          *string `json:"regex"`
+	TeamId         *string `json:"team_id"`
+	TimeZoneOffset *int    `json:"time_zone_offset"`
+}
+
 type AnalyticsPostCountsOptions struct {
 	TeamId        string
 	BotsOnly      bool
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/mattermost/mattermost-server/v6/shared/jsonschema"
+)
+
+const (
+	PostPropSchemaNamespaceMaxLength = 64
+	PostPropSchemaMaxSize            = 64 * 1024 // 64KB
+)
+
+// Namespaces are dotted like plugin ids, e.g. com.example.poll, which keeps them apart from the
+// props set by the server itself.
+var validPostPropSchemaNamespace = regexp.MustCompile(`^[a-z0-9_-]+(\.[a-z0-9_-]+)+$`)
+
+// PostPropSchema is the JSON schema an integration registered for the values of the post prop
+// named after its namespace. Posts setting the prop are rejected unless it is valid against the
+// schema.
+type PostPropSchema struct {
+	Namespace string          `json:"namespace"`
+	Schema    json.RawMessage `db:"Definition" json:"schema"`
+	CreatorId string          `json:"creator_id"`
+	CreateAt  int64           `json:"create_at"`
+	UpdateAt  int64           `json:"update_at"`
+}
+
+func IsValidPostPropSchemaNamespace(namespace string) bool {
+	return len(namespace) <= PostPropSchemaNamespaceMaxLength && validPostPropSchemaNamespace.MatchString(namespace)
+}
+
+func (s *PostPropSchema) PreSave() {
+	if s.CreateAt == 0 {
+		s.CreateAt = GetMillis()
+	}
+	s.UpdateAt = s.CreateAt
+}
+
+func (s *PostPropSchema) PreUpdate() {
+	s.UpdateAt = GetMillis()
+}
+
+func (s *PostPropSchema) IsValid() *AppError {
+	if !IsValidPostPropSchemaNamespace(s.Namespace) {
+		return NewAppError("PostPropSchema.IsValid", "model.post_prop_schema.is_valid.namespace.app_error", map[string]interface{}{"MaxLength": PostPropSchemaNamespaceMaxLength}, "namespace="+s.Namespace, http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.CreatorId) {
+		return NewAppError("PostPropSchema.IsValid", "model.post_prop_schema.is_valid.creator_id.app_error", nil, "namespace="+s.Namespace, http.StatusBadRequest)
+	}
+
+	if s.CreateAt == 0 {
+		return NewAppError("PostPropSchema.IsValid", "model.post_prop_schema.is_valid.create_at.app_error", nil, "namespace="+s.Namespace, http.StatusBadRequest)
+	}
+
+	if s.UpdateAt == 0 {
+		return NewAppError("PostPropSchema.IsValid", "model.post_prop_schema.is_valid.update_at.app_error", nil, "namespace="+s.Namespace, http.StatusBadRequest)
+	}
+
+	if len(s.Schema) > PostPropSchemaMaxSize {
+		return NewAppError("PostPropSchema.IsValid", "model.post_prop_schema.is_valid.size.app_error", map[string]interface{}{"MaxSize": PostPropSchemaMaxSize}, "namespace="+s.Namespace, http.StatusBadRequest)
+	}
+
+	if _, err := s.Compile(); err != nil {
+		return NewAppError("PostPropSchema.IsValid", "model.post_prop_schema.is_valid.schema.app_error", map[string]interface{}{"Error": err.Error()}, "namespace="+s.Namespace, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Compile parses the schema so that values can be validated against it.
+func (s *PostPropSchema) Compile() (*jsonschema.Schema, error) {
+	return jsonschema.Compile(s.Schema)
+}
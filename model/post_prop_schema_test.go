@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidPostPropSchemaNamespace(t *testing.T) {
+	for _, namespace := range []string{"com.example.poll", "io.acme_bot.card-v2", "a.b"} {
+		assert.True(t, IsValidPostPropSchemaNamespace(namespace), namespace)
+	}
+
+	for _, namespace := range []string{"", "attachments", "from_webhook", "Com.Example", "com..example", ".com", "com.", "com.example/poll", "com." + strings.Repeat("a", PostPropSchemaNamespaceMaxLength)} {
+		assert.False(t, IsValidPostPropSchemaNamespace(namespace), namespace)
+	}
+}
+
+func TestPostPropSchemaIsValid(t *testing.T) {
+	s := PostPropSchema{
+		Namespace: "com.example.poll",
+		Schema:    json.RawMessage(`{"type": "object", "required": ["question"]}`),
+		CreatorId: NewId(),
+	}
+	s.PreSave()
+	require.Nil(t, s.IsValid())
+
+	s.Namespace = "poll"
+	require.NotNil(t, s.IsValid())
+
+	s.Namespace = "com.example.poll"
+	s.CreatorId = ""
+	require.NotNil(t, s.IsValid())
+
+	s.CreatorId = NewId()
+	s.Schema = json.RawMessage(`{"type": "object", "oneOf": []}`)
+	appErr := s.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.post_prop_schema.is_valid.schema.app_error", appErr.Id)
+
+	s.Schema = json.RawMessage(`{"description": "` + strings.Repeat("a", PostPropSchemaMaxSize) + `"}`)
+	appErr = s.IsValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.post_prop_schema.is_valid.size.app_error", appErr.Id)
+}
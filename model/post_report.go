@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	PostReportReasonSpam          = "spam"
+	PostReportReasonHarassment    = "harassment"
+	PostReportReasonInappropriate = "inappropriate"
+	PostReportReasonOther         = "other"
+	// Posts flagged by the content policy are reported by the system bot.
+	PostReportReasonContentPolicy = "content_policy"
+
+	PostReportStatusOpen      = "open"
+	PostReportStatusDismissed = "dismissed"
+	PostReportStatusResolved  = "resolved"
+
+	// The actions a moderator can take on a reported post. Dismissing a report leaves the post
+	// as it is, the other actions resolve the report.
+	PostReportActionDismiss       = "dismiss"
+	PostReportActionDeletePost    = "delete_post"
+	PostReportActionTimeoutAuthor = "timeout_author"
+
+	PostReportCommentMaxRunes = 1024
+	PostReportPerPageDefault  = 60
+	PostReportPerPageMax      = 200
+)
+
+// PostReport is the report of a post to the moderators of the server, or of its channel.
+type PostReport struct {
+	Id         string `json:"id"`
+	PostId     string `json:"post_id"`
+	ChannelId  string `json:"channel_id"`
+	PostUserId string `json:"post_user_id"`
+	ReporterId string `json:"reporter_id"`
+	Reason     string `json:"reason"`
+	Comment    string `json:"comment"`
+	Status     string `json:"status"`
+	// Action, ResolverId and ResolveAt are set once a moderator has acted on the report.
+	Action     string `json:"action,omitempty"`
+	ResolverId string `json:"resolver_id,omitempty"`
+	CreateAt   int64  `json:"create_at"`
+	ResolveAt  int64  `json:"resolve_at,omitempty"`
+}
+
+// PostReportAction is the action of a moderator on a report. TimeoutMinutes is only used to
+// time the author of the post out.
+type PostReportAction struct {
+	Action         string `json:"action"`
+	TimeoutMinutes int    `json:"timeout_minutes,omitempty"`
+}
+
+type PostReportGetOptions struct {
+	Status    string
+	ChannelId string
+	Page      int
+	PerPage   int
+}
+
+func IsValidPostReportReason(reason string) bool {
+	switch reason {
+	case PostReportReasonSpam, PostReportReasonHarassment, PostReportReasonInappropriate, PostReportReasonOther, PostReportReasonContentPolicy:
+		return true
+	}
+	return false
+}
+
+func IsValidPostReportStatus(status string) bool {
+	switch status {
+	case PostReportStatusOpen, PostReportStatusDismissed, PostReportStatusResolved:
+		return true
+	}
+	return false
+}
+
+func (r *PostReport) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.Status == "" {
+		r.Status = PostReportStatusOpen
+	}
+
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+}
+
+func (r *PostReport) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.PostId) || !IsValidId(r.ChannelId) || !IsValidId(r.PostUserId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.post_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.ReporterId) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.reporter_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidPostReportReason(r.Reason) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.reason.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(r.Comment) > PostReportCommentMaxRunes {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.comment.app_error", map[string]interface{}{"Max": PostReportCommentMaxRunes}, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidPostReportStatus(r.Status) {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.status.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 {
+		return NewAppError("PostReport.IsValid", "model.post_report.is_valid.create_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (a *PostReportAction) IsValid() *AppError {
+	switch a.Action {
+	case PostReportActionDismiss, PostReportActionDeletePost:
+		return nil
+	case PostReportActionTimeoutAuthor:
+		if a.TimeoutMinutes <= 0 || a.TimeoutMinutes > ChannelMemberTimeoutMaxMinutes {
+			return NewAppError("PostReportAction.IsValid", "model.post_report_action.is_valid.timeout_minutes.app_error", map[string]interface{}{"Max": ChannelMemberTimeoutMaxMinutes}, "", http.StatusBadRequest)
+		}
+		return nil
+	}
+	return NewAppError("PostReportAction.IsValid", "model.post_report_action.is_valid.action.app_error", nil, "action="+a.Action, http.StatusBadRequest)
+}
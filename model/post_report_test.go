@@ -0,0 +1,45 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReportIsValid(t *testing.T) {
+	report := &PostReport{
+		PostId:     NewId(),
+		ChannelId:  NewId(),
+		PostUserId: NewId(),
+		ReporterId: NewId(),
+		Reason:     PostReportReasonSpam,
+	}
+	report.PreSave()
+	assert.Equal(t, PostReportStatusOpen, report.Status)
+	require.Nil(t, report.IsValid())
+
+	report.Reason = "boring"
+	require.NotNil(t, report.IsValid())
+
+	report.Reason = PostReportReasonOther
+	report.Comment = strings.Repeat("a", PostReportCommentMaxRunes+1)
+	require.NotNil(t, report.IsValid())
+
+	report.Comment = ""
+	report.Status = "closed"
+	require.NotNil(t, report.IsValid())
+}
+
+func TestPostReportActionIsValid(t *testing.T) {
+	assert.Nil(t, (&PostReportAction{Action: PostReportActionDismiss}).IsValid())
+	assert.Nil(t, (&PostReportAction{Action: PostReportActionDeletePost}).IsValid())
+	assert.Nil(t, (&PostReportAction{Action: PostReportActionTimeoutAuthor, TimeoutMinutes: 10}).IsValid())
+	assert.NotNil(t, (&PostReportAction{Action: PostReportActionTimeoutAuthor}).IsValid())
+	assert.NotNil(t, (&PostReportAction{Action: PostReportActionTimeoutAuthor, TimeoutMinutes: ChannelMemberTimeoutMaxMinutes + 1}).IsValid())
+	assert.NotNil(t, (&PostReportAction{Action: "ban"}).IsValid())
+}
@@ -90,6 +90,16 @@ func (o *Preference) IsValid() *AppError {
 		}
 	}
 
+	if o.Category == PreferenceCategoryDoNotDisturb && o.Name == PreferenceNameDNDBypass && o.Value != "" {
+		var list DNDBypassList
+		if err := json.NewDecoder(strings.NewReader(o.Value)).Decode(&list); err != nil {
+			return NewAppError("Preference.IsValid", "model.preference.is_valid.dnd_bypass.app_error", nil, "value="+o.Value, http.StatusBadRequest)
+		}
+		if err := list.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -119,4 +129,18 @@ func (o *Preference) PreUpdate() {
 			o.Value = string(b)
 		}
 	}
+
+	if o.Category == PreferenceCategoryDoNotDisturb && o.Name == PreferenceNameDNDBypass && o.Value != "" {
+		// trim and deduplicate the bypass list so that it is validated as it will be used
+		var list DNDBypassList
+		if err := json.NewDecoder(strings.NewReader(o.Value)).Decode(&list); err != nil {
+			// the invalid preference value gets caught by IsValid before saving
+			return
+		}
+
+		list.PreSave()
+		if b, err := json.Marshal(list); err == nil {
+			o.Value = string(b)
+		}
+	}
 }
@@ -46,6 +46,17 @@ func TestPreferenceIsValid(t *testing.T) {
 
 	preference.Value = `{"color": "#ff0000", "color2": "#faf"}`
 	require.Nil(t, preference.IsValid())
+
+	preference.Category = PreferenceCategoryDoNotDisturb
+	preference.Name = PreferenceNameDNDBypass
+	preference.Value = "1234garbage"
+	require.NotNil(t, preference.IsValid())
+
+	preference.Value = `{"user_ids": ["invalid"]}`
+	require.NotNil(t, preference.IsValid())
+
+	preference.Value = `{"user_ids": ["` + NewId() + `"], "keywords": ["outage"]}`
+	require.Nil(t, preference.IsValid())
 }
 
 func TestPreferencePreUpdate(t *testing.T) {
@@ -65,3 +76,17 @@ func TestPreferencePreUpdate(t *testing.T) {
 
 	require.NotEqual(t, "invalid", props["invalid"], "should have changed invalid prop")
 }
+
+func TestPreferencePreUpdateDNDBypass(t *testing.T) {
+	preference := Preference{
+		Category: PreferenceCategoryDoNotDisturb,
+		Name:     PreferenceNameDNDBypass,
+		Value:    `{"user_ids": [], "keywords": [" outage ", "Outage", ""]}`,
+	}
+
+	preference.PreUpdate()
+
+	var list DNDBypassList
+	require.NoError(t, json.NewDecoder(strings.NewReader(preference.Value)).Decode(&list))
+	require.Equal(t, []string{"outage"}, list.Keywords)
+}
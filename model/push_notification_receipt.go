@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	PushReceiptStatusSent     = "sent"
+	PushReceiptStatusFailed   = "failed"
+	PushReceiptStatusReceived = "received"
+
+	// PushReceiptRetentionDays is how long push notification receipts are kept for diagnostics.
+	PushReceiptRetentionDays = 7
+	// PushReceiptDiagnosticsLimit is the maximum number of receipts returned for a user.
+	PushReceiptDiagnosticsLimit = 100
+
+	pushReceiptErrorMaxLength = 1024
+)
+
+// PushNotificationReceipt records a single attempt to deliver a push notification to one of a
+// user's devices, and whether the device acknowledged receiving it.
+type PushNotificationReceipt struct {
+	AckId      string `json:"ack_id"`
+	UserId     string `json:"user_id"`
+	PostId     string `json:"post_id,omitempty"`
+	ChannelId  string `json:"channel_id,omitempty"`
+	Platform   string `json:"platform"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	CreateAt   int64  `json:"create_at"`
+	ReceivedAt int64  `json:"received_at,omitempty"`
+}
+
+// PushNotificationDiagnostics summarises the recent push notification attempts for a user.
+type PushNotificationDiagnostics struct {
+	PushNotificationsEnabled bool                       `json:"push_notifications_enabled"`
+	MobileSessions           int                        `json:"mobile_sessions"`
+	Sent                     int                        `json:"sent"`
+	Failed                   int                        `json:"failed"`
+	Received                 int                        `json:"received"`
+	Receipts                 []*PushNotificationReceipt `json:"receipts"`
+}
+
+func (r *PushNotificationReceipt) PreSave() {
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+
+	if len(r.Error) > pushReceiptErrorMaxLength {
+		r.Error = r.Error[:pushReceiptErrorMaxLength]
+	}
+}
+
+func (r *PushNotificationReceipt) IsValid() *AppError {
+	if !IsValidId(r.AckId) {
+		return NewAppError("PushNotificationReceipt.IsValid", "model.push_notification_receipt.is_valid.ack_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("PushNotificationReceipt.IsValid", "model.push_notification_receipt.is_valid.user_id.app_error", nil, "id="+r.AckId, http.StatusBadRequest)
+	}
+
+	switch r.Status {
+	case PushReceiptStatusSent, PushReceiptStatusFailed, PushReceiptStatusReceived:
+	default:
+		return NewAppError("PushNotificationReceipt.IsValid", "model.push_notification_receipt.is_valid.status.app_error", nil, "id="+r.AckId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// NewPushNotificationDiagnostics counts the receipts by status.
+func NewPushNotificationDiagnostics(receipts []*PushNotificationReceipt) *PushNotificationDiagnostics {
+	diagnostics := &PushNotificationDiagnostics{
+		Receipts: receipts,
+	}
+
+	for _, receipt := range receipts {
+		switch receipt.Status {
+		case PushReceiptStatusSent:
+			diagnostics.Sent++
+		case PushReceiptStatusFailed:
+			diagnostics.Failed++
+		case PushReceiptStatusReceived:
+			diagnostics.Received++
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushNotificationReceiptIsValid(t *testing.T) {
+	r := &PushNotificationReceipt{
+		AckId:  NewId(),
+		UserId: NewId(),
+		Status: PushReceiptStatusSent,
+	}
+	require.Nil(t, r.IsValid())
+
+	r.Status = "lost"
+	require.NotNil(t, r.IsValid())
+
+	r.Status = PushReceiptStatusReceived
+	r.UserId = "junk"
+	require.NotNil(t, r.IsValid())
+
+	r.UserId = NewId()
+	r.AckId = ""
+	require.NotNil(t, r.IsValid())
+}
+
+func TestPushNotificationReceiptPreSave(t *testing.T) {
+	r := &PushNotificationReceipt{Error: strings.Repeat("a", pushReceiptErrorMaxLength+10)}
+	r.PreSave()
+	assert.NotZero(t, r.CreateAt)
+	assert.Len(t, r.Error, pushReceiptErrorMaxLength)
+}
+
+func TestNewPushNotificationDiagnostics(t *testing.T) {
+	diagnostics := NewPushNotificationDiagnostics([]*PushNotificationReceipt{
+		{Status: PushReceiptStatusSent},
+		{Status: PushReceiptStatusReceived},
+		{Status: PushReceiptStatusReceived},
+		{Status: PushReceiptStatusFailed},
+	})
+
+	assert.Equal(t, 1, diagnostics.Sent)
+	assert.Equal(t, 2, diagnostics.Received)
+	assert.Equal(t, 1, diagnostics.Failed)
+	assert.Len(t, diagnostics.Receipts, 4)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	ReminderRecurrenceDaily  = "daily"
+	ReminderRecurrenceWeekly = "weekly"
+
+	ReminderMessageMaxRunes = 1024
+)
+
+// Reminder is a direct message the system bot sends a user at a given time, about a post or with
+// a message of their own. A recurring reminder is sent again every day or week.
+type Reminder struct {
+	Id         string `json:"id"`
+	UserId     string `json:"user_id"`
+	PostId     string `json:"post_id,omitempty"`
+	Message    string `json:"message"`
+	RemindAt   int64  `json:"remind_at"`
+	Recurrence string `json:"recurrence,omitempty"`
+	CreateAt   int64  `json:"create_at"`
+	UpdateAt   int64  `json:"update_at"`
+}
+
+type ReminderPatch struct {
+	Message    *string `json:"message"`
+	RemindAt   *int64  `json:"remind_at"`
+	Recurrence *string `json:"recurrence"`
+}
+
+func IsValidReminderRecurrence(recurrence string) bool {
+	switch recurrence {
+	case "", ReminderRecurrenceDaily, ReminderRecurrenceWeekly:
+		return true
+	}
+	return false
+}
+
+func (r *Reminder) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+	r.UpdateAt = r.CreateAt
+}
+
+func (r *Reminder) PreUpdate() {
+	r.UpdateAt = GetMillis()
+}
+
+func (r *Reminder) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.user_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.PostId != "" && !IsValidId(r.PostId) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.post_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if (r.PostId == "" && r.Message == "") || utf8.RuneCountInString(r.Message) > ReminderMessageMaxRunes {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.message.app_error", map[string]interface{}{"Max": ReminderMessageMaxRunes}, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.RemindAt <= 0 {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.remind_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidReminderRecurrence(r.Recurrence) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.recurrence.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.create_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.UpdateAt == 0 {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.update_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (r *Reminder) Patch(patch *ReminderPatch) {
+	if patch.Message != nil {
+		r.Message = *patch.Message
+	}
+
+	if patch.RemindAt != nil {
+		r.RemindAt = *patch.RemindAt
+	}
+
+	if patch.Recurrence != nil {
+		r.Recurrence = *patch.Recurrence
+	}
+}
+
+// NextRemindAt returns the first time after now a recurring reminder is due again, or zero for a
+// reminder that doesn't recur.
+func (r *Reminder) NextRemindAt(now int64) int64 {
+	var interval int64
+	switch r.Recurrence {
+	case ReminderRecurrenceDaily:
+		interval = 24 * 60 * 60 * 1000
+	case ReminderRecurrenceWeekly:
+		interval = 7 * 24 * 60 * 60 * 1000
+	default:
+		return 0
+	}
+
+	next := r.RemindAt + interval
+	if next <= now {
+		// Reminders missed while the server was down are only sent once.
+		next += ((now-next)/interval + 1) * interval
+	}
+	return next
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderIsValid(t *testing.T) {
+	r := Reminder{UserId: NewId(), Message: "water the plants", RemindAt: GetMillis()}
+	r.PreSave()
+	require.Nil(t, r.IsValid())
+
+	r.Message = ""
+	require.NotNil(t, r.IsValid())
+
+	r.PostId = NewId()
+	require.Nil(t, r.IsValid())
+
+	r.Recurrence = "hourly"
+	require.NotNil(t, r.IsValid())
+
+	r.Recurrence = ReminderRecurrenceWeekly
+	require.Nil(t, r.IsValid())
+
+	r.RemindAt = 0
+	require.NotNil(t, r.IsValid())
+}
+
+func TestReminderNextRemindAt(t *testing.T) {
+	day := int64(24 * 60 * 60 * 1000)
+	r := Reminder{RemindAt: 1000}
+
+	assert.Zero(t, r.NextRemindAt(1000))
+
+	r.Recurrence = ReminderRecurrenceDaily
+	assert.Equal(t, 1000+day, r.NextRemindAt(1000))
+	// Missed occurrences are skipped
+	assert.Equal(t, 1000+3*day, r.NextRemindAt(1000+2*day))
+	assert.Equal(t, 1000+3*day, r.NextRemindAt(1000+2*day+1))
+
+	r.Recurrence = ReminderRecurrenceWeekly
+	assert.Equal(t, 1000+7*day, r.NextRemindAt(1000))
+}
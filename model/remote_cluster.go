@@ -7,6 +7,9 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io"
@@ -21,10 +24,12 @@ const (
 	RemoteOfflineAfterMillis = 1000 * 60 * 5 // 5 minutes
 	RemoteNameMinLength      = 1
 	RemoteNameMaxLength      = 64
+	RemoteMaxPinnedCerts     = 8
 )
 
 var (
-	validRemoteNameChars = regexp.MustCompile(`^[a-zA-Z0-9\.\-\_]+$`)
+	validRemoteNameChars    = regexp.MustCompile(`^[a-zA-Z0-9\.\-\_]+$`)
+	validCertFingerprintStr = regexp.MustCompile(`^[a-f0-9]{64}$`)
 )
 
 type RemoteCluster struct {
@@ -39,6 +44,7 @@ type RemoteCluster struct {
 	RemoteToken  string `json:"remote_token"`
 	Topics       string `json:"topics"`
 	CreatorId    string `json:"creator_id"`
+	PinnedCerts  string `json:"pinned_certs"`
 }
 
 func (rc *RemoteCluster) PreSave() {
@@ -80,6 +86,16 @@ func (rc *RemoteCluster) IsValid() *AppError {
 	if !IsValidId(rc.CreatorId) {
 		return NewAppError("RemoteCluster.IsValid", "model.cluster.is_valid.id.app_error", nil, "creator_id="+rc.CreatorId, http.StatusBadRequest)
 	}
+
+	pinned := rc.GetPinnedCerts()
+	if len(pinned) > RemoteMaxPinnedCerts {
+		return NewAppError("RemoteCluster.IsValid", "model.cluster.is_valid.pinned_certs.app_error", nil, "too many pinned certificates", http.StatusBadRequest)
+	}
+	for _, fp := range pinned {
+		if !IsValidCertFingerprint(fp) {
+			return NewAppError("RemoteCluster.IsValid", "model.cluster.is_valid.pinned_certs.app_error", nil, "fingerprint="+fp, http.StatusBadRequest)
+		}
+	}
 	return nil
 }
 
@@ -105,6 +121,47 @@ func (rc *RemoteCluster) IsOnline() bool {
 	return rc.LastPingAt > GetMillis()-RemoteOfflineAfterMillis
 }
 
+// GetPinnedCerts returns the SHA-256 fingerprints of the certificates this remote
+// is expected to present, or an empty slice if no certificates are pinned.
+func (rc *RemoteCluster) GetPinnedCerts() []string {
+	return strings.Fields(rc.PinnedCerts)
+}
+
+// SetPinnedCerts replaces the pinned certificate fingerprints. Fingerprints are normalized
+// so that the common `AA:BB:...` notation is accepted.
+func (rc *RemoteCluster) SetPinnedCerts(fingerprints []string) {
+	normalized := make([]string, 0, len(fingerprints))
+	seen := make(map[string]bool)
+	for _, fp := range fingerprints {
+		fp = NormalizeCertFingerprint(fp)
+		if fp == "" || seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		normalized = append(normalized, fp)
+	}
+	rc.PinnedCerts = strings.Join(normalized, " ")
+}
+
+// HasPinnedCerts returns true if at least one certificate fingerprint is pinned for this remote.
+func (rc *RemoteCluster) HasPinnedCerts() bool {
+	return strings.TrimSpace(rc.PinnedCerts) != ""
+}
+
+// IsCertPinned returns true if the certificate matches one of the pinned fingerprints.
+func (rc *RemoteCluster) IsCertPinned(cert *x509.Certificate) bool {
+	if cert == nil {
+		return false
+	}
+	fingerprint := CertFingerprint(cert)
+	for _, fp := range rc.GetPinnedCerts() {
+		if fp == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
 // fixTopics ensures all topics are separated by one, and only one, space.
 func (rc *RemoteCluster) fixTopics() {
 	trimmed := strings.TrimSpace(rc.Topics)
@@ -140,6 +197,41 @@ func NormalizeRemoteName(name string) string {
 	return strings.ToLower(name)
 }
 
+// CertFingerprint returns the hex encoded SHA-256 digest of a certificate's DER encoding.
+func CertFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeCertFingerprint lower-cases a fingerprint and strips the colons and whitespace
+// commonly found in fingerprints copied from tools like openssl.
+func NormalizeCertFingerprint(fp string) string {
+	fp = strings.ToLower(strings.TrimSpace(fp))
+	return strings.ReplaceAll(fp, ":", "")
+}
+
+func IsValidCertFingerprint(fp string) bool {
+	return validCertFingerprintStr.MatchString(fp)
+}
+
+// RemoteClusterPinnedCerts is the payload used to read and rotate the certificates pinned for a remote cluster.
+type RemoteClusterPinnedCerts struct {
+	RemoteId    string   `json:"remote_id"`
+	PinnedCerts []string `json:"pinned_certs"`
+}
+
+func (p *RemoteClusterPinnedCerts) IsValid() *AppError {
+	if len(p.PinnedCerts) > RemoteMaxPinnedCerts {
+		return NewAppError("RemoteClusterPinnedCerts.IsValid", "model.cluster.is_valid.pinned_certs.app_error", nil, "too many pinned certificates", http.StatusBadRequest)
+	}
+	for _, fp := range p.PinnedCerts {
+		if !IsValidCertFingerprint(NormalizeCertFingerprint(fp)) {
+			return NewAppError("RemoteClusterPinnedCerts.IsValid", "model.cluster.is_valid.pinned_certs.app_error", nil, "fingerprint="+fp, http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
 // RemoteClusterInfo provides a subset of RemoteCluster fields suitable for sending to clients.
 type RemoteClusterInfo struct {
 	Name        string `json:"name"`
@@ -5,7 +5,9 @@ package model
 
 import (
 	"crypto/rand"
+	"crypto/x509"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +32,8 @@ func TestRemoteClusterIsValid(t *testing.T) {
 		{name: "RemoteCluster valid", rc: &RemoteCluster{RemoteId: id, Name: NewId(), SiteURL: "example.com", CreateAt: now, LastPingAt: now, CreatorId: creator}, valid: true},
 		{name: "Include protocol", rc: &RemoteCluster{RemoteId: id, Name: NewId(), SiteURL: "http://example.com", CreateAt: now, LastPingAt: now, CreatorId: creator}, valid: true},
 		{name: "Include protocol & port", rc: &RemoteCluster{RemoteId: id, Name: NewId(), SiteURL: "http://example.com:8065", CreateAt: now, LastPingAt: now, CreatorId: creator}, valid: true},
+		{name: "Pinned cert", rc: &RemoteCluster{RemoteId: id, Name: NewId(), SiteURL: "example.com", CreateAt: now, CreatorId: creator, PinnedCerts: strings.Repeat("ab", 32)}, valid: true},
+		{name: "Invalid pinned cert", rc: &RemoteCluster{RemoteId: id, Name: NewId(), SiteURL: "example.com", CreateAt: now, CreatorId: creator, PinnedCerts: "not-a-fingerprint"}, valid: false},
 	}
 
 	for _, item := range data {
@@ -51,6 +55,42 @@ func TestRemoteClusterPreSave(t *testing.T) {
 	require.GreaterOrEqual(t, o.CreateAt, now)
 }
 
+func TestRemoteClusterPinnedCerts(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("certificate")}
+	other := &x509.Certificate{Raw: []byte("other certificate")}
+	fingerprint := CertFingerprint(cert)
+
+	rc := RemoteCluster{}
+	require.False(t, rc.HasPinnedCerts())
+	require.False(t, rc.IsCertPinned(cert))
+
+	colons := make([]string, 0, len(fingerprint)/2)
+	for i := 0; i < len(fingerprint); i += 2 {
+		colons = append(colons, strings.ToUpper(fingerprint[i:i+2]))
+	}
+
+	rc.SetPinnedCerts([]string{strings.Join(colons, ":"), fingerprint, ""})
+	require.True(t, rc.HasPinnedCerts())
+	require.Equal(t, []string{fingerprint}, rc.GetPinnedCerts())
+	require.True(t, rc.IsCertPinned(cert))
+	require.False(t, rc.IsCertPinned(other))
+
+	rc.SetPinnedCerts([]string{fingerprint, CertFingerprint(other)})
+	require.Len(t, rc.GetPinnedCerts(), 2)
+	require.True(t, rc.IsCertPinned(other))
+
+	t.Run("payload validation", func(t *testing.T) {
+		require.Nil(t, (&RemoteClusterPinnedCerts{PinnedCerts: []string{fingerprint}}).IsValid())
+		require.NotNil(t, (&RemoteClusterPinnedCerts{PinnedCerts: []string{"abc"}}).IsValid())
+
+		tooMany := make([]string, RemoteMaxPinnedCerts+1)
+		for i := range tooMany {
+			tooMany[i] = fingerprint
+		}
+		require.NotNil(t, (&RemoteClusterPinnedCerts{PinnedCerts: tooMany}).IsValid())
+	})
+}
+
 func TestRemoteClusterMsgIsValid(t *testing.T) {
 	id := NewId()
 	now := GetMillis()
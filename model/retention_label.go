@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+)
+
+var validRetentionLabelName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// RetentionLabel is a retention label configured by the administrators, such as
+// "retain-7-years" or "ephemeral-24h", that can be applied to individual posts.
+type RetentionLabel struct {
+	Name string `json:"name"`
+	// RetentionHours is how long a labeled post is kept for, from its creation.
+	RetentionHours int64 `json:"retention_hours"`
+}
+
+func (l *RetentionLabel) IsValid() *AppError {
+	if !validRetentionLabelName.MatchString(l.Name) {
+		return NewAppError("RetentionLabel.IsValid", "model.retention_label.is_valid.name.app_error", nil, "name="+l.Name, http.StatusBadRequest)
+	}
+
+	if l.RetentionHours <= 0 {
+		return NewAppError("RetentionLabel.IsValid", "model.retention_label.is_valid.retention_hours.app_error", nil, "name="+l.Name, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PostRetentionLabel is a retention label applied to a post. It overrides the retention policies
+// of the channel and team of the post: the post is deleted by the data retention job once
+// ExpireAt has passed, and not before.
+type PostRetentionLabel struct {
+	PostId    string `json:"post_id"`
+	Label     string `json:"label"`
+	ExpireAt  int64  `json:"expire_at"`
+	CreatorId string `json:"creator_id"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (l *PostRetentionLabel) IsValid() *AppError {
+	if !IsValidId(l.PostId) {
+		return NewAppError("PostRetentionLabel.IsValid", "model.post_retention_label.is_valid.post_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !validRetentionLabelName.MatchString(l.Label) {
+		return NewAppError("PostRetentionLabel.IsValid", "model.retention_label.is_valid.name.app_error", nil, "name="+l.Label, http.StatusBadRequest)
+	}
+
+	if !IsValidId(l.CreatorId) {
+		return NewAppError("PostRetentionLabel.IsValid", "model.post_retention_label.is_valid.creator_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if l.ExpireAt <= 0 || l.CreateAt == 0 {
+		return NewAppError("PostRetentionLabel.IsValid", "model.post_retention_label.is_valid.expire_at.app_error", nil, "post_id="+l.PostId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PostRetentionLabelRequest is the body of a request applying a retention label to a post.
+type PostRetentionLabelRequest struct {
+	Label string `json:"label"`
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionLabelIsValid(t *testing.T) {
+	assert.Nil(t, (&RetentionLabel{Name: "retain-7-years", RetentionHours: 61320}).IsValid())
+	assert.Nil(t, (&RetentionLabel{Name: "ephemeral_24h.v2", RetentionHours: 24}).IsValid())
+
+	assert.NotNil(t, (&RetentionLabel{Name: "", RetentionHours: 24}).IsValid())
+	assert.NotNil(t, (&RetentionLabel{Name: "Retain", RetentionHours: 24}).IsValid())
+	assert.NotNil(t, (&RetentionLabel{Name: "-retain", RetentionHours: 24}).IsValid())
+	assert.NotNil(t, (&RetentionLabel{Name: "retain", RetentionHours: 0}).IsValid())
+}
+
+func TestDataRetentionSettingsRetentionLabelsIsValid(t *testing.T) {
+	s := DataRetentionSettings{}
+	s.SetDefaults()
+	assert.Nil(t, s.isValid())
+
+	s.RetentionLabels = []*RetentionLabel{{Name: "ephemeral-24h", RetentionHours: 24}}
+	assert.Nil(t, s.isValid())
+
+	s.RetentionLabels = append(s.RetentionLabels, &RetentionLabel{Name: "ephemeral-24h", RetentionHours: 48})
+	assert.NotNil(t, s.isValid())
+
+	s.RetentionLabels = []*RetentionLabel{{Name: "ephemeral-24h"}}
+	assert.NotNil(t, s.isValid())
+}
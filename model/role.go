@@ -402,6 +402,8 @@ type Role struct {
 	Permissions   []string `json:"permissions"`
 	SchemeManaged bool     `json:"scheme_managed"`
 	BuiltIn       bool     `json:"built_in"`
+	// Scope is only set on custom roles, and restricts where they can be assigned.
+	Scope RoleScope `json:"scope,omitempty"`
 }
 
 type RolePatch struct {
@@ -654,9 +656,45 @@ func (r *Role) IsValidWithoutId() bool {
 		}
 	}
 
+	return r.isValidForScope()
+}
+
+// isValidForScope checks that a custom role only grants permissions which apply where it can be
+// assigned: team roles can grant team and channel permissions, channel roles only channel ones.
+func (r *Role) isValidForScope() bool {
+	var allowedScopes map[string]bool
+	switch r.Scope {
+	case "", RoleScopeSystem:
+		return true
+	case RoleScopeTeam:
+		allowedScopes = map[string]bool{PermissionScopeTeam: true, PermissionScopeChannel: true}
+	case RoleScopeChannel:
+		allowedScopes = map[string]bool{PermissionScopeChannel: true}
+	default:
+		return false
+	}
+
+	permissionScopes := map[string]string{}
+	for _, perms := range [][]*Permission{AllPermissions, DeprecatedPermissions} {
+		for _, p := range perms {
+			permissionScopes[p.Id] = p.Scope
+		}
+	}
+
+	for _, permission := range r.Permissions {
+		if !allowedScopes[permissionScopes[permission]] {
+			return false
+		}
+	}
+
 	return true
 }
 
+// IsAssignableAt returns false if the role is a custom role of another scope.
+func (r *Role) IsAssignableAt(scope RoleScope) bool {
+	return r.Scope == "" || r.Scope == scope
+}
+
 func CleanRoleNames(roleNames []string) ([]string, bool) {
 	var cleanedRoleNames []string
 	for _, roleName := range roleNames {
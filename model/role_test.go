@@ -276,3 +276,27 @@ func TestGetChannelModeratedPermissions(t *testing.T) {
 		})
 	}
 }
+
+func TestRoleIsValidForScope(t *testing.T) {
+	newRole := func(scope RoleScope, permissions ...string) *Role {
+		return &Role{
+			Name:        NewId(),
+			DisplayName: NewId(),
+			Permissions: permissions,
+			Scope:       scope,
+		}
+	}
+
+	assert.True(t, newRole("", PermissionManageSystem.Id).IsValidWithoutId())
+	assert.True(t, newRole(RoleScopeSystem, PermissionManageSystem.Id, PermissionCreatePost.Id).IsValidWithoutId())
+	assert.True(t, newRole(RoleScopeTeam, PermissionAddUserToTeam.Id, PermissionCreatePost.Id).IsValidWithoutId())
+	assert.False(t, newRole(RoleScopeTeam, PermissionManageSystem.Id).IsValidWithoutId())
+	assert.True(t, newRole(RoleScopeChannel, PermissionCreatePost.Id).IsValidWithoutId())
+	assert.False(t, newRole(RoleScopeChannel, PermissionAddUserToTeam.Id).IsValidWithoutId())
+	assert.False(t, newRole(RoleScopeGroup, PermissionCreatePost.Id).IsValidWithoutId())
+
+	role := newRole(RoleScopeTeam)
+	assert.True(t, role.IsAssignableAt(RoleScopeTeam))
+	assert.False(t, role.IsAssignableAt(RoleScopeChannel))
+	assert.True(t, newRole("").IsAssignableAt(RoleScopeChannel))
+}
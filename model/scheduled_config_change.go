@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	ScheduledConfigChangeStatusPending  = "pending"
+	ScheduledConfigChangeStatusApplied  = "applied"
+	ScheduledConfigChangeStatusFailed   = "failed"
+	ScheduledConfigChangeStatusCanceled = "canceled"
+)
+
+// ScheduledConfigChange is a change to the configuration applied by a job at a future time.
+// Config is a partial configuration, merged onto the configuration in effect when the change is
+// applied. Diffs are the sanitized differences it made to the configuration when it was scheduled.
+type ScheduledConfigChange struct {
+	Id        string          `json:"id"`
+	CreatorId string          `json:"creator_id"`
+	ApplyAt   int64           `json:"apply_at"`
+	Config    *Config         `json:"config,omitempty"`
+	Diffs     json.RawMessage `json:"diffs,omitempty"`
+	Status    string          `json:"status"`
+	Error     string          `json:"error,omitempty"`
+	CreateAt  int64           `json:"create_at"`
+	UpdateAt  int64           `json:"update_at"`
+}
+
+func IsValidScheduledConfigChangeStatus(status string) bool {
+	switch status {
+	case ScheduledConfigChangeStatusPending,
+		ScheduledConfigChangeStatusApplied,
+		ScheduledConfigChangeStatusFailed,
+		ScheduledConfigChangeStatusCanceled:
+		return true
+	}
+	return false
+}
+
+func (c *ScheduledConfigChange) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	if c.Status == "" {
+		c.Status = ScheduledConfigChangeStatusPending
+	}
+
+	if c.CreateAt == 0 {
+		c.CreateAt = GetMillis()
+	}
+	c.UpdateAt = c.CreateAt
+}
+
+func (c *ScheduledConfigChange) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("ScheduledConfigChange.IsValid", "model.scheduled_config_change.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(c.CreatorId) {
+		return NewAppError("ScheduledConfigChange.IsValid", "model.scheduled_config_change.is_valid.creator_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.ApplyAt <= 0 {
+		return NewAppError("ScheduledConfigChange.IsValid", "model.scheduled_config_change.is_valid.apply_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.Config == nil {
+		return NewAppError("ScheduledConfigChange.IsValid", "model.scheduled_config_change.is_valid.config.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidScheduledConfigChangeStatus(c.Status) {
+		return NewAppError("ScheduledConfigChange.IsValid", "model.scheduled_config_change.is_valid.status.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("ScheduledConfigChange.IsValid", "model.scheduled_config_change.is_valid.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Sanitize removes the partial configuration of the change, which may hold secrets. The diffs
+// are left, they are sanitized when the change is scheduled.
+func (c *ScheduledConfigChange) Sanitize() {
+	c.Config = nil
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledConfigChangeIsValid(t *testing.T) {
+	c := ScheduledConfigChange{CreatorId: NewId(), ApplyAt: GetMillis(), Config: &Config{}}
+	c.PreSave()
+	assert.Equal(t, ScheduledConfigChangeStatusPending, c.Status)
+	require.Nil(t, c.IsValid())
+
+	c.ApplyAt = 0
+	require.NotNil(t, c.IsValid())
+	c.ApplyAt = GetMillis()
+
+	c.Config = nil
+	require.NotNil(t, c.IsValid())
+	c.Config = &Config{}
+
+	c.Status = "applying"
+	require.NotNil(t, c.IsValid())
+	c.Status = ScheduledConfigChangeStatusFailed
+	require.Nil(t, c.IsValid())
+
+	c.Sanitize()
+	assert.Nil(t, c.Config)
+}
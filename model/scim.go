@@ -0,0 +1,531 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ScimURLSuffix = "/api/scim/v2"
+
+	ScimSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	ScimResourceTypeUser  = "User"
+	ScimResourceTypeGroup = "Group"
+
+	ScimPatchOpAdd     = "add"
+	ScimPatchOpRemove  = "remove"
+	ScimPatchOpReplace = "replace"
+
+	ScimDefaultCount = 100
+	ScimMaxCount     = 200
+
+	// The userName and externalId of a provisioned user are kept in its props, since its
+	// username is cleaned from the userName, which often is an email address.
+	UserPropScimUserName   = "scim_user_name"
+	UserPropScimExternalId = "scim_external_id"
+)
+
+var (
+	scimFilterRegexp         = regexp.MustCompile(`^\s*([A-Za-z.]+)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+	scimMemberPathRegexp     = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+	scimEmailValuePathRegexp = regexp.MustCompile(`^(?i:emails)\[.*\]\.(?i:value)$`)
+)
+
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type ScimName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	Formatted  string `json:"formatted,omitempty"`
+}
+
+type ScimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type ScimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// ScimUser is the SCIM representation of a user, as described by RFC 7643.
+type ScimUser struct {
+	Schemas     []string    `json:"schemas"`
+	Id          string      `json:"id,omitempty"`
+	ExternalId  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *ScimName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []ScimEmail `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Meta        *ScimMeta   `json:"meta,omitempty"`
+}
+
+// ScimGroup is the SCIM representation of a custom group.
+type ScimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	Id          string       `json:"id,omitempty"`
+	ExternalId  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []ScimMember `json:"members"`
+	Meta        *ScimMeta    `json:"meta,omitempty"`
+}
+
+type ScimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// ScimError is the body of the error responses, whose status is the HTTP status code.
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type ScimPatchRequest struct {
+	Schemas    []string              `json:"schemas"`
+	Operations []*ScimPatchOperation `json:"Operations"`
+}
+
+// scimErrorTypes are the SCIM types of the errors of invalid requests, which are otherwise
+// invalid values.
+var scimErrorTypes = map[string]string{
+	"model.scim.filter.app_error":              "invalidFilter",
+	"model.scim.patch.path.app_error":          "invalidPath",
+	"model.scim.patch.op.app_error":            "invalidSyntax",
+	"api.context.invalid_body_param.app_error": "invalidSyntax",
+	"api.context.invalid_url_param.app_error":  "invalidSyntax",
+}
+
+// ScimFilter is a filter of the form `attribute eq "value"`, the only one supported.
+type ScimFilter struct {
+	Attribute string
+	Value     string
+}
+
+// ParseScimFilter parses a filter, returning nil if filter is empty.
+func ParseScimFilter(filter string) (*ScimFilter, *AppError) {
+	if filter == "" {
+		return nil, nil
+	}
+
+	matches := scimFilterRegexp.FindStringSubmatch(filter)
+	if matches == nil {
+		return nil, NewAppError("ParseScimFilter", "model.scim.filter.app_error", nil, "filter="+filter, http.StatusBadRequest)
+	}
+
+	value, err := strconv.Unquote(`"` + matches[2] + `"`)
+	if err != nil {
+		return nil, NewAppError("ParseScimFilter", "model.scim.filter.app_error", nil, "filter="+filter, http.StatusBadRequest)
+	}
+
+	return &ScimFilter{Attribute: matches[1], Value: value}, nil
+}
+
+// NewScimListResponse returns the page of resources starting at startIndex, which is 1-based.
+func NewScimListResponse(resources []interface{}, totalResults, startIndex int) *ScimListResponse {
+	return &ScimListResponse{
+		Schemas:      []string{ScimSchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// NewScimError returns the SCIM representation of an error, with the type of the errors of the
+// requests identity providers can act upon.
+func NewScimError(appErr *AppError) *ScimError {
+	scimErr := &ScimError{
+		Schemas: []string{ScimSchemaError},
+		Status:  strconv.Itoa(appErr.StatusCode),
+		Detail:  appErr.Message,
+	}
+
+	switch appErr.StatusCode {
+	case http.StatusConflict:
+		scimErr.ScimType = "uniqueness"
+	case http.StatusBadRequest:
+		scimErr.ScimType = "invalidValue"
+		if scimType, ok := scimErrorTypes[appErr.Id]; ok {
+			scimErr.ScimType = scimType
+		}
+	}
+
+	return scimErr
+}
+
+func scimTime(millis int64) string {
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+// NewScimUser returns the SCIM representation of a user.
+func NewScimUser(user *User, siteURL string) *ScimUser {
+	userName := user.Props[UserPropScimUserName]
+	if userName == "" {
+		userName = user.Username
+	}
+
+	return &ScimUser{
+		Schemas:    []string{ScimSchemaUser},
+		Id:         user.Id,
+		ExternalId: user.Props[UserPropScimExternalId],
+		UserName:   userName,
+		Name: &ScimName{
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+			Formatted:  user.GetFullName(),
+		},
+		DisplayName: user.GetDisplayName(ShowNicknameFullName),
+		Emails:      []ScimEmail{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      NewBool(user.DeleteAt == 0),
+		Meta: &ScimMeta{
+			ResourceType: ScimResourceTypeUser,
+			Created:      scimTime(user.CreateAt),
+			LastModified: scimTime(user.UpdateAt),
+			Location:     siteURL + ScimURLSuffix + "/Users/" + user.Id,
+		},
+	}
+}
+
+// PrimaryEmail returns the primary email address of the user, or its first one.
+func (u *ScimUser) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Username returns the username of the user, cleaned from its userName. An email address is
+// reduced to its local part.
+func (u *ScimUser) Username() string {
+	return CleanUsername(strings.Split(u.UserName, "@")[0])
+}
+
+func (u *ScimUser) IsValid() *AppError {
+	if u.UserName == "" {
+		return NewAppError("ScimUser.IsValid", "model.scim.user.is_valid.user_name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidEmail(u.PrimaryEmail()) {
+		return NewAppError("ScimUser.IsValid", "model.scim.user.is_valid.email.app_error", nil, "user_name="+u.UserName, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ApplyTo sets the attributes of the user to those of a Mattermost user.
+func (u *ScimUser) ApplyTo(user *User) {
+	user.Username = u.Username()
+	user.Email = strings.ToLower(u.PrimaryEmail())
+	if u.Name != nil {
+		user.FirstName = u.Name.GivenName
+		user.LastName = u.Name.FamilyName
+	}
+	user.SetProp(UserPropScimUserName, u.UserName)
+	if u.ExternalId != "" {
+		user.SetProp(UserPropScimExternalId, u.ExternalId)
+	} else {
+		delete(user.Props, UserPropScimExternalId)
+	}
+}
+
+// ApplyPatch applies patch operations to the user. Operations without a path set the
+// attributes of their value.
+func (u *ScimUser) ApplyPatch(operations []*ScimPatchOperation) *AppError {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != ScimPatchOpAdd && op != ScimPatchOpRemove && op != ScimPatchOpReplace {
+			return NewAppError("ScimUser.ApplyPatch", "model.scim.patch.op.app_error", nil, "op="+operation.Op, http.StatusBadRequest)
+		}
+
+		if operation.Path != "" {
+			if err := u.setAttribute(operation.Path, operation.Value, op == ScimPatchOpRemove); err != nil {
+				return NewAppError("ScimUser.ApplyPatch", "model.scim.patch.path.app_error", map[string]interface{}{"Path": operation.Path}, err.Error(), http.StatusBadRequest)
+			}
+			continue
+		}
+
+		var attributes map[string]json.RawMessage
+		if op == ScimPatchOpRemove || json.Unmarshal(operation.Value, &attributes) != nil {
+			return NewAppError("ScimUser.ApplyPatch", "model.scim.patch.value.app_error", nil, "", http.StatusBadRequest)
+		}
+		for path, value := range attributes {
+			if err := u.setAttribute(path, value, false); err != nil {
+				return NewAppError("ScimUser.ApplyPatch", "model.scim.patch.path.app_error", map[string]interface{}{"Path": path}, err.Error(), http.StatusBadRequest)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (u *ScimUser) setAttribute(path string, value json.RawMessage, remove bool) error {
+	var str string
+	if !remove && !strings.EqualFold(path, "active") && !strings.EqualFold(path, "name") && !strings.EqualFold(path, "emails") {
+		if err := json.Unmarshal(value, &str); err != nil {
+			return err
+		}
+	}
+
+	if u.Name == nil {
+		u.Name = &ScimName{}
+	}
+
+	switch {
+	case strings.EqualFold(path, "active"):
+		if remove {
+			return errScimAttributeRequired
+		}
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = NewBool(active)
+	case strings.EqualFold(path, "userName"):
+		if remove {
+			return errScimAttributeRequired
+		}
+		u.UserName = str
+	case strings.EqualFold(path, "externalId"):
+		u.ExternalId = str
+	case strings.EqualFold(path, "displayName"):
+		u.DisplayName = str
+	case strings.EqualFold(path, "name"):
+		name := ScimName{}
+		if !remove {
+			if err := json.Unmarshal(value, &name); err != nil {
+				return err
+			}
+		}
+		u.Name = &name
+	case strings.EqualFold(path, "name.givenName"):
+		u.Name.GivenName = str
+	case strings.EqualFold(path, "name.familyName"):
+		u.Name.FamilyName = str
+	case strings.EqualFold(path, "emails"):
+		if remove {
+			return errScimAttributeRequired
+		}
+		var emails []ScimEmail
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return err
+		}
+		u.Emails = emails
+	case scimEmailValuePathRegexp.MatchString(path):
+		if remove {
+			return errScimAttributeRequired
+		}
+		u.Emails = []ScimEmail{{Value: str, Type: "work", Primary: true}}
+	default:
+		return errScimUnsupportedPath
+	}
+
+	return nil
+}
+
+// NewScimGroup returns the SCIM representation of a custom group and its members.
+func NewScimGroup(group *Group, members []*User, siteURL string) *ScimGroup {
+	scimMembers := make([]ScimMember, 0, len(members))
+	for _, member := range members {
+		scimMembers = append(scimMembers, ScimMember{Value: member.Id, Display: member.Username})
+	}
+
+	return &ScimGroup{
+		Schemas:     []string{ScimSchemaGroup},
+		Id:          group.Id,
+		DisplayName: group.DisplayName,
+		Members:     scimMembers,
+		Meta: &ScimMeta{
+			ResourceType: ScimResourceTypeGroup,
+			Created:      scimTime(group.CreateAt),
+			LastModified: scimTime(group.UpdateAt),
+			Location:     siteURL + ScimURLSuffix + "/Groups/" + group.Id,
+		},
+	}
+}
+
+func (g *ScimGroup) IsValid() *AppError {
+	if strings.TrimSpace(g.DisplayName) == "" {
+		return NewAppError("ScimGroup.IsValid", "model.scim.group.is_valid.display_name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, member := range g.Members {
+		if !IsValidId(member.Value) {
+			return NewAppError("ScimGroup.IsValid", "model.scim.group.is_valid.member.app_error", nil, "member="+member.Value, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// GroupName returns the name of the custom group, cleaned from its display name.
+func (g *ScimGroup) GroupName() string {
+	return CleanUsername(strings.ToLower(g.DisplayName))
+}
+
+// MemberIds returns the ids of the members of the group.
+func (g *ScimGroup) MemberIds() []string {
+	ids := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
+
+// ApplyPatch applies patch operations to the group, such as adding and removing members.
+func (g *ScimGroup) ApplyPatch(operations []*ScimPatchOperation) *AppError {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != ScimPatchOpAdd && op != ScimPatchOpRemove && op != ScimPatchOpReplace {
+			return NewAppError("ScimGroup.ApplyPatch", "model.scim.patch.op.app_error", nil, "op="+operation.Op, http.StatusBadRequest)
+		}
+
+		if err := g.applyOperation(op, operation.Path, operation.Value); err != nil {
+			return NewAppError("ScimGroup.ApplyPatch", "model.scim.patch.path.app_error", map[string]interface{}{"Path": operation.Path}, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (g *ScimGroup) applyOperation(op, path string, value json.RawMessage) error {
+	if matches := scimMemberPathRegexp.FindStringSubmatch(path); matches != nil {
+		if op != ScimPatchOpRemove {
+			return errScimUnsupportedPath
+		}
+		g.removeMembers(map[string]bool{matches[1]: true})
+		return nil
+	}
+
+	switch {
+	case path == "":
+		if op == ScimPatchOpRemove {
+			return errScimAttributeRequired
+		}
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(value, &attributes); err != nil {
+			return err
+		}
+		for attribute, attributeValue := range attributes {
+			if err := g.applyOperation(op, attribute, attributeValue); err != nil {
+				return err
+			}
+		}
+	case strings.EqualFold(path, "displayName"):
+		if op == ScimPatchOpRemove {
+			return errScimAttributeRequired
+		}
+		return json.Unmarshal(value, &g.DisplayName)
+	case strings.EqualFold(path, "externalId"):
+		if op == ScimPatchOpRemove {
+			g.ExternalId = ""
+			return nil
+		}
+		return json.Unmarshal(value, &g.ExternalId)
+	case strings.EqualFold(path, "members"):
+		var members []ScimMember
+		if len(value) > 0 {
+			if err := json.Unmarshal(value, &members); err != nil {
+				return err
+			}
+		}
+
+		switch op {
+		case ScimPatchOpAdd:
+			existing := make(map[string]bool, len(g.Members))
+			for _, member := range g.Members {
+				existing[member.Value] = true
+			}
+			for _, member := range members {
+				if !existing[member.Value] {
+					g.Members = append(g.Members, member)
+					existing[member.Value] = true
+				}
+			}
+		case ScimPatchOpReplace:
+			g.Members = members
+		case ScimPatchOpRemove:
+			// Removing members without a value removes all of them.
+			if len(members) == 0 {
+				g.Members = []ScimMember{}
+				return nil
+			}
+			ids := make(map[string]bool, len(members))
+			for _, member := range members {
+				ids[member.Value] = true
+			}
+			g.removeMembers(ids)
+		}
+	default:
+		return errScimUnsupportedPath
+	}
+
+	return nil
+}
+
+func (g *ScimGroup) removeMembers(ids map[string]bool) {
+	members := make([]ScimMember, 0, len(g.Members))
+	for _, member := range g.Members {
+		if !ids[member.Value] {
+			members = append(members, member)
+		}
+	}
+	g.Members = members
+}
+
+// scimBool reads a boolean, which some identity providers send as a string such as "False".
+func scimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(strings.ToLower(s))
+}
+
+var (
+	errScimUnsupportedPath   = errors.New("unsupported path")
+	errScimAttributeRequired = errors.New("the attribute can't be removed")
+)
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScimFilter(t *testing.T) {
+	filter, appErr := ParseScimFilter("")
+	require.Nil(t, appErr)
+	assert.Nil(t, filter)
+
+	filter, appErr = ParseScimFilter(`userName EQ "jane\"doe@example.com"`)
+	require.Nil(t, appErr)
+	assert.Equal(t, &ScimFilter{Attribute: "userName", Value: `jane"doe@example.com`}, filter)
+
+	_, appErr = ParseScimFilter(`userName eq "jane" and active eq true`)
+	assert.NotNil(t, appErr)
+}
+
+func TestNewScimError(t *testing.T) {
+	_, appErr := ParseScimFilter("userName")
+	require.NotNil(t, appErr)
+	appErr.Message = "Invalid filter."
+	assert.Equal(t, &ScimError{Schemas: []string{ScimSchemaError}, Status: "400", ScimType: "invalidFilter", Detail: "Invalid filter."}, NewScimError(appErr))
+
+	scimErr := NewScimError(NewAppError("test", "model.scim.user.is_valid.email.app_error", nil, "", http.StatusBadRequest))
+	assert.Equal(t, "invalidValue", scimErr.ScimType)
+
+	scimErr = NewScimError(NewAppError("test", "app.scim.user_exists.app_error", nil, "", http.StatusConflict))
+	assert.Equal(t, "409", scimErr.Status)
+	assert.Equal(t, "uniqueness", scimErr.ScimType)
+
+	scimErr = NewScimError(NewAppError("test", "app.user.missing_account.const", nil, "", http.StatusNotFound))
+	assert.Equal(t, "404", scimErr.Status)
+	assert.Empty(t, scimErr.ScimType)
+}
+
+func TestScimUserApplyPatch(t *testing.T) {
+	user := &ScimUser{UserName: "jane@example.com", Emails: []ScimEmail{{Value: "jane@example.com"}}, Active: NewBool(true)}
+
+	var patch ScimPatchRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"Operations": [
+		{"op": "Replace", "path": "emails[type eq \"work\"].value", "value": "jane.doe@example.com"},
+		{"op": "replace", "value": {"name": {"givenName": "Jane"}, "active": "False", "externalId": "00u1"}}
+	]}`), &patch))
+
+	require.Nil(t, user.ApplyPatch(patch.Operations))
+	assert.Equal(t, "jane.doe@example.com", user.PrimaryEmail())
+	assert.Equal(t, "Jane", user.Name.GivenName)
+	assert.Equal(t, "00u1", user.ExternalId)
+	assert.False(t, *user.Active)
+
+	assert.NotNil(t, user.ApplyPatch([]*ScimPatchOperation{{Op: "remove", Path: "userName"}}))
+	assert.NotNil(t, user.ApplyPatch([]*ScimPatchOperation{{Op: "move", Path: "userName"}}))
+	assert.NotNil(t, user.ApplyPatch([]*ScimPatchOperation{{Op: "replace", Path: "nickName", Value: json.RawMessage(`"jd"`)}}))
+
+	mmUser := &User{}
+	user.ApplyTo(mmUser)
+	assert.Equal(t, "jane", mmUser.Username)
+	assert.Equal(t, "jane@example.com", mmUser.Props[UserPropScimUserName])
+	assert.Equal(t, "00u1", mmUser.Props[UserPropScimExternalId])
+}
+
+func TestScimGroupApplyPatch(t *testing.T) {
+	group := &ScimGroup{DisplayName: "Engineers", Members: []ScimMember{{Value: "a"}, {Value: "b"}}}
+
+	var patch ScimPatchRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"Operations": [
+		{"op": "add", "path": "members", "value": [{"value": "b"}, {"value": "c"}]},
+		{"op": "remove", "path": "members[value eq \"a\"]"},
+		{"op": "replace", "value": {"displayName": "Platform Engineers"}}
+	]}`), &patch))
+
+	require.Nil(t, group.ApplyPatch(patch.Operations))
+	assert.Equal(t, "Platform Engineers", group.DisplayName)
+	assert.Equal(t, []string{"b", "c"}, group.MemberIds())
+	assert.Equal(t, "platform-engineers", group.GroupName())
+
+	require.Nil(t, group.ApplyPatch([]*ScimPatchOperation{{Op: "remove", Path: "members"}}))
+	assert.Empty(t, group.Members)
+
+	assert.NotNil(t, group.ApplyPatch([]*ScimPatchOperation{{Op: "remove", Path: "displayName"}}))
+}
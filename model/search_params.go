@@ -35,6 +35,10 @@ type SearchParams struct {
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool   `json:"search_without_userid,omitempty"`
 	Modifier            string `json:"modifier"`
+	// Regex, HasLink and HasFile are only set by advanced searches.
+	Regex   string `json:"regex,omitempty"`
+	HasLink bool   `json:"has_link,omitempty"`
+	HasFile bool   `json:"has_file,omitempty"`
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
@@ -387,6 +391,42 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 	return paramsList
 }
 
+// ParseAdvancedSearchParams parses the terms of an advanced search. Besides the regular search
+// syntax, they accept the has:link and has:file operators. regex applies to every returned
+// SearchParams.
+func ParseAdvancedSearchParams(text string, regex string, timeZoneOffset int) []*SearchParams {
+	hasLink := false
+	hasFile := false
+	words := []string{}
+	for _, word := range splitWords(text) {
+		switch strings.ToLower(word) {
+		case "has:link":
+			hasLink = true
+		case "has:file":
+			hasFile = true
+		default:
+			words = append(words, word)
+		}
+	}
+
+	paramsList := ParseSearchParams(strings.Join(words, " "), timeZoneOffset)
+
+	// special case for when only advanced filters are specified
+	if len(paramsList) == 0 && (regex != "" || hasLink || hasFile) {
+		paramsList = append(paramsList, &SearchParams{
+			TimeZoneOffset: timeZoneOffset,
+		})
+	}
+
+	for _, params := range paramsList {
+		params.Regex = regex
+		params.HasLink = hasLink
+		params.HasFile = hasFile
+	}
+
+	return paramsList
+}
+
 func IsSearchParamsListValid(paramsList []*SearchParams) *AppError {
 	// All SearchParams should have same IncludeDeletedChannels value.
 	for _, params := range paramsList {
@@ -1798,3 +1798,40 @@ func TestIsSearchParamsListValid(t *testing.T) {
 	err = IsSearchParamsListValid([]*SearchParams{})
 	assert.Nil(t, err)
 }
+
+func TestParseAdvancedSearchParams(t *testing.T) {
+	t.Run("has operators are extracted from the terms", func(t *testing.T) {
+		paramsList := ParseAdvancedSearchParams("deploy has:link HAS:FILE in:town-square", "", 0)
+		require.Len(t, paramsList, 1)
+		assert.Equal(t, "deploy", paramsList[0].Terms)
+		assert.Equal(t, []string{"town-square"}, paramsList[0].InChannels)
+		assert.True(t, paramsList[0].HasLink)
+		assert.True(t, paramsList[0].HasFile)
+		assert.Empty(t, paramsList[0].Regex)
+	})
+
+	t.Run("regex applies to every params", func(t *testing.T) {
+		paramsList := ParseAdvancedSearchParams("deploy #release", "v[0-9]+", 0)
+		require.Len(t, paramsList, 2)
+		for _, params := range paramsList {
+			assert.Equal(t, "v[0-9]+", params.Regex)
+			assert.False(t, params.HasLink)
+			assert.False(t, params.HasFile)
+		}
+	})
+
+	t.Run("only advanced filters", func(t *testing.T) {
+		paramsList := ParseAdvancedSearchParams("has:file", "", 0)
+		require.Len(t, paramsList, 1)
+		assert.Empty(t, paramsList[0].Terms)
+		assert.True(t, paramsList[0].HasFile)
+
+		paramsList = ParseAdvancedSearchParams("", "error [0-9]{3}", 0)
+		require.Len(t, paramsList, 1)
+		assert.Equal(t, "error [0-9]{3}", paramsList[0].Regex)
+	})
+
+	t.Run("nothing to search for", func(t *testing.T) {
+		assert.Empty(t, ParseAdvancedSearchParams("  ", "", 0))
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// Secret scanning warns the author of a post containing a secret, masks the secrets before
+	// saving the post, or blocks it.
+	SecretScanningActionWarn  = "warn"
+	SecretScanningActionMask  = "mask"
+	SecretScanningActionBlock = "block"
+
+	SecretKindAWSAccessKey      = "aws_access_key"
+	SecretKindPrivateKey        = "private_key"
+	SecretKindGitHubToken       = "github_token"
+	SecretKindSlackToken        = "slack_token"
+	SecretKindGoogleAPIKey      = "google_api_key"
+	SecretKindGenericAssignment = "generic_assignment"
+
+	secretMask = "********"
+)
+
+// SecretDetector finds a kind of secret in messages. If its expression has a group, only the
+// text of the group is the secret, otherwise the whole match is. Masking keeps the first
+// KeepPrefix characters of the secret, which usually identify its kind.
+type SecretDetector struct {
+	Kind       string
+	Regexp     *regexp.Regexp
+	KeepPrefix int
+}
+
+// SecretDetectors are the heuristics used to find secrets in posts, code blocks included.
+var SecretDetectors = []*SecretDetector{
+	{Kind: SecretKindAWSAccessKey, Regexp: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), KeepPrefix: 4},
+	{Kind: SecretKindPrivateKey, Regexp: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----(?:[\s\S]*?-----END (?:[A-Z]+ )*PRIVATE KEY-----|[\s\S]*)`)},
+	{Kind: SecretKindGitHubToken, Regexp: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`), KeepPrefix: 4},
+	{Kind: SecretKindSlackToken, Regexp: regexp.MustCompile(`\bxox[abprs]-[0-9A-Za-z-]{10,}`), KeepPrefix: 5},
+	{Kind: SecretKindGoogleAPIKey, Regexp: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), KeepPrefix: 4},
+	{Kind: SecretKindGenericAssignment, Regexp: regexp.MustCompile(`(?i)\b(?:api[_-]?key|secret(?:[_-]?key)?|access[_-]?token|auth[_-]?token|password|passwd)\b["']?\s*[:=]\s*["']?([A-Za-z0-9/+_.=-]{12,})`)},
+}
+
+func IsValidSecretScanningAction(action string) bool {
+	switch action {
+	case SecretScanningActionWarn, SecretScanningActionMask, SecretScanningActionBlock:
+		return true
+	}
+	return false
+}
+
+// secretSpan returns the start and end of the secret of a match of a detector.
+func (d *SecretDetector) secretSpan(match []int) (int, int) {
+	if len(match) >= 4 && match[2] >= 0 {
+		return match[2], match[3]
+	}
+	return match[0], match[1]
+}
+
+// ScanSecrets returns the kinds of the secrets found in the message.
+func ScanSecrets(message string) []string {
+	var kinds []string
+	for _, detector := range SecretDetectors {
+		if detector.Regexp.MatchString(message) {
+			kinds = append(kinds, detector.Kind)
+		}
+	}
+	return kinds
+}
+
+// MaskSecrets returns the message with the secrets it contains masked.
+func MaskSecrets(message string) string {
+	for _, detector := range SecretDetectors {
+		matches := detector.Regexp.FindAllStringSubmatchIndex(message, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		var sb strings.Builder
+		last := 0
+		for _, match := range matches {
+			start, end := detector.secretSpan(match)
+			if end-start > detector.KeepPrefix {
+				start += detector.KeepPrefix
+			}
+			sb.WriteString(message[last:start])
+			sb.WriteString(secretMask)
+			last = end
+		}
+		sb.WriteString(message[last:])
+		message = sb.String()
+	}
+
+	return message
+}
@@ -203,6 +203,12 @@ func (s *Session) IsSSOLogin() bool {
 	return s.IsOAuthUser() || s.IsSaml()
 }
 
+// IsImpersonation returns true if the session was started by a system admin impersonating
+// the user. Such sessions are read-only.
+func (s *Session) IsImpersonation() bool {
+	return s.Props[SessionPropType] == SessionTypeImpersonation
+}
+
 func (s *Session) GetUserRoles() []string {
 	return strings.Fields(s.Roles)
 }
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SessionPropIPAddress         = "ip_address"
+	SessionPropDeviceName        = "device_name"
+	SessionPropDeviceFingerprint = "device_fingerprint"
+
+	SessionDeviceNameMaxRunes = 64
+)
+
+// GeoLocation is the approximate location of an IP address.
+type GeoLocation struct {
+	Country string `json:"country"`
+	Region  string `json:"region,omitempty"`
+	City    string `json:"city,omitempty"`
+}
+
+// SessionDevice describes the device of a session, so that users can review the devices logged
+// into their account and revoke the sessions of those they don't recognize.
+type SessionDevice struct {
+	SessionId      string       `json:"session_id"`
+	Name           string       `json:"name"`
+	Fingerprint    string       `json:"fingerprint"`
+	Platform       string       `json:"platform"`
+	Os             string       `json:"os"`
+	Browser        string       `json:"browser"`
+	IsMobile       bool         `json:"is_mobile"`
+	IPAddress      string       `json:"ip_address"`
+	Location       *GeoLocation `json:"location,omitempty"`
+	CreateAt       int64        `json:"create_at"`
+	LastActivityAt int64        `json:"last_activity_at"`
+	ExpiresAt      int64        `json:"expires_at"`
+	Current        bool         `json:"current"`
+}
+
+// NewSessionDevice returns the device of a session, without its location.
+func NewSessionDevice(s *Session) *SessionDevice {
+	return &SessionDevice{
+		SessionId:      s.Id,
+		Name:           s.Props[SessionPropDeviceName],
+		Fingerprint:    s.Props[SessionPropDeviceFingerprint],
+		Platform:       s.Props[SessionPropPlatform],
+		Os:             s.Props[SessionPropOs],
+		Browser:        s.Props[SessionPropBrowser],
+		IsMobile:       s.IsMobileApp(),
+		IPAddress:      s.Props[SessionPropIPAddress],
+		CreateAt:       s.CreateAt,
+		LastActivityAt: s.LastActivityAt,
+		ExpiresAt:      s.ExpiresAt,
+	}
+}
+
+// SessionDeviceFingerprint identifies a device from its user agent and, for the mobile apps,
+// its device id. The fingerprint is hashed so that the device id isn't exposed.
+func SessionDeviceFingerprint(userAgent, deviceID string) string {
+	hash := sha256.Sum256([]byte(userAgent + "\n" + deviceID))
+	return hex.EncodeToString(hash[:8])
+}
+
+// IsValidSessionDeviceName returns an error if name can't be used to name a device.
+func IsValidSessionDeviceName(name string) *AppError {
+	if strings.TrimSpace(name) != name || utf8.RuneCountInString(name) > SessionDeviceNameMaxRunes {
+		return NewAppError("IsValidSessionDeviceName", "model.session_device.is_valid.name.app_error", map[string]interface{}{"Max": SessionDeviceNameMaxRunes}, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// UserDevice records that a user logged in from a device, and from which country when it is
+// known, so that logins from new devices and countries can be notified.
+type UserDevice struct {
+	UserId      string `json:"user_id"`
+	Fingerprint string `json:"fingerprint"`
+	Country     string `json:"country"`
+	CreateAt    int64  `json:"create_at"`
+	LastSeenAt  int64  `json:"last_seen_at"`
+}
@@ -133,3 +133,28 @@ func TestSessionIsOAuthUser(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionDevice(t *testing.T) {
+	session := &Session{Id: NewId(), CreateAt: 1, LastActivityAt: 2}
+	session.AddProp(SessionPropPlatform, "Linux")
+	session.AddProp(SessionPropBrowser, "Firefox/99.0")
+	session.AddProp(SessionPropIPAddress, "10.1.2.3")
+	session.AddProp(SessionPropDeviceName, "Work laptop")
+	session.AddProp(SessionPropDeviceFingerprint, SessionDeviceFingerprint("Mozilla/5.0", ""))
+
+	device := NewSessionDevice(session)
+	assert.Equal(t, session.Id, device.SessionId)
+	assert.Equal(t, "Work laptop", device.Name)
+	assert.Equal(t, "Linux", device.Platform)
+	assert.Equal(t, "10.1.2.3", device.IPAddress)
+	assert.Len(t, device.Fingerprint, 16)
+	assert.False(t, device.IsMobile)
+
+	assert.Equal(t, SessionDeviceFingerprint("Mozilla/5.0", ""), SessionDeviceFingerprint("Mozilla/5.0", ""))
+	assert.NotEqual(t, SessionDeviceFingerprint("Mozilla/5.0", ""), SessionDeviceFingerprint("Mozilla/5.0", "device"))
+
+	assert.Nil(t, IsValidSessionDeviceName(""))
+	assert.Nil(t, IsValidSessionDeviceName("Work laptop"))
+	assert.NotNil(t, IsValidSessionDeviceName(" Work laptop"))
+	assert.NotNil(t, IsValidSessionDeviceName(strings.Repeat("a", SessionDeviceNameMaxRunes+1)))
+}
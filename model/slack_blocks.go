@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PayloadFormatMattermost is the default format of the requests sent to, and responses
+	// received from, outgoing webhooks and custom slash commands.
+	PayloadFormatMattermost = ""
+	// PayloadFormatSlack sends requests in the exact format used by Slack, and translates
+	// Slack responses, including a subset of Block Kit, so that existing Slack integrations
+	// work unchanged.
+	PayloadFormatSlack = "slack"
+
+	SlackSignatureVersion = "v0"
+
+	SlackBlockTypeSection = "section"
+	SlackBlockTypeHeader  = "header"
+	SlackBlockTypeDivider = "divider"
+	SlackBlockTypeContext = "context"
+	SlackBlockTypeImage   = "image"
+
+	SlackTextTypeMrkdwn    = "mrkdwn"
+	SlackTextTypePlainText = "plain_text"
+)
+
+func IsValidPayloadFormat(format string) bool {
+	return format == PayloadFormatMattermost || format == PayloadFormatSlack
+}
+
+// SlackTimestamp formats a time in milliseconds as a Slack message timestamp, which is the
+// number of seconds since the epoch with six decimals.
+func SlackTimestamp(millis int64) string {
+	return fmt.Sprintf("%d.%06d", millis/1000, (millis%1000)*1000)
+}
+
+// SlackRequestSignature computes the X-Slack-Signature header of a request, letting Slack
+// integrations verify the requests they receive using the token of the integration as their
+// signing secret.
+func SlackRequestSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(SlackSignatureVersion + ":" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(body)
+	return SlackSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SlackBlockText is a Block Kit text object. It is also decoded from a plain string, the form
+// used by the text of context elements.
+type SlackBlockText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (t *SlackBlockText) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &t.Text)
+	}
+
+	type blockText SlackBlockText
+	return json.Unmarshal(data, (*blockText)(t))
+}
+
+// SlackBlockElement is an element of a context block, or the accessory of a section block.
+type SlackBlockElement struct {
+	Type     string          `json:"type"`
+	Text     *SlackBlockText `json:"text,omitempty"`
+	ImageURL string          `json:"image_url,omitempty"`
+	AltText  string          `json:"alt_text,omitempty"`
+}
+
+// SlackBlock is a Slack Block Kit layout block. Only the section, header, divider, context and
+// image blocks are translated, other blocks are ignored.
+type SlackBlock struct {
+	Type      string               `json:"type"`
+	BlockId   string               `json:"block_id,omitempty"`
+	Text      *SlackBlockText      `json:"text,omitempty"`
+	Fields    []*SlackBlockText    `json:"fields,omitempty"`
+	Elements  []*SlackBlockElement `json:"elements,omitempty"`
+	Accessory *SlackBlockElement   `json:"accessory,omitempty"`
+	ImageURL  string               `json:"image_url,omitempty"`
+	AltText   string               `json:"alt_text,omitempty"`
+	Title     *SlackBlockText      `json:"title,omitempty"`
+}
+
+var (
+	slackBoldRegexp   = regexp.MustCompile(`(^|[\s(_~])\*([^*\s]|[^*\s][^*\n]*[^*\s])\*`)
+	slackStrikeRegexp = regexp.MustCompile(`(^|[\s(_*])~([^~\s]|[^~\s][^~\n]*[^~\s])~`)
+)
+
+// SlackMrkdwnToMarkdown converts the emphasis of Slack mrkdwn, which differs from Markdown, to
+// Markdown. Links and mentions are handled by the usual Slack text processing.
+func SlackMrkdwnToMarkdown(text string) string {
+	var sb strings.Builder
+	// Code spans and blocks are left untouched.
+	for i, part := range strings.Split(text, "`") {
+		if i > 0 {
+			sb.WriteString("`")
+		}
+		if i%2 == 1 {
+			sb.WriteString(part)
+			continue
+		}
+		part = slackBoldRegexp.ReplaceAllString(part, "$1**$2**")
+		part = slackStrikeRegexp.ReplaceAllString(part, "$1~~$2~~")
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+func (t *SlackBlockText) toMarkdown(textType string) string {
+	if t == nil {
+		return ""
+	}
+	if t.Type != "" {
+		textType = t.Type
+	}
+	if textType == SlackTextTypeMrkdwn {
+		return SlackMrkdwnToMarkdown(t.Text)
+	}
+	return t.Text
+}
+
+func slackImageMarkdown(url, altText string) string {
+	return fmt.Sprintf("![%s](%s)", altText, url)
+}
+
+// SlackBlocksToMarkdown renders the supported blocks as a Markdown message. It returns an empty
+// string if none of the blocks is supported.
+func SlackBlocksToMarkdown(blocks []*SlackBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+
+		switch block.Type {
+		case SlackBlockTypeSection:
+			var lines []string
+			if text := block.Text.toMarkdown(SlackTextTypeMrkdwn); text != "" {
+				lines = append(lines, text)
+			}
+			for _, field := range block.Fields {
+				if text := field.toMarkdown(SlackTextTypeMrkdwn); text != "" {
+					lines = append(lines, text)
+				}
+			}
+			if block.Accessory != nil && block.Accessory.Type == SlackBlockTypeImage && block.Accessory.ImageURL != "" {
+				lines = append(lines, slackImageMarkdown(block.Accessory.ImageURL, block.Accessory.AltText))
+			}
+			if len(lines) > 0 {
+				parts = append(parts, strings.Join(lines, "\n"))
+			}
+		case SlackBlockTypeHeader:
+			if text := block.Text.toMarkdown(SlackTextTypePlainText); text != "" {
+				parts = append(parts, "### "+text)
+			}
+		case SlackBlockTypeDivider:
+			parts = append(parts, "---")
+		case SlackBlockTypeContext:
+			var elements []string
+			for _, element := range block.Elements {
+				if element == nil {
+					continue
+				}
+				if element.Type == SlackBlockTypeImage {
+					if element.AltText != "" {
+						elements = append(elements, element.AltText)
+					}
+				} else if text := element.Text.toMarkdown(element.Type); text != "" {
+					elements = append(elements, text)
+				}
+			}
+			if len(elements) > 0 {
+				parts = append(parts, "_"+strings.Join(elements, " ")+"_")
+			}
+		case SlackBlockTypeImage:
+			if block.ImageURL == "" {
+				continue
+			}
+			image := slackImageMarkdown(block.ImageURL, block.AltText)
+			if title := block.Title.toMarkdown(SlackTextTypePlainText); title != "" {
+				image = "**" + title + "**\n" + image
+			}
+			parts = append(parts, image)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// slackMessageText returns the text of a Slack message. When blocks are present, Slack only
+// uses the text as a fallback for notifications, the blocks being displayed instead.
+func slackMessageText(text string, blocks []*SlackBlock) string {
+	if rendered := SlackBlocksToMarkdown(blocks); rendered != "" {
+		return rendered
+	}
+	return SlackMrkdwnToMarkdown(text)
+}
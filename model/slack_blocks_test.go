@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackTimestamp(t *testing.T) {
+	assert.Equal(t, "1355517523.005000", SlackTimestamp(1355517523005))
+	assert.Equal(t, "1355517523.000000", SlackTimestamp(1355517523000))
+}
+
+func TestSlackRequestSignature(t *testing.T) {
+	// Example from the Slack documentation on verifying requests.
+	body := "token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c"
+	assert.Equal(t,
+		"v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503",
+		SlackRequestSignature("8f742231b10e8888abcd99yyyzzz85a5", 1531420618, []byte(body)),
+	)
+}
+
+func TestSlackMrkdwnToMarkdown(t *testing.T) {
+	for input, expected := range map[string]string{
+		"plain text":                "plain text",
+		"*bold* and _italic_":       "**bold** and _italic_",
+		"a ~strike~ word":           "a ~~strike~~ word",
+		"(*bold*)":                  "(**bold**)",
+		"`*not bold*` but *bold*":   "`*not bold*` but **bold**",
+		"2 * 3 * 4":                 "2 * 3 * 4",
+		"<https://example.com|ex>*": "<https://example.com|ex>*",
+	} {
+		assert.Equal(t, expected, SlackMrkdwnToMarkdown(input), input)
+	}
+}
+
+func TestSlackBlocksToMarkdown(t *testing.T) {
+	var blocks []*SlackBlock
+	err := json.Unmarshal([]byte(`[
+		{"type": "header", "text": {"type": "plain_text", "text": "Release"}},
+		{"type": "section", "text": {"type": "mrkdwn", "text": "*v1.2* is out"}, "fields": [{"type": "mrkdwn", "text": "*Env*\nprod"}],
+			"accessory": {"type": "image", "image_url": "https://example.com/logo.png", "alt_text": "logo"}},
+		{"type": "divider"},
+		{"type": "context", "elements": [{"type": "image", "image_url": "https://example.com/a.png", "alt_text": "avatar"}, {"type": "mrkdwn", "text": "by *bot*"}]},
+		{"type": "image", "image_url": "https://example.com/chart.png", "alt_text": "chart", "title": {"type": "plain_text", "text": "Chart"}},
+		{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Roll back"}, "value": "rollback"}]}
+	]`), &blocks)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		"### Release",
+		"**v1.2** is out\n**Env**\nprod\n![logo](https://example.com/logo.png)",
+		"---",
+		"_avatar by **bot**_",
+		"**Chart**\n![chart](https://example.com/chart.png)",
+	}, "\n\n"), SlackBlocksToMarkdown(blocks))
+
+	assert.Empty(t, SlackBlocksToMarkdown(nil))
+	assert.Empty(t, SlackBlocksToMarkdown([]*SlackBlock{{Type: "actions"}}))
+}
+
+func TestCommandResponseTranslateSlackFormat(t *testing.T) {
+	response, err := CommandResponseFromJSON(strings.NewReader(`{"response_type": "in_channel", "text": "fallback", "blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "*done*"}}]}`))
+	require.NoError(t, err)
+	response.TranslateSlackFormat()
+	assert.Equal(t, "**done**", response.Text)
+	assert.Equal(t, CommandResponseTypeInChannel, response.ResponseType)
+	assert.Nil(t, response.Blocks)
+
+	response = &CommandResponse{Text: "*done*"}
+	response.TranslateSlackFormat()
+	assert.Equal(t, "**done**", response.Text)
+}
+
+func TestOutgoingWebhookResponseTranslateSlackFormat(t *testing.T) {
+	response := &OutgoingWebhookResponse{}
+	response.TranslateSlackFormat()
+	assert.Nil(t, response.Text)
+
+	response = &OutgoingWebhookResponse{Blocks: []*SlackBlock{{Type: SlackBlockTypeDivider}}}
+	response.TranslateSlackFormat()
+	require.NotNil(t, response.Text)
+	assert.Equal(t, "---", *response.Text)
+}
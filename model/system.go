@@ -178,3 +178,41 @@ type AppliedMigration struct {
 	Version int    `json:"version"`
 	Name    string `json:"name"`
 }
+
+// SupportPacketOptions controls what is included in a generated support packet.
+type SupportPacketOptions struct {
+	// IncludeDiagnostics adds store statistics and runtime profiles to the packet.
+	IncludeDiagnostics bool `json:"include_diagnostics"`
+}
+
+// TableRowCount is the estimated number of rows in a database table.
+type TableRowCount struct {
+	Name     string `json:"name"`
+	RowCount int64  `json:"row_count"`
+}
+
+// SlowQuery holds the execution statistics of a normalized query, as reported by the database.
+// Literal values are replaced with placeholders so no user data is included.
+type SlowQuery struct {
+	Query       string  `json:"query"`
+	Calls       int64   `json:"calls"`
+	MeanTimeMs  float64 `json:"mean_time_ms"`
+	TotalTimeMs float64 `json:"total_time_ms"`
+}
+
+// OrphanedRows is the number of rows of a table that reference a record that no longer exists.
+type OrphanedRows struct {
+	Name  string `json:"name"`
+	Table string `json:"table"`
+	Count int64  `json:"count"`
+}
+
+// TableBloat holds the storage statistics of a database table. DeadRows is only reported by
+// Postgres and FreeBytes only by MySQL.
+type TableBloat struct {
+	Name       string `json:"name"`
+	LiveRows   int64  `json:"live_rows"`
+	DeadRows   int64  `json:"dead_rows"`
+	TotalBytes int64  `json:"total_bytes"`
+	FreeBytes  int64  `json:"free_bytes"`
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+)
+
+const (
+	CheckupSeverityInfo     = "info"
+	CheckupSeverityWarning  = "warning"
+	CheckupSeverityCritical = "critical"
+
+	CheckupCategoryConfig      = "config"
+	CheckupCategoryDatabase    = "database"
+	CheckupCategoryEnvironment = "environment"
+	CheckupCategoryFileStorage = "file_storage"
+	CheckupCategoryEmail       = "email"
+)
+
+// CheckupFinding describes a single problem, or potential problem, found while
+// evaluating the server configuration and environment.
+type CheckupFinding struct {
+	Id       string `json:"id"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	// MessageId and MessageParams identify the translation of the message, which is only set once
+	// the checkup is translated.
+	MessageId     string                 `json:"message_id"`
+	MessageParams map[string]interface{} `json:"message_params,omitempty"`
+	Message       string                 `json:"message"`
+	Detail        string                 `json:"detail,omitempty"`
+}
+
+// SystemCheckup is the result of running every checkup rule against the server.
+type SystemCheckup struct {
+	CreateAt int64             `json:"create_at"`
+	Findings []*CheckupFinding `json:"findings"`
+}
+
+// Translate sets the message of every finding in the language of T.
+func (sc *SystemCheckup) Translate(T i18n.TranslateFunc) {
+	for _, f := range sc.Findings {
+		switch {
+		case T == nil:
+			f.Message = f.MessageId
+		case f.MessageParams == nil:
+			f.Message = T(f.MessageId)
+		default:
+			f.Message = T(f.MessageId, f.MessageParams)
+		}
+	}
+}
+
+// HighestSeverity returns the most severe level among the findings, or an
+// empty string when there are none.
+func (sc *SystemCheckup) HighestSeverity() string {
+	highest := ""
+	for _, f := range sc.Findings {
+		if checkupSeverityRank(f.Severity) > checkupSeverityRank(highest) {
+			highest = f.Severity
+		}
+	}
+	return highest
+}
+
+// FindingsBySeverity returns the findings with the given severity.
+func (sc *SystemCheckup) FindingsBySeverity(severity string) []*CheckupFinding {
+	findings := []*CheckupFinding{}
+	for _, f := range sc.Findings {
+		if f.Severity == severity {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func IsValidCheckupSeverity(severity string) bool {
+	return checkupSeverityRank(severity) > 0
+}
+
+func checkupSeverityRank(severity string) int {
+	switch severity {
+	case CheckupSeverityInfo:
+		return 1
+	case CheckupSeverityWarning:
+		return 2
+	case CheckupSeverityCritical:
+		return 3
+	}
+	return 0
+}
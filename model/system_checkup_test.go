@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemCheckupSeverity(t *testing.T) {
+	t.Run("no findings", func(t *testing.T) {
+		sc := &SystemCheckup{}
+		assert.Equal(t, "", sc.HighestSeverity())
+		assert.Empty(t, sc.FindingsBySeverity(CheckupSeverityCritical))
+	})
+
+	t.Run("mixed findings", func(t *testing.T) {
+		sc := &SystemCheckup{
+			Findings: []*CheckupFinding{
+				{Id: "a", Severity: CheckupSeverityInfo},
+				{Id: "b", Severity: CheckupSeverityCritical},
+				{Id: "c", Severity: CheckupSeverityWarning},
+			},
+		}
+		assert.Equal(t, CheckupSeverityCritical, sc.HighestSeverity())
+
+		warnings := sc.FindingsBySeverity(CheckupSeverityWarning)
+		assert.Len(t, warnings, 1)
+		assert.Equal(t, "c", warnings[0].Id)
+	})
+
+	t.Run("valid severities", func(t *testing.T) {
+		assert.True(t, IsValidCheckupSeverity(CheckupSeverityInfo))
+		assert.True(t, IsValidCheckupSeverity(CheckupSeverityWarning))
+		assert.True(t, IsValidCheckupSeverity(CheckupSeverityCritical))
+		assert.False(t, IsValidCheckupSeverity(""))
+		assert.False(t, IsValidCheckupSeverity("fatal"))
+	})
+}
+
+func TestSystemCheckupTranslate(t *testing.T) {
+	sc := &SystemCheckup{
+		Findings: []*CheckupFinding{
+			{Id: "a", MessageId: "app.system_checkup.a"},
+			{Id: "b", MessageId: "app.system_checkup.b", MessageParams: map[string]interface{}{"Driver": "local"}},
+		},
+	}
+
+	sc.Translate(nil)
+	assert.Equal(t, "app.system_checkup.a", sc.Findings[0].Message)
+
+	sc.Translate(func(id string, params ...interface{}) string {
+		if len(params) > 0 {
+			return id + ":" + params[0].(map[string]interface{})["Driver"].(string)
+		}
+		return id + ":translated"
+	})
+	assert.Equal(t, "app.system_checkup.a:translated", sc.Findings[0].Message)
+	assert.Equal(t, "app.system_checkup.b:local", sc.Findings[1].Message)
+}
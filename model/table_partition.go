@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// TablePartitionLegacySuffix names the partition holding the rows a table had when it was
+	// converted to a partitioned table.
+	TablePartitionLegacySuffix = "_legacy"
+	// TablePartitionDefaultSuffix names the partition catching rows outside of every monthly
+	// partition.
+	TablePartitionDefaultSuffix = "_default"
+
+	tablePartitionMonthFormat = "2006_01"
+)
+
+// PartitionedTables are the tables partitioned by CreateAt when table partitioning is enabled.
+var PartitionedTables = []string{"Posts", "Audits"}
+
+// TablePartition is a monthly partition of a table, holding the rows created in [Start, End).
+type TablePartition struct {
+	Table string
+	Name  string
+	Start int64
+	End   int64
+}
+
+// NewMonthlyTablePartition returns the partition of table holding the rows created in the month
+// of t, in UTC.
+func NewMonthlyTablePartition(table string, t time.Time) *TablePartition {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	return &TablePartition{
+		Table: table,
+		Name:  fmt.Sprintf("%s_p%s", strings.ToLower(table), start.Format(tablePartitionMonthFormat)),
+		Start: GetMillisForTime(start),
+		End:   GetMillisForTime(end),
+	}
+}
+
+// ParseMonthlyTablePartition returns the monthly partition of table with the given name, or nil
+// if name isn't the name of one.
+func ParseMonthlyTablePartition(table, name string) *TablePartition {
+	prefix := strings.ToLower(table) + "_p"
+	if !strings.HasPrefix(name, prefix) {
+		return nil
+	}
+
+	month, err := time.Parse(tablePartitionMonthFormat, strings.TrimPrefix(name, prefix))
+	if err != nil {
+		return nil
+	}
+
+	return NewMonthlyTablePartition(table, month)
+}
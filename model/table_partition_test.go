@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMonthlyTablePartition(t *testing.T) {
+	partition := NewMonthlyTablePartition("Posts", time.Date(2021, time.December, 15, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, "Posts", partition.Table)
+	assert.Equal(t, "posts_p2021_12", partition.Name)
+	assert.Equal(t, GetMillisForTime(time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC)), partition.Start)
+	assert.Equal(t, GetMillisForTime(time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)), partition.End)
+}
+
+func TestParseMonthlyTablePartition(t *testing.T) {
+	partition := ParseMonthlyTablePartition("Audits", "audits_p2022_03")
+	require.NotNil(t, partition)
+	assert.Equal(t, NewMonthlyTablePartition("Audits", time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)), partition)
+
+	assert.Nil(t, ParseMonthlyTablePartition("Audits", "posts_p2022_03"))
+	assert.Nil(t, ParseMonthlyTablePartition("Audits", "audits_legacy"))
+	assert.Nil(t, ParseMonthlyTablePartition("Audits", "audits_default"))
+}
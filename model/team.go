@@ -21,8 +21,21 @@ const (
 	TeamEmailMaxLength          = 128
 	TeamNameMaxLength           = 64
 	TeamNameMinLength           = 2
+
+	TeamFeatureCustomEmoji       = "custom_emoji"
+	TeamFeatureGifs              = "gifs"
+	TeamFeatureFileUploads       = "file_uploads"
+	TeamFeatureTownSquarePosting = "town_square_posting"
 )
 
+// TeamFeatures are the features that can be disabled per team.
+var TeamFeatures = []string{
+	TeamFeatureCustomEmoji,
+	TeamFeatureGifs,
+	TeamFeatureFileUploads,
+	TeamFeatureTownSquarePosting,
+}
+
 type Team struct {
 	Id                  string  `json:"id"`
 	CreateAt            int64   `json:"create_at"`
@@ -42,6 +55,8 @@ type Team struct {
 	GroupConstrained    *bool   `json:"group_constrained"`
 	PolicyID            *string `json:"policy_id"`
 	CloudLimitsArchived bool    `json:"cloud_limits_archived"`
+	// DisabledFeatures are the TeamFeatures disabled in the team.
+	DisabledFeatures StringArray `json:"disabled_features,omitempty"`
 }
 
 type TeamPatch struct {
@@ -54,6 +69,19 @@ type TeamPatch struct {
 	CloudLimitsArchived *bool   `json:"cloud_limits_archived"`
 }
 
+// TeamFeaturesPatch enables or disables features of a team, keyed by their name in TeamFeatures.
+type TeamFeaturesPatch map[string]bool
+
+func (p TeamFeaturesPatch) IsValid() *AppError {
+	for feature := range p {
+		if !StringArray(TeamFeatures).Contains(feature) {
+			return NewAppError("TeamFeaturesPatch.IsValid", "model.team.is_valid.feature.app_error", map[string]interface{}{"Feature": feature}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
 type TeamForExport struct {
 	Team
 	SchemeName *string
@@ -254,6 +282,26 @@ func (o *Team) Patch(patch *TeamPatch) {
 	}
 }
 
+// IsFeatureEnabled returns whether the feature, one of TeamFeatures, is enabled in the team.
+func (o *Team) IsFeatureEnabled(feature string) bool {
+	return !o.DisabledFeatures.Contains(feature)
+}
+
+// PatchFeatures enables or disables the features of the team set in the patch.
+func (o *Team) PatchFeatures(patch TeamFeaturesPatch) {
+	disabled := StringArray{}
+	for _, feature := range TeamFeatures {
+		enabled, ok := patch[feature]
+		if !ok {
+			enabled = o.IsFeatureEnabled(feature)
+		}
+		if !enabled {
+			disabled = append(disabled, feature)
+		}
+	}
+	o.DisabledFeatures = disabled
+}
+
 func (o *Team) IsGroupConstrained() bool {
 	return o.GroupConstrained != nil && *o.GroupConstrained
 }
@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	TeamInviteLinkNameMaxRunes = 64
+)
+
+// TeamInviteLink lets users join a team with its id, used in place of the invite id of the team.
+// A link can be used MaxUses times, unless zero, until ExpireAt, unless zero, and by the users
+// whose email matches AllowedDomains, unless empty. Links are revoked rather than removed, to keep
+// their counters.
+type TeamInviteLink struct {
+	Id             string `json:"id"`
+	TeamId         string `json:"team_id"`
+	CreatorId      string `json:"creator_id"`
+	Name           string `json:"name"`
+	MaxUses        int64  `json:"max_uses"`
+	UseCount       int64  `json:"use_count"`
+	RejectedCount  int64  `json:"rejected_count"`
+	LastUsedAt     int64  `json:"last_used_at"`
+	ExpireAt       int64  `json:"expire_at"`
+	AllowedDomains string `json:"allowed_domains"`
+	CreateAt       int64  `json:"create_at"`
+	UpdateAt       int64  `json:"update_at"`
+	DeleteAt       int64  `json:"delete_at"`
+}
+
+type TeamInviteLinkPatch struct {
+	Name           *string `json:"name"`
+	MaxUses        *int64  `json:"max_uses"`
+	ExpireAt       *int64  `json:"expire_at"`
+	AllowedDomains *string `json:"allowed_domains"`
+}
+
+func (o *TeamInviteLink) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = o.CreateAt
+
+	o.UseCount = 0
+	o.RejectedCount = 0
+	o.LastUsedAt = 0
+	o.DeleteAt = 0
+}
+
+func (o *TeamInviteLink) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+func (o *TeamInviteLink) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TeamId) {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Name) > TeamInviteLinkNameMaxRunes {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.name.app_error", map[string]interface{}{"Max": TeamInviteLinkNameMaxRunes}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.MaxUses < 0 {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.max_uses.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ExpireAt < 0 {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.expire_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.AllowedDomains) > TeamAllowedDomainsMaxLength {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.allowed_domains.app_error", map[string]interface{}{"Max": TeamAllowedDomainsMaxLength}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("TeamInviteLink.IsValid", "model.team_invite_link.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *TeamInviteLink) Patch(patch *TeamInviteLinkPatch) {
+	if patch.Name != nil {
+		o.Name = *patch.Name
+	}
+
+	if patch.MaxUses != nil {
+		o.MaxUses = *patch.MaxUses
+	}
+
+	if patch.ExpireAt != nil {
+		o.ExpireAt = *patch.ExpireAt
+	}
+
+	if patch.AllowedDomains != nil {
+		o.AllowedDomains = *patch.AllowedDomains
+	}
+}
+
+// IsExpired returns whether the link can no longer be used at the given time.
+func (o *TeamInviteLink) IsExpired(now int64) bool {
+	return o.ExpireAt != 0 && o.ExpireAt <= now
+}
+
+// IsExhausted returns whether the link was used as many times as allowed.
+func (o *TeamInviteLink) IsExhausted() bool {
+	return o.MaxUses != 0 && o.UseCount >= o.MaxUses
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamInviteLinkIsValid(t *testing.T) {
+	link := &TeamInviteLink{
+		TeamId:    NewId(),
+		CreatorId: NewId(),
+		UseCount:  3,
+	}
+	link.PreSave()
+	require.Nil(t, link.IsValid())
+	assert.Zero(t, link.UseCount, "a new link has not been used")
+
+	link.Name = strings.Repeat("a", TeamInviteLinkNameMaxRunes+1)
+	require.NotNil(t, link.IsValid())
+	link.Name = ""
+
+	link.MaxUses = -1
+	require.NotNil(t, link.IsValid())
+	link.MaxUses = 0
+
+	link.AllowedDomains = strings.Repeat("a", TeamAllowedDomainsMaxLength+1)
+	require.NotNil(t, link.IsValid())
+	link.AllowedDomains = "example.com"
+
+	require.Nil(t, link.IsValid())
+}
+
+func TestTeamInviteLinkUsability(t *testing.T) {
+	link := &TeamInviteLink{}
+	assert.False(t, link.IsExpired(GetMillis()), "a link without expiry never expires")
+	assert.False(t, link.IsExhausted(), "a link without cap is never exhausted")
+
+	link.ExpireAt = 1000
+	assert.False(t, link.IsExpired(999))
+	assert.True(t, link.IsExpired(1000))
+
+	link.MaxUses = 2
+	link.UseCount = 1
+	assert.False(t, link.IsExhausted())
+	link.UseCount = 2
+	assert.True(t, link.IsExhausted())
+}
+
+func TestTeamInviteLinkPatch(t *testing.T) {
+	link := &TeamInviteLink{Name: "conference", MaxUses: 10}
+	name := "meetup"
+	var expireAt int64 = 1000
+	link.Patch(&TeamInviteLinkPatch{Name: &name, ExpireAt: &expireAt})
+	assert.Equal(t, "meetup", link.Name)
+	assert.Equal(t, int64(10), link.MaxUses)
+	assert.Equal(t, int64(1000), link.ExpireAt)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	TeamTemplateDisplayNameMaxRunes = 64
+	TeamTemplateDescriptionMaxRunes = 1024
+	TeamTemplateMaxChannels         = 50
+	TeamTemplateMaxCategories       = 20
+	TeamTemplateMaxWebhooks         = 20
+	TeamTemplateMaxBots             = 20
+)
+
+// TeamTemplate is an admin defined blueprint used to provision the channels, sidebar categories,
+// incoming webhooks and bot members of new teams.
+type TeamTemplate struct {
+	Id          string                 `json:"id"`
+	DisplayName string                 `json:"display_name"`
+	Description string                 `json:"description"`
+	CreatorId   string                 `json:"creator_id"`
+	CreateAt    int64                  `json:"create_at"`
+	UpdateAt    int64                  `json:"update_at"`
+	DeleteAt    int64                  `json:"delete_at"`
+	Definition  TeamTemplateDefinition `json:"definition"`
+}
+
+type TeamTemplateDefinition struct {
+	Channels         []TeamTemplateChannel         `json:"channels"`
+	Categories       []TeamTemplateCategory        `json:"categories"`
+	IncomingWebhooks []TeamTemplateIncomingWebhook `json:"incoming_webhooks"`
+	BotUserIds       []string                      `json:"bot_user_ids"`
+}
+
+type TeamTemplateChannel struct {
+	Name        string      `json:"name"`
+	DisplayName string      `json:"display_name"`
+	Type        ChannelType `json:"type"`
+	Purpose     string      `json:"purpose"`
+	Header      string      `json:"header"`
+}
+
+// TeamTemplateCategory is a sidebar category created for the team creator, holding channels of
+// the template referenced by name.
+type TeamTemplateCategory struct {
+	DisplayName  string   `json:"display_name"`
+	ChannelNames []string `json:"channel_names"`
+}
+
+type TeamTemplateIncomingWebhook struct {
+	ChannelName string `json:"channel_name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	Username    string `json:"username"`
+}
+
+func (t *TeamTemplate) PreSave() {
+	if t.Id == "" {
+		t.Id = NewId()
+	}
+
+	t.CreateAt = GetMillis()
+	t.UpdateAt = t.CreateAt
+}
+
+func (t *TeamTemplate) PreUpdate() {
+	t.UpdateAt = GetMillis()
+}
+
+func (t *TeamTemplate) IsValid() *AppError {
+	if !IsValidId(t.Id) {
+		return NewAppError("TeamTemplate.IsValid", "model.team_template.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if t.CreateAt == 0 {
+		return NewAppError("TeamTemplate.IsValid", "model.team_template.is_valid.create_at.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.UpdateAt == 0 {
+		return NewAppError("TeamTemplate.IsValid", "model.team_template.is_valid.update_at.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.DisplayName == "" || utf8.RuneCountInString(t.DisplayName) > TeamTemplateDisplayNameMaxRunes {
+		return NewAppError("TeamTemplate.IsValid", "model.team_template.is_valid.display_name.app_error", map[string]interface{}{"Max": TeamTemplateDisplayNameMaxRunes}, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(t.Description) > TeamTemplateDescriptionMaxRunes {
+		return NewAppError("TeamTemplate.IsValid", "model.team_template.is_valid.description.app_error", map[string]interface{}{"Max": TeamTemplateDescriptionMaxRunes}, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	if t.CreatorId != "" && !IsValidId(t.CreatorId) {
+		return NewAppError("TeamTemplate.IsValid", "model.team_template.is_valid.creator_id.app_error", nil, "id="+t.Id, http.StatusBadRequest)
+	}
+
+	return t.Definition.IsValid()
+}
+
+func (d *TeamTemplateDefinition) IsValid() *AppError {
+	if len(d.Channels) > TeamTemplateMaxChannels || len(d.Categories) > TeamTemplateMaxCategories ||
+		len(d.IncomingWebhooks) > TeamTemplateMaxWebhooks || len(d.BotUserIds) > TeamTemplateMaxBots {
+		return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.too_many.app_error", map[string]interface{}{
+			"MaxChannels":   TeamTemplateMaxChannels,
+			"MaxCategories": TeamTemplateMaxCategories,
+			"MaxWebhooks":   TeamTemplateMaxWebhooks,
+			"MaxBots":       TeamTemplateMaxBots,
+		}, "", http.StatusBadRequest)
+	}
+
+	channelNames := make(map[string]bool, len(d.Channels))
+	for _, channel := range d.Channels {
+		if !IsValidChannelIdentifier(channel.Name) || channelNames[channel.Name] {
+			return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.channel_name.app_error", nil, "name="+channel.Name, http.StatusBadRequest)
+		}
+		channelNames[channel.Name] = true
+
+		if channel.DisplayName == "" || utf8.RuneCountInString(channel.DisplayName) > ChannelDisplayNameMaxRunes {
+			return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.channel_display_name.app_error", nil, "name="+channel.Name, http.StatusBadRequest)
+		}
+
+		if channel.Type != ChannelTypeOpen && channel.Type != ChannelTypePrivate {
+			return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.channel_type.app_error", nil, "name="+channel.Name, http.StatusBadRequest)
+		}
+	}
+
+	for _, category := range d.Categories {
+		if category.DisplayName == "" {
+			return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.category_display_name.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		for _, name := range category.ChannelNames {
+			if !channelNames[name] {
+				return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.unknown_channel.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+			}
+		}
+	}
+
+	for _, hook := range d.IncomingWebhooks {
+		if !channelNames[hook.ChannelName] {
+			return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.unknown_channel.app_error", map[string]interface{}{"Name": hook.ChannelName}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, botUserID := range d.BotUserIds {
+		if !IsValidId(botUserID) {
+			return NewAppError("TeamTemplateDefinition.IsValid", "model.team_template.is_valid.bot_user_id.app_error", nil, "bot_user_id="+botUserID, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamTemplateIsValid(t *testing.T) {
+	newTemplate := func() *TeamTemplate {
+		template := &TeamTemplate{
+			DisplayName: "Project",
+			CreatorId:   NewId(),
+			Definition: TeamTemplateDefinition{
+				Channels: []TeamTemplateChannel{
+					{Name: "planning", DisplayName: "Planning", Type: ChannelTypeOpen},
+					{Name: "leads", DisplayName: "Leads", Type: ChannelTypePrivate},
+				},
+				Categories: []TeamTemplateCategory{
+					{DisplayName: "Project", ChannelNames: []string{"planning", "leads"}},
+				},
+				IncomingWebhooks: []TeamTemplateIncomingWebhook{
+					{ChannelName: "planning", DisplayName: "CI"},
+				},
+				BotUserIds: []string{NewId()},
+			},
+		}
+		template.PreSave()
+		return template
+	}
+
+	require.Nil(t, newTemplate().IsValid())
+
+	for name, tc := range map[string]struct {
+		update  func(*TeamTemplate)
+		errorID string
+	}{
+		"invalid id": {
+			update:  func(tt *TeamTemplate) { tt.Id = "junk" },
+			errorID: "model.team_template.is_valid.id.app_error",
+		},
+		"empty display name": {
+			update:  func(tt *TeamTemplate) { tt.DisplayName = "" },
+			errorID: "model.team_template.is_valid.display_name.app_error",
+		},
+		"description too long": {
+			update:  func(tt *TeamTemplate) { tt.Description = strings.Repeat("a", TeamTemplateDescriptionMaxRunes+1) },
+			errorID: "model.team_template.is_valid.description.app_error",
+		},
+		"duplicate channel name": {
+			update: func(tt *TeamTemplate) {
+				tt.Definition.Channels = append(tt.Definition.Channels, TeamTemplateChannel{Name: "planning", DisplayName: "Again", Type: ChannelTypeOpen})
+			},
+			errorID: "model.team_template.is_valid.channel_name.app_error",
+		},
+		"direct channel type": {
+			update:  func(tt *TeamTemplate) { tt.Definition.Channels[0].Type = ChannelTypeDirect },
+			errorID: "model.team_template.is_valid.channel_type.app_error",
+		},
+		"category with unknown channel": {
+			update:  func(tt *TeamTemplate) { tt.Definition.Categories[0].ChannelNames = []string{"missing"} },
+			errorID: "model.team_template.is_valid.unknown_channel.app_error",
+		},
+		"webhook with unknown channel": {
+			update:  func(tt *TeamTemplate) { tt.Definition.IncomingWebhooks[0].ChannelName = "missing" },
+			errorID: "model.team_template.is_valid.unknown_channel.app_error",
+		},
+		"invalid bot user id": {
+			update:  func(tt *TeamTemplate) { tt.Definition.BotUserIds = []string{"junk"} },
+			errorID: "model.team_template.is_valid.bot_user_id.app_error",
+		},
+		"too many channels": {
+			update: func(tt *TeamTemplate) {
+				tt.Definition.Channels = make([]TeamTemplateChannel, TeamTemplateMaxChannels+1)
+			},
+			errorID: "model.team_template.is_valid.too_many.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			template := newTemplate()
+			tc.update(template)
+			appErr := template.IsValid()
+			require.NotNil(t, appErr)
+			require.Equal(t, tc.errorID, appErr.Id)
+		})
+	}
+}
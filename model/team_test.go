@@ -141,3 +141,20 @@ func TestTeamPatch(t *testing.T) {
 	require.Equal(t, *p.AllowOpenInvite, o.AllowOpenInvite, "AllowOpenInvite did not update")
 	require.Equal(t, *p.GroupConstrained, *o.GroupConstrained)
 }
+
+func TestTeamPatchFeatures(t *testing.T) {
+	o := Team{Id: NewId()}
+	require.True(t, o.IsFeatureEnabled(TeamFeatureGifs))
+
+	o.PatchFeatures(TeamFeaturesPatch{TeamFeatureGifs: false, TeamFeatureCustomEmoji: false})
+	require.False(t, o.IsFeatureEnabled(TeamFeatureGifs))
+	require.False(t, o.IsFeatureEnabled(TeamFeatureCustomEmoji))
+	require.True(t, o.IsFeatureEnabled(TeamFeatureFileUploads))
+
+	o.PatchFeatures(TeamFeaturesPatch{TeamFeatureGifs: true})
+	require.True(t, o.IsFeatureEnabled(TeamFeatureGifs))
+	require.False(t, o.IsFeatureEnabled(TeamFeatureCustomEmoji))
+
+	require.Nil(t, TeamFeaturesPatch{TeamFeatureTownSquarePosting: false}.IsValid())
+	require.NotNil(t, TeamFeaturesPatch{"unknown": false}.IsValid())
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const TelemetryEventNameMaxRunes = 128
+
+// TelemetryEvent is an event tracked by a client, which the server forwards to the self-hosted
+// diagnostics sink.
+type TelemetryEvent struct {
+	Event      string                 `json:"event"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func (e *TelemetryEvent) IsValid() *AppError {
+	if e.Event == "" || utf8.RuneCountInString(e.Event) > TelemetryEventNameMaxRunes {
+		return NewAppError("TelemetryEvent.IsValid", "model.telemetry_event.is_valid.event.app_error", map[string]interface{}{"MaxLength": TelemetryEventNameMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
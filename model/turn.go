@@ -0,0 +1,18 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// TurnCredentials are time-limited credentials of the configured TURN servers, following the
+// TURN REST API convention: the username holds the expiry time, and the credential is the
+// HMAC-SHA1 of the username keyed with the secret shared with the servers. It has the shape of an
+// RTCIceServer, so that clients can use it as is.
+type TurnCredentials struct {
+	URLs       []string `json:"urls"`
+	Username   string   `json:"username"`
+	Credential string   `json:"credential"`
+	// TTL is how many seconds the credentials are valid for.
+	TTL int64 `json:"ttl"`
+	// ExpiresAt is when the credentials expire, in milliseconds.
+	ExpiresAt int64 `json:"expires_at"`
+}
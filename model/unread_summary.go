@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ThreadUnread holds the unread state of a thread followed by a user.
+type ThreadUnread struct {
+	PostId         string `json:"post_id"`
+	ChannelId      string `json:"channel_id"`
+	TeamId         string `json:"team_id"`
+	UnreadMentions int64  `json:"unread_mentions"`
+	// Unread is whether the thread was replied to since the user last viewed it.
+	Unread bool `json:"unread"`
+}
+
+// UnreadSummary holds the unread messages and mentions of a user in all of their channels and
+// followed threads, leaving out the channels and threads they have fully read.
+type UnreadSummary struct {
+	Channels []*ChannelUnread `json:"channels"`
+	Threads  []*ThreadUnread  `json:"threads"`
+}
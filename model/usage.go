@@ -3,6 +3,31 @@
 
 package model
 
+// The usage counters reported by products, named after the limits of ProductLimits.
+const (
+	UsageCounterBoardsCards         = "boards.cards"
+	UsageCounterBoardsViews         = "boards.views"
+	UsageCounterFilesTotalStorage   = "files.total_storage"
+	UsageCounterIntegrationsEnabled = "integrations.enabled"
+	UsageCounterMessagesHistory     = "messages.history"
+	UsageCounterTeamsActive         = "teams.active"
+)
+
+// UsageCounter is a usage counter reported by a product, along with the limit it is evaluated
+// against. A nil limit means the counter is not limited.
+type UsageCounter struct {
+	Name     string `json:"name"`
+	Product  string `json:"product"`
+	Count    int64  `json:"count"`
+	Limit    *int64 `json:"limit,omitempty"`
+	Exceeded bool   `json:"exceeded"`
+}
+
+// Usage is the combined usage reported by all the products, sorted by counter name.
+type Usage struct {
+	Counters []*UsageCounter `json:"counters"`
+}
+
 type PostsUsage struct {
 	Count int64 `json:"count"`
 }
@@ -11,6 +36,14 @@ type IntegrationsUsage struct {
 	Enabled int `json:"enabled"`
 }
 
+// EmojiUsage is the number of custom emoji scoped to a team along with the quota of the team. A
+// limit of zero means the team can create any number of emoji.
+type EmojiUsage struct {
+	TeamId string `json:"team_id"`
+	Count  int64  `json:"count"`
+	Limit  int    `json:"limit"`
+}
+
 var InstalledIntegrationsIgnoredPlugins = map[string]struct{}{
 	PluginIdPlaybooks:     {},
 	PluginIdFocalboard:    {},
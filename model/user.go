@@ -767,7 +767,8 @@ func (u *User) IsOAuthUser() bool {
 	return u.AuthService == ServiceGitlab ||
 		u.AuthService == ServiceGoogle ||
 		u.AuthService == ServiceOffice365 ||
-		u.AuthService == ServiceOpenid
+		u.AuthService == ServiceOpenid ||
+		IsOpenIdConnectService(u.AuthService)
 }
 
 func (u *User) IsLDAPUser() bool {
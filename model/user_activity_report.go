@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	// UserActivityReportDefaultPeriod is the period covered by a report when no start is given,
+	// in milliseconds.
+	UserActivityReportDefaultPeriod = 30 * 24 * 60 * 60 * 1000
+	// UserActivityReportMaxPeriod is the longest period a report can cover, in milliseconds.
+	UserActivityReportMaxPeriod = 366 * 24 * 60 * 60 * 1000
+	// UserActivityReportMaxAudits is the maximum number of audit records read for a report.
+	UserActivityReportMaxAudits = 10000
+)
+
+// UserActivityReport is the access report of a user over a period, built from the audit records
+// and the data of the store, for investigations.
+type UserActivityReport struct {
+	UserId      string `json:"user_id"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	Since       int64  `json:"since"`
+	Until       int64  `json:"until"`
+	GeneratedAt int64  `json:"generated_at"`
+	GeneratedBy string `json:"generated_by"`
+
+	// Sessions are the sessions of the user active during the period that haven't been revoked.
+	// The logins and logouts are among the actions.
+	Sessions      []*Session                  `json:"sessions"`
+	Channels      []*UserActivityChannel      `json:"channels"`
+	FileDownloads []*UserActivityFileDownload `json:"file_downloads"`
+	// Actions are the audited requests of the user, and AdminActions the ones made on their
+	// account by other users, such as the system admins.
+	Actions      Audits `json:"actions"`
+	AdminActions Audits `json:"admin_actions"`
+	// Truncated is set when the user has more audit records over the period than
+	// UserActivityReportMaxAudits, in which case the oldest are left out.
+	Truncated bool `json:"truncated"`
+}
+
+// UserActivityChannel is a channel the user was a member of during the period. LeaveTime is nil
+// if the user is still a member, and LastViewedAt is only known for the current memberships.
+type UserActivityChannel struct {
+	ChannelId    string      `json:"channel_id"`
+	TeamId       string      `json:"team_id"`
+	Name         string      `json:"name"`
+	DisplayName  string      `json:"display_name"`
+	Type         ChannelType `json:"type"`
+	JoinTime     int64       `json:"join_time"`
+	LeaveTime    *int64      `json:"leave_time"`
+	LastViewedAt int64       `json:"last_viewed_at,omitempty"`
+}
+
+// UserActivityFileDownload is a file downloaded by the user.
+type UserActivityFileDownload struct {
+	FileId     string `json:"file_id"`
+	Name       string `json:"name"`
+	ChannelId  string `json:"channel_id"`
+	PostId     string `json:"post_id"`
+	DownloadAt int64  `json:"download_at"`
+	IpAddress  string `json:"ip_address"`
+	SessionId  string `json:"session_id"`
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	// UserDeletionPolicyAnonymize keeps the posts and files of the user, attributed to a
+	// deactivated account stripped of its profile, credentials and settings.
+	UserDeletionPolicyAnonymize = "anonymize"
+	// UserDeletionPolicyRemove permanently deletes the user along with their posts and files.
+	UserDeletionPolicyRemove = "remove"
+
+	// The stages a user deletion job goes through, recorded in its data.
+	UserDeletionStageDeactivating = "deactivating"
+	UserDeletionStageExporting    = "exporting"
+	UserDeletionStageDeleting     = "deleting"
+	UserDeletionStageDone         = "done"
+)
+
+// UserDeletionRequest is the body of a request deleting a user.
+type UserDeletionRequest struct {
+	Policy string `json:"policy"`
+}
+
+func (r *UserDeletionRequest) IsValid() *AppError {
+	switch r.Policy {
+	case UserDeletionPolicyAnonymize, UserDeletionPolicyRemove:
+	default:
+		return NewAppError("UserDeletionRequest.IsValid", "model.user_deletion.is_valid.policy.app_error", nil, "policy="+r.Policy, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// UserDeletionReport is the progress of a user deletion job. ExportName is the name of the
+// export of the personal data of the user once it is written, which admins download from the
+// exports.
+type UserDeletionReport struct {
+	JobId      string `json:"job_id"`
+	UserId     string `json:"user_id"`
+	Policy     string `json:"policy"`
+	Status     string `json:"status"`
+	Stage      string `json:"stage"`
+	Progress   int64  `json:"progress"`
+	ExportName string `json:"export_name,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
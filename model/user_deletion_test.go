@@ -0,0 +1,17 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserDeletionRequestIsValid(t *testing.T) {
+	assert.Nil(t, (&UserDeletionRequest{Policy: UserDeletionPolicyAnonymize}).IsValid())
+	assert.Nil(t, (&UserDeletionRequest{Policy: UserDeletionPolicyRemove}).IsValid())
+	assert.NotNil(t, (&UserDeletionRequest{}).IsValid())
+	assert.NotNil(t, (&UserDeletionRequest{Policy: "archive"}).IsValid())
+}
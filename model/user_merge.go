@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// UserPropMergedInto is the prop set on an account merged into another one, holding the id of
+// the account it was merged into.
+const UserPropMergedInto = "merged_into"
+
+// UserMergeRequest is the body of a request merging a duplicate account into a primary one.
+type UserMergeRequest struct {
+	SourceUserId string `json:"source_user_id"`
+	TargetUserId string `json:"target_user_id"`
+}
+
+func (r *UserMergeRequest) IsValid() *AppError {
+	if !IsValidId(r.SourceUserId) {
+		return NewAppError("UserMergeRequest.IsValid", "model.user_merge.is_valid.source_user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.TargetUserId) {
+		return NewAppError("UserMergeRequest.IsValid", "model.user_merge.is_valid.target_user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.SourceUserId == r.TargetUserId {
+		return NewAppError("UserMergeRequest.IsValid", "model.user_merge.is_valid.same_user.app_error", nil, "user_id="+r.SourceUserId, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// UserMergeCounts is how many rows of each kind were re-attributed from the source account to
+// the target account. Rows the target account already had an equivalent of, such as a
+// membership of the same channel, are dropped rather than re-attributed.
+type UserMergeCounts struct {
+	Posts              int64 `json:"posts"`
+	FileInfos          int64 `json:"file_infos"`
+	Reactions          int64 `json:"reactions"`
+	ChannelMemberships int64 `json:"channel_memberships"`
+	TeamMemberships    int64 `json:"team_memberships"`
+	GroupMemberships   int64 `json:"group_memberships"`
+	ThreadMemberships  int64 `json:"thread_memberships"`
+	Preferences        int64 `json:"preferences"`
+
+	// SkippedDirectChannels is the number of direct and group messages channels of the source
+	// account. Their members are part of their name, so they and their posts are left untouched.
+	SkippedDirectChannels int64 `json:"skipped_direct_channels"`
+}
+
+// UserMergeReport is the progress of a user merge job and, once it is done, what was merged.
+type UserMergeReport struct {
+	JobId           string           `json:"job_id"`
+	SourceUserId    string           `json:"source_user_id"`
+	TargetUserId    string           `json:"target_user_id"`
+	Status          string           `json:"status"`
+	Progress        int64            `json:"progress"`
+	Counts          *UserMergeCounts `json:"counts,omitempty"`
+	RevokedSessions int              `json:"revoked_sessions"`
+	Error           string           `json:"error,omitempty"`
+}
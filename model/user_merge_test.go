@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserMergeRequestIsValid(t *testing.T) {
+	userID := NewId()
+
+	assert.Nil(t, (&UserMergeRequest{SourceUserId: NewId(), TargetUserId: userID}).IsValid())
+	assert.NotNil(t, (&UserMergeRequest{SourceUserId: "invalid", TargetUserId: userID}).IsValid())
+	assert.NotNil(t, (&UserMergeRequest{SourceUserId: NewId()}).IsValid())
+	assert.NotNil(t, (&UserMergeRequest{SourceUserId: userID, TargetUserId: userID}).IsValid())
+}
@@ -312,6 +312,13 @@ func (wsc *WebSocketClient) UserTyping(channelId, parentId string) {
 	wsc.SendMessage("user_typing", data)
 }
 
+// Reauthenticate will replace the session of the connection with the session of the given token,
+// which must be of the same user, and use the token if the client reconnects
+func (wsc *WebSocketClient) Reauthenticate(authToken string) {
+	wsc.AuthToken = authToken
+	wsc.SendMessage(WebsocketReauthenticate, map[string]interface{}{"token": authToken})
+}
+
 // GetStatuses will return a map of string statuses using user id as the key
 func (wsc *WebSocketClient) GetStatuses() {
 	wsc.SendMessage("get_statuses", nil)
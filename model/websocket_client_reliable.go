@@ -0,0 +1,390 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// ErrWebSocketNotConnected is returned when sending a message while the reliable websocket client
+// is reconnecting or closed.
+var ErrWebSocketNotConnected = errors.New("websocket not connected")
+
+// ReliableWebSocketOptions configures a ReliableWebSocketClient. The zero value is usable.
+type ReliableWebSocketOptions struct {
+	// Dialer is used to open the connections, websocket.DefaultDialer if nil.
+	Dialer *websocket.Dialer
+	// TokenFunc returns the token to authenticate each connection with, so that an expired token
+	// can be refreshed before reconnecting. The token given to the client is used if nil.
+	TokenFunc func() (string, error)
+	// The delay between the attempts to reconnect grows exponentially from MinReconnectDelay,
+	// with random jitter, up to MaxReconnectDelay. They default to 1 second and 1 minute.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+	// MaxReconnectAttempts is the number of failed attempts to reconnect after which the client
+	// gives up and closes its channels. The client tries forever if it is 0.
+	MaxReconnectAttempts int
+	// OnReconnect is called with the first event received after each reconnect, with whether the
+	// server resumed the connection by replaying the events sent while the client was
+	// disconnected. If it didn't, the events were lost and the state of the client should be
+	// fetched again.
+	OnReconnect func(resumed bool)
+}
+
+// ReliableWebSocketClient is a websocket client that reconnects when its connection breaks or the
+// server stops pinging it, and asks the server to resume the connection so that no event is lost.
+// The server replays the events sent since the last one received by the client, along with the
+// persistent events of the plugins published in the meantime.
+//
+// Unlike WebSocketClient, its channels stay open across reconnects: they are only closed by Close,
+// or when the client gives up reconnecting, in which case Err returns why. The channels must be
+// read to prevent the client from blocking.
+type ReliableWebSocketClient struct {
+	EventChannel    chan *WebSocketEvent
+	ResponseChannel chan *WebSocketResponse
+
+	url     string
+	options ReliableWebSocketOptions
+
+	// mutex guards the token, the connection, the sequence of the requests and the error.
+	mutex    sync.Mutex
+	token    string
+	conn     *websocket.Conn
+	sequence int64
+	err      error
+
+	// The state needed to resume the connection, only used by the reader.
+	connectionID      string
+	serverSequence    int64
+	pluginEventsSince int64
+	pluginEventIds    map[string]bool
+
+	quit      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewReliableWebSocketClient connects to the websocket of the server at the given URL, like
+// "ws://localhost:8065", and returns a client that keeps the connection alive until it is closed.
+func NewReliableWebSocketClient(url, authToken string, options *ReliableWebSocketOptions) (*ReliableWebSocketClient, error) {
+	wsc := &ReliableWebSocketClient{
+		EventChannel:    make(chan *WebSocketEvent, 100),
+		ResponseChannel: make(chan *WebSocketResponse, 100),
+		url:             url,
+		token:           authToken,
+		sequence:        1,
+		pluginEventIds:  make(map[string]bool),
+		quit:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+	if options != nil {
+		wsc.options = *options
+	}
+	if wsc.options.Dialer == nil {
+		wsc.options.Dialer = websocket.DefaultDialer
+	}
+	if wsc.options.MinReconnectDelay <= 0 {
+		wsc.options.MinReconnectDelay = time.Second
+	}
+	if wsc.options.MaxReconnectDelay <= 0 {
+		wsc.options.MaxReconnectDelay = time.Minute
+	}
+
+	conn, err := wsc.connect()
+	if err != nil {
+		return nil, NewAppError("NewReliableWebSocketClient", "model.websocket_client.connect_fail.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	go wsc.run(conn)
+
+	return wsc, nil
+}
+
+// SendMessage sends a request to the server, whose response is received on ResponseChannel.
+func (wsc *ReliableWebSocketClient) SendMessage(action string, data map[string]interface{}) error {
+	wsc.mutex.Lock()
+	defer wsc.mutex.Unlock()
+
+	if wsc.conn == nil {
+		return ErrWebSocketNotConnected
+	}
+
+	req := &WebSocketRequest{
+		Seq:    wsc.sequence,
+		Action: action,
+		Data:   data,
+	}
+	wsc.sequence++
+
+	return wsc.conn.WriteJSON(req)
+}
+
+// Reauthenticate replaces the session of the connection with the session of the given token, which
+// must be of the same user, and uses the token from then on when reconnecting unless TokenFunc is
+// set. The response is received on ResponseChannel.
+func (wsc *ReliableWebSocketClient) Reauthenticate(authToken string) error {
+	wsc.mutex.Lock()
+	wsc.token = authToken
+	wsc.mutex.Unlock()
+
+	return wsc.SendMessage(WebsocketReauthenticate, map[string]interface{}{"token": authToken})
+}
+
+// Err returns why the client gave up reconnecting, or nil.
+func (wsc *ReliableWebSocketClient) Err() error {
+	wsc.mutex.Lock()
+	defer wsc.mutex.Unlock()
+
+	return wsc.err
+}
+
+// Close closes the connection and the channels of the client, and waits for it to stop.
+func (wsc *ReliableWebSocketClient) Close() {
+	wsc.closeOnce.Do(func() {
+		close(wsc.quit)
+
+		wsc.mutex.Lock()
+		if wsc.conn != nil {
+			wsc.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			wsc.conn.Close()
+		}
+		wsc.mutex.Unlock()
+	})
+
+	<-wsc.done
+}
+
+func (wsc *ReliableWebSocketClient) run(conn *websocket.Conn) {
+	defer func() {
+		close(wsc.EventChannel)
+		close(wsc.ResponseChannel)
+		close(wsc.done)
+	}()
+
+	for {
+		wsc.read(conn)
+
+		wsc.mutex.Lock()
+		wsc.conn = nil
+		wsc.mutex.Unlock()
+		conn.Close()
+
+		conn = wsc.reconnect()
+		if conn == nil {
+			return
+		}
+	}
+}
+
+// reconnect opens a new connection, resuming the previous one, returning nil if the client is
+// closed or gives up.
+func (wsc *ReliableWebSocketClient) reconnect() *websocket.Conn {
+	backoff := &ClientRetryPolicy{
+		MinDelay: wsc.options.MinReconnectDelay,
+		MaxDelay: wsc.options.MaxReconnectDelay,
+	}
+
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-wsc.quit:
+			return nil
+		case <-time.After(backoff.backoff(attempt)):
+		}
+
+		conn, err := wsc.connect()
+		if err == nil {
+			return conn
+		}
+
+		mlog.Debug("Failed to reconnect the websocket", mlog.Int("attempt", attempt+1), mlog.Err(err))
+		if wsc.options.MaxReconnectAttempts > 0 && attempt+1 >= wsc.options.MaxReconnectAttempts {
+			wsc.mutex.Lock()
+			wsc.err = err
+			wsc.mutex.Unlock()
+			return nil
+		}
+	}
+}
+
+// connect opens a connection, resuming the previous one if any, and authenticates it.
+func (wsc *ReliableWebSocketClient) connect() (*websocket.Conn, error) {
+	wsc.mutex.Lock()
+	token := wsc.token
+	wsc.mutex.Unlock()
+	if wsc.options.TokenFunc != nil {
+		var err error
+		if token, err = wsc.options.TokenFunc(); err != nil {
+			return nil, fmt.Errorf("failed to get the token: %w", err)
+		}
+	}
+
+	query := url.Values{}
+	if wsc.connectionID != "" {
+		query.Set("connection_id", wsc.connectionID)
+		query.Set("sequence_number", fmt.Sprintf("%d", wsc.serverSequence))
+	}
+	if wsc.pluginEventsSince != 0 {
+		query.Set("plugin_events_since", fmt.Sprintf("%d", wsc.pluginEventsSince))
+	}
+	connectURL := wsc.url + APIURLSuffix + "/websocket"
+	if len(query) > 0 {
+		connectURL += "?" + query.Encode()
+	}
+
+	header := http.Header{HeaderAuth: []string{HeaderBearer + " " + token}}
+	conn, resp, err := wsc.options.Dialer.Dial(connectURL, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("failed to connect with status %d: %w", resp.StatusCode, err)
+		}
+		return nil, err
+	}
+
+	wsc.mutex.Lock()
+	defer wsc.mutex.Unlock()
+
+	select {
+	case <-wsc.quit:
+		conn.Close()
+		return nil, ErrWebSocketNotConnected
+	default:
+	}
+
+	// The connection is authenticated by the header, but the challenge is still sent for the
+	// servers that only authenticate through it. The server closes the connection if the token
+	// is invalid, and the client then reconnects with a refreshed one.
+	req := &WebSocketRequest{
+		Seq:    wsc.sequence,
+		Action: WebsocketAuthenticationChallenge,
+		Data:   map[string]interface{}{"token": token},
+	}
+	wsc.sequence++
+	if err := conn.WriteJSON(req); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	wsc.conn = conn
+	return conn, nil
+}
+
+// read reads the messages of the connection until it breaks or the server stops pinging it.
+func (wsc *ReliableWebSocketClient) read(conn *websocket.Conn) {
+	pingTimeout := time.Second * (60 + PingTimeoutBufferSeconds)
+	conn.SetReadDeadline(time.Now().Add(pingTimeout))
+	conn.SetPingHandler(func(appData string) error {
+		conn.SetReadDeadline(time.Now().Add(pingTimeout))
+		err := conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(time.Second))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+
+	reconnecting := wsc.connectionID != ""
+	var buf bytes.Buffer
+	buf.Grow(avgReadMsgSizeBytes)
+
+	for {
+		buf.Reset()
+		_, r, err := conn.NextReader()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
+				mlog.Debug("Websocket connection broken", mlog.Err(err))
+			}
+			return
+		}
+		if _, err := buf.ReadFrom(r); err != nil {
+			mlog.Debug("Failed to read from the websocket", mlog.Err(err))
+			return
+		}
+
+		event, jsonErr := WebSocketEventFromJSON(bytes.NewReader(buf.Bytes()))
+		if jsonErr != nil {
+			mlog.Warn("Failed to decode from JSON", mlog.Err(jsonErr))
+			continue
+		}
+
+		if event.IsValid() {
+			if !wsc.trackEvent(event) {
+				continue
+			}
+
+			if event.EventType() == WebsocketEventHello && reconnecting {
+				reconnecting = false
+				if wsc.options.OnReconnect != nil {
+					wsc.options.OnReconnect(false)
+				}
+			} else if reconnecting {
+				// The server replays the missed events without a hello when it resumes the
+				// connection.
+				reconnecting = false
+				if wsc.options.OnReconnect != nil {
+					wsc.options.OnReconnect(true)
+				}
+			}
+
+			select {
+			case wsc.EventChannel <- event:
+			case <-wsc.quit:
+				return
+			}
+			continue
+		}
+
+		var response WebSocketResponse
+		if err := json.Unmarshal(buf.Bytes(), &response); err == nil && response.IsValid() {
+			select {
+			case wsc.ResponseChannel <- &response:
+			case <-wsc.quit:
+				return
+			}
+		}
+	}
+}
+
+// trackEvent records the state needed to resume the connection from the event, and returns
+// whether the event should be delivered, i.e. it isn't a persistent event already received.
+func (wsc *ReliableWebSocketClient) trackEvent(event *WebSocketEvent) bool {
+	if event.EventType() == WebsocketEventHello {
+		if connectionID, ok := event.GetData()["connection_id"].(string); ok {
+			wsc.connectionID = connectionID
+		}
+	}
+	wsc.serverSequence = event.GetSequence() + 1
+
+	id, _ := event.GetData()[PersistentWebSocketEventIdKey].(string)
+	createAt, _ := event.GetData()[PersistentWebSocketEventCreateAtKey].(float64)
+	if id == "" || createAt == 0 {
+		return true
+	}
+
+	// A persistent event can be received twice when the server replays it both as a missed event
+	// and as a persistent one.
+	switch {
+	case int64(createAt) < wsc.pluginEventsSince:
+		return false
+	case int64(createAt) == wsc.pluginEventsSince:
+		if wsc.pluginEventIds[id] {
+			return false
+		}
+	default:
+		wsc.pluginEventsSince = int64(createAt)
+		wsc.pluginEventIds = make(map[string]bool)
+	}
+	wsc.pluginEventIds[id] = true
+
+	return true
+}
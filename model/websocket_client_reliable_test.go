@@ -0,0 +1,219 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestWebSocketEvent(t *testing.T, conn *websocket.Conn, ev *WebSocketEvent) {
+	data, err := ev.ToJSON()
+	assert.NoError(t, err)
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, data))
+}
+
+func receiveTestWebSocketEvent(t *testing.T, wsc *ReliableWebSocketClient) *WebSocketEvent {
+	select {
+	case ev, ok := <-wsc.EventChannel:
+		require.True(t, ok, "the event channel should be open")
+		return ev
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for an event")
+		return nil
+	}
+}
+
+func TestReliableWebSocketClient(t *testing.T) {
+	connectionID := NewId()
+	var connections int32
+	var resumed int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+		assert.Equal(t, "Bearer token"+string(rune('0'+n)), r.Header.Get(HeaderAuth))
+
+		upgrader := &websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		// The authentication challenge.
+		var req WebSocketRequest
+		assert.NoError(t, conn.ReadJSON(&req))
+		assert.Equal(t, WebsocketAuthenticationChallenge, req.Action)
+
+		switch n {
+		case 1:
+			assert.Empty(t, r.URL.Query().Get("connection_id"))
+
+			hello := NewWebSocketEvent(WebsocketEventHello, "", "", "", nil)
+			hello.Add("connection_id", connectionID)
+			writeTestWebSocketEvent(t, conn, hello.SetSequence(0))
+
+			posted := NewWebSocketEvent(WebsocketEventPosted, "", "", "", nil)
+			posted.Add(PersistentWebSocketEventIdKey, "event1")
+			posted.Add(PersistentWebSocketEventCreateAtKey, 1000)
+			writeTestWebSocketEvent(t, conn, posted.SetSequence(1))
+
+			// Break the connection.
+		case 2:
+			// The client resumes the connection from the next event.
+			assert.Equal(t, connectionID, r.URL.Query().Get("connection_id"))
+			assert.Equal(t, "2", r.URL.Query().Get("sequence_number"))
+			assert.Equal(t, "1000", r.URL.Query().Get("plugin_events_since"))
+
+			// The persistent event is replayed again and should be skipped.
+			replayed := NewWebSocketEvent(WebsocketEventPosted, "", "", "", nil)
+			replayed.Add(PersistentWebSocketEventIdKey, "event1")
+			replayed.Add(PersistentWebSocketEventCreateAtKey, 1000)
+			writeTestWebSocketEvent(t, conn, replayed.SetSequence(1))
+
+			writeTestWebSocketEvent(t, conn, NewWebSocketEvent(WebsocketEventTyping, "", "", "", nil).SetSequence(2))
+
+			// Wait for the client to close the connection.
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			conn.ReadMessage()
+		}
+	}))
+	defer server.Close()
+
+	var tokens int32
+	wsc, err := NewReliableWebSocketClient(strings.Replace(server.URL, "http://", "ws://", 1), "", &ReliableWebSocketOptions{
+		TokenFunc: func() (string, error) {
+			return "token" + string(rune('0'+atomic.AddInt32(&tokens, 1))), nil
+		},
+		MinReconnectDelay: time.Millisecond,
+		MaxReconnectDelay: 10 * time.Millisecond,
+		OnReconnect: func(wasResumed bool) {
+			if wasResumed {
+				atomic.StoreInt32(&resumed, 1)
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, WebsocketEventHello, receiveTestWebSocketEvent(t, wsc).EventType())
+	assert.Equal(t, WebsocketEventPosted, receiveTestWebSocketEvent(t, wsc).EventType())
+
+	ev := receiveTestWebSocketEvent(t, wsc)
+	assert.Equal(t, WebsocketEventTyping, ev.EventType())
+	assert.Equal(t, int64(2), ev.GetSequence())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&resumed))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&tokens))
+
+	require.NoError(t, wsc.SendMessage("user_typing", map[string]interface{}{"channel_id": NewId()}))
+
+	wsc.Close()
+	_, ok := <-wsc.EventChannel
+	assert.False(t, ok, "the event channel should be closed")
+	assert.ErrorIs(t, wsc.SendMessage("user_typing", nil), ErrWebSocketNotConnected)
+	assert.NoError(t, wsc.Err())
+}
+
+func TestReliableWebSocketClientGivesUp(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&connections, 1) > 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		upgrader := &websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	wsc, err := NewReliableWebSocketClient(strings.Replace(server.URL, "http://", "ws://", 1), "token", &ReliableWebSocketOptions{
+		MinReconnectDelay:    time.Millisecond,
+		MaxReconnectDelay:    10 * time.Millisecond,
+		MaxReconnectAttempts: 3,
+	})
+	require.NoError(t, err)
+
+	select {
+	case _, ok := <-wsc.EventChannel:
+		assert.False(t, ok, "the event channel should be closed")
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for the client to give up")
+	}
+
+	assert.Error(t, wsc.Err())
+	assert.Equal(t, int32(4), atomic.LoadInt32(&connections))
+	wsc.Close()
+}
+
+func TestReliableWebSocketClientReauthenticate(t *testing.T) {
+	var connections int32
+	reauthenticated := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+
+		upgrader := &websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		var req WebSocketRequest
+		assert.NoError(t, conn.ReadJSON(&req))
+		assert.Equal(t, WebsocketAuthenticationChallenge, req.Action)
+
+		switch n {
+		case 1:
+			assert.Equal(t, "old", req.Data["token"])
+
+			assert.NoError(t, conn.ReadJSON(&req))
+			assert.Equal(t, WebsocketReauthenticate, req.Action)
+			assert.Equal(t, "new", req.Data["token"])
+			close(reauthenticated)
+
+			// Break the connection.
+		case 2:
+			// The client reconnects with the new token.
+			assert.Equal(t, "Bearer new", r.Header.Get(HeaderAuth))
+			assert.Equal(t, "new", req.Data["token"])
+
+			writeTestWebSocketEvent(t, conn, NewWebSocketEvent(WebsocketEventHello, "", "", "", nil).SetSequence(0))
+
+			// Wait for the client to close the connection.
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			conn.ReadMessage()
+		}
+	}))
+	defer server.Close()
+
+	wsc, err := NewReliableWebSocketClient(strings.Replace(server.URL, "http://", "ws://", 1), "old", &ReliableWebSocketOptions{
+		MinReconnectDelay: time.Millisecond,
+		MaxReconnectDelay: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer wsc.Close()
+
+	require.NoError(t, wsc.Reauthenticate("new"))
+	select {
+	case <-reauthenticated:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for the reauthentication")
+	}
+
+	assert.Equal(t, WebsocketEventHello, receiveTestWebSocketEvent(t, wsc).EventType())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&connections))
+}
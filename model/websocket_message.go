@@ -41,8 +41,10 @@ const (
 	WebsocketEventPreferencesDeleted                  = "preferences_deleted"
 	WebsocketEventEphemeralMessage                    = "ephemeral_message"
 	WebsocketEventStatusChange                        = "status_change"
+	WebsocketEventStatusesChanged                     = "statuses_changed"
 	WebsocketEventHello                               = "hello"
 	WebsocketAuthenticationChallenge                  = "authentication_challenge"
+	WebsocketReauthenticate                           = "reauthenticate"
 	WebsocketEventReactionAdded                       = "reaction_added"
 	WebsocketEventReactionRemoved                     = "reaction_removed"
 	WebsocketEventResponse                            = "response"
@@ -77,6 +79,17 @@ const (
 	WebsocketEventThreadReadChanged                   = "thread_read_changed"
 	WebsocketFirstAdminVisitMarketplaceStatusReceived = "first_admin_visit_marketplace_status_received"
 	WebsocketEventIntegrationsUsageChanged            = "integrations_usage_changed"
+	WebsocketEventImpersonationRequestUpdated         = "impersonation_request_updated"
+	WebsocketEventChannelMemberTimeoutUpdated         = "channel_member_timeout_updated"
+	WebsocketEventDirectMessageRequestCreated         = "direct_message_request_created"
+	WebsocketEventDirectMessageRequestUpdated         = "direct_message_request_updated"
+	WebsocketEventPinnedPostsOrderUpdated             = "pinned_posts_order_updated"
+	WebsocketEventChannelBookmarkCreated              = "channel_bookmark_created"
+	WebsocketEventChannelBookmarkUpdated              = "channel_bookmark_updated"
+	WebsocketEventChannelBookmarkDeleted              = "channel_bookmark_deleted"
+	WebsocketEventChannelBookmarkSorted               = "channel_bookmark_sorted"
+	WebsocketEventChannelJoinRequestCreated           = "channel_join_request_created"
+	WebsocketEventChannelJoinRequestUpdated           = "channel_join_request_updated"
 )
 
 type WebSocketMessage interface {
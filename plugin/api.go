@@ -902,6 +902,19 @@ type API interface {
 	// Minimum server version: 5.20
 	KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
 
+	// KVSetMultiple applies several writes in a single transaction, unique per plugin. Each
+	// operation sets or, with a nil value, deletes a key. An atomic operation requires the current
+	// value to match its OldValue, or the key not to exist if OldValue is nil. Either every
+	// operation is applied or, if the comparison of any atomic operation fails, none of them is.
+	// At most 100 operations can be applied at once, and each key only once.
+	// Returns (false, err) if DB error occurred
+	// Returns (false, nil) if the comparison of an atomic operation failed and nothing was written
+	// Returns (true, nil) if every operation was applied
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 7.0
+	KVSetMultiple(operations []*model.PluginKVSetOperation) (bool, *model.AppError)
+
 	// KVSet stores a key-value pair with an expiry time, unique per plugin.
 	//
 	// @tag KeyValueStore
@@ -1152,6 +1165,69 @@ type API interface {
 	//
 	// Minimum server version: 7.0
 	GetCloudLimits() (*model.ProductLimits, error)
+
+	// PublishPersistentWebSocketEvent sends an event to WebSocket connections like
+	// PublishWebSocketEvent, but also keeps it for an hour so that the clients that were
+	// disconnected when it was published receive it once they reconnect.
+	// The persistent_event_id and persistent_event_create_at keys are added to the payload.
+	// An event can be received more than once, in which case clients should ignore the events
+	// whose persistent_event_id they already received. The connection of the broadcast is ignored.
+	//
+	// Minimum server version: 7.0
+	PublishPersistentWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) *model.AppError
+
+	// RegisterScheduledTask registers a recurring task run on a single server of the cluster
+	// through the jobs infrastructure, rather than by a ticker on every server. The cron
+	// expression has the five standard fields and is evaluated in the server's time zone. When the
+	// task is due, the OnScheduledTask hook is invoked with the callback, which identifies the
+	// task. Registering a callback again replaces its schedule.
+	//
+	// Tasks are kept until they are unregistered or the plugin is disabled, and should be
+	// registered again in OnActivate.
+	//
+	// Minimum server version: 7.0
+	RegisterScheduledTask(cron, callback string) error
+
+	// UnregisterScheduledTask unregisters a task previously registered via RegisterScheduledTask.
+	//
+	// Minimum server version: 7.0
+	UnregisterScheduledTask(callback string) error
+
+	// CreateSearchIndex creates a full-text search index of the plugin, in Elasticsearch or
+	// Bleve, whichever is active and supports it. Creating an existing index does nothing. The
+	// name has up to 64 lowercase letters, digits, underscores and dashes, and only needs to be
+	// unique for the plugin.
+	//
+	// @tag Search
+	// Minimum server version: 7.0
+	CreateSearchIndex(index string) *model.AppError
+
+	// DeleteSearchIndex deletes a search index of the plugin and its documents.
+	//
+	// @tag Search
+	// Minimum server version: 7.0
+	DeleteSearchIndex(index string) *model.AppError
+
+	// IndexSearchDocument adds a document to a search index of the plugin, replacing the
+	// document with the same id. The document belongs to a tenant, e.g. the team it is part of.
+	//
+	// @tag Search
+	// Minimum server version: 7.0
+	IndexSearchDocument(index string, document *model.PluginSearchDocument) *model.AppError
+
+	// DeleteSearchDocument removes a document from a search index of the plugin.
+	//
+	// @tag Search
+	// Minimum server version: 7.0
+	DeleteSearchDocument(index, documentID string) *model.AppError
+
+	// SearchIndex runs a full-text query on a search index of the plugin. Only the documents of
+	// the tenants of the params are matched, and the plugin is responsible for restricting them to
+	// the ones the user can access.
+	//
+	// @tag Search
+	// Minimum server version: 7.0
+	SearchIndex(index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError)
 }
 
 var handshake = plugin.HandshakeConfig{
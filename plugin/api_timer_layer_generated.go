@@ -972,6 +972,13 @@ func (api *apiTimerLayer) KVSetWithOptions(key string, value []byte, options mod
 	return _returnsA, _returnsB
 }
 
+func (api *apiTimerLayer) KVSetMultiple(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVSetMultiple(operations)
+	api.recordTime(startTime, "KVSetMultiple", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (api *apiTimerLayer) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
 	startTime := timePkg.Now()
 	_returnsA := api.apiImpl.KVSetWithExpiry(key, value, expireInSeconds)
@@ -1232,3 +1239,59 @@ func (api *apiTimerLayer) GetCloudLimits() (*model.ProductLimits, error) {
 	api.recordTime(startTime, "GetCloudLimits", _returnsB == nil)
 	return _returnsA, _returnsB
 }
+
+func (api *apiTimerLayer) PublishPersistentWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.PublishPersistentWebSocketEvent(event, payload, broadcast)
+	api.recordTime(startTime, "PublishPersistentWebSocketEvent", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) RegisterScheduledTask(cron, callback string) error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterScheduledTask(cron, callback)
+	api.recordTime(startTime, "RegisterScheduledTask", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnregisterScheduledTask(callback string) error {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterScheduledTask(callback)
+	api.recordTime(startTime, "UnregisterScheduledTask", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) CreateSearchIndex(index string) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.CreateSearchIndex(index)
+	api.recordTime(startTime, "CreateSearchIndex", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) DeleteSearchIndex(index string) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteSearchIndex(index)
+	api.recordTime(startTime, "DeleteSearchIndex", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) IndexSearchDocument(index string, document *model.PluginSearchDocument) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.IndexSearchDocument(index, document)
+	api.recordTime(startTime, "IndexSearchDocument", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) DeleteSearchDocument(index, documentID string) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteSearchDocument(index, documentID)
+	api.recordTime(startTime, "DeleteSearchDocument", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) SearchIndex(index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchIndex(index, params)
+	api.recordTime(startTime, "SearchIndex", _returnsB == nil)
+	return _returnsA, _returnsB
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/go-plugin"
 	"github.com/lib/pq"
 
+	"github.com/mattermost/mattermost-server/v6/einterfaces"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -35,6 +36,8 @@ var hookNameToId map[string]int = make(map[string]int)
 type hooksRPCClient struct {
 	client      *rpc.Client
 	log         *mlog.Logger
+	pluginID    string
+	metrics     einterfaces.MetricsInterface
 	muxBroker   *plugin.MuxBroker
 	apiImpl     API
 	driver      Driver
@@ -54,6 +57,8 @@ type hooksPlugin struct {
 	apiImpl    API
 	driverImpl Driver
 	log        *mlog.Logger
+	pluginID   string
+	metrics    einterfaces.MetricsInterface
 }
 
 func (p *hooksPlugin) Server(b *plugin.MuxBroker) (interface{}, error) {
@@ -63,6 +68,8 @@ func (p *hooksPlugin) Server(b *plugin.MuxBroker) (interface{}, error) {
 func (p *hooksPlugin) Client(b *plugin.MuxBroker, client *rpc.Client) (interface{}, error) {
 	return &hooksRPCClient{client: client,
 		log:       p.log,
+		pluginID:  p.pluginID,
+		metrics:   p.metrics,
 		muxBroker: b,
 		apiImpl:   p.apiImpl,
 		driver:    p.driverImpl,
@@ -177,6 +184,23 @@ func init() {
 var _ plugin.Plugin = &hooksPlugin{}
 var _ Hooks = &hooksRPCClient{}
 
+// The types of the errors counted for the hooks of a plugin.
+const (
+	// HookErrorTypeReturned is counted when a hook returns an error.
+	HookErrorTypeReturned = "returned"
+	// HookErrorTypeRPC is counted when a hook can't be called over RPC, usually because the
+	// plugin crashed.
+	HookErrorTypeRPC = "rpc"
+)
+
+// recordRPCError counts the failed calls of a hook, which are otherwise only logged since the
+// hooks return their zero values when the plugin can't be reached.
+func (g *hooksRPCClient) recordRPCError(hookName string) {
+	if g.metrics != nil {
+		g.metrics.IncrementPluginHookErrorCounter(g.pluginID, hookName, HookErrorTypeRPC)
+	}
+}
+
 //
 // Below are special cases for hooks or APIs that can not be auto generated
 //
@@ -269,6 +293,7 @@ func (g *hooksRPCClient) OnActivate() error {
 
 	if err := g.client.Call("Plugin.OnActivate", _args, _returns); err != nil {
 		g.log.Error("RPC call to OnActivate plugin failed.", mlog.Err(err))
+		g.recordRPCError("OnActivate")
 	}
 	return _returns.A
 }
@@ -424,6 +449,7 @@ func (g *hooksRPCClient) ServeHTTP(c *Context, w http.ResponseWriter, r *http.Re
 		RequestBodyStream:    requestBodyStreamId,
 	}, nil); err != nil {
 		g.log.Error("Plugin failed to ServeHTTP, RPC call failed", mlog.Err(err))
+		g.recordRPCError("ServeHTTP")
 		http.Error(w, "500 internal server error", http.StatusInternalServerError)
 	}
 }
@@ -586,6 +612,7 @@ func (g *hooksRPCClient) FileWillBeUploaded(c *Context, info *model.FileInfo, fi
 	_returns := &Z_FileWillBeUploadedReturns{A: _args.B}
 	if err := g.client.Call("Plugin.FileWillBeUploaded", _args, _returns); err != nil {
 		g.log.Error("RPC call FileWillBeUploaded to plugin failed.", mlog.Err(err))
+		g.recordRPCError("FileWillBeUploaded")
 	}
 
 	// Ensure the io.Copy from the replacementFileConnection above completes.
@@ -645,6 +672,7 @@ func (g *hooksRPCClient) MessageWillBePosted(c *Context, post *model.Post) (*mod
 	if g.implemented[MessageWillBePostedID] {
 		if err := g.client.Call("Plugin.MessageWillBePosted", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageWillBePosted to plugin failed.", mlog.Err(err))
+			g.recordRPCError("MessageWillBePosted")
 		}
 	}
 	return _returns.A, _returns.B
@@ -686,6 +714,7 @@ func (g *hooksRPCClient) MessageWillBeUpdated(c *Context, newPost, oldPost *mode
 	if g.implemented[MessageWillBeUpdatedID] {
 		if err := g.client.Call("Plugin.MessageWillBeUpdated", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageWillBeUpdated to plugin failed.", mlog.Err(err))
+			g.recordRPCError("MessageWillBeUpdated")
 		}
 	}
 	return _returns.A, _returns.B
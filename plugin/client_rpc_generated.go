@@ -31,6 +31,7 @@ func (g *hooksRPCClient) OnDeactivate() error {
 	if g.implemented[OnDeactivateID] {
 		if err := g.client.Call("Plugin.OnDeactivate", _args, _returns); err != nil {
 			g.log.Error("RPC call OnDeactivate to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnDeactivate")
 		}
 	}
 	return _returns.A
@@ -65,6 +66,7 @@ func (g *hooksRPCClient) OnConfigurationChange() error {
 	if g.implemented[OnConfigurationChangeID] {
 		if err := g.client.Call("Plugin.OnConfigurationChange", _args, _returns); err != nil {
 			g.log.Error("RPC call OnConfigurationChange to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnConfigurationChange")
 		}
 	}
 	return _returns.A
@@ -102,6 +104,7 @@ func (g *hooksRPCClient) ExecuteCommand(c *Context, args *model.CommandArgs) (*m
 	if g.implemented[ExecuteCommandID] {
 		if err := g.client.Call("Plugin.ExecuteCommand", _args, _returns); err != nil {
 			g.log.Error("RPC call ExecuteCommand to plugin failed.", mlog.Err(err))
+			g.recordRPCError("ExecuteCommand")
 		}
 	}
 	return _returns.A, _returns.B
@@ -136,6 +139,7 @@ func (g *hooksRPCClient) UserHasBeenCreated(c *Context, user *model.User) {
 	if g.implemented[UserHasBeenCreatedID] {
 		if err := g.client.Call("Plugin.UserHasBeenCreated", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasBeenCreated to plugin failed.", mlog.Err(err))
+			g.recordRPCError("UserHasBeenCreated")
 		}
 	}
 
@@ -171,6 +175,7 @@ func (g *hooksRPCClient) UserWillLogIn(c *Context, user *model.User) string {
 	if g.implemented[UserWillLogInID] {
 		if err := g.client.Call("Plugin.UserWillLogIn", _args, _returns); err != nil {
 			g.log.Error("RPC call UserWillLogIn to plugin failed.", mlog.Err(err))
+			g.recordRPCError("UserWillLogIn")
 		}
 	}
 	return _returns.A
@@ -205,6 +210,7 @@ func (g *hooksRPCClient) UserHasLoggedIn(c *Context, user *model.User) {
 	if g.implemented[UserHasLoggedInID] {
 		if err := g.client.Call("Plugin.UserHasLoggedIn", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLoggedIn to plugin failed.", mlog.Err(err))
+			g.recordRPCError("UserHasLoggedIn")
 		}
 	}
 
@@ -239,6 +245,7 @@ func (g *hooksRPCClient) MessageHasBeenPosted(c *Context, post *model.Post) {
 	if g.implemented[MessageHasBeenPostedID] {
 		if err := g.client.Call("Plugin.MessageHasBeenPosted", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageHasBeenPosted to plugin failed.", mlog.Err(err))
+			g.recordRPCError("MessageHasBeenPosted")
 		}
 	}
 
@@ -274,6 +281,7 @@ func (g *hooksRPCClient) MessageHasBeenUpdated(c *Context, newPost, oldPost *mod
 	if g.implemented[MessageHasBeenUpdatedID] {
 		if err := g.client.Call("Plugin.MessageHasBeenUpdated", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageHasBeenUpdated to plugin failed.", mlog.Err(err))
+			g.recordRPCError("MessageHasBeenUpdated")
 		}
 	}
 
@@ -308,6 +316,7 @@ func (g *hooksRPCClient) ChannelHasBeenCreated(c *Context, channel *model.Channe
 	if g.implemented[ChannelHasBeenCreatedID] {
 		if err := g.client.Call("Plugin.ChannelHasBeenCreated", _args, _returns); err != nil {
 			g.log.Error("RPC call ChannelHasBeenCreated to plugin failed.", mlog.Err(err))
+			g.recordRPCError("ChannelHasBeenCreated")
 		}
 	}
 
@@ -343,6 +352,7 @@ func (g *hooksRPCClient) UserHasJoinedChannel(c *Context, channelMember *model.C
 	if g.implemented[UserHasJoinedChannelID] {
 		if err := g.client.Call("Plugin.UserHasJoinedChannel", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasJoinedChannel to plugin failed.", mlog.Err(err))
+			g.recordRPCError("UserHasJoinedChannel")
 		}
 	}
 
@@ -378,6 +388,7 @@ func (g *hooksRPCClient) UserHasLeftChannel(c *Context, channelMember *model.Cha
 	if g.implemented[UserHasLeftChannelID] {
 		if err := g.client.Call("Plugin.UserHasLeftChannel", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLeftChannel to plugin failed.", mlog.Err(err))
+			g.recordRPCError("UserHasLeftChannel")
 		}
 	}
 
@@ -413,6 +424,7 @@ func (g *hooksRPCClient) UserHasJoinedTeam(c *Context, teamMember *model.TeamMem
 	if g.implemented[UserHasJoinedTeamID] {
 		if err := g.client.Call("Plugin.UserHasJoinedTeam", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasJoinedTeam to plugin failed.", mlog.Err(err))
+			g.recordRPCError("UserHasJoinedTeam")
 		}
 	}
 
@@ -448,6 +460,7 @@ func (g *hooksRPCClient) UserHasLeftTeam(c *Context, teamMember *model.TeamMembe
 	if g.implemented[UserHasLeftTeamID] {
 		if err := g.client.Call("Plugin.UserHasLeftTeam", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLeftTeam to plugin failed.", mlog.Err(err))
+			g.recordRPCError("UserHasLeftTeam")
 		}
 	}
 
@@ -482,6 +495,7 @@ func (g *hooksRPCClient) ReactionHasBeenAdded(c *Context, reaction *model.Reacti
 	if g.implemented[ReactionHasBeenAddedID] {
 		if err := g.client.Call("Plugin.ReactionHasBeenAdded", _args, _returns); err != nil {
 			g.log.Error("RPC call ReactionHasBeenAdded to plugin failed.", mlog.Err(err))
+			g.recordRPCError("ReactionHasBeenAdded")
 		}
 	}
 
@@ -516,6 +530,7 @@ func (g *hooksRPCClient) ReactionHasBeenRemoved(c *Context, reaction *model.Reac
 	if g.implemented[ReactionHasBeenRemovedID] {
 		if err := g.client.Call("Plugin.ReactionHasBeenRemoved", _args, _returns); err != nil {
 			g.log.Error("RPC call ReactionHasBeenRemoved to plugin failed.", mlog.Err(err))
+			g.recordRPCError("ReactionHasBeenRemoved")
 		}
 	}
 
@@ -550,6 +565,7 @@ func (g *hooksRPCClient) OnPluginClusterEvent(c *Context, ev model.PluginCluster
 	if g.implemented[OnPluginClusterEventID] {
 		if err := g.client.Call("Plugin.OnPluginClusterEvent", _args, _returns); err != nil {
 			g.log.Error("RPC call OnPluginClusterEvent to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnPluginClusterEvent")
 		}
 	}
 
@@ -584,6 +600,7 @@ func (g *hooksRPCClient) OnWebSocketConnect(webConnID, userID string) {
 	if g.implemented[OnWebSocketConnectID] {
 		if err := g.client.Call("Plugin.OnWebSocketConnect", _args, _returns); err != nil {
 			g.log.Error("RPC call OnWebSocketConnect to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnWebSocketConnect")
 		}
 	}
 
@@ -618,6 +635,7 @@ func (g *hooksRPCClient) OnWebSocketDisconnect(webConnID, userID string) {
 	if g.implemented[OnWebSocketDisconnectID] {
 		if err := g.client.Call("Plugin.OnWebSocketDisconnect", _args, _returns); err != nil {
 			g.log.Error("RPC call OnWebSocketDisconnect to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnWebSocketDisconnect")
 		}
 	}
 
@@ -653,6 +671,7 @@ func (g *hooksRPCClient) WebSocketMessageHasBeenPosted(webConnID, userID string,
 	if g.implemented[WebSocketMessageHasBeenPostedID] {
 		if err := g.client.Call("Plugin.WebSocketMessageHasBeenPosted", _args, _returns); err != nil {
 			g.log.Error("RPC call WebSocketMessageHasBeenPosted to plugin failed.", mlog.Err(err))
+			g.recordRPCError("WebSocketMessageHasBeenPosted")
 		}
 	}
 
@@ -689,6 +708,7 @@ func (g *hooksRPCClient) RunDataRetention(nowTime, batchSize int64) (int64, erro
 	if g.implemented[RunDataRetentionID] {
 		if err := g.client.Call("Plugin.RunDataRetention", _args, _returns); err != nil {
 			g.log.Error("RPC call RunDataRetention to plugin failed.", mlog.Err(err))
+			g.recordRPCError("RunDataRetention")
 		}
 	}
 	return _returns.A, _returns.B
@@ -725,6 +745,7 @@ func (g *hooksRPCClient) OnInstall(c *Context, event model.OnInstallEvent) error
 	if g.implemented[OnInstallID] {
 		if err := g.client.Call("Plugin.OnInstall", _args, _returns); err != nil {
 			g.log.Error("RPC call OnInstall to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnInstall")
 		}
 	}
 	return _returns.A
@@ -758,6 +779,7 @@ func (g *hooksRPCClient) OnSendDailyTelemetry() {
 	if g.implemented[OnSendDailyTelemetryID] {
 		if err := g.client.Call("Plugin.OnSendDailyTelemetry", _args, _returns); err != nil {
 			g.log.Error("RPC call OnSendDailyTelemetry to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnSendDailyTelemetry")
 		}
 	}
 
@@ -791,6 +813,7 @@ func (g *hooksRPCClient) OnCloudLimitsUpdated(limits *model.ProductLimits) {
 	if g.implemented[OnCloudLimitsUpdatedID] {
 		if err := g.client.Call("Plugin.OnCloudLimitsUpdated", _args, _returns); err != nil {
 			g.log.Error("RPC call OnCloudLimitsUpdated to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnCloudLimitsUpdated")
 		}
 	}
 
@@ -807,6 +830,43 @@ func (s *hooksRPCServer) OnCloudLimitsUpdated(args *Z_OnCloudLimitsUpdatedArgs,
 	return nil
 }
 
+func init() {
+	hookNameToId["OnScheduledTask"] = OnScheduledTaskID
+}
+
+type Z_OnScheduledTaskArgs struct {
+	A *Context
+	B string
+}
+
+type Z_OnScheduledTaskReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) OnScheduledTask(c *Context, callback string) error {
+	_args := &Z_OnScheduledTaskArgs{c, callback}
+	_returns := &Z_OnScheduledTaskReturns{}
+	if g.implemented[OnScheduledTaskID] {
+		if err := g.client.Call("Plugin.OnScheduledTask", _args, _returns); err != nil {
+			g.log.Error("RPC call OnScheduledTask to plugin failed.", mlog.Err(err))
+			g.recordRPCError("OnScheduledTask")
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) OnScheduledTask(args *Z_OnScheduledTaskArgs, returns *Z_OnScheduledTaskReturns) error {
+	if hook, ok := s.impl.(interface {
+		OnScheduledTask(c *Context, callback string) error
+	}); ok {
+		returns.A = hook.OnScheduledTask(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook OnScheduledTask called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -4708,6 +4768,35 @@ func (s *apiRPCServer) KVSetWithOptions(args *Z_KVSetWithOptionsArgs, returns *Z
 	return nil
 }
 
+type Z_KVSetMultipleArgs struct {
+	A []*model.PluginKVSetOperation
+}
+
+type Z_KVSetMultipleReturns struct {
+	A bool
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVSetMultiple(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	_args := &Z_KVSetMultipleArgs{operations}
+	_returns := &Z_KVSetMultipleReturns{}
+	if err := g.client.Call("Plugin.KVSetMultiple", _args, _returns); err != nil {
+		log.Printf("RPC call to KVSetMultiple API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVSetMultiple(args *Z_KVSetMultipleArgs, returns *Z_KVSetMultipleReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVSetMultiple(operations []*model.PluginKVSetOperation) (bool, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVSetMultiple(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVSetMultiple called but not implemented."))
+	}
+	return nil
+}
+
 type Z_KVSetWithExpiryArgs struct {
 	A string
 	B []byte
@@ -5678,3 +5767,236 @@ func (s *apiRPCServer) GetCloudLimits(args *Z_GetCloudLimitsArgs, returns *Z_Get
 	}
 	return nil
 }
+
+type Z_PublishPersistentWebSocketEventArgs struct {
+	A string
+	B map[string]interface{}
+	C *model.WebsocketBroadcast
+}
+
+type Z_PublishPersistentWebSocketEventReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) PublishPersistentWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) *model.AppError {
+	_args := &Z_PublishPersistentWebSocketEventArgs{event, payload, broadcast}
+	_returns := &Z_PublishPersistentWebSocketEventReturns{}
+	if err := g.client.Call("Plugin.PublishPersistentWebSocketEvent", _args, _returns); err != nil {
+		log.Printf("RPC call to PublishPersistentWebSocketEvent API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) PublishPersistentWebSocketEvent(args *Z_PublishPersistentWebSocketEventArgs, returns *Z_PublishPersistentWebSocketEventReturns) error {
+	if hook, ok := s.impl.(interface {
+		PublishPersistentWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) *model.AppError
+	}); ok {
+		returns.A = hook.PublishPersistentWebSocketEvent(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API PublishPersistentWebSocketEvent called but not implemented."))
+	}
+	return nil
+}
+
+type Z_RegisterScheduledTaskArgs struct {
+	A string
+	B string
+}
+
+type Z_RegisterScheduledTaskReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) RegisterScheduledTask(cron, callback string) error {
+	_args := &Z_RegisterScheduledTaskArgs{cron, callback}
+	_returns := &Z_RegisterScheduledTaskReturns{}
+	if err := g.client.Call("Plugin.RegisterScheduledTask", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterScheduledTask API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterScheduledTask(args *Z_RegisterScheduledTaskArgs, returns *Z_RegisterScheduledTaskReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterScheduledTask(cron, callback string) error
+	}); ok {
+		returns.A = hook.RegisterScheduledTask(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API RegisterScheduledTask called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterScheduledTaskArgs struct {
+	A string
+}
+
+type Z_UnregisterScheduledTaskReturns struct {
+	A error
+}
+
+func (g *apiRPCClient) UnregisterScheduledTask(callback string) error {
+	_args := &Z_UnregisterScheduledTaskArgs{callback}
+	_returns := &Z_UnregisterScheduledTaskReturns{}
+	if err := g.client.Call("Plugin.UnregisterScheduledTask", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterScheduledTask API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterScheduledTask(args *Z_UnregisterScheduledTaskArgs, returns *Z_UnregisterScheduledTaskReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnregisterScheduledTask(callback string) error
+	}); ok {
+		returns.A = hook.UnregisterScheduledTask(args.A)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterScheduledTask called but not implemented."))
+	}
+	return nil
+}
+
+type Z_CreateSearchIndexArgs struct {
+	A string
+}
+
+type Z_CreateSearchIndexReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) CreateSearchIndex(index string) *model.AppError {
+	_args := &Z_CreateSearchIndexArgs{index}
+	_returns := &Z_CreateSearchIndexReturns{}
+	if err := g.client.Call("Plugin.CreateSearchIndex", _args, _returns); err != nil {
+		log.Printf("RPC call to CreateSearchIndex API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) CreateSearchIndex(args *Z_CreateSearchIndexArgs, returns *Z_CreateSearchIndexReturns) error {
+	if hook, ok := s.impl.(interface {
+		CreateSearchIndex(index string) *model.AppError
+	}); ok {
+		returns.A = hook.CreateSearchIndex(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API CreateSearchIndex called but not implemented."))
+	}
+	return nil
+}
+
+type Z_DeleteSearchIndexArgs struct {
+	A string
+}
+
+type Z_DeleteSearchIndexReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) DeleteSearchIndex(index string) *model.AppError {
+	_args := &Z_DeleteSearchIndexArgs{index}
+	_returns := &Z_DeleteSearchIndexReturns{}
+	if err := g.client.Call("Plugin.DeleteSearchIndex", _args, _returns); err != nil {
+		log.Printf("RPC call to DeleteSearchIndex API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) DeleteSearchIndex(args *Z_DeleteSearchIndexArgs, returns *Z_DeleteSearchIndexReturns) error {
+	if hook, ok := s.impl.(interface {
+		DeleteSearchIndex(index string) *model.AppError
+	}); ok {
+		returns.A = hook.DeleteSearchIndex(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API DeleteSearchIndex called but not implemented."))
+	}
+	return nil
+}
+
+type Z_IndexSearchDocumentArgs struct {
+	A string
+	B *model.PluginSearchDocument
+}
+
+type Z_IndexSearchDocumentReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) IndexSearchDocument(index string, document *model.PluginSearchDocument) *model.AppError {
+	_args := &Z_IndexSearchDocumentArgs{index, document}
+	_returns := &Z_IndexSearchDocumentReturns{}
+	if err := g.client.Call("Plugin.IndexSearchDocument", _args, _returns); err != nil {
+		log.Printf("RPC call to IndexSearchDocument API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) IndexSearchDocument(args *Z_IndexSearchDocumentArgs, returns *Z_IndexSearchDocumentReturns) error {
+	if hook, ok := s.impl.(interface {
+		IndexSearchDocument(index string, document *model.PluginSearchDocument) *model.AppError
+	}); ok {
+		returns.A = hook.IndexSearchDocument(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API IndexSearchDocument called but not implemented."))
+	}
+	return nil
+}
+
+type Z_DeleteSearchDocumentArgs struct {
+	A string
+	B string
+}
+
+type Z_DeleteSearchDocumentReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) DeleteSearchDocument(index, documentID string) *model.AppError {
+	_args := &Z_DeleteSearchDocumentArgs{index, documentID}
+	_returns := &Z_DeleteSearchDocumentReturns{}
+	if err := g.client.Call("Plugin.DeleteSearchDocument", _args, _returns); err != nil {
+		log.Printf("RPC call to DeleteSearchDocument API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) DeleteSearchDocument(args *Z_DeleteSearchDocumentArgs, returns *Z_DeleteSearchDocumentReturns) error {
+	if hook, ok := s.impl.(interface {
+		DeleteSearchDocument(index, documentID string) *model.AppError
+	}); ok {
+		returns.A = hook.DeleteSearchDocument(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API DeleteSearchDocument called but not implemented."))
+	}
+	return nil
+}
+
+type Z_SearchIndexArgs struct {
+	A string
+	B *model.PluginSearchParams
+}
+
+type Z_SearchIndexReturns struct {
+	A *model.PluginSearchResults
+	B *model.AppError
+}
+
+func (g *apiRPCClient) SearchIndex(index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError) {
+	_args := &Z_SearchIndexArgs{index, params}
+	_returns := &Z_SearchIndexReturns{}
+	if err := g.client.Call("Plugin.SearchIndex", _args, _returns); err != nil {
+		log.Printf("RPC call to SearchIndex API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) SearchIndex(args *Z_SearchIndexArgs, returns *Z_SearchIndexReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchIndex(index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.SearchIndex(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API SearchIndex called but not implemented."))
+	}
+	return nil
+}
@@ -59,6 +59,13 @@ type Environment struct {
 	webappPluginDir        string
 	prepackagedPlugins     []*PrepackagedPlugin
 	prepackagedPluginsLock sync.RWMutex
+
+	pluginResourceMonitorJob *PluginResourceMonitorJob
+	resourceLimitsLock       sync.RWMutex
+	resourceLimits           map[string]*model.PluginResourceLimits
+	resourceCgroupParent     string
+	resourceSamplesLock      sync.Mutex
+	resourceSamples          map[string]*processSample
 }
 
 func NewEnvironment(newAPIImpl apiImplCreatorFunc,
@@ -73,6 +80,7 @@ func NewEnvironment(newAPIImpl apiImplCreatorFunc,
 		dbDriver:        dbDriver,
 		pluginDir:       pluginDir,
 		webappPluginDir: webappPluginDir,
+		resourceSamples: make(map[string]*processSample),
 	}, nil
 }
 
@@ -274,6 +282,8 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 			return nil, false, errors.Wrapf(err, "unable to start plugin: %v", id)
 		}
 
+		env.applyResourceLimits(id, sup)
+
 		// We pre-emptively set the state to running to prevent re-entrancy issues.
 		// The plugin's OnActivate hook can in-turn call UpdateConfiguration
 		// which again calls this method. This method is guarded against multiple calls,
@@ -342,6 +352,7 @@ func (env *Environment) RestartPlugin(id string) error {
 // Shutdown deactivates all plugins and gracefully shuts down the environment.
 func (env *Environment) Shutdown() {
 	env.TogglePluginHealthCheckJob(false)
+	env.TogglePluginResourceMonitorJob(false)
 
 	var wg sync.WaitGroup
 	env.registeredPlugins.Range(func(key, value interface{}) bool {
@@ -538,3 +549,103 @@ func (env *Environment) TogglePluginHealthCheckJob(enable bool) {
 func (env *Environment) GetPluginHealthCheckJob() *PluginHealthCheckJob {
 	return env.pluginHealthCheckJob
 }
+
+// SetResourceLimits sets the resource limits of the plugins, by plugin id, and the cgroup under
+// which they are enforced by the kernel, if any. Limits enforced with cgroups only apply to the
+// plugins activated afterwards.
+func (env *Environment) SetResourceLimits(limits map[string]*model.PluginResourceLimits, cgroupParent string) {
+	env.resourceLimitsLock.Lock()
+	defer env.resourceLimitsLock.Unlock()
+	env.resourceLimits = limits
+	env.resourceCgroupParent = cgroupParent
+}
+
+func (env *Environment) getResourceLimits(id string) *model.PluginResourceLimits {
+	env.resourceLimitsLock.RLock()
+	defer env.resourceLimitsLock.RUnlock()
+	return env.resourceLimits[id]
+}
+
+// applyResourceLimits moves the process of a plugin being activated to its own cgroup if its
+// limits are enforced by the kernel. If that fails, the limits are only monitored.
+func (env *Environment) applyResourceLimits(id string, sup *supervisor) {
+	env.resourceLimitsLock.RLock()
+	limits := env.resourceLimits[id]
+	cgroupParent := env.resourceCgroupParent
+	env.resourceLimitsLock.RUnlock()
+
+	if limits == nil || cgroupParent == "" {
+		return
+	}
+
+	cgroup, err := applyCgroupLimits(cgroupParent, id, sup.pid, limits)
+	if err != nil {
+		env.logger.Warn("Failed to enforce plugin resource limits with cgroups, falling back to monitoring them", mlog.String("plugin_id", id), mlog.Err(err))
+		return
+	}
+	sup.cgroup = cgroup
+}
+
+// GetPluginResourceUsage samples the resource usage of the server process of the plugin with the
+// given id. It returns nil if the plugin has no running server process.
+func (env *Environment) GetPluginResourceUsage(id string) (*model.PluginResourceUsage, error) {
+	p, ok := env.registeredPlugins.Load(id)
+	if !ok || !env.IsActive(id) {
+		return nil, nil
+	}
+	sup := p.(registeredPlugin).supervisor
+	if sup == nil {
+		return nil, nil
+	}
+
+	sample, err := readProcessSample(sup.pid)
+	if err != nil {
+		return nil, err
+	}
+	sample.pid = sup.pid
+
+	env.resourceSamplesLock.Lock()
+	previous := env.resourceSamples[id]
+	env.resourceSamples[id] = sample
+	env.resourceSamplesLock.Unlock()
+
+	usage := &model.PluginResourceUsage{
+		PluginId:      id,
+		Pid:           sup.pid,
+		MemoryBytes:   sample.memoryBytes,
+		CPUTimeMillis: sample.cpuTime.Milliseconds(),
+		Cgroup:        sup.cgroup,
+		Limits:        env.getResourceLimits(id),
+		SampledAt:     model.GetMillisForTime(sample.at),
+	}
+
+	// The previous sample is ignored if the plugin was restarted since.
+	measuredCPU := previous != nil && previous.pid == sample.pid && sample.at.After(previous.at)
+	if measuredCPU {
+		usage.CPUPercent = 100 * float64(sample.cpuTime-previous.cpuTime) / float64(sample.at.Sub(previous.at))
+	}
+
+	if usage.Limits != nil {
+		usage.LimitExceeded = isAboveLimits(usage, usage.Limits, measuredCPU)
+	}
+
+	return usage, nil
+}
+
+// TogglePluginResourceMonitorJob starts a new job if one is not running and is set to enabled, or kills an existing one if set to disabled.
+func (env *Environment) TogglePluginResourceMonitorJob(enable bool) {
+	if enable && env.pluginResourceMonitorJob == nil {
+		mlog.Debug("Enabling plugin resource monitor job", mlog.Duration("interval_s", ResourceMonitorInterval))
+
+		job := newPluginResourceMonitorJob(env)
+		env.pluginResourceMonitorJob = job
+		go job.run()
+	}
+
+	if !enable && env.pluginResourceMonitorJob != nil {
+		mlog.Debug("Disabling plugin resource monitor job")
+
+		env.pluginResourceMonitorJob.Cancel()
+		env.pluginResourceMonitorJob = nil
+	}
+}
@@ -43,6 +43,7 @@ const (
 	OnInstallID                     = 25
 	OnSendDailyTelemetryID          = 26
 	OnCloudLimitsUpdatedID          = 27
+	OnScheduledTaskID               = 28
 	TotalHooksID                    = iota
 )
 
@@ -270,4 +271,10 @@ type Hooks interface {
 	//
 	// Minimum server version: 7.0
 	OnCloudLimitsUpdated(limits *model.ProductLimits)
+
+	// OnScheduledTask is invoked when a task registered via RegisterScheduledTask is due, on a
+	// single server of the cluster. The returned error is recorded on the job that ran the task.
+	//
+	// Minimum server version: 7.0
+	OnScheduledTask(c *Context, callback string) error
 }
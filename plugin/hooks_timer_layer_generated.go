@@ -25,6 +25,10 @@ func (hooks *hooksTimerLayer) recordTime(startTime timePkg.Time, name string, su
 	if hooks.metrics != nil {
 		elapsedTime := float64(timePkg.Since(startTime)) / float64(timePkg.Second)
 		hooks.metrics.ObservePluginHookDuration(hooks.pluginID, name, success, elapsedTime)
+		hooks.metrics.IncrementPluginHookCounter(hooks.pluginID, name)
+		if !success {
+			hooks.metrics.IncrementPluginHookErrorCounter(hooks.pluginID, name, HookErrorTypeReturned)
+		}
 	}
 }
 
@@ -212,3 +216,10 @@ func (hooks *hooksTimerLayer) OnCloudLimitsUpdated(limits *model.ProductLimits)
 	hooks.hooksImpl.OnCloudLimitsUpdated(limits)
 	hooks.recordTime(startTime, "OnCloudLimitsUpdated", true)
 }
+
+func (hooks *hooksTimerLayer) OnScheduledTask(c *Context, callback string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.OnScheduledTask(c, callback)
+	hooks.recordTime(startTime, "OnScheduledTask", _returnsA == nil)
+	return _returnsA
+}
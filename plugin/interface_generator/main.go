@@ -309,6 +309,7 @@ func (g *hooksRPCClient) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 	if g.implemented[{{.Name}}ID] {
 		if err := g.client.Call("Plugin.{{.Name}}", _args, _returns); err != nil {
 			g.log.Error("RPC call {{.Name}} to plugin failed.", mlog.Err(err))
+			g.recordRPCError("{{.Name}}")
 		}
 	}
 	{{ if .Return }} return {{destruct "_returns." .Return}} {{ end }}
@@ -429,6 +430,10 @@ func (hooks *hooksTimerLayer) recordTime(startTime timePkg.Time, name string, su
 	if hooks.metrics != nil {
 		elapsedTime := float64(timePkg.Since(startTime)) / float64(timePkg.Second)
 		hooks.metrics.ObservePluginHookDuration(hooks.pluginID, name, success, elapsedTime)
+		hooks.metrics.IncrementPluginHookCounter(hooks.pluginID, name)
+		if !success {
+			hooks.metrics.IncrementPluginHookErrorCounter(hooks.pluginID, name, HookErrorTypeReturned)
+		}
 	}
 }
 
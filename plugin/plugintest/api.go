@@ -266,6 +266,22 @@ func (_m *API) CreatePost(post *model.Post) (*model.Post, *model.AppError) {
 	return r0, r1
 }
 
+// CreateSearchIndex provides a mock function with given fields: index
+func (_m *API) CreateSearchIndex(index string) *model.AppError {
+	ret := _m.Called(index)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(index)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // CreateSession provides a mock function with given fields: session
 func (_m *API) CreateSession(session *model.Session) (*model.Session, *model.AppError) {
 	ret := _m.Called(session)
@@ -540,6 +556,38 @@ func (_m *API) DeletePreferencesForUser(userID string, preferences []model.Prefe
 	return r0
 }
 
+// DeleteSearchDocument provides a mock function with given fields: index, documentID
+func (_m *API) DeleteSearchDocument(index string, documentID string) *model.AppError {
+	ret := _m.Called(index, documentID)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, string) *model.AppError); ok {
+		r0 = rf(index, documentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// DeleteSearchIndex provides a mock function with given fields: index
+func (_m *API) DeleteSearchIndex(index string) *model.AppError {
+	ret := _m.Called(index)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(index)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // DeleteTeam provides a mock function with given fields: teamID
 func (_m *API) DeleteTeam(teamID string) *model.AppError {
 	ret := _m.Called(teamID)
@@ -2427,6 +2475,22 @@ func (_m *API) HasPermissionToTeam(userID string, teamID string, permission *mod
 	return r0
 }
 
+// IndexSearchDocument provides a mock function with given fields: index, document
+func (_m *API) IndexSearchDocument(index string, document *model.PluginSearchDocument) *model.AppError {
+	ret := _m.Called(index, document)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, *model.PluginSearchDocument) *model.AppError); ok {
+		r0 = rf(index, document)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // InstallPlugin provides a mock function with given fields: file, replace
 func (_m *API) InstallPlugin(file io.Reader, replace bool) (*model.Manifest, *model.AppError) {
 	ret := _m.Called(file, replace)
@@ -2610,6 +2674,29 @@ func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	return r0
 }
 
+// KVSetMultiple provides a mock function with given fields: operations
+func (_m *API) KVSetMultiple(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	ret := _m.Called(operations)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]*model.PluginKVSetOperation) bool); ok {
+		r0 = rf(operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func([]*model.PluginKVSetOperation) *model.AppError); ok {
+		r1 = rf(operations)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVSetWithExpiry provides a mock function with given fields: key, value, expireInSeconds
 func (_m *API) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
 	ret := _m.Called(key, value, expireInSeconds)
@@ -2860,6 +2947,22 @@ func (_m *API) PluginHTTP(request *http.Request) *http.Response {
 	return r0
 }
 
+// PublishPersistentWebSocketEvent provides a mock function with given fields: event, payload, broadcast
+func (_m *API) PublishPersistentWebSocketEvent(event string, payload map[string]interface{}, broadcast *model.WebsocketBroadcast) *model.AppError {
+	ret := _m.Called(event, payload, broadcast)
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}, *model.WebsocketBroadcast) *model.AppError); ok {
+		r0 = rf(event, payload, broadcast)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // PublishPluginClusterEvent provides a mock function with given fields: ev, opts
 func (_m *API) PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model.PluginClusterEventSendOptions) error {
 	ret := _m.Called(ev, opts)
@@ -2934,6 +3037,20 @@ func (_m *API) RegisterCommand(command *model.Command) error {
 	return r0
 }
 
+// RegisterScheduledTask provides a mock function with given fields: cron, callback
+func (_m *API) RegisterScheduledTask(cron string, callback string) error {
+	ret := _m.Called(cron, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(cron, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemovePlugin provides a mock function with given fields: id
 func (_m *API) RemovePlugin(id string) *model.AppError {
 	ret := _m.Called(id)
@@ -3117,6 +3234,31 @@ func (_m *API) SearchChannels(teamID string, term string) ([]*model.Channel, *mo
 	return r0, r1
 }
 
+// SearchIndex provides a mock function with given fields: index, params
+func (_m *API) SearchIndex(index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError) {
+	ret := _m.Called(index, params)
+
+	var r0 *model.PluginSearchResults
+	if rf, ok := ret.Get(0).(func(string, *model.PluginSearchParams) *model.PluginSearchResults); ok {
+		r0 = rf(index, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PluginSearchResults)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, *model.PluginSearchParams) *model.AppError); ok {
+		r1 = rf(index, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SearchPostsInTeam provides a mock function with given fields: teamID, paramsList
 func (_m *API) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) ([]*model.Post, *model.AppError) {
 	ret := _m.Called(teamID, paramsList)
@@ -3320,6 +3462,20 @@ func (_m *API) UnregisterCommand(teamID string, trigger string) error {
 	return r0
 }
 
+// UnregisterScheduledTask provides a mock function with given fields: callback
+func (_m *API) UnregisterScheduledTask(callback string) error {
+	ret := _m.Called(callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateBotActive provides a mock function with given fields: botUserId, active
 func (_m *API) UpdateBotActive(botUserId string, active bool) (*model.Bot, *model.AppError) {
 	ret := _m.Called(botUserId, active)
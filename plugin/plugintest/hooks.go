@@ -218,6 +218,20 @@ func (_m *Hooks) OnPluginClusterEvent(c *plugin.Context, ev model.PluginClusterE
 	_m.Called(c, ev)
 }
 
+// OnScheduledTask provides a mock function with given fields: c, callback
+func (_m *Hooks) OnScheduledTask(c *plugin.Context, callback string) error {
+	ret := _m.Called(c, callback)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string) error); ok {
+		r0 = rf(c, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnSendDailyTelemetry provides a mock function with given fields:
 func (_m *Hooks) OnSendDailyTelemetry() {
 	_m.Called()
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const ResourceMonitorInterval = 15 * time.Second // How often the resource usage of limited plugins is sampled
+
+// processSample is a measure of the resources used by a plugin process.
+type processSample struct {
+	pid         int
+	memoryBytes uint64
+	cpuTime     time.Duration
+	at          time.Time
+}
+
+// PluginResourceMonitorJob samples the resource usage of the active plugins having resource
+// limits, and applies the policy of the ones found above their limits.
+type PluginResourceMonitorJob struct {
+	cancel            chan struct{}
+	cancelled         chan struct{}
+	cancelOnce        sync.Once
+	env               *Environment
+	restartTimestamps sync.Map
+}
+
+// run continuously checks the resource usage of the limited plugins, on a timer.
+func (job *PluginResourceMonitorJob) run() {
+	mlog.Debug("Plugin resource monitor job starting.")
+	defer close(job.cancelled)
+
+	ticker := time.NewTicker(ResourceMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, plugin := range job.env.Active() {
+				if job.env.getResourceLimits(plugin.Manifest.Id) != nil {
+					job.CheckPlugin(plugin.Manifest.Id)
+				}
+			}
+		case <-job.cancel:
+			return
+		}
+	}
+}
+
+// CheckPlugin samples the resource usage of the plugin and applies its policy if it is above its limits.
+// Plugins restarted for being above their limits are deactivated after HealthCheckNumRestartsLimit restarts
+// within HealthCheckDeactivationWindow, as if they were failing health checks.
+func (job *PluginResourceMonitorJob) CheckPlugin(id string) {
+	usage, err := job.env.GetPluginResourceUsage(id)
+	if err != nil {
+		mlog.Debug("Failed to sample plugin resource usage", mlog.String("id", id), mlog.Err(err))
+		return
+	}
+	if usage == nil || !usage.LimitExceeded {
+		return
+	}
+
+	policy := usage.Limits.GetPolicy()
+	mlog.Warn("Plugin is above its resource limits",
+		mlog.String("id", id),
+		mlog.Uint64("memory_bytes", usage.MemoryBytes),
+		mlog.Float64("cpu_percent", usage.CPUPercent),
+		mlog.String("policy", policy),
+	)
+
+	switch policy {
+	case model.PluginResourceLimitPolicyLog:
+		return
+	case model.PluginResourceLimitPolicyDeactivate:
+		job.deactivate(id)
+		return
+	}
+
+	timestamps := job.getStoredTimestamps(id)
+	timestamps = append(timestamps, time.Now())
+
+	if shouldDeactivatePlugin(timestamps) {
+		mlog.Debug("Deactivating plugin restarted too often for being above its resource limits", mlog.String("id", id))
+		job.deactivate(id)
+		return
+	}
+
+	mlog.Debug("Restarting plugin above its resource limits", mlog.String("id", id))
+	if err := job.env.RestartPlugin(id); err != nil {
+		mlog.Error("Failed to restart plugin", mlog.String("id", id), mlog.Err(err))
+	}
+	job.restartTimestamps.Store(id, removeStaleTimestamps(timestamps))
+}
+
+func (job *PluginResourceMonitorJob) deactivate(id string) {
+	// Order matters here, must deactivate first and then set plugin state
+	job.env.Deactivate(id)
+	job.restartTimestamps.Delete(id)
+	job.env.setPluginState(id, model.PluginStateFailedToStayRunning)
+}
+
+// getStoredTimestamps returns the times a plugin was restarted for being above its limits.
+func (job *PluginResourceMonitorJob) getStoredTimestamps(id string) []time.Time {
+	timestamps, ok := job.restartTimestamps.Load(id)
+	if !ok {
+		timestamps = []time.Time{}
+	}
+	return timestamps.([]time.Time)
+}
+
+func newPluginResourceMonitorJob(env *Environment) *PluginResourceMonitorJob {
+	return &PluginResourceMonitorJob{
+		cancel:    make(chan struct{}),
+		cancelled: make(chan struct{}),
+		env:       env,
+	}
+}
+
+func (job *PluginResourceMonitorJob) Cancel() {
+	job.cancelOnce.Do(func() {
+		close(job.cancel)
+	})
+	<-job.cancelled
+}
+
+// isAboveLimits returns true if the usage exceeds one of the limits. The CPU limit is only
+// checked once the CPU usage has been measured over an interval.
+func isAboveLimits(usage *model.PluginResourceUsage, limits *model.PluginResourceLimits, measuredCPU bool) bool {
+	if limits.MaxMemoryMB > 0 && usage.MemoryBytes > uint64(limits.MaxMemoryMB)*1024*1024 {
+		return true
+	}
+
+	return measuredCPU && limits.MaxCPUPercent > 0 && usage.CPUPercent > float64(limits.MaxCPUPercent)
+}
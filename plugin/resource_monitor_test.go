@@ -0,0 +1,29 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestIsAboveLimits(t *testing.T) {
+	limits := &model.PluginResourceLimits{MaxMemoryMB: 100, MaxCPUPercent: 50}
+
+	usage := &model.PluginResourceUsage{MemoryBytes: 50 * 1024 * 1024, CPUPercent: 20}
+	assert.False(t, isAboveLimits(usage, limits, true))
+
+	usage.MemoryBytes = 101 * 1024 * 1024
+	assert.True(t, isAboveLimits(usage, limits, true))
+
+	usage.MemoryBytes = 0
+	usage.CPUPercent = 80
+	assert.True(t, isAboveLimits(usage, limits, true))
+	assert.False(t, isAboveLimits(usage, limits, false), "the CPU usage was not measured")
+
+	assert.False(t, isAboveLimits(usage, &model.PluginResourceLimits{}, true), "no limits")
+}
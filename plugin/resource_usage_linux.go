@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// clockTicksPerSecond is USER_HZ, the unit of the CPU times in /proc. It is 100 on every
+// architecture supported by the server.
+const clockTicksPerSecond = 100
+
+const cgroupCPUPeriodMicros = 100000
+
+// readProcessSample reads the resident memory and CPU time of a process from /proc.
+func readProcessSample(pid int) (*processSample, error) {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read process stat")
+	}
+
+	// The command name, in parentheses, can contain spaces: fields are counted from its end.
+	end := strings.LastIndexByte(string(stat), ')')
+	if end < 0 {
+		return nil, errors.New("malformed process stat")
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	// utime and stime are the 14th and 15th fields, the first one after the name being the 3rd.
+	if len(fields) < 13 {
+		return nil, errors.New("malformed process stat")
+	}
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed process stat")
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed process stat")
+	}
+
+	statm, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read process memory")
+	}
+	memFields := strings.Fields(string(statm))
+	if len(memFields) < 2 {
+		return nil, errors.New("malformed process memory")
+	}
+	residentPages, err := strconv.ParseUint(memFields[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed process memory")
+	}
+
+	return &processSample{
+		memoryBytes: residentPages * uint64(os.Getpagesize()),
+		cpuTime:     time.Duration(utime+stime) * time.Second / clockTicksPerSecond,
+		at:          time.Now(),
+	}, nil
+}
+
+// applyCgroupLimits creates a cgroup v2 for the plugin under parent, sets its memory and CPU
+// limits and moves the plugin process into it. The parent must be delegated to the server, with
+// the memory and cpu controllers enabled in its cgroup.subtree_control.
+func applyCgroupLimits(parent, pluginID string, pid int, limits *model.PluginResourceLimits) (string, error) {
+	dir := filepath.Join(parent, "plugin-"+pluginID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", errors.Wrap(err, "failed to create cgroup")
+	}
+
+	memoryMax := "max"
+	if limits.MaxMemoryMB > 0 {
+		memoryMax = strconv.FormatInt(int64(limits.MaxMemoryMB)*1024*1024, 10)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "memory.max"), []byte(memoryMax), 0644); err != nil {
+		removeCgroup(dir)
+		return "", errors.Wrap(err, "failed to set cgroup memory limit")
+	}
+
+	cpuMax := fmt.Sprintf("max %d", cgroupCPUPeriodMicros)
+	if limits.MaxCPUPercent > 0 {
+		cpuMax = fmt.Sprintf("%d %d", limits.MaxCPUPercent*cgroupCPUPeriodMicros/100, cgroupCPUPeriodMicros)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "cpu.max"), []byte(cpuMax), 0644); err != nil {
+		removeCgroup(dir)
+		return "", errors.Wrap(err, "failed to set cgroup CPU limit")
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "cgroup.procs"), []byte(strconv.Itoa(pid)), 0644); err != nil {
+		removeCgroup(dir)
+		return "", errors.Wrap(err, "failed to move plugin process to cgroup")
+	}
+
+	return dir, nil
+}
+
+// removeCgroup removes the cgroup of a plugin once its process has exited.
+func removeCgroup(dir string) {
+	// A cgroup directory is removed with rmdir, its interface files cannot be deleted.
+	os.Remove(dir)
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProcessSample(t *testing.T) {
+	sample, err := readProcessSample(os.Getpid())
+	require.NoError(t, err)
+	assert.NotZero(t, sample.memoryBytes)
+	assert.False(t, sample.at.IsZero())
+
+	_, err = readProcessSample(-1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+//go:build !linux
+// +build !linux
+
+package plugin
+
+import (
+	"errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func readProcessSample(pid int) (*processSample, error) {
+	return nil, errors.New("plugin resource usage is only available on linux")
+}
+
+func applyCgroupLimits(parent, pluginID string, pid int, limits *model.PluginResourceLimits) (string, error) {
+	return "", errors.New("cgroups are only available on linux")
+}
+
+func removeCgroup(dir string) {}
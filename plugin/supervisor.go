@@ -26,6 +26,7 @@ type supervisor struct {
 	implemented [TotalHooksID]bool
 	pid         int
 	hooksClient *hooksRPCClient
+	cgroup      string
 }
 
 func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver Driver, parentLogger *mlog.Logger, metrics einterfaces.MetricsInterface) (retSupervisor *supervisor, retErr error) {
@@ -46,6 +47,8 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver Driver, par
 	pluginMap := map[string]plugin.Plugin{
 		"hooks": &hooksPlugin{
 			log:        wrappedLogger,
+			pluginID:   pluginInfo.Manifest.Id,
+			metrics:    metrics,
 			driverImpl: driver,
 			apiImpl:    &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics},
 		},
@@ -115,6 +118,10 @@ func (sup *supervisor) Shutdown() {
 	if sup.hooksClient != nil {
 		sup.hooksClient.doneWg.Wait()
 	}
+
+	if sup.cgroup != "" {
+		removeCgroup(sup.cgroup)
+	}
 }
 
 func (sup *supervisor) Hooks() Hooks {
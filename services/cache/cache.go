@@ -48,4 +48,29 @@ type Cache interface {
 
 	// Name returns the name of the cache
 	Name() string
+
+	// Stats returns the usage counters of the cache.
+	Stats() CacheStats
+}
+
+// CacheStats contains the usage counters of a cache.
+type CacheStats struct {
+	Name    string  `json:"name"`
+	Len     int     `json:"len"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+func newCacheStats(name string, length int, hits, misses int64) CacheStats {
+	stats := CacheStats{
+		Name:   name,
+		Len:    length,
+		Hits:   hits,
+		Misses: misses,
+	}
+	if lookups := hits + misses; lookups > 0 {
+		stats.HitRate = float64(hits) / float64(lookups)
+	}
+	return stats
 }
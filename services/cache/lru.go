@@ -25,6 +25,8 @@ type LRU struct {
 	defaultExpiry          time.Duration
 	name                   string
 	invalidateClusterEvent model.ClusterEvent
+	hits                   int64
+	misses                 int64
 }
 
 // LRUOptions contains options for initializing LRU cache
@@ -137,6 +139,13 @@ func (l *LRU) Name() string {
 	return l.name
 }
 
+// Stats returns the number of items in the cache and how often lookups hit or missed.
+func (l *LRU) Stats() CacheStats {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return newCacheStats(l.name, l.len, l.hits, l.misses)
+}
+
 func (l *LRU) set(key string, value interface{}, ttl time.Duration) error {
 	var expires time.Time
 	if ttl > 0 {
@@ -227,13 +236,16 @@ func (l *LRU) getItem(key string) ([]byte, error) {
 
 	ent, ok := l.items[key]
 	if !ok {
+		l.misses++
 		return nil, ErrKeyNotFound
 	}
 	e := ent.Value.(*entry)
 	if e.generation != l.currentGeneration || (!e.expires.IsZero() && time.Now().After(e.expires)) {
 		l.removeElement(ent)
+		l.misses++
 		return nil, ErrKeyNotFound
 	}
+	l.hits++
 	l.evictList.MoveToFront(ent)
 	return e.value, nil
 }
@@ -119,6 +119,19 @@ func (L LRUStriped) Name() string {
 	return L.name
 }
 
+// Stats does the same as LRU.Stats, summing the counters of every bucket.
+func (L LRUStriped) Stats() CacheStats {
+	var length int
+	var hits, misses int64
+	for _, lru := range L.buckets {
+		s := lru.Stats()
+		length += s.Len
+		hits += s.Hits
+		misses += s.Misses
+	}
+	return newCacheStats(L.name, length, hits, misses)
+}
+
 // NewLRUStriped creates a striped LRU cache using the special LRUOptions.StripedBuckets value.
 // See LRUStriped and LRUOptions for more details.
 //
@@ -105,6 +105,30 @@ func TestLRUExpire(t *testing.T) {
 	require.Equal(t, 3, r2)
 }
 
+func TestLRUStats(t *testing.T) {
+	l := NewLRU(LRUOptions{
+		Name: "test",
+		Size: 128,
+	})
+
+	stats := l.Stats()
+	assert.Equal(t, "test", stats.Name)
+	assert.Zero(t, stats.HitRate)
+
+	require.NoError(t, l.Set("1", 1))
+
+	var v int
+	require.NoError(t, l.Get("1", &v))
+	require.NoError(t, l.Get("1", &v))
+	require.Equal(t, ErrKeyNotFound, l.Get("2", &v))
+
+	stats = l.Stats()
+	assert.Equal(t, 1, stats.Len)
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.InDelta(t, 2.0/3.0, stats.HitRate, 0.0001)
+}
+
 func TestLRUMarshalUnMarshal(t *testing.T) {
 	l := NewLRU(LRUOptions{
 		Size:                   1,
@@ -6,6 +6,8 @@ import (
 	time "time"
 
 	mock "github.com/stretchr/testify/mock"
+
+	cache "github.com/mattermost/mattermost-server/v6/services/cache"
 )
 
 // Cache is an autogenerated mock type for the Cache type
@@ -168,3 +170,17 @@ func (_m *Cache) SetWithExpiry(key string, value interface{}, ttl time.Duration)
 
 	return r0
 }
+
+// Stats provides a mock function with given fields:
+func (_m *Cache) Stats() cache.CacheStats {
+	ret := _m.Called()
+
+	var r0 cache.CacheStats
+	if rf, ok := ret.Get(0).(func() cache.CacheStats); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(cache.CacheStats)
+	}
+
+	return r0
+}
@@ -63,3 +63,19 @@ func (_m *Provider) NewCache(opts *cache.CacheOptions) (cache.Cache, error) {
 
 	return r0, r1
 }
+
+// Stats provides a mock function with given fields:
+func (_m *Provider) Stats() []cache.CacheStats {
+	ret := _m.Called()
+
+	var r0 []cache.CacheStats
+	if rf, ok := ret.Get(0).(func() []cache.CacheStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cache.CacheStats)
+		}
+	}
+
+	return r0
+}
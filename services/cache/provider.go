@@ -4,6 +4,7 @@
 package cache
 
 import (
+	"sync"
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -27,9 +28,13 @@ type Provider interface {
 	Connect() error
 	// Close releases any resources used by the cache provider.
 	Close() error
+	// Stats returns the usage counters of every cache created by the provider.
+	Stats() []CacheStats
 }
 
 type cacheProvider struct {
+	mut    sync.Mutex
+	caches []Cache
 }
 
 // NewProvider creates a new CacheProvider
@@ -39,21 +44,33 @@ func NewProvider() Provider {
 
 // NewCache creates a new cache with given opts
 func (c *cacheProvider) NewCache(opts *CacheOptions) (Cache, error) {
+	var newCache Cache
 	if opts.Striped {
-		return NewLRUStriped(LRUOptions{
+		var err error
+		newCache, err = NewLRUStriped(LRUOptions{
 			Name:                   opts.Name,
 			Size:                   opts.Size,
 			DefaultExpiry:          opts.DefaultExpiry,
 			InvalidateClusterEvent: opts.InvalidateClusterEvent,
 			StripedBuckets:         opts.StripedBuckets,
 		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newCache = NewLRU(LRUOptions{
+			Name:                   opts.Name,
+			Size:                   opts.Size,
+			DefaultExpiry:          opts.DefaultExpiry,
+			InvalidateClusterEvent: opts.InvalidateClusterEvent,
+		})
 	}
-	return NewLRU(LRUOptions{
-		Name:                   opts.Name,
-		Size:                   opts.Size,
-		DefaultExpiry:          opts.DefaultExpiry,
-		InvalidateClusterEvent: opts.InvalidateClusterEvent,
-	}), nil
+
+	c.mut.Lock()
+	c.caches = append(c.caches, newCache)
+	c.mut.Unlock()
+
+	return newCache, nil
 }
 
 // Connect opens a new connection to the cache using specific provider parameters.
@@ -65,3 +82,15 @@ func (c *cacheProvider) Connect() error {
 func (c *cacheProvider) Close() error {
 	return nil
 }
+
+// Stats returns the usage counters of every cache created by the provider.
+func (c *cacheProvider) Stats() []CacheStats {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	stats := make([]CacheStats, 0, len(c.caches))
+	for _, cache := range c.caches {
+		stats = append(stats, cache.Stats())
+	}
+	return stats
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -184,3 +185,25 @@ func TestConnectClose(t *testing.T) {
 	err = p.Close()
 	require.NoError(t, err)
 }
+
+func TestProviderStats(t *testing.T) {
+	p := NewProvider()
+	require.Empty(t, p.Stats())
+
+	c1, err := p.NewCache(&CacheOptions{Name: "c1", Size: 128})
+	require.NoError(t, err)
+	c2, err := p.NewCache(&CacheOptions{Name: "c2", Size: 128, Striped: true, StripedBuckets: 4})
+	require.NoError(t, err)
+
+	require.NoError(t, c1.Set("key", "value"))
+	var v string
+	require.NoError(t, c1.Get("key", &v))
+	require.Equal(t, ErrKeyNotFound, c2.Get("key", &v))
+
+	stats := p.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "c1", stats[0].Name)
+	assert.Equal(t, int64(1), stats[0].Hits)
+	assert.Equal(t, "c2", stats[1].Name)
+	assert.Equal(t, int64(1), stats[1].Misses)
+}
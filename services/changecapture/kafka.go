@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapture
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const (
+	kafkaTimeout        = 10 * time.Second
+	kafkaPublishTimeout = time.Minute
+	kafkaMaxAttempts    = 5
+)
+
+// kafkaPublisher publishes the events to a Kafka topic, keyed by the id of their entity so that
+// the events of an entity land in the same partition, in order. The events are published once all
+// the in-sync replicas of their partition stored them, and the failed attempts are retried.
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func newKafkaPublisher(settings *model.ChangeDataCaptureSettings) (*kafkaPublisher, error) {
+	var brokers []string
+	for _, broker := range strings.Split(*settings.URL, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 {
+		return nil, errors.New("no Kafka broker")
+	}
+
+	transport := &kafka.Transport{
+		DialTimeout: kafkaTimeout,
+		ClientID:    "mattermost",
+	}
+	if *settings.EnableTLS {
+		tlsConfig, err := newTLSConfig(*settings.RootCAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLS = tlsConfig
+	}
+	if *settings.Username != "" {
+		transport.SASL = plain.Mechanism{Username: *settings.Username, Password: *settings.Password}
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        *settings.Topic,
+		Balancer:     &kafka.Hash{},
+		MaxAttempts:  kafkaMaxAttempts,
+		BatchSize:    *settings.BatchSize,
+		BatchTimeout: 10 * time.Millisecond,
+		ReadTimeout:  kafkaTimeout,
+		WriteTimeout: kafkaTimeout,
+		RequiredAcks: kafka.RequireAll,
+		Transport:    transport,
+	}
+
+	return &kafkaPublisher{writer: writer}, nil
+}
+
+func (p *kafkaPublisher) Publish(events []*model.ChangeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]kafka.Message, 0, len(events))
+	for _, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal change event with id=%s", event.Id)
+		}
+		messages = append(messages, kafka.Message{Key: []byte(event.EntityId), Value: value})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaPublishTimeout)
+	defer cancel()
+
+	if err := p.writer.WriteMessages(ctx, messages...); err != nil {
+		return errors.Wrap(err, "failed to publish the change events to Kafka")
+	}
+
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapture
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/natsclient"
+)
+
+const natsTimeout = 10 * time.Second
+
+// natsPublisher publishes the events to NATS JetStream on the subject made of the topic and the
+// type of their entity, e.g. mattermost.post, which a stream must capture. Each event is published
+// once the stream stored it, with its id as the message id so that JetStream discards the events
+// published again after a failure.
+type natsPublisher struct {
+	topic string
+	conn  *nats.Conn
+	js    nats.JetStreamContext
+}
+
+func newNatsPublisher(settings *model.ChangeDataCaptureSettings) (*natsPublisher, error) {
+	for _, rawURL := range strings.Split(*settings.URL, ",") {
+		u, err := url.Parse(strings.TrimSpace(rawURL))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse NATS URL")
+		}
+		if u.Scheme != "nats" && u.Scheme != "tls" {
+			return nil, errors.Errorf("unsupported NATS URL scheme %s", u.Scheme)
+		}
+	}
+
+	conn, err := natsclient.Connect("mattermost-change-capture", natsclient.Options{
+		URL:             *settings.URL,
+		CredentialsFile: *settings.CredentialsFile,
+		NkeySeedFile:    *settings.NkeySeedFile,
+		RootCAFile:      *settings.RootCAFile,
+	}, natsTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream(nats.MaxWait(natsTimeout))
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to use JetStream")
+	}
+
+	return &natsPublisher{topic: *settings.Topic, conn: conn, js: js}, nil
+}
+
+func (p *natsPublisher) Publish(events []*model.ChangeEvent) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal change event with id=%s", event.Id)
+		}
+
+		msg := &nats.Msg{Subject: p.topic + "." + event.EntityType, Data: payload}
+		if _, err := p.js.PublishMsg(msg, nats.MsgId(event.Id)); err != nil {
+			return errors.Wrapf(err, "failed to publish change event with id=%s to JetStream", event.Id)
+		}
+	}
+
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
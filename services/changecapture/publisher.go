@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapture
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// Publisher streams the change events to a message broker.
+type Publisher interface {
+	// Publish publishes the events in order, returning once the broker stored them. The events
+	// are either all published or the publication failed, in which case some of them may have
+	// been published nonetheless.
+	Publish(events []*model.ChangeEvent) error
+	// Close closes the connections to the broker.
+	Close() error
+}
+
+// NewPublisher returns the publisher configured by the settings. The publisher keeps its
+// connections open, and reconnects when they are lost, until it is closed.
+func NewPublisher(settings *model.ChangeDataCaptureSettings) (Publisher, error) {
+	switch *settings.Publisher {
+	case model.ChangeDataCapturePublisherKafka:
+		return newKafkaPublisher(settings)
+	case model.ChangeDataCapturePublisherNats:
+		return newNatsPublisher(settings)
+	default:
+		return nil, errors.Errorf("unknown change data capture publisher %s", *settings.Publisher)
+	}
+}
+
+// newTLSConfig returns the TLS configuration trusting the certificate authorities of the PEM file,
+// or the ones of the system if the file is empty.
+func newTLSConfig(rootCAFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if rootCAFile == "" {
+		return config, nil
+	}
+
+	pem, err := ioutil.ReadFile(rootCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the root certificate authorities")
+	}
+	config.RootCAs = x509.NewCertPool()
+	if !config.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no certificate found in %s", rootCAFile)
+	}
+
+	return config, nil
+}
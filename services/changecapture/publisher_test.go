@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapture
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/natsclient/natsclienttest"
+)
+
+func makeChangeEvents(t *testing.T) []*model.ChangeEvent {
+	events := []*model.ChangeEvent{}
+	for _, entityType := range []string{model.ChangeEventEntityPost, model.ChangeEventEntityUser} {
+		event, err := model.NewChangeEvent(entityType, model.NewId(), model.ChangeEventOperationCreate, map[string]string{"message": "hello"})
+		require.NoError(t, err)
+		event.PreSave()
+		events = append(events, event)
+	}
+	return events
+}
+
+func makeSettings(publisher, url string) *model.ChangeDataCaptureSettings {
+	settings := &model.ChangeDataCaptureSettings{}
+	settings.SetDefaults()
+	settings.Enable = model.NewBool(true)
+	settings.Publisher = model.NewString(publisher)
+	settings.URL = model.NewString(url)
+	settings.Topic = model.NewString("changes")
+	return settings
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	t.Run("connects to the brokers", func(t *testing.T) {
+		settings := makeSettings(model.ChangeDataCapturePublisherKafka, "kafka1:9092, kafka2:9092")
+		settings.EnableTLS = model.NewBool(true)
+		settings.Username = model.NewString("user")
+		settings.Password = model.NewString("pass")
+
+		publisher, err := newKafkaPublisher(settings)
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		assert.Equal(t, "kafka1:9092,kafka2:9092", publisher.writer.Addr.String())
+		assert.Equal(t, "changes", publisher.writer.Topic)
+		assert.Equal(t, kafka.RequireAll, publisher.writer.RequiredAcks)
+
+		transport := publisher.writer.Transport.(*kafka.Transport)
+		require.NotNil(t, transport.TLS)
+		require.NotNil(t, transport.SASL)
+		assert.Equal(t, "PLAIN", transport.SASL.Name())
+	})
+
+	t.Run("fails without a broker", func(t *testing.T) {
+		_, err := newKafkaPublisher(makeSettings(model.ChangeDataCapturePublisherKafka, " , "))
+		require.Error(t, err)
+	})
+
+	t.Run("fails without the root certificate authorities", func(t *testing.T) {
+		settings := makeSettings(model.ChangeDataCapturePublisherKafka, "kafka1:9092")
+		settings.EnableTLS = model.NewBool(true)
+		settings.RootCAFile = model.NewString(filepath.Join(t.TempDir(), "missing.pem"))
+
+		_, err := newKafkaPublisher(settings)
+		require.Error(t, err)
+	})
+
+	t.Run("fails when the brokers are unreachable", func(t *testing.T) {
+		publisher, err := newKafkaPublisher(makeSettings(model.ChangeDataCapturePublisherKafka, "127.0.0.1:1"))
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		require.Error(t, publisher.Publish(makeChangeEvents(t)))
+	})
+}
+
+func TestNatsPublisher(t *testing.T) {
+	events := makeChangeEvents(t)
+
+	t.Run("publishes the events to JetStream on the subjects of their entity", func(t *testing.T) {
+		server := natsclienttest.NewServer(t, "changes")
+		defer server.Close()
+
+		publisher, err := newNatsPublisher(makeSettings(model.ChangeDataCapturePublisherNats, server.URL()))
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		require.NoError(t, publisher.Publish(events))
+		// The events published again, e.g. after a failure, are discarded by JetStream.
+		require.NoError(t, publisher.Publish(events))
+
+		messages := server.Messages()
+		require.Len(t, messages, 2)
+		assert.Equal(t, "changes.post", messages[0].Subject)
+		assert.Equal(t, events[0].Id, messages[0].MsgId)
+		assert.Equal(t, "changes.user", messages[1].Subject)
+		assert.Equal(t, events[1].Id, messages[1].MsgId)
+
+		var event model.ChangeEvent
+		require.NoError(t, json.Unmarshal(messages[0].Data, &event))
+		assert.Equal(t, events[0].Id, event.Id)
+	})
+
+	t.Run("fails when no stream captures the events", func(t *testing.T) {
+		server := natsclienttest.NewServer(t, "other")
+		defer server.Close()
+
+		publisher, err := newNatsPublisher(makeSettings(model.ChangeDataCapturePublisherNats, server.URL()))
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		require.Error(t, publisher.Publish(events))
+	})
+
+	t.Run("fails without a server", func(t *testing.T) {
+		_, err := newNatsPublisher(makeSettings(model.ChangeDataCapturePublisherNats, "nats://127.0.0.1:1"))
+		require.Error(t, err)
+	})
+
+	t.Run("rejects an unsupported scheme", func(t *testing.T) {
+		_, err := newNatsPublisher(makeSettings(model.ChangeDataCapturePublisherNats, "http://localhost:4222"))
+		require.Error(t, err)
+	})
+}
@@ -5,6 +5,8 @@ package docextractor
 
 import (
 	"io"
+	"path"
+	"strings"
 )
 
 // ExtractSettings defines the features enabled/disable during the document text extraction.
@@ -12,6 +14,25 @@ type ExtractSettings struct {
 	ArchiveRecursion bool
 	MMPreviewURL     string
 	MMPreviewSecret  string
+	TikaURL          string
+	// DisablePDF and DisableOfficeDocuments skip the extraction of the text of the given kind of
+	// documents, whatever the extractor.
+	DisablePDF             bool
+	DisableOfficeDocuments bool
+}
+
+// officeDocumentExtensions are the extensions of the documents produced by office suites.
+var officeDocumentExtensions = map[string]bool{
+	"doc":  true,
+	"docx": true,
+	"odt":  true,
+	"rtf":  true,
+	"ppt":  true,
+	"pptx": true,
+	"odp":  true,
+	"xls":  true,
+	"xlsx": true,
+	"ods":  true,
 }
 
 // Extract extract the text from a document using the system default extractors
@@ -21,10 +42,18 @@ func Extract(filename string, r io.ReadSeeker, settings ExtractSettings) (string
 
 // ExtractWithExtraExtractors extract the text from a document using the provided extractors beside the system default extractors.
 func ExtractWithExtraExtractors(filename string, r io.ReadSeeker, settings ExtractSettings, extraExtractors []Extractor) (string, error) {
+	extension := strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+	if (settings.DisablePDF && extension == "pdf") || (settings.DisableOfficeDocuments && officeDocumentExtensions[extension]) {
+		return "", nil
+	}
+
 	enabledExtractors := &combineExtractor{}
 	for _, extraExtractor := range extraExtractors {
 		enabledExtractors.Add(extraExtractor)
 	}
+	if settings.TikaURL != "" {
+		enabledExtractors.Add(newTikaExtractor(settings.TikaURL))
+	}
 	enabledExtractors.Add(&documentExtractor{})
 	enabledExtractors.Add(&pdfExtractor{})
 
@@ -7,6 +7,9 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -121,6 +124,22 @@ func TestExtract(t *testing.T) {
 			[]string{},
 			false,
 		},
+		{
+			"Pdf file with pdf extraction disabled",
+			"sample-doc.pdf",
+			ExtractSettings{DisablePDF: true},
+			[]string{},
+			[]string{"simple", "document", "contains"},
+			false,
+		},
+		{
+			"Docx file with office documents extraction disabled",
+			"sample-doc.docx",
+			ExtractSettings{DisableOfficeDocuments: true},
+			[]string{},
+			[]string{"simple", "document", "contains"},
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -200,3 +219,50 @@ func TestExtractWithExtraExtractors(t *testing.T) {
 		assert.Contains(t, text, "contains")
 	})
 }
+
+func TestExtractWithTika(t *testing.T) {
+	data, err := testutils.ReadTestFile("sample-doc.pdf")
+	require.NoError(t, err)
+
+	t.Run("uses the Tika server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/tika", r.URL.Path)
+			assert.Equal(t, "text/plain", r.Header.Get("Accept"))
+
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, data, body)
+
+			w.Write([]byte("text extracted by tika\n"))
+		}))
+		defer server.Close()
+
+		text, err := Extract("sample-doc.pdf", bytes.NewReader(data), ExtractSettings{TikaURL: server.URL + "/"})
+		require.NoError(t, err)
+		assert.Equal(t, "text extracted by tika", text)
+	})
+
+	t.Run("falls back to the embedded extractors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}))
+		defer server.Close()
+
+		text, err := Extract("sample-doc.pdf", bytes.NewReader(data), ExtractSettings{TikaURL: server.URL})
+		require.NoError(t, err)
+		assert.Contains(t, text, "simple")
+		assert.Contains(t, text, "document")
+	})
+
+	t.Run("doesn't send disabled document types", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "no request should be made")
+		}))
+		defer server.Close()
+
+		text, err := Extract("sample-doc.pdf", bytes.NewReader(data), ExtractSettings{TikaURL: server.URL, DisablePDF: true})
+		require.NoError(t, err)
+		assert.Empty(t, text)
+	})
+}
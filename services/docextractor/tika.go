@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+// Apache Tika server extracts the text of most office and PDF documents. When
+// it's configured, it's tried before the embedded extractors, which are only
+// used when Tika fails.
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const tikaRequestTimeout = 2 * time.Minute
+
+type tikaExtractor struct {
+	url    string
+	client *http.Client
+}
+
+func newTikaExtractor(url string) *tikaExtractor {
+	return &tikaExtractor{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: tikaRequestTimeout},
+	}
+}
+
+func (te *tikaExtractor) Match(filename string) bool {
+	extension := strings.TrimPrefix(path.Ext(filename), ".")
+	return extension == "pdf" || officeDocumentExtensions[extension]
+}
+
+func (te *tikaExtractor) Extract(filename string, file io.ReadSeeker) (string, error) {
+	req, err := http.NewRequest(http.MethodPut, te.url+"/tika", file)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to extract the file content using Tika")
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := te.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to extract the file content using Tika")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("unable to extract the file content using Tika (the server replied with status %d)", resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read the response from Tika")
+	}
+	return strings.TrimSpace(string(data)), nil
+}
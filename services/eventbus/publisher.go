@@ -0,0 +1,216 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package eventbus
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/natsclient"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	publishTimeout = 10 * time.Second
+	minRetryDelay  = 100 * time.Millisecond
+	maxRetryDelay  = 30 * time.Second
+)
+
+// message is an event waiting to be published.
+type message struct {
+	id      string
+	subject string
+	payload []byte
+}
+
+// Publisher publishes the server events to NATS JetStream, from a queue processed in the
+// background. An event is published again until JetStream acknowledges it, with the id of the
+// event as the message id for JetStream to discard the duplicates, so the events are published at
+// least once as long as the server doesn't stop and the queue doesn't overflow. The connection to
+// NATS is kept open, and reconnects on its own when lost.
+type Publisher struct {
+	mut      sync.RWMutex
+	settings model.EventBusSettings
+	events   map[string]bool
+
+	queue   chan *message
+	stop    chan struct{}
+	stopped chan struct{}
+
+	// conn, js and connOptions are only used by the worker.
+	conn        *nats.Conn
+	js          nats.JetStreamContext
+	connOptions natsclient.Options
+}
+
+// NewPublisher returns a publisher with the given settings, processing its queue until stopped.
+func NewPublisher(settings model.EventBusSettings) *Publisher {
+	p := &Publisher{
+		queue:   make(chan *message, *settings.QueueSize),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	p.UpdateSettings(settings)
+
+	go p.worker()
+
+	return p
+}
+
+// UpdateSettings applies the settings to the events published from now on, except for the size
+// of the queue, which is fixed.
+func (p *Publisher) UpdateSettings(settings model.EventBusSettings) {
+	events := make(map[string]bool, len(settings.Events))
+	for _, event := range settings.Events {
+		events[event] = true
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	p.settings = settings
+	p.events = events
+}
+
+func (p *Publisher) getSettings() model.EventBusSettings {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	return p.settings
+}
+
+// Publish queues the event to be published, if the event bus is enabled and the type of the
+// event is one of the published types. The events with sensitive data are never published.
+func (p *Publisher) Publish(event *model.WebSocketEvent) {
+	p.mut.RLock()
+	enabled := *p.settings.Enable && p.events[event.EventType()]
+	prefix := *p.settings.SubjectPrefix
+	p.mut.RUnlock()
+
+	if !enabled || (event.GetBroadcast() != nil && event.GetBroadcast().ContainsSensitiveData) {
+		return
+	}
+
+	busEvent := model.NewEventBusEvent(event)
+	// The event is marshalled right away, as its data may be shared with the websocket event.
+	payload, err := json.Marshal(busEvent)
+	if err != nil {
+		mlog.Warn("Failed to marshal an event for the event bus", mlog.String("event", busEvent.Type), mlog.Err(err))
+		return
+	}
+
+	msg := &message{
+		id:      busEvent.Id,
+		subject: prefix + "." + busEvent.Type,
+		payload: payload,
+	}
+
+	select {
+	case p.queue <- msg:
+	default:
+		mlog.Warn("Event bus queue is full, dropping event", mlog.String("event", busEvent.Type), mlog.String("event_id", busEvent.Id))
+	}
+}
+
+// Stop stops publishing the events. The events still in the queue are dropped.
+func (p *Publisher) Stop() {
+	close(p.stop)
+	<-p.stopped
+}
+
+func (p *Publisher) worker() {
+	defer close(p.stopped)
+	defer p.closeConn()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case msg := <-p.queue:
+			p.publishWithRetry(msg)
+		}
+	}
+}
+
+// publishWithRetry publishes the message until it is acknowledged, the event bus is disabled or
+// the publisher is stopped.
+func (p *Publisher) publishWithRetry(msg *message) {
+	delay := minRetryDelay
+	for {
+		settings := p.getSettings()
+		if !*settings.Enable {
+			return
+		}
+
+		err := p.publish(connOptions(settings), msg)
+		if err == nil {
+			return
+		}
+		mlog.Warn("Failed to publish an event to the event bus, retrying", mlog.String("subject", msg.subject), mlog.String("event_id", msg.id), mlog.Duration("delay", delay), mlog.Err(err))
+
+		select {
+		case <-p.stop:
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+func connOptions(settings model.EventBusSettings) natsclient.Options {
+	return natsclient.Options{
+		URL:             *settings.URL,
+		CredentialsFile: *settings.CredentialsFile,
+		NkeySeedFile:    *settings.NkeySeedFile,
+		RootCAFile:      *settings.RootCAFile,
+	}
+}
+
+func (p *Publisher) publish(options natsclient.Options, msg *message) error {
+	if p.conn != nil && p.connOptions != options {
+		p.closeConn()
+	}
+
+	if p.conn == nil {
+		conn, err := natsclient.Connect("mattermost-event-bus", options, publishTimeout)
+		if err != nil {
+			return err
+		}
+		js, err := conn.JetStream(nats.MaxWait(publishTimeout))
+		if err != nil {
+			conn.Close()
+			return errors.Wrap(err, "failed to use JetStream")
+		}
+		p.conn = conn
+		p.js = js
+		p.connOptions = options
+	}
+
+	// The connection outlives the failures, since it reconnects on its own. The message id lets
+	// JetStream discard the message if a previous attempt stored it but its acknowledgement was
+	// lost.
+	if _, err := p.js.PublishMsg(&nats.Msg{Subject: msg.subject, Data: msg.payload}, nats.MsgId(msg.id)); err != nil {
+		return errors.Wrap(err, "failed to publish to JetStream")
+	}
+
+	return nil
+}
+
+func (p *Publisher) closeConn() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+		p.js = nil
+		p.connOptions = natsclient.Options{}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package eventbus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// makePublisher returns a publisher whose queue isn't processed, for the test to inspect it.
+func makePublisher(enable bool) *Publisher {
+	settings := model.EventBusSettings{}
+	settings.SetDefaults()
+	settings.Enable = model.NewBool(enable)
+	settings.QueueSize = model.NewInt(2)
+
+	p := &Publisher{queue: make(chan *message, *settings.QueueSize)}
+	p.UpdateSettings(settings)
+	return p
+}
+
+func TestPublish(t *testing.T) {
+	channelID := model.NewId()
+
+	t.Run("queues the published event types", func(t *testing.T) {
+		p := makePublisher(true)
+		event := model.NewWebSocketEvent(model.WebsocketEventPosted, "", channelID, "", nil)
+		event.Add("post", `{"message":"hello"}`)
+
+		p.Publish(event)
+
+		require.Len(t, p.queue, 1)
+		msg := <-p.queue
+		assert.Equal(t, "mattermost.events.posted", msg.subject)
+
+		var busEvent model.EventBusEvent
+		require.NoError(t, json.Unmarshal(msg.payload, &busEvent))
+		assert.Equal(t, model.EventBusSchemaVersion, busEvent.SchemaVersion)
+		assert.Equal(t, msg.id, busEvent.Id)
+		assert.Equal(t, model.WebsocketEventPosted, busEvent.Type)
+		assert.Equal(t, channelID, busEvent.ChannelId)
+		assert.Equal(t, `{"message":"hello"}`, busEvent.Data["post"])
+	})
+
+	t.Run("ignores the other event types", func(t *testing.T) {
+		p := makePublisher(true)
+		p.Publish(model.NewWebSocketEvent(model.WebsocketEventTyping, "", channelID, "", nil))
+		assert.Empty(t, p.queue)
+	})
+
+	t.Run("ignores the events with sensitive data", func(t *testing.T) {
+		p := makePublisher(true)
+		event := model.NewWebSocketEvent(model.WebsocketEventPosted, "", channelID, "", nil)
+		event.GetBroadcast().ContainsSensitiveData = true
+		p.Publish(event)
+		assert.Empty(t, p.queue)
+	})
+
+	t.Run("ignores the events when disabled", func(t *testing.T) {
+		p := makePublisher(false)
+		p.Publish(model.NewWebSocketEvent(model.WebsocketEventPosted, "", channelID, "", nil))
+		assert.Empty(t, p.queue)
+	})
+
+	t.Run("drops the events when the queue is full", func(t *testing.T) {
+		p := makePublisher(true)
+		for i := 0; i < 3; i++ {
+			p.Publish(model.NewWebSocketEvent(model.WebsocketEventPosted, "", channelID, "", nil))
+		}
+		assert.Len(t, p.queue, 2)
+	})
+}
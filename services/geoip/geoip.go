@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package geoip approximates the location of IP addresses from a local database, so that no
+// address is ever sent to a third party.
+//
+// The database is a CSV file of IP ranges in the layout of the DB-IP "IP to City Lite" database:
+//
+//	ip_start,ip_end,continent,country,stateprov,city[,latitude,longitude]
+//
+// where ip_start and ip_end are the first and last addresses of the range, both IPv4 or both
+// IPv6, and the ranges don't overlap.
+package geoip
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const (
+	columnStart   = 0
+	columnEnd     = 1
+	columnCountry = 3
+	columnRegion  = 4
+	columnCity    = 5
+	minColumns    = 6
+)
+
+type ipRange struct {
+	start    net.IP
+	end      net.IP
+	location *model.GeoLocation
+}
+
+// Database is an in-memory GeoIP database, safe for concurrent lookups.
+type Database struct {
+	ranges []ipRange
+}
+
+// Open loads the database stored at path.
+func Open(path string) (*Database, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the GeoIP database")
+	}
+	defer file.Close()
+
+	return Load(file)
+}
+
+// Load reads a database in the CSV layout described by the package documentation.
+func Load(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	db := &Database{}
+	// The locations are shared by the ranges of a same city, which are often numerous.
+	locations := make(map[model.GeoLocation]*model.GeoLocation)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read line %d of the GeoIP database", line)
+		}
+		if len(record) < minColumns {
+			return nil, errors.Errorf("line %d of the GeoIP database has %d columns instead of at least %d", line, len(record), minColumns)
+		}
+
+		start := net.ParseIP(record[columnStart])
+		end := net.ParseIP(record[columnEnd])
+		if start == nil || end == nil || (start.To4() == nil) != (end.To4() == nil) || bytes.Compare(start.To16(), end.To16()) > 0 {
+			return nil, errors.Errorf("line %d of the GeoIP database has an invalid IP range", line)
+		}
+
+		location := model.GeoLocation{
+			Country: record[columnCountry],
+			Region:  record[columnRegion],
+			City:    record[columnCity],
+		}
+		shared, ok := locations[location]
+		if !ok {
+			shared = &location
+			locations[location] = shared
+		}
+
+		db.ranges = append(db.ranges, ipRange{start: start.To16(), end: end.To16(), location: shared})
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return bytes.Compare(db.ranges[i].start, db.ranges[j].start) < 0
+	})
+
+	return db, nil
+}
+
+// Lookup returns the approximate location of ip, or nil if it isn't in the database.
+func (db *Database) Lookup(ip net.IP) *model.GeoLocation {
+	if db == nil || ip == nil {
+		return nil
+	}
+
+	ip = ip.To16()
+	// Find the last range starting at or before ip.
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return bytes.Compare(db.ranges[i].start, ip) > 0
+	}) - 1
+	if i < 0 || bytes.Compare(ip, db.ranges[i].end) > 0 {
+		return nil
+	}
+
+	location := *db.ranges[i].location
+	return &location
+}
+
+// Len returns the number of IP ranges in the database.
+func (db *Database) Len() int {
+	return len(db.ranges)
+}
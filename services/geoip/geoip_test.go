@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package geoip
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDatabase = `1.0.0.0,1.0.0.255,OC,AU,Queensland,"South Brisbane",-27.4748,153.017
+10.0.0.0,10.255.255.255,EU,FR,Ile-de-France,Paris,48.8566,2.35222
+2001:db8::,2001:db8::ffff,NA,US,California,"San Francisco",37.7749,-122.419
+`
+
+func TestLookup(t *testing.T) {
+	db, err := Load(strings.NewReader(testDatabase))
+	require.NoError(t, err)
+	require.Equal(t, 3, db.Len())
+
+	location := db.Lookup(net.ParseIP("10.1.2.3"))
+	require.NotNil(t, location)
+	require.Equal(t, "FR", location.Country)
+	require.Equal(t, "Ile-de-France", location.Region)
+	require.Equal(t, "Paris", location.City)
+
+	location = db.Lookup(net.ParseIP("1.0.0.0"))
+	require.NotNil(t, location)
+	require.Equal(t, "South Brisbane", location.City)
+
+	location = db.Lookup(net.ParseIP("2001:db8::1"))
+	require.NotNil(t, location)
+	require.Equal(t, "US", location.Country)
+
+	require.Nil(t, db.Lookup(net.ParseIP("1.0.1.0")), "between two ranges")
+	require.Nil(t, db.Lookup(net.ParseIP("0.0.0.1")), "before the first range")
+	require.Nil(t, db.Lookup(net.ParseIP("2001:db9::")), "after the last range")
+	require.Nil(t, db.Lookup(nil))
+
+	var nilDB *Database
+	require.Nil(t, nilDB.Lookup(net.ParseIP("10.1.2.3")))
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, database := range map[string]string{
+		"missing columns":  "10.0.0.0,10.255.255.255,EU,FR\n",
+		"invalid address":  "10.0.0.0,10.0.0.256,EU,FR,Ile-de-France,Paris\n",
+		"reversed range":   "10.255.255.255,10.0.0.0,EU,FR,Ile-de-France,Paris\n",
+		"mixed IP version": "10.0.0.0,2001:db8::,EU,FR,Ile-de-France,Paris\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(database))
+			require.Error(t, err)
+		})
+	}
+}
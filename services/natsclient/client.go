@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package natsclient connects the services publishing to NATS with the settings they share.
+package natsclient
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// Options are the settings of a connection to NATS.
+type Options struct {
+	// URL is the nats:// or tls:// URL of the server, or a comma-separated list of URLs of the
+	// servers of a cluster. It may hold a user and a password, or a token.
+	URL string
+	// CredentialsFile is the path to the file holding the JWT and the nkey seed of the user, as
+	// generated by nsc.
+	CredentialsFile string
+	// NkeySeedFile is the path to the file holding the nkey seed of the user.
+	NkeySeedFile string
+	// RootCAFile is the path to the PEM file of the certificate authorities the certificate of the
+	// server is verified against, instead of the ones of the system.
+	RootCAFile string
+}
+
+// Connect connects to NATS, identifying the client by name. The timeout applies to establishing
+// the connection. Once connected, the connection reconnects on its own whenever it is lost, and
+// buffers the messages published meanwhile, until it is closed.
+func Connect(name string, options Options, timeout time.Duration) (*nats.Conn, error) {
+	natsOptions := []nats.Option{
+		nats.Name(name),
+		nats.Timeout(timeout),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				mlog.Warn("Disconnected from NATS, reconnecting", mlog.String("client", name), mlog.Err(err))
+			}
+		}),
+		nats.ReconnectHandler(func(_ *nats.Conn) {
+			mlog.Info("Reconnected to NATS", mlog.String("client", name))
+		}),
+	}
+
+	if options.CredentialsFile != "" {
+		natsOptions = append(natsOptions, nats.UserCredentials(options.CredentialsFile))
+	}
+
+	if options.NkeySeedFile != "" {
+		nkeyOption, err := nats.NkeyOptionFromSeed(options.NkeySeedFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the NATS nkey seed")
+		}
+		natsOptions = append(natsOptions, nkeyOption)
+	}
+
+	if options.RootCAFile != "" {
+		natsOptions = append(natsOptions, nats.RootCAs(options.RootCAFile))
+	}
+
+	conn, err := nats.Connect(options.URL, natsOptions...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to NATS")
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package natsclient
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/services/natsclient/natsclienttest"
+)
+
+func TestConnect(t *testing.T) {
+	server := natsclienttest.NewServer(t, "events")
+	defer server.Close()
+
+	t.Run("publishes to JetStream", func(t *testing.T) {
+		conn, err := Connect("test", Options{URL: server.URL()}, 5*time.Second)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		js, err := conn.JetStream()
+		require.NoError(t, err)
+
+		ack, err := js.Publish("events.posted", []byte(`{"message":"hello"}`), nats.MsgId("id1"))
+		require.NoError(t, err)
+		assert.False(t, ack.Duplicate)
+
+		ack, err = js.Publish("events.posted", []byte(`{"message":"hello"}`), nats.MsgId("id1"))
+		require.NoError(t, err)
+		assert.True(t, ack.Duplicate)
+
+		_, err = js.Publish("other.posted", []byte(`{}`))
+		require.Error(t, err)
+
+		messages := server.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "events.posted", messages[0].Subject)
+		assert.Equal(t, "id1", messages[0].MsgId)
+	})
+
+	t.Run("reconnects when the connection is lost", func(t *testing.T) {
+		conn, err := Connect("test", Options{URL: server.URL()}, 5*time.Second)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		server.DropConnections()
+
+		require.Eventually(t, func() bool {
+			return conn.Stats().Reconnects > 0 && conn.IsConnected()
+		}, 10*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("fails without the nkey seed file", func(t *testing.T) {
+		_, err := Connect("test", Options{URL: server.URL(), NkeySeedFile: filepath.Join(t.TempDir(), "missing.nk")}, 5*time.Second)
+		require.Error(t, err)
+	})
+
+	t.Run("fails without a server", func(t *testing.T) {
+		_, err := Connect("test", Options{URL: "nats://127.0.0.1:1"}, time.Second)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package natsclienttest provides a fake NATS server for the tests of the clients publishing to
+// NATS.
+package natsclienttest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const msgIDHeader = "Nats-Msg-Id"
+
+// Message is a message stored by a stream of the server.
+type Message struct {
+	Subject string
+	MsgId   string
+	Data    []byte
+}
+
+// Server is a fake NATS server with JetStream streams, speaking just enough of the protocol for
+// nats.go to connect and publish to core NATS and to JetStream. The streams drop the duplicates
+// of the messages, by message id, and the requests on the other subjects have no responders.
+type Server struct {
+	t        *testing.T
+	listener net.Listener
+	prefixes []string
+
+	mut      sync.Mutex
+	conns    map[net.Conn]bool
+	messages []*Message
+	msgIDs   map[string]bool
+	sequence int
+}
+
+// NewServer starts a server whose streams capture the subjects starting with the given prefixes,
+// followed by a dot.
+func NewServer(t *testing.T, prefixes ...string) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &Server{
+		t:        t,
+		listener: listener,
+		prefixes: prefixes,
+		conns:    map[net.Conn]bool{},
+		msgIDs:   map[string]bool{},
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mut.Lock()
+			s.conns[conn] = true
+			s.mut.Unlock()
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+// URL returns the nats:// URL of the server.
+func (s *Server) URL() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+// Messages returns the messages stored by the streams, in order.
+func (s *Server) Messages() []*Message {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return append([]*Message{}, s.messages...)
+}
+
+// DropConnections closes the connections of the clients, which are expected to reconnect.
+func (s *Server) DropConnections() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.DropConnections()
+}
+
+func (s *Server) captures(subject string) bool {
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(subject, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// store stores the message in the streams, returning the acknowledgement of JetStream.
+func (s *Server) store(msg *Message) string {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if msg.MsgId != "" && s.msgIDs[msg.MsgId] {
+		return fmt.Sprintf(`{"stream":"TEST","seq":%d,"duplicate":true}`, s.sequence)
+	}
+
+	s.msgIDs[msg.MsgId] = true
+	s.messages = append(s.messages, msg)
+	s.sequence++
+	return fmt.Sprintf(`{"stream":"TEST","seq":%d}`, s.sequence)
+}
+
+// subscription is a subscription of a connection, whose subject may hold wildcards.
+type subscription struct {
+	subject string
+	sid     string
+}
+
+func (sub *subscription) matches(subject string) bool {
+	pattern := strings.Split(sub.subject, ".")
+	tokens := strings.Split(subject, ".")
+	for i, token := range pattern {
+		if token == ">" {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) || (token != "*" && token != tokens[i]) {
+			return false
+		}
+	}
+	return len(tokens) == len(pattern)
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mut.Lock()
+		delete(s.conns, conn)
+		s.mut.Unlock()
+	}()
+
+	conn.Write([]byte(`INFO {"server_id":"test","version":"2.8.0","proto":1,"headers":true,"jetstream":true,"max_payload":1048576}` + "\r\n"))
+
+	var subs []*subscription
+	reply := func(subject, payload string) {
+		for _, sub := range subs {
+			if sub.matches(subject) {
+				fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", subject, sub.sid, len(payload), payload)
+				return
+			}
+		}
+	}
+	noResponders := func(subject string) {
+		status := "NATS/1.0 503\r\n\r\n"
+		for _, sub := range subs {
+			if sub.matches(subject) {
+				fmt.Fprintf(conn, "HMSG %s %s %d %d\r\n%s\r\n", subject, sub.sid, len(status), len(status), status)
+				return
+			}
+		}
+	}
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(strings.TrimRight(line, "\r\n"))
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case "SUB":
+			subs = append(subs, &subscription{subject: fields[1], sid: fields[len(fields)-1]})
+		case "PUB", "HPUB":
+			// PUB subject [reply] size, HPUB subject [reply] header-size size
+			args := fields[1:]
+			headerSize := 0
+			if fields[0] == "HPUB" {
+				headerSize, _ = strconv.Atoi(args[len(args)-2])
+				args = append(args[:len(args)-2], args[len(args)-1])
+			}
+			size, _ := strconv.Atoi(args[len(args)-1])
+			data := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, data); err != nil {
+				return
+			}
+
+			msg := &Message{Subject: args[0], Data: data[headerSize:size]}
+			for _, header := range strings.Split(string(data[:headerSize]), "\r\n") {
+				if strings.HasPrefix(header, msgIDHeader+":") {
+					msg.MsgId = strings.TrimSpace(strings.TrimPrefix(header, msgIDHeader+":"))
+				}
+			}
+
+			replyTo := ""
+			if len(args) == 3 {
+				replyTo = args[1]
+			}
+
+			switch {
+			case msg.Subject == "$JS.API.INFO":
+				reply(replyTo, `{"type":"io.nats.jetstream.api.v1.account_info_response","memory":0,"storage":0,"streams":1,"consumers":0,"limits":{},"api":{"total":0,"errors":0}}`)
+			case s.captures(msg.Subject):
+				ack := s.store(msg)
+				if replyTo != "" {
+					reply(replyTo, ack)
+				}
+			case replyTo != "":
+				noResponders(replyTo)
+			}
+		}
+	}
+}
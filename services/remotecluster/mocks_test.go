@@ -18,14 +18,19 @@ type mockServer struct {
 	remotes []*model.RemoteCluster
 	logger  *mlog.Logger
 	user    *model.User
+	config  *model.Config
 }
 
 func newMockServer(remotes []*model.RemoteCluster) *mockServer {
 	testLogger := mlog.CreateConsoleTestLogger(true, mlog.LvlDebug)
 
+	config := &model.Config{}
+	config.SetDefaults()
+
 	return &mockServer{
 		remotes: remotes,
 		logger:  testLogger,
+		config:  config,
 	}
 }
 
@@ -33,7 +38,7 @@ func (ms *mockServer) SetUser(user *model.User) {
 	ms.user = user
 }
 
-func (ms *mockServer) Config() *model.Config                                  { return nil }
+func (ms *mockServer) Config() *model.Config                                  { return ms.config }
 func (ms *mockServer) GetMetrics() einterfaces.MetricsInterface               { return nil }
 func (ms *mockServer) IsLeader() bool                                         { return true }
 func (ms *mockServer) AddClusterLeaderChangedListener(listener func()) string { return model.NewId() }
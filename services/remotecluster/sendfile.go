@@ -111,7 +111,7 @@ func (rcs *Service) sendFileToRemote(timeout time.Duration, task sendFileTask) (
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	resp, err := rcs.httpClient.Do(req.WithContext(ctx))
+	resp, err := rcs.getHTTPClient(task.rc).Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
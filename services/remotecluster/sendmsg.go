@@ -151,7 +151,7 @@ func (rcs *Service) sendFrameToRemote(timeout time.Duration, rc *model.RemoteClu
 	req.Header.Set(model.HeaderRemoteclusterId, rc.RemoteId)
 	req.Header.Set(model.HeaderRemoteclusterToken, rc.RemoteToken)
 
-	resp, err := rcs.httpClient.Do(req.WithContext(ctx))
+	resp, err := rcs.getHTTPClient(rc).Do(req.WithContext(ctx))
 	if metrics := rcs.server.GetMetrics(); metrics != nil {
 		if err != nil || resp.StatusCode != http.StatusOK {
 			metrics.IncrementRemoteClusterMsgErrorsCounter(frame.RemoteId, os.IsTimeout(err))
@@ -128,7 +128,7 @@ func (rcs *Service) sendProfileImageToRemote(timeout time.Duration, task sendPro
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	resp, err := rcs.httpClient.Do(req.WithContext(ctx))
+	resp, err := rcs.getHTTPClient(task.rc).Do(req.WithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -5,7 +5,6 @@ package remotecluster
 
 import (
 	"context"
-	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -79,8 +78,12 @@ type ConnectionStateListener func(rc *model.RemoteCluster, online bool)
 type Service struct {
 	server     ServerIface
 	httpClient *http.Client
+	certLoader *clientCertLoader
 	send       []chan interface{}
 
+	clientsMux sync.Mutex
+	tlsClients map[string]tlsClient // maps remote id to a client verifying that remote's pinned certs
+
 	// everything below guarded by `mux`
 	mux                      sync.RWMutex
 	active                   bool
@@ -92,30 +95,16 @@ type Service struct {
 
 // NewRemoteClusterService creates a RemoteClusterService instance. In product this is called a "Secured Connection".
 func NewRemoteClusterService(server ServerIface) (*Service, error) {
-	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-			DualStack: true,
-		}).DialContext,
-		ForceAttemptHTTP2:     true,
-		MaxIdleConns:          200,
-		MaxIdleConnsPerHost:   2,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-		DisableCompression:    false,
-	}
-
 	client := &http.Client{
-		Transport: transport,
+		Transport: newTransport(),
 		Timeout:   SendTimeout,
 	}
 
 	service := &Service{
 		server:                   server,
 		httpClient:               client,
+		certLoader:               &clientCertLoader{server: server},
+		tlsClients:               make(map[string]tlsClient),
 		topicListeners:           make(map[string]map[string]TopicListener),
 		connectionStateListeners: make(map[string]ConnectionStateListener),
 	}
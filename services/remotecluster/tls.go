@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remotecluster
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+var (
+	ErrNoPeerCertificate = errors.New("remote did not present a certificate")
+	ErrCertNotPinned     = errors.New("remote certificate does not match any pinned certificate")
+	ErrNoPinnedCert      = errors.New("remote has no pinned certificate")
+)
+
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          200,
+		MaxIdleConnsPerHost:   2,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		DisableCompression:    false,
+	}
+}
+
+// clientCertLoader loads the client certificate presented to remotes when mutual TLS is enabled.
+// The key pair is re-read whenever the files change on disk so certificates can be rotated
+// without restarting the server.
+type clientCertLoader struct {
+	server ServerIface
+
+	mux      sync.Mutex
+	cert     *tls.Certificate
+	certFile string
+	keyFile  string
+	modTime  time.Time
+}
+
+func (l *clientCertLoader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	cfg := l.server.Config().ExperimentalSettings
+	certFile := *cfg.RemoteClusterClientCertFile
+	keyFile := *cfg.RemoteClusterClientKeyFile
+
+	if certFile == "" || keyFile == "" {
+		// an empty certificate tells the remote we have none to offer.
+		return &tls.Certificate{}, nil
+	}
+
+	certInfo, err := os.Stat(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read remote cluster client certificate: %w", err)
+	}
+	keyInfo, err := os.Stat(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read remote cluster client key: %w", err)
+	}
+	modTime := certInfo.ModTime()
+	if keyInfo.ModTime().After(modTime) {
+		modTime = keyInfo.ModTime()
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.cert != nil && l.certFile == certFile && l.keyFile == keyFile && l.modTime.Equal(modTime) {
+		return l.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load remote cluster client key pair: %w", err)
+	}
+
+	l.cert = &cert
+	l.certFile = certFile
+	l.keyFile = keyFile
+	l.modTime = modTime
+	return l.cert, nil
+}
+
+// VerifyPinnedCert checks that the leaf certificate of a TLS connection matches one of the
+// certificates pinned for the remote. Remotes without pinned certificates are not checked.
+func VerifyPinnedCert(rc *model.RemoteCluster, state *tls.ConnectionState) error {
+	if !rc.HasPinnedCerts() {
+		return nil
+	}
+
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return ErrNoPeerCertificate
+	}
+
+	if !rc.IsCertPinned(state.PeerCertificates[0]) {
+		return ErrCertNotPinned
+	}
+	return nil
+}
+
+// VerifyClientCert checks the certificate presented by a remote calling this server. When mutual
+// TLS is enabled every remote must have pinned certificates and present one of them; otherwise only
+// remotes with pinned certificates are checked.
+func VerifyClientCert(rc *model.RemoteCluster, state *tls.ConnectionState, mutualTLS bool) error {
+	if mutualTLS && !rc.HasPinnedCerts() {
+		return ErrNoPinnedCert
+	}
+	return VerifyPinnedCert(rc, state)
+}
+
+// getHTTPClient returns the client used to send requests to a remote. Remotes with pinned
+// certificates, or any remote when mutual TLS is enabled, get a dedicated client so the TLS
+// handshake can be verified against that remote's pins.
+func (rcs *Service) getHTTPClient(rc *model.RemoteCluster) *http.Client {
+	mutualTLS := *rcs.server.Config().ExperimentalSettings.RemoteClusterEnableMutualTLS
+	if !mutualTLS && !rc.HasPinnedCerts() {
+		return rcs.httpClient
+	}
+
+	key := fmt.Sprintf("%s:%t:%s", rc.RemoteId, mutualTLS, rc.PinnedCerts)
+
+	rcs.clientsMux.Lock()
+	defer rcs.clientsMux.Unlock()
+
+	if client, ok := rcs.tlsClients[rc.RemoteId]; ok && client.key == key {
+		return client.client
+	}
+
+	pinned := *rc
+	transport := newTransport()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return VerifyPinnedCert(&pinned, &state)
+		},
+	}
+	if mutualTLS {
+		transport.TLSClientConfig.GetClientCertificate = rcs.certLoader.GetClientCertificate
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   SendTimeout,
+	}
+
+	if old, ok := rcs.tlsClients[rc.RemoteId]; ok {
+		old.client.CloseIdleConnections()
+	}
+	rcs.tlsClients[rc.RemoteId] = tlsClient{key: key, client: client}
+	return client
+}
+
+type tlsClient struct {
+	key    string
+	client *http.Client
+}
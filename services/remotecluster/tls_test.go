@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remotecluster
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestVerifyPinnedCert(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("remote certificate")}
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	t.Run("no pinned certs", func(t *testing.T) {
+		rc := &model.RemoteCluster{}
+		assert.NoError(t, VerifyPinnedCert(rc, nil))
+		assert.NoError(t, VerifyPinnedCert(rc, state))
+	})
+
+	t.Run("pinned cert matches", func(t *testing.T) {
+		rc := &model.RemoteCluster{}
+		rc.SetPinnedCerts([]string{model.CertFingerprint(cert)})
+		assert.NoError(t, VerifyPinnedCert(rc, state))
+	})
+
+	t.Run("pinned cert missing", func(t *testing.T) {
+		rc := &model.RemoteCluster{}
+		rc.SetPinnedCerts([]string{model.CertFingerprint(cert)})
+		assert.ErrorIs(t, VerifyPinnedCert(rc, nil), ErrNoPeerCertificate)
+		assert.ErrorIs(t, VerifyPinnedCert(rc, &tls.ConnectionState{}), ErrNoPeerCertificate)
+	})
+
+	t.Run("pinned cert mismatch", func(t *testing.T) {
+		rc := &model.RemoteCluster{}
+		rc.SetPinnedCerts([]string{model.CertFingerprint(&x509.Certificate{Raw: []byte("other")})})
+		assert.ErrorIs(t, VerifyPinnedCert(rc, state), ErrCertNotPinned)
+	})
+}
+
+func TestVerifyClientCert(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("remote certificate")}
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	t.Run("unpinned remote", func(t *testing.T) {
+		rc := &model.RemoteCluster{}
+		assert.NoError(t, VerifyClientCert(rc, state, false))
+		assert.ErrorIs(t, VerifyClientCert(rc, state, true), ErrNoPinnedCert)
+		assert.ErrorIs(t, VerifyClientCert(rc, nil, true), ErrNoPinnedCert)
+	})
+
+	t.Run("pinned remote", func(t *testing.T) {
+		rc := &model.RemoteCluster{}
+		rc.SetPinnedCerts([]string{model.CertFingerprint(cert)})
+		assert.NoError(t, VerifyClientCert(rc, state, true))
+		assert.ErrorIs(t, VerifyClientCert(rc, nil, true), ErrNoPeerCertificate)
+		assert.ErrorIs(t, VerifyClientCert(rc, &tls.ConnectionState{}, true), ErrNoPeerCertificate)
+	})
+}
+
+func TestGetHTTPClientPinning(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	mockServer := newMockServer(nil)
+	defer mockServer.Shutdown()
+
+	service, err := NewRemoteClusterService(mockServer)
+	require.NoError(t, err)
+
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), SiteURL: ts.URL}
+	require.Same(t, service.httpClient, service.getHTTPClient(rc), "remotes without pins should use the shared client")
+
+	// trust the test server's self-signed certificate so only the pinning check differs.
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+
+	doRequest := func(rc *model.RemoteCluster) error {
+		client := service.getHTTPClient(rc)
+		client.Transport.(*http.Transport).TLSClientConfig.RootCAs = pool
+		resp, err := client.Get(ts.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("pinned cert accepted", func(t *testing.T) {
+		rc.SetPinnedCerts([]string{model.CertFingerprint(ts.Certificate())})
+		assert.NoError(t, doRequest(rc))
+	})
+
+	t.Run("rotated pin without server cert rejected", func(t *testing.T) {
+		rc.SetPinnedCerts([]string{model.CertFingerprint(&x509.Certificate{Raw: []byte("new certificate")})})
+		assert.Error(t, doRequest(rc))
+	})
+}
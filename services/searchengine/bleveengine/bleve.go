@@ -38,6 +38,9 @@ type BleveEngine struct {
 	ready        int32
 	cfg          *model.Config
 	indexSync    bool
+
+	pluginIndexes     map[string]bleve.Index
+	pluginIndexesLock sync.Mutex
 }
 
 var keywordMapping *mapping.FieldMapping
@@ -204,6 +207,10 @@ func (b *BleveEngine) closeIndexes() *model.AppError {
 		if err := b.ChannelIndex.Close(); err != nil {
 			return model.NewAppError("Bleveengine.Stop", "bleveengine.stop_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 		}
+
+		if err := b.closePluginIndexes(); err != nil {
+			return model.NewAppError("Bleveengine.Stop", "bleveengine.stop_plugin_indexes.error", nil, err.Error(), http.StatusInternalServerError)
+		}
 	}
 
 	atomic.StoreInt32(&b.ready, 0)
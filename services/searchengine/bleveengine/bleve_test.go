@@ -61,7 +61,7 @@ func (s *BleveEngineTestSuite) setupStore() {
 	cfg.SqlSettings.DisableDatabaseSearch = model.NewBool(true)
 
 	s.SearchEngine = searchengine.NewBroker(cfg)
-	s.Store = searchlayer.NewSearchLayer(&testlib.TestStore{Store: s.SQLStore}, s.SearchEngine, cfg)
+	s.Store = searchlayer.NewSearchLayer(&testlib.TestStore{Store: s.SQLStore}, s.SearchEngine, cfg, nil)
 
 	s.BleveEngine = NewBleveEngine(cfg)
 	s.BleveEngine.indexSync = true
@@ -0,0 +1,208 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// The fields of the plugin documents are nested under this field, so that they can't be
+// confused with the tenant.
+const pluginDocumentFieldsPrefix = "Fields"
+
+func getPluginIndexMapping() *mapping.IndexMappingImpl {
+	documentMapping := bleve.NewDocumentMapping()
+	documentMapping.AddFieldMappingsAt("TenantId", keywordMapping)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultAnalyzer = standard.Name
+	indexMapping.AddDocumentMapping("_default", documentMapping)
+
+	return indexMapping
+}
+
+func (b *BleveEngine) getPluginIndexDir(pluginID, index string) string {
+	return filepath.Join(*b.cfg.BleveSettings.IndexDir, "plugins", pluginID, index+".bleve")
+}
+
+// getPluginIndex returns the open index of a plugin, opening it if needed. The index is created
+// if create is true. b.Mutex must be held.
+func (b *BleveEngine) getPluginIndex(pluginID, index string, create bool) (bleve.Index, *model.AppError) {
+	if !b.IsActive() {
+		return nil, model.NewAppError("Bleveengine.getPluginIndex", "bleveengine.plugin_index.not_active.error", nil, "", http.StatusServiceUnavailable)
+	}
+	if !model.IsValidPluginId(pluginID) || !model.IsValidPluginSearchIndexName(index) {
+		return nil, model.NewAppError("Bleveengine.getPluginIndex", "bleveengine.plugin_index.invalid_name.error", nil, "plugin_id="+pluginID+", index="+index, http.StatusBadRequest)
+	}
+
+	b.pluginIndexesLock.Lock()
+	defer b.pluginIndexesLock.Unlock()
+
+	name := pluginID + "/" + index
+	if blvIndex, ok := b.pluginIndexes[name]; ok {
+		return blvIndex, nil
+	}
+
+	indexPath := b.getPluginIndexDir(pluginID, index)
+	blvIndex, err := bleve.Open(indexPath)
+	if err != nil && create {
+		blvIndex, err = bleve.NewUsing(indexPath, getPluginIndexMapping(), "scorch", "scorch", map[string]interface{}{
+			"forceSegmentType":    "zap",
+			"forceSegmentVersion": 15,
+		})
+	}
+	if err != nil {
+		return nil, model.NewAppError("Bleveengine.getPluginIndex", "bleveengine.plugin_index.open.error", map[string]interface{}{"Index": index}, err.Error(), http.StatusNotFound)
+	}
+
+	if b.pluginIndexes == nil {
+		b.pluginIndexes = map[string]bleve.Index{}
+	}
+	b.pluginIndexes[name] = blvIndex
+	return blvIndex, nil
+}
+
+// closePluginIndexes closes the open indexes of the plugins. b.Mutex must be held.
+func (b *BleveEngine) closePluginIndexes() error {
+	b.pluginIndexesLock.Lock()
+	defer b.pluginIndexesLock.Unlock()
+
+	for name, blvIndex := range b.pluginIndexes {
+		if err := blvIndex.Close(); err != nil {
+			return err
+		}
+		delete(b.pluginIndexes, name)
+	}
+	return nil
+}
+
+func (b *BleveEngine) CreatePluginIndex(pluginID, index string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	_, appErr := b.getPluginIndex(pluginID, index, true)
+	return appErr
+}
+
+func (b *BleveEngine) DeletePluginIndex(pluginID, index string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	blvIndex, appErr := b.getPluginIndex(pluginID, index, false)
+	if appErr != nil {
+		return appErr
+	}
+
+	b.pluginIndexesLock.Lock()
+	defer b.pluginIndexesLock.Unlock()
+
+	delete(b.pluginIndexes, pluginID+"/"+index)
+	if err := blvIndex.Close(); err != nil {
+		return model.NewAppError("Bleveengine.DeletePluginIndex", "bleveengine.plugin_index.delete.error", map[string]interface{}{"Index": index}, err.Error(), http.StatusInternalServerError)
+	}
+	if err := os.RemoveAll(b.getPluginIndexDir(pluginID, index)); err != nil {
+		return model.NewAppError("Bleveengine.DeletePluginIndex", "bleveengine.plugin_index.delete.error", map[string]interface{}{"Index": index}, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (b *BleveEngine) IndexPluginDocument(pluginID, index string, doc *model.PluginSearchDocument) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	blvIndex, appErr := b.getPluginIndex(pluginID, index, false)
+	if appErr != nil {
+		return appErr
+	}
+
+	fields := make(map[string]interface{}, len(doc.Fields))
+	for name, value := range doc.Fields {
+		fields[name] = value
+	}
+	blvDoc := map[string]interface{}{
+		"TenantId":                 doc.TenantId,
+		pluginDocumentFieldsPrefix: fields,
+	}
+
+	if err := blvIndex.Index(doc.Id, blvDoc); err != nil {
+		return model.NewAppError("Bleveengine.IndexPluginDocument", "bleveengine.plugin_index.index_document.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (b *BleveEngine) DeletePluginDocument(pluginID, index, documentID string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	blvIndex, appErr := b.getPluginIndex(pluginID, index, false)
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := blvIndex.Delete(documentID); err != nil {
+		return model.NewAppError("Bleveengine.DeletePluginDocument", "bleveengine.plugin_index.delete_document.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (b *BleveEngine) SearchPluginIndex(pluginID, index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError) {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	blvIndex, appErr := b.getPluginIndex(pluginID, index, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	tenantQueries := []query.Query{}
+	for _, tenantId := range params.TenantIds {
+		tenantQ := bleve.NewTermQuery(tenantId)
+		tenantQ.SetField("TenantId")
+		tenantQueries = append(tenantQueries, tenantQ)
+	}
+
+	var termsQ query.Query
+	if len(params.Fields) == 0 {
+		termsQ = bleve.NewMatchQuery(params.Terms)
+	} else {
+		fieldQueries := []query.Query{}
+		for _, field := range params.Fields {
+			fieldQ := bleve.NewMatchQuery(params.Terms)
+			fieldQ.SetField(pluginDocumentFieldsPrefix + "." + field)
+			fieldQueries = append(fieldQueries, fieldQ)
+		}
+		termsQ = bleve.NewDisjunctionQuery(fieldQueries...)
+	}
+
+	perPage := params.GetPerPage()
+	searchQ := bleve.NewConjunctionQuery(bleve.NewDisjunctionQuery(tenantQueries...), termsQ)
+	search := bleve.NewSearchRequestOptions(searchQ, perPage, params.Page*perPage, false)
+
+	results, err := blvIndex.Search(search)
+	if err != nil {
+		return nil, model.NewAppError("Bleveengine.SearchPluginIndex", "bleveengine.plugin_index.search.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	hits := make([]*model.PluginSearchHit, 0, len(results.Hits))
+	for _, hit := range results.Hits {
+		hits = append(hits, &model.PluginSearchHit{
+			Id:    hit.ID,
+			Score: hit.Score,
+		})
+	}
+
+	return &model.PluginSearchResults{
+		Hits:  hits,
+		Total: int64(results.Total),
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestPluginIndex(t *testing.T) {
+	indexDir, err := ioutil.TempDir("", "mmbleve")
+	require.NoError(t, err)
+	defer os.RemoveAll(indexDir)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.BleveSettings.EnableIndexing = model.NewBool(true)
+	cfg.BleveSettings.IndexDir = model.NewString(indexDir)
+
+	engine := NewBleveEngine(cfg)
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	pluginID := "com.mattermost.test"
+	require.Nil(t, engine.CreatePluginIndex(pluginID, "cards"))
+
+	t.Run("invalid names", func(t *testing.T) {
+		assert.NotNil(t, engine.CreatePluginIndex(pluginID, "../cards"))
+		assert.NotNil(t, engine.CreatePluginIndex("..", "cards"))
+	})
+
+	t.Run("index not created", func(t *testing.T) {
+		_, appErr := engine.SearchPluginIndex(pluginID, "missing", &model.PluginSearchParams{Terms: "test", TenantIds: []string{"board1"}})
+		assert.NotNil(t, appErr)
+	})
+
+	docs := []*model.PluginSearchDocument{
+		{Id: "card1", TenantId: "board1", Fields: map[string]string{"title": "Quarterly planning", "description": "Plan the roadmap"}},
+		{Id: "card2", TenantId: "board1", Fields: map[string]string{"title": "Release notes", "description": "Write the planning notes"}},
+		{Id: "card3", TenantId: "board2", Fields: map[string]string{"title": "Planning offsite"}},
+	}
+	for _, doc := range docs {
+		require.Nil(t, engine.IndexPluginDocument(pluginID, "cards", doc))
+	}
+
+	search := func(t *testing.T, params *model.PluginSearchParams) []string {
+		results, appErr := engine.SearchPluginIndex(pluginID, "cards", params)
+		require.Nil(t, appErr)
+		ids := []string{}
+		for _, hit := range results.Hits {
+			ids = append(ids, hit.Id)
+		}
+		return ids
+	}
+
+	t.Run("restricted to the tenants", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"card1", "card2"}, search(t, &model.PluginSearchParams{Terms: "planning", TenantIds: []string{"board1"}}))
+		assert.ElementsMatch(t, []string{"card1", "card2", "card3"}, search(t, &model.PluginSearchParams{Terms: "planning", TenantIds: []string{"board1", "board2"}}))
+		assert.Empty(t, search(t, &model.PluginSearchParams{Terms: "planning", TenantIds: []string{"board3"}}))
+	})
+
+	t.Run("restricted to the fields", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"card1"}, search(t, &model.PluginSearchParams{Terms: "planning", TenantIds: []string{"board1"}, Fields: []string{"title"}}))
+	})
+
+	t.Run("isolated from other plugins", func(t *testing.T) {
+		require.Nil(t, engine.CreatePluginIndex("com.mattermost.other", "cards"))
+		results, appErr := engine.SearchPluginIndex("com.mattermost.other", "cards", &model.PluginSearchParams{Terms: "planning", TenantIds: []string{"board1"}})
+		require.Nil(t, appErr)
+		assert.Empty(t, results.Hits)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Nil(t, engine.DeletePluginDocument(pluginID, "cards", "card2"))
+		assert.ElementsMatch(t, []string{"card1"}, search(t, &model.PluginSearchParams{Terms: "planning", TenantIds: []string{"board1"}}))
+
+		require.Nil(t, engine.DeletePluginIndex(pluginID, "cards"))
+		_, appErr := engine.SearchPluginIndex(pluginID, "cards", &model.PluginSearchParams{Terms: "planning", TenantIds: []string{"board1"}})
+		assert.NotNil(t, appErr)
+	})
+}
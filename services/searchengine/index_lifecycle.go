@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+const (
+	postIndexName              = "posts"
+	rolloverIndexFirstSequence = "000001"
+)
+
+// IndexLifecyclePolicy is the lifecycle of the post indexes built from the Elasticsearch
+// settings: the posts are written through a rollover alias to an index that is rolled over
+// once too old or too large, and the indexes then move through the warm and cold phases.
+type IndexLifecyclePolicy struct {
+	// Name is the name of the policy.
+	Name string
+	// RolloverAlias is the alias the posts are written to and searched through.
+	RolloverAlias string
+	// FirstIndex is the index the rollover alias points to when bootstrapped, whose name ends
+	// with the sequence incremented on every rollover.
+	FirstIndex string
+	// LegacyIndexPattern matches the daily post indexes created before the policy, which are
+	// migrated to it.
+	LegacyIndexPattern string
+
+	RolloverMaxAgeDays int
+	RolloverMaxSizeGB  int
+	WarmPhaseAfterDays int
+	ColdPhaseAfterDays int
+}
+
+// NewIndexLifecyclePolicy builds the lifecycle policy of the post indexes from the settings, or
+// returns nil if the index lifecycle management is disabled.
+func NewIndexLifecyclePolicy(settings *model.ElasticsearchSettings) *IndexLifecyclePolicy {
+	if !*settings.EnableIndexLifecycleManagement {
+		return nil
+	}
+
+	prefix := *settings.IndexPrefix
+	return &IndexLifecyclePolicy{
+		Name:               prefix + postIndexName + "_policy",
+		RolloverAlias:      prefix + postIndexName,
+		FirstIndex:         prefix + postIndexName + "-" + rolloverIndexFirstSequence,
+		LegacyIndexPattern: prefix + postIndexName + "_*",
+		RolloverMaxAgeDays: *settings.RolloverMaxAgeDays,
+		RolloverMaxSizeGB:  *settings.RolloverMaxSizeGB,
+		WarmPhaseAfterDays: *settings.WarmPhaseAfterDays,
+		ColdPhaseAfterDays: *settings.ColdPhaseAfterDays,
+	}
+}
+
+// Body returns the body of the request creating or updating the policy with the Elasticsearch
+// index lifecycle management API.
+func (p *IndexLifecyclePolicy) Body() map[string]interface{} {
+	return map[string]interface{}{
+		"policy": map[string]interface{}{
+			"phases": map[string]interface{}{
+				"hot": map[string]interface{}{
+					"min_age": "0ms",
+					"actions": map[string]interface{}{
+						"rollover": map[string]interface{}{
+							"max_age":  fmt.Sprintf("%dd", p.RolloverMaxAgeDays),
+							"max_size": fmt.Sprintf("%dgb", p.RolloverMaxSizeGB),
+						},
+						"set_priority": map[string]interface{}{"priority": 100},
+					},
+				},
+				"warm": map[string]interface{}{
+					"min_age": fmt.Sprintf("%dd", p.WarmPhaseAfterDays),
+					"actions": map[string]interface{}{
+						"readonly":     map[string]interface{}{},
+						"forcemerge":   map[string]interface{}{"max_num_segments": 1},
+						"set_priority": map[string]interface{}{"priority": 50},
+					},
+				},
+				"cold": map[string]interface{}{
+					"min_age": fmt.Sprintf("%dd", p.ColdPhaseAfterDays),
+					"actions": map[string]interface{}{
+						"set_priority": map[string]interface{}{"priority": 0},
+					},
+				},
+			},
+		},
+	}
+}
+
+// IndexSettings returns the settings attaching an index to the policy. The rollover alias is
+// only set on the indexes written through it, and not on the migrated daily indexes.
+func (p *IndexLifecyclePolicy) IndexSettings(withRolloverAlias bool) map[string]interface{} {
+	settings := map[string]interface{}{
+		"index.lifecycle.name": p.Name,
+	}
+	if withRolloverAlias {
+		settings["index.lifecycle.rollover_alias"] = p.RolloverAlias
+	}
+	return settings
+}
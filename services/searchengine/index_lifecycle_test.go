@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestNewIndexLifecyclePolicy(t *testing.T) {
+	settings := &model.ElasticsearchSettings{}
+	settings.SetDefaults()
+	settings.IndexPrefix = model.NewString("mm_")
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, NewIndexLifecyclePolicy(settings))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		settings.EnableIndexLifecycleManagement = model.NewBool(true)
+		settings.RolloverMaxAgeDays = model.NewInt(7)
+		settings.RolloverMaxSizeGB = model.NewInt(20)
+		settings.WarmPhaseAfterDays = model.NewInt(14)
+		settings.ColdPhaseAfterDays = model.NewInt(90)
+
+		policy := NewIndexLifecyclePolicy(settings)
+		require.NotNil(t, policy)
+		assert.Equal(t, "mm_posts_policy", policy.Name)
+		assert.Equal(t, "mm_posts", policy.RolloverAlias)
+		assert.Equal(t, "mm_posts-000001", policy.FirstIndex)
+		assert.Equal(t, "mm_posts_*", policy.LegacyIndexPattern)
+
+		body, err := json.Marshal(policy.Body())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"policy": {"phases": {
+			"hot": {"min_age": "0ms", "actions": {"rollover": {"max_age": "7d", "max_size": "20gb"}, "set_priority": {"priority": 100}}},
+			"warm": {"min_age": "14d", "actions": {"readonly": {}, "forcemerge": {"max_num_segments": 1}, "set_priority": {"priority": 50}}},
+			"cold": {"min_age": "90d", "actions": {"set_priority": {"priority": 0}}}
+		}}}`, string(body))
+
+		assert.Equal(t, map[string]interface{}{
+			"index.lifecycle.name":           "mm_posts_policy",
+			"index.lifecycle.rollover_alias": "mm_posts",
+		}, policy.IndexSettings(true))
+		assert.Equal(t, map[string]interface{}{
+			"index.lifecycle.name": "mm_posts_policy",
+		}, policy.IndexSettings(false))
+	})
+}
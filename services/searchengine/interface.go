@@ -47,3 +47,24 @@ type SearchEngineInterface interface {
 	RefreshIndexes() *model.AppError
 	DataRetentionDeleteIndexes(cutoff time.Time) *model.AppError
 }
+
+// PluginIndexEngine is implemented by the engines able to hold the custom indexes of plugins.
+// Every index belongs to a plugin, and the same index name can be used by several plugins.
+type PluginIndexEngine interface {
+	CreatePluginIndex(pluginID, index string) *model.AppError
+	DeletePluginIndex(pluginID, index string) *model.AppError
+	IndexPluginDocument(pluginID, index string, doc *model.PluginSearchDocument) *model.AppError
+	DeletePluginDocument(pluginID, index, documentID string) *model.AppError
+	SearchPluginIndex(pluginID, index string, params *model.PluginSearchParams) (*model.PluginSearchResults, *model.AppError)
+}
+
+// IndexLifecycleEngine is implemented by the engines able to write the posts through a rollover
+// alias managed by an index lifecycle policy, instead of creating an index per day.
+type IndexLifecycleEngine interface {
+	// ApplyIndexLifecyclePolicy creates or updates the policy, and bootstraps its first index
+	// and rollover alias if they don't exist yet.
+	ApplyIndexLifecyclePolicy(policy *IndexLifecyclePolicy) *model.AppError
+	// MigrateIndexesToLifecyclePolicy attaches the daily post indexes created before the policy
+	// to it, so they age through its phases too.
+	MigrateIndexesToLifecyclePolicy(policy *IndexLifecyclePolicy) *model.AppError
+}
@@ -50,3 +50,24 @@ func (seb *Broker) GetActiveEngines() []SearchEngineInterface {
 	}
 	return engines
 }
+
+// GetPluginIndexEngine returns the active engine holding the indexes of plugins, Elasticsearch
+// first, or nil if no active engine supports them.
+func (seb *Broker) GetPluginIndexEngine() PluginIndexEngine {
+	for _, engine := range seb.GetActiveEngines() {
+		if pluginIndexEngine, ok := engine.(PluginIndexEngine); ok {
+			return pluginIndexEngine
+		}
+	}
+	return nil
+}
+
+// GetIndexLifecycleEngine returns the Elasticsearch engine if it is active and manages the
+// lifecycle of its indexes, or nil otherwise.
+func (seb *Broker) GetIndexLifecycleEngine() IndexLifecycleEngine {
+	if seb.ElasticsearchEngine == nil || !seb.ElasticsearchEngine.IsActive() {
+		return nil
+	}
+	indexLifecycleEngine, _ := seb.ElasticsearchEngine.(IndexLifecycleEngine)
+	return indexLifecycleEngine
+}
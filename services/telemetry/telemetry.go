@@ -9,8 +9,10 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	rudder "github.com/rudderlabs/analytics-go"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -66,6 +68,12 @@ const (
 	TrackConfigImageProxy        = "config_image_proxy"
 	TrackConfigBleve             = "config_bleve"
 	TrackConfigExport            = "config_export"
+	TrackConfigNotification      = "config_notification"
+	TrackConfigContentPolicy     = "config_content_policy"
+	TrackConfigScim              = "config_scim"
+	TrackConfigTurn              = "config_turn"
+	TrackConfigChangeDataCapture = "config_change_data_capture"
+	TrackConfigEventBus          = "config_event_bus"
 	TrackFeatureFlags            = "config_feature_flags"
 	TrackPermissionsGeneral      = "permissions_general"
 	TrackPermissionsSystemScheme = "permissions_system_scheme"
@@ -98,8 +106,15 @@ type TelemetryService struct {
 	searchEngine               *searchengine.Broker
 	log                        *mlog.Logger
 	rudderClient               rudder.Client
+	rudderEndpoint             string
 	TelemetryID                string
 	timestampLastTelemetrySent time.Time
+
+	// The events of the clients are forwarded to the self-hosted sink by any node, so that its
+	// key is never exposed to the clients.
+	sinkMut    sync.Mutex
+	sinkClient rudder.Client
+	sinkConfig RudderConfig
 }
 
 type RudderConfig struct {
@@ -135,7 +150,10 @@ func (ts *TelemetryService) ensureTelemetryID() {
 }
 
 func (ts *TelemetryService) getRudderConfig() RudderConfig {
-	if !strings.Contains(RudderKey, "placeholder") && !strings.Contains(RudderDataplaneURL, "placeholder") {
+	// A self-hosted sink receives the same payloads in place of the Mattermost dataplane.
+	if sinkURL := *ts.srv.Config().LogSettings.DiagnosticsSinkURL; sinkURL != "" {
+		return RudderConfig{*ts.srv.Config().LogSettings.DiagnosticsSinkKey, sinkURL}
+	} else if !strings.Contains(RudderKey, "placeholder") && !strings.Contains(RudderDataplaneURL, "placeholder") {
 		return RudderConfig{RudderKey, RudderDataplaneURL}
 	} else if os.Getenv("RudderKey") != "" && os.Getenv("RudderDataplaneURL") != "" {
 		return RudderConfig{os.Getenv("RudderKey"), os.Getenv("RudderDataplaneURL")}
@@ -150,7 +168,8 @@ func (ts *TelemetryService) telemetryEnabled() bool {
 
 func (ts *TelemetryService) sendDailyTelemetry(override bool) {
 	config := ts.getRudderConfig()
-	if ts.telemetryEnabled() && ((config.DataplaneURL != "" && config.RudderKey != "") || override) {
+	sinkConfigured := *ts.srv.Config().LogSettings.DiagnosticsSinkURL != ""
+	if ts.telemetryEnabled() && ((config.DataplaneURL != "" && config.RudderKey != "") || sinkConfigured || override) {
 		ts.initRudder(config.DataplaneURL, config.RudderKey)
 		ts.trackActivity()
 		ts.trackConfig()
@@ -181,6 +200,45 @@ func (ts *TelemetryService) SendTelemetry(event string, properties map[string]in
 	}
 }
 
+// TrackClientEvent forwards an event tracked by the client of a user to the self-hosted sink,
+// which must be configured.
+func (ts *TelemetryService) TrackClientEvent(userID string, event *model.TelemetryEvent) error {
+	settings := ts.srv.Config().LogSettings
+	if *settings.DiagnosticsSinkURL == "" {
+		return errors.New("the diagnostics sink is not configured")
+	}
+	config := RudderConfig{*settings.DiagnosticsSinkKey, *settings.DiagnosticsSinkURL}
+
+	ts.sinkMut.Lock()
+	defer ts.sinkMut.Unlock()
+
+	if ts.sinkClient != nil && ts.sinkConfig != config {
+		if err := ts.sinkClient.Close(); err != nil {
+			mlog.Warn("Failed to close Rudder instance of the diagnostics sink", mlog.Err(err))
+		}
+		ts.sinkClient = nil
+	}
+
+	if ts.sinkClient == nil {
+		client, err := rudder.NewWithConfig(config.RudderKey, config.DataplaneURL, rudder.Config{
+			Endpoint: config.DataplaneURL,
+			Logger:   rudder.StdLogger(ts.log.With(mlog.String("source", "rudder_sink")).StdLogger(mlog.LvlDebug)),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create Rudder instance of the diagnostics sink")
+		}
+		ts.sinkClient = client
+		ts.sinkConfig = config
+	}
+
+	return ts.sinkClient.Enqueue(rudder.Track{
+		Event:      event.Event,
+		UserId:     userID,
+		Properties: event.Properties,
+		Context:    &rudder.Context{Traits: map[string]interface{}{"server_id": ts.TelemetryID}},
+	})
+}
+
 func isDefaultArray(setting, defaultValue []string) bool {
 	if len(setting) != len(defaultValue) {
 		return false
@@ -367,6 +425,8 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_user_access_tokens":                               *cfg.ServiceSettings.EnableUserAccessTokens,
 		"enable_custom_emoji":                                     *cfg.ServiceSettings.EnableCustomEmoji,
 		"enable_emoji_picker":                                     *cfg.ServiceSettings.EnableEmojiPicker,
+		"max_custom_emoji_per_team":                               *cfg.ServiceSettings.MaxCustomEmojiPerTeam,
+		"max_pinned_posts_per_channel":                            *cfg.ServiceSettings.MaxPinnedPostsPerChannel,
 		"enable_gif_picker":                                       *cfg.ServiceSettings.EnableGifPicker,
 		"gfycat_api_key":                                          isDefault(*cfg.ServiceSettings.GfycatAPIKey, model.ServiceSettingsDefaultGfycatAPIKey),
 		"gfycat_api_secret":                                       isDefault(*cfg.ServiceSettings.GfycatAPISecret, model.ServiceSettingsDefaultGfycatAPISecret),
@@ -430,6 +490,7 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_inline_latex":                                     *cfg.ServiceSettings.EnableInlineLatex,
 		"enable_opentracing":                                      *cfg.ServiceSettings.EnableOpenTracing,
 		"enable_local_mode":                                       *cfg.ServiceSettings.EnableLocalMode,
+		"local_mode_socket_permissions":                           *cfg.ServiceSettings.LocalModeSocketPermissions,
 		"managed_resource_paths":                                  isDefault(*cfg.ServiceSettings.ManagedResourcePaths, ""),
 		"thread_auto_follow":                                      *cfg.ServiceSettings.ThreadAutoFollow,
 		"enable_link_previews":                                    *cfg.ServiceSettings.EnableLinkPreviews,
@@ -437,6 +498,28 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_file_search":                                      *cfg.ServiceSettings.EnableFileSearch,
 		"restrict_link_previews":                                  isDefault(*cfg.ServiceSettings.RestrictLinkPreviews, ""),
 		"enable_custom_groups":                                    *cfg.ServiceSettings.EnableCustomGroups,
+		"enable_resource_guard":                                   *cfg.ServiceSettings.EnableResourceGuard,
+		"resource_guard_memory_limit_mb":                          *cfg.ServiceSettings.ResourceGuardMemoryLimitMB,
+		"resource_guard_shed_threshold_percent":                   *cfg.ServiceSettings.ResourceGuardShedThresholdPercent,
+		"resource_guard_sample_rate":                              *cfg.ServiceSettings.ResourceGuardSampleRate,
+		"resource_guard_shed_handlers":                            isDefault(*cfg.ServiceSettings.ResourceGuardShedHandlers, model.ServiceSettingsDefaultResourceGuardShedHandlers),
+		"enable_http3":                                            *cfg.ServiceSettings.EnableHTTP3,
+		"http3_listen_address":                                    isDefault(*cfg.ServiceSettings.HTTP3ListenAddress, ""),
+		"http3_alt_svc_max_age":                                   *cfg.ServiceSettings.HTTP3AltSvcMaxAge,
+		"startup_dependency_mode":                                 *cfg.ServiceSettings.StartupDependencyMode,
+		"startup_dependency_timeout_seconds":                      *cfg.ServiceSettings.StartupDependencyTimeoutSeconds,
+		"degraded_mode_recheck_seconds":                           *cfg.ServiceSettings.DegradedModeRecheckSeconds,
+		"readiness_required_dependencies":                         *cfg.ServiceSettings.ReadinessRequiredDependencies,
+		"geoip_database_path":                                     isDefault(*cfg.ServiceSettings.GeoIPDatabasePath, ""),
+		"enable_login_notifications":                              *cfg.ServiceSettings.EnableLoginNotifications,
+		"login_notifications_direct_message":                      *cfg.ServiceSettings.LoginNotificationsDirectMessage,
+		"websocket_hub_shards":                                    *cfg.ServiceSettings.WebsocketHubShards,
+		"websocket_hub_workers":                                   *cfg.ServiceSettings.WebsocketHubWorkers,
+		"typing_messages_max_channel_members":                     *cfg.ServiceSettings.TypingMessagesMaxChannelMembers,
+		"status_batch_interval_milliseconds":                      *cfg.ServiceSettings.StatusBatchIntervalMilliseconds,
+		"use_maintained_channel_member_counts":                    *cfg.ServiceSettings.UseMaintainedChannelMemberCounts,
+		"enable_reminders":                                        *cfg.ServiceSettings.EnableReminders,
+		"enable_channel_events":                                   *cfg.ServiceSettings.EnableChannelEvents,
 	})
 
 	ts.SendTelemetry(TrackConfigTeam, map[string]interface{}{
@@ -460,6 +543,7 @@ func (ts *TelemetryService) trackConfig() {
 		"experimental_enable_automatic_replies":   *cfg.TeamSettings.ExperimentalEnableAutomaticReplies,
 		"experimental_primary_team":               isDefault(*cfg.TeamSettings.ExperimentalPrimaryTeam, ""),
 		"experimental_default_channels":           len(cfg.TeamSettings.ExperimentalDefaultChannels),
+		"enable_direct_message_requests":          *cfg.TeamSettings.EnableDirectMessageRequests,
 	})
 
 	ts.SendTelemetry(TrackConfigClientReq, map[string]interface{}{
@@ -481,6 +565,11 @@ func (ts *TelemetryService) trackConfig() {
 		"query_timeout":                        *cfg.SqlSettings.QueryTimeout,
 		"disable_database_search":              *cfg.SqlSettings.DisableDatabaseSearch,
 		"migrations_statement_timeout_seconds": *cfg.SqlSettings.MigrationsStatementTimeoutSeconds,
+		"enable_table_partitioning":            *cfg.SqlSettings.EnableTablePartitioning,
+		"partitions_ahead_months":              *cfg.SqlSettings.PartitionsAheadMonths,
+		"partition_retention_months":           *cfg.SqlSettings.PartitionRetentionMonths,
+		"enable_post_archiving":                *cfg.SqlSettings.EnablePostArchiving,
+		"post_archive_after_months":            *cfg.SqlSettings.PostArchiveAfterMonths,
 	})
 
 	ts.SendTelemetry(TrackConfigLog, map[string]interface{}{
@@ -496,13 +585,14 @@ func (ts *TelemetryService) trackConfig() {
 	})
 
 	ts.SendTelemetry(TrackConfigAudit, map[string]interface{}{
-		"file_enabled":            *cfg.ExperimentalAuditSettings.FileEnabled,
-		"file_max_size_mb":        *cfg.ExperimentalAuditSettings.FileMaxSizeMB,
-		"file_max_age_days":       *cfg.ExperimentalAuditSettings.FileMaxAgeDays,
-		"file_max_backups":        *cfg.ExperimentalAuditSettings.FileMaxBackups,
-		"file_compress":           *cfg.ExperimentalAuditSettings.FileCompress,
-		"file_max_queue_size":     *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
-		"advanced_logging_config": *cfg.ExperimentalAuditSettings.AdvancedLoggingConfig != "",
+		"file_enabled":             *cfg.ExperimentalAuditSettings.FileEnabled,
+		"file_max_size_mb":         *cfg.ExperimentalAuditSettings.FileMaxSizeMB,
+		"file_max_age_days":        *cfg.ExperimentalAuditSettings.FileMaxAgeDays,
+		"file_max_backups":         *cfg.ExperimentalAuditSettings.FileMaxBackups,
+		"file_compress":            *cfg.ExperimentalAuditSettings.FileCompress,
+		"file_max_queue_size":      *cfg.ExperimentalAuditSettings.FileMaxQueueSize,
+		"sensitive_route_patterns": len(cfg.ExperimentalAuditSettings.SensitiveRoutePatterns),
+		"advanced_logging_config":  *cfg.ExperimentalAuditSettings.AdvancedLoggingConfig != "",
 	})
 
 	ts.SendTelemetry(TrackConfigNotificationLog, map[string]interface{}{
@@ -525,21 +615,29 @@ func (ts *TelemetryService) trackConfig() {
 	})
 
 	ts.SendTelemetry(TrackConfigFile, map[string]interface{}{
-		"enable_public_links":     cfg.FileSettings.EnablePublicLink,
-		"driver_name":             *cfg.FileSettings.DriverName,
-		"isdefault_directory":     isDefault(*cfg.FileSettings.Directory, model.FileSettingsDefaultDirectory),
-		"isabsolute_directory":    filepath.IsAbs(*cfg.FileSettings.Directory),
-		"extract_content":         *cfg.FileSettings.ExtractContent,
-		"archive_recursion":       *cfg.FileSettings.ArchiveRecursion,
-		"amazon_s3_ssl":           *cfg.FileSettings.AmazonS3SSL,
-		"amazon_s3_sse":           *cfg.FileSettings.AmazonS3SSE,
-		"amazon_s3_signv2":        *cfg.FileSettings.AmazonS3SignV2,
-		"amazon_s3_trace":         *cfg.FileSettings.AmazonS3Trace,
-		"max_file_size":           *cfg.FileSettings.MaxFileSize,
-		"max_image_resolution":    *cfg.FileSettings.MaxImageResolution,
-		"enable_file_attachments": *cfg.FileSettings.EnableFileAttachments,
-		"enable_mobile_upload":    *cfg.FileSettings.EnableMobileUpload,
-		"enable_mobile_download":  *cfg.FileSettings.EnableMobileDownload,
+		"enable_public_links":                cfg.FileSettings.EnablePublicLink,
+		"driver_name":                        *cfg.FileSettings.DriverName,
+		"isdefault_directory":                isDefault(*cfg.FileSettings.Directory, model.FileSettingsDefaultDirectory),
+		"isabsolute_directory":               filepath.IsAbs(*cfg.FileSettings.Directory),
+		"extract_content":                    *cfg.FileSettings.ExtractContent,
+		"archive_recursion":                  *cfg.FileSettings.ArchiveRecursion,
+		"extract_content_tika":               *cfg.FileSettings.ExtractContentTikaURL != "",
+		"extract_content_max_size":           *cfg.FileSettings.ExtractContentMaxSize,
+		"transcribe_voice_messages":          *cfg.FileSettings.TranscribeVoiceMessages,
+		"generate_image_alt_text":            *cfg.FileSettings.GenerateImageAltText,
+		"extract_pdf_content":                *cfg.FileSettings.ExtractPDFContent,
+		"extract_document_content":           *cfg.FileSettings.ExtractDocumentContent,
+		"amazon_s3_ssl":                      *cfg.FileSettings.AmazonS3SSL,
+		"amazon_s3_sse":                      *cfg.FileSettings.AmazonS3SSE,
+		"amazon_s3_signv2":                   *cfg.FileSettings.AmazonS3SignV2,
+		"amazon_s3_trace":                    *cfg.FileSettings.AmazonS3Trace,
+		"max_file_size":                      *cfg.FileSettings.MaxFileSize,
+		"max_image_resolution":               *cfg.FileSettings.MaxImageResolution,
+		"enable_file_attachments":            *cfg.FileSettings.EnableFileAttachments,
+		"enable_mobile_upload":               *cfg.FileSettings.EnableMobileUpload,
+		"enable_mobile_download":             *cfg.FileSettings.EnableMobileDownload,
+		"enable_orphaned_files_cleanup":      *cfg.FileSettings.EnableOrphanedFilesCleanup,
+		"orphaned_files_safety_window_hours": *cfg.FileSettings.OrphanedFilesSafetyWindowHours,
 	})
 
 	ts.SendTelemetry(TrackConfigEmail, map[string]interface{}{
@@ -592,14 +690,22 @@ func (ts *TelemetryService) trackConfig() {
 		"allowed_themes":          len(cfg.ThemeSettings.AllowedThemes),
 	})
 
+	openIdConnectProviders := 0
+	for _, provider := range cfg.OpenIdConnectSettings.Providers {
+		if provider.Enable {
+			openIdConnectProviders++
+		}
+	}
+
 	ts.SendTelemetry(TrackConfigOAuth, map[string]interface{}{
-		"enable_gitlab":    cfg.GitLabSettings.Enable,
-		"openid_gitlab":    *cfg.GitLabSettings.Enable && strings.Contains(*cfg.GitLabSettings.Scope, model.ServiceOpenid),
-		"enable_google":    cfg.GoogleSettings.Enable,
-		"openid_google":    *cfg.GoogleSettings.Enable && strings.Contains(*cfg.GoogleSettings.Scope, model.ServiceOpenid),
-		"enable_office365": cfg.Office365Settings.Enable,
-		"openid_office365": *cfg.Office365Settings.Enable && strings.Contains(*cfg.Office365Settings.Scope, model.ServiceOpenid),
-		"enable_openid":    cfg.OpenIdSettings.Enable,
+		"enable_gitlab":            cfg.GitLabSettings.Enable,
+		"openid_gitlab":            *cfg.GitLabSettings.Enable && strings.Contains(*cfg.GitLabSettings.Scope, model.ServiceOpenid),
+		"enable_google":            cfg.GoogleSettings.Enable,
+		"openid_google":            *cfg.GoogleSettings.Enable && strings.Contains(*cfg.GoogleSettings.Scope, model.ServiceOpenid),
+		"enable_office365":         cfg.Office365Settings.Enable,
+		"openid_office365":         *cfg.Office365Settings.Enable && strings.Contains(*cfg.Office365Settings.Scope, model.ServiceOpenid),
+		"enable_openid":            cfg.OpenIdSettings.Enable,
+		"openid_connect_providers": openIdConnectProviders,
 	})
 
 	ts.SendTelemetry(TrackConfigSupport, map[string]interface{}{
@@ -651,9 +757,10 @@ func (ts *TelemetryService) trackConfig() {
 	})
 
 	ts.SendTelemetry(TrackConfigLocalization, map[string]interface{}{
-		"default_server_locale": *cfg.LocalizationSettings.DefaultServerLocale,
-		"default_client_locale": *cfg.LocalizationSettings.DefaultClientLocale,
-		"available_locales":     *cfg.LocalizationSettings.AvailableLocales,
+		"default_server_locale":           *cfg.LocalizationSettings.DefaultServerLocale,
+		"default_client_locale":           *cfg.LocalizationSettings.DefaultClientLocale,
+		"available_locales":               *cfg.LocalizationSettings.AvailableLocales,
+		"notification_template_overrides": len(cfg.LocalizationSettings.NotificationTemplateOverrides),
 	})
 
 	ts.SendTelemetry(TrackConfigSAML, map[string]interface{}{
@@ -719,6 +826,7 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_shared_channels":             *cfg.ExperimentalSettings.EnableSharedChannels,
 		"enable_remote_cluster_service":      *cfg.ExperimentalSettings.EnableRemoteClusterService && cfg.FeatureFlags.EnableRemoteClusterService,
 		"enable_app_bar":                     *cfg.ExperimentalSettings.EnableAppBar,
+		"remote_cluster_enable_mutual_tls":   *cfg.ExperimentalSettings.RemoteClusterEnableMutualTLS,
 	})
 
 	ts.SendTelemetry(TrackConfigAnalytics, map[string]interface{}{
@@ -735,40 +843,48 @@ func (ts *TelemetryService) trackConfig() {
 	})
 
 	ts.SendTelemetry(TrackConfigElasticsearch, map[string]interface{}{
-		"isdefault_connection_url": isDefault(*cfg.ElasticsearchSettings.ConnectionURL, model.ElasticsearchSettingsDefaultConnectionURL),
-		"isdefault_username":       isDefault(*cfg.ElasticsearchSettings.Username, model.ElasticsearchSettingsDefaultUsername),
-		"isdefault_password":       isDefault(*cfg.ElasticsearchSettings.Password, model.ElasticsearchSettingsDefaultPassword),
-		"enable_indexing":          *cfg.ElasticsearchSettings.EnableIndexing,
-		"enable_searching":         *cfg.ElasticsearchSettings.EnableSearching,
-		"enable_autocomplete":      *cfg.ElasticsearchSettings.EnableAutocomplete,
-		"sniff":                    *cfg.ElasticsearchSettings.Sniff,
-		"post_index_replicas":      *cfg.ElasticsearchSettings.PostIndexReplicas,
-		"post_index_shards":        *cfg.ElasticsearchSettings.PostIndexShards,
-		"channel_index_replicas":   *cfg.ElasticsearchSettings.ChannelIndexReplicas,
-		"channel_index_shards":     *cfg.ElasticsearchSettings.ChannelIndexShards,
-		"user_index_replicas":      *cfg.ElasticsearchSettings.UserIndexReplicas,
-		"user_index_shards":        *cfg.ElasticsearchSettings.UserIndexShards,
-		"isdefault_index_prefix":   isDefault(*cfg.ElasticsearchSettings.IndexPrefix, model.ElasticsearchSettingsDefaultIndexPrefix),
-		"live_indexing_batch_size": *cfg.ElasticsearchSettings.LiveIndexingBatchSize,
-		"bulk_indexing_batch_size": *cfg.ElasticsearchSettings.BatchSize,
-		"request_timeout_seconds":  *cfg.ElasticsearchSettings.RequestTimeoutSeconds,
-		"skip_tls_verification":    *cfg.ElasticsearchSettings.SkipTLSVerification,
-		"trace":                    *cfg.ElasticsearchSettings.Trace,
+		"isdefault_connection_url":          isDefault(*cfg.ElasticsearchSettings.ConnectionURL, model.ElasticsearchSettingsDefaultConnectionURL),
+		"isdefault_username":                isDefault(*cfg.ElasticsearchSettings.Username, model.ElasticsearchSettingsDefaultUsername),
+		"isdefault_password":                isDefault(*cfg.ElasticsearchSettings.Password, model.ElasticsearchSettingsDefaultPassword),
+		"enable_indexing":                   *cfg.ElasticsearchSettings.EnableIndexing,
+		"enable_searching":                  *cfg.ElasticsearchSettings.EnableSearching,
+		"enable_autocomplete":               *cfg.ElasticsearchSettings.EnableAutocomplete,
+		"sniff":                             *cfg.ElasticsearchSettings.Sniff,
+		"post_index_replicas":               *cfg.ElasticsearchSettings.PostIndexReplicas,
+		"post_index_shards":                 *cfg.ElasticsearchSettings.PostIndexShards,
+		"channel_index_replicas":            *cfg.ElasticsearchSettings.ChannelIndexReplicas,
+		"channel_index_shards":              *cfg.ElasticsearchSettings.ChannelIndexShards,
+		"user_index_replicas":               *cfg.ElasticsearchSettings.UserIndexReplicas,
+		"user_index_shards":                 *cfg.ElasticsearchSettings.UserIndexShards,
+		"isdefault_index_prefix":            isDefault(*cfg.ElasticsearchSettings.IndexPrefix, model.ElasticsearchSettingsDefaultIndexPrefix),
+		"live_indexing_batch_size":          *cfg.ElasticsearchSettings.LiveIndexingBatchSize,
+		"bulk_indexing_batch_size":          *cfg.ElasticsearchSettings.BatchSize,
+		"request_timeout_seconds":           *cfg.ElasticsearchSettings.RequestTimeoutSeconds,
+		"skip_tls_verification":             *cfg.ElasticsearchSettings.SkipTLSVerification,
+		"trace":                             *cfg.ElasticsearchSettings.Trace,
+		"enable_index_lifecycle_management": *cfg.ElasticsearchSettings.EnableIndexLifecycleManagement,
+		"rollover_max_age_days":             *cfg.ElasticsearchSettings.RolloverMaxAgeDays,
+		"rollover_max_size_gb":              *cfg.ElasticsearchSettings.RolloverMaxSizeGB,
+		"warm_phase_after_days":             *cfg.ElasticsearchSettings.WarmPhaseAfterDays,
+		"cold_phase_after_days":             *cfg.ElasticsearchSettings.ColdPhaseAfterDays,
 	})
 
 	ts.trackPluginConfig(cfg, model.PluginSettingsDefaultMarketplaceURL)
 
 	ts.SendTelemetry(TrackConfigDataRetention, map[string]interface{}{
-		"enable_message_deletion":       *cfg.DataRetentionSettings.EnableMessageDeletion,
-		"enable_file_deletion":          *cfg.DataRetentionSettings.EnableFileDeletion,
-		"enable_boards_deletion":        *cfg.DataRetentionSettings.EnableBoardsDeletion,
-		"message_retention_days":        *cfg.DataRetentionSettings.MessageRetentionDays,
-		"file_retention_days":           *cfg.DataRetentionSettings.FileRetentionDays,
-		"boards_retention_days":         *cfg.DataRetentionSettings.BoardsRetentionDays,
-		"deletion_job_start_time":       *cfg.DataRetentionSettings.DeletionJobStartTime,
-		"batch_size":                    *cfg.DataRetentionSettings.BatchSize,
-		"cleanup_jobs_threshold_days":   *cfg.JobSettings.CleanupJobsThresholdDays,
-		"cleanup_config_threshold_days": *cfg.JobSettings.CleanupConfigThresholdDays,
+		"enable_message_deletion":           *cfg.DataRetentionSettings.EnableMessageDeletion,
+		"enable_file_deletion":              *cfg.DataRetentionSettings.EnableFileDeletion,
+		"enable_boards_deletion":            *cfg.DataRetentionSettings.EnableBoardsDeletion,
+		"message_retention_days":            *cfg.DataRetentionSettings.MessageRetentionDays,
+		"file_retention_days":               *cfg.DataRetentionSettings.FileRetentionDays,
+		"boards_retention_days":             *cfg.DataRetentionSettings.BoardsRetentionDays,
+		"deletion_job_start_time":           *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"batch_size":                        *cfg.DataRetentionSettings.BatchSize,
+		"retention_labels":                  len(cfg.DataRetentionSettings.RetentionLabels),
+		"enable_policy_file_deletion":       *cfg.DataRetentionSettings.EnablePolicyFileDeletion,
+		"cleanup_jobs_threshold_days":       *cfg.JobSettings.CleanupJobsThresholdDays,
+		"cleanup_config_threshold_days":     *cfg.JobSettings.CleanupConfigThresholdDays,
+		"purge_soft_deleted_threshold_days": *cfg.JobSettings.PurgeSoftDeletedThresholdDays,
 	})
 
 	ts.SendTelemetry(TrackConfigMessageExport, map[string]interface{}{
@@ -815,6 +931,44 @@ func (ts *TelemetryService) trackConfig() {
 		"retention_days": *cfg.ExportSettings.RetentionDays,
 	})
 
+	ts.SendTelemetry(TrackConfigNotification, map[string]interface{}{
+		"mention_aggregation_window_seconds": *cfg.NotificationSettings.MentionAggregationWindowSeconds,
+	})
+
+	ts.SendTelemetry(TrackConfigContentPolicy, map[string]interface{}{
+		"enable":                       *cfg.ContentPolicySettings.Enable,
+		"rules":                        len(cfg.ContentPolicySettings.Rules),
+		"exempt_roles":                 len(cfg.ContentPolicySettings.ExemptRoles),
+		"exempt_channels":              len(cfg.ContentPolicySettings.ExemptChannelIds),
+		"enable_secret_scanning":       *cfg.ContentPolicySettings.EnableSecretScanning,
+		"secret_scanning_action":       *cfg.ContentPolicySettings.SecretScanningAction,
+		"secret_scanning_team_actions": len(cfg.ContentPolicySettings.SecretScanningTeamActions),
+	})
+
+	ts.SendTelemetry(TrackConfigScim, map[string]interface{}{
+		"enable":       *cfg.ScimSettings.Enable,
+		"auth_service": *cfg.ScimSettings.AuthService,
+	})
+
+	ts.SendTelemetry(TrackConfigTurn, map[string]interface{}{
+		"enable":                 *cfg.TurnSettings.Enable,
+		"uris":                   len(cfg.TurnSettings.URIs),
+		"credential_ttl_seconds": *cfg.TurnSettings.CredentialTTLSeconds,
+	})
+
+	ts.SendTelemetry(TrackConfigChangeDataCapture, map[string]interface{}{
+		"enable":          *cfg.ChangeDataCaptureSettings.Enable,
+		"publisher":       *cfg.ChangeDataCaptureSettings.Publisher,
+		"batch_size":      *cfg.ChangeDataCaptureSettings.BatchSize,
+		"retention_hours": *cfg.ChangeDataCaptureSettings.RetentionHours,
+	})
+
+	ts.SendTelemetry(TrackConfigEventBus, map[string]interface{}{
+		"enable":     *cfg.EventBusSettings.Enable,
+		"events":     len(cfg.EventBusSettings.Events),
+		"queue_size": *cfg.EventBusSettings.QueueSize,
+	})
+
 	// Convert feature flags to map[string]interface{} for sending
 	flags := cfg.FeatureFlags.ToMap()
 	interfaceFlags := make(map[string]interface{})
@@ -1227,12 +1381,20 @@ func (ts *TelemetryService) trackChannelModeration() {
 }
 
 func (ts *TelemetryService) initRudder(endpoint string, rudderKey string) {
+	// The endpoint changes when the self-hosted sink is configured or removed.
+	if ts.rudderClient != nil && endpoint != "" && ts.rudderEndpoint != endpoint {
+		if err := ts.rudderClient.Close(); err != nil {
+			mlog.Warn("Failed to close Rudder instance", mlog.Err(err))
+		}
+		ts.rudderClient = nil
+	}
+
 	if ts.rudderClient == nil {
 		config := rudder.Config{}
 		config.Logger = rudder.StdLogger(ts.log.With(mlog.String("source", "rudder")).StdLogger(mlog.LvlDebug))
 		config.Endpoint = endpoint
 		// For testing
-		if endpoint != RudderDataplaneURL {
+		if endpoint != RudderDataplaneURL && endpoint != *ts.srv.Config().LogSettings.DiagnosticsSinkURL {
 			config.Verbose = true
 			config.BatchSize = 1
 		}
@@ -1246,6 +1408,7 @@ func (ts *TelemetryService) initRudder(endpoint string, rudderKey string) {
 		})
 
 		ts.rudderClient = client
+		ts.rudderEndpoint = endpoint
 	}
 }
 
@@ -1278,8 +1441,17 @@ func (ts *TelemetryService) doTelemetry() {
 	}
 }
 
-// Shutdown closes the telemetry client.
+// Shutdown closes the telemetry clients.
 func (ts *TelemetryService) Shutdown() error {
+	ts.sinkMut.Lock()
+	if ts.sinkClient != nil {
+		if err := ts.sinkClient.Close(); err != nil {
+			mlog.Warn("Failed to close Rudder instance of the diagnostics sink", mlog.Err(err))
+		}
+		ts.sinkClient = nil
+	}
+	ts.sinkMut.Unlock()
+
 	if ts.rudderClient != nil {
 		return ts.rudderClient.Close()
 	}
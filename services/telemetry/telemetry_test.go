@@ -624,6 +624,20 @@ func TestRudderTelemetry(t *testing.T) {
 		assert.Equal(t, "arudderstackplace", config.DataplaneURL)
 		assert.Equal(t, "abc123", config.RudderKey)
 	})
+
+	t.Run("RudderConfigUsesDiagnosticsSink", func(t *testing.T) {
+		*cfg.LogSettings.DiagnosticsSinkURL = "https://analytics.example.com"
+		*cfg.LogSettings.DiagnosticsSinkKey = "sinkkey"
+		defer func() {
+			*cfg.LogSettings.DiagnosticsSinkURL = ""
+			*cfg.LogSettings.DiagnosticsSinkKey = ""
+		}()
+
+		config := telemetryService.getRudderConfig()
+
+		assert.Equal(t, "https://analytics.example.com", config.DataplaneURL)
+		assert.Equal(t, "sinkkey", config.RudderKey)
+	})
 }
 
 func TestIsDefaultArray(t *testing.T) {
@@ -631,3 +645,58 @@ func TestIsDefaultArray(t *testing.T) {
 	assert.False(t, isDefaultArray([]string{"one", "two"}, []string{"one", "two", "three"}))
 	assert.False(t, isDefaultArray([]string{"one", "two"}, []string{"one", "three"}))
 }
+
+func TestTrackClientEvent(t *testing.T) {
+	type payload struct {
+		Batch []struct {
+			UserId     string
+			Event      string
+			Properties map[string]interface{}
+			Context    struct {
+				Traits map[string]interface{}
+			}
+		}
+	}
+
+	data := make(chan payload, 10)
+	keys := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _, _ := r.BasicAuth()
+		var p payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		keys <- key
+		data <- p
+	}))
+	defer server.Close()
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	serverIfaceMock := &mocks.ServerIface{}
+	serverIfaceMock.On("Config").Return(cfg)
+	logger, _ := mlog.NewLogger()
+	defer logger.Shutdown()
+
+	telemetryService := &TelemetryService{srv: serverIfaceMock, log: logger, TelemetryID: "test-telemetry-id"}
+	event := &model.TelemetryEvent{Event: "click", Properties: map[string]interface{}{"button": "save"}}
+
+	require.Error(t, telemetryService.TrackClientEvent("user1", event), "the sink is not configured")
+
+	*cfg.LogSettings.DiagnosticsSinkURL = server.URL
+	*cfg.LogSettings.DiagnosticsSinkKey = "sinkkey"
+
+	require.NoError(t, telemetryService.TrackClientEvent("user1", event))
+	// Closing the client flushes the events.
+	require.NoError(t, telemetryService.Shutdown())
+
+	select {
+	case p := <-data:
+		assert.Equal(t, "sinkkey", <-keys)
+		require.Len(t, p.Batch, 1)
+		assert.Equal(t, "user1", p.Batch[0].UserId)
+		assert.Equal(t, "click", p.Batch[0].Event)
+		assert.Equal(t, map[string]interface{}{"button": "save"}, p.Batch[0].Properties)
+		assert.Equal(t, "test-telemetry-id", p.Batch[0].Context.Traits["server_id"])
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "Did not receive the event")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package waveform computes the waveform previews shown for voice messages.
+package waveform
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MaxPeak is the value of the peak of a bucket at full scale.
+const MaxPeak = 100
+
+// ErrUnsupportedFormat is returned for audio that can't be decoded. Only uncompressed PCM WAV
+// files are supported.
+var ErrUnsupportedFormat = errors.New("unsupported audio format")
+
+// Waveform is the preview of an audio file: the peak amplitude of each of its buckets, from 0 to
+// MaxPeak, and its duration.
+type Waveform struct {
+	Peaks    []int
+	Duration int64 // in milliseconds
+}
+
+type wavFormat struct {
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+}
+
+const wavFormatPCM = 1
+
+// Generate reads an audio file and splits its samples in the given number of buckets, keeping the
+// peak amplitude of each. Audio shorter than the number of buckets has one bucket per frame.
+func Generate(r io.Reader, buckets int) (*Waveform, error) {
+	if buckets <= 0 {
+		return nil, fmt.Errorf("invalid number of buckets: %d", buckets)
+	}
+
+	br := bufio.NewReader(r)
+
+	var header [12]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	var format *wavFormat
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(br, chunk[:]); err != nil {
+			return nil, fmt.Errorf("no data chunk: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, ErrUnsupportedFormat
+			}
+			format = &wavFormat{}
+			if err := binary.Read(br, binary.LittleEndian, format); err != nil {
+				return nil, fmt.Errorf("failed to read the format chunk: %w", err)
+			}
+			if err := skip(br, size-16+size%2); err != nil {
+				return nil, err
+			}
+		case "data":
+			if format == nil {
+				return nil, ErrUnsupportedFormat
+			}
+			return readPeaks(io.LimitReader(br, size), format, size, buckets)
+		default:
+			// Chunks are padded to an even size.
+			if err := skip(br, size+size%2); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func skip(r io.Reader, n int64) error {
+	if _, err := io.CopyN(io.Discard, r, n); err != nil {
+		return fmt.Errorf("failed to skip chunk: %w", err)
+	}
+	return nil
+}
+
+func readPeaks(r io.Reader, format *wavFormat, size int64, buckets int) (*Waveform, error) {
+	bytesPerSample := int(format.BitsPerSample) / 8
+	if format.AudioFormat != wavFormatPCM || format.Channels == 0 || format.SampleRate == 0 ||
+		bytesPerSample < 1 || bytesPerSample > 4 || int(format.BlockAlign) != bytesPerSample*int(format.Channels) {
+		return nil, ErrUnsupportedFormat
+	}
+
+	frames := size / int64(format.BlockAlign)
+	framesPerBucket := (frames + int64(buckets) - 1) / int64(buckets)
+	if framesPerBucket == 0 {
+		framesPerBucket = 1
+	}
+	fullScale := int64(1) << (format.BitsPerSample - 1)
+
+	waveform := &Waveform{
+		Peaks:    make([]int, 0, buckets),
+		Duration: frames * 1000 / int64(format.SampleRate),
+	}
+
+	frame := make([]byte, format.BlockAlign)
+	var peak, inBucket int64
+	for i := int64(0); i < frames; i++ {
+		if _, err := io.ReadFull(r, frame); err != nil {
+			return nil, fmt.Errorf("failed to read samples: %w", err)
+		}
+
+		for c := 0; c < int(format.Channels); c++ {
+			amplitude := sampleAmplitude(frame[c*bytesPerSample:(c+1)*bytesPerSample], fullScale)
+			if amplitude > peak {
+				peak = amplitude
+			}
+		}
+
+		inBucket++
+		if inBucket == framesPerBucket || i == frames-1 {
+			waveform.Peaks = append(waveform.Peaks, int(peak*MaxPeak/fullScale))
+			peak, inBucket = 0, 0
+		}
+	}
+
+	return waveform, nil
+}
+
+// sampleAmplitude returns the absolute amplitude of a little endian sample. 8 bit samples are
+// unsigned, the others signed.
+func sampleAmplitude(sample []byte, fullScale int64) int64 {
+	var value int64
+	if len(sample) == 1 {
+		value = int64(sample[0]) - 128
+	} else {
+		var u uint32
+		for i := len(sample) - 1; i >= 0; i-- {
+			u = u<<8 | uint32(sample[i])
+		}
+		shift := 32 - 8*uint(len(sample))
+		value = int64(int32(u<<shift) >> shift)
+	}
+
+	if value < 0 {
+		value = -value
+	}
+	if value > fullScale {
+		value = fullScale
+	}
+	return value
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package waveform
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wav encodes 16 bit mono PCM samples as a WAV file.
+func wav(sampleRate uint32, samples []int16) []byte {
+	var data bytes.Buffer
+	binary.Write(&data, binary.LittleEndian, samples)
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(4+8+16+8+data.Len()))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, wavFormat{
+		AudioFormat:   wavFormatPCM,
+		Channels:      1,
+		SampleRate:    sampleRate,
+		ByteRate:      sampleRate * 2,
+		BlockAlign:    2,
+		BitsPerSample: 16,
+	})
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(data.Len()))
+	buf.Write(data.Bytes())
+	return buf.Bytes()
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("peaks of each bucket", func(t *testing.T) {
+		samples := []int16{0, 100, -16384, 0, 32767, -32768, 0, 0}
+		waveform, err := Generate(bytes.NewReader(wav(8, samples)), 4)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 50, 100, 0}, waveform.Peaks)
+		assert.Equal(t, int64(1000), waveform.Duration)
+	})
+
+	t.Run("fewer frames than buckets", func(t *testing.T) {
+		waveform, err := Generate(bytes.NewReader(wav(8000, []int16{16384, 0})), 64)
+		require.NoError(t, err)
+		assert.Equal(t, []int{50, 0}, waveform.Peaks)
+	})
+
+	t.Run("unsupported formats", func(t *testing.T) {
+		_, err := Generate(bytes.NewReader([]byte("ID3\x03\x00\x00\x00\x00\x00\x00 not a wav file")), 64)
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+		_, err = Generate(bytes.NewReader(nil), 64)
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
@@ -96,12 +96,22 @@ func getTranslationsBySystemLocale() (TranslateFunc, error) {
 
 // GetUserTranslations get the translation function for an specific locale
 func GetUserTranslations(locale string) TranslateFunc {
-	if _, ok := locales[locale]; !ok {
-		locale = defaultLocale
+	translations := tfuncWithFallback(GetUserLocale(locale))
+	return translations
+}
+
+// GetUserLocale returns the locale the translations of a user with the given locale are in: the
+// locale itself if it's supported, or else the default client locale, falling back to english.
+func GetUserLocale(locale string) string {
+	if _, ok := locales[locale]; ok {
+		return locale
 	}
 
-	translations := tfuncWithFallback(locale)
-	return translations
+	if _, ok := locales[defaultClientLocale]; ok {
+		return defaultClientLocale
+	}
+
+	return defaultLocale
 }
 
 // GetTranslationsAndLocaleFromRequest return the translation function and the
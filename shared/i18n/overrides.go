@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package i18n
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/mattermost/go-i18n/i18n/bundle"
+	"github.com/mattermost/go-i18n/i18n/language"
+	"github.com/mattermost/go-i18n/i18n/translation"
+)
+
+// TemplateOverride replaces the translation of a notification template in a locale. Translation
+// is keyed by CLDR plural category (zero, one, two, few, many or other), a translation with only
+// the other category being used regardless of the count.
+type TemplateOverride struct {
+	Locale        string
+	TranslationID string
+	Translation   map[string]string
+}
+
+var notificationOverrides atomic.Value // *bundle.Bundle
+
+// SetNotificationOverrides replaces the template overrides applied by GetNotificationTranslations.
+func SetNotificationOverrides(overrides []TemplateOverride) error {
+	b := bundle.New()
+	for _, override := range overrides {
+		langs := language.Parse(override.Locale)
+		if len(langs) == 0 {
+			return fmt.Errorf("unsupported locale %q for the override of %s", override.Locale, override.TranslationID)
+		}
+
+		var text interface{}
+		if other, ok := override.Translation[string(language.Other)]; ok && len(override.Translation) == 1 {
+			text = other
+		} else {
+			plural := make(map[string]interface{}, len(override.Translation))
+			for category, t := range override.Translation {
+				plural[category] = t
+			}
+			text = plural
+		}
+
+		t, err := translation.NewTranslation(map[string]interface{}{
+			"id":          override.TranslationID,
+			"translation": text,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid override of %s for locale %q: %w", override.TranslationID, override.Locale, err)
+		}
+		b.AddTranslation(langs[0], t)
+	}
+
+	notificationOverrides.Store(b)
+	return nil
+}
+
+// GetNotificationTranslations returns the translate function for the notifications sent to a
+// user with the given locale. Templates overridden for the locale are used instead of the
+// translations shipped with the server.
+func GetNotificationTranslations(locale string) TranslateFunc {
+	locale = GetUserLocale(locale)
+	translations := tfuncWithFallback(locale)
+
+	b, _ := notificationOverrides.Load().(*bundle.Bundle)
+	if b == nil {
+		return translations
+	}
+
+	overridden, err := b.Tfunc(locale)
+	if err != nil {
+		// Nothing is overridden for the locale.
+		return translations
+	}
+
+	return func(translationID string, args ...interface{}) string {
+		if translated := overridden(translationID, args...); translated != translationID {
+			return translated
+		}
+
+		return translations(translationID, args...)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationTranslations(t *testing.T) {
+	locales["en"] = "en.json"
+	locales["fr"] = "fr.json"
+	defer func() {
+		delete(locales, "en")
+		delete(locales, "fr")
+		SetNotificationOverrides(nil)
+	}()
+
+	require.NoError(t, SetNotificationOverrides([]TemplateOverride{
+		{
+			Locale:        "en",
+			TranslationID: "notification.title",
+			Translation:   map[string]string{"one": "One message", "other": "{{.Count}} messages"},
+		},
+		{
+			Locale:        "en",
+			TranslationID: "notification.subject",
+			Translation:   map[string]string{"other": "News from {{.SiteName}}"},
+		},
+	}))
+
+	t.Run("overridden templates are pluralized", func(t *testing.T) {
+		T := GetNotificationTranslations("en")
+		assert.Equal(t, "One message", T("notification.title", 1))
+		assert.Equal(t, "3 messages", T("notification.title", 3))
+		assert.Equal(t, "News from Mattermost", T("notification.subject", map[string]interface{}{"SiteName": "Mattermost"}))
+	})
+
+	t.Run("templates without an override aren't changed", func(t *testing.T) {
+		assert.Equal(t, "notification.body", GetNotificationTranslations("en")("notification.body"))
+	})
+
+	t.Run("overrides only apply to their locale", func(t *testing.T) {
+		assert.Equal(t, "notification.subject", GetNotificationTranslations("fr")("notification.subject"))
+	})
+
+	t.Run("unsupported locales use the default locale", func(t *testing.T) {
+		assert.Equal(t, "One message", GetNotificationTranslations("xx")("notification.title", 1))
+	})
+
+	t.Run("invalid overrides are rejected", func(t *testing.T) {
+		err := SetNotificationOverrides([]TemplateOverride{
+			{Locale: "en", TranslationID: "notification.title", Translation: map[string]string{"other": "{{.Count"}},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,380 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package jsonschema validates JSON values against a subset of JSON Schema.
+//
+// The supported keywords are type, enum, properties, required, additionalProperties, items,
+// minItems, maxItems, minLength, maxLength, pattern, minimum and maximum. The $schema, $id,
+// $comment, title, description, default and examples annotations are accepted and ignored. Any
+// other keyword fails compilation rather than being silently skipped, so a schema never looks
+// stricter than it is.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a compiled JSON schema.
+type Schema struct {
+	// never is set for the false schema, which no value is valid against.
+	never bool
+
+	types      []string
+	enum       []interface{}
+	properties map[string]*Schema
+	required   []string
+	// additionalProperties validates the properties not listed in properties. A nil value allows
+	// any, while noAdditionalProperties rejects them.
+	additionalProperties   *Schema
+	noAdditionalProperties bool
+	items                  *Schema
+	minItems               *int
+	maxItems               *int
+	minLength              *int
+	maxLength              *int
+	pattern                *regexp.Regexp
+	minimum                *float64
+	maximum                *float64
+}
+
+// ValidationError is the reason a value is not valid against a schema, along with the JSON
+// pointer of the invalid part of the value.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+var types = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"number":  true,
+	"integer": true,
+	"string":  true,
+	"array":   true,
+	"object":  true,
+}
+
+var annotations = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+}
+
+// Compile parses a JSON schema.
+func Compile(data []byte) (*Schema, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return compile(raw, "")
+}
+
+func compile(raw interface{}, path string) (*Schema, error) {
+	switch raw := raw.(type) {
+	case bool:
+		return &Schema{never: !raw}, nil
+	case map[string]interface{}:
+		s := &Schema{}
+		keywords := make([]string, 0, len(raw))
+		for keyword := range raw {
+			keywords = append(keywords, keyword)
+		}
+		sort.Strings(keywords)
+
+		for _, keyword := range keywords {
+			if err := s.compileKeyword(keyword, raw[keyword], path+"/"+escape(keyword)); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	default:
+		return nil, &ValidationError{Path: path, Message: "a schema must be an object or a boolean"}
+	}
+}
+
+func (s *Schema) compileKeyword(keyword string, value interface{}, path string) error {
+	var err error
+	switch keyword {
+	case "type":
+		switch value := value.(type) {
+		case string:
+			s.types = []string{value}
+		case []interface{}:
+			for _, t := range value {
+				name, ok := t.(string)
+				if !ok {
+					return &ValidationError{Path: path, Message: "types must be strings"}
+				}
+				s.types = append(s.types, name)
+			}
+		default:
+			return &ValidationError{Path: path, Message: "must be a string or an array of strings"}
+		}
+		for _, t := range s.types {
+			if !types[t] {
+				return &ValidationError{Path: path, Message: fmt.Sprintf("unknown type %q", t)}
+			}
+		}
+	case "enum":
+		values, ok := value.([]interface{})
+		if !ok || len(values) == 0 {
+			return &ValidationError{Path: path, Message: "must be a non-empty array"}
+		}
+		s.enum = values
+	case "properties":
+		properties, ok := value.(map[string]interface{})
+		if !ok {
+			return &ValidationError{Path: path, Message: "must be an object"}
+		}
+		s.properties = make(map[string]*Schema, len(properties))
+		for name, property := range properties {
+			if s.properties[name], err = compile(property, path+"/"+escape(name)); err != nil {
+				return err
+			}
+		}
+	case "required":
+		names, ok := value.([]interface{})
+		if !ok {
+			return &ValidationError{Path: path, Message: "must be an array of strings"}
+		}
+		for _, name := range names {
+			property, ok := name.(string)
+			if !ok {
+				return &ValidationError{Path: path, Message: "must be an array of strings"}
+			}
+			s.required = append(s.required, property)
+		}
+	case "additionalProperties":
+		if allowed, ok := value.(bool); ok {
+			s.noAdditionalProperties = !allowed
+			return nil
+		}
+		s.additionalProperties, err = compile(value, path)
+	case "items":
+		s.items, err = compile(value, path)
+	case "minItems":
+		s.minItems, err = compileCount(value, path)
+	case "maxItems":
+		s.maxItems, err = compileCount(value, path)
+	case "minLength":
+		s.minLength, err = compileCount(value, path)
+	case "maxLength":
+		s.maxLength, err = compileCount(value, path)
+	case "pattern":
+		pattern, ok := value.(string)
+		if !ok {
+			return &ValidationError{Path: path, Message: "must be a string"}
+		}
+		if s.pattern, err = regexp.Compile(pattern); err != nil {
+			return &ValidationError{Path: path, Message: err.Error()}
+		}
+	case "minimum":
+		s.minimum, err = compileNumber(value, path)
+	case "maximum":
+		s.maximum, err = compileNumber(value, path)
+	default:
+		if !annotations[keyword] {
+			return &ValidationError{Path: path, Message: "unsupported keyword"}
+		}
+	}
+	return err
+}
+
+func compileCount(value interface{}, path string) (*int, error) {
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) || n > math.MaxInt32 {
+		return nil, &ValidationError{Path: path, Message: "must be a non-negative integer"}
+	}
+	count := int(n)
+	return &count, nil
+}
+
+func compileNumber(value interface{}, path string) (*float64, error) {
+	n, ok := value.(float64)
+	if !ok {
+		return nil, &ValidationError{Path: path, Message: "must be a number"}
+	}
+	return &n, nil
+}
+
+// Validate checks a value against the schema. The value is any value encoding/json can
+// marshal, and is validated as the JSON it marshals to.
+func (s *Schema) Validate(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+
+	return s.validate(normalized, "")
+}
+
+func (s *Schema) validate(value interface{}, path string) error {
+	if s.never {
+		return &ValidationError{Path: path, Message: "no value is allowed"}
+	}
+
+	if len(s.types) > 0 && !s.hasType(value) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected %s, got %s", strings.Join(s.types, " or "), typeOf(value))}
+	}
+
+	if s.enum != nil && !s.inEnum(value) {
+		return &ValidationError{Path: path, Message: "value is not one of the allowed values"}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return s.validateObject(value, path)
+	case []interface{}:
+		return s.validateArray(value, path)
+	case string:
+		return s.validateString(value, path)
+	case float64:
+		return s.validateNumber(value, path)
+	}
+	return nil
+}
+
+func (s *Schema) hasType(value interface{}) bool {
+	actual := typeOf(value)
+	for _, t := range s.types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) inEnum(value interface{}) bool {
+	for _, allowed := range s.enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, path string) error {
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			return &ValidationError{Path: path + "/" + escape(name), Message: "missing required property"}
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + "/" + escape(name)
+		if property, ok := s.properties[name]; ok {
+			if err := property.validate(object[name], propertyPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if s.noAdditionalProperties {
+			return &ValidationError{Path: propertyPath, Message: "property is not allowed"}
+		}
+		if s.additionalProperties != nil {
+			if err := s.additionalProperties.validate(object[name], propertyPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateArray(array []interface{}, path string) error {
+	if s.minItems != nil && len(array) < *s.minItems {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at least %d items", *s.minItems)}
+	}
+	if s.maxItems != nil && len(array) > *s.maxItems {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at most %d items", *s.maxItems)}
+	}
+
+	if s.items != nil {
+		for i, item := range array {
+			if err := s.items.validate(item, path+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateString(str string, path string) error {
+	length := utf8.RuneCountInString(str)
+	if s.minLength != nil && length < *s.minLength {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at least %d characters", *s.minLength)}
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at most %d characters", *s.maxLength)}
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("does not match pattern %q", s.pattern.String())}
+	}
+	return nil
+}
+
+func (s *Schema) validateNumber(n float64, path string) error {
+	if s.minimum != nil && n < *s.minimum {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at least %v", *s.minimum)}
+	}
+	if s.maximum != nil && n > *s.maximum {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("expected at most %v", *s.maximum)}
+	}
+	return nil
+}
+
+func typeOf(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// escape escapes a property name as a JSON pointer reference token.
+func escape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
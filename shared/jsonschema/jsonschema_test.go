@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pollSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Poll",
+	"type": "object",
+	"required": ["question", "options"],
+	"additionalProperties": false,
+	"properties": {
+		"question": {"type": "string", "minLength": 1, "maxLength": 10},
+		"options": {
+			"type": "array",
+			"minItems": 2,
+			"maxItems": 3,
+			"items": {"type": "string", "pattern": "^[a-z]+$"}
+		},
+		"votes": {"type": "integer", "minimum": 0},
+		"kind": {"enum": ["single", "multiple"]},
+		"meta": {"type": ["object", "null"], "additionalProperties": {"type": "string"}}
+	}
+}`
+
+func TestCompile(t *testing.T) {
+	t.Run("valid schemas", func(t *testing.T) {
+		for _, schema := range []string{pollSchema, `true`, `false`, `{}`, `{"type": ["string", "null"]}`} {
+			_, err := Compile([]byte(schema))
+			assert.NoError(t, err, schema)
+		}
+	})
+
+	t.Run("invalid schemas", func(t *testing.T) {
+		for schema, path := range map[string]string{
+			`{`:                               "",
+			`"object"`:                        "",
+			`{"type": "date"}`:                "/type",
+			`{"type": 1}`:                     "/type",
+			`{"enum": []}`:                    "/enum",
+			`{"required": [1]}`:               "/required",
+			`{"minLength": -1}`:               "/minLength",
+			`{"maxItems": 1.5}`:               "/maxItems",
+			`{"pattern": "("}`:                "/pattern",
+			`{"minimum": "1"}`:                "/minimum",
+			`{"oneOf": [{"type": "string"}]}`: "/oneOf",
+			`{"properties": {"a/b": {"$ref": "#/defs/x"}}}`:   "/properties/a~1b/$ref",
+			`{"items": {"additionalProperties": {"x": {}}}}`:  "/items/additionalProperties/x",
+			`{"properties": {"question": {"type": "text"}}}`:  "/properties/question/type",
+			`{"additionalProperties": "no"}`:                  "/additionalProperties",
+			`{"properties": []}`:                              "/properties",
+			`{"items": 1}`:                                    "/items",
+			`{"type": ["string", 1]}`:                         "/type",
+			`{"properties": {"question": {"maxLength": {}}}}`: "/properties/question/maxLength",
+		} {
+			_, err := Compile([]byte(schema))
+			require.Error(t, err, schema)
+			if path != "" {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr, schema)
+				assert.Equal(t, path, validationErr.Path, schema)
+			}
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(pollSchema))
+	require.NoError(t, err)
+
+	t.Run("valid values", func(t *testing.T) {
+		for _, value := range []interface{}{
+			map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "sushi"}},
+			map[string]interface{}{"question": "Lunch?", "options": []interface{}{"pizza", "sushi", "tacos"}, "votes": 3, "kind": "single"},
+			map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "sushi"}, "votes": 3.0, "meta": nil},
+			map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "sushi"}, "meta": map[string]string{"by": "bob"}},
+		} {
+			assert.NoError(t, schema.Validate(value), value)
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for path, value := range map[string]interface{}{
+			"":            "Lunch?",
+			"/options":    map[string]interface{}{"question": "Lunch?"},
+			"/question":   map[string]interface{}{"question": "", "options": []string{"pizza", "sushi"}},
+			"/options/1":  map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "Sushi"}},
+			"/votes":      map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "sushi"}, "votes": 1.5},
+			"/kind":       map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "sushi"}, "kind": "ranked"},
+			"/meta/count": map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "sushi"}, "meta": map[string]int{"count": 1}},
+			"/extra":      map[string]interface{}{"question": "Lunch?", "options": []string{"pizza", "sushi"}, "extra": true},
+		} {
+			err := schema.Validate(value)
+			require.Error(t, err, path)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, path, validationErr.Path)
+		}
+
+		err := schema.Validate(map[string]interface{}{"question": "What is for lunch?", "options": []string{"pizza", "sushi"}})
+		assert.EqualError(t, err, "/question: expected at most 10 characters")
+	})
+
+	t.Run("boolean schemas", func(t *testing.T) {
+		always, err := Compile([]byte(`true`))
+		require.NoError(t, err)
+		assert.NoError(t, always.Validate(map[string]interface{}{"any": "thing"}))
+
+		never, err := Compile([]byte(`false`))
+		require.NoError(t, err)
+		assert.Error(t, never.Validate(nil))
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		number, err := Compile([]byte(`{"type": "number", "maximum": 1}`))
+		require.NoError(t, err)
+		assert.NoError(t, number.Validate(1))
+		assert.NoError(t, number.Validate(0.5))
+		assert.EqualError(t, number.Validate(1.5), "expected at most 1")
+		assert.EqualError(t, number.Validate("1"), "expected number, got string")
+	})
+}
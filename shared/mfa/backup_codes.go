@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// BackupCodeCount is the number of backup codes generated at once.
+	BackupCodeCount = 10
+
+	// This will result in 80 bits of entropy, encoded as 16 base32 characters.
+	backupCodeSize   = 10
+	backupCodeLength = 16
+	backupCodeGroup  = 4
+)
+
+// GenerateBackupCodes returns new one-time backup codes, formatted to be shown to the user,
+// along with their hashes, which are the only form in which they should be stored.
+func GenerateBackupCodes() (codes []string, hashes []string) {
+	codes = make([]string, 0, BackupCodeCount)
+	hashes = make([]string, 0, BackupCodeCount)
+	for i := 0; i < BackupCodeCount; i++ {
+		code := strings.ToLower(newRandomBase32String(backupCodeSize))
+
+		var groups []string
+		for j := 0; j < len(code); j += backupCodeGroup {
+			groups = append(groups, code[j:j+backupCodeGroup])
+		}
+
+		codes = append(codes, strings.Join(groups, "-"))
+		hashes = append(hashes, HashBackupCode(code))
+	}
+
+	return codes, hashes
+}
+
+// NormalizeBackupCode returns a code typed by the user without its separators and in lower case,
+// and false if it can't be a backup code, such as a token generated by an authenticator app.
+func NormalizeBackupCode(code string) (string, bool) {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	if len(code) != backupCodeLength {
+		return "", false
+	}
+
+	for _, c := range code {
+		if !(c >= 'a' && c <= 'z') && !(c >= '2' && c <= '7') {
+			return "", false
+		}
+	}
+
+	return code, true
+}
+
+// HashBackupCode returns the hash of a normalized backup code. The codes being random, a fast
+// hash is enough to keep them from being recovered, and allows them to be looked up.
+func HashBackupCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
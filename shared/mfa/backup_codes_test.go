@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBackupCodes(t *testing.T) {
+	codes, hashes := GenerateBackupCodes()
+	require.Len(t, codes, BackupCodeCount)
+	require.Len(t, hashes, BackupCodeCount)
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.Len(t, code, 19)
+		assert.False(t, seen[code], "codes are unique")
+		seen[code] = true
+
+		normalized, ok := NormalizeBackupCode(code)
+		require.True(t, ok)
+		assert.Equal(t, hashes[i], HashBackupCode(normalized))
+	}
+}
+
+func TestNormalizeBackupCode(t *testing.T) {
+	for _, tc := range []struct {
+		code       string
+		normalized string
+		ok         bool
+	}{
+		{"abcd-efgh-ijkl-mnop", "abcdefghijklmnop", true},
+		{" ABCD EFGH IJKL MNOP ", "abcdefghijklmnop", true},
+		{"abcdefghijklmn23", "abcdefghijklmn23", true},
+		{"123456", "", false},
+		{"abcdefgh", "", false},
+		{"abcd-efgh-ijkl-mno1", "", false},
+		{"abcd-efgh-ijkl-mnopq", "", false},
+	} {
+		normalized, ok := NormalizeBackupCode(tc.code)
+		assert.Equal(t, tc.ok, ok, tc.code)
+		assert.Equal(t, tc.normalized, normalized, tc.code)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapturelayer
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type ChangeCaptureChannelStore struct {
+	store.ChannelStore
+	rootStore *ChangeCaptureStore
+}
+
+func (s *ChangeCaptureChannelStore) Save(channel *model.Channel, maxChannels int64) (*model.Channel, error) {
+	newChannel, err := s.ChannelStore.Save(channel, maxChannels)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityChannel, newChannel.Id, model.ChangeEventOperationCreate, newChannel)
+	}
+	return newChannel, err
+}
+
+func (s *ChangeCaptureChannelStore) CreateDirectChannel(user *model.User, otherUser *model.User, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+	channel, err := s.ChannelStore.CreateDirectChannel(user, otherUser, channelOptions...)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityChannel, channel.Id, model.ChangeEventOperationCreate, channel)
+	}
+	return channel, err
+}
+
+func (s *ChangeCaptureChannelStore) SaveDirectChannel(directchannel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	channel, err := s.ChannelStore.SaveDirectChannel(directchannel, member1, member2)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityChannel, channel.Id, model.ChangeEventOperationCreate, channel)
+	}
+	return channel, err
+}
+
+func (s *ChangeCaptureChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+	updatedChannel, err := s.ChannelStore.Update(channel)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityChannel, updatedChannel.Id, model.ChangeEventOperationUpdate, updatedChannel)
+	}
+	return updatedChannel, err
+}
+
+// recordChannel records the change to the channel as it is after the change, as Delete and Restore
+// don't return it.
+func (s *ChangeCaptureChannelStore) recordChannel(channelID, operation string) {
+	var data interface{}
+	if channel, err := s.ChannelStore.Get(channelID, false); err == nil {
+		data = channel
+	}
+	s.rootStore.record(model.ChangeEventEntityChannel, channelID, operation, data)
+}
+
+func (s *ChangeCaptureChannelStore) Delete(channelID string, time int64) error {
+	err := s.ChannelStore.Delete(channelID, time)
+	if err == nil {
+		s.recordChannel(channelID, model.ChangeEventOperationDelete)
+	}
+	return err
+}
+
+func (s *ChangeCaptureChannelStore) Restore(channelID string, time int64) error {
+	err := s.ChannelStore.Restore(channelID, time)
+	if err == nil {
+		s.recordChannel(channelID, model.ChangeEventOperationUpdate)
+	}
+	return err
+}
+
+func (s *ChangeCaptureChannelStore) PermanentDelete(channelID string) error {
+	err := s.ChannelStore.PermanentDelete(channelID)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityChannel, channelID, model.ChangeEventOperationDelete, nil)
+	}
+	return err
+}
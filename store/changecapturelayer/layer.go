@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapturelayer
+
+import (
+	"sync/atomic"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// ChangeCaptureStore records the changes made through it to the posts, users and channels as
+// events in the ChangeEvents outbox table, when change data capture is enabled. The events are
+// recorded once the changes succeed, outside of their transactions, so a change made right before
+// the server stops may be missing from the stream.
+type ChangeCaptureStore struct {
+	store.Store
+	post        *ChangeCapturePostStore
+	user        *ChangeCaptureUserStore
+	channel     *ChangeCaptureChannelStore
+	configValue atomic.Value
+}
+
+func NewChangeCaptureLayer(baseStore store.Store, cfg *model.Config) *ChangeCaptureStore {
+	changeCaptureStore := &ChangeCaptureStore{
+		Store: baseStore,
+	}
+	changeCaptureStore.configValue.Store(cfg)
+	changeCaptureStore.post = &ChangeCapturePostStore{PostStore: baseStore.Post(), rootStore: changeCaptureStore}
+	changeCaptureStore.user = &ChangeCaptureUserStore{UserStore: baseStore.User(), rootStore: changeCaptureStore}
+	changeCaptureStore.channel = &ChangeCaptureChannelStore{ChannelStore: baseStore.Channel(), rootStore: changeCaptureStore}
+
+	return changeCaptureStore
+}
+
+func (s *ChangeCaptureStore) UpdateConfig(cfg *model.Config) {
+	s.configValue.Store(cfg)
+}
+
+func (s *ChangeCaptureStore) getConfig() *model.Config {
+	return s.configValue.Load().(*model.Config)
+}
+
+func (s *ChangeCaptureStore) Post() store.PostStore {
+	return s.post
+}
+
+func (s *ChangeCaptureStore) User() store.UserStore {
+	return s.user
+}
+
+func (s *ChangeCaptureStore) Channel() store.ChannelStore {
+	return s.channel
+}
+
+// record saves the event of a change to an entity, whose data may be nil. A failure to record
+// it is only logged, as the change itself was already made.
+func (s *ChangeCaptureStore) record(entityType, entityID, operation string, data interface{}) {
+	if !*s.getConfig().ChangeDataCaptureSettings.Enable {
+		return
+	}
+
+	event, err := model.NewChangeEvent(entityType, entityID, operation, data)
+	if err == nil {
+		_, err = s.Store.ChangeEvent().Save(event)
+	}
+	if err != nil {
+		mlog.Warn("Failed to record a change event", mlog.String("entity_type", entityType), mlog.String("entity_id", entityID), mlog.String("operation", operation), mlog.Err(err))
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapturelayer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store/storetest/mocks"
+)
+
+func makeLayer(enable bool) (*ChangeCaptureStore, *mocks.Store, *[]*model.ChangeEvent) {
+	mockStore := &mocks.Store{}
+	mockStore.On("Post").Return(&mocks.PostStore{})
+	mockStore.On("User").Return(&mocks.UserStore{})
+	mockStore.On("Channel").Return(&mocks.ChannelStore{})
+
+	saved := []*model.ChangeEvent{}
+	mockChangeEventStore := &mocks.ChangeEventStore{}
+	mockChangeEventStore.On("Save", mock.AnythingOfType("*model.ChangeEvent")).Return(func(event *model.ChangeEvent) *model.ChangeEvent {
+		saved = append(saved, event)
+		return event
+	}, nil)
+	mockStore.On("ChangeEvent").Return(mockChangeEventStore)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.ChangeDataCaptureSettings.Enable = model.NewBool(enable)
+
+	return NewChangeCaptureLayer(mockStore, cfg), mockStore, &saved
+}
+
+func TestChangeCapturePostStore(t *testing.T) {
+	post := &model.Post{Id: model.NewId(), Message: "message"}
+
+	t.Run("records the saved post", func(t *testing.T) {
+		layer, mockStore, saved := makeLayer(true)
+		mockStore.Post().(*mocks.PostStore).On("Save", post).Return(post, nil)
+
+		_, err := layer.Post().Save(post)
+		require.NoError(t, err)
+
+		require.Len(t, *saved, 1)
+		event := (*saved)[0]
+		assert.Equal(t, model.ChangeEventEntityPost, event.EntityType)
+		assert.Equal(t, post.Id, event.EntityId)
+		assert.Equal(t, model.ChangeEventOperationCreate, event.Operation)
+
+		var data model.Post
+		require.NoError(t, json.Unmarshal(event.Data, &data))
+		assert.Equal(t, "message", data.Message)
+	})
+
+	t.Run("records the deleted post without data", func(t *testing.T) {
+		layer, mockStore, saved := makeLayer(true)
+		mockStore.Post().(*mocks.PostStore).On("Delete", post.Id, int64(1), "").Return(nil)
+
+		require.NoError(t, layer.Post().Delete(post.Id, 1, ""))
+
+		require.Len(t, *saved, 1)
+		assert.Equal(t, model.ChangeEventOperationDelete, (*saved)[0].Operation)
+		assert.Empty(t, (*saved)[0].Data)
+	})
+
+	t.Run("doesn't record a failed change", func(t *testing.T) {
+		layer, mockStore, saved := makeLayer(true)
+		mockStore.Post().(*mocks.PostStore).On("Save", post).Return(nil, errors.New("failed"))
+
+		_, err := layer.Post().Save(post)
+		require.Error(t, err)
+		assert.Empty(t, *saved)
+	})
+
+	t.Run("doesn't record when disabled", func(t *testing.T) {
+		layer, mockStore, saved := makeLayer(false)
+		mockStore.Post().(*mocks.PostStore).On("Save", post).Return(post, nil)
+
+		_, err := layer.Post().Save(post)
+		require.NoError(t, err)
+		assert.Empty(t, *saved)
+
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+		cfg.ChangeDataCaptureSettings.Enable = model.NewBool(true)
+		layer.UpdateConfig(cfg)
+
+		_, err = layer.Post().Save(post)
+		require.NoError(t, err)
+		assert.Len(t, *saved, 1)
+	})
+}
+
+func TestChangeCaptureUserStore(t *testing.T) {
+	t.Run("records the updated user without secrets", func(t *testing.T) {
+		layer, mockStore, saved := makeLayer(true)
+		user := &model.User{Id: model.NewId(), Username: "username", Password: "password", MfaSecret: "secret"}
+		mockStore.User().(*mocks.UserStore).On("Update", user, false).Return(&model.UserUpdate{New: user, Old: user}, nil)
+
+		_, err := layer.User().Update(user, false)
+		require.NoError(t, err)
+
+		require.Len(t, *saved, 1)
+		assert.Equal(t, model.ChangeEventOperationUpdate, (*saved)[0].Operation)
+
+		var data model.User
+		require.NoError(t, json.Unmarshal((*saved)[0].Data, &data))
+		assert.Equal(t, "username", data.Username)
+		assert.Empty(t, data.Password)
+		assert.Empty(t, data.MfaSecret)
+		assert.Equal(t, "password", user.Password, "the user itself must not be sanitized")
+	})
+}
+
+func TestChangeCaptureChannelStore(t *testing.T) {
+	t.Run("records the archived channel", func(t *testing.T) {
+		layer, mockStore, saved := makeLayer(true)
+		channel := &model.Channel{Id: model.NewId(), DeleteAt: 1}
+		mockStore.Channel().(*mocks.ChannelStore).On("Delete", channel.Id, int64(1)).Return(nil)
+		mockStore.Channel().(*mocks.ChannelStore).On("Get", channel.Id, false).Return(channel, nil)
+
+		require.NoError(t, layer.Channel().Delete(channel.Id, 1))
+
+		require.Len(t, *saved, 1)
+		assert.Equal(t, model.ChangeEventEntityChannel, (*saved)[0].EntityType)
+		assert.Equal(t, model.ChangeEventOperationDelete, (*saved)[0].Operation)
+
+		var data model.Channel
+		require.NoError(t, json.Unmarshal((*saved)[0].Data, &data))
+		assert.Equal(t, int64(1), data.DeleteAt)
+	})
+}
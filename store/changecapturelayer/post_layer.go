@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapturelayer
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type ChangeCapturePostStore struct {
+	store.PostStore
+	rootStore *ChangeCaptureStore
+}
+
+func (s *ChangeCapturePostStore) recordPosts(posts []*model.Post, operation string) {
+	for _, post := range posts {
+		s.rootStore.record(model.ChangeEventEntityPost, post.Id, operation, post)
+	}
+}
+
+func (s *ChangeCapturePostStore) Save(post *model.Post) (*model.Post, error) {
+	npost, err := s.PostStore.Save(post)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityPost, npost.Id, model.ChangeEventOperationCreate, npost)
+	}
+	return npost, err
+}
+
+func (s *ChangeCapturePostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	nposts, errIdx, err := s.PostStore.SaveMultiple(posts)
+	if err == nil {
+		s.recordPosts(nposts, model.ChangeEventOperationCreate)
+	}
+	return nposts, errIdx, err
+}
+
+func (s *ChangeCapturePostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	post, err := s.PostStore.Update(newPost, oldPost)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityPost, post.Id, model.ChangeEventOperationUpdate, post)
+	}
+	return post, err
+}
+
+func (s *ChangeCapturePostStore) Overwrite(post *model.Post) (*model.Post, error) {
+	npost, err := s.PostStore.Overwrite(post)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityPost, npost.Id, model.ChangeEventOperationUpdate, npost)
+	}
+	return npost, err
+}
+
+func (s *ChangeCapturePostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	nposts, errIdx, err := s.PostStore.OverwriteMultiple(posts)
+	if err == nil {
+		s.recordPosts(nposts, model.ChangeEventOperationUpdate)
+	}
+	return nposts, errIdx, err
+}
+
+func (s *ChangeCapturePostStore) Delete(postID string, time int64, deleteByID string) error {
+	err := s.PostStore.Delete(postID, time, deleteByID)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityPost, postID, model.ChangeEventOperationDelete, nil)
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package changecapturelayer
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type ChangeCaptureUserStore struct {
+	store.UserStore
+	rootStore *ChangeCaptureStore
+}
+
+// recordUser records the change to the user without its password, authentication data and MFA
+// secret.
+func (s *ChangeCaptureUserStore) recordUser(user *model.User, operation string) {
+	sanitized := user.DeepCopy()
+	sanitized.Sanitize(map[string]bool{})
+	s.rootStore.record(model.ChangeEventEntityUser, user.Id, operation, sanitized)
+}
+
+func (s *ChangeCaptureUserStore) Save(user *model.User) (*model.User, error) {
+	nuser, err := s.UserStore.Save(user)
+	if err == nil {
+		s.recordUser(nuser, model.ChangeEventOperationCreate)
+	}
+	return nuser, err
+}
+
+func (s *ChangeCaptureUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	userUpdate, err := s.UserStore.Update(user, allowRoleUpdate)
+	if err == nil {
+		s.recordUser(userUpdate.New, model.ChangeEventOperationUpdate)
+	}
+	return userUpdate, err
+}
+
+func (s *ChangeCaptureUserStore) PermanentDelete(userID string) error {
+	err := s.UserStore.PermanentDelete(userID)
+	if err == nil {
+		s.rootStore.record(model.ChangeEventEntityUser, userID, model.ChangeEventOperationDelete, nil)
+	}
+	return err
+}
@@ -51,6 +51,9 @@ const (
 	LastPostTimeCacheSize   = 25000
 	LastPostTimeCacheSec    = 15 * 60
 
+	PostPropSchemaCacheSize = 1
+	PostPropSchemaCacheSec  = 30 * 60
+
 	UserProfileByIDCacheSize = 20000
 	UserProfileByIDSec       = 30 * 60
 
@@ -110,6 +113,9 @@ type LocalCacheStore struct {
 
 	termsOfService      LocalCacheTermsOfServiceStore
 	termsOfServiceCache cache.Cache
+
+	postPropSchema      LocalCachePostPropSchemaStore
+	postPropSchemaCache cache.Cache
 }
 
 func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, cluster einterfaces.ClusterInterface, cacheProvider cache.Provider) (localCacheStore LocalCacheStore, err error) {
@@ -280,6 +286,17 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 	}
 	localCacheStore.termsOfService = LocalCacheTermsOfServiceStore{TermsOfServiceStore: baseStore.TermsOfService(), rootStore: &localCacheStore}
 
+	// Post prop schemas
+	if localCacheStore.postPropSchemaCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   PostPropSchemaCacheSize,
+		Name:                   "PostPropSchema",
+		DefaultExpiry:          PostPropSchemaCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForPostPropSchemas,
+	}); err != nil {
+		return
+	}
+	localCacheStore.postPropSchema = LocalCachePostPropSchemaStore{PostPropSchemaStore: baseStore.PostPropSchema(), rootStore: &localCacheStore}
+
 	// Users
 	if localCacheStore.userProfileByIdsCache, err = cacheProvider.NewCache(&cache.CacheOptions{
 		Size:                   UserProfileByIDCacheSize,
@@ -333,6 +350,7 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForChannel, localCacheStore.channel.handleClusterInvalidateChannelById)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForLastPosts, localCacheStore.post.handleClusterInvalidateLastPosts)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForTermsOfService, localCacheStore.termsOfService.handleClusterInvalidateTermsOfService)
+		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForPostPropSchemas, localCacheStore.postPropSchema.handleClusterInvalidatePostPropSchemas)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForProfileByIds, localCacheStore.user.handleClusterInvalidateScheme)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForProfileInChannel, localCacheStore.user.handleClusterInvalidateProfilesInChannel)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForTeams, localCacheStore.team.handleClusterInvalidateTeam)
@@ -383,6 +401,10 @@ func (s LocalCacheStore) TermsOfService() store.TermsOfServiceStore {
 	return s.termsOfService
 }
 
+func (s LocalCacheStore) PostPropSchema() store.PostPropSchemaStore {
+	return s.postPropSchema
+}
+
 func (s LocalCacheStore) User() store.UserStore {
 	return s.user
 }
@@ -452,6 +474,7 @@ func (s *LocalCacheStore) Invalidate() {
 	s.doClearCacheCluster(s.channelByIdCache)
 	s.doClearCacheCluster(s.postLastPostsCache)
 	s.doClearCacheCluster(s.termsOfServiceCache)
+	s.doClearCacheCluster(s.postPropSchemaCache)
 	s.doClearCacheCluster(s.lastPostTimeCache)
 	s.doClearCacheCluster(s.userProfileByIdsCache)
 	s.doClearCacheCluster(s.profilesInChannelCache)
@@ -133,6 +133,13 @@ func getMockStore() *mocks.Store {
 	mockTermsOfServiceStore.On("Get", "123", false).Return(&fakeTermsOfService, nil)
 	mockStore.On("TermsOfService").Return(&mockTermsOfServiceStore)
 
+	fakePostPropSchemas := []*model.PostPropSchema{{Namespace: "com.example.poll", Schema: []byte(`{"type":"object"}`), CreatorId: "321", CreateAt: 11111, UpdateAt: 11111}}
+	mockPostPropSchemaStore := mocks.PostPropSchemaStore{}
+	mockPostPropSchemaStore.On("GetAll").Return(fakePostPropSchemas, nil)
+	mockPostPropSchemaStore.On("Save", fakePostPropSchemas[0]).Return(fakePostPropSchemas[0], nil)
+	mockPostPropSchemaStore.On("Delete", "com.example.poll").Return(nil)
+	mockStore.On("PostPropSchema").Return(&mockPostPropSchemaStore)
+
 	fakeUser := []*model.User{{
 		Id:          "123",
 		AuthData:    model.NewString("authData"),
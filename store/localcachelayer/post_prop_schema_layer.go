@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+const (
+	AllPostPropSchemasKey = "all"
+)
+
+// LocalCachePostPropSchemaStore caches the registered schemas, which are read for every post
+// setting props.
+type LocalCachePostPropSchemaStore struct {
+	store.PostPropSchemaStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCachePostPropSchemaStore) handleClusterInvalidatePostPropSchemas(msg *model.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.postPropSchemaCache.Purge()
+	} else {
+		s.rootStore.postPropSchemaCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCachePostPropSchemaStore) Save(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
+	saved, err := s.PostPropSchemaStore.Save(schema)
+	if err == nil {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.postPropSchemaCache, AllPostPropSchemasKey)
+	}
+	return saved, err
+}
+
+func (s LocalCachePostPropSchemaStore) Update(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
+	updated, err := s.PostPropSchemaStore.Update(schema)
+	if err == nil {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.postPropSchemaCache, AllPostPropSchemasKey)
+	}
+	return updated, err
+}
+
+func (s LocalCachePostPropSchemaStore) Delete(namespace string) error {
+	err := s.PostPropSchemaStore.Delete(namespace)
+	if err == nil {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.postPropSchemaCache, AllPostPropSchemasKey)
+	}
+	return err
+}
+
+func (s LocalCachePostPropSchemaStore) GetAll() ([]*model.PostPropSchema, error) {
+	var schemas []*model.PostPropSchema
+	if err := s.rootStore.doStandardReadCache(s.rootStore.postPropSchemaCache, AllPostPropSchemasKey, &schemas); err == nil {
+		return schemas, nil
+	}
+
+	schemas, err := s.PostPropSchemaStore.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.postPropSchemaCache, AllPostPropSchemasKey, schemas)
+	return schemas, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store/storetest"
+	"github.com/mattermost/mattermost-server/v6/store/storetest/mocks"
+)
+
+func TestPostPropSchemaStore(t *testing.T) {
+	StoreTest(t, storetest.TestPostPropSchemaStore)
+}
+
+func TestPostPropSchemaStoreCache(t *testing.T) {
+	fakePostPropSchema := &model.PostPropSchema{Namespace: "com.example.poll", Schema: []byte(`{"type":"object"}`), CreatorId: "321", CreateAt: 11111, UpdateAt: 11111}
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+		require.NoError(t, err)
+
+		schemas, err := cachedStore.PostPropSchema().GetAll()
+		require.NoError(t, err)
+		assert.Equal(t, []*model.PostPropSchema{fakePostPropSchema}, schemas)
+		mockStore.PostPropSchema().(*mocks.PostPropSchemaStore).AssertNumberOfCalls(t, "GetAll", 1)
+
+		schemas, err = cachedStore.PostPropSchema().GetAll()
+		require.NoError(t, err)
+		assert.Equal(t, []*model.PostPropSchema{fakePostPropSchema}, schemas)
+		mockStore.PostPropSchema().(*mocks.PostPropSchemaStore).AssertNumberOfCalls(t, "GetAll", 1)
+	})
+
+	t.Run("first call not cached, save, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+		require.NoError(t, err)
+
+		cachedStore.PostPropSchema().GetAll()
+		mockStore.PostPropSchema().(*mocks.PostPropSchemaStore).AssertNumberOfCalls(t, "GetAll", 1)
+		cachedStore.PostPropSchema().Save(fakePostPropSchema)
+		cachedStore.PostPropSchema().GetAll()
+		mockStore.PostPropSchema().(*mocks.PostPropSchemaStore).AssertNumberOfCalls(t, "GetAll", 2)
+	})
+
+	t.Run("first call not cached, delete, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+		require.NoError(t, err)
+
+		cachedStore.PostPropSchema().GetAll()
+		mockStore.PostPropSchema().(*mocks.PostPropSchemaStore).AssertNumberOfCalls(t, "GetAll", 1)
+		cachedStore.PostPropSchema().Delete("com.example.poll")
+		cachedStore.PostPropSchema().GetAll()
+		mockStore.PostPropSchema().(*mocks.PostPropSchemaStore).AssertNumberOfCalls(t, "GetAll", 2)
+	})
+}
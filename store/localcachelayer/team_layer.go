@@ -81,3 +81,29 @@ func (s LocalCacheTeamStore) Update(team *model.Team) (*model.Team, error) {
 
 	return tm, err
 }
+
+func (s LocalCacheTeamStore) SoftDelete(teamID string, deleteAt int64) error {
+	if err := s.TeamStore.SoftDelete(teamID, deleteAt); err != nil {
+		return err
+	}
+
+	s.invalidateSoftDeleteCascade()
+	return nil
+}
+
+func (s LocalCacheTeamStore) Restore(teamID string) error {
+	if err := s.TeamStore.Restore(teamID); err != nil {
+		return err
+	}
+
+	s.invalidateSoftDeleteCascade()
+	return nil
+}
+
+// invalidateSoftDeleteCascade clears the caches holding the memberships and channels of a team
+// that was deleted or restored, as they are deleted and restored along with it.
+func (s LocalCacheTeamStore) invalidateSoftDeleteCascade() {
+	s.rootStore.doClearCacheCluster(s.rootStore.teamAllTeamIdsForUserCache)
+	s.rootStore.doClearCacheCluster(s.rootStore.rolePermissionsCache)
+	s.rootStore.doClearCacheCluster(s.rootStore.channelByIdCache)
+}
@@ -21,43 +21,65 @@ const mySQLDeadlockCode = uint16(1213)
 
 type RetryLayer struct {
 	store.Store
-	AuditStore                store.AuditStore
-	BotStore                  store.BotStore
-	ChannelStore              store.ChannelStore
-	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
-	ClusterDiscoveryStore     store.ClusterDiscoveryStore
-	CommandStore              store.CommandStore
-	CommandWebhookStore       store.CommandWebhookStore
-	ComplianceStore           store.ComplianceStore
-	EmojiStore                store.EmojiStore
-	FileInfoStore             store.FileInfoStore
-	GroupStore                store.GroupStore
-	JobStore                  store.JobStore
-	LicenseStore              store.LicenseStore
-	LinkMetadataStore         store.LinkMetadataStore
-	OAuthStore                store.OAuthStore
-	PluginStore               store.PluginStore
-	PostStore                 store.PostStore
-	PreferenceStore           store.PreferenceStore
-	ProductNoticesStore       store.ProductNoticesStore
-	ReactionStore             store.ReactionStore
-	RemoteClusterStore        store.RemoteClusterStore
-	RetentionPolicyStore      store.RetentionPolicyStore
-	RoleStore                 store.RoleStore
-	SchemeStore               store.SchemeStore
-	SessionStore              store.SessionStore
-	SharedChannelStore        store.SharedChannelStore
-	StatusStore               store.StatusStore
-	SystemStore               store.SystemStore
-	TeamStore                 store.TeamStore
-	TermsOfServiceStore       store.TermsOfServiceStore
-	ThreadStore               store.ThreadStore
-	TokenStore                store.TokenStore
-	UploadSessionStore        store.UploadSessionStore
-	UserStore                 store.UserStore
-	UserAccessTokenStore      store.UserAccessTokenStore
-	UserTermsOfServiceStore   store.UserTermsOfServiceStore
-	WebhookStore              store.WebhookStore
+	AuditStore                    store.AuditStore
+	BotStore                      store.BotStore
+	ChangeEventStore              store.ChangeEventStore
+	ChannelStore                  store.ChannelStore
+	ChannelBookmarkStore          store.ChannelBookmarkStore
+	ChannelEventStore             store.ChannelEventStore
+	ChannelJoinRequestStore       store.ChannelJoinRequestStore
+	ChannelMemberHistoryStore     store.ChannelMemberHistoryStore
+	ChannelMemberTimeoutStore     store.ChannelMemberTimeoutStore
+	ClusterDiscoveryStore         store.ClusterDiscoveryStore
+	CommandStore                  store.CommandStore
+	CommandWebhookStore           store.CommandWebhookStore
+	ComplianceStore               store.ComplianceStore
+	ConnectivityTestResultStore   store.ConnectivityTestResultStore
+	DirectMessageRequestStore     store.DirectMessageRequestStore
+	EmojiStore                    store.EmojiStore
+	FileInfoStore                 store.FileInfoStore
+	GroupStore                    store.GroupStore
+	JobStore                      store.JobStore
+	LicenseStore                  store.LicenseStore
+	LinkMetadataStore             store.LinkMetadataStore
+	MfaBackupCodeStore            store.MfaBackupCodeStore
+	OAuthStore                    store.OAuthStore
+	OnboardingTaskStore           store.OnboardingTaskStore
+	OutgoingOAuthConnectionStore  store.OutgoingOAuthConnectionStore
+	PersistentWebSocketEventStore store.PersistentWebSocketEventStore
+	PluginStore                   store.PluginStore
+	PostStore                     store.PostStore
+	PostArchiveStore              store.PostArchiveStore
+	PostPropSchemaStore           store.PostPropSchemaStore
+	PostReportStore               store.PostReportStore
+	PostRetentionLabelStore       store.PostRetentionLabelStore
+	PreferenceStore               store.PreferenceStore
+	ProductNoticesStore           store.ProductNoticesStore
+	PushNotificationReceiptStore  store.PushNotificationReceiptStore
+	ReactionStore                 store.ReactionStore
+	ReminderStore                 store.ReminderStore
+	RemoteClusterStore            store.RemoteClusterStore
+	RetentionPolicyStore          store.RetentionPolicyStore
+	RoleStore                     store.RoleStore
+	ScheduledConfigChangeStore    store.ScheduledConfigChangeStore
+	SchemeStore                   store.SchemeStore
+	SessionStore                  store.SessionStore
+	SharedChannelStore            store.SharedChannelStore
+	StatusStore                   store.StatusStore
+	SystemStore                   store.SystemStore
+	TablePartitionStore           store.TablePartitionStore
+	TeamStore                     store.TeamStore
+	TeamInviteLinkStore           store.TeamInviteLinkStore
+	TeamTemplateStore             store.TeamTemplateStore
+	TermsOfServiceStore           store.TermsOfServiceStore
+	ThreadStore                   store.ThreadStore
+	TokenStore                    store.TokenStore
+	UploadSessionStore            store.UploadSessionStore
+	UserStore                     store.UserStore
+	UserAccessTokenStore          store.UserAccessTokenStore
+	UserDeviceStore               store.UserDeviceStore
+	UserTermsOfServiceStore       store.UserTermsOfServiceStore
+	WebhookStore                  store.WebhookStore
 }
 
 func (s *RetryLayer) Audit() store.AuditStore {
@@ -68,14 +90,34 @@ func (s *RetryLayer) Bot() store.BotStore {
 	return s.BotStore
 }
 
+func (s *RetryLayer) ChangeEvent() store.ChangeEventStore {
+	return s.ChangeEventStore
+}
+
 func (s *RetryLayer) Channel() store.ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *RetryLayer) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
+func (s *RetryLayer) ChannelEvent() store.ChannelEventStore {
+	return s.ChannelEventStore
+}
+
+func (s *RetryLayer) ChannelJoinRequest() store.ChannelJoinRequestStore {
+	return s.ChannelJoinRequestStore
+}
+
 func (s *RetryLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
 
+func (s *RetryLayer) ChannelMemberTimeout() store.ChannelMemberTimeoutStore {
+	return s.ChannelMemberTimeoutStore
+}
+
 func (s *RetryLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -92,6 +134,14 @@ func (s *RetryLayer) Compliance() store.ComplianceStore {
 	return s.ComplianceStore
 }
 
+func (s *RetryLayer) ConnectivityTestResult() store.ConnectivityTestResultStore {
+	return s.ConnectivityTestResultStore
+}
+
+func (s *RetryLayer) DirectMessageRequest() store.DirectMessageRequestStore {
+	return s.DirectMessageRequestStore
+}
+
 func (s *RetryLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
@@ -116,10 +166,26 @@ func (s *RetryLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *RetryLayer) MfaBackupCode() store.MfaBackupCodeStore {
+	return s.MfaBackupCodeStore
+}
+
 func (s *RetryLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *RetryLayer) OnboardingTask() store.OnboardingTaskStore {
+	return s.OnboardingTaskStore
+}
+
+func (s *RetryLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	return s.OutgoingOAuthConnectionStore
+}
+
+func (s *RetryLayer) PersistentWebSocketEvent() store.PersistentWebSocketEventStore {
+	return s.PersistentWebSocketEventStore
+}
+
 func (s *RetryLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -128,6 +194,22 @@ func (s *RetryLayer) Post() store.PostStore {
 	return s.PostStore
 }
 
+func (s *RetryLayer) PostArchive() store.PostArchiveStore {
+	return s.PostArchiveStore
+}
+
+func (s *RetryLayer) PostPropSchema() store.PostPropSchemaStore {
+	return s.PostPropSchemaStore
+}
+
+func (s *RetryLayer) PostReport() store.PostReportStore {
+	return s.PostReportStore
+}
+
+func (s *RetryLayer) PostRetentionLabel() store.PostRetentionLabelStore {
+	return s.PostRetentionLabelStore
+}
+
 func (s *RetryLayer) Preference() store.PreferenceStore {
 	return s.PreferenceStore
 }
@@ -136,10 +218,18 @@ func (s *RetryLayer) ProductNotices() store.ProductNoticesStore {
 	return s.ProductNoticesStore
 }
 
+func (s *RetryLayer) PushNotificationReceipt() store.PushNotificationReceiptStore {
+	return s.PushNotificationReceiptStore
+}
+
 func (s *RetryLayer) Reaction() store.ReactionStore {
 	return s.ReactionStore
 }
 
+func (s *RetryLayer) Reminder() store.ReminderStore {
+	return s.ReminderStore
+}
+
 func (s *RetryLayer) RemoteCluster() store.RemoteClusterStore {
 	return s.RemoteClusterStore
 }
@@ -152,6 +242,10 @@ func (s *RetryLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *RetryLayer) ScheduledConfigChange() store.ScheduledConfigChangeStore {
+	return s.ScheduledConfigChangeStore
+}
+
 func (s *RetryLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -172,10 +266,22 @@ func (s *RetryLayer) System() store.SystemStore {
 	return s.SystemStore
 }
 
+func (s *RetryLayer) TablePartition() store.TablePartitionStore {
+	return s.TablePartitionStore
+}
+
 func (s *RetryLayer) Team() store.TeamStore {
 	return s.TeamStore
 }
 
+func (s *RetryLayer) TeamInviteLink() store.TeamInviteLinkStore {
+	return s.TeamInviteLinkStore
+}
+
+func (s *RetryLayer) TeamTemplate() store.TeamTemplateStore {
+	return s.TeamTemplateStore
+}
+
 func (s *RetryLayer) TermsOfService() store.TermsOfServiceStore {
 	return s.TermsOfServiceStore
 }
@@ -200,6 +306,10 @@ func (s *RetryLayer) UserAccessToken() store.UserAccessTokenStore {
 	return s.UserAccessTokenStore
 }
 
+func (s *RetryLayer) UserDevice() store.UserDeviceStore {
+	return s.UserDeviceStore
+}
+
 func (s *RetryLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
@@ -218,16 +328,41 @@ type RetryLayerBotStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerChangeEventStore struct {
+	store.ChangeEventStore
+	Root *RetryLayer
+}
+
 type RetryLayerChannelStore struct {
 	store.ChannelStore
 	Root *RetryLayer
 }
 
+type RetryLayerChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelEventStore struct {
+	store.ChannelEventStore
+	Root *RetryLayer
+}
+
+type RetryLayerChannelJoinRequestStore struct {
+	store.ChannelJoinRequestStore
+	Root *RetryLayer
+}
+
 type RetryLayerChannelMemberHistoryStore struct {
 	store.ChannelMemberHistoryStore
 	Root *RetryLayer
 }
 
+type RetryLayerChannelMemberTimeoutStore struct {
+	store.ChannelMemberTimeoutStore
+	Root *RetryLayer
+}
+
 type RetryLayerClusterDiscoveryStore struct {
 	store.ClusterDiscoveryStore
 	Root *RetryLayer
@@ -248,6 +383,16 @@ type RetryLayerComplianceStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerConnectivityTestResultStore struct {
+	store.ConnectivityTestResultStore
+	Root *RetryLayer
+}
+
+type RetryLayerDirectMessageRequestStore struct {
+	store.DirectMessageRequestStore
+	Root *RetryLayer
+}
+
 type RetryLayerEmojiStore struct {
 	store.EmojiStore
 	Root *RetryLayer
@@ -278,11 +423,31 @@ type RetryLayerLinkMetadataStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerMfaBackupCodeStore struct {
+	store.MfaBackupCodeStore
+	Root *RetryLayer
+}
+
 type RetryLayerOAuthStore struct {
 	store.OAuthStore
 	Root *RetryLayer
 }
 
+type RetryLayerOnboardingTaskStore struct {
+	store.OnboardingTaskStore
+	Root *RetryLayer
+}
+
+type RetryLayerOutgoingOAuthConnectionStore struct {
+	store.OutgoingOAuthConnectionStore
+	Root *RetryLayer
+}
+
+type RetryLayerPersistentWebSocketEventStore struct {
+	store.PersistentWebSocketEventStore
+	Root *RetryLayer
+}
+
 type RetryLayerPluginStore struct {
 	store.PluginStore
 	Root *RetryLayer
@@ -293,6 +458,26 @@ type RetryLayerPostStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPostArchiveStore struct {
+	store.PostArchiveStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostPropSchemaStore struct {
+	store.PostPropSchemaStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostReportStore struct {
+	store.PostReportStore
+	Root *RetryLayer
+}
+
+type RetryLayerPostRetentionLabelStore struct {
+	store.PostRetentionLabelStore
+	Root *RetryLayer
+}
+
 type RetryLayerPreferenceStore struct {
 	store.PreferenceStore
 	Root *RetryLayer
@@ -303,11 +488,21 @@ type RetryLayerProductNoticesStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPushNotificationReceiptStore struct {
+	store.PushNotificationReceiptStore
+	Root *RetryLayer
+}
+
 type RetryLayerReactionStore struct {
 	store.ReactionStore
 	Root *RetryLayer
 }
 
+type RetryLayerReminderStore struct {
+	store.ReminderStore
+	Root *RetryLayer
+}
+
 type RetryLayerRemoteClusterStore struct {
 	store.RemoteClusterStore
 	Root *RetryLayer
@@ -323,6 +518,11 @@ type RetryLayerRoleStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerScheduledConfigChangeStore struct {
+	store.ScheduledConfigChangeStore
+	Root *RetryLayer
+}
+
 type RetryLayerSchemeStore struct {
 	store.SchemeStore
 	Root *RetryLayer
@@ -348,11 +548,26 @@ type RetryLayerSystemStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerTablePartitionStore struct {
+	store.TablePartitionStore
+	Root *RetryLayer
+}
+
 type RetryLayerTeamStore struct {
 	store.TeamStore
 	Root *RetryLayer
 }
 
+type RetryLayerTeamInviteLinkStore struct {
+	store.TeamInviteLinkStore
+	Root *RetryLayer
+}
+
+type RetryLayerTeamTemplateStore struct {
+	store.TeamTemplateStore
+	Root *RetryLayer
+}
+
 type RetryLayerTermsOfServiceStore struct {
 	store.TermsOfServiceStore
 	Root *RetryLayer
@@ -383,6 +598,11 @@ type RetryLayerUserAccessTokenStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerUserDeviceStore struct {
+	store.UserDeviceStore
+	Root *RetryLayer
+}
+
 type RetryLayerUserTermsOfServiceStore struct {
 	store.UserTermsOfServiceStore
 	Root *RetryLayer
@@ -577,6 +797,90 @@ func (s *RetryLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
 
 }
 
+func (s *RetryLayerChangeEventStore) GetUnpublished(limit int) ([]*model.ChangeEvent, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChangeEventStore.GetUnpublished(limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChangeEventStore) MarkPublished(ids []string, publishedAt int64) error {
+
+	tries := 0
+	for {
+		err := s.ChangeEventStore.MarkPublished(ids, publishedAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChangeEventStore) PermanentDeletePublishedBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChangeEventStore.PermanentDeletePublishedBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChangeEventStore) Save(event *model.ChangeEvent) (*model.ChangeEvent, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChangeEventStore.Save(event)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) AnalyticsDeletedTypeCount(teamID string, channelType model.ChannelType) (int64, error) {
 
 	tries := 0
@@ -1213,6 +1517,27 @@ func (s *RetryLayerChannelStore) GetChannelUnread(channelID string, userID strin
 
 }
 
+func (s *RetryLayerChannelStore) GetChannelUnreadsForUser(userID string) ([]*model.ChannelUnread, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetChannelUnreadsForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
 
 	tries := 0
@@ -1534,6 +1859,27 @@ func (s *RetryLayerChannelStore) GetMemberCountFromCache(channelID string) int64
 
 }
 
+func (s *RetryLayerChannelStore) GetMemberCounts(channelID string) (*model.ChannelMemberCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetMemberCounts(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
 
 	tries := 0
@@ -2212,6 +2558,27 @@ func (s *RetryLayerChannelStore) PermanentDeleteMembersByUser(userID string) err
 
 }
 
+func (s *RetryLayerChannelStore) ReconcileMemberCounts(afterChannelID string, limit int) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.ReconcileMemberCounts(afterChannelID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) RemoveAllDeactivatedMembers(channelID string) error {
 
 	tries := 0
@@ -2548,11 +2915,11 @@ func (s *RetryLayerChannelStore) SetDeleteAt(channelID string, deleteAt int64, u
 
 }
 
-func (s *RetryLayerChannelStore) SetShared(channelId string, shared bool) error {
+func (s *RetryLayerChannelStore) SetPinnedPostsOrder(channelID string, postIDs []string) error {
 
 	tries := 0
 	for {
-		err := s.ChannelStore.SetShared(channelId, shared)
+		err := s.ChannelStore.SetPinnedPostsOrder(channelID, postIDs)
 		if err == nil {
 			return nil
 		}
@@ -2569,28 +2936,49 @@ func (s *RetryLayerChannelStore) SetShared(channelId string, shared bool) error
 
 }
 
-func (s *RetryLayerChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+func (s *RetryLayerChannelStore) SetShared(channelId string, shared bool) error {
 
 	tries := 0
 	for {
-		result, err := s.ChannelStore.Update(channel)
+		err := s.ChannelStore.SetShared(channelId, shared)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerChannelStore) UpdateLastViewedAt(channelIds []string, userID string) (map[string]int64, error) {
+func (s *RetryLayerChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.Update(channel)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelStore) UpdateLastViewedAt(channelIds []string, userID string) (map[string]int64, error) {
 
 	tries := 0
 	for {
@@ -2821,11 +3209,11 @@ func (s *RetryLayerChannelStore) UserBelongsToChannels(userID string, channelIds
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerChannelStore) UsersShareChannel(userID string, otherUserID string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelMemberHistoryStore.DeleteOrphanedRows(limit)
+		result, err := s.ChannelStore.UsersShareChannel(userID, otherUserID)
 		if err == nil {
 			return result, nil
 		}
@@ -2842,32 +3230,32 @@ func (s *RetryLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
+func (s *RetryLayerChannelBookmarkStore) Delete(id string, deleteAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.ChannelMemberHistoryStore.GetChannelsLeftSince(userID, since)
+		err := s.ChannelBookmarkStore.Delete(id, deleteAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
+func (s *RetryLayerChannelBookmarkStore) Get(id string, includeDeleted bool) (*model.ChannelBookmark, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
+		result, err := s.ChannelBookmarkStore.Get(id, includeDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -2884,53 +3272,53 @@ func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
+func (s *RetryLayerChannelBookmarkStore) GetForChannel(channelID string) ([]*model.ChannelBookmark, error) {
 
 	tries := 0
 	for {
-		err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
+		result, err := s.ChannelBookmarkStore.GetForChannel(channelID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
+func (s *RetryLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
 
 	tries := 0
 	for {
-		err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
+		result, err := s.ChannelBookmarkStore.Save(bookmark)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.ChannelBookmarkStore.Update(bookmark)
 		if err == nil {
 			return result, nil
 		}
@@ -2947,32 +3335,32 @@ func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64
 
 }
 
-func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *RetryLayerChannelBookmarkStore) UpdateSortOrder(bookmarkID string, channelID string, newIndex int64) ([]*model.ChannelBookmark, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		result, err := s.ChannelBookmarkStore.UpdateSortOrder(bookmarkID, channelID, newIndex)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerClusterDiscoveryStore) Cleanup() error {
+func (s *RetryLayerChannelEventStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.ClusterDiscoveryStore.Cleanup()
+		err := s.ChannelEventStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -2989,11 +3377,11 @@ func (s *RetryLayerClusterDiscoveryStore) Cleanup() error {
 
 }
 
-func (s *RetryLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *RetryLayerChannelEventStore) Get(id string) (*model.ChannelEvent, error) {
 
 	tries := 0
 	for {
-		result, err := s.ClusterDiscoveryStore.Delete(discovery)
+		result, err := s.ChannelEventStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -3010,11 +3398,11 @@ func (s *RetryLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscove
 
 }
 
-func (s *RetryLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *RetryLayerChannelEventStore) GetDue(now int64, limit int) ([]*model.ChannelEvent, error) {
 
 	tries := 0
 	for {
-		result, err := s.ClusterDiscoveryStore.Exists(discovery)
+		result, err := s.ChannelEventStore.GetDue(now, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3031,11 +3419,11 @@ func (s *RetryLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscove
 
 }
 
-func (s *RetryLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+func (s *RetryLayerChannelEventStore) GetForChannel(channelID string) ([]*model.ChannelEvent, error) {
 
 	tries := 0
 	for {
-		result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+		result, err := s.ChannelEventStore.GetForChannel(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -3052,74 +3440,74 @@ func (s *RetryLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterNa
 
 }
 
-func (s *RetryLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+func (s *RetryLayerChannelEventStore) Save(event *model.ChannelEvent) (*model.ChannelEvent, error) {
 
 	tries := 0
 	for {
-		err := s.ClusterDiscoveryStore.Save(discovery)
+		result, err := s.ChannelEventStore.Save(event)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+func (s *RetryLayerChannelEventStore) Update(event *model.ChannelEvent) (*model.ChannelEvent, error) {
 
 	tries := 0
 	for {
-		err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+		result, err := s.ChannelEventStore.Update(event)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
+func (s *RetryLayerChannelJoinRequestStore) Cleanup(expiryTime int64, batchSize int) error {
 
 	tries := 0
 	for {
-		result, err := s.CommandStore.AnalyticsCommandCount(teamID)
+		err := s.ChannelJoinRequestStore.Cleanup(expiryTime, batchSize)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerCommandStore) Delete(commandID string, time int64) error {
+func (s *RetryLayerChannelJoinRequestStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.CommandStore.Delete(commandID, time)
+		err := s.ChannelJoinRequestStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -3136,11 +3524,11 @@ func (s *RetryLayerCommandStore) Delete(commandID string, time int64) error {
 
 }
 
-func (s *RetryLayerCommandStore) Get(id string) (*model.Command, error) {
+func (s *RetryLayerChannelJoinRequestStore) Expire(now int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.CommandStore.Get(id)
+		result, err := s.ChannelJoinRequestStore.Expire(now)
 		if err == nil {
 			return result, nil
 		}
@@ -3157,11 +3545,11 @@ func (s *RetryLayerCommandStore) Get(id string) (*model.Command, error) {
 
 }
 
-func (s *RetryLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, error) {
+func (s *RetryLayerChannelJoinRequestStore) Get(id string) (*model.ChannelJoinRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.CommandStore.GetByTeam(teamID)
+		result, err := s.ChannelJoinRequestStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -3178,11 +3566,11 @@ func (s *RetryLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, err
 
 }
 
-func (s *RetryLayerCommandStore) GetByTrigger(teamID string, trigger string) (*model.Command, error) {
+func (s *RetryLayerChannelJoinRequestStore) GetPendingForChannel(channelID string, now int64) ([]*model.ChannelJoinRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.CommandStore.GetByTrigger(teamID, trigger)
+		result, err := s.ChannelJoinRequestStore.GetPendingForChannel(channelID, now)
 		if err == nil {
 			return result, nil
 		}
@@ -3199,53 +3587,53 @@ func (s *RetryLayerCommandStore) GetByTrigger(teamID string, trigger string) (*m
 
 }
 
-func (s *RetryLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
+func (s *RetryLayerChannelJoinRequestStore) Save(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error) {
 
 	tries := 0
 	for {
-		err := s.CommandStore.PermanentDeleteByTeam(teamID)
+		result, err := s.ChannelJoinRequestStore.Save(request)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerCommandStore) PermanentDeleteByUser(userID string) error {
+func (s *RetryLayerChannelJoinRequestStore) Update(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error) {
 
 	tries := 0
 	for {
-		err := s.CommandStore.PermanentDeleteByUser(userID)
+		result, err := s.ChannelJoinRequestStore.Update(request)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+func (s *RetryLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.CommandStore.Save(webhook)
+		result, err := s.ChannelMemberHistoryStore.DeleteOrphanedRows(limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3262,11 +3650,11 @@ func (s *RetryLayerCommandStore) Save(webhook *model.Command) (*model.Command, e
 
 }
 
-func (s *RetryLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+func (s *RetryLayerChannelMemberHistoryStore) GetChannelsForUserDuring(userID string, startTime int64, endTime int64) ([]*model.ChannelMemberHistory, error) {
 
 	tries := 0
 	for {
-		result, err := s.CommandStore.Update(hook)
+		result, err := s.ChannelMemberHistoryStore.GetChannelsForUserDuring(userID, startTime, endTime)
 		if err == nil {
 			return result, nil
 		}
@@ -3283,17 +3671,11 @@ func (s *RetryLayerCommandStore) Update(hook *model.Command) (*model.Command, er
 
 }
 
-func (s *RetryLayerCommandWebhookStore) Cleanup() {
-
-	s.CommandWebhookStore.Cleanup()
-
-}
-
-func (s *RetryLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+func (s *RetryLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.CommandWebhookStore.Get(id)
+		result, err := s.ChannelMemberHistoryStore.GetChannelsLeftSince(userID, since)
 		if err == nil {
 			return result, nil
 		}
@@ -3310,11 +3692,11 @@ func (s *RetryLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, e
 
 }
 
-func (s *RetryLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+func (s *RetryLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
 
 	tries := 0
 	for {
-		result, err := s.CommandWebhookStore.Save(webhook)
+		result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -3331,11 +3713,11 @@ func (s *RetryLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*mo
 
 }
 
-func (s *RetryLayerCommandWebhookStore) TryUse(id string, limit int) error {
+func (s *RetryLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
 
 	tries := 0
 	for {
-		err := s.CommandWebhookStore.TryUse(id, limit)
+		err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
 		if err == nil {
 			return nil
 		}
@@ -3352,32 +3734,32 @@ func (s *RetryLayerCommandWebhookStore) TryUse(id string, limit int) error {
 
 }
 
-func (s *RetryLayerComplianceStore) ComplianceExport(compliance *model.Compliance, cursor model.ComplianceExportCursor, limit int) ([]*model.CompliancePost, model.ComplianceExportCursor, error) {
+func (s *RetryLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.ComplianceStore.ComplianceExport(compliance, cursor, limit)
+		err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerComplianceStore) Get(id string) (*model.Compliance, error) {
+func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ComplianceStore.Get(id)
+		result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3394,53 +3776,53 @@ func (s *RetryLayerComplianceStore) Get(id string) (*model.Compliance, error) {
 
 }
 
-func (s *RetryLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.ComplianceStore.GetAll(offset, limit)
+		result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerComplianceStore) MessageExport(cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+func (s *RetryLayerChannelMemberTimeoutStore) Delete(channelID string, userID string) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.ComplianceStore.MessageExport(cursor, limit)
+		err := s.ChannelMemberTimeoutStore.Delete(channelID, userID)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+func (s *RetryLayerChannelMemberTimeoutStore) Get(channelID string, userID string) (*model.ChannelMemberTimeout, error) {
 
 	tries := 0
 	for {
-		result, err := s.ComplianceStore.Save(compliance)
+		result, err := s.ChannelMemberTimeoutStore.Get(channelID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -3457,11 +3839,11 @@ func (s *RetryLayerComplianceStore) Save(compliance *model.Compliance) (*model.C
 
 }
 
-func (s *RetryLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+func (s *RetryLayerChannelMemberTimeoutStore) GetForChannel(channelID string, now int64) ([]*model.ChannelMemberTimeout, error) {
 
 	tries := 0
 	for {
-		result, err := s.ComplianceStore.Update(compliance)
+		result, err := s.ChannelMemberTimeoutStore.GetForChannel(channelID, now)
 		if err == nil {
 			return result, nil
 		}
@@ -3478,11 +3860,32 @@ func (s *RetryLayerComplianceStore) Update(compliance *model.Compliance) (*model
 
 }
 
-func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+func (s *RetryLayerChannelMemberTimeoutStore) Save(timeout *model.ChannelMemberTimeout) (*model.ChannelMemberTimeout, error) {
 
 	tries := 0
 	for {
-		err := s.EmojiStore.Delete(emoji, time)
+		result, err := s.ChannelMemberTimeoutStore.Save(timeout)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerClusterDiscoveryStore) Cleanup() error {
+
+	tries := 0
+	for {
+		err := s.ClusterDiscoveryStore.Cleanup()
 		if err == nil {
 			return nil
 		}
@@ -3499,11 +3902,11 @@ func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 
 }
 
-func (s *RetryLayerEmojiStore) Get(ctx context.Context, id string, allowFromCache bool) (*model.Emoji, error) {
+func (s *RetryLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Get(ctx, id, allowFromCache)
+		result, err := s.ClusterDiscoveryStore.Delete(discovery)
 		if err == nil {
 			return result, nil
 		}
@@ -3520,11 +3923,11 @@ func (s *RetryLayerEmojiStore) Get(ctx context.Context, id string, allowFromCach
 
 }
 
-func (s *RetryLayerEmojiStore) GetByName(ctx context.Context, name string, allowFromCache bool) (*model.Emoji, error) {
+func (s *RetryLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetByName(ctx, name, allowFromCache)
+		result, err := s.ClusterDiscoveryStore.Exists(discovery)
 		if err == nil {
 			return result, nil
 		}
@@ -3541,11 +3944,11 @@ func (s *RetryLayerEmojiStore) GetByName(ctx context.Context, name string, allow
 
 }
 
-func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+func (s *RetryLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetList(offset, limit, sort)
+		result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
 		if err == nil {
 			return result, nil
 		}
@@ -3562,53 +3965,53 @@ func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 
 }
 
-func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+func (s *RetryLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetMultipleByName(names)
+		err := s.ClusterDiscoveryStore.Save(discovery)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+func (s *RetryLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Save(emoji)
+		err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+func (s *RetryLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+		result, err := s.CommandStore.AnalyticsCommandCount(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -3625,11 +4028,11 @@ func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 
 }
 
-func (s *RetryLayerFileInfoStore) AttachToPost(fileID string, postID string, creatorID string) error {
+func (s *RetryLayerCommandStore) Delete(commandID string, time int64) error {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.AttachToPost(fileID, postID, creatorID)
+		err := s.CommandStore.Delete(commandID, time)
 		if err == nil {
 			return nil
 		}
@@ -3646,17 +4049,11 @@ func (s *RetryLayerFileInfoStore) AttachToPost(fileID string, postID string, cre
 
 }
 
-func (s *RetryLayerFileInfoStore) ClearCaches() {
-
-	s.FileInfoStore.ClearCaches()
-
-}
-
-func (s *RetryLayerFileInfoStore) CountAll() (int64, error) {
+func (s *RetryLayerCommandStore) Get(id string) (*model.Command, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.CountAll()
+		result, err := s.CommandStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -3673,11 +4070,11 @@ func (s *RetryLayerFileInfoStore) CountAll() (int64, error) {
 
 }
 
-func (s *RetryLayerFileInfoStore) DeleteForPost(postID string) (string, error) {
+func (s *RetryLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.DeleteForPost(postID)
+		result, err := s.CommandStore.GetByTeam(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -3694,11 +4091,11 @@ func (s *RetryLayerFileInfoStore) DeleteForPost(postID string) (string, error) {
 
 }
 
-func (s *RetryLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+func (s *RetryLayerCommandStore) GetByTrigger(teamID string, trigger string) (*model.Command, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Get(id)
+		result, err := s.CommandStore.GetByTrigger(teamID, trigger)
 		if err == nil {
 			return result, nil
 		}
@@ -3715,53 +4112,53 @@ func (s *RetryLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 
 }
 
-func (s *RetryLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+func (s *RetryLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetByIds(ids)
+		err := s.CommandStore.PermanentDeleteByTeam(teamID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+func (s *RetryLayerCommandStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetByPath(path)
+		err := s.CommandStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.FileForIndexing, error) {
+func (s *RetryLayerCommandStore) PermanentDeleteSoftDeletedBatch(before int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, limit)
+		result, err := s.CommandStore.PermanentDeleteSoftDeletedBatch(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3778,11 +4175,11 @@ func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, star
 
 }
 
-func (s *RetryLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+func (s *RetryLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
+		result, err := s.CommandStore.Save(webhook)
 		if err == nil {
 			return result, nil
 		}
@@ -3799,11 +4196,11 @@ func (s *RetryLayerFileInfoStore) GetForPost(postID string, readFromMaster bool,
 
 }
 
-func (s *RetryLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
+func (s *RetryLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetForUser(userID)
+		result, err := s.CommandStore.Update(hook)
 		if err == nil {
 			return result, nil
 		}
@@ -3820,11 +4217,17 @@ func (s *RetryLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo,
 
 }
 
-func (s *RetryLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
+func (s *RetryLayerCommandWebhookStore) Cleanup() {
+
+	s.CommandWebhookStore.Cleanup()
+
+}
+
+func (s *RetryLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetFromMaster(id)
+		result, err := s.CommandWebhookStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -3841,11 +4244,11 @@ func (s *RetryLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, err
 
 }
 
-func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+func (s *RetryLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+		result, err := s.CommandWebhookStore.Save(webhook)
 		if err == nil {
 			return result, nil
 		}
@@ -3862,17 +4265,11 @@ func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *mod
 
 }
 
-func (s *RetryLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
-
-	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
-
-}
-
-func (s *RetryLayerFileInfoStore) PermanentDelete(fileID string) error {
+func (s *RetryLayerCommandWebhookStore) TryUse(id string, limit int) error {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.PermanentDelete(fileID)
+		err := s.CommandWebhookStore.TryUse(id, limit)
 		if err == nil {
 			return nil
 		}
@@ -3889,32 +4286,32 @@ func (s *RetryLayerFileInfoStore) PermanentDelete(fileID string) error {
 
 }
 
-func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerComplianceStore) ComplianceExport(compliance *model.Compliance, cursor model.ComplianceExportCursor, limit int) ([]*model.CompliancePost, model.ComplianceExportCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+		result, resultVar1, err := s.ComplianceStore.ComplianceExport(compliance, cursor, limit)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(userID string) (int64, error) {
+func (s *RetryLayerComplianceStore) Get(id string) (*model.Compliance, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.PermanentDeleteByUser(userID)
+		result, err := s.ComplianceStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -3931,11 +4328,11 @@ func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(userID string) (int64, e
 
 }
 
-func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *RetryLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Save(info)
+		result, err := s.ComplianceStore.GetAll(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -3952,53 +4349,53 @@ func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, e
 
 }
 
-func (s *RetryLayerFileInfoStore) Search(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
+func (s *RetryLayerComplianceStore) MessageExport(cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Search(paramsList, userID, teamID, page, perPage)
+		result, resultVar1, err := s.ComplianceStore.MessageExport(cursor, limit)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) SetContent(fileID string, content string) error {
+func (s *RetryLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.SetContent(fileID, content)
+		result, err := s.ComplianceStore.Save(compliance)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *RetryLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Upsert(info)
+		result, err := s.ComplianceStore.Update(compliance)
 		if err == nil {
 			return result, nil
 		}
@@ -4015,32 +4412,32 @@ func (s *RetryLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo,
 
 }
 
-func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *RetryLayerConnectivityTestResultStore) Cleanup(expiryTime int64, batchSize int) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+		err := s.ConnectivityTestResultStore.Cleanup(expiryTime, batchSize)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *RetryLayerConnectivityTestResultStore) GetHistory(service string, offset int, limit int) ([]*model.ConnectivityTestResult, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+		result, err := s.ConnectivityTestResultStore.GetHistory(service, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4057,11 +4454,11 @@ func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string,
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, includeRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
+func (s *RetryLayerConnectivityTestResultStore) Save(result *model.ConnectivityTestResult) (*model.ConnectivityTestResult, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, includeRemovedMembers)
+		result, err := s.ConnectivityTestResultStore.Save(result)
 		if err == nil {
 			return result, nil
 		}
@@ -4078,32 +4475,32 @@ func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *strin
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
+func (s *RetryLayerDirectMessageRequestStore) Delete(channelID string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersToRemove(channelID)
+		err := s.DirectMessageRequestStore.Delete(channelID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
+func (s *RetryLayerDirectMessageRequestStore) Get(channelID string) (*model.DirectMessageRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+		result, err := s.DirectMessageRequestStore.Get(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -4120,11 +4517,11 @@ func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID st
 
 }
 
-func (s *RetryLayerGroupStore) CountGroupsByChannel(channelID string, opts model.GroupSearchOpts) (int64, error) {
+func (s *RetryLayerDirectMessageRequestStore) GetPendingForRecipient(recipientID string) ([]*model.DirectMessageRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountGroupsByChannel(channelID, opts)
+		result, err := s.DirectMessageRequestStore.GetPendingForRecipient(recipientID)
 		if err == nil {
 			return result, nil
 		}
@@ -4141,11 +4538,11 @@ func (s *RetryLayerGroupStore) CountGroupsByChannel(channelID string, opts model
 
 }
 
-func (s *RetryLayerGroupStore) CountGroupsByTeam(teamID string, opts model.GroupSearchOpts) (int64, error) {
+func (s *RetryLayerDirectMessageRequestStore) Save(request *model.DirectMessageRequest) (*model.DirectMessageRequest, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountGroupsByTeam(teamID, opts)
+		result, err := s.DirectMessageRequestStore.Save(request)
 		if err == nil {
 			return result, nil
 		}
@@ -4162,32 +4559,32 @@ func (s *RetryLayerGroupStore) CountGroupsByTeam(teamID string, opts model.Group
 
 }
 
-func (s *RetryLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
+func (s *RetryLayerDirectMessageRequestStore) UpdateStatus(channelID string, status string, updateAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+		err := s.DirectMessageRequestStore.UpdateStatus(channelID, status, updateAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
+func (s *RetryLayerEmojiStore) CountByTeam(teamID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Create(group)
+		result, err := s.EmojiStore.CountByTeam(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -4204,32 +4601,32 @@ func (s *RetryLayerGroupStore) Create(group *model.Group) (*model.Group, error)
 
 }
 
-func (s *RetryLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
+		err := s.EmojiStore.Delete(emoji, time)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds) (*model.Group, error) {
+func (s *RetryLayerEmojiStore) Get(ctx context.Context, id string, allowFromCache bool) (*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CreateWithUserIds(group)
+		result, err := s.EmojiStore.Get(ctx, id, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -4246,11 +4643,11 @@ func (s *RetryLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds)
 
 }
 
-func (s *RetryLayerGroupStore) Delete(groupID string) (*model.Group, error) {
+func (s *RetryLayerEmojiStore) GetByName(ctx context.Context, name string, allowFromCache bool) (*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Delete(groupID)
+		result, err := s.EmojiStore.GetByName(ctx, name, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -4267,11 +4664,11 @@ func (s *RetryLayerGroupStore) Delete(groupID string) (*model.Group, error) {
 
 }
 
-func (s *RetryLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+		result, err := s.EmojiStore.GetList(offset, limit, sort)
 		if err == nil {
 			return result, nil
 		}
@@ -4288,11 +4685,11 @@ func (s *RetryLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID st
 
 }
 
-func (s *RetryLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *RetryLayerEmojiStore) GetListForTeam(teamID string, offset int, limit int, sort string) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.DeleteMember(groupID, userID)
+		result, err := s.EmojiStore.GetListForTeam(teamID, offset, limit, sort)
 		if err == nil {
 			return result, nil
 		}
@@ -4309,11 +4706,11 @@ func (s *RetryLayerGroupStore) DeleteMember(groupID string, userID string) (*mod
 
 }
 
-func (s *RetryLayerGroupStore) DeleteMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.DeleteMembers(groupID, userIDs)
+		result, err := s.EmojiStore.GetMultipleByName(names)
 		if err == nil {
 			return result, nil
 		}
@@ -4330,11 +4727,11 @@ func (s *RetryLayerGroupStore) DeleteMembers(groupID string, userIDs []string) (
 
 }
 
-func (s *RetryLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
+func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.DistinctGroupMemberCount()
+		result, err := s.EmojiStore.Save(emoji)
 		if err == nil {
 			return result, nil
 		}
@@ -4351,11 +4748,11 @@ func (s *RetryLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) Get(groupID string) (*model.Group, error) {
+func (s *RetryLayerEmojiStore) SaveForTeam(emoji *model.Emoji, maxEmojiPerTeam int64) (*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Get(groupID)
+		result, err := s.EmojiStore.SaveForTeam(emoji, maxEmojiPerTeam)
 		if err == nil {
 			return result, nil
 		}
@@ -4372,11 +4769,11 @@ func (s *RetryLayerGroupStore) Get(groupID string) (*model.Group, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
+func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetAllBySource(groupSource)
+		result, err := s.EmojiStore.Search(name, prefixOnly, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4393,32 +4790,38 @@ func (s *RetryLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]
 
 }
 
-func (s *RetryLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
+func (s *RetryLayerFileInfoStore) AttachToPost(fileID string, postID string, creatorID string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+		err := s.FileInfoStore.AttachToPost(fileID, postID, creatorID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
+func (s *RetryLayerFileInfoStore) ClearCaches() {
+
+	s.FileInfoStore.ClearCaches()
+
+}
+
+func (s *RetryLayerFileInfoStore) CountAll() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByIDs(groupIDs)
+		result, err := s.FileInfoStore.CountAll()
 		if err == nil {
 			return result, nil
 		}
@@ -4435,11 +4838,11 @@ func (s *RetryLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, erro
 
 }
 
-func (s *RetryLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
+func (s *RetryLayerFileInfoStore) CountDedupReferences(fileID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByName(name, opts)
+		result, err := s.FileInfoStore.CountDedupReferences(fileID)
 		if err == nil {
 			return result, nil
 		}
@@ -4456,11 +4859,11 @@ func (s *RetryLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts
 
 }
 
-func (s *RetryLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
+func (s *RetryLayerFileInfoStore) DeleteForPost(postID string) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+		result, err := s.FileInfoStore.DeleteForPost(postID)
 		if err == nil {
 			return result, nil
 		}
@@ -4477,11 +4880,11 @@ func (s *RetryLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.
 
 }
 
-func (s *RetryLayerGroupStore) GetByUser(userID string) ([]*model.Group, error) {
+func (s *RetryLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetByUser(userID)
+		result, err := s.FileInfoStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -4498,11 +4901,11 @@ func (s *RetryLayerGroupStore) GetByUser(userID string) ([]*model.Group, error)
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+func (s *RetryLayerFileInfoStore) GetByContentHash(hash string, channelID string) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+		result, err := s.FileInfoStore.GetByContentHash(hash, channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -4519,11 +4922,11 @@ func (s *RetryLayerGroupStore) GetGroupSyncable(groupID string, syncableID strin
 
 }
 
-func (s *RetryLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, error) {
+func (s *RetryLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroups(page, perPage, opts)
+		result, err := s.FileInfoStore.GetByIds(ids)
 		if err == nil {
 			return result, nil
 		}
@@ -4540,11 +4943,11 @@ func (s *RetryLayerGroupStore) GetGroups(page int, perPage int, opts model.Group
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
+func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
+		result, err := s.FileInfoStore.GetByPath(path)
 		if err == nil {
 			return result, nil
 		}
@@ -4561,11 +4964,11 @@ func (s *RetryLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+func (s *RetryLayerFileInfoStore) GetExpiredForRetentionPolicies(now int64, afterID string, limit int) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupsByChannel(channelID, opts)
+		result, err := s.FileInfoStore.GetExpiredForRetentionPolicies(now, afterID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4582,11 +4985,11 @@ func (s *RetryLayerGroupStore) GetGroupsByChannel(channelID string, opts model.G
 
 }
 
-func (s *RetryLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.FileForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetGroupsByTeam(teamID, opts)
+		result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4603,11 +5006,11 @@ func (s *RetryLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSe
 
 }
 
-func (s *RetryLayerGroupStore) GetMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *RetryLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMember(groupID, userID)
+		result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -4624,11 +5027,11 @@ func (s *RetryLayerGroupStore) GetMember(groupID string, userID string) (*model.
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
+func (s *RetryLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberCount(groupID)
+		result, err := s.FileInfoStore.GetForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -4645,11 +5048,11 @@ func (s *RetryLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsers(groupID)
+		result, err := s.FileInfoStore.GetFromMaster(id)
 		if err == nil {
 			return result, nil
 		}
@@ -4666,11 +5069,11 @@ func (s *RetryLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, er
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) GetOrphaned(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
+		result, err := s.FileInfoStore.GetOrphaned(createdBefore, afterID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4687,11 +5090,11 @@ func (s *RetryLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID strin
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) GetReferencedIds(fileIDs []string) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
+		result, err := s.FileInfoStore.GetReferencedIds(fileIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -4708,11 +5111,11 @@ func (s *RetryLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channe
 
 }
 
-func (s *RetryLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+		result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
 		if err == nil {
 			return result, nil
 		}
@@ -4729,32 +5132,38 @@ func (s *RetryLayerGroupStore) GetMemberUsersPage(groupID string, page int, perP
 
 }
 
-func (s *RetryLayerGroupStore) GetNonMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
+func (s *RetryLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
+
+}
+
+func (s *RetryLayerFileInfoStore) PermanentDelete(fileID string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GetNonMemberUsersPage(groupID, page, perPage)
+		err := s.FileInfoStore.PermanentDelete(fileID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) GroupChannelCount() (int64, error) {
+func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupChannelCount()
+		result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4771,11 +5180,11 @@ func (s *RetryLayerGroupStore) GroupChannelCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GroupCount() (int64, error) {
+func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(userID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupCount()
+		result, err := s.FileInfoStore.PermanentDeleteByUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -4792,11 +5201,11 @@ func (s *RetryLayerGroupStore) GroupCount() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GroupCountBySource(source model.GroupSource) (int64, error) {
+func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupCountBySource(source)
+		result, err := s.FileInfoStore.Save(info)
 		if err == nil {
 			return result, nil
 		}
@@ -4813,11 +5222,11 @@ func (s *RetryLayerGroupStore) GroupCountBySource(source model.GroupSource) (int
 
 }
 
-func (s *RetryLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
+func (s *RetryLayerFileInfoStore) Search(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupCountWithAllowReference()
+		result, err := s.FileInfoStore.Search(paramsList, userID, teamID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -4834,74 +5243,74 @@ func (s *RetryLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
 
 }
 
-func (s *RetryLayerGroupStore) GroupMemberCount() (int64, error) {
+func (s *RetryLayerFileInfoStore) SetAltText(fileID string, altText string, generated bool) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupMemberCount()
+		err := s.FileInfoStore.SetAltText(fileID, altText, generated)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) GroupTeamCount() (int64, error) {
+func (s *RetryLayerFileInfoStore) SetContent(fileID string, content string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.GroupTeamCount()
+		err := s.FileInfoStore.SetContent(fileID, content)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) PermanentDeleteMembersByUser(userID string) error {
+func (s *RetryLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		err := s.GroupStore.PermanentDeleteMembersByUser(userID)
+		result, err := s.FileInfoStore.Upsert(info)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
+		result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -4918,11 +5327,11 @@ func (s *RetryLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncab
 
 }
 
-func (s *RetryLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+		result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -4939,11 +5348,11 @@ func (s *RetryLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, group
 
 }
 
-func (s *RetryLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, includeRemovedMembers bool) ([]*model.UserTeamIDPair, error) {
+func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, includeRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.TeamMembersToAdd(since, teamID, includeRemovedMembers)
+		result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, includeRemovedMembers)
 		if err == nil {
 			return result, nil
 		}
@@ -4960,11 +5369,11 @@ func (s *RetryLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, inc
 
 }
 
-func (s *RetryLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
+func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.TeamMembersToRemove(teamID)
+		result, err := s.GroupStore.ChannelMembersToRemove(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -4981,11 +5390,11 @@ func (s *RetryLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.Tea
 
 }
 
-func (s *RetryLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
+func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.Update(group)
+		result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -5002,11 +5411,11 @@ func (s *RetryLayerGroupStore) Update(group *model.Group) (*model.Group, error)
 
 }
 
-func (s *RetryLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+func (s *RetryLayerGroupStore) CountGroupsByChannel(channelID string, opts model.GroupSearchOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+		result, err := s.GroupStore.CountGroupsByChannel(channelID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -5023,11 +5432,11 @@ func (s *RetryLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyn
 
 }
 
-func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *RetryLayerGroupStore) CountGroupsByTeam(teamID string, opts model.GroupSearchOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.UpsertMember(groupID, userID)
+		result, err := s.GroupStore.CountGroupsByTeam(teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -5044,11 +5453,11 @@ func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*mod
 
 }
 
-func (s *RetryLayerGroupStore) UpsertMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+func (s *RetryLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.UpsertMembers(groupID, userIDs)
+		result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -5065,32 +5474,32 @@ func (s *RetryLayerGroupStore) UpsertMembers(groupID string, userIDs []string) (
 
 }
 
-func (s *RetryLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
+func (s *RetryLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
 
 	tries := 0
 	for {
-		err := s.JobStore.Cleanup(expiryTime, batchSize)
+		result, err := s.GroupStore.Create(group)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerJobStore) Delete(id string) (string, error) {
+func (s *RetryLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.Delete(id)
+		result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
 		if err == nil {
 			return result, nil
 		}
@@ -5107,11 +5516,11 @@ func (s *RetryLayerJobStore) Delete(id string) (string, error) {
 
 }
 
-func (s *RetryLayerJobStore) Get(id string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.Get(id)
+		result, err := s.GroupStore.CreateWithUserIds(group)
 		if err == nil {
 			return result, nil
 		}
@@ -5128,11 +5537,11 @@ func (s *RetryLayerJobStore) Get(id string) (*model.Job, error) {
 
 }
 
-func (s *RetryLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) Delete(groupID string) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByStatus(status)
+		result, err := s.GroupStore.Delete(groupID)
 		if err == nil {
 			return result, nil
 		}
@@ -5149,11 +5558,11 @@ func (s *RetryLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error)
 
 }
 
-func (s *RetryLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByType(jobType)
+		result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -5170,11 +5579,11 @@ func (s *RetryLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error)
 
 }
 
-func (s *RetryLayerJobStore) GetAllByTypeAndStatus(jobType string, status string) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByTypeAndStatus(jobType, status)
+		result, err := s.GroupStore.DeleteMember(groupID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -5191,11 +5600,11 @@ func (s *RetryLayerJobStore) GetAllByTypeAndStatus(jobType string, status string
 
 }
 
-func (s *RetryLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) DeleteMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+		result, err := s.GroupStore.DeleteMembers(groupID, userIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -5212,11 +5621,11 @@ func (s *RetryLayerJobStore) GetAllByTypePage(jobType string, offset int, limit
 
 }
 
-func (s *RetryLayerJobStore) GetAllByTypesPage(jobTypes []string, offset int, limit int) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllByTypesPage(jobTypes, offset, limit)
+		result, err := s.GroupStore.DistinctGroupMemberCount()
 		if err == nil {
 			return result, nil
 		}
@@ -5233,11 +5642,11 @@ func (s *RetryLayerJobStore) GetAllByTypesPage(jobTypes []string, offset int, li
 
 }
 
-func (s *RetryLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+func (s *RetryLayerGroupStore) Get(groupID string) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetAllPage(offset, limit)
+		result, err := s.GroupStore.Get(groupID)
 		if err == nil {
 			return result, nil
 		}
@@ -5254,11 +5663,11 @@ func (s *RetryLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, er
 
 }
 
-func (s *RetryLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+func (s *RetryLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+		result, err := s.GroupStore.GetAllBySource(groupSource)
 		if err == nil {
 			return result, nil
 		}
@@ -5275,11 +5684,11 @@ func (s *RetryLayerJobStore) GetCountByStatusAndType(status string, jobType stri
 
 }
 
-func (s *RetryLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+		result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -5296,11 +5705,11 @@ func (s *RetryLayerJobStore) GetNewestJobByStatusAndType(status string, jobType
 
 }
 
-func (s *RetryLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+		result, err := s.GroupStore.GetByIDs(groupIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -5317,11 +5726,11 @@ func (s *RetryLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jo
 
 }
 
-func (s *RetryLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.Save(job)
+		result, err := s.GroupStore.GetByName(name, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -5338,11 +5747,11 @@ func (s *RetryLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 
 }
 
-func (s *RetryLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+func (s *RetryLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+		result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
 		if err == nil {
 			return result, nil
 		}
@@ -5359,11 +5768,11 @@ func (s *RetryLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus
 
 }
 
-func (s *RetryLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+func (s *RetryLayerGroupStore) GetByUser(userID string) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.UpdateStatus(id, status)
+		result, err := s.GroupStore.GetByUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -5380,11 +5789,11 @@ func (s *RetryLayerJobStore) UpdateStatus(id string, status string) (*model.Job,
 
 }
 
-func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+func (s *RetryLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+		result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
 		if err == nil {
 			return result, nil
 		}
@@ -5401,11 +5810,11 @@ func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 
 }
 
-func (s *RetryLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
+func (s *RetryLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.LicenseStore.Get(id)
+		result, err := s.GroupStore.GetGroups(page, perPage, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -5422,11 +5831,11 @@ func (s *RetryLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
 
 }
 
-func (s *RetryLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
+func (s *RetryLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
 
 	tries := 0
 	for {
-		result, err := s.LicenseStore.GetAll()
+		result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -5443,11 +5852,11 @@ func (s *RetryLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
 
 }
 
-func (s *RetryLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+func (s *RetryLayerGroupStore) GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
 
 	tries := 0
 	for {
-		result, err := s.LicenseStore.Save(license)
+		result, err := s.GroupStore.GetGroupsByChannel(channelID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -5464,11 +5873,11 @@ func (s *RetryLayerLicenseStore) Save(license *model.LicenseRecord) (*model.Lice
 
 }
 
-func (s *RetryLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+func (s *RetryLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
 
 	tries := 0
 	for {
-		result, err := s.LinkMetadataStore.Get(url, timestamp)
+		result, err := s.GroupStore.GetGroupsByTeam(teamID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -5485,11 +5894,11 @@ func (s *RetryLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.L
 
 }
 
-func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+func (s *RetryLayerGroupStore) GetMember(groupID string, userID string) (*model.GroupMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.LinkMetadataStore.Save(linkMetadata)
+		result, err := s.GroupStore.GetMember(groupID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -5506,32 +5915,32 @@ func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 
 }
 
-func (s *RetryLayerOAuthStore) DeleteApp(id string) error {
+func (s *RetryLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.DeleteApp(id)
+		result, err := s.GroupStore.GetMemberCount(groupID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessData(token)
+		result, err := s.GroupStore.GetMemberUsers(groupID)
 		if err == nil {
 			return result, nil
 		}
@@ -5548,11 +5957,11 @@ func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, e
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+		result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -5569,11 +5978,11 @@ func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model
 
 }
 
-func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId string) ([]*model.AccessData, error) {
+func (s *RetryLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientId)
+		result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -5590,11 +5999,11 @@ func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId
 
 }
 
-func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetApp(id)
+		result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -5611,11 +6020,11 @@ func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 
 }
 
-func (s *RetryLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) GetNonMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
+		result, err := s.GroupStore.GetNonMemberUsersPage(groupID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -5632,11 +6041,11 @@ func (s *RetryLayerOAuthStore) GetAppByUser(userID string, offset int, limit int
 
 }
 
-func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) GroupChannelCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetApps(offset, limit)
+		result, err := s.GroupStore.GroupChannelCount()
 		if err == nil {
 			return result, nil
 		}
@@ -5653,11 +6062,11 @@ func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp
 
 }
 
-func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+func (s *RetryLayerGroupStore) GroupCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAuthData(code)
+		result, err := s.GroupStore.GroupCount()
 		if err == nil {
 			return result, nil
 		}
@@ -5674,11 +6083,11 @@ func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error)
 
 }
 
-func (s *RetryLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) GroupCountBySource(source model.GroupSource) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
+		result, err := s.GroupStore.GroupCountBySource(source)
 		if err == nil {
 			return result, nil
 		}
@@ -5695,11 +6104,11 @@ func (s *RetryLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limi
 
 }
 
-func (s *RetryLayerOAuthStore) GetPreviousAccessData(userID string, clientId string) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.GetPreviousAccessData(userID, clientId)
+		result, err := s.GroupStore.GroupCountWithAllowReference()
 		if err == nil {
 			return result, nil
 		}
@@ -5716,53 +6125,53 @@ func (s *RetryLayerOAuthStore) GetPreviousAccessData(userID string, clientId str
 
 }
 
-func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
+func (s *RetryLayerGroupStore) GroupMemberCount() (int64, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
+		result, err := s.GroupStore.GroupMemberCount()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAccessData(token string) error {
+func (s *RetryLayerGroupStore) GroupTeamCount() (int64, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAccessData(token)
+		result, err := s.GroupStore.GroupTeamCount()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAllAccessData() error {
+func (s *RetryLayerGroupStore) PermanentDeleteMembersByUser(userID string) error {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAllAccessData()
+		err := s.GroupStore.PermanentDeleteMembersByUser(userID)
 		if err == nil {
 			return nil
 		}
@@ -5779,32 +6188,32 @@ func (s *RetryLayerOAuthStore) RemoveAllAccessData() error {
 
 }
 
-func (s *RetryLayerOAuthStore) RemoveAuthData(code string) error {
+func (s *RetryLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
 
 	tries := 0
 	for {
-		err := s.OAuthStore.RemoveAuthData(code)
+		result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveAccessData(accessData)
+		result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -5821,11 +6230,11 @@ func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*mo
 
 }
 
-func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, includeRemovedMembers bool) ([]*model.UserTeamIDPair, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveApp(app)
+		result, err := s.GroupStore.TeamMembersToAdd(since, teamID, includeRemovedMembers)
 		if err == nil {
 			return result, nil
 		}
@@ -5842,11 +6251,11 @@ func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, er
 
 }
 
-func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+func (s *RetryLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.SaveAuthData(authData)
+		result, err := s.GroupStore.TeamMembersToRemove(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -5863,11 +6272,11 @@ func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.Au
 
 }
 
-func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *RetryLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.UpdateAccessData(accessData)
+		result, err := s.GroupStore.Update(group)
 		if err == nil {
 			return result, nil
 		}
@@ -5884,11 +6293,11 @@ func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*
 
 }
 
-func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *RetryLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
 
 	tries := 0
 	for {
-		result, err := s.OAuthStore.UpdateApp(app)
+		result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
 		if err == nil {
 			return result, nil
 		}
@@ -5905,11 +6314,11 @@ func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+		result, err := s.GroupStore.UpsertMember(groupID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -5926,11 +6335,11 @@ func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerGroupStore) UpsertMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+		result, err := s.GroupStore.UpsertMembers(groupID, userIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -5947,11 +6356,11 @@ func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 
 }
 
-func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
+func (s *RetryLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
 
 	tries := 0
 	for {
-		err := s.PluginStore.Delete(pluginID, key)
+		err := s.JobStore.Cleanup(expiryTime, batchSize)
 		if err == nil {
 			return nil
 		}
@@ -5968,53 +6377,53 @@ func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllExpired() error {
+func (s *RetryLayerJobStore) Delete(id string) (string, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllExpired()
+		result, err := s.JobStore.Delete(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+func (s *RetryLayerJobStore) Get(id string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllForPlugin(PluginID)
+		result, err := s.JobStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+func (s *RetryLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.Get(pluginID, key)
+		result, err := s.JobStore.GetAllByStatus(status)
 		if err == nil {
 			return result, nil
 		}
@@ -6031,11 +6440,11 @@ func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 
 }
 
-func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+func (s *RetryLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.List(pluginID, page, perPage)
+		result, err := s.JobStore.GetAllByType(jobType)
 		if err == nil {
 			return result, nil
 		}
@@ -6052,11 +6461,11 @@ func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 
 }
 
-func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+func (s *RetryLayerJobStore) GetAllByTypeAndStatus(jobType string, status string) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.SaveOrUpdate(keyVal)
+		result, err := s.JobStore.GetAllByTypeAndStatus(jobType, status)
 		if err == nil {
 			return result, nil
 		}
@@ -6073,11 +6482,11 @@ func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 
 }
 
-func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+func (s *RetryLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
+		result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6094,11 +6503,11 @@ func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, valu
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+func (s *RetryLayerJobStore) GetAllByTypesPage(jobTypes []string, offset int, limit int) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCount(options)
+		result, err := s.JobStore.GetAllByTypesPage(jobTypes, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6115,11 +6524,11 @@ func (s *RetryLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+func (s *RetryLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+		result, err := s.JobStore.GetAllPage(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6136,11 +6545,11 @@ func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+func (s *RetryLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+		result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
 		if err == nil {
 			return result, nil
 		}
@@ -6157,38 +6566,32 @@ func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (
 
 }
 
-func (s *RetryLayerPostStore) ClearCaches() {
-
-	s.PostStore.ClearCaches()
-
-}
-
-func (s *RetryLayerPostStore) Delete(postID string, time int64, deleteByID string) error {
+func (s *RetryLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.Delete(postID, time, deleteByID)
+		result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.DeleteOrphanedRows(limit)
+		result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
 		if err == nil {
 			return result, nil
 		}
@@ -6205,11 +6608,11 @@ func (s *RetryLayerPostStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string) (*model.PostList, error) {
+func (s *RetryLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Get(ctx, id, opts, userID)
+		result, err := s.JobStore.Save(job)
 		if err == nil {
 			return result, nil
 		}
@@ -6226,11 +6629,11 @@ func (s *RetryLayerPostStore) Get(ctx context.Context, id string, opts model.Get
 
 }
 
-func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string) ([]*model.DirectPostForExport, error) {
+func (s *RetryLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID)
+		result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
 		if err == nil {
 			return result, nil
 		}
@@ -6247,17 +6650,11 @@ func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 
 }
 
-func (s *RetryLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
-
-	return s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
-
-}
-
-func (s *RetryLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
+		result, err := s.JobStore.UpdateStatus(id, status)
 		if err == nil {
 			return result, nil
 		}
@@ -6274,11 +6671,11 @@ func (s *RetryLayerPostStore) GetFlaggedPosts(userID string, offset int, limit i
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+		result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
 		if err == nil {
 			return result, nil
 		}
@@ -6295,11 +6692,11 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+		result, err := s.LicenseStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -6316,11 +6713,11 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 
 }
 
-func (s *RetryLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
+func (s *RetryLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetLastPostRowCreateAt()
+		result, err := s.LicenseStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -6337,17 +6734,11 @@ func (s *RetryLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetMaxPostSize() int {
-
-	return s.PostStore.GetMaxPostSize()
-
-}
-
-func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
+func (s *RetryLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldest()
+		result, err := s.LicenseStore.Save(license)
 		if err == nil {
 			return result, nil
 		}
@@ -6364,11 +6755,11 @@ func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+func (s *RetryLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldestEntityCreationTime()
+		result, err := s.LinkMetadataStore.Get(url, timestamp)
 		if err == nil {
 			return result, nil
 		}
@@ -6385,11 +6776,11 @@ func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string) ([]*model.PostForExport, error) {
+func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID)
+		result, err := s.LinkMetadataStore.Save(linkMetadata)
 		if err == nil {
 			return result, nil
 		}
@@ -6406,11 +6797,11 @@ func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string
 
 }
 
-func (s *RetryLayerPostStore) GetPostAfterTime(channelID string, time int64, collapsedThreads bool) (*model.Post, error) {
+func (s *RetryLayerMfaBackupCodeStore) CountUnusedForUser(userID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostAfterTime(channelID, time, collapsedThreads)
+		result, err := s.MfaBackupCodeStore.CountUnusedForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -6427,53 +6818,53 @@ func (s *RetryLayerPostStore) GetPostAfterTime(channelID string, time int64, col
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdAfterTime(channelID string, time int64, collapsedThreads bool) (string, error) {
+func (s *RetryLayerMfaBackupCodeStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdAfterTime(channelID, time, collapsedThreads)
+		err := s.MfaBackupCodeStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelID string, time int64, collapsedThreads bool) (string, error) {
+func (s *RetryLayerMfaBackupCodeStore) ReplaceForUser(userID string, hashes []string, createAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdBeforeTime(channelID, time, collapsedThreads)
+		err := s.MfaBackupCodeStore.ReplaceForUser(userID, hashes, createAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *RetryLayerMfaBackupCodeStore) Use(userID string, hash string, usedAt int64) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPosts(options, allowFromCache)
+		result, err := s.MfaBackupCodeStore.Use(userID, hash, usedAt)
 		if err == nil {
 			return result, nil
 		}
@@ -6490,32 +6881,32 @@ func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromC
 
 }
 
-func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *RetryLayerOAuthStore) DeleteApp(id string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsAfter(options)
+		err := s.OAuthStore.DeleteApp(id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+func (s *RetryLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
+		result, err := s.OAuthStore.GetAccessData(token)
 		if err == nil {
 			return result, nil
 		}
@@ -6532,11 +6923,11 @@ func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPos
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *RetryLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBefore(options)
+		result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
 		if err == nil {
 			return result, nil
 		}
@@ -6553,11 +6944,11 @@ func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*mo
 
 }
 
-func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+func (s *RetryLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId string) ([]*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsByIds(postIds)
+		result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientId)
 		if err == nil {
 			return result, nil
 		}
@@ -6574,11 +6965,11 @@ func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, er
 
 }
 
-func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, time int64) ([]*model.Post, error) {
+func (s *RetryLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsCreatedAt(channelID, time)
+		result, err := s.OAuthStore.GetApp(id)
 		if err == nil {
 			return result, nil
 		}
@@ -6595,11 +6986,11 @@ func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, time int64) ([
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *RetryLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+		result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6616,32 +7007,32 @@ func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions,
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+func (s *RetryLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
+		result, err := s.OAuthStore.GetApps(offset, limit)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
+func (s *RetryLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetRecentSearchesForUser(userID)
+		result, err := s.OAuthStore.GetAuthData(code)
 		if err == nil {
 			return result, nil
 		}
@@ -6658,11 +7049,11 @@ func (s *RetryLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.
 
 }
 
-func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+func (s *RetryLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetRepliesForExport(parentID)
+		result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -6679,11 +7070,11 @@ func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.Rep
 
 }
 
-func (s *RetryLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error) {
+func (s *RetryLayerOAuthStore) GetPreviousAccessData(userID string, clientId string) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetSingle(id, inclDeleted)
+		result, err := s.OAuthStore.GetPreviousAccessData(userID, clientId)
 		if err == nil {
 			return result, nil
 		}
@@ -6700,38 +7091,32 @@ func (s *RetryLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Pos
 
 }
 
-func (s *RetryLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
+func (s *RetryLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
+		err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
-
-	s.PostStore.InvalidateLastPostTimeCache(channelID)
-
-}
-
-func (s *RetryLayerPostStore) LogRecentSearch(userID string, searchQuery []byte, createAt int64) error {
+func (s *RetryLayerOAuthStore) RemoveAccessData(token string) error {
 
 	tries := 0
 	for {
-		err := s.PostStore.LogRecentSearch(userID, searchQuery, createAt)
+		err := s.OAuthStore.RemoveAccessData(token)
 		if err == nil {
 			return nil
 		}
@@ -6748,53 +7133,53 @@ func (s *RetryLayerPostStore) LogRecentSearch(userID string, searchQuery []byte,
 
 }
 
-func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+func (s *RetryLayerOAuthStore) RemoveAllAccessData() error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Overwrite(post)
+		err := s.OAuthStore.RemoveAllAccessData()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerOAuthStore) RemoveAuthData(code string) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+		err := s.OAuthStore.RemoveAuthData(code)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.OAuthStore.SaveAccessData(accessData)
 		if err == nil {
 			return result, nil
 		}
@@ -6811,74 +7196,74 @@ func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *RetryLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+		result, err := s.OAuthStore.SaveApp(app)
 		if err == nil {
-			return result, resultVar1, nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelID string) error {
+func (s *RetryLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByChannel(channelID)
+		result, err := s.OAuthStore.SaveAuthData(authData)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) PermanentDeleteByUser(userID string) error {
+func (s *RetryLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 
 	tries := 0
 	for {
-		err := s.PostStore.PermanentDeleteByUser(userID)
+		result, err := s.OAuthStore.UpdateAccessData(accessData)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Save(post)
+		result, err := s.OAuthStore.UpdateApp(app)
 		if err == nil {
 			return result, nil
 		}
@@ -6895,32 +7280,32 @@ func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *RetryLayerOnboardingTaskStore) Delete(id string, deleteAt int64) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+		err := s.OnboardingTaskStore.Delete(id, deleteAt)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
+func (s *RetryLayerOnboardingTaskStore) Get(id string) (*model.OnboardingTask, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Search(teamID, userID, params)
+		result, err := s.OnboardingTaskStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -6937,11 +7322,11 @@ func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model
 
 }
 
-func (s *RetryLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+func (s *RetryLayerOnboardingTaskStore) GetAll() ([]*model.OnboardingTask, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.SearchPostsForUser(paramsList, userID, teamID, page, perPage)
+		result, err := s.OnboardingTaskStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -6958,11 +7343,11 @@ func (s *RetryLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParam
 
 }
 
-func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+func (s *RetryLayerOnboardingTaskStore) Save(task *model.OnboardingTask) (*model.OnboardingTask, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Update(newPost, oldPost)
+		result, err := s.OnboardingTaskStore.Save(task)
 		if err == nil {
 			return result, nil
 		}
@@ -6979,11 +7364,11 @@ func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (
 
 }
 
-func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+func (s *RetryLayerOnboardingTaskStore) Update(task *model.OnboardingTask) (*model.OnboardingTask, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+		result, err := s.OnboardingTaskStore.Update(task)
 		if err == nil {
 			return result, nil
 		}
@@ -7000,11 +7385,11 @@ func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error
 
 }
 
-func (s *RetryLayerPreferenceStore) Delete(userID string, category string, name string) error {
+func (s *RetryLayerOutgoingOAuthConnectionStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Delete(userID, category, name)
+		err := s.OutgoingOAuthConnectionStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -7021,53 +7406,53 @@ func (s *RetryLayerPreferenceStore) Delete(userID string, category string, name
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+func (s *RetryLayerOutgoingOAuthConnectionStore) Get(id string) (*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.DeleteCategory(userID, category)
+		result, err := s.OutgoingOAuthConnectionStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+func (s *RetryLayerOutgoingOAuthConnectionStore) GetAll(offset int, limit int) ([]*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+		result, err := s.OutgoingOAuthConnectionStore.GetAll(offset, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerOutgoingOAuthConnectionStore) Save(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+		result, err := s.OutgoingOAuthConnectionStore.Save(connection)
 		if err == nil {
 			return result, nil
 		}
@@ -7084,11 +7469,11 @@ func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error)
 
 }
 
-func (s *RetryLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+func (s *RetryLayerOutgoingOAuthConnectionStore) Update(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.Get(userID, category, name)
+		result, err := s.OutgoingOAuthConnectionStore.Update(connection)
 		if err == nil {
 			return result, nil
 		}
@@ -7105,32 +7490,32 @@ func (s *RetryLayerPreferenceStore) Get(userID string, category string, name str
 
 }
 
-func (s *RetryLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+func (s *RetryLayerPersistentWebSocketEventStore) Cleanup(expiryTime int64, batchSize int) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetAll(userID)
+		err := s.PersistentWebSocketEventStore.Cleanup(expiryTime, batchSize)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+func (s *RetryLayerPersistentWebSocketEventStore) GetForUserSince(userID string, since int64, limit int) ([]*model.PersistentWebSocketEvent, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetCategory(userID, category)
+		result, err := s.PersistentWebSocketEventStore.GetForUserSince(userID, since, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7147,74 +7532,74 @@ func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string)
 
 }
 
-func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+func (s *RetryLayerPersistentWebSocketEventStore) Save(event *model.PersistentWebSocketEvent) (*model.PersistentWebSocketEvent, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.PermanentDeleteByUser(userID)
+		result, err := s.PersistentWebSocketEventStore.Save(event)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) Save(preferences model.Preferences) error {
+func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Save(preferences)
+		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
+func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.Clear(notices)
+		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+		err := s.PluginStore.Delete(pluginID, key)
 		if err == nil {
 			return nil
 		}
@@ -7231,32 +7616,32 @@ func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.Pro
 
 }
 
-func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+func (s *RetryLayerPluginStore) DeleteAllExpired() error {
 
 	tries := 0
 	for {
-		result, err := s.ProductNoticesStore.GetViews(userID)
+		err := s.PluginStore.DeleteAllExpired()
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) View(userID string, notices []string) error {
+func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.View(userID, notices)
+		err := s.PluginStore.DeleteAllForPlugin(PluginID)
 		if err == nil {
 			return nil
 		}
@@ -7273,11 +7658,11 @@ func (s *RetryLayerProductNoticesStore) View(userID string, notices []string) er
 
 }
 
-func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.BulkGetForPosts(postIds)
+		result, err := s.PluginStore.Get(pluginID, key)
 		if err == nil {
 			return result, nil
 		}
@@ -7294,11 +7679,11 @@ func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 
 }
 
-func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Delete(reaction)
+		result, err := s.PluginStore.List(pluginID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -7315,32 +7700,32 @@ func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.React
 
 }
 
-func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 
 	tries := 0
 	for {
-		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+		result, err := s.PluginStore.SaveOrUpdate(keyVal)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerPluginStore) SetMultiple(pluginID string, operations []*model.PluginKVSetOperation) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.DeleteOrphanedRows(limit)
+		result, err := s.PluginStore.SetMultiple(pluginID, operations)
 		if err == nil {
 			return result, nil
 		}
@@ -7357,11 +7742,11 @@ func (s *RetryLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 }
 
-func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+		result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
 		if err == nil {
 			return result, nil
 		}
@@ -7378,11 +7763,11 @@ func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool)
 
 }
 
-func (s *RetryLayerReactionStore) GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error) {
+func (s *RetryLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetForPostSince(postId, since, excludeRemoteId, inclDeleted)
+		result, err := s.PostStore.AnalyticsPostCount(options)
 		if err == nil {
 			return result, nil
 		}
@@ -7399,11 +7784,11 @@ func (s *RetryLayerReactionStore) GetForPostSince(postId string, since int64, ex
 
 }
 
-func (s *RetryLayerReactionStore) GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
+func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetTopForTeamSince(teamID, userID, since, offset, limit)
+		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
 		if err == nil {
 			return result, nil
 		}
@@ -7420,11 +7805,11 @@ func (s *RetryLayerReactionStore) GetTopForTeamSince(teamID string, userID strin
 
 }
 
-func (s *RetryLayerReactionStore) GetTopForUserSince(userID string, teamID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
+func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetTopForUserSince(userID, teamID, since, offset, limit)
+		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -7441,32 +7826,38 @@ func (s *RetryLayerReactionStore) GetTopForUserSince(userID string, teamID strin
 
 }
 
-func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerPostStore) ClearCaches() {
+
+	s.PostStore.ClearCaches()
+
+}
+
+func (s *RetryLayerPostStore) Delete(postID string, time int64, deleteByID string) error {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+		err := s.PostStore.Delete(postID, time, deleteByID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerPostStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Save(reaction)
+		result, err := s.PostStore.DeleteOrphanedRows(limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7483,11 +7874,11 @@ func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
+func (s *RetryLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Delete(remoteClusterId)
+		result, err := s.PostStore.Get(ctx, id, opts, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -7504,11 +7895,11 @@ func (s *RetryLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, err
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Get(remoteClusterId string) (*model.RemoteCluster, error) {
+func (s *RetryLayerPostStore) GetChannelPostsForExportAfter(channelID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Get(remoteClusterId)
+		result, err := s.PostStore.GetChannelPostsForExportAfter(channelID, afterCreateAt, afterID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7525,11 +7916,11 @@ func (s *RetryLayerRemoteClusterStore) Get(remoteClusterId string) (*model.Remot
 
 }
 
-func (s *RetryLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
+func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string) ([]*model.DirectPostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.GetAll(filter)
+		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID)
 		if err == nil {
 			return result, nil
 		}
@@ -7546,11 +7937,17 @@ func (s *RetryLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFil
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+func (s *RetryLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
+
+	return s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Save(rc)
+		result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7567,32 +7964,32 @@ func (s *RetryLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.Rem
 
 }
 
-func (s *RetryLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) error {
+func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		err := s.RemoteClusterStore.SetLastPingAt(remoteClusterId)
+		result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.Update(rc)
+		result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7609,11 +8006,11 @@ func (s *RetryLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.R
 
 }
 
-func (s *RetryLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topics string) (*model.RemoteCluster, error) {
+func (s *RetryLayerPostStore) GetLastPostAtByUserSince(options model.GetPostsSinceOptions, userId string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterId, topics)
+		result, err := s.PostStore.GetLastPostAtByUserSince(options, userId)
 		if err == nil {
 			return result, nil
 		}
@@ -7630,74 +8027,80 @@ func (s *RetryLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topi
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) AddChannels(policyId string, channelIds []string) error {
+func (s *RetryLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.AddChannels(policyId, channelIds)
+		result, err := s.PostStore.GetLastPostRowCreateAt()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) AddTeams(policyId string, teamIds []string) error {
+func (s *RetryLayerPostStore) GetMaxPostSize() int {
+
+	return s.PostStore.GetMaxPostSize()
+
+}
+
+func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.AddTeams(policyId, teamIds)
+		result, err := s.PostStore.GetOldest()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Delete(id string) error {
+func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.Delete(id)
+		result, err := s.PostStore.GetOldestEntityCreationTime()
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string) ([]*model.PostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
+		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID)
 		if err == nil {
 			return result, nil
 		}
@@ -7714,11 +8117,11 @@ func (s *RetryLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, e
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerPostStore) GetPostAfterTime(channelID string, time int64, collapsedThreads bool) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.Get(id)
+		result, err := s.PostStore.GetPostAfterTime(channelID, time, collapsedThreads)
 		if err == nil {
 			return result, nil
 		}
@@ -7735,11 +8138,11 @@ func (s *RetryLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyW
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerPostStore) GetPostIdAfterTime(channelID string, time int64, collapsedThreads bool) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetAll(offset, limit)
+		result, err := s.PostStore.GetPostIdAfterTime(channelID, time, collapsedThreads)
 		if err == nil {
 			return result, nil
 		}
@@ -7756,11 +8159,11 @@ func (s *RetryLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
+func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelID string, time int64, collapsedThreads bool) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
+		result, err := s.PostStore.GetPostIdBeforeTime(channelID, time, collapsedThreads)
 		if err == nil {
 			return result, nil
 		}
@@ -7777,11 +8180,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID s
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
+func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
+		result, err := s.PostStore.GetPosts(options, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -7798,11 +8201,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannels(policyId string, offset int, limit int) (model.ChannelListWithTeamData, error) {
+func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannels(policyId, offset, limit)
+		result, err := s.PostStore.GetPostsAfter(options)
 		if err == nil {
 			return result, nil
 		}
@@ -7819,11 +8222,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannels(policyId string, offset int
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int64, error) {
+func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetChannelsCount(policyId)
+		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -7840,11 +8243,11 @@ func (s *RetryLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int6
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetCount() (int64, error) {
+func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetCount()
+		result, err := s.PostStore.GetPostsBefore(options)
 		if err == nil {
 			return result, nil
 		}
@@ -7861,11 +8264,11 @@ func (s *RetryLayerRetentionPolicyStore) GetCount() (int64, error) {
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+		result, err := s.PostStore.GetPostsByIds(postIds)
 		if err == nil {
 			return result, nil
 		}
@@ -7882,11 +8285,11 @@ func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID stri
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, time int64) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+		result, err := s.PostStore.GetPostsCreatedAt(channelID, time)
 		if err == nil {
 			return result, nil
 		}
@@ -7903,11 +8306,11 @@ func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, o
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeams(policyId string, offset int, limit int) ([]*model.Team, error) {
+func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeams(policyId, offset, limit)
+		result, err := s.PostStore.GetPostsSince(options, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -7924,32 +8327,32 @@ func (s *RetryLayerRetentionPolicyStore) GetTeams(policyId string, offset int, l
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) GetTeamsCount(policyId string) (int64, error) {
+func (s *RetryLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.GetTeamsCount(policyId)
+		result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.Patch(patch)
+		result, err := s.PostStore.GetRecentSearchesForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -7966,53 +8369,53 @@ func (s *RetryLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithT
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) RemoveChannels(policyId string, channelIds []string) error {
+func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.RemoveChannels(policyId, channelIds)
+		result, err := s.PostStore.GetRepliesForExport(parentID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
+func (s *RetryLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error) {
 
 	tries := 0
 	for {
-		err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
+		result, err := s.PostStore.GetSingle(id, inclDeleted)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *RetryLayerPostStore) GetUserPostsForExportAfter(userID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.RetentionPolicyStore.Save(policy)
+		result, err := s.PostStore.GetUserPostsForExportAfter(userID, afterCreateAt, afterID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8029,11 +8432,11 @@ func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithT
 
 }
 
-func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+func (s *RetryLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.AllChannelSchemeRoles()
+		result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
 		if err == nil {
 			return result, nil
 		}
@@ -8050,53 +8453,38 @@ func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
 
-	tries := 0
-	for {
-		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
-		if err == nil {
-			return result, nil
-		}
-		if !isRepeatableError(err) {
-			return result, err
-		}
-		tries++
-		if tries >= 3 {
-			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
-		}
-		timepkg.Sleep(100 * timepkg.Millisecond)
-	}
+	s.PostStore.InvalidateLastPostTimeCache(channelID)
 
 }
 
-func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+func (s *RetryLayerPostStore) LogRecentSearch(userID string, searchQuery []byte, createAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+		err := s.PostStore.LogRecentSearch(userID, searchQuery, createAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Delete(roleID)
+		result, err := s.PostStore.Overwrite(post)
 		if err == nil {
 			return result, nil
 		}
@@ -8113,32 +8501,32 @@ func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) Get(roleID string) (*model.Role, error) {
+func (s *RetryLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Get(roleID)
+		result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
+func (s *RetryLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetAll()
+		result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8155,53 +8543,53 @@ func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
 
 }
 
-func (s *RetryLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+func (s *RetryLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByName(ctx, name)
+		result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+func (s *RetryLayerPostStore) PermanentDeleteByChannel(channelID string) error {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.GetByNames(names)
+		err := s.PostStore.PermanentDeleteByChannel(channelID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
+func (s *RetryLayerPostStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		err := s.RoleStore.PermanentDeleteAll()
+		err := s.PostStore.PermanentDeleteByUser(userID)
 		if err == nil {
 			return nil
 		}
@@ -8218,11 +8606,11 @@ func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
 
 }
 
-func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+func (s *RetryLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.RoleStore.Save(role)
+		result, err := s.PostStore.Save(post)
 		if err == nil {
 			return result, nil
 		}
@@ -8239,32 +8627,32 @@ func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 
 }
 
-func (s *RetryLayerSchemeStore) CountByScope(scope string) (int64, error) {
+func (s *RetryLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.CountByScope(scope)
+		result, resultVar1, err := s.PostStore.SaveMultiple(posts)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+func (s *RetryLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+		result, err := s.PostStore.Search(teamID, userID, params)
 		if err == nil {
 			return result, nil
 		}
@@ -8281,11 +8669,11 @@ func (s *RetryLayerSchemeStore) CountWithoutPermission(scope string, permissionI
 
 }
 
-func (s *RetryLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
+func (s *RetryLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.Delete(schemeID)
+		result, err := s.PostStore.SearchPostsForUser(paramsList, userID, teamID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -8302,11 +8690,11 @@ func (s *RetryLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
 
 }
 
-func (s *RetryLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
+func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.Get(schemeID)
+		result, err := s.PostStore.Update(newPost, oldPost)
 		if err == nil {
 			return result, nil
 		}
@@ -8323,11 +8711,11 @@ func (s *RetryLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
 
 }
 
-func (s *RetryLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+func (s *RetryLayerPostArchiveStore) ArchiveThreadsBefore(before int64, limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+		result, err := s.PostArchiveStore.ArchiveThreadsBefore(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8344,11 +8732,11 @@ func (s *RetryLayerSchemeStore) GetAllPage(scope string, offset int, limit int)
 
 }
 
-func (s *RetryLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+func (s *RetryLayerPostArchiveStore) Search(channelIDs []string, terms string, offset int, limit int) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.GetByName(schemeName)
+		result, err := s.PostArchiveStore.Search(channelIDs, terms, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8365,11 +8753,11 @@ func (s *RetryLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, err
 
 }
 
-func (s *RetryLayerSchemeStore) PermanentDeleteAll() error {
+func (s *RetryLayerPostPropSchemaStore) Delete(namespace string) error {
 
 	tries := 0
 	for {
-		err := s.SchemeStore.PermanentDeleteAll()
+		err := s.PostPropSchemaStore.Delete(namespace)
 		if err == nil {
 			return nil
 		}
@@ -8386,11 +8774,11 @@ func (s *RetryLayerSchemeStore) PermanentDeleteAll() error {
 
 }
 
-func (s *RetryLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+func (s *RetryLayerPostPropSchemaStore) Get(namespace string) (*model.PostPropSchema, error) {
 
 	tries := 0
 	for {
-		result, err := s.SchemeStore.Save(scheme)
+		result, err := s.PostPropSchemaStore.Get(namespace)
 		if err == nil {
 			return result, nil
 		}
@@ -8407,11 +8795,11 @@ func (s *RetryLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error
 
 }
 
-func (s *RetryLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+func (s *RetryLayerPostPropSchemaStore) GetAll() ([]*model.PostPropSchema, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.AnalyticsSessionCount()
+		result, err := s.PostPropSchemaStore.GetAll()
 		if err == nil {
 			return result, nil
 		}
@@ -8428,32 +8816,32 @@ func (s *RetryLayerSessionStore) AnalyticsSessionCount() (int64, error) {
 
 }
 
-func (s *RetryLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+func (s *RetryLayerPostPropSchemaStore) Save(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.Cleanup(expiryTime, batchSize)
+		result, err := s.PostPropSchemaStore.Save(schema)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
+func (s *RetryLayerPostPropSchemaStore) Update(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.Get(ctx, sessionIDOrToken)
+		result, err := s.PostPropSchemaStore.Update(schema)
 		if err == nil {
 			return result, nil
 		}
@@ -8470,11 +8858,11 @@ func (s *RetryLayerSessionStore) Get(ctx context.Context, sessionIDOrToken strin
 
 }
 
-func (s *RetryLayerSessionStore) GetLastSessionRowCreateAt() (int64, error) {
+func (s *RetryLayerPostReportStore) Get(id string) (*model.PostReport, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetLastSessionRowCreateAt()
+		result, err := s.PostReportStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -8491,11 +8879,11 @@ func (s *RetryLayerSessionStore) GetLastSessionRowCreateAt() (int64, error) {
 
 }
 
-func (s *RetryLayerSessionStore) GetSessions(userID string) ([]*model.Session, error) {
+func (s *RetryLayerPostReportStore) GetReports(opts model.PostReportGetOptions) ([]*model.PostReport, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessions(userID)
+		result, err := s.PostReportStore.GetReports(opts)
 		if err == nil {
 			return result, nil
 		}
@@ -8512,11 +8900,11 @@ func (s *RetryLayerSessionStore) GetSessions(userID string) ([]*model.Session, e
 
 }
 
-func (s *RetryLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+func (s *RetryLayerPostReportStore) ResolveForPost(postID string, status string, action string, resolverID string, resolveAt int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+		result, err := s.PostReportStore.ResolveForPost(postID, status, action, resolverID, resolveAt)
 		if err == nil {
 			return result, nil
 		}
@@ -8533,11 +8921,11 @@ func (s *RetryLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobil
 
 }
 
-func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+func (s *RetryLayerPostReportStore) Save(report *model.PostReport) (*model.PostReport, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+		result, err := s.PostReportStore.Save(report)
 		if err == nil {
 			return result, nil
 		}
@@ -8554,11 +8942,11 @@ func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) (
 
 }
 
-func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+func (s *RetryLayerPostRetentionLabelStore) Delete(postID string) error {
 
 	tries := 0
 	for {
-		err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+		err := s.PostRetentionLabelStore.Delete(postID)
 		if err == nil {
 			return nil
 		}
@@ -8575,53 +8963,53 @@ func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) er
 
 }
 
-func (s *RetryLayerSessionStore) Remove(sessionIDOrToken string) error {
+func (s *RetryLayerPostRetentionLabelStore) Get(postID string) (*model.PostRetentionLabel, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.Remove(sessionIDOrToken)
+		result, err := s.PostRetentionLabelStore.Get(postID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) RemoveAllSessions() error {
+func (s *RetryLayerPostRetentionLabelStore) Save(label *model.PostRetentionLabel) (*model.PostRetentionLabel, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.RemoveAllSessions()
+		result, err := s.PostRetentionLabelStore.Save(label)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.Save(session)
+		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8638,32 +9026,32 @@ func (s *RetryLayerSessionStore) Save(session *model.Session) (*model.Session, e
 
 }
 
-func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+func (s *RetryLayerPreferenceStore) Delete(userID string, category string, name string) error {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+		err := s.PreferenceStore.Delete(userID, category, name)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+func (s *RetryLayerPreferenceStore) DeleteCategory(userID string, category string) error {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+		err := s.PreferenceStore.DeleteCategory(userID, category)
 		if err == nil {
 			return nil
 		}
@@ -8680,11 +9068,11 @@ func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified
 
 }
 
-func (s *RetryLayerSessionStore) UpdateExpiresAt(sessionID string, time int64) error {
+func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateExpiresAt(sessionID, time)
+		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
 		if err == nil {
 			return nil
 		}
@@ -8701,53 +9089,53 @@ func (s *RetryLayerSessionStore) UpdateExpiresAt(sessionID string, time int64) e
 
 }
 
-func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionID string, time int64) error {
+func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateLastActivityAt(sessionID, time)
+		result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateProps(session *model.Session) error {
+func (s *RetryLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateProps(session)
+		result, err := s.PreferenceStore.Get(userID, category, name)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+func (s *RetryLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.UpdateRoles(userID, roles)
+		result, err := s.PreferenceStore.GetAll(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -8764,11 +9152,11 @@ func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (strin
 
 }
 
-func (s *RetryLayerSharedChannelStore) Delete(channelId string) (bool, error) {
+func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Delete(channelId)
+		result, err := s.PreferenceStore.GetCategory(userID, category)
 		if err == nil {
 			return result, nil
 		}
@@ -8785,95 +9173,95 @@ func (s *RetryLayerSharedChannelStore) Delete(channelId string) (bool, error) {
 
 }
 
-func (s *RetryLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, error) {
+func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.DeleteRemote(remoteId)
+		err := s.PreferenceStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) Get(channelId string) (*model.SharedChannel, error) {
+func (s *RetryLayerPreferenceStore) Save(preferences model.Preferences) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Get(channelId)
+		err := s.PreferenceStore.Save(preferences)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
+func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
+		err := s.ProductNoticesStore.Clear(notices)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
+func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetAllCount(opts)
+		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetAttachment(fileId string, remoteId string) (*model.SharedChannelAttachment, error) {
+func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetAttachment(fileId, remoteId)
+		result, err := s.ProductNoticesStore.GetViews(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -8890,53 +9278,53 @@ func (s *RetryLayerSharedChannelStore) GetAttachment(fileId string, remoteId str
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerProductNoticesStore) View(userID string, notices []string) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemote(id)
+		err := s.ProductNoticesStore.View(userID, notices)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemoteByIds(channelId string, remoteId string) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerPushNotificationReceiptStore) Cleanup(expiryTime int64, batchSize int) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemoteByIds(channelId, remoteId)
+		err := s.PushNotificationReceiptStore.Cleanup(expiryTime, batchSize)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId string) (*model.RemoteCluster, error) {
+func (s *RetryLayerPushNotificationReceiptStore) GetForUser(userID string, limit int) ([]*model.PushNotificationReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemoteForUser(remoteId, userId)
+		result, err := s.PushNotificationReceiptStore.GetForUser(userID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8953,32 +9341,32 @@ func (s *RetryLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+func (s *RetryLayerPushNotificationReceiptStore) MarkReceived(ackID string, receivedAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemotes(opts)
+		err := s.PushNotificationReceiptStore.MarkReceived(ackID, receivedAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.SharedChannelRemoteStatus, error) {
+func (s *RetryLayerPushNotificationReceiptStore) Save(receipt *model.PushNotificationReceipt) (*model.PushNotificationReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetRemotesStatus(channelId)
+		result, err := s.PushNotificationReceiptStore.Save(receipt)
 		if err == nil {
 			return result, nil
 		}
@@ -8995,11 +9383,11 @@ func (s *RetryLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*mo
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
+func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
+		result, err := s.ReactionStore.BulkGetForPosts(postIds)
 		if err == nil {
 			return result, nil
 		}
@@ -9016,11 +9404,11 @@ func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID st
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
+func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetUsersForSync(filter)
+		result, err := s.ReactionStore.Delete(reaction)
 		if err == nil {
 			return result, nil
 		}
@@ -9037,32 +9425,32 @@ func (s *RetryLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForS
 
 }
 
-func (s *RetryLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
+func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.GetUsersForUser(userID)
+		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
+func (s *RetryLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.HasChannel(channelID)
+		result, err := s.ReactionStore.DeleteOrphanedRows(limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9079,11 +9467,11 @@ func (s *RetryLayerSharedChannelStore) HasChannel(channelID string) (bool, error
 
 }
 
-func (s *RetryLayerSharedChannelStore) HasRemote(channelID string, remoteId string) (bool, error) {
+func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.HasRemote(channelID, remoteId)
+		result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -9100,11 +9488,11 @@ func (s *RetryLayerSharedChannelStore) HasRemote(channelID string, remoteId stri
 
 }
 
-func (s *RetryLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
+func (s *RetryLayerReactionStore) GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Save(sc)
+		result, err := s.ReactionStore.GetForPostSince(postId, since, excludeRemoteId, inclDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -9121,11 +9509,11 @@ func (s *RetryLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.Sha
 
 }
 
-func (s *RetryLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
+func (s *RetryLayerReactionStore) GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.SaveAttachment(remote)
+		result, err := s.ReactionStore.GetTopForTeamSince(teamID, userID, since, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9142,11 +9530,11 @@ func (s *RetryLayerSharedChannelStore) SaveAttachment(remote *model.SharedChanne
 
 }
 
-func (s *RetryLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerReactionStore) GetTopForUserSince(userID string, teamID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.SaveRemote(remote)
+		result, err := s.ReactionStore.GetTopForUserSince(userID, teamID, since, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9163,11 +9551,11 @@ func (s *RetryLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRem
 
 }
 
-func (s *RetryLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
+func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.SaveUser(remote)
+		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9184,11 +9572,11 @@ func (s *RetryLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser)
 
 }
 
-func (s *RetryLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
+func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.Update(sc)
+		result, err := s.ReactionStore.Save(reaction)
 		if err == nil {
 			return result, nil
 		}
@@ -9205,11 +9593,11 @@ func (s *RetryLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.S
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
+func (s *RetryLayerReminderStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
+		err := s.ReminderStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -9226,11 +9614,11 @@ func (s *RetryLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syn
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+func (s *RetryLayerReminderStore) Get(id string) (*model.Reminder, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.UpdateRemote(remote)
+		result, err := s.ReminderStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -9247,53 +9635,32 @@ func (s *RetryLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelR
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
-
-	tries := 0
-	for {
-		err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
-		if err == nil {
-			return nil
-		}
-		if !isRepeatableError(err) {
-			return err
-		}
-		tries++
-		if tries >= 3 {
-			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
-		}
-		timepkg.Sleep(100 * timepkg.Millisecond)
-	}
-
-}
-
-func (s *RetryLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
+func (s *RetryLayerReminderStore) GetDue(now int64, limit int) ([]*model.Reminder, error) {
 
 	tries := 0
 	for {
-		err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
+		result, err := s.ReminderStore.GetDue(now, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
+func (s *RetryLayerReminderStore) GetForUser(userID string) ([]*model.Reminder, error) {
 
 	tries := 0
 	for {
-		result, err := s.SharedChannelStore.UpsertAttachment(remote)
+		result, err := s.ReminderStore.GetForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9310,11 +9677,11 @@ func (s *RetryLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChan
 
 }
 
-func (s *RetryLayerStatusStore) Get(userID string) (*model.Status, error) {
+func (s *RetryLayerReminderStore) Save(reminder *model.Reminder) (*model.Reminder, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.Get(userID)
+		result, err := s.ReminderStore.Save(reminder)
 		if err == nil {
 			return result, nil
 		}
@@ -9331,11 +9698,11 @@ func (s *RetryLayerStatusStore) Get(userID string) (*model.Status, error) {
 
 }
 
-func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+func (s *RetryLayerReminderStore) Update(reminder *model.Reminder) (*model.Reminder, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.GetByIds(userIds)
+		result, err := s.ReminderStore.Update(reminder)
 		if err == nil {
 			return result, nil
 		}
@@ -9352,11 +9719,11 @@ func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, err
 
 }
 
-func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+func (s *RetryLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.GetTotalActiveUsersCount()
+		result, err := s.RemoteClusterStore.Delete(remoteClusterId)
 		if err == nil {
 			return result, nil
 		}
@@ -9373,53 +9740,53 @@ func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
 
 }
 
-func (s *RetryLayerStatusStore) ResetAll() error {
+func (s *RetryLayerRemoteClusterStore) Get(remoteClusterId string) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		err := s.StatusStore.ResetAll()
+		result, err := s.RemoteClusterStore.Get(remoteClusterId)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+func (s *RetryLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		err := s.StatusStore.SaveOrUpdate(status)
+		result, err := s.RemoteClusterStore.GetAll(filter)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+func (s *RetryLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.StatusStore.UpdateExpiredDNDStatuses()
+		result, err := s.RemoteClusterStore.Save(rc)
 		if err == nil {
 			return result, nil
 		}
@@ -9436,11 +9803,11 @@ func (s *RetryLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, err
 
 }
 
-func (s *RetryLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
+func (s *RetryLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) error {
 
 	tries := 0
 	for {
-		err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
+		err := s.RemoteClusterStore.SetLastPingAt(remoteClusterId)
 		if err == nil {
 			return nil
 		}
@@ -9457,11 +9824,11 @@ func (s *RetryLayerStatusStore) UpdateLastActivityAt(userID string, lastActivity
 
 }
 
-func (s *RetryLayerSystemStore) Get() (model.StringMap, error) {
+func (s *RetryLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.Get()
+		result, err := s.RemoteClusterStore.Update(rc)
 		if err == nil {
 			return result, nil
 		}
@@ -9478,11 +9845,11 @@ func (s *RetryLayerSystemStore) Get() (model.StringMap, error) {
 
 }
 
-func (s *RetryLayerSystemStore) GetByName(name string) (*model.System, error) {
+func (s *RetryLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topics string) (*model.RemoteCluster, error) {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.GetByName(name)
+		result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterId, topics)
 		if err == nil {
 			return result, nil
 		}
@@ -9499,53 +9866,53 @@ func (s *RetryLayerSystemStore) GetByName(name string) (*model.System, error) {
 
 }
 
-func (s *RetryLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+func (s *RetryLayerRetentionPolicyStore) AddChannels(policyId string, channelIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.InsertIfExists(system)
+		err := s.RetentionPolicyStore.AddChannels(policyId, channelIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+func (s *RetryLayerRetentionPolicyStore) AddTeams(policyId string, teamIds []string) error {
 
 	tries := 0
 	for {
-		result, err := s.SystemStore.PermanentDeleteByName(name)
+		err := s.RetentionPolicyStore.AddTeams(policyId, teamIds)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSystemStore) Save(system *model.System) error {
+func (s *RetryLayerRetentionPolicyStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.SystemStore.Save(system)
+		err := s.RetentionPolicyStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -9562,74 +9929,2348 @@ func (s *RetryLayerSystemStore) Save(system *model.System) error {
 
 }
 
-func (s *RetryLayerSystemStore) SaveOrUpdate(system *model.System) error {
+func (s *RetryLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.SystemStore.SaveOrUpdate(system)
+		result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+func (s *RetryLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
 
 	tries := 0
 	for {
-		err := s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+		result, err := s.RetentionPolicyStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetAll(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetChannels(policyId string, offset int, limit int) (model.ChannelListWithTeamData, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetChannels(policyId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetChannelsCount(policyId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetCount() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetCount()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetTeams(policyId string, offset int, limit int) ([]*model.Team, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetTeams(policyId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) GetTeamsCount(policyId string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetTeamsCount(policyId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.Patch(patch)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) RemoveChannels(policyId string, channelIds []string) error {
+
+	tries := 0
+	for {
+		err := s.RetentionPolicyStore.RemoveChannels(policyId, channelIds)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
+
+	tries := 0
+	for {
+		err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.Save(policy)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.AllChannelSchemeRoles()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.Delete(roleID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) Get(roleID string) (*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.Get(roleID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) GetAll() ([]*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.GetAll()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.GetByName(ctx, name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.GetByNames(names)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) PermanentDeleteAll() error {
+
+	tries := 0
+	for {
+		err := s.RoleStore.PermanentDeleteAll()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+
+	tries := 0
+	for {
+		result, err := s.RoleStore.Save(role)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerScheduledConfigChangeStore) Get(id string) (*model.ScheduledConfigChange, error) {
+
+	tries := 0
+	for {
+		result, err := s.ScheduledConfigChangeStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerScheduledConfigChangeStore) GetAll(includeFinished bool, offset int, limit int) ([]*model.ScheduledConfigChange, error) {
+
+	tries := 0
+	for {
+		result, err := s.ScheduledConfigChangeStore.GetAll(includeFinished, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerScheduledConfigChangeStore) GetDue(now int64, limit int) ([]*model.ScheduledConfigChange, error) {
+
+	tries := 0
+	for {
+		result, err := s.ScheduledConfigChangeStore.GetDue(now, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerScheduledConfigChangeStore) Save(change *model.ScheduledConfigChange) (*model.ScheduledConfigChange, error) {
+
+	tries := 0
+	for {
+		result, err := s.ScheduledConfigChangeStore.Save(change)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerScheduledConfigChangeStore) UpdateStatus(id string, oldStatus string, newStatus string, errorMessage string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.ScheduledConfigChangeStore.UpdateStatus(id, oldStatus, newStatus, errorMessage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) CountByScope(scope string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SchemeStore.CountByScope(scope)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
+
+	tries := 0
+	for {
+		result, err := s.SchemeStore.Delete(schemeID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
+
+	tries := 0
+	for {
+		result, err := s.SchemeStore.Get(schemeID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+
+	tries := 0
+	for {
+		result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+
+	tries := 0
+	for {
+		result, err := s.SchemeStore.GetByName(schemeName)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) PermanentDeleteAll() error {
+
+	tries := 0
+	for {
+		err := s.SchemeStore.PermanentDeleteAll()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+
+	tries := 0
+	for {
+		result, err := s.SchemeStore.Save(scheme)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.AnalyticsSessionCount()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.Cleanup(expiryTime, batchSize)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.Get(ctx, sessionIDOrToken)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetLastSessionRowCreateAt() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetLastSessionRowCreateAt()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetSessions(userID string) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetSessions(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) Remove(sessionIDOrToken string) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.Remove(sessionIDOrToken)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) RemoveAllSessions() error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.RemoveAllSessions()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.Save(session)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) UpdateExpiresAt(sessionID string, time int64) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.UpdateExpiresAt(sessionID, time)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionID string, time int64) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.UpdateLastActivityAt(sessionID, time)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) UpdateProps(session *model.Session) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.UpdateProps(session)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.UpdateRoles(userID, roles)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) Delete(channelId string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.Delete(channelId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.DeleteRemote(remoteId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) Get(channelId string) (*model.SharedChannel, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.Get(channelId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetAllCount(opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetAttachment(fileId string, remoteId string) (*model.SharedChannelAttachment, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetAttachment(fileId, remoteId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetRemote(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetRemoteByIds(channelId string, remoteId string) (*model.SharedChannelRemote, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetRemoteByIds(channelId, remoteId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId string) (*model.RemoteCluster, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetRemoteForUser(remoteId, userId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetRemotes(opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.SharedChannelRemoteStatus, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetRemotesStatus(channelId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetUsersForSync(filter)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetUsersForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.HasChannel(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) HasRemote(channelID string, remoteId string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.HasRemote(channelID, remoteId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.Save(sc)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.SaveAttachment(remote)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.SaveRemote(remote)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.SaveUser(remote)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.Update(sc)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.UpdateRemote(remote)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.UpsertAttachment(remote)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerStatusStore) Get(userID string) (*model.Status, error) {
+
+	tries := 0
+	for {
+		result, err := s.StatusStore.Get(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+
+	tries := 0
+	for {
+		result, err := s.StatusStore.GetByIds(userIds)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.StatusStore.GetTotalActiveUsersCount()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerStatusStore) ResetAll() error {
+
+	tries := 0
+	for {
+		err := s.StatusStore.ResetAll()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+
+	tries := 0
+	for {
+		err := s.StatusStore.SaveOrUpdate(status)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+
+	tries := 0
+	for {
+		result, err := s.StatusStore.UpdateExpiredDNDStatuses()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
+
+	tries := 0
+	for {
+		err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) Get() (model.StringMap, error) {
+
+	tries := 0
+	for {
+		result, err := s.SystemStore.Get()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) GetByName(name string) (*model.System, error) {
+
+	tries := 0
+	for {
+		result, err := s.SystemStore.GetByName(name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+
+	tries := 0
+	for {
+		result, err := s.SystemStore.InsertIfExists(system)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+
+	tries := 0
+	for {
+		result, err := s.SystemStore.PermanentDeleteByName(name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) Save(system *model.System) error {
+
+	tries := 0
+	for {
+		err := s.SystemStore.Save(system)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) SaveOrUpdate(system *model.System) error {
+
+	tries := 0
+	for {
+		err := s.SystemStore.SaveOrUpdate(system)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+
+	tries := 0
+	for {
+		err := s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSystemStore) Update(system *model.System) error {
+
+	tries := 0
+	for {
+		err := s.SystemStore.Update(system)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTablePartitionStore) CreatePartition(partition *model.TablePartition) error {
+
+	tries := 0
+	for {
+		err := s.TablePartitionStore.CreatePartition(partition)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTablePartitionStore) DropPartition(partition *model.TablePartition) error {
+
+	tries := 0
+	for {
+		err := s.TablePartitionStore.DropPartition(partition)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTablePartitionStore) GetPartitions(table string) ([]*model.TablePartition, error) {
+
+	tries := 0
+	for {
+		result, err := s.TablePartitionStore.GetPartitions(table)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTablePartitionStore) HasRetainedRows(partition *model.TablePartition, now int64, globalPolicyEndTime int64) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.TablePartitionStore.HasRetainedRows(partition, now, globalPolicyEndTime)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTablePartitionStore) IsPartitioned(table string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.TablePartitionStore.IsPartitioned(table)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTablePartitionStore) Partition(table string, boundary int64) error {
+
+	tries := 0
+	for {
+		err := s.TablePartitionStore.Partition(table, boundary)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.AnalyticsTeamCount(opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) ClearAllCustomRoleAssignments() error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.ClearAllCustomRoleAssignments()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) ClearCaches() {
+
+	s.TeamStore.ClearCaches()
+
+}
+
+func (s *RetryLayerTeamStore) Get(id string) (*model.Team, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) GetAll() ([]*model.Team, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.GetAll()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSystemStore) Update(system *model.System) error {
+func (s *RetryLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.SystemStore.Update(system)
+		result, err := s.TeamStore.GetAllPage(offset, limit, opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
+func (s *RetryLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
+		result, err := s.TeamStore.GetAllPrivateTeamListing()
 		if err == nil {
 			return result, nil
 		}
@@ -9646,11 +12287,11 @@ func (s *RetryLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (i
 
 }
 
-func (s *RetryLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
+func (s *RetryLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.AnalyticsTeamCount(opts)
+		result, err := s.TeamStore.GetAllTeamListing()
 		if err == nil {
 			return result, nil
 		}
@@ -9667,38 +12308,53 @@ func (s *RetryLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64,
 
 }
 
-func (s *RetryLayerTeamStore) ClearAllCustomRoleAssignments() error {
+func (s *RetryLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.ClearAllCustomRoleAssignments()
+		result, err := s.TeamStore.GetByInviteId(inviteID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) ClearCaches() {
+func (s *RetryLayerTeamStore) GetByName(name string) (*model.Team, error) {
 
-	s.TeamStore.ClearCaches()
+	tries := 0
+	for {
+		result, err := s.TeamStore.GetByName(name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
 
 }
 
-func (s *RetryLayerTeamStore) Get(id string) (*model.Team, error) {
+func (s *RetryLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.Get(id)
+		result, err := s.TeamStore.GetByNames(name)
 		if err == nil {
 			return result, nil
 		}
@@ -9715,11 +12371,11 @@ func (s *RetryLayerTeamStore) Get(id string) (*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+func (s *RetryLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
+		result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9736,11 +12392,11 @@ func (s *RetryLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *
 
 }
 
-func (s *RetryLayerTeamStore) GetAll() ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAll()
+		result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9757,11 +12413,11 @@ func (s *RetryLayerTeamStore) GetAll() ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
+func (s *RetryLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
+		result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
 		if err == nil {
 			return result, nil
 		}
@@ -9778,11 +12434,11 @@ func (s *RetryLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([
 
 }
 
-func (s *RetryLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllPage(offset, limit, opts)
+		result, err := s.TeamStore.GetMany(ids)
 		if err == nil {
 			return result, nil
 		}
@@ -9799,11 +12455,11 @@ func (s *RetryLayerTeamStore) GetAllPage(offset int, limit int, opts *model.Team
 
 }
 
-func (s *RetryLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetMember(ctx context.Context, teamID string, userID string) (*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllPrivateTeamListing()
+		result, err := s.TeamStore.GetMember(ctx, teamID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9820,11 +12476,11 @@ func (s *RetryLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error)
 
 }
 
-func (s *RetryLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetAllTeamListing()
+		result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
 		if err == nil {
 			return result, nil
 		}
@@ -9841,11 +12497,11 @@ func (s *RetryLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
+func (s *RetryLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetByInviteId(inviteID)
+		result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -9862,11 +12518,11 @@ func (s *RetryLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error
 
 }
 
-func (s *RetryLayerTeamStore) GetByName(name string) (*model.Team, error) {
+func (s *RetryLayerTeamStore) GetSoftDeletedIds(before int64, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetByName(name)
+		result, err := s.TeamStore.GetSoftDeletedIds(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9883,11 +12539,11 @@ func (s *RetryLayerTeamStore) GetByName(name string) (*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetByNames(name)
+		result, err := s.TeamStore.GetTeamMembersForExport(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9904,11 +12560,11 @@ func (s *RetryLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
+func (s *RetryLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
+		result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9925,11 +12581,11 @@ func (s *RetryLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string,
 
 }
 
-func (s *RetryLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
+func (s *RetryLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
+		result, err := s.TeamStore.GetTeamsByUserId(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9946,11 +12602,11 @@ func (s *RetryLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID str
 
 }
 
-func (s *RetryLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
+func (s *RetryLayerTeamStore) GetTeamsForUser(ctx context.Context, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
+		result, err := s.TeamStore.GetTeamsForUser(ctx, userID, excludeTeamID, includeDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -9967,11 +12623,11 @@ func (s *RetryLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUs
 
 }
 
-func (s *RetryLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMany(ids)
+		result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -9988,11 +12644,11 @@ func (s *RetryLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) GetMember(ctx context.Context, teamID string, userID string) (*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMember(ctx, teamID, userID)
+		result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
 		if err == nil {
 			return result, nil
 		}
@@ -10009,11 +12665,11 @@ func (s *RetryLayerTeamStore) GetMember(ctx context.Context, teamID string, user
 
 }
 
-func (s *RetryLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
+		result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -10030,11 +12686,11 @@ func (s *RetryLayerTeamStore) GetMembers(teamID string, offset int, limit int, t
 
 }
 
-func (s *RetryLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
+		result, err := s.TeamStore.GroupSyncedTeamCount()
 		if err == nil {
 			return result, nil
 		}
@@ -10051,11 +12707,17 @@ func (s *RetryLayerTeamStore) GetMembersByIds(teamID string, userIds []string, r
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
+func (s *RetryLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
+
+	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
+
+}
+
+func (s *RetryLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamMembersForExport(userID)
+		result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
 		if err == nil {
 			return result, nil
 		}
@@ -10065,39 +12727,165 @@ func (s *RetryLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.T
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) PermanentDelete(teamID string) error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.PermanentDelete(teamID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.RemoveAllMembersByTeam(teamID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) RemoveAllMembersByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.RemoveAllMembersByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) RemoveMember(teamID string, userID string) error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.RemoveMember(teamID, userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) RemoveMembers(teamID string, userIds []string) error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.RemoveMembers(teamID, userIds)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerTeamStore) ResetAllTeamSchemes() error {
+
+	tries := 0
+	for {
+		err := s.TeamStore.ResetAllTeamSchemes()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) Restore(teamID string) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
+		err := s.TeamStore.Restore(teamID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
+func (s *RetryLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsByUserId(userID)
+		result, err := s.TeamStore.Save(team)
 		if err == nil {
 			return result, nil
 		}
@@ -10114,11 +12902,11 @@ func (s *RetryLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, er
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsForUser(ctx context.Context, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsForUser(ctx, userID, excludeTeamID, includeDeleted)
+		result, err := s.TeamStore.SaveMember(member, maxUsersPerTeam)
 		if err == nil {
 			return result, nil
 		}
@@ -10135,11 +12923,11 @@ func (s *RetryLayerTeamStore) GetTeamsForUser(ctx context.Context, userID string
 
 }
 
-func (s *RetryLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
+		result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
 		if err == nil {
 			return result, nil
 		}
@@ -10156,11 +12944,11 @@ func (s *RetryLayerTeamStore) GetTeamsForUserWithPagination(userID string, page
 
 }
 
-func (s *RetryLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+func (s *RetryLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
+		result, err := s.TeamStore.SearchAll(opts)
 		if err == nil {
 			return result, nil
 		}
@@ -10177,32 +12965,32 @@ func (s *RetryLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *m
 
 }
 
-func (s *RetryLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
+func (s *RetryLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
+		result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
+func (s *RetryLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.GroupSyncedTeamCount()
+		result, err := s.TeamStore.SearchOpen(opts)
 		if err == nil {
 			return result, nil
 		}
@@ -10219,17 +13007,11 @@ func (s *RetryLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 
 }
 
-func (s *RetryLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
-
-	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
-
-}
-
-func (s *RetryLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
+func (s *RetryLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
+		result, err := s.TeamStore.SearchPrivate(opts)
 		if err == nil {
 			return result, nil
 		}
@@ -10246,11 +13028,11 @@ func (s *RetryLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID s
 
 }
 
-func (s *RetryLayerTeamStore) PermanentDelete(teamID string) error {
+func (s *RetryLayerTeamStore) SoftDelete(teamID string, deleteAt int64) error {
 
 	tries := 0
 	for {
-		err := s.TeamStore.PermanentDelete(teamID)
+		err := s.TeamStore.SoftDelete(teamID, deleteAt)
 		if err == nil {
 			return nil
 		}
@@ -10267,32 +13049,32 @@ func (s *RetryLayerTeamStore) PermanentDelete(teamID string) error {
 
 }
 
-func (s *RetryLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
+func (s *RetryLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveAllMembersByTeam(teamID)
+		result, err := s.TeamStore.Update(team)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) RemoveAllMembersByUser(userID string) error {
+func (s *RetryLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveAllMembersByUser(userID)
+		err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
 		if err == nil {
 			return nil
 		}
@@ -10309,32 +13091,32 @@ func (s *RetryLayerTeamStore) RemoveAllMembersByUser(userID string) error {
 
 }
 
-func (s *RetryLayerTeamStore) RemoveMember(teamID string, userID string) error {
+func (s *RetryLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveMember(teamID, userID)
+		result, err := s.TeamStore.UpdateMember(member)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) RemoveMembers(teamID string, userIds []string) error {
+func (s *RetryLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
 
 	tries := 0
 	for {
-		err := s.TeamStore.RemoveMembers(teamID, userIds)
+		err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
 		if err == nil {
 			return nil
 		}
@@ -10351,32 +13133,32 @@ func (s *RetryLayerTeamStore) RemoveMembers(teamID string, userIds []string) err
 
 }
 
-func (s *RetryLayerTeamStore) ResetAllTeamSchemes() error {
+func (s *RetryLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.ResetAllTeamSchemes()
+		result, err := s.TeamStore.UpdateMultipleMembers(members)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+func (s *RetryLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.Save(team)
+		result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
 		if err == nil {
 			return result, nil
 		}
@@ -10393,11 +13175,11 @@ func (s *RetryLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 
 }
 
-func (s *RetryLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+func (s *RetryLayerTeamInviteLinkStore) Get(id string) (*model.TeamInviteLink, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+		result, err := s.TeamInviteLinkStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -10414,11 +13196,11 @@ func (s *RetryLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTe
 
 }
 
-func (s *RetryLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamInviteLinkStore) GetForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+		result, err := s.TeamInviteLinkStore.GetForTeam(teamID, includeDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -10435,53 +13217,53 @@ func (s *RetryLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, m
 
 }
 
-func (s *RetryLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerTeamInviteLinkStore) Reject(id string) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SearchAll(opts)
+		err := s.TeamInviteLinkStore.Reject(id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
+func (s *RetryLayerTeamInviteLinkStore) Release(id string) error {
 
 	tries := 0
 	for {
-		result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
+		err := s.TeamInviteLinkStore.Release(id)
 		if err == nil {
-			return result, resultVar1, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, resultVar1, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, resultVar1, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerTeamInviteLinkStore) Save(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SearchOpen(opts)
+		result, err := s.TeamInviteLinkStore.Save(link)
 		if err == nil {
 			return result, nil
 		}
@@ -10498,11 +13280,11 @@ func (s *RetryLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team,
 
 }
 
-func (s *RetryLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *RetryLayerTeamInviteLinkStore) Update(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.SearchPrivate(opts)
+		result, err := s.TeamInviteLinkStore.Update(link)
 		if err == nil {
 			return result, nil
 		}
@@ -10519,32 +13301,32 @@ func (s *RetryLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Te
 
 }
 
-func (s *RetryLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+func (s *RetryLayerTeamInviteLinkStore) Use(id string, now int64) error {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.Update(team)
+		err := s.TeamInviteLinkStore.Use(id, now)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
+func (s *RetryLayerTeamTemplateStore) Delete(id string, deleteAt int64) error {
 
 	tries := 0
 	for {
-		err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
+		err := s.TeamTemplateStore.Delete(id, deleteAt)
 		if err == nil {
 			return nil
 		}
@@ -10561,11 +13343,11 @@ func (s *RetryLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime in
 
 }
 
-func (s *RetryLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+func (s *RetryLayerTeamTemplateStore) Get(id string) (*model.TeamTemplate, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.UpdateMember(member)
+		result, err := s.TeamTemplateStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -10582,32 +13364,32 @@ func (s *RetryLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.Tea
 
 }
 
-func (s *RetryLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+func (s *RetryLayerTeamTemplateStore) GetAll(offset int, limit int) ([]*model.TeamTemplate, error) {
 
 	tries := 0
 	for {
-		err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
+		result, err := s.TeamTemplateStore.GetAll(offset, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+func (s *RetryLayerTeamTemplateStore) Save(template *model.TeamTemplate) (*model.TeamTemplate, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.UpdateMultipleMembers(members)
+		result, err := s.TeamTemplateStore.Save(template)
 		if err == nil {
 			return result, nil
 		}
@@ -10624,11 +13406,11 @@ func (s *RetryLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember)
 
 }
 
-func (s *RetryLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
+func (s *RetryLayerTeamTemplateStore) Update(template *model.TeamTemplate) (*model.TeamTemplate, error) {
 
 	tries := 0
 	for {
-		result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
+		result, err := s.TeamTemplateStore.Update(template)
 		if err == nil {
 			return result, nil
 		}
@@ -11002,6 +13784,27 @@ func (s *RetryLayerThreadStore) GetTotalUnreadThreads(userId string, teamID stri
 
 }
 
+func (s *RetryLayerThreadStore) GetUnreadThreadsForUser(userID string) ([]*model.ThreadUnread, error) {
+
+	tries := 0
+	for {
+		result, err := s.ThreadStore.GetUnreadThreadsForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerThreadStore) MaintainMembership(userID string, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
 
 	tries := 0
@@ -11977,6 +14780,27 @@ func (s *RetryLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limi
 
 }
 
+func (s *RetryLayerUserStore) GetNonBotUsers(offset int, limit int) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetNonBotUsers(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
 
 	tries := 0
@@ -12394,6 +15218,27 @@ func (s *RetryLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
 
 }
 
+func (s *RetryLayerUserStore) MergeInto(sourceUserID string, targetUserID string) (*model.UserMergeCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.MergeInto(sourceUserID, targetUserID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) PermanentDelete(userID string) error {
 
 	tries := 0
@@ -13066,6 +15911,69 @@ func (s *RetryLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error
 
 }
 
+func (s *RetryLayerUserDeviceStore) GetForUser(userID string) ([]*model.UserDevice, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserDeviceStore.GetForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserDeviceStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.UserDeviceStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserDeviceStore) Save(device *model.UserDevice) error {
+
+	tries := 0
+	for {
+		err := s.UserDeviceStore.Save(device)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceId string) error {
 
 	tries := 0
@@ -13709,39 +16617,61 @@ func New(childStore store.Store) *RetryLayer {
 
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChangeEventStore = &RetryLayerChangeEventStore{ChangeEventStore: childStore.ChangeEvent(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &RetryLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
+	newStore.ChannelEventStore = &RetryLayerChannelEventStore{ChannelEventStore: childStore.ChannelEvent(), Root: &newStore}
+	newStore.ChannelJoinRequestStore = &RetryLayerChannelJoinRequestStore{ChannelJoinRequestStore: childStore.ChannelJoinRequest(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &RetryLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ChannelMemberTimeoutStore = &RetryLayerChannelMemberTimeoutStore{ChannelMemberTimeoutStore: childStore.ChannelMemberTimeout(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &RetryLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &RetryLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &RetryLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &RetryLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ConnectivityTestResultStore = &RetryLayerConnectivityTestResultStore{ConnectivityTestResultStore: childStore.ConnectivityTestResult(), Root: &newStore}
+	newStore.DirectMessageRequestStore = &RetryLayerDirectMessageRequestStore{DirectMessageRequestStore: childStore.DirectMessageRequest(), Root: &newStore}
 	newStore.EmojiStore = &RetryLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &RetryLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &RetryLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MfaBackupCodeStore = &RetryLayerMfaBackupCodeStore{MfaBackupCodeStore: childStore.MfaBackupCode(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OnboardingTaskStore = &RetryLayerOnboardingTaskStore{OnboardingTaskStore: childStore.OnboardingTask(), Root: &newStore}
+	newStore.OutgoingOAuthConnectionStore = &RetryLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PersistentWebSocketEventStore = &RetryLayerPersistentWebSocketEventStore{PersistentWebSocketEventStore: childStore.PersistentWebSocketEvent(), Root: &newStore}
 	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostArchiveStore = &RetryLayerPostArchiveStore{PostArchiveStore: childStore.PostArchive(), Root: &newStore}
+	newStore.PostPropSchemaStore = &RetryLayerPostPropSchemaStore{PostPropSchemaStore: childStore.PostPropSchema(), Root: &newStore}
+	newStore.PostReportStore = &RetryLayerPostReportStore{PostReportStore: childStore.PostReport(), Root: &newStore}
+	newStore.PostRetentionLabelStore = &RetryLayerPostRetentionLabelStore{PostRetentionLabelStore: childStore.PostRetentionLabel(), Root: &newStore}
 	newStore.PreferenceStore = &RetryLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &RetryLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.PushNotificationReceiptStore = &RetryLayerPushNotificationReceiptStore{PushNotificationReceiptStore: childStore.PushNotificationReceipt(), Root: &newStore}
 	newStore.ReactionStore = &RetryLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.ReminderStore = &RetryLayerReminderStore{ReminderStore: childStore.Reminder(), Root: &newStore}
 	newStore.RemoteClusterStore = &RetryLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &RetryLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &RetryLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledConfigChangeStore = &RetryLayerScheduledConfigChangeStore{ScheduledConfigChangeStore: childStore.ScheduledConfigChange(), Root: &newStore}
 	newStore.SchemeStore = &RetryLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &RetryLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.SharedChannelStore = &RetryLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
 	newStore.StatusStore = &RetryLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.SystemStore = &RetryLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TablePartitionStore = &RetryLayerTablePartitionStore{TablePartitionStore: childStore.TablePartition(), Root: &newStore}
 	newStore.TeamStore = &RetryLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TeamInviteLinkStore = &RetryLayerTeamInviteLinkStore{TeamInviteLinkStore: childStore.TeamInviteLink(), Root: &newStore}
+	newStore.TeamTemplateStore = &RetryLayerTeamTemplateStore{TeamTemplateStore: childStore.TeamTemplate(), Root: &newStore}
 	newStore.TermsOfServiceStore = &RetryLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
 	newStore.ThreadStore = &RetryLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
 	newStore.TokenStore = &RetryLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
 	newStore.UploadSessionStore = &RetryLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
 	newStore.UserStore = &RetryLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &RetryLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserDeviceStore = &RetryLayerUserDeviceStore{UserDeviceStore: childStore.UserDevice(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &RetryLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
 	newStore.WebhookStore = &RetryLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
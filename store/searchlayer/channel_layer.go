@@ -23,12 +23,13 @@ func (c *SearchChannelStore) deleteChannelIndex(channel *model.Channel) {
 	if channel.Type == model.ChannelTypeOpen {
 		for _, engine := range c.rootStore.searchEngine.GetActiveEngines() {
 			if engine.IsIndexingEnabled() {
-				runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				c.rootStore.runIndexFn(engine, "delete_channel", channel.Id, func(engineCopy searchengine.SearchEngineInterface) error {
 					if err := engineCopy.DeleteChannel(channel); err != nil {
 						mlog.Warn("Encountered error deleting channel", mlog.String("channel_id", channel.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-						return
+						return err
 					}
 					mlog.Debug("Removed channel from index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("channel_id", channel.Id))
+					return nil
 				})
 			}
 		}
@@ -54,12 +55,13 @@ func (c *SearchChannelStore) indexChannel(channel *model.Channel) {
 
 	for _, engine := range c.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			c.rootStore.runIndexFn(engine, "index_channel", channel.Id, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.IndexChannel(channel, userIDs, teamMemberIDs); err != nil {
 					mlog.Warn("Encountered error indexing channel", mlog.String("channel_id", channel.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Indexed channel in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("channel_id", channel.Id))
+				return nil
 			})
 		}
 	}
@@ -4,6 +4,8 @@
 package searchlayer
 
 import (
+	"strconv"
+
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/services/searchengine"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -18,21 +20,22 @@ type SearchFileInfoStore struct {
 func (s SearchFileInfoStore) indexFile(file *model.FileInfo) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "index_file", file.Id, func(engineCopy searchengine.SearchEngineInterface) error {
 				if file.PostId == "" {
-					return
+					return nil
 				}
 				post, postErr := s.rootStore.Post().GetSingle(file.PostId, false)
 				if postErr != nil {
 					mlog.Error("Couldn't get post for file for SearchEngine indexing.", mlog.String("post_id", file.PostId), mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", file.Id), mlog.Err(postErr))
-					return
+					return postErr
 				}
 
 				if err := engineCopy.IndexFile(file, post.ChannelId); err != nil {
 					mlog.Error("Encountered error indexing file", mlog.String("file_info_id", file.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Indexed file in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", file.Id))
+				return nil
 			})
 		}
 	}
@@ -41,12 +44,13 @@ func (s SearchFileInfoStore) indexFile(file *model.FileInfo) {
 func (s SearchFileInfoStore) deleteFileIndex(fileID string) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_file", fileID, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeleteFile(fileID); err != nil {
 					mlog.Error("Encountered error deleting file", mlog.String("file_info_id", fileID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed file from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", fileID))
+				return nil
 			})
 		}
 	}
@@ -55,12 +59,13 @@ func (s SearchFileInfoStore) deleteFileIndex(fileID string) {
 func (s SearchFileInfoStore) deleteFileIndexForUser(userID string) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_user_files", userID, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeleteUserFiles(userID); err != nil {
 					mlog.Error("Encountered error deleting files for user", mlog.String("user_id", userID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed user's files from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("user_id", userID))
+				return nil
 			})
 		}
 	}
@@ -69,12 +74,13 @@ func (s SearchFileInfoStore) deleteFileIndexForUser(userID string) {
 func (s SearchFileInfoStore) deleteFileIndexForPost(postID string) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_post_files", postID, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeletePostFiles(postID); err != nil {
 					mlog.Error("Encountered error deleting files for post", mlog.String("post_id", postID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed post's files from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("post_id", postID))
+				return nil
 			})
 		}
 	}
@@ -83,12 +89,13 @@ func (s SearchFileInfoStore) deleteFileIndexForPost(postID string) {
 func (s SearchFileInfoStore) deleteFileIndexBatch(endTime, limit int64) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_files_batch", strconv.FormatInt(endTime, 10), func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeleteFilesBatch(endTime, limit); err != nil {
 					mlog.Error("Encountered error deleting a batch of files", mlog.Int64("limit", limit), mlog.Int64("end_time", endTime), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed batch of files from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.Int64("end_time", endTime), mlog.Int64("limit", limit))
+				return nil
 			})
 		}
 	}
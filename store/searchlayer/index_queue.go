@@ -0,0 +1,188 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/einterfaces"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/services/searchengine"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	indexQueueSize    = 10000
+	indexQueueWorkers = 8
+	// indexQueueEnqueueTimeout is how long a store call waits for room in a full queue before the
+	// operation is dead-lettered.
+	indexQueueEnqueueTimeout = 5 * time.Second
+	indexTaskMaxAttempts     = 3
+	indexTaskRetryDelay      = time.Second
+	// indexDeadLettersMax is how many of the most recent dead letters are kept.
+	indexDeadLettersMax = 1000
+)
+
+var errIndexQueueFull = errors.New("indexing queue is full")
+
+// IndexDeadLetter is an indexing operation that was given up on, either because it kept failing
+// or because the indexing queue stayed full. The affected documents are only brought back in sync
+// by indexing them again.
+type IndexDeadLetter struct {
+	Engine    string
+	Operation string
+	Id        string
+	Error     string
+	FailedAt  int64
+}
+
+type indexTask struct {
+	engine     searchengine.SearchEngineInterface
+	operation  string
+	id         string
+	fn         func(searchengine.SearchEngineInterface) error
+	enqueuedAt time.Time
+}
+
+// indexQueue runs the indexing operations of the asynchronous search engines on a fixed pool of
+// workers. When the queue is full, callers are blocked for a while rather than spawning an
+// unbounded number of goroutines.
+type indexQueue struct {
+	tasks   chan *indexTask
+	metrics einterfaces.MetricsInterface
+	stop    chan struct{}
+	wg      sync.WaitGroup
+
+	deadLettersMut sync.Mutex
+	deadLetters    []IndexDeadLetter
+}
+
+func newIndexQueue(metrics einterfaces.MetricsInterface) *indexQueue {
+	q := &indexQueue{
+		tasks:   make(chan *indexTask, indexQueueSize),
+		metrics: metrics,
+		stop:    make(chan struct{}),
+	}
+
+	q.wg.Add(indexQueueWorkers)
+	for i := 0; i < indexQueueWorkers; i++ {
+		go q.work()
+	}
+
+	return q
+}
+
+func (q *indexQueue) enqueue(task *indexTask) {
+	task.enqueuedAt = time.Now()
+
+	select {
+	case q.tasks <- task:
+		q.observeDepth()
+		return
+	default:
+	}
+
+	mlog.Warn("Search indexing queue is full, waiting for room", mlog.String("search_engine", task.engine.GetName()), mlog.String("operation", task.operation), mlog.Int("queue_size", indexQueueSize))
+
+	timer := time.NewTimer(indexQueueEnqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case q.tasks <- task:
+		q.observeDepth()
+	case <-timer.C:
+		q.addDeadLetter(task, errIndexQueueFull)
+	case <-q.stop:
+		q.addDeadLetter(task, errors.New("indexing queue is stopped"))
+	}
+}
+
+func (q *indexQueue) work() {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case task := <-q.tasks:
+			q.observeDepth()
+			q.process(task, indexTaskMaxAttempts)
+		}
+	}
+}
+
+// process runs task, retrying it up to attempts times before dead-lettering it.
+func (q *indexQueue) process(task *indexTask, attempts int) {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = task.fn(task.engine); err == nil {
+			if q.metrics != nil {
+				q.metrics.ObserveSearchIndexingLag(time.Since(task.enqueuedAt).Seconds())
+			}
+			return
+		}
+
+		if attempt < attempts {
+			select {
+			case <-time.After(time.Duration(attempt) * indexTaskRetryDelay):
+			case <-q.stop:
+				q.addDeadLetter(task, err)
+				return
+			}
+		}
+	}
+
+	q.addDeadLetter(task, err)
+}
+
+func (q *indexQueue) addDeadLetter(task *indexTask, err error) {
+	mlog.Error("Giving up on search indexing operation", mlog.String("search_engine", task.engine.GetName()), mlog.String("operation", task.operation), mlog.String("id", task.id), mlog.Err(err))
+
+	if q.metrics != nil {
+		q.metrics.IncrementSearchIndexingDeadLetterCounter(task.engine.GetName())
+	}
+
+	q.deadLettersMut.Lock()
+	defer q.deadLettersMut.Unlock()
+
+	q.deadLetters = append(q.deadLetters, IndexDeadLetter{
+		Engine:    task.engine.GetName(),
+		Operation: task.operation,
+		Id:        task.id,
+		Error:     err.Error(),
+		FailedAt:  model.GetMillis(),
+	})
+	if len(q.deadLetters) > indexDeadLettersMax {
+		q.deadLetters = q.deadLetters[len(q.deadLetters)-indexDeadLettersMax:]
+	}
+}
+
+func (q *indexQueue) getDeadLetters() []IndexDeadLetter {
+	q.deadLettersMut.Lock()
+	defer q.deadLettersMut.Unlock()
+
+	deadLetters := make([]IndexDeadLetter, len(q.deadLetters))
+	copy(deadLetters, q.deadLetters)
+	return deadLetters
+}
+
+func (q *indexQueue) observeDepth() {
+	if q.metrics != nil {
+		q.metrics.SetSearchIndexingQueueDepth(float64(len(q.tasks)))
+	}
+}
+
+// close stops the workers once they are done with their current operation. The operations
+// still queued are dropped.
+func (q *indexQueue) close() {
+	close(q.stop)
+	q.wg.Wait()
+
+	if pending := len(q.tasks); pending > 0 {
+		mlog.Warn("Dropping queued search indexing operations on shutdown", mlog.Int("pending", pending))
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	emocks "github.com/mattermost/mattermost-server/v6/einterfaces/mocks"
+	"github.com/mattermost/mattermost-server/v6/services/searchengine"
+	"github.com/mattermost/mattermost-server/v6/services/searchengine/mocks"
+)
+
+func TestIndexQueue(t *testing.T) {
+	engine := &mocks.SearchEngineInterface{}
+	engine.On("GetName").Return("testengine")
+
+	t.Run("runs queued operations", func(t *testing.T) {
+		metrics := &emocks.MetricsInterface{}
+		metrics.On("SetSearchIndexingQueueDepth", mock.Anything).Return()
+		metrics.On("ObserveSearchIndexingLag", mock.Anything).Return()
+
+		q := newIndexQueue(metrics)
+		defer q.close()
+
+		done := make(chan struct{})
+		q.enqueue(&indexTask{
+			engine:    engine,
+			operation: "index_post",
+			id:        "post1",
+			fn: func(searchengine.SearchEngineInterface) error {
+				close(done)
+				return nil
+			},
+		})
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the queued operation wasn't run")
+		}
+		metrics.AssertCalled(t, "SetSearchIndexingQueueDepth", mock.Anything)
+	})
+
+	t.Run("retries failing operations", func(t *testing.T) {
+		q := newIndexQueue(nil)
+		defer q.close()
+
+		calls := 0
+		q.process(&indexTask{
+			engine:    engine,
+			operation: "index_post",
+			id:        "post1",
+			fn: func(searchengine.SearchEngineInterface) error {
+				calls++
+				if calls == 1 {
+					return errors.New("unavailable")
+				}
+				return nil
+			},
+		}, 2)
+
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, q.getDeadLetters())
+	})
+
+	t.Run("dead-letters operations that keep failing", func(t *testing.T) {
+		metrics := &emocks.MetricsInterface{}
+		metrics.On("IncrementSearchIndexingDeadLetterCounter", "testengine").Return()
+
+		q := newIndexQueue(metrics)
+		defer q.close()
+
+		q.process(&indexTask{
+			engine:    engine,
+			operation: "delete_post",
+			id:        "post2",
+			fn: func(searchengine.SearchEngineInterface) error {
+				return errors.New("unavailable")
+			},
+		}, 1)
+
+		deadLetters := q.getDeadLetters()
+		require.Len(t, deadLetters, 1)
+		assert.Equal(t, "testengine", deadLetters[0].Engine)
+		assert.Equal(t, "delete_post", deadLetters[0].Operation)
+		assert.Equal(t, "post2", deadLetters[0].Id)
+		assert.Equal(t, "unavailable", deadLetters[0].Error)
+		metrics.AssertExpectations(t)
+	})
+}
@@ -5,8 +5,11 @@ package searchlayer
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/mattermost/mattermost-server/v6/einterfaces"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/services/searchengine"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -22,12 +25,15 @@ type SearchStore struct {
 	post         *SearchPostStore
 	fileInfo     *SearchFileInfoStore
 	configValue  atomic.Value
+	indexQueue   *indexQueue
+	closeOnce    sync.Once
 }
 
-func NewSearchLayer(baseStore store.Store, searchEngine *searchengine.Broker, cfg *model.Config) *SearchStore {
+func NewSearchLayer(baseStore store.Store, searchEngine *searchengine.Broker, cfg *model.Config, metrics einterfaces.MetricsInterface) *SearchStore {
 	searchStore := &SearchStore{
 		Store:        baseStore,
 		searchEngine: searchEngine,
+		indexQueue:   newIndexQueue(metrics),
 	}
 	searchStore.configValue.Store(cfg)
 	searchStore.channel = &SearchChannelStore{ChannelStore: baseStore.Channel(), rootStore: searchStore}
@@ -47,6 +53,16 @@ func (s *SearchStore) getConfig() *model.Config {
 	return s.configValue.Load().(*model.Config)
 }
 
+func (s *SearchStore) Close() {
+	s.closeOnce.Do(s.indexQueue.close)
+	s.Store.Close()
+}
+
+// IndexDeadLetters returns the most recent indexing operations that were given up on, oldest first.
+func (s *SearchStore) IndexDeadLetters() []IndexDeadLetter {
+	return s.indexQueue.getDeadLetters()
+}
+
 func (s *SearchStore) Channel() store.ChannelStore {
 	return s.channel
 }
@@ -78,11 +94,11 @@ func (s *SearchStore) indexUserFromID(userId string) {
 func (s *SearchStore) indexUser(user *model.User) {
 	for _, engine := range s.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.runIndexFn(engine, "index_user", user.Id, func(engineCopy searchengine.SearchEngineInterface) error {
 				userTeams, nErr := s.Team().GetTeamsByUserId(user.Id)
 				if nErr != nil {
 					mlog.Error("Encountered error indexing user", mlog.String("user_id", user.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(nErr))
-					return
+					return nErr
 				}
 
 				userTeamsIds := []string{}
@@ -93,7 +109,7 @@ func (s *SearchStore) indexUser(user *model.User) {
 				userChannelMembers, err := s.Channel().GetAllChannelMembersForUser(user.Id, false, true)
 				if err != nil {
 					mlog.Error("Encountered error indexing user", mlog.String("user_id", user.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 
 				userChannelsIds := []string{}
@@ -103,24 +119,32 @@ func (s *SearchStore) indexUser(user *model.User) {
 
 				if err := engineCopy.IndexUser(user, userTeamsIds, userChannelsIds); err != nil {
 					mlog.Error("Encountered error indexing user", mlog.String("user_id", user.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Indexed user in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("user_id", user.Id))
+				return nil
 			})
 		}
 	}
 }
 
-// Runs an indexing function synchronously or asynchronously depending on the engine
-func runIndexFn(engine searchengine.SearchEngineInterface, indexFn func(searchengine.SearchEngineInterface)) {
+// Runs an indexing function synchronously or through the indexing queue depending on the engine.
+// Synchronous engines are only given one attempt, so that callers aren't held up by retries.
+func (s *SearchStore) runIndexFn(engine searchengine.SearchEngineInterface, operation, id string, indexFn func(searchengine.SearchEngineInterface) error) {
+	task := &indexTask{
+		engine:    engine,
+		operation: operation,
+		id:        id,
+		fn:        indexFn,
+	}
+
 	if engine.IsIndexingSync() {
-		indexFn(engine)
+		task.enqueuedAt = time.Now()
+		s.indexQueue.process(task, 1)
 		if err := engine.RefreshIndexes(); err != nil {
 			mlog.Error("Encountered error refresh the indexes", mlog.Err(err))
 		}
 	} else {
-		go (func(engineCopy searchengine.SearchEngineInterface) {
-			indexFn(engineCopy)
-		})(engine)
+		s.indexQueue.enqueue(task)
 	}
 }
@@ -30,7 +30,7 @@ func TestUpdateConfigRace(t *testing.T) {
 	cfg.SetDefaults()
 	cfg.ClusterSettings.MaxIdleConns = model.NewInt(1)
 	searchEngine := searchengine.NewBroker(cfg)
-	layer := searchlayer.NewSearchLayer(&testlib.TestStore{Store: store}, searchEngine, cfg)
+	layer := searchlayer.NewSearchLayer(&testlib.TestStore{Store: store}, searchEngine, cfg, nil)
 	var wg sync.WaitGroup
 
 	wg.Add(5)
@@ -22,17 +22,18 @@ type SearchPostStore struct {
 func (s SearchPostStore) indexPost(post *model.Post) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "index_post", post.Id, func(engineCopy searchengine.SearchEngineInterface) error {
 				channel, chanErr := s.rootStore.Channel().Get(post.ChannelId, true)
 				if chanErr != nil {
 					mlog.Error("Couldn't get channel for post for SearchEngine indexing.", mlog.String("channel_id", post.ChannelId), mlog.String("search_engine", engineCopy.GetName()), mlog.String("post_id", post.Id), mlog.Err(chanErr))
-					return
+					return chanErr
 				}
 				if err := engineCopy.IndexPost(post, channel.TeamId); err != nil {
 					mlog.Warn("Encountered error indexing post", mlog.String("post_id", post.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Indexed post in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("post_id", post.Id))
+				return nil
 			})
 		}
 	}
@@ -41,12 +42,13 @@ func (s SearchPostStore) indexPost(post *model.Post) {
 func (s SearchPostStore) deletePostIndex(post *model.Post) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_post", post.Id, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeletePost(post); err != nil {
 					mlog.Warn("Encountered error deleting post", mlog.String("post_id", post.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed post from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("post_id", post.Id))
+				return nil
 			})
 		}
 	}
@@ -55,12 +57,13 @@ func (s SearchPostStore) deletePostIndex(post *model.Post) {
 func (s SearchPostStore) deleteChannelPostsIndex(channelID string) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_channel_posts", channelID, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeleteChannelPosts(channelID); err != nil {
 					mlog.Warn("Encountered error deleting channel posts", mlog.String("channel_id", channelID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed all channel posts from the index in search engine", mlog.String("channel_id", channelID), mlog.String("search_engine", engineCopy.GetName()))
+				return nil
 			})
 		}
 	}
@@ -69,12 +72,13 @@ func (s SearchPostStore) deleteChannelPostsIndex(channelID string) {
 func (s SearchPostStore) deleteUserPostsIndex(userID string) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_user_posts", userID, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeleteUserPosts(userID); err != nil {
 					mlog.Warn("Encountered error deleting user posts", mlog.String("user_id", userID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed all user posts from the index in search engine", mlog.String("user_id", userID), mlog.String("search_engine", engineCopy.GetName()))
+				return nil
 			})
 		}
 	}
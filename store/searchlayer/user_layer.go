@@ -23,12 +23,13 @@ type SearchUserStore struct {
 func (s *SearchUserStore) deleteUserIndex(user *model.User) {
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
-			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+			s.rootStore.runIndexFn(engine, "delete_user", user.Id, func(engineCopy searchengine.SearchEngineInterface) error {
 				if err := engineCopy.DeleteUser(user); err != nil {
 					mlog.Error("Encountered error deleting user", mlog.String("user_id", user.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
-					return
+					return err
 				}
 				mlog.Debug("Removed user from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("user_id", user.Id))
+				return nil
 			})
 		}
 	}
@@ -267,6 +267,11 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testSearchAcrossTeams,
 		Tags: []string{EngineAll},
 	},
+	{
+		Name: "Should be able to filter messages with a regex and has: operators",
+		Fn:   testSearchAdvancedFilters,
+		Tags: []string{EnginePostgres, EngineMySql},
+	},
 }
 
 func TestSearchPostStore(t *testing.T, s store.Store, testEngine *SearchTestEngine) {
@@ -1841,3 +1846,52 @@ func testSearchAcrossTeams(t *testing.T, th *SearchTestHelper) {
 
 	require.Len(t, results.Posts, 2)
 }
+
+func testSearchAdvancedFilters(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "release v12 is out at https://example.com", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "release notes are pending", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	postModel := th.createPostModel(th.User.Id, th.ChannelBasic.Id, "release v13 attached", "", model.PostTypeDefault, 1000000, false)
+	postModel.FileIds = model.StringArray{model.NewId()}
+	p3, err := th.Store.Post().Save(postModel)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	t.Run("Should be able to filter messages with a regex", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "release", Regex: "v1[0-9]"}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 2)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+
+	t.Run("Should be able to filter messages with a regex and no terms", func(t *testing.T) {
+		params := &model.SearchParams{Regex: "notes? are"}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("Should be able to filter messages with links", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "release", HasLink: true}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	})
+
+	t.Run("Should be able to filter messages with files", func(t *testing.T) {
+		params := &model.SearchParams{Terms: "release", HasFile: true}
+		results, err := th.Store.Post().SearchPostsForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"encoding/json"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var changeEventColumns = []string{"Id", "EntityType", "EntityId", "Operation", "Data", "CreateAt", "PublishedAt"}
+
+// changeEvent is a row of the ChangeEvents table, which holds the data of an event as JSON.
+type changeEvent struct {
+	Id          string
+	EntityType  string
+	EntityId    string
+	Operation   string
+	Data        string
+	CreateAt    int64
+	PublishedAt int64
+}
+
+func (e *changeEvent) toModel() *model.ChangeEvent {
+	event := &model.ChangeEvent{
+		Id:          e.Id,
+		EntityType:  e.EntityType,
+		EntityId:    e.EntityId,
+		Operation:   e.Operation,
+		CreateAt:    e.CreateAt,
+		PublishedAt: e.PublishedAt,
+	}
+
+	if e.Data != "" {
+		event.Data = json.RawMessage(e.Data)
+	}
+
+	return event
+}
+
+type SqlChangeEventStore struct {
+	*SqlStore
+}
+
+func newSqlChangeEventStore(sqlStore *SqlStore) store.ChangeEventStore {
+	return &SqlChangeEventStore{sqlStore}
+}
+
+func (s SqlChangeEventStore) Save(event *model.ChangeEvent) (*model.ChangeEvent, error) {
+	event.PreSave()
+	if err := event.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("ChangeEvents").
+		Columns(changeEventColumns...).
+		Values(event.Id, event.EntityType, event.EntityId, event.Operation, string(event.Data), event.CreateAt, event.PublishedAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "change_event_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ChangeEvent with id=%s", event.Id)
+	}
+
+	return event, nil
+}
+
+func (s SqlChangeEventStore) GetUnpublished(limit int) ([]*model.ChangeEvent, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(changeEventColumns...).
+		From("ChangeEvents").
+		Where(sq.Eq{"PublishedAt": 0}).
+		OrderBy("CreateAt ASC", "Id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "change_event_tosql")
+	}
+
+	rows := []*changeEvent{}
+	if err := s.GetMasterX().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get unpublished ChangeEvents")
+	}
+
+	events := make([]*model.ChangeEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, row.toModel())
+	}
+
+	return events, nil
+}
+
+func (s SqlChangeEventStore) MarkPublished(ids []string, publishedAt int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("ChangeEvents").
+		Set("PublishedAt", publishedAt).
+		Where(sq.Eq{"Id": ids}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "change_event_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrap(err, "failed to mark ChangeEvents as published")
+	}
+
+	return nil
+}
+
+func (s SqlChangeEventStore) PermanentDeletePublishedBatch(endTime int64, limit int64) (int64, error) {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM ChangeEvents WHERE Id = any (array (SELECT Id FROM ChangeEvents WHERE PublishedAt > 0 AND PublishedAt < ? LIMIT ?))"
+	} else {
+		query = "DELETE FROM ChangeEvents WHERE PublishedAt > 0 AND PublishedAt < ? LIMIT ?"
+	}
+
+	result, err := s.GetMasterX().Exec(query, endTime, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete published ChangeEvents in batch")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var channelBookmarkColumns = []string{"Id", "ChannelId", "OwnerId", "DisplayName", "Type", "LinkUrl", "FileId", "Emoji", "SortOrder", "CreateAt", "UpdateAt", "DeleteAt"}
+
+type SqlChannelBookmarkStore struct {
+	*SqlStore
+}
+
+func newSqlChannelBookmarkStore(sqlStore *SqlStore) store.ChannelBookmarkStore {
+	return &SqlChannelBookmarkStore{sqlStore}
+}
+
+func (s SqlChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	bookmark.PreSave()
+	if err := bookmark.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("ChannelBookmarks").
+		Columns(channelBookmarkColumns...).
+		Values(bookmark.Id, bookmark.ChannelId, bookmark.OwnerId, bookmark.DisplayName, bookmark.Type, bookmark.LinkUrl, bookmark.FileId, bookmark.Emoji, bookmark.SortOrder, bookmark.CreateAt, bookmark.UpdateAt, bookmark.DeleteAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_bookmark_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ChannelBookmark with id=%s", bookmark.Id)
+	}
+
+	return bookmark, nil
+}
+
+func (s SqlChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
+	bookmark.PreUpdate()
+	if err := bookmark.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("ChannelBookmarks").
+		SetMap(map[string]interface{}{
+			"DisplayName": bookmark.DisplayName,
+			"LinkUrl":     bookmark.LinkUrl,
+			"FileId":      bookmark.FileId,
+			"Emoji":       bookmark.Emoji,
+			"UpdateAt":    bookmark.UpdateAt,
+		}).
+		Where(sq.Eq{"Id": bookmark.Id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_bookmark_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update ChannelBookmark with id=%s", bookmark.Id)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	} else if rows == 0 {
+		return nil, store.NewErrNotFound("ChannelBookmark", bookmark.Id)
+	}
+
+	return bookmark, nil
+}
+
+func (s SqlChannelBookmarkStore) Get(id string, includeDeleted bool) (*model.ChannelBookmark, error) {
+	builder := s.getQueryBuilder().
+		Select(channelBookmarkColumns...).
+		From("ChannelBookmarks").
+		Where(sq.Eq{"Id": id})
+	if !includeDeleted {
+		builder = builder.Where(sq.Eq{"DeleteAt": 0})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_bookmark_tosql")
+	}
+
+	var bookmark model.ChannelBookmark
+	if err := s.GetReplicaX().Get(&bookmark, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelBookmark", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelBookmark with id=%s", id)
+	}
+
+	return &bookmark, nil
+}
+
+func (s SqlChannelBookmarkStore) GetForChannel(channelID string) ([]*model.ChannelBookmark, error) {
+	return s.getForChannel(s.GetReplicaX(), channelID)
+}
+
+func (s SqlChannelBookmarkStore) getForChannel(q sqlxExecutor, channelID string) ([]*model.ChannelBookmark, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelBookmarkColumns...).
+		From("ChannelBookmarks").
+		Where(sq.Eq{"ChannelId": channelID, "DeleteAt": 0}).
+		OrderBy("SortOrder ASC", "CreateAt ASC", "Id ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_bookmark_tosql")
+	}
+
+	bookmarks := []*model.ChannelBookmark{}
+	if err := q.Select(&bookmarks, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelBookmarks with channelId=%s", channelID)
+	}
+
+	return bookmarks, nil
+}
+
+func (s SqlChannelBookmarkStore) UpdateSortOrder(bookmarkID, channelID string, newIndex int64) ([]*model.ChannelBookmark, error) {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	bookmarks, err := s.getForChannel(transaction, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := -1
+	for i, bookmark := range bookmarks {
+		if bookmark.Id == bookmarkID {
+			current = i
+			break
+		}
+	}
+	if current == -1 {
+		return nil, store.NewErrNotFound("ChannelBookmark", bookmarkID)
+	}
+	if newIndex < 0 || newIndex >= int64(len(bookmarks)) {
+		return nil, store.NewErrInvalidInput("ChannelBookmark", "SortOrder", newIndex)
+	}
+
+	moved := bookmarks[current]
+	bookmarks = append(bookmarks[:current], bookmarks[current+1:]...)
+	bookmarks = append(bookmarks[:newIndex], append([]*model.ChannelBookmark{moved}, bookmarks[newIndex:]...)...)
+
+	updateAt := model.GetMillis()
+	changed := []*model.ChannelBookmark{}
+	for i, bookmark := range bookmarks {
+		if bookmark.SortOrder == int64(i) {
+			continue
+		}
+
+		query, args, err := s.getQueryBuilder().
+			Update("ChannelBookmarks").
+			SetMap(map[string]interface{}{
+				"SortOrder": i,
+				"UpdateAt":  updateAt,
+			}).
+			Where(sq.Eq{"Id": bookmark.Id}).
+			ToSql()
+		if err != nil {
+			return nil, errors.Wrap(err, "channel_bookmark_tosql")
+		}
+		if _, err := transaction.Exec(query, args...); err != nil {
+			return nil, errors.Wrapf(err, "failed to update ChannelBookmark with id=%s", bookmark.Id)
+		}
+
+		bookmark.SortOrder = int64(i)
+		bookmark.UpdateAt = updateAt
+		changed = append(changed, bookmark)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return changed, nil
+}
+
+func (s SqlChannelBookmarkStore) Delete(id string, deleteAt int64) error {
+	query, args, err := s.getQueryBuilder().
+		Update("ChannelBookmarks").
+		SetMap(map[string]interface{}{
+			"DeleteAt": deleteAt,
+			"UpdateAt": deleteAt,
+		}).
+		Where(sq.Eq{"Id": id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_bookmark_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelBookmark with id=%s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var channelEventColumns = []string{"Id", "ChannelId", "CreatorId", "Title", "Description", "StartAt", "Timezone", "Recurrence", "RemindBeforeMinutes", "NextStartAt", "NotifyAt", "CreateAt", "UpdateAt"}
+
+type SqlChannelEventStore struct {
+	*SqlStore
+}
+
+func newSqlChannelEventStore(sqlStore *SqlStore) store.ChannelEventStore {
+	return &SqlChannelEventStore{sqlStore}
+}
+
+func (s SqlChannelEventStore) Save(event *model.ChannelEvent) (*model.ChannelEvent, error) {
+	event.PreSave()
+	if err := event.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("ChannelEvents").
+		Columns(channelEventColumns...).
+		Values(event.Id, event.ChannelId, event.CreatorId, event.Title, event.Description, event.StartAt, event.Timezone, event.Recurrence, event.RemindBeforeMinutes, event.NextStartAt, event.NotifyAt, event.CreateAt, event.UpdateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_event_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ChannelEvent with id=%s", event.Id)
+	}
+
+	return event, nil
+}
+
+func (s SqlChannelEventStore) Update(event *model.ChannelEvent) (*model.ChannelEvent, error) {
+	event.PreUpdate()
+	if err := event.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("ChannelEvents").
+		SetMap(map[string]interface{}{
+			"Title":               event.Title,
+			"Description":         event.Description,
+			"StartAt":             event.StartAt,
+			"Timezone":            event.Timezone,
+			"Recurrence":          event.Recurrence,
+			"RemindBeforeMinutes": event.RemindBeforeMinutes,
+			"NextStartAt":         event.NextStartAt,
+			"NotifyAt":            event.NotifyAt,
+			"UpdateAt":            event.UpdateAt,
+		}).
+		Where(sq.Eq{"Id": event.Id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_event_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to update ChannelEvent with id=%s", event.Id)
+	}
+
+	return event, nil
+}
+
+func (s SqlChannelEventStore) Get(id string) (*model.ChannelEvent, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelEventColumns...).
+		From("ChannelEvents").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_event_tosql")
+	}
+
+	var event model.ChannelEvent
+	if err := s.GetReplicaX().Get(&event, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelEvent", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelEvent with id=%s", id)
+	}
+
+	return &event, nil
+}
+
+func (s SqlChannelEventStore) GetForChannel(channelID string) ([]*model.ChannelEvent, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelEventColumns...).
+		From("ChannelEvents").
+		Where(sq.Eq{"ChannelId": channelID}).
+		OrderBy("StartAt ASC", "Id ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_event_tosql")
+	}
+
+	events := []*model.ChannelEvent{}
+	if err := s.GetReplicaX().Select(&events, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelEvents with channelId=%s", channelID)
+	}
+
+	return events, nil
+}
+
+func (s SqlChannelEventStore) GetDue(now int64, limit int) ([]*model.ChannelEvent, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelEventColumns...).
+		From("ChannelEvents").
+		Where(sq.And{
+			sq.Gt{"NotifyAt": 0},
+			sq.LtOrEq{"NotifyAt": now},
+		}).
+		OrderBy("NotifyAt ASC", "Id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_event_tosql")
+	}
+
+	events := []*model.ChannelEvent{}
+	if err := s.GetMasterX().Select(&events, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find due ChannelEvents")
+	}
+
+	return events, nil
+}
+
+func (s SqlChannelEventStore) Delete(id string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("ChannelEvents").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_event_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelEvent with id=%s", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var channelJoinRequestColumns = []string{"Id", "ChannelId", "UserId", "Message", "Status", "ReviewerId", "CreateAt", "UpdateAt", "ExpireAt"}
+
+type SqlChannelJoinRequestStore struct {
+	*SqlStore
+}
+
+func newSqlChannelJoinRequestStore(sqlStore *SqlStore) store.ChannelJoinRequestStore {
+	return &SqlChannelJoinRequestStore{sqlStore}
+}
+
+func (s SqlChannelJoinRequestStore) Save(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error) {
+	request.PreSave()
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	// A pending request past its expiry which wasn't marked as such yet would otherwise prevent
+	// the user from asking again.
+	if request.Status == model.ChannelJoinRequestStatusPending {
+		if err := s.expireForUser(request.ChannelId, request.UserId, model.GetMillis()); err != nil {
+			return nil, err
+		}
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("ChannelJoinRequests").
+		Columns(channelJoinRequestColumns...).
+		Values(request.Id, request.ChannelId, request.UserId, request.Message, request.Status, request.ReviewerId, request.CreateAt, request.UpdateAt, request.ExpireAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_join_request_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		if IsUniqueConstraintError(err, []string{"idx_channeljoinrequests_pending_channel_id_user_id"}) {
+			return nil, store.NewErrUniqueConstraint("ChannelId", "UserId")
+		}
+		return nil, errors.Wrapf(err, "failed to save ChannelJoinRequest with id=%s", request.Id)
+	}
+
+	return request, nil
+}
+
+func (s SqlChannelJoinRequestStore) Update(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error) {
+	request.PreUpdate()
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("ChannelJoinRequests").
+		SetMap(map[string]interface{}{
+			"Message":    request.Message,
+			"Status":     request.Status,
+			"ReviewerId": request.ReviewerId,
+			"UpdateAt":   request.UpdateAt,
+			"ExpireAt":   request.ExpireAt,
+		}).
+		Where(sq.And{
+			sq.Eq{"Id": request.Id},
+			sq.Eq{"Status": model.ChannelJoinRequestStatusPending},
+			sq.Gt{"ExpireAt": request.UpdateAt},
+		}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_join_request_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update ChannelJoinRequest with id=%s", request.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return nil, store.NewErrConflict("ChannelJoinRequest", errors.New("request is not pending"), "id="+request.Id)
+	}
+
+	return request, nil
+}
+
+func (s SqlChannelJoinRequestStore) Get(id string) (*model.ChannelJoinRequest, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelJoinRequestColumns...).
+		From("ChannelJoinRequests").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_join_request_tosql")
+	}
+
+	var request model.ChannelJoinRequest
+	if err := s.GetMasterX().Get(&request, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelJoinRequest", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelJoinRequest with id=%s", id)
+	}
+
+	return &request, nil
+}
+
+func (s SqlChannelJoinRequestStore) GetPendingForChannel(channelID string, now int64) ([]*model.ChannelJoinRequest, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(channelJoinRequestColumns...).
+		From("ChannelJoinRequests").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.Eq{"Status": model.ChannelJoinRequestStatusPending},
+			sq.Gt{"ExpireAt": now},
+		}).
+		OrderBy("CreateAt ASC", "Id ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_join_request_tosql")
+	}
+
+	requests := []*model.ChannelJoinRequest{}
+	if err := s.GetMasterX().Select(&requests, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelJoinRequests with channelId=%s", channelID)
+	}
+
+	return requests, nil
+}
+
+func (s SqlChannelJoinRequestStore) Delete(id string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("ChannelJoinRequests").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_join_request_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelJoinRequest with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s SqlChannelJoinRequestStore) Expire(now int64) (int64, error) {
+	query, args, err := s.getQueryBuilder().
+		Update("ChannelJoinRequests").
+		Set("Status", model.ChannelJoinRequestStatusExpired).
+		Set("UpdateAt", now).
+		Where(sq.And{
+			sq.Eq{"Status": model.ChannelJoinRequestStatusPending},
+			sq.LtOrEq{"ExpireAt": now},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "channel_join_request_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to expire ChannelJoinRequests")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return count, nil
+}
+
+// expireForUser marks the pending request of a user to join a channel as expired if it is by the
+// given time.
+func (s SqlChannelJoinRequestStore) expireForUser(channelID, userID string, now int64) error {
+	query, args, err := s.getQueryBuilder().
+		Update("ChannelJoinRequests").
+		Set("Status", model.ChannelJoinRequestStatusExpired).
+		Set("UpdateAt", now).
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.Eq{"UserId": userID},
+			sq.Eq{"Status": model.ChannelJoinRequestStatusPending},
+			sq.LtOrEq{"ExpireAt": now},
+		}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_join_request_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to expire ChannelJoinRequests with channelId=%s userId=%s", channelID, userID)
+	}
+
+	return nil
+}
+
+func (s SqlChannelJoinRequestStore) Cleanup(expiryTime int64, batchSize int) error {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM ChannelJoinRequests WHERE Id IN (SELECT Id FROM ChannelJoinRequests WHERE Status != ? AND UpdateAt < ? LIMIT ?)"
+	} else {
+		query = "DELETE FROM ChannelJoinRequests WHERE Status != ? AND UpdateAt < ? LIMIT ?"
+	}
+
+	var rowsAffected int64 = 1
+
+	for rowsAffected > 0 {
+		sqlResult, err := s.GetMasterX().Exec(query, model.ChannelJoinRequestStatusPending, expiryTime, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "unable to delete channel join requests")
+		}
+		rowsAffected, err = sqlResult.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "unable to delete channel join requests")
+		}
+	}
+
+	return nil
+}
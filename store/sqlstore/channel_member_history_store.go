@@ -267,3 +267,28 @@ func (s SqlChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since
 
 	return channelIds, nil
 }
+
+func (s SqlChannelMemberHistoryStore) GetChannelsForUserDuring(userID string, startTime, endTime int64) ([]*model.ChannelMemberHistory, error) {
+	query, params, err := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "JoinTime", "LeaveTime").
+		From("ChannelMemberHistory").
+		Where(sq.And{
+			sq.Eq{"UserId": userID},
+			sq.LtOrEq{"JoinTime": endTime},
+			sq.Or{
+				sq.Eq{"LeaveTime": nil},
+				sq.GtOrEq{"LeaveTime": startTime},
+			},
+		}).
+		OrderBy("JoinTime ASC", "ChannelId ASC").ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_history_to_sql")
+	}
+
+	histories := []*model.ChannelMemberHistory{}
+	if err := s.GetReplicaX().Select(&histories, query, params...); err != nil {
+		return nil, errors.Wrapf(err, "GetChannelsForUserDuring userId=%s startTime=%d endTime=%d", userID, startTime, endTime)
+	}
+
+	return histories, nil
+}
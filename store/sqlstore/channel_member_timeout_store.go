@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlChannelMemberTimeoutStore struct {
+	*SqlStore
+}
+
+func newSqlChannelMemberTimeoutStore(sqlStore *SqlStore) store.ChannelMemberTimeoutStore {
+	return &SqlChannelMemberTimeoutStore{sqlStore}
+}
+
+func (s SqlChannelMemberTimeoutStore) Save(timeout *model.ChannelMemberTimeout) (*model.ChannelMemberTimeout, error) {
+	timeout.PreSave()
+	if err := timeout.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	deleteQuery, args, err := s.getQueryBuilder().
+		Delete("ChannelMemberTimeouts").
+		Where(sq.Eq{"ChannelId": timeout.ChannelId}).
+		Where(sq.Or{
+			sq.Eq{"UserId": timeout.UserId},
+			sq.LtOrEq{"ExpiresAt": timeout.CreateAt},
+		}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_timeout_tosql")
+	}
+	if _, err := transaction.Exec(deleteQuery, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete ChannelMemberTimeouts with channelId=%s", timeout.ChannelId)
+	}
+
+	insertQuery, args, err := s.getQueryBuilder().
+		Insert("ChannelMemberTimeouts").
+		Columns("ChannelId", "UserId", "CreatorId", "CreateAt", "ExpiresAt").
+		Values(timeout.ChannelId, timeout.UserId, timeout.CreatorId, timeout.CreateAt, timeout.ExpiresAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_timeout_tosql")
+	}
+	if _, err := transaction.Exec(insertQuery, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ChannelMemberTimeout with channelId=%s and userId=%s", timeout.ChannelId, timeout.UserId)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return timeout, nil
+}
+
+func (s SqlChannelMemberTimeoutStore) Get(channelID, userID string) (*model.ChannelMemberTimeout, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "CreatorId", "CreateAt", "ExpiresAt").
+		From("ChannelMemberTimeouts").
+		Where(sq.Eq{"ChannelId": channelID, "UserId": userID}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_timeout_tosql")
+	}
+
+	var timeout model.ChannelMemberTimeout
+	if err := s.GetReplicaX().Get(&timeout, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelMemberTimeout", "channelId="+channelID+", userId="+userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelMemberTimeout with channelId=%s and userId=%s", channelID, userID)
+	}
+
+	return &timeout, nil
+}
+
+func (s SqlChannelMemberTimeoutStore) GetForChannel(channelID string, now int64) ([]*model.ChannelMemberTimeout, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "CreatorId", "CreateAt", "ExpiresAt").
+		From("ChannelMemberTimeouts").
+		Where(sq.Eq{"ChannelId": channelID}).
+		Where(sq.Gt{"ExpiresAt": now}).
+		OrderBy("ExpiresAt ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_member_timeout_tosql")
+	}
+
+	timeouts := []*model.ChannelMemberTimeout{}
+	if err := s.GetReplicaX().Select(&timeouts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ChannelMemberTimeouts with channelId=%s", channelID)
+	}
+
+	return timeouts, nil
+}
+
+func (s SqlChannelMemberTimeoutStore) Delete(channelID, userID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("ChannelMemberTimeouts").
+		Where(sq.Eq{"ChannelId": channelID, "UserId": userID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_member_timeout_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelMemberTimeout with channelId=%s and userId=%s", channelID, userID)
+	}
+
+	return nil
+}
@@ -681,9 +681,9 @@ func (s SqlChannelStore) saveChannelT(transaction *sqlxTxWrapper, channel *model
 	}
 
 	if _, err := transaction.NamedExec(`INSERT INTO Channels
-		(Id, CreateAt, UpdateAt, DeleteAt, TeamId, Type, DisplayName, Name, Header, Purpose, LastPostAt, TotalMsgCount, ExtraUpdateAt, CreatorId, SchemeId, GroupConstrained, Shared, TotalMsgCountRoot, LastRootPostAt)
+		(Id, CreateAt, UpdateAt, DeleteAt, TeamId, Type, DisplayName, Name, Header, Purpose, LastPostAt, TotalMsgCount, ExtraUpdateAt, CreatorId, SchemeId, GroupConstrained, Shared, TotalMsgCountRoot, LastRootPostAt, SlowmodeSeconds)
 		VALUES
-		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :TeamId, :Type, :DisplayName, :Name, :Header, :Purpose, :LastPostAt, :TotalMsgCount, :ExtraUpdateAt, :CreatorId, :SchemeId, :GroupConstrained, :Shared, :TotalMsgCountRoot, :LastRootPostAt)`, channel); err != nil {
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :TeamId, :Type, :DisplayName, :Name, :Header, :Purpose, :LastPostAt, :TotalMsgCount, :ExtraUpdateAt, :CreatorId, :SchemeId, :GroupConstrained, :Shared, :TotalMsgCountRoot, :LastRootPostAt, :SlowmodeSeconds)`, channel); err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "channels_name_teamid_key"}) {
 			dupChannel := model.Channel{}
 			s.GetMasterX().Get(&dupChannel, "SELECT * FROM Channels WHERE TeamId = ? AND Name = ?", channel.TeamId, channel.Name)
@@ -691,6 +691,10 @@ func (s SqlChannelStore) saveChannelT(transaction *sqlxTxWrapper, channel *model
 		}
 		return nil, errors.Wrapf(err, "save_channel: id=%s", channel.Id)
 	}
+
+	if _, err := transaction.Exec("INSERT INTO ChannelMemberCounts (ChannelId, MemberCount, GuestCount, UpdateAt) VALUES (?, 0, 0, ?)", channel.Id, channel.CreateAt); err != nil {
+		return nil, errors.Wrapf(err, "save_channel_member_counts: id=%s", channel.Id)
+	}
 	return channel, nil
 }
 
@@ -747,7 +751,8 @@ func (s SqlChannelStore) updateChannelT(transaction *sqlxTxWrapper, channel *mod
 			GroupConstrained=:GroupConstrained,
 			Shared=:Shared,
 			TotalMsgCountRoot=:TotalMsgCountRoot,
-			LastRootPostAt=:LastRootPostAt
+			LastRootPostAt=:LastRootPostAt,
+			SlowmodeSeconds=:SlowmodeSeconds
 		WHERE Id=:Id`, channel)
 	if err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "channels_name_teamid_key"}) {
@@ -795,6 +800,27 @@ func (s SqlChannelStore) GetChannelUnread(channelId, userId string) (*model.Chan
 	return &unreadChannel, nil
 }
 
+// GetChannelUnreadsForUser returns the unread messages and mentions of the user in the channels,
+// of all the teams, they have unread messages or mentions in.
+func (s SqlChannelStore) GetChannelUnreadsForUser(userId string) ([]*model.ChannelUnread, error) {
+	query := s.getQueryBuilder().
+		Select("Channels.TeamId TeamId", "Channels.Id ChannelId", "(Channels.TotalMsgCount - ChannelMembers.MsgCount) MsgCount", "(Channels.TotalMsgCountRoot - ChannelMembers.MsgCountRoot) MsgCountRoot", "ChannelMembers.MentionCount MentionCount", "ChannelMembers.MentionCountRoot MentionCountRoot", "ChannelMembers.NotifyProps NotifyProps").
+		From("Channels").
+		Join("ChannelMembers ON Channels.Id = ChannelMembers.ChannelId").
+		Where(sq.Eq{"ChannelMembers.UserId": userId, "Channels.DeleteAt": 0}).
+		Where(sq.Or{
+			sq.Expr("Channels.TotalMsgCount > ChannelMembers.MsgCount"),
+			sq.Gt{"ChannelMembers.MentionCount": 0},
+		})
+
+	unreads := []*model.ChannelUnread{}
+	if err := s.GetReplicaX().SelectBuilder(&unreads, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get unread Channels with userId=%s", userId)
+	}
+
+	return unreads, nil
+}
+
 //nolint:unparam
 func (s SqlChannelStore) InvalidateChannel(id string) {
 }
@@ -810,7 +836,23 @@ func (s SqlChannelStore) GetPinnedPosts(channelId string) (*model.PostList, erro
 	pl := model.NewPostList()
 
 	posts := []*model.Post{}
-	if err := s.GetReplicaX().Select(&posts, "SELECT *, (SELECT count(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0) as ReplyCount  FROM Posts p WHERE IsPinned = true AND ChannelId = ? AND DeleteAt = 0 ORDER BY CreateAt ASC", channelId); err != nil {
+	// Posts given a position with SetPinnedPostsOrder come first, the others following from the
+	// oldest to the newest.
+	if err := s.GetReplicaX().Select(&posts, `
+		SELECT
+			p.*,
+			(SELECT count(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0) as ReplyCount
+		FROM
+			Posts p
+			LEFT JOIN PinnedPostOrders ppo ON ppo.ChannelId = p.ChannelId AND ppo.PostId = p.Id
+		WHERE
+			p.IsPinned = true
+			AND p.ChannelId = ?
+			AND p.DeleteAt = 0
+		ORDER BY
+			CASE WHEN ppo.SortOrder IS NULL THEN 1 ELSE 0 END,
+			ppo.SortOrder ASC,
+			p.CreateAt ASC`, channelId); err != nil {
 		return nil, errors.Wrap(err, "failed to find Posts")
 	}
 	for _, post := range posts {
@@ -820,6 +862,48 @@ func (s SqlChannelStore) GetPinnedPosts(channelId string) (*model.PostList, erro
 	return pl, nil
 }
 
+func (s SqlChannelStore) SetPinnedPostsOrder(channelID string, postIDs []string) error {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	query, args, err := s.getQueryBuilder().
+		Delete("PinnedPostOrders").
+		Where(sq.Eq{"ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_tosql")
+	}
+	if _, err := transaction.Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete PinnedPostOrders with channelId=%s", channelID)
+	}
+
+	if len(postIDs) > 0 {
+		insert := s.getQueryBuilder().
+			Insert("PinnedPostOrders").
+			Columns("ChannelId", "PostId", "SortOrder")
+		for i, postID := range postIDs {
+			insert = insert.Values(channelID, postID, i)
+		}
+
+		query, args, err = insert.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "channel_tosql")
+		}
+		if _, err := transaction.Exec(query, args...); err != nil {
+			return errors.Wrapf(err, "failed to save PinnedPostOrders with channelId=%s", channelID)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
 //nolint:unparam
 func (s SqlChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
 	ch := model.Channel{}
@@ -985,6 +1069,10 @@ func (s SqlChannelStore) permanentDeleteT(transaction *sqlxTxWrapper, channelId
 		return errors.Wrapf(err, "failed to delete channel with id=%s", channelId)
 	}
 
+	if _, err := transaction.Exec("DELETE FROM ChannelMemberCounts WHERE ChannelId = ?", channelId); err != nil {
+		return errors.Wrapf(err, "failed to delete channel member counts with id=%s", channelId)
+	}
+
 	return nil
 }
 
@@ -994,6 +1082,10 @@ func (s SqlChannelStore) PermanentDeleteMembersByChannel(channelId string) error
 		return errors.Wrapf(err, "failed to delete Channel with channelId=%s", channelId)
 	}
 
+	if _, err = s.GetMasterX().Exec("UPDATE ChannelMemberCounts SET MemberCount = 0, GuestCount = 0, UpdateAt = ? WHERE ChannelId = ?", model.GetMillis(), channelId); err != nil {
+		return errors.Wrapf(err, "failed to reset channel member counts with channelId=%s", channelId)
+	}
+
 	return nil
 }
 
@@ -1772,6 +1864,16 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 		return nil, errors.Wrap(err, "channel_members_save")
 	}
 
+	userIdsByChannel := map[string][]string{}
+	for _, member := range members {
+		userIdsByChannel[member.ChannelId] = append(userIdsByChannel[member.ChannelId], member.UserId)
+	}
+	for channelId, userIds := range userIdsByChannel {
+		if err := s.adjustMemberCounts(channelId, userIds, "+"); err != nil {
+			return nil, err
+		}
+	}
+
 	newMembers := []*model.ChannelMember{}
 	for _, member := range members {
 		defaultTeamGuestRole := defaultTeamRolesByChannel[member.ChannelId].Guest.String
@@ -2301,6 +2403,113 @@ func (s SqlChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID s
 	return data, nil
 }
 
+// adjustMemberCounts adds ("+") or subtracts ("-") the active members among the given users to
+// or from the maintained member counts of the channel. Channels without maintained counts are
+// left to the reconciliation.
+func (s SqlChannelStore) adjustMemberCounts(channelId string, userIds []string, op string) error {
+	members := sq.Select("COUNT(*)").
+		From("ChannelMembers").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		Where(sq.Eq{
+			"ChannelMembers.ChannelId": channelId,
+			"ChannelMembers.UserId":    userIds,
+			"Users.DeleteAt":           0,
+		})
+	guests := members.Where(sq.Eq{"ChannelMembers.SchemeGuest": true})
+
+	query, args, err := s.getQueryBuilder().
+		Update("ChannelMemberCounts").
+		Set("MemberCount", sq.Expr("MemberCount "+op+" (?)", members)).
+		Set("GuestCount", sq.Expr("GuestCount "+op+" (?)", guests)).
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"ChannelId": channelId}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_member_counts_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to update ChannelMemberCounts with channelId=%s", channelId)
+	}
+
+	return nil
+}
+
+// GetMemberCounts returns the maintained member counts of the channel.
+func (s SqlChannelStore) GetMemberCounts(channelId string) (*model.ChannelMemberCounts, error) {
+	var counts model.ChannelMemberCounts
+	if err := s.GetReplicaX().Get(&counts, "SELECT * FROM ChannelMemberCounts WHERE ChannelId = ?", channelId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelMemberCounts", channelId)
+		}
+		return nil, errors.Wrapf(err, "failed to get ChannelMemberCounts with channelId=%s", channelId)
+	}
+
+	return &counts, nil
+}
+
+// ReconcileMemberCounts recounts the active members and guests of up to limit channels with an id
+// greater than afterChannelId, in order of id, and stores their counts. It returns the id of the
+// last channel recounted, or an empty string once all the channels were.
+func (s SqlChannelStore) ReconcileMemberCounts(afterChannelId string, limit int) (string, error) {
+	var channelIds []string
+	if err := s.GetReplicaX().Select(&channelIds, "SELECT Id FROM Channels WHERE Id > ? ORDER BY Id LIMIT ?", afterChannelId, limit); err != nil {
+		return "", errors.Wrap(err, "failed to get Channels to reconcile member counts")
+	}
+	if len(channelIds) == 0 {
+		return "", nil
+	}
+
+	counts := []*model.ChannelMemberCounts{}
+	query, args, err := s.getQueryBuilder().
+		Select(
+			"ChannelMembers.ChannelId AS ChannelId",
+			"COUNT(*) AS MemberCount",
+			"SUM(CASE WHEN ChannelMembers.SchemeGuest = TRUE THEN 1 ELSE 0 END) AS GuestCount",
+		).
+		From("ChannelMembers").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		Where(sq.Eq{"ChannelMembers.ChannelId": channelIds, "Users.DeleteAt": 0}).
+		GroupBy("ChannelMembers.ChannelId").
+		ToSql()
+	if err != nil {
+		return "", errors.Wrap(err, "channel_member_counts_tosql")
+	}
+	if err := s.GetReplicaX().Select(&counts, query, args...); err != nil {
+		return "", errors.Wrap(err, "failed to count ChannelMembers to reconcile member counts")
+	}
+
+	countsByChannel := make(map[string]*model.ChannelMemberCounts, len(counts))
+	for _, c := range counts {
+		countsByChannel[c.ChannelId] = c
+	}
+
+	now := model.GetMillis()
+	insert := s.getQueryBuilder().Insert("ChannelMemberCounts").Columns("ChannelId", "MemberCount", "GuestCount", "UpdateAt")
+	for _, channelId := range channelIds {
+		c, ok := countsByChannel[channelId]
+		if !ok {
+			c = &model.ChannelMemberCounts{ChannelId: channelId}
+		}
+		insert = insert.Values(channelId, c.MemberCount, c.GuestCount, now)
+	}
+	if s.DriverName() == model.DatabaseDriverMysql {
+		insert = insert.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE MemberCount = VALUES(MemberCount), GuestCount = VALUES(GuestCount), UpdateAt = VALUES(UpdateAt)"))
+	} else {
+		insert = insert.SuffixExpr(sq.Expr("ON CONFLICT (ChannelId) DO UPDATE SET MemberCount = excluded.MemberCount, GuestCount = excluded.GuestCount, UpdateAt = excluded.UpdateAt"))
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return "", errors.Wrap(err, "channel_member_counts_tosql")
+	}
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return "", errors.Wrap(err, "failed to save ChannelMemberCounts")
+	}
+
+	return channelIds[len(channelIds)-1], nil
+}
+
 //nolint:unparam
 func (s SqlChannelStore) InvalidatePinnedPostCount(channelId string) {
 }
@@ -2352,6 +2561,11 @@ func (s SqlChannelStore) GetGuestCount(channelId string, allowFromCache bool) (i
 }
 
 func (s SqlChannelStore) RemoveMembers(channelId string, userIds []string) error {
+	// The members are discounted before being deleted, as only the active ones are counted.
+	if err := s.adjustMemberCounts(channelId, userIds, "-"); err != nil {
+		return err
+	}
+
 	builder := s.getQueryBuilder().
 		Delete("ChannelMembers").
 		Where(sq.Eq{"ChannelId": channelId}).
@@ -4034,6 +4248,34 @@ func (s SqlChannelStore) UserBelongsToChannels(userId string, channelIds []strin
 	return c > 0, nil
 }
 
+func (s SqlChannelStore) UsersShareChannel(userID, otherUserID string) (bool, error) {
+	subQuery := s.getQueryBuilder().
+		Select("1").
+		From("ChannelMembers cm1").
+		Join("ChannelMembers cm2 ON cm2.ChannelId = cm1.ChannelId").
+		Join("Channels c ON c.Id = cm1.ChannelId").
+		Where(sq.Eq{
+			"cm1.UserId": userID,
+			"cm2.UserId": otherUserID,
+			"c.Type":     []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate, model.ChannelTypeGroup},
+			"c.DeleteAt": 0,
+		}).
+		Prefix("SELECT EXISTS (").
+		Suffix(")")
+
+	queryString, args, err := subQuery.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "channel_tosql")
+	}
+
+	var shared bool
+	if err := s.GetReplicaX().Get(&shared, queryString, args...); err != nil {
+		return false, errors.Wrapf(err, "failed to find channels shared by userId=%s and userId=%s", userID, otherUserID)
+	}
+
+	return shared, nil
+}
+
 // TODO: parameterize userIDs
 func (s SqlChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
 	sql := fmt.Sprintf(`
@@ -45,10 +45,10 @@ func (s SqlCommandStore) Save(command *model.Command) (*model.Command, error) {
 	if _, err := s.GetMasterX().NamedExec(`INSERT INTO Commands (Id, Token, CreateAt,
 		UpdateAt, DeleteAt, CreatorId, TeamId, `+trigger+`, Method, Username,
 		IconURL, AutoComplete, AutoCompleteDesc, AutoCompleteHint, DisplayName, Description,
-		URL, PluginId)
+		URL, PluginId, PayloadFormat, OutgoingOAuthConnectionId, SigningSecret)
 	VALUES (:Id, :Token, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :TeamId, :Trigger, :Method,
 		:Username, :IconURL, :AutoComplete, :AutoCompleteDesc, :AutoCompleteHint, :DisplayName,
-		:Description, :URL, :PluginId)`, command); err != nil {
+		:Description, :URL, :PluginId, :PayloadFormat, :OutgoingOAuthConnectionId, :SigningSecret)`, command); err != nil {
 		return nil, errors.Wrapf(err, "insert: command_id=%s", command.Id)
 	}
 
@@ -113,17 +113,8 @@ func (s SqlCommandStore) GetByTrigger(teamId string, trigger string) (*model.Com
 }
 
 func (s SqlCommandStore) Delete(commandId string, time int64) error {
-	sql, args, err := s.getQueryBuilder().
-		Update("Commands").
-		SetMap(sq.Eq{"DeleteAt": time, "UpdateAt": time}).
-		Where(sq.Eq{"Id": commandId}).ToSql()
-	if err != nil {
-		return errors.Wrapf(err, "commands_tosql")
-	}
-
-	_, err = s.GetMasterX().Exec(sql, args...)
-	if err != nil {
-		errors.Wrapf(err, "delete: command_id=%s", commandId)
+	if err := s.softDelete("Commands", commandId, time); err != nil {
+		return errors.Wrapf(err, "delete: command_id=%s", commandId)
 	}
 
 	return nil
@@ -143,6 +134,10 @@ func (s SqlCommandStore) PermanentDeleteByTeam(teamId string) error {
 	return nil
 }
 
+func (s SqlCommandStore) PermanentDeleteSoftDeletedBatch(before int64, limit int64) (int64, error) {
+	return s.permanentDeleteSoftDeletedBatch("Commands", before, limit)
+}
+
 func (s SqlCommandStore) PermanentDeleteByUser(userId string) error {
 	sql, args, err := s.getQueryBuilder().
 		Delete("Commands").
@@ -182,6 +177,9 @@ func (s SqlCommandStore) Update(cmd *model.Command) (*model.Command, error) {
 		Set("Description", cmd.Description).
 		Set("URL", cmd.URL).
 		Set("PluginId", cmd.PluginId).
+		Set("PayloadFormat", cmd.PayloadFormat).
+		Set("OutgoingOAuthConnectionId", cmd.OutgoingOAuthConnectionId).
+		Set("SigningSecret", cmd.SigningSecret).
 		Where(sq.Eq{"Id": cmd.Id})
 
 	// Trigger is a keyword
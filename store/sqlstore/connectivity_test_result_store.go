@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlConnectivityTestResultStore struct {
+	*SqlStore
+}
+
+func newSqlConnectivityTestResultStore(sqlStore *SqlStore) store.ConnectivityTestResultStore {
+	return &SqlConnectivityTestResultStore{sqlStore}
+}
+
+func (s SqlConnectivityTestResultStore) Save(result *model.ConnectivityTestResult) (*model.ConnectivityTestResult, error) {
+	result.PreSave()
+	if err := result.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("ConnectivityTestResults").
+		Columns("Id", "Service", "UserId", "CreateAt", "LatencyMs", "Success", "Error").
+		Values(result.Id, result.Service, result.UserId, result.CreateAt, result.LatencyMs, result.Success, result.Error).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "connectivity_test_result_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ConnectivityTestResult with id=%s", result.Id)
+	}
+
+	return result, nil
+}
+
+func (s SqlConnectivityTestResultStore) GetHistory(service string, offset, limit int) ([]*model.ConnectivityTestResult, error) {
+	builder := s.getQueryBuilder().
+		Select("Id", "Service", "UserId", "CreateAt", "LatencyMs", "Success", "Error").
+		From("ConnectivityTestResults").
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if service != "" {
+		builder = builder.Where(sq.Eq{"Service": service})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "connectivity_test_result_tosql")
+	}
+
+	results := []*model.ConnectivityTestResult{}
+	if err := s.GetReplicaX().Select(&results, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find ConnectivityTestResults with service=%s", service)
+	}
+
+	return results, nil
+}
+
+func (s SqlConnectivityTestResultStore) Cleanup(expiryTime int64, batchSize int) error {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM ConnectivityTestResults WHERE Id IN (SELECT Id FROM ConnectivityTestResults WHERE CreateAt < ? LIMIT ?)"
+	} else {
+		query = "DELETE FROM ConnectivityTestResults WHERE CreateAt < ? LIMIT ?"
+	}
+
+	var rowsAffected int64 = 1
+
+	for rowsAffected > 0 {
+		sqlResult, err := s.GetMasterX().Exec(query, expiryTime, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "unable to delete connectivity test results")
+		}
+		rowsAffected, err = sqlResult.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "unable to delete connectivity test results")
+		}
+	}
+
+	return nil
+}
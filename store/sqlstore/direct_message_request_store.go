@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlDirectMessageRequestStore struct {
+	*SqlStore
+}
+
+func newSqlDirectMessageRequestStore(sqlStore *SqlStore) store.DirectMessageRequestStore {
+	return &SqlDirectMessageRequestStore{sqlStore}
+}
+
+func (s SqlDirectMessageRequestStore) Save(request *model.DirectMessageRequest) (*model.DirectMessageRequest, error) {
+	request.PreSave()
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("DirectMessageRequests").
+		Columns("ChannelId", "RequesterId", "RecipientId", "Status", "CreateAt", "UpdateAt").
+		Values(request.ChannelId, request.RequesterId, request.RecipientId, request.Status, request.CreateAt, request.UpdateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "direct_message_request_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "directmessagerequests_pkey"}) {
+			return nil, store.NewErrConflict("DirectMessageRequest", err, "channelId="+request.ChannelId)
+		}
+		return nil, errors.Wrapf(err, "failed to save DirectMessageRequest with channelId=%s", request.ChannelId)
+	}
+
+	return request, nil
+}
+
+func (s SqlDirectMessageRequestStore) Get(channelID string) (*model.DirectMessageRequest, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("ChannelId", "RequesterId", "RecipientId", "Status", "CreateAt", "UpdateAt").
+		From("DirectMessageRequests").
+		Where(sq.Eq{"ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "direct_message_request_tosql")
+	}
+
+	var request model.DirectMessageRequest
+	if err := s.GetMasterX().Get(&request, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("DirectMessageRequest", "channelId="+channelID)
+		}
+		return nil, errors.Wrapf(err, "failed to get DirectMessageRequest with channelId=%s", channelID)
+	}
+
+	return &request, nil
+}
+
+func (s SqlDirectMessageRequestStore) GetPendingForRecipient(recipientID string) ([]*model.DirectMessageRequest, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("ChannelId", "RequesterId", "RecipientId", "Status", "CreateAt", "UpdateAt").
+		From("DirectMessageRequests").
+		Where(sq.Eq{"RecipientId": recipientID, "Status": model.DirectMessageRequestStatusPending}).
+		OrderBy("CreateAt DESC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "direct_message_request_tosql")
+	}
+
+	requests := []*model.DirectMessageRequest{}
+	if err := s.GetReplicaX().Select(&requests, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find DirectMessageRequests with recipientId=%s", recipientID)
+	}
+
+	return requests, nil
+}
+
+func (s SqlDirectMessageRequestStore) UpdateStatus(channelID, status string, updateAt int64) error {
+	query, args, err := s.getQueryBuilder().
+		Update("DirectMessageRequests").
+		Set("Status", status).
+		Set("UpdateAt", updateAt).
+		Where(sq.Eq{"ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "direct_message_request_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to update DirectMessageRequest with channelId=%s", channelID)
+	}
+
+	return nil
+}
+
+func (s SqlDirectMessageRequestStore) Delete(channelID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("DirectMessageRequests").
+		Where(sq.Eq{"ChannelId": channelID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "direct_message_request_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete DirectMessageRequest with channelId=%s", channelID)
+	}
+
+	return nil
+}
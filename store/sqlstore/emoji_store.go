@@ -35,15 +35,56 @@ func (es SqlEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	}
 
 	if _, err := es.GetMasterX().NamedExec(`INSERT INTO Emoji
-		(Id, CreateAt, UpdateAt, DeleteAt, CreatorId, Name)
+		(Id, CreateAt, UpdateAt, DeleteAt, CreatorId, Name, TeamId)
 		VALUES
-		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :Name)`, emoji); err != nil {
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :Name, :TeamId)`, emoji); err != nil {
 		return nil, errors.Wrap(err, "error saving emoji")
 	}
 
 	return emoji, nil
 }
 
+// SaveForTeam saves a team scoped emoji unless the team already has maxEmojiPerTeam emoji. A
+// negative maxEmojiPerTeam disables the quota.
+func (es SqlEmojiStore) SaveForTeam(emoji *model.Emoji, maxEmojiPerTeam int64) (*model.Emoji, error) {
+	if emoji.TeamId == "" {
+		return nil, store.NewErrInvalidInput("Emoji", "TeamId", emoji.TeamId)
+	}
+
+	emoji.PreSave()
+	if err := emoji.IsValid(); err != nil {
+		return nil, err
+	}
+
+	transaction, err := es.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	if maxEmojiPerTeam >= 0 {
+		var count int64
+		if err = transaction.Get(&count, "SELECT COUNT(0) FROM Emoji WHERE TeamId = ? AND DeleteAt = 0", emoji.TeamId); err != nil {
+			return nil, errors.Wrapf(err, "save_emoji_count: teamId=%s", emoji.TeamId)
+		} else if count >= maxEmojiPerTeam {
+			return nil, store.NewErrLimitExceeded("emoji_per_team", int(count), "teamId="+emoji.TeamId)
+		}
+	}
+
+	if _, err = transaction.NamedExec(`INSERT INTO Emoji
+		(Id, CreateAt, UpdateAt, DeleteAt, CreatorId, Name, TeamId)
+		VALUES
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :Name, :TeamId)`, emoji); err != nil {
+		return nil, errors.Wrap(err, "error saving emoji")
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return emoji, nil
+}
+
 func (es SqlEmojiStore) Get(ctx context.Context, id string, allowFromCache bool) (*model.Emoji, error) {
 	return es.getBy(ctx, "Id", id)
 }
@@ -88,6 +129,31 @@ func (es SqlEmojiStore) GetList(offset, limit int, sort string) ([]*model.Emoji,
 	return emojis, nil
 }
 
+func (es SqlEmojiStore) GetListForTeam(teamID string, offset, limit int, sort string) ([]*model.Emoji, error) {
+	emojis := []*model.Emoji{}
+
+	query := "SELECT * FROM Emoji WHERE TeamId = ? AND DeleteAt = 0"
+
+	if sort == model.EmojiSortByName {
+		query += " ORDER BY Name"
+	}
+
+	query += " LIMIT ? OFFSET ?"
+
+	if err := es.GetReplicaX().Select(&emojis, query, teamID, limit, offset); err != nil {
+		return nil, errors.Wrapf(err, "could not get list of emojis for team %s", teamID)
+	}
+	return emojis, nil
+}
+
+func (es SqlEmojiStore) CountByTeam(teamID string) (int64, error) {
+	var count int64
+	if err := es.GetReplicaX().Get(&count, "SELECT COUNT(0) FROM Emoji WHERE TeamId = ? AND DeleteAt = 0", teamID); err != nil {
+		return 0, errors.Wrapf(err, "could not count emojis for team %s", teamID)
+	}
+	return count, nil
+}
+
 func (es SqlEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	if sqlResult, err := es.GetMasterX().Exec(
 		`UPDATE
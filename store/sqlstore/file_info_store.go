@@ -21,51 +21,59 @@ import (
 )
 
 type fileInfoWithChannelID struct {
-	Id              string
-	CreatorId       string
-	PostId          string
-	ChannelId       string
-	CreateAt        int64
-	UpdateAt        int64
-	DeleteAt        int64
-	Path            string
-	ThumbnailPath   string
-	PreviewPath     string
-	Name            string
-	Extension       string
-	Size            int64
-	MimeType        string
-	Width           int
-	Height          int
-	HasPreviewImage bool
-	MiniPreview     *[]byte
-	Content         string
-	RemoteId        *string
-	Archived        bool
+	Id               string
+	CreatorId        string
+	PostId           string
+	ChannelId        string
+	CreateAt         int64
+	UpdateAt         int64
+	DeleteAt         int64
+	Path             string
+	ThumbnailPath    string
+	PreviewPath      string
+	Name             string
+	Extension        string
+	Size             int64
+	MimeType         string
+	Width            int
+	Height           int
+	HasPreviewImage  bool
+	MiniPreview      *[]byte
+	Content          string
+	RemoteId         *string
+	Archived         bool
+	ContentHash      string
+	DedupOf          string
+	AltText          string
+	AltTextGenerated bool
 }
 
 func (fi fileInfoWithChannelID) ToModel() *model.FileInfo {
 	return &model.FileInfo{
-		Id:              fi.Id,
-		CreatorId:       fi.CreatorId,
-		PostId:          fi.PostId,
-		ChannelId:       fi.ChannelId,
-		CreateAt:        fi.CreateAt,
-		UpdateAt:        fi.UpdateAt,
-		DeleteAt:        fi.DeleteAt,
-		Path:            fi.Path,
-		ThumbnailPath:   fi.ThumbnailPath,
-		PreviewPath:     fi.PreviewPath,
-		Name:            fi.Name,
-		Extension:       fi.Extension,
-		Size:            fi.Size,
-		MimeType:        fi.MimeType,
-		Width:           fi.Width,
-		Height:          fi.Height,
-		HasPreviewImage: fi.HasPreviewImage,
-		MiniPreview:     fi.MiniPreview,
-		Content:         fi.Content,
-		RemoteId:        fi.RemoteId,
+		Id:               fi.Id,
+		CreatorId:        fi.CreatorId,
+		PostId:           fi.PostId,
+		ChannelId:        fi.ChannelId,
+		CreateAt:         fi.CreateAt,
+		UpdateAt:         fi.UpdateAt,
+		DeleteAt:         fi.DeleteAt,
+		Path:             fi.Path,
+		ThumbnailPath:    fi.ThumbnailPath,
+		PreviewPath:      fi.PreviewPath,
+		Name:             fi.Name,
+		Extension:        fi.Extension,
+		Size:             fi.Size,
+		MimeType:         fi.MimeType,
+		Width:            fi.Width,
+		Height:           fi.Height,
+		HasPreviewImage:  fi.HasPreviewImage,
+		MiniPreview:      fi.MiniPreview,
+		Content:          fi.Content,
+		RemoteId:         fi.RemoteId,
+		ContentHash:      fi.ContentHash,
+		DedupOf:          fi.DedupOf,
+		AltText:          fi.AltText,
+		AltTextGenerated: fi.AltTextGenerated,
 	}
 }
 
@@ -105,6 +113,10 @@ func newSqlFileInfoStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterfac
 		"Coalesce(FileInfo.Content, '') AS Content",
 		"Coalesce(FileInfo.RemoteId, '') AS RemoteId",
 		"FileInfo.Archived",
+		"FileInfo.ContentHash",
+		"FileInfo.DedupOf",
+		"FileInfo.AltText",
+		"FileInfo.AltTextGenerated",
 	}
 
 	return s
@@ -119,10 +131,12 @@ func (fs SqlFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 	query := `
 		INSERT INTO FileInfo
 		(Id, CreatorId, PostId, CreateAt, UpdateAt, DeleteAt, Path, ThumbnailPath, PreviewPath,
-			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId)
+			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId,
+			ContentHash, DedupOf, AltText, AltTextGenerated)
 		VALUES
 		(:Id, :CreatorId, :PostId, :CreateAt, :UpdateAt, :DeleteAt, :Path, :ThumbnailPath, :PreviewPath,
-			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId)
+			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId,
+			:ContentHash, :DedupOf, :AltText, :AltTextGenerated)
 	`
 
 	if _, err := fs.GetMasterX().NamedExec(query, info); err != nil {
@@ -169,20 +183,24 @@ func (fs SqlFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error)
 	queryString, args, err := fs.getQueryBuilder().
 		Update("FileInfo").
 		SetMap(map[string]interface{}{
-			"UpdateAt":        info.UpdateAt,
-			"DeleteAt":        info.DeleteAt,
-			"Path":            info.Path,
-			"ThumbnailPath":   info.ThumbnailPath,
-			"PreviewPath":     info.PreviewPath,
-			"Name":            info.Name,
-			"Extension":       info.Extension,
-			"Size":            info.Size,
-			"MimeType":        info.MimeType,
-			"Width":           info.Width,
-			"Height":          info.Height,
-			"HasPreviewImage": info.HasPreviewImage,
-			"Content":         info.Content,
-			"RemoteId":        info.RemoteId,
+			"UpdateAt":         info.UpdateAt,
+			"DeleteAt":         info.DeleteAt,
+			"Path":             info.Path,
+			"ThumbnailPath":    info.ThumbnailPath,
+			"PreviewPath":      info.PreviewPath,
+			"Name":             info.Name,
+			"Extension":        info.Extension,
+			"Size":             info.Size,
+			"MimeType":         info.MimeType,
+			"Width":            info.Width,
+			"Height":           info.Height,
+			"HasPreviewImage":  info.HasPreviewImage,
+			"Content":          info.Content,
+			"RemoteId":         info.RemoteId,
+			"ContentHash":      info.ContentHash,
+			"DedupOf":          info.DedupOf,
+			"AltText":          info.AltText,
+			"AltTextGenerated": info.AltTextGenerated,
 		}).
 		Where(sq.Eq{"Id": info.Id}).
 		ToSql()
@@ -333,6 +351,151 @@ func (fs SqlFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 	return info, nil
 }
 
+func (fs SqlFileInfoStore) GetByContentHash(hash, channelID string) (*model.FileInfo, error) {
+	info := &model.FileInfo{}
+
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Join("Posts ON FileInfo.PostId = Posts.Id").
+		Where(sq.Eq{"Posts.ChannelId": channelID}).
+		Where(sq.Eq{"FileInfo.ContentHash": hash}).
+		Where(sq.Eq{"FileInfo.DedupOf": ""}).
+		Where(sq.Eq{"FileInfo.DeleteAt": 0}).
+		OrderBy("FileInfo.CreateAt ASC", "FileInfo.Id ASC").
+		Limit(1)
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	if err := fs.GetReplicaX().Get(info, queryString, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("FileInfo", fmt.Sprintf("contentHash=%s, channelId=%s", hash, channelID))
+		}
+
+		return nil, errors.Wrapf(err, "failed to get FileInfo with contentHash=%s, channelId=%s", hash, channelID)
+	}
+	return info, nil
+}
+
+func (fs SqlFileInfoStore) CountDedupReferences(fileID string) (int64, error) {
+	query := fs.getQueryBuilder().
+		Select("COUNT(*)").
+		From("FileInfo").
+		Where(sq.Eq{"DedupOf": fileID})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "file_info_tosql")
+	}
+
+	var count int64
+	if err := fs.GetReplicaX().Get(&count, queryString, args...); err != nil {
+		return 0, errors.Wrapf(err, "failed to count FileInfos deduplicated from fileId=%s", fileID)
+	}
+	return count, nil
+}
+
+func (fs SqlFileInfoStore) GetOrphaned(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		LeftJoin("Posts ON FileInfo.PostId = Posts.Id").
+		LeftJoin("PostsArchive ON FileInfo.PostId = PostsArchive.Id").
+		Where(sq.NotEq{"FileInfo.PostId": ""}).
+		Where("Posts.Id IS NULL").
+		Where("PostsArchive.Id IS NULL").
+		Where(sq.Lt{"FileInfo.CreateAt": createdBefore}).
+		Where(sq.Gt{"FileInfo.Id": afterID}).
+		OrderBy("FileInfo.Id ASC").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	infos := []*model.FileInfo{}
+	if err := fs.GetReplicaX().Select(&infos, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find orphaned FileInfos")
+	}
+	return infos, nil
+}
+
+func (fs SqlFileInfoStore) GetExpiredForRetentionPolicies(now int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		InnerJoin("Posts ON FileInfo.PostId = Posts.Id").
+		InnerJoin("Channels ON Posts.ChannelId = Channels.Id").
+		LeftJoin("RetentionPoliciesChannels ON Posts.ChannelId = RetentionPoliciesChannels.ChannelId").
+		LeftJoin("RetentionPoliciesTeams ON Channels.TeamId = RetentionPoliciesTeams.TeamId").
+		InnerJoin("RetentionPolicies ON RetentionPolicies.Id = COALESCE(RetentionPoliciesChannels.PolicyId, RetentionPoliciesTeams.PolicyId)").
+		LeftJoin("PostRetentionLabels ON Posts.Id = PostRetentionLabels.PostId").
+		Where(sq.GtOrEq{"RetentionPolicies.FileDuration": 0}).
+		Where(sq.Expr("? - FileInfo.CreateAt > RetentionPolicies.FileDuration * ?", now, model.DayInMilliseconds)).
+		Where("PostRetentionLabels.PostId IS NULL").
+		Where(sq.Gt{"FileInfo.Id": afterID}).
+		OrderBy("FileInfo.Id ASC").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	infos := []*model.FileInfo{}
+	if err := fs.GetReplicaX().Select(&infos, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find expired FileInfos")
+	}
+	return infos, nil
+}
+
+func (fs SqlFileInfoStore) GetReferencedIds(fileIDs []string) ([]string, error) {
+	if len(fileIDs) == 0 {
+		return []string{}, nil
+	}
+
+	query := fs.getQueryBuilder().
+		Select("Id", "DedupOf").
+		From("FileInfo").
+		Where(sq.Or{
+			sq.Eq{"Id": fileIDs},
+			sq.Eq{"DedupOf": fileIDs},
+		})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	rows := []struct {
+		Id      string
+		DedupOf string
+	}{}
+	if err := fs.GetReplicaX().Select(&rows, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find referenced FileInfos")
+	}
+
+	wanted := make(map[string]bool, len(fileIDs))
+	for _, id := range fileIDs {
+		wanted[id] = true
+	}
+
+	referenced := []string{}
+	for _, row := range rows {
+		for _, id := range []string{row.Id, row.DedupOf} {
+			if wanted[id] {
+				referenced = append(referenced, id)
+				wanted[id] = false
+			}
+		}
+	}
+	return referenced, nil
+}
+
 func (fs SqlFileInfoStore) InvalidateFileInfosForPostCache(postId string, deleted bool) {
 }
 
@@ -439,6 +602,29 @@ func (fs SqlFileInfoStore) SetContent(fileId, content string) error {
 	return nil
 }
 
+func (fs SqlFileInfoStore) SetAltText(fileID, altText string, generated bool) error {
+	query := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("AltText", altText).
+		Set("AltTextGenerated", generated).
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"Id": fileID})
+	if generated {
+		query = query.Where(sq.Or{sq.Eq{"AltText": ""}, sq.Eq{"AltTextGenerated": true}})
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "file_info_tosql")
+	}
+
+	if _, err := fs.GetMasterX().Exec(queryString, args...); err != nil {
+		return errors.Wrapf(err, "failed to update FileInfo alt text with id=%s", fileID)
+	}
+
+	return nil
+}
+
 func (fs SqlFileInfoStore) DeleteForPost(postId string) (string, error) {
 	if _, err := fs.GetMasterX().Exec(
 		`UPDATE
@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -520,3 +521,87 @@ func CheckRelationalIntegrity(ss *SqlStore, results chan<- model.IntegrityCheckR
 	mlog.Info("Done with relational integrity checks")
 	close(results)
 }
+
+// clearableRelations are the relations whose reference can be emptied, rather than the
+// referencing record being deleted, without breaking the data model. They map to the value an
+// empty reference is stored as.
+var clearableRelations = map[string]interface{}{
+	"Audits.SessionId":   "",
+	"Audits.UserId":      "",
+	"Channels.CreatorId": "",
+	"Channels.SchemeId":  nil,
+	"Posts.RootId":       "",
+	"Teams.SchemeId":     nil,
+}
+
+// RepairRelationalIntegrity fixes the orphaned records found by a relational integrity check
+// using the given strategy: IntegrityRepairStrategyDelete deletes the records and
+// IntegrityRepairStrategyClear empties their reference, which is only supported by relations where
+// an empty reference is valid. A record is only repaired if its parent is still missing. The number
+// of repaired records is returned.
+func RepairRelationalIntegrity(ss *SqlStore, data model.RelationalIntegrityCheckData, strategy string) (int64, error) {
+	switch strategy {
+	case model.IntegrityRepairStrategySkip:
+		return 0, nil
+	case model.IntegrityRepairStrategyDelete:
+	case model.IntegrityRepairStrategyClear:
+		if _, ok := clearableRelations[data.Relation()]; !ok {
+			return 0, errors.Errorf("references of %s cannot be cleared", data.Relation())
+		}
+	default:
+		return 0, errors.Errorf("unknown repair strategy %q", strategy)
+	}
+
+	// The parents are checked up front because MySQL doesn't allow a subquery on the table being
+	// modified, which is the case of Posts.RootId.
+	parentExists := map[string]bool{}
+
+	var repaired int64
+	for _, record := range data.Records {
+		if record.ParentId == nil {
+			continue
+		}
+
+		exists, ok := parentExists[*record.ParentId]
+		if !ok {
+			var count int64
+			if err := ss.GetMasterX().Get(&count, "SELECT COUNT(*) FROM "+data.ParentName+" WHERE Id = ?", *record.ParentId); err != nil {
+				return repaired, errors.Wrapf(err, "failed to check the parent of %s", data.Relation())
+			}
+			exists = count > 0
+			parentExists[*record.ParentId] = exists
+		}
+		if exists {
+			continue
+		}
+
+		where := sq.And{sq.Eq{data.ParentIdAttr: *record.ParentId}}
+		if data.ChildIdAttr != "" {
+			if record.ChildId == nil {
+				continue
+			}
+			where = append(where, sq.Eq{data.ChildIdAttr: *record.ChildId})
+		} else if strategy == model.IntegrityRepairStrategyClear {
+			return repaired, errors.Errorf("references of %s cannot be cleared", data.Relation())
+		}
+
+		var query Builder
+		if strategy == model.IntegrityRepairStrategyDelete {
+			query = ss.getQueryBuilder().Delete(data.ChildName).Where(where)
+		} else {
+			query = ss.getQueryBuilder().Update(data.ChildName).Set(data.ParentIdAttr, clearableRelations[data.Relation()]).Where(where)
+		}
+
+		result, err := ss.GetMasterX().ExecBuilder(query)
+		if err != nil {
+			return repaired, errors.Wrapf(err, "failed to repair %s", data.Relation())
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return repaired, errors.Wrap(err, "failed to get rows affected")
+		}
+		repaired += rowsAffected
+	}
+
+	return repaired, nil
+}
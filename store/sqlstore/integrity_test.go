@@ -1601,3 +1601,70 @@ func TestCheckUsersUserAccessTokensIntegrity(t *testing.T) {
 		})
 	})
 }
+
+func TestRepairRelationalIntegrity(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		store := ss.(*SqlStore)
+
+		t.Run("should delete orphaned records", func(t *testing.T) {
+			reaction := createReaction(ss, model.NewId(), model.NewId())
+			result := checkPostsReactionsIntegrity(store)
+			require.NoError(t, result.Err)
+			data := result.Data.(model.RelationalIntegrityCheckData)
+			require.Len(t, data.Records, 1)
+
+			repaired, err := RepairRelationalIntegrity(store, data, model.IntegrityRepairStrategyDelete)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), repaired)
+
+			result = checkPostsReactionsIntegrity(store)
+			require.NoError(t, result.Err)
+			require.Empty(t, result.Data.(model.RelationalIntegrityCheckData).Records)
+			reactions, err := ss.Reaction().GetForPost(reaction.PostId, false)
+			require.NoError(t, err)
+			require.Empty(t, reactions)
+		})
+
+		t.Run("should clear orphaned references", func(t *testing.T) {
+			post := createPost(ss, model.NewId(), model.NewId(), model.NewId(), "")
+			result := checkPostsPostsRootIdIntegrity(store)
+			require.NoError(t, result.Err)
+			data := result.Data.(model.RelationalIntegrityCheckData)
+			require.Len(t, data.Records, 1)
+
+			repaired, err := RepairRelationalIntegrity(store, data, model.IntegrityRepairStrategyClear)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), repaired)
+
+			var rootId string
+			require.NoError(t, store.GetMasterX().Get(&rootId, `SELECT RootId FROM Posts WHERE Id=?`, post.Id))
+			require.Empty(t, rootId)
+			store.GetMasterX().Exec(`DELETE FROM Posts WHERE Id=?`, post.Id)
+		})
+
+		t.Run("should not clear required references", func(t *testing.T) {
+			data := model.RelationalIntegrityCheckData{
+				ParentName:   "Posts",
+				ParentIdAttr: "PostId",
+				ChildName:    "Reactions",
+			}
+			_, err := RepairRelationalIntegrity(store, data, model.IntegrityRepairStrategyClear)
+			require.Error(t, err)
+		})
+
+		t.Run("should skip records whose parent exists", func(t *testing.T) {
+			user := createUser(ss)
+			parentId := user.Id
+			data := model.RelationalIntegrityCheckData{
+				ParentName:   "Users",
+				ParentIdAttr: "UserId",
+				ChildName:    "Preferences",
+				Records:      []model.OrphanedRecord{{ParentId: &parentId}},
+			}
+			repaired, err := RepairRelationalIntegrity(store, data, model.IntegrityRepairStrategyDelete)
+			require.NoError(t, err)
+			require.Zero(t, repaired)
+			store.GetMasterX().Exec(`DELETE FROM Users WHERE Id=?`, user.Id)
+		})
+	})
+}
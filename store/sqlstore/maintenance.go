@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+type orphanedRowsConfig struct {
+	name       string
+	table      string
+	keyColumns []string
+	// where selects the orphaned rows. It must only reference the table by its name, as
+	// it is used both when counting and when deleting the rows.
+	where string
+}
+
+var orphanedRowsConfigs = []orphanedRowsConfig{
+	{
+		// Files that were uploaded but never attached to a post have an empty PostId and are
+		// cleaned up separately, so they are not considered orphaned.
+		name:       "fileinfo_without_post",
+		table:      "FileInfo",
+		keyColumns: []string{"Id"},
+		where:      "FileInfo.PostId != '' AND NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = FileInfo.PostId)",
+	},
+	{
+		// Deactivated users keep their memberships, so only the memberships of users that
+		// were permanently deleted are considered orphaned.
+		name:       "channelmembers_without_user",
+		table:      "ChannelMembers",
+		keyColumns: []string{"ChannelId", "UserId"},
+		where:      "NOT EXISTS (SELECT 1 FROM Users WHERE Users.Id = ChannelMembers.UserId)",
+	},
+}
+
+func getOrphanedRowsConfig(name string) (orphanedRowsConfig, bool) {
+	for _, cfg := range orphanedRowsConfigs {
+		if cfg.name == name {
+			return cfg, true
+		}
+	}
+	return orphanedRowsConfig{}, false
+}
+
+// CountOrphanedRows returns the number of rows found by every orphaned rows check.
+func (ss *SqlStore) CountOrphanedRows() ([]*model.OrphanedRows, error) {
+	results := make([]*model.OrphanedRows, 0, len(orphanedRowsConfigs))
+	for _, cfg := range orphanedRowsConfigs {
+		var count int64
+		if err := ss.GetReplicaX().Get(&count, "SELECT COUNT(*) FROM "+cfg.table+" WHERE "+cfg.where); err != nil {
+			return nil, errors.Wrapf(err, "failed to count orphaned rows of %s", cfg.table)
+		}
+		results = append(results, &model.OrphanedRows{
+			Name:  cfg.name,
+			Table: cfg.table,
+			Count: count,
+		})
+	}
+
+	return results, nil
+}
+
+// DeleteOrphanedRows deletes the rows found by the named orphaned rows check, batchSize rows at
+// a time so that locks are held briefly. progress, if not nil, is called after every batch with
+// the number of rows deleted so far. The total number of deleted rows is returned.
+func (ss *SqlStore) DeleteOrphanedRows(name string, batchSize int, progress func(deleted int64)) (int64, error) {
+	cfg, ok := getOrphanedRowsConfig(name)
+	if !ok {
+		return 0, errors.Errorf("unknown orphaned rows check %q", name)
+	}
+	if batchSize <= 0 {
+		return 0, errors.New("batch size must be positive")
+	}
+
+	var query string
+	switch ss.DriverName() {
+	case model.DatabaseDriverPostgres:
+		keys := strings.Join(cfg.keyColumns, ", ")
+		query = "DELETE FROM " + cfg.table + " WHERE (" + keys + ") IN (SELECT " + keys + " FROM " + cfg.table + " WHERE " + cfg.where + " LIMIT ?)"
+	case model.DatabaseDriverMysql:
+		query = "DELETE FROM " + cfg.table + " WHERE " + cfg.where + " LIMIT ?"
+	default:
+		return 0, errors.New("Not supported driver")
+	}
+
+	var deleted int64
+	for {
+		result, err := ss.GetMasterX().Exec(query, batchSize)
+		if err != nil {
+			return deleted, errors.Wrapf(err, "failed to delete orphaned rows of %s", cfg.table)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return deleted, errors.Wrap(err, "failed to get rows affected")
+		}
+		deleted += rowsAffected
+
+		if progress != nil && rowsAffected > 0 {
+			progress(deleted)
+		}
+		if rowsAffected < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+// GetTableBloat returns the storage statistics of every table in the current schema, the ones
+// with the most reclaimable space first. Like GetTableRowCounts, the row counts are estimates.
+func (ss *SqlStore) GetTableBloat() ([]*model.TableBloat, error) {
+	var query string
+	switch ss.DriverName() {
+	case model.DatabaseDriverPostgres:
+		query = `SELECT relname AS Name, n_live_tup AS LiveRows, n_dead_tup AS DeadRows,
+				pg_total_relation_size(relid) AS TotalBytes, 0 AS FreeBytes
+			FROM pg_stat_user_tables
+			WHERE schemaname = current_schema()
+			ORDER BY n_dead_tup DESC, relname`
+	case model.DatabaseDriverMysql:
+		query = `SELECT TABLE_NAME AS Name, COALESCE(TABLE_ROWS, 0) AS LiveRows, 0 AS DeadRows,
+				COALESCE(DATA_LENGTH + INDEX_LENGTH, 0) AS TotalBytes, COALESCE(DATA_FREE, 0) AS FreeBytes
+			FROM information_schema.TABLES
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'
+			ORDER BY DATA_FREE DESC, TABLE_NAME`
+	default:
+		return nil, errors.New("Not supported driver")
+	}
+
+	tables := []*model.TableBloat{}
+	if err := ss.GetReplicaX().Select(&tables, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get table bloat")
+	}
+
+	return tables, nil
+}
+
+// ReindexTable rebuilds the indexes of a table without blocking writes to it. Postgres rebuilds
+// them concurrently, which requires Postgres 12, and MySQL rebuilds the table in place. An error
+// is returned rather than falling back to an operation that would lock the table.
+func (ss *SqlStore) ReindexTable(table string) error {
+	tables, err := ss.GetTableBloat()
+	if err != nil {
+		return err
+	}
+
+	var name string
+	for _, t := range tables {
+		if strings.EqualFold(t.Name, table) {
+			name = t.Name
+			break
+		}
+	}
+	if name == "" {
+		return errors.Errorf("table %q does not exist", table)
+	}
+
+	var query string
+	switch ss.DriverName() {
+	case model.DatabaseDriverPostgres:
+		version, err := ss.GetDbVersion(true)
+		if err != nil {
+			return errors.Wrap(err, "failed to get database version")
+		}
+		if intVer, err := strconv.Atoi(version); err == nil && intVer < 120000 {
+			return errors.New("reindexing without locking requires Postgres 12 or later")
+		}
+		query = "REINDEX TABLE CONCURRENTLY " + name
+	case model.DatabaseDriverMysql:
+		query = "ALTER TABLE " + name + " FORCE, ALGORITHM=INPLACE, LOCK=NONE"
+	default:
+		return errors.New("Not supported driver")
+	}
+
+	// Rebuilding a large table can take far longer than the query timeout.
+	if _, err := ss.GetMasterX().ExecNoTimeout(query); err != nil {
+		return errors.Wrapf(err, "failed to reindex table %s", name)
+	}
+
+	return nil
+}
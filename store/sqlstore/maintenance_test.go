@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func getOrphanedRowsCount(t *testing.T, ss *SqlStore, name string) int64 {
+	t.Helper()
+	orphans, err := ss.CountOrphanedRows()
+	require.NoError(t, err)
+	for _, orphan := range orphans {
+		if orphan.Name == name {
+			return orphan.Count
+		}
+	}
+	require.Failf(t, "missing orphaned rows check", "name: %s", name)
+	return 0
+}
+
+func TestDeleteOrphanedRows(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		sqlStore := ss.(*SqlStore)
+
+		t.Run("fileinfo without post", func(t *testing.T) {
+			user := createUser(ss)
+			channel := createChannel(ss, model.NewId(), user.Id)
+			post := createPost(ss, channel.Id, user.Id, "", "")
+			attached := createFileInfo(ss, post.Id, user.Id)
+			pending := createFileInfo(ss, "", user.Id)
+			orphaned1 := createFileInfo(ss, model.NewId(), user.Id)
+			orphaned2 := createFileInfo(ss, model.NewId(), user.Id)
+			defer ss.FileInfo().PermanentDelete(attached.Id)
+			defer ss.FileInfo().PermanentDelete(pending.Id)
+
+			require.Equal(t, int64(2), getOrphanedRowsCount(t, sqlStore, "fileinfo_without_post"))
+
+			var batches []int64
+			deleted, err := sqlStore.DeleteOrphanedRows("fileinfo_without_post", 1, func(deleted int64) {
+				batches = append(batches, deleted)
+			})
+			require.NoError(t, err)
+			require.Equal(t, int64(2), deleted)
+			require.Equal(t, []int64{1, 2}, batches)
+
+			require.Zero(t, getOrphanedRowsCount(t, sqlStore, "fileinfo_without_post"))
+			_, err = ss.FileInfo().Get(orphaned1.Id)
+			require.Error(t, err)
+			_, err = ss.FileInfo().Get(orphaned2.Id)
+			require.Error(t, err)
+			_, err = ss.FileInfo().Get(attached.Id)
+			require.NoError(t, err)
+			_, err = ss.FileInfo().Get(pending.Id)
+			require.NoError(t, err)
+		})
+
+		t.Run("channelmembers without user", func(t *testing.T) {
+			user := createUser(ss)
+			channel := createChannel(ss, model.NewId(), user.Id)
+			createChannelMember(ss, channel.Id, user.Id)
+			createChannelMember(ss, channel.Id, model.NewId())
+			defer ss.Channel().PermanentDeleteMembersByChannel(channel.Id)
+
+			require.Equal(t, int64(1), getOrphanedRowsCount(t, sqlStore, "channelmembers_without_user"))
+
+			deleted, err := sqlStore.DeleteOrphanedRows("channelmembers_without_user", 100, nil)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), deleted)
+
+			count, err := ss.Channel().GetMemberCount(channel.Id, false)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), count)
+		})
+
+		t.Run("unknown check", func(t *testing.T) {
+			_, err := sqlStore.DeleteOrphanedRows("unknown", 100, nil)
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestGetTableBloat(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		tables, err := ss.(*SqlStore).GetTableBloat()
+		require.NoError(t, err)
+
+		var found bool
+		for _, table := range tables {
+			if table.Name == "posts" || table.Name == "Posts" {
+				found = true
+				require.NotZero(t, table.TotalBytes)
+			}
+		}
+		require.True(t, found)
+	})
+}
+
+func TestReindexTable(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		sqlStore := ss.(*SqlStore)
+
+		require.Error(t, sqlStore.ReindexTable("NotATable"))
+
+		if sqlStore.DriverName() == model.DatabaseDriverPostgres {
+			version, err := sqlStore.GetDbVersion(true)
+			require.NoError(t, err)
+			if intVer, _ := strconv.Atoi(version); intVer < 120000 {
+				require.Error(t, sqlStore.ReindexTable("Preferences"))
+				return
+			}
+		}
+
+		require.NoError(t, sqlStore.ReindexTable("Preferences"))
+	})
+}
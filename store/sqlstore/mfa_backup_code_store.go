@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlMfaBackupCodeStore struct {
+	*SqlStore
+}
+
+func newSqlMfaBackupCodeStore(sqlStore *SqlStore) store.MfaBackupCodeStore {
+	return &SqlMfaBackupCodeStore{sqlStore}
+}
+
+func (s SqlMfaBackupCodeStore) ReplaceForUser(userID string, hashes []string, createAt int64) error {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	deleteQuery, args, err := s.getQueryBuilder().
+		Delete("MfaBackupCodes").
+		Where(sq.Eq{"UserId": userID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "mfa_backup_code_tosql")
+	}
+	if _, err := transaction.Exec(deleteQuery, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaBackupCodes with userId=%s", userID)
+	}
+
+	if len(hashes) > 0 {
+		builder := s.getQueryBuilder().
+			Insert("MfaBackupCodes").
+			Columns("UserId", "CodeHash", "CreateAt", "UsedAt")
+		for _, hash := range hashes {
+			builder = builder.Values(userID, hash, createAt, 0)
+		}
+
+		insertQuery, args, err := builder.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "mfa_backup_code_tosql")
+		}
+		if _, err := transaction.Exec(insertQuery, args...); err != nil {
+			return errors.Wrapf(err, "failed to save MfaBackupCodes with userId=%s", userID)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s SqlMfaBackupCodeStore) Use(userID, hash string, usedAt int64) (bool, error) {
+	query, args, err := s.getQueryBuilder().
+		Update("MfaBackupCodes").
+		Set("UsedAt", usedAt).
+		Where(sq.Eq{"UserId": userID, "CodeHash": hash, "UsedAt": 0}).
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "mfa_backup_code_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to use MfaBackupCode with userId=%s", userID)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return count == 1, nil
+}
+
+func (s SqlMfaBackupCodeStore) CountUnusedForUser(userID string) (int64, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("MfaBackupCodes").
+		Where(sq.Eq{"UserId": userID, "UsedAt": 0}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "mfa_backup_code_tosql")
+	}
+
+	var count int64
+	if err := s.GetReplicaX().Get(&count, query, args...); err != nil {
+		return 0, errors.Wrapf(err, "failed to count MfaBackupCodes with userId=%s", userID)
+	}
+
+	return count, nil
+}
+
+func (s SqlMfaBackupCodeStore) PermanentDeleteByUser(userID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("MfaBackupCodes").
+		Where(sq.Eq{"UserId": userID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "mfa_backup_code_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaBackupCodes with userId=%s", userID)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlOnboardingTaskStore struct {
+	*SqlStore
+}
+
+func newSqlOnboardingTaskStore(sqlStore *SqlStore) store.OnboardingTaskStore {
+	return &SqlOnboardingTaskStore{sqlStore}
+}
+
+func (s SqlOnboardingTaskStore) onboardingTaskSelectQuery() sq.SelectBuilder {
+	return s.getQueryBuilder().
+		Select("Id", "Title", "Description", "Link", "Audience", "SortOrder", "CreatorId", "CreateAt", "UpdateAt", "DeleteAt").
+		From("OnboardingTasks")
+}
+
+func (s SqlOnboardingTaskStore) Save(task *model.OnboardingTask) (*model.OnboardingTask, error) {
+	task.PreSave()
+	if err := task.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("OnboardingTasks").
+		Columns("Id", "Title", "Description", "Link", "Audience", "SortOrder", "CreatorId", "CreateAt", "UpdateAt", "DeleteAt").
+		Values(task.Id, task.Title, task.Description, task.Link, task.Audience, task.SortOrder, task.CreatorId, task.CreateAt, task.UpdateAt, task.DeleteAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "onboarding_task_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OnboardingTask with id=%s", task.Id)
+	}
+
+	return task, nil
+}
+
+func (s SqlOnboardingTaskStore) Update(task *model.OnboardingTask) (*model.OnboardingTask, error) {
+	task.PreUpdate()
+	if err := task.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("OnboardingTasks").
+		Set("Title", task.Title).
+		Set("Description", task.Description).
+		Set("Link", task.Link).
+		Set("Audience", task.Audience).
+		Set("SortOrder", task.SortOrder).
+		Set("UpdateAt", task.UpdateAt).
+		Where(sq.Eq{"Id": task.Id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "onboarding_task_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update OnboardingTask with id=%s", task.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("OnboardingTask", task.Id)
+	}
+
+	return task, nil
+}
+
+func (s SqlOnboardingTaskStore) Get(id string) (*model.OnboardingTask, error) {
+	query, args, err := s.onboardingTaskSelectQuery().
+		Where(sq.Eq{"Id": id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "onboarding_task_tosql")
+	}
+
+	var task model.OnboardingTask
+	if err := s.GetReplicaX().Get(&task, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OnboardingTask", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get OnboardingTask with id=%s", id)
+	}
+
+	return &task, nil
+}
+
+func (s SqlOnboardingTaskStore) GetAll() ([]*model.OnboardingTask, error) {
+	query, args, err := s.onboardingTaskSelectQuery().
+		Where(sq.Eq{"DeleteAt": 0}).
+		OrderBy("SortOrder ASC", "CreateAt ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "onboarding_task_tosql")
+	}
+
+	tasks := []*model.OnboardingTask{}
+	if err := s.GetReplicaX().Select(&tasks, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find OnboardingTasks")
+	}
+
+	return tasks, nil
+}
+
+func (s SqlOnboardingTaskStore) Delete(id string, deleteAt int64) error {
+	query, args, err := s.getQueryBuilder().
+		Update("OnboardingTasks").
+		Set("DeleteAt", deleteAt).
+		Set("UpdateAt", deleteAt).
+		Where(sq.Eq{"Id": id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "onboarding_task_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete OnboardingTask with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("OnboardingTask", id)
+	}
+
+	return nil
+}
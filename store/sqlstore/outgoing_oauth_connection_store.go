@@ -0,0 +1,159 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var outgoingOAuthConnectionColumns = []string{
+	"Id", "CreatorId", "CreateAt", "UpdateAt", "Name", "ClientId", "ClientSecret", "OAuthTokenURL",
+	"GrantType", "Scopes", "CredentialsUsername", "CredentialsPassword", "Audiences",
+}
+
+type SqlOutgoingOAuthConnectionStore struct {
+	*SqlStore
+}
+
+func newSqlOutgoingOAuthConnectionStore(sqlStore *SqlStore) store.OutgoingOAuthConnectionStore {
+	return &SqlOutgoingOAuthConnectionStore{sqlStore}
+}
+
+func (s SqlOutgoingOAuthConnectionStore) Save(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	connection.PreSave()
+	if err := connection.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("OutgoingOAuthConnections").
+		Columns(outgoingOAuthConnectionColumns...).
+		Values(connection.Id, connection.CreatorId, connection.CreateAt, connection.UpdateAt, connection.Name,
+			connection.ClientId, connection.ClientSecret, connection.OAuthTokenURL, connection.GrantType,
+			connection.Scopes, connection.CredentialsUsername, connection.CredentialsPassword, connection.Audiences).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_oauth_connection_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutgoingOAuthConnection with id=%s", connection.Id)
+	}
+
+	return connection, nil
+}
+
+func (s SqlOutgoingOAuthConnectionStore) Update(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	connection.PreUpdate()
+	if err := connection.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("OutgoingOAuthConnections").
+		SetMap(map[string]interface{}{
+			"UpdateAt":            connection.UpdateAt,
+			"Name":                connection.Name,
+			"ClientId":            connection.ClientId,
+			"ClientSecret":        connection.ClientSecret,
+			"OAuthTokenURL":       connection.OAuthTokenURL,
+			"GrantType":           connection.GrantType,
+			"Scopes":              connection.Scopes,
+			"CredentialsUsername": connection.CredentialsUsername,
+			"CredentialsPassword": connection.CredentialsPassword,
+			"Audiences":           connection.Audiences,
+		}).
+		Where(sq.Eq{"Id": connection.Id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_oauth_connection_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update OutgoingOAuthConnection with id=%s", connection.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("OutgoingOAuthConnection", connection.Id)
+	}
+
+	return connection, nil
+}
+
+func (s SqlOutgoingOAuthConnectionStore) Get(id string) (*model.OutgoingOAuthConnection, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(outgoingOAuthConnectionColumns...).
+		From("OutgoingOAuthConnections").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_oauth_connection_tosql")
+	}
+
+	var connection model.OutgoingOAuthConnection
+	if err := s.GetReplicaX().Get(&connection, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutgoingOAuthConnection", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get OutgoingOAuthConnection with id=%s", id)
+	}
+
+	return &connection, nil
+}
+
+func (s SqlOutgoingOAuthConnectionStore) GetAll(offset, limit int) ([]*model.OutgoingOAuthConnection, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(outgoingOAuthConnectionColumns...).
+		From("OutgoingOAuthConnections").
+		OrderBy("Name ASC", "Id ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_oauth_connection_tosql")
+	}
+
+	connections := []*model.OutgoingOAuthConnection{}
+	if err := s.GetReplicaX().Select(&connections, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find OutgoingOAuthConnections")
+	}
+
+	return connections, nil
+}
+
+func (s SqlOutgoingOAuthConnectionStore) Delete(id string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("OutgoingOAuthConnections").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "outgoing_oauth_connection_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete OutgoingOAuthConnection with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("OutgoingOAuthConnection", id)
+	}
+
+	return nil
+}
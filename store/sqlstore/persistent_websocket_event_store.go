@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlPersistentWebSocketEventStore struct {
+	*SqlStore
+}
+
+func newSqlPersistentWebSocketEventStore(sqlStore *SqlStore) store.PersistentWebSocketEventStore {
+	return &SqlPersistentWebSocketEventStore{sqlStore}
+}
+
+func (s SqlPersistentWebSocketEventStore) Save(event *model.PersistentWebSocketEvent) (*model.PersistentWebSocketEvent, error) {
+	event.PreSave()
+	if err := event.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("PersistentWebSocketEvents").
+		Columns("Id", "PluginId", "Event", "Data", "UserId", "ChannelId", "TeamId", "OmitUsers", "CreateAt").
+		Values(event.Id, event.PluginId, event.Event, event.Data, event.UserId, event.ChannelId, event.TeamId, event.OmitUsers, event.CreateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "persistent_websocket_event_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PersistentWebSocketEvent with id=%s", event.Id)
+	}
+
+	return event, nil
+}
+
+func (s SqlPersistentWebSocketEventStore) GetForUserSince(userID string, since int64, limit int) ([]*model.PersistentWebSocketEvent, error) {
+	channelMembers := s.getSubQueryBuilder().
+		Select("ChannelId").
+		From("ChannelMembers").
+		Where(sq.Eq{"UserId": userID})
+	teamMembers := s.getSubQueryBuilder().
+		Select("TeamId").
+		From("TeamMembers").
+		Where(sq.Eq{"UserId": userID, "DeleteAt": 0})
+
+	query, args, err := s.getQueryBuilder().
+		Select("Id", "PluginId", "Event", "Data", "UserId", "ChannelId", "TeamId", "OmitUsers", "CreateAt").
+		From("PersistentWebSocketEvents").
+		Where(sq.Gt{"CreateAt": since}).
+		Where(sq.Or{
+			sq.Eq{"UserId": userID},
+			sq.And{
+				sq.Eq{"UserId": ""},
+				sq.Or{
+					sq.Expr("ChannelId IN (?)", channelMembers),
+					sq.And{
+						sq.Eq{"ChannelId": ""},
+						sq.Or{
+							sq.Eq{"TeamId": ""},
+							sq.Expr("TeamId IN (?)", teamMembers),
+						},
+					},
+				},
+			},
+		}).
+		OrderBy("CreateAt ASC", "Id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "persistent_websocket_event_tosql")
+	}
+
+	events := []*model.PersistentWebSocketEvent{}
+	if err := s.GetReplicaX().Select(&events, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PersistentWebSocketEvents with userId=%s", userID)
+	}
+
+	return events, nil
+}
+
+func (s SqlPersistentWebSocketEventStore) Cleanup(expiryTime int64, batchSize int) error {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM PersistentWebSocketEvents WHERE Id IN (SELECT Id FROM PersistentWebSocketEvents WHERE CreateAt < ? LIMIT ?)"
+	} else {
+		query = "DELETE FROM PersistentWebSocketEvents WHERE CreateAt < ? LIMIT ?"
+	}
+
+	var rowsAffected int64 = 1
+
+	for rowsAffected > 0 {
+		sqlResult, err := s.GetMasterX().Exec(query, expiryTime, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "unable to delete persistent websocket events")
+		}
+		rowsAffected, err = sqlResult.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "unable to delete persistent websocket events")
+		}
+	}
+
+	return nil
+}
@@ -247,6 +247,120 @@ func (ps SqlPluginStore) SetWithOptions(pluginId string, key string, value []byt
 	return savedKv != nil, nil
 }
 
+// SetMultiple applies the operations in a single transaction. If the comparison of any atomic
+// operation fails, nothing is written and false is returned.
+func (ps SqlPluginStore) SetMultiple(pluginId string, operations []*model.PluginKVSetOperation) (bool, error) {
+	if err := model.IsValidPluginKVSetOperations(pluginId, operations); err != nil {
+		return false, err
+	}
+
+	keys := make([]string, 0, len(operations))
+	for _, op := range operations {
+		keys = append(keys, op.Key)
+	}
+
+	transaction, err := ps.GetMasterX().Beginx()
+	if err != nil {
+		return false, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	// Lock the existing rows, always in the same order so that concurrent calls can't deadlock.
+	query := ps.getQueryBuilder().
+		Select("PluginId", "PKey", "PValue", "ExpireAt").
+		From("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Eq{"PKey": keys}).
+		OrderBy("PKey").
+		Suffix("FOR UPDATE")
+
+	var rows []*model.PluginKeyValue
+	if err = transaction.SelectBuilder(&rows, query); err != nil {
+		return false, errors.Wrapf(err, "failed to get PluginKeyValues with pluginId=%s", pluginId)
+	}
+
+	currentTime := model.GetMillis()
+	current := make(map[string]*model.PluginKeyValue, len(rows))
+	for _, row := range rows {
+		current[row.Key] = row
+	}
+
+	for _, op := range operations {
+		if !op.Options.Atomic {
+			continue
+		}
+
+		existing, ok := current[op.Key]
+		if ok && existing.ExpireAt != 0 && existing.ExpireAt <= currentTime {
+			ok = false
+		}
+
+		if op.Options.OldValue == nil {
+			if ok {
+				return false, nil
+			}
+		} else if !ok || !bytes.Equal(existing.Value, op.Options.OldValue) {
+			return false, nil
+		}
+	}
+
+	for _, op := range operations {
+		kv, appErr := model.NewPluginKeyValueFromOptions(pluginId, op.Key, op.Value, op.Options)
+		if appErr != nil {
+			return false, appErr
+		}
+
+		var builders []Builder
+		switch {
+		case kv.Value == nil:
+			// Setting a key to nil is the same as removing it
+			builders = append(builders, ps.getQueryBuilder().
+				Delete("PluginKeyValueStore").
+				Where(sq.Eq{"PluginId": kv.PluginId}).
+				Where(sq.Eq{"PKey": kv.Key}))
+		case op.Options.Atomic && op.Options.OldValue == nil:
+			// The key doesn't exist or has expired. A key that doesn't exist can't be locked, so
+			// it is inserted rather than upserted for a concurrent insert to fail the operations.
+			if _, ok := current[kv.Key]; ok {
+				builders = append(builders, ps.getQueryBuilder().
+					Delete("PluginKeyValueStore").
+					Where(sq.Eq{"PluginId": kv.PluginId}).
+					Where(sq.Eq{"PKey": kv.Key}))
+			}
+			builders = append(builders, ps.getQueryBuilder().
+				Insert("PluginKeyValueStore").
+				Columns("PluginId", "PKey", "PValue", "ExpireAt").
+				Values(kv.PluginId, kv.Key, kv.Value, kv.ExpireAt))
+		default:
+			insert := ps.getQueryBuilder().
+				Insert("PluginKeyValueStore").
+				Columns("PluginId", "PKey", "PValue", "ExpireAt").
+				Values(kv.PluginId, kv.Key, kv.Value, kv.ExpireAt)
+			if ps.DriverName() == model.DatabaseDriverPostgres {
+				insert = insert.SuffixExpr(sq.Expr("ON CONFLICT (pluginid, pkey) DO UPDATE SET PValue = ?, ExpireAt = ?", kv.Value, kv.ExpireAt))
+			} else if ps.DriverName() == model.DatabaseDriverMysql {
+				insert = insert.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE PValue = ?, ExpireAt = ?", kv.Value, kv.ExpireAt))
+			}
+			builders = append(builders, insert)
+		}
+
+		for _, builder := range builders {
+			if _, err = transaction.ExecBuilder(builder); err != nil {
+				if IsUniqueConstraintError(err, []string{"PRIMARY", "PluginId", "Key", "PKey", "pkey"}) {
+					return false, nil
+				}
+				return false, errors.Wrapf(err, "failed to set PluginKeyValue with pluginId=%s and key=%s", kv.PluginId, kv.Key)
+			}
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit_transaction")
+	}
+
+	return true, nil
+}
+
 func (ps SqlPluginStore) Get(pluginId, key string) (*model.PluginKeyValue, error) {
 	currentTime := model.GetMillis()
 	query := ps.getQueryBuilder().Select("PluginId, PKey, PValue, ExpireAt").
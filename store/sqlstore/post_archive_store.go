@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"strconv"
+	"strings"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlPostArchiveStore struct {
+	*SqlStore
+}
+
+func newSqlPostArchiveStore(sqlStore *SqlStore) store.PostArchiveStore {
+	return &SqlPostArchiveStore{sqlStore}
+}
+
+func (s SqlPostArchiveStore) ArchiveThreadsBefore(before int64, limit int) (int64, error) {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	// A thread is only archived as a whole, once neither its root nor any of its replies is
+	// pinned or newer than before.
+	rootIDs := []string{}
+	err = transaction.Select(&rootIDs, `
+		SELECT p.Id
+		FROM Posts p
+		WHERE p.RootId = ''
+			AND p.CreateAt < ?
+			AND p.IsPinned = false
+			AND NOT EXISTS (
+				SELECT 1 FROM Posts r
+				WHERE r.RootId = p.Id AND (r.CreateAt >= ? OR r.IsPinned = true)
+			)
+		ORDER BY p.CreateAt
+		LIMIT ?`, before, before, limit)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find threads to archive before=%d", before)
+	}
+
+	if len(rootIDs) == 0 {
+		return 0, nil
+	}
+
+	postIDs := []string{}
+	query, args, err := s.getQueryBuilder().
+		Select("Id").
+		From("Posts").
+		Where(sq.Or{sq.Eq{"Id": rootIDs}, sq.Eq{"RootId": rootIDs}}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "post_archive_tosql")
+	}
+	if err = transaction.Select(&postIDs, query, args...); err != nil {
+		return 0, errors.Wrap(err, "failed to find posts to archive")
+	}
+
+	columns := strings.Join(postSliceColumns(), ", ")
+	query, args, err = s.getQueryBuilder().
+		Select(columns, strconv.FormatInt(model.GetMillis(), 10)).
+		From("Posts").
+		Where(sq.Eq{"Id": postIDs}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "post_archive_tosql")
+	}
+
+	result, err := transaction.Exec("INSERT INTO PostsArchive ("+columns+", ArchivedAt) "+query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to copy posts to PostsArchive")
+	}
+	archived, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to copy posts to PostsArchive")
+	}
+
+	query, args, err = s.getQueryBuilder().
+		Delete("Posts").
+		Where(sq.Eq{"Id": postIDs}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "post_archive_tosql")
+	}
+	if _, err = transaction.Exec(query, args...); err != nil {
+		return 0, errors.Wrap(err, "failed to delete archived Posts")
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
+
+	return archived, nil
+}
+
+func (s SqlPostArchiveStore) Search(channelIDs []string, terms string, offset, limit int) ([]*model.Post, error) {
+	posts := []*model.Post{}
+	if len(channelIDs) == 0 {
+		return posts, nil
+	}
+
+	builder := s.getQueryBuilder().
+		Select(postSliceColumns()...).
+		From("PostsArchive").
+		Where(sq.Eq{"ChannelId": channelIDs, "DeleteAt": 0}).
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	for _, term := range strings.Fields(strings.ToLower(terms)) {
+		builder = builder.Where("LOWER(Message) LIKE ? ESCAPE '\\'", "%"+sanitizeSearchTerm(term, "\\")+"%")
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_archive_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&posts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to search PostsArchive with terms=%s", terms)
+	}
+
+	return posts, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var postPropSchemaColumns = []string{"Namespace", "Definition", "CreatorId", "CreateAt", "UpdateAt"}
+
+type SqlPostPropSchemaStore struct {
+	*SqlStore
+}
+
+func newSqlPostPropSchemaStore(sqlStore *SqlStore) store.PostPropSchemaStore {
+	return &SqlPostPropSchemaStore{sqlStore}
+}
+
+func (s SqlPostPropSchemaStore) Save(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
+	schema.PreSave()
+	if err := schema.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("PostPropSchemas").
+		Columns(postPropSchemaColumns...).
+		Values(schema.Namespace, string(schema.Schema), schema.CreatorId, schema.CreateAt, schema.UpdateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_prop_schema_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PostPropSchema with namespace=%s", schema.Namespace)
+	}
+
+	return schema, nil
+}
+
+func (s SqlPostPropSchemaStore) Update(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
+	schema.PreUpdate()
+	if err := schema.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("PostPropSchemas").
+		SetMap(map[string]interface{}{
+			"Definition": string(schema.Schema),
+			"CreatorId":  schema.CreatorId,
+			"UpdateAt":   schema.UpdateAt,
+		}).
+		Where(sq.Eq{"Namespace": schema.Namespace}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_prop_schema_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update PostPropSchema with namespace=%s", schema.Namespace)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	} else if rows == 0 {
+		return nil, store.NewErrNotFound("PostPropSchema", schema.Namespace)
+	}
+
+	return schema, nil
+}
+
+func (s SqlPostPropSchemaStore) Get(namespace string) (*model.PostPropSchema, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(postPropSchemaColumns...).
+		From("PostPropSchemas").
+		Where(sq.Eq{"Namespace": namespace}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_prop_schema_tosql")
+	}
+
+	var schema model.PostPropSchema
+	if err := s.GetReplicaX().Get(&schema, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PostPropSchema", namespace)
+		}
+		return nil, errors.Wrapf(err, "failed to get PostPropSchema with namespace=%s", namespace)
+	}
+
+	return &schema, nil
+}
+
+func (s SqlPostPropSchemaStore) GetAll() ([]*model.PostPropSchema, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(postPropSchemaColumns...).
+		From("PostPropSchemas").
+		OrderBy("Namespace ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_prop_schema_tosql")
+	}
+
+	schemas := []*model.PostPropSchema{}
+	if err := s.GetReplicaX().Select(&schemas, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to get PostPropSchemas")
+	}
+
+	return schemas, nil
+}
+
+func (s SqlPostPropSchemaStore) Delete(namespace string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("PostPropSchemas").
+		Where(sq.Eq{"Namespace": namespace}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "post_prop_schema_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete PostPropSchema with namespace=%s", namespace)
+	}
+
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	} else if rows == 0 {
+		return store.NewErrNotFound("PostPropSchema", namespace)
+	}
+
+	return nil
+}
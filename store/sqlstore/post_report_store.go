@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var postReportColumns = []string{"Id", "PostId", "ChannelId", "PostUserId", "ReporterId", "Reason", "Comment", "Status", "Action", "ResolverId", "CreateAt", "ResolveAt"}
+
+type SqlPostReportStore struct {
+	*SqlStore
+}
+
+func newSqlPostReportStore(sqlStore *SqlStore) store.PostReportStore {
+	return &SqlPostReportStore{sqlStore}
+}
+
+func (s SqlPostReportStore) Save(report *model.PostReport) (*model.PostReport, error) {
+	report.PreSave()
+	if err := report.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("PostReports").
+		Columns(postReportColumns...).
+		Values(report.Id, report.PostId, report.ChannelId, report.PostUserId, report.ReporterId, report.Reason, report.Comment, report.Status, report.Action, report.ResolverId, report.CreateAt, report.ResolveAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_report_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		if IsUniqueConstraintError(err, []string{"PostId", "idx_postreports_post_id_reporter_id"}) {
+			return nil, store.NewErrConflict("PostReport", err, "postId="+report.PostId)
+		}
+		return nil, errors.Wrapf(err, "failed to save PostReport with id=%s", report.Id)
+	}
+
+	return report, nil
+}
+
+func (s SqlPostReportStore) Get(id string) (*model.PostReport, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(postReportColumns...).
+		From("PostReports").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_report_tosql")
+	}
+
+	var report model.PostReport
+	if err := s.GetReplicaX().Get(&report, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PostReport", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get PostReport with id=%s", id)
+	}
+
+	return &report, nil
+}
+
+func (s SqlPostReportStore) GetReports(opts model.PostReportGetOptions) ([]*model.PostReport, error) {
+	builder := s.getQueryBuilder().
+		Select(postReportColumns...).
+		From("PostReports").
+		OrderBy("CreateAt ASC", "Id ASC").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+	if opts.Status != "" {
+		builder = builder.Where(sq.Eq{"Status": opts.Status})
+	}
+	if opts.ChannelId != "" {
+		builder = builder.Where(sq.Eq{"ChannelId": opts.ChannelId})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_report_tosql")
+	}
+
+	reports := []*model.PostReport{}
+	if err := s.GetReplicaX().Select(&reports, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find PostReports")
+	}
+
+	return reports, nil
+}
+
+func (s SqlPostReportStore) ResolveForPost(postID, status, action, resolverID string, resolveAt int64) (int64, error) {
+	query, args, err := s.getQueryBuilder().
+		Update("PostReports").
+		Set("Status", status).
+		Set("Action", action).
+		Set("ResolverId", resolverID).
+		Set("ResolveAt", resolveAt).
+		Where(sq.Eq{"PostId": postID, "Status": model.PostReportStatusOpen}).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "post_report_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to update PostReports with postId=%s", postID)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return count, nil
+}
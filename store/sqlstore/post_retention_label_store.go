@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var postRetentionLabelColumns = []string{"PostId", "Label", "ExpireAt", "CreatorId", "CreateAt"}
+
+type SqlPostRetentionLabelStore struct {
+	*SqlStore
+}
+
+func newSqlPostRetentionLabelStore(sqlStore *SqlStore) store.PostRetentionLabelStore {
+	return &SqlPostRetentionLabelStore{sqlStore}
+}
+
+func (s SqlPostRetentionLabelStore) Save(label *model.PostRetentionLabel) (*model.PostRetentionLabel, error) {
+	if label.CreateAt == 0 {
+		label.CreateAt = model.GetMillis()
+	}
+	if err := label.IsValid(); err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("PostRetentionLabels").
+		Columns(postRetentionLabelColumns...).
+		Values(label.PostId, label.Label, label.ExpireAt, label.CreatorId, label.CreateAt)
+	if s.DriverName() == model.DatabaseDriverMysql {
+		builder = builder.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE Label = ?, ExpireAt = ?, CreatorId = ?, CreateAt = ?", label.Label, label.ExpireAt, label.CreatorId, label.CreateAt))
+	} else {
+		builder = builder.SuffixExpr(sq.Expr("ON CONFLICT (postid) DO UPDATE SET Label = ?, ExpireAt = ?, CreatorId = ?, CreateAt = ?", label.Label, label.ExpireAt, label.CreatorId, label.CreateAt))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_retention_label_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PostRetentionLabel with postId=%s", label.PostId)
+	}
+
+	return label, nil
+}
+
+func (s SqlPostRetentionLabelStore) Get(postID string) (*model.PostRetentionLabel, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(postRetentionLabelColumns...).
+		From("PostRetentionLabels").
+		Where(sq.Eq{"PostId": postID}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_retention_label_tosql")
+	}
+
+	var label model.PostRetentionLabel
+	if err := s.GetReplicaX().Get(&label, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PostRetentionLabel", postID)
+		}
+		return nil, errors.Wrapf(err, "failed to get PostRetentionLabel with postId=%s", postID)
+	}
+
+	return &label, nil
+}
+
+func (s SqlPostRetentionLabelStore) Delete(postID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("PostRetentionLabels").
+		Where(sq.Eq{"PostId": postID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "post_retention_label_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete PostRetentionLabel with postId=%s", postID)
+	}
+
+	return nil
+}
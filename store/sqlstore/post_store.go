@@ -1340,6 +1340,26 @@ func (s *SqlPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinc
 	return exist, nil
 }
 
+// GetLastPostAtByUserSince returns the creation time of the last post of the user in the channel
+// created since the given time, deleted posts included, or zero if there is none. System messages
+// are ignored.
+func (s *SqlPostStore) GetLastPostAtByUserSince(options model.GetPostsSinceOptions, userId string) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("COALESCE(MAX(CreateAt), 0)").
+		From("Posts").
+		Where(sq.Eq{"ChannelId": options.ChannelId, "UserId": userId}).
+		Where(sq.GtOrEq{"CreateAt": options.Time}).
+		Where(sq.NotLike{"Type": model.PostSystemMessagePrefix + "%"})
+
+	var lastPostAt int64
+	if err := s.GetMasterX().GetBuilder(&lastPostAt, query); err != nil {
+		return 0, errors.Wrapf(err,
+			"failed to get the last post in channelId=%s for userId=%s since %s", options.ChannelId, userId, model.GetTimeForMillis(options.Time))
+	}
+
+	return lastPostAt, nil
+}
+
 func (s *SqlPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
 	query := s.getQueryBuilder().
 		Select("*").
@@ -1852,12 +1872,34 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 	return s.search(teamId, userId, params, true, true)
 }
 
+func (s *SqlPostStore) buildAdvancedFilterClause(params *model.SearchParams, builder sq.SelectBuilder) sq.SelectBuilder {
+	// handle the regex and has: filters of advanced searches
+	if params.Regex != "" {
+		if s.DriverName() == model.DatabaseDriverPostgres {
+			builder = builder.Where("q2.Message ~* ?", params.Regex)
+		} else {
+			builder = builder.Where("q2.Message REGEXP ?", params.Regex)
+		}
+	}
+
+	if params.HasFile {
+		builder = builder.Where("q2.FileIds != '[]' AND q2.FileIds != ''")
+	}
+
+	if params.HasLink {
+		builder = builder.Where("(q2.Message LIKE '%http://%' OR q2.Message LIKE '%https://%')")
+	}
+
+	return builder
+}
+
 func (s *SqlPostStore) search(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool) (*model.PostList, error) {
 	list := model.NewPostList()
 	if params.Terms == "" && params.ExcludedTerms == "" &&
 		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
 		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" {
+		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" &&
+		params.Regex == "" && !params.HasLink && !params.HasFile {
 		return list, nil
 	}
 
@@ -1876,6 +1918,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		return nil, errors.Wrap(err, "failed to build search post filter clause")
 	}
 	baseQuery = s.buildCreateDateFilterClause(params, baseQuery)
+	baseQuery = s.buildAdvancedFilterClause(params, baseQuery)
 
 	termMap := map[string]bool{}
 	terms := params.Terms
@@ -2279,12 +2322,28 @@ func (s *SqlPostStore) GetPostsBatchForIndexing(startTime int64, startPostID str
 
 // PermanentDeleteBatchForRetentionPolicies deletes a batch of records which are affected by
 // the global or a granular retention policy.
+// Posts with a retention label are not affected by the policies, they are deleted once their
+// label has expired instead, before the posts affected by the policies.
 // See `genericPermanentDeleteBatchForRetentionPolicies` for details.
 func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+	var labeledRowsAffected int64
+	if now > 0 && !cursor.ChannelPoliciesDone {
+		var err error
+		labeledRowsAffected, err = s.permanentDeleteExpiredLabeledPosts(now, limit)
+		if err != nil {
+			return 0, cursor, err
+		}
+		if labeledRowsAffected >= limit {
+			return labeledRowsAffected, cursor, nil
+		}
+	}
+
 	builder := s.getQueryBuilder().
 		Select("Posts.Id").
-		From("Posts")
-	return genericPermanentDeleteBatchForRetentionPolicies(RetentionPolicyBatchDeletionInfo{
+		From("Posts").
+		LeftJoin("PostRetentionLabels ON Posts.Id = PostRetentionLabels.PostId").
+		Where(sq.Eq{"PostRetentionLabels.PostId": nil})
+	rowsAffected, cursor, err := genericPermanentDeleteBatchForRetentionPolicies(RetentionPolicyBatchDeletionInfo{
 		BaseBuilder:         builder,
 		Table:               "Posts",
 		TimeColumn:          "CreateAt",
@@ -2292,8 +2351,45 @@ func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(now, globalPolic
 		ChannelIDTable:      "Posts",
 		NowMillis:           now,
 		GlobalPolicyEndTime: globalPolicyEndTime,
-		Limit:               limit,
+		Limit:               limit - labeledRowsAffected,
 	}, s.SqlStore, cursor)
+	if err != nil {
+		return 0, cursor, err
+	}
+
+	return labeledRowsAffected + rowsAffected, cursor, nil
+}
+
+// permanentDeleteExpiredLabeledPosts deletes a batch of the posts whose retention label has
+// expired, along with the labels of the posts which no longer exist.
+func (s *SqlPostStore) permanentDeleteExpiredLabeledPosts(now, limit int64) (int64, error) {
+	builder := s.getQueryBuilder().
+		Select("Posts.Id").
+		From("Posts").
+		InnerJoin("PostRetentionLabels ON Posts.Id = PostRetentionLabels.PostId").
+		Where(sq.LtOrEq{"PostRetentionLabels.ExpireAt": now}).
+		Limit(uint64(limit))
+	rowsAffected, err := genericRetentionPoliciesDeletion(builder, RetentionPolicyBatchDeletionInfo{
+		Table:       "Posts",
+		PrimaryKeys: []string{"Id"},
+	}, s.SqlStore)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Delete("PostRetentionLabels").
+		Where(sq.LtOrEq{"ExpireAt": now}).
+		Where("NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = PostRetentionLabels.PostId)").
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "post_retention_label_tosql")
+	}
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return 0, errors.Wrap(err, "failed to delete PostRetentionLabels")
+	}
+
+	return rowsAffected, nil
 }
 
 // DeleteOrphanedRows removes entries from Posts when a corresponding channel no longer exists.
@@ -2559,6 +2655,71 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 	return posts, nil
 }
 
+// GetChannelPostsForExportAfter returns the posts and replies of a channel in the order they were
+// created, starting after the post with the given creation time and id. Root posts have their
+// ReplyCount populated.
+func (s *SqlPostStore) GetChannelPostsForExportAfter(channelID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("p.*, (SELECT count(*) FROM Posts WHERE Posts.RootId = p.Id AND Posts.DeleteAt = 0) as ReplyCount").
+		From("Posts p").
+		Where(sq.And{
+			sq.Eq{"p.ChannelId": channelID},
+			sq.Eq{"p.DeleteAt": 0},
+			sq.Or{
+				sq.Gt{"p.CreateAt": afterCreateAt},
+				sq.And{
+					sq.Eq{"p.CreateAt": afterCreateAt},
+					sq.Gt{"p.Id": afterID},
+				},
+			},
+		}).
+		OrderBy("p.CreateAt", "p.Id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "channel_posts_for_export_tosql")
+	}
+
+	posts := []*model.Post{}
+	if err := s.GetSearchReplicaX().Select(&posts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", channelID)
+	}
+
+	return posts, nil
+}
+
+// GetUserPostsForExportAfter returns the posts of a user in the order they were created, starting
+// after the post with the given creation time and id. Deleted posts are included, being still
+// stored.
+func (s *SqlPostStore) GetUserPostsForExportAfter(userID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Posts").
+		Where(sq.And{
+			sq.Eq{"UserId": userID},
+			sq.Or{
+				sq.Gt{"CreateAt": afterCreateAt},
+				sq.And{
+					sq.Eq{"CreateAt": afterCreateAt},
+					sq.Gt{"Id": afterID},
+				},
+			},
+		}).
+		OrderBy("CreateAt", "Id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "user_posts_for_export_tosql")
+	}
+
+	posts := []*model.Post{}
+	if err := s.GetSearchReplicaX().Select(&posts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts with userId=%s", userID)
+	}
+
+	return posts, nil
+}
+
 //nolint:unparam
 func (s *SqlPostStore) SearchPostsForUser(paramsList []*model.SearchParams, userID, teamId string, page, perPage int) (*model.PostSearchResults, error) {
 	// Since we don't support paging for DB search, we just return nothing for later pages
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlPushNotificationReceiptStore struct {
+	*SqlStore
+}
+
+func newSqlPushNotificationReceiptStore(sqlStore *SqlStore) store.PushNotificationReceiptStore {
+	return &SqlPushNotificationReceiptStore{sqlStore}
+}
+
+func (s SqlPushNotificationReceiptStore) Save(receipt *model.PushNotificationReceipt) (*model.PushNotificationReceipt, error) {
+	receipt.PreSave()
+	if err := receipt.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("PushNotificationReceipts").
+		Columns("AckId", "UserId", "PostId", "ChannelId", "Platform", "Type", "Status", "Error", "CreateAt", "ReceivedAt").
+		Values(receipt.AckId, receipt.UserId, receipt.PostId, receipt.ChannelId, receipt.Platform, receipt.Type, receipt.Status, receipt.Error, receipt.CreateAt, receipt.ReceivedAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "push_notification_receipt_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PushNotificationReceipt with ackId=%s", receipt.AckId)
+	}
+
+	return receipt, nil
+}
+
+func (s SqlPushNotificationReceiptStore) MarkReceived(ackID string, receivedAt int64) error {
+	query, args, err := s.getQueryBuilder().
+		Update("PushNotificationReceipts").
+		Set("Status", model.PushReceiptStatusReceived).
+		Set("ReceivedAt", receivedAt).
+		Where(sq.Eq{"AckId": ackID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "push_notification_receipt_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update PushNotificationReceipt with ackId=%s", ackID)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("PushNotificationReceipt", ackID)
+	}
+
+	return nil
+}
+
+func (s SqlPushNotificationReceiptStore) GetForUser(userID string, limit int) ([]*model.PushNotificationReceipt, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("AckId", "UserId", "PostId", "ChannelId", "Platform", "Type", "Status", "Error", "CreateAt", "ReceivedAt").
+		From("PushNotificationReceipts").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "push_notification_receipt_tosql")
+	}
+
+	receipts := []*model.PushNotificationReceipt{}
+	if err := s.GetReplicaX().Select(&receipts, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find PushNotificationReceipts with userId=%s", userID)
+	}
+
+	return receipts, nil
+}
+
+func (s SqlPushNotificationReceiptStore) Cleanup(expiryTime int64, batchSize int) error {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM PushNotificationReceipts WHERE AckId IN (SELECT AckId FROM PushNotificationReceipts WHERE CreateAt < ? LIMIT ?)"
+	} else {
+		query = "DELETE FROM PushNotificationReceipts WHERE CreateAt < ? LIMIT ?"
+	}
+
+	var rowsAffected int64 = 1
+
+	for rowsAffected > 0 {
+		sqlResult, err := s.GetMasterX().Exec(query, expiryTime, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "unable to delete push notification receipts")
+		}
+		rowsAffected, err = sqlResult.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "unable to delete push notification receipts")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var reminderColumns = []string{"Id", "UserId", "PostId", "Message", "RemindAt", "Recurrence", "CreateAt", "UpdateAt"}
+
+type SqlReminderStore struct {
+	*SqlStore
+}
+
+func newSqlReminderStore(sqlStore *SqlStore) store.ReminderStore {
+	return &SqlReminderStore{sqlStore}
+}
+
+func (s SqlReminderStore) Save(reminder *model.Reminder) (*model.Reminder, error) {
+	reminder.PreSave()
+	if err := reminder.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("Reminders").
+		Columns(reminderColumns...).
+		Values(reminder.Id, reminder.UserId, reminder.PostId, reminder.Message, reminder.RemindAt, reminder.Recurrence, reminder.CreateAt, reminder.UpdateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "reminder_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save Reminder with id=%s", reminder.Id)
+	}
+
+	return reminder, nil
+}
+
+func (s SqlReminderStore) Update(reminder *model.Reminder) (*model.Reminder, error) {
+	reminder.PreUpdate()
+	if err := reminder.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("Reminders").
+		SetMap(map[string]interface{}{
+			"Message":    reminder.Message,
+			"RemindAt":   reminder.RemindAt,
+			"Recurrence": reminder.Recurrence,
+			"UpdateAt":   reminder.UpdateAt,
+		}).
+		Where(sq.Eq{"Id": reminder.Id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "reminder_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to update Reminder with id=%s", reminder.Id)
+	}
+
+	return reminder, nil
+}
+
+func (s SqlReminderStore) Get(id string) (*model.Reminder, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(reminderColumns...).
+		From("Reminders").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "reminder_tosql")
+	}
+
+	var reminder model.Reminder
+	if err := s.GetReplicaX().Get(&reminder, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Reminder", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get Reminder with id=%s", id)
+	}
+
+	return &reminder, nil
+}
+
+func (s SqlReminderStore) GetForUser(userID string) ([]*model.Reminder, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(reminderColumns...).
+		From("Reminders").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("RemindAt ASC", "Id ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "reminder_tosql")
+	}
+
+	reminders := []*model.Reminder{}
+	if err := s.GetReplicaX().Select(&reminders, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Reminders with userId=%s", userID)
+	}
+
+	return reminders, nil
+}
+
+func (s SqlReminderStore) GetDue(now int64, limit int) ([]*model.Reminder, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(reminderColumns...).
+		From("Reminders").
+		Where(sq.LtOrEq{"RemindAt": now}).
+		OrderBy("RemindAt ASC", "Id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "reminder_tosql")
+	}
+
+	reminders := []*model.Reminder{}
+	if err := s.GetMasterX().Select(&reminders, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find due Reminders")
+	}
+
+	return reminders, nil
+}
+
+func (s SqlReminderStore) Delete(id string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("Reminders").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "reminder_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete Reminder with id=%s", id)
+	}
+
+	return nil
+}
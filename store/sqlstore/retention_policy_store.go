@@ -53,11 +53,14 @@ func (s *SqlRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndC
 	}
 
 	policy.ID = model.NewId()
+	if policy.FileDurationDays == nil {
+		policy.FileDurationDays = model.NewInt64(-1)
+	}
 
 	policyInsertQuery, policyInsertArgs, err := s.getQueryBuilder().
 		Insert("RetentionPolicies").
-		Columns("Id", "DisplayName", "PostDuration").
-		Values(policy.ID, policy.DisplayName, policy.PostDurationDays).
+		Columns("Id", "DisplayName", "PostDuration", "FileDuration").
+		Values(policy.ID, policy.DisplayName, policy.PostDurationDays, policy.FileDurationDays).
 		ToSql()
 	if err != nil {
 		return nil, err
@@ -214,7 +217,7 @@ func (s *SqlRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndC
 
 	policyUpdateQuery := ""
 	policyUpdateArgs := []interface{}{}
-	if patch.DisplayName != "" || patch.PostDurationDays != nil {
+	if patch.DisplayName != "" || patch.PostDurationDays != nil || patch.FileDurationDays != nil {
 		builder := s.getQueryBuilder().Update("RetentionPolicies")
 		if patch.DisplayName != "" {
 			builder = builder.Set("DisplayName", patch.DisplayName)
@@ -222,6 +225,9 @@ func (s *SqlRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndC
 		if patch.PostDurationDays != nil {
 			builder = builder.Set("PostDuration", *patch.PostDurationDays)
 		}
+		if patch.FileDurationDays != nil {
+			builder = builder.Set("FileDuration", *patch.FileDurationDays)
+		}
 		policyUpdateQuery, policyUpdateArgs, err = builder.
 			Where(sq.Eq{"Id": patch.ID}).
 			ToSql()
@@ -358,6 +364,7 @@ func (s *SqlRetentionPolicyStore) buildGetPoliciesQuery(id string, offset, limit
 			RetentionPolicies.Id as "Id",
 			RetentionPolicies.DisplayName,
 			RetentionPolicies.PostDuration as "PostDuration",
+			RetentionPolicies.FileDuration as "FileDuration",
 			A.Count AS ChannelCount,
 			B.Count AS TeamCount
 	  `).
@@ -31,6 +31,7 @@ type Role struct {
 	Permissions   string
 	SchemeManaged bool
 	BuiltIn       bool
+	Scope         string
 }
 
 type channelRolesPermissions struct {
@@ -64,6 +65,7 @@ func NewRoleFromModel(role *model.Role) *Role {
 		Permissions:   permissions,
 		SchemeManaged: role.SchemeManaged,
 		BuiltIn:       role.BuiltIn,
+		Scope:         string(role.Scope),
 	}
 }
 
@@ -79,6 +81,7 @@ func (role Role) ToModel() *model.Role {
 		Permissions:   strings.Fields(role.Permissions),
 		SchemeManaged: role.SchemeManaged,
 		BuiltIn:       role.BuiltIn,
+		Scope:         model.RoleScope(role.Scope),
 	}
 }
 
@@ -113,7 +116,7 @@ func (s *SqlRoleStore) Save(role *model.Role) (*model.Role, error) {
 
 	res, err := s.GetMasterX().NamedExec(`UPDATE Roles
 		SET UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, CreateAt=:CreateAt,  Name=:Name, DisplayName=:DisplayName,
-		Description=:Description, Permissions=:Permissions, SchemeManaged=:SchemeManaged, BuiltIn=:BuiltIn
+		Description=:Description, Permissions=:Permissions, SchemeManaged=:SchemeManaged, BuiltIn=:BuiltIn, Scope=:Scope
 		 WHERE Id=:Id`, &dbRole)
 
 	if err != nil {
@@ -145,9 +148,9 @@ func (s *SqlRoleStore) createRole(role *model.Role, transaction *sqlxTxWrapper)
 	dbRole.UpdateAt = dbRole.CreateAt
 
 	if _, err := transaction.NamedExec(`INSERT INTO Roles
-		(Id, Name, DisplayName, Description, Permissions, CreateAt, UpdateAt, DeleteAt, SchemeManaged, BuiltIn)
+		(Id, Name, DisplayName, Description, Permissions, CreateAt, UpdateAt, DeleteAt, SchemeManaged, BuiltIn, Scope)
 		VALUES
-		(:Id, :Name, :DisplayName, :Description, :Permissions, :CreateAt, :UpdateAt, :DeleteAt, :SchemeManaged, :BuiltIn)`, dbRole); err != nil {
+		(:Id, :Name, :DisplayName, :Description, :Permissions, :CreateAt, :UpdateAt, :DeleteAt, :SchemeManaged, :BuiltIn, :Scope)`, dbRole); err != nil {
 		return nil, errors.Wrap(err, "failed to save Role")
 	}
 
@@ -248,7 +251,7 @@ func (s *SqlRoleStore) Delete(roleId string) (*model.Role, error) {
 
 	res, err := s.GetMasterX().NamedExec(`UPDATE Roles
 		SET UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, CreateAt=:CreateAt,  Name=:Name, DisplayName=:DisplayName,
-		Description=:Description, Permissions=:Permissions, SchemeManaged=:SchemeManaged, BuiltIn=:BuiltIn
+		Description=:Description, Permissions=:Permissions, SchemeManaged=:SchemeManaged, BuiltIn=:BuiltIn, Scope=:Scope
 		 WHERE Id=:Id`, &role)
 
 	if err != nil {
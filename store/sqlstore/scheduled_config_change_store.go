@@ -0,0 +1,194 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var scheduledConfigChangeColumns = []string{"Id", "CreatorId", "ApplyAt", "Config", "Diffs", "Status", "Error", "CreateAt", "UpdateAt"}
+
+// scheduledConfigChange is a row of the ScheduledConfigChanges table, which holds the partial
+// configuration and the diffs of a change as JSON.
+type scheduledConfigChange struct {
+	Id        string
+	CreatorId string
+	ApplyAt   int64
+	Config    string
+	Diffs     string
+	Status    string
+	Error     string
+	CreateAt  int64
+	UpdateAt  int64
+}
+
+func (c *scheduledConfigChange) toModel() (*model.ScheduledConfigChange, error) {
+	change := &model.ScheduledConfigChange{
+		Id:        c.Id,
+		CreatorId: c.CreatorId,
+		ApplyAt:   c.ApplyAt,
+		Status:    c.Status,
+		Error:     c.Error,
+		CreateAt:  c.CreateAt,
+		UpdateAt:  c.UpdateAt,
+	}
+
+	if err := json.Unmarshal([]byte(c.Config), &change.Config); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the config of ScheduledConfigChange with id=%s", c.Id)
+	}
+
+	if c.Diffs != "" {
+		change.Diffs = json.RawMessage(c.Diffs)
+	}
+
+	return change, nil
+}
+
+type SqlScheduledConfigChangeStore struct {
+	*SqlStore
+}
+
+func newSqlScheduledConfigChangeStore(sqlStore *SqlStore) store.ScheduledConfigChangeStore {
+	return &SqlScheduledConfigChangeStore{sqlStore}
+}
+
+func (s SqlScheduledConfigChangeStore) Save(change *model.ScheduledConfigChange) (*model.ScheduledConfigChange, error) {
+	change.PreSave()
+	if err := change.IsValid(); err != nil {
+		return nil, err
+	}
+
+	cfgJSON, err := json.Marshal(change.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal the config of ScheduledConfigChange with id=%s", change.Id)
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("ScheduledConfigChanges").
+		Columns(scheduledConfigChangeColumns...).
+		Values(change.Id, change.CreatorId, change.ApplyAt, string(cfgJSON), string(change.Diffs), change.Status, change.Error, change.CreateAt, change.UpdateAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "scheduled_config_change_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ScheduledConfigChange with id=%s", change.Id)
+	}
+
+	return change, nil
+}
+
+func (s SqlScheduledConfigChangeStore) Get(id string) (*model.ScheduledConfigChange, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(scheduledConfigChangeColumns...).
+		From("ScheduledConfigChanges").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "scheduled_config_change_tosql")
+	}
+
+	var change scheduledConfigChange
+	if err := s.GetMasterX().Get(&change, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ScheduledConfigChange", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ScheduledConfigChange with id=%s", id)
+	}
+
+	return change.toModel()
+}
+
+func (s SqlScheduledConfigChangeStore) GetAll(includeFinished bool, offset, limit int) ([]*model.ScheduledConfigChange, error) {
+	builder := s.getQueryBuilder().
+		Select(scheduledConfigChangeColumns...).
+		From("ScheduledConfigChanges").
+		OrderBy("ApplyAt DESC", "Id ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+	if !includeFinished {
+		builder = builder.Where(sq.Eq{"Status": model.ScheduledConfigChangeStatusPending})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "scheduled_config_change_tosql")
+	}
+
+	var rows []*scheduledConfigChange
+	if err := s.GetReplicaX().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find ScheduledConfigChanges")
+	}
+
+	return scheduledConfigChangesToModel(rows)
+}
+
+func (s SqlScheduledConfigChangeStore) GetDue(now int64, limit int) ([]*model.ScheduledConfigChange, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(scheduledConfigChangeColumns...).
+		From("ScheduledConfigChanges").
+		Where(sq.And{
+			sq.Eq{"Status": model.ScheduledConfigChangeStatusPending},
+			sq.LtOrEq{"ApplyAt": now},
+		}).
+		OrderBy("ApplyAt ASC", "Id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "scheduled_config_change_tosql")
+	}
+
+	var rows []*scheduledConfigChange
+	if err := s.GetMasterX().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find due ScheduledConfigChanges")
+	}
+
+	return scheduledConfigChangesToModel(rows)
+}
+
+func (s SqlScheduledConfigChangeStore) UpdateStatus(id, oldStatus, newStatus, errorMessage string) (bool, error) {
+	query, args, err := s.getQueryBuilder().
+		Update("ScheduledConfigChanges").
+		Set("Status", newStatus).
+		Set("Error", errorMessage).
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"Id": id, "Status": oldStatus}).
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "scheduled_config_change_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to update the status of ScheduledConfigChange with id=%s", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func scheduledConfigChangesToModel(rows []*scheduledConfigChange) ([]*model.ScheduledConfigChange, error) {
+	changes := make([]*model.ScheduledConfigChange, 0, len(rows))
+	for _, row := range rows {
+		change, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
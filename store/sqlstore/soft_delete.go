@@ -0,0 +1,255 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// softDeleteCascade is a table whose rows are soft-deleted along with the row they reference
+// through column.
+type softDeleteCascade struct {
+	table  string
+	column string
+}
+
+// softDeleteTable describes a table whose rows are soft-deleted by setting their DeleteAt
+// column. UpdateAt is set along with DeleteAt for the tables that have one.
+type softDeleteTable struct {
+	hasUpdateAt bool
+	// cascades are soft-deleted and restored along with the rows of the table. Cascades are
+	// not recursive: the cascades of a cascade are left untouched.
+	cascades []softDeleteCascade
+}
+
+var softDeleteTables = map[string]softDeleteTable{
+	"Teams": {
+		hasUpdateAt: true,
+		cascades: []softDeleteCascade{
+			{table: "Channels", column: "TeamId"},
+			{table: "PublicChannels", column: "TeamId"},
+			{table: "TeamMembers", column: "TeamId"},
+			{table: "Commands", column: "TeamId"},
+		},
+	},
+	"Channels": {
+		hasUpdateAt: true,
+		cascades: []softDeleteCascade{
+			{table: "PublicChannels", column: "Id"},
+		},
+	},
+	"PublicChannels": {},
+	"TeamMembers":    {},
+	"Commands": {
+		hasUpdateAt: true,
+	},
+}
+
+func getSoftDeleteTable(table string) (softDeleteTable, error) {
+	cfg, ok := softDeleteTables[table]
+	if !ok {
+		return softDeleteTable{}, errors.Errorf("table %s is not soft-deletable", table)
+	}
+	return cfg, nil
+}
+
+// softDelete marks the row of the table with the given id as deleted at deleteAt, along with the
+// rows of its cascades that aren't deleted yet. Deleting a row that is already deleted does
+// nothing.
+func (ss *SqlStore) softDelete(table, id string, deleteAt int64) error {
+	transaction, err := ss.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	if err := ss.softDeleteT(transaction, table, id, deleteAt); err != nil {
+		return err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (ss *SqlStore) softDeleteT(transaction *sqlxTxWrapper, table, id string, deleteAt int64) error {
+	cfg, err := getSoftDeleteTable(table)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := ss.setDeleteAtT(transaction, table, "Id", id, 0, deleteAt, deleteAt)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return nil
+	}
+
+	for _, cascade := range cfg.cascades {
+		if _, err := ss.setDeleteAtT(transaction, cascade.table, cascade.column, id, 0, deleteAt, deleteAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restore restores the row of the table with the given id, along with the rows of its cascades
+// that were deleted with it. The rows of the cascades deleted before the row, e.g. the channels
+// archived before their team was deleted, stay deleted. It returns a store.ErrNotFound if the row
+// doesn't exist.
+func (ss *SqlStore) restore(table, id string) error {
+	transaction, err := ss.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	if err := ss.restoreT(transaction, table, id, model.GetMillis()); err != nil {
+		return err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (ss *SqlStore) restoreT(transaction *sqlxTxWrapper, table, id string, updateAt int64) error {
+	cfg, err := getSoftDeleteTable(table)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := ss.getQueryBuilder().
+		Select("DeleteAt").
+		From(table).
+		Where(sq.Eq{"Id": id}).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "soft_delete_tosql")
+	}
+
+	var deleteAt int64
+	if err := transaction.Get(&deleteAt, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return store.NewErrNotFound(table, id)
+		}
+		return errors.Wrapf(err, "failed to get %s with id=%s", table, id)
+	}
+	if deleteAt == 0 {
+		return nil
+	}
+
+	if _, err := ss.setDeleteAtT(transaction, table, "Id", id, deleteAt, 0, updateAt); err != nil {
+		return err
+	}
+
+	for _, cascade := range cfg.cascades {
+		if _, err := ss.setDeleteAtT(transaction, cascade.table, cascade.column, id, deleteAt, 0, updateAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setDeleteAtT sets DeleteAt to the given value for the rows of the table matching the column
+// whose DeleteAt is currently from, returning how many were updated.
+func (ss *SqlStore) setDeleteAtT(transaction *sqlxTxWrapper, table, column, value string, from, deleteAt, updateAt int64) (int64, error) {
+	cfg, err := getSoftDeleteTable(table)
+	if err != nil {
+		return 0, err
+	}
+
+	set := sq.Eq{"DeleteAt": deleteAt}
+	if cfg.hasUpdateAt {
+		set["UpdateAt"] = updateAt
+	}
+
+	query, args, err := ss.getQueryBuilder().
+		Update(table).
+		SetMap(set).
+		Where(sq.Eq{column: value, "DeleteAt": from}).ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "soft_delete_tosql")
+	}
+
+	result, err := transaction.Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to set DeleteAt of %s with %s=%s", table, column, value)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected, nil
+}
+
+// getSoftDeletedIds returns the ids of up to limit rows of the table deleted before the given
+// time, the first deleted first.
+func (ss *SqlStore) getSoftDeletedIds(table string, before int64, limit int) ([]string, error) {
+	if _, err := getSoftDeleteTable(table); err != nil {
+		return nil, err
+	}
+
+	query, args, err := ss.getQueryBuilder().
+		Select("Id").
+		From(table).
+		Where(sq.And{
+			sq.Gt{"DeleteAt": 0},
+			sq.Lt{"DeleteAt": before},
+		}).
+		OrderBy("DeleteAt", "Id").
+		Limit(uint64(limit)).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "soft_delete_tosql")
+	}
+
+	ids := []string{}
+	if err := ss.GetReplicaX().Select(&ids, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get deleted %s", table)
+	}
+
+	return ids, nil
+}
+
+// permanentDeleteSoftDeletedBatch permanently deletes up to limit rows of the table deleted before
+// the given time, returning how many were deleted. It is only meant for tables whose rows nothing
+// else depends on; the others are purged along with their dependents by the app.
+func (ss *SqlStore) permanentDeleteSoftDeletedBatch(table string, before, limit int64) (int64, error) {
+	if _, err := getSoftDeleteTable(table); err != nil {
+		return 0, err
+	}
+
+	var query string
+	if ss.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM " + table + " WHERE Id = any (array (SELECT Id FROM " + table + " WHERE DeleteAt > 0 AND DeleteAt < ? LIMIT ?))"
+	} else {
+		query = "DELETE FROM " + table + " WHERE DeleteAt > 0 AND DeleteAt < ? LIMIT ?"
+	}
+
+	result, err := ss.GetMasterX().Exec(query, before, limit)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete deleted %s in batch", table)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected, nil
+}
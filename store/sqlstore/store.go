@@ -69,43 +69,65 @@ const (
 var tablesToCheckForCollation = []string{"incomingwebhooks", "preferences", "users", "uploadsessions", "channels", "publicchannels"}
 
 type SqlStoreStores struct {
-	team                 store.TeamStore
-	channel              store.ChannelStore
-	post                 store.PostStore
-	retentionPolicy      store.RetentionPolicyStore
-	thread               store.ThreadStore
-	user                 store.UserStore
-	bot                  store.BotStore
-	audit                store.AuditStore
-	cluster              store.ClusterDiscoveryStore
-	remoteCluster        store.RemoteClusterStore
-	compliance           store.ComplianceStore
-	session              store.SessionStore
-	oauth                store.OAuthStore
-	system               store.SystemStore
-	webhook              store.WebhookStore
-	command              store.CommandStore
-	commandWebhook       store.CommandWebhookStore
-	preference           store.PreferenceStore
-	license              store.LicenseStore
-	token                store.TokenStore
-	emoji                store.EmojiStore
-	status               store.StatusStore
-	fileInfo             store.FileInfoStore
-	uploadSession        store.UploadSessionStore
-	reaction             store.ReactionStore
-	job                  store.JobStore
-	userAccessToken      store.UserAccessTokenStore
-	plugin               store.PluginStore
-	channelMemberHistory store.ChannelMemberHistoryStore
-	role                 store.RoleStore
-	scheme               store.SchemeStore
-	TermsOfService       store.TermsOfServiceStore
-	productNotices       store.ProductNoticesStore
-	group                store.GroupStore
-	UserTermsOfService   store.UserTermsOfServiceStore
-	linkMetadata         store.LinkMetadataStore
-	sharedchannel        store.SharedChannelStore
+	team                    store.TeamStore
+	channel                 store.ChannelStore
+	post                    store.PostStore
+	retentionPolicy         store.RetentionPolicyStore
+	thread                  store.ThreadStore
+	user                    store.UserStore
+	bot                     store.BotStore
+	audit                   store.AuditStore
+	cluster                 store.ClusterDiscoveryStore
+	remoteCluster           store.RemoteClusterStore
+	compliance              store.ComplianceStore
+	session                 store.SessionStore
+	oauth                   store.OAuthStore
+	system                  store.SystemStore
+	webhook                 store.WebhookStore
+	command                 store.CommandStore
+	commandWebhook          store.CommandWebhookStore
+	preference              store.PreferenceStore
+	license                 store.LicenseStore
+	token                   store.TokenStore
+	emoji                   store.EmojiStore
+	status                  store.StatusStore
+	fileInfo                store.FileInfoStore
+	uploadSession           store.UploadSessionStore
+	reaction                store.ReactionStore
+	job                     store.JobStore
+	userAccessToken         store.UserAccessTokenStore
+	plugin                  store.PluginStore
+	channelMemberHistory    store.ChannelMemberHistoryStore
+	role                    store.RoleStore
+	scheme                  store.SchemeStore
+	TermsOfService          store.TermsOfServiceStore
+	productNotices          store.ProductNoticesStore
+	group                   store.GroupStore
+	UserTermsOfService      store.UserTermsOfServiceStore
+	linkMetadata            store.LinkMetadataStore
+	sharedchannel           store.SharedChannelStore
+	pushReceipt             store.PushNotificationReceiptStore
+	teamTemplate            store.TeamTemplateStore
+	onboardingTask          store.OnboardingTaskStore
+	connectivityTest        store.ConnectivityTestResultStore
+	tablePartition          store.TablePartitionStore
+	postArchive             store.PostArchiveStore
+	persistentWSEvent       store.PersistentWebSocketEventStore
+	channelMemberTimeout    store.ChannelMemberTimeoutStore
+	postReport              store.PostReportStore
+	userDevice              store.UserDeviceStore
+	mfaBackupCode           store.MfaBackupCodeStore
+	postRetentionLabel      store.PostRetentionLabelStore
+	directMessageRequest    store.DirectMessageRequestStore
+	reminder                store.ReminderStore
+	channelBookmark         store.ChannelBookmarkStore
+	postPropSchema          store.PostPropSchemaStore
+	channelEvent            store.ChannelEventStore
+	scheduledConfigChange   store.ScheduledConfigChangeStore
+	changeEvent             store.ChangeEventStore
+	outgoingOAuthConnection store.OutgoingOAuthConnectionStore
+	channelJoinRequest      store.ChannelJoinRequestStore
+	teamInviteLink          store.TeamInviteLinkStore
 }
 
 type SqlStore struct {
@@ -204,6 +226,28 @@ func New(settings model.SqlSettings, metrics einterfaces.MetricsInterface) *SqlS
 	store.stores.scheme = newSqlSchemeStore(store)
 	store.stores.group = newSqlGroupStore(store)
 	store.stores.productNotices = newSqlProductNoticesStore(store)
+	store.stores.pushReceipt = newSqlPushNotificationReceiptStore(store)
+	store.stores.teamTemplate = newSqlTeamTemplateStore(store)
+	store.stores.onboardingTask = newSqlOnboardingTaskStore(store)
+	store.stores.connectivityTest = newSqlConnectivityTestResultStore(store)
+	store.stores.tablePartition = newSqlTablePartitionStore(store)
+	store.stores.postArchive = newSqlPostArchiveStore(store)
+	store.stores.persistentWSEvent = newSqlPersistentWebSocketEventStore(store)
+	store.stores.channelMemberTimeout = newSqlChannelMemberTimeoutStore(store)
+	store.stores.postReport = newSqlPostReportStore(store)
+	store.stores.userDevice = newSqlUserDeviceStore(store)
+	store.stores.mfaBackupCode = newSqlMfaBackupCodeStore(store)
+	store.stores.postRetentionLabel = newSqlPostRetentionLabelStore(store)
+	store.stores.directMessageRequest = newSqlDirectMessageRequestStore(store)
+	store.stores.reminder = newSqlReminderStore(store)
+	store.stores.channelBookmark = newSqlChannelBookmarkStore(store)
+	store.stores.postPropSchema = newSqlPostPropSchemaStore(store)
+	store.stores.channelEvent = newSqlChannelEventStore(store)
+	store.stores.scheduledConfigChange = newSqlScheduledConfigChangeStore(store)
+	store.stores.changeEvent = newSqlChangeEventStore(store)
+	store.stores.outgoingOAuthConnection = newSqlOutgoingOAuthConnectionStore(store)
+	store.stores.channelJoinRequest = newSqlChannelJoinRequestStore(store)
+	store.stores.teamInviteLink = newSqlTeamInviteLinkStore(store)
 
 	err = upgradeDatabase(store, model.CurrentVersion)
 	if err != nil {
@@ -898,6 +942,94 @@ func (ss *SqlStore) SharedChannel() store.SharedChannelStore {
 	return ss.stores.sharedchannel
 }
 
+func (ss *SqlStore) PushNotificationReceipt() store.PushNotificationReceiptStore {
+	return ss.stores.pushReceipt
+}
+
+func (ss *SqlStore) TeamTemplate() store.TeamTemplateStore {
+	return ss.stores.teamTemplate
+}
+
+func (ss *SqlStore) OnboardingTask() store.OnboardingTaskStore {
+	return ss.stores.onboardingTask
+}
+
+func (ss *SqlStore) ConnectivityTestResult() store.ConnectivityTestResultStore {
+	return ss.stores.connectivityTest
+}
+
+func (ss *SqlStore) TablePartition() store.TablePartitionStore {
+	return ss.stores.tablePartition
+}
+
+func (ss *SqlStore) PostArchive() store.PostArchiveStore {
+	return ss.stores.postArchive
+}
+
+func (ss *SqlStore) PersistentWebSocketEvent() store.PersistentWebSocketEventStore {
+	return ss.stores.persistentWSEvent
+}
+
+func (ss *SqlStore) ChannelMemberTimeout() store.ChannelMemberTimeoutStore {
+	return ss.stores.channelMemberTimeout
+}
+
+func (ss *SqlStore) PostReport() store.PostReportStore {
+	return ss.stores.postReport
+}
+
+func (ss *SqlStore) UserDevice() store.UserDeviceStore {
+	return ss.stores.userDevice
+}
+
+func (ss *SqlStore) MfaBackupCode() store.MfaBackupCodeStore {
+	return ss.stores.mfaBackupCode
+}
+
+func (ss *SqlStore) PostRetentionLabel() store.PostRetentionLabelStore {
+	return ss.stores.postRetentionLabel
+}
+
+func (ss *SqlStore) DirectMessageRequest() store.DirectMessageRequestStore {
+	return ss.stores.directMessageRequest
+}
+
+func (ss *SqlStore) Reminder() store.ReminderStore {
+	return ss.stores.reminder
+}
+
+func (ss *SqlStore) ChannelBookmark() store.ChannelBookmarkStore {
+	return ss.stores.channelBookmark
+}
+
+func (ss *SqlStore) PostPropSchema() store.PostPropSchemaStore {
+	return ss.stores.postPropSchema
+}
+
+func (ss *SqlStore) ChannelEvent() store.ChannelEventStore {
+	return ss.stores.channelEvent
+}
+
+func (ss *SqlStore) ScheduledConfigChange() store.ScheduledConfigChangeStore {
+	return ss.stores.scheduledConfigChange
+}
+
+func (ss *SqlStore) ChangeEvent() store.ChangeEventStore {
+	return ss.stores.changeEvent
+}
+
+func (ss *SqlStore) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	return ss.stores.outgoingOAuthConnection
+}
+
+func (ss *SqlStore) ChannelJoinRequest() store.ChannelJoinRequestStore {
+	return ss.stores.channelJoinRequest
+}
+
+func (ss *SqlStore) TeamInviteLink() store.TeamInviteLinkStore {
+	return ss.stores.teamInviteLink
+}
+
 func (ss *SqlStore) DropAllTables() {
 	if ss.DriverName() == model.DatabaseDriverPostgres {
 		ss.masterX.Exec(`DO
@@ -1191,3 +1323,82 @@ func (ss *SqlStore) GetAppliedMigrations() ([]model.AppliedMigration, error) {
 
 	return migrations, nil
 }
+
+// GetTableRowCounts returns the row count of every table in the current schema. The counts
+// are the estimates kept by the database statistics, so they are cheap to fetch but may lag
+// behind the real values.
+func (ss *SqlStore) GetTableRowCounts() ([]*model.TableRowCount, error) {
+	var query string
+	switch ss.DriverName() {
+	case model.DatabaseDriverPostgres:
+		query = `SELECT relname AS Name, n_live_tup AS RowCount
+			FROM pg_stat_user_tables
+			WHERE schemaname = current_schema()
+			ORDER BY relname`
+	case model.DatabaseDriverMysql:
+		query = `SELECT TABLE_NAME AS Name, COALESCE(TABLE_ROWS, 0) AS RowCount
+			FROM information_schema.TABLES
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'
+			ORDER BY TABLE_NAME`
+	default:
+		return nil, errors.New("Not supported driver")
+	}
+
+	counts := []*model.TableRowCount{}
+	if err := ss.GetReplicaX().Select(&counts, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get table row counts")
+	}
+
+	return counts, nil
+}
+
+// GetSlowQueries returns the queries with the highest mean execution time. It relies on
+// pg_stat_statements for Postgres and on the performance schema for MySQL, and returns an
+// error if those are not available.
+func (ss *SqlStore) GetSlowQueries(limit int) ([]*model.SlowQuery, error) {
+	var query string
+	switch ss.DriverName() {
+	case model.DatabaseDriverPostgres:
+		var count int
+		if err := ss.GetReplicaX().Get(&count, "SELECT COUNT(*) FROM pg_extension WHERE extname = 'pg_stat_statements'"); err != nil {
+			return nil, errors.Wrap(err, "failed to check for pg_stat_statements")
+		}
+		if count == 0 {
+			return nil, errors.New("pg_stat_statements extension is not installed")
+		}
+
+		// The timing columns were renamed in Postgres 13.
+		timeColumn := "mean_exec_time"
+		totalColumn := "total_exec_time"
+		version, err := ss.GetDbVersion(true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get database version")
+		}
+		if intVer, err := strconv.Atoi(version); err == nil && intVer < 130000 {
+			timeColumn = "mean_time"
+			totalColumn = "total_time"
+		}
+
+		query = `SELECT query AS Query, calls AS Calls, ` + timeColumn + ` AS MeanTimeMs, ` + totalColumn + ` AS TotalTimeMs
+			FROM pg_stat_statements
+			WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+			ORDER BY ` + timeColumn + ` DESC
+			LIMIT $1`
+	case model.DatabaseDriverMysql:
+		// Timer columns are in picoseconds.
+		query = `SELECT DIGEST_TEXT AS Query, COUNT_STAR AS Calls, AVG_TIMER_WAIT / 1000000000 AS MeanTimeMs, SUM_TIMER_WAIT / 1000000000 AS TotalTimeMs
+			FROM performance_schema.events_statements_summary_by_digest
+			WHERE SCHEMA_NAME = DATABASE() AND DIGEST_TEXT IS NOT NULL
+			ORDER BY AVG_TIMER_WAIT DESC
+			LIMIT ?`
+	default:
+		return nil, errors.New("Not supported driver")
+	}
+
+	queries := []*model.SlowQuery{}
+	if err := ss.GetReplicaX().Select(&queries, query, limit); err != nil {
+		return nil, errors.Wrap(err, "failed to get slow queries")
+	}
+
+	return queries, nil
+}
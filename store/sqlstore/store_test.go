@@ -878,3 +878,29 @@ func TestGetAppliedMigrations(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTableRowCounts(t *testing.T) {
+	testDrivers := []string{
+		model.DatabaseDriverPostgres,
+		model.DatabaseDriverMysql,
+	}
+
+	for _, driver := range testDrivers {
+		t.Run("Should return table row counts for "+driver, func(t *testing.T) {
+			t.Parallel()
+			settings := makeSqlSettings(driver)
+			store := New(*settings, nil)
+
+			counts, err := store.GetTableRowCounts()
+			require.NoError(t, err)
+
+			var names []string
+			for _, count := range counts {
+				names = append(names, strings.ToLower(count.Name))
+				require.GreaterOrEqual(t, count.RowCount, int64(0))
+			}
+			require.Contains(t, names, "users")
+			require.Contains(t, names, "posts")
+		})
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+// postgresIdentifierMaxLength is the length PostgreSQL truncates identifiers to.
+const postgresIdentifierMaxLength = 63
+
+// The table names are part of the statements, so they are restricted to plain identifiers.
+var tablePartitionTableRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// partitionUpperBoundRegexp matches the upper bound of the partition bounds written by
+// PostgreSQL, e.g. FOR VALUES FROM (MINVALUE) TO ('1646092800000').
+var partitionUpperBoundRegexp = regexp.MustCompile(`TO \('?(-?\d+)'?\)$`)
+
+type SqlTablePartitionStore struct {
+	*SqlStore
+}
+
+func newSqlTablePartitionStore(sqlStore *SqlStore) store.TablePartitionStore {
+	return &SqlTablePartitionStore{sqlStore}
+}
+
+type tableIndex struct {
+	IndexName string
+	IndexDef  string
+}
+
+// checkTable returns the name PostgreSQL knows table under, or an error if table can't be partitioned.
+func (s SqlTablePartitionStore) checkTable(table string) (string, error) {
+	if s.DriverName() != model.DatabaseDriverPostgres {
+		return "", store.NewErrNotImplemented("table partitioning is only supported on PostgreSQL")
+	}
+
+	name := strings.ToLower(table)
+	if !tablePartitionTableRegexp.MatchString(name) {
+		return "", store.NewErrInvalidInput("TablePartition", "table", table)
+	}
+
+	return name, nil
+}
+
+func (s SqlTablePartitionStore) IsPartitioned(table string) (bool, error) {
+	name, err := s.checkTable(table)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	query := `
+		SELECT COUNT(*)
+		FROM pg_partitioned_table
+		JOIN pg_class ON pg_class.oid = pg_partitioned_table.partrelid
+		WHERE pg_class.relname = ? AND pg_table_is_visible(pg_class.oid)`
+	if err := s.GetMasterX().Get(&count, query, name); err != nil {
+		return false, errors.Wrapf(err, "failed to check whether table=%s is partitioned", table)
+	}
+
+	return count > 0, nil
+}
+
+func legacyIndexName(indexName string) string {
+	maxLength := postgresIdentifierMaxLength - len(model.TablePartitionLegacySuffix)
+	if len(indexName) > maxLength {
+		indexName = indexName[:maxLength]
+	}
+	return indexName + model.TablePartitionLegacySuffix
+}
+
+func (s SqlTablePartitionStore) Partition(table string, boundary int64) error {
+	name, err := s.checkTable(table)
+	if err != nil {
+		return err
+	}
+
+	legacy := name + model.TablePartitionLegacySuffix
+	primaryKey := name + "_pkey"
+	boundsCheck := name + "_partition_check"
+	partitionKey := name + "_id_createat_key"
+
+	// A previous attempt may have left the index behind, possibly invalid.
+	if _, err := s.GetMasterX().ExecNoTimeout(fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s", partitionKey)); err != nil {
+		return errors.Wrapf(err, "failed to partition table=%s", table)
+	}
+
+	// The index definitions are read before the table is renamed so that they can be replayed
+	// as they are on the partitioned table.
+	indexes := []tableIndex{}
+	query := `
+		SELECT indexname AS IndexName, indexdef AS IndexDef
+		FROM pg_indexes
+		WHERE tablename = ? AND schemaname = current_schema() AND indexname <> ?`
+	if err := s.GetMasterX().Select(&indexes, query, name, primaryKey); err != nil {
+		return errors.Wrapf(err, "failed to get the indexes of table=%s", table)
+	}
+
+	// The rows are checked against the bounds of the legacy partition, and indexed by the primary
+	// key of the partitioned table, while the table can still be read and written, so that
+	// attaching the legacy partition doesn't have to scan it under an exclusive lock. The check
+	// also applies to the rows written meanwhile, which is why boundary has to be ahead of now.
+	prepare := []string{
+		fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s", name, boundsCheck),
+		fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (createat IS NOT NULL AND createat < %d) NOT VALID", name, boundsCheck, boundary),
+		fmt.Sprintf("ALTER TABLE %s VALIDATE CONSTRAINT %s", name, boundsCheck),
+		fmt.Sprintf("CREATE UNIQUE INDEX CONCURRENTLY %s ON %s (id, createat)", partitionKey, name),
+	}
+	for _, statement := range prepare {
+		if _, err := s.GetMasterX().ExecNoTimeout(statement); err != nil {
+			return errors.Wrapf(err, "failed to partition table=%s", table)
+		}
+	}
+
+	statements := []string{
+		// The primary key of the partitioned table is only matched by a constraint of the legacy
+		// partition.
+		fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s UNIQUE USING INDEX %s", name, partitionKey, partitionKey),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", name, legacy),
+		fmt.Sprintf("ALTER TABLE %s RENAME CONSTRAINT %s TO %s", legacy, primaryKey, legacy+"_pkey"),
+	}
+	for _, index := range indexes {
+		statements = append(statements, fmt.Sprintf("ALTER INDEX %s RENAME TO %s", index.IndexName, legacyIndexName(index.IndexName)))
+	}
+	statements = append(statements,
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS) PARTITION BY RANGE (createat)", name, legacy),
+		// The primary key of a partitioned table has to include the partition key.
+		fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (id, createat)", name, primaryKey),
+	)
+	for _, index := range indexes {
+		statements = append(statements, index.IndexDef)
+	}
+	statements = append(statements,
+		// Neither of these scans the legacy partition, whose rows are known to be within its
+		// bounds from the validated check.
+		fmt.Sprintf("ALTER TABLE %s ALTER COLUMN createat SET NOT NULL", legacy),
+		fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM (MINVALUE) TO (%d)", name, legacy, boundary),
+		fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", legacy, boundsCheck),
+		fmt.Sprintf("CREATE TABLE %s PARTITION OF %s DEFAULT", name+model.TablePartitionDefaultSuffix, name),
+	)
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	for _, statement := range statements {
+		if _, err := transaction.ExecNoTimeout(statement); err != nil {
+			return errors.Wrapf(err, "failed to partition table=%s", table)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+// legacyPartitionEnd returns the upper bound of the legacy partition of the table, or 0 if the
+// table has none.
+func (s SqlTablePartitionStore) legacyPartitionEnd(name string) (int64, error) {
+	bounds := []string{}
+	query := `
+		SELECT pg_get_expr(relpartbound, oid)
+		FROM pg_class
+		WHERE relname = ? AND relispartition AND pg_table_is_visible(oid)`
+	if err := s.GetMasterX().Select(&bounds, query, name+model.TablePartitionLegacySuffix); err != nil {
+		return 0, errors.Wrapf(err, "failed to get the bounds of the legacy partition of table=%s", name)
+	}
+	if len(bounds) == 0 {
+		return 0, nil
+	}
+
+	match := partitionUpperBoundRegexp.FindStringSubmatch(bounds[0])
+	if match == nil {
+		return 0, errors.Errorf("unexpected bounds %q of the legacy partition of table=%s", bounds[0], name)
+	}
+	return strconv.ParseInt(match[1], 10, 64)
+}
+
+func (s SqlTablePartitionStore) CreatePartition(partition *model.TablePartition) error {
+	name, err := s.checkTable(partition.Table)
+	if err != nil {
+		return err
+	}
+
+	if model.ParseMonthlyTablePartition(partition.Table, partition.Name) == nil {
+		return store.NewErrInvalidInput("TablePartition", "name", partition.Name)
+	}
+
+	// The legacy partition may extend into the months following the conversion, whose rows it
+	// keeps holding.
+	legacyEnd, err := s.legacyPartitionEnd(name)
+	if err != nil {
+		return err
+	}
+	if partition.Start < legacyEnd {
+		return nil
+	}
+
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM (%d) TO (%d)", partition.Name, name, partition.Start, partition.End)
+	if _, err := s.GetMasterX().ExecNoTimeout(query); err != nil {
+		return errors.Wrapf(err, "failed to create partition=%s", partition.Name)
+	}
+
+	return nil
+}
+
+func (s SqlTablePartitionStore) GetPartitions(table string) ([]*model.TablePartition, error) {
+	name, err := s.checkTable(table)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	query := `
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE parent.relname = ? AND pg_table_is_visible(parent.oid)`
+	if err := s.GetMasterX().Select(&names, query, name); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the partitions of table=%s", table)
+	}
+
+	partitions := []*model.TablePartition{}
+	for _, partitionName := range names {
+		if partition := model.ParseMonthlyTablePartition(table, partitionName); partition != nil {
+			partitions = append(partitions, partition)
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].Start < partitions[j].Start
+	})
+
+	return partitions, nil
+}
+
+// partitionDependentTables are the tables whose rows belong to the rows of a partitioned table,
+// by its id, and are deleted along with its partitions.
+var partitionDependentTables = map[string][]string{
+	"posts": {"Reactions", "FileInfo", "Threads", "ThreadMemberships", "PostRetentionLabels"},
+}
+
+func (s SqlTablePartitionStore) HasRetainedRows(partition *model.TablePartition, now, globalPolicyEndTime int64) (bool, error) {
+	if _, err := s.checkTable(partition.Table); err != nil {
+		return false, err
+	}
+
+	if model.ParseMonthlyTablePartition(partition.Table, partition.Name) == nil {
+		return false, store.NewErrInvalidInput("TablePartition", "name", partition.Name)
+	}
+
+	// Mirrors the deletion of the data retention job: a labeled post is kept until its label
+	// expires, and the other posts are kept by the channel policy of their channel, or else by
+	// the team policy of their team, or else by the global policy.
+	const millisecondsInADay = 24 * 60 * 60 * 1000
+	keptByPolicy := func(policy string) string {
+		return fmt.Sprintf("(%[1]s.PostDuration < 0 OR %[2]d - p.CreateAt <= %[1]s.PostDuration * %[3]d)", policy, now, millisecondsInADay)
+	}
+	keptByGlobalPolicy := "FALSE"
+	if globalPolicyEndTime > 0 {
+		keptByGlobalPolicy = fmt.Sprintf("p.CreateAt >= %d", globalPolicyEndTime)
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM ` + partition.Name + ` p
+			LEFT JOIN PostRetentionLabels l ON l.PostId = p.Id
+			LEFT JOIN Channels c ON c.Id = p.ChannelId
+			LEFT JOIN RetentionPoliciesChannels rpc ON rpc.ChannelId = p.ChannelId
+			LEFT JOIN RetentionPolicies cp ON cp.Id = rpc.PolicyId
+			LEFT JOIN RetentionPoliciesTeams rpt ON rpt.TeamId = c.TeamId
+			LEFT JOIN RetentionPolicies tp ON tp.Id = rpt.PolicyId
+			WHERE (l.PostId IS NOT NULL AND l.ExpireAt > ?)
+				OR (l.PostId IS NULL AND cp.Id IS NOT NULL AND ` + keptByPolicy("cp") + `)
+				OR (l.PostId IS NULL AND cp.Id IS NULL AND tp.Id IS NOT NULL AND ` + keptByPolicy("tp") + `)
+				OR (l.PostId IS NULL AND cp.Id IS NULL AND tp.Id IS NULL AND ` + keptByGlobalPolicy + `)
+		)`
+	var retained bool
+	if err := s.GetMasterX().Get(&retained, query, now); err != nil {
+		return false, errors.Wrapf(err, "failed to check the retained rows of partition=%s", partition.Name)
+	}
+
+	return retained, nil
+}
+
+func (s SqlTablePartitionStore) DropPartition(partition *model.TablePartition) error {
+	name, err := s.checkTable(partition.Table)
+	if err != nil {
+		return err
+	}
+
+	// Only monthly partitions are dropped, never the legacy or default ones.
+	if model.ParseMonthlyTablePartition(partition.Table, partition.Name) == nil {
+		return store.NewErrInvalidInput("TablePartition", "name", partition.Name)
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	for _, dependent := range partitionDependentTables[name] {
+		query := fmt.Sprintf("DELETE FROM %s WHERE PostId IN (SELECT Id FROM %s)", dependent, partition.Name)
+		if _, err := transaction.ExecNoTimeout(query); err != nil {
+			return errors.Wrapf(err, "failed to delete the %s of partition=%s", dependent, partition.Name)
+		}
+	}
+
+	if _, err := transaction.ExecNoTimeout(fmt.Sprintf("DROP TABLE IF EXISTS %s", partition.Name)); err != nil {
+		return errors.Wrapf(err, "failed to drop partition=%s", partition.Name)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var teamInviteLinkColumns = []string{"Id", "TeamId", "CreatorId", "Name", "MaxUses", "UseCount", "RejectedCount", "LastUsedAt", "ExpireAt", "AllowedDomains", "CreateAt", "UpdateAt", "DeleteAt"}
+
+type SqlTeamInviteLinkStore struct {
+	*SqlStore
+}
+
+func newSqlTeamInviteLinkStore(sqlStore *SqlStore) store.TeamInviteLinkStore {
+	return &SqlTeamInviteLinkStore{sqlStore}
+}
+
+func (s SqlTeamInviteLinkStore) Save(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	link.PreSave()
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("TeamInviteLinks").
+		Columns(teamInviteLinkColumns...).
+		Values(link.Id, link.TeamId, link.CreatorId, link.Name, link.MaxUses, link.UseCount, link.RejectedCount, link.LastUsedAt, link.ExpireAt, link.AllowedDomains, link.CreateAt, link.UpdateAt, link.DeleteAt).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_invite_link_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save TeamInviteLink with id=%s", link.Id)
+	}
+
+	return link, nil
+}
+
+// Update leaves the counters of the link alone, they are only changed by Use, Release and Reject.
+func (s SqlTeamInviteLinkStore) Update(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
+	link.PreUpdate()
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("TeamInviteLinks").
+		SetMap(map[string]interface{}{
+			"Name":           link.Name,
+			"MaxUses":        link.MaxUses,
+			"ExpireAt":       link.ExpireAt,
+			"AllowedDomains": link.AllowedDomains,
+			"UpdateAt":       link.UpdateAt,
+			"DeleteAt":       link.DeleteAt,
+		}).
+		Where(sq.Eq{"Id": link.Id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_invite_link_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to update TeamInviteLink with id=%s", link.Id)
+	}
+
+	return link, nil
+}
+
+func (s SqlTeamInviteLinkStore) Get(id string) (*model.TeamInviteLink, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(teamInviteLinkColumns...).
+		From("TeamInviteLinks").
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_invite_link_tosql")
+	}
+
+	var link model.TeamInviteLink
+	if err := s.GetMasterX().Get(&link, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("TeamInviteLink", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get TeamInviteLink with id=%s", id)
+	}
+
+	return &link, nil
+}
+
+func (s SqlTeamInviteLinkStore) GetForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error) {
+	builder := s.getQueryBuilder().
+		Select(teamInviteLinkColumns...).
+		From("TeamInviteLinks").
+		Where(sq.Eq{"TeamId": teamID}).
+		OrderBy("CreateAt DESC", "Id DESC")
+	if !includeDeleted {
+		builder = builder.Where(sq.Eq{"DeleteAt": 0})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_invite_link_tosql")
+	}
+
+	links := []*model.TeamInviteLink{}
+	if err := s.GetReplicaX().Select(&links, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find TeamInviteLinks with teamId=%s", teamID)
+	}
+
+	return links, nil
+}
+
+func (s SqlTeamInviteLinkStore) Use(id string, now int64) error {
+	query, args, err := s.getQueryBuilder().
+		Update("TeamInviteLinks").
+		Set("UseCount", sq.Expr("UseCount + 1")).
+		Set("LastUsedAt", now).
+		Where(sq.And{
+			sq.Eq{"Id": id},
+			sq.Eq{"DeleteAt": 0},
+			sq.Or{sq.Eq{"MaxUses": 0}, sq.Expr("UseCount < MaxUses")},
+			sq.Or{sq.Eq{"ExpireAt": 0}, sq.Gt{"ExpireAt": now}},
+		}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "team_invite_link_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to use TeamInviteLink with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("TeamInviteLink", id)
+	}
+
+	return nil
+}
+
+func (s SqlTeamInviteLinkStore) Release(id string) error {
+	return s.incrementCounter(id, "UseCount", -1)
+}
+
+func (s SqlTeamInviteLinkStore) Reject(id string) error {
+	return s.incrementCounter(id, "RejectedCount", 1)
+}
+
+func (s SqlTeamInviteLinkStore) incrementCounter(id, column string, delta int) error {
+	query, args, err := s.getQueryBuilder().
+		Update("TeamInviteLinks").
+		Set(column, sq.Expr(column+" + ?", delta)).
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "team_invite_link_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to update %s of TeamInviteLink with id=%s", column, id)
+	}
+
+	return nil
+}
@@ -227,10 +227,10 @@ func (s SqlTeamStore) Save(team *model.Team) (*model.Team, error) {
 
 	if _, err := s.GetMasterX().NamedExec(`INSERT INTO Teams
 		(Id, CreateAt, UpdateAt, DeleteAt, DisplayName, Name, Description, Email, Type, CompanyName, AllowedDomains,
-		InviteId, AllowOpenInvite, LastTeamIconUpdate, SchemeId, GroupConstrained, CloudLimitsArchived)
+		InviteId, AllowOpenInvite, LastTeamIconUpdate, SchemeId, GroupConstrained, CloudLimitsArchived, DisabledFeatures)
 		VALUES
 		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :DisplayName, :Name, :Description, :Email, :Type, :CompanyName, :AllowedDomains,
-		:InviteId, :AllowOpenInvite, :LastTeamIconUpdate, :SchemeId, :GroupConstrained, :CloudLimitsArchived)`, team); err != nil {
+		:InviteId, :AllowOpenInvite, :LastTeamIconUpdate, :SchemeId, :GroupConstrained, :CloudLimitsArchived, :DisabledFeatures)`, team); err != nil {
 		if IsUniqueConstraintError(err, []string{"Name", "teams_name_key"}) {
 			return nil, store.NewErrInvalidInput("Team", "id", team.Id)
 		}
@@ -268,7 +268,8 @@ func (s SqlTeamStore) Update(team *model.Team) (*model.Team, error) {
 			SET CreateAt=:CreateAt, UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, DisplayName=:DisplayName, Name=:Name,
 				Description=:Description, Email=:Email, Type=:Type, CompanyName=:CompanyName, AllowedDomains=:AllowedDomains,
 				InviteId=:InviteId, AllowOpenInvite=:AllowOpenInvite, LastTeamIconUpdate=:LastTeamIconUpdate,
-				SchemeId=:SchemeId, GroupConstrained=:GroupConstrained, CloudLimitsArchived=:CloudLimitsArchived
+				SchemeId=:SchemeId, GroupConstrained=:GroupConstrained, CloudLimitsArchived=:CloudLimitsArchived,
+				DisabledFeatures=:DisabledFeatures
 			WHERE Id=:Id`, team)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to update Team with id=%s", team.Id)
@@ -656,7 +657,7 @@ func (s SqlTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 }
 
 // PermanentDelete permanently deletes from the database the team entry that matches the teamId passed as parameter.
-// To soft-delete the team you can Update it with the DeleteAt field set to the current millisecond using model.GetMillis()
+// To soft-delete the team use SoftDelete.
 func (s SqlTeamStore) PermanentDelete(teamId string) error {
 	sql, args, err := s.getQueryBuilder().
 		Delete("Teams").
@@ -670,6 +671,18 @@ func (s SqlTeamStore) PermanentDelete(teamId string) error {
 	return nil
 }
 
+func (s SqlTeamStore) SoftDelete(teamId string, deleteAt int64) error {
+	return s.softDelete("Teams", teamId, deleteAt)
+}
+
+func (s SqlTeamStore) Restore(teamId string) error {
+	return s.restore("Teams", teamId)
+}
+
+func (s SqlTeamStore) GetSoftDeletedIds(before int64, limit int) ([]string, error) {
+	return s.getSoftDeletedIds("Teams", before, limit)
+}
+
 // AnalyticsTeamCount returns the total number of teams.
 func (s SqlTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
 	query := s.getQueryBuilder().Select("COUNT(*) FROM Teams")
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+type SqlTeamTemplateStore struct {
+	*SqlStore
+}
+
+// teamTemplateRow is a team template as stored in the database, with its definition encoded as JSON.
+type teamTemplateRow struct {
+	Id          string
+	DisplayName string
+	Description string
+	CreatorId   string
+	CreateAt    int64
+	UpdateAt    int64
+	DeleteAt    int64
+	Definition  string
+}
+
+func (r *teamTemplateRow) toModel() (*model.TeamTemplate, error) {
+	template := &model.TeamTemplate{
+		Id:          r.Id,
+		DisplayName: r.DisplayName,
+		Description: r.Description,
+		CreatorId:   r.CreatorId,
+		CreateAt:    r.CreateAt,
+		UpdateAt:    r.UpdateAt,
+		DeleteAt:    r.DeleteAt,
+	}
+
+	if err := json.Unmarshal([]byte(r.Definition), &template.Definition); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode definition of TeamTemplate with id=%s", r.Id)
+	}
+
+	return template, nil
+}
+
+func newSqlTeamTemplateStore(sqlStore *SqlStore) store.TeamTemplateStore {
+	return &SqlTeamTemplateStore{sqlStore}
+}
+
+func (s SqlTeamTemplateStore) teamTemplateSelectQuery() sq.SelectBuilder {
+	return s.getQueryBuilder().
+		Select("Id", "DisplayName", "Description", "CreatorId", "CreateAt", "UpdateAt", "DeleteAt", "Definition").
+		From("TeamTemplates")
+}
+
+func (s SqlTeamTemplateStore) Save(template *model.TeamTemplate) (*model.TeamTemplate, error) {
+	template.PreSave()
+	if err := template.IsValid(); err != nil {
+		return nil, err
+	}
+
+	definition, err := json.Marshal(template.Definition)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode team template definition")
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("TeamTemplates").
+		Columns("Id", "DisplayName", "Description", "CreatorId", "CreateAt", "UpdateAt", "DeleteAt", "Definition").
+		Values(template.Id, template.DisplayName, template.Description, template.CreatorId, template.CreateAt, template.UpdateAt, template.DeleteAt, string(definition)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_template_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to save TeamTemplate with id=%s", template.Id)
+	}
+
+	return template, nil
+}
+
+func (s SqlTeamTemplateStore) Update(template *model.TeamTemplate) (*model.TeamTemplate, error) {
+	template.PreUpdate()
+	if err := template.IsValid(); err != nil {
+		return nil, err
+	}
+
+	definition, err := json.Marshal(template.Definition)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode team template definition")
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Update("TeamTemplates").
+		Set("DisplayName", template.DisplayName).
+		Set("Description", template.Description).
+		Set("UpdateAt", template.UpdateAt).
+		Set("Definition", string(definition)).
+		Where(sq.Eq{"Id": template.Id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_template_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update TeamTemplate with id=%s", template.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("TeamTemplate", template.Id)
+	}
+
+	return template, nil
+}
+
+func (s SqlTeamTemplateStore) Get(id string) (*model.TeamTemplate, error) {
+	query, args, err := s.teamTemplateSelectQuery().
+		Where(sq.Eq{"Id": id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_template_tosql")
+	}
+
+	var row teamTemplateRow
+	if err := s.GetReplicaX().Get(&row, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("TeamTemplate", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get TeamTemplate with id=%s", id)
+	}
+
+	return row.toModel()
+}
+
+func (s SqlTeamTemplateStore) GetAll(offset, limit int) ([]*model.TeamTemplate, error) {
+	query, args, err := s.teamTemplateSelectQuery().
+		Where(sq.Eq{"DeleteAt": 0}).
+		OrderBy("DisplayName ASC", "Id ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_template_tosql")
+	}
+
+	rows := []teamTemplateRow{}
+	if err := s.GetReplicaX().Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find TeamTemplates")
+	}
+
+	templates := make([]*model.TeamTemplate, 0, len(rows))
+	for i := range rows {
+		template, err := rows[i].toModel()
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (s SqlTeamTemplateStore) Delete(id string, deleteAt int64) error {
+	query, args, err := s.getQueryBuilder().
+		Update("TeamTemplates").
+		Set("DeleteAt", deleteAt).
+		Set("UpdateAt", deleteAt).
+		Where(sq.Eq{"Id": id, "DeleteAt": 0}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "team_template_tosql")
+	}
+
+	result, err := s.GetMasterX().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete TeamTemplate with id=%s", id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("TeamTemplate", id)
+	}
+
+	return nil
+}
@@ -407,6 +407,39 @@ func (s *SqlThreadStore) GetTeamsUnreadForUser(userID string, teamIDs []string)
 	return res, nil
 }
 
+// GetUnreadThreadsForUser returns the unread state of the threads followed by the user that were
+// replied to since they last viewed them, or that they have unread mentions in.
+func (s *SqlThreadStore) GetUnreadThreadsForUser(userID string) ([]*model.ThreadUnread, error) {
+	query := s.getQueryBuilder().
+		Select(
+			"ThreadMemberships.PostId AS PostId",
+			"Threads.ChannelId AS ChannelId",
+			"Channels.TeamId AS TeamId",
+			"ThreadMemberships.UnreadMentions AS UnreadMentions",
+			"(Threads.LastReplyAt > ThreadMemberships.LastViewed) AS Unread",
+		).
+		From("ThreadMemberships").
+		Join("Threads ON Threads.PostId = ThreadMemberships.PostId").
+		Join("Channels ON Threads.ChannelId = Channels.Id").
+		Where(sq.Eq{
+			"ThreadMemberships.UserId":            userID,
+			"ThreadMemberships.Following":         true,
+			"Channels.DeleteAt":                   0,
+			"COALESCE(Threads.ThreadDeleteAt, 0)": 0,
+		}).
+		Where(sq.Or{
+			sq.Expr("Threads.LastReplyAt > ThreadMemberships.LastViewed"),
+			sq.Gt{"ThreadMemberships.UnreadMentions": 0},
+		})
+
+	unreads := []*model.ThreadUnread{}
+	if err := s.GetReplicaX().SelectBuilder(&unreads, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get unread Threads with userId=%s", userID)
+	}
+
+	return unreads, nil
+}
+
 func (s *SqlThreadStore) GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error) {
 	users := []string{}
 
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+var userDeviceColumns = []string{"UserId", "Fingerprint", "Country", "CreateAt", "LastSeenAt"}
+
+type SqlUserDeviceStore struct {
+	*SqlStore
+}
+
+func newSqlUserDeviceStore(sqlStore *SqlStore) store.UserDeviceStore {
+	return &SqlUserDeviceStore{sqlStore}
+}
+
+func (s SqlUserDeviceStore) Save(device *model.UserDevice) error {
+	query := s.getQueryBuilder().
+		Insert("UserDevices").
+		Columns(userDeviceColumns...).
+		Values(device.UserId, device.Fingerprint, device.Country, device.CreateAt, device.LastSeenAt)
+
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE LastSeenAt = ?", device.LastSeenAt))
+	} else {
+		query = query.SuffixExpr(sq.Expr("ON CONFLICT (userid, fingerprint, country) DO UPDATE SET LastSeenAt = ?", device.LastSeenAt))
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "user_device_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(queryString, args...); err != nil {
+		return errors.Wrapf(err, "failed to save UserDevice with userId=%s", device.UserId)
+	}
+
+	return nil
+}
+
+func (s SqlUserDeviceStore) GetForUser(userID string) ([]*model.UserDevice, error) {
+	query, args, err := s.getQueryBuilder().
+		Select(userDeviceColumns...).
+		From("UserDevices").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt ASC", "Fingerprint ASC", "Country ASC").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "user_device_tosql")
+	}
+
+	devices := []*model.UserDevice{}
+	if err := s.GetReplicaX().Select(&devices, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get UserDevices with userId=%s", userID)
+	}
+
+	return devices, nil
+}
+
+func (s SqlUserDeviceStore) PermanentDeleteByUser(userID string) error {
+	query, args, err := s.getQueryBuilder().
+		Delete("UserDevices").
+		Where(sq.Eq{"UserId": userID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "user_device_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to delete UserDevices with userId=%s", userID)
+	}
+
+	return nil
+}
@@ -477,6 +477,31 @@ func (us SqlUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, er
 	return users, nil
 }
 
+// GetNonBotUsers returns a page of the users, active or not, which aren't bots, ordered by
+// username.
+func (us SqlUserStore) GetNonBotUsers(offset, limit int) ([]*model.User, error) {
+	query := us.usersQuery.
+		Where("b.UserId IS NULL").
+		OrderBy("u.Username ASC").
+		Offset(uint64(offset)).Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_non_bot_users_tosql")
+	}
+
+	users := []*model.User{}
+	if err := us.GetReplicaX().Select(&users, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find Users")
+	}
+
+	for _, u := range users {
+		u.Sanitize(map[string]bool{})
+	}
+
+	return users, nil
+}
+
 func (us SqlUserStore) GetEtagForAllProfiles() string {
 	var updateAt int64
 	err := us.GetReplicaX().Get(&updateAt, "SELECT UpdateAt FROM Users ORDER BY UpdateAt DESC LIMIT 1")
@@ -2100,3 +2125,104 @@ func (us SqlUserStore) GetUsersWithInvalidEmails(page int, perPage int, restrict
 
 	return users, nil
 }
+
+// MergeInto re-attributes the posts, files, reactions, memberships and preferences of the source
+// user to the target user, in a single transaction. Direct and group messages channels of the
+// source user are left untouched since their members are part of their name.
+func (us SqlUserStore) MergeInto(sourceUserID, targetUserID string) (*model.UserMergeCounts, error) {
+	transaction, err := us.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction)
+
+	directChannels := "SELECT Id FROM Channels WHERE Type IN ('" + string(model.ChannelTypeDirect) + "', '" + string(model.ChannelTypeGroup) + "')"
+	counts := &model.UserMergeCounts{}
+
+	if err = transaction.Get(&counts.SkippedDirectChannels, `
+		SELECT
+			COUNT(*)
+		FROM
+			ChannelMembers
+		WHERE
+			UserId = ?
+			AND ChannelId IN (`+directChannels+`)`, sourceUserID); err != nil {
+		return nil, errors.Wrapf(err, "failed to count direct channels of userId=%s", sourceUserID)
+	}
+
+	result, err := transaction.Exec("UPDATE Posts SET UserId = ? WHERE UserId = ? AND ChannelId NOT IN ("+directChannels+")", targetUserID, sourceUserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update Posts with userId=%s", sourceUserID)
+	}
+	if counts.Posts, err = result.RowsAffected(); err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	// Files follow their post, files not yet attached to a post follow the user.
+	result, err = transaction.Exec("UPDATE FileInfo SET CreatorId = ? WHERE CreatorId = ? AND (PostId = '' OR PostId NOT IN (SELECT Id FROM Posts WHERE UserId = ?))", targetUserID, sourceUserID, sourceUserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update FileInfo with userId=%s", sourceUserID)
+	}
+	if counts.FileInfos, err = result.RowsAffected(); err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	merges := []struct {
+		table     string
+		keys      string
+		condition string
+		count     *int64
+	}{
+		{"Reactions", "PostId, EmojiName", "", &counts.Reactions},
+		{"ChannelMembers", "ChannelId", "ChannelId NOT IN (" + directChannels + ")", &counts.ChannelMemberships},
+		{"TeamMembers", "TeamId", "", &counts.TeamMemberships},
+		{"GroupMembers", "GroupId", "", &counts.GroupMemberships},
+		{"ThreadMemberships", "PostId", "", &counts.ThreadMemberships},
+		{"Preferences", "Category, Name", "", &counts.Preferences},
+	}
+	for _, merge := range merges {
+		if *merge.count, err = us.mergeUserRows(transaction, merge.table, merge.keys, merge.condition, sourceUserID, targetUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return counts, nil
+}
+
+// mergeUserRows moves the rows of the source user in table to the target user, except for the
+// ones the target user already has a row with the same keys for, which are deleted.
+func (us SqlUserStore) mergeUserRows(transaction *sqlxTxWrapper, table, keys, condition, sourceUserID, targetUserID string) (int64, error) {
+	if condition != "" {
+		condition = " AND " + condition
+	}
+
+	// The target rows are selected through a derived table since MySQL doesn't allow selecting
+	// from the table being updated.
+	result, err := transaction.Exec(`
+		UPDATE
+			`+table+`
+		SET
+			UserId = ?
+		WHERE
+			UserId = ?
+			AND (`+keys+`) NOT IN (
+				SELECT `+keys+` FROM (SELECT `+keys+` FROM `+table+` WHERE UserId = ?) AS TargetRows
+			)`+condition, targetUserID, sourceUserID, targetUserID)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to update %s with userId=%s", table, sourceUserID)
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	if _, err := transaction.Exec("DELETE FROM "+table+" WHERE UserId = ?"+condition, sourceUserID); err != nil {
+		return 0, errors.Wrapf(err, "failed to delete %s with userId=%s", table, sourceUserID)
+	}
+
+	return moved, nil
+}
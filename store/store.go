@@ -57,6 +57,28 @@ type Store interface {
 	UserTermsOfService() UserTermsOfServiceStore
 	LinkMetadata() LinkMetadataStore
 	SharedChannel() SharedChannelStore
+	PushNotificationReceipt() PushNotificationReceiptStore
+	TeamTemplate() TeamTemplateStore
+	OnboardingTask() OnboardingTaskStore
+	ConnectivityTestResult() ConnectivityTestResultStore
+	TablePartition() TablePartitionStore
+	PostArchive() PostArchiveStore
+	PersistentWebSocketEvent() PersistentWebSocketEventStore
+	ChannelMemberTimeout() ChannelMemberTimeoutStore
+	PostReport() PostReportStore
+	UserDevice() UserDeviceStore
+	MfaBackupCode() MfaBackupCodeStore
+	PostRetentionLabel() PostRetentionLabelStore
+	DirectMessageRequest() DirectMessageRequestStore
+	Reminder() ReminderStore
+	ChannelBookmark() ChannelBookmarkStore
+	PostPropSchema() PostPropSchemaStore
+	ChannelEvent() ChannelEventStore
+	ScheduledConfigChange() ScheduledConfigChangeStore
+	ChangeEvent() ChangeEventStore
+	OutgoingOAuthConnection() OutgoingOAuthConnectionStore
+	ChannelJoinRequest() ChannelJoinRequestStore
+	TeamInviteLink() TeamInviteLinkStore
 	MarkSystemRanUnitTests()
 	Close()
 	LockToMaster()
@@ -66,6 +88,8 @@ type Store interface {
 	GetDBSchemaVersion() (int, error)
 	GetAppliedMigrations() ([]model.AppliedMigration, error)
 	GetDbVersion(numerical bool) (string, error)
+	GetTableRowCounts() ([]*model.TableRowCount, error)
+	GetSlowQueries(limit int) ([]*model.SlowQuery, error)
 	TotalMasterDbConnections() int
 	TotalReadDbConnections() int
 	TotalSearchDbConnections() int
@@ -116,6 +140,13 @@ type TeamStore interface {
 	GetTeamsByUserId(userID string) ([]*model.Team, error)
 	GetByInviteId(inviteID string) (*model.Team, error)
 	PermanentDelete(teamID string) error
+	// SoftDelete marks the team as deleted at deleteAt, along with its channels, members and
+	// commands that aren't deleted yet.
+	SoftDelete(teamID string, deleteAt int64) error
+	// Restore restores the team, along with the channels, members and commands deleted with it.
+	Restore(teamID string) error
+	// GetSoftDeletedIds returns the ids of up to limit teams deleted before the given time.
+	GetSoftDeletedIds(before int64, limit int) ([]string, error)
 	AnalyticsTeamCount(opts *model.TeamSearch) (int64, error)
 	SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error)
 	SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error)
@@ -217,11 +248,16 @@ type ChannelStore interface {
 	GetFileCount(channelID string) (int64, error)
 	GetMemberCount(channelID string, allowFromCache bool) (int64, error)
 	GetMemberCountsByGroup(ctx context.Context, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error)
+	GetMemberCounts(channelID string) (*model.ChannelMemberCounts, error)
+	ReconcileMemberCounts(afterChannelID string, limit int) (string, error)
 	InvalidatePinnedPostCount(channelID string)
 	GetPinnedPostCount(channelID string, allowFromCache bool) (int64, error)
 	InvalidateGuestCount(channelID string)
 	GetGuestCount(channelID string, allowFromCache bool) (int64, error)
 	GetPinnedPosts(channelID string) (*model.PostList, error)
+	// SetPinnedPostsOrder puts the given pinned posts of a channel first in its pinned posts, in
+	// that order, replacing the order previously set.
+	SetPinnedPostsOrder(channelID string, postIDs []string) error
 	RemoveMember(channelID string, userID string) error
 	RemoveMembers(channelID string, userIds []string) error
 	PermanentDeleteMembersByUser(userID string) error
@@ -249,6 +285,7 @@ type ChannelStore interface {
 	GetMembersInfoByChannelIds(channelIDs []string) (map[string][]*model.User, error)
 	AnalyticsDeletedTypeCount(teamID string, channelType model.ChannelType) (int64, error)
 	GetChannelUnread(channelID, userID string) (*model.ChannelUnread, error)
+	GetChannelUnreadsForUser(userID string) ([]*model.ChannelUnread, error)
 	ClearCaches()
 	GetChannelsByScheme(schemeID string, offset int, limit int) (model.ChannelList, error)
 	MigrateChannelMembers(fromChannelID string, fromUserID string) (map[string]string, error)
@@ -270,6 +307,9 @@ type ChannelStore interface {
 	RemoveAllDeactivatedMembers(channelID string) error
 	GetChannelsBatchForIndexing(startTime int64, startChannelID string, limit int) ([]*model.Channel, error)
 	UserBelongsToChannels(userID string, channelIds []string) (bool, error)
+	// UsersShareChannel returns whether two users are both members of a public, private or group
+	// messages channel that isn't archived.
+	UsersShareChannel(userID, otherUserID string) (bool, error)
 
 	// UpdateMembersRole sets all of the given team members to admins and all of the other members of the team to
 	// non-admin members.
@@ -295,6 +335,9 @@ type ChannelMemberHistoryStore interface {
 	DeleteOrphanedRows(limit int) (deleted int64, err error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetChannelsLeftSince(userID string, since int64) ([]string, error)
+	// GetChannelsForUserDuring returns the memberships of the user that overlap the period, the
+	// earliest joined first.
+	GetChannelsForUserDuring(userID string, startTime, endTime int64) ([]*model.ChannelMemberHistory, error)
 }
 type ThreadStore interface {
 	GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error)
@@ -306,6 +349,7 @@ type ThreadStore interface {
 	GetThreadsForUser(userId, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error)
 	GetThreadForUser(teamID string, threadMembership *model.ThreadMembership, extended bool) (*model.ThreadResponse, error)
 	GetTeamsUnreadForUser(userID string, teamIDs []string) (map[string]*model.TeamUnread, error)
+	GetUnreadThreadsForUser(userID string) ([]*model.ThreadUnread, error)
 	GetPosts(threadID string, since int64) ([]*model.Post, error)
 
 	MarkAllAsRead(userID string, threadIds []string) error
@@ -365,11 +409,14 @@ type PostStore interface {
 	GetParentsForExportAfter(limit int, afterID string) ([]*model.PostForExport, error)
 	GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error)
 	GetDirectPostParentsForExportAfter(limit int, afterID string) ([]*model.DirectPostForExport, error)
+	GetChannelPostsForExportAfter(channelID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error)
+	GetUserPostsForExportAfter(userID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error)
 	SearchPostsForUser(paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.PostSearchResults, error)
 	GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error)
 	LogRecentSearch(userID string, searchQuery []byte, createAt int64) error
 	GetOldestEntityCreationTime() (int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error)
+	GetLastPostAtByUserSince(options model.GetPostsSinceOptions, userId string) (int64, error)
 	GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error)
 }
 
@@ -437,6 +484,7 @@ type UserStore interface {
 	ClearAllCustomRoleAssignments() error
 	InferSystemInstallDate() (int64, error)
 	GetAllAfter(limit int, afterID string) ([]*model.User, error)
+	GetNonBotUsers(offset, limit int) ([]*model.User, error)
 	GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error)
 	Count(options model.UserCountOptions) (int64, error)
 	GetTeamGroupUsers(teamID string) ([]*model.User, error)
@@ -449,6 +497,7 @@ type UserStore interface {
 	IsEmpty(excludeBots bool) (bool, error)
 	GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error)
 	InsertUsers(users []*model.User) error
+	MergeInto(sourceUserID, targetUserID string) (*model.UserMergeCounts, error)
 }
 
 type BotStore interface {
@@ -485,6 +534,138 @@ type AuditStore interface {
 	PermanentDeleteByUser(userID string) error
 }
 
+// PostRetentionLabelStore holds the retention labels applied to posts. A post has at most one
+// label, applying another one replaces it.
+type PostRetentionLabelStore interface {
+	// Save applies a label to a post, replacing the label it had if any.
+	Save(label *model.PostRetentionLabel) (*model.PostRetentionLabel, error)
+	Get(postID string) (*model.PostRetentionLabel, error)
+	Delete(postID string) error
+}
+
+// DirectMessageRequestStore holds the pending and declined message requests of direct channels.
+// A direct channel has at most one request.
+type DirectMessageRequestStore interface {
+	Save(request *model.DirectMessageRequest) (*model.DirectMessageRequest, error)
+	Get(channelID string) (*model.DirectMessageRequest, error)
+	// GetPendingForRecipient returns the pending requests sent to a user, newest first.
+	GetPendingForRecipient(recipientID string) ([]*model.DirectMessageRequest, error)
+	UpdateStatus(channelID, status string, updateAt int64) error
+	Delete(channelID string) error
+}
+
+type ReminderStore interface {
+	Save(reminder *model.Reminder) (*model.Reminder, error)
+	Update(reminder *model.Reminder) (*model.Reminder, error)
+	Get(id string) (*model.Reminder, error)
+	// GetForUser returns the reminders of a user, the first due first.
+	GetForUser(userID string) ([]*model.Reminder, error)
+	// GetDue returns up to limit reminders due by now, the first due first.
+	GetDue(now int64, limit int) ([]*model.Reminder, error)
+	Delete(id string) error
+}
+
+// ChannelBookmarkStore holds the bookmarks of channels. Deleted bookmarks are kept, with their
+// DeleteAt set.
+type ChannelBookmarkStore interface {
+	Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error)
+	Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error)
+	Get(id string, includeDeleted bool) (*model.ChannelBookmark, error)
+	// GetForChannel returns the bookmarks of a channel that aren't deleted, in their order.
+	GetForChannel(channelID string) ([]*model.ChannelBookmark, error)
+	// UpdateSortOrder moves a bookmark of a channel to the given index and returns the bookmarks of
+	// the channel whose order changed.
+	UpdateSortOrder(bookmarkID, channelID string, newIndex int64) ([]*model.ChannelBookmark, error)
+	Delete(id string, deleteAt int64) error
+}
+
+// PostPropSchemaStore holds the JSON schemas registered for post props, keyed by namespace.
+type PostPropSchemaStore interface {
+	Save(schema *model.PostPropSchema) (*model.PostPropSchema, error)
+	Update(schema *model.PostPropSchema) (*model.PostPropSchema, error)
+	Get(namespace string) (*model.PostPropSchema, error)
+	// GetAll returns every registered schema, ordered by namespace.
+	GetAll() ([]*model.PostPropSchema, error)
+	Delete(namespace string) error
+}
+
+type ChannelEventStore interface {
+	Save(event *model.ChannelEvent) (*model.ChannelEvent, error)
+	Update(event *model.ChannelEvent) (*model.ChannelEvent, error)
+	Get(id string) (*model.ChannelEvent, error)
+	// GetForChannel returns the events of a channel, ordered by start.
+	GetForChannel(channelID string) ([]*model.ChannelEvent, error)
+	// GetDue returns up to limit events due to be announced by now, the first due first.
+	GetDue(now int64, limit int) ([]*model.ChannelEvent, error)
+	Delete(id string) error
+}
+
+type ScheduledConfigChangeStore interface {
+	Save(change *model.ScheduledConfigChange) (*model.ScheduledConfigChange, error)
+	Get(id string) (*model.ScheduledConfigChange, error)
+	// GetAll returns the changes, the last to apply first. The changes that are no longer pending
+	// are only returned if includeFinished is true.
+	GetAll(includeFinished bool, offset, limit int) ([]*model.ScheduledConfigChange, error)
+	// GetDue returns up to limit pending changes due to be applied by now, the first due first.
+	GetDue(now int64, limit int) ([]*model.ScheduledConfigChange, error)
+	// UpdateStatus sets the status and error of a change if its status is still oldStatus, and
+	// returns whether it did.
+	UpdateStatus(id, oldStatus, newStatus, errorMessage string) (bool, error)
+}
+
+type ChangeEventStore interface {
+	Save(event *model.ChangeEvent) (*model.ChangeEvent, error)
+	// GetUnpublished returns up to limit events that weren't published yet, the first recorded
+	// first.
+	GetUnpublished(limit int) ([]*model.ChangeEvent, error)
+	MarkPublished(ids []string, publishedAt int64) error
+	// PermanentDeletePublishedBatch deletes up to limit events published before endTime, returning
+	// how many were deleted.
+	PermanentDeletePublishedBatch(endTime int64, limit int64) (int64, error)
+}
+
+type OutgoingOAuthConnectionStore interface {
+	Save(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error)
+	Update(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error)
+	Get(id string) (*model.OutgoingOAuthConnection, error)
+	GetAll(offset, limit int) ([]*model.OutgoingOAuthConnection, error)
+	Delete(id string) error
+}
+
+type ChannelJoinRequestStore interface {
+	// Save fails with an ErrUniqueConstraint if the user already has a pending request to join
+	// the channel.
+	Save(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error)
+	// Update only updates a request still pending, failing with an ErrConflict otherwise, so that a
+	// request is only reviewed once.
+	Update(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error)
+	Get(id string) (*model.ChannelJoinRequest, error)
+	// GetPendingForChannel returns the requests to join a channel still pending at the given time,
+	// the oldest first.
+	GetPendingForChannel(channelID string, now int64) ([]*model.ChannelJoinRequest, error)
+	Delete(id string) error
+	// Expire marks the pending requests expired by the given time as such, returning how many
+	// were.
+	Expire(now int64) (int64, error)
+	// Cleanup deletes the requests no longer pending which were last updated before expiryTime.
+	Cleanup(expiryTime int64, batchSize int) error
+}
+
+type TeamInviteLinkStore interface {
+	Save(link *model.TeamInviteLink) (*model.TeamInviteLink, error)
+	Update(link *model.TeamInviteLink) (*model.TeamInviteLink, error)
+	Get(id string) (*model.TeamInviteLink, error)
+	// GetForTeam returns the invite links of a team, the newest first.
+	GetForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error)
+	// Use counts a use of a link at the given time, provided it is neither revoked, expired nor
+	// exhausted, returning a store.ErrNotFound otherwise.
+	Use(id string, now int64) error
+	// Release gives back a use of a link which did not let the user join the team.
+	Release(id string) error
+	// Reject counts a refused use of a link.
+	Reject(id string) error
+}
+
 type ClusterDiscoveryStore interface {
 	Save(discovery *model.ClusterDiscovery) error
 	Delete(discovery *model.ClusterDiscovery) (bool, error)
@@ -586,6 +767,9 @@ type CommandStore interface {
 	Delete(commandID string, time int64) error
 	PermanentDeleteByTeam(teamID string) error
 	PermanentDeleteByUser(userID string) error
+	// PermanentDeleteSoftDeletedBatch permanently deletes up to limit commands deleted before the
+	// given time, returning how many were deleted.
+	PermanentDeleteSoftDeletedBatch(before int64, limit int64) (int64, error)
 	Update(hook *model.Command) (*model.Command, error)
 	AnalyticsCommandCount(teamID string) (int64, error)
 }
@@ -631,6 +815,9 @@ type EmojiStore interface {
 	GetByName(ctx context.Context, name string, allowFromCache bool) (*model.Emoji, error)
 	GetMultipleByName(names []string) ([]*model.Emoji, error)
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
+	SaveForTeam(emoji *model.Emoji, maxEmojiPerTeam int64) (*model.Emoji, error)
+	GetListForTeam(teamID string, offset, limit int, sort string) ([]*model.Emoji, error)
+	CountByTeam(teamID string) (int64, error)
 	Delete(emoji *model.Emoji, time int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
 }
@@ -652,6 +839,21 @@ type FileInfoStore interface {
 	GetFromMaster(id string) (*model.FileInfo, error)
 	GetByIds(ids []string) ([]*model.FileInfo, error)
 	GetByPath(path string) (*model.FileInfo, error)
+	// GetByContentHash returns the oldest FileInfo with the given content hash attached to a post in
+	// the channel that owns its stored file, as opposed to reusing the file of another FileInfo.
+	GetByContentHash(hash, channelID string) (*model.FileInfo, error)
+	// CountDedupReferences returns how many FileInfos reuse the stored file of the given one.
+	CountDedupReferences(fileID string) (int64, error)
+	// GetOrphaned returns, ordered by id, the FileInfos created before createdBefore which are
+	// attached to a post that exists neither in Posts nor in PostsArchive.
+	GetOrphaned(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error)
+	// GetExpiredForRetentionPolicies returns, ordered by id, the FileInfos of the posts affected by
+	// a retention policy whose file duration has passed at now. A channel policy overrides the
+	// policy of its team, and posts with a retention label are not affected.
+	GetExpiredForRetentionPolicies(now int64, afterID string, limit int) ([]*model.FileInfo, error)
+	// GetReferencedIds returns the ids among fileIDs which are those of a FileInfo, deleted or
+	// not, or whose stored file is reused by one.
+	GetReferencedIds(fileIDs []string) ([]string, error)
 	GetForPost(postID string, readFromMaster, includeDeleted, allowFromCache bool) ([]*model.FileInfo, error)
 	GetForUser(userID string) ([]*model.FileInfo, error)
 	GetWithOptions(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error)
@@ -662,6 +864,9 @@ type FileInfoStore interface {
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	PermanentDeleteByUser(userID string) (int64, error)
 	SetContent(fileID, content string) error
+	// SetAltText sets the alt text of a file. A generated alt text doesn't replace one set by the
+	// author of the file.
+	SetAltText(fileID, altText string, generated bool) error
 	Search(paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.FileInfoList, error)
 	CountAll() (int64, error)
 	GetFilesBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.FileForIndexing, error)
@@ -726,6 +931,7 @@ type PluginStore interface {
 	CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error)
 	CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error)
 	SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error)
+	SetMultiple(pluginID string, operations []*model.PluginKVSetOperation) (bool, error)
 	Get(pluginID, key string) (*model.PluginKeyValue, error)
 	Delete(pluginID, key string) error
 	DeleteAllForPlugin(PluginID string) error
@@ -778,6 +984,121 @@ type ProductNoticesStore interface {
 	GetViews(userID string) ([]model.ProductNoticeViewState, error)
 }
 
+type PushNotificationReceiptStore interface {
+	Save(receipt *model.PushNotificationReceipt) (*model.PushNotificationReceipt, error)
+	MarkReceived(ackID string, receivedAt int64) error
+	GetForUser(userID string, limit int) ([]*model.PushNotificationReceipt, error)
+	Cleanup(expiryTime int64, batchSize int) error
+}
+
+type TeamTemplateStore interface {
+	Save(template *model.TeamTemplate) (*model.TeamTemplate, error)
+	Update(template *model.TeamTemplate) (*model.TeamTemplate, error)
+	Get(id string) (*model.TeamTemplate, error)
+	GetAll(offset, limit int) ([]*model.TeamTemplate, error)
+	Delete(id string, deleteAt int64) error
+}
+
+type OnboardingTaskStore interface {
+	Save(task *model.OnboardingTask) (*model.OnboardingTask, error)
+	Update(task *model.OnboardingTask) (*model.OnboardingTask, error)
+	Get(id string) (*model.OnboardingTask, error)
+	GetAll() ([]*model.OnboardingTask, error)
+	Delete(id string, deleteAt int64) error
+}
+
+type ConnectivityTestResultStore interface {
+	Save(result *model.ConnectivityTestResult) (*model.ConnectivityTestResult, error)
+	// GetHistory returns the most recent results first. An empty service returns the results of
+	// every service.
+	GetHistory(service string, offset, limit int) ([]*model.ConnectivityTestResult, error)
+	Cleanup(expiryTime int64, batchSize int) error
+}
+
+// TablePartitionStore manages the monthly partitions of the tables partitioned by CreateAt. It's
+// only supported on PostgreSQL.
+type TablePartitionStore interface {
+	IsPartitioned(table string) (bool, error)
+	// Partition converts table to a table partitioned by CreateAt. Its existing rows are kept in a
+	// legacy partition holding everything created before boundary.
+	Partition(table string, boundary int64) error
+	// CreatePartition creates a monthly partition of a table, unless its rows are held by the
+	// legacy partition.
+	CreatePartition(partition *model.TablePartition) error
+	// GetPartitions returns the monthly partitions of table, oldest first.
+	GetPartitions(table string) ([]*model.TablePartition, error)
+	// HasRetainedRows returns whether a monthly partition of a table of posts holds posts that the
+	// data retention keeps: labeled posts whose label hasn't expired, and posts within the post
+	// duration of the policy of their channel or team, or else created at or after
+	// globalPolicyEndTime if it is positive.
+	HasRetainedRows(partition *model.TablePartition, now, globalPolicyEndTime int64) (bool, error)
+	// DropPartition drops a monthly partition of a table, along with the rows of the other tables
+	// belonging to its rows.
+	DropPartition(partition *model.TablePartition) error
+}
+
+// PostArchiveStore holds the old threads moved out of the Posts table. Archived posts are only
+// reachable through Search, which is slower than the regular post search.
+type PostArchiveStore interface {
+	// ArchiveThreadsBefore moves up to limit threads that are unpinned and had no activity since
+	// before to the archive, returning the number of posts moved.
+	ArchiveThreadsBefore(before int64, limit int) (int64, error)
+	// Search returns the archived posts of the given channels containing every term, newest first.
+	Search(channelIDs []string, terms string, offset, limit int) ([]*model.Post, error)
+}
+
+type PersistentWebSocketEventStore interface {
+	Save(event *model.PersistentWebSocketEvent) (*model.PersistentWebSocketEvent, error)
+	// GetForUserSince returns, oldest first, the events created after since that are broadcast to
+	// the user, the channels and teams they are a member of, or everyone. The omitted users of an
+	// event are not taken into account.
+	GetForUserSince(userID string, since int64, limit int) ([]*model.PersistentWebSocketEvent, error)
+	Cleanup(expiryTime int64, batchSize int) error
+}
+
+// ChannelMemberTimeoutStore holds the timeouts preventing channel members from posting. A member
+// has at most one timeout per channel.
+type ChannelMemberTimeoutStore interface {
+	// Save replaces the timeout of the member, and removes the expired timeouts of the channel.
+	Save(timeout *model.ChannelMemberTimeout) (*model.ChannelMemberTimeout, error)
+	Get(channelID, userID string) (*model.ChannelMemberTimeout, error)
+	// GetForChannel returns the timeouts of the channel which expire after now.
+	GetForChannel(channelID string, now int64) ([]*model.ChannelMemberTimeout, error)
+	Delete(channelID, userID string) error
+}
+
+// PostReportStore holds the reports of posts to moderators. A user can only report a post once.
+type PostReportStore interface {
+	Save(report *model.PostReport) (*model.PostReport, error)
+	Get(id string) (*model.PostReport, error)
+	// GetReports returns the reports matching the options, oldest first.
+	GetReports(opts model.PostReportGetOptions) ([]*model.PostReport, error)
+	// ResolveForPost closes the open reports of a post with the status and action given,
+	// returning the number of reports closed.
+	ResolveForPost(postID, status, action, resolverID string, resolveAt int64) (int64, error)
+}
+
+// UserDeviceStore holds the devices and countries users logged in from, so that logins from new
+// ones can be notified.
+type UserDeviceStore interface {
+	// Save records a device, or updates when it was last seen if it is already known.
+	Save(device *model.UserDevice) error
+	GetForUser(userID string) ([]*model.UserDevice, error)
+	PermanentDeleteByUser(userID string) error
+}
+
+// MfaBackupCodeStore holds the hashes of the one-time backup codes of users with multi-factor
+// authentication.
+type MfaBackupCodeStore interface {
+	// ReplaceForUser replaces all the backup codes of a user with new ones, given their hashes.
+	ReplaceForUser(userID string, hashes []string, createAt int64) error
+	// Use marks a backup code of a user as used, returning false if it doesn't exist or was
+	// already used.
+	Use(userID, hash string, usedAt int64) (bool, error)
+	CountUnusedForUser(userID string) (int64, error)
+	PermanentDeleteByUser(userID string) error
+}
+
 type UserTermsOfServiceStore interface {
 	GetByUser(userID string) (*model.UserTermsOfService, error)
 	Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error)
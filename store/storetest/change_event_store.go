@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestChangeEventStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGetUnpublished", func(t *testing.T) { testChangeEventSaveAndGetUnpublished(t, ss) })
+	t.Run("PermanentDeletePublishedBatch", func(t *testing.T) { testChangeEventPermanentDeletePublishedBatch(t, ss) })
+}
+
+func saveTestChangeEvent(t *testing.T, ss store.Store, createAt int64) *model.ChangeEvent {
+	t.Helper()
+
+	event, err := model.NewChangeEvent(model.ChangeEventEntityPost, model.NewId(), model.ChangeEventOperationCreate, map[string]string{"message": "hello"})
+	require.NoError(t, err)
+	event.CreateAt = createAt
+
+	saved, err := ss.ChangeEvent().Save(event)
+	require.NoError(t, err)
+	return saved
+}
+
+func testChangeEventSaveAndGetUnpublished(t *testing.T, ss store.Store) {
+	_, err := ss.ChangeEvent().Save(&model.ChangeEvent{EntityType: "team", EntityId: model.NewId(), Operation: model.ChangeEventOperationCreate})
+	require.Error(t, err)
+
+	second := saveTestChangeEvent(t, ss, 2000)
+	first := saveTestChangeEvent(t, ss, 1000)
+	deletion, err := ss.ChangeEvent().Save(&model.ChangeEvent{EntityType: model.ChangeEventEntityUser, EntityId: model.NewId(), Operation: model.ChangeEventOperationDelete, CreateAt: 3000})
+	require.NoError(t, err)
+
+	events, err := ss.ChangeEvent().GetUnpublished(1000)
+	require.NoError(t, err)
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.Id)
+	}
+	require.Contains(t, ids, first.Id)
+	require.Contains(t, ids, second.Id)
+	require.Contains(t, ids, deletion.Id)
+
+	var firstIndex, secondIndex int
+	for i, event := range events {
+		switch event.Id {
+		case first.Id:
+			firstIndex = i
+			assert.Equal(t, model.ChangeEventEntityPost, event.EntityType)
+			assert.Equal(t, first.EntityId, event.EntityId)
+			assert.JSONEq(t, `{"message":"hello"}`, string(event.Data))
+		case second.Id:
+			secondIndex = i
+		case deletion.Id:
+			assert.Empty(t, event.Data)
+		}
+	}
+	assert.Less(t, firstIndex, secondIndex)
+
+	require.NoError(t, ss.ChangeEvent().MarkPublished([]string{first.Id, second.Id, deletion.Id}, model.GetMillis()))
+
+	events, err = ss.ChangeEvent().GetUnpublished(1000)
+	require.NoError(t, err)
+	for _, event := range events {
+		assert.NotEqual(t, first.Id, event.Id)
+		assert.NotEqual(t, second.Id, event.Id)
+	}
+
+	require.NoError(t, ss.ChangeEvent().MarkPublished(nil, model.GetMillis()))
+}
+
+func testChangeEventPermanentDeletePublishedBatch(t *testing.T, ss store.Store) {
+	published := saveTestChangeEvent(t, ss, 1000)
+	unpublished := saveTestChangeEvent(t, ss, 1000)
+	require.NoError(t, ss.ChangeEvent().MarkPublished([]string{published.Id}, 5000))
+
+	_, err := ss.ChangeEvent().PermanentDeletePublishedBatch(6000, 1000)
+	require.NoError(t, err)
+
+	events, err := ss.ChangeEvent().GetUnpublished(1000)
+	require.NoError(t, err)
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.Id)
+	}
+	assert.Contains(t, ids, unpublished.Id)
+
+	deleted, err := ss.ChangeEvent().PermanentDeletePublishedBatch(6000, 1000)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+}
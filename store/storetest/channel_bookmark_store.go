@@ -0,0 +1,117 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestChannelBookmarkStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testChannelBookmarkSaveGetUpdateDelete(t, ss) })
+	t.Run("GetForChannel", func(t *testing.T) { testChannelBookmarkGetForChannel(t, ss) })
+	t.Run("UpdateSortOrder", func(t *testing.T) { testChannelBookmarkUpdateSortOrder(t, ss) })
+}
+
+func newLinkBookmark(channelID, displayName string, sortOrder int64) *model.ChannelBookmark {
+	return &model.ChannelBookmark{
+		ChannelId:   channelID,
+		OwnerId:     model.NewId(),
+		DisplayName: displayName,
+		Type:        model.ChannelBookmarkLink,
+		LinkUrl:     "https://example.com/" + displayName,
+		SortOrder:   sortOrder,
+	}
+}
+
+func testChannelBookmarkSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	_, err := ss.ChannelBookmark().Save(&model.ChannelBookmark{ChannelId: model.NewId(), OwnerId: model.NewId(), DisplayName: "nowhere", Type: model.ChannelBookmarkLink})
+	require.Error(t, err)
+
+	bookmark, err := ss.ChannelBookmark().Save(newLinkBookmark(model.NewId(), "handbook", 0))
+	require.NoError(t, err)
+	assert.NotEmpty(t, bookmark.Id)
+	assert.NotZero(t, bookmark.CreateAt)
+
+	bookmark.DisplayName = "Handbook"
+	bookmark.Emoji = "books"
+	_, err = ss.ChannelBookmark().Update(bookmark)
+	require.NoError(t, err)
+
+	got, err := ss.ChannelBookmark().Get(bookmark.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Handbook", got.DisplayName)
+	assert.Equal(t, "books", got.Emoji)
+
+	require.NoError(t, ss.ChannelBookmark().Delete(bookmark.Id, model.GetMillis()))
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.ChannelBookmark().Get(bookmark.Id, false)
+	require.True(t, errors.As(err, &nfErr))
+
+	got, err = ss.ChannelBookmark().Get(bookmark.Id, true)
+	require.NoError(t, err)
+	assert.NotZero(t, got.DeleteAt)
+
+	_, err = ss.ChannelBookmark().Update(got)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testChannelBookmarkGetForChannel(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+
+	second, err := ss.ChannelBookmark().Save(newLinkBookmark(channelID, "second", 1))
+	require.NoError(t, err)
+	first, err := ss.ChannelBookmark().Save(newLinkBookmark(channelID, "first", 0))
+	require.NoError(t, err)
+	deleted, err := ss.ChannelBookmark().Save(newLinkBookmark(channelID, "deleted", 2))
+	require.NoError(t, err)
+	_, err = ss.ChannelBookmark().Save(newLinkBookmark(model.NewId(), "other", 0))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.ChannelBookmark().Delete(deleted.Id, model.GetMillis()))
+
+	bookmarks, err := ss.ChannelBookmark().GetForChannel(channelID)
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 2)
+	assert.Equal(t, first.Id, bookmarks[0].Id)
+	assert.Equal(t, second.Id, bookmarks[1].Id)
+}
+
+func testChannelBookmarkUpdateSortOrder(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+
+	var ids []string
+	for i, name := range []string{"a", "b", "c", "d"} {
+		bookmark, err := ss.ChannelBookmark().Save(newLinkBookmark(channelID, name, int64(i)))
+		require.NoError(t, err)
+		ids = append(ids, bookmark.Id)
+	}
+
+	changed, err := ss.ChannelBookmark().UpdateSortOrder(ids[3], channelID, 1)
+	require.NoError(t, err)
+	assert.Len(t, changed, 3)
+
+	bookmarks, err := ss.ChannelBookmark().GetForChannel(channelID)
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 4)
+	for i, id := range []string{ids[0], ids[3], ids[1], ids[2]} {
+		assert.Equal(t, id, bookmarks[i].Id)
+		assert.Equal(t, int64(i), bookmarks[i].SortOrder)
+	}
+
+	_, err = ss.ChannelBookmark().UpdateSortOrder(ids[0], channelID, 4)
+	var invErr *store.ErrInvalidInput
+	require.True(t, errors.As(err, &invErr))
+
+	_, err = ss.ChannelBookmark().UpdateSortOrder(model.NewId(), channelID, 0)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
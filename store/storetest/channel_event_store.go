@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestChannelEventStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testChannelEventSaveGetUpdateDelete(t, ss) })
+	t.Run("GetForChannel", func(t *testing.T) { testChannelEventGetForChannel(t, ss) })
+	t.Run("GetDue", func(t *testing.T) { testChannelEventGetDue(t, ss) })
+}
+
+func newTestChannelEvent(channelID string, startAt, notifyAt int64) *model.ChannelEvent {
+	return &model.ChannelEvent{
+		ChannelId:   channelID,
+		CreatorId:   model.NewId(),
+		Title:       "Standup",
+		StartAt:     startAt,
+		Timezone:    "Europe/Paris",
+		NextStartAt: startAt,
+		NotifyAt:    notifyAt,
+	}
+}
+
+func testChannelEventSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	invalid := newTestChannelEvent(model.NewId(), 1000, 1000)
+	invalid.Timezone = "Mars/Olympus_Mons"
+	_, err := ss.ChannelEvent().Save(invalid)
+	require.Error(t, err)
+
+	event, err := ss.ChannelEvent().Save(newTestChannelEvent(model.NewId(), 1000, 1000))
+	require.NoError(t, err)
+	assert.NotEmpty(t, event.Id)
+	assert.NotZero(t, event.CreateAt)
+
+	event.Title = "Retrospective"
+	event.Recurrence = model.ChannelEventRecurrenceWeekly
+	event.RemindBeforeMinutes = 10
+	event.NotifyAt = 0
+	_, err = ss.ChannelEvent().Update(event)
+	require.NoError(t, err)
+
+	got, err := ss.ChannelEvent().Get(event.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Retrospective", got.Title)
+	assert.Equal(t, "Europe/Paris", got.Timezone)
+	assert.Equal(t, model.ChannelEventRecurrenceWeekly, got.Recurrence)
+	assert.Equal(t, 10, got.RemindBeforeMinutes)
+	assert.Zero(t, got.NotifyAt)
+
+	require.NoError(t, ss.ChannelEvent().Delete(event.Id))
+
+	_, err = ss.ChannelEvent().Get(event.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testChannelEventGetForChannel(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+
+	later, err := ss.ChannelEvent().Save(newTestChannelEvent(channelID, 2000, 2000))
+	require.NoError(t, err)
+	defer ss.ChannelEvent().Delete(later.Id)
+
+	sooner, err := ss.ChannelEvent().Save(newTestChannelEvent(channelID, 1000, 1000))
+	require.NoError(t, err)
+	defer ss.ChannelEvent().Delete(sooner.Id)
+
+	other, err := ss.ChannelEvent().Save(newTestChannelEvent(model.NewId(), 1000, 1000))
+	require.NoError(t, err)
+	defer ss.ChannelEvent().Delete(other.Id)
+
+	events, err := ss.ChannelEvent().GetForChannel(channelID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, sooner.Id, events[0].Id)
+	assert.Equal(t, later.Id, events[1].Id)
+}
+
+func testChannelEventGetDue(t *testing.T, ss store.Store) {
+	// Events due long ago, so that no other test's events come first.
+	first, err := ss.ChannelEvent().Save(newTestChannelEvent(model.NewId(), 10, 1))
+	require.NoError(t, err)
+	defer ss.ChannelEvent().Delete(first.Id)
+
+	second, err := ss.ChannelEvent().Save(newTestChannelEvent(model.NewId(), 10, 2))
+	require.NoError(t, err)
+	defer ss.ChannelEvent().Delete(second.Id)
+
+	notDue, err := ss.ChannelEvent().Save(newTestChannelEvent(model.NewId(), 10, 4))
+	require.NoError(t, err)
+	defer ss.ChannelEvent().Delete(notDue.Id)
+
+	over, err := ss.ChannelEvent().Save(newTestChannelEvent(model.NewId(), 10, 0))
+	require.NoError(t, err)
+	defer ss.ChannelEvent().Delete(over.Id)
+
+	events, err := ss.ChannelEvent().GetDue(3, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, first.Id, events[0].Id)
+	assert.Equal(t, second.Id, events[1].Id)
+
+	events, err = ss.ChannelEvent().GetDue(3, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, first.Id, events[0].Id)
+}
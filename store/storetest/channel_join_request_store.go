@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestChannelJoinRequestStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testChannelJoinRequestSaveGetUpdateDelete(t, ss) })
+	t.Run("GetPendingForChannel", func(t *testing.T) { testChannelJoinRequestGetPendingForChannel(t, ss) })
+	t.Run("ExpireAndCleanup", func(t *testing.T) { testChannelJoinRequestExpireAndCleanup(t, ss) })
+	t.Run("UniquePending", func(t *testing.T) { testChannelJoinRequestUniquePending(t, ss) })
+}
+
+func testChannelJoinRequestSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	_, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: model.NewId(), UserId: "invalid"})
+	require.Error(t, err)
+
+	request, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "Please add me",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, request.Id)
+	assert.Equal(t, model.ChannelJoinRequestStatusPending, request.Status)
+	assert.Greater(t, request.ExpireAt, request.CreateAt)
+
+	request.Status = model.ChannelJoinRequestStatusDenied
+	_, err = ss.ChannelJoinRequest().Update(request)
+	require.Error(t, err, "a reviewed request needs a reviewer")
+
+	request.ReviewerId = model.NewId()
+	_, err = ss.ChannelJoinRequest().Update(request)
+	require.NoError(t, err)
+
+	got, err := ss.ChannelJoinRequest().Get(request.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Please add me", got.Message)
+	assert.Equal(t, model.ChannelJoinRequestStatusDenied, got.Status)
+	assert.Equal(t, request.ReviewerId, got.ReviewerId)
+
+	request.Status = model.ChannelJoinRequestStatusApproved
+	_, err = ss.ChannelJoinRequest().Update(request)
+	var cErr *store.ErrConflict
+	require.True(t, errors.As(err, &cErr), "a reviewed request can't be reviewed again")
+
+	require.NoError(t, ss.ChannelJoinRequest().Delete(request.Id))
+
+	_, err = ss.ChannelJoinRequest().Get(request.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testChannelJoinRequestGetPendingForChannel(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+
+	first, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: model.NewId(), CreateAt: 1000})
+	require.NoError(t, err)
+	second, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: model.NewId(), CreateAt: 2000})
+	require.NoError(t, err)
+	_, err = ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: model.NewId(), CreateAt: 3000, ExpireAt: 4000})
+	require.NoError(t, err)
+	_, err = ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: model.NewId(), CreateAt: 3000, Status: model.ChannelJoinRequestStatusExpired})
+	require.NoError(t, err)
+	_, err = ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: model.NewId(), UserId: model.NewId(), CreateAt: 3000})
+	require.NoError(t, err)
+
+	requests, err := ss.ChannelJoinRequest().GetPendingForChannel(channelID, 5000)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, first.Id, requests[0].Id)
+	assert.Equal(t, second.Id, requests[1].Id)
+
+	requests, err = ss.ChannelJoinRequest().GetPendingForChannel(model.NewId(), 5000)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+}
+
+func testChannelJoinRequestExpireAndCleanup(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+
+	expired, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: model.NewId(), UserId: model.NewId(), CreateAt: now - 2000, ExpireAt: now - 1000})
+	require.NoError(t, err)
+	pending, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: model.NewId(), UserId: model.NewId()})
+	require.NoError(t, err)
+
+	count, err := ss.ChannelJoinRequest().Expire(now)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(1))
+
+	got, err := ss.ChannelJoinRequest().Get(expired.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.ChannelJoinRequestStatusExpired, got.Status)
+
+	got, err = ss.ChannelJoinRequest().Get(pending.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.ChannelJoinRequestStatusPending, got.Status)
+
+	require.NoError(t, ss.ChannelJoinRequest().Cleanup(now+1, 1))
+
+	_, err = ss.ChannelJoinRequest().Get(expired.Id)
+	require.Error(t, err)
+
+	_, err = ss.ChannelJoinRequest().Get(pending.Id)
+	require.NoError(t, err, "pending requests are kept")
+}
+
+func testChannelJoinRequestUniquePending(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+	now := model.GetMillis()
+
+	stale, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: userID, CreateAt: now - 2000, ExpireAt: now - 1000})
+	require.NoError(t, err)
+
+	_, err = ss.ChannelJoinRequest().Update(stale)
+	var cErr *store.ErrConflict
+	require.True(t, errors.As(err, &cErr), "an expired request can't be reviewed")
+
+	pending, err := ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: userID})
+	require.NoError(t, err, "a request past its expiry doesn't prevent asking again")
+
+	got, err := ss.ChannelJoinRequest().Get(stale.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.ChannelJoinRequestStatusExpired, got.Status)
+
+	_, err = ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: userID})
+	var uniqueErr *store.ErrUniqueConstraint
+	require.True(t, errors.As(err, &uniqueErr), "a user can only have one pending request per channel")
+
+	_, err = ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: model.NewId(), UserId: userID})
+	require.NoError(t, err, "requests to other channels are independent")
+
+	pending.Status = model.ChannelJoinRequestStatusDenied
+	pending.ReviewerId = model.NewId()
+	_, err = ss.ChannelJoinRequest().Update(pending)
+	require.NoError(t, err)
+
+	_, err = ss.ChannelJoinRequest().Save(&model.ChannelJoinRequest{ChannelId: channelID, UserId: userID})
+	require.NoError(t, err, "a user can ask again once their request was reviewed")
+}
@@ -23,6 +23,7 @@ func TestChannelMemberHistoryStore(t *testing.T, ss store.Store) {
 	t.Run("TestPermanentDeleteBatch", func(t *testing.T) { testPermanentDeleteBatch(t, ss) })
 	t.Run("TestPermanentDeleteBatchForRetentionPolicies", func(t *testing.T) { testPermanentDeleteBatchForRetentionPolicies(t, ss) })
 	t.Run("TestGetChannelsLeftSince", func(t *testing.T) { testGetChannelsLeftSince(t, ss) })
+	t.Run("TestGetChannelsForUserDuring", func(t *testing.T) { testGetChannelsForUserDuring(t, ss) })
 }
 
 func testLogJoinEvent(t *testing.T, ss store.Store) {
@@ -441,3 +442,34 @@ func testGetChannelsLeftSince(t *testing.T, ss store.Store) {
 	require.NoError(t, err)
 	assert.Equal(t, []string{channel.Id}, ids)
 }
+
+func testGetChannelsForUserDuring(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	channelIDs := []string{model.NewId(), model.NewId(), model.NewId(), model.NewId()}
+
+	// Left before the period.
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID, channelIDs[0], 100))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID, channelIDs[0], 200))
+	// Left during the period.
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID, channelIDs[1], 300))
+	require.NoError(t, ss.ChannelMemberHistory().LogLeaveEvent(userID, channelIDs[1], 1500))
+	// Still a member.
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID, channelIDs[2], 1200))
+	// Joined after the period.
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(userID, channelIDs[3], 3000))
+	// Another user.
+	require.NoError(t, ss.ChannelMemberHistory().LogJoinEvent(model.NewId(), channelIDs[2], 1200))
+
+	histories, err := ss.ChannelMemberHistory().GetChannelsForUserDuring(userID, 1000, 2000)
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+
+	assert.Equal(t, channelIDs[1], histories[0].ChannelId)
+	assert.Equal(t, int64(300), histories[0].JoinTime)
+	require.NotNil(t, histories[0].LeaveTime)
+	assert.Equal(t, int64(1500), *histories[0].LeaveTime)
+
+	assert.Equal(t, channelIDs[2], histories[1].ChannelId)
+	assert.Equal(t, userID, histories[1].UserId)
+	assert.Nil(t, histories[1].LeaveTime)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestChannelMemberTimeoutStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testChannelMemberTimeoutSaveAndGet(t, ss) })
+	t.Run("GetForChannel", func(t *testing.T) { testChannelMemberTimeoutGetForChannel(t, ss) })
+}
+
+func newTestChannelMemberTimeout(channelID, userID string, createAt int64, minutes int) *model.ChannelMemberTimeout {
+	return &model.ChannelMemberTimeout{
+		ChannelId: channelID,
+		UserId:    userID,
+		CreatorId: model.NewId(),
+		CreateAt:  createAt,
+		ExpiresAt: createAt + int64(minutes)*60*1000,
+	}
+}
+
+func testChannelMemberTimeoutSaveAndGet(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+
+	_, err := ss.ChannelMemberTimeout().Get(channelID, userID)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.ChannelMemberTimeout().Save(newTestChannelMemberTimeout(channelID, userID, model.GetMillis(), 0))
+	require.Error(t, err)
+
+	saved, err := ss.ChannelMemberTimeout().Save(newTestChannelMemberTimeout(channelID, userID, model.GetMillis(), 10))
+	require.NoError(t, err)
+
+	timeout, err := ss.ChannelMemberTimeout().Get(channelID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, timeout)
+
+	// Saving again replaces the timeout of the member.
+	replaced, err := ss.ChannelMemberTimeout().Save(newTestChannelMemberTimeout(channelID, userID, model.GetMillis(), 60))
+	require.NoError(t, err)
+
+	timeout, err = ss.ChannelMemberTimeout().Get(channelID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, replaced, timeout)
+
+	require.NoError(t, ss.ChannelMemberTimeout().Delete(channelID, userID))
+
+	_, err = ss.ChannelMemberTimeout().Get(channelID, userID)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testChannelMemberTimeoutGetForChannel(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	now := model.GetMillis()
+
+	expired, err := ss.ChannelMemberTimeout().Save(newTestChannelMemberTimeout(channelID, model.NewId(), now-20*60*1000, 10))
+	require.NoError(t, err)
+	longer, err := ss.ChannelMemberTimeout().Save(newTestChannelMemberTimeout(channelID, model.NewId(), now, 30))
+	require.NoError(t, err)
+	shorter, err := ss.ChannelMemberTimeout().Save(newTestChannelMemberTimeout(channelID, model.NewId(), now, 10))
+	require.NoError(t, err)
+	_, err = ss.ChannelMemberTimeout().Save(newTestChannelMemberTimeout(model.NewId(), model.NewId(), now, 10))
+	require.NoError(t, err)
+
+	timeouts, err := ss.ChannelMemberTimeout().GetForChannel(channelID, now)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ChannelMemberTimeout{shorter, longer}, timeouts)
+
+	// The expired timeout was removed when the others were saved.
+	_, err = ss.ChannelMemberTimeout().Get(channelID, expired.UserId)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
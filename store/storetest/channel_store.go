@@ -71,6 +71,8 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("CreateDirectChannel", func(t *testing.T) { testChannelStoreCreateDirectChannel(t, ss) })
 	t.Run("Update", func(t *testing.T) { testChannelStoreUpdate(t, ss) })
 	t.Run("GetChannelUnread", func(t *testing.T) { testGetChannelUnread(t, ss) })
+	t.Run("GetChannelUnreadsForUser", func(t *testing.T) { testGetChannelUnreadsForUser(t, ss) })
+	t.Run("UsersShareChannel", func(t *testing.T) { testChannelStoreUsersShareChannel(t, ss) })
 	t.Run("Get", func(t *testing.T) { testChannelStoreGet(t, ss, s) })
 	t.Run("GetMany", func(t *testing.T) { testChannelStoreGetMany(t, ss, s) })
 	t.Run("GetChannelsByIds", func(t *testing.T) { testChannelStoreGetChannelsByIds(t, ss) })
@@ -110,6 +112,7 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetMember", func(t *testing.T) { testGetMember(t, ss) })
 	t.Run("GetMemberForPost", func(t *testing.T) { testChannelStoreGetMemberForPost(t, ss) })
 	t.Run("GetMemberCount", func(t *testing.T) { testGetMemberCount(t, ss) })
+	t.Run("MemberCounts", func(t *testing.T) { testChannelStoreMemberCounts(t, ss) })
 	t.Run("GetMemberCountsByGroup", func(t *testing.T) { testGetMemberCountsByGroup(t, ss) })
 	t.Run("GetGuestCount", func(t *testing.T) { testGetGuestCount(t, ss) })
 	t.Run("SearchMore", func(t *testing.T) { testChannelStoreSearchMore(t, ss) })
@@ -402,6 +405,99 @@ func testGetChannelUnread(t *testing.T, ss store.Store) {
 	require.EqualValues(t, 10, ch2.MsgCount, "wrong MsgCount for channel 2")
 }
 
+func testGetChannelUnreadsForUser(t *testing.T, ss store.Store) {
+	uid := model.NewId()
+	otherUid := model.NewId()
+	notifyPropsModel := model.GetDefaultChannelNotifyProps()
+
+	// Unread messages
+	c1 := &model.Channel{TeamId: model.NewId(), Name: model.NewId(), DisplayName: "Unread", Type: model.ChannelTypeOpen, TotalMsgCount: 100, TotalMsgCountRoot: 99}
+	_, nErr := ss.Channel().Save(c1, -1)
+	require.NoError(t, nErr)
+	_, err := ss.Channel().SaveMember(&model.ChannelMember{ChannelId: c1.Id, UserId: uid, NotifyProps: notifyPropsModel, MsgCount: 90, MsgCountRoot: 80})
+	require.NoError(t, err)
+
+	// Fully read
+	c2 := &model.Channel{TeamId: model.NewId(), Name: model.NewId(), DisplayName: "Read", Type: model.ChannelTypeOpen, TotalMsgCount: 100, TotalMsgCountRoot: 100}
+	_, nErr = ss.Channel().Save(c2, -1)
+	require.NoError(t, nErr)
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: c2.Id, UserId: uid, NotifyProps: notifyPropsModel, MsgCount: 100, MsgCountRoot: 100})
+	require.NoError(t, err)
+
+	// Direct channel with mentions only
+	c3 := &model.Channel{Name: model.GetDMNameFromIds(uid, otherUid), DisplayName: "Direct", Type: model.ChannelTypeDirect, TotalMsgCount: 10, TotalMsgCountRoot: 10}
+	_, nErr = ss.Channel().SaveDirectChannel(c3,
+		&model.ChannelMember{UserId: uid, NotifyProps: notifyPropsModel, MsgCount: 10, MsgCountRoot: 10, MentionCount: 2, MentionCountRoot: 2},
+		&model.ChannelMember{UserId: otherUid, NotifyProps: notifyPropsModel})
+	require.NoError(t, nErr)
+
+	unreads, err := ss.Channel().GetChannelUnreadsForUser(uid)
+	require.NoError(t, err)
+	require.Len(t, unreads, 2)
+
+	byChannel := map[string]*model.ChannelUnread{}
+	for _, cu := range unreads {
+		byChannel[cu.ChannelId] = cu
+	}
+
+	require.Contains(t, byChannel, c1.Id)
+	assert.Equal(t, c1.TeamId, byChannel[c1.Id].TeamId)
+	assert.EqualValues(t, 10, byChannel[c1.Id].MsgCount)
+	assert.EqualValues(t, 19, byChannel[c1.Id].MsgCountRoot)
+	assert.NotNil(t, byChannel[c1.Id].NotifyProps)
+
+	require.Contains(t, byChannel, c3.Id)
+	assert.Equal(t, "", byChannel[c3.Id].TeamId)
+	assert.EqualValues(t, 0, byChannel[c3.Id].MsgCount)
+	assert.EqualValues(t, 2, byChannel[c3.Id].MentionCount)
+
+	// Archived channels are left out
+	require.NoError(t, ss.Channel().Delete(c1.Id, model.GetMillis()))
+	unreads, err = ss.Channel().GetChannelUnreadsForUser(uid)
+	require.NoError(t, err)
+	require.Len(t, unreads, 1)
+	assert.Equal(t, c3.Id, unreads[0].ChannelId)
+}
+
+func testChannelStoreUsersShareChannel(t *testing.T, ss store.Store) {
+	uid := model.NewId()
+	otherUid := model.NewId()
+	notifyPropsModel := model.GetDefaultChannelNotifyProps()
+
+	// A direct channel isn't a channel the users share
+	dm := &model.Channel{Name: model.GetDMNameFromIds(uid, otherUid), DisplayName: "Direct", Type: model.ChannelTypeDirect}
+	_, nErr := ss.Channel().SaveDirectChannel(dm,
+		&model.ChannelMember{UserId: uid, NotifyProps: notifyPropsModel},
+		&model.ChannelMember{UserId: otherUid, NotifyProps: notifyPropsModel})
+	require.NoError(t, nErr)
+
+	shared, err := ss.Channel().UsersShareChannel(uid, otherUid)
+	require.NoError(t, err)
+	assert.False(t, shared)
+
+	c1 := &model.Channel{TeamId: model.NewId(), Name: model.NewId(), DisplayName: "Shared", Type: model.ChannelTypeOpen}
+	_, nErr = ss.Channel().Save(c1, -1)
+	require.NoError(t, nErr)
+	for _, userID := range []string{uid, otherUid} {
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: c1.Id, UserId: userID, NotifyProps: notifyPropsModel})
+		require.NoError(t, err)
+	}
+
+	shared, err = ss.Channel().UsersShareChannel(uid, otherUid)
+	require.NoError(t, err)
+	assert.True(t, shared)
+
+	shared, err = ss.Channel().UsersShareChannel(otherUid, uid)
+	require.NoError(t, err)
+	assert.True(t, shared)
+
+	// Archived channels are left out
+	require.NoError(t, ss.Channel().Delete(c1.Id, model.GetMillis()))
+	shared, err = ss.Channel().UsersShareChannel(uid, otherUid)
+	require.NoError(t, err)
+	assert.False(t, shared)
+}
+
 func testChannelStoreGet(t *testing.T, ss store.Store, s SqlStore) {
 	o1 := model.Channel{}
 	o1.TeamId = model.NewId()
@@ -5141,6 +5237,88 @@ func testGetMemberCount(t *testing.T, ss store.Store) {
 	require.EqualValuesf(t, 2, count, "got incorrect member count %v", count)
 }
 
+func testChannelStoreMemberCounts(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	c1 := model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Channel1",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}
+	_, nErr := ss.Channel().Save(&c1, -1)
+	require.NoError(t, nErr)
+
+	requireCounts := func(t *testing.T, members, guests int64) {
+		t.Helper()
+		counts, err := ss.Channel().GetMemberCounts(c1.Id)
+		require.NoError(t, err)
+		assert.Equal(t, c1.Id, counts.ChannelId)
+		assert.Equal(t, members, counts.MemberCount)
+		assert.Equal(t, guests, counts.GuestCount)
+	}
+
+	saveMember := func(t *testing.T, deleteAt int64, guest bool) *model.User {
+		t.Helper()
+		u, err := ss.User().Save(&model.User{Email: MakeEmail(), DeleteAt: deleteAt})
+		require.NoError(t, err)
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   c1.Id,
+			UserId:      u.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			SchemeGuest: guest,
+			SchemeUser:  !guest,
+		})
+		require.NoError(t, err)
+		return u
+	}
+
+	t.Run("new channels have no members", func(t *testing.T) {
+		requireCounts(t, 0, 0)
+	})
+
+	u1 := saveMember(t, 0, false)
+	u2 := saveMember(t, 0, true)
+
+	t.Run("joining members are counted", func(t *testing.T) {
+		requireCounts(t, 2, 1)
+	})
+
+	t.Run("inactive members aren't counted", func(t *testing.T) {
+		saveMember(t, 10000, false)
+		requireCounts(t, 2, 1)
+	})
+
+	t.Run("leaving members are discounted", func(t *testing.T) {
+		require.NoError(t, ss.Channel().RemoveMember(c1.Id, u1.Id))
+		requireCounts(t, 1, 1)
+	})
+
+	t.Run("counts are reconciled with deactivated users", func(t *testing.T) {
+		u2.DeleteAt = model.GetMillis()
+		_, err := ss.User().Update(u2, true)
+		require.NoError(t, err)
+		requireCounts(t, 1, 1)
+
+		afterChannelId := ""
+		for {
+			lastChannelId, err := ss.Channel().ReconcileMemberCounts(afterChannelId, 1000)
+			require.NoError(t, err)
+			if lastChannelId == "" {
+				break
+			}
+			afterChannelId = lastChannelId
+		}
+		requireCounts(t, 0, 0)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		_, err := ss.Channel().GetMemberCounts(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
 func testGetMemberCountsByGroup(t *testing.T, ss store.Store) {
 	var memberCounts []*model.ChannelMemberCountByGroup
 	teamId := model.NewId()
@@ -6918,6 +7096,45 @@ func testChannelStoreGetPinnedPosts(t *testing.T, ss store.Store) {
 		require.Equal(t, posts.Posts[post2.Id].ReplyCount, int64(0))
 		require.Equal(t, posts.Posts[post3.Id].ReplyCount, int64(1))
 	})
+
+	t.Run("in the order set", func(t *testing.T) {
+		channelId := model.NewId()
+		userId := model.NewId()
+
+		var postIds []string
+		for i := 0; i < 4; i++ {
+			post, err := ss.Post().Save(&model.Post{
+				ChannelId: channelId,
+				UserId:    userId,
+				Message:   "message",
+				IsPinned:  true,
+			})
+			require.NoError(t, err)
+			postIds = append(postIds, post.Id)
+			time.Sleep(time.Millisecond)
+		}
+
+		posts, err := ss.Channel().GetPinnedPosts(channelId)
+		require.NoError(t, err)
+		require.Equal(t, postIds, posts.Order)
+
+		// Posts without a position follow the ordered ones from the oldest to the newest
+		require.NoError(t, ss.Channel().SetPinnedPostsOrder(channelId, []string{postIds[3], postIds[1]}))
+		posts, err = ss.Channel().GetPinnedPosts(channelId)
+		require.NoError(t, err)
+		require.Equal(t, []string{postIds[3], postIds[1], postIds[0], postIds[2]}, posts.Order)
+
+		// Setting the order again replaces it
+		require.NoError(t, ss.Channel().SetPinnedPostsOrder(channelId, []string{postIds[2]}))
+		posts, err = ss.Channel().GetPinnedPosts(channelId)
+		require.NoError(t, err)
+		require.Equal(t, []string{postIds[2], postIds[0], postIds[1], postIds[3]}, posts.Order)
+
+		require.NoError(t, ss.Channel().SetPinnedPostsOrder(channelId, nil))
+		posts, err = ss.Channel().GetPinnedPosts(channelId)
+		require.NoError(t, err)
+		require.Equal(t, postIds, posts.Order)
+	})
 }
 
 func testChannelStoreGetPinnedPostCount(t *testing.T, ss store.Store) {
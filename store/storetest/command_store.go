@@ -7,6 +7,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -21,6 +22,7 @@ func TestCommandStore(t *testing.T, ss store.Store) {
 	t.Run("Delete", func(t *testing.T) { testCommandStoreDelete(t, ss) })
 	t.Run("DeleteByTeam", func(t *testing.T) { testCommandStoreDeleteByTeam(t, ss) })
 	t.Run("DeleteByUser", func(t *testing.T) { testCommandStoreDeleteByUser(t, ss) })
+	t.Run("PermanentDeleteSoftDeletedBatch", func(t *testing.T) { testCommandStorePermanentDeleteSoftDeletedBatch(t, ss) })
 	t.Run("Update", func(t *testing.T) { testCommandStoreUpdate(t, ss) })
 	t.Run("CommandCount", func(t *testing.T) { testCommandCount(t, ss) })
 }
@@ -141,6 +143,41 @@ func testCommandStoreDelete(t *testing.T, ss store.Store) {
 	require.True(t, errors.As(err, &nfErr))
 }
 
+func testCommandStorePermanentDeleteSoftDeletedBatch(t *testing.T, ss store.Store) {
+	newCommand := func() *model.Command {
+		command, err := ss.Command().Save(&model.Command{
+			CreatorId: model.NewId(),
+			Method:    model.CommandMethodPost,
+			TeamId:    model.NewId(),
+			URL:       "http://nowhere.com/",
+			Trigger:   "trigger",
+		})
+		require.NoError(t, err)
+		return command
+	}
+
+	active := newCommand()
+	deletedLongAgo := newCommand()
+	deletedRecently := newCommand()
+	require.NoError(t, ss.Command().Delete(deletedLongAgo.Id, 1000))
+	require.NoError(t, ss.Command().Delete(deletedRecently.Id, 3000))
+
+	deleted, err := ss.Command().PermanentDeleteSoftDeletedBatch(2000, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = ss.Command().PermanentDeleteSoftDeletedBatch(2000, 1000)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = ss.Command().PermanentDeleteSoftDeletedBatch(4000, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, err = ss.Command().Get(active.Id)
+	require.NoError(t, err)
+}
+
 func testCommandStoreDeleteByTeam(t *testing.T, ss store.Store) {
 	o1 := &model.Command{}
 	o1.CreatorId = model.NewId()
@@ -201,10 +238,15 @@ func testCommandStoreUpdate(t *testing.T, ss store.Store) {
 	require.NoError(t, nErr)
 
 	o1.Token = model.NewId()
+	o1.PayloadFormat = model.PayloadFormatSlack
 
 	_, nErr = ss.Command().Update(o1)
 	require.NoError(t, nErr)
 
+	r1, nErr := ss.Command().Get(o1.Id)
+	require.NoError(t, nErr)
+	require.Equal(t, model.PayloadFormatSlack, r1.PayloadFormat)
+
 	o1.URL = "junk"
 
 	_, err := ss.Command().Update(o1)
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestConnectivityTestResultStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGetHistory", func(t *testing.T) { testConnectivityTestResultSaveAndGetHistory(t, ss) })
+	t.Run("Cleanup", func(t *testing.T) { testConnectivityTestResultCleanup(t, ss) })
+}
+
+func newTestConnectivityTestResult(service string, createAt int64) *model.ConnectivityTestResult {
+	return &model.ConnectivityTestResult{
+		Service:   service,
+		UserId:    model.NewId(),
+		CreateAt:  createAt,
+		LatencyMs: 42,
+		Success:   true,
+	}
+}
+
+func testConnectivityTestResultSaveAndGetHistory(t *testing.T, ss store.Store) {
+	require.NoError(t, ss.ConnectivityTestResult().Cleanup(math.MaxInt64, 100))
+
+	first, err := ss.ConnectivityTestResult().Save(newTestConnectivityTestResult(model.ConnectivityTestServiceSMTP, 1000))
+	require.NoError(t, err)
+	require.NotEmpty(t, first.Id)
+
+	failed := newTestConnectivityTestResult(model.ConnectivityTestServiceSMTP, 2000)
+	failed.Success = false
+	failed.Error = "dial tcp: connection refused"
+	_, err = ss.ConnectivityTestResult().Save(failed)
+	require.NoError(t, err)
+
+	s3, err := ss.ConnectivityTestResult().Save(newTestConnectivityTestResult(model.ConnectivityTestServiceS3, 3000))
+	require.NoError(t, err)
+
+	_, err = ss.ConnectivityTestResult().Save(newTestConnectivityTestResult("ftp", 4000))
+	require.Error(t, err)
+
+	results, err := ss.ConnectivityTestResult().GetHistory(model.ConnectivityTestServiceSMTP, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, failed.Id, results[0].Id, "newest results should come first")
+	assert.False(t, results[0].Success)
+	assert.Equal(t, failed.Error, results[0].Error)
+	assert.Equal(t, first, results[1])
+
+	results, err = ss.ConnectivityTestResult().GetHistory(model.ConnectivityTestServiceSMTP, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, first.Id, results[0].Id)
+
+	results, err = ss.ConnectivityTestResult().GetHistory("", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, s3.Id, results[0].Id)
+}
+
+func testConnectivityTestResultCleanup(t *testing.T, ss store.Store) {
+	require.NoError(t, ss.ConnectivityTestResult().Cleanup(math.MaxInt64, 100))
+
+	_, err := ss.ConnectivityTestResult().Save(newTestConnectivityTestResult(model.ConnectivityTestServiceElasticsearch, 1000))
+	require.NoError(t, err)
+	_, err = ss.ConnectivityTestResult().Save(newTestConnectivityTestResult(model.ConnectivityTestServiceElasticsearch, 2000))
+	require.NoError(t, err)
+	recent, err := ss.ConnectivityTestResult().Save(newTestConnectivityTestResult(model.ConnectivityTestServiceElasticsearch, model.GetMillis()))
+	require.NoError(t, err)
+
+	err = ss.ConnectivityTestResult().Cleanup(3000, 1)
+	require.NoError(t, err)
+
+	results, err := ss.ConnectivityTestResult().GetHistory(model.ConnectivityTestServiceElasticsearch, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, recent.Id, results[0].Id)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestDirectMessageRequestStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testDirectMessageRequestSaveGetDelete(t, ss) })
+	t.Run("GetPendingForRecipient", func(t *testing.T) { testDirectMessageRequestGetPendingForRecipient(t, ss) })
+}
+
+func testDirectMessageRequestSaveGetDelete(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	requesterID := model.NewId()
+	recipientID := model.NewId()
+
+	_, err := ss.DirectMessageRequest().Save(&model.DirectMessageRequest{ChannelId: channelID, RequesterId: requesterID, RecipientId: requesterID})
+	require.Error(t, err)
+
+	request, err := ss.DirectMessageRequest().Save(&model.DirectMessageRequest{ChannelId: channelID, RequesterId: requesterID, RecipientId: recipientID})
+	require.NoError(t, err)
+	assert.NotZero(t, request.CreateAt)
+	assert.Equal(t, model.DirectMessageRequestStatusPending, request.Status)
+
+	// A channel has a single request.
+	_, err = ss.DirectMessageRequest().Save(&model.DirectMessageRequest{ChannelId: channelID, RequesterId: recipientID, RecipientId: requesterID})
+	var cErr *store.ErrConflict
+	require.True(t, errors.As(err, &cErr))
+
+	require.NoError(t, ss.DirectMessageRequest().UpdateStatus(channelID, model.DirectMessageRequestStatusDeclined, request.CreateAt+1))
+
+	request, err = ss.DirectMessageRequest().Get(channelID)
+	require.NoError(t, err)
+	assert.Equal(t, requesterID, request.RequesterId)
+	assert.Equal(t, recipientID, request.RecipientId)
+	assert.Equal(t, model.DirectMessageRequestStatusDeclined, request.Status)
+	assert.Equal(t, request.CreateAt+1, request.UpdateAt)
+
+	require.NoError(t, ss.DirectMessageRequest().Delete(channelID))
+
+	_, err = ss.DirectMessageRequest().Get(channelID)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testDirectMessageRequestGetPendingForRecipient(t *testing.T, ss store.Store) {
+	recipientID := model.NewId()
+
+	older, err := ss.DirectMessageRequest().Save(&model.DirectMessageRequest{ChannelId: model.NewId(), RequesterId: model.NewId(), RecipientId: recipientID, CreateAt: 1000})
+	require.NoError(t, err)
+	defer ss.DirectMessageRequest().Delete(older.ChannelId)
+
+	newer, err := ss.DirectMessageRequest().Save(&model.DirectMessageRequest{ChannelId: model.NewId(), RequesterId: model.NewId(), RecipientId: recipientID, CreateAt: 2000})
+	require.NoError(t, err)
+	defer ss.DirectMessageRequest().Delete(newer.ChannelId)
+
+	declined, err := ss.DirectMessageRequest().Save(&model.DirectMessageRequest{ChannelId: model.NewId(), RequesterId: model.NewId(), RecipientId: recipientID, Status: model.DirectMessageRequestStatusDeclined})
+	require.NoError(t, err)
+	defer ss.DirectMessageRequest().Delete(declined.ChannelId)
+
+	other, err := ss.DirectMessageRequest().Save(&model.DirectMessageRequest{ChannelId: model.NewId(), RequesterId: recipientID, RecipientId: model.NewId()})
+	require.NoError(t, err)
+	defer ss.DirectMessageRequest().Delete(other.ChannelId)
+
+	requests, err := ss.DirectMessageRequest().GetPendingForRecipient(recipientID)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, newer.ChannelId, requests[0].ChannelId)
+	assert.Equal(t, older.ChannelId, requests[1].ChannelId)
+
+	requests, err = ss.DirectMessageRequest().GetPendingForRecipient(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+}
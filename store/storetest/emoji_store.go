@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ func TestEmojiStore(t *testing.T, ss store.Store) {
 	t.Run("EmojiGetMultipleByName", func(t *testing.T) { testEmojiGetMultipleByName(t, ss) })
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, ss) })
+	t.Run("EmojiSaveForTeam", func(t *testing.T) { testEmojiSaveForTeam(t, ss) })
 }
 
 func testEmojiSaveDelete(t *testing.T, ss store.Store) {
@@ -303,3 +305,44 @@ func testEmojiSearch(t *testing.T, ss store.Store) {
 		assert.Equal(t, shouldFind[i], found, emoji.Name)
 	}
 }
+
+func testEmojiSaveForTeam(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+
+	emoji1, err := ss.Emoji().SaveForTeam(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId(), TeamId: teamID}, 2)
+	require.NoError(t, err)
+	defer ss.Emoji().Delete(emoji1, time.Now().Unix())
+
+	emoji2, err := ss.Emoji().SaveForTeam(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId(), TeamId: teamID}, 2)
+	require.NoError(t, err)
+
+	_, err = ss.Emoji().SaveForTeam(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId(), TeamId: teamID}, 2)
+	var ltErr *store.ErrLimitExceeded
+	require.True(t, errors.As(err, &ltErr), "should enforce the team quota")
+
+	// Emoji of other teams and instance wide emoji don't count towards the quota
+	otherEmoji, err := ss.Emoji().SaveForTeam(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId(), TeamId: model.NewId()}, 2)
+	require.NoError(t, err)
+	defer ss.Emoji().Delete(otherEmoji, time.Now().Unix())
+
+	_, err = ss.Emoji().SaveForTeam(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId()}, 2)
+	require.Error(t, err, "should require a team")
+
+	count, err := ss.Emoji().CountByTeam(teamID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	list, err := ss.Emoji().GetListForTeam(teamID, 0, 10, model.EmojiSortByName)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	require.NoError(t, ss.Emoji().Delete(emoji2, time.Now().Unix()))
+
+	count, err = ss.Emoji().CountByTeam(teamID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	unlimited, err := ss.Emoji().SaveForTeam(&model.Emoji{CreatorId: model.NewId(), Name: model.NewId(), TeamId: teamID}, -1)
+	require.NoError(t, err)
+	defer ss.Emoji().Delete(unlimited, time.Now().Unix())
+}
@@ -19,6 +19,11 @@ import (
 func TestFileInfoStore(t *testing.T, ss store.Store) {
 	t.Run("FileInfoSaveGet", func(t *testing.T) { testFileInfoSaveGet(t, ss) })
 	t.Run("FileInfoSaveGetByPath", func(t *testing.T) { testFileInfoSaveGetByPath(t, ss) })
+	t.Run("FileInfoGetByContentHash", func(t *testing.T) { testFileInfoGetByContentHash(t, ss) })
+	t.Run("FileInfoSetAltText", func(t *testing.T) { testFileInfoSetAltText(t, ss) })
+	t.Run("FileInfoGetOrphaned", func(t *testing.T) { testFileInfoGetOrphaned(t, ss) })
+	t.Run("FileInfoGetExpiredForRetentionPolicies", func(t *testing.T) { testFileInfoGetExpiredForRetentionPolicies(t, ss) })
+	t.Run("FileInfoGetReferencedIds", func(t *testing.T) { testFileInfoGetReferencedIds(t, ss) })
 	t.Run("FileInfoGetForPost", func(t *testing.T) { testFileInfoGetForPost(t, ss) })
 	t.Run("FileInfoGetForUser", func(t *testing.T) { testFileInfoGetForUser(t, ss) })
 	t.Run("FileInfoGetWithOptions", func(t *testing.T) { testFileInfoGetWithOptions(t, ss) })
@@ -96,6 +101,276 @@ func testFileInfoSaveGetByPath(t *testing.T, ss store.Store) {
 	}()
 }
 
+func testFileInfoSetAltText(t *testing.T, ss store.Store) {
+	info, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "image.png",
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(info.Id)
+
+	require.NoError(t, ss.FileInfo().SetAltText(info.Id, "a cat", true))
+	rinfo, err := ss.FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "a cat", rinfo.AltText)
+	assert.True(t, rinfo.AltTextGenerated)
+
+	require.NoError(t, ss.FileInfo().SetAltText(info.Id, "my cat sleeping", false))
+	rinfo, err = ss.FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "my cat sleeping", rinfo.AltText)
+	assert.False(t, rinfo.AltTextGenerated)
+
+	// A generated alt text doesn't replace the one of the author.
+	require.NoError(t, ss.FileInfo().SetAltText(info.Id, "a cat", true))
+	rinfo, err = ss.FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "my cat sleeping", rinfo.AltText)
+	assert.False(t, rinfo.AltTextGenerated)
+}
+
+func testFileInfoGetByContentHash(t *testing.T, ss store.Store) {
+	hash := model.NewId() + model.NewId()
+
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "message",
+	})
+	require.NoError(t, err)
+
+	source, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId:   post.UserId,
+		PostId:      post.Id,
+		Path:        "file.txt",
+		ContentHash: hash,
+		CreateAt:    1000,
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(source.Id)
+
+	dedup, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId:   post.UserId,
+		PostId:      post.Id,
+		Path:        source.Path,
+		ContentHash: hash,
+		DedupOf:     source.Id,
+		CreateAt:    500,
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(dedup.Id)
+
+	t.Run("returns the file owning the stored content", func(t *testing.T) {
+		info, err := ss.FileInfo().GetByContentHash(hash, post.ChannelId)
+		require.NoError(t, err)
+		assert.Equal(t, source.Id, info.Id)
+		assert.Equal(t, hash, info.ContentHash)
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		_, err := ss.FileInfo().GetByContentHash(model.NewId(), post.ChannelId)
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("other channel", func(t *testing.T) {
+		_, err := ss.FileInfo().GetByContentHash(hash, model.NewId())
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("count references", func(t *testing.T) {
+		count, err := ss.FileInfo().CountDedupReferences(source.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = ss.FileInfo().CountDedupReferences(dedup.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func testFileInfoGetOrphaned(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "message",
+	})
+	require.NoError(t, err)
+
+	save := func(postID string, createAt int64) *model.FileInfo {
+		info, err := ss.FileInfo().Save(&model.FileInfo{
+			CreatorId: model.NewId(),
+			PostId:    postID,
+			Path:      "file.txt",
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { ss.FileInfo().PermanentDelete(info.Id) })
+		return info
+	}
+
+	attached := save(post.Id, 1000)
+	unattached := save("", 1000)
+	orphaned := save(model.NewId(), 1000)
+	recent := save(model.NewId(), 3000)
+	deleted := save(model.NewId(), 1000)
+	_, err = ss.FileInfo().DeleteForPost(deleted.PostId)
+	require.NoError(t, err)
+
+	getIds := func(createdBefore int64, afterID string, limit int) []string {
+		infos, err := ss.FileInfo().GetOrphaned(createdBefore, afterID, limit)
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, info := range infos {
+			ids = append(ids, info.Id)
+		}
+		return ids
+	}
+
+	ids := getIds(2000, "", 1000)
+	assert.Contains(t, ids, orphaned.Id)
+	assert.Contains(t, ids, deleted.Id, "soft deleted files of purged posts are orphaned too")
+	assert.NotContains(t, ids, attached.Id)
+	assert.NotContains(t, ids, unattached.Id, "files not attached yet are not orphaned")
+	assert.NotContains(t, ids, recent.Id)
+	assert.True(t, sort.StringsAreSorted(ids))
+
+	t.Run("pagination", func(t *testing.T) {
+		first := getIds(2000, "", 1)
+		require.Len(t, first, 1)
+		next := getIds(2000, first[0], 1000)
+		assert.NotContains(t, next, first[0])
+		assert.Equal(t, ids, append(first, next...))
+	})
+}
+
+func testFileInfoGetExpiredForRetentionPolicies(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        "team" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	saveChannel := func() *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      team.Id,
+			DisplayName: "DisplayName",
+			Name:        "channel" + model.NewId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+		return channel
+	}
+	teamChannel := saveChannel()
+	policyChannel := saveChannel()
+
+	teamPolicy, err := ss.RetentionPolicy().Save(&model.RetentionPolicyWithTeamAndChannelIDs{
+		RetentionPolicy: model.RetentionPolicy{
+			DisplayName:      "Files only",
+			PostDurationDays: model.NewInt64(-1),
+			FileDurationDays: model.NewInt64(1),
+		},
+		TeamIDs: []string{team.Id},
+	})
+	require.NoError(t, err)
+	defer ss.RetentionPolicy().Delete(teamPolicy.ID)
+	assert.Equal(t, int64(1), *teamPolicy.FileDurationDays)
+
+	// The channel policy, which keeps the files, overrides the team policy.
+	channelPolicy, err := ss.RetentionPolicy().Save(&model.RetentionPolicyWithTeamAndChannelIDs{
+		RetentionPolicy: model.RetentionPolicy{
+			DisplayName:      "Keep everything",
+			PostDurationDays: model.NewInt64(-1),
+		},
+		ChannelIDs: []string{policyChannel.Id},
+	})
+	require.NoError(t, err)
+	defer ss.RetentionPolicy().Delete(channelPolicy.ID)
+	assert.Equal(t, int64(-1), *channelPolicy.FileDurationDays)
+
+	save := func(channelID string, createAt int64) *model.FileInfo {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   "message",
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		info, err := ss.FileInfo().Save(&model.FileInfo{
+			CreatorId: post.UserId,
+			PostId:    post.Id,
+			Path:      "file.txt",
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { ss.FileInfo().PermanentDelete(info.Id) })
+		return info
+	}
+
+	now := int64(10 * model.DayInMilliseconds)
+	expired := save(teamChannel.Id, now-2*model.DayInMilliseconds)
+	recent := save(teamChannel.Id, now-model.DayInMilliseconds/2)
+	kept := save(policyChannel.Id, now-2*model.DayInMilliseconds)
+	labeled := save(teamChannel.Id, now-2*model.DayInMilliseconds)
+	_, err = ss.PostRetentionLabel().Save(&model.PostRetentionLabel{PostId: labeled.PostId, Label: "retain-7-years", ExpireAt: now * 1000, CreatorId: model.NewId()})
+	require.NoError(t, err)
+	defer ss.PostRetentionLabel().Delete(labeled.PostId)
+
+	infos, err := ss.FileInfo().GetExpiredForRetentionPolicies(now, "", 1000)
+	require.NoError(t, err)
+	ids := []string{}
+	for _, info := range infos {
+		ids = append(ids, info.Id)
+	}
+	assert.Contains(t, ids, expired.Id)
+	assert.NotContains(t, ids, recent.Id)
+	assert.NotContains(t, ids, kept.Id, "the channel policy overrides the team policy")
+	assert.NotContains(t, ids, labeled.Id, "the retention label of the post overrides the policies")
+
+	infos, err = ss.FileInfo().GetExpiredForRetentionPolicies(now, expired.Id, 1000)
+	require.NoError(t, err)
+	for _, info := range infos {
+		assert.Greater(t, info.Id, expired.Id)
+	}
+}
+
+func testFileInfoGetReferencedIds(t *testing.T, ss store.Store) {
+	source, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "file.txt",
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(source.Id)
+
+	dedup, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      source.Path,
+		DedupOf:   model.NewId(),
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(dedup.Id)
+
+	deleted, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "file.txt",
+		DeleteAt:  123,
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(deleted.Id)
+
+	unknown := model.NewId()
+	ids, err := ss.FileInfo().GetReferencedIds([]string{source.Id, dedup.DedupOf, deleted.Id, unknown})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{source.Id, dedup.DedupOf, deleted.Id}, ids)
+
+	ids, err = ss.FileInfo().GetReferencedIds([]string{})
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
 func testFileInfoGetForPost(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	postId := model.NewId()
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestMfaBackupCodeStore(t *testing.T, ss store.Store) {
+	t.Run("ReplaceForUserAndUse", func(t *testing.T) { testMfaBackupCodeReplaceForUserAndUse(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testMfaBackupCodePermanentDeleteByUser(t, ss) })
+}
+
+func testMfaBackupCodeReplaceForUserAndUse(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	require.NoError(t, ss.MfaBackupCode().ReplaceForUser(userID, []string{"hash1", "hash2"}, 1000))
+	require.NoError(t, ss.MfaBackupCode().ReplaceForUser(otherUserID, []string{"hash3"}, 1000))
+
+	count, err := ss.MfaBackupCode().CountUnusedForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	used, err := ss.MfaBackupCode().Use(userID, "hash3", 2000)
+	require.NoError(t, err)
+	assert.False(t, used, "the codes of other users can't be used")
+
+	used, err = ss.MfaBackupCode().Use(userID, "hash1", 2000)
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = ss.MfaBackupCode().Use(userID, "hash1", 3000)
+	require.NoError(t, err)
+	assert.False(t, used, "a code can only be used once")
+
+	count, err = ss.MfaBackupCode().CountUnusedForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Replacing the codes invalidates the previous ones.
+	require.NoError(t, ss.MfaBackupCode().ReplaceForUser(userID, []string{"hash4"}, 4000))
+	used, err = ss.MfaBackupCode().Use(userID, "hash2", 5000)
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	count, err = ss.MfaBackupCode().CountUnusedForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testMfaBackupCodePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	require.NoError(t, ss.MfaBackupCode().ReplaceForUser(userID, []string{"hash1"}, 1000))
+	require.NoError(t, ss.MfaBackupCode().ReplaceForUser(otherUserID, []string{"hash1"}, 1000))
+
+	require.NoError(t, ss.MfaBackupCode().PermanentDeleteByUser(userID))
+
+	count, err := ss.MfaBackupCode().CountUnusedForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	count, err = ss.MfaBackupCode().CountUnusedForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
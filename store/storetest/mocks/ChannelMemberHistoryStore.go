@@ -35,6 +35,29 @@ func (_m *ChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error
 	return r0, r1
 }
 
+// GetChannelsForUserDuring provides a mock function with given fields: userID, startTime, endTime
+func (_m *ChannelMemberHistoryStore) GetChannelsForUserDuring(userID string, startTime int64, endTime int64) ([]*model.ChannelMemberHistory, error) {
+	ret := _m.Called(userID, startTime, endTime)
+
+	var r0 []*model.ChannelMemberHistory
+	if rf, ok := ret.Get(0).(func(string, int64, int64) []*model.ChannelMemberHistory); ok {
+		r0 = rf(userID, startTime, endTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = rf(userID, startTime, endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelsLeftSince provides a mock function with given fields: userID, since
 func (_m *ChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	ret := _m.Called(userID, since)
@@ -674,6 +674,29 @@ func (_m *ChannelStore) GetChannelUnread(channelID string, userID string) (*mode
 	return r0, r1
 }
 
+// GetChannelUnreadsForUser provides a mock function with given fields: userID
+func (_m *ChannelStore) GetChannelUnreadsForUser(userID string) ([]*model.ChannelUnread, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*model.ChannelUnread
+	if rf, ok := ret.Get(0).(func(string) []*model.ChannelUnread); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelUnread)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannels provides a mock function with given fields: teamID, userID, opts
 func (_m *ChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
 	ret := _m.Called(teamID, userID, opts)
@@ -1027,6 +1050,29 @@ func (_m *ChannelStore) GetMemberCountFromCache(channelID string) int64 {
 	return r0
 }
 
+// GetMemberCounts provides a mock function with given fields: channelID
+func (_m *ChannelStore) GetMemberCounts(channelID string) (*model.ChannelMemberCounts, error) {
+	ret := _m.Called(channelID)
+
+	var r0 *model.ChannelMemberCounts
+	if rf, ok := ret.Get(0).(func(string) *model.ChannelMemberCounts); ok {
+		r0 = rf(channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMemberCounts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMemberCountsByGroup provides a mock function with given fields: ctx, channelID, includeTimezones
 func (_m *ChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
 	ret := _m.Called(ctx, channelID, includeTimezones)
@@ -1717,6 +1763,27 @@ func (_m *ChannelStore) PermanentDeleteMembersByUser(userID string) error {
 	return r0
 }
 
+// ReconcileMemberCounts provides a mock function with given fields: afterChannelID, limit
+func (_m *ChannelStore) ReconcileMemberCounts(afterChannelID string, limit int) (string, error) {
+	ret := _m.Called(afterChannelID, limit)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(afterChannelID, limit)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterChannelID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveAllDeactivatedMembers provides a mock function with given fields: channelID
 func (_m *ChannelStore) RemoveAllDeactivatedMembers(channelID string) error {
 	ret := _m.Called(channelID)
@@ -2038,6 +2105,20 @@ func (_m *ChannelStore) SetDeleteAt(channelID string, deleteAt int64, updateAt i
 	return r0
 }
 
+// SetPinnedPostsOrder provides a mock function with given fields: channelID, postIDs
+func (_m *ChannelStore) SetPinnedPostsOrder(channelID string, postIDs []string) error {
+	ret := _m.Called(channelID, postIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(channelID, postIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetShared provides a mock function with given fields: channelId, shared
 func (_m *ChannelStore) SetShared(channelId string, shared bool) error {
 	ret := _m.Called(channelId, shared)
@@ -2298,3 +2379,24 @@ func (_m *ChannelStore) UserBelongsToChannels(userID string, channelIds []string
 
 	return r0, r1
 }
+
+// UsersShareChannel provides a mock function with given fields: userID, otherUserID
+func (_m *ChannelStore) UsersShareChannel(userID string, otherUserID string) (bool, error) {
+	ret := _m.Called(userID, otherUserID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(userID, otherUserID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, otherUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -146,6 +146,27 @@ func (_m *CommandStore) PermanentDeleteByUser(userID string) error {
 	return r0
 }
 
+// PermanentDeleteSoftDeletedBatch provides a mock function with given fields: before, limit
+func (_m *CommandStore) PermanentDeleteSoftDeletedBatch(before int64, limit int64) (int64, error) {
+	ret := _m.Called(before, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: webhook
 func (_m *CommandStore) Save(webhook *model.Command) (*model.Command, error) {
 	ret := _m.Called(webhook)
@@ -16,6 +16,27 @@ type EmojiStore struct {
 	mock.Mock
 }
 
+// CountByTeam provides a mock function with given fields: teamID
+func (_m *EmojiStore) CountByTeam(teamID string) (int64, error) {
+	ret := _m.Called(teamID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(teamID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: emoji, time
 func (_m *EmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	ret := _m.Called(emoji, time)
@@ -99,6 +120,29 @@ func (_m *EmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoj
 	return r0, r1
 }
 
+// GetListForTeam provides a mock function with given fields: teamID, offset, limit, sort
+func (_m *EmojiStore) GetListForTeam(teamID string, offset int, limit int, sort string) ([]*model.Emoji, error) {
+	ret := _m.Called(teamID, offset, limit, sort)
+
+	var r0 []*model.Emoji
+	if rf, ok := ret.Get(0).(func(string, int, int, string) []*model.Emoji); ok {
+		r0 = rf(teamID, offset, limit, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Emoji)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int, string) error); ok {
+		r1 = rf(teamID, offset, limit, sort)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMultipleByName provides a mock function with given fields: names
 func (_m *EmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
 	ret := _m.Called(names)
@@ -145,6 +189,29 @@ func (_m *EmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	return r0, r1
 }
 
+// SaveForTeam provides a mock function with given fields: emoji, maxEmojiPerTeam
+func (_m *EmojiStore) SaveForTeam(emoji *model.Emoji, maxEmojiPerTeam int64) (*model.Emoji, error) {
+	ret := _m.Called(emoji, maxEmojiPerTeam)
+
+	var r0 *model.Emoji
+	if rf, ok := ret.Get(0).(func(*model.Emoji, int64) *model.Emoji); ok {
+		r0 = rf(emoji, maxEmojiPerTeam)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Emoji)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.Emoji, int64) error); ok {
+		r1 = rf(emoji, maxEmojiPerTeam)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: name, prefixOnly, limit
 func (_m *EmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 	ret := _m.Called(name, prefixOnly, limit)
@@ -54,6 +54,27 @@ func (_m *FileInfoStore) CountAll() (int64, error) {
 	return r0, r1
 }
 
+// CountDedupReferences provides a mock function with given fields: fileID
+func (_m *FileInfoStore) CountDedupReferences(fileID string) (int64, error) {
+	ret := _m.Called(fileID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(fileID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(fileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteForPost provides a mock function with given fields: postID
 func (_m *FileInfoStore) DeleteForPost(postID string) (string, error) {
 	ret := _m.Called(postID)
@@ -98,6 +119,29 @@ func (_m *FileInfoStore) Get(id string) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetByContentHash provides a mock function with given fields: hash, channelID
+func (_m *FileInfoStore) GetByContentHash(hash string, channelID string) (*model.FileInfo, error) {
+	ret := _m.Called(hash, channelID)
+
+	var r0 *model.FileInfo
+	if rf, ok := ret.Get(0).(func(string, string) *model.FileInfo); ok {
+		r0 = rf(hash, channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(hash, channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByIds provides a mock function with given fields: ids
 func (_m *FileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
 	ret := _m.Called(ids)
@@ -144,6 +188,29 @@ func (_m *FileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetExpiredForRetentionPolicies provides a mock function with given fields: now, afterID, limit
+func (_m *FileInfoStore) GetExpiredForRetentionPolicies(now int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	ret := _m.Called(now, afterID, limit)
+
+	var r0 []*model.FileInfo
+	if rf, ok := ret.Get(0).(func(int64, string, int) []*model.FileInfo); ok {
+		r0 = rf(now, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, int) error); ok {
+		r1 = rf(now, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFilesBatchForIndexing provides a mock function with given fields: startTime, startFileID, limit
 func (_m *FileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.FileForIndexing, error) {
 	ret := _m.Called(startTime, startFileID, limit)
@@ -236,6 +303,52 @@ func (_m *FileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetOrphaned provides a mock function with given fields: createdBefore, afterID, limit
+func (_m *FileInfoStore) GetOrphaned(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	ret := _m.Called(createdBefore, afterID, limit)
+
+	var r0 []*model.FileInfo
+	if rf, ok := ret.Get(0).(func(int64, string, int) []*model.FileInfo); ok {
+		r0 = rf(createdBefore, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, int) error); ok {
+		r1 = rf(createdBefore, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReferencedIds provides a mock function with given fields: fileIDs
+func (_m *FileInfoStore) GetReferencedIds(fileIDs []string) ([]string, error) {
+	ret := _m.Called(fileIDs)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func([]string) []string); ok {
+		r0 = rf(fileIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(fileIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWithOptions provides a mock function with given fields: page, perPage, opt
 func (_m *FileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
 	ret := _m.Called(page, perPage, opt)
@@ -366,6 +479,20 @@ func (_m *FileInfoStore) Search(paramsList []*model.SearchParams, userID string,
 	return r0, r1
 }
 
+// SetAltText provides a mock function with given fields: fileID, altText, generated
+func (_m *FileInfoStore) SetAltText(fileID string, altText string, generated bool) error {
+	ret := _m.Called(fileID, altText, generated)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(fileID, altText, generated)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetContent provides a mock function with given fields: fileID, content
 func (_m *FileInfoStore) SetContent(fileID string, content string) error {
 	ret := _m.Called(fileID, content)
@@ -167,6 +167,27 @@ func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.Plugin
 	return r0, r1
 }
 
+// SetMultiple provides a mock function with given fields: pluginID, operations
+func (_m *PluginStore) SetMultiple(pluginID string, operations []*model.PluginKVSetOperation) (bool, error) {
+	ret := _m.Called(pluginID, operations)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, []*model.PluginKVSetOperation) bool); ok {
+		r0 = rf(pluginID, operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []*model.PluginKVSetOperation) error); ok {
+		r1 = rf(pluginID, operations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetWithOptions provides a mock function with given fields: pluginID, key, value, options
 func (_m *PluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 	ret := _m.Called(pluginID, key, value, options)
@@ -146,6 +146,29 @@ func (_m *PostStore) Get(ctx context.Context, id string, opts model.GetPostsOpti
 	return r0, r1
 }
 
+// GetChannelPostsForExportAfter provides a mock function with given fields: channelID, afterCreateAt, afterID, limit
+func (_m *PostStore) GetChannelPostsForExportAfter(channelID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
+	ret := _m.Called(channelID, afterCreateAt, afterID, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, int64, string, int) []*model.Post); ok {
+		r0 = rf(channelID, afterCreateAt, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, string, int) error); ok {
+		r1 = rf(channelID, afterCreateAt, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID
 func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string) ([]*model.DirectPostForExport, error) {
 	ret := _m.Called(limit, afterID)
@@ -252,6 +275,27 @@ func (_m *PostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset
 	return r0, r1
 }
 
+// GetLastPostAtByUserSince provides a mock function with given fields: options, userId
+func (_m *PostStore) GetLastPostAtByUserSince(options model.GetPostsSinceOptions, userId string) (int64, error) {
+	ret := _m.Called(options, userId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(model.GetPostsSinceOptions, string) int64); ok {
+		r0 = rf(options, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(model.GetPostsSinceOptions, string) error); ok {
+		r1 = rf(options, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastPostRowCreateAt provides a mock function with given fields:
 func (_m *PostStore) GetLastPostRowCreateAt() (int64, error) {
 	ret := _m.Called()
@@ -679,6 +723,29 @@ func (_m *PostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error)
 	return r0, r1
 }
 
+// GetUserPostsForExportAfter provides a mock function with given fields: userID, afterCreateAt, afterID, limit
+func (_m *PostStore) GetUserPostsForExportAfter(userID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
+	ret := _m.Called(userID, afterCreateAt, afterID, limit)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, int64, string, int) []*model.Post); ok {
+		r0 = rf(userID, afterCreateAt, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, string, int) error); ok {
+		r1 = rf(userID, afterCreateAt, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasAutoResponsePostByUserSince provides a mock function with given fields: options, userId
 func (_m *PostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
 	ret := _m.Called(options, userId)
@@ -52,6 +52,20 @@ func (_m *Store) Bot() store.BotStore {
 	return r0
 }
 
+// ChangeEvent provides a mock function with given fields:
+func (_m *Store) ChangeEvent() store.ChangeEventStore {
+	ret := _m.Called()
+
+	var r0 store.ChangeEventStore
+	if rf, ok := ret.Get(0).(func() store.ChangeEventStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.ChangeEventStore)
+	}
+
+	return r0
+}
+
 // Channel provides a mock function with given fields:
 func (_m *Store) Channel() store.ChannelStore {
 	ret := _m.Called()
@@ -68,6 +82,54 @@ func (_m *Store) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelBookmark provides a mock function with given fields:
+func (_m *Store) ChannelBookmark() store.ChannelBookmarkStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelBookmarkStore
+	if rf, ok := ret.Get(0).(func() store.ChannelBookmarkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelBookmarkStore)
+		}
+	}
+
+	return r0
+}
+
+// ChannelEvent provides a mock function with given fields:
+func (_m *Store) ChannelEvent() store.ChannelEventStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelEventStore
+	if rf, ok := ret.Get(0).(func() store.ChannelEventStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelEventStore)
+		}
+	}
+
+	return r0
+}
+
+// ChannelJoinRequest provides a mock function with given fields:
+func (_m *Store) ChannelJoinRequest() store.ChannelJoinRequestStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelJoinRequestStore
+	if rf, ok := ret.Get(0).(func() store.ChannelJoinRequestStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelJoinRequestStore)
+		}
+	}
+
+	return r0
+}
+
 // ChannelMemberHistory provides a mock function with given fields:
 func (_m *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	ret := _m.Called()
@@ -84,6 +146,22 @@ func (_m *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return r0
 }
 
+// ChannelMemberTimeout provides a mock function with given fields:
+func (_m *Store) ChannelMemberTimeout() store.ChannelMemberTimeoutStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelMemberTimeoutStore
+	if rf, ok := ret.Get(0).(func() store.ChannelMemberTimeoutStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelMemberTimeoutStore)
+		}
+	}
+
+	return r0
+}
+
 // CheckIntegrity provides a mock function with given fields:
 func (_m *Store) CheckIntegrity() <-chan model.IntegrityCheckResult {
 	ret := _m.Called()
@@ -169,6 +247,22 @@ func (_m *Store) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// ConnectivityTestResult provides a mock function with given fields:
+func (_m *Store) ConnectivityTestResult() store.ConnectivityTestResultStore {
+	ret := _m.Called()
+
+	var r0 store.ConnectivityTestResultStore
+	if rf, ok := ret.Get(0).(func() store.ConnectivityTestResultStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ConnectivityTestResultStore)
+		}
+	}
+
+	return r0
+}
+
 // Context provides a mock function with given fields:
 func (_m *Store) Context() context.Context {
 	ret := _m.Called()
@@ -185,6 +279,22 @@ func (_m *Store) Context() context.Context {
 	return r0
 }
 
+// DirectMessageRequest provides a mock function with given fields:
+func (_m *Store) DirectMessageRequest() store.DirectMessageRequestStore {
+	ret := _m.Called()
+
+	var r0 store.DirectMessageRequestStore
+	if rf, ok := ret.Get(0).(func() store.DirectMessageRequestStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.DirectMessageRequestStore)
+		}
+	}
+
+	return r0
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *Store) DropAllTables() {
 	_m.Called()
@@ -287,6 +397,52 @@ func (_m *Store) GetDbVersion(numerical bool) (string, error) {
 	return r0, r1
 }
 
+// GetSlowQueries provides a mock function with given fields: limit
+func (_m *Store) GetSlowQueries(limit int) ([]*model.SlowQuery, error) {
+	ret := _m.Called(limit)
+
+	var r0 []*model.SlowQuery
+	if rf, ok := ret.Get(0).(func(int) []*model.SlowQuery); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SlowQuery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTableRowCounts provides a mock function with given fields:
+func (_m *Store) GetTableRowCounts() ([]*model.TableRowCount, error) {
+	ret := _m.Called()
+
+	var r0 []*model.TableRowCount
+	if rf, ok := ret.Get(0).(func() []*model.TableRowCount); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TableRowCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Group provides a mock function with given fields:
 func (_m *Store) Group() store.GroupStore {
 	ret := _m.Called()
@@ -361,6 +517,22 @@ func (_m *Store) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MfaBackupCode provides a mock function with given fields:
+func (_m *Store) MfaBackupCode() store.MfaBackupCodeStore {
+	ret := _m.Called()
+
+	var r0 store.MfaBackupCodeStore
+	if rf, ok := ret.Get(0).(func() store.MfaBackupCodeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MfaBackupCodeStore)
+		}
+	}
+
+	return r0
+}
+
 // OAuth provides a mock function with given fields:
 func (_m *Store) OAuth() store.OAuthStore {
 	ret := _m.Called()
@@ -377,6 +549,52 @@ func (_m *Store) OAuth() store.OAuthStore {
 	return r0
 }
 
+// OnboardingTask provides a mock function with given fields:
+func (_m *Store) OnboardingTask() store.OnboardingTaskStore {
+	ret := _m.Called()
+
+	var r0 store.OnboardingTaskStore
+	if rf, ok := ret.Get(0).(func() store.OnboardingTaskStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OnboardingTaskStore)
+		}
+	}
+
+	return r0
+}
+
+// OutgoingOAuthConnection provides a mock function with given fields:
+func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	ret := _m.Called()
+
+	var r0 store.OutgoingOAuthConnectionStore
+	if rf, ok := ret.Get(0).(func() store.OutgoingOAuthConnectionStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.OutgoingOAuthConnectionStore)
+	}
+
+	return r0
+}
+
+// PersistentWebSocketEvent provides a mock function with given fields:
+func (_m *Store) PersistentWebSocketEvent() store.PersistentWebSocketEventStore {
+	ret := _m.Called()
+
+	var r0 store.PersistentWebSocketEventStore
+	if rf, ok := ret.Get(0).(func() store.PersistentWebSocketEventStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PersistentWebSocketEventStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -409,6 +627,70 @@ func (_m *Store) Post() store.PostStore {
 	return r0
 }
 
+// PostArchive provides a mock function with given fields:
+func (_m *Store) PostArchive() store.PostArchiveStore {
+	ret := _m.Called()
+
+	var r0 store.PostArchiveStore
+	if rf, ok := ret.Get(0).(func() store.PostArchiveStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostArchiveStore)
+		}
+	}
+
+	return r0
+}
+
+// PostPropSchema provides a mock function with given fields:
+func (_m *Store) PostPropSchema() store.PostPropSchemaStore {
+	ret := _m.Called()
+
+	var r0 store.PostPropSchemaStore
+	if rf, ok := ret.Get(0).(func() store.PostPropSchemaStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostPropSchemaStore)
+		}
+	}
+
+	return r0
+}
+
+// PostReport provides a mock function with given fields:
+func (_m *Store) PostReport() store.PostReportStore {
+	ret := _m.Called()
+
+	var r0 store.PostReportStore
+	if rf, ok := ret.Get(0).(func() store.PostReportStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostReportStore)
+		}
+	}
+
+	return r0
+}
+
+// PostRetentionLabel provides a mock function with given fields:
+func (_m *Store) PostRetentionLabel() store.PostRetentionLabelStore {
+	ret := _m.Called()
+
+	var r0 store.PostRetentionLabelStore
+	if rf, ok := ret.Get(0).(func() store.PostRetentionLabelStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostRetentionLabelStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with given fields:
 func (_m *Store) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -441,6 +723,22 @@ func (_m *Store) ProductNotices() store.ProductNoticesStore {
 	return r0
 }
 
+// PushNotificationReceipt provides a mock function with given fields:
+func (_m *Store) PushNotificationReceipt() store.PushNotificationReceiptStore {
+	ret := _m.Called()
+
+	var r0 store.PushNotificationReceiptStore
+	if rf, ok := ret.Get(0).(func() store.PushNotificationReceiptStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PushNotificationReceiptStore)
+		}
+	}
+
+	return r0
+}
+
 // Reaction provides a mock function with given fields:
 func (_m *Store) Reaction() store.ReactionStore {
 	ret := _m.Called()
@@ -462,6 +760,22 @@ func (_m *Store) RecycleDBConnections(d time.Duration) {
 	_m.Called(d)
 }
 
+// Reminder provides a mock function with given fields:
+func (_m *Store) Reminder() store.ReminderStore {
+	ret := _m.Called()
+
+	var r0 store.ReminderStore
+	if rf, ok := ret.Get(0).(func() store.ReminderStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ReminderStore)
+		}
+	}
+
+	return r0
+}
+
 // RemoteCluster provides a mock function with given fields:
 func (_m *Store) RemoteCluster() store.RemoteClusterStore {
 	ret := _m.Called()
@@ -538,6 +852,20 @@ func (_m *Store) Role() store.RoleStore {
 	return r0
 }
 
+// ScheduledConfigChange provides a mock function with given fields:
+func (_m *Store) ScheduledConfigChange() store.ScheduledConfigChangeStore {
+	ret := _m.Called()
+
+	var r0 store.ScheduledConfigChangeStore
+	if rf, ok := ret.Get(0).(func() store.ScheduledConfigChangeStore); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(store.ScheduledConfigChangeStore)
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *Store) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -623,6 +951,22 @@ func (_m *Store) System() store.SystemStore {
 	return r0
 }
 
+// TablePartition provides a mock function with given fields:
+func (_m *Store) TablePartition() store.TablePartitionStore {
+	ret := _m.Called()
+
+	var r0 store.TablePartitionStore
+	if rf, ok := ret.Get(0).(func() store.TablePartitionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TablePartitionStore)
+		}
+	}
+
+	return r0
+}
+
 // Team provides a mock function with given fields:
 func (_m *Store) Team() store.TeamStore {
 	ret := _m.Called()
@@ -639,6 +983,38 @@ func (_m *Store) Team() store.TeamStore {
 	return r0
 }
 
+// TeamInviteLink provides a mock function with given fields:
+func (_m *Store) TeamInviteLink() store.TeamInviteLinkStore {
+	ret := _m.Called()
+
+	var r0 store.TeamInviteLinkStore
+	if rf, ok := ret.Get(0).(func() store.TeamInviteLinkStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TeamInviteLinkStore)
+		}
+	}
+
+	return r0
+}
+
+// TeamTemplate provides a mock function with given fields:
+func (_m *Store) TeamTemplate() store.TeamTemplateStore {
+	ret := _m.Called()
+
+	var r0 store.TeamTemplateStore
+	if rf, ok := ret.Get(0).(func() store.TeamTemplateStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TeamTemplateStore)
+		}
+	}
+
+	return r0
+}
+
 // TermsOfService provides a mock function with given fields:
 func (_m *Store) TermsOfService() store.TermsOfServiceStore {
 	ret := _m.Called()
@@ -782,6 +1158,22 @@ func (_m *Store) UserAccessToken() store.UserAccessTokenStore {
 	return r0
 }
 
+// UserDevice provides a mock function with given fields:
+func (_m *Store) UserDevice() store.UserDeviceStore {
+	ret := _m.Called()
+
+	var r0 store.UserDeviceStore
+	if rf, ok := ret.Get(0).(func() store.UserDeviceStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.UserDeviceStore)
+		}
+	}
+
+	return r0
+}
+
 // UserTermsOfService provides a mock function with given fields:
 func (_m *Store) UserTermsOfService() store.UserTermsOfServiceStore {
 	ret := _m.Called()
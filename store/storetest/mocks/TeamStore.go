@@ -466,6 +466,29 @@ func (_m *TeamStore) GetMembersByIds(teamID string, userIds []string, restrictio
 	return r0, r1
 }
 
+// GetSoftDeletedIds provides a mock function with given fields: before, limit
+func (_m *TeamStore) GetSoftDeletedIds(before int64, limit int) ([]string, error) {
+	ret := _m.Called(before, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64, int) []string); ok {
+		r0 = rf(before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeamMembersForExport provides a mock function with given fields: userID
 func (_m *TeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 	ret := _m.Called(userID)
@@ -758,6 +781,20 @@ func (_m *TeamStore) ResetAllTeamSchemes() error {
 	return r0
 }
 
+// Restore provides a mock function with given fields: teamID
+func (_m *TeamStore) Restore(teamID string) error {
+	ret := _m.Called(teamID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(teamID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: team
 func (_m *TeamStore) Save(team *model.Team) (*model.Team, error) {
 	ret := _m.Called(team)
@@ -926,6 +963,20 @@ func (_m *TeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error
 	return r0, r1
 }
 
+// SoftDelete provides a mock function with given fields: teamID, deleteAt
+func (_m *TeamStore) SoftDelete(teamID string, deleteAt int64) error {
+	ret := _m.Called(teamID, deleteAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(teamID, deleteAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: team
 func (_m *TeamStore) Update(team *model.Team) (*model.Team, error) {
 	ret := _m.Called(team)
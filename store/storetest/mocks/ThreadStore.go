@@ -318,6 +318,29 @@ func (_m *ThreadStore) GetTotalUnreadThreads(userId string, teamID string, opts
 	return r0, r1
 }
 
+// GetUnreadThreadsForUser provides a mock function with given fields: userID
+func (_m *ThreadStore) GetUnreadThreadsForUser(userID string) ([]*model.ThreadUnread, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*model.ThreadUnread
+	if rf, ok := ret.Get(0).(func(string) []*model.ThreadUnread); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ThreadUnread)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MaintainMembership provides a mock function with given fields: userID, postID, opts
 func (_m *ThreadStore) MaintainMembership(userID string, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
 	ret := _m.Called(userID, postID, opts)
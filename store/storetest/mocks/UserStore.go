@@ -661,6 +661,29 @@ func (_m *UserStore) GetNewUsersForTeam(teamID string, offset int, limit int, vi
 	return r0, r1
 }
 
+// GetNonBotUsers provides a mock function with given fields: offset, limit
+func (_m *UserStore) GetNonBotUsers(offset int, limit int) ([]*model.User, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []*model.User
+	if rf, ok := ret.Get(0).(func(int, int) []*model.User); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProfileByGroupChannelIdsForUser provides a mock function with given fields: userID, channelIds
 func (_m *UserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
 	ret := _m.Called(userID, channelIds)
@@ -1096,6 +1119,29 @@ func (_m *UserStore) IsEmpty(excludeBots bool) (bool, error) {
 	return r0, r1
 }
 
+// MergeInto provides a mock function with given fields: sourceUserID, targetUserID
+func (_m *UserStore) MergeInto(sourceUserID string, targetUserID string) (*model.UserMergeCounts, error) {
+	ret := _m.Called(sourceUserID, targetUserID)
+
+	var r0 *model.UserMergeCounts
+	if rf, ok := ret.Get(0).(func(string, string) *model.UserMergeCounts); ok {
+		r0 = rf(sourceUserID, targetUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserMergeCounts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(sourceUserID, targetUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDelete provides a mock function with given fields: userID
 func (_m *UserStore) PermanentDelete(userID string) error {
 	ret := _m.Called(userID)
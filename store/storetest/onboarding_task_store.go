@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestOnboardingTaskStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testOnboardingTaskSaveAndGet(t, ss) })
+	t.Run("Update", func(t *testing.T) { testOnboardingTaskUpdate(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testOnboardingTaskGetAll(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testOnboardingTaskDelete(t, ss) })
+}
+
+func newTestOnboardingTask(title string, sortOrder int64) *model.OnboardingTask {
+	return &model.OnboardingTask{
+		Title:       title,
+		Description: "Read the handbook before your first week",
+		Link:        "https://example.com/handbook",
+		SortOrder:   sortOrder,
+		CreatorId:   model.NewId(),
+	}
+}
+
+func testOnboardingTaskSaveAndGet(t *testing.T, ss store.Store) {
+	task, err := ss.OnboardingTask().Save(newTestOnboardingTask("Read the handbook", 10))
+	require.NoError(t, err)
+	require.NotEmpty(t, task.Id)
+	defer ss.OnboardingTask().Delete(task.Id, model.GetMillis())
+	assert.Equal(t, model.OnboardingTaskAudienceAll, task.Audience)
+
+	fetched, err := ss.OnboardingTask().Get(task.Id)
+	require.NoError(t, err)
+	assert.Equal(t, task, fetched)
+
+	_, err = ss.OnboardingTask().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.OnboardingTask().Save(newTestOnboardingTask("", 10))
+	require.Error(t, err)
+}
+
+func testOnboardingTaskUpdate(t *testing.T, ss store.Store) {
+	task, err := ss.OnboardingTask().Save(newTestOnboardingTask("Read the handbook", 10))
+	require.NoError(t, err)
+	defer ss.OnboardingTask().Delete(task.Id, model.GetMillis())
+
+	task.Title = "Read the new handbook"
+	task.Audience = model.OnboardingTaskAudienceAdmins
+	_, err = ss.OnboardingTask().Update(task)
+	require.NoError(t, err)
+
+	fetched, err := ss.OnboardingTask().Get(task.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Read the new handbook", fetched.Title)
+	assert.Equal(t, model.OnboardingTaskAudienceAdmins, fetched.Audience)
+
+	missing := newTestOnboardingTask("Missing", 10)
+	missing.PreSave()
+	_, err = ss.OnboardingTask().Update(missing)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+}
+
+func testOnboardingTaskGetAll(t *testing.T, ss store.Store) {
+	second, err := ss.OnboardingTask().Save(newTestOnboardingTask("Second", 20))
+	require.NoError(t, err)
+	defer ss.OnboardingTask().Delete(second.Id, model.GetMillis())
+
+	first, err := ss.OnboardingTask().Save(newTestOnboardingTask("First", 10))
+	require.NoError(t, err)
+	defer ss.OnboardingTask().Delete(first.Id, model.GetMillis())
+
+	tasks, err := ss.OnboardingTask().GetAll()
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, first.Id, tasks[0].Id)
+	assert.Equal(t, second.Id, tasks[1].Id)
+}
+
+func testOnboardingTaskDelete(t *testing.T, ss store.Store) {
+	task, err := ss.OnboardingTask().Save(newTestOnboardingTask("Read the handbook", 10))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.OnboardingTask().Delete(task.Id, model.GetMillis()))
+
+	_, err = ss.OnboardingTask().Get(task.Id)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+
+	err = ss.OnboardingTask().Delete(task.Id, model.GetMillis())
+	assert.True(t, errors.As(err, &nfErr))
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestOutgoingOAuthConnectionStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testOutgoingOAuthConnectionSaveAndGet(t, ss) })
+	t.Run("Update", func(t *testing.T) { testOutgoingOAuthConnectionUpdate(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testOutgoingOAuthConnectionGetAll(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testOutgoingOAuthConnectionDelete(t, ss) })
+}
+
+func newTestOutgoingOAuthConnection(name string) *model.OutgoingOAuthConnection {
+	return &model.OutgoingOAuthConnection{
+		CreatorId:     model.NewId(),
+		Name:          name,
+		ClientId:      "client",
+		ClientSecret:  "secret",
+		OAuthTokenURL: "https://auth.example.com/token",
+		GrantType:     model.OutgoingOAuthConnectionGrantTypeClientCredentials,
+		Scopes:        "read write",
+		Audiences:     model.StringArray{"https://api.example.com", "https://hooks.example.com/mattermost"},
+	}
+}
+
+func testOutgoingOAuthConnectionSaveAndGet(t *testing.T, ss store.Store) {
+	connection, err := ss.OutgoingOAuthConnection().Save(newTestOutgoingOAuthConnection("Example"))
+	require.NoError(t, err)
+	require.NotEmpty(t, connection.Id)
+	defer ss.OutgoingOAuthConnection().Delete(connection.Id)
+
+	fetched, err := ss.OutgoingOAuthConnection().Get(connection.Id)
+	require.NoError(t, err)
+	assert.Equal(t, connection, fetched)
+
+	_, err = ss.OutgoingOAuthConnection().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+
+	invalid := newTestOutgoingOAuthConnection("Invalid")
+	invalid.Audiences = nil
+	_, err = ss.OutgoingOAuthConnection().Save(invalid)
+	require.Error(t, err)
+}
+
+func testOutgoingOAuthConnectionUpdate(t *testing.T, ss store.Store) {
+	connection, err := ss.OutgoingOAuthConnection().Save(newTestOutgoingOAuthConnection("Example"))
+	require.NoError(t, err)
+	defer ss.OutgoingOAuthConnection().Delete(connection.Id)
+
+	connection.Name = "Renamed"
+	connection.GrantType = model.OutgoingOAuthConnectionGrantTypePassword
+	connection.CredentialsUsername = "user"
+	connection.CredentialsPassword = "password"
+	connection.Audiences = model.StringArray{"https://other.example.com"}
+	_, err = ss.OutgoingOAuthConnection().Update(connection)
+	require.NoError(t, err)
+
+	fetched, err := ss.OutgoingOAuthConnection().Get(connection.Id)
+	require.NoError(t, err)
+	assert.Equal(t, connection, fetched)
+
+	missing := newTestOutgoingOAuthConnection("Missing")
+	missing.PreSave()
+	_, err = ss.OutgoingOAuthConnection().Update(missing)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+}
+
+func testOutgoingOAuthConnectionGetAll(t *testing.T, ss store.Store) {
+	connection1, err := ss.OutgoingOAuthConnection().Save(newTestOutgoingOAuthConnection("B connection"))
+	require.NoError(t, err)
+	defer ss.OutgoingOAuthConnection().Delete(connection1.Id)
+
+	connection2, err := ss.OutgoingOAuthConnection().Save(newTestOutgoingOAuthConnection("A connection"))
+	require.NoError(t, err)
+	defer ss.OutgoingOAuthConnection().Delete(connection2.Id)
+
+	connections, err := ss.OutgoingOAuthConnection().GetAll(0, 10)
+	require.NoError(t, err)
+	require.Len(t, connections, 2)
+	assert.Equal(t, connection2.Id, connections[0].Id)
+	assert.Equal(t, connection1.Id, connections[1].Id)
+
+	connections, err = ss.OutgoingOAuthConnection().GetAll(1, 10)
+	require.NoError(t, err)
+	require.Len(t, connections, 1)
+	assert.Equal(t, connection1.Id, connections[0].Id)
+}
+
+func testOutgoingOAuthConnectionDelete(t *testing.T, ss store.Store) {
+	connection, err := ss.OutgoingOAuthConnection().Save(newTestOutgoingOAuthConnection("Example"))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.OutgoingOAuthConnection().Delete(connection.Id))
+
+	_, err = ss.OutgoingOAuthConnection().Get(connection.Id)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+
+	err = ss.OutgoingOAuthConnection().Delete(connection.Id)
+	assert.True(t, errors.As(err, &nfErr))
+}
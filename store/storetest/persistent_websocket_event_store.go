@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPersistentWebSocketEventStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGetForUserSince", func(t *testing.T) { testPersistentWebSocketEventSaveAndGetForUserSince(t, ss) })
+	t.Run("Cleanup", func(t *testing.T) { testPersistentWebSocketEventCleanup(t, ss) })
+}
+
+func newTestPersistentWebSocketEvent(createAt int64, userID, channelID, teamID string) *model.PersistentWebSocketEvent {
+	return &model.PersistentWebSocketEvent{
+		PluginId:  "com.example.plugin",
+		Event:     "custom_com.example.plugin_event",
+		Data:      `{"value":1}`,
+		UserId:    userID,
+		ChannelId: channelID,
+		TeamId:    teamID,
+		CreateAt:  createAt,
+	}
+}
+
+func testPersistentWebSocketEventSaveAndGetForUserSince(t *testing.T, ss store.Store) {
+	require.NoError(t, ss.PersistentWebSocketEvent().Cleanup(math.MaxInt64, 100))
+
+	userID := model.NewId()
+	teamID := model.NewId()
+	channelID := model.NewId()
+	_, err := ss.Team().SaveMember(&model.TeamMember{TeamId: teamID, UserId: userID}, -1)
+	require.NoError(t, err)
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channelID, UserId: userID, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.NoError(t, err)
+
+	everyone, err := ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(1000, "", "", ""))
+	require.NoError(t, err)
+	require.NotEmpty(t, everyone.Id)
+	toUser, err := ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(2000, userID, "", ""))
+	require.NoError(t, err)
+	toChannel, err := ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(3000, "", channelID, ""))
+	require.NoError(t, err)
+	toTeam, err := ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(4000, "", "", teamID))
+	require.NoError(t, err)
+
+	_, err = ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(5000, model.NewId(), "", ""))
+	require.NoError(t, err)
+	_, err = ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(6000, "", model.NewId(), ""))
+	require.NoError(t, err)
+	_, err = ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(7000, "", "", model.NewId()))
+	require.NoError(t, err)
+
+	invalid := newTestPersistentWebSocketEvent(8000, "", "", "")
+	invalid.PluginId = ""
+	_, err = ss.PersistentWebSocketEvent().Save(invalid)
+	require.Error(t, err)
+
+	events, err := ss.PersistentWebSocketEvent().GetForUserSince(userID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, everyone, events[0], "oldest events should come first")
+	assert.Equal(t, toUser.Id, events[1].Id)
+	assert.Equal(t, toChannel.Id, events[2].Id)
+	assert.Equal(t, toTeam.Id, events[3].Id)
+
+	events, err = ss.PersistentWebSocketEvent().GetForUserSince(userID, 2000, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, toChannel.Id, events[0].Id)
+
+	events, err = ss.PersistentWebSocketEvent().GetForUserSince(userID, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, everyone.Id, events[0].Id)
+}
+
+func testPersistentWebSocketEventCleanup(t *testing.T, ss store.Store) {
+	require.NoError(t, ss.PersistentWebSocketEvent().Cleanup(math.MaxInt64, 100))
+
+	for i := int64(1); i <= 5; i++ {
+		_, err := ss.PersistentWebSocketEvent().Save(newTestPersistentWebSocketEvent(i*1000, "", "", ""))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, ss.PersistentWebSocketEvent().Cleanup(3500, 2))
+
+	events, err := ss.PersistentWebSocketEvent().GetForUserSince(model.NewId(), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(4000), events[0].CreateAt)
+	assert.Equal(t, int64(5000), events[1].CreateAt)
+}
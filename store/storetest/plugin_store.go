@@ -19,6 +19,7 @@ func TestPluginStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("CompareAndSet", func(t *testing.T) { testPluginCompareAndSet(t, ss) })
 	t.Run("CompareAndDelete", func(t *testing.T) { testPluginCompareAndDelete(t, ss) })
 	t.Run("SetWithOptions", func(t *testing.T) { testPluginSetWithOptions(t, ss) })
+	t.Run("SetMultiple", func(t *testing.T) { testPluginSetMultiple(t, ss) })
 	t.Run("Get", func(t *testing.T) { testPluginGet(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testPluginDelete(t, ss) })
 	t.Run("DeleteAllForPlugin", func(t *testing.T) { testPluginDeleteAllForPlugin(t, ss) })
@@ -734,6 +735,119 @@ func testPluginSetWithOptions(t *testing.T, ss store.Store) {
 	})
 }
 
+func testPluginSetMultiple(t *testing.T, ss store.Store) {
+	getValue := func(t *testing.T, pluginId, key string) []byte {
+		kv, err := ss.Plugin().Get(pluginId, key)
+		if _, ok := err.(*store.ErrNotFound); ok {
+			return nil
+		}
+		require.NoError(t, err)
+		return kv.Value
+	}
+
+	t.Run("invalid operations", func(t *testing.T) {
+		pluginId := model.NewId()
+
+		_, err := ss.Plugin().SetMultiple(pluginId, nil)
+		require.Error(t, err)
+
+		_, err = ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+			{Key: "key", Value: []byte("value1")},
+			{Key: "key", Value: []byte("value2")},
+		})
+		require.Error(t, err)
+
+		_, err = ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+			{Key: "key", Value: []byte("value"), Options: model.PluginKVSetOptions{OldValue: []byte("old")}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("non-atomic operations", func(t *testing.T) {
+		pluginId := model.NewId()
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "existing", Value: []byte("value")})
+		require.NoError(t, err)
+		_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "deleted", Value: []byte("value")})
+		require.NoError(t, err)
+
+		ok, err := ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+			{Key: "existing", Value: []byte("updated")},
+			{Key: "new", Value: []byte("inserted")},
+			{Key: "deleted", Value: nil},
+		})
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		assert.Equal(t, []byte("updated"), getValue(t, pluginId, "existing"))
+		assert.Equal(t, []byte("inserted"), getValue(t, pluginId, "new"))
+		assert.Nil(t, getValue(t, pluginId, "deleted"))
+	})
+
+	t.Run("atomic operations", func(t *testing.T) {
+		pluginId := model.NewId()
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "key1", Value: []byte("value1")})
+		require.NoError(t, err)
+		_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "key2", Value: []byte("value2")})
+		require.NoError(t, err)
+
+		t.Run("should write nothing if a comparison fails", func(t *testing.T) {
+			ok, err := ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+				{Key: "key1", Value: []byte("new1"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value1")}},
+				{Key: "key2", Value: []byte("new2"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("different")}},
+				{Key: "key3", Value: []byte("new3")},
+			})
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			assert.Equal(t, []byte("value1"), getValue(t, pluginId, "key1"))
+			assert.Equal(t, []byte("value2"), getValue(t, pluginId, "key2"))
+			assert.Nil(t, getValue(t, pluginId, "key3"))
+		})
+
+		t.Run("should fail inserting an existing key", func(t *testing.T) {
+			ok, err := ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+				{Key: "key1", Value: []byte("new1"), Options: model.PluginKVSetOptions{Atomic: true}},
+			})
+			require.NoError(t, err)
+			assert.False(t, ok)
+			assert.Equal(t, []byte("value1"), getValue(t, pluginId, "key1"))
+		})
+
+		t.Run("should write everything if every comparison succeeds", func(t *testing.T) {
+			ok, err := ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+				{Key: "key1", Value: []byte("new1"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value1")}},
+				{Key: "key2", Value: nil, Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value2")}},
+				{Key: "key3", Value: []byte("new3"), Options: model.PluginKVSetOptions{Atomic: true}},
+			})
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			assert.Equal(t, []byte("new1"), getValue(t, pluginId, "key1"))
+			assert.Nil(t, getValue(t, pluginId, "key2"))
+			assert.Equal(t, []byte("new3"), getValue(t, pluginId, "key3"))
+		})
+	})
+
+	t.Run("expired key is considered missing", func(t *testing.T) {
+		pluginId := model.NewId()
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginId, Key: "expired", Value: []byte("value"), ExpireAt: model.GetMillis() - 1000})
+		require.NoError(t, err)
+
+		ok, err := ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+			{Key: "expired", Value: []byte("value"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value")}},
+		})
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		ok, err = ss.Plugin().SetMultiple(pluginId, []*model.PluginKVSetOperation{
+			{Key: "expired", Value: []byte("new"), Options: model.PluginKVSetOptions{Atomic: true}},
+		})
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("new"), getValue(t, pluginId, "expired"))
+	})
+}
+
 func testPluginGet(t *testing.T, ss store.Store) {
 	t.Run("no matching key value", func(t *testing.T) {
 		pluginId := model.NewId()
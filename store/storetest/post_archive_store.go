@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPostArchiveStore(t *testing.T, ss store.Store) {
+	t.Run("ArchiveThreadsBefore", func(t *testing.T) { testPostArchiveArchiveThreadsBefore(t, ss) })
+	t.Run("Search", func(t *testing.T) { testPostArchiveSearch(t, ss) })
+}
+
+func savePostForArchive(t *testing.T, ss store.Store, channelID, rootID, message string, createAt int64, pinned bool) *model.Post {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: channelID,
+		UserId:    model.NewId(),
+		RootId:    rootID,
+		Message:   message,
+		CreateAt:  createAt,
+		IsPinned:  pinned,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+// The stores are shared between tests, so the posts of these tests are created far in the past
+// to keep the other tests' posts out of the archive.
+const postArchiveTestBase = int64(-1000000)
+
+func testPostArchiveArchiveThreadsBefore(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	cutoff := postArchiveTestBase + 10000
+
+	oldRoot := savePostForArchive(t, ss, channelID, "", "old thread", postArchiveTestBase+1000, false)
+	oldReply := savePostForArchive(t, ss, channelID, oldRoot.Id, "old reply", postArchiveTestBase+2000, false)
+	activeRoot := savePostForArchive(t, ss, channelID, "", "active thread", postArchiveTestBase+1000, false)
+	savePostForArchive(t, ss, channelID, activeRoot.Id, "recent reply", postArchiveTestBase+20000, false)
+	pinnedRoot := savePostForArchive(t, ss, channelID, "", "pinned thread", postArchiveTestBase+1000, true)
+	recentRoot := savePostForArchive(t, ss, channelID, "", "recent thread", postArchiveTestBase+20000, false)
+
+	archived, err := ss.PostArchive().ArchiveThreadsBefore(cutoff, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), archived)
+
+	for _, id := range []string{oldRoot.Id, oldReply.Id} {
+		_, err = ss.Post().GetSingle(id, false)
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr, "archived post %s should have left the Posts table", id)
+	}
+
+	for _, id := range []string{activeRoot.Id, pinnedRoot.Id, recentRoot.Id} {
+		_, err = ss.Post().GetSingle(id, false)
+		assert.NoError(t, err)
+	}
+
+	posts, err := ss.PostArchive().Search([]string{channelID}, "", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, oldReply.Id, posts[0].Id)
+	assert.Equal(t, oldRoot.Id, posts[1].Id)
+	assert.Equal(t, oldReply.Message, posts[0].Message)
+	assert.Equal(t, oldRoot.Id, posts[0].RootId)
+
+	archived, err = ss.PostArchive().ArchiveThreadsBefore(cutoff, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), archived)
+}
+
+func testPostArchiveSearch(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+
+	first := savePostForArchive(t, ss, channelID, "", "Quarterly budget review", postArchiveTestBase+1000, false)
+	second := savePostForArchive(t, ss, channelID, "", "budget 100% approved", postArchiveTestBase+2000, false)
+	savePostForArchive(t, ss, otherChannelID, "", "budget elsewhere", postArchiveTestBase+3000, false)
+
+	_, err := ss.PostArchive().ArchiveThreadsBefore(postArchiveTestBase+10000, 100)
+	require.NoError(t, err)
+
+	posts, err := ss.PostArchive().Search([]string{channelID}, "BUDGET", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, second.Id, posts[0].Id)
+	assert.Equal(t, first.Id, posts[1].Id)
+
+	posts, err = ss.PostArchive().Search([]string{channelID}, "budget review", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, first.Id, posts[0].Id)
+
+	posts, err = ss.PostArchive().Search([]string{channelID}, "100%", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, second.Id, posts[0].Id)
+
+	posts, err = ss.PostArchive().Search([]string{channelID}, "budget", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, first.Id, posts[0].Id)
+
+	posts, err = ss.PostArchive().Search([]string{}, "budget", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+}
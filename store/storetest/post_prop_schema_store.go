@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPostPropSchemaStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testPostPropSchemaSaveGetUpdateDelete(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testPostPropSchemaGetAll(t, ss) })
+}
+
+func newPostPropSchema(namespace string) *model.PostPropSchema {
+	return &model.PostPropSchema{
+		Namespace: namespace,
+		Schema:    json.RawMessage(`{"type":"object","required":["question"]}`),
+		CreatorId: model.NewId(),
+	}
+}
+
+func testPostPropSchemaSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	_, err := ss.PostPropSchema().Save(newPostPropSchema("poll"))
+	require.Error(t, err)
+
+	namespace := "com.test." + model.NewId()
+	schema, err := ss.PostPropSchema().Save(newPostPropSchema(namespace))
+	require.NoError(t, err)
+	assert.NotZero(t, schema.CreateAt)
+
+	_, err = ss.PostPropSchema().Save(newPostPropSchema(namespace))
+	require.Error(t, err)
+
+	schema.Schema = json.RawMessage(`{"type":"string"}`)
+	_, err = ss.PostPropSchema().Update(schema)
+	require.NoError(t, err)
+
+	got, err := ss.PostPropSchema().Get(namespace)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"string"}`, string(got.Schema))
+	assert.Equal(t, schema.CreatorId, got.CreatorId)
+
+	require.NoError(t, ss.PostPropSchema().Delete(namespace))
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.PostPropSchema().Get(namespace)
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.PostPropSchema().Update(schema)
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.PostPropSchema().Delete(namespace)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testPostPropSchemaGetAll(t *testing.T, ss store.Store) {
+	second, err := ss.PostPropSchema().Save(newPostPropSchema("com.test.getall-b"))
+	require.NoError(t, err)
+	defer ss.PostPropSchema().Delete(second.Namespace)
+	first, err := ss.PostPropSchema().Save(newPostPropSchema("com.test.getall-a"))
+	require.NoError(t, err)
+	defer ss.PostPropSchema().Delete(first.Namespace)
+
+	schemas, err := ss.PostPropSchema().GetAll()
+	require.NoError(t, err)
+
+	var namespaces []string
+	for _, schema := range schemas {
+		if schema.Namespace == first.Namespace || schema.Namespace == second.Namespace {
+			namespaces = append(namespaces, schema.Namespace)
+			assert.JSONEq(t, `{"type":"object","required":["question"]}`, string(schema.Schema))
+		}
+	}
+	assert.Equal(t, []string{first.Namespace, second.Namespace}, namespaces)
+}
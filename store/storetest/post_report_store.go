@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPostReportStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testPostReportSaveAndGet(t, ss) })
+	t.Run("GetReportsAndResolveForPost", func(t *testing.T) { testPostReportGetReportsAndResolveForPost(t, ss) })
+}
+
+func newTestPostReport(postID, channelID string, createAt int64) *model.PostReport {
+	return &model.PostReport{
+		PostId:     postID,
+		ChannelId:  channelID,
+		PostUserId: model.NewId(),
+		ReporterId: model.NewId(),
+		Reason:     model.PostReportReasonSpam,
+		Comment:    "buy now",
+		CreateAt:   createAt,
+	}
+}
+
+func testPostReportSaveAndGet(t *testing.T, ss store.Store) {
+	report, err := ss.PostReport().Save(newTestPostReport(model.NewId(), model.NewId(), 0))
+	require.NoError(t, err)
+	require.NotEmpty(t, report.Id)
+	assert.Equal(t, model.PostReportStatusOpen, report.Status)
+
+	saved, err := ss.PostReport().Get(report.Id)
+	require.NoError(t, err)
+	assert.Equal(t, report, saved)
+
+	// A user can only report a post once.
+	duplicate := newTestPostReport(report.PostId, report.ChannelId, 0)
+	duplicate.ReporterId = report.ReporterId
+	_, err = ss.PostReport().Save(duplicate)
+	var cErr *store.ErrConflict
+	require.True(t, errors.As(err, &cErr))
+
+	invalid := newTestPostReport(model.NewId(), model.NewId(), 0)
+	invalid.Reason = "boring"
+	_, err = ss.PostReport().Save(invalid)
+	require.Error(t, err)
+
+	_, err = ss.PostReport().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testPostReportGetReportsAndResolveForPost(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	postID := model.NewId()
+	otherPostID := model.NewId()
+
+	first, err := ss.PostReport().Save(newTestPostReport(postID, channelID, 1000))
+	require.NoError(t, err)
+	second, err := ss.PostReport().Save(newTestPostReport(postID, channelID, 2000))
+	require.NoError(t, err)
+	other, err := ss.PostReport().Save(newTestPostReport(otherPostID, channelID, 3000))
+	require.NoError(t, err)
+
+	reports, err := ss.PostReport().GetReports(model.PostReportGetOptions{ChannelId: channelID, Status: model.PostReportStatusOpen, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.PostReport{first, second, other}, reports)
+
+	reports, err = ss.PostReport().GetReports(model.PostReportGetOptions{ChannelId: channelID, Page: 1, PerPage: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.PostReport{other}, reports)
+
+	resolverID := model.NewId()
+	count, err := ss.PostReport().ResolveForPost(postID, model.PostReportStatusResolved, model.PostReportActionDeletePost, resolverID, 4000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	reports, err = ss.PostReport().GetReports(model.PostReportGetOptions{ChannelId: channelID, Status: model.PostReportStatusOpen, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.PostReport{other}, reports)
+
+	resolved, err := ss.PostReport().Get(first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.PostReportStatusResolved, resolved.Status)
+	assert.Equal(t, model.PostReportActionDeletePost, resolved.Action)
+	assert.Equal(t, resolverID, resolved.ResolverId)
+	assert.Equal(t, int64(4000), resolved.ResolveAt)
+
+	// Closed reports are left as they are.
+	count, err = ss.PostReport().ResolveForPost(postID, model.PostReportStatusDismissed, model.PostReportActionDismiss, resolverID, 5000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPostRetentionLabelStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testPostRetentionLabelSaveGetDelete(t, ss) })
+	t.Run("PermanentDeleteBatchForRetentionPolicies", func(t *testing.T) { testPostRetentionLabelPermanentDeleteBatchForRetentionPolicies(t, ss) })
+}
+
+func testPostRetentionLabelSaveGetDelete(t *testing.T, ss store.Store) {
+	postID := model.NewId()
+	creatorID := model.NewId()
+
+	_, err := ss.PostRetentionLabel().Save(&model.PostRetentionLabel{PostId: postID, Label: "Invalid Label", ExpireAt: 1000, CreatorId: creatorID})
+	require.Error(t, err)
+
+	label, err := ss.PostRetentionLabel().Save(&model.PostRetentionLabel{PostId: postID, Label: "ephemeral-24h", ExpireAt: 1000, CreatorId: creatorID})
+	require.NoError(t, err)
+	assert.NotZero(t, label.CreateAt)
+
+	// Applying another label replaces the previous one.
+	_, err = ss.PostRetentionLabel().Save(&model.PostRetentionLabel{PostId: postID, Label: "retain-7-years", ExpireAt: 2000, CreatorId: creatorID})
+	require.NoError(t, err)
+
+	label, err = ss.PostRetentionLabel().Get(postID)
+	require.NoError(t, err)
+	assert.Equal(t, "retain-7-years", label.Label)
+	assert.Equal(t, int64(2000), label.ExpireAt)
+	assert.Equal(t, creatorID, label.CreatorId)
+
+	require.NoError(t, ss.PostRetentionLabel().Delete(postID))
+
+	_, err = ss.PostRetentionLabel().Get(postID)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testPostRetentionLabelPermanentDeleteBatchForRetentionPolicies(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        "team" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "DisplayName",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	savePost := func(createAt int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channel.Id,
+			UserId:    model.NewId(),
+			Message:   NewTestId(),
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		return post
+	}
+	unlabeled := savePost(1000)
+	retained := savePost(1000)
+	expired := savePost(100000)
+
+	_, err = ss.PostRetentionLabel().Save(&model.PostRetentionLabel{PostId: retained.Id, Label: "retain-7-years", ExpireAt: 10000000, CreatorId: model.NewId()})
+	require.NoError(t, err)
+	_, err = ss.PostRetentionLabel().Save(&model.PostRetentionLabel{PostId: expired.Id, Label: "ephemeral-24h", ExpireAt: 200000, CreatorId: model.NewId()})
+	require.NoError(t, err)
+
+	deleted, _, err := ss.Post().PermanentDeleteBatchForRetentionPolicies(300000, 2000, 1000, model.RetentionPolicyCursor{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(2))
+
+	_, err = ss.Post().Get(context.Background(), unlabeled.Id, model.GetPostsOptions{}, "")
+	require.Error(t, err, "the posts without a label are deleted by the policies")
+
+	_, err = ss.Post().Get(context.Background(), retained.Id, model.GetPostsOptions{}, "")
+	require.NoError(t, err, "the label of the post overrides the policies")
+
+	_, err = ss.Post().Get(context.Background(), expired.Id, model.GetPostsOptions{}, "")
+	require.Error(t, err, "the post is deleted once its label has expired")
+
+	_, err = ss.PostRetentionLabel().Get(expired.Id)
+	require.Error(t, err, "the label of a deleted post is deleted")
+
+	_, err = ss.PostRetentionLabel().Get(retained.Id)
+	require.NoError(t, err)
+}
@@ -54,8 +54,11 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetDirectPostParentsForExportAfter", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfter(t, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterDeleted", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterDeleted(t, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterBatched", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterBatched(t, ss, s) })
+	t.Run("GetChannelPostsForExportAfter", func(t *testing.T) { testPostStoreGetChannelPostsForExportAfter(t, ss) })
+	t.Run("GetUserPostsForExportAfter", func(t *testing.T) { testPostStoreGetUserPostsForExportAfter(t, ss) })
 	t.Run("GetForThread", func(t *testing.T) { testPostStoreGetForThread(t, ss) })
 	t.Run("HasAutoResponsePostByUserSince", func(t *testing.T) { testHasAutoResponsePostByUserSince(t, ss) })
+	t.Run("GetLastPostAtByUserSince", func(t *testing.T) { testGetLastPostAtByUserSince(t, ss) })
 	t.Run("GetPostsSinceForSync", func(t *testing.T) { testGetPostsSinceForSync(t, ss, s) })
 }
 
@@ -3652,6 +3655,60 @@ func testHasAutoResponsePostByUserSince(t *testing.T, ss store.Store) {
 	})
 }
 
+func testGetLastPostAtByUserSince(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+
+	post1, err := ss.Post().Save(&model.Post{
+		ChannelId: channelId,
+		UserId:    userId,
+		Message:   "message",
+		CreateAt:  1000,
+	})
+	require.NoError(t, err)
+
+	post2, err := ss.Post().Save(&model.Post{
+		ChannelId: channelId,
+		UserId:    userId,
+		Message:   "message",
+		CreateAt:  2000,
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: channelId,
+		UserId:    userId,
+		Message:   "joined",
+		Type:      model.PostTypeJoinChannel,
+		CreateAt:  3000,
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: channelId,
+		UserId:    model.NewId(),
+		Message:   "message",
+		CreateAt:  4000,
+	})
+	require.NoError(t, err)
+
+	lastPostAt, err := ss.Post().GetLastPostAtByUserSince(model.GetPostsSinceOptions{ChannelId: channelId, Time: post1.CreateAt}, userId)
+	require.NoError(t, err)
+	assert.Equal(t, post2.CreateAt, lastPostAt)
+
+	// Deleted posts still count
+	err = ss.Post().Delete(post2.Id, model.GetMillis(), userId)
+	require.NoError(t, err)
+
+	lastPostAt, err = ss.Post().GetLastPostAtByUserSince(model.GetPostsSinceOptions{ChannelId: channelId, Time: post1.CreateAt}, userId)
+	require.NoError(t, err)
+	assert.Equal(t, post2.CreateAt, lastPostAt)
+
+	lastPostAt, err = ss.Post().GetLastPostAtByUserSince(model.GetPostsSinceOptions{ChannelId: channelId, Time: post2.CreateAt + 1}, userId)
+	require.NoError(t, err)
+	assert.Zero(t, lastPostAt)
+}
+
 func testGetPostsSinceForSync(t *testing.T, ss store.Store, s SqlStore) {
 	// create some posts.
 	channelID := model.NewId()
@@ -3765,3 +3822,58 @@ func getPostIds(posts []*model.Post, morePosts ...*model.Post) []string {
 	}
 	return ids
 }
+
+func testPostStoreGetChannelPostsForExportAfter(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+
+	root, err := ss.Post().Save(&model.Post{ChannelId: channelID, UserId: userID, Message: "root", CreateAt: 1000})
+	require.NoError(t, err)
+	reply, err := ss.Post().Save(&model.Post{ChannelId: channelID, UserId: userID, RootId: root.Id, Message: "reply", CreateAt: 3000})
+	require.NoError(t, err)
+	other, err := ss.Post().Save(&model.Post{ChannelId: channelID, UserId: userID, Message: "other", CreateAt: 2000})
+	require.NoError(t, err)
+	deleted, err := ss.Post().Save(&model.Post{ChannelId: channelID, UserId: userID, Message: "deleted", CreateAt: 2500})
+	require.NoError(t, err)
+	require.NoError(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userID))
+	_, err = ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: userID, Message: "elsewhere", CreateAt: 1500})
+	require.NoError(t, err)
+
+	posts, err := ss.Post().GetChannelPostsForExportAfter(channelID, 0, "", 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 3)
+	assert.Equal(t, root.Id, posts[0].Id)
+	assert.Equal(t, int64(1), posts[0].ReplyCount)
+	assert.Equal(t, other.Id, posts[1].Id)
+	assert.Equal(t, int64(0), posts[1].ReplyCount)
+	assert.Equal(t, reply.Id, posts[2].Id)
+
+	posts, err = ss.Post().GetChannelPostsForExportAfter(channelID, root.CreateAt, root.Id, 1)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, other.Id, posts[0].Id)
+}
+
+func testPostStoreGetUserPostsForExportAfter(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	first, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: userID, Message: "first", CreateAt: 1000})
+	require.NoError(t, err)
+	deleted, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: userID, Message: "deleted", CreateAt: 2000})
+	require.NoError(t, err)
+	require.NoError(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userID))
+	_, err = ss.Post().Save(&model.Post{ChannelId: first.ChannelId, UserId: model.NewId(), Message: "someone else", CreateAt: 1500})
+	require.NoError(t, err)
+
+	posts, err := ss.Post().GetUserPostsForExportAfter(userID, 0, "", 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, first.Id, posts[0].Id)
+	assert.Equal(t, deleted.Id, posts[1].Id)
+	assert.NotZero(t, posts[1].DeleteAt)
+
+	posts, err = ss.Post().GetUserPostsForExportAfter(userID, first.CreateAt, first.Id, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, deleted.Id, posts[0].Id)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestPushNotificationReceiptStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGetForUser", func(t *testing.T) { testPushNotificationReceiptSaveAndGet(t, ss) })
+	t.Run("MarkReceived", func(t *testing.T) { testPushNotificationReceiptMarkReceived(t, ss) })
+	t.Run("Cleanup", func(t *testing.T) { testPushNotificationReceiptCleanup(t, ss) })
+}
+
+func newTestPushNotificationReceipt(userID string, createAt int64) *model.PushNotificationReceipt {
+	return &model.PushNotificationReceipt{
+		AckId:    model.NewId(),
+		UserId:   userID,
+		PostId:   model.NewId(),
+		Platform: model.PushNotifyAndroidReactNative,
+		Type:     model.PushTypeMessage,
+		Status:   model.PushReceiptStatusSent,
+		CreateAt: createAt,
+	}
+}
+
+func testPushNotificationReceiptSaveAndGet(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	first, err := ss.PushNotificationReceipt().Save(newTestPushNotificationReceipt(userID, 1000))
+	require.NoError(t, err)
+
+	failed := newTestPushNotificationReceipt(userID, 2000)
+	failed.Status = model.PushReceiptStatusFailed
+	failed.Error = "Device was reported as removed"
+	_, err = ss.PushNotificationReceipt().Save(failed)
+	require.NoError(t, err)
+
+	_, err = ss.PushNotificationReceipt().Save(newTestPushNotificationReceipt(model.NewId(), 3000))
+	require.NoError(t, err)
+
+	_, err = ss.PushNotificationReceipt().Save(&model.PushNotificationReceipt{AckId: model.NewId(), UserId: userID, Status: "lost"})
+	require.Error(t, err)
+
+	receipts, err := ss.PushNotificationReceipt().GetForUser(userID, 10)
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+	assert.Equal(t, failed.AckId, receipts[0].AckId, "newest receipts should come first")
+	assert.Equal(t, failed.Error, receipts[0].Error)
+	assert.Equal(t, first.AckId, receipts[1].AckId)
+
+	receipts, err = ss.PushNotificationReceipt().GetForUser(userID, 1)
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+}
+
+func testPushNotificationReceiptMarkReceived(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	receipt, err := ss.PushNotificationReceipt().Save(newTestPushNotificationReceipt(userID, model.GetMillis()))
+	require.NoError(t, err)
+
+	err = ss.PushNotificationReceipt().MarkReceived(receipt.AckId, 5000)
+	require.NoError(t, err)
+
+	receipts, err := ss.PushNotificationReceipt().GetForUser(userID, 10)
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	assert.Equal(t, model.PushReceiptStatusReceived, receipts[0].Status)
+	assert.Equal(t, int64(5000), receipts[0].ReceivedAt)
+
+	err = ss.PushNotificationReceipt().MarkReceived(model.NewId(), 5000)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+}
+
+func testPushNotificationReceiptCleanup(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	_, err := ss.PushNotificationReceipt().Save(newTestPushNotificationReceipt(userID, 1000))
+	require.NoError(t, err)
+	_, err = ss.PushNotificationReceipt().Save(newTestPushNotificationReceipt(userID, 2000))
+	require.NoError(t, err)
+	recent, err := ss.PushNotificationReceipt().Save(newTestPushNotificationReceipt(userID, model.GetMillis()))
+	require.NoError(t, err)
+
+	err = ss.PushNotificationReceipt().Cleanup(3000, 1)
+	require.NoError(t, err)
+
+	receipts, err := ss.PushNotificationReceipt().GetForUser(userID, 10)
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	assert.Equal(t, recent.AckId, receipts[0].AckId)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestReminderStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdateDelete", func(t *testing.T) { testReminderSaveGetUpdateDelete(t, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testReminderGetForUser(t, ss) })
+	t.Run("GetDue", func(t *testing.T) { testReminderGetDue(t, ss) })
+}
+
+func testReminderSaveGetUpdateDelete(t *testing.T, ss store.Store) {
+	_, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), RemindAt: 1000})
+	require.Error(t, err)
+
+	reminder, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "water the plants", RemindAt: 1000})
+	require.NoError(t, err)
+	assert.NotEmpty(t, reminder.Id)
+	assert.NotZero(t, reminder.CreateAt)
+
+	reminder.RemindAt = 2000
+	reminder.Recurrence = model.ReminderRecurrenceDaily
+	_, err = ss.Reminder().Update(reminder)
+	require.NoError(t, err)
+
+	got, err := ss.Reminder().Get(reminder.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "water the plants", got.Message)
+	assert.Equal(t, int64(2000), got.RemindAt)
+	assert.Equal(t, model.ReminderRecurrenceDaily, got.Recurrence)
+
+	require.NoError(t, ss.Reminder().Delete(reminder.Id))
+
+	_, err = ss.Reminder().Get(reminder.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testReminderGetForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	later, err := ss.Reminder().Save(&model.Reminder{UserId: userID, Message: "later", RemindAt: 2000})
+	require.NoError(t, err)
+	defer ss.Reminder().Delete(later.Id)
+
+	sooner, err := ss.Reminder().Save(&model.Reminder{UserId: userID, PostId: model.NewId(), RemindAt: 1000})
+	require.NoError(t, err)
+	defer ss.Reminder().Delete(sooner.Id)
+
+	other, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "other", RemindAt: 1000})
+	require.NoError(t, err)
+	defer ss.Reminder().Delete(other.Id)
+
+	reminders, err := ss.Reminder().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, sooner.Id, reminders[0].Id)
+	assert.Equal(t, later.Id, reminders[1].Id)
+}
+
+func testReminderGetDue(t *testing.T, ss store.Store) {
+	// Reminders due long ago, so that no other test's reminders come first.
+	first, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "first", RemindAt: 1})
+	require.NoError(t, err)
+	defer ss.Reminder().Delete(first.Id)
+
+	second, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "second", RemindAt: 2})
+	require.NoError(t, err)
+	defer ss.Reminder().Delete(second.Id)
+
+	notDue, err := ss.Reminder().Save(&model.Reminder{UserId: model.NewId(), Message: "not due", RemindAt: 4})
+	require.NoError(t, err)
+	defer ss.Reminder().Delete(notDue.Id)
+
+	reminders, err := ss.Reminder().GetDue(3, 10)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, first.Id, reminders[0].Id)
+	assert.Equal(t, second.Id, reminders[1].Id)
+
+	reminders, err = ss.Reminder().GetDue(3, 1)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, first.Id, reminders[0].Id)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestScheduledConfigChangeStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testScheduledConfigChangeSaveGet(t, ss) })
+	t.Run("GetAllAndGetDue", func(t *testing.T) { testScheduledConfigChangeGetAllAndGetDue(t, ss) })
+	t.Run("UpdateStatus", func(t *testing.T) { testScheduledConfigChangeUpdateStatus(t, ss) })
+}
+
+func newTestScheduledConfigChange(applyAt int64) *model.ScheduledConfigChange {
+	cfg := &model.Config{}
+	cfg.AnnouncementSettings.EnableBanner = model.NewBool(true)
+
+	return &model.ScheduledConfigChange{
+		CreatorId: model.NewId(),
+		ApplyAt:   applyAt,
+		Config:    cfg,
+		Diffs:     json.RawMessage(`[{"path":"AnnouncementSettings.EnableBanner","base_val":false,"actual_val":true}]`),
+	}
+}
+
+func testScheduledConfigChangeSaveGet(t *testing.T, ss store.Store) {
+	invalid := newTestScheduledConfigChange(1000)
+	invalid.Config = nil
+	_, err := ss.ScheduledConfigChange().Save(invalid)
+	require.Error(t, err)
+
+	change, err := ss.ScheduledConfigChange().Save(newTestScheduledConfigChange(1000))
+	require.NoError(t, err)
+	assert.NotEmpty(t, change.Id)
+	assert.Equal(t, model.ScheduledConfigChangeStatusPending, change.Status)
+
+	got, err := ss.ScheduledConfigChange().Get(change.Id)
+	require.NoError(t, err)
+	assert.Equal(t, change.CreatorId, got.CreatorId)
+	assert.Equal(t, int64(1000), got.ApplyAt)
+	require.NotNil(t, got.Config)
+	require.NotNil(t, got.Config.AnnouncementSettings.EnableBanner)
+	assert.True(t, *got.Config.AnnouncementSettings.EnableBanner)
+	assert.Nil(t, got.Config.ServiceSettings.SiteURL)
+	assert.JSONEq(t, string(change.Diffs), string(got.Diffs))
+
+	_, err = ss.ScheduledConfigChange().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+}
+
+func testScheduledConfigChangeGetAllAndGetDue(t *testing.T, ss store.Store) {
+	now := model.GetMillis()
+	first, err := ss.ScheduledConfigChange().Save(newTestScheduledConfigChange(now - 2000))
+	require.NoError(t, err)
+	second, err := ss.ScheduledConfigChange().Save(newTestScheduledConfigChange(now - 1000))
+	require.NoError(t, err)
+	later, err := ss.ScheduledConfigChange().Save(newTestScheduledConfigChange(now + 60*60*1000))
+	require.NoError(t, err)
+	canceled, err := ss.ScheduledConfigChange().Save(newTestScheduledConfigChange(now - 3000))
+	require.NoError(t, err)
+	_, err = ss.ScheduledConfigChange().UpdateStatus(canceled.Id, model.ScheduledConfigChangeStatusPending, model.ScheduledConfigChangeStatusCanceled, "")
+	require.NoError(t, err)
+
+	due, err := ss.ScheduledConfigChange().GetDue(now, 100)
+	require.NoError(t, err)
+	var dueIDs []string
+	for _, change := range due {
+		dueIDs = append(dueIDs, change.Id)
+	}
+	assert.Contains(t, dueIDs, first.Id)
+	assert.Contains(t, dueIDs, second.Id)
+	assert.NotContains(t, dueIDs, later.Id)
+	assert.NotContains(t, dueIDs, canceled.Id)
+
+	pending, err := ss.ScheduledConfigChange().GetAll(false, 0, 100)
+	require.NoError(t, err)
+	require.NotEmpty(t, pending)
+	assert.Equal(t, later.Id, pending[0].Id)
+	for _, change := range pending {
+		assert.NotEqual(t, canceled.Id, change.Id)
+	}
+
+	all, err := ss.ScheduledConfigChange().GetAll(true, 0, 100)
+	require.NoError(t, err)
+	var allIDs []string
+	for _, change := range all {
+		allIDs = append(allIDs, change.Id)
+	}
+	assert.Contains(t, allIDs, canceled.Id)
+
+	limited, err := ss.ScheduledConfigChange().GetAll(true, 0, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
+
+func testScheduledConfigChangeUpdateStatus(t *testing.T, ss store.Store) {
+	change, err := ss.ScheduledConfigChange().Save(newTestScheduledConfigChange(1000))
+	require.NoError(t, err)
+
+	updated, err := ss.ScheduledConfigChange().UpdateStatus(change.Id, model.ScheduledConfigChangeStatusPending, model.ScheduledConfigChangeStatusApplied, "")
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	updated, err = ss.ScheduledConfigChange().UpdateStatus(change.Id, model.ScheduledConfigChangeStatusPending, model.ScheduledConfigChangeStatusCanceled, "")
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	updated, err = ss.ScheduledConfigChange().UpdateStatus(change.Id, model.ScheduledConfigChangeStatusApplied, model.ScheduledConfigChangeStatusFailed, "invalid config")
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	got, err := ss.ScheduledConfigChange().Get(change.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.ScheduledConfigChangeStatusFailed, got.Status)
+	assert.Equal(t, "invalid config", got.Error)
+}
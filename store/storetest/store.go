@@ -16,44 +16,66 @@ import (
 
 // Store can be used to provide mock stores for testing.
 type Store struct {
-	TeamStore                 mocks.TeamStore
-	ChannelStore              mocks.ChannelStore
-	PostStore                 mocks.PostStore
-	UserStore                 mocks.UserStore
-	RetentionPolicyStore      mocks.RetentionPolicyStore
-	BotStore                  mocks.BotStore
-	AuditStore                mocks.AuditStore
-	ClusterDiscoveryStore     mocks.ClusterDiscoveryStore
-	RemoteClusterStore        mocks.RemoteClusterStore
-	ComplianceStore           mocks.ComplianceStore
-	SessionStore              mocks.SessionStore
-	OAuthStore                mocks.OAuthStore
-	SystemStore               mocks.SystemStore
-	WebhookStore              mocks.WebhookStore
-	CommandStore              mocks.CommandStore
-	CommandWebhookStore       mocks.CommandWebhookStore
-	PreferenceStore           mocks.PreferenceStore
-	LicenseStore              mocks.LicenseStore
-	TokenStore                mocks.TokenStore
-	EmojiStore                mocks.EmojiStore
-	ThreadStore               mocks.ThreadStore
-	StatusStore               mocks.StatusStore
-	FileInfoStore             mocks.FileInfoStore
-	UploadSessionStore        mocks.UploadSessionStore
-	ReactionStore             mocks.ReactionStore
-	JobStore                  mocks.JobStore
-	UserAccessTokenStore      mocks.UserAccessTokenStore
-	PluginStore               mocks.PluginStore
-	ChannelMemberHistoryStore mocks.ChannelMemberHistoryStore
-	RoleStore                 mocks.RoleStore
-	SchemeStore               mocks.SchemeStore
-	TermsOfServiceStore       mocks.TermsOfServiceStore
-	GroupStore                mocks.GroupStore
-	UserTermsOfServiceStore   mocks.UserTermsOfServiceStore
-	LinkMetadataStore         mocks.LinkMetadataStore
-	SharedChannelStore        mocks.SharedChannelStore
-	ProductNoticesStore       mocks.ProductNoticesStore
-	context                   context.Context
+	TeamStore                    mocks.TeamStore
+	ChannelStore                 mocks.ChannelStore
+	PostStore                    mocks.PostStore
+	UserStore                    mocks.UserStore
+	RetentionPolicyStore         mocks.RetentionPolicyStore
+	BotStore                     mocks.BotStore
+	AuditStore                   mocks.AuditStore
+	ClusterDiscoveryStore        mocks.ClusterDiscoveryStore
+	RemoteClusterStore           mocks.RemoteClusterStore
+	ComplianceStore              mocks.ComplianceStore
+	SessionStore                 mocks.SessionStore
+	OAuthStore                   mocks.OAuthStore
+	SystemStore                  mocks.SystemStore
+	WebhookStore                 mocks.WebhookStore
+	CommandStore                 mocks.CommandStore
+	CommandWebhookStore          mocks.CommandWebhookStore
+	PreferenceStore              mocks.PreferenceStore
+	LicenseStore                 mocks.LicenseStore
+	TokenStore                   mocks.TokenStore
+	EmojiStore                   mocks.EmojiStore
+	ThreadStore                  mocks.ThreadStore
+	StatusStore                  mocks.StatusStore
+	FileInfoStore                mocks.FileInfoStore
+	UploadSessionStore           mocks.UploadSessionStore
+	ReactionStore                mocks.ReactionStore
+	JobStore                     mocks.JobStore
+	UserAccessTokenStore         mocks.UserAccessTokenStore
+	PluginStore                  mocks.PluginStore
+	ChannelMemberHistoryStore    mocks.ChannelMemberHistoryStore
+	RoleStore                    mocks.RoleStore
+	SchemeStore                  mocks.SchemeStore
+	TermsOfServiceStore          mocks.TermsOfServiceStore
+	GroupStore                   mocks.GroupStore
+	UserTermsOfServiceStore      mocks.UserTermsOfServiceStore
+	LinkMetadataStore            mocks.LinkMetadataStore
+	SharedChannelStore           mocks.SharedChannelStore
+	ProductNoticesStore          mocks.ProductNoticesStore
+	PushReceiptStore             mocks.PushNotificationReceiptStore
+	TeamTemplateStore            mocks.TeamTemplateStore
+	OnboardingTaskStore          mocks.OnboardingTaskStore
+	ConnectivityTestStore        mocks.ConnectivityTestResultStore
+	TablePartitionStore          mocks.TablePartitionStore
+	PostArchiveStore             mocks.PostArchiveStore
+	PersistentWSEventStore       mocks.PersistentWebSocketEventStore
+	ChannelMemberTimeoutStore    mocks.ChannelMemberTimeoutStore
+	PostReportStore              mocks.PostReportStore
+	UserDeviceStore              mocks.UserDeviceStore
+	MfaBackupCodeStore           mocks.MfaBackupCodeStore
+	PostRetentionLabelStore      mocks.PostRetentionLabelStore
+	DirectMessageRequestStore    mocks.DirectMessageRequestStore
+	ReminderStore                mocks.ReminderStore
+	ChannelBookmarkStore         mocks.ChannelBookmarkStore
+	PostPropSchemaStore          mocks.PostPropSchemaStore
+	ChannelEventStore            mocks.ChannelEventStore
+	ScheduledConfigChangeStore   mocks.ScheduledConfigChangeStore
+	ChangeEventStore             mocks.ChangeEventStore
+	OutgoingOAuthConnectionStore mocks.OutgoingOAuthConnectionStore
+	ChannelJoinRequestStore      mocks.ChannelJoinRequestStore
+	TeamInviteLinkStore          mocks.TeamInviteLinkStore
+	context                      context.Context
 }
 
 func (s *Store) SetContext(context context.Context)                { s.context = context }
@@ -91,6 +113,60 @@ func (s *Store) Role() store.RoleStore                             { return &s.R
 func (s *Store) Scheme() store.SchemeStore                         { return &s.SchemeStore }
 func (s *Store) TermsOfService() store.TermsOfServiceStore         { return &s.TermsOfServiceStore }
 func (s *Store) UserTermsOfService() store.UserTermsOfServiceStore { return &s.UserTermsOfServiceStore }
+func (s *Store) PushNotificationReceipt() store.PushNotificationReceiptStore {
+	return &s.PushReceiptStore
+}
+func (s *Store) TeamTemplate() store.TeamTemplateStore     { return &s.TeamTemplateStore }
+func (s *Store) OnboardingTask() store.OnboardingTaskStore { return &s.OnboardingTaskStore }
+func (s *Store) ConnectivityTestResult() store.ConnectivityTestResultStore {
+	return &s.ConnectivityTestStore
+}
+func (s *Store) TablePartition() store.TablePartitionStore { return &s.TablePartitionStore }
+func (s *Store) PostArchive() store.PostArchiveStore       { return &s.PostArchiveStore }
+func (s *Store) PersistentWebSocketEvent() store.PersistentWebSocketEventStore {
+	return &s.PersistentWSEventStore
+}
+func (s *Store) ChannelMemberTimeout() store.ChannelMemberTimeoutStore {
+	return &s.ChannelMemberTimeoutStore
+}
+func (s *Store) PostReport() store.PostReportStore { return &s.PostReportStore }
+func (s *Store) UserDevice() store.UserDeviceStore { return &s.UserDeviceStore }
+func (s *Store) MfaBackupCode() store.MfaBackupCodeStore {
+	return &s.MfaBackupCodeStore
+}
+func (s *Store) PostRetentionLabel() store.PostRetentionLabelStore {
+	return &s.PostRetentionLabelStore
+}
+func (s *Store) DirectMessageRequest() store.DirectMessageRequestStore {
+	return &s.DirectMessageRequestStore
+}
+func (s *Store) Reminder() store.ReminderStore {
+	return &s.ReminderStore
+}
+func (s *Store) ChannelBookmark() store.ChannelBookmarkStore {
+	return &s.ChannelBookmarkStore
+}
+func (s *Store) PostPropSchema() store.PostPropSchemaStore {
+	return &s.PostPropSchemaStore
+}
+func (s *Store) ChannelEvent() store.ChannelEventStore {
+	return &s.ChannelEventStore
+}
+func (s *Store) ScheduledConfigChange() store.ScheduledConfigChangeStore {
+	return &s.ScheduledConfigChangeStore
+}
+func (s *Store) ChangeEvent() store.ChangeEventStore {
+	return &s.ChangeEventStore
+}
+func (s *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	return &s.OutgoingOAuthConnectionStore
+}
+func (s *Store) ChannelJoinRequest() store.ChannelJoinRequestStore {
+	return &s.ChannelJoinRequestStore
+}
+func (s *Store) TeamInviteLink() store.TeamInviteLinkStore {
+	return &s.TeamInviteLinkStore
+}
 func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return &s.ChannelMemberHistoryStore
 }
@@ -108,6 +184,12 @@ func (s *Store) GetDBSchemaVersion() (int, error)        { return 1, nil }
 func (s *Store) GetAppliedMigrations() ([]model.AppliedMigration, error) {
 	return []model.AppliedMigration{}, nil
 }
+func (s *Store) GetTableRowCounts() ([]*model.TableRowCount, error) {
+	return []*model.TableRowCount{}, nil
+}
+func (s *Store) GetSlowQueries(int) ([]*model.SlowQuery, error) {
+	return []*model.SlowQuery{}, nil
+}
 func (s *Store) TotalMasterDbConnections() int { return 1 }
 func (s *Store) TotalReadDbConnections() int   { return 1 }
 func (s *Store) TotalSearchDbConnections() int { return 1 }
@@ -151,5 +233,27 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.ThreadStore,
 		&s.ProductNoticesStore,
 		&s.SharedChannelStore,
+		&s.PushReceiptStore,
+		&s.TeamTemplateStore,
+		&s.OnboardingTaskStore,
+		&s.ConnectivityTestStore,
+		&s.TablePartitionStore,
+		&s.PostArchiveStore,
+		&s.PersistentWSEventStore,
+		&s.ChannelMemberTimeoutStore,
+		&s.PostReportStore,
+		&s.UserDeviceStore,
+		&s.MfaBackupCodeStore,
+		&s.PostRetentionLabelStore,
+		&s.DirectMessageRequestStore,
+		&s.ReminderStore,
+		&s.ChannelBookmarkStore,
+		&s.PostPropSchemaStore,
+		&s.ChannelEventStore,
+		&s.ScheduledConfigChangeStore,
+		&s.ChangeEventStore,
+		&s.OutgoingOAuthConnectionStore,
+		&s.ChannelJoinRequestStore,
+		&s.TeamInviteLinkStore,
 	)
 }
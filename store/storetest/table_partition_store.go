@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestTablePartitionStore(t *testing.T, ss store.Store, s SqlStore) {
+	t.Run("NotSupported", func(t *testing.T) { testTablePartitionNotSupported(t, ss, s) })
+	t.Run("Partition", func(t *testing.T) { testTablePartitionPartition(t, ss, s) })
+}
+
+func testTablePartitionNotSupported(t *testing.T, ss store.Store, s SqlStore) {
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		t.Skip("table partitioning is supported on PostgreSQL")
+	}
+
+	_, err := ss.TablePartition().IsPartitioned("Posts")
+	var nieErr *store.ErrNotImplemented
+	assert.ErrorAs(t, err, &nieErr)
+}
+
+func testTablePartitionPartition(t *testing.T, ss store.Store, s SqlStore) {
+	if s.DriverName() != model.DatabaseDriverPostgres {
+		t.Skip("table partitioning is only supported on PostgreSQL")
+	}
+
+	// A table shaped like the partitioned ones, so that the shared tables are left alone.
+	const table = "PartitionTest"
+	_, err := s.GetMasterX().Exec("CREATE TABLE partitiontest (id varchar(26) PRIMARY KEY, channelid varchar(26), createat bigint, message text)")
+	require.NoError(t, err)
+	_, err = s.GetMasterX().Exec("CREATE INDEX idx_partitiontest_create_at ON partitiontest (createat)")
+	require.NoError(t, err)
+	defer func() {
+		_, err := s.GetMasterX().Exec("DROP TABLE IF EXISTS partitiontest CASCADE")
+		require.NoError(t, err)
+		_, err = s.GetMasterX().Exec("DROP TABLE IF EXISTS partitiontest_legacy CASCADE")
+		require.NoError(t, err)
+	}()
+
+	now := time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC)
+	_, err = s.GetMasterX().Exec("INSERT INTO partitiontest (id, createat, message) VALUES (?, ?, 'old')", model.NewId(), model.GetMillisForTime(now.AddDate(0, -6, 0)))
+	require.NoError(t, err)
+
+	partitioned, err := ss.TablePartition().IsPartitioned(table)
+	require.NoError(t, err)
+	require.False(t, partitioned)
+
+	current := model.NewMonthlyTablePartition(table, now)
+	require.NoError(t, ss.TablePartition().Partition(table, current.Start))
+
+	partitioned, err = ss.TablePartition().IsPartitioned(table)
+	require.NoError(t, err)
+	require.True(t, partitioned)
+
+	next := model.NewMonthlyTablePartition(table, now.AddDate(0, 1, 0))
+	require.NoError(t, ss.TablePartition().CreatePartition(next))
+	require.NoError(t, ss.TablePartition().CreatePartition(current))
+	// creating an existing partition is a no-op.
+	require.NoError(t, ss.TablePartition().CreatePartition(current))
+	// so is creating a partition whose rows are held by the legacy partition.
+	require.NoError(t, ss.TablePartition().CreatePartition(model.NewMonthlyTablePartition(table, now.AddDate(0, -1, 0))))
+
+	partitions, err := ss.TablePartition().GetPartitions(table)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.TablePartition{current, next}, partitions)
+
+	postID := model.NewId()
+	_, err = s.GetMasterX().Exec("INSERT INTO partitiontest (id, channelid, createat, message) VALUES (?, ?, ?, 'new')", postID, model.NewId(), model.GetMillisForTime(now))
+	require.NoError(t, err)
+
+	t.Run("retained rows", func(t *testing.T) {
+		later := model.GetMillisForTime(now.AddDate(1, 0, 0))
+
+		retained, err := ss.TablePartition().HasRetainedRows(current, later, 0)
+		require.NoError(t, err)
+		assert.False(t, retained, "no policy keeps the post")
+
+		retained, err = ss.TablePartition().HasRetainedRows(current, later, current.Start)
+		require.NoError(t, err)
+		assert.True(t, retained, "the global policy keeps the post")
+
+		_, err = ss.PostRetentionLabel().Save(&model.PostRetentionLabel{PostId: postID, Label: "legal-hold", ExpireAt: later + 1, CreatorId: model.NewId()})
+		require.NoError(t, err)
+		defer ss.PostRetentionLabel().Delete(postID)
+
+		retained, err = ss.TablePartition().HasRetainedRows(current, later, 0)
+		require.NoError(t, err)
+		assert.True(t, retained, "the label keeps the post")
+
+		retained, err = ss.TablePartition().HasRetainedRows(current, later+1, current.Start)
+		require.NoError(t, err)
+		assert.False(t, retained, "the expired label overrides the global policy")
+	})
+
+	var count int64
+	require.NoError(t, s.GetMasterX().Get(&count, "SELECT COUNT(*) FROM partitiontest"))
+	assert.Equal(t, int64(2), count)
+
+	require.NoError(t, ss.TablePartition().DropPartition(current))
+	require.NoError(t, s.GetMasterX().Get(&count, "SELECT COUNT(*) FROM partitiontest"))
+	assert.Equal(t, int64(1), count, "the rows of the dropped partition should be gone")
+
+	partitions, err = ss.TablePartition().GetPartitions(table)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.TablePartition{next}, partitions)
+
+	err = ss.TablePartition().DropPartition(&model.TablePartition{Table: table, Name: "partitiontest_legacy"})
+	var invErr *store.ErrInvalidInput
+	assert.ErrorAs(t, err, &invErr)
+}
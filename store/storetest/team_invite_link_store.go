@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestTeamInviteLinkStore(t *testing.T, ss store.Store) {
+	t.Run("SaveGetUpdate", func(t *testing.T) { testTeamInviteLinkSaveGetUpdate(t, ss) })
+	t.Run("GetForTeam", func(t *testing.T) { testTeamInviteLinkGetForTeam(t, ss) })
+	t.Run("Use", func(t *testing.T) { testTeamInviteLinkUse(t, ss) })
+}
+
+func testTeamInviteLinkSaveGetUpdate(t *testing.T, ss store.Store) {
+	_, err := ss.TeamInviteLink().Save(&model.TeamInviteLink{TeamId: model.NewId(), CreatorId: "invalid"})
+	require.Error(t, err)
+
+	link, err := ss.TeamInviteLink().Save(&model.TeamInviteLink{
+		TeamId:    model.NewId(),
+		CreatorId: model.NewId(),
+		Name:      "Conference",
+		MaxUses:   10,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, link.Id)
+
+	link.MaxUses = -1
+	_, err = ss.TeamInviteLink().Update(link)
+	require.Error(t, err)
+
+	link.MaxUses = 5
+	link.AllowedDomains = "example.com"
+	link.UseCount = 3
+	_, err = ss.TeamInviteLink().Update(link)
+	require.NoError(t, err)
+
+	got, err := ss.TeamInviteLink().Get(link.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Conference", got.Name)
+	assert.Equal(t, int64(5), got.MaxUses)
+	assert.Equal(t, "example.com", got.AllowedDomains)
+	assert.Zero(t, got.UseCount, "the counters are not updated")
+
+	_, err = ss.TeamInviteLink().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testTeamInviteLinkGetForTeam(t *testing.T, ss store.Store) {
+	teamID := model.NewId()
+
+	first, err := ss.TeamInviteLink().Save(&model.TeamInviteLink{TeamId: teamID, CreatorId: model.NewId(), CreateAt: 1000})
+	require.NoError(t, err)
+	second, err := ss.TeamInviteLink().Save(&model.TeamInviteLink{TeamId: teamID, CreatorId: model.NewId(), CreateAt: 2000})
+	require.NoError(t, err)
+	_, err = ss.TeamInviteLink().Save(&model.TeamInviteLink{TeamId: model.NewId(), CreatorId: model.NewId()})
+	require.NoError(t, err)
+
+	first.DeleteAt = model.GetMillis()
+	_, err = ss.TeamInviteLink().Update(first)
+	require.NoError(t, err)
+
+	links, err := ss.TeamInviteLink().GetForTeam(teamID, false)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, second.Id, links[0].Id)
+
+	links, err = ss.TeamInviteLink().GetForTeam(teamID, true)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, second.Id, links[0].Id)
+	assert.Equal(t, first.Id, links[1].Id)
+}
+
+func testTeamInviteLinkUse(t *testing.T, ss store.Store) {
+	var nfErr *store.ErrNotFound
+
+	t.Run("capped", func(t *testing.T) {
+		link, err := ss.TeamInviteLink().Save(&model.TeamInviteLink{TeamId: model.NewId(), CreatorId: model.NewId(), MaxUses: 2})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.TeamInviteLink().Use(link.Id, 1000))
+		require.NoError(t, ss.TeamInviteLink().Use(link.Id, 2000))
+		require.True(t, errors.As(ss.TeamInviteLink().Use(link.Id, 3000), &nfErr))
+
+		require.NoError(t, ss.TeamInviteLink().Release(link.Id))
+		require.NoError(t, ss.TeamInviteLink().Use(link.Id, 4000))
+		require.NoError(t, ss.TeamInviteLink().Reject(link.Id))
+
+		got, err := ss.TeamInviteLink().Get(link.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), got.UseCount)
+		assert.Equal(t, int64(1), got.RejectedCount)
+		assert.Equal(t, int64(4000), got.LastUsedAt)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		link, err := ss.TeamInviteLink().Save(&model.TeamInviteLink{TeamId: model.NewId(), CreatorId: model.NewId(), ExpireAt: 2000})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.TeamInviteLink().Use(link.Id, 1000))
+		require.True(t, errors.As(ss.TeamInviteLink().Use(link.Id, 2000), &nfErr))
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		link, err := ss.TeamInviteLink().Save(&model.TeamInviteLink{TeamId: model.NewId(), CreatorId: model.NewId()})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.TeamInviteLink().Use(link.Id, 1000))
+
+		link.DeleteAt = model.GetMillis()
+		_, err = ss.TeamInviteLink().Update(link)
+		require.NoError(t, err)
+		require.True(t, errors.As(ss.TeamInviteLink().Use(link.Id, 2000), &nfErr))
+	})
+}
@@ -46,6 +46,7 @@ func TestTeamStore(t *testing.T, ss store.Store) {
 	t.Run("GetAllPrivateTeamPageListing", func(t *testing.T) { testGetAllPrivateTeamPageListing(t, ss) })
 	t.Run("GetAllPublicTeamPageListing", func(t *testing.T) { testGetAllPublicTeamPageListing(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testDelete(t, ss) })
+	t.Run("SoftDeleteAndRestore", func(t *testing.T) { testTeamStoreSoftDeleteAndRestore(t, ss) })
 	t.Run("TeamCount", func(t *testing.T) { testTeamCount(t, ss) })
 	t.Run("TeamPublicCount", func(t *testing.T) { testPublicTeamCount(t, ss) })
 	t.Run("TeamPrivateCount", func(t *testing.T) { testPrivateTeamCount(t, ss) })
@@ -1064,6 +1065,108 @@ func testDelete(t *testing.T, ss store.Store) {
 	require.NoError(t, r1)
 }
 
+func testTeamStoreSoftDeleteAndRestore(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        NewTestId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	channel, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Open",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+	archived, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Archived",
+		Name:        NewTestId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, nErr)
+	require.NoError(t, ss.Channel().Delete(archived.Id, 1000))
+
+	member, nErr := ss.Team().SaveMember(&model.TeamMember{TeamId: team.Id, UserId: model.NewId()}, -1)
+	require.NoError(t, nErr)
+
+	command, nErr := ss.Command().Save(&model.Command{
+		CreatorId: model.NewId(),
+		Method:    model.CommandMethodPost,
+		TeamId:    team.Id,
+		URL:       "http://nowhere.com/",
+		Trigger:   "trigger",
+	})
+	require.NoError(t, nErr)
+
+	deleteAt := model.GetMillis()
+	require.NoError(t, ss.Team().SoftDelete(team.Id, deleteAt))
+
+	t.Run("cascades to the channels, members and commands", func(t *testing.T) {
+		deleted, err := ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt, deleted.DeleteAt)
+
+		deletedChannel, err := ss.Channel().Get(channel.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt, deletedChannel.DeleteAt)
+
+		deletedArchived, err := ss.Channel().Get(archived.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), deletedArchived.DeleteAt)
+
+		deletedMember, err := ss.Team().GetMember(context.Background(), team.Id, member.UserId)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt, deletedMember.DeleteAt)
+
+		_, err = ss.Command().Get(command.Id)
+		var nfErr *store.ErrNotFound
+		assert.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("lists the deleted teams", func(t *testing.T) {
+		ids, err := ss.Team().GetSoftDeletedIds(deleteAt+1, 1000)
+		require.NoError(t, err)
+		assert.Contains(t, ids, team.Id)
+
+		ids, err = ss.Team().GetSoftDeletedIds(deleteAt, 1000)
+		require.NoError(t, err)
+		assert.NotContains(t, ids, team.Id)
+	})
+
+	t.Run("restores what was deleted with the team", func(t *testing.T) {
+		require.NoError(t, ss.Team().Restore(team.Id))
+
+		restored, err := ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		assert.Zero(t, restored.DeleteAt)
+
+		restoredChannel, err := ss.Channel().Get(channel.Id, false)
+		require.NoError(t, err)
+		assert.Zero(t, restoredChannel.DeleteAt)
+
+		stillArchived, err := ss.Channel().Get(archived.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), stillArchived.DeleteAt)
+
+		restoredMember, err := ss.Team().GetMember(context.Background(), team.Id, member.UserId)
+		require.NoError(t, err)
+		assert.Zero(t, restoredMember.DeleteAt)
+
+		_, err = ss.Command().Get(command.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("restoring a missing team", func(t *testing.T) {
+		err := ss.Team().Restore(model.NewId())
+		var nfErr *store.ErrNotFound
+		assert.True(t, errors.As(err, &nfErr))
+	})
+}
+
 func testPublicTeamCount(t *testing.T, ss store.Store) {
 	cleanupTeamStore(t, ss)
 
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestTeamTemplateStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testTeamTemplateSaveAndGet(t, ss) })
+	t.Run("Update", func(t *testing.T) { testTeamTemplateUpdate(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testTeamTemplateGetAll(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testTeamTemplateDelete(t, ss) })
+}
+
+func newTestTeamTemplate(displayName string) *model.TeamTemplate {
+	return &model.TeamTemplate{
+		DisplayName: displayName,
+		Description: "A template for new projects",
+		CreatorId:   model.NewId(),
+		Definition: model.TeamTemplateDefinition{
+			Channels: []model.TeamTemplateChannel{
+				{Name: "standup", DisplayName: "Standup", Type: model.ChannelTypeOpen},
+				{Name: "leads", DisplayName: "Leads", Type: model.ChannelTypePrivate},
+			},
+			Categories: []model.TeamTemplateCategory{
+				{DisplayName: "Project", ChannelNames: []string{"standup", "leads"}},
+			},
+		},
+	}
+}
+
+func testTeamTemplateSaveAndGet(t *testing.T, ss store.Store) {
+	template, err := ss.TeamTemplate().Save(newTestTeamTemplate("Project"))
+	require.NoError(t, err)
+	require.NotEmpty(t, template.Id)
+	defer ss.TeamTemplate().Delete(template.Id, model.GetMillis())
+
+	fetched, err := ss.TeamTemplate().Get(template.Id)
+	require.NoError(t, err)
+	assert.Equal(t, template, fetched)
+
+	_, err = ss.TeamTemplate().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+
+	invalid := newTestTeamTemplate("")
+	_, err = ss.TeamTemplate().Save(invalid)
+	require.Error(t, err)
+}
+
+func testTeamTemplateUpdate(t *testing.T, ss store.Store) {
+	template, err := ss.TeamTemplate().Save(newTestTeamTemplate("Project"))
+	require.NoError(t, err)
+	defer ss.TeamTemplate().Delete(template.Id, model.GetMillis())
+
+	template.DisplayName = "Renamed"
+	template.Definition.Channels = template.Definition.Channels[:1]
+	template.Definition.Categories = nil
+	_, err = ss.TeamTemplate().Update(template)
+	require.NoError(t, err)
+
+	fetched, err := ss.TeamTemplate().Get(template.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", fetched.DisplayName)
+	assert.Len(t, fetched.Definition.Channels, 1)
+
+	missing := newTestTeamTemplate("Missing")
+	missing.PreSave()
+	_, err = ss.TeamTemplate().Update(missing)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+}
+
+func testTeamTemplateGetAll(t *testing.T, ss store.Store) {
+	second, err := ss.TeamTemplate().Save(newTestTeamTemplate("zz Second"))
+	require.NoError(t, err)
+	defer ss.TeamTemplate().Delete(second.Id, model.GetMillis())
+
+	first, err := ss.TeamTemplate().Save(newTestTeamTemplate("zz First"))
+	require.NoError(t, err)
+	defer ss.TeamTemplate().Delete(first.Id, model.GetMillis())
+
+	templates, err := ss.TeamTemplate().GetAll(0, 100)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, template := range templates {
+		ids = append(ids, template.Id)
+	}
+	require.Contains(t, ids, first.Id)
+	require.Contains(t, ids, second.Id)
+
+	var firstIndex, secondIndex int
+	for i, id := range ids {
+		if id == first.Id {
+			firstIndex = i
+		} else if id == second.Id {
+			secondIndex = i
+		}
+	}
+	assert.Less(t, firstIndex, secondIndex)
+}
+
+func testTeamTemplateDelete(t *testing.T, ss store.Store) {
+	template, err := ss.TeamTemplate().Save(newTestTeamTemplate("Project"))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.TeamTemplate().Delete(template.Id, model.GetMillis()))
+
+	_, err = ss.TeamTemplate().Get(template.Id)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+
+	err = ss.TeamTemplate().Delete(template.Id, model.GetMillis())
+	assert.True(t, errors.As(err, &nfErr))
+}
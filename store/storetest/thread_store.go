@@ -25,6 +25,7 @@ func TestThreadStore(t *testing.T, ss store.Store, s SqlStore) {
 		testThreadStorePermanentDeleteBatchThreadMembershipsForRetentionPolicies(t, ss, s)
 	})
 	t.Run("GetTeamsUnreadForUser", func(t *testing.T) { testGetTeamsUnreadForUser(t, ss) })
+	t.Run("GetUnreadThreadsForUser", func(t *testing.T) { testGetUnreadThreadsForUser(t, ss) })
 	t.Run("GetVarious", func(t *testing.T) { testVarious(t, ss) })
 	t.Run("MarkAllAsReadByChannels", func(t *testing.T) { testMarkAllAsReadByChannels(t, ss) })
 }
@@ -1226,3 +1227,69 @@ func testMarkAllAsReadByChannels(t *testing.T, ss store.Store) {
 		assertThreadReplyCount(t, userBID, 0)
 	})
 }
+
+func testGetUnreadThreadsForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        "team" + model.NewId(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "DisplayName",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	createThread := func() string {
+		t.Helper()
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channel.Id,
+			UserId:    userID,
+			Message:   model.NewRandomString(10),
+		})
+		require.NoError(t, err)
+		threadStoreCreateReply(t, ss, channel.Id, post.Id, post.UserId, model.GetMillis())
+		_, err = ss.Thread().MaintainMembership(userID, post.Id, store.ThreadMembershipOpts{
+			Following:       true,
+			UpdateFollowing: true,
+		})
+		require.NoError(t, err)
+		return post.Id
+	}
+
+	threadID1 := createThread()
+	threadID2 := createThread()
+
+	unreads, err := ss.Thread().GetUnreadThreadsForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, unreads, 2)
+	for _, tu := range unreads {
+		assert.Contains(t, []string{threadID1, threadID2}, tu.PostId)
+		assert.Equal(t, channel.Id, tu.ChannelId)
+		assert.Equal(t, team.Id, tu.TeamId)
+		assert.True(t, tu.Unread)
+	}
+
+	err = ss.Thread().MarkAsRead(userID, threadID1, model.GetMillis()+1)
+	require.NoError(t, err)
+
+	unreads, err = ss.Thread().GetUnreadThreadsForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, unreads, 1)
+	assert.Equal(t, threadID2, unreads[0].PostId)
+
+	_, err = ss.Thread().MaintainMembership(userID, threadID2, store.ThreadMembershipOpts{
+		Following:       false,
+		UpdateFollowing: true,
+	})
+	require.NoError(t, err)
+
+	unreads, err = ss.Thread().GetUnreadThreadsForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, unreads)
+}
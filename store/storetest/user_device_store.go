@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/store"
+)
+
+func TestUserDeviceStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGetForUser", func(t *testing.T) { testUserDeviceSaveAndGetForUser(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testUserDevicePermanentDeleteByUser(t, ss) })
+}
+
+func testUserDeviceSaveAndGetForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	devices, err := ss.UserDevice().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+
+	first := &model.UserDevice{UserId: userID, Fingerprint: "a1", Country: "FR", CreateAt: 1000, LastSeenAt: 1000}
+	require.NoError(t, ss.UserDevice().Save(first))
+	second := &model.UserDevice{UserId: userID, Fingerprint: "a1", Country: "", CreateAt: 2000, LastSeenAt: 2000}
+	require.NoError(t, ss.UserDevice().Save(second))
+	require.NoError(t, ss.UserDevice().Save(&model.UserDevice{UserId: model.NewId(), Fingerprint: "a1", Country: "FR", CreateAt: 3000, LastSeenAt: 3000}))
+
+	// Saving a known device only updates when it was last seen.
+	require.NoError(t, ss.UserDevice().Save(&model.UserDevice{UserId: userID, Fingerprint: "a1", Country: "FR", CreateAt: 4000, LastSeenAt: 4000}))
+	first.LastSeenAt = 4000
+
+	devices, err = ss.UserDevice().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.UserDevice{first, second}, devices)
+}
+
+func testUserDevicePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	require.NoError(t, ss.UserDevice().Save(&model.UserDevice{UserId: userID, Fingerprint: "b1", CreateAt: 1000, LastSeenAt: 1000}))
+	require.NoError(t, ss.UserDevice().Save(&model.UserDevice{UserId: otherUserID, Fingerprint: "b1", CreateAt: 1000, LastSeenAt: 1000}))
+
+	require.NoError(t, ss.UserDevice().PermanentDeleteByUser(userID))
+
+	devices, err := ss.UserDevice().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+
+	devices, err = ss.UserDevice().GetForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, devices, 1)
+}
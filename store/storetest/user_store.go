@@ -84,6 +84,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("GetProfilesNotInTeam", func(t *testing.T) { testUserStoreGetProfilesNotInTeam(t, ss) })
 	t.Run("ClearAllCustomRoleAssignments", func(t *testing.T) { testUserStoreClearAllCustomRoleAssignments(t, ss) })
 	t.Run("GetAllAfter", func(t *testing.T) { testUserStoreGetAllAfter(t, ss) })
+	t.Run("GetNonBotUsers", func(t *testing.T) { testUserStoreGetNonBotUsers(t, ss) })
 	t.Run("GetUsersBatchForIndexing", func(t *testing.T) { testUserStoreGetUsersBatchForIndexing(t, ss) })
 	t.Run("GetTeamGroupUsers", func(t *testing.T) { testUserStoreGetTeamGroupUsers(t, ss) })
 	t.Run("GetChannelGroupUsers", func(t *testing.T) { testUserStoreGetChannelGroupUsers(t, ss) })
@@ -93,6 +94,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlStore) {
 	t.Run("ResetLastPictureUpdate", func(t *testing.T) { testUserStoreResetLastPictureUpdate(t, ss) })
 	t.Run("GetKnownUsers", func(t *testing.T) { testGetKnownUsers(t, ss) })
 	t.Run("GetUsersWithInvalidEmails", func(t *testing.T) { testGetUsersWithInvalidEmails(t, ss) })
+	t.Run("MergeInto", func(t *testing.T) { testUserStoreMergeInto(t, ss) })
 }
 
 func testUserStoreSave(t *testing.T, ss store.Store) {
@@ -4655,6 +4657,79 @@ func testUserStoreGetAllAfter(t *testing.T, ss store.Store) {
 	})
 }
 
+func testUserStoreGetNonBotUsers(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u1" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(u1.Id)) }()
+
+	u2, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u2" + model.NewId(),
+		DeleteAt: model.GetMillis(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(u2.Id)) }()
+
+	u3, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u3" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(u3.Id)) }()
+	_, nErr := ss.Bot().Save(&model.Bot{
+		UserId:   u3.Id,
+		Username: u3.Username,
+		OwnerId:  u1.Id,
+	})
+	require.NoError(t, nErr)
+	defer func() { require.NoError(t, ss.Bot().PermanentDelete(u3.Id)) }()
+
+	count, err := ss.User().Count(model.UserCountOptions{IncludeDeleted: true})
+	require.NoError(t, err)
+
+	all, err := ss.User().GetNonBotUsers(0, int(count)+10)
+	require.NoError(t, err)
+	require.Len(t, all, int(count), "the users should match the count of the users which aren't bots")
+
+	offset := -1
+	for i, user := range all {
+		assert.False(t, user.IsBot)
+		assert.NotEqual(t, u3.Id, user.Id)
+		if user.Id == u1.Id {
+			offset = i
+		}
+	}
+	require.NotEqual(t, -1, offset)
+
+	t.Run("page starting at any offset", func(t *testing.T) {
+		actual, err := ss.User().GetNonBotUsers(offset, 2)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, u1.Id, actual[0].Id)
+		assert.Equal(t, all[offset+1].Id, actual[1].Id)
+	})
+
+	t.Run("deleted users", func(t *testing.T) {
+		found := false
+		for _, user := range all {
+			if user.Id == u2.Id {
+				found = true
+				assert.NotZero(t, user.DeleteAt)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("past the last user", func(t *testing.T) {
+		actual, err := ss.User().GetNonBotUsers(len(all), 10)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
 func testUserStoreGetUsersBatchForIndexing(t *testing.T, ss store.Store) {
 	// Set up all the objects needed
 	t1, err := ss.Team().Save(&model.Team{
@@ -5930,3 +6005,80 @@ func testGetUsersWithInvalidEmails(t *testing.T, ss store.Store) {
 	require.NoError(t, err)
 	assert.Len(t, users, 1)
 }
+
+func testUserStoreMergeInto(t *testing.T, ss store.Store) {
+	source, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: "u1" + model.NewId()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(source.Id)) }()
+	target, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: "u2" + model.NewId()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(target.Id)) }()
+	other, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: "u3" + model.NewId()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(other.Id)) }()
+
+	teamID := model.NewId()
+	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: teamID, UserId: source.Id}, -1)
+	require.NoError(t, err)
+
+	shared, err := ss.Channel().Save(&model.Channel{TeamId: teamID, DisplayName: "Shared", Name: "shared" + model.NewId(), Type: model.ChannelTypeOpen}, -1)
+	require.NoError(t, err)
+	sourceOnly, err := ss.Channel().Save(&model.Channel{TeamId: teamID, DisplayName: "Source", Name: "source" + model.NewId(), Type: model.ChannelTypeOpen}, -1)
+	require.NoError(t, err)
+	for _, member := range []*model.ChannelMember{
+		{ChannelId: shared.Id, UserId: source.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		{ChannelId: shared.Id, UserId: target.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		{ChannelId: sourceOnly.Id, UserId: source.Id, NotifyProps: model.GetDefaultChannelNotifyProps()},
+	} {
+		_, err = ss.Channel().SaveMember(member)
+		require.NoError(t, err)
+	}
+
+	dm, err := ss.Channel().CreateDirectChannel(source, other)
+	require.NoError(t, err)
+
+	post, err := ss.Post().Save(&model.Post{ChannelId: sourceOnly.Id, UserId: source.Id, Message: "merged"})
+	require.NoError(t, err)
+	dmPost, err := ss.Post().Save(&model.Post{ChannelId: dm.Id, UserId: source.Id, Message: "kept"})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Preference().Save(model.Preferences{
+		{UserId: source.Id, Category: model.PreferenceCategoryDisplaySettings, Name: model.PreferenceNameUseMilitaryTime, Value: "true"},
+		{UserId: source.Id, Category: model.PreferenceCategoryTheme, Name: teamID, Value: "{}"},
+		{UserId: target.Id, Category: model.PreferenceCategoryDisplaySettings, Name: model.PreferenceNameUseMilitaryTime, Value: "false"},
+	}))
+
+	counts, err := ss.User().MergeInto(source.Id, target.Id)
+	require.NoError(t, err)
+	assert.Equal(t, &model.UserMergeCounts{
+		Posts:                 1,
+		ChannelMemberships:    1,
+		TeamMemberships:       1,
+		Preferences:           1,
+		SkippedDirectChannels: 1,
+	}, counts)
+
+	fetched, err := ss.Post().GetSingle(post.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, target.Id, fetched.UserId)
+	fetched, err = ss.Post().GetSingle(dmPost.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, source.Id, fetched.UserId)
+
+	_, err = ss.Channel().GetMember(context.Background(), sourceOnly.Id, target.Id)
+	require.NoError(t, err)
+	_, err = ss.Channel().GetMember(context.Background(), shared.Id, source.Id)
+	require.Error(t, err)
+	_, err = ss.Channel().GetMember(context.Background(), dm.Id, source.Id)
+	require.NoError(t, err)
+	_, err = ss.Team().GetMember(context.Background(), teamID, target.Id)
+	require.NoError(t, err)
+
+	preferences, err := ss.Preference().GetCategory(target.Id, model.PreferenceCategoryDisplaySettings)
+	require.NoError(t, err)
+	require.Len(t, preferences, 1)
+	assert.Equal(t, "false", preferences[0].Value)
+	preferences, err = ss.Preference().GetCategory(source.Id, model.PreferenceCategoryDisplaySettings)
+	require.NoError(t, err)
+	assert.Empty(t, preferences)
+}
@@ -564,9 +564,14 @@ func testWebhookStoreUpdateOutgoing(t *testing.T, ss store.Store) {
 
 	o1.Token = model.NewId()
 	o1.Username = "another-test-user-name"
+	o1.PayloadFormat = model.PayloadFormatSlack
 
 	_, err := ss.Webhook().UpdateOutgoing(o1)
 	require.NoError(t, err)
+
+	webhook, err := ss.Webhook().GetOutgoing(o1.Id)
+	require.NoError(t, err)
+	require.Equal(t, model.PayloadFormatSlack, webhook.PayloadFormat)
 }
 
 func testWebhookStoreCountIncoming(t *testing.T, ss store.Store) {
@@ -17,44 +17,66 @@ import (
 
 type TimerLayer struct {
 	store.Store
-	Metrics                   einterfaces.MetricsInterface
-	AuditStore                store.AuditStore
-	BotStore                  store.BotStore
-	ChannelStore              store.ChannelStore
-	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
-	ClusterDiscoveryStore     store.ClusterDiscoveryStore
-	CommandStore              store.CommandStore
-	CommandWebhookStore       store.CommandWebhookStore
-	ComplianceStore           store.ComplianceStore
-	EmojiStore                store.EmojiStore
-	FileInfoStore             store.FileInfoStore
-	GroupStore                store.GroupStore
-	JobStore                  store.JobStore
-	LicenseStore              store.LicenseStore
-	LinkMetadataStore         store.LinkMetadataStore
-	OAuthStore                store.OAuthStore
-	PluginStore               store.PluginStore
-	PostStore                 store.PostStore
-	PreferenceStore           store.PreferenceStore
-	ProductNoticesStore       store.ProductNoticesStore
-	ReactionStore             store.ReactionStore
-	RemoteClusterStore        store.RemoteClusterStore
-	RetentionPolicyStore      store.RetentionPolicyStore
-	RoleStore                 store.RoleStore
-	SchemeStore               store.SchemeStore
-	SessionStore              store.SessionStore
-	SharedChannelStore        store.SharedChannelStore
-	StatusStore               store.StatusStore
-	SystemStore               store.SystemStore
-	TeamStore                 store.TeamStore
-	TermsOfServiceStore       store.TermsOfServiceStore
-	ThreadStore               store.ThreadStore
-	TokenStore                store.TokenStore
-	UploadSessionStore        store.UploadSessionStore
-	UserStore                 store.UserStore
-	UserAccessTokenStore      store.UserAccessTokenStore
-	UserTermsOfServiceStore   store.UserTermsOfServiceStore
-	WebhookStore              store.WebhookStore
+	Metrics                       einterfaces.MetricsInterface
+	AuditStore                    store.AuditStore
+	BotStore                      store.BotStore
+	ChangeEventStore              store.ChangeEventStore
+	ChannelStore                  store.ChannelStore
+	ChannelBookmarkStore          store.ChannelBookmarkStore
+	ChannelEventStore             store.ChannelEventStore
+	ChannelJoinRequestStore       store.ChannelJoinRequestStore
+	ChannelMemberHistoryStore     store.ChannelMemberHistoryStore
+	ChannelMemberTimeoutStore     store.ChannelMemberTimeoutStore
+	ClusterDiscoveryStore         store.ClusterDiscoveryStore
+	CommandStore                  store.CommandStore
+	CommandWebhookStore           store.CommandWebhookStore
+	ComplianceStore               store.ComplianceStore
+	ConnectivityTestResultStore   store.ConnectivityTestResultStore
+	DirectMessageRequestStore     store.DirectMessageRequestStore
+	EmojiStore                    store.EmojiStore
+	FileInfoStore                 store.FileInfoStore
+	GroupStore                    store.GroupStore
+	JobStore                      store.JobStore
+	LicenseStore                  store.LicenseStore
+	LinkMetadataStore             store.LinkMetadataStore
+	MfaBackupCodeStore            store.MfaBackupCodeStore
+	OAuthStore                    store.OAuthStore
+	OnboardingTaskStore           store.OnboardingTaskStore
+	OutgoingOAuthConnectionStore  store.OutgoingOAuthConnectionStore
+	PersistentWebSocketEventStore store.PersistentWebSocketEventStore
+	PluginStore                   store.PluginStore
+	PostStore                     store.PostStore
+	PostArchiveStore              store.PostArchiveStore
+	PostPropSchemaStore           store.PostPropSchemaStore
+	PostReportStore               store.PostReportStore
+	PostRetentionLabelStore       store.PostRetentionLabelStore
+	PreferenceStore               store.PreferenceStore
+	ProductNoticesStore           store.ProductNoticesStore
+	PushNotificationReceiptStore  store.PushNotificationReceiptStore
+	ReactionStore                 store.ReactionStore
+	ReminderStore                 store.ReminderStore
+	RemoteClusterStore            store.RemoteClusterStore
+	RetentionPolicyStore          store.RetentionPolicyStore
+	RoleStore                     store.RoleStore
+	ScheduledConfigChangeStore    store.ScheduledConfigChangeStore
+	SchemeStore                   store.SchemeStore
+	SessionStore                  store.SessionStore
+	SharedChannelStore            store.SharedChannelStore
+	StatusStore                   store.StatusStore
+	SystemStore                   store.SystemStore
+	TablePartitionStore           store.TablePartitionStore
+	TeamStore                     store.TeamStore
+	TeamInviteLinkStore           store.TeamInviteLinkStore
+	TeamTemplateStore             store.TeamTemplateStore
+	TermsOfServiceStore           store.TermsOfServiceStore
+	ThreadStore                   store.ThreadStore
+	TokenStore                    store.TokenStore
+	UploadSessionStore            store.UploadSessionStore
+	UserStore                     store.UserStore
+	UserAccessTokenStore          store.UserAccessTokenStore
+	UserDeviceStore               store.UserDeviceStore
+	UserTermsOfServiceStore       store.UserTermsOfServiceStore
+	WebhookStore                  store.WebhookStore
 }
 
 func (s *TimerLayer) Audit() store.AuditStore {
@@ -65,14 +87,34 @@ func (s *TimerLayer) Bot() store.BotStore {
 	return s.BotStore
 }
 
+func (s *TimerLayer) ChangeEvent() store.ChangeEventStore {
+	return s.ChangeEventStore
+}
+
 func (s *TimerLayer) Channel() store.ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *TimerLayer) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
+func (s *TimerLayer) ChannelEvent() store.ChannelEventStore {
+	return s.ChannelEventStore
+}
+
+func (s *TimerLayer) ChannelJoinRequest() store.ChannelJoinRequestStore {
+	return s.ChannelJoinRequestStore
+}
+
 func (s *TimerLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
 
+func (s *TimerLayer) ChannelMemberTimeout() store.ChannelMemberTimeoutStore {
+	return s.ChannelMemberTimeoutStore
+}
+
 func (s *TimerLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -89,6 +131,14 @@ func (s *TimerLayer) Compliance() store.ComplianceStore {
 	return s.ComplianceStore
 }
 
+func (s *TimerLayer) ConnectivityTestResult() store.ConnectivityTestResultStore {
+	return s.ConnectivityTestResultStore
+}
+
+func (s *TimerLayer) DirectMessageRequest() store.DirectMessageRequestStore {
+	return s.DirectMessageRequestStore
+}
+
 func (s *TimerLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
@@ -113,10 +163,26 @@ func (s *TimerLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *TimerLayer) MfaBackupCode() store.MfaBackupCodeStore {
+	return s.MfaBackupCodeStore
+}
+
 func (s *TimerLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *TimerLayer) OnboardingTask() store.OnboardingTaskStore {
+	return s.OnboardingTaskStore
+}
+
+func (s *TimerLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	return s.OutgoingOAuthConnectionStore
+}
+
+func (s *TimerLayer) PersistentWebSocketEvent() store.PersistentWebSocketEventStore {
+	return s.PersistentWebSocketEventStore
+}
+
 func (s *TimerLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -125,6 +191,22 @@ func (s *TimerLayer) Post() store.PostStore {
 	return s.PostStore
 }
 
+func (s *TimerLayer) PostArchive() store.PostArchiveStore {
+	return s.PostArchiveStore
+}
+
+func (s *TimerLayer) PostPropSchema() store.PostPropSchemaStore {
+	return s.PostPropSchemaStore
+}
+
+func (s *TimerLayer) PostReport() store.PostReportStore {
+	return s.PostReportStore
+}
+
+func (s *TimerLayer) PostRetentionLabel() store.PostRetentionLabelStore {
+	return s.PostRetentionLabelStore
+}
+
 func (s *TimerLayer) Preference() store.PreferenceStore {
 	return s.PreferenceStore
 }
@@ -133,10 +215,18 @@ func (s *TimerLayer) ProductNotices() store.ProductNoticesStore {
 	return s.ProductNoticesStore
 }
 
+func (s *TimerLayer) PushNotificationReceipt() store.PushNotificationReceiptStore {
+	return s.PushNotificationReceiptStore
+}
+
 func (s *TimerLayer) Reaction() store.ReactionStore {
 	return s.ReactionStore
 }
 
+func (s *TimerLayer) Reminder() store.ReminderStore {
+	return s.ReminderStore
+}
+
 func (s *TimerLayer) RemoteCluster() store.RemoteClusterStore {
 	return s.RemoteClusterStore
 }
@@ -149,6 +239,10 @@ func (s *TimerLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *TimerLayer) ScheduledConfigChange() store.ScheduledConfigChangeStore {
+	return s.ScheduledConfigChangeStore
+}
+
 func (s *TimerLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -169,10 +263,22 @@ func (s *TimerLayer) System() store.SystemStore {
 	return s.SystemStore
 }
 
+func (s *TimerLayer) TablePartition() store.TablePartitionStore {
+	return s.TablePartitionStore
+}
+
 func (s *TimerLayer) Team() store.TeamStore {
 	return s.TeamStore
 }
 
+func (s *TimerLayer) TeamInviteLink() store.TeamInviteLinkStore {
+	return s.TeamInviteLinkStore
+}
+
+func (s *TimerLayer) TeamTemplate() store.TeamTemplateStore {
+	return s.TeamTemplateStore
+}
+
 func (s *TimerLayer) TermsOfService() store.TermsOfServiceStore {
 	return s.TermsOfServiceStore
 }
@@ -197,6 +303,10 @@ func (s *TimerLayer) UserAccessToken() store.UserAccessTokenStore {
 	return s.UserAccessTokenStore
 }
 
+func (s *TimerLayer) UserDevice() store.UserDeviceStore {
+	return s.UserDeviceStore
+}
+
 func (s *TimerLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
@@ -215,16 +325,41 @@ type TimerLayerBotStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerChangeEventStore struct {
+	store.ChangeEventStore
+	Root *TimerLayer
+}
+
 type TimerLayerChannelStore struct {
 	store.ChannelStore
 	Root *TimerLayer
 }
 
+type TimerLayerChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	Root *TimerLayer
+}
+
+type TimerLayerChannelEventStore struct {
+	store.ChannelEventStore
+	Root *TimerLayer
+}
+
+type TimerLayerChannelJoinRequestStore struct {
+	store.ChannelJoinRequestStore
+	Root *TimerLayer
+}
+
 type TimerLayerChannelMemberHistoryStore struct {
 	store.ChannelMemberHistoryStore
 	Root *TimerLayer
 }
 
+type TimerLayerChannelMemberTimeoutStore struct {
+	store.ChannelMemberTimeoutStore
+	Root *TimerLayer
+}
+
 type TimerLayerClusterDiscoveryStore struct {
 	store.ClusterDiscoveryStore
 	Root *TimerLayer
@@ -245,6 +380,16 @@ type TimerLayerComplianceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerConnectivityTestResultStore struct {
+	store.ConnectivityTestResultStore
+	Root *TimerLayer
+}
+
+type TimerLayerDirectMessageRequestStore struct {
+	store.DirectMessageRequestStore
+	Root *TimerLayer
+}
+
 type TimerLayerEmojiStore struct {
 	store.EmojiStore
 	Root *TimerLayer
@@ -275,11 +420,31 @@ type TimerLayerLinkMetadataStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerMfaBackupCodeStore struct {
+	store.MfaBackupCodeStore
+	Root *TimerLayer
+}
+
 type TimerLayerOAuthStore struct {
 	store.OAuthStore
 	Root *TimerLayer
 }
 
+type TimerLayerOnboardingTaskStore struct {
+	store.OnboardingTaskStore
+	Root *TimerLayer
+}
+
+type TimerLayerOutgoingOAuthConnectionStore struct {
+	store.OutgoingOAuthConnectionStore
+	Root *TimerLayer
+}
+
+type TimerLayerPersistentWebSocketEventStore struct {
+	store.PersistentWebSocketEventStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	store.PluginStore
 	Root *TimerLayer
@@ -290,6 +455,26 @@ type TimerLayerPostStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPostArchiveStore struct {
+	store.PostArchiveStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostPropSchemaStore struct {
+	store.PostPropSchemaStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostReportStore struct {
+	store.PostReportStore
+	Root *TimerLayer
+}
+
+type TimerLayerPostRetentionLabelStore struct {
+	store.PostRetentionLabelStore
+	Root *TimerLayer
+}
+
 type TimerLayerPreferenceStore struct {
 	store.PreferenceStore
 	Root *TimerLayer
@@ -300,11 +485,21 @@ type TimerLayerProductNoticesStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPushNotificationReceiptStore struct {
+	store.PushNotificationReceiptStore
+	Root *TimerLayer
+}
+
 type TimerLayerReactionStore struct {
 	store.ReactionStore
 	Root *TimerLayer
 }
 
+type TimerLayerReminderStore struct {
+	store.ReminderStore
+	Root *TimerLayer
+}
+
 type TimerLayerRemoteClusterStore struct {
 	store.RemoteClusterStore
 	Root *TimerLayer
@@ -320,6 +515,11 @@ type TimerLayerRoleStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerScheduledConfigChangeStore struct {
+	store.ScheduledConfigChangeStore
+	Root *TimerLayer
+}
+
 type TimerLayerSchemeStore struct {
 	store.SchemeStore
 	Root *TimerLayer
@@ -345,11 +545,26 @@ type TimerLayerSystemStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerTablePartitionStore struct {
+	store.TablePartitionStore
+	Root *TimerLayer
+}
+
 type TimerLayerTeamStore struct {
 	store.TeamStore
 	Root *TimerLayer
 }
 
+type TimerLayerTeamInviteLinkStore struct {
+	store.TeamInviteLinkStore
+	Root *TimerLayer
+}
+
+type TimerLayerTeamTemplateStore struct {
+	store.TeamTemplateStore
+	Root *TimerLayer
+}
+
 type TimerLayerTermsOfServiceStore struct {
 	store.TermsOfServiceStore
 	Root *TimerLayer
@@ -380,6 +595,11 @@ type TimerLayerUserAccessTokenStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerUserDeviceStore struct {
+	store.UserDeviceStore
+	Root *TimerLayer
+}
+
 type TimerLayerUserTermsOfServiceStore struct {
 	store.UserTermsOfServiceStore
 	Root *TimerLayer
@@ -518,6 +738,70 @@ func (s *TimerLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
 	return result, err
 }
 
+func (s *TimerLayerChangeEventStore) GetUnpublished(limit int) ([]*model.ChangeEvent, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChangeEventStore.GetUnpublished(limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChangeEventStore.GetUnpublished", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChangeEventStore) MarkPublished(ids []string, publishedAt int64) error {
+	start := timemodule.Now()
+
+	err := s.ChangeEventStore.MarkPublished(ids, publishedAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChangeEventStore.MarkPublished", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerChangeEventStore) PermanentDeletePublishedBatch(endTime int64, limit int64) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChangeEventStore.PermanentDeletePublishedBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChangeEventStore.PermanentDeletePublishedBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChangeEventStore) Save(event *model.ChangeEvent) (*model.ChangeEvent, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChangeEventStore.Save(event)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChangeEventStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) AnalyticsDeletedTypeCount(teamID string, channelType model.ChannelType) (int64, error) {
 	start := timemodule.Now()
 
@@ -1013,6 +1297,22 @@ func (s *TimerLayerChannelStore) GetChannelUnread(channelID string, userID strin
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetChannelUnreadsForUser(userID string) ([]*model.ChannelUnread, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.GetChannelUnreadsForUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelUnreadsForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
 	start := timemodule.Now()
 
@@ -1269,6 +1569,22 @@ func (s *TimerLayerChannelStore) GetMemberCountFromCache(channelID string) int64
 	return result
 }
 
+func (s *TimerLayerChannelStore) GetMemberCounts(channelID string) (*model.ChannelMemberCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.GetMemberCounts(channelID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCounts", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetMemberCountsByGroup(ctx context.Context, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
 	start := timemodule.Now()
 
@@ -1870,6 +2186,22 @@ func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(userID string) err
 	return err
 }
 
+func (s *TimerLayerChannelStore) ReconcileMemberCounts(afterChannelID string, limit int) (string, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.ReconcileMemberCounts(afterChannelID, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ReconcileMemberCounts", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(channelID string) error {
 	start := timemodule.Now()
 
@@ -2126,6 +2458,22 @@ func (s *TimerLayerChannelStore) SetDeleteAt(channelID string, deleteAt int64, u
 	return err
 }
 
+func (s *TimerLayerChannelStore) SetPinnedPostsOrder(channelID string, postIDs []string) error {
+	start := timemodule.Now()
+
+	err := s.ChannelStore.SetPinnedPostsOrder(channelID, postIDs)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SetPinnedPostsOrder", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerChannelStore) SetShared(channelId string, shared bool) error {
 	start := timemodule.Now()
 
@@ -2334,10 +2682,10 @@ func (s *TimerLayerChannelStore) UserBelongsToChannels(userID string, channelIds
 	return result, err
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *TimerLayerChannelStore) UsersShareChannel(userID string, otherUserID string) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.ChannelMemberHistoryStore.DeleteOrphanedRows(limit)
+	result, err := s.ChannelStore.UsersShareChannel(userID, otherUserID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2345,15 +2693,15 @@ func (s *TimerLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.DeleteOrphanedRows", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UsersShareChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
+func (s *TimerLayerChannelBookmarkStore) Delete(id string, deleteAt int64) error {
 	start := timemodule.Now()
 
-	result, err := s.ChannelMemberHistoryStore.GetChannelsLeftSince(userID, since)
+	err := s.ChannelBookmarkStore.Delete(id, deleteAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2361,15 +2709,15 @@ func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetChannelsLeftSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
+func (s *TimerLayerChannelBookmarkStore) Get(id string, includeDeleted bool) (*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
+	result, err := s.ChannelBookmarkStore.Get(id, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2377,15 +2725,15 @@ func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
+func (s *TimerLayerChannelBookmarkStore) GetForChannel(channelID string) ([]*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
+	result, err := s.ChannelBookmarkStore.GetForChannel(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2393,15 +2741,15 @@ func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channe
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.GetForChannel", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
+func (s *TimerLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
+	result, err := s.ChannelBookmarkStore.Save(bookmark)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2409,15 +2757,15 @@ func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, chann
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Save", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *TimerLayerChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) (*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	result, err := s.ChannelBookmarkStore.Update(bookmark)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2425,15 +2773,15 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *TimerLayerChannelBookmarkStore) UpdateSortOrder(bookmarkID string, channelID string, newIndex int64) ([]*model.ChannelBookmark, error) {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+	result, err := s.ChannelBookmarkStore.UpdateSortOrder(bookmarkID, channelID, newIndex)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2441,15 +2789,15 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelBookmarkStore.UpdateSortOrder", success, elapsed)
 	}
-	return result, resultVar1, err
+	return result, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
+func (s *TimerLayerChannelEventStore) Delete(id string) error {
 	start := timemodule.Now()
 
-	err := s.ClusterDiscoveryStore.Cleanup()
+	err := s.ChannelEventStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2457,15 +2805,15 @@ func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelEventStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *TimerLayerChannelEventStore) Get(id string) (*model.ChannelEvent, error) {
 	start := timemodule.Now()
 
-	result, err := s.ClusterDiscoveryStore.Delete(discovery)
+	result, err := s.ChannelEventStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2473,15 +2821,15 @@ func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscove
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelEventStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+func (s *TimerLayerChannelEventStore) GetDue(now int64, limit int) ([]*model.ChannelEvent, error) {
 	start := timemodule.Now()
 
-	result, err := s.ClusterDiscoveryStore.Exists(discovery)
+	result, err := s.ChannelEventStore.GetDue(now, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2489,15 +2837,15 @@ func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscove
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelEventStore.GetDue", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+func (s *TimerLayerChannelEventStore) GetForChannel(channelID string) ([]*model.ChannelEvent, error) {
 	start := timemodule.Now()
 
-	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	result, err := s.ChannelEventStore.GetForChannel(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2505,15 +2853,15 @@ func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterNa
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelEventStore.GetForChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+func (s *TimerLayerChannelEventStore) Save(event *model.ChannelEvent) (*model.ChannelEvent, error) {
 	start := timemodule.Now()
 
-	err := s.ClusterDiscoveryStore.Save(discovery)
+	result, err := s.ChannelEventStore.Save(event)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2521,15 +2869,15 @@ func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelEventStore.Save", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+func (s *TimerLayerChannelEventStore) Update(event *model.ChannelEvent) (*model.ChannelEvent, error) {
 	start := timemodule.Now()
 
-	err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	result, err := s.ChannelEventStore.Update(event)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2537,15 +2885,15 @@ func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.Cluster
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelEventStore.Update", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
+func (s *TimerLayerChannelJoinRequestStore) Cleanup(expiryTime int64, batchSize int) error {
 	start := timemodule.Now()
 
-	result, err := s.CommandStore.AnalyticsCommandCount(teamID)
+	err := s.ChannelJoinRequestStore.Cleanup(expiryTime, batchSize)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2553,15 +2901,15 @@ func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.AnalyticsCommandCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelJoinRequestStore.Cleanup", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerCommandStore) Delete(commandID string, time int64) error {
+func (s *TimerLayerChannelJoinRequestStore) Delete(id string) error {
 	start := timemodule.Now()
 
-	err := s.CommandStore.Delete(commandID, time)
+	err := s.ChannelJoinRequestStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2569,15 +2917,15 @@ func (s *TimerLayerCommandStore) Delete(commandID string, time int64) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelJoinRequestStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerCommandStore) Get(id string) (*model.Command, error) {
+func (s *TimerLayerChannelJoinRequestStore) Expire(now int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.CommandStore.Get(id)
+	result, err := s.ChannelJoinRequestStore.Expire(now)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2585,15 +2933,15 @@ func (s *TimerLayerCommandStore) Get(id string) (*model.Command, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelJoinRequestStore.Expire", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, error) {
+func (s *TimerLayerChannelJoinRequestStore) Get(id string) (*model.ChannelJoinRequest, error) {
 	start := timemodule.Now()
 
-	result, err := s.CommandStore.GetByTeam(teamID)
+	result, err := s.ChannelJoinRequestStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2601,15 +2949,15 @@ func (s *TimerLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelJoinRequestStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerCommandStore) GetByTrigger(teamID string, trigger string) (*model.Command, error) {
+func (s *TimerLayerChannelJoinRequestStore) GetPendingForChannel(channelID string, now int64) ([]*model.ChannelJoinRequest, error) {
 	start := timemodule.Now()
 
-	result, err := s.CommandStore.GetByTrigger(teamID, trigger)
+	result, err := s.ChannelJoinRequestStore.GetPendingForChannel(channelID, now)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2617,15 +2965,15 @@ func (s *TimerLayerCommandStore) GetByTrigger(teamID string, trigger string) (*m
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTrigger", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelJoinRequestStore.GetPendingForChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
+func (s *TimerLayerChannelJoinRequestStore) Save(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error) {
 	start := timemodule.Now()
 
-	err := s.CommandStore.PermanentDeleteByTeam(teamID)
+	result, err := s.ChannelJoinRequestStore.Save(request)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2633,15 +2981,15 @@ func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelJoinRequestStore.Save", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerCommandStore) PermanentDeleteByUser(userID string) error {
+func (s *TimerLayerChannelJoinRequestStore) Update(request *model.ChannelJoinRequest) (*model.ChannelJoinRequest, error) {
 	start := timemodule.Now()
 
-	err := s.CommandStore.PermanentDeleteByUser(userID)
+	result, err := s.ChannelJoinRequestStore.Update(request)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2649,15 +2997,15 @@ func (s *TimerLayerCommandStore) PermanentDeleteByUser(userID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelJoinRequestStore.Update", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+func (s *TimerLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.CommandStore.Save(webhook)
+	result, err := s.ChannelMemberHistoryStore.DeleteOrphanedRows(limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2665,15 +3013,15 @@ func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.DeleteOrphanedRows", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+func (s *TimerLayerChannelMemberHistoryStore) GetChannelsForUserDuring(userID string, startTime int64, endTime int64) ([]*model.ChannelMemberHistory, error) {
 	start := timemodule.Now()
 
-	result, err := s.CommandStore.Update(hook)
+	result, err := s.ChannelMemberHistoryStore.GetChannelsForUserDuring(userID, startTime, endTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2681,30 +3029,31 @@ func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetChannelsForUserDuring", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerCommandWebhookStore) Cleanup() {
+func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	start := timemodule.Now()
 
-	s.CommandWebhookStore.Cleanup()
+	result, err := s.ChannelMemberHistoryStore.GetChannelsLeftSince(userID, since)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetChannelsLeftSince", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID string) ([]*model.ChannelMemberHistoryResult, error) {
 	start := timemodule.Now()
 
-	result, err := s.CommandWebhookStore.Get(id)
+	result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2712,15 +3061,15 @@ func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
 	start := timemodule.Now()
 
-	result, err := s.CommandWebhookStore.Save(webhook)
+	err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2728,15 +3077,15 @@ func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*mo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) error {
+func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
 	start := timemodule.Now()
 
-	err := s.CommandWebhookStore.TryUse(id, limit)
+	err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2744,15 +3093,15 @@ func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.TryUse", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Compliance, cursor model.ComplianceExportCursor, limit int) ([]*model.CompliancePost, model.ComplianceExportCursor, error) {
+func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.ComplianceStore.ComplianceExport(compliance, cursor, limit)
+	result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2760,15 +3109,15 @@ func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Complianc
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.ComplianceExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, elapsed)
 	}
-	return result, resultVar1, err
+	return result, err
 }
 
-func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, error) {
+func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
 	start := timemodule.Now()
 
-	result, err := s.ComplianceStore.Get(id)
+	result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2776,15 +3125,15 @@ func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies", success, elapsed)
 	}
-	return result, err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+func (s *TimerLayerChannelMemberTimeoutStore) Delete(channelID string, userID string) error {
 	start := timemodule.Now()
 
-	result, err := s.ComplianceStore.GetAll(offset, limit)
+	err := s.ChannelMemberTimeoutStore.Delete(channelID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2792,15 +3141,15 @@ func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Complia
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberTimeoutStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerComplianceStore) MessageExport(cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+func (s *TimerLayerChannelMemberTimeoutStore) Get(channelID string, userID string) (*model.ChannelMemberTimeout, error) {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.ComplianceStore.MessageExport(cursor, limit)
+	result, err := s.ChannelMemberTimeoutStore.Get(channelID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2808,15 +3157,15 @@ func (s *TimerLayerComplianceStore) MessageExport(cursor model.MessageExportCurs
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.MessageExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberTimeoutStore.Get", success, elapsed)
 	}
-	return result, resultVar1, err
+	return result, err
 }
 
-func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+func (s *TimerLayerChannelMemberTimeoutStore) GetForChannel(channelID string, now int64) ([]*model.ChannelMemberTimeout, error) {
 	start := timemodule.Now()
 
-	result, err := s.ComplianceStore.Save(compliance)
+	result, err := s.ChannelMemberTimeoutStore.GetForChannel(channelID, now)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2824,15 +3173,15 @@ func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.C
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberTimeoutStore.GetForChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+func (s *TimerLayerChannelMemberTimeoutStore) Save(timeout *model.ChannelMemberTimeout) (*model.ChannelMemberTimeout, error) {
 	start := timemodule.Now()
 
-	result, err := s.ComplianceStore.Update(compliance)
+	result, err := s.ChannelMemberTimeoutStore.Save(timeout)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2840,15 +3189,15 @@ func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberTimeoutStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
 	start := timemodule.Now()
 
-	err := s.EmojiStore.Delete(emoji, time)
+	err := s.ClusterDiscoveryStore.Cleanup()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2856,15 +3205,15 @@ func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerEmojiStore) Get(ctx context.Context, id string, allowFromCache bool) (*model.Emoji, error) {
+func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.Get(ctx, id, allowFromCache)
+	result, err := s.ClusterDiscoveryStore.Delete(discovery)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2872,15 +3221,15 @@ func (s *TimerLayerEmojiStore) Get(ctx context.Context, id string, allowFromCach
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) GetByName(ctx context.Context, name string, allowFromCache bool) (*model.Emoji, error) {
+func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.GetByName(ctx, name, allowFromCache)
+	result, err := s.ClusterDiscoveryStore.Exists(discovery)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2888,15 +3237,15 @@ func (s *TimerLayerEmojiStore) GetByName(ctx context.Context, name string, allow
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.GetList(offset, limit, sort)
+	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2904,15 +3253,15 @@ func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.GetMultipleByName(names)
+	err := s.ClusterDiscoveryStore.Save(discovery)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2920,15 +3269,15 @@ func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.Save(emoji)
+	err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2936,15 +3285,15 @@ func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+	result, err := s.CommandStore.AnalyticsCommandCount(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2952,15 +3301,15 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.AnalyticsCommandCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) AttachToPost(fileID string, postID string, creatorID string) error {
+func (s *TimerLayerCommandStore) Delete(commandID string, time int64) error {
 	start := timemodule.Now()
 
-	err := s.FileInfoStore.AttachToPost(fileID, postID, creatorID)
+	err := s.CommandStore.Delete(commandID, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2968,30 +3317,31 @@ func (s *TimerLayerFileInfoStore) AttachToPost(fileID string, postID string, cre
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerFileInfoStore) ClearCaches() {
+func (s *TimerLayerCommandStore) Get(id string) (*model.Command, error) {
 	start := timemodule.Now()
 
-	s.FileInfoStore.ClearCaches()
+	result, err := s.CommandStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Get", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) CountAll() (int64, error) {
+func (s *TimerLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.CountAll()
+	result, err := s.CommandStore.GetByTeam(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -2999,15 +3349,15 @@ func (s *TimerLayerFileInfoStore) CountAll() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.CountAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) DeleteForPost(postID string) (string, error) {
+func (s *TimerLayerCommandStore) GetByTrigger(teamID string, trigger string) (*model.Command, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.DeleteForPost(postID)
+	result, err := s.CommandStore.GetByTrigger(teamID, trigger)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3015,15 +3365,15 @@ func (s *TimerLayerFileInfoStore) DeleteForPost(postID string) (string, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTrigger", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.Get(id)
+	err := s.CommandStore.PermanentDeleteByTeam(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3031,15 +3381,15 @@ func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByTeam", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+func (s *TimerLayerCommandStore) PermanentDeleteByUser(userID string) error {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetByIds(ids)
+	err := s.CommandStore.PermanentDeleteByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3047,15 +3397,15 @@ func (s *TimerLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+func (s *TimerLayerCommandStore) PermanentDeleteSoftDeletedBatch(before int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetByPath(path)
+	result, err := s.CommandStore.PermanentDeleteSoftDeletedBatch(before, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3063,15 +3413,15 @@ func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteSoftDeletedBatch", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.FileForIndexing, error) {
+func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, limit)
+	result, err := s.CommandStore.Save(webhook)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3079,15 +3429,15 @@ func (s *TimerLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, star
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetFilesBatchForIndexing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
+	result, err := s.CommandStore.Update(hook)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3095,31 +3445,30 @@ func (s *TimerLayerFileInfoStore) GetForPost(postID string, readFromMaster bool,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
+func (s *TimerLayerCommandWebhookStore) Cleanup() {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetForUser(userID)
+	s.CommandWebhookStore.Cleanup()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Cleanup", success, elapsed)
 	}
-	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
+func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetFromMaster(id)
+	result, err := s.CommandWebhookStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3127,15 +3476,15 @@ func (s *TimerLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetFromMaster", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+	result, err := s.CommandWebhookStore.Save(webhook)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3143,30 +3492,31 @@ func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetWithOptions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
+func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) error {
 	start := timemodule.Now()
 
-	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
+	err := s.CommandWebhookStore.TryUse(id, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.TryUse", success, elapsed)
 	}
+	return err
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDelete(fileID string) error {
+func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Compliance, cursor model.ComplianceExportCursor, limit int) ([]*model.CompliancePost, model.ComplianceExportCursor, error) {
 	start := timemodule.Now()
 
-	err := s.FileInfoStore.PermanentDelete(fileID)
+	result, resultVar1, err := s.ComplianceStore.ComplianceExport(compliance, cursor, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3174,15 +3524,15 @@ func (s *TimerLayerFileInfoStore) PermanentDelete(fileID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.ComplianceExport", success, elapsed)
 	}
-	return err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+	result, err := s.ComplianceStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3190,15 +3540,15 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int6
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userID string) (int64, error) {
+func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.PermanentDeleteByUser(userID)
+	result, err := s.ComplianceStore.GetAll(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3206,15 +3556,15 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userID string) (int64, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *TimerLayerComplianceStore) MessageExport(cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.Save(info)
+	result, resultVar1, err := s.ComplianceStore.MessageExport(cursor, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3222,15 +3572,15 @@ func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.MessageExport", success, elapsed)
 	}
-	return result, err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerFileInfoStore) Search(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
+func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.Search(paramsList, userID, teamID, page, perPage)
+	result, err := s.ComplianceStore.Save(compliance)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3238,15 +3588,15 @@ func (s *TimerLayerFileInfoStore) Search(paramsList []*model.SearchParams, userI
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) SetContent(fileID string, content string) error {
+func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
 	start := timemodule.Now()
 
-	err := s.FileInfoStore.SetContent(fileID, content)
+	result, err := s.ComplianceStore.Update(compliance)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3254,15 +3604,15 @@ func (s *TimerLayerFileInfoStore) SetContent(fileID string, content string) erro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetContent", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Update", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+func (s *TimerLayerConnectivityTestResultStore) Cleanup(expiryTime int64, batchSize int) error {
 	start := timemodule.Now()
 
-	result, err := s.FileInfoStore.Upsert(info)
+	err := s.ConnectivityTestResultStore.Cleanup(expiryTime, batchSize)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3270,15 +3620,15 @@ func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Upsert", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ConnectivityTestResultStore.Cleanup", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *TimerLayerConnectivityTestResultStore) GetHistory(service string, offset int, limit int) ([]*model.ConnectivityTestResult, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+	result, err := s.ConnectivityTestResultStore.GetHistory(service, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3286,15 +3636,15 @@ func (s *TimerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, s
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.AdminRoleGroupsForSyncableMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ConnectivityTestResultStore.GetHistory", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *TimerLayerConnectivityTestResultStore) Save(result *model.ConnectivityTestResult) (*model.ConnectivityTestResult, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+	result, err := s.ConnectivityTestResultStore.Save(result)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3302,15 +3652,15 @@ func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ConnectivityTestResultStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, includeRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
+func (s *TimerLayerDirectMessageRequestStore) Delete(channelID string) error {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, includeRemovedMembers)
+	err := s.DirectMessageRequestStore.Delete(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3318,15 +3668,15 @@ func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DirectMessageRequestStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
+func (s *TimerLayerDirectMessageRequestStore) Get(channelID string) (*model.DirectMessageRequest, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.ChannelMembersToRemove(channelID)
+	result, err := s.DirectMessageRequestStore.Get(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3334,15 +3684,15 @@ func (s *TimerLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToRemove", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DirectMessageRequestStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
+func (s *TimerLayerDirectMessageRequestStore) GetPendingForRecipient(recipientID string) ([]*model.DirectMessageRequest, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+	result, err := s.DirectMessageRequestStore.GetPendingForRecipient(recipientID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3350,15 +3700,15 @@ func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID st
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountChannelMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DirectMessageRequestStore.GetPendingForRecipient", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) CountGroupsByChannel(channelID string, opts model.GroupSearchOpts) (int64, error) {
+func (s *TimerLayerDirectMessageRequestStore) Save(request *model.DirectMessageRequest) (*model.DirectMessageRequest, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.CountGroupsByChannel(channelID, opts)
+	result, err := s.DirectMessageRequestStore.Save(request)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3366,15 +3716,15 @@ func (s *TimerLayerGroupStore) CountGroupsByChannel(channelID string, opts model
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DirectMessageRequestStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) CountGroupsByTeam(teamID string, opts model.GroupSearchOpts) (int64, error) {
+func (s *TimerLayerDirectMessageRequestStore) UpdateStatus(channelID string, status string, updateAt int64) error {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.CountGroupsByTeam(teamID, opts)
+	err := s.DirectMessageRequestStore.UpdateStatus(channelID, status, updateAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3382,15 +3732,15 @@ func (s *TimerLayerGroupStore) CountGroupsByTeam(teamID string, opts model.Group
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("DirectMessageRequestStore.UpdateStatus", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
+func (s *TimerLayerEmojiStore) CountByTeam(teamID string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+	result, err := s.EmojiStore.CountByTeam(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3398,15 +3748,15 @@ func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountTeamMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.CountByTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
+func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.Create(group)
+	err := s.EmojiStore.Delete(emoji, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3414,15 +3764,15 @@ func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Create", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+func (s *TimerLayerEmojiStore) Get(ctx context.Context, id string, allowFromCache bool) (*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
+	result, err := s.EmojiStore.Get(ctx, id, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3430,15 +3780,15 @@ func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyn
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds) (*model.Group, error) {
+func (s *TimerLayerEmojiStore) GetByName(ctx context.Context, name string, allowFromCache bool) (*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.CreateWithUserIds(group)
+	result, err := s.EmojiStore.GetByName(ctx, name, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3446,15 +3796,15 @@ func (s *TimerLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateWithUserIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, error) {
+func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.Delete(groupID)
+	result, err := s.EmojiStore.GetList(offset, limit, sort)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3462,15 +3812,15 @@ func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+func (s *TimerLayerEmojiStore) GetListForTeam(teamID string, offset int, limit int, sort string) ([]*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+	result, err := s.EmojiStore.GetListForTeam(teamID, offset, limit, sort)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3478,15 +3828,15 @@ func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID st
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetListForTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.DeleteMember(groupID, userID)
+	result, err := s.EmojiStore.GetMultipleByName(names)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3494,15 +3844,15 @@ func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) DeleteMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.DeleteMembers(groupID, userIDs)
+	result, err := s.EmojiStore.Save(emoji)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3510,15 +3860,15 @@ func (s *TimerLayerGroupStore) DeleteMembers(groupID string, userIDs []string) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
+func (s *TimerLayerEmojiStore) SaveForTeam(emoji *model.Emoji, maxEmojiPerTeam int64) (*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.DistinctGroupMemberCount()
+	result, err := s.EmojiStore.SaveForTeam(emoji, maxEmojiPerTeam)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3526,15 +3876,15 @@ func (s *TimerLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DistinctGroupMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.SaveForTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, error) {
+func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.Get(groupID)
+	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3542,15 +3892,15 @@ func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
+func (s *TimerLayerFileInfoStore) AttachToPost(fileID string, postID string, creatorID string) error {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetAllBySource(groupSource)
+	err := s.FileInfoStore.AttachToPost(fileID, postID, creatorID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3558,31 +3908,30 @@ func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllBySource", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
+func (s *TimerLayerFileInfoStore) ClearCaches() {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+	s.FileInfoStore.ClearCaches()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllGroupSyncablesByGroupId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, elapsed)
 	}
-	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
+func (s *TimerLayerFileInfoStore) CountAll() (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetByIDs(groupIDs)
+	result, err := s.FileInfoStore.CountAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3590,15 +3939,15 @@ func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, erro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByIDs", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.CountAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
+func (s *TimerLayerFileInfoStore) CountDedupReferences(fileID string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetByName(name, opts)
+	result, err := s.FileInfoStore.CountDedupReferences(fileID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3606,15 +3955,15 @@ func (s *TimerLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.CountDedupReferences", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
+func (s *TimerLayerFileInfoStore) DeleteForPost(postID string) (string, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+	result, err := s.FileInfoStore.DeleteForPost(postID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3622,15 +3971,15 @@ func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByRemoteID", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetByUser(userID string) ([]*model.Group, error) {
+func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetByUser(userID)
+	result, err := s.FileInfoStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3638,15 +3987,15 @@ func (s *TimerLayerGroupStore) GetByUser(userID string) ([]*model.Group, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+func (s *TimerLayerFileInfoStore) GetByContentHash(hash string, channelID string) (*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+	result, err := s.FileInfoStore.GetByContentHash(hash, channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3654,15 +4003,15 @@ func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByContentHash", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, error) {
+func (s *TimerLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetGroups(page, perPage, opts)
+	result, err := s.FileInfoStore.GetByIds(ids)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3670,15 +4019,15 @@ func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.Group
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroups", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
+func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
+	result, err := s.FileInfoStore.GetByPath(path)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3686,15 +4035,15 @@ func (s *TimerLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsAssociatedToChannelsByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+func (s *TimerLayerFileInfoStore) GetExpiredForRetentionPolicies(now int64, afterID string, limit int) ([]*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetGroupsByChannel(channelID, opts)
+	result, err := s.FileInfoStore.GetExpiredForRetentionPolicies(now, afterID, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3702,15 +4051,15 @@ func (s *TimerLayerGroupStore) GetGroupsByChannel(channelID string, opts model.G
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetExpiredForRetentionPolicies", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+func (s *TimerLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.FileForIndexing, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetGroupsByTeam(teamID, opts)
+	result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3718,15 +4067,15 @@ func (s *TimerLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSe
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetFilesBatchForIndexing", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *TimerLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetMember(groupID, userID)
+	result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3734,15 +4083,15 @@ func (s *TimerLayerGroupStore) GetMember(groupID string, userID string) (*model.
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
+func (s *TimerLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetMemberCount(groupID)
+	result, err := s.FileInfoStore.GetForUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3750,15 +4099,15 @@ func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
+func (s *TimerLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetMemberUsers(groupID)
+	result, err := s.FileInfoStore.GetFromMaster(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3766,15 +4115,15 @@ func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetFromMaster", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
+func (s *TimerLayerFileInfoStore) GetOrphaned(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
+	result, err := s.FileInfoStore.GetOrphaned(createdBefore, afterID, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3782,15 +4131,15 @@ func (s *TimerLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersInTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetOrphaned", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
+func (s *TimerLayerFileInfoStore) GetReferencedIds(fileIDs []string) ([]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
+	result, err := s.FileInfoStore.GetReferencedIds(fileIDs)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3798,15 +4147,15 @@ func (s *TimerLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channe
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersNotInChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetReferencedIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
+func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3814,31 +4163,30 @@ func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perP
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetWithOptions", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GetNonMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
+func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GetNonMemberUsersPage(groupID, page, perPage)
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetNonMemberUsersPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, elapsed)
 	}
-	return result, err
 }
 
-func (s *TimerLayerGroupStore) GroupChannelCount() (int64, error) {
+func (s *TimerLayerFileInfoStore) PermanentDelete(fileID string) error {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GroupChannelCount()
+	err := s.FileInfoStore.PermanentDelete(fileID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3846,15 +4194,15 @@ func (s *TimerLayerGroupStore) GroupChannelCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupChannelCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerGroupStore) GroupCount() (int64, error) {
+func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GroupCount()
+	result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3862,15 +4210,15 @@ func (s *TimerLayerGroupStore) GroupCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GroupCountBySource(source model.GroupSource) (int64, error) {
+func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userID string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GroupCountBySource(source)
+	result, err := s.FileInfoStore.PermanentDeleteByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3878,15 +4226,15 @@ func (s *TimerLayerGroupStore) GroupCountBySource(source model.GroupSource) (int
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCountBySource", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
+func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GroupCountWithAllowReference()
+	result, err := s.FileInfoStore.Save(info)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3894,15 +4242,15 @@ func (s *TimerLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCountWithAllowReference", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GroupMemberCount() (int64, error) {
+func (s *TimerLayerFileInfoStore) Search(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GroupMemberCount()
+	result, err := s.FileInfoStore.Search(paramsList, userID, teamID, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3910,15 +4258,15 @@ func (s *TimerLayerGroupStore) GroupMemberCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Search", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) GroupTeamCount() (int64, error) {
+func (s *TimerLayerFileInfoStore) SetAltText(fileID string, altText string, generated bool) error {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.GroupTeamCount()
+	err := s.FileInfoStore.SetAltText(fileID, altText, generated)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3926,15 +4274,15 @@ func (s *TimerLayerGroupStore) GroupTeamCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupTeamCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetAltText", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerGroupStore) PermanentDeleteMembersByUser(userID string) error {
+func (s *TimerLayerFileInfoStore) SetContent(fileID string, content string) error {
 	start := timemodule.Now()
 
-	err := s.GroupStore.PermanentDeleteMembersByUser(userID)
+	err := s.FileInfoStore.SetContent(fileID, content)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3942,15 +4290,15 @@ func (s *TimerLayerGroupStore) PermanentDeleteMembersByUser(userID string) error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.PermanentDeleteMembersByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetContent", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
+	result, err := s.FileInfoStore.Upsert(info)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3958,15 +4306,15 @@ func (s *TimerLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncab
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.PermittedSyncableAdmins", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Upsert", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *TimerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+	result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3974,15 +4322,15 @@ func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, group
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersMinusGroupMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.AdminRoleGroupsForSyncableMember", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, includeRemovedMembers bool) ([]*model.UserTeamIDPair, error) {
+func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.TeamMembersToAdd(since, teamID, includeRemovedMembers)
+	result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3990,15 +4338,15 @@ func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, inc
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToAdd", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
+func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, includeRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.TeamMembersToRemove(teamID)
+	result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, includeRemovedMembers)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4006,15 +4354,15 @@ func (s *TimerLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.Tea
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToRemove", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
+func (s *TimerLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.Update(group)
+	result, err := s.GroupStore.ChannelMembersToRemove(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4022,15 +4370,15 @@ func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToRemove", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+	result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4038,15 +4386,15 @@ func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyn
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpdateGroupSyncable", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountChannelMembersMinusGroupMembers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
+func (s *TimerLayerGroupStore) CountGroupsByChannel(channelID string, opts model.GroupSearchOpts) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.UpsertMember(groupID, userID)
+	result, err := s.GroupStore.CountGroupsByChannel(channelID, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4054,15 +4402,15 @@ func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerGroupStore) UpsertMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+func (s *TimerLayerGroupStore) CountGroupsByTeam(teamID string, opts model.GroupSearchOpts) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.GroupStore.UpsertMembers(groupID, userIDs)
+	result, err := s.GroupStore.CountGroupsByTeam(teamID, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4070,15 +4418,15 @@ func (s *TimerLayerGroupStore) UpsertMembers(groupID string, userIDs []string) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
+func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
 	start := timemodule.Now()
 
-	err := s.JobStore.Cleanup(expiryTime, batchSize)
+	result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4086,15 +4434,15 @@ func (s *TimerLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountTeamMembersMinusGroupMembers", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerJobStore) Delete(id string) (string, error) {
+func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.Delete(id)
+	result, err := s.GroupStore.Create(group)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4102,15 +4450,15 @@ func (s *TimerLayerJobStore) Delete(id string) (string, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Create", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) Get(id string) (*model.Job, error) {
+func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.Get(id)
+	result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4118,15 +4466,15 @@ func (s *TimerLayerJobStore) Get(id string) (*model.Job, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateGroupSyncable", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
+func (s *TimerLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds) (*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllByStatus(status)
+	result, err := s.GroupStore.CreateWithUserIds(group)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4134,15 +4482,15 @@ func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateWithUserIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllByType(jobType)
+	result, err := s.GroupStore.Delete(groupID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4150,15 +4498,15 @@ func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Delete", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllByTypeAndStatus(jobType string, status string) ([]*model.Job, error) {
+func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllByTypeAndStatus(jobType, status)
+	result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4166,15 +4514,15 @@ func (s *TimerLayerJobStore) GetAllByTypeAndStatus(jobType string, status string
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypeAndStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteGroupSyncable", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+	result, err := s.GroupStore.DeleteMember(groupID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4182,15 +4530,15 @@ func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMember", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllByTypesPage(jobTypes []string, offset int, limit int) ([]*model.Job, error) {
+func (s *TimerLayerGroupStore) DeleteMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllByTypesPage(jobTypes, offset, limit)
+	result, err := s.GroupStore.DeleteMembers(groupID, userIDs)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4198,15 +4546,15 @@ func (s *TimerLayerJobStore) GetAllByTypesPage(jobTypes []string, offset int, li
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypesPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMembers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+func (s *TimerLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetAllPage(offset, limit)
+	result, err := s.GroupStore.DistinctGroupMemberCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4214,15 +4562,15 @@ func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DistinctGroupMemberCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+	result, err := s.GroupStore.Get(groupID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4230,15 +4578,15 @@ func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType stri
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	result, err := s.GroupStore.GetAllBySource(groupSource)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4246,15 +4594,15 @@ func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllBySource", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+func (s *TimerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+	result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4262,15 +4610,15 @@ func (s *TimerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusesAndType", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllGroupSyncablesByGroupId", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.Save(job)
+	result, err := s.GroupStore.GetByIDs(groupIDs)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4278,15 +4626,15 @@ func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByIDs", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+func (s *TimerLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+	result, err := s.GroupStore.GetByName(name, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4294,15 +4642,15 @@ func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByName", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.UpdateStatus(id, status)
+	result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4310,15 +4658,15 @@ func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByRemoteID", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+func (s *TimerLayerGroupStore) GetByUser(userID string) ([]*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	result, err := s.GroupStore.GetByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4326,15 +4674,15 @@ func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
+func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
 	start := timemodule.Now()
 
-	result, err := s.LicenseStore.Get(id)
+	result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4342,15 +4690,15 @@ func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupSyncable", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
+func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.LicenseStore.GetAll()
+	result, err := s.GroupStore.GetGroups(page, perPage, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4358,15 +4706,15 @@ func (s *TimerLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroups", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+func (s *TimerLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
 	start := timemodule.Now()
 
-	result, err := s.LicenseStore.Save(license)
+	result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4374,15 +4722,15 @@ func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.Lice
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsAssociatedToChannelsByTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+func (s *TimerLayerGroupStore) GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
 	start := timemodule.Now()
 
-	result, err := s.LinkMetadataStore.Get(url, timestamp)
+	result, err := s.GroupStore.GetGroupsByChannel(channelID, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4390,15 +4738,15 @@ func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.L
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+func (s *TimerLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
 	start := timemodule.Now()
 
-	result, err := s.LinkMetadataStore.Save(linkMetadata)
+	result, err := s.GroupStore.GetGroupsByTeam(teamID, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4406,15 +4754,15 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) DeleteApp(id string) error {
+func (s *TimerLayerGroupStore) GetMember(groupID string, userID string) (*model.GroupMember, error) {
 	start := timemodule.Now()
 
-	err := s.OAuthStore.DeleteApp(id)
+	result, err := s.GroupStore.GetMember(groupID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4422,15 +4770,15 @@ func (s *TimerLayerOAuthStore) DeleteApp(id string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMember", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetAccessData(token)
+	result, err := s.GroupStore.GetMemberCount(groupID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4438,15 +4786,15 @@ func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+	result, err := s.GroupStore.GetMemberUsers(groupID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4454,15 +4802,15 @@ func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByRefreshToken", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId string) ([]*model.AccessData, error) {
+func (s *TimerLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientId)
+	result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4470,15 +4818,15 @@ func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByUserForApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersInTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+func (s *TimerLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetApp(id)
+	result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4486,15 +4834,15 @@ func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersNotInChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
+	result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4502,15 +4850,15 @@ func (s *TimerLayerOAuthStore) GetAppByUser(userID string, offset int, limit int
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAppByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersPage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *TimerLayerGroupStore) GetNonMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetApps(offset, limit)
+	result, err := s.GroupStore.GetNonMemberUsersPage(groupID, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4518,15 +4866,15 @@ func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApps", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetNonMemberUsersPage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+func (s *TimerLayerGroupStore) GroupChannelCount() (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetAuthData(code)
+	result, err := s.GroupStore.GroupChannelCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4534,15 +4882,15 @@ func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupChannelCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+func (s *TimerLayerGroupStore) GroupCount() (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
+	result, err := s.GroupStore.GroupCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4550,15 +4898,15 @@ func (s *TimerLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limi
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthorizedApps", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) GetPreviousAccessData(userID string, clientId string) (*model.AccessData, error) {
+func (s *TimerLayerGroupStore) GroupCountBySource(source model.GroupSource) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.GetPreviousAccessData(userID, clientId)
+	result, err := s.GroupStore.GroupCountBySource(source)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4566,15 +4914,15 @@ func (s *TimerLayerOAuthStore) GetPreviousAccessData(userID string, clientId str
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetPreviousAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCountBySource", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
+func (s *TimerLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
 	start := timemodule.Now()
 
-	err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
+	result, err := s.GroupStore.GroupCountWithAllowReference()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4582,15 +4930,15 @@ func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) erro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.PermanentDeleteAuthDataByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCountWithAllowReference", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerOAuthStore) RemoveAccessData(token string) error {
+func (s *TimerLayerGroupStore) GroupMemberCount() (int64, error) {
 	start := timemodule.Now()
 
-	err := s.OAuthStore.RemoveAccessData(token)
+	result, err := s.GroupStore.GroupMemberCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4598,15 +4946,15 @@ func (s *TimerLayerOAuthStore) RemoveAccessData(token string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupMemberCount", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerOAuthStore) RemoveAllAccessData() error {
+func (s *TimerLayerGroupStore) GroupTeamCount() (int64, error) {
 	start := timemodule.Now()
 
-	err := s.OAuthStore.RemoveAllAccessData()
+	result, err := s.GroupStore.GroupTeamCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4614,15 +4962,15 @@ func (s *TimerLayerOAuthStore) RemoveAllAccessData() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAllAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupTeamCount", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerOAuthStore) RemoveAuthData(code string) error {
+func (s *TimerLayerGroupStore) PermanentDeleteMembersByUser(userID string) error {
 	start := timemodule.Now()
 
-	err := s.OAuthStore.RemoveAuthData(code)
+	err := s.GroupStore.PermanentDeleteMembersByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4630,15 +4978,15 @@ func (s *TimerLayerOAuthStore) RemoveAuthData(code string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.PermanentDeleteMembersByUser", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *TimerLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.SaveAccessData(accessData)
+	result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4646,15 +4994,15 @@ func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*mo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.PermittedSyncableAdmins", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.SaveApp(app)
+	result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4662,15 +5010,15 @@ func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersMinusGroupMembers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, includeRemovedMembers bool) ([]*model.UserTeamIDPair, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.SaveAuthData(authData)
+	result, err := s.GroupStore.TeamMembersToAdd(since, teamID, includeRemovedMembers)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4678,15 +5026,15 @@ func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.Au
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToAdd", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+func (s *TimerLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.UpdateAccessData(accessData)
+	result, err := s.GroupStore.TeamMembersToRemove(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4694,15 +5042,15 @@ func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToRemove", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
 	start := timemodule.Now()
 
-	result, err := s.OAuthStore.UpdateApp(app)
+	result, err := s.GroupStore.Update(group)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4710,15 +5058,15 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
 	start := timemodule.Now()
 
-	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4726,15 +5074,15 @@ func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpdateGroupSyncable", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	result, err := s.GroupStore.UpsertMember(groupID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4742,15 +5090,15 @@ func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMember", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
+func (s *TimerLayerGroupStore) UpsertMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
 	start := timemodule.Now()
 
-	err := s.PluginStore.Delete(pluginID, key)
+	result, err := s.GroupStore.UpsertMembers(groupID, userIDs)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4758,15 +5106,15 @@ func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMembers", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPluginStore) DeleteAllExpired() error {
+func (s *TimerLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
 	start := timemodule.Now()
 
-	err := s.PluginStore.DeleteAllExpired()
+	err := s.JobStore.Cleanup(expiryTime, batchSize)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4774,15 +5122,15 @@ func (s *TimerLayerPluginStore) DeleteAllExpired() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Cleanup", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+func (s *TimerLayerJobStore) Delete(id string) (string, error) {
 	start := timemodule.Now()
 
-	err := s.PluginStore.DeleteAllForPlugin(PluginID)
+	result, err := s.JobStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4790,15 +5138,15 @@ func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Delete", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+func (s *TimerLayerJobStore) Get(id string) (*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PluginStore.Get(pluginID, key)
+	result, err := s.JobStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4806,15 +5154,15 @@ func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PluginStore.List(pluginID, page, perPage)
+	result, err := s.JobStore.GetAllByStatus(status)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4822,15 +5170,15 @@ func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+	result, err := s.JobStore.GetAllByType(jobType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4838,15 +5186,15 @@ func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+func (s *TimerLayerJobStore) GetAllByTypeAndStatus(jobType string, status string) ([]*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
+	result, err := s.JobStore.GetAllByTypeAndStatus(jobType, status)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4854,15 +5202,15 @@ func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, valu
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypeAndStatus", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.AnalyticsPostCount(options)
+	result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4870,15 +5218,15 @@ func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+func (s *TimerLayerJobStore) GetAllByTypesPage(jobTypes []string, offset int, limit int) ([]*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+	result, err := s.JobStore.GetAllByTypesPage(jobTypes, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4886,15 +5234,15 @@ func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypesPage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+	result, err := s.JobStore.GetAllPage(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4902,30 +5250,31 @@ func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) ClearCaches() {
+func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
 	start := timemodule.Now()
 
-	s.PostStore.ClearCaches()
+	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerPostStore) Delete(postID string, time int64, deleteByID string) error {
+func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
 	start := timemodule.Now()
 
-	err := s.PostStore.Delete(postID, time, deleteByID)
+	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4933,15 +5282,15 @@ func (s *TimerLayerPostStore) Delete(postID string, time int64, deleteByID strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *TimerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.DeleteOrphanedRows(limit)
+	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4949,15 +5298,15 @@ func (s *TimerLayerPostStore) DeleteOrphanedRows(limit int) (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.DeleteOrphanedRows", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusesAndType", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string) (*model.PostList, error) {
+func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.Get(ctx, id, opts, userID)
+	result, err := s.JobStore.Save(job)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4965,15 +5314,15 @@ func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.Get
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string) ([]*model.DirectPostForExport, error) {
+func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID)
+	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4981,31 +5330,31 @@ func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
+func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
 	start := timemodule.Now()
 
-	result := s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
+	result, err := s.JobStore.UpdateStatus(id, status)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, elapsed)
 	}
-	return result
+	return result, err
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
+func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
+	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5013,15 +5362,15 @@ func (s *TimerLayerPostStore) GetFlaggedPosts(userID string, offset int, limit i
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
+func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+	result, err := s.LicenseStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5029,15 +5378,15 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
+func (s *TimerLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+	result, err := s.LicenseStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5045,15 +5394,15 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
+func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetLastPostRowCreateAt()
+	result, err := s.LicenseStore.Save(license)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5061,31 +5410,31 @@ func (s *TimerLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetLastPostRowCreateAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetMaxPostSize() int {
+func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
 	start := timemodule.Now()
 
-	result := s.PostStore.GetMaxPostSize()
+	result, err := s.LinkMetadataStore.Get(url, timestamp)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Get", success, elapsed)
 	}
-	return result
+	return result, err
 }
 
-func (s *TimerLayerPostStore) GetOldest() (*model.Post, error) {
+func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetOldest()
+	result, err := s.LinkMetadataStore.Save(linkMetadata)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5093,15 +5442,15 @@ func (s *TimerLayerPostStore) GetOldest() (*model.Post, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+func (s *TimerLayerMfaBackupCodeStore) CountUnusedForUser(userID string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetOldestEntityCreationTime()
+	result, err := s.MfaBackupCodeStore.CountUnusedForUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5109,15 +5458,15 @@ func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldestEntityCreationTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaBackupCodeStore.CountUnusedForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string) ([]*model.PostForExport, error) {
+func (s *TimerLayerMfaBackupCodeStore) PermanentDeleteByUser(userID string) error {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID)
+	err := s.MfaBackupCodeStore.PermanentDeleteByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5125,15 +5474,15 @@ func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaBackupCodeStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) GetPostAfterTime(channelID string, time int64, collapsedThreads bool) (*model.Post, error) {
+func (s *TimerLayerMfaBackupCodeStore) ReplaceForUser(userID string, hashes []string, createAt int64) error {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostAfterTime(channelID, time, collapsedThreads)
+	err := s.MfaBackupCodeStore.ReplaceForUser(userID, hashes, createAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5141,15 +5490,15 @@ func (s *TimerLayerPostStore) GetPostAfterTime(channelID string, time int64, col
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaBackupCodeStore.ReplaceForUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) GetPostIdAfterTime(channelID string, time int64, collapsedThreads bool) (string, error) {
+func (s *TimerLayerMfaBackupCodeStore) Use(userID string, hash string, usedAt int64) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostIdAfterTime(channelID, time, collapsedThreads)
+	result, err := s.MfaBackupCodeStore.Use(userID, hash, usedAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5157,15 +5506,15 @@ func (s *TimerLayerPostStore) GetPostIdAfterTime(channelID string, time int64, c
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaBackupCodeStore.Use", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelID string, time int64, collapsedThreads bool) (string, error) {
+func (s *TimerLayerOAuthStore) DeleteApp(id string) error {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostIdBeforeTime(channelID, time, collapsedThreads)
+	err := s.OAuthStore.DeleteApp(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5173,15 +5522,15 @@ func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelID string, time int64,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPosts(options, allowFromCache)
+	result, err := s.OAuthStore.GetAccessData(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5189,15 +5538,15 @@ func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromC
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessData", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsAfter(options)
+	result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5205,15 +5554,15 @@ func (s *TimerLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByRefreshToken", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientId string) ([]*model.AccessData, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
+	result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5221,15 +5570,15 @@ func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPos
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByUserForApp", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsBefore(options)
+	result, err := s.OAuthStore.GetApp(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5237,15 +5586,15 @@ func (s *TimerLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*mo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBefore", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApp", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+func (s *TimerLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsByIds(postIds)
+	result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5253,15 +5602,15 @@ func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAppByUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsCreatedAt(channelID string, time int64) ([]*model.Post, error) {
+func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsCreatedAt(channelID, time)
+	result, err := s.OAuthStore.GetApps(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5269,15 +5618,15 @@ func (s *TimerLayerPostStore) GetPostsCreatedAt(channelID string, time int64) ([
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCreatedAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApps", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+	result, err := s.OAuthStore.GetAuthData(code)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5285,15 +5634,15 @@ func (s *TimerLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthData", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+func (s *TimerLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
+	result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5301,15 +5650,15 @@ func (s *TimerLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceFo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSinceForSync", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthorizedApps", success, elapsed)
 	}
-	return result, resultVar1, err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
+func (s *TimerLayerOAuthStore) GetPreviousAccessData(userID string, clientId string) (*model.AccessData, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetRecentSearchesForUser(userID)
+	result, err := s.OAuthStore.GetPreviousAccessData(userID, clientId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5317,15 +5666,15 @@ func (s *TimerLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRecentSearchesForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetPreviousAccessData", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetRepliesForExport(parentID)
+	err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5333,15 +5682,15 @@ func (s *TimerLayerPostStore) GetRepliesForExport(parentID string) ([]*model.Rep
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRepliesForExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.PermanentDeleteAuthDataByUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error) {
+func (s *TimerLayerOAuthStore) RemoveAccessData(token string) error {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetSingle(id, inclDeleted)
+	err := s.OAuthStore.RemoveAccessData(token)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5349,15 +5698,15 @@ func (s *TimerLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Pos
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingle", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAccessData", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
+func (s *TimerLayerOAuthStore) RemoveAllAccessData() error {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
+	err := s.OAuthStore.RemoveAllAccessData()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5365,30 +5714,31 @@ func (s *TimerLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.HasAutoResponsePostByUserSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAllAccessData", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
+func (s *TimerLayerOAuthStore) RemoveAuthData(code string) error {
 	start := timemodule.Now()
 
-	s.PostStore.InvalidateLastPostTimeCache(channelID)
+	err := s.OAuthStore.RemoveAuthData(code)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.InvalidateLastPostTimeCache", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAuthData", success, elapsed)
 	}
+	return err
 }
 
-func (s *TimerLayerPostStore) LogRecentSearch(userID string, searchQuery []byte, createAt int64) error {
+func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 	start := timemodule.Now()
 
-	err := s.PostStore.LogRecentSearch(userID, searchQuery, createAt)
+	result, err := s.OAuthStore.SaveAccessData(accessData)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5396,15 +5746,15 @@ func (s *TimerLayerPostStore) LogRecentSearch(userID string, searchQuery []byte,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.LogRecentSearch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.Overwrite(post)
+	result, err := s.OAuthStore.SaveApp(app)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5412,15 +5762,15 @@ func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Overwrite", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+	result, err := s.OAuthStore.SaveAuthData(authData)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5428,15 +5778,15 @@ func (s *TimerLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.P
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.OverwriteMultiple", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, elapsed)
 	}
-	return result, resultVar1, err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+	result, err := s.OAuthStore.UpdateAccessData(accessData)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5444,15 +5794,15 @@ func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
+	result, err := s.OAuthStore.UpdateApp(app)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5460,15 +5810,15 @@ func (s *TimerLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatchForRetentionPolicies", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, elapsed)
 	}
-	return result, resultVar1, err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelID string) error {
+func (s *TimerLayerOnboardingTaskStore) Delete(id string, deleteAt int64) error {
 	start := timemodule.Now()
 
-	err := s.PostStore.PermanentDeleteByChannel(channelID)
+	err := s.OnboardingTaskStore.Delete(id, deleteAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5476,15 +5826,15 @@ func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingTaskStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPostStore) PermanentDeleteByUser(userID string) error {
+func (s *TimerLayerOnboardingTaskStore) Get(id string) (*model.OnboardingTask, error) {
 	start := timemodule.Now()
 
-	err := s.PostStore.PermanentDeleteByUser(userID)
+	result, err := s.OnboardingTaskStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5492,15 +5842,15 @@ func (s *TimerLayerPostStore) PermanentDeleteByUser(userID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingTaskStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+func (s *TimerLayerOnboardingTaskStore) GetAll() ([]*model.OnboardingTask, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.Save(post)
+	result, err := s.OnboardingTaskStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5508,15 +5858,15 @@ func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingTaskStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+func (s *TimerLayerOnboardingTaskStore) Save(task *model.OnboardingTask) (*model.OnboardingTask, error) {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+	result, err := s.OnboardingTaskStore.Save(task)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5524,15 +5874,15 @@ func (s *TimerLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SaveMultiple", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingTaskStore.Save", success, elapsed)
 	}
-	return result, resultVar1, err
+	return result, err
 }
 
-func (s *TimerLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
+func (s *TimerLayerOnboardingTaskStore) Update(task *model.OnboardingTask) (*model.OnboardingTask, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.Search(teamID, userID, params)
+	result, err := s.OnboardingTaskStore.Update(task)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5540,15 +5890,15 @@ func (s *TimerLayerPostStore) Search(teamID string, userID string, params *model
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OnboardingTaskStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) Delete(id string) error {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.SearchPostsForUser(paramsList, userID, teamID, page, perPage)
+	err := s.OutgoingOAuthConnectionStore.Delete(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5556,15 +5906,15 @@ func (s *TimerLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParam
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchPostsForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) Get(id string) (*model.OutgoingOAuthConnection, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.Update(newPost, oldPost)
+	result, err := s.OutgoingOAuthConnectionStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5572,15 +5922,15 @@ func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) GetAll(offset int, limit int) ([]*model.OutgoingOAuthConnection, error) {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+	result, err := s.OutgoingOAuthConnectionStore.GetAll(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5588,15 +5938,15 @@ func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) Delete(userID string, category string, name string) error {
+func (s *TimerLayerOutgoingOAuthConnectionStore) Save(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.Delete(userID, category, name)
+	result, err := s.OutgoingOAuthConnectionStore.Save(connection)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5604,15 +5954,15 @@ func (s *TimerLayerPreferenceStore) Delete(userID string, category string, name
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.Save", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+func (s *TimerLayerOutgoingOAuthConnectionStore) Update(connection *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.DeleteCategory(userID, category)
+	result, err := s.OutgoingOAuthConnectionStore.Update(connection)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5620,15 +5970,15 @@ func (s *TimerLayerPreferenceStore) DeleteCategory(userID string, category strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.Update", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+func (s *TimerLayerPersistentWebSocketEventStore) Cleanup(expiryTime int64, batchSize int) error {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	err := s.PersistentWebSocketEventStore.Cleanup(expiryTime, batchSize)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5636,15 +5986,15 @@ func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentWebSocketEventStore.Cleanup", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *TimerLayerPersistentWebSocketEventStore) GetForUserSince(userID string, since int64, limit int) ([]*model.PersistentWebSocketEvent, error) {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+	result, err := s.PersistentWebSocketEventStore.GetForUserSince(userID, since, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5652,15 +6002,15 @@ func (s *TimerLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteOrphanedRows", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentWebSocketEventStore.GetForUserSince", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+func (s *TimerLayerPersistentWebSocketEventStore) Save(event *model.PersistentWebSocketEvent) (*model.PersistentWebSocketEvent, error) {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.Get(userID, category, name)
+	result, err := s.PersistentWebSocketEventStore.Save(event)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5668,15 +6018,15 @@ func (s *TimerLayerPreferenceStore) Get(userID string, category string, name str
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentWebSocketEventStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.GetAll(userID)
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5684,15 +6034,15 @@ func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.PreferenceStore.GetCategory(userID, category)
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5700,15 +6050,15 @@ func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.PermanentDeleteByUser(userID)
+	err := s.PluginStore.Delete(pluginID, key)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5716,15 +6066,15 @@ func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPreferenceStore) Save(preferences model.Preferences) error {
+func (s *TimerLayerPluginStore) DeleteAllExpired() error {
 	start := timemodule.Now()
 
-	err := s.PreferenceStore.Save(preferences)
+	err := s.PluginStore.DeleteAllExpired()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5732,15 +6082,15 @@ func (s *TimerLayerPreferenceStore) Save(preferences model.Preferences) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
+func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 	start := timemodule.Now()
 
-	err := s.ProductNoticesStore.Clear(notices)
+	err := s.PluginStore.DeleteAllForPlugin(PluginID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5748,15 +6098,15 @@ func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.Clear", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
 	start := timemodule.Now()
 
-	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+	result, err := s.PluginStore.Get(pluginID, key)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5764,15 +6114,15 @@ func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices model.Pro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.ClearOldNotices", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.ProductNoticesStore.GetViews(userID)
+	result, err := s.PluginStore.List(pluginID, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5780,15 +6130,15 @@ func (s *TimerLayerProductNoticesStore) GetViews(userID string) ([]model.Product
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.GetViews", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) View(userID string, notices []string) error {
+func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	start := timemodule.Now()
 
-	err := s.ProductNoticesStore.View(userID, notices)
+	result, err := s.PluginStore.SaveOrUpdate(keyVal)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5796,15 +6146,15 @@ func (s *TimerLayerProductNoticesStore) View(userID string, notices []string) er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.View", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+func (s *TimerLayerPluginStore) SetMultiple(pluginID string, operations []*model.PluginKVSetOperation) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.BulkGetForPosts(postIds)
+	result, err := s.PluginStore.SetMultiple(pluginID, operations)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5812,15 +6162,15 @@ func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetMultiple", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.Delete(reaction)
+	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5828,15 +6178,15 @@ func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.React
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+func (s *TimerLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
 	start := timemodule.Now()
 
-	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	result, err := s.PostStore.AnalyticsPostCount(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5844,15 +6194,15 @@ func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.DeleteOrphanedRows(limit)
+	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5860,15 +6210,15 @@ func (s *TimerLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteOrphanedRows", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5876,31 +6226,30 @@ func (s *TimerLayerReactionStore) GetForPost(postID string, allowFromCache bool)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error) {
+func (s *TimerLayerPostStore) ClearCaches() {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.GetForPostSince(postId, since, excludeRemoteId, inclDeleted)
+	s.PostStore.ClearCaches()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPostSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
 	}
-	return result, err
 }
 
-func (s *TimerLayerReactionStore) GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
+func (s *TimerLayerPostStore) Delete(postID string, time int64, deleteByID string) error {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.GetTopForTeamSince(teamID, userID, since, offset, limit)
+	err := s.PostStore.Delete(postID, time, deleteByID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5908,15 +6257,15 @@ func (s *TimerLayerReactionStore) GetTopForTeamSince(teamID string, userID strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetTopForTeamSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerReactionStore) GetTopForUserSince(userID string, teamID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
+func (s *TimerLayerPostStore) DeleteOrphanedRows(limit int) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.GetTopForUserSince(userID, teamID, since, offset, limit)
+	result, err := s.PostStore.DeleteOrphanedRows(limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5924,15 +6273,15 @@ func (s *TimerLayerReactionStore) GetTopForUserSince(userID string, teamID strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetTopForUserSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.DeleteOrphanedRows", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	result, err := s.PostStore.Get(ctx, id, opts, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5940,15 +6289,15 @@ func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int6
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *TimerLayerPostStore) GetChannelPostsForExportAfter(channelID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.ReactionStore.Save(reaction)
+	result, err := s.PostStore.GetChannelPostsForExportAfter(channelID, afterCreateAt, afterID, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5956,15 +6305,15 @@ func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetChannelPostsForExportAfter", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
+func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string) ([]*model.DirectPostForExport, error) {
 	start := timemodule.Now()
 
-	result, err := s.RemoteClusterStore.Delete(remoteClusterId)
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -5972,31 +6321,31 @@ func (s *TimerLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRemoteClusterStore) Get(remoteClusterId string) (*model.RemoteCluster, error) {
+func (s *TimerLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
 	start := timemodule.Now()
 
-	result, err := s.RemoteClusterStore.Get(remoteClusterId)
+	result := s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, elapsed)
 	}
-	return result, err
+	return result
 }
 
-func (s *TimerLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
+func (s *TimerLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.RemoteClusterStore.GetAll(filter)
+	result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6004,15 +6353,15 @@ func (s *TimerLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFil
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.RemoteClusterStore.Save(rc)
+	result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6020,15 +6369,15 @@ func (s *TimerLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.Rem
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) error {
+func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	err := s.RemoteClusterStore.SetLastPingAt(remoteClusterId)
+	result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6036,15 +6385,15 @@ func (s *TimerLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.SetLastPingAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+func (s *TimerLayerPostStore) GetLastPostAtByUserSince(options model.GetPostsSinceOptions, userId string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.RemoteClusterStore.Update(rc)
+	result, err := s.PostStore.GetLastPostAtByUserSince(options, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6052,15 +6401,15 @@ func (s *TimerLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.R
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetLastPostAtByUserSince", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topics string) (*model.RemoteCluster, error) {
+func (s *TimerLayerPostStore) GetLastPostRowCreateAt() (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterId, topics)
+	result, err := s.PostStore.GetLastPostRowCreateAt()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6068,31 +6417,31 @@ func (s *TimerLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topi
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.UpdateTopics", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetLastPostRowCreateAt", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) AddChannels(policyId string, channelIds []string) error {
+func (s *TimerLayerPostStore) GetMaxPostSize() int {
 	start := timemodule.Now()
 
-	err := s.RetentionPolicyStore.AddChannels(policyId, channelIds)
+	result := s.PostStore.GetMaxPostSize()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.AddChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, elapsed)
 	}
-	return err
+	return result
 }
 
-func (s *TimerLayerRetentionPolicyStore) AddTeams(policyId string, teamIds []string) error {
+func (s *TimerLayerPostStore) GetOldest() (*model.Post, error) {
 	start := timemodule.Now()
 
-	err := s.RetentionPolicyStore.AddTeams(policyId, teamIds)
+	result, err := s.PostStore.GetOldest()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6100,15 +6449,15 @@ func (s *TimerLayerRetentionPolicyStore) AddTeams(policyId string, teamIds []str
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.AddTeams", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) Delete(id string) error {
+func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 	start := timemodule.Now()
 
-	err := s.RetentionPolicyStore.Delete(id)
+	result, err := s.PostStore.GetOldestEntityCreationTime()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6116,15 +6465,15 @@ func (s *TimerLayerRetentionPolicyStore) Delete(id string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldestEntityCreationTime", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string) ([]*model.PostForExport, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6132,15 +6481,15 @@ func (s *TimerLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.DeleteOrphanedRows", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForExportAfter", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *TimerLayerPostStore) GetPostAfterTime(channelID string, time int64, collapsedThreads bool) (*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.Get(id)
+	result, err := s.PostStore.GetPostAfterTime(channelID, time, collapsedThreads)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6148,15 +6497,15 @@ func (s *TimerLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyW
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *TimerLayerPostStore) GetPostIdAfterTime(channelID string, time int64, collapsedThreads bool) (string, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetAll(offset, limit)
+	result, err := s.PostStore.GetPostIdAfterTime(channelID, time, collapsedThreads)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6164,15 +6513,15 @@ func (s *TimerLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
+func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelID string, time int64, collapsedThreads bool) (string, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
+	result, err := s.PostStore.GetPostIdBeforeTime(channelID, time, collapsedThreads)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6180,15 +6529,15 @@ func (s *TimerLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID s
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannelPoliciesCountForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
+func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
+	result, err := s.PostStore.GetPosts(options, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6196,15 +6545,15 @@ func (s *TimerLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannelPoliciesForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetChannels(policyId string, offset int, limit int) (model.ChannelListWithTeamData, error) {
+func (s *TimerLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetChannels(policyId, offset, limit)
+	result, err := s.PostStore.GetPostsAfter(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6212,15 +6561,15 @@ func (s *TimerLayerRetentionPolicyStore) GetChannels(policyId string, offset int
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int64, error) {
+func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetChannelsCount(policyId)
+	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6228,15 +6577,15 @@ func (s *TimerLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int6
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannelsCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetCount() (int64, error) {
+func (s *TimerLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetCount()
+	result, err := s.PostStore.GetPostsBefore(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6244,15 +6593,15 @@ func (s *TimerLayerRetentionPolicyStore) GetCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBefore", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+	result, err := s.PostStore.GetPostsByIds(postIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6260,15 +6609,15 @@ func (s *TimerLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID stri
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeamPoliciesCountForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+func (s *TimerLayerPostStore) GetPostsCreatedAt(channelID string, time int64) ([]*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+	result, err := s.PostStore.GetPostsCreatedAt(channelID, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6276,15 +6625,15 @@ func (s *TimerLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, o
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeamPoliciesForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCreatedAt", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetTeams(policyId string, offset int, limit int) ([]*model.Team, error) {
+func (s *TimerLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetTeams(policyId, offset, limit)
+	result, err := s.PostStore.GetPostsSince(options, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6292,15 +6641,15 @@ func (s *TimerLayerRetentionPolicyStore) GetTeams(policyId string, offset int, l
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeams", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSince", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) GetTeamsCount(policyId string) (int64, error) {
+func (s *TimerLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.GetTeamsCount(policyId)
+	result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6308,15 +6657,15 @@ func (s *TimerLayerRetentionPolicyStore) GetTeamsCount(policyId string) (int64,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeamsCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSinceForSync", success, elapsed)
 	}
-	return result, err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *TimerLayerPostStore) GetRecentSearchesForUser(userID string) ([]*model.SearchParams, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.Patch(patch)
+	result, err := s.PostStore.GetRecentSearchesForUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6324,15 +6673,15 @@ func (s *TimerLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithT
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Patch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRecentSearchesForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) RemoveChannels(policyId string, channelIds []string) error {
+func (s *TimerLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
 	start := timemodule.Now()
 
-	err := s.RetentionPolicyStore.RemoveChannels(policyId, channelIds)
+	result, err := s.PostStore.GetRepliesForExport(parentID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6340,15 +6689,15 @@ func (s *TimerLayerRetentionPolicyStore) RemoveChannels(policyId string, channel
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.RemoveChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRepliesForExport", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
+func (s *TimerLayerPostStore) GetSingle(id string, inclDeleted bool) (*model.Post, error) {
 	start := timemodule.Now()
 
-	err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
+	result, err := s.PostStore.GetSingle(id, inclDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6356,15 +6705,15 @@ func (s *TimerLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.RemoveTeams", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingle", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *TimerLayerPostStore) GetUserPostsForExportAfter(userID string, afterCreateAt int64, afterID string, limit int) ([]*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.RetentionPolicyStore.Save(policy)
+	result, err := s.PostStore.GetUserPostsForExportAfter(userID, afterCreateAt, afterID, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6372,15 +6721,15 @@ func (s *TimerLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithT
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetUserPostsForExportAfter", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+func (s *TimerLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userId string) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.AllChannelSchemeRoles()
+	result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6388,31 +6737,30 @@ func (s *TimerLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.AllChannelSchemeRoles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.HasAutoResponsePostByUserSince", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+	s.PostStore.InvalidateLastPostTimeCache(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.ChannelHigherScopedPermissions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.InvalidateLastPostTimeCache", success, elapsed)
 	}
-	return result, err
 }
 
-func (s *TimerLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+func (s *TimerLayerPostStore) LogRecentSearch(userID string, searchQuery []byte, createAt int64) error {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+	err := s.PostStore.LogRecentSearch(userID, searchQuery, createAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6420,15 +6768,15 @@ func (s *TimerLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.ChannelRolesUnderTeamRole", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.LogRecentSearch", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.Delete(roleID)
+	result, err := s.PostStore.Overwrite(post)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6436,15 +6784,15 @@ func (s *TimerLayerRoleStore) Delete(roleID string) (*model.Role, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Overwrite", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRoleStore) Get(roleID string) (*model.Role, error) {
+func (s *TimerLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.Get(roleID)
+	result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6452,15 +6800,15 @@ func (s *TimerLayerRoleStore) Get(roleID string) (*model.Role, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.OverwriteMultiple", success, elapsed)
 	}
-	return result, err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, error) {
+func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.GetAll()
+	result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6468,15 +6816,15 @@ func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatch", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+func (s *TimerLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now int64, globalPolicyEndTime int64, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.GetByName(ctx, name)
+	result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6484,15 +6832,15 @@ func (s *TimerLayerRoleStore) GetByName(ctx context.Context, name string) (*mode
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatchForRetentionPolicies", success, elapsed)
 	}
-	return result, err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelID string) error {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.GetByNames(names)
+	err := s.PostStore.PermanentDeleteByChannel(channelID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6500,15 +6848,15 @@ func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByNames", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByChannel", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerRoleStore) PermanentDeleteAll() error {
+func (s *TimerLayerPostStore) PermanentDeleteByUser(userID string) error {
 	start := timemodule.Now()
 
-	err := s.RoleStore.PermanentDeleteAll()
+	err := s.PostStore.PermanentDeleteByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6516,15 +6864,15 @@ func (s *TimerLayerRoleStore) PermanentDeleteAll() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.PermanentDeleteAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByUser", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.RoleStore.Save(role)
+	result, err := s.PostStore.Save(post)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6532,15 +6880,15 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
+func (s *TimerLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
 	start := timemodule.Now()
 
-	result, err := s.SchemeStore.CountByScope(scope)
+	result, resultVar1, err := s.PostStore.SaveMultiple(posts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6548,15 +6896,15 @@ func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.CountByScope", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SaveMultiple", success, elapsed)
 	}
-	return result, err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+func (s *TimerLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+	result, err := s.PostStore.Search(teamID, userID, params)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6564,15 +6912,15 @@ func (s *TimerLayerSchemeStore) CountWithoutPermission(scope string, permissionI
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.CountWithoutPermission", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
+func (s *TimerLayerPostStore) SearchPostsForUser(paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
 	start := timemodule.Now()
 
-	result, err := s.SchemeStore.Delete(schemeID)
+	result, err := s.PostStore.SearchPostsForUser(paramsList, userID, teamID, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6580,15 +6928,15 @@ func (s *TimerLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchPostsForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
+func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.SchemeStore.Get(schemeID)
+	result, err := s.PostStore.Update(newPost, oldPost)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6596,15 +6944,15 @@ func (s *TimerLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+func (s *TimerLayerPostArchiveStore) ArchiveThreadsBefore(before int64, limit int) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+	result, err := s.PostArchiveStore.ArchiveThreadsBefore(before, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6612,15 +6960,15 @@ func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetAllPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostArchiveStore.ArchiveThreadsBefore", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+func (s *TimerLayerPostArchiveStore) Search(channelIDs []string, terms string, offset int, limit int) ([]*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.SchemeStore.GetByName(schemeName)
+	result, err := s.PostArchiveStore.Search(channelIDs, terms, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6628,15 +6976,15 @@ func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostArchiveStore.Search", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSchemeStore) PermanentDeleteAll() error {
+func (s *TimerLayerPostPropSchemaStore) Delete(namespace string) error {
 	start := timemodule.Now()
 
-	err := s.SchemeStore.PermanentDeleteAll()
+	err := s.PostPropSchemaStore.Delete(namespace)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6644,15 +6992,15 @@ func (s *TimerLayerSchemeStore) PermanentDeleteAll() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.PermanentDeleteAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPropSchemaStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+func (s *TimerLayerPostPropSchemaStore) Get(namespace string) (*model.PostPropSchema, error) {
 	start := timemodule.Now()
 
-	result, err := s.SchemeStore.Save(scheme)
+	result, err := s.PostPropSchemaStore.Get(namespace)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6660,15 +7008,15 @@ func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPropSchemaStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+func (s *TimerLayerPostPropSchemaStore) GetAll() ([]*model.PostPropSchema, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.AnalyticsSessionCount()
+	result, err := s.PostPropSchemaStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6676,15 +7024,15 @@ func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.AnalyticsSessionCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPropSchemaStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+func (s *TimerLayerPostPropSchemaStore) Save(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
 	start := timemodule.Now()
 
-	err := s.SessionStore.Cleanup(expiryTime, batchSize)
+	result, err := s.PostPropSchemaStore.Save(schema)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6692,15 +7040,15 @@ func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) erro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Cleanup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPropSchemaStore.Save", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
+func (s *TimerLayerPostPropSchemaStore) Update(schema *model.PostPropSchema) (*model.PostPropSchema, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.Get(ctx, sessionIDOrToken)
+	result, err := s.PostPropSchemaStore.Update(schema)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6708,15 +7056,15 @@ func (s *TimerLayerSessionStore) Get(ctx context.Context, sessionIDOrToken strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPropSchemaStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) GetLastSessionRowCreateAt() (int64, error) {
+func (s *TimerLayerPostReportStore) Get(id string) (*model.PostReport, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.GetLastSessionRowCreateAt()
+	result, err := s.PostReportStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6724,15 +7072,15 @@ func (s *TimerLayerSessionStore) GetLastSessionRowCreateAt() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetLastSessionRowCreateAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) GetSessions(userID string) ([]*model.Session, error) {
+func (s *TimerLayerPostReportStore) GetReports(opts model.PostReportGetOptions) ([]*model.PostReport, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.GetSessions(userID)
+	result, err := s.PostReportStore.GetReports(opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6740,15 +7088,15 @@ func (s *TimerLayerSessionStore) GetSessions(userID string) ([]*model.Session, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.GetReports", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+func (s *TimerLayerPostReportStore) ResolveForPost(postID string, status string, action string, resolverID string, resolveAt int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+	result, err := s.PostReportStore.ResolveForPost(postID, status, action, resolverID, resolveAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6756,15 +7104,15 @@ func (s *TimerLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobil
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsExpired", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.ResolveForPost", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+func (s *TimerLayerPostReportStore) Save(report *model.PostReport) (*model.PostReport, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+	result, err := s.PostReportStore.Save(report)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6772,15 +7120,15 @@ func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsWithActiveDeviceIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReportStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+func (s *TimerLayerPostRetentionLabelStore) Delete(postID string) error {
 	start := timemodule.Now()
 
-	err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+	err := s.PostRetentionLabelStore.Delete(postID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6788,15 +7136,15 @@ func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.PermanentDeleteSessionsByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostRetentionLabelStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerSessionStore) Remove(sessionIDOrToken string) error {
+func (s *TimerLayerPostRetentionLabelStore) Get(postID string) (*model.PostRetentionLabel, error) {
 	start := timemodule.Now()
 
-	err := s.SessionStore.Remove(sessionIDOrToken)
+	result, err := s.PostRetentionLabelStore.Get(postID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6804,15 +7152,15 @@ func (s *TimerLayerSessionStore) Remove(sessionIDOrToken string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Remove", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostRetentionLabelStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSessionStore) RemoveAllSessions() error {
+func (s *TimerLayerPostRetentionLabelStore) Save(label *model.PostRetentionLabel) (*model.PostRetentionLabel, error) {
 	start := timemodule.Now()
 
-	err := s.SessionStore.RemoveAllSessions()
+	result, err := s.PostRetentionLabelStore.Save(label)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6820,15 +7168,15 @@ func (s *TimerLayerSessionStore) RemoveAllSessions() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.RemoveAllSessions", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostRetentionLabelStore.Save", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.Save(session)
+	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6836,15 +7184,15 @@ func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+func (s *TimerLayerPreferenceStore) Delete(userID string, category string, name string) error {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+	err := s.PreferenceStore.Delete(userID, category, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6852,15 +7200,15 @@ func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceID string, expi
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateDeviceId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+func (s *TimerLayerPreferenceStore) DeleteCategory(userID string, category string) error {
 	start := timemodule.Now()
 
-	err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+	err := s.PreferenceStore.DeleteCategory(userID, category)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6868,15 +7216,15 @@ func (s *TimerLayerSessionStore) UpdateExpiredNotify(sessionid string, notified
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateExpiredNotify", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerSessionStore) UpdateExpiresAt(sessionID string, time int64) error {
+func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
 	start := timemodule.Now()
 
-	err := s.SessionStore.UpdateExpiresAt(sessionID, time)
+	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6884,15 +7232,15 @@ func (s *TimerLayerSessionStore) UpdateExpiresAt(sessionID string, time int64) e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateExpiresAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionID string, time int64) error {
+func (s *TimerLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
 	start := timemodule.Now()
 
-	err := s.SessionStore.UpdateLastActivityAt(sessionID, time)
+	result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6900,15 +7248,15 @@ func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionID string, time int
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateLastActivityAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteOrphanedRows", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) error {
+func (s *TimerLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
 	start := timemodule.Now()
 
-	err := s.SessionStore.UpdateProps(session)
+	result, err := s.PreferenceStore.Get(userID, category, name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6916,15 +7264,15 @@ func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateProps", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
 	start := timemodule.Now()
 
-	result, err := s.SessionStore.UpdateRoles(userID, roles)
+	result, err := s.PreferenceStore.GetAll(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6932,15 +7280,15 @@ func (s *TimerLayerSessionStore) UpdateRoles(userID string, roles string) (strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateRoles", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) Delete(channelId string) (bool, error) {
+func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.Delete(channelId)
+	result, err := s.PreferenceStore.GetCategory(userID, category)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6948,15 +7296,15 @@ func (s *TimerLayerSharedChannelStore) Delete(channelId string) (bool, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, error) {
+func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.DeleteRemote(remoteId)
+	err := s.PreferenceStore.PermanentDeleteByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6964,15 +7312,15 @@ func (s *TimerLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, erro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.DeleteRemote", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) Get(channelId string) (*model.SharedChannel, error) {
+func (s *TimerLayerPreferenceStore) Save(preferences model.Preferences) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.Get(channelId)
+	err := s.PreferenceStore.Save(preferences)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6980,15 +7328,15 @@ func (s *TimerLayerSharedChannelStore) Get(channelId string) (*model.SharedChann
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
+func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
+	err := s.ProductNoticesStore.Clear(notices)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -6996,15 +7344,15 @@ func (s *TimerLayerSharedChannelStore) GetAll(offset int, limit int, opts model.
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.Clear", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
+func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetAllCount(opts)
+	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7012,15 +7360,15 @@ func (s *TimerLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilte
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetAllCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.ClearOldNotices", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) GetAttachment(fileId string, remoteId string) (*model.SharedChannelAttachment, error) {
+func (s *TimerLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetAttachment(fileId, remoteId)
+	result, err := s.ProductNoticesStore.GetViews(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7028,15 +7376,15 @@ func (s *TimerLayerSharedChannelStore) GetAttachment(fileId string, remoteId str
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetAttachment", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.GetViews", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
+func (s *TimerLayerProductNoticesStore) View(userID string, notices []string) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetRemote(id)
+	err := s.ProductNoticesStore.View(userID, notices)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7044,15 +7392,15 @@ func (s *TimerLayerSharedChannelStore) GetRemote(id string) (*model.SharedChanne
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemote", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.View", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) GetRemoteByIds(channelId string, remoteId string) (*model.SharedChannelRemote, error) {
+func (s *TimerLayerPushNotificationReceiptStore) Cleanup(expiryTime int64, batchSize int) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetRemoteByIds(channelId, remoteId)
+	err := s.PushNotificationReceiptStore.Cleanup(expiryTime, batchSize)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7060,15 +7408,15 @@ func (s *TimerLayerSharedChannelStore) GetRemoteByIds(channelId string, remoteId
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemoteByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationReceiptStore.Cleanup", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId string) (*model.RemoteCluster, error) {
+func (s *TimerLayerPushNotificationReceiptStore) GetForUser(userID string, limit int) ([]*model.PushNotificationReceipt, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetRemoteForUser(remoteId, userId)
+	result, err := s.PushNotificationReceiptStore.GetForUser(userID, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7076,15 +7424,15 @@ func (s *TimerLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemoteForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationReceiptStore.GetForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+func (s *TimerLayerPushNotificationReceiptStore) MarkReceived(ackID string, receivedAt int64) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetRemotes(opts)
+	err := s.PushNotificationReceiptStore.MarkReceived(ackID, receivedAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7092,15 +7440,31 @@ func (s *TimerLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemote
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemotes", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationReceiptStore.MarkReceived", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPushNotificationReceiptStore) Save(receipt *model.PushNotificationReceipt) (*model.PushNotificationReceipt, error) {
+	start := timemodule.Now()
+
+	result, err := s.PushNotificationReceiptStore.Save(receipt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationReceiptStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.SharedChannelRemoteStatus, error) {
+func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetRemotesStatus(channelId)
+	result, err := s.ReactionStore.BulkGetForPosts(postIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7108,15 +7472,15 @@ func (s *TimerLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*mo
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemotesStatus", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
+func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
+	result, err := s.ReactionStore.Delete(reaction)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7124,15 +7488,15 @@ func (s *TimerLayerSharedChannelStore) GetSingleUser(userID string, channelID st
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetSingleUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
+func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetUsersForSync(filter)
+	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7140,15 +7504,31 @@ func (s *TimerLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForS
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetUsersForSync", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerReactionStore) DeleteOrphanedRows(limit int) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReactionStore.DeleteOrphanedRows(limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteOrphanedRows", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
+func (s *TimerLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.GetUsersForUser(userID)
+	result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7156,15 +7536,15 @@ func (s *TimerLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetUsersForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
+func (s *TimerLayerReactionStore) GetForPostSince(postId string, since int64, excludeRemoteId string, inclDeleted bool) ([]*model.Reaction, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.HasChannel(channelID)
+	result, err := s.ReactionStore.GetForPostSince(postId, since, excludeRemoteId, inclDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7172,15 +7552,15 @@ func (s *TimerLayerSharedChannelStore) HasChannel(channelID string) (bool, error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.HasChannel", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPostSince", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) HasRemote(channelID string, remoteId string) (bool, error) {
+func (s *TimerLayerReactionStore) GetTopForTeamSince(teamID string, userID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.HasRemote(channelID, remoteId)
+	result, err := s.ReactionStore.GetTopForTeamSince(teamID, userID, since, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7188,15 +7568,15 @@ func (s *TimerLayerSharedChannelStore) HasRemote(channelID string, remoteId stri
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.HasRemote", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetTopForTeamSince", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
+func (s *TimerLayerReactionStore) GetTopForUserSince(userID string, teamID string, since int64, offset int, limit int) (*model.TopReactionList, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.Save(sc)
+	result, err := s.ReactionStore.GetTopForUserSince(userID, teamID, since, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7204,15 +7584,15 @@ func (s *TimerLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.Sha
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetTopForUserSince", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
+func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.SaveAttachment(remote)
+	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7220,15 +7600,15 @@ func (s *TimerLayerSharedChannelStore) SaveAttachment(remote *model.SharedChanne
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveAttachment", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.SaveRemote(remote)
+	result, err := s.ReactionStore.Save(reaction)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7236,15 +7616,1807 @@ func (s *TimerLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRem
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveRemote", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
+func (s *TimerLayerReminderStore) Delete(id string) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.SaveUser(remote)
+	err := s.ReminderStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerReminderStore) Get(id string) (*model.Reminder, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReminderStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReminderStore) GetDue(now int64, limit int) ([]*model.Reminder, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReminderStore.GetDue(now, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.GetDue", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReminderStore) GetForUser(userID string) ([]*model.Reminder, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReminderStore.GetForUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReminderStore) Save(reminder *model.Reminder) (*model.Reminder, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReminderStore.Save(reminder)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReminderStore) Update(reminder *model.Reminder) (*model.Reminder, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReminderStore.Update(reminder)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReminderStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRemoteClusterStore) Delete(remoteClusterId string) (bool, error) {
+	start := timemodule.Now()
+
+	result, err := s.RemoteClusterStore.Delete(remoteClusterId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRemoteClusterStore) Get(remoteClusterId string) (*model.RemoteCluster, error) {
+	start := timemodule.Now()
+
+	result, err := s.RemoteClusterStore.Get(remoteClusterId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRemoteClusterStore) GetAll(filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
+	start := timemodule.Now()
+
+	result, err := s.RemoteClusterStore.GetAll(filter)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+	start := timemodule.Now()
+
+	result, err := s.RemoteClusterStore.Save(rc)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRemoteClusterStore) SetLastPingAt(remoteClusterId string) error {
+	start := timemodule.Now()
+
+	err := s.RemoteClusterStore.SetLastPingAt(remoteClusterId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.SetLastPingAt", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+	start := timemodule.Now()
+
+	result, err := s.RemoteClusterStore.Update(rc)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRemoteClusterStore) UpdateTopics(remoteClusterId string, topics string) (*model.RemoteCluster, error) {
+	start := timemodule.Now()
+
+	result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterId, topics)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RemoteClusterStore.UpdateTopics", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) AddChannels(policyId string, channelIds []string) error {
+	start := timemodule.Now()
+
+	err := s.RetentionPolicyStore.AddChannels(policyId, channelIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.AddChannels", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerRetentionPolicyStore) AddTeams(policyId string, teamIds []string) error {
+	start := timemodule.Now()
+
+	err := s.RetentionPolicyStore.AddTeams(policyId, teamIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.AddTeams", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerRetentionPolicyStore) Delete(id string) error {
+	start := timemodule.Now()
+
+	err := s.RetentionPolicyStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.DeleteOrphanedRows", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetAll(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannelPoliciesCountForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannelPoliciesForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetChannels(policyId string, offset int, limit int) (model.ChannelListWithTeamData, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetChannels(policyId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannels", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetChannelsCount(policyId string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetChannelsCount(policyId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetChannelsCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetCount() (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetCount()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeamPoliciesCountForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeamPoliciesForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetTeams(policyId string, offset int, limit int) ([]*model.Team, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetTeams(policyId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeams", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) GetTeamsCount(policyId string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.GetTeamsCount(policyId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetTeamsCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.Patch(patch)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Patch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRetentionPolicyStore) RemoveChannels(policyId string, channelIds []string) error {
+	start := timemodule.Now()
+
+	err := s.RetentionPolicyStore.RemoveChannels(policyId, channelIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.RemoveChannels", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerRetentionPolicyStore) RemoveTeams(policyId string, teamIds []string) error {
+	start := timemodule.Now()
+
+	err := s.RetentionPolicyStore.RemoveTeams(policyId, teamIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.RemoveTeams", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.RetentionPolicyStore.Save(policy)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.AllChannelSchemeRoles()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.AllChannelSchemeRoles", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.ChannelHigherScopedPermissions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.ChannelRolesUnderTeamRole", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.Delete(roleID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) Get(roleID string) (*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.Get(roleID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.GetAll()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.GetByName(ctx, name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.GetByNames(names)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByNames", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerRoleStore) PermanentDeleteAll() error {
+	start := timemodule.Now()
+
+	err := s.RoleStore.PermanentDeleteAll()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.PermanentDeleteAll", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+	start := timemodule.Now()
+
+	result, err := s.RoleStore.Save(role)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledConfigChangeStore) Get(id string) (*model.ScheduledConfigChange, error) {
+	start := timemodule.Now()
+
+	result, err := s.ScheduledConfigChangeStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledConfigChangeStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledConfigChangeStore) GetAll(includeFinished bool, offset int, limit int) ([]*model.ScheduledConfigChange, error) {
+	start := timemodule.Now()
+
+	result, err := s.ScheduledConfigChangeStore.GetAll(includeFinished, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledConfigChangeStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledConfigChangeStore) GetDue(now int64, limit int) ([]*model.ScheduledConfigChange, error) {
+	start := timemodule.Now()
+
+	result, err := s.ScheduledConfigChangeStore.GetDue(now, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledConfigChangeStore.GetDue", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledConfigChangeStore) Save(change *model.ScheduledConfigChange) (*model.ScheduledConfigChange, error) {
+	start := timemodule.Now()
+
+	result, err := s.ScheduledConfigChangeStore.Save(change)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledConfigChangeStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerScheduledConfigChangeStore) UpdateStatus(id string, oldStatus string, newStatus string, errorMessage string) (bool, error) {
+	start := timemodule.Now()
+
+	result, err := s.ScheduledConfigChangeStore.UpdateStatus(id, oldStatus, newStatus, errorMessage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledConfigChangeStore.UpdateStatus", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.SchemeStore.CountByScope(scope)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.CountByScope", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.CountWithoutPermission", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
+	start := timemodule.Now()
+
+	result, err := s.SchemeStore.Delete(schemeID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
+	start := timemodule.Now()
+
+	result, err := s.SchemeStore.Get(schemeID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+	start := timemodule.Now()
+
+	result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetAllPage", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+	start := timemodule.Now()
+
+	result, err := s.SchemeStore.GetByName(schemeName)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetByName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSchemeStore) PermanentDeleteAll() error {
+	start := timemodule.Now()
+
+	err := s.SchemeStore.PermanentDeleteAll()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.PermanentDeleteAll", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+	start := timemodule.Now()
+
+	result, err := s.SchemeStore.Save(scheme)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.AnalyticsSessionCount()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.AnalyticsSessionCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.Cleanup(expiryTime, batchSize)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Cleanup", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) Get(ctx context.Context, sessionIDOrToken string) (*model.Session, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.Get(ctx, sessionIDOrToken)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) GetLastSessionRowCreateAt() (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.GetLastSessionRowCreateAt()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetLastSessionRowCreateAt", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) GetSessions(userID string) ([]*model.Session, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.GetSessions(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsExpired", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsWithActiveDeviceIds", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.PermanentDeleteSessionsByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) Remove(sessionIDOrToken string) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.Remove(sessionIDOrToken)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Remove", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) RemoveAllSessions() error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.RemoveAllSessions()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.RemoveAllSessions", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.Save(session)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateDeviceId", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateExpiredNotify", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) UpdateExpiresAt(sessionID string, time int64) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.UpdateExpiresAt(sessionID, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateExpiresAt", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionID string, time int64) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.UpdateLastActivityAt(sessionID, time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateLastActivityAt", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) error {
+	start := timemodule.Now()
+
+	err := s.SessionStore.UpdateProps(session)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateProps", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+	start := timemodule.Now()
+
+	result, err := s.SessionStore.UpdateRoles(userID, roles)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateRoles", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) Delete(channelId string) (bool, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.Delete(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) DeleteRemote(remoteId string) (bool, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.DeleteRemote(remoteId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.DeleteRemote", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) Get(channelId string) (*model.SharedChannel, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.Get(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetAllCount(opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetAllCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetAttachment(fileId string, remoteId string) (*model.SharedChannelAttachment, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetAttachment(fileId, remoteId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetAttachment", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetRemote(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemote", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetRemoteByIds(channelId string, remoteId string) (*model.SharedChannelRemote, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetRemoteByIds(channelId, remoteId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemoteByIds", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetRemoteForUser(remoteId string, userId string) (*model.RemoteCluster, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetRemoteForUser(remoteId, userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemoteForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetRemotes(opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemotes", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.SharedChannelRemoteStatus, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetRemotesStatus(channelId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemotesStatus", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetSingleUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetUsersForSync(filter)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetUsersForSync", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.GetUsersForUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetUsersForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.HasChannel(channelID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.HasChannel", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) HasRemote(channelID string, remoteId string) (bool, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.HasRemote(channelID, remoteId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.HasRemote", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.Save(sc)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.SaveAttachment(remote)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveAttachment", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.SaveRemote(remote)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveRemote", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.SaveUser(remote)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.Update(sc)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
+	start := timemodule.Now()
+
+	err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateAttachmentLastSyncAt", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.UpdateRemote(remote)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemote", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+	start := timemodule.Now()
+
+	err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteCursor", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
+	start := timemodule.Now()
+
+	err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateUserLastSyncAt", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
+	start := timemodule.Now()
+
+	result, err := s.SharedChannelStore.UpsertAttachment(remote)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpsertAttachment", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerStatusStore) Get(userID string) (*model.Status, error) {
+	start := timemodule.Now()
+
+	result, err := s.StatusStore.Get(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+	start := timemodule.Now()
+
+	result, err := s.StatusStore.GetByIds(userIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetByIds", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+	start := timemodule.Now()
+
+	result, err := s.StatusStore.GetTotalActiveUsersCount()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetTotalActiveUsersCount", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerStatusStore) ResetAll() error {
+	start := timemodule.Now()
+
+	err := s.StatusStore.ResetAll()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.ResetAll", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+	start := timemodule.Now()
+
+	err := s.StatusStore.SaveOrUpdate(status)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.SaveOrUpdate", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+	start := timemodule.Now()
+
+	result, err := s.StatusStore.UpdateExpiredDNDStatuses()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateExpiredDNDStatuses", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
+	start := timemodule.Now()
+
+	err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateLastActivityAt", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSystemStore) Get() (model.StringMap, error) {
+	start := timemodule.Now()
+
+	result, err := s.SystemStore.Get()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, error) {
+	start := timemodule.Now()
+
+	result, err := s.SystemStore.GetByName(name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetByName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+	start := timemodule.Now()
+
+	result, err := s.SystemStore.InsertIfExists(system)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.InsertIfExists", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+	start := timemodule.Now()
+
+	result, err := s.SystemStore.PermanentDeleteByName(name)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.PermanentDeleteByName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSystemStore) Save(system *model.System) error {
+	start := timemodule.Now()
+
+	err := s.SystemStore.Save(system)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Save", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) error {
+	start := timemodule.Now()
+
+	err := s.SystemStore.SaveOrUpdate(system)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdate", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+	start := timemodule.Now()
+
+	err := s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdateWithWarnMetricHandling", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSystemStore) Update(system *model.System) error {
+	start := timemodule.Now()
+
+	err := s.SystemStore.Update(system)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7252,15 +9424,15 @@ func (s *TimerLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Update", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
+func (s *TimerLayerTablePartitionStore) CreatePartition(partition *model.TablePartition) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.Update(sc)
+	err := s.TablePartitionStore.CreatePartition(partition)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7268,15 +9440,15 @@ func (s *TimerLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.S
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TablePartitionStore.CreatePartition", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
+func (s *TimerLayerTablePartitionStore) DropPartition(partition *model.TablePartition) error {
 	start := timemodule.Now()
 
-	err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
+	err := s.TablePartitionStore.DropPartition(partition)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7284,15 +9456,15 @@ func (s *TimerLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syn
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateAttachmentLastSyncAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TablePartitionStore.DropPartition", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+func (s *TimerLayerTablePartitionStore) GetPartitions(table string) ([]*model.TablePartition, error) {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.UpdateRemote(remote)
+	result, err := s.TablePartitionStore.GetPartitions(table)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7300,15 +9472,15 @@ func (s *TimerLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelR
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemote", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TablePartitionStore.GetPartitions", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+func (s *TimerLayerTablePartitionStore) HasRetainedRows(partition *model.TablePartition, now int64, globalPolicyEndTime int64) (bool, error) {
 	start := timemodule.Now()
 
-	err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
+	result, err := s.TablePartitionStore.HasRetainedRows(partition, now, globalPolicyEndTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7316,15 +9488,15 @@ func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor mode
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteCursor", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TablePartitionStore.HasRetainedRows", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
+func (s *TimerLayerTablePartitionStore) IsPartitioned(table string) (bool, error) {
 	start := timemodule.Now()
 
-	err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
+	result, err := s.TablePartitionStore.IsPartitioned(table)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7332,15 +9504,15 @@ func (s *TimerLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, chann
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateUserLastSyncAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TablePartitionStore.IsPartitioned", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
+func (s *TimerLayerTablePartitionStore) Partition(table string, boundary int64) error {
 	start := timemodule.Now()
 
-	result, err := s.SharedChannelStore.UpsertAttachment(remote)
+	err := s.TablePartitionStore.Partition(table, boundary)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7348,15 +9520,15 @@ func (s *TimerLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChan
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpsertAttachment", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TablePartitionStore.Partition", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerStatusStore) Get(userID string) (*model.Status, error) {
+func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.StatusStore.Get(userID)
+	result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7364,15 +9536,15 @@ func (s *TimerLayerStatusStore) Get(userID string) (*model.Status, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsGetTeamCountForScheme", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+func (s *TimerLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.StatusStore.GetByIds(userIds)
+	result, err := s.TeamStore.AnalyticsTeamCount(opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7380,15 +9552,15 @@ func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsTeamCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() error {
 	start := timemodule.Now()
 
-	result, err := s.StatusStore.GetTotalActiveUsersCount()
+	err := s.TeamStore.ClearAllCustomRoleAssignments()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7396,31 +9568,30 @@ func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetTotalActiveUsersCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearAllCustomRoleAssignments", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerStatusStore) ResetAll() error {
+func (s *TimerLayerTeamStore) ClearCaches() {
 	start := timemodule.Now()
 
-	err := s.StatusStore.ResetAll()
+	s.TeamStore.ClearCaches()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.ResetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearCaches", success, elapsed)
 	}
-	return err
 }
 
-func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+func (s *TimerLayerTeamStore) Get(id string) (*model.Team, error) {
 	start := timemodule.Now()
 
-	err := s.StatusStore.SaveOrUpdate(status)
+	result, err := s.TeamStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7428,15 +9599,15 @@ func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Get", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+func (s *TimerLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.StatusStore.UpdateExpiredDNDStatuses()
+	result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7444,15 +9615,15 @@ func (s *TimerLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateExpiredDNDStatuses", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetActiveMemberCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
+func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
+	result, err := s.TeamStore.GetAll()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7460,15 +9631,15 @@ func (s *TimerLayerStatusStore) UpdateLastActivityAt(userID string, lastActivity
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateLastActivityAt", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAll", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSystemStore) Get() (model.StringMap, error) {
+func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
 	start := timemodule.Now()
 
-	result, err := s.SystemStore.Get()
+	result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7476,15 +9647,15 @@ func (s *TimerLayerSystemStore) Get() (model.StringMap, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllForExportAfter", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, error) {
+func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.SystemStore.GetByName(name)
+	result, err := s.TeamStore.GetAllPage(offset, limit, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7492,15 +9663,15 @@ func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.SystemStore.InsertIfExists(system)
+	result, err := s.TeamStore.GetAllPrivateTeamListing()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7508,15 +9679,15 @@ func (s *TimerLayerSystemStore) InsertIfExists(system *model.System) (*model.Sys
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.InsertIfExists", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamListing", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.SystemStore.PermanentDeleteByName(name)
+	result, err := s.TeamStore.GetAllTeamListing()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7524,15 +9695,15 @@ func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.Syste
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.PermanentDeleteByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamListing", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerSystemStore) Save(system *model.System) error {
+func (s *TimerLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
 	start := timemodule.Now()
 
-	err := s.SystemStore.Save(system)
+	result, err := s.TeamStore.GetByInviteId(inviteID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7540,15 +9711,15 @@ func (s *TimerLayerSystemStore) Save(system *model.System) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByInviteId", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) error {
+func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, error) {
 	start := timemodule.Now()
 
-	err := s.SystemStore.SaveOrUpdate(system)
+	result, err := s.TeamStore.GetByName(name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7556,15 +9727,15 @@ func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByName", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+func (s *TimerLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	err := s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+	result, err := s.TeamStore.GetByNames(name)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7572,15 +9743,15 @@ func (s *TimerLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdateWithWarnMetricHandling", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByNames", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerSystemStore) Update(system *model.System) error {
+func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
 	start := timemodule.Now()
 
-	err := s.SystemStore.Update(system)
+	result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7588,15 +9759,15 @@ func (s *TimerLayerSystemStore) Update(system *model.System) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForAllTeams", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
+func (s *TimerLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
+	result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7604,15 +9775,15 @@ func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (i
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsGetTeamCountForScheme", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
+func (s *TimerLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.AnalyticsTeamCount(opts)
+	result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7620,15 +9791,15 @@ func (s *TimerLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsTeamCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetCommonTeamIDsForTwoUsers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() error {
+func (s *TimerLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	err := s.TeamStore.ClearAllCustomRoleAssignments()
+	result, err := s.TeamStore.GetMany(ids)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7636,30 +9807,31 @@ func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearAllCustomRoleAssignments", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMany", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) ClearCaches() {
+func (s *TimerLayerTeamStore) GetMember(ctx context.Context, teamID string, userID string) (*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	s.TeamStore.ClearCaches()
+	result, err := s.TeamStore.GetMember(ctx, teamID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearCaches", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMember", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) Get(id string) (*model.Team, error) {
+func (s *TimerLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.Get(id)
+	result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7667,15 +9839,15 @@ func (s *TimerLayerTeamStore) Get(id string) (*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+func (s *TimerLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
+	result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7683,15 +9855,15 @@ func (s *TimerLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetActiveMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersByIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) GetSoftDeletedIds(before int64, limit int) ([]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetAll()
+	result, err := s.TeamStore.GetSoftDeletedIds(before, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7699,15 +9871,15 @@ func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetSoftDeletedIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
+func (s *TimerLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
+	result, err := s.TeamStore.GetTeamMembersForExport(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7715,15 +9887,15 @@ func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllForExportAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamMembersForExport", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetAllPage(offset, limit, opts)
+	result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7731,15 +9903,15 @@ func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int, opts *model.Team
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPage", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByScheme", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetAllPrivateTeamListing()
+	result, err := s.TeamStore.GetTeamsByUserId(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7747,15 +9919,15 @@ func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamListing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByUserId", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) GetTeamsForUser(ctx context.Context, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetAllTeamListing()
+	result, err := s.TeamStore.GetTeamsForUser(ctx, userID, excludeTeamID, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7763,15 +9935,15 @@ func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamListing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
+func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetByInviteId(inviteID)
+	result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7779,15 +9951,15 @@ func (s *TimerLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByInviteId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUserWithPagination", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, error) {
+func (s *TimerLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetByName(name)
+	result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7795,15 +9967,15 @@ func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTotalMemberCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetByNames(name)
+	result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7811,15 +9983,15 @@ func (s *TimerLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByNames", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetUserTeamIds", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
+func (s *TimerLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
+	result, err := s.TeamStore.GroupSyncedTeamCount()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7827,31 +9999,30 @@ func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForAllTeams", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GroupSyncedTeamCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
+func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
+	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if err == nil {
+		if true {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.InvalidateAllTeamIdsForUser", success, elapsed)
 	}
-	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
+func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
+	result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7859,15 +10030,15 @@ func (s *TimerLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUs
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetCommonTeamIDsForTwoUsers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.MigrateTeamMembers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) PermanentDelete(teamID string) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetMany(ids)
+	err := s.TeamStore.PermanentDelete(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7875,15 +10046,15 @@ func (s *TimerLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMany", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.PermanentDelete", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) GetMember(ctx context.Context, teamID string, userID string) (*model.TeamMember, error) {
+func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetMember(ctx, teamID, userID)
+	err := s.TeamStore.RemoveAllMembersByTeam(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7891,15 +10062,15 @@ func (s *TimerLayerTeamStore) GetMember(ctx context.Context, teamID string, user
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByTeam", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userID string) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
+	err := s.TeamStore.RemoveAllMembersByUser(userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7907,15 +10078,15 @@ func (s *TimerLayerTeamStore) GetMembers(teamID string, offset int, limit int, t
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+func (s *TimerLayerTeamStore) RemoveMember(teamID string, userID string) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
+	err := s.TeamStore.RemoveMember(teamID, userID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7923,15 +10094,15 @@ func (s *TimerLayerTeamStore) GetMembersByIds(teamID string, userIds []string, r
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMember", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
+func (s *TimerLayerTeamStore) RemoveMembers(teamID string, userIds []string) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetTeamMembersForExport(userID)
+	err := s.TeamStore.RemoveMembers(teamID, userIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7939,15 +10110,15 @@ func (s *TimerLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.T
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamMembersForExport", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMembers", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) ResetAllTeamSchemes() error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
+	err := s.TeamStore.ResetAllTeamSchemes()
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7955,15 +10126,15 @@ func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limi
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByScheme", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ResetAllTeamSchemes", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
+func (s *TimerLayerTeamStore) Restore(teamID string) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetTeamsByUserId(userID)
+	err := s.TeamStore.Restore(teamID)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7971,15 +10142,15 @@ func (s *TimerLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByUserId", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Restore", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) GetTeamsForUser(ctx context.Context, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
+func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetTeamsForUser(ctx, userID, excludeTeamID, includeDeleted)
+	result, err := s.TeamStore.Save(team)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7987,15 +10158,15 @@ func (s *TimerLayerTeamStore) GetTeamsForUser(ctx context.Context, userID string
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
+func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
+	result, err := s.TeamStore.SaveMember(member, maxUsersPerTeam)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8003,15 +10174,15 @@ func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userID string, page
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUserWithPagination", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMember", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+func (s *TimerLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
+	result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8019,15 +10190,15 @@ func (s *TimerLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *m
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTotalMemberCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMultipleMembers", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
+func (s *TimerLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
+	result, err := s.TeamStore.SearchAll(opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8035,15 +10206,15 @@ func (s *TimerLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetUserTeamIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAll", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
+func (s *TimerLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.GroupSyncedTeamCount()
+	result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8051,30 +10222,31 @@ func (s *TimerLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GroupSyncedTeamCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAllPaged", success, elapsed)
 	}
-	return result, err
+	return result, resultVar1, err
 }
 
-func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
+func (s *TimerLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
+	result, err := s.TeamStore.SearchOpen(opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if true {
+		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.InvalidateAllTeamIdsForUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchOpen", success, elapsed)
 	}
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
+func (s *TimerLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
+	result, err := s.TeamStore.SearchPrivate(opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8082,15 +10254,15 @@ func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID s
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.MigrateTeamMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchPrivate", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) PermanentDelete(teamID string) error {
+func (s *TimerLayerTeamStore) SoftDelete(teamID string, deleteAt int64) error {
 	start := timemodule.Now()
 
-	err := s.TeamStore.PermanentDelete(teamID)
+	err := s.TeamStore.SoftDelete(teamID, deleteAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8098,15 +10270,15 @@ func (s *TimerLayerTeamStore) PermanentDelete(teamID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.PermanentDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SoftDelete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
+func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
 	start := timemodule.Now()
 
-	err := s.TeamStore.RemoveAllMembersByTeam(teamID)
+	result, err := s.TeamStore.Update(team)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8114,15 +10286,15 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByTeam", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Update", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userID string) error {
+func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
 	start := timemodule.Now()
 
-	err := s.TeamStore.RemoveAllMembersByUser(userID)
+	err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8130,15 +10302,15 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateLastTeamIconUpdate", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerTeamStore) RemoveMember(teamID string, userID string) error {
+func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	err := s.TeamStore.RemoveMember(teamID, userID)
+	result, err := s.TeamStore.UpdateMember(member)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8146,15 +10318,15 @@ func (s *TimerLayerTeamStore) RemoveMember(teamID string, userID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMember", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) RemoveMembers(teamID string, userIds []string) error {
+func (s *TimerLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
 	start := timemodule.Now()
 
-	err := s.TeamStore.RemoveMembers(teamID, userIds)
+	err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8162,15 +10334,15 @@ func (s *TimerLayerTeamStore) RemoveMembers(teamID string, userIds []string) err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMembersRole", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerTeamStore) ResetAllTeamSchemes() error {
+func (s *TimerLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
 	start := timemodule.Now()
 
-	err := s.TeamStore.ResetAllTeamSchemes()
+	result, err := s.TeamStore.UpdateMultipleMembers(members)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8178,15 +10350,15 @@ func (s *TimerLayerTeamStore) ResetAllTeamSchemes() error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ResetAllTeamSchemes", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMultipleMembers", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+func (s *TimerLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.Save(team)
+	result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8194,15 +10366,15 @@ func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UserBelongsToTeams", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+func (s *TimerLayerTeamInviteLinkStore) Get(id string) (*model.TeamInviteLink, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+	result, err := s.TeamInviteLinkStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8210,15 +10382,15 @@ func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTe
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamInviteLinkStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+func (s *TimerLayerTeamInviteLinkStore) GetForTeam(teamID string, includeDeleted bool) ([]*model.TeamInviteLink, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+	result, err := s.TeamInviteLinkStore.GetForTeam(teamID, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8226,15 +10398,15 @@ func (s *TimerLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, m
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMultipleMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamInviteLinkStore.GetForTeam", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *TimerLayerTeamInviteLinkStore) Reject(id string) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.SearchAll(opts)
+	err := s.TeamInviteLinkStore.Reject(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8242,15 +10414,15 @@ func (s *TimerLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamInviteLinkStore.Reject", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
+func (s *TimerLayerTeamInviteLinkStore) Release(id string) error {
 	start := timemodule.Now()
 
-	result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
+	err := s.TeamInviteLinkStore.Release(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8258,15 +10430,15 @@ func (s *TimerLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.T
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAllPaged", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamInviteLinkStore.Release", success, elapsed)
 	}
-	return result, resultVar1, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *TimerLayerTeamInviteLinkStore) Save(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.SearchOpen(opts)
+	result, err := s.TeamInviteLinkStore.Save(link)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8274,15 +10446,15 @@ func (s *TimerLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team,
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchOpen", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamInviteLinkStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
+func (s *TimerLayerTeamInviteLinkStore) Update(link *model.TeamInviteLink) (*model.TeamInviteLink, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.SearchPrivate(opts)
+	result, err := s.TeamInviteLinkStore.Update(link)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8290,15 +10462,15 @@ func (s *TimerLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Te
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchPrivate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamInviteLinkStore.Update", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+func (s *TimerLayerTeamInviteLinkStore) Use(id string, now int64) error {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.Update(team)
+	err := s.TeamInviteLinkStore.Use(id, now)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8306,15 +10478,15 @@ func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamInviteLinkStore.Use", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
+func (s *TimerLayerTeamTemplateStore) Delete(id string, deleteAt int64) error {
 	start := timemodule.Now()
 
-	err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
+	err := s.TeamTemplateStore.Delete(id, deleteAt)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8322,15 +10494,15 @@ func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime in
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateLastTeamIconUpdate", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamTemplateStore.Delete", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+func (s *TimerLayerTeamTemplateStore) Get(id string) (*model.TeamTemplate, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.UpdateMember(member)
+	result, err := s.TeamTemplateStore.Get(id)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8338,15 +10510,15 @@ func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.Tea
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMember", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamTemplateStore.Get", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+func (s *TimerLayerTeamTemplateStore) GetAll(offset int, limit int) ([]*model.TeamTemplate, error) {
 	start := timemodule.Now()
 
-	err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
+	result, err := s.TeamTemplateStore.GetAll(offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8354,15 +10526,15 @@ func (s *TimerLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMembersRole", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamTemplateStore.GetAll", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+func (s *TimerLayerTeamTemplateStore) Save(template *model.TeamTemplate) (*model.TeamTemplate, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.UpdateMultipleMembers(members)
+	result, err := s.TeamTemplateStore.Save(template)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8370,15 +10542,15 @@ func (s *TimerLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMultipleMembers", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamTemplateStore.Save", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
+func (s *TimerLayerTeamTemplateStore) Update(template *model.TeamTemplate) (*model.TeamTemplate, error) {
 	start := timemodule.Now()
 
-	result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
+	result, err := s.TeamTemplateStore.Update(template)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -8386,7 +10558,7 @@ func (s *TimerLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UserBelongsToTeams", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamTemplateStore.Update", success, elapsed)
 	}
 	return result, err
 }
@@ -8663,6 +10835,22 @@ func (s *TimerLayerThreadStore) GetTotalUnreadThreads(userId string, teamID stri
 	return result, err
 }
 
+func (s *TimerLayerThreadStore) GetUnreadThreadsForUser(userID string) ([]*model.ThreadUnread, error) {
+	start := timemodule.Now()
+
+	result, err := s.ThreadStore.GetUnreadThreadsForUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.GetUnreadThreadsForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerThreadStore) MaintainMembership(userID string, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
 	start := timemodule.Now()
 
@@ -9461,6 +11649,22 @@ func (s *TimerLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limi
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetNonBotUsers(offset int, limit int) ([]*model.User, error) {
+	start := timemodule.Now()
+
+	result, err := s.UserStore.GetNonBotUsers(offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetNonBotUsers", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
 	start := timemodule.Now()
 
@@ -9810,6 +12014,22 @@ func (s *TimerLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
 	return result, err
 }
 
+func (s *TimerLayerUserStore) MergeInto(sourceUserID string, targetUserID string) (*model.UserMergeCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.UserStore.MergeInto(sourceUserID, targetUserID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.MergeInto", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) PermanentDelete(userID string) error {
 	start := timemodule.Now()
 
@@ -10322,6 +12542,54 @@ func (s *TimerLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error
 	return err
 }
 
+func (s *TimerLayerUserDeviceStore) GetForUser(userID string) ([]*model.UserDevice, error) {
+	start := timemodule.Now()
+
+	result, err := s.UserDeviceStore.GetForUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeviceStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerUserDeviceStore) PermanentDeleteByUser(userID string) error {
+	start := timemodule.Now()
+
+	err := s.UserDeviceStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeviceStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerUserDeviceStore) Save(device *model.UserDevice) error {
+	start := timemodule.Now()
+
+	err := s.UserDeviceStore.Save(device)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserDeviceStore.Save", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceId string) error {
 	start := timemodule.Now()
 
@@ -10844,39 +13112,61 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChangeEventStore = &TimerLayerChangeEventStore{ChangeEventStore: childStore.ChangeEvent(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &TimerLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
+	newStore.ChannelEventStore = &TimerLayerChannelEventStore{ChannelEventStore: childStore.ChannelEvent(), Root: &newStore}
+	newStore.ChannelJoinRequestStore = &TimerLayerChannelJoinRequestStore{ChannelJoinRequestStore: childStore.ChannelJoinRequest(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ChannelMemberTimeoutStore = &TimerLayerChannelMemberTimeoutStore{ChannelMemberTimeoutStore: childStore.ChannelMemberTimeout(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &TimerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &TimerLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &TimerLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ConnectivityTestResultStore = &TimerLayerConnectivityTestResultStore{ConnectivityTestResultStore: childStore.ConnectivityTestResult(), Root: &newStore}
+	newStore.DirectMessageRequestStore = &TimerLayerDirectMessageRequestStore{DirectMessageRequestStore: childStore.DirectMessageRequest(), Root: &newStore}
 	newStore.EmojiStore = &TimerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MfaBackupCodeStore = &TimerLayerMfaBackupCodeStore{MfaBackupCodeStore: childStore.MfaBackupCode(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OnboardingTaskStore = &TimerLayerOnboardingTaskStore{OnboardingTaskStore: childStore.OnboardingTask(), Root: &newStore}
+	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PersistentWebSocketEventStore = &TimerLayerPersistentWebSocketEventStore{PersistentWebSocketEventStore: childStore.PersistentWebSocketEvent(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostArchiveStore = &TimerLayerPostArchiveStore{PostArchiveStore: childStore.PostArchive(), Root: &newStore}
+	newStore.PostPropSchemaStore = &TimerLayerPostPropSchemaStore{PostPropSchemaStore: childStore.PostPropSchema(), Root: &newStore}
+	newStore.PostReportStore = &TimerLayerPostReportStore{PostReportStore: childStore.PostReport(), Root: &newStore}
+	newStore.PostRetentionLabelStore = &TimerLayerPostRetentionLabelStore{PostRetentionLabelStore: childStore.PostRetentionLabel(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &TimerLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.PushNotificationReceiptStore = &TimerLayerPushNotificationReceiptStore{PushNotificationReceiptStore: childStore.PushNotificationReceipt(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.ReminderStore = &TimerLayerReminderStore{ReminderStore: childStore.Reminder(), Root: &newStore}
 	newStore.RemoteClusterStore = &TimerLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &TimerLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledConfigChangeStore = &TimerLayerScheduledConfigChangeStore{ScheduledConfigChangeStore: childStore.ScheduledConfigChange(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.SharedChannelStore = &TimerLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
 	newStore.StatusStore = &TimerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.SystemStore = &TimerLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TablePartitionStore = &TimerLayerTablePartitionStore{TablePartitionStore: childStore.TablePartition(), Root: &newStore}
 	newStore.TeamStore = &TimerLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TeamInviteLinkStore = &TimerLayerTeamInviteLinkStore{TeamInviteLinkStore: childStore.TeamInviteLink(), Root: &newStore}
+	newStore.TeamTemplateStore = &TimerLayerTeamTemplateStore{TeamTemplateStore: childStore.TeamTemplate(), Root: &newStore}
 	newStore.TermsOfServiceStore = &TimerLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
 	newStore.ThreadStore = &TimerLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
 	newStore.TokenStore = &TimerLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
 	newStore.UploadSessionStore = &TimerLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
 	newStore.UserStore = &TimerLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &TimerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserDeviceStore = &TimerLayerUserDeviceStore{UserDeviceStore: childStore.UserDevice(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &TimerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
 	newStore.WebhookStore = &TimerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
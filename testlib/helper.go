@@ -107,7 +107,7 @@ func (h *MainHelper) setupStore(withReadReplica bool) {
 	h.SQLStore = sqlstore.New(*h.Settings, nil)
 	h.Store = searchlayer.NewSearchLayer(&TestStore{
 		h.SQLStore,
-	}, h.SearchEngine, config)
+	}, h.SearchEngine, config, nil)
 }
 
 func (h *MainHelper) ToggleReplicasOff() {
@@ -183,6 +183,70 @@ func (c *Context) MfaRequired() {
 	}
 }
 
+// ImpersonationReadOnly denies the requests of impersonators which could change data.
+func (c *Context) ImpersonationReadOnly(r *http.Request) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return
+	}
+
+	// Special case to let impersonators end their session
+	subpath, _ := utils.GetSubpathFromConfig(c.App.Config())
+	if c.AppContext.Path() == path.Join(subpath, "/api/v4/users/logout") {
+		return
+	}
+
+	c.Err = model.NewAppError("ImpersonationReadOnly", "api.context.impersonation_read_only.app_error", nil, "", http.StatusForbidden)
+}
+
+// DegradedModeReadOnly denies the requests which could change data while the server runs in
+// degraded mode, except those of system admins so that they can fix the configuration.
+func (c *Context) DegradedModeReadOnly(r *http.Request) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return
+	}
+
+	// Users can still log in and out
+	subpath, _ := utils.GetSubpathFromConfig(c.App.Config())
+	switch c.AppContext.Path() {
+	case path.Join(subpath, "/api/v4/users/login"), path.Join(subpath, "/api/v4/users/logout"):
+		return
+	}
+
+	if c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		return
+	}
+
+	c.Err = model.NewAppError("DegradedModeReadOnly", "api.context.degraded_mode_read_only.app_error", nil, "", http.StatusServiceUnavailable)
+}
+
+// LogImpersonatedRequest audits a request made through an impersonation session.
+func (c *Context) LogImpersonatedRequest(r *http.Request, handlerName string) {
+	session := c.AppContext.Session()
+
+	auditRec := c.MakeAuditRecord("impersonatedRequest", audit.Success)
+	auditRec.AddMeta("impersonator_id", session.Props[model.SessionPropImpersonatorId])
+	auditRec.AddMeta("impersonation_id", session.Props[model.SessionPropImpersonationId])
+	auditRec.AddMeta("method", r.Method)
+	auditRec.AddMeta("handler", handlerName)
+	c.LogAuditRec(auditRec)
+}
+
+// LogSensitiveRequest audits a request to a sensitive route, with the digest of its body and
+// its response code, whether the handler audited it or not.
+func (c *Context) LogSensitiveRequest(r *http.Request, handlerName, bodyDigest string, bodySize int, bodySanitized bool, statusCode int) {
+	auditRec := c.MakeAuditRecord("sensitiveRequest", audit.Success)
+	auditRec.AddMeta("method", r.Method)
+	auditRec.AddMeta("handler", handlerName)
+	auditRec.AddMeta("body_sha256", bodyDigest)
+	auditRec.AddMeta("body_size", bodySize)
+	auditRec.AddMeta("body_sanitized", bodySanitized)
+	auditRec.AddMeta("status_code", statusCode)
+	if statusCode >= http.StatusBadRequest {
+		auditRec.Fail()
+	}
+	c.LogAuditRec(auditRec)
+}
+
 // ExtendSessionExpiryIfNeeded will update Session.ExpiresAt based on session lengths in config.
 // Session cookies will be resent to the client with updated max age.
 func (c *Context) ExtendSessionExpiryIfNeeded(w http.ResponseWriter, r *http.Request) {
@@ -285,6 +349,28 @@ func (c *Context) SetPermissionError(permissions ...*model.Permission) {
 	c.Err = c.App.MakePermissionError(c.AppContext.Session(), permissions)
 }
 
+// PrepareBulkItemError prepares the error of an item of a batch response the way the error of a
+// whole request is before being written: translated, and without its details unless in developer
+// mode.
+func (c *Context) PrepareBulkItemError(appErr *model.AppError) {
+	appErr.Translate(c.AppContext.T)
+	appErr.RequestId = c.AppContext.RequestId()
+	c.LogErrorByCode(appErr)
+
+	if !*c.App.Config().ServiceSettings.EnableDeveloper {
+		appErr.DetailedError = ""
+	}
+
+	if *c.App.Config().ServiceSettings.ExperimentalEnableHardenedMode && appErr.StatusCode >= 500 {
+		appErr.Id = ""
+		appErr.Message = "Internal Server Error"
+		appErr.DetailedError = ""
+		appErr.StatusCode = 500
+		appErr.Where = ""
+		appErr.IsOAuth = false
+	}
+}
+
 func (c *Context) SetSiteURLHeader(url string) {
 	c.siteURLHeader = strings.TrimRight(url, "/")
 }
@@ -489,6 +575,94 @@ func (c *Context) RequireReportId() *Context {
 	return c
 }
 
+func (c *Context) RequireReminderId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.ReminderId) {
+		c.SetInvalidURLParam("reminder_id")
+	}
+	return c
+}
+
+func (c *Context) RequireBookmarkId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.BookmarkId) {
+		c.SetInvalidURLParam("bookmark_id")
+	}
+	return c
+}
+
+func (c *Context) RequireEventId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.EventId) {
+		c.SetInvalidURLParam("event_id")
+	}
+	return c
+}
+
+func (c *Context) RequireJoinRequestId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.JoinRequestId) {
+		c.SetInvalidURLParam("join_request_id")
+	}
+	return c
+}
+
+func (c *Context) RequireInviteLinkId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.InviteLinkId) {
+		c.SetInvalidURLParam("invite_link_id")
+	}
+	return c
+}
+
+func (c *Context) RequireConfigChangeId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.ConfigChangeId) {
+		c.SetInvalidURLParam("config_change_id")
+	}
+	return c
+}
+
+func (c *Context) RequireOutgoingOAuthConnectionId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.OutgoingOAuthConnectionId) {
+		c.SetInvalidURLParam("outgoing_oauth_connection_id")
+	}
+	return c
+}
+
+func (c *Context) RequireNamespace() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidPostPropSchemaNamespace(c.Params.Namespace) {
+		c.SetInvalidURLParam("namespace")
+	}
+	return c
+}
+
 func (c *Context) RequireEmojiId() *Context {
 	if c.Err != nil {
 		return c
@@ -620,6 +794,39 @@ func (c *Context) RequireJobId() *Context {
 	return c
 }
 
+func (c *Context) RequireTeamTemplateId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.TeamTemplateId) {
+		c.SetInvalidURLParam("team_template_id")
+	}
+	return c
+}
+
+func (c *Context) RequireOnboardingTaskId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidOnboardingTaskId(c.Params.OnboardingTaskId) {
+		c.SetInvalidURLParam("onboarding_task_id")
+	}
+	return c
+}
+
+func (c *Context) RequireImpersonationId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.ImpersonationId) != model.TokenSize {
+		c.SetInvalidURLParam("impersonation_id")
+	}
+	return c
+}
+
 func (c *Context) RequireJobType() *Context {
 	if c.Err != nil {
 		return c
@@ -82,6 +82,8 @@ type Handler struct {
 	IsStatic                  bool
 	IsLocal                   bool
 	DisableWhenBusy           bool
+	// WriteError writes the errors of the handler instead of the JSON of the application errors.
+	WriteError func(w http.ResponseWriter, appErr *model.AppError)
 
 	cspShaDirective string
 }
@@ -292,6 +294,9 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				c.Logger.Warn("Invalid remote cluster token", mlog.Err(err))
 				c.Err = err
+			} else if err = c.App.VerifyRemoteClusterCertificate(remoteId, r.TLS); err != nil {
+				c.Logger.Warn("Invalid remote cluster certificate", mlog.Err(err))
+				c.Err = err
 			} else {
 				c.AppContext.SetSession(session)
 			}
@@ -314,10 +319,18 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		c.MfaRequired()
 	}
 
+	if c.Err == nil && c.AppContext.Session().IsImpersonation() {
+		c.ImpersonationReadOnly(r)
+	}
+
 	if c.Err == nil && h.DisableWhenBusy && c.App.Srv().Busy.IsBusy() {
 		c.SetServerBusyError()
 	}
 
+	if c.Err == nil && c.App.Srv().ResourceGuard.ShouldShed(h.HandlerName) {
+		c.SetServerBusyError()
+	}
+
 	if c.Err == nil && h.RequireCloudKey {
 		c.CloudKeyRequired()
 	}
@@ -337,8 +350,27 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if c.Err == nil && c.App.Srv().DependencyHealth.IsDegraded() {
+		c.DegradedModeReadOnly(r)
+	}
+
+	// Changes to sensitive routes are audited with the digest of their body, including the ones
+	// denied.
+	sensitive := isSensitiveRoute(c.App.Config(), r)
+	var bodyDigest *requestBodyDigest
+	if sensitive {
+		bodyDigest = newRequestBodyDigest(r)
+	}
+
 	if c.Err == nil {
+		endSample := c.App.Srv().ResourceGuard.SampleRequest(h.HandlerName)
 		h.HandleFunc(c, w, r)
+		endSample()
+	}
+
+	// Every request made by an impersonator is audited, including the ones denied.
+	if c.AppContext.Session().IsImpersonation() {
+		c.LogImpersonatedRequest(r, h.HandlerName)
 	}
 
 	// Handle errors that have occurred
@@ -364,7 +396,9 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			c.Err.IsOAuth = false
 		}
 
-		if IsAPICall(c.App, r) || IsWebhookCall(c.App, r) || IsOAuthAPICall(c.App, r) || r.Header.Get("X-Mobile-App") != "" {
+		if h.WriteError != nil {
+			h.WriteError(w, c.Err)
+		} else if IsAPICall(c.App, r) || IsWebhookCall(c.App, r) || IsOAuthAPICall(c.App, r) || r.Header.Get("X-Mobile-App") != "" {
 			w.WriteHeader(c.Err.StatusCode)
 			w.Write([]byte(c.Err.ToJSON()))
 		} else {
@@ -377,6 +411,13 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	statusCode = strconv.Itoa(w.(*responseWriterWrapper).StatusCode())
+	if sensitive {
+		digest, size, sanitized, err := bodyDigest.Sum()
+		if err != nil {
+			c.Logger.Warn("Unable to read the body of a sensitive request", mlog.String("handler", h.HandlerName), mlog.Err(err))
+		}
+		c.LogSensitiveRequest(r, h.HandlerName, digest, size, sanitized, w.(*responseWriterWrapper).StatusCode())
+	}
 	if c.App.Metrics() != nil {
 		c.App.Metrics().IncrementHTTPRequest()
 
@@ -42,6 +42,14 @@ type Params struct {
 	CommandId                 string
 	HookId                    string
 	ReportId                  string
+	ReminderId                string
+	BookmarkId                string
+	EventId                   string
+	JoinRequestId             string
+	InviteLinkId              string
+	ConfigChangeId            string
+	OutgoingOAuthConnectionId string
+	Namespace                 string
 	EmojiId                   string
 	AppId                     string
 	Email                     string
@@ -90,6 +98,9 @@ type Params struct {
 	ExcludePolicyConstrained  bool
 	GroupSource               model.GroupSource
 	FilterHasMember           string
+	TeamTemplateId            string
+	OnboardingTaskId          string
+	ImpersonationId           string
 
 	// Cloud
 	InvoiceId string
@@ -165,6 +176,38 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.ReportId = val
 	}
 
+	if val, ok := props["reminder_id"]; ok {
+		params.ReminderId = val
+	}
+
+	if val, ok := props["bookmark_id"]; ok {
+		params.BookmarkId = val
+	}
+
+	if val, ok := props["event_id"]; ok {
+		params.EventId = val
+	}
+
+	if val, ok := props["join_request_id"]; ok {
+		params.JoinRequestId = val
+	}
+
+	if val, ok := props["invite_link_id"]; ok {
+		params.InviteLinkId = val
+	}
+
+	if val, ok := props["config_change_id"]; ok {
+		params.ConfigChangeId = val
+	}
+
+	if val, ok := props["outgoing_oauth_connection_id"]; ok {
+		params.OutgoingOAuthConnectionId = val
+	}
+
+	if val, ok := props["namespace"]; ok {
+		params.Namespace = val
+	}
+
 	if val, ok := props["emoji_id"]; ok {
 		params.EmojiId = val
 	}
@@ -209,6 +252,18 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.JobId = val
 	}
 
+	if val, ok := props["team_template_id"]; ok {
+		params.TeamTemplateId = val
+	}
+
+	if val, ok := props["onboarding_task_id"]; ok {
+		params.OnboardingTaskId = val
+	}
+
+	if val, ok := props["impersonation_id"]; ok {
+		params.ImpersonationId = val
+	}
+
 	if val, ok := props["job_type"]; ok {
 		params.JobType = val
 	}
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// secretFieldRegexp matches the names of the fields of a JSON request body whose values are
+// blanked out before the body is hashed.
+var secretFieldRegexp = regexp.MustCompile(`(?i)(password|secret|token|salt|datasource|key)$`)
+
+// isSensitiveRoute returns true if the request changes a route matching one of the sensitive
+// route patterns, which are relative to the API root.
+func isSensitiveRoute(cfg *model.Config, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+		return false
+	}
+
+	i := strings.Index(r.URL.Path, model.APIURLSuffix+"/")
+	if i < 0 {
+		return false
+	}
+	route := strings.TrimSuffix(r.URL.Path[i+len(model.APIURLSuffix)+1:], "/")
+
+	for _, pattern := range cfg.ExperimentalAuditSettings.SensitiveRoutePatterns {
+		if matched, _ := path.Match(pattern, route); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// requestBodyDigest computes the SHA-256 digest of the body of a request and its size. Bodies of
+// up to maxSanitizedRequestBodySize bytes are read upfront, and put back for the handler, so that
+// the values of the secret fields of a JSON body are blanked out before it is hashed, preventing
+// the digest from being used to guess them, and its fields are sorted so that the digest doesn't
+// depend on their order. Larger bodies aren't held in memory and can't be sanitized, so they aren't
+// hashed at all: only the number of bytes read from them is recorded.
+type requestBodyDigest struct {
+	digest string
+	size   int
+	err    error
+
+	// streamed is set for the bodies too large to be sanitized, which are passed on to the handler
+	// as they are read.
+	streamed bool
+}
+
+// maxSanitizedRequestBodySize is the size above which the body of a request isn't hashed.
+const maxSanitizedRequestBodySize = 1024 * 1024
+
+func newRequestBodyDigest(r *http.Request) *requestBodyDigest {
+	d := &requestBodyDigest{}
+	if r.Body == nil {
+		return d
+	}
+
+	body := r.Body
+	head, err := io.ReadAll(io.LimitReader(body, maxSanitizedRequestBodySize+1))
+	d.size = len(head)
+	if err != nil || len(head) <= maxSanitizedRequestBodySize {
+		r.Body = io.NopCloser(bytes.NewReader(head))
+		d.err = err
+		if err == nil {
+			d.digest = sanitizedDigest(head)
+		}
+		return d
+	}
+
+	d.streamed = true
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), &countingReader{r: body, d: d}), body}
+	return d
+}
+
+// countingReader records the number of bytes of a streamed body read by the handler, and the
+// error the read stopped on if any.
+type countingReader struct {
+	r io.Reader
+	d *requestBodyDigest
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.d.size += n
+	if err != nil && err != io.EOF {
+		cr.d.err = err
+	}
+	return n, err
+}
+
+// Sum returns the digest of the body, its size and whether it was sanitized, once the handler is
+// done with the request. Streamed bodies have no digest and their size only covers the part read
+// upfront and by the handler: the rest isn't read once the handler has returned.
+func (d *requestBodyDigest) Sum() (digest string, size int, sanitized bool, err error) {
+	return d.digest, d.size, !d.streamed, d.err
+}
+
+func sanitizedDigest(body []byte) string {
+	content := body
+	var decoded interface{}
+	if json.Unmarshal(body, &decoded) == nil {
+		if sanitized, err := json.Marshal(sanitizeRequestBody(decoded)); err == nil {
+			content = sanitized
+		}
+	}
+
+	digest := sha256.Sum256(content)
+	return hex.EncodeToString(digest[:])
+}
+
+func sanitizeRequestBody(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if s, ok := field.(string); ok && s != "" && secretFieldRegexp.MatchString(key) {
+				v[key] = model.FakeSetting
+				continue
+			}
+			v[key] = sanitizeRequestBody(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = sanitizeRequestBody(item)
+		}
+	}
+	return value
+}
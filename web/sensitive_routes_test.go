@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestIsSensitiveRoute(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	for _, tc := range []struct {
+		method    string
+		path      string
+		sensitive bool
+	}{
+		{"PUT", "/api/v4/config", true},
+		{"PUT", "/api/v4/config/patch", true},
+		{"PUT", "/subpath/api/v4/roles/" + model.NewId() + "/patch", true},
+		{"PUT", "/api/v4/users/" + model.NewId() + "/roles", true},
+		{"PUT", "/api/v4/channels/" + model.NewId() + "/members/" + model.NewId() + "/schemeRoles", true},
+		{"GET", "/api/v4/config", false},
+		{"PUT", "/api/v4/users/" + model.NewId() + "/patch", false},
+		{"POST", "/api/v4/posts", false},
+		{"PUT", "/config", false},
+	} {
+		r := httptest.NewRequest(tc.method, tc.path, nil)
+		assert.Equal(t, tc.sensitive, isSensitiveRoute(cfg, r), tc.method+" "+tc.path)
+	}
+}
+
+func TestRequestBodyDigest(t *testing.T) {
+	digest := func(body string) (string, int) {
+		r := httptest.NewRequest("PUT", "/api/v4/config", strings.NewReader(body))
+		bodyDigest := newRequestBodyDigest(r)
+
+		read, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(read), "the body is put back for the handler")
+
+		d, size, sanitized, err := bodyDigest.Sum()
+		require.NoError(t, err)
+		assert.True(t, sanitized)
+		return d, size
+	}
+
+	d1, size := digest(`{"roles": "system_user", "password": "secret1"}`)
+	assert.Equal(t, 47, size)
+	assert.Len(t, d1, 64)
+
+	d2, _ := digest(`{"password": "secret2", "roles": "system_user"}`)
+	assert.Equal(t, d1, d2, "secrets and field order don't change the digest")
+
+	d3, _ := digest(`{"roles": "system_admin", "password": "secret1"}`)
+	assert.NotEqual(t, d1, d3)
+
+	d4, _ := digest("not json")
+	assert.Len(t, d4, 64)
+}
+
+func TestRequestBodyDigestLargeBody(t *testing.T) {
+	body := `{"password": "secret1", "data": "` + strings.Repeat("a", maxSanitizedRequestBodySize) + `"}`
+
+	t.Run("not hashed but passed on to the handler", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/api/v4/config", strings.NewReader(body))
+		bodyDigest := newRequestBodyDigest(r)
+
+		read, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(read), "the body is passed on to the handler")
+
+		d, size, sanitized, err := bodyDigest.Sum()
+		require.NoError(t, err)
+		assert.Equal(t, len(body), size)
+		assert.False(t, sanitized)
+		assert.Empty(t, d, "large bodies can't be sanitized so they aren't hashed")
+	})
+
+	t.Run("the part the handler didn't read isn't drained", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/api/v4/config", strings.NewReader(body))
+		bodyDigest := newRequestBodyDigest(r)
+
+		_, err := io.ReadFull(r.Body, make([]byte, maxSanitizedRequestBodySize+11))
+		require.NoError(t, err)
+
+		_, size, sanitized, err := bodyDigest.Sum()
+		require.NoError(t, err)
+		assert.Equal(t, maxSanitizedRequestBodySize+11, size)
+		assert.False(t, sanitized)
+	})
+
+	t.Run("the body is bounded by the max bytes reader", func(t *testing.T) {
+		r := httptest.NewRequest("PUT", "/api/v4/config", strings.NewReader(body))
+		r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, maxSanitizedRequestBodySize+10)
+		bodyDigest := newRequestBodyDigest(r)
+
+		_, err := io.ReadAll(r.Body)
+		require.Error(t, err)
+
+		_, _, _, err = bodyDigest.Sum()
+		require.Error(t, err)
+	})
+}
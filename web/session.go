@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v6/app"
+	"github.com/mattermost/mattermost-server/v6/audit"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/mattermost/mattermost-server/v6/shared/templates"
+	"github.com/mattermost/mattermost-server/v6/utils"
+)
+
+// revokeSessionCSRFCookie holds the CSRF token of the form confirming the revocation of a session,
+// which must match the one it posts.
+const revokeSessionCSRFCookie = "MMREVOKESESSIONCSRF"
+
+func (w *Web) InitSession() {
+	w.MainRouter.Handle("/login/revoke_session", w.APIHandler(confirmRevokeSessionFromLoginNotification)).Methods("GET")
+	// The form is posted without the CSRF header of the API, being checked against the cookie
+	// set along with it instead.
+	w.MainRouter.Handle("/login/revoke_session", w.APIHandlerTrustRequester(revokeSessionFromLoginNotification)).Methods("POST")
+}
+
+// confirmRevokeSessionFromLoginNotification renders the page asking the user to confirm the
+// revocation of the session of a login from the link of its notification. Opening the link alone
+// doesn't revoke the session, since mail scanners and link previews open it too.
+func confirmRevokeSessionFromLoginNotification(c *Context, w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if err := c.App.CheckLoginNotificationToken(token); err != nil {
+		utils.RenderWebAppError(c.App.Config(), w, r, err, c.App.AsymmetricSigningKey())
+		return
+	}
+
+	subpath, _ := utils.GetSubpathFromConfig(c.App.Config())
+	csrfToken := model.NewId()
+	http.SetCookie(w, &http.Cookie{
+		Name:     revokeSessionCSRFCookie,
+		Value:    csrfToken,
+		Path:     subpath,
+		HttpOnly: true,
+		Secure:   app.GetProtocol(r) == "https",
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	data := templates.Data{
+		Props: map[string]interface{}{
+			"Title":     c.AppContext.T("web.revoke_session.title"),
+			"Info":      c.AppContext.T("web.revoke_session.info"),
+			"Button":    c.AppContext.T("web.revoke_session.button"),
+			"Token":     token,
+			"CSRFToken": csrfToken,
+		},
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := c.App.Srv().TemplatesContainer().Render(w, "revoke_session", data); err != nil {
+		c.Logger.Warn("Unable to render the session revocation page", mlog.Err(err))
+	}
+}
+
+// revokeSessionFromLoginNotification revokes the session of a login from the confirmation form of
+// the link of its notification, then sends the user to the login page.
+func revokeSessionFromLoginNotification(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("revokeSessionFromLoginNotification", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	cookie, err := r.Cookie(revokeSessionCSRFCookie)
+	if err != nil || cookie.Value == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.FormValue("csrf"))) != 1 {
+		appErr := model.NewAppError("revokeSessionFromLoginNotification", "web.revoke_session.csrf.app_error", nil, "", http.StatusForbidden)
+		utils.RenderWebAppError(c.App.Config(), w, r, appErr, c.App.AsymmetricSigningKey())
+		return
+	}
+
+	if err := c.App.RevokeSessionFromLoginNotification(r.FormValue("token")); err != nil {
+		utils.RenderWebAppError(c.App.Config(), w, r, err, c.App.AsymmetricSigningKey())
+		return
+	}
+
+	subpath, _ := utils.GetSubpathFromConfig(c.App.Config())
+	http.SetCookie(w, &http.Cookie{
+		Name:     revokeSessionCSRFCookie,
+		Value:    "",
+		Path:     subpath,
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+
+	auditRec.Success()
+	http.Redirect(w, r, c.GetSiteURLHeader()+"/login", http.StatusFound)
+}
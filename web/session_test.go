@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+func TestRevokeSessionFromLoginNotification(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	session, appErr := th.App.CreateSession(&model.Session{UserId: th.BasicUser.Id})
+	require.Nil(t, appErr)
+
+	token := model.NewToken(app.TokenTypeRevokeSession, model.MapToJSON(map[string]string{
+		"user_id":    th.BasicUser.Id,
+		"session_id": session.Id,
+	}))
+	require.NoError(t, th.App.Srv().Store.Token().Save(token))
+
+	post := func(form url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/login/revoke_session", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		for _, cookie := range cookies {
+			r.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		th.Web.MainRouter.ServeHTTP(w, r)
+		return w
+	}
+
+	w := httptest.NewRecorder()
+	th.Web.MainRouter.ServeHTTP(w, httptest.NewRequest("GET", "/login/revoke_session?token="+url.QueryEscape(token.Token), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `method="post"`)
+
+	var csrfCookie *http.Cookie
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == revokeSessionCSRFCookie {
+			csrfCookie = cookie
+		}
+	}
+	require.NotNil(t, csrfCookie)
+
+	t.Run("opening the link doesn't revoke the session", func(t *testing.T) {
+		_, appErr := th.App.GetSessionById(session.Id)
+		require.Nil(t, appErr)
+
+		_, err := th.App.Srv().Store.Token().GetByToken(token.Token)
+		require.NoError(t, err)
+	})
+
+	t.Run("posting without the CSRF token is refused", func(t *testing.T) {
+		w := post(url.Values{"token": {token.Token}})
+		assert.NotEqual(t, http.StatusFound, w.Code)
+
+		w = post(url.Values{"token": {token.Token}, "csrf": {model.NewId()}}, csrfCookie)
+		assert.NotEqual(t, http.StatusFound, w.Code)
+
+		_, appErr := th.App.GetSessionById(session.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("posting the confirmation revokes the session", func(t *testing.T) {
+		w := post(url.Values{"token": {token.Token}, "csrf": {csrfCookie.Value}}, csrfCookie)
+		require.Equal(t, http.StatusFound, w.Code)
+
+		_, appErr := th.App.GetSessionById(session.Id)
+		require.NotNil(t, appErr)
+	})
+}